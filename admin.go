@@ -0,0 +1,230 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/handlers"
+)
+
+const adminUsage = `usage: sphinx-tribes admin <command>
+  list-workspaces
+  grant-role <workspace_uuid> <pubkey> <role>...
+  requeue-jobs [-status dead] [-type <type>] [job_uuid]...
+  reconcile-payments [workspace_uuid]...
+  migrate-bounties [-dry-run]`
+
+// runAdmin handles the `admin` subcommand, maintenance tasks that go
+// through the db and handlers packages instead of raw SQL or HTTP calls
+func runAdmin(args []string) {
+	if len(args) == 0 {
+		fmt.Println(adminUsage)
+		os.Exit(1)
+	}
+
+	config.InitConfig()
+	db.ConnectDB()
+
+	var err error
+	switch args[0] {
+	case "list-workspaces":
+		err = adminListWorkspaces()
+	case "grant-role":
+		err = adminGrantRole(args[1:])
+	case "requeue-jobs":
+		err = adminRequeueJobs(args[1:])
+	case "reconcile-payments":
+		err = adminReconcilePayments(args[1:])
+	case "migrate-bounties":
+		err = adminMigrateBounties(args[1:])
+	default:
+		fmt.Println(adminUsage)
+		os.Exit(1)
+	}
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
+
+func adminListWorkspaces() error {
+	workspaces := db.DB.GetWorkspaces(nil)
+	fmt.Printf("%-22s %-20s %-66s %12s  %s\n", "UUID", "NAME", "OWNER", "BUDGET", "AUTO-PAY")
+	for _, w := range workspaces {
+		budget := db.DB.GetWorkspaceBudget(w.Uuid)
+		fmt.Printf("%-22s %-20s %-66s %12d  %t\n", w.Uuid, w.Name, w.OwnerPubKey, budget.TotalBudget, w.AutoPay)
+	}
+	fmt.Printf("%d workspaces\n", len(workspaces))
+	return nil
+}
+
+// adminGrantRole adds roles to a user of a workspace, the roles they
+// already have are kept and the user is added to the workspace if needed
+func adminGrantRole(args []string) error {
+	if len(args) < 3 {
+		return errors.New(adminUsage)
+	}
+	uuid, pubkey, roles := args[0], args[1], args[2:]
+
+	workspace := db.DB.GetWorkspaceByUuid(uuid)
+	if workspace.Uuid == "" {
+		return fmt.Errorf("workspace %s not found", uuid)
+	}
+	rolesMap := db.GetRolesMap()
+	for _, role := range roles {
+		if _, ok := rolesMap[role]; !ok {
+			return fmt.Errorf("%q is not a valid user role", role)
+		}
+	}
+
+	now := time.Now()
+	if db.DB.GetWorkspaceUser(pubkey, uuid).ID == 0 {
+		db.DB.CreateWorkspaceUser(db.WorkspaceUsers{
+			OwnerPubKey:   pubkey,
+			WorkspaceUuid: uuid,
+			Created:       &now,
+			Updated:       &now,
+		})
+		fmt.Printf("added %s to %s\n", pubkey, workspace.Name)
+	}
+
+	userRoles := db.DB.GetUserRoles(uuid, pubkey)
+	existing := db.GetUserRolesMap(userRoles)
+	for _, role := range roles {
+		if _, ok := existing[role]; ok {
+			continue
+		}
+		existing[role] = role
+		userRoles = append(userRoles, db.WorkspaceUserRoles{
+			Role:          role,
+			OwnerPubKey:   pubkey,
+			WorkspaceUuid: uuid,
+			Created:       &now,
+		})
+	}
+	db.DB.CreateUserRoles(userRoles, uuid, pubkey)
+
+	for _, role := range userRoles {
+		fmt.Println(role.Role)
+	}
+	return nil
+}
+
+// adminRequeueJobs requeues the given jobs, or every job with the status
+// and type of the flags when none is given
+func adminRequeueJobs(args []string) error {
+	flags := flag.NewFlagSet("requeue-jobs", flag.ContinueOnError)
+	status := flags.String("status", string(db.JobDead), "status of the jobs to requeue")
+	jobType := flags.String("type", "", "type of the jobs to requeue")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	uuids := flags.Args()
+	if len(uuids) == 0 {
+		query := url.Values{"status": {*status}, "limit": {"-1"}}
+		if *jobType != "" {
+			query.Set("type", *jobType)
+		}
+		req, err := http.NewRequest(http.MethodGet, "/admin/jobs?"+query.Encode(), nil)
+		if err != nil {
+			return err
+		}
+		jobs, err := db.DB.GetJobs(req)
+		if err != nil {
+			return err
+		}
+		for _, job := range jobs {
+			uuids = append(uuids, job.Uuid)
+		}
+	}
+
+	requeued := 0
+	for _, uuid := range uuids {
+		job, err := db.DB.RequeueJob(uuid)
+		if err != nil {
+			fmt.Printf("%s: %s\n", uuid, err)
+			continue
+		}
+		requeued++
+		fmt.Printf("%s %s requeued\n", job.Uuid, job.Type)
+	}
+	fmt.Printf("requeued %d of %d jobs\n", requeued, len(uuids))
+	if requeued < len(uuids) {
+		return fmt.Errorf("%d jobs could not be requeued", len(uuids)-requeued)
+	}
+	return nil
+}
+
+// adminReconcilePayments checks the invoices of the given workspaces, or of
+// every workspace, with the relay like the budget polling of the app does
+func adminReconcilePayments(args []string) error {
+	uuids := args
+	if len(uuids) == 0 {
+		for _, w := range db.DB.GetWorkspaces(nil) {
+			uuids = append(uuids, w.Uuid)
+		}
+	}
+
+	workspaceHandler := handlers.NewWorkspaceHandler(db.DB)
+	failed := 0
+	for _, uuid := range uuids {
+		result, invoiceErr := workspaceHandler.ReconcileInvoices(uuid)
+		if invoiceErr.Error != "" {
+			failed++
+			fmt.Printf("%s: %s\n", uuid, invoiceErr.Error)
+			continue
+		}
+		fmt.Printf("%s: checked %d invoices, credited %d, deleted %d expired\n", uuid, result.Checked, result.Settled, result.Expired)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d workspaces could not be reconciled", failed)
+	}
+	return nil
+}
+
+// adminMigrateBounties moves the bounties left in the wanted lists of
+// profiles into the bounty table, and prints what it migrated and the rows
+// that don't match their legacy bounty
+func adminMigrateBounties(args []string) error {
+	flags := flag.NewFlagSet("migrate-bounties", flag.ContinueOnError)
+	dryRun := flags.Bool("dry-run", false, "report without writing")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	report, err := db.DB.MigrateLegacyBounties(*dryRun)
+	if err != nil {
+		return err
+	}
+
+	if report.DryRun {
+		fmt.Println("dry run, nothing was written")
+	}
+	fmt.Printf("legacy bounties:  %d\n", report.LegacyBounties)
+	fmt.Printf("migrated:         %d\n", report.Migrated)
+	fmt.Printf("already migrated: %d\n", report.AlreadyMigrated)
+	fmt.Printf("skipped:          %d\n", report.Skipped)
+	columns := make([]string, 0, len(report.Backfilled))
+	for column := range report.Backfilled {
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+	for _, column := range columns {
+		fmt.Printf("backfilled %s: %d rows\n", column, report.Backfilled[column])
+	}
+	for _, mismatch := range report.Mismatches {
+		fmt.Printf("mismatch: bounty %d of %s created %d differs on %s\n", mismatch.BountyID, mismatch.OwnerID, mismatch.Created, strings.Join(mismatch.Fields, ", "))
+	}
+	fmt.Printf("%d mismatches\n", len(report.Mismatches))
+	return nil
+}
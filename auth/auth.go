@@ -46,41 +46,44 @@ func PubKeyContext(next http.Handler) http.Handler {
 			return
 		}
 
-		isJwt := strings.Contains(token, ".") && !strings.HasPrefix(token, ".")
+		pubkey, err := PubKeyFromToken(token)
+		if err != nil {
+			fmt.Println("[auth]", err)
+			http.Error(w, http.StatusText(401), 401)
+			return
+		}
 
-		if isJwt {
-			claims, err := DecodeJwt(token)
+		ctx := context.WithValue(r.Context(), ContextKey, pubkey)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
 
-			if err != nil {
-				fmt.Println("Failed to parse JWT")
-				http.Error(w, http.StatusText(401), 401)
-				return
-			}
+// PubKeyFromToken returns the pubkey of a jwt or of a signed timestamp token
+func PubKeyFromToken(token string) (string, error) {
+	isJwt := strings.Contains(token, ".") && !strings.HasPrefix(token, ".")
 
-			if claims.VerifyExpiresAt(time.Now().UnixNano(), true) {
-				fmt.Println("Token has expired")
-				http.Error(w, http.StatusText(401), 401)
-				return
-			}
+	if isJwt {
+		claims, err := DecodeJwt(token)
+		if err != nil {
+			return "", errors.New("failed to parse JWT")
+		}
 
-			ctx := context.WithValue(r.Context(), ContextKey, claims["pubkey"])
-			next.ServeHTTP(w, r.WithContext(ctx))
-		} else {
-			pubkey, err := VerifyTribeUUID(token, true)
+		if claims.VerifyExpiresAt(time.Now().UnixNano(), true) {
+			return "", errors.New("token has expired")
+		}
 
-			if pubkey == "" || err != nil {
-				fmt.Println("[auth] no pubkey || err != nil")
-				if err != nil {
-					fmt.Println(err)
-				}
-				http.Error(w, http.StatusText(401), 401)
-				return
-			}
+		pubkey, _ := claims["pubkey"].(string)
+		return pubkey, nil
+	}
 
-			ctx := context.WithValue(r.Context(), ContextKey, pubkey)
-			next.ServeHTTP(w, r.WithContext(ctx))
-		}
-	})
+	pubkey, err := VerifyTribeUUID(token, true)
+	if err != nil {
+		return "", err
+	}
+	if pubkey == "" {
+		return "", errors.New("no pubkey in token")
+	}
+	return pubkey, nil
 }
 
 // PubKeyContext parses pukey from signed timestamp
@@ -181,6 +184,18 @@ func CypressContext(next http.Handler) http.Handler {
 	})
 }
 
+// BearerToken accepts the jwt as a standard "Authorization: Bearer" header,
+// it is copied to x-jwt for the auth middlewares when x-jwt is not set
+func BearerToken(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization := r.Header.Get("Authorization")
+		if r.Header.Get("x-jwt") == "" && len(authorization) > 7 && strings.EqualFold(authorization[:7], "bearer ") {
+			r.Header.Set("x-jwt", strings.TrimSpace(authorization[7:]))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
 func AdminCheck(pubkey string) bool {
 	for _, val := range config.SuperAdmins {
 		if val == pubkey {
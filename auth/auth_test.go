@@ -0,0 +1,76 @@
+package auth
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBearerToken(t *testing.T) {
+	var received string
+	handler := BearerToken(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Get("x-jwt")
+	}))
+
+	req, _ := http.NewRequest(http.MethodGet, "/v2/people", nil)
+	req.Header.Set("Authorization", "Bearer a.b.c")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	assert.Equal(t, "a.b.c", received)
+
+	req, _ = http.NewRequest(http.MethodGet, "/v2/people", nil)
+	req.Header.Set("Authorization", "Bearer a.b.c")
+	req.Header.Set("x-jwt", "x.y.z")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	assert.Equal(t, "x.y.z", received)
+
+	req, _ = http.NewRequest(http.MethodGet, "/v2/people", nil)
+	req.Header.Set("Authorization", "Basic dXNlcjpwYXNz")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	assert.Equal(t, "", received)
+}
+
+func TestStakworkWebhook(t *testing.T) {
+	t.Setenv("RELAY_AUTH_KEY", "relay-key")
+	t.Setenv("RELAY_URL", "http://localhost:3001")
+	defer config.InitConfig()
+	body := []byte(`{"chat_uuid": "chat-uuid"}`)
+	var received []byte
+	handler := StakworkWebhook(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received, _ = io.ReadAll(r.Body)
+	}))
+	serve := func(signature string) int {
+		req, _ := http.NewRequest(http.MethodPost, "/hivechat/response", bytes.NewReader(body))
+		if signature != "" {
+			req.Header.Set(WebhookSignature, signature)
+		}
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr.Code
+	}
+
+	t.Run("should refuse every webhook without a secret", func(t *testing.T) {
+		t.Setenv("STAKWORK_WEBHOOK_SECRET", "")
+		config.InitConfig()
+		assert.Equal(t, http.StatusUnauthorized, serve(SignWebhook("", body)))
+	})
+
+	t.Setenv("STAKWORK_WEBHOOK_SECRET", "webhook-secret")
+	config.InitConfig()
+
+	t.Run("should pass the body of a signed webhook on", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, serve(SignWebhook("webhook-secret", body)))
+		assert.Equal(t, body, received)
+	})
+
+	t.Run("should refuse a webhook without a valid signature", func(t *testing.T) {
+		received = nil
+		assert.Equal(t, http.StatusUnauthorized, serve(""))
+		assert.Equal(t, http.StatusUnauthorized, serve(SignWebhook("other-secret", body)))
+		assert.Nil(t, received)
+	})
+}
@@ -0,0 +1,53 @@
+package auth
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/stakwork/sphinx-tribes/config"
+)
+
+// WebhookSignature is the header of the HMAC of a webhook body, the same
+// header the outgoing webhooks are signed in
+const WebhookSignature = "x-hub-signature-256"
+
+// SignWebhook returns the x-hub-signature-256 value of a body
+func SignWebhook(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// StakworkWebhook lets through the webhooks of Stakwork, whose body is
+// signed with STAKWORK_WEBHOOK_SECRET. Every webhook is refused while the
+// secret is not set
+func StakworkWebhook(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		secret := config.Get().StakworkWebhookSecret
+		if secret == "" {
+			fmt.Println("[auth] STAKWORK_WEBHOOK_SECRET is not set, the webhook is refused")
+			http.Error(w, http.StatusText(401), 401)
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		r.Body.Close()
+		if err != nil {
+			http.Error(w, http.StatusText(400), 400)
+			return
+		}
+		if !hmac.Equal([]byte(r.Header.Get(WebhookSignature)), []byte(SignWebhook(secret, body))) {
+			fmt.Println("[auth] invalid webhook signature")
+			http.Error(w, http.StatusText(401), 401)
+			return
+		}
+
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		next.ServeHTTP(w, r)
+	})
+}
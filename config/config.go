@@ -12,11 +12,14 @@ import (
 	"strings"
 	"time"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 )
 
+// the startup values of Config, kept as variables for the existing callers.
+// Anything that can be reloaded is read through Get instead
 var Host string
 var JwtKey string
 var RelayUrl string
@@ -35,71 +38,68 @@ var AdminCheck string
 var AdminDevFreePass = "FREE_PASS"
 var Connection_Auth string
 var AdminStrings string
+var StakworkKey string
+var BountyDescriptionUrl string
+var HiveChatWorkflowId string
+var BriefWorkflowId string
+var StakworkProjectsUrl = "https://jobs.stakwork.com/api/v1/projects"
 
 var S3Client *s3.Client
 var PresignClient *s3.PresignClient
 
 func InitConfig() {
-	Host = os.Getenv("LN_SERVER_BASE_URL")
-	JwtKey = os.Getenv("LN_JWT_KEY")
-	RelayUrl = os.Getenv("RELAY_URL")
-	MemeUrl = os.Getenv("MEME_URL")
-	RelayAuthKey = os.Getenv("RELAY_AUTH_KEY")
+	cfg, err := Load()
+	if err != nil {
+		panic(err)
+	}
+	if cfg.JwtKey == "" {
+		cfg.JwtKey = GenerateRandomString()
+	}
+	set(cfg)
+
+	Host = cfg.Host
+	JwtKey = cfg.JwtKey
+	RelayUrl = cfg.RelayUrl
+	MemeUrl = cfg.MemeUrl
+	RelayAuthKey = cfg.RelayAuthKey
 	AdminStrings = os.Getenv("ADMINS")
-	AwsSecret := os.Getenv("AWS_SECRET_ACCESS")
-	AwsAccess := os.Getenv("AWS_ACCESS_KEY_ID")
-	AwsRegion := os.Getenv("AWS_REGION")
-	S3BucketName = os.Getenv("S3_BUCKET_NAME")
-	S3FolderName = os.Getenv("S3_FOLDER_NAME")
-	S3Url = os.Getenv("S3_URL")
-	AdminCheck = os.Getenv("ADMIN_CHECK")
-	Connection_Auth = os.Getenv("CONNECTION_AUTH")
-
-	// Add to super admins
-	SuperAdmins = StripSuperAdmins(AdminStrings)
+	S3BucketName = cfg.S3BucketName
+	S3FolderName = cfg.S3FolderName
+	S3Url = cfg.S3Url
+	AdminCheck = cfg.AdminCheck
+	Connection_Auth = cfg.ConnectionAuth
+	StakworkKey = cfg.StakworkKey
+	BountyDescriptionUrl = cfg.BountyDescriptionUrl
+	HiveChatWorkflowId = cfg.HiveChatWorkflowId
+	BriefWorkflowId = cfg.BriefWorkflowId
+	SuperAdmins = cfg.Admins
 
 	awsConfig, err := config.LoadDefaultConfig(context.TODO(),
-		config.WithRegion(AwsRegion),
-		config.WithCredentialsProvider(credentials.NewStaticCredentialsProvider(AwsAccess, AwsSecret, "")),
+		config.WithRegion(cfg.AwsRegion),
+		config.WithCredentialsProvider(credentials.NewStaticCredentialsProvider(cfg.AwsAccessKeyId, cfg.AwsSecretAccess, "")),
 	)
 
 	if err != nil {
 		fmt.Println("Could not setup AWS session", err)
 	}
 
-	// create a s3 client session
-	S3Client = s3.NewFromConfig(awsConfig)
+	// create a s3 client session, S3_ENDPOINT points it to an s3 compatible
+	// storage instead of aws
+	S3Client = s3.NewFromConfig(awsConfig, func(o *s3.Options) {
+		if cfg.S3Endpoint != "" {
+			o.BaseEndpoint = aws.String(cfg.S3Endpoint)
+			o.UsePathStyle = true
+		}
+	})
 	PresignClient = s3.NewPresignClient(S3Client)
 
-	// only make this call if there is a Relay auth key
-	if RelayAuthKey != "" {
+	// the sandbox sets the key of its node, see upstream.UseSandbox, and
+	// the key of the v2 node is V2_CONTACT_KEY
+	switch cfg.LightningBackend {
+	case LightningRelay:
 		RelayNodeKey = GetNodePubKey()
-	} else {
-		panic("No relay auth key set")
-	}
-
-	if Host == "" {
-		Host = "https://people.sphinx.chat"
-	}
-
-	if MemeUrl == "" {
-		MemeUrl = "https://memes.sphinx.chat"
-	}
-
-	if JwtKey == "" {
-		JwtKey = GenerateRandomString()
-	}
-
-	if S3BucketName == "" {
-		S3BucketName = "sphinx-tribes"
-	}
-
-	if S3FolderName == "" {
-		S3FolderName = "metrics"
-	}
-
-	if S3Url == "" {
-		S3Url = "https://sphinx-tribes.s3.amazonaws.com"
+	case LightningV2:
+		RelayNodeKey = cfg.V2ContactKey
 	}
 }
 
@@ -80,3 +80,136 @@ func TestStripSuperAdmins(t *testing.T) {
 	admins2 := StripSuperAdmins(test2Admins)
 	assert.Equal(t, len(admins2), 2)
 }
+
+func TestLoad(t *testing.T) {
+	t.Setenv("RELAY_AUTH_KEY", "relay-key")
+	t.Setenv("RELAY_URL", "http://localhost:3001")
+
+	cfg, err := Load()
+	assert.NoError(t, err)
+	assert.Equal(t, "https://people.sphinx.chat", cfg.Host)
+	assert.Equal(t, "5002", cfg.Port)
+	assert.Equal(t, 20, cfg.DbMaxOpenConns)
+	assert.Equal(t, 200, cfg.SlowQueryMs)
+	assert.Equal(t, TenantGuardLog, cfg.TenantGuard)
+	assert.Equal(t, 15, cfg.RelayTimeout)
+	assert.Equal(t, 5, cfg.UpstreamBreakerFailures)
+	assert.Equal(t, 60, cfg.SpamScoreThreshold)
+	assert.Equal(t, 5, cfg.WorkspaceCreationsPerDay)
+	assert.Equal(t, VerificationOff, cfg.WorkspaceVerification)
+	assert.Equal(t, LightningRelay, cfg.LightningBackend)
+	assert.Equal(t, map[string]int{"tribe": 10, "bounty": 10}, cfg.DedupWindows)
+
+	t.Setenv("DEDUP_WINDOWS", "bounty=0")
+	cfg, err = Load()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int{"tribe": 10, "bounty": 0}, cfg.DedupWindows)
+
+	t.Setenv("RELAY_URL", "relay")
+	t.Setenv("API_V1_SUNSET", "soon")
+	t.Setenv("TEST_MODE", "maybe")
+	_, err = Load()
+	assert.EqualError(t, err, "invalid config: API_V1_SUNSET is not a date; TEST_MODE is not a boolean; RELAY_URL is not a http(s) url")
+
+	t.Setenv("RELAY_URL", "")
+	t.Setenv("RELAY_AUTH_KEY", "")
+	t.Setenv("API_V1_SUNSET", "")
+	t.Setenv("TEST_MODE", "")
+	_, err = Load()
+	assert.EqualError(t, err, "invalid config: RELAY_AUTH_KEY is required")
+
+	t.Setenv("LIGHTNING_BACKEND", "sandbox")
+	t.Setenv("SANDBOX_FAILURES", "keysend=3")
+	cfg, err = Load()
+	assert.NoError(t, err)
+	assert.Equal(t, SandboxRelayUrl, cfg.RelayUrl)
+	assert.Equal(t, map[string]int{"keysend": 3}, cfg.SandboxFailures)
+
+	t.Setenv("QUOTA_STORAGE_MB", "free=100, pro=5000")
+	cfg, err = Load()
+	assert.NoError(t, err)
+	assert.Equal(t, "free", cfg.DefaultPlan)
+	assert.Equal(t, map[string]int{"free": 100, "pro": 5000}, cfg.QuotaStorageMb)
+	assert.Empty(t, cfg.QuotaActiveBounties)
+	assert.Equal(t, 3, cfg.SkillVerificationBounties)
+	assert.Equal(t, 30, cfg.EndorsementPairDays)
+	assert.Equal(t, 180, cfg.EndorsementHalfLifeDays)
+	assert.Equal(t, 256, cfg.UploadMaxMb)
+	assert.Equal(t, 24, cfg.UploadSessionHours)
+	assert.Contains(t, cfg.HtmlAllowedTags, "blockquote")
+	assert.Contains(t, cfg.HtmlAllowedAttributes, "a:href")
+
+	t.Setenv("SECRETS_PREVIOUS_MASTER_KEY", "c2hvcnQ=")
+	_, err = Load()
+	assert.EqualError(t, err, "invalid config: SECRETS_PREVIOUS_MASTER_KEY is not a base64 32 byte key; SECRETS_MASTER_KEY is required by SECRETS_PREVIOUS_MASTER_KEY")
+	t.Setenv("SECRETS_PREVIOUS_MASTER_KEY", "")
+
+	t.Setenv("BOUNTY_CERTIFICATE_KEY", "c2hvcnQ=")
+	_, err = Load()
+	assert.EqualError(t, err, "invalid config: BOUNTY_CERTIFICATE_KEY is not a base64 32 byte key")
+	t.Setenv("BOUNTY_CERTIFICATE_KEY", "")
+
+	t.Setenv("WORKSPACE_VERIFICATION", "contact")
+	_, err = Load()
+	assert.EqualError(t, err, "invalid config: WORKSPACE_VERIFICATION_WEBHOOK is required by the contact verification")
+	t.Setenv("WORKSPACE_VERIFICATION", "sms")
+	_, err = Load()
+	assert.EqualError(t, err, "invalid config: WORKSPACE_VERIFICATION is not off, contact or payment")
+	t.Setenv("WORKSPACE_VERIFICATION", "")
+
+	t.Setenv("LIGHTNING_BACKEND", "lnd")
+	_, err = Load()
+	assert.EqualError(t, err, "invalid config: RELAY_AUTH_KEY is required; LIGHTNING_BACKEND is not relay, sandbox or v2")
+
+	t.Setenv("LIGHTNING_BACKEND", "v2")
+	_, err = Load()
+	assert.EqualError(t, err, "invalid config: V2_BOT_URL is required by the v2 backend; V2_BOT_TOKEN is required by the v2 backend")
+
+	t.Setenv("V2_BOT_URL", "http://bot:3000")
+	t.Setenv("V2_BOT_TOKEN", "bot-token")
+	cfg, err = Load()
+	assert.NoError(t, err)
+	assert.Equal(t, V2RelayUrl, cfg.RelayUrl)
+}
+
+func TestReload(t *testing.T) {
+	defer set(Config{})
+	t.Setenv("RELAY_AUTH_KEY", "relay-key")
+	t.Setenv("GITHUB_TOKEN", "first-token")
+	cfg, err := Load()
+	assert.NoError(t, err)
+	set(cfg)
+
+	t.Setenv("FEATURE_FLAGS", "new-checkout, tickets")
+	t.Setenv("API_V1_SUNSET", "2027-01-01")
+	t.Setenv("GITHUB_TOKEN", "second-token")
+	changed, err := Reload()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"api_v1_sunset", "feature_flags"}, changed)
+	assert.True(t, FeatureEnabled("tickets"))
+	assert.False(t, FeatureEnabled("payments"))
+	assert.Equal(t, "first-token", Get().GithubToken)
+
+	t.Setenv("API_V1_SUNSET", "not a date")
+	_, err = Reload()
+	assert.Error(t, err)
+	assert.Equal(t, 2027, Get().ApiV1Sunset.Year())
+}
+
+func TestRedacted(t *testing.T) {
+	redacted := Config{Host: "https://people.sphinx.chat", RelayAuthKey: "relay-key"}.Redacted()
+	assert.Equal(t, "https://people.sphinx.chat", redacted["host"])
+	assert.Equal(t, "[redacted]", redacted["relay_auth_key"])
+	assert.Equal(t, "", redacted["stakwork_key"])
+}
+
+func TestParseCounts(t *testing.T) {
+	var errs []string
+	t.Setenv("RETENTION_DAYS", "expired_invoices=30, finished_jobs=0,")
+	assert.Equal(t, map[string]int{"expired_invoices": 30, "finished_jobs": 0}, parseCounts("RETENTION_DAYS", "days", &errs))
+	assert.Empty(t, errs)
+
+	t.Setenv("RETENTION_DAYS", "expired_invoices,finished_jobs=-1")
+	assert.Empty(t, parseCounts("RETENTION_DAYS", "days", &errs))
+	assert.Len(t, errs, 2)
+}
@@ -0,0 +1,599 @@
+package config
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/joho/godotenv"
+)
+
+// Config is the typed view of the environment. Fields tagged secret are
+// redacted from the admin view, fields tagged reload are swapped by Reload
+// while the rest only change on restart
+type Config struct {
+	Host                 string   `json:"host"`
+	Port                 string   `json:"port"`
+	JwtKey               string   `json:"jwt_key" secret:"true"`
+	RelayUrl             string   `json:"relay_url"`
+	RelayAuthKey         string   `json:"relay_auth_key" secret:"true"`
+	MemeUrl              string   `json:"meme_url"`
+	Admins               []string `json:"admins"`
+	AdminPubkeys         []string `json:"admin_pubkeys"`
+	AdminCheck           string   `json:"admin_check" secret:"true"`
+	ConnectionAuth       string   `json:"connection_auth" secret:"true"`
+	AwsRegion            string   `json:"aws_region"`
+	AwsAccessKeyId       string   `json:"aws_access_key_id" secret:"true"`
+	AwsSecretAccess      string   `json:"aws_secret_access" secret:"true"`
+	S3BucketName         string   `json:"s3_bucket_name"`
+	S3FolderName         string   `json:"s3_folder_name"`
+	S3Url                string   `json:"s3_url"`
+	S3Endpoint           string   `json:"s3_endpoint"`
+	ImageStore           string   `json:"image_store"`
+	SearchEngine         string   `json:"search_engine"`
+	MeilisearchUrl       string   `json:"meilisearch_url"`
+	MeilisearchKey       string   `json:"meilisearch_key" secret:"true"`
+	StakworkKey          string   `json:"stakwork_key" secret:"true"`
+	BountyDescriptionUrl string   `json:"bounty_description_url"`
+	HiveChatWorkflowId   string   `json:"hive_chat_workflow_id"`
+	BriefWorkflowId      string   `json:"brief_workflow_id"`
+	GithubToken          string   `json:"github_token" secret:"true"`
+	TwitterToken         string   `json:"twitter_token" secret:"true"`
+	YoutubeKey           string   `json:"youtube_key" secret:"true"`
+	PodcastIndexKey      string   `json:"podcast_index_key" secret:"true"`
+	PodcastIndexSecret   string   `json:"podcast_index_secret" secret:"true"`
+	AlertUrl             string   `json:"alert_url"`
+	AlertSecret          string   `json:"alert_secret" secret:"true"`
+	AlertTribeUuid       string   `json:"alert_tribe_uuid"`
+	AlertBotId           string   `json:"alert_bot_id"`
+	AssetListUrl         string   `json:"asset_list_url"`
+	TestMode             bool     `json:"test_mode"`
+	TestAssetUrl         string   `json:"test_asset_url"`
+	SkipLoops            bool     `json:"skip_loops"`
+
+	// database pool, the lifetimes are in seconds. Queries slower than
+	// SlowQueryMs are logged, 0 turns the log off
+	DbMaxOpenConns    int `json:"db_max_open_conns"`
+	DbMaxIdleConns    int `json:"db_max_idle_conns"`
+	DbConnMaxLifetime int `json:"db_conn_max_lifetime"`
+	DbConnMaxIdleTime int `json:"db_conn_max_idle_time"`
+	SlowQueryMs       int `json:"slow_query_ms" reload:"true"`
+
+	// dates announced to clients of the unversioned (v1) api, zero when not set
+	ApiV1DeprecatedAt time.Time `json:"api_v1_deprecated_at" reload:"true"`
+	ApiV1Sunset       time.Time `json:"api_v1_sunset" reload:"true"`
+	FeatureFlags      []string  `json:"feature_flags" reload:"true"`
+
+	// retention runs only count what they would change, RetentionDays
+	// overrides the age in days of a retention rule, 0 turns it off
+	RetentionDryRun bool           `json:"retention_dry_run" reload:"true"`
+	RetentionDays   map[string]int `json:"retention_days" reload:"true"`
+
+	// repeats of a create or edit request by the same pubkey within
+	// DedupWindows seconds are suppressed, per endpoint, 0 turns it off.
+	// The endpoints in DedupConflict answer 409 instead of the existing
+	// resource
+	DedupWindows  map[string]int `json:"dedup_windows" reload:"true"`
+	DedupConflict []string       `json:"dedup_conflict" reload:"true"`
+
+	// connection code redemptions are posted to ConnectionCodeWebhook,
+	// signed with ConnectionCodeWebhookSecret when it is set
+	ConnectionCodeWebhook       string `json:"connection_code_webhook" reload:"true"`
+	ConnectionCodeWebhookSecret string `json:"connection_code_webhook_secret" secret:"true" reload:"true"`
+
+	// the Stakwork webhooks of the hive chat and the workspace brief carry
+	// the HMAC of their body by StakworkWebhookSecret, they are refused
+	// while it is not set
+	StakworkWebhookSecret string `json:"stakwork_webhook_secret" secret:"true" reload:"true"`
+
+	// queries of a workspace request, run with its context, that don't
+	// filter on its workspace are logged, or failed with TenantGuardReject
+	TenantGuard string `json:"tenant_guard" reload:"true"`
+
+	// calls to the Relay and Stakwork time out after these seconds. A
+	// service is cut off for UpstreamBreakerCooldown seconds after
+	// UpstreamBreakerFailures failed calls in a row, 0 turns it off
+	RelayTimeout            int `json:"relay_timeout" reload:"true"`
+	StakworkTimeout         int `json:"stakwork_timeout" reload:"true"`
+	UpstreamBreakerFailures int `json:"upstream_breaker_failures" reload:"true"`
+	UpstreamBreakerCooldown int `json:"upstream_breaker_cooldown" reload:"true"`
+
+	// tribes with a spam score of SpamScoreThreshold or more, out of 100,
+	// are shadow-listed until an admin reviews them, 0 turns it off
+	SpamScoreThreshold int `json:"spam_score_threshold" reload:"true"`
+
+	// a pubkey creates at most WorkspaceCreationsPerDay workspaces in 24
+	// hours, 0 turns it off. WorkspaceVerification is the check a workspace
+	// passes before it receives deposits: off, contact or payment. Contact
+	// codes are posted to WorkspaceVerificationWebhook, which emails or
+	// texts them, and a payment is an invoice of WorkspaceVerificationSats
+	WorkspaceCreationsPerDay           int    `json:"workspace_creations_per_day" reload:"true"`
+	WorkspaceVerification              string `json:"workspace_verification" reload:"true"`
+	WorkspaceVerificationWebhook       string `json:"workspace_verification_webhook" reload:"true"`
+	WorkspaceVerificationWebhookSecret string `json:"workspace_verification_webhook_secret" secret:"true" reload:"true"`
+	WorkspaceVerificationSats          int    `json:"workspace_verification_sats" reload:"true"`
+
+	// LightningBackend is the node payments go through, the sandbox moves
+	// no sats, see upstream/sandbox.go. SandboxFailures makes every nth
+	// call to an endpoint of the sandbox fail, 0 turns it off
+	LightningBackend string         `json:"lightning_backend"`
+	SandboxFailures  map[string]int `json:"sandbox_failures" reload:"true"`
+
+	// the bot of the Sphinx v2 node of the v2 backend and the pubkey of
+	// that node, see upstream/v2.go
+	V2BotUrl     string `json:"v2_bot_url"`
+	V2BotToken   string `json:"v2_bot_token" secret:"true"`
+	V2ContactKey string `json:"v2_contact_key"`
+
+	// the quotas of each workspace plan by plan name, a plan without a
+	// quota is unlimited on it. Workspaces without a plan are on
+	// DefaultPlan. Stakwork submissions are counted per month
+	DefaultPlan              string         `json:"default_plan" reload:"true"`
+	QuotaActiveBounties      map[string]int `json:"quota_active_bounties" reload:"true"`
+	QuotaStakworkSubmissions map[string]int `json:"quota_stakwork_submissions" reload:"true"`
+	QuotaStorageMb           map[string]int `json:"quota_storage_mb" reload:"true"`
+
+	// a skill of a profile is verified once the person was paid for this
+	// many bounties tagged with it, 0 turns it off
+	SkillVerificationBounties int `json:"skill_verification_bounties" reload:"true"`
+
+	// a person endorses another at most once per EndorsementPairDays, and
+	// an endorsement loses half of its weight every EndorsementHalfLifeDays
+	EndorsementPairDays     int `json:"endorsement_pair_days" reload:"true"`
+	EndorsementHalfLifeDays int `json:"endorsement_half_life_days" reload:"true"`
+
+	// workspace secrets are sealed with SecretsMasterKey, a base64 32 byte
+	// key. Secrets sealed with SecretsPreviousMasterKey still open until
+	// they are rewrapped, see the vault package
+	SecretsMasterKey         string `json:"secrets_master_key" secret:"true"`
+	SecretsPreviousMasterKey string `json:"secrets_previous_master_key" secret:"true"`
+
+	// paid bounties get a completion certificate signed with the Ed25519
+	// key of BountyCertificateKey, a base64 32 byte seed. Without it no
+	// certificates are issued, see the credentials package
+	BountyCertificateKey string `json:"bounty_certificate_key" secret:"true"`
+
+	// resumable uploads are at most UploadMaxMb, and their sessions expire
+	// UploadSessionHours after they are created
+	UploadMaxMb        int `json:"upload_max_mb" reload:"true"`
+	UploadSessionHours int `json:"upload_session_hours" reload:"true"`
+
+	// markdown fields keep only the tags of HtmlAllowedTags and the
+	// attributes of HtmlAllowedAttributes, given as tag:attribute or as an
+	// attribute allowed on every tag, see the markdown package
+	HtmlAllowedTags       []string `json:"html_allowed_tags" reload:"true"`
+	HtmlAllowedAttributes []string `json:"html_allowed_attributes" reload:"true"`
+}
+
+// where processed image uploads are stored
+const (
+	ImageStoreMeme = "meme"
+	ImageStoreS3   = "s3"
+)
+
+// modes of the tenant guard, see db/tenant.go
+const (
+	TenantGuardOff    = "off"
+	TenantGuardLog    = "log"
+	TenantGuardReject = "reject"
+)
+
+// lightning backends
+const (
+	LightningRelay   = "relay"
+	LightningSandbox = "sandbox"
+	LightningV2      = "v2"
+)
+
+// checks a workspace passes before it receives deposits
+const (
+	VerificationOff     = "off"
+	VerificationContact = "contact"
+	VerificationPayment = "payment"
+)
+
+// the RELAY_URL of the sandbox when it is not set, calls to it never leave
+// the instance
+const SandboxRelayUrl = "http://relay.sandbox"
+
+// the RELAY_URL of the v2 backend when it is not set, calls to it are sent
+// on to the v2 bot
+const V2RelayUrl = "http://relay.v2"
+
+// the html markdown fields keep when HTML_ALLOWED_TAGS and
+// HTML_ALLOWED_ATTRIBUTES are not set
+const (
+	DefaultHtmlAllowedTags       = "a,b,blockquote,br,code,del,em,h1,h2,h3,h4,h5,h6,hr,i,img,li,ol,p,pre,s,strong,sub,sup,table,tbody,td,th,thead,tr,ul"
+	DefaultHtmlAllowedAttributes = "a:href,a:title,img:src,img:alt,img:title,code:class,td:align,th:align"
+)
+
+// search engines, see the search package
+const (
+	SearchEnginePostgres    = "postgres"
+	SearchEngineMeilisearch = "meilisearch"
+)
+
+var (
+	currentMu sync.RWMutex
+	current   Config
+)
+
+// Get returns the loaded config, the zero Config before InitConfig
+func Get() Config {
+	currentMu.RLock()
+	defer currentMu.RUnlock()
+	return current
+}
+
+func set(cfg Config) {
+	currentMu.Lock()
+	defer currentMu.Unlock()
+	current = cfg
+}
+
+// FeatureEnabled reports if name is listed in FEATURE_FLAGS
+func FeatureEnabled(name string) bool {
+	for _, flag := range Get().FeatureFlags {
+		if flag == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Load reads the config from the environment, applies the defaults and
+// validates it
+func Load() (Config, error) {
+	var errs []string
+	cfg := Config{
+		Host:                 envOr("LN_SERVER_BASE_URL", "https://people.sphinx.chat"),
+		Port:                 envOr("PORT", "5002"),
+		JwtKey:               os.Getenv("LN_JWT_KEY"),
+		RelayUrl:             os.Getenv("RELAY_URL"),
+		RelayAuthKey:         os.Getenv("RELAY_AUTH_KEY"),
+		MemeUrl:              envOr("MEME_URL", "https://memes.sphinx.chat"),
+		Admins:               StripSuperAdmins(os.Getenv("ADMINS")),
+		AdminPubkeys:         StripSuperAdmins(os.Getenv("ADMIN_PUBKEYS")),
+		AdminCheck:           os.Getenv("ADMIN_CHECK"),
+		ConnectionAuth:       os.Getenv("CONNECTION_AUTH"),
+		AwsRegion:            os.Getenv("AWS_REGION"),
+		AwsAccessKeyId:       os.Getenv("AWS_ACCESS_KEY_ID"),
+		AwsSecretAccess:      os.Getenv("AWS_SECRET_ACCESS"),
+		S3BucketName:         envOr("S3_BUCKET_NAME", "sphinx-tribes"),
+		S3FolderName:         envOr("S3_FOLDER_NAME", "metrics"),
+		S3Url:                envOr("S3_URL", "https://sphinx-tribes.s3.amazonaws.com"),
+		S3Endpoint:           os.Getenv("S3_ENDPOINT"),
+		ImageStore:           envOr("IMAGE_STORE", ImageStoreMeme),
+		SearchEngine:         envOr("SEARCH_ENGINE", SearchEnginePostgres),
+		MeilisearchUrl:       os.Getenv("MEILISEARCH_URL"),
+		MeilisearchKey:       os.Getenv("MEILISEARCH_KEY"),
+		StakworkKey:          os.Getenv("STAKWORK_KEY"),
+		BountyDescriptionUrl: os.Getenv("BOUNTY_DESCRIPTION_URL"),
+		HiveChatWorkflowId:   os.Getenv("HIVE_CHAT_WORKFLOW_ID"),
+		BriefWorkflowId:      os.Getenv("WORKSPACE_BRIEF_WORKFLOW_ID"),
+		GithubToken:          os.Getenv("GITHUB_TOKEN"),
+		TwitterToken:         os.Getenv("TWITTER_TOKEN"),
+		YoutubeKey:           os.Getenv("YOUTUBE_KEY"),
+		PodcastIndexKey:      os.Getenv("PODCAST_INDEX_KEY"),
+		PodcastIndexSecret:   os.Getenv("PODCAST_INDEX_SECRET"),
+		AlertUrl:             os.Getenv("ALERT_URL"),
+		AlertSecret:          os.Getenv("ALERT_SECRET"),
+		AlertTribeUuid:       os.Getenv("ALERT_TRIBE_UUID"),
+		AlertBotId:           os.Getenv("ALERT_BOT_ID"),
+		AssetListUrl:         envOr("ASSET_LIST_URL", "https://liquid.sphinx.chat/assets"),
+		TestAssetUrl:         os.Getenv("TEST_ASSET_URL"),
+		ApiV1DeprecatedAt:    parseDate("API_V1_DEPRECATED_AT", &errs),
+		ApiV1Sunset:          parseDate("API_V1_SUNSET", &errs),
+		FeatureFlags:         StripSuperAdmins(os.Getenv("FEATURE_FLAGS")),
+	}
+	cfg.TestMode = parseBool("TEST_MODE", &errs)
+	cfg.SkipLoops = parseBool("SKIP_LOOPS", &errs)
+	cfg.DbMaxOpenConns = parseInt("DB_MAX_OPEN_CONNS", 20, &errs)
+	cfg.DbMaxIdleConns = parseInt("DB_MAX_IDLE_CONNS", 10, &errs)
+	cfg.DbConnMaxLifetime = parseInt("DB_CONN_MAX_LIFETIME", 1800, &errs)
+	cfg.DbConnMaxIdleTime = parseInt("DB_CONN_MAX_IDLE_TIME", 300, &errs)
+	cfg.SlowQueryMs = parseInt("SLOW_QUERY_MS", 200, &errs)
+	cfg.RetentionDryRun = parseBool("RETENTION_DRY_RUN", &errs)
+	cfg.RetentionDays = parseCounts("RETENTION_DAYS", "days", &errs)
+	cfg.DedupWindows = map[string]int{"tribe": 10, "bounty": 10}
+	for endpoint, seconds := range parseCounts("DEDUP_WINDOWS", "seconds", &errs) {
+		cfg.DedupWindows[endpoint] = seconds
+	}
+	cfg.DedupConflict = StripSuperAdmins(os.Getenv("DEDUP_CONFLICT"))
+	cfg.ConnectionCodeWebhook = os.Getenv("CONNECTION_CODE_WEBHOOK")
+	cfg.ConnectionCodeWebhookSecret = os.Getenv("CONNECTION_CODE_WEBHOOK_SECRET")
+	cfg.StakworkWebhookSecret = os.Getenv("STAKWORK_WEBHOOK_SECRET")
+	cfg.TenantGuard = envOr("TENANT_GUARD", TenantGuardLog)
+	cfg.RelayTimeout = parseInt("RELAY_TIMEOUT", 15, &errs)
+	cfg.StakworkTimeout = parseInt("STAKWORK_TIMEOUT", 30, &errs)
+	cfg.UpstreamBreakerFailures = parseInt("UPSTREAM_BREAKER_FAILURES", 5, &errs)
+	cfg.UpstreamBreakerCooldown = parseInt("UPSTREAM_BREAKER_COOLDOWN", 30, &errs)
+	cfg.SpamScoreThreshold = parseInt("SPAM_SCORE_THRESHOLD", 60, &errs)
+	cfg.WorkspaceCreationsPerDay = parseInt("WORKSPACE_CREATIONS_PER_DAY", 5, &errs)
+	cfg.WorkspaceVerification = envOr("WORKSPACE_VERIFICATION", VerificationOff)
+	cfg.WorkspaceVerificationWebhook = os.Getenv("WORKSPACE_VERIFICATION_WEBHOOK")
+	cfg.WorkspaceVerificationWebhookSecret = os.Getenv("WORKSPACE_VERIFICATION_WEBHOOK_SECRET")
+	cfg.WorkspaceVerificationSats = parseInt("WORKSPACE_VERIFICATION_SATS", 100, &errs)
+	cfg.LightningBackend = envOr("LIGHTNING_BACKEND", LightningRelay)
+	cfg.SandboxFailures = parseCounts("SANDBOX_FAILURES", "calls", &errs)
+	cfg.V2BotUrl = os.Getenv("V2_BOT_URL")
+	cfg.V2BotToken = os.Getenv("V2_BOT_TOKEN")
+	cfg.V2ContactKey = os.Getenv("V2_CONTACT_KEY")
+	cfg.DefaultPlan = envOr("DEFAULT_PLAN", "free")
+	cfg.QuotaActiveBounties = parseCounts("QUOTA_ACTIVE_BOUNTIES", "bounties", &errs)
+	cfg.QuotaStakworkSubmissions = parseCounts("QUOTA_STAKWORK_SUBMISSIONS", "submissions", &errs)
+	cfg.QuotaStorageMb = parseCounts("QUOTA_STORAGE_MB", "MB", &errs)
+	cfg.SkillVerificationBounties = parseInt("SKILL_VERIFICATION_BOUNTIES", 3, &errs)
+	cfg.EndorsementPairDays = parseInt("ENDORSEMENT_PAIR_DAYS", 30, &errs)
+	cfg.EndorsementHalfLifeDays = parseInt("ENDORSEMENT_HALF_LIFE_DAYS", 180, &errs)
+	cfg.SecretsMasterKey = os.Getenv("SECRETS_MASTER_KEY")
+	cfg.SecretsPreviousMasterKey = os.Getenv("SECRETS_PREVIOUS_MASTER_KEY")
+	cfg.BountyCertificateKey = os.Getenv("BOUNTY_CERTIFICATE_KEY")
+	cfg.UploadMaxMb = parseInt("UPLOAD_MAX_MB", 256, &errs)
+	cfg.UploadSessionHours = parseInt("UPLOAD_SESSION_HOURS", 24, &errs)
+	cfg.HtmlAllowedTags = parseList("HTML_ALLOWED_TAGS", DefaultHtmlAllowedTags)
+	cfg.HtmlAllowedAttributes = parseList("HTML_ALLOWED_ATTRIBUTES", DefaultHtmlAllowedAttributes)
+	if cfg.LightningBackend == LightningSandbox && cfg.RelayUrl == "" {
+		cfg.RelayUrl = SandboxRelayUrl
+	}
+	if cfg.LightningBackend == LightningV2 && cfg.RelayUrl == "" {
+		cfg.RelayUrl = V2RelayUrl
+	}
+
+	errs = append(errs, cfg.validate()...)
+	if len(errs) > 0 {
+		return cfg, errors.New("invalid config: " + strings.Join(errs, "; "))
+	}
+	return cfg, nil
+}
+
+func (cfg Config) validate() []string {
+	errs := []string{}
+	if cfg.RelayAuthKey == "" && cfg.LightningBackend != LightningSandbox && cfg.LightningBackend != LightningV2 {
+		errs = append(errs, "RELAY_AUTH_KEY is required")
+	}
+	switch cfg.LightningBackend {
+	case LightningRelay, LightningSandbox:
+	case LightningV2:
+		if cfg.V2BotUrl == "" {
+			errs = append(errs, "V2_BOT_URL is required by the v2 backend")
+		}
+		if cfg.V2BotToken == "" {
+			errs = append(errs, "V2_BOT_TOKEN is required by the v2 backend")
+		}
+	default:
+		errs = append(errs, "LIGHTNING_BACKEND is not relay, sandbox or v2")
+	}
+
+	urls := map[string]string{
+		"LN_SERVER_BASE_URL":             cfg.Host,
+		"RELAY_URL":                      cfg.RelayUrl,
+		"MEME_URL":                       cfg.MemeUrl,
+		"S3_URL":                         cfg.S3Url,
+		"S3_ENDPOINT":                    cfg.S3Endpoint,
+		"MEILISEARCH_URL":                cfg.MeilisearchUrl,
+		"BOUNTY_DESCRIPTION_URL":         cfg.BountyDescriptionUrl,
+		"ALERT_URL":                      cfg.AlertUrl,
+		"ASSET_LIST_URL":                 cfg.AssetListUrl,
+		"TEST_ASSET_URL":                 cfg.TestAssetUrl,
+		"CONNECTION_CODE_WEBHOOK":        cfg.ConnectionCodeWebhook,
+		"V2_BOT_URL":                     cfg.V2BotUrl,
+		"WORKSPACE_VERIFICATION_WEBHOOK": cfg.WorkspaceVerificationWebhook,
+	}
+	keys := make([]string, 0, len(urls))
+	for key := range urls {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if urls[key] == "" {
+			continue
+		}
+		u, err := url.Parse(urls[key])
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			errs = append(errs, key+" is not a http(s) url")
+		}
+	}
+
+	if cfg.ImageStore != ImageStoreMeme && cfg.ImageStore != ImageStoreS3 {
+		errs = append(errs, "IMAGE_STORE is not meme or s3")
+	}
+	switch cfg.SearchEngine {
+	case SearchEnginePostgres:
+	case SearchEngineMeilisearch:
+		if cfg.MeilisearchUrl == "" {
+			errs = append(errs, "MEILISEARCH_URL is required by the meilisearch engine")
+		}
+	default:
+		errs = append(errs, "SEARCH_ENGINE is not postgres or meilisearch")
+	}
+	switch cfg.TenantGuard {
+	case TenantGuardOff, TenantGuardLog, TenantGuardReject:
+	default:
+		errs = append(errs, "TENANT_GUARD is not off, log or reject")
+	}
+	if cfg.UpstreamBreakerFailures > 0 && cfg.UpstreamBreakerCooldown == 0 {
+		errs = append(errs, "UPSTREAM_BREAKER_COOLDOWN is required by the upstream breaker")
+	}
+	if cfg.SpamScoreThreshold < 0 || cfg.SpamScoreThreshold > 100 {
+		errs = append(errs, "SPAM_SCORE_THRESHOLD is not between 0 and 100")
+	}
+	switch cfg.WorkspaceVerification {
+	case VerificationOff:
+	case VerificationContact:
+		if cfg.WorkspaceVerificationWebhook == "" {
+			errs = append(errs, "WORKSPACE_VERIFICATION_WEBHOOK is required by the contact verification")
+		}
+	case VerificationPayment:
+		if cfg.WorkspaceVerificationSats <= 0 {
+			errs = append(errs, "WORKSPACE_VERIFICATION_SATS is required by the payment verification")
+		}
+	default:
+		errs = append(errs, "WORKSPACE_VERIFICATION is not off, contact or payment")
+	}
+	for _, key := range []struct{ name, value string }{
+		{"SECRETS_MASTER_KEY", cfg.SecretsMasterKey},
+		{"SECRETS_PREVIOUS_MASTER_KEY", cfg.SecretsPreviousMasterKey},
+		{"BOUNTY_CERTIFICATE_KEY", cfg.BountyCertificateKey},
+	} {
+		if key.value == "" {
+			continue
+		}
+		if decoded, err := base64.StdEncoding.DecodeString(key.value); err != nil || len(decoded) != 32 {
+			errs = append(errs, key.name+" is not a base64 32 byte key")
+		}
+	}
+	if cfg.SecretsPreviousMasterKey != "" && cfg.SecretsMasterKey == "" {
+		errs = append(errs, "SECRETS_MASTER_KEY is required by SECRETS_PREVIOUS_MASTER_KEY")
+	}
+	if cfg.DbMaxOpenConns > 0 && cfg.DbMaxIdleConns > cfg.DbMaxOpenConns {
+		errs = append(errs, "DB_MAX_IDLE_CONNS is more than DB_MAX_OPEN_CONNS")
+	}
+	if _, err := strconv.Atoi(cfg.Port); err != nil {
+		errs = append(errs, "PORT is not a number")
+	}
+	if !cfg.ApiV1DeprecatedAt.IsZero() && !cfg.ApiV1Sunset.IsZero() && cfg.ApiV1Sunset.Before(cfg.ApiV1DeprecatedAt) {
+		errs = append(errs, "API_V1_SUNSET is before API_V1_DEPRECATED_AT")
+	}
+	return errs
+}
+
+// Reload reads the .env file and the environment again and swaps the
+// reloadable values, the others keep their startup value. It returns the
+// json names of the values that changed, an invalid config changes nothing
+func Reload() ([]string, error) {
+	if err := godotenv.Overload(); err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	loaded, err := Load()
+	if err != nil {
+		return nil, err
+	}
+
+	currentMu.Lock()
+	defer currentMu.Unlock()
+	changed := []string{}
+	next := reflect.ValueOf(&current).Elem()
+	from := reflect.ValueOf(loaded)
+	for i := 0; i < next.NumField(); i++ {
+		field := next.Type().Field(i)
+		if field.Tag.Get("reload") != "true" {
+			continue
+		}
+		if !reflect.DeepEqual(next.Field(i).Interface(), from.Field(i).Interface()) {
+			next.Field(i).Set(from.Field(i))
+			changed = append(changed, jsonName(field))
+		}
+	}
+	return changed, nil
+}
+
+// Redacted is the config for the admin view, secrets only show if they
+// are set
+func (cfg Config) Redacted() map[string]interface{} {
+	redacted := map[string]interface{}{}
+	v := reflect.ValueOf(cfg)
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		value := v.Field(i).Interface()
+		if field.Tag.Get("secret") == "true" {
+			if value != "" {
+				value = "[redacted]"
+			}
+		}
+		redacted[jsonName(field)] = value
+	}
+	return redacted
+}
+
+func jsonName(field reflect.StructField) string {
+	return strings.Split(field.Tag.Get("json"), ",")[0]
+}
+
+func envOr(key string, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
+
+func parseBool(key string, errs *[]string) bool {
+	value := os.Getenv(key)
+	if value == "" {
+		return false
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		*errs = append(*errs, key+" is not a boolean")
+	}
+	return b
+}
+
+// parseInt reads a number that is 0 or more from the env
+func parseInt(key string, fallback int, errs *[]string) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		*errs = append(*errs, key+" is not a number")
+		return fallback
+	}
+	return n
+}
+
+// parseDate reads a YYYY-MM-DD or RFC3339 date from the env
+func parseDate(key string, errs *[]string) time.Time {
+	value := os.Getenv(key)
+	if value == "" {
+		return time.Time{}
+	}
+	for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+		if date, err := time.Parse(layout, value); err == nil {
+			return date
+		}
+	}
+	*errs = append(*errs, fmt.Sprintf("%s is not a date", key))
+	return time.Time{}
+}
+
+// parseList reads a comma separated list from the env, without the blank
+// entries
+func parseList(key string, fallback string) []string {
+	list := []string{}
+	for _, item := range strings.Split(envOr(key, fallback), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+// parseCounts reads a comma separated list of name=count from the env,
+// unit names the count in the errors
+func parseCounts(key string, unit string, errs *[]string) map[string]int {
+	counts := map[string]int{}
+	for _, pair := range parseList(key, "") {
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 {
+			*errs = append(*errs, fmt.Sprintf("%s has %q without =%s", key, pair, unit))
+			continue
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(parts[1]))
+		if err != nil || n < 0 {
+			*errs = append(*errs, fmt.Sprintf("%s has %q that is not a number of %s", key, pair, unit))
+			continue
+		}
+		counts[strings.TrimSpace(parts[0])] = n
+	}
+	return counts
+}
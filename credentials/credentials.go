@@ -0,0 +1,88 @@
+// Package credentials signs the completion certificates of bounties with the
+// Ed25519 key of BOUNTY_CERTIFICATE_KEY. The public key is published, so a
+// portfolio or any other website can verify a certificate without asking
+// the server: the signature is over the exact bytes of the certificate.
+package credentials
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+
+	"github.com/stakwork/sphinx-tribes/config"
+)
+
+// Algorithm names the signatures in the certificates and the published key
+const Algorithm = "Ed25519"
+
+// ErrNotConfigured is the error of signing without BOUNTY_CERTIFICATE_KEY
+var ErrNotConfigured = errors.New("BOUNTY_CERTIFICATE_KEY is not set")
+
+// PublicKey is the published key certificates are verified with
+type PublicKey struct {
+	Algorithm string `json:"algorithm"`
+	KeyId     string `json:"key_id"`
+	PublicKey string `json:"public_key"`
+}
+
+// KeyId names a public key, certificates carry the id of their key
+func KeyId(publicKey ed25519.PublicKey) string {
+	sum := sha256.Sum256(publicKey)
+	return hex.EncodeToString(sum[:4])
+}
+
+// signingKey is the key of BOUNTY_CERTIFICATE_KEY, nil when it is not set.
+// The config validates the seed
+func signingKey() ed25519.PrivateKey {
+	seed, err := base64.StdEncoding.DecodeString(config.Get().BountyCertificateKey)
+	if err != nil || len(seed) != ed25519.SeedSize {
+		return nil
+	}
+	return ed25519.NewKeyFromSeed(seed)
+}
+
+// Configured reports if certificates can be signed
+func Configured() bool {
+	return signingKey() != nil
+}
+
+// Published returns the public key of the signing key
+func Published() (PublicKey, error) {
+	key := signingKey()
+	if key == nil {
+		return PublicKey{}, ErrNotConfigured
+	}
+	publicKey := key.Public().(ed25519.PublicKey)
+	return PublicKey{
+		Algorithm: Algorithm,
+		KeyId:     KeyId(publicKey),
+		PublicKey: base64.StdEncoding.EncodeToString(publicKey),
+	}, nil
+}
+
+// Sign signs a certificate, returning the base64 signature and the id of
+// the key
+func Sign(certificate []byte) (string, string, error) {
+	key := signingKey()
+	if key == nil {
+		return "", "", ErrNotConfigured
+	}
+	signature := ed25519.Sign(key, certificate)
+	return base64.StdEncoding.EncodeToString(signature), KeyId(key.Public().(ed25519.PublicKey)), nil
+}
+
+// Verify checks the base64 signature of a certificate against a published
+// key
+func Verify(publicKey PublicKey, certificate []byte, signature string) bool {
+	key, err := base64.StdEncoding.DecodeString(publicKey.PublicKey)
+	if err != nil || len(key) != ed25519.PublicKeySize {
+		return false
+	}
+	decoded, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	return ed25519.Verify(ed25519.PublicKey(key), certificate, decoded)
+}
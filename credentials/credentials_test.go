@@ -0,0 +1,45 @@
+package credentials
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCredentials(t *testing.T) {
+	t.Setenv("RELAY_AUTH_KEY", "relay-key")
+	t.Setenv("RELAY_URL", "http://localhost:3001")
+	defer func() {
+		t.Setenv("BOUNTY_CERTIFICATE_KEY", "")
+		config.InitConfig()
+	}()
+
+	t.Run("should not sign without a key", func(t *testing.T) {
+		config.InitConfig()
+		assert.False(t, Configured())
+		_, _, err := Sign([]byte(`{}`))
+		assert.Equal(t, ErrNotConfigured, err)
+	})
+
+	t.Setenv("BOUNTY_CERTIFICATE_KEY", base64.StdEncoding.EncodeToString([]byte(strings.Repeat("k", 32))))
+	config.InitConfig()
+	certificate := []byte(`{"pubkey":"hunter","bounty_id":7}`)
+	signature, keyId, err := Sign(certificate)
+	assert.NoError(t, err)
+	published, err := Published()
+	assert.NoError(t, err)
+
+	t.Run("should verify with the published key", func(t *testing.T) {
+		assert.Equal(t, Algorithm, published.Algorithm)
+		assert.Equal(t, keyId, published.KeyId)
+		assert.True(t, Verify(published, certificate, signature))
+	})
+
+	t.Run("should not verify an edited certificate", func(t *testing.T) {
+		assert.False(t, Verify(published, []byte(`{"pubkey":"hunter","bounty_id":8}`), signature))
+		assert.False(t, Verify(published, certificate, "not base64"))
+	})
+}
@@ -0,0 +1,100 @@
+package db
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// AiUsageMonth is the month of a month param as 2006-01, the month of now
+// when it is empty
+func AiUsageMonth(month string, now time.Time) (time.Time, error) {
+	if month == "" {
+		now = now.UTC()
+		return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC), nil
+	}
+	start, err := time.Parse("2006-01", month)
+	if err != nil {
+		return start, errors.New("month is not formatted as 2006-01")
+	}
+	return start, nil
+}
+
+// CreateAiSubmission records a submission of a workspace to Stakwork
+func (db database) CreateAiSubmission(submission AiSubmission) error {
+	if submission.WorkspaceUuid == "" {
+		return errors.New("workspace uuid is required")
+	}
+	if submission.Created == nil {
+		now := time.Now()
+		submission.Created = &now
+	}
+	return db.db.Create(&submission).Error
+}
+
+// AddAiSubmissionCost adds the credits a run reported to the submission of
+// kind it answers, a run may report them in several webhooks
+func (db database) AddAiSubmissionCost(kind string, reference string, cost float64) error {
+	if reference == "" {
+		return nil
+	}
+	return db.db.Model(&AiSubmission{}).Where("kind = ? AND reference = ?", kind, reference).
+		Update("cost", gorm.Expr("COALESCE(cost, 0) + ?", cost)).Error
+}
+
+// CountAiSubmissions counts the submissions a workspace sent in the month
+// that starts at month, failed ones are not counted
+func (db database) CountAiSubmissions(workspaceUuid string, month time.Time) (int64, error) {
+	var count int64
+	err := db.db.Model(&AiSubmission{}).
+		Where("workspace_uuid = ? AND status = ? AND created >= ? AND created < ?", workspaceUuid, AiSubmissionSent, month, month.AddDate(0, 1, 0)).
+		Count(&count).Error
+	return count, err
+}
+
+// GetAiUsage sums the submissions of a workspace in the month that starts at
+// month, per workflow
+func (db database) GetAiUsage(workspaceUuid string, month time.Time) (AiUsage, error) {
+	usage := AiUsage{WorkspaceUuid: workspaceUuid, Month: month.Format("2006-01"), Workflows: []AiWorkflowUsage{}}
+	err := db.db.Raw(`SELECT kind, workflow_id,
+			COUNT(*) FILTER (WHERE status = ?) AS submissions,
+			COUNT(*) FILTER (WHERE status = ?) AS failed,
+			COALESCE(SUM(duration_ms), 0) AS duration_ms,
+			SUM(cost) AS cost
+		FROM ai_submissions WHERE workspace_uuid = ? AND created >= ? AND created < ?
+		GROUP BY kind, workflow_id ORDER BY kind, workflow_id`,
+		AiSubmissionSent, AiSubmissionFailed, workspaceUuid, month, month.AddDate(0, 1, 0)).Scan(&usage.Workflows).Error
+	if err != nil {
+		return usage, err
+	}
+
+	for _, workflow := range usage.Workflows {
+		usage.Submissions += workflow.Submissions
+		usage.Failed += workflow.Failed
+		usage.DurationMs += workflow.DurationMs
+		if workflow.Cost != nil {
+			cost := *workflow.Cost
+			if usage.Cost != nil {
+				cost += *usage.Cost
+			}
+			usage.Cost = &cost
+		}
+	}
+	return usage, nil
+}
+
+// SetWorkspaceAiCap sets the most AI submissions a workspace makes in a
+// month, 0 removes the cap
+func (db database) SetWorkspaceAiCap(uuid string, monthlyCap uint) (Workspace, error) {
+	workspace := Workspace{}
+	now := time.Now()
+	if err := db.db.Model(&Workspace{}).Where("uuid = ?", uuid).Updates(map[string]interface{}{
+		"ai_monthly_cap": monthlyCap,
+		"updated":        &now,
+	}).Error; err != nil {
+		return workspace, err
+	}
+	err := db.db.Where("uuid = ?", uuid).First(&workspace).Error
+	return workspace, err
+}
@@ -0,0 +1,22 @@
+package db
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAiUsageMonth(t *testing.T) {
+	now := time.Date(2026, time.October, 15, 9, 30, 0, 0, time.FixedZone("UTC+3", 3*3600))
+
+	month, err := AiUsageMonth("", now)
+	if err != nil || !month.Equal(time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected the month of now, got %s %v", month, err)
+	}
+	month, err = AiUsageMonth("2026-02", now)
+	if err != nil || !month.Equal(time.Date(2026, time.February, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected february, got %s %v", month, err)
+	}
+	if _, err := AiUsageMonth("2026-13", now); err == nil {
+		t.Error("expected an error for a month that doesn't exist")
+	}
+}
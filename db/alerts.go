@@ -8,8 +8,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"os"
 	"strconv"
+
+	"github.com/stakwork/sphinx-tribes/config"
 )
 
 type Action struct {
@@ -31,10 +32,11 @@ func (db database) ProcessAlerts(p Person) {
 	// if they match, build an Action with their pubkey
 	// post all the Actions you have build to relay with the HMAC header
 
-	relayUrl := os.Getenv("ALERT_URL")
-	alertSecret := os.Getenv("ALERT_SECRET")
-	alertTribeUuid := os.Getenv("ALERT_TRIBE_UUID")
-	botId := os.Getenv("ALERT_BOT_ID")
+	cfg := config.Get()
+	relayUrl := cfg.AlertUrl
+	alertSecret := cfg.AlertSecret
+	alertTribeUuid := cfg.AlertTribeUuid
+	botId := cfg.AlertBotId
 	if relayUrl == "" || alertSecret == "" || alertTribeUuid == "" || botId == "" {
 		fmt.Println("Ticket alerts: ENV information not found")
 		return
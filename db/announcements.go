@@ -0,0 +1,125 @@
+package db
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// GetTribeAnnouncements returns the announcements of a tribe, the newest
+// first
+func (db database) GetTribeAnnouncements(tribeUuid string) []TribeAnnouncement {
+	announcements := []TribeAnnouncement{}
+	db.db.Where("tribe_uuid = ?", tribeUuid).Order("created DESC, id DESC").Find(&announcements)
+	return announcements
+}
+
+// GetPinnedTribeAnnouncements returns the announcements of a tribe still
+// pinned at a time, the newest first
+func (db database) GetPinnedTribeAnnouncements(tribeUuid string, at time.Time) []TribeAnnouncement {
+	announcements := []TribeAnnouncement{}
+	db.db.Where("tribe_uuid = ? AND (pin_until IS NULL OR pin_until > ?)", tribeUuid, at).
+		Order("created DESC, id DESC").Find(&announcements)
+	return announcements
+}
+
+func (db database) GetTribeAnnouncement(uuid string) (TribeAnnouncement, error) {
+	announcement := TribeAnnouncement{}
+	result := db.db.Where("uuid = ?", uuid).Find(&announcement)
+	if result.Error != nil {
+		return announcement, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return announcement, errors.New("announcement not found")
+	}
+	return announcement, nil
+}
+
+// CreateOrEditTribeAnnouncement saves an announcement, an edit keeps its
+// tribe and author
+func (db database) CreateOrEditTribeAnnouncement(announcement TribeAnnouncement) (TribeAnnouncement, error) {
+	if announcement.Uuid == "" {
+		return announcement, errors.New("announcement uuid is required")
+	}
+	announcement.Title = strings.TrimSpace(announcement.Title)
+
+	now := time.Now()
+	announcement.Updated = &now
+	existing := TribeAnnouncement{}
+	result := db.db.Where("uuid = ?", announcement.Uuid).Find(&existing)
+	if result.Error != nil {
+		return announcement, result.Error
+	}
+	if result.RowsAffected == 0 {
+		announcement.Created = &now
+		return announcement, db.db.Create(&announcement).Error
+	}
+
+	announcement.ID = existing.ID
+	announcement.TribeUuid = existing.TribeUuid
+	announcement.Author = existing.Author
+	announcement.Created = existing.Created
+	return announcement, db.db.Save(&announcement).Error
+}
+
+func (db database) DeleteTribeAnnouncement(uuid string) error {
+	return db.db.Where("uuid = ?", uuid).Delete(&TribeAnnouncement{}).Error
+}
+
+// GetTribeEvents returns the events of a tribe, the next to start first
+func (db database) GetTribeEvents(tribeUuid string) []TribeEvent {
+	events := []TribeEvent{}
+	db.db.Where("tribe_uuid = ?", tribeUuid).Order("starts_at ASC, id ASC").Find(&events)
+	return events
+}
+
+// GetTribeUpdates returns the announcements and the events of a tribe, the
+// last updated first, up to limit of each
+func (db database) GetTribeUpdates(tribeUuid string, limit int) TribeUpdates {
+	updates := TribeUpdates{Announcements: []TribeAnnouncement{}, Events: []TribeEvent{}}
+	db.db.Where("tribe_uuid = ?", tribeUuid).Order("updated DESC, id DESC").Limit(limit).Find(&updates.Announcements)
+	db.db.Where("tribe_uuid = ?", tribeUuid).Order("updated DESC, id DESC").Limit(limit).Find(&updates.Events)
+	return updates
+}
+
+func (db database) GetTribeEvent(uuid string) (TribeEvent, error) {
+	event := TribeEvent{}
+	result := db.db.Where("uuid = ?", uuid).Find(&event)
+	if result.Error != nil {
+		return event, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return event, errors.New("event not found")
+	}
+	return event, nil
+}
+
+// CreateOrEditTribeEvent saves an event, an edit keeps its tribe and author
+func (db database) CreateOrEditTribeEvent(event TribeEvent) (TribeEvent, error) {
+	if event.Uuid == "" {
+		return event, errors.New("event uuid is required")
+	}
+	event.Title = strings.TrimSpace(event.Title)
+
+	now := time.Now()
+	event.Updated = &now
+	existing := TribeEvent{}
+	result := db.db.Where("uuid = ?", event.Uuid).Find(&existing)
+	if result.Error != nil {
+		return event, result.Error
+	}
+	if result.RowsAffected == 0 {
+		event.Created = &now
+		return event, db.db.Create(&event).Error
+	}
+
+	event.ID = existing.ID
+	event.TribeUuid = existing.TribeUuid
+	event.Author = existing.Author
+	event.Created = existing.Created
+	return event, db.db.Save(&event).Error
+}
+
+func (db database) DeleteTribeEvent(uuid string) error {
+	return db.db.Where("uuid = ?", uuid).Delete(&TribeEvent{}).Error
+}
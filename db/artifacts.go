@@ -0,0 +1,142 @@
+package db
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/utils"
+	"gorm.io/gorm"
+)
+
+// CreateOrEditArtifact saves an artifact as its next version. The previous
+// versions stay readable with GetArtifactVersion
+func (db database) CreateOrEditArtifact(artifact WorkspaceArtifact) (WorkspaceArtifact, error) {
+	artifact.Name = strings.TrimSpace(artifact.Name)
+	artifact.Url = strings.TrimSpace(artifact.Url)
+	if artifact.Tags == nil {
+		artifact.Tags = []string{}
+	}
+
+	err := db.transaction(func(tx database) error {
+		now := time.Now()
+		existing := WorkspaceArtifact{}
+		err := tx.db.Where("uuid = ?", artifact.Uuid).First(&existing).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+
+		artifact.Updated = &now
+		if existing.ID == 0 {
+			artifact.Version = 1
+			artifact.Created = &now
+			artifact.UpdatedBy = artifact.CreatedBy
+			err = tx.db.Create(&artifact).Error
+		} else {
+			artifact.ID = existing.ID
+			artifact.WorkspaceUuid = existing.WorkspaceUuid
+			artifact.Version = existing.Version + 1
+			artifact.Created = existing.Created
+			artifact.CreatedBy = existing.CreatedBy
+			err = tx.db.Save(&artifact).Error
+		}
+		if err != nil {
+			return err
+		}
+
+		return tx.db.Create(&WorkspaceArtifactVersion{
+			ArtifactUuid: artifact.Uuid,
+			Version:      artifact.Version,
+			Name:         artifact.Name,
+			Content:      artifact.Content,
+			Url:          artifact.Url,
+			Tags:         artifact.Tags,
+			Created:      &now,
+			CreatedBy:    artifact.UpdatedBy,
+		}).Error
+	})
+	return artifact, err
+}
+
+func artifactsQuery(db *gorm.DB, workspaceUuid string, r *http.Request) *gorm.DB {
+	query := db.Model(&WorkspaceArtifact{}).Where("workspace_uuid = ?", workspaceUuid)
+	keys := r.URL.Query()
+	if kind := keys.Get("kind"); kind != "" {
+		query = query.Where("kind = ?", kind)
+	}
+	if tag := keys.Get("tag"); tag != "" {
+		query = query.Where("? = ANY(tags)", tag)
+	}
+	if feature := keys.Get("feature_uuid"); feature != "" {
+		query = query.Where("feature_uuid = ?", feature)
+	}
+	return query
+}
+
+// GetArtifacts lists the artifacts of a workspace last updated first,
+// filtered by the kind, tag and feature_uuid query params
+func (db database) GetArtifacts(workspaceUuid string, r *http.Request) ([]WorkspaceArtifact, error) {
+	artifacts := []WorkspaceArtifact{}
+	offset, limit, _, _, _ := utils.GetPaginationParams(r)
+	if r.URL.Query().Get("limit") == "" {
+		limit = 50
+	}
+
+	query := artifactsQuery(db.forRequest(r), workspaceUuid, r)
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if offset > 0 {
+		query = query.Offset(offset)
+	}
+
+	err := query.Order("updated DESC, id DESC").Find(&artifacts).Error
+	return artifacts, err
+}
+
+func (db database) GetArtifactsCount(workspaceUuid string, r *http.Request) int64 {
+	var count int64
+	artifactsQuery(db.db, workspaceUuid, r).Count(&count)
+	return count
+}
+
+// GetArtifactsByUuid returns the artifacts of a workspace with the given
+// uuids, the unknown ones are left out
+func (db database) GetArtifactsByUuid(workspaceUuid string, uuids []string) ([]WorkspaceArtifact, error) {
+	artifacts := []WorkspaceArtifact{}
+	if len(uuids) == 0 {
+		return artifacts, nil
+	}
+	err := db.db.Where("workspace_uuid = ? AND uuid IN ?", workspaceUuid, uuids).Order("id").Find(&artifacts).Error
+	return artifacts, err
+}
+
+func (db database) GetArtifact(uuid string) (WorkspaceArtifact, error) {
+	artifact := WorkspaceArtifact{}
+	err := db.db.Where("uuid = ?", uuid).First(&artifact).Error
+	return artifact, err
+}
+
+// GetArtifactVersions lists the versions of an artifact, newest first
+func (db database) GetArtifactVersions(uuid string) ([]WorkspaceArtifactVersion, error) {
+	versions := []WorkspaceArtifactVersion{}
+	err := db.db.Where("artifact_uuid = ?", uuid).Order("version DESC").Find(&versions).Error
+	return versions, err
+}
+
+func (db database) GetArtifactVersion(uuid string, version int) (WorkspaceArtifactVersion, error) {
+	artifactVersion := WorkspaceArtifactVersion{}
+	err := db.db.Where("artifact_uuid = ? AND version = ?", uuid, version).First(&artifactVersion).Error
+	return artifactVersion, err
+}
+
+// DeleteArtifact deletes an artifact with all its versions
+func (db database) DeleteArtifact(uuid string) error {
+	return db.transaction(func(tx database) error {
+		if err := tx.db.Where("artifact_uuid = ?", uuid).Delete(&WorkspaceArtifactVersion{}).Error; err != nil {
+			return err
+		}
+		return tx.db.Where("uuid = ?", uuid).Delete(&WorkspaceArtifact{}).Error
+	})
+}
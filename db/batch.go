@@ -0,0 +1,28 @@
+package db
+
+func (db database) GetPeopleByPubkeys(pubkeys []string) []Person {
+	people := []Person{}
+	if len(pubkeys) == 0 {
+		return people
+	}
+	db.db.Where("owner_pub_key IN ? AND (deleted = false OR deleted is null)", pubkeys).Find(&people)
+	return people
+}
+
+func (db database) GetBountiesByIds(ids []uint) []NewBounty {
+	bounties := []NewBounty{}
+	if len(ids) == 0 {
+		return bounties
+	}
+	db.db.Where("id IN ?", ids).Find(&bounties)
+	return bounties
+}
+
+func (db database) GetTribesByUuids(uuids []string) []Tribe {
+	tribes := []Tribe{}
+	if len(uuids) == 0 {
+		return tribes
+	}
+	db.db.Where("uuid IN ? AND (deleted = 'f' OR deleted is null)", uuids).Find(&tribes)
+	return tribes
+}
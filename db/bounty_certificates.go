@@ -0,0 +1,37 @@
+package db
+
+import (
+	"gorm.io/gorm/clause"
+)
+
+// CreateBountyCertificate saves the certificate of a bounty. A bounty has
+// one certificate, the one saved first is returned when it already has it
+func (db database) CreateBountyCertificate(certificate BountyCertificate) (BountyCertificate, error) {
+	err := db.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "bounty_id"}},
+		DoNothing: true,
+	}).Create(&certificate).Error
+	if err != nil {
+		return certificate, err
+	}
+
+	saved := BountyCertificate{}
+	err = db.db.Where("bounty_id = ?", certificate.BountyID).First(&saved).Error
+	return saved, err
+}
+
+// GetBountyCertificate returns the certificate of the uuid, with an ID of 0
+// when there is none
+func (db database) GetBountyCertificate(uuid string) BountyCertificate {
+	certificate := BountyCertificate{}
+	db.db.Where("uuid = ?", uuid).Find(&certificate)
+	return certificate
+}
+
+// GetBountyCertificatesByPubkey returns the certificates of a hunter, the
+// newest first
+func (db database) GetBountyCertificatesByPubkey(pubkey string) ([]BountyCertificate, error) {
+	certificates := []BountyCertificate{}
+	err := db.db.Where("pubkey = ?", pubkey).Order("created DESC").Find(&certificates).Error
+	return certificates, err
+}
@@ -0,0 +1,141 @@
+package db
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+)
+
+// the values of a facet returned at most, the most common first
+const bountyFacetLimit = 50
+
+// BountyPriceBands are the price ranges the marketplace filters by, in sats
+var BountyPriceBands = []BountyPriceBand{
+	{Key: "under_10k", Min: 0, Max: 10000},
+	{Key: "10k_50k", Min: 10000, Max: 50000},
+	{Key: "50k_100k", Min: 50000, Max: 100000},
+	{Key: "100k_500k", Min: 100000, Max: 500000},
+	{Key: "500k_plus", Min: 500000},
+}
+
+// bountyStatusConditions are the statuses of the marketplace filters, with
+// the same conditions as /bounties/all
+var bountyStatusConditions = map[string]string{
+	"open":      "bounty.assignee = '' AND bounty.paid != true",
+	"assigned":  "bounty.assignee != '' AND bounty.paid = false",
+	"completed": "bounty.assignee != '' AND bounty.completed = true AND bounty.paid = false",
+	"paid":      "bounty.paid = true",
+}
+
+// PriceBandOf is the band of the key, false when there is none
+func PriceBandOf(key string) (BountyPriceBand, bool) {
+	for _, band := range BountyPriceBands {
+		if band.Key == key {
+			return band, true
+		}
+	}
+	return BountyPriceBand{}, false
+}
+
+// priceBandCase is the SQL expression of the band of a bounty price
+func priceBandCase() string {
+	cases := "CASE"
+	for _, band := range BountyPriceBands {
+		if band.Max == 0 {
+			continue
+		}
+		cases += " WHEN bounty.price < " + strconv.FormatUint(uint64(band.Max), 10) + " THEN '" + band.Key + "'"
+	}
+	return cases + " ELSE '" + BountyPriceBands[len(BountyPriceBands)-1].Key + "' END"
+}
+
+// filteredBounties is a new query of the shown bounties matching filters
+func (db database) filteredBounties(filters BountyFacetFilters) *gorm.DB {
+	query := db.db.Table("bounty").Where("bounty.show != false AND bounty.deleted_at IS NULL")
+
+	conditions := []string{}
+	for _, status := range filters.Statuses {
+		if condition, ok := bountyStatusConditions[status]; ok {
+			conditions = append(conditions, "("+condition+")")
+		}
+	}
+	if len(conditions) > 0 {
+		query = query.Where(strings.Join(conditions, " OR "))
+	}
+	for _, tag := range filters.Tags {
+		query = query.Where("? = ANY (bounty.labels)", tag)
+	}
+	if len(filters.Languages) > 0 {
+		query = query.Where("bounty.coding_languages && ?", pq.Array(filters.Languages))
+	}
+	if filters.WorkspaceUuid != "" {
+		query = query.Where("bounty.workspace_uuid = ?", filters.WorkspaceUuid)
+	}
+	if filters.PhaseUuid != "" {
+		query = query.Where("bounty.phase_uuid = ?", filters.PhaseUuid)
+	}
+	if filters.Search != "" {
+		query = query.Where("LOWER(bounty.title) LIKE ?", "%"+strings.ToLower(filters.Search)+"%")
+	}
+	if band, ok := PriceBandOf(filters.PriceBand); ok {
+		query = query.Where("bounty.price >= ?", band.Min)
+		if band.Max > 0 {
+			query = query.Where("bounty.price < ?", band.Max)
+		}
+	}
+	return query
+}
+
+// GetBountyFacets counts the shown bounties matching the filters, per label,
+// coding language, price band and workspace, so the marketplace sidebar can
+// show its counts without loading the bounties
+func (db database) GetBountyFacets(filters BountyFacetFilters) (BountyFacets, error) {
+	facets := BountyFacets{
+		Tags:       []BountyFacetCount{},
+		Languages:  []BountyFacetCount{},
+		PriceBands: []BountyFacetCount{},
+		Workspaces: []BountyFacetCount{},
+	}
+
+	if err := db.filteredBounties(filters).Count(&facets.Total).Error; err != nil {
+		return facets, err
+	}
+
+	err := db.filteredBounties(filters).Select("unnest(bounty.labels) AS value, COUNT(*) AS count").
+		Group("value").Order("count DESC, value").Limit(bountyFacetLimit).Scan(&facets.Tags).Error
+	if err != nil {
+		return facets, err
+	}
+
+	err = db.filteredBounties(filters).Select("unnest(bounty.coding_languages) AS value, COUNT(*) AS count").
+		Group("value").Order("count DESC, value").Limit(bountyFacetLimit).Scan(&facets.Languages).Error
+	if err != nil {
+		return facets, err
+	}
+
+	err = db.filteredBounties(filters).Select("bounty.workspace_uuid AS value, MAX(workspaces.name) AS label, COUNT(*) AS count").
+		Joins("LEFT JOIN workspaces ON workspaces.uuid = bounty.workspace_uuid").
+		Where("bounty.workspace_uuid != ''").
+		Group("bounty.workspace_uuid").Order("count DESC, value").Limit(bountyFacetLimit).Scan(&facets.Workspaces).Error
+	if err != nil {
+		return facets, err
+	}
+
+	bands := []BountyFacetCount{}
+	err = db.filteredBounties(filters).Select(priceBandCase() + " AS value, COUNT(*) AS count").
+		Group("value").Scan(&bands).Error
+	if err != nil {
+		return facets, err
+	}
+	counts := map[string]int64{}
+	for _, band := range bands {
+		counts[band.Value] = band.Count
+	}
+	// every band is listed, in order, so the sidebar can show the empty ones
+	for _, band := range BountyPriceBands {
+		facets.PriceBands = append(facets.PriceBands, BountyFacetCount{Value: band.Key, Count: counts[band.Key]})
+	}
+	return facets, nil
+}
@@ -0,0 +1,19 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPriceBands(t *testing.T) {
+	band, ok := PriceBandOf("10k_50k")
+	assert.True(t, ok)
+	assert.Equal(t, BountyPriceBand{Key: "10k_50k", Min: 10000, Max: 50000}, band)
+
+	_, ok = PriceBandOf("cheap")
+	assert.False(t, ok)
+
+	assert.Equal(t, "CASE WHEN bounty.price < 10000 THEN 'under_10k' WHEN bounty.price < 50000 THEN '10k_50k'"+
+		" WHEN bounty.price < 100000 THEN '50k_100k' WHEN bounty.price < 500000 THEN '100k_500k' ELSE '500k_plus' END", priceBandCase())
+}
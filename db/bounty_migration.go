@@ -0,0 +1,190 @@
+package db
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// the columns the legacy schema left NULL, with the value NewBounty reads
+// for them
+var bountyBackfills = []struct {
+	column string
+	value  interface{}
+}{
+	{"phase_uuid", ""},
+	{"phase_priority", 0},
+	{"coding_languages", pq.StringArray{}},
+}
+
+// MigrateLegacyBounties moves the bounties that still live in the wanted
+// lists of profiles into the bounty table, and sets the NULL columns of
+// rows written with the legacy schema. A bounty is matched to its row by
+// owner and created time, so running it again only reports. The rows that
+// differ from their legacy bounty are reported, not overwritten. A dry run
+// writes nothing
+func (db database) MigrateLegacyBounties(dryRun bool) (BountyMigrationReport, error) {
+	report := BountyMigrationReport{
+		DryRun:     dryRun,
+		Backfilled: map[string]int64{},
+		Mismatches: []BountyMigrationMismatch{},
+	}
+
+	err := db.transaction(func(tx database) error {
+		people := []Person{}
+		if err := tx.db.Where("deleted = 'f' OR deleted is null").Find(&people).Error; err != nil {
+			return err
+		}
+		for _, person := range people {
+			wanteds, _ := person.Extras["wanted"].([]interface{})
+			for _, wanted := range wanteds {
+				entry, _ := wanted.(map[string]interface{})
+				report.LegacyBounties++
+				bounty, ok := LegacyWantedBounty(person.OwnerPubKey, entry)
+				if !ok {
+					report.Skipped++
+					continue
+				}
+
+				existing := NewBounty{}
+				tx.db.Unscoped().Where("owner_id = ? AND created = ?", bounty.OwnerID, bounty.Created).Limit(1).Find(&existing)
+				if existing.ID != 0 {
+					report.AlreadyMigrated++
+					if fields := BountyMismatches(bounty, existing); len(fields) > 0 {
+						report.Mismatches = append(report.Mismatches, BountyMigrationMismatch{
+							BountyID: existing.ID,
+							OwnerID:  bounty.OwnerID,
+							Created:  bounty.Created,
+							Fields:   fields,
+						})
+					}
+					continue
+				}
+
+				report.Migrated++
+				if dryRun {
+					continue
+				}
+				now := time.Now()
+				bounty.Updated = &now
+				if err := tx.db.Create(&bounty).Error; err != nil {
+					return err
+				}
+			}
+		}
+
+		for _, backfill := range bountyBackfills {
+			query := tx.db.Unscoped().Model(&NewBounty{}).Where(backfill.column + " IS NULL")
+			if dryRun {
+				var count int64
+				if err := query.Count(&count).Error; err != nil {
+					return err
+				}
+				report.Backfilled[backfill.column] = count
+				continue
+			}
+			result := query.UpdateColumn(backfill.column, backfill.value)
+			if result.Error != nil {
+				return result.Error
+			}
+			report.Backfilled[backfill.column] = result.RowsAffected
+		}
+		return nil
+	})
+	return report, err
+}
+
+// LegacyWantedBounty reads a bounty from an entry of the wanted list of a
+// profile. It is false when the entry has no title or created time, the
+// two things the app always set
+func LegacyWantedBounty(owner string, wanted map[string]interface{}) (NewBounty, bool) {
+	text := func(key string) string {
+		value, _ := wanted[key].(string)
+		return value
+	}
+	flag := func(key string, fallback bool) bool {
+		value, ok := wanted[key].(bool)
+		if !ok {
+			return fallback
+		}
+		return value
+	}
+
+	bounty := NewBounty{
+		OwnerID:                 owner,
+		Title:                   strings.TrimSpace(text("title")),
+		Paid:                    flag("paid", false),
+		Show:                    flag("show", true),
+		Type:                    text("type"),
+		Award:                   text("award"),
+		Tribe:                   text("tribe"),
+		TicketUrl:               text("ticketUrl"),
+		Description:             text("description"),
+		WantedType:              text("wanted_type"),
+		Deliverables:            text("deliverables"),
+		GithubDescription:       flag("github_description", false),
+		OneSentenceSummary:      text("one_sentence_summary"),
+		EstimatedSessionLength:  text("estimated_session_length"),
+		EstimatedCompletionDate: text("estimated_completion_date"),
+		CodingLanguages:         pq.StringArray{},
+		Labels:                  pq.StringArray{},
+	}
+
+	// JSON numbers decode to float64, the app sent some of them as strings
+	number := func(key string) float64 {
+		switch value := wanted[key].(type) {
+		case float64:
+			return value
+		case string:
+			parsed, _ := strconv.ParseFloat(strings.TrimSpace(value), 64)
+			return parsed
+		}
+		return 0
+	}
+	bounty.Created = int64(number("created"))
+	if price := number("price"); price > 0 {
+		bounty.Price = uint(price)
+	}
+
+	if assignee, ok := wanted["assignee"].(map[string]interface{}); ok {
+		bounty.Assignee, _ = assignee["owner_pubkey"].(string)
+	}
+
+	// the languages were picked from a list of {label, value} options
+	languages, _ := wanted["codingLanguage"].([]interface{})
+	for _, language := range languages {
+		switch language := language.(type) {
+		case string:
+			bounty.CodingLanguages = append(bounty.CodingLanguages, language)
+		case map[string]interface{}:
+			if value, ok := language["value"].(string); ok && value != "" {
+				bounty.CodingLanguages = append(bounty.CodingLanguages, value)
+			} else if label, ok := language["label"].(string); ok && label != "" {
+				bounty.CodingLanguages = append(bounty.CodingLanguages, label)
+			}
+		}
+	}
+
+	return bounty, bounty.Title != "" && bounty.Created != 0
+}
+
+// BountyMismatches names the fields a migrated bounty row no longer agrees
+// on with its legacy bounty
+func BountyMismatches(legacy NewBounty, row NewBounty) []string {
+	fields := []string{}
+	if legacy.Title != strings.TrimSpace(row.Title) {
+		fields = append(fields, "title")
+	}
+	if legacy.Price != row.Price {
+		fields = append(fields, "price")
+	}
+	if legacy.Paid != row.Paid {
+		fields = append(fields, "paid")
+	}
+	if legacy.Assignee != row.Assignee {
+		fields = append(fields, "assignee")
+	}
+	return fields
+}
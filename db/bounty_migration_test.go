@@ -0,0 +1,71 @@
+package db
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLegacyWantedBounty(t *testing.T) {
+	wanted := func(raw string) map[string]interface{} {
+		entry := map[string]interface{}{}
+		if err := json.Unmarshal([]byte(raw), &entry); err != nil {
+			t.Fatal(err)
+		}
+		return entry
+	}
+
+	t.Run("should read a bounty from a wanted entry", func(t *testing.T) {
+		bounty, ok := LegacyWantedBounty("owner-pubkey", wanted(`{
+			"title": " Fix the login ",
+			"price": 1500,
+			"created": 1650000000,
+			"paid": true,
+			"type": "coding_task",
+			"ticketUrl": "https://github.com/stakwork/sphinx-tribes/issues/1",
+			"description": "Returns a Vec<String>, see **login**",
+			"assignee": {"owner_pubkey": "hunter-pubkey", "owner_alias": "Hunter"},
+			"codingLanguage": [{"label": "Go", "value": "Golang"}, {"label": "Rust"}, "Typescript"]
+		}`))
+
+		assert.True(t, ok)
+		assert.Equal(t, "owner-pubkey", bounty.OwnerID)
+		assert.Equal(t, "Fix the login", bounty.Title)
+		assert.Equal(t, uint(1500), bounty.Price)
+		assert.Equal(t, int64(1650000000), bounty.Created)
+		assert.True(t, bounty.Paid)
+		assert.True(t, bounty.Show)
+		assert.Equal(t, "hunter-pubkey", bounty.Assignee)
+		assert.Equal(t, "Returns a Vec<String>, see **login**", bounty.Description)
+		assert.Equal(t, pq.StringArray{"Golang", "Rust", "Typescript"}, bounty.CodingLanguages)
+	})
+
+	t.Run("should read a price sent as a string and keep a hidden bounty hidden", func(t *testing.T) {
+		bounty, ok := LegacyWantedBounty("owner-pubkey", wanted(`{"title": "Design", "price": "2000", "created": 1650000001, "show": false}`))
+
+		assert.True(t, ok)
+		assert.Equal(t, uint(2000), bounty.Price)
+		assert.False(t, bounty.Show)
+		assert.Equal(t, pq.StringArray{}, bounty.CodingLanguages)
+	})
+
+	t.Run("should skip entries without a title or a created time", func(t *testing.T) {
+		_, ok := LegacyWantedBounty("owner-pubkey", wanted(`{"title": "No time"}`))
+		assert.False(t, ok)
+
+		_, ok = LegacyWantedBounty("owner-pubkey", wanted(`{"created": 1650000002}`))
+		assert.False(t, ok)
+
+		_, ok = LegacyWantedBounty("owner-pubkey", nil)
+		assert.False(t, ok)
+	})
+}
+
+func TestBountyMismatches(t *testing.T) {
+	legacy := NewBounty{Title: "Fix the login", Price: 1500, Paid: true, Assignee: "hunter-pubkey"}
+
+	assert.Empty(t, BountyMismatches(legacy, NewBounty{ID: 1, Title: "Fix the login ", Price: 1500, Paid: true, Assignee: "hunter-pubkey", Description: "edited since"}))
+	assert.Equal(t, []string{"price", "assignee"}, BountyMismatches(legacy, NewBounty{ID: 1, Title: "Fix the login", Price: 3000, Paid: true}))
+}
@@ -0,0 +1,79 @@
+package db
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrNotPendingAssignee = errors.New("the bounty is not waiting for this person to accept its terms")
+	ErrBountyTermsChanged = errors.New("the terms of the bounty changed")
+)
+
+// BountyTermsHash is the hex sha256 of the terms of a bounty
+func BountyTermsHash(terms string) string {
+	sum := sha256.Sum256([]byte(terms))
+	return hex.EncodeToString(sum[:])
+}
+
+// BountyTermsMessage is what the assignee of a bounty signs to accept the
+// terms of hash. It names the bounty so the signature can't be used for
+// another one with the same terms
+func BountyTermsMessage(bountyID uint, hash string) string {
+	return fmt.Sprintf("I accept the terms of bounty %d: %s", bountyID, hash)
+}
+
+func (db database) GetBountyTermsAcceptances(bountyID uint) ([]BountyTermsAcceptance, error) {
+	acceptances := []BountyTermsAcceptance{}
+	err := db.db.Where("bounty_id = ?", bountyID).Order("created DESC").Find(&acceptances).Error
+	return acceptances, err
+}
+
+// HasAcceptedBountyTerms reports if pubkey accepted the terms of hash of a
+// bounty
+func (db database) HasAcceptedBountyTerms(bountyID uint, pubkey string, hash string) bool {
+	var count int64
+	db.db.Model(&BountyTermsAcceptance{}).Where("bounty_id = ? AND pubkey = ? AND terms_hash = ?", bountyID, pubkey, hash).Count(&count)
+	return count > 0
+}
+
+// AcceptBountyTerms records the acceptance and completes the assignment of
+// the bounty to the person that was waiting to accept its terms. It fails
+// with ErrBountyTermsChanged when the terms are not those of the hash of
+// the acceptance anymore
+func (db database) AcceptBountyTerms(acceptance BountyTermsAcceptance) (NewBounty, error) {
+	bounty := NewBounty{}
+	err := db.gormTransaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", acceptance.BountyID).First(&bounty).Error; err != nil {
+			return err
+		}
+		if bounty.PendingAssignee == "" || bounty.PendingAssignee != acceptance.Pubkey {
+			return ErrNotPendingAssignee
+		}
+		if BountyTermsHash(bounty.Terms) != acceptance.TermsHash {
+			return ErrBountyTermsChanged
+		}
+
+		now := time.Now()
+		acceptance.Created = &now
+		if err := tx.Create(&acceptance).Error; err != nil {
+			return err
+		}
+		err := tx.Model(&NewBounty{}).Where("id = ?", bounty.ID).
+			Updates(map[string]interface{}{"assignee": acceptance.Pubkey, "pending_assignee": "", "assigned_date": &now, "updated": &now}).Error
+		if err != nil {
+			return err
+		}
+		if err := openContactExchange(tx, bounty.ID); err != nil {
+			return err
+		}
+		return tx.Where("id = ?", bounty.ID).First(&bounty).Error
+	})
+	return bounty, err
+}
@@ -0,0 +1,219 @@
+package db
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/xid"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	bountyViewsKey   = "bounty_views:counts"
+	bountyViewersKey = "bounty_views:viewers"
+)
+
+// popularBountyOrder is the order of the bounty lists asked with
+// sort=popular, the most viewed bounties first
+const popularBountyOrder = "view_count DESC, unique_viewers DESC, created"
+
+// bountyViewsBackend buffers the views of bounties between two flushes, so a
+// view doesn't write to the bounty table
+type bountyViewsBackend interface {
+	add(bountyId uint, views BountyViews)
+	take() map[uint]BountyViews
+}
+
+type redisBountyViews struct{}
+
+func (redisBountyViews) add(bountyId uint, views BountyViews) {
+	id := strconv.FormatUint(uint64(bountyId), 10)
+	pipe := RedisClient.TxPipeline()
+	pipe.HIncrBy(ctx, bountyViewsKey, id, views.Views)
+	for _, viewer := range views.Viewers {
+		pipe.SAdd(ctx, bountyViewersKey, id+":"+viewer)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		fmt.Println("[bounty views] redis add error", err)
+	}
+}
+
+// take renames the buffers before reading them, the views recorded while
+// they are read go to new ones. Each flush renames them to its own keys so
+// two instances flushing at once don't overwrite each other
+func (redisBountyViews) take() map[uint]BountyViews {
+	taken := map[uint]BountyViews{}
+	suffix := ":flushing:" + xid.New().String()
+	pipe := RedisClient.TxPipeline()
+	pipe.Rename(ctx, bountyViewsKey, bountyViewsKey+suffix)
+	pipe.Rename(ctx, bountyViewersKey, bountyViewersKey+suffix)
+	// a buffer without views can't be renamed, that is not an error
+	pipe.Exec(ctx)
+
+	counts := RedisClient.HGetAll(ctx, bountyViewsKey+suffix).Val()
+	for id, count := range counts {
+		bountyId, err := strconv.ParseUint(id, 10, 64)
+		views, _ := strconv.ParseInt(count, 10, 64)
+		if err != nil || views <= 0 {
+			continue
+		}
+		taken[uint(bountyId)] = BountyViews{Views: views}
+	}
+	for _, member := range RedisClient.SMembers(ctx, bountyViewersKey+suffix).Val() {
+		parts := strings.SplitN(member, ":", 2)
+		bountyId, err := strconv.ParseUint(parts[0], 10, 64)
+		if err != nil || len(parts) != 2 {
+			continue
+		}
+		views := taken[uint(bountyId)]
+		views.Viewers = append(views.Viewers, parts[1])
+		taken[uint(bountyId)] = views
+	}
+	RedisClient.Del(ctx, bountyViewsKey+suffix, bountyViewersKey+suffix)
+	return taken
+}
+
+type memoryBountyViews struct {
+	mu      sync.Mutex
+	views   map[uint]int64
+	viewers map[uint]map[string]bool
+}
+
+func newMemoryBountyViews() *memoryBountyViews {
+	return &memoryBountyViews{views: map[uint]int64{}, viewers: map[uint]map[string]bool{}}
+}
+
+func (m *memoryBountyViews) add(bountyId uint, views BountyViews) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.views[bountyId] += views.Views
+	if m.viewers[bountyId] == nil {
+		m.viewers[bountyId] = map[string]bool{}
+	}
+	for _, viewer := range views.Viewers {
+		m.viewers[bountyId][viewer] = true
+	}
+}
+
+func (m *memoryBountyViews) take() map[uint]BountyViews {
+	m.mu.Lock()
+	views, viewers := m.views, m.viewers
+	m.views, m.viewers = map[uint]int64{}, map[uint]map[string]bool{}
+	m.mu.Unlock()
+
+	taken := map[uint]BountyViews{}
+	for bountyId, count := range views {
+		taken[bountyId] = BountyViews{Views: count}
+	}
+	for bountyId, set := range viewers {
+		entry := taken[bountyId]
+		for viewer := range set {
+			entry.Viewers = append(entry.Viewers, viewer)
+		}
+		taken[bountyId] = entry
+	}
+	return taken
+}
+
+var bountyViews bountyViewsBackend = newMemoryBountyViews()
+
+// InitBountyViews buffers the bounty views in redis when it is reachable,
+// so the instances share one buffer, otherwise they stay in memory
+func InitBountyViews() {
+	if RedisClient != nil && RedisError == nil {
+		bountyViews = redisBountyViews{}
+		fmt.Println("[bounty views] using redis")
+	}
+}
+
+// viewerKey stands for a viewer without keeping their pubkey or address
+func viewerKey(viewer string) string {
+	sum := sha256.Sum256([]byte(viewer))
+	return hex.EncodeToString(sum[:16])
+}
+
+// RecordBountyView counts a view of a bounty by viewer. It is buffered until
+// the next FlushBountyViews
+func RecordBountyView(bountyId uint, viewer string) {
+	views := BountyViews{Views: 1}
+	if viewer != "" {
+		views.Viewers = []string{viewerKey(viewer)}
+	}
+	bountyViews.add(bountyId, views)
+}
+
+// FlushBountyViews writes the buffered views to the bounties. The views are
+// buffered again when they can't be written
+func FlushBountyViews(database Database) error {
+	taken := bountyViews.take()
+	if len(taken) == 0 {
+		return nil
+	}
+	if err := database.AddBountyViews(taken); err != nil {
+		for bountyId, views := range taken {
+			bountyViews.add(bountyId, views)
+		}
+		return err
+	}
+	return nil
+}
+
+// WatchBountyViews flushes the bounty views every interval, and once more
+// when ctx is done
+func WatchBountyViews(ctx context.Context, database Database, interval time.Duration) {
+	for {
+		select {
+		case <-ctx.Done():
+			FlushBountyViews(database)
+			return
+		case <-time.After(interval):
+		}
+		if err := FlushBountyViews(database); err != nil {
+			fmt.Println("[bounty views] could not flush the views", err)
+		}
+	}
+}
+
+// bountySort is the sort of a bounty list, sort=popular takes over from
+// sortBy and direction
+func bountySort(r *http.Request, sortBy string, direction string) (string, string) {
+	if r != nil && r.URL.Query().Get("sort") == "popular" {
+		return popularBountyOrder, "DESC"
+	}
+	return sortBy, direction
+}
+
+// AddBountyViews adds the views of each bounty to its counts. A viewer is
+// unique the first time they are stored for the bounty
+func (db database) AddBountyViews(views map[uint]BountyViews) error {
+	return db.transaction(func(tx database) error {
+		now := time.Now()
+		for bountyId, entry := range views {
+			var unique int64
+			if len(entry.Viewers) > 0 {
+				viewers := make([]BountyViewer, 0, len(entry.Viewers))
+				for _, viewer := range entry.Viewers {
+					viewers = append(viewers, BountyViewer{BountyID: bountyId, Viewer: viewer, Created: &now})
+				}
+				result := tx.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&viewers)
+				if result.Error != nil {
+					return result.Error
+				}
+				unique = result.RowsAffected
+			}
+			err := tx.db.Exec("UPDATE bounty SET view_count = view_count + ?, unique_viewers = unique_viewers + ? WHERE id = ?",
+				entry.Views, unique, bountyId).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
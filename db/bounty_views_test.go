@@ -0,0 +1,54 @@
+package db
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMemoryBountyViews(t *testing.T) {
+	buffer := newMemoryBountyViews()
+	buffer.add(1, BountyViews{Views: 1, Viewers: []string{"a"}})
+	buffer.add(1, BountyViews{Views: 1, Viewers: []string{"a"}})
+	buffer.add(1, BountyViews{Views: 1, Viewers: []string{"b"}})
+	buffer.add(2, BountyViews{Views: 3})
+
+	taken := buffer.take()
+	if taken[1].Views != 3 || len(taken[1].Viewers) != 2 {
+		t.Errorf("expected 3 views by 2 viewers, got %+v", taken[1])
+	}
+	if taken[2].Views != 3 || len(taken[2].Viewers) != 0 {
+		t.Errorf("expected 3 views without viewers, got %+v", taken[2])
+	}
+	if again := buffer.take(); len(again) != 0 {
+		t.Errorf("expected the buffer to be empty after a take, got %+v", again)
+	}
+}
+
+func TestRecordBountyView(t *testing.T) {
+	defer func(backend bountyViewsBackend) { bountyViews = backend }(bountyViews)
+	bountyViews = newMemoryBountyViews()
+
+	RecordBountyView(7, "pubkey:viewer")
+	RecordBountyView(7, "pubkey:viewer")
+	RecordBountyView(7, "")
+
+	taken := bountyViews.take()
+	if taken[7].Views != 3 || len(taken[7].Viewers) != 1 {
+		t.Fatalf("expected 3 views by 1 viewer, got %+v", taken[7])
+	}
+	if taken[7].Viewers[0] == "pubkey:viewer" {
+		t.Error("the viewer is stored as is")
+	}
+}
+
+func TestBountySort(t *testing.T) {
+	sortBy, direction := bountySort(httptest.NewRequest("GET", "/gobounties/all?sort=popular&sortBy=created", nil), "created", "desc")
+	if sortBy != popularBountyOrder || direction != "DESC" {
+		t.Errorf("expected the popular order, got %s %s", sortBy, direction)
+	}
+
+	sortBy, direction = bountySort(httptest.NewRequest("GET", "/gobounties/all?sortBy=price", nil), "price", "asc")
+	if sortBy != "price" || direction != "asc" {
+		t.Errorf("expected the order of the request, got %s %s", sortBy, direction)
+	}
+}
@@ -0,0 +1,256 @@
+package db
+
+import (
+	"errors"
+	"regexp"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrUnknownCategory is the error of a slug no category has
+	ErrUnknownCategory = errors.New("unknown category")
+	// ErrCategoryCycle is the error of a category moved under itself or
+	// one of its descendants
+	ErrCategoryCycle = errors.New("a category can't be moved under itself")
+	// ErrCategoryHasChildren is the error of deleting a category that
+	// still has categories under it
+	ErrCategoryHasChildren = errors.New("the category has subcategories")
+)
+
+var slugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// the categories under the slugs, with all their descendants
+const categorySubtreeSQL = `WITH RECURSIVE subtree AS (
+		SELECT id FROM categories WHERE slug IN ?
+		UNION SELECT categories.id FROM categories JOIN subtree ON categories.parent_id = subtree.id
+	) SELECT id FROM subtree`
+
+// CategoryTree nests the categories under their parents, siblings ordered
+// by position then name. A category whose parent is missing is at the top
+func CategoryTree(categories []Category) []Category {
+	children := map[uint][]Category{}
+	ids := map[uint]bool{}
+	for _, category := range categories {
+		ids[category.ID] = true
+	}
+	roots := []Category{}
+	for _, category := range categories {
+		if category.ParentID != nil && ids[*category.ParentID] {
+			children[*category.ParentID] = append(children[*category.ParentID], category)
+		} else {
+			roots = append(roots, category)
+		}
+	}
+
+	var nest func(level []Category, seen map[uint]bool) []Category
+	nest = func(level []Category, seen map[uint]bool) []Category {
+		sort.SliceStable(level, func(i, j int) bool {
+			if level[i].Position != level[j].Position {
+				return level[i].Position < level[j].Position
+			}
+			return level[i].Name < level[j].Name
+		})
+		for i := range level {
+			// a cycle left by hand in the table stops here
+			if seen[level[i].ID] {
+				continue
+			}
+			seen[level[i].ID] = true
+			level[i].Children = nest(children[level[i].ID], seen)
+		}
+		return level
+	}
+	return nest(roots, map[uint]bool{})
+}
+
+// GetCategories returns every category, flat
+func (db database) GetCategories() ([]Category, error) {
+	categories := []Category{}
+	err := db.db.Order("position, name").Find(&categories).Error
+	return categories, err
+}
+
+// CreateOrEditCategory creates the category of the slug of the request or
+// renames and moves it. It fails with ErrUnknownCategory when the parent
+// doesn't exist and ErrCategoryCycle when it is the category or under it
+func (db database) CreateOrEditCategory(request CategoryRequest) (Category, error) {
+	category := Category{}
+	now := time.Now()
+	err := db.gormTransaction(func(tx *gorm.DB) error {
+		if err := tx.Where("slug = ?", request.Slug).Find(&category).Error; err != nil {
+			return err
+		}
+
+		var parentID *uint
+		if request.ParentSlug != "" {
+			parent := Category{}
+			if err := tx.Where("slug = ?", request.ParentSlug).Find(&parent).Error; err != nil {
+				return err
+			}
+			if parent.ID == 0 {
+				return ErrUnknownCategory
+			}
+			if category.ID != 0 {
+				var under int64
+				if err := tx.Raw("SELECT COUNT(*) FROM ("+categorySubtreeSQL+") AS subtree WHERE id = ?", []string{request.Slug}, parent.ID).Scan(&under).Error; err != nil {
+					return err
+				}
+				if under > 0 {
+					return ErrCategoryCycle
+				}
+			}
+			parentID = &parent.ID
+		}
+
+		category.Slug = request.Slug
+		category.Name = request.Name
+		category.ParentID = parentID
+		category.Position = request.Position
+		category.Updated = &now
+		if category.ID == 0 {
+			category.Created = &now
+		}
+		return tx.Save(&category).Error
+	})
+	return category, err
+}
+
+// DeleteCategory deletes a category without subcategories, which unfiles
+// its tribes
+func (db database) DeleteCategory(slug string) error {
+	return db.gormTransaction(func(tx *gorm.DB) error {
+		category := Category{}
+		if err := tx.Where("slug = ?", slug).Find(&category).Error; err != nil {
+			return err
+		}
+		if category.ID == 0 {
+			return ErrUnknownCategory
+		}
+		var children int64
+		if err := tx.Model(&Category{}).Where("parent_id = ?", category.ID).Count(&children).Error; err != nil {
+			return err
+		}
+		if children > 0 {
+			return ErrCategoryHasChildren
+		}
+		if err := tx.Where("category_id = ?", category.ID).Delete(&TribeCategory{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&category).Error
+	})
+}
+
+// SetTribeCategories files the tribe under the categories of the slugs, in
+// place of its current ones. It fails with ErrUnknownCategory when a slug
+// has no category
+func (db database) SetTribeCategories(tribeUuid string, slugs []string) error {
+	return db.gormTransaction(func(tx *gorm.DB) error {
+		categories := []Category{}
+		if len(slugs) > 0 {
+			if err := tx.Where("slug IN ?", slugs).Find(&categories).Error; err != nil {
+				return err
+			}
+		}
+		found := map[string]bool{}
+		for _, category := range categories {
+			found[category.Slug] = true
+		}
+		for _, slug := range slugs {
+			if !found[slug] {
+				return ErrUnknownCategory
+			}
+		}
+
+		if err := tx.Where("tribe_uuid = ?", tribeUuid).Delete(&TribeCategory{}).Error; err != nil {
+			return err
+		}
+		if len(categories) == 0 {
+			return nil
+		}
+		rows := make([]TribeCategory, len(categories))
+		for i, category := range categories {
+			rows[i] = TribeCategory{TribeUuid: tribeUuid, CategoryID: category.ID}
+		}
+		return tx.Create(&rows).Error
+	})
+}
+
+// GetTribesCategories returns the category slugs of each of the tribes
+func (db database) GetTribesCategories(uuids []string) (map[string][]string, error) {
+	slugs := map[string][]string{}
+	if len(uuids) == 0 {
+		return slugs, nil
+	}
+	rows := []struct {
+		TribeUuid string
+		Slug      string
+	}{}
+	err := db.db.Table("tribe_categories").
+		Select("tribe_categories.tribe_uuid, categories.slug").
+		Joins("JOIN categories ON categories.id = tribe_categories.category_id").
+		Where("tribe_categories.tribe_uuid IN ?", uuids).
+		Order("categories.position, categories.name").
+		Scan(&rows).Error
+	for _, row := range rows {
+		slugs[row.TribeUuid] = append(slugs[row.TribeUuid], row.Slug)
+	}
+	return slugs, err
+}
+
+// attachTribeCategories sets the category slugs of the tribes
+func (db database) attachTribeCategories(tribes []Tribe) {
+	uuids := make([]string, len(tribes))
+	for i, tribe := range tribes {
+		uuids[i] = tribe.UUID
+	}
+	slugs, err := db.GetTribesCategories(uuids)
+	if err != nil {
+		return
+	}
+	for i := range tribes {
+		tribes[i].Categories = slugs[tribes[i].UUID]
+	}
+}
+
+// the tree migration 51 starts with, admins curate it from there. Like the
+// migration, it must not be edited
+var seededCategories = []struct {
+	slug, name, parent string
+}{
+	{"bitcoin", "Bitcoin", ""},
+	{"lightning", "Lightning", "bitcoin"},
+	{"nostr", "Nostr", "bitcoin"},
+	{"technology", "Technology", ""},
+	{"programming", "Programming", "technology"},
+	{"ai", "AI", "technology"},
+	{"business", "Business", ""},
+	{"education", "Education", ""},
+	{"entertainment", "Entertainment", ""},
+	{"music", "Music", "entertainment"},
+	{"gaming", "Gaming", "entertainment"},
+	{"podcasts", "Podcasts", "entertainment"},
+	{"news", "News", ""},
+	{"art", "Art", ""},
+	{"sports", "Sports", ""},
+	{"community", "Community", ""},
+}
+
+func seedCategories(tx *gorm.DB) error {
+	now := time.Now()
+	ids := map[string]uint{}
+	for i, seed := range seededCategories {
+		category := Category{Slug: seed.slug, Name: seed.name, Position: i, Created: &now, Updated: &now}
+		if seed.parent != "" {
+			parentID := ids[seed.parent]
+			category.ParentID = &parentID
+		}
+		if err := tx.Create(&category).Error; err != nil {
+			return err
+		}
+		ids[seed.slug] = category.ID
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCategoryTree(t *testing.T) {
+	id := func(v uint) *uint { return &v }
+	tree := CategoryTree([]Category{
+		{ID: 1, Slug: "bitcoin", Name: "Bitcoin", Position: 1},
+		{ID: 2, Slug: "nostr", Name: "Nostr", ParentID: id(1)},
+		{ID: 3, Slug: "lightning", Name: "Lightning", ParentID: id(1)},
+		{ID: 4, Slug: "art", Name: "Art", Position: 1},
+		{ID: 5, Slug: "orphan", Name: "Orphan", ParentID: id(9)},
+	})
+
+	slugs := []string{}
+	for _, category := range tree {
+		slugs = append(slugs, category.Slug)
+	}
+	assert.Equal(t, []string{"orphan", "art", "bitcoin"}, slugs)
+	assert.Equal(t, "lightning", tree[2].Children[0].Slug)
+	assert.Equal(t, "nostr", tree[2].Children[1].Slug)
+	assert.Empty(t, tree[1].Children)
+}
+
+func TestSlugValidation(t *testing.T) {
+	assert.NoError(t, Validate.Struct(CategoryRequest{Slug: "open-source", Name: "Open source"}))
+	assert.Error(t, Validate.Struct(CategoryRequest{Slug: "Open Source", Name: "Open source"}))
+	assert.Error(t, Validate.Struct(CategoryRequest{Slug: "open-", Name: "Open source"}))
+}
@@ -0,0 +1,133 @@
+package db
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+func (db database) CreateChat(chat Chat) (Chat, error) {
+	if chat.Uuid == "" {
+		return Chat{}, errors.New("chat uuid is required")
+	}
+
+	chat.Title = strings.TrimSpace(chat.Title)
+	if chat.Status == "" {
+		chat.Status = ActiveStatus
+	}
+
+	now := time.Now()
+	chat.Created = &now
+	chat.Updated = &now
+
+	if err := db.db.Create(&chat).Error; err != nil {
+		return Chat{}, err
+	}
+
+	return chat, nil
+}
+
+func (db database) UpdateChat(chat Chat) (Chat, error) {
+	if chat.Uuid == "" {
+		return Chat{}, errors.New("chat uuid is required")
+	}
+
+	now := time.Now()
+	chat.Updated = &now
+
+	result := db.db.Model(&Chat{}).Where("uuid = ?", chat.Uuid).Updates(map[string]interface{}{
+		"title":   strings.TrimSpace(chat.Title),
+		"status":  chat.Status,
+		"updated": chat.Updated,
+	})
+	if result.Error != nil {
+		return Chat{}, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return Chat{}, errors.New("no chat found to update")
+	}
+
+	return db.GetChatByUuid(chat.Uuid)
+}
+
+func (db database) GetChatByUuid(uuid string) (Chat, error) {
+	chat := Chat{}
+	result := db.db.Model(&Chat{}).Where("uuid = ?", uuid).First(&chat)
+	if result.RowsAffected == 0 {
+		return chat, errors.New("no chat found")
+	}
+	return chat, nil
+}
+
+func (db database) GetChatsForWorkspace(workspaceUuid string, status string) ([]Chat, error) {
+	chats := []Chat{}
+
+	query := db.db.Model(&Chat{}).Where("workspace_uuid = ?", workspaceUuid)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	if err := query.Order("updated DESC").Find(&chats).Error; err != nil {
+		return nil, err
+	}
+	return chats, nil
+}
+
+func (db database) AddChatMessage(message ChatMessage) (ChatMessage, error) {
+	if message.Uuid == "" || message.ChatUuid == "" {
+		return ChatMessage{}, errors.New("message uuid and chat uuid are required")
+	}
+
+	now := time.Now()
+	message.Created = &now
+	message.Updated = &now
+
+	if err := db.db.Create(&message).Error; err != nil {
+		return ChatMessage{}, err
+	}
+
+	// bump the chat so the most recent conversations sort first
+	db.db.Model(&Chat{}).Where("uuid = ?", message.ChatUuid).Update("updated", &now)
+
+	return message, nil
+}
+
+func (db database) UpdateChatMessage(message ChatMessage) (ChatMessage, error) {
+	if message.Uuid == "" {
+		return ChatMessage{}, errors.New("message uuid is required")
+	}
+
+	now := time.Now()
+	message.Updated = &now
+
+	result := db.db.Model(&ChatMessage{}).Where("uuid = ?", message.Uuid).Updates(map[string]interface{}{
+		"message": message.Message,
+		"status":  message.Status,
+		"updated": message.Updated,
+	})
+	if result.Error != nil {
+		return ChatMessage{}, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ChatMessage{}, errors.New("no chat message found to update")
+	}
+
+	return db.GetChatMessageByUuid(message.Uuid)
+}
+
+func (db database) GetChatMessageByUuid(uuid string) (ChatMessage, error) {
+	message := ChatMessage{}
+	result := db.db.Model(&ChatMessage{}).Where("uuid = ?", uuid).First(&message)
+	if result.RowsAffected == 0 {
+		return message, errors.New("no chat message found")
+	}
+	return message, nil
+}
+
+func (db database) GetChatMessagesForChatID(chatUuid string) ([]ChatMessage, error) {
+	messages := []ChatMessage{}
+	if err := db.db.Model(&ChatMessage{}).Where("chat_uuid = ?", chatUuid).Order("created ASC").Find(&messages).Error; err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
@@ -2,6 +2,7 @@ package db
 
 import (
 	"fmt"
+	"net/http"
 	"os"
 
 	"github.com/rs/xid"
@@ -24,10 +25,40 @@ func NewDatabaseConfig(db *gorm.DB) *database {
 	}
 }
 
+// forRequest scopes queries to the request context, so they are cancelled
+// when the client goes away or the route times out, and their spans are
+// children of the request span. r can be nil for callers outside of a
+// request
+func (db database) forRequest(r *http.Request) *gorm.DB {
+	if r == nil {
+		return db.db
+	}
+	return db.db.WithContext(r.Context())
+}
+
 // DB is the object
 var DB database
 
 func InitDB() {
+	ConnectDB()
+
+	// apply schema changes, see migrations.go
+	if err := DB.CheckMigrations(); err != nil {
+		panic(err)
+	}
+
+	people := DB.GetAllPeople()
+	for _, p := range people {
+		if p.Uuid == "" {
+			DB.AddUuidToPerson(p.ID, xid.New().String())
+		}
+	}
+
+}
+
+// ConnectDB opens the connection without touching the schema, it is used on
+// its own by the migrate commands
+func ConnectDB() {
 	dbURL := os.Getenv("DATABASE_URL")
 	fmt.Printf("db url : %v", dbURL)
 
@@ -55,33 +86,33 @@ func InitDB() {
 		panic(err)
 	}
 
-	DB.db = db
+	if err := db.Use(tracingPlugin{}); err != nil {
+		fmt.Println("could not register db tracing", err)
+	}
 
-	fmt.Println("db connected")
+	if err := db.Use(readCachePlugin{}); err != nil {
+		fmt.Println("could not register read cache invalidation", err)
+	}
 
-	// migrate table changes
-	db.AutoMigrate(&Tribe{})
-	db.AutoMigrate(&Person{})
-	db.AutoMigrate(&Channel{})
-	db.AutoMigrate(&LeaderBoard{})
-	db.AutoMigrate(&ConnectionCodes{})
-	db.AutoMigrate(&BountyRoles{})
-	db.AutoMigrate(&UserInvoiceData{})
-	db.AutoMigrate(&WorkspaceRepositories{})
-	db.AutoMigrate(&WorkspaceFeatures{})
-	db.AutoMigrate(&FeaturePhase{})
-	db.AutoMigrate(&FeatureStory{})
-
-	DB.MigrateTablesWithOrgUuid()
-	DB.MigrateOrganizationToWorkspace()
+	if err := db.Use(slowQueryPlugin{}); err != nil {
+		fmt.Println("could not register the slow query log", err)
+	}
 
-	people := DB.GetAllPeople()
-	for _, p := range people {
-		if p.Uuid == "" {
-			DB.AddUuidToPerson(p.ID, xid.New().String())
-		}
+	if err := db.Use(versionPlugin{}); err != nil {
+		fmt.Println("could not register bounty versions", err)
+	}
+
+	if err := db.Use(tenantGuardPlugin{}); err != nil {
+		fmt.Println("could not register the tenant guard", err)
 	}
 
+	if err := configurePool(db); err != nil {
+		fmt.Println("could not configure the db pool", err)
+	}
+
+	DB.db = db
+
+	fmt.Println("db connected")
 }
 
 const (
@@ -162,9 +193,10 @@ var Peopleupdatables = []string{
 	"owner_route_hint",
 	"price_to_meet", "updated",
 	"extras",
+	"timezone", "region",
 }
 
-var Validate *validator.Validate = validator.New()
+var Validate *validator.Validate = NewValidator()
 
 var Channelupdatables = []string{
 	"name", "deleted"}
@@ -177,112 +209,6 @@ func (db database) GetRolesCount() int64 {
 	return count
 }
 
-func (db database) MigrateTablesWithOrgUuid() {
-	if db.db.Migrator().HasTable("bounty") {
-		if !db.db.Migrator().HasColumn(Bounty{}, "workspace_uuid") {
-			db.db.AutoMigrate(&Bounty{})
-		} else {
-			db.db.AutoMigrate(&NewBounty{})
-		}
-	} else {
-		db.db.AutoMigrate(&NewBounty{})
-	}
-	if !db.db.Migrator().HasTable("budget_histories") {
-		if !db.db.Migrator().HasColumn(BudgetHistory{}, "workspace_uuid") {
-			db.db.AutoMigrate(&BudgetHistory{})
-		}
-	}
-	if !db.db.Migrator().HasTable("payment_histories") {
-		if !db.db.Migrator().HasColumn(PaymentHistory{}, "workspace_uuid") {
-			db.db.AutoMigrate(&PaymentHistory{})
-		} else {
-			db.db.AutoMigrate(&NewPaymentHistory{})
-		}
-	}
-	if !db.db.Migrator().HasTable("invoice_list") {
-		if !db.db.Migrator().HasColumn(InvoiceList{}, "workspace_uuid") {
-			db.db.AutoMigrate(&InvoiceList{})
-		} else {
-			db.db.AutoMigrate(&NewInvoiceList{})
-		}
-	}
-	if !db.db.Migrator().HasTable("bounty_budgets") {
-		if !db.db.Migrator().HasColumn(BountyBudget{}, "workspace_uuid") {
-			db.db.AutoMigrate(&BountyBudget{})
-		} else {
-			db.db.AutoMigrate(&NewBountyBudget{})
-		}
-	}
-	if !db.db.Migrator().HasTable("workspace_user_roles") {
-		if !db.db.Migrator().HasColumn(UserRoles{}, "workspace_uuid") {
-			db.db.AutoMigrate(&UserRoles{})
-		}
-	} else {
-		db.db.AutoMigrate(&WorkspaceUserRoles{})
-	}
-	if !db.db.Migrator().HasTable("workspaces") {
-		db.db.AutoMigrate(&Organization{})
-	} else {
-		db.db.AutoMigrate(&Workspace{})
-	}
-	if !db.db.Migrator().HasTable("workspace_users") {
-		db.db.AutoMigrate(&OrganizationUsers{})
-	} else {
-		db.db.AutoMigrate(&WorkspaceUsers{})
-	}
-}
-
-func (db database) MigrateOrganizationToWorkspace() {
-	if (db.db.Migrator().HasTable(&Organization{}) && !db.db.Migrator().HasTable("workspaces")) {
-		db.db.Migrator().RenameTable(&Organization{}, "workspaces")
-	}
-
-	if (db.db.Migrator().HasTable(&OrganizationUsers{}) && !db.db.Migrator().HasTable("workspace_users")) {
-		if db.db.Migrator().HasColumn(&OrganizationUsers{}, "org_uuid") {
-			db.db.Migrator().RenameColumn(&OrganizationUsers{}, "org_uuid", "workspace_uuid")
-		}
-		db.db.Migrator().RenameTable(&OrganizationUsers{}, "workspace_users")
-	}
-
-	if (db.db.Migrator().HasTable(&UserRoles{}) && !db.db.Migrator().HasTable("workspace_user_roles")) {
-		if db.db.Migrator().HasColumn(&UserRoles{}, "org_uuid") {
-			db.db.Migrator().RenameColumn(&UserRoles{}, "org_uuid", "workspace_uuid")
-		}
-
-		db.db.Migrator().RenameTable(&UserRoles{}, "workspace_user_roles")
-	}
-
-	if (db.db.Migrator().HasTable(&Bounty{})) {
-		if db.db.Migrator().HasColumn(&Bounty{}, "org_uuid") {
-			db.db.Migrator().RenameColumn(&Bounty{}, "org_uuid", "workspace_uuid")
-		}
-	}
-
-	if (db.db.Migrator().HasTable(&BountyBudget{})) {
-		if db.db.Migrator().HasColumn(&BountyBudget{}, "org_uuid") {
-			db.db.Migrator().RenameColumn(&BountyBudget{}, "org_uuid", "workspace_uuid")
-		}
-	}
-
-	if (db.db.Migrator().HasTable(&BudgetHistory{})) {
-		if db.db.Migrator().HasColumn(&BudgetHistory{}, "org_uuid") {
-			db.db.Migrator().RenameColumn(&BudgetHistory{}, "org_uuid", "workspace_uuid")
-		}
-	}
-
-	if (db.db.Migrator().HasTable(&PaymentHistory{})) {
-		if db.db.Migrator().HasColumn(&PaymentHistory{}, "org_uuid") {
-			db.db.Migrator().RenameColumn(&PaymentHistory{}, "org_uuid", "workspace_uuid")
-		}
-	}
-
-	if (db.db.Migrator().HasTable(&InvoiceList{})) {
-		if db.db.Migrator().HasColumn(&InvoiceList{}, "org_uuid") {
-			db.db.Migrator().RenameColumn(&InvoiceList{}, "org_uuid", "workspace_uuid")
-		}
-	}
-}
-
 func (db database) CreateRoles() {
 	db.db.Create(&ConfigBountyRoles)
 }
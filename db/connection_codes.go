@@ -0,0 +1,38 @@
+package db
+
+import "time"
+
+// RedeemConnectionCode claims the newest unused code and marks it used in a
+// single statement, so two clients can't be handed the same code. An empty
+// code is returned when none is left
+func (db database) RedeemConnectionCode() (ConnectionCodes, error) {
+	code := ConnectionCodes{}
+	now := time.Now()
+
+	result := db.db.Raw(`
+		UPDATE connectioncodes SET is_used = true, date_used = ?
+		WHERE id = (
+			SELECT id FROM connectioncodes
+			WHERE is_used = false
+			ORDER BY id DESC
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *`, &now).Scan(&code)
+
+	if result.Error != nil {
+		return ConnectionCodes{}, result.Error
+	}
+	return code, nil
+}
+
+// GetConnectionCodeStats counts the codes created and redeemed per campaign
+func (db database) GetConnectionCodeStats() ([]ConnectionCodeStats, error) {
+	stats := []ConnectionCodeStats{}
+	err := db.db.Model(&ConnectionCodes{}).
+		Select("campaign, COUNT(*) AS created, COUNT(*) FILTER (WHERE is_used) AS redeemed, MAX(date_used) AS last_redeemed").
+		Group("campaign").
+		Order("campaign ASC").
+		Scan(&stats).Error
+	return stats, err
+}
@@ -0,0 +1,70 @@
+package db
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrNoContactExchange is the error of a bounty that has no assignee to
+// exchange contacts with
+var ErrNoContactExchange = errors.New("the bounty is not assigned")
+
+// openContactExchange opens the contact exchange of a bounty with its
+// assignee, when it has one and the exchange isn't open yet. Every write
+// that assigns a bounty calls it
+func openContactExchange(tx *gorm.DB, bountyID uint) error {
+	if bountyID == 0 {
+		return nil
+	}
+	now := time.Now()
+	return tx.Exec(`INSERT INTO bounty_contact_exchanges (bounty_id, owner_pub_key, assignee_pub_key, created, updated)
+		SELECT id, owner_id, assignee, ?, ? FROM bounty WHERE id = ? AND assignee != '' AND deleted_at IS NULL
+		ON CONFLICT (bounty_id, assignee_pub_key) DO NOTHING`, now, now, bountyID).Error
+}
+
+// GetBountyContactExchange returns the contact exchange of a bounty with
+// its current assignee, opening it for bounties assigned before exchanges
+// existed. It fails with ErrNoContactExchange when the bounty has no
+// assignee
+func (db database) GetBountyContactExchange(bounty NewBounty) (BountyContactExchange, error) {
+	exchange := BountyContactExchange{}
+	if bounty.Assignee == "" {
+		return exchange, ErrNoContactExchange
+	}
+	if err := openContactExchange(db.db, bounty.ID); err != nil {
+		return exchange, err
+	}
+	result := db.db.Where("bounty_id = ? AND assignee_pub_key = ?", bounty.ID, bounty.Assignee).Find(&exchange)
+	if result.Error != nil {
+		return exchange, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return exchange, ErrNoContactExchange
+	}
+	return exchange, nil
+}
+
+// SetBountyContactConsent records if a party of a contact exchange agrees to
+// share their contact, the owner when owner is true and the assignee
+// otherwise
+func (db database) SetBountyContactConsent(exchange BountyContactExchange, owner bool, consent bool) (BountyContactExchange, error) {
+	column := "assignee_consent"
+	if owner {
+		column = "owner_consent"
+	}
+	now := time.Now()
+	err := db.db.Model(&BountyContactExchange{}).Where("id = ?", exchange.ID).
+		Updates(map[string]interface{}{column: consent, "updated": &now}).Error
+	if err != nil {
+		return exchange, err
+	}
+	if owner {
+		exchange.OwnerConsent = consent
+	} else {
+		exchange.AssigneeConsent = consent
+	}
+	exchange.Updated = &now
+	return exchange, nil
+}
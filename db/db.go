@@ -20,6 +20,8 @@ import (
 
 	"github.com/stakwork/sphinx-tribes/auth"
 	"github.com/stakwork/sphinx-tribes/utils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // check that update owner_pub_key does in fact throw error
@@ -47,8 +49,10 @@ func (db database) CreateOrEditTribe(m Tribe) (Tribe, error) {
 		m.Badges = []string{}
 	}
 
-	if db.db.Model(&m).Where("uuid = ?", m.UUID).Updates(&m).RowsAffected == 0 {
-		db.db.Create(&m)
+	// the spam review columns are only set by the spam job and the admins,
+	// the joined members only by joins, leaves and kicks
+	if db.db.Model(&m).Where("uuid = ?", m.UUID).Omit("shadow_listed", "spam_cleared", "joined_members").Updates(&m).RowsAffected == 0 {
+		db.db.Omit("shadow_listed", "spam_cleared", "joined_members").Create(&m)
 	}
 
 	db.db.Exec(`UPDATE tribes SET tsv =
@@ -248,7 +252,7 @@ func (db database) GetOpenGithubIssues(r *http.Request) (int64, error) {
 	ms := []GithubOpenIssueCount{}
 
 	// set limit
-	result := db.db.Raw(
+	result := db.forRequest(r).Raw(
 		`SELECT COUNT(value)
 		FROM (
 			SELECT * 
@@ -264,11 +268,25 @@ func (db database) GetOpenGithubIssues(r *http.Request) (int64, error) {
 
 func (db database) GetListedTribes(r *http.Request) []Tribe {
 	ms := []Tribe{}
+	offset, limit, sortBy, direction, _ := utils.GetPaginationParams(r)
+
+	db.listedTribesQuery(r).Offset(offset).Limit(limit).Order(sortBy + " " + direction).Find(&ms)
+	db.attachTribeCategories(ms)
+	return ms
+}
+
+func (db database) GetListedTribesCount(r *http.Request) int64 {
+	var count int64
+	db.listedTribesQuery(r).Count(&count)
+	return count
+}
+
+func (db database) listedTribesQuery(r *http.Request) *gorm.DB {
 	keys := r.URL.Query()
 	tags := keys.Get("tags") // this is a string of tags separated by commas
-	offset, limit, sortBy, direction, search := utils.GetPaginationParams(r)
+	_, _, _, _, search := utils.GetPaginationParams(r)
 
-	thequery := db.db.Offset(offset).Limit(limit).Order(sortBy+" "+direction).Where("(unlisted = 'f' OR unlisted is null) AND (deleted = 'f' OR deleted is null)").Where("LOWER(name) LIKE ?", "%"+search+"%")
+	thequery := db.forRequest(r).Model(&Tribe{}).Where("(unlisted = 'f' OR unlisted is null) AND (deleted = 'f' OR deleted is null) AND shadow_listed = false").Where("LOWER(name) LIKE ?", "%"+search+"%")
 
 	if tags != "" {
 		// pull out the tags and add them in here
@@ -277,14 +295,16 @@ func (db database) GetListedTribes(r *http.Request) []Tribe {
 			thequery = thequery.Where("'" + s + "'" + " = any (tags)")
 		}
 	}
-
-	thequery.Find(&ms)
-	return ms
+	// a category matches the tribes filed under it or its subcategories
+	if categories := keys.Get("categories"); categories != "" {
+		thequery = thequery.Where("tribes.uuid IN (SELECT tribe_uuid FROM tribe_categories WHERE category_id IN ("+categorySubtreeSQL+"))", strings.Split(categories, ","))
+	}
+	return thequery
 }
 
 func (db database) GetTribesByOwner(pubkey string) []Tribe {
 	ms := []Tribe{}
-	db.db.Where("owner_pub_key = ? AND (unlisted = 'f' OR unlisted is null) AND (deleted = 'f' OR deleted is null)", pubkey).Find(&ms)
+	db.db.Where("owner_pub_key = ? AND (unlisted = 'f' OR unlisted is null) AND (deleted = 'f' OR deleted is null) AND shadow_listed = false", pubkey).Find(&ms)
 	return ms
 }
 
@@ -316,15 +336,47 @@ func (db database) GetListedBots(r *http.Request) []Bot {
 	ms := []Bot{}
 	offset, limit, sortBy, direction, search := utils.GetPaginationParams(r)
 
-	// db.db.Where("(unlisted = 'f' OR unlisted is null) AND (deleted = 'f' OR deleted is null)").Find(&ms)
-	db.db.Offset(offset).Limit(limit).Order(sortBy+" "+direction).Where("(unlisted = 'f' OR unlisted is null) AND (deleted = 'f' OR deleted is null)").Where("LOWER(name) LIKE ?", "%"+search+"%").Find(&ms)
+	db.forRequest(r).Offset(offset).Limit(limit).Order(sortBy+" "+direction).Where("(unlisted = 'f' OR unlisted is null) AND (deleted = 'f' OR deleted is null)").Where("LOWER(name) LIKE ?", "%"+search+"%").Find(&ms)
 
 	return ms
 }
 
 func (db database) GetListedPeople(r *http.Request) []Person {
 	ms := []Person{}
-	offset, limit, sortBy, direction, search := utils.GetPaginationParams(r)
+	offset, limit, sortBy, direction, _ := utils.GetPaginationParams(r)
+
+	orderQuery := ""
+	limitQuery := ""
+
+	if sortBy != "" && direction != "" {
+		orderQuery = "ORDER BY " + sortBy + " " + direction
+	} else {
+		orderQuery = "ORDER BY " + sortBy + "" + "DESC"
+	}
+	if limit > -1 {
+		limitQuery = fmt.Sprintf("LIMIT %d  OFFSET %d", limit, offset)
+	}
+
+	filters, args := db.listedPeopleFilters(r)
+	query := "SELECT * FROM people WHERE " + filters
+
+	allQuery := query + " " + orderQuery + " " + limitQuery
+
+	db.forRequest(r).Raw(allQuery, args...).Find(&ms)
+	return ms
+}
+
+func (db database) GetListedPeopleCount(r *http.Request) int64 {
+	var count int64
+	filters, args := db.listedPeopleFilters(r)
+	db.forRequest(r).Raw("SELECT COUNT(*) FROM people WHERE "+filters, args...).Scan(&count)
+	return count
+}
+
+// listedPeopleFilters is the where clause shared by the listed people page
+// and its count, with the arguments of its filters
+func (db database) listedPeopleFilters(r *http.Request) (string, []interface{}) {
+	_, _, _, _, search := utils.GetPaginationParams(r)
 
 	// avoid dereference error, since r can be nil
 	var keys url.Values
@@ -336,20 +388,9 @@ func (db database) GetListedPeople(r *http.Request) []Person {
 	languageArray := strings.Split(languages, ",")
 	languageLength := len(languageArray)
 
-	orderQuery := ""
-	limitQuery := ""
 	searchQuery := ""
-
 	languageQuery := ""
 
-	if sortBy != "" && direction != "" {
-		orderQuery = "ORDER BY " + sortBy + " " + direction
-	} else {
-		orderQuery = "ORDER BY " + sortBy + "" + "DESC"
-	}
-	if limit > -1 {
-		limitQuery = fmt.Sprintf("LIMIT %d  OFFSET %d", limit, offset)
-	}
 	if search != "" {
 		searchQuery = fmt.Sprintf("AND LOWER(owner_alias) LIKE %[1]s OR LOWER(unique_name) LIKE %[1]s", "'%"+strings.ToLower(search)+"%'")
 	}
@@ -367,12 +408,21 @@ func (db database) GetListedPeople(r *http.Request) []Person {
 
 	}
 
-	query := "SELECT * FROM people WHERE (unlisted = 'f' OR unlisted is null) AND (deleted = 'f' OR deleted is null)"
-
-	allQuery := query + " " + searchQuery + " " + languageQuery + " " + orderQuery + " " + limitQuery
+	// verified=true only matches the languages verified by paid bounties
+	args := []interface{}{}
+	if keys.Get("verified") == "true" && languages != "" {
+		verified := []string{}
+		for _, val := range languageArray {
+			if val = strings.ToLower(strings.TrimSpace(val)); val != "" {
+				verified = append(verified, val)
+			}
+		}
+		languageQuery = "AND verified_skills && ?"
+		args = append(args, pq.Array(verified))
+	}
 
-	db.db.Raw(allQuery).Find(&ms)
-	return ms
+	locationQuery, locationArgs := db.locationFilters(r)
+	return "(unlisted = 'f' OR unlisted is null) AND (deleted = 'f' OR deleted is null) " + searchQuery + " " + languageQuery + locationQuery, append(args, locationArgs...)
 }
 
 func (db database) ListAllPeople(r *http.Request) []Person {
@@ -402,7 +452,7 @@ func (db database) ListAllPeople(r *http.Request) []Person {
 
 	query := "SELECT * from people WHERE (unlisted = 'f' OR unlisted is null AND (deleted = 'f' OR deleted is null)"
 	allQuery := query + languageQuery
-	db.db.Raw(allQuery).Find(&ms)
+	db.forRequest(r).Raw(allQuery).Find(&ms)
 	return ms
 }
 
@@ -420,7 +470,11 @@ func (db database) GetPeopleBySearch(r *http.Request) []Person {
 	// if search is empty, returns all
 
 	// return if like owner_alias, unique_name, or equals pubkey
-	db.db.Offset(offset).Limit(limit).Order(sortBy+" "+direction+" NULLS LAST").Where("(unlisted = 'f' OR unlisted is null) AND (deleted = 'f' OR deleted is null)").Where("LOWER(owner_alias) LIKE ?", "%"+search+"%").Or("LOWER(unique_name) LIKE ?", "%"+search+"%").Or("LOWER(owner_pub_key) = ?", search).Find(&ms)
+	query := db.forRequest(r).Offset(offset).Limit(limit).Order(sortBy + " " + direction + " NULLS LAST").Where("(unlisted = 'f' OR unlisted is null) AND (deleted = 'f' OR deleted is null)")
+	if locationQuery, args := db.locationFilters(r); locationQuery != "" {
+		query = query.Where(strings.TrimPrefix(locationQuery, " AND "), args...)
+	}
+	query.Where(db.db.Where("LOWER(owner_alias) LIKE ?", "%"+search+"%").Or("LOWER(unique_name) LIKE ?", "%"+search+"%").Or("LOWER(owner_pub_key) = ?", search)).Find(&ms)
 	return ms
 }
 
@@ -499,7 +553,7 @@ func (db database) GetListedPosts(r *http.Request) ([]PeopleExtra, error) {
 	}
 
 	// sort by newest
-	result := db.db.Offset(offset).Limit(limit).Order("arr.item_object->>'"+sortBy+"' DESC").Raw(
+	result := db.forRequest(r).Offset(offset).Limit(limit).Order("arr.item_object->>'"+sortBy+"' DESC").Raw(
 		rawQuery, "%"+search+"%").Find(&ms)
 
 	return ms, result.Error
@@ -561,9 +615,9 @@ func (db database) GetBountiesCount(r *http.Request) int64 {
 
 	var count int64
 
-	query := "SELECT COUNT(*) FROM bounty WHERE show != false"
+	query := "SELECT COUNT(*) FROM bounty WHERE show != false AND deleted_at IS NULL"
 	allQuery := query + " " + openQuery + " " + assignedQuery + " " + completedQuery + " " + paidQuery
-	db.db.Raw(allQuery).Scan(&count)
+	db.forRequest(r).Raw(allQuery).Scan(&count)
 	return count
 }
 
@@ -573,10 +627,10 @@ func (db database) GetFilterStatusCount() FilterStattuCount {
 	var completedCount int64
 	var paidCount int64
 
-	db.db.Model(&Bounty{}).Where("show != false").Where("assignee = ''").Where("paid != true").Count(&openCount)
-	db.db.Model(&Bounty{}).Where("show != false").Where("assignee != ''").Where("paid != true").Count(&assignedCount)
-	db.db.Model(&Bounty{}).Where("show != false").Where("assignee != ''").Where("completed = true").Where("paid != true").Count(&completedCount)
-	db.db.Model(&Bounty{}).Where("show != false").Where("assignee != ''").Where("paid = true").Count(&paidCount)
+	db.db.Model(&NewBounty{}).Where("show != false").Where("assignee = ''").Where("paid != true").Count(&openCount)
+	db.db.Model(&NewBounty{}).Where("show != false").Where("assignee != ''").Where("paid != true").Count(&assignedCount)
+	db.db.Model(&NewBounty{}).Where("show != false").Where("assignee != ''").Where("completed = true").Where("paid != true").Count(&completedCount)
+	db.db.Model(&NewBounty{}).Where("show != false").Where("assignee != ''").Where("paid = true").Count(&paidCount)
 
 	ms := FilterStattuCount{
 		Open:      openCount,
@@ -592,6 +646,7 @@ func (db database) GetWorkspaceBounties(r *http.Request, workspace_uuid string)
 	keys := r.URL.Query()
 	tags := keys.Get("tags") // this is a string of tags separated by commas
 	offset, limit, sortBy, direction, search := utils.GetPaginationParams(r)
+	sortBy, direction = bountySort(r, sortBy, direction)
 	open := keys.Get("Open")
 	assingned := keys.Get("Assigned")
 	completed := keys.Get("Completed")
@@ -658,9 +713,9 @@ func (db database) GetWorkspaceBounties(r *http.Request, workspace_uuid string)
 		}
 	}
 
-	query := `SELECT * FROM bounty WHERE workspace_uuid = '` + workspace_uuid + `'`
+	query := `SELECT * FROM bounty WHERE workspace_uuid = '` + workspace_uuid + `' AND deleted_at IS NULL`
 	allQuery := query + " " + statusQuery + " " + searchQuery + " " + languageQuery + " " + orderQuery + " " + limitQuery
-	theQuery := db.db.Raw(allQuery)
+	theQuery := db.forRequest(r).Raw(allQuery)
 
 	if tags != "" {
 		// pull out the tags and add them in here
@@ -732,9 +787,9 @@ func (db database) GetWorkspaceBountiesCount(r *http.Request, workspace_uuid str
 
 	var count int64
 
-	query := `SELECT COUNT(*) FROM bounty WHERE workspace_uuid = '` + workspace_uuid + `'`
+	query := `SELECT COUNT(*) FROM bounty WHERE workspace_uuid = '` + workspace_uuid + `' AND deleted_at IS NULL`
 	allQuery := query + " " + statusQuery + " " + searchQuery + " " + languageQuery
-	theQuery := db.db.Raw(allQuery)
+	theQuery := db.forRequest(r).Raw(allQuery)
 
 	if tags != "" {
 		// pull out the tags and add them in here
@@ -751,6 +806,7 @@ func (db database) GetWorkspaceBountiesCount(r *http.Request, workspace_uuid str
 
 func (db database) GetAssignedBounties(r *http.Request) ([]NewBounty, error) {
 	offset, limit, sortBy, direction, _ := utils.GetPaginationParams(r)
+	sortBy, direction = bountySort(r, sortBy, direction)
 	uuid := chi.URLParam(r, "uuid")
 	person := db.GetPersonByUuid(uuid)
 	pubkey := person.OwnerPubKey
@@ -792,14 +848,15 @@ func (db database) GetAssignedBounties(r *http.Request) ([]NewBounty, error) {
 
 	ms := []NewBounty{}
 
-	query := `SELECT * FROM public.bounty WHERE assignee = '` + pubkey + `' AND show != false`
+	query := `SELECT * FROM public.bounty WHERE assignee = '` + pubkey + `' AND show != false AND deleted_at IS NULL`
 	allQuery := query + " " + statusQuery + " " + orderQuery + " " + limitQuery
-	err := db.db.Raw(allQuery).Find(&ms).Error
+	err := db.forRequest(r).Raw(allQuery).Find(&ms).Error
 	return ms, err
 }
 
 func (db database) GetCreatedBounties(r *http.Request) ([]NewBounty, error) {
 	offset, limit, sortBy, direction, _ := utils.GetPaginationParams(r)
+	sortBy, direction = bountySort(r, sortBy, direction)
 	uuid := chi.URLParam(r, "uuid")
 	person := db.GetPersonByUuid(uuid)
 	pubkey := person.OwnerPubKey
@@ -842,16 +899,16 @@ func (db database) GetCreatedBounties(r *http.Request) ([]NewBounty, error) {
 
 	ms := []NewBounty{}
 
-	query := `SELECT * FROM public.bounty WHERE owner_id = '` + pubkey + `'`
+	query := `SELECT * FROM public.bounty WHERE owner_id = '` + pubkey + `' AND deleted_at IS NULL`
 	allQuery := query + " " + statusQuery + " " + orderQuery + " " + limitQuery
 
-	err := db.db.Raw(allQuery).Find(&ms).Error
+	err := db.forRequest(r).Raw(allQuery).Find(&ms).Error
 	return ms, err
 }
 
 func (db database) GetBountyById(id string) ([]NewBounty, error) {
 	ms := []NewBounty{}
-	err := db.db.Raw(`SELECT * FROM public.bounty WHERE id = '` + id + `'`).Find(&ms).Error
+	err := db.db.Raw(`SELECT * FROM public.bounty WHERE id = '` + id + `' AND deleted_at IS NULL`).Find(&ms).Error
 	return ms, err
 }
 
@@ -907,12 +964,12 @@ func (db database) GetNextBountyByCreated(r *http.Request) (uint, error) {
 		}
 	}
 
-	query := `SELECT id FROM public.bounty WHERE created > '` + created + `' AND show = true`
+	query := `SELECT id FROM public.bounty WHERE created > '` + created + `' AND show = true AND deleted_at IS NULL`
 	orderQuery := "ORDER BY created ASC LIMIT 1"
 
 	allQuery := query + " " + searchQuery + " " + statusQuery + " " + languageQuery + " " + orderQuery
 
-	err := db.db.Raw(allQuery).Find(&bountyId).Error
+	err := db.forRequest(r).Raw(allQuery).Find(&bountyId).Error
 	return bountyId, err
 }
 
@@ -972,12 +1029,12 @@ func (db database) GetPreviousBountyByCreated(r *http.Request) (uint, error) {
 		}
 	}
 
-	query := `SELECT id FROM public.bounty WHERE created < '` + created + `' AND show = true`
+	query := `SELECT id FROM public.bounty WHERE created < '` + created + `' AND show = true AND deleted_at IS NULL`
 	orderQuery := "ORDER BY created DESC LIMIT 1"
 
 	allQuery := query + " " + searchQuery + " " + statusQuery + " " + languageQuery + " " + orderQuery
 
-	err := db.db.Raw(allQuery).Find(&bountyId).Error
+	err := db.forRequest(r).Raw(allQuery).Find(&bountyId).Error
 	return bountyId, err
 }
 
@@ -1038,12 +1095,12 @@ func (db database) GetNextWorkspaceBountyByCreated(r *http.Request) (uint, error
 		}
 	}
 
-	query := `SELECT id FROM public.bounty WHERE workspace_uuid = '` + uuid + `' AND created > '` + created + `' AND show = true`
+	query := `SELECT id FROM public.bounty WHERE workspace_uuid = '` + uuid + `' AND created > '` + created + `' AND show = true AND deleted_at IS NULL`
 	orderQuery := "ORDER BY created ASC LIMIT 1"
 
 	allQuery := query + " " + searchQuery + " " + statusQuery + " " + languageQuery + " " + orderQuery
 
-	err := db.db.Raw(allQuery).Find(&bountyId).Error
+	err := db.forRequest(r).Raw(allQuery).Find(&bountyId).Error
 	return bountyId, err
 }
 
@@ -1104,36 +1161,165 @@ func (db database) GetPreviousWorkspaceBountyByCreated(r *http.Request) (uint, e
 		}
 	}
 
-	query := `SELECT id FROM public.bounty WHERE workspace_uuid = '` + uuid + `' AND created < '` + created + `' AND show = true`
+	query := `SELECT id FROM public.bounty WHERE workspace_uuid = '` + uuid + `' AND created < '` + created + `' AND show = true AND deleted_at IS NULL`
 	orderQuery := "ORDER BY created DESC LIMIT 1"
 
 	allQuery := query + " " + searchQuery + " " + statusQuery + " " + languageQuery + " " + orderQuery
 
-	err := db.db.Raw(allQuery).Find(&bountyId).Error
+	err := db.forRequest(r).Raw(allQuery).Find(&bountyId).Error
 	return bountyId, err
 }
 
 func (db database) GetBountyIndexById(id string) int64 {
 	var index int64
-	db.db.Raw(`SELECT position FROM(SELECT *, row_number() over( ORDER BY id DESC) as position FROM public.bounty) result WHERE id = '` + id + `' OR created = '` + id + `'`).Scan(&index)
+	db.db.Raw(`SELECT position FROM(SELECT *, row_number() over( ORDER BY id DESC) as position FROM public.bounty WHERE deleted_at IS NULL) result WHERE id = '` + id + `' OR created = '` + id + `'`).Scan(&index)
 	return index
 }
 
 func (db database) GetBountyDataByCreated(created string) ([]NewBounty, error) {
 	ms := []NewBounty{}
-	err := db.db.Raw(`SELECT * FROM public.bounty WHERE created = '` + created + `'`).Find(&ms).Error
+	err := db.db.Raw(`SELECT * FROM public.bounty WHERE created = '` + created + `' AND deleted_at IS NULL`).Find(&ms).Error
 	return ms, err
 }
 
-func (db database) AddBounty(b Bounty) (Bounty, error) {
-	db.db.Create(&b)
-	return b, nil
+// GetBountyResponses composes the list response for bounties, loading the
+// owners, assignees and workspaces of the whole page in two queries instead
+// of three per bounty
+func (db database) GetBountyResponses(bounties []NewBounty) []BountyResponse {
+	var bountyResponse []BountyResponse
+	if len(bounties) == 0 {
+		return bountyResponse
+	}
+
+	pubkeySet := map[string]bool{}
+	workspaceSet := map[string]bool{}
+	for _, bounty := range bounties {
+		pubkeySet[bounty.OwnerID] = true
+		pubkeySet[bounty.Assignee] = true
+		workspaceSet[bounty.WorkspaceUuid] = true
+	}
+
+	pubkeys := []string{}
+	for pubkey := range pubkeySet {
+		if pubkey != "" {
+			pubkeys = append(pubkeys, pubkey)
+		}
+	}
+	workspaceUuids := []string{}
+	for uuid := range workspaceSet {
+		if uuid != "" {
+			workspaceUuids = append(workspaceUuids, uuid)
+		}
+	}
+
+	people := []Person{}
+	if len(pubkeys) > 0 {
+		db.db.Where("owner_pub_key IN ? AND (deleted = false OR deleted is null)", pubkeys).Find(&people)
+	}
+	peopleByPubkey := map[string]Person{}
+	for _, person := range people {
+		peopleByPubkey[person.OwnerPubKey] = person
+	}
+
+	workspaces := []Workspace{}
+	if len(workspaceUuids) > 0 {
+		db.db.Model(&Workspace{}).Where("uuid IN ?", workspaceUuids).Find(&workspaces)
+	}
+	workspacesByUuid := map[string]Workspace{}
+	for _, workspace := range workspaces {
+		workspacesByUuid[workspace.Uuid] = workspace
+	}
+
+	for _, bounty := range bounties {
+		bountyResponse = append(bountyResponse, NewBountyResponse(bounty, peopleByPubkey[bounty.OwnerID], peopleByPubkey[bounty.Assignee], workspacesByUuid[bounty.WorkspaceUuid]))
+	}
+	return bountyResponse
+}
+
+// NewBountyResponse builds the list entry of a bounty from its already
+// loaded owner, assignee and workspace. Their contact keys and route hints
+// are left out, the two parties exchange them with GetBountyContactExchange
+func NewBountyResponse(bounty NewBounty, owner Person, assignee Person, workspace Workspace) BountyResponse {
+	return BountyResponse{
+		Bounty: NewBounty{
+			ID:                      bounty.ID,
+			OwnerID:                 bounty.OwnerID,
+			Paid:                    bounty.Paid,
+			Show:                    bounty.Show,
+			Type:                    bounty.Type,
+			Award:                   bounty.Award,
+			AssignedHours:           bounty.AssignedHours,
+			BountyExpires:           bounty.BountyExpires,
+			CommitmentFee:           bounty.CommitmentFee,
+			Price:                   bounty.Price,
+			Title:                   bounty.Title,
+			Tribe:                   bounty.Tribe,
+			Created:                 bounty.Created,
+			Assignee:                bounty.Assignee,
+			TicketUrl:               bounty.TicketUrl,
+			Description:             bounty.Description,
+			WantedType:              bounty.WantedType,
+			Deliverables:            bounty.Deliverables,
+			GithubDescription:       bounty.GithubDescription,
+			OneSentenceSummary:      bounty.OneSentenceSummary,
+			EstimatedSessionLength:  bounty.EstimatedSessionLength,
+			EstimatedCompletionDate: bounty.EstimatedCompletionDate,
+			OrgUuid:                 bounty.WorkspaceUuid,
+			WorkspaceUuid:           bounty.WorkspaceUuid,
+			Updated:                 bounty.Updated,
+			CodingLanguages:         bounty.CodingLanguages,
+			Completed:               bounty.Completed,
+			ViewCount:               bounty.ViewCount,
+			UniqueViewers:           bounty.UniqueViewers,
+		},
+		Assignee: Person{
+			ID:               assignee.ID,
+			Uuid:             assignee.Uuid,
+			OwnerPubKey:      assignee.OwnerPubKey,
+			OwnerAlias:       assignee.OwnerAlias,
+			UniqueName:       assignee.UniqueName,
+			Description:      assignee.Description,
+			Tags:             assignee.Tags,
+			Img:              assignee.Img,
+			Created:          assignee.Created,
+			Updated:          assignee.Updated,
+			LastLogin:        assignee.LastLogin,
+			PriceToMeet:      assignee.PriceToMeet,
+			TwitterConfirmed: assignee.TwitterConfirmed,
+		},
+		Owner: Person{
+			ID:               owner.ID,
+			Uuid:             owner.Uuid,
+			OwnerPubKey:      owner.OwnerPubKey,
+			OwnerAlias:       owner.OwnerAlias,
+			UniqueName:       owner.UniqueName,
+			Description:      owner.Description,
+			Tags:             owner.Tags,
+			Img:              owner.Img,
+			Created:          owner.Created,
+			Updated:          owner.Updated,
+			LastLogin:        owner.LastLogin,
+			PriceToMeet:      owner.PriceToMeet,
+			TwitterConfirmed: owner.TwitterConfirmed,
+		},
+		Organization: WorkspaceShort{
+			Name: workspace.Name,
+			Uuid: workspace.Uuid,
+			Img:  workspace.Img,
+		},
+		Workspace: WorkspaceShort{
+			Name: workspace.Name,
+			Uuid: workspace.Uuid,
+			Img:  workspace.Img,
+		},
+	}
 }
 
 func (db database) GetAllBounties(r *http.Request) []NewBounty {
 	keys := r.URL.Query()
 	tags := keys.Get("tags") // this is a string of tags separated by commas
 	offset, limit, sortBy, direction, search := utils.GetPaginationParams(r)
+	sortBy, direction = bountySort(r, sortBy, direction)
 	open := keys.Get("Open")
 	assingned := keys.Get("Assigned")
 	completed := keys.Get("Completed")
@@ -1219,11 +1405,11 @@ func (db database) GetAllBounties(r *http.Request) []NewBounty {
 		}
 	}
 
-	query := "SELECT * FROM public.bounty WHERE show != false"
+	query := "SELECT * FROM public.bounty WHERE show != false AND deleted_at IS NULL"
 
 	allQuery := query + " " + statusQuery + " " + searchQuery + " " + workspaceQuery + " " + languageQuery + " " + phaseUuidQuery + " " + phasePriorityQuery + " " + orderQuery + " " + limitQuery
 
-	theQuery := db.db.Raw(allQuery)
+	theQuery := db.forRequest(r).Raw(allQuery)
 
 	if tags != "" {
 		// pull out the tags and add them in here
@@ -1243,24 +1429,42 @@ func (db database) CreateOrEditBounty(b NewBounty) (NewBounty, error) {
 		return NewBounty{}, errors.New("no pub key")
 	}
 
-	if db.db.Model(&b).Where("id = ? OR owner_id = ? AND created = ?", b.ID, b.OwnerID, b.Created).Updates(&b).RowsAffected == 0 {
+	// the version comes back from the update, see versionPlugin
+	if db.db.Model(&b).Clauses(clause.Returning{Columns: []clause.Column{{Name: versionColumn}}}).Where("id = ? OR owner_id = ? AND created = ?", b.ID, b.OwnerID, b.Created).Updates(&b).RowsAffected == 0 {
 		db.db.Create(&b)
 	}
+	if b.Assignee != "" {
+		if err := openContactExchange(db.db, b.ID); err != nil {
+			return b, err
+		}
+	}
 	return b, nil
 }
 
-func (db database) UpdateBountyNullColumn(b NewBounty, column string) NewBounty {
+// LockBountyVersion locks the bounty row until the end of the transaction
+// and returns its version, 0 when there is no such bounty. Run it in WithTx
+// before an edit so the version can't move between the check and the write
+func (db database) LockBountyVersion(id uint) (int, error) {
+	versions := []int{}
+	err := db.db.Raw("SELECT version FROM bounty WHERE id = ? AND deleted_at IS NULL FOR UPDATE", id).Scan(&versions).Error
+	if err != nil || len(versions) == 0 {
+		return 0, err
+	}
+	return versions[0], nil
+}
+
+func (db database) UpdateBountyNullColumn(b NewBounty, column string) (NewBounty, error) {
 	columnMap := make(map[string]interface{})
 	columnMap[column] = ""
-	db.db.Model(&b).Where("created = ?", b.Created).UpdateColumns(&columnMap)
-	return b
+	err := db.db.Model(&b).Where("created = ?", b.Created).UpdateColumns(&columnMap).Error
+	return b, err
 }
 
-func (db database) UpdateBountyBoolColumn(b NewBounty, column string) NewBounty {
+func (db database) UpdateBountyBoolColumn(b NewBounty, column string) (NewBounty, error) {
 	columnMap := make(map[string]interface{})
 	columnMap[column] = false
-	db.db.Model(&b).Select(column).UpdateColumns(columnMap)
-	return b
+	err := db.db.Model(&b).Select(column).UpdateColumns(columnMap).Error
+	return b, err
 }
 
 func (db database) DeleteBounty(pubkey string, created string) (NewBounty, error) {
@@ -1283,6 +1487,11 @@ func (db database) GetBounty(id uint) NewBounty {
 
 func (db database) UpdateBounty(b NewBounty) (NewBounty, error) {
 	db.db.Where("created", b.Created).Updates(&b)
+	if b.Assignee != "" {
+		if err := openContactExchange(db.db, b.ID); err != nil {
+			return b, err
+		}
+	}
 	return b, nil
 }
 
@@ -1341,7 +1550,7 @@ func (db database) GetListedOffers(r *http.Request) ([]PeopleExtra, error) {
 	}
 
 	// sort by newest
-	result := db.db.Offset(offset).Limit(limit).Order("arr.item_object->>'"+sortBy+"' DESC").Raw(
+	result := db.forRequest(r).Offset(offset).Limit(limit).Order("arr.item_object->>'"+sortBy+"' DESC").Raw(
 		rawQuery, "%"+search+"%").Find(&ms)
 
 	return ms, result.Error
@@ -1366,6 +1575,7 @@ func (db database) DeleteTribe() (bool, error) {
 	if result.Error != nil {
 		return false, result.Error
 	}
+	InvalidateReadCache(TribesCacheKey)
 	return true, nil
 }
 
@@ -1553,7 +1763,7 @@ func (db database) CountDevelopers() int64 {
 
 func (db database) CountBounties() uint64 {
 	var count uint64
-	db.db.Raw(`Select COUNT(*) from bounty`).Scan(&count)
+	db.db.Raw(`Select COUNT(*) from bounty WHERE deleted_at IS NULL`).Scan(&count)
 	return count
 }
 
@@ -1659,13 +1869,13 @@ func (db database) GetBountiesLeaderboard() []LeaderData {
 (SELECT assignee as owner_pubkey, 
 COUNT(assignee) as total_bounties_completed
 From bounty 
-where paid=true and assignee != '' 
+where paid=true and assignee != '' and deleted_at IS NULL
 GROUP BY assignee) t1
  Right Join
 (SELECT assignee as owner_pubkey,  
 SUM(CAST(price as integer)) as total_sats_earned
 From bounty
-where paid=true and assignee != ''
+where paid=true and assignee != '' and deleted_at IS NULL
 GROUP BY assignee) t2
 ON t1.owner_pubkey = t2.owner_pubkey
 ORDER by total_sats_earned DESC`).Find(&ms)
@@ -1710,11 +1920,22 @@ func (db database) GetInvoice(payment_request string) NewInvoiceList {
 	return ms
 }
 
-func (db database) UpdateInvoice(payment_request string) NewInvoiceList {
-	ms := NewInvoiceList{}
-	db.db.Model(&NewInvoiceList{}).Where("payment_request = ?", payment_request).Update("status", true)
-	ms.Status = true
-	return ms
+// UpdateInvoice settles an invoice that is not settled yet. It reports
+// false when the invoice was already settled, so in a transaction only one
+// of concurrent calls settles it
+func (db database) UpdateInvoice(payment_request string) (bool, error) {
+	result := db.db.Model(&NewInvoiceList{}).
+		Where("payment_request = ? AND status = ?", payment_request, false).
+		Update("status", true)
+	return result.RowsAffected > 0, result.Error
+}
+
+// ReleaseInvoice puts back a settled invoice whose payment didn't go out,
+// so a later poll pays it
+func (db database) ReleaseInvoice(payment_request string) error {
+	return db.db.Model(&NewInvoiceList{}).
+		Where("payment_request = ? AND status = ?", payment_request, true).
+		Update("status", false).Error
 }
 
 func (db database) AddInvoice(invoice NewInvoiceList) NewInvoiceList {
@@ -0,0 +1,101 @@
+package db
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/patrickmn/go-cache"
+)
+
+const dedupPrefix = "dedup:"
+
+// DedupPending is the value of a claimed request that has no result yet
+const DedupPending = "pending"
+
+type dedupBackend interface {
+	claim(key string, ttl time.Duration) (string, bool)
+	set(key string, value string, ttl time.Duration)
+	release(key string)
+}
+
+type redisDedup struct{}
+
+func (redisDedup) claim(key string, ttl time.Duration) (string, bool) {
+	claimed, err := RedisClient.SetNX(ctx, dedupPrefix+key, DedupPending, ttl).Result()
+	if err != nil {
+		// without redis the request is let through rather than refused
+		fmt.Println("[dedup] redis claim error", err)
+		return "", true
+	}
+	if claimed {
+		return "", true
+	}
+	value, err := RedisClient.Get(ctx, dedupPrefix+key).Result()
+	if err != nil {
+		return "", true
+	}
+	return value, false
+}
+
+func (redisDedup) set(key string, value string, ttl time.Duration) {
+	if err := RedisClient.Set(ctx, dedupPrefix+key, value, ttl).Err(); err != nil {
+		fmt.Println("[dedup] redis set error", err)
+	}
+}
+
+func (redisDedup) release(key string) {
+	RedisClient.Del(ctx, dedupPrefix+key)
+}
+
+type memoryDedup struct {
+	cache *cache.Cache
+}
+
+func (m memoryDedup) claim(key string, ttl time.Duration) (string, bool) {
+	if err := m.cache.Add(key, DedupPending, ttl); err == nil {
+		return "", true
+	}
+	value, found := m.cache.Get(key)
+	if !found {
+		// expired between the add and the get
+		return "", m.cache.Add(key, DedupPending, ttl) == nil
+	}
+	s, _ := value.(string)
+	return s, false
+}
+
+func (m memoryDedup) set(key string, value string, ttl time.Duration) {
+	m.cache.Set(key, value, ttl)
+}
+
+func (m memoryDedup) release(key string) {
+	m.cache.Delete(key)
+}
+
+var dedup dedupBackend = memoryDedup{cache: cache.New(time.Minute, 2*time.Minute)}
+
+// InitDedup keeps the recent request keys in redis when it is reachable, so
+// every instance sees them, otherwise they stay in memory
+func InitDedup() {
+	if RedisClient != nil && RedisError == nil {
+		dedup = redisDedup{}
+		fmt.Println("[dedup] using redis")
+	}
+}
+
+// ClaimDedup claims a request key for ttl. When the key is already claimed
+// it returns false with the value stored by FinishDedup, DedupPending while
+// the first request is still running
+func ClaimDedup(key string, ttl time.Duration) (string, bool) {
+	return dedup.claim(key, ttl)
+}
+
+// FinishDedup stores the id of the resource the claimed request returned
+func FinishDedup(key string, id string, ttl time.Duration) {
+	dedup.set(key, id, ttl)
+}
+
+// ReleaseDedup drops a claim, a request that failed can be sent again
+func ReleaseDedup(key string) {
+	dedup.release(key)
+}
@@ -0,0 +1,130 @@
+package db
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/rs/xid"
+	"github.com/stakwork/sphinx-tribes/utils"
+	"gorm.io/gorm/clause"
+)
+
+// an assigned bounty without an update for this long is stale
+const staleBountyAge = 14 * 24 * time.Hour
+
+// CreateWorkspaceDigest summarizes the period of a workspace and saves it,
+// replacing an earlier digest of the same period so a retried run doesn't
+// add a second one. start is in the time zone of the workspace
+func (db database) CreateWorkspaceDigest(workspaceUuid string, start time.Time, end time.Time) (WorkspaceDigest, error) {
+	now := time.Now()
+	digest := WorkspaceDigest{
+		Uuid:          xid.New().String(),
+		WorkspaceUuid: workspaceUuid,
+		PeriodStart:   start,
+		PeriodEnd:     end,
+		Created:       &now,
+	}
+
+	counts := []struct {
+		into  *int64
+		query string
+		args  []interface{}
+	}{
+		{&digest.NewBounties, "SELECT COUNT(*) FROM bounty WHERE workspace_uuid = ? AND created >= ? AND created < ? AND deleted_at IS NULL",
+			[]interface{}{workspaceUuid, start.Unix(), end.Unix()}},
+		{&digest.Completed, "SELECT COUNT(*) FROM bounty WHERE workspace_uuid = ? AND completion_date >= ? AND completion_date < ? AND deleted_at IS NULL",
+			[]interface{}{workspaceUuid, start, end}},
+		{&digest.Paid, "SELECT COUNT(*) FROM bounty WHERE workspace_uuid = ? AND paid = true AND paid_date >= ? AND paid_date < ? AND deleted_at IS NULL",
+			[]interface{}{workspaceUuid, start, end}},
+		{&digest.SatsPaid, "SELECT COALESCE(SUM(amount), 0) FROM payment_histories WHERE workspace_uuid = ? AND payment_type = ? AND status = true AND created >= ? AND created < ?",
+			[]interface{}{workspaceUuid, Payment, start, end}},
+		{&digest.BudgetDelta, `SELECT COALESCE(SUM(CASE WHEN payment_type = ? THEN amount ELSE -amount END), 0) FROM payment_histories
+			WHERE workspace_uuid = ? AND payment_type IN ? AND status = true AND created >= ? AND created < ?`,
+			[]interface{}{Deposit, workspaceUuid, []PaymentType{Deposit, Withdraw, Payment, QuestBonus, Tip}, start, end}},
+	}
+	for _, c := range counts {
+		if err := db.db.Raw(c.query, c.args...).Scan(c.into).Error; err != nil {
+			return WorkspaceDigest{}, err
+		}
+	}
+
+	err := db.db.Model(&NewBounty{}).
+		Where("workspace_uuid = ? AND assignee != '' AND completed = false AND paid = false", workspaceUuid).
+		Where("COALESCE(updated, assigned_date) < ?", end.Add(-staleBountyAge)).
+		Order("id ASC").Pluck("id", &digest.StaleBountyIDs).Error
+	if err != nil {
+		return WorkspaceDigest{}, err
+	}
+	digest.StaleBounties = int64(len(digest.StaleBountyIDs))
+
+	// the deadlines are dates of the workspace, read in the time zone the
+	// period is in
+	open := []NewBounty{}
+	err = db.db.Model(&NewBounty{}).Select("id", "estimated_completion_date").
+		Where("workspace_uuid = ? AND completed = false AND paid = false AND estimated_completion_date != ''", workspaceUuid).
+		Order("id ASC").Find(&open).Error
+	if err != nil {
+		return WorkspaceDigest{}, err
+	}
+	digest.OverdueBountyIDs = pq.Int64Array{}
+	for _, bounty := range open {
+		if deadline, ok := BountyDeadline(bounty.EstimatedCompletionDate, start.Location()); ok && deadline.Before(end) {
+			digest.OverdueBountyIDs = append(digest.OverdueBountyIDs, int64(bounty.ID))
+		}
+	}
+	digest.OverdueBounties = int64(len(digest.OverdueBountyIDs))
+
+	err = db.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "workspace_uuid"}, {Name: "period_start"}},
+		DoUpdates: clause.AssignmentColumns([]string{"period_end", "new_bounties", "completed", "paid", "sats_paid",
+			"budget_delta", "stale_bounties", "stale_bounty_ids", "overdue_bounties", "overdue_bounty_ids", "created"}),
+	}).Create(&digest).Error
+	if err != nil {
+		return WorkspaceDigest{}, err
+	}
+
+	saved := WorkspaceDigest{}
+	err = db.db.Where("workspace_uuid = ? AND period_start = ?", workspaceUuid, start).First(&saved).Error
+	return saved, err
+}
+
+// GetWorkspaceDigests returns the digests of a workspace, the latest week
+// first
+func (db database) GetWorkspaceDigests(workspaceUuid string, r *http.Request) ([]WorkspaceDigest, error) {
+	digests := []WorkspaceDigest{}
+	offset, limit, _, _, _ := utils.GetPaginationParams(r)
+	if r.URL.Query().Get("limit") == "" {
+		limit = 12
+	}
+
+	query := db.db.Model(&WorkspaceDigest{}).Where("workspace_uuid = ?", workspaceUuid)
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if offset > 0 {
+		query = query.Offset(offset)
+	}
+
+	err := query.Order("period_start DESC").Find(&digests).Error
+	return digests, err
+}
+
+// GetDigestWorkspaces returns the workspaces the weekly digest is written
+// for, every one that is not deleted
+func (db database) GetDigestWorkspaces() ([]Workspace, error) {
+	workspaces := []Workspace{}
+	err := db.db.Where("deleted = false OR deleted IS NULL").Order("id ASC").Find(&workspaces).Error
+	return workspaces, err
+}
+
+// GetWorkspaceMemberPubkeys returns the owner and the users of a workspace
+func (db database) GetWorkspaceMemberPubkeys(workspace Workspace) ([]string, error) {
+	pubkeys := []string{}
+	err := db.db.Model(&WorkspaceUsers{}).Where("workspace_uuid = ? AND owner_pub_key != ?", workspace.Uuid, workspace.OwnerPubKey).
+		Distinct().Pluck("owner_pub_key", &pubkeys).Error
+	if workspace.OwnerPubKey != "" {
+		pubkeys = append([]string{workspace.OwnerPubKey}, pubkeys...)
+	}
+	return pubkeys, err
+}
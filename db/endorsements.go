@@ -0,0 +1,147 @@
+package db
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"time"
+)
+
+// the paid bounties an endorser needs for half of the weight of a full
+// endorsement. An endorser who was never paid for a bounty adds nothing, so
+// accounts endorsing each other can't raise their reputation
+const endorserHalfWeight = 5.0
+
+// EndorsementWeight is what an endorsement adds to the reputation of the
+// endorsee: up to 1 by the paid bounties of the endorser, halved every
+// halfLife of its age, and halved again when the endorsee endorsed the
+// endorser back. A halfLife of 0 doesn't decay
+func EndorsementWeight(endorserPaid int64, age time.Duration, halfLife time.Duration, mutual bool) float64 {
+	if endorserPaid <= 0 {
+		return 0
+	}
+	weight := float64(endorserPaid) / (float64(endorserPaid) + endorserHalfWeight)
+	if halfLife > 0 && age > 0 {
+		weight *= math.Pow(0.5, float64(age)/float64(halfLife))
+	}
+	if mutual {
+		weight /= 2
+	}
+	return weight
+}
+
+// Reputations adds to the paid bounties of each pubkey the weight of the
+// endorsements they received at a time. Only the latest endorsement of a
+// pair counts, endorsing again refreshes it
+func Reputations(pubkeys []string, paid map[string]int64, endorsements []PersonEndorsement, at time.Time, halfLife time.Duration) map[string]Reputation {
+	latest := map[[2]string]PersonEndorsement{}
+	for _, endorsement := range endorsements {
+		pair := [2]string{endorsement.Endorser, endorsement.Endorsee}
+		if current, ok := latest[pair]; !ok || laterThan(endorsement.Created, current.Created) {
+			latest[pair] = endorsement
+		}
+	}
+
+	reputations := map[string]Reputation{}
+	for _, pubkey := range pubkeys {
+		reputations[pubkey] = Reputation{Pubkey: pubkey, PaidBounties: paid[pubkey], Received: []PersonEndorsement{}}
+	}
+	for pair, endorsement := range latest {
+		reputation, ok := reputations[pair[1]]
+		if !ok {
+			continue
+		}
+		_, mutual := latest[[2]string{pair[1], pair[0]}]
+		age := time.Duration(0)
+		if endorsement.Created != nil {
+			age = at.Sub(*endorsement.Created)
+		}
+		reputation.Endorsements += EndorsementWeight(paid[pair[0]], age, halfLife, mutual)
+		reputation.Received = append(reputation.Received, endorsement)
+		reputations[pair[1]] = reputation
+	}
+	for pubkey, reputation := range reputations {
+		sort.Slice(reputation.Received, func(i, j int) bool {
+			return laterThan(reputation.Received[i].Created, reputation.Received[j].Created)
+		})
+		reputation.Endorsements = math.Round(reputation.Endorsements*100) / 100
+		reputation.Reputation = float64(reputation.PaidBounties) + reputation.Endorsements
+		reputations[pubkey] = reputation
+	}
+	return reputations
+}
+
+// laterThan reports if a is later than b, a missing time is the earliest
+func laterThan(a *time.Time, b *time.Time) bool {
+	if a == nil {
+		return false
+	}
+	return b == nil || a.After(*b)
+}
+
+// CreatePersonEndorsement saves an endorsement of endorsee by endorser
+func (db database) CreatePersonEndorsement(endorsement PersonEndorsement) (PersonEndorsement, error) {
+	if endorsement.Endorser == "" || endorsement.Endorsee == "" {
+		return endorsement, errors.New("endorser and endorsee are required")
+	}
+	if endorsement.Created == nil {
+		now := time.Now()
+		endorsement.Created = &now
+	}
+	err := db.db.Create(&endorsement).Error
+	return endorsement, err
+}
+
+// GetLastEndorsement returns the latest endorsement of endorsee by endorser
+func (db database) GetLastEndorsement(endorser string, endorsee string) (PersonEndorsement, error) {
+	endorsements := []PersonEndorsement{}
+	err := db.db.Where("endorser = ? AND endorsee = ?", endorser, endorsee).Order("created DESC").Limit(1).Find(&endorsements).Error
+	if err != nil {
+		return PersonEndorsement{}, err
+	}
+	if len(endorsements) == 0 {
+		return PersonEndorsement{}, errors.New("no endorsement")
+	}
+	return endorsements[0], nil
+}
+
+// GetReputations returns the reputation of each pubkey at a time, with the
+// endorsements they received
+func (db database) GetReputations(pubkeys []string, at time.Time, halfLife time.Duration) (map[string]Reputation, error) {
+	if len(pubkeys) == 0 {
+		return map[string]Reputation{}, nil
+	}
+
+	// the endorsements of the pubkeys, and the ones they gave to find the
+	// mutual ones
+	endorsements := []PersonEndorsement{}
+	if err := db.db.Where("endorsee IN ? OR endorser IN ?", pubkeys, pubkeys).Find(&endorsements).Error; err != nil {
+		return nil, err
+	}
+	counted := map[string]bool{}
+	for _, pubkey := range pubkeys {
+		counted[pubkey] = true
+	}
+	for _, endorsement := range endorsements {
+		counted[endorsement.Endorser] = true
+	}
+	people := make([]string, 0, len(counted))
+	for pubkey := range counted {
+		people = append(people, pubkey)
+	}
+
+	rows := []struct {
+		Assignee string
+		Paid     int64
+	}{}
+	err := db.db.Raw(`SELECT assignee, COUNT(*) AS paid FROM bounty
+		WHERE paid = true AND assignee IN ? AND deleted_at IS NULL GROUP BY assignee`, people).Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	paid := map[string]int64{}
+	for _, row := range rows {
+		paid[row.Assignee] = row.Paid
+	}
+	return Reputations(pubkeys, paid, endorsements, at, halfLife), nil
+}
@@ -0,0 +1,49 @@
+package db
+
+import (
+	"testing"
+	"time"
+)
+
+func TestReputations(t *testing.T) {
+	at := time.Date(2026, time.October, 15, 0, 0, 0, 0, time.UTC)
+	halfLife := 180 * 24 * time.Hour
+	daysAgo := func(days int) *time.Time {
+		created := at.Add(-time.Duration(days) * 24 * time.Hour)
+		return &created
+	}
+
+	if weight := EndorsementWeight(0, 0, halfLife, false); weight != 0 {
+		t.Errorf("expected an endorser without paid bounties to add nothing, got %f", weight)
+	}
+	if weight := EndorsementWeight(5, 0, halfLife, false); weight != 0.5 {
+		t.Errorf("expected half a point from an endorser with 5 paid bounties, got %f", weight)
+	}
+	if weight := EndorsementWeight(5, halfLife, halfLife, false); weight != 0.25 {
+		t.Errorf("expected the weight to halve after a half-life, got %f", weight)
+	}
+	if weight := EndorsementWeight(5, 0, halfLife, true); weight != 0.25 {
+		t.Errorf("expected a mutual endorsement to be halved, got %f", weight)
+	}
+
+	paid := map[string]int64{"alice": 5, "bob": 2, "sock": 0}
+	endorsements := []PersonEndorsement{
+		{Endorser: "alice", Endorsee: "bob", Created: daysAgo(400)},
+		{Endorser: "alice", Endorsee: "bob", Created: daysAgo(0)},
+		{Endorser: "sock", Endorsee: "bob", Created: daysAgo(0)},
+		{Endorser: "bob", Endorsee: "sock", Created: daysAgo(0)},
+	}
+	reputations := Reputations([]string{"bob", "sock"}, paid, endorsements, at, halfLife)
+
+	bob := reputations["bob"]
+	if bob.Endorsements != 0.5 || bob.Reputation != 2.5 {
+		t.Errorf("expected only the latest endorsement of alice to count, got %+v", bob)
+	}
+	if len(bob.Received) != 2 {
+		t.Errorf("expected one endorsement per endorser, got %d", len(bob.Received))
+	}
+	// bob has 2 paid bounties, 2/7 of a point, halved as sock endorsed him back
+	if sock := reputations["sock"]; sock.Endorsements != 0.14 {
+		t.Errorf("expected the mutual endorsement of sock to be halved, got %+v", sock)
+	}
+}
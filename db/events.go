@@ -0,0 +1,122 @@
+package db
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/utils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func (db database) CreateEvent(event Event) (Event, error) {
+	if event.Uuid == "" {
+		return Event{}, errors.New("event uuid is required")
+	}
+	if event.Type == "" {
+		return Event{}, errors.New("event type is required")
+	}
+
+	now := time.Now()
+	if event.Payload == nil {
+		event.Payload = PropertyMap{}
+	}
+	event.Created = &now
+
+	if err := db.db.Create(&event).Error; err != nil {
+		return Event{}, err
+	}
+	return event, nil
+}
+
+// GetEventsAfter returns up to limit events with an id above id, oldest
+// first. All event types are returned when types is empty
+func (db database) GetEventsAfter(id uint, types []string, limit int) ([]Event, error) {
+	events := []Event{}
+	query := db.db.Model(&Event{}).Where("id > ?", id)
+	if len(types) > 0 {
+		query = query.Where("type IN ?", types)
+	}
+	err := query.Order("id ASC").Limit(limit).Find(&events).Error
+	return events, err
+}
+
+func (db database) GetEvents(r *http.Request) ([]Event, error) {
+	events := []Event{}
+	offset, limit, _, _, _ := utils.GetPaginationParams(r)
+	if r.URL.Query().Get("limit") == "" {
+		limit = 50
+	}
+
+	query := db.forRequest(r).Model(&Event{})
+	if eventType := r.URL.Query().Get("type"); eventType != "" {
+		query = query.Where("type = ?", eventType)
+	}
+	if subject := r.URL.Query().Get("subject"); subject != "" {
+		query = query.Where("subject = ?", subject)
+	}
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if offset > 0 {
+		query = query.Offset(offset)
+	}
+
+	err := query.Order("id DESC").Find(&events).Error
+	return events, err
+}
+
+func activityQuery(db *gorm.DB, pubkey string, types []string) *gorm.DB {
+	query := db.Model(&Event{}).Where("? = ANY(pub_keys)", pubkey)
+	if len(types) > 0 {
+		query = query.Where("type IN ?", types)
+	}
+	return query
+}
+
+// GetUserActivity returns the events that concern a user newest first, of
+// the given types or of every type when types is empty
+func (db database) GetUserActivity(pubkey string, types []string, r *http.Request) ([]Event, error) {
+	events := []Event{}
+	offset, limit, _, _, _ := utils.GetPaginationParams(r)
+	if r.URL.Query().Get("limit") == "" {
+		limit = 50
+	}
+
+	query := activityQuery(db.forRequest(r), pubkey, types)
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if offset > 0 {
+		query = query.Offset(offset)
+	}
+
+	err := query.Order("id DESC").Find(&events).Error
+	return events, err
+}
+
+func (db database) GetUserActivityCount(pubkey string, types []string) int64 {
+	var count int64
+	activityQuery(db.db, pubkey, types).Count(&count)
+	return count
+}
+
+// GetEventConsumer returns the position of a consumer, a consumer that never
+// ran starts at the beginning of the log
+func (db database) GetEventConsumer(name string) EventConsumer {
+	consumer := EventConsumer{}
+	db.db.Model(&EventConsumer{}).Where("name = ?", name).First(&consumer)
+	if consumer.Name == "" {
+		consumer.Name = name
+	}
+	return consumer
+}
+
+func (db database) SaveEventConsumer(name string, lastEventID uint) error {
+	now := time.Now()
+	return db.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "name"}},
+		DoUpdates: clause.AssignmentColumns([]string{"last_event_id", "updated"}),
+	}).Create(&EventConsumer{Name: name, LastEventID: lastEventID, Updated: &now}).Error
+}
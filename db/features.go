@@ -28,11 +28,11 @@ func (db database) GetFeaturesByWorkspaceUuid(uuid string, r *http.Request) []Wo
 		limitQuery = fmt.Sprintf("LIMIT %d  OFFSET %d", limit, offset)
 	}
 
-	query := `SELECT * FROM public.workspace_features WHERE workspace_uuid = '` + uuid + `'`
+	query := `SELECT * FROM public.workspace_features WHERE workspace_uuid = '` + uuid + `' AND deleted_at IS NULL`
 
 	allQuery := query + " " + orderQuery + " " + limitQuery
 
-	theQuery := db.db.Raw(allQuery)
+	theQuery := db.forRequest(r).Raw(allQuery)
 
 	theQuery.Scan(&ms)
 
@@ -65,9 +65,9 @@ func (db database) CreateOrEditFeature(m WorkspaceFeatures) (WorkspaceFeatures,
 	result := db.db.Model(&WorkspaceFeatures{}).Where("uuid = ?", m.Uuid).First(&existing)
 	if result.RowsAffected == 0 {
 		m.Created = &now
-		db.db.Create(&m)
+		db.db.Omit("budget", "default_reviewers").Create(&m)
 	} else {
-		db.db.Model(&WorkspaceFeatures{}).Where("uuid = ?", m.Uuid).Updates(m)
+		db.db.Model(&WorkspaceFeatures{}).Where("uuid = ?", m.Uuid).Omit("budget", "default_reviewers").Updates(m)
 	}
 
 	db.db.Model(&WorkspaceFeatures{}).Where("uuid = ?", m.Uuid).First(&m)
@@ -195,7 +195,7 @@ func (db database) GetBountiesByFeatureAndPhaseUuid(featureUuid string, phaseUui
 	var bounties []NewBounty
 
 	// Initialize the query with the necessary joins and initial filters
-	query := db.db.Model(&Bounty{}).
+	query := db.forRequest(r).Model(&NewBounty{}).
 		Select("bounty.*").
 		Joins(`INNER JOIN "feature_phases" ON "feature_phases"."uuid" = "bounty"."phase_uuid"`).
 		Where(`"feature_phases"."feature_uuid" = ? AND "feature_phases"."uuid" = ?`, featureUuid, phaseUuid)
@@ -281,7 +281,7 @@ func (db database) GetBountiesCountByFeatureAndPhaseUuid(featureUuid string, pha
 	paid := keys.Get("Paid")
 
 	// Initialize the query with the necessary joins and initial filters
-	query := db.db.Model(&Bounty{}).
+	query := db.forRequest(r).Model(&NewBounty{}).
 		Select("COUNT(*)").
 		Joins(`INNER JOIN "feature_phases" ON "feature_phases"."uuid" = "bounty"."phase_uuid"`).
 		Where(`"feature_phases"."feature_uuid" = ? AND "feature_phases"."uuid" = ?`, featureUuid, phaseUuid)
@@ -322,8 +322,107 @@ func (db database) GetPhaseByUuid(phaseUuid string) (FeaturePhase, error) {
 	return phase, nil
 }
 
-func (db database) GetBountiesByPhaseUuid(phaseUuid string) []Bounty {
-	bounties := []Bounty{}
-	db.db.Model(&Bounty{}).Where("phase_uuid = ?", phaseUuid).Find(&bounties)
+func (db database) GetBountiesByPhaseUuid(phaseUuid string) []NewBounty {
+	bounties := []NewBounty{}
+	db.db.Model(&NewBounty{}).Where("phase_uuid = ?", phaseUuid).Find(&bounties)
 	return bounties
 }
+
+// GetPhaseCompletion counts the bounties of a phase and how many of them
+// are completed or paid
+func (db database) GetPhaseCompletion(phaseUuid string) (int64, int64) {
+	var total, done int64
+	db.db.Model(&NewBounty{}).Where("phase_uuid = ?", phaseUuid).Count(&total)
+	db.db.Model(&NewBounty{}).Where("phase_uuid = ? AND (completed = true OR paid = true)", phaseUuid).Count(&done)
+	return total, done
+}
+
+// SetPhaseDependency sets the phase that has to be complete before bounties
+// are opened in a phase, an empty dependsOn removes the gate
+func (db database) SetPhaseDependency(phaseUuid string, dependsOn string, pubkey string) (FeaturePhase, error) {
+	now := time.Now()
+	err := db.db.Model(&FeaturePhase{}).Where("uuid = ?", phaseUuid).Updates(map[string]interface{}{
+		"depends_on": dependsOn,
+		"updated":    &now,
+		"updated_by": pubkey,
+	}).Error
+	if err != nil {
+		return FeaturePhase{}, err
+	}
+	return db.GetPhaseByUuid(phaseUuid)
+}
+
+// SetPhaseGateOverride opens a locked phase before the phase it depends on
+// is complete, or closes it again
+func (db database) SetPhaseGateOverride(phaseUuid string, override bool, pubkey string) (FeaturePhase, error) {
+	now := time.Now()
+	updates := map[string]interface{}{"gate_overridden": nil, "gate_overridden_by": "", "updated": &now, "updated_by": pubkey}
+	if override {
+		updates["gate_overridden"] = &now
+		updates["gate_overridden_by"] = pubkey
+	}
+	if err := db.db.Model(&FeaturePhase{}).Where("uuid = ?", phaseUuid).Updates(updates).Error; err != nil {
+		return FeaturePhase{}, err
+	}
+	return db.GetPhaseByUuid(phaseUuid)
+}
+
+// AllocateFeatureBudget sets the part of the workspace budget allocated to
+// a feature and records it in the budget history
+func (db database) AllocateFeatureBudget(feature WorkspaceFeatures, amount uint, pubkey string) (WorkspaceFeatures, error) {
+	err := db.transaction(func(tx database) error {
+		now := time.Now()
+		if err := tx.db.Model(&WorkspaceFeatures{}).Where("uuid = ?", feature.Uuid).Updates(map[string]interface{}{
+			"budget":     amount,
+			"updated":    &now,
+			"updated_by": pubkey,
+		}).Error; err != nil {
+			return err
+		}
+		return tx.db.Create(&FeatureBudgetHistory{
+			FeatureUuid:   feature.Uuid,
+			WorkspaceUuid: feature.WorkspaceUuid,
+			Amount:        amount,
+			Created:       &now,
+			CreatedBy:     pubkey,
+		}).Error
+	})
+	if err != nil {
+		return feature, err
+	}
+	return db.GetFeatureByUuid(feature.Uuid), nil
+}
+
+func (db database) GetFeatureBudgetHistory(featureUuid string) []FeatureBudgetHistory {
+	history := []FeatureBudgetHistory{}
+	db.db.Where("feature_uuid = ?", featureUuid).Order("created, id").Find(&history)
+	return history
+}
+
+// GetFeatureBounties returns the bounties of every phase of a feature
+func (db database) GetFeatureBounties(featureUuid string) []NewBounty {
+	bounties := []NewBounty{}
+	db.db.Model(&NewBounty{}).
+		Joins(`INNER JOIN "feature_phases" ON "feature_phases"."uuid" = "bounty"."phase_uuid"`).
+		Where(`"feature_phases"."feature_uuid" = ?`, featureUuid).
+		Order("bounty.created").
+		Find(&bounties)
+	return bounties
+}
+
+// GetWorkspaceAllocatedBudget returns how much of the workspace budget the
+// features still hold, their allocations minus what they already paid
+func (db database) GetWorkspaceAllocatedBudget(workspaceUuid string) uint {
+	var allocated, spent uint
+	db.db.Model(&WorkspaceFeatures{}).Where("workspace_uuid = ? AND budget > 0", workspaceUuid).Select("COALESCE(SUM(budget), 0)").Row().Scan(&allocated)
+	db.db.Model(&NewBounty{}).
+		Joins(`INNER JOIN "feature_phases" ON "feature_phases"."uuid" = "bounty"."phase_uuid"`).
+		Joins(`INNER JOIN "workspace_features" ON "workspace_features"."uuid" = "feature_phases"."feature_uuid"`).
+		Where(`"workspace_features"."workspace_uuid" = ? AND "workspace_features"."budget" > 0 AND "workspace_features"."deleted_at" IS NULL`, workspaceUuid).
+		Where("bounty.paid = true").
+		Select("COALESCE(SUM(bounty.price), 0)").Row().Scan(&spent)
+	if spent > allocated {
+		return 0
+	}
+	return allocated - spent
+}
@@ -1,11 +1,13 @@
 package db
 
 import (
+	"context"
 	"net/http"
 	"time"
 )
 
 type Database interface {
+	WithTx(fn func(Database) error) error
 	CreateOrEditTribe(m Tribe) (Tribe, error)
 	CreateChannel(c Channel) (Channel, error)
 	CreateOrEditBot(b Bot) (Bot, error)
@@ -20,6 +22,7 @@ type Database interface {
 	UpdateTribeUniqueName(uuid string, u string)
 	GetOpenGithubIssues(r *http.Request) (int64, error)
 	GetListedTribes(r *http.Request) []Tribe
+	GetListedTribesCount(r *http.Request) int64
 	GetTribesByOwner(pubkey string) []Tribe
 	GetAllTribesByOwner(pubkey string) []Tribe
 	GetTribesByAppUrl(aurl string) []Tribe
@@ -27,6 +30,7 @@ type Database interface {
 	GetChannel(id uint) Channel
 	GetListedBots(r *http.Request) []Bot
 	GetListedPeople(r *http.Request) []Person
+	GetListedPeopleCount(r *http.Request) int64
 	GetPeopleBySearch(r *http.Request) []Person
 	GetListedPosts(r *http.Request) ([]PeopleExtra, error)
 	GetUserBountiesCount(personKey string, tabType string) int64
@@ -42,16 +46,17 @@ type Database interface {
 	GetPreviousWorkspaceBountyByCreated(r *http.Request) (uint, error)
 	GetBountyIndexById(id string) int64
 	GetBountyDataByCreated(created string) ([]NewBounty, error)
-	AddBounty(b Bounty) (Bounty, error)
+	GetBountyResponses(bounties []NewBounty) []BountyResponse
 	GetAllBounties(r *http.Request) []NewBounty
 	CreateOrEditBounty(b NewBounty) (NewBounty, error)
-	UpdateBountyNullColumn(b NewBounty, column string) NewBounty
-	UpdateBountyBoolColumn(b NewBounty, column string) NewBounty
+	UpdateBountyNullColumn(b NewBounty, column string) (NewBounty, error)
+	UpdateBountyBoolColumn(b NewBounty, column string) (NewBounty, error)
 	DeleteBounty(pubkey string, created string) (NewBounty, error)
 	GetBountyByCreated(created uint) (NewBounty, error)
 	GetBounty(id uint) NewBounty
 	UpdateBounty(b NewBounty) (NewBounty, error)
 	UpdateBountyPayment(b NewBounty) (NewBounty, error)
+	UpdateBountyCompleted(b NewBounty) (NewBounty, error)
 	GetListedOffers(r *http.Request) ([]PeopleExtra, error)
 	UpdateBot(uuid string, u map[string]interface{}) bool
 	GetAllTribes() []Tribe
@@ -86,6 +91,8 @@ type Database interface {
 	GetWorkspaces(r *http.Request) []Workspace
 	GetWorkspacesCount() int64
 	GetWorkspaceByUuid(uuid string) Workspace
+	SetWorkspaceAutoPay(uuid string, autoPay bool, autoPayCap uint) (Workspace, error)
+	SetWorkspaceDefinitionOfDone(uuid string, done DefinitionOfDone) (Workspace, error)
 	GetWorkspaceByName(name string) Workspace
 	CreateOrEditWorkspace(m Workspace) (Workspace, error)
 	GetWorkspaceUsers(uuid string) ([]WorkspaceUsersData, error)
@@ -106,15 +113,21 @@ type Database interface {
 	GetWorkspaceStatusBudget(workspace_uuid string) StatusBudget
 	GetWorkspaceBudgetHistory(workspace_uuid string) []BudgetHistoryData
 	ProcessUpdateBudget(invoice NewInvoiceList) error
-	AddAndUpdateBudget(invoice NewInvoiceList) NewPaymentHistory
-	WithdrawBudget(sender_pubkey string, workspace_uuid string, amount uint)
+	AddAndUpdateBudget(invoice NewInvoiceList) (NewPaymentHistory, error)
 	AddPaymentHistory(payment NewPaymentHistory) NewPaymentHistory
-	ProcessBountyPayment(payment NewPaymentHistory, bounty NewBounty) error
+	ClaimBountyPayment(id uint) (bool, error)
+	ReleaseBountyPayment(id uint) error
+	CreatePendingPayment(payment NewPaymentHistory) (NewPaymentHistory, error)
+	GetPendingPayments() []NewPaymentHistory
+	ReconcilePayment(id uint, settled bool, paymentHash string) (NewPaymentHistory, error)
+	SetPaymentHash(id uint, paymentHash string) error
+	SetPaymentPreimage(id uint, preimage string) error
 	GetPaymentHistory(workspace_uuid string, r *http.Request) []NewPaymentHistory
 	GetInvoice(payment_request string) NewInvoiceList
 	GetWorkspaceInvoices(workspace_uuid string) []NewInvoiceList
 	GetWorkspaceInvoicesCount(workspace_uuid string) int64
-	UpdateInvoice(payment_request string) NewInvoiceList
+	UpdateInvoice(payment_request string) (bool, error)
+	ReleaseInvoice(payment_request string) error
 	AddInvoice(invoice NewInvoiceList) NewInvoiceList
 	DeleteInvoice(payment_request string) NewInvoiceList
 	AddUserInvoiceData(userData UserInvoiceData) UserInvoiceData
@@ -150,10 +163,22 @@ type Database interface {
 	GetWorkspaceRepositorByWorkspaceUuid(uuid string) []WorkspaceRepositories
 	GetWorkspaceRepoByWorkspaceUuidAndRepoUuid(workspace_uuid string, uuid string) (WorkspaceRepositories, error)
 	DeleteWorkspaceRepository(workspace_uuid string, uuid string) bool
+	CreateOrEditArtifact(artifact WorkspaceArtifact) (WorkspaceArtifact, error)
+	GetArtifacts(workspaceUuid string, r *http.Request) ([]WorkspaceArtifact, error)
+	GetArtifactsCount(workspaceUuid string, r *http.Request) int64
+	GetArtifactsByUuid(workspaceUuid string, uuids []string) ([]WorkspaceArtifact, error)
+	GetArtifact(uuid string) (WorkspaceArtifact, error)
+	GetArtifactVersions(uuid string) ([]WorkspaceArtifactVersion, error)
+	GetArtifactVersion(uuid string, version int) (WorkspaceArtifactVersion, error)
+	DeleteArtifact(uuid string) error
 	CreateOrEditFeature(m WorkspaceFeatures) (WorkspaceFeatures, error)
 	GetFeaturesByWorkspaceUuid(uuid string, r *http.Request) []WorkspaceFeatures
 	GetWorkspaceFeaturesCount(uuid string) int64
 	GetFeatureByUuid(uuid string) WorkspaceFeatures
+	AllocateFeatureBudget(feature WorkspaceFeatures, amount uint, pubkey string) (WorkspaceFeatures, error)
+	GetFeatureBudgetHistory(featureUuid string) []FeatureBudgetHistory
+	GetFeatureBounties(featureUuid string) []NewBounty
+	GetWorkspaceAllocatedBudget(workspaceUuid string) uint
 	CreateOrEditFeaturePhase(phase FeaturePhase) (FeaturePhase, error)
 	GetPhasesByFeatureUuid(featureUuid string) []FeaturePhase
 	GetFeaturePhaseByUuid(featureUuid, phaseUuid string) (FeaturePhase, error)
@@ -166,6 +191,219 @@ type Database interface {
 	GetBountiesByFeatureAndPhaseUuid(featureUuid string, phaseUuid string, r *http.Request) ([]NewBounty, error)
 	GetBountiesCountByFeatureAndPhaseUuid(featureUuid string, phaseUuid string, r *http.Request) int64
 	GetPhaseByUuid(phaseUuid string) (FeaturePhase, error)
-	GetBountiesByPhaseUuid(phaseUuid string) []Bounty
+	GetBountiesByPhaseUuid(phaseUuid string) []NewBounty
 	GetFeaturePhasesBountiesCount(bountyType string, phaseUuid string) int64
+	CreateChat(chat Chat) (Chat, error)
+	UpdateChat(chat Chat) (Chat, error)
+	GetChatByUuid(uuid string) (Chat, error)
+	GetChatsForWorkspace(workspaceUuid string, status string) ([]Chat, error)
+	AddChatMessage(message ChatMessage) (ChatMessage, error)
+	UpdateChatMessage(message ChatMessage) (ChatMessage, error)
+	GetChatMessageByUuid(uuid string) (ChatMessage, error)
+	GetChatMessagesForChatID(chatUuid string) ([]ChatMessage, error)
+	EnqueueJob(job Job) (Job, error)
+	ClaimNextJob(types []string) (Job, error)
+	CompleteJob(uuid string) error
+	FailJob(job Job, lastError string, retryAt time.Time) (Job, error)
+	GetJobByUuid(uuid string) (Job, error)
+	GetJobs(r *http.Request) ([]Job, error)
+	RequeueJob(uuid string) (Job, error)
+	ReleaseStaleJobs(lockedBefore time.Time) (int64, error)
+	ExplainHotQueries() ([]QueryPlan, error)
+	GetPoolStats() (PoolStats, error)
+	CreateEvent(event Event) (Event, error)
+	GetEventsAfter(id uint, types []string, limit int) ([]Event, error)
+	GetEvents(r *http.Request) ([]Event, error)
+	GetUserActivity(pubkey string, types []string, r *http.Request) ([]Event, error)
+	GetUserActivityCount(pubkey string, types []string) int64
+	CreateNotifications(notifications []Notification) error
+	GetNotifications(pubkey string, r *http.Request) ([]Notification, error)
+	GetNotificationsCount(pubkey string, unread bool) int64
+	ReadNotification(pubkey string, id uint) (bool, error)
+	ReadAllNotifications(pubkey string) (int64, error)
+	CreateOrEditWorkspaceBrief(brief WorkspaceBrief) (WorkspaceBrief, error)
+	FinishWorkspaceBrief(brief WorkspaceBrief) (WorkspaceBrief, error)
+	GetWorkspaceBrief(uuid string) (WorkspaceBrief, error)
+	GetWorkspaceBriefs(workspaceUuid string) []WorkspaceBrief
+	ApproveWorkspaceBrief(brief WorkspaceBrief, pubkey string) (Workspace, error)
+	GetRecentWorkspaceBounties(workspaceUuid string, limit int) []NewBounty
+	SetWorkspaceReservePercent(workspaceUuid string, percent uint) (NewBountyBudget, error)
+	CreateReserveEntry(entry ReserveEntry) (ReserveEntry, error)
+	GetReserveEntry(uuid string) (ReserveEntry, error)
+	GetReserveEntries(workspaceUuid string) []ReserveEntry
+	GetPendingReserveAmount(workspaceUuid string) uint
+	ApproveReserveRelease(entry ReserveEntry, pubkey string) (NewBountyBudget, error)
+	RejectReserveRelease(entry ReserveEntry, pubkey string) (ReserveEntry, error)
+	CreateTimeEntry(entry TimeEntry) (TimeEntry, error)
+	GetTimeEntry(uuid string) (TimeEntry, error)
+	GetRunningTimeEntry(pubkey string) (TimeEntry, error)
+	StopTimeEntry(entry TimeEntry, ended time.Time) (TimeEntry, error)
+	GetBountyTimeEntries(bountyId uint) []TimeEntry
+	DeleteTimeEntry(uuid string) error
+	GetBountyTrackedMinutes(bountyId uint, pubkey string) uint
+	GetTimeTotals(workspaceUuid string, pubkey string, from *time.Time, to *time.Time) []TimeTotal
+	CreateOrEditQuest(quest Quest) (Quest, error)
+	GetQuest(uuid string) (Quest, error)
+	GetQuests(workspaceUuid string, activeOnly bool) []Quest
+	GetQuestBounties(questUuid string) []NewBounty
+	DeleteQuest(uuid string) error
+	PayQuestBonus(quest Quest, payment NewPaymentHistory) (Quest, error)
+	SaveMentions(sourceType string, sourceID string, mentions []Mention) ([]Mention, error)
+	GetMentions(sourceType string, sourceID string) []Mention
+	GetMentionsOf(targetType string, targetID string) []Mention
+	GetEventConsumer(name string) EventConsumer
+	SaveEventConsumer(name string, lastEventID uint) error
+	AggregatePlatformStats(at time.Time) (PlatformStats, error)
+	GetPlatformStats(since time.Time) ([]PlatformStats, error)
+	CreateReport(report Report) (Report, error)
+	GetReportByUuid(uuid string) (Report, error)
+	GetPendingReport(targetType string, targetUuid string, reporter string) (Report, error)
+	GetReports(r *http.Request) ([]Report, error)
+	ResolveTargetReports(resolution Report) ([]Report, error)
+	BanPubkey(ban BannedPubkey) error
+	IsBannedPubkey(pubkey string) bool
+	SearchDocuments(ctx context.Context, index string, text string, limit int, offset int) ([]SearchDocument, error)
+	GetSearchDocument(index string, id string) (SearchDocument, bool, error)
+	GetSearchDocuments(index string, limit int, offset int) ([]SearchDocument, error)
+	ApplyRetentionRule(rule RetentionRule, before time.Time, dryRun bool) (int64, error)
+	CreateRetentionRuns(runs []RetentionRun) error
+	GetRetentionRuns(r *http.Request) ([]RetentionRun, error)
+	SoftDelete(kind string, id string) (bool, error)
+	RestoreDeleted(kind string, id string) (bool, error)
+	GetSoftDeleted(kind string, r *http.Request) ([]SoftDeleted, error)
+	GetPeopleByPubkeys(pubkeys []string) []Person
+	GetBountiesByIds(ids []uint) []NewBounty
+	GetTribesByUuids(uuids []string) []Tribe
+	GetLastEventId() uint
+	GetLastNotificationId() uint
+	GetUserEventSubjects(pubkey string, after uint, upTo uint) ([]string, error)
+	GetNotificationsBetween(pubkey string, after uint, upTo uint, limit int) ([]Notification, error)
+	GetBountiesAssignedTo(pubkey string) []NewBounty
+	RedeemConnectionCode() (ConnectionCodes, error)
+	GetConnectionCodeStats() ([]ConnectionCodeStats, error)
+	LockBountyVersion(id uint) (int, error)
+	CreateWorkspaceExport(export WorkspaceExport) (WorkspaceExport, error)
+	GetWorkspaceExport(workspaceUuid string, uuid string) (WorkspaceExport, error)
+	CompleteWorkspaceExport(uuid string, bundle string, exportErr string) error
+	GetWorkspaceBundle(workspaceUuid string) (WorkspaceBundle, error)
+	ImportWorkspaceBundle(bundle WorkspaceBundle, name string, pubkey string) (Workspace, error)
+	GetMaintenance() (Maintenance, error)
+	SetMaintenance(m Maintenance) (Maintenance, error)
+	RefreshBountyRecommendations(pubkeys []string, at time.Time) error
+	GetBountyRecommendations(pubkey string, since time.Time, limit int) ([]BountyRecommendation, error)
+	GetRecommendationPubkeys(at time.Time) ([]string, error)
+	CreateWorkspaceDigest(workspaceUuid string, start time.Time, end time.Time) (WorkspaceDigest, error)
+	GetWorkspaceDigests(workspaceUuid string, r *http.Request) ([]WorkspaceDigest, error)
+	GetDigestWorkspaces() ([]Workspace, error)
+	GetWorkspaceMemberPubkeys(workspace Workspace) ([]string, error)
+	GetSpamScoringTribes() ([]Tribe, error)
+	GetTribeReportCounts() (map[string]int64, error)
+	SaveTribeSpamScore(score TribeSpamScore, threshold float64) (bool, error)
+	GetShadowListedTribes(r *http.Request) ([]ShadowListedTribe, error)
+	ReviewShadowListedTribe(uuid string, review TribeSpamReview, reviewer string) (Tribe, error)
+	CreateTribeAppeal(appeal TribeAppeal) (TribeAppeal, error)
+	GetPendingTribeAppeal(tribeUuid string) (TribeAppeal, error)
+	GetPublicMetrics(activeSince time.Time) (PublicMetrics, error)
+	GetBountyRoutingRules(workspaceUuid string) ([]BountyRoutingRule, error)
+	GetBountyRoutingRule(uuid string) (BountyRoutingRule, error)
+	CreateOrEditBountyRoutingRule(rule BountyRoutingRule) (BountyRoutingRule, error)
+	DeleteBountyRoutingRule(uuid string) error
+	GetAppliedBountyRoutings(bountyID uint) ([]string, error)
+	ApplyBountyRouting(bountyID uint, plan BountyRoutingPlan) (NewBounty, bool, error)
+	CreateTipInvoice(invoice NewInvoiceList, userData UserInvoiceData, payment NewPaymentHistory) error
+	SettleTip(paymentRequest string) (NewPaymentHistory, error)
+	MarkTipState(paymentRequest string, state string) error
+	CountWorkspaceUsage(workspaceUuid string, quota string, at time.Time) error
+	GetWorkspaceUsage(workspaceUuid string, at time.Time) (map[string]int64, error)
+	SetWorkspacePlan(uuid string, plan string) (Workspace, error)
+	GetPhaseCompletion(phaseUuid string) (int64, int64)
+	SetPhaseDependency(phaseUuid string, dependsOn string, pubkey string) (FeaturePhase, error)
+	SetPhaseGateOverride(phaseUuid string, override bool, pubkey string) (FeaturePhase, error)
+	UpdateVerifiedSkills(pubkey string, threshold int) (Person, bool, error)
+	GetTribeAnnouncements(tribeUuid string) []TribeAnnouncement
+	GetPinnedTribeAnnouncements(tribeUuid string, at time.Time) []TribeAnnouncement
+	GetTribeAnnouncement(uuid string) (TribeAnnouncement, error)
+	CreateOrEditTribeAnnouncement(announcement TribeAnnouncement) (TribeAnnouncement, error)
+	DeleteTribeAnnouncement(uuid string) error
+	GetWorkspaceSecrets(workspaceUuid string) ([]WorkspaceSecret, error)
+	GetWorkspaceSecret(workspaceUuid string, name string) (WorkspaceSecret, error)
+	CreateOrEditWorkspaceSecret(secret WorkspaceSecret) (WorkspaceSecret, error)
+	DeleteWorkspaceSecret(workspaceUuid string, name string) error
+	GetWorkspaceSecretsToRewrap(masterKeyId string) ([]WorkspaceSecret, error)
+	RewrapWorkspaceSecret(uuid string, dataKey []byte, masterKeyId string) error
+	CreateUploadSession(session UploadSession) (UploadSession, error)
+	GetUploadSession(uuid string) (UploadSession, error)
+	AppendUploadChunk(uuid string, offset int64, data []byte, at time.Time) (UploadSession, error)
+	GetUploadChunks(uuid string) ([]UploadChunk, error)
+	CompleteUploadSession(uuid string, url string, errMsg string) (UploadSession, error)
+	DeleteUploadSession(uuid string) error
+	AddBountyViews(views map[uint]BountyViews) error
+	SetWorkspaceReviewers(uuid string, settings ReviewerSettings) (Workspace, error)
+	SetFeatureReviewers(uuid string, reviewers []string, pubkey string) (WorkspaceFeatures, error)
+	CreateBountyProof(proof BountyProof, reviewers []string) (BountyProof, error)
+	GetBountyProofs(bountyId uint) ([]BountyProof, error)
+	GetLatestBountyProof(bountyId uint) (BountyProof, error)
+	HasApprovedBountyProof(bountyId uint) bool
+	ReviewBountyProof(proofUuid string, reviewer string, decision string, comment string, at time.Time) (BountyProof, error)
+	CreateAiSubmission(submission AiSubmission) error
+	AddAiSubmissionCost(kind string, reference string, cost float64) error
+	CountAiSubmissions(workspaceUuid string, month time.Time) (int64, error)
+	GetAiUsage(workspaceUuid string, month time.Time) (AiUsage, error)
+	SetWorkspaceAiCap(uuid string, monthlyCap uint) (Workspace, error)
+	GetTribeEvents(tribeUuid string) []TribeEvent
+	GetTribeUpdates(tribeUuid string, limit int) TribeUpdates
+	GetTribeEvent(uuid string) (TribeEvent, error)
+	CreateOrEditTribeEvent(event TribeEvent) (TribeEvent, error)
+	DeleteTribeEvent(uuid string) error
+	CreatePersonEndorsement(endorsement PersonEndorsement) (PersonEndorsement, error)
+	GetLastEndorsement(endorser string, endorsee string) (PersonEndorsement, error)
+	GetReputations(pubkeys []string, at time.Time, halfLife time.Duration) (map[string]Reputation, error)
+	GetBountyTermsAcceptances(bountyID uint) ([]BountyTermsAcceptance, error)
+	HasAcceptedBountyTerms(bountyID uint, pubkey string, hash string) bool
+	AcceptBountyTerms(acceptance BountyTermsAcceptance) (NewBounty, error)
+	GetOpenWorkspaceBounties(workspaceUuid string, limit int) []NewBounty
+	GetBountyPayment(bountyId uint) NewPaymentHistory
+	MigrateLegacyBounties(dryRun bool) (BountyMigrationReport, error)
+	GetListedTribesPaginated(r *http.Request, limit int, after string) ([]Tribe, string, error)
+	RefreshBountyPriceSuggestions(at time.Time) error
+	GetBountyPriceSuggestions(workspaceUuid string, tags []string, hoursBucket int) (BountyPriceSuggestions, error)
+	GetBountyFacets(filters BountyFacetFilters) (BountyFacets, error)
+	MoveTicket(bountyId uint, phaseUuid string, position int) (NewBounty, error)
+	SetTicketStatuses(phaseUuid string, changes []TicketStatusChange) ([]NewBounty, error)
+	JoinTribe(tribeUuid string, pubkey string, alias string) (TribeMember, error)
+	LeaveTribe(tribeUuid string, pubkey string) error
+	KickTribeMember(tribeUuid string, pubkey string) error
+	GetTribeMember(tribeUuid string, pubkey string) TribeMember
+	GetTribeMembers(tribeUuid string, r *http.Request) ([]TribeMember, error)
+	GetGithubSyncWorkspaces() ([]Workspace, error)
+	SyncWorkspaceMemberSuggestions(workspaceUuid string, logins []GithubOrgLogin, at time.Time) error
+	GetWorkspaceMemberSuggestions(workspace Workspace) ([]WorkspaceMemberSuggestion, error)
+	GetBountyContactExchange(bounty NewBounty) (BountyContactExchange, error)
+	SetBountyContactConsent(exchange BountyContactExchange, owner bool, consent bool) (BountyContactExchange, error)
+	CountWorkspacesCreatedSince(pubkey string, since time.Time) int64
+	FlagWorkspace(uuid string, reasons []string) error
+	GetFlaggedWorkspaces(r *http.Request) ([]Workspace, error)
+	ReviewFlaggedWorkspace(uuid string, review WorkspaceReview) (Workspace, error)
+	SaveWorkspaceVerification(verification WorkspaceVerification) (WorkspaceVerification, error)
+	GetWorkspaceVerification(workspaceUuid string) WorkspaceVerification
+	AddWorkspaceVerificationAttempt(id uint) error
+	VerifyWorkspace(uuid string, method string) (Workspace, error)
+	GetCategories() ([]Category, error)
+	CreateOrEditCategory(request CategoryRequest) (Category, error)
+	DeleteCategory(slug string) error
+	SetTribeCategories(tribeUuid string, slugs []string) error
+	GetTribesCategories(uuids []string) (map[string][]string, error)
+	QueueTribePreview(tribeUuid string, url string) (TribePreview, error)
+	GetTribePreview(tribeUuid string) TribePreview
+	CompleteTribePreview(preview TribePreview, previewUrl string) error
+	CreateBountyCertificate(certificate BountyCertificate) (BountyCertificate, error)
+	GetBountyCertificate(uuid string) BountyCertificate
+	GetBountyCertificatesByPubkey(pubkey string) ([]BountyCertificate, error)
+	CreateTribeWebhook(webhook TribeWebhook) (TribeWebhook, error)
+	GetTribeWebhooks(tribeUuid string) ([]TribeWebhook, error)
+	GetTribeWebhook(uuid string) TribeWebhook
+	DeleteTribeWebhook(uuid string) error
+	RecordTribeWebhookDelivery(uuid string, status int, deliveryErr string) error
+	GetTribeActivity(tribeUuid string, kinds []string, r *http.Request) ([]TribeActivity, error)
+	GetTribeActivityCount(tribeUuid string, kinds []string) int64
 }
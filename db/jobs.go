@@ -0,0 +1,185 @@
+package db
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/utils"
+)
+
+const defaultJobMaxAttempts = 5
+
+func (db database) EnqueueJob(job Job) (Job, error) {
+	if job.Uuid == "" {
+		return Job{}, errors.New("job uuid is required")
+	}
+	if job.Type == "" {
+		return Job{}, errors.New("job type is required")
+	}
+
+	now := time.Now()
+	if job.RunAt == nil {
+		job.RunAt = &now
+	}
+	if job.MaxAttempts <= 0 {
+		job.MaxAttempts = defaultJobMaxAttempts
+	}
+	if job.Payload == nil {
+		job.Payload = PropertyMap{}
+	}
+	job.Status = JobPending
+	job.Attempts = 0
+	job.Created = &now
+	job.Updated = &now
+
+	if err := db.db.Create(&job).Error; err != nil {
+		return Job{}, err
+	}
+
+	return job, nil
+}
+
+// ClaimNextJob locks the oldest due job of one of the given types and marks
+// it as running. SKIP LOCKED lets several workers poll the table without
+// blocking on each other. An empty job is returned when nothing is due
+func (db database) ClaimNextJob(types []string) (Job, error) {
+	job := Job{}
+	if len(types) == 0 {
+		return job, nil
+	}
+
+	result := db.db.Raw(`
+		UPDATE jobs SET status = ?, attempts = attempts + 1, locked_at = NOW(), updated = NOW()
+		WHERE id = (
+			SELECT id FROM jobs
+			WHERE status = ? AND run_at <= NOW() AND type IN ?
+			ORDER BY run_at ASC
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *`, JobRunning, JobPending, types).Scan(&job)
+
+	if result.Error != nil {
+		return Job{}, result.Error
+	}
+	return job, nil
+}
+
+func (db database) CompleteJob(uuid string) error {
+	now := time.Now()
+	result := db.db.Model(&Job{}).Where("uuid = ?", uuid).Updates(map[string]interface{}{
+		"status":     JobCompleted,
+		"last_error": "",
+		"locked_at":  nil,
+		"updated":    &now,
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("no job found to complete")
+	}
+	return nil
+}
+
+// FailJob records a failed attempt. The job is scheduled again at retryAt,
+// or moved to the dead status once it has used up all of its attempts
+func (db database) FailJob(job Job, lastError string, retryAt time.Time) (Job, error) {
+	now := time.Now()
+	status := JobPending
+	if job.Attempts >= job.MaxAttempts {
+		status = JobDead
+	}
+
+	result := db.db.Model(&Job{}).Where("uuid = ?", job.Uuid).Updates(map[string]interface{}{
+		"status":     status,
+		"last_error": lastError,
+		"run_at":     &retryAt,
+		"locked_at":  nil,
+		"updated":    &now,
+	})
+	if result.Error != nil {
+		return Job{}, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return Job{}, errors.New("no job found to fail")
+	}
+
+	return db.GetJobByUuid(job.Uuid)
+}
+
+func (db database) GetJobByUuid(uuid string) (Job, error) {
+	job := Job{}
+	result := db.db.Model(&Job{}).Where("uuid = ?", uuid).First(&job)
+	if result.RowsAffected == 0 {
+		return job, errors.New("no job found")
+	}
+	return job, nil
+}
+
+func (db database) GetJobs(r *http.Request) ([]Job, error) {
+	jobs := []Job{}
+	offset, limit, _, _, _ := utils.GetPaginationParams(r)
+	if r.URL.Query().Get("limit") == "" {
+		limit = 50
+	}
+
+	query := db.forRequest(r).Model(&Job{})
+	if status := r.URL.Query().Get("status"); status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if jobType := r.URL.Query().Get("type"); jobType != "" {
+		query = query.Where("type = ?", jobType)
+	}
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if offset > 0 {
+		query = query.Offset(offset)
+	}
+
+	err := query.Order("created DESC").Find(&jobs).Error
+	return jobs, err
+}
+
+// RequeueJob puts a failed, dead or completed job back in the queue with a
+// fresh set of attempts
+func (db database) RequeueJob(uuid string) (Job, error) {
+	job, err := db.GetJobByUuid(uuid)
+	if err != nil {
+		return Job{}, err
+	}
+	if job.Status == JobRunning {
+		return Job{}, errors.New("job is currently running")
+	}
+
+	now := time.Now()
+	err = db.db.Model(&Job{}).Where("uuid = ?", uuid).Updates(map[string]interface{}{
+		"status":    JobPending,
+		"attempts":  0,
+		"run_at":    &now,
+		"locked_at": nil,
+		"updated":   &now,
+	}).Error
+	if err != nil {
+		return Job{}, err
+	}
+
+	return db.GetJobByUuid(uuid)
+}
+
+// ReleaseStaleJobs returns jobs that were left running by a worker that went
+// away (e.g. a restart mid job) back to the pending status
+func (db database) ReleaseStaleJobs(lockedBefore time.Time) (int64, error) {
+	now := time.Now()
+	result := db.db.Model(&Job{}).
+		Where("status = ? AND locked_at < ?", JobRunning, lockedBefore).
+		Updates(map[string]interface{}{
+			"status":    JobPending,
+			"run_at":    &now,
+			"locked_at": nil,
+			"updated":   &now,
+		})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,156 @@
+package db
+
+import (
+	"errors"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	// the time zones of the profiles don't depend on the zoneinfo of the host
+	_ "time/tzdata"
+
+	"github.com/stakwork/sphinx-tribes/utils"
+)
+
+// Regions a profile can be in, by continent
+var Regions = []string{"AF", "AS", "EU", "NA", "OC", "SA"}
+
+func ValidRegion(region string) bool {
+	for _, r := range Regions {
+		if r == region {
+			return true
+		}
+	}
+	return false
+}
+
+// LoadTimezone loads an IANA time zone, unlike time.LoadLocation it refuses
+// the empty name and Local
+func LoadTimezone(name string) (*time.Location, error) {
+	if name == "" || name == "Local" {
+		return nil, errors.New("not an IANA time zone")
+	}
+	return time.LoadLocation(name)
+}
+
+// a BCP 47 language tag with an optional script and region, like en,
+// pt-BR or zh-Hant-TW
+var localePattern = regexp.MustCompile(`^[a-z]{2,3}(-[A-Z][a-z]{3})?(-([A-Z]{2}|[0-9]{3}))?$`)
+
+func ValidLocale(locale string) bool {
+	return localePattern.MatchString(locale)
+}
+
+// Location is the time zone of the workspace, UTC when it has none
+func (w Workspace) Location() *time.Location {
+	loc, err := LoadTimezone(w.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// BountyDeadline reads the estimated completion date of a bounty. A date
+// without a time is due by the end of that day in loc, a time with an
+// offset is due then. It is false when the bounty has no date it can read
+func BountyDeadline(due string, loc *time.Location) (time.Time, bool) {
+	due = strings.TrimSpace(due)
+	if deadline, err := time.Parse(time.RFC3339, due); err == nil {
+		return deadline, true
+	}
+	if day, err := time.ParseInLocation("2006-01-02", due, loc); err == nil {
+		return day.AddDate(0, 0, 1), true
+	}
+	return time.Time{}, false
+}
+
+// InLocation turns the days of a date range, like 2024-05-01, into the unix
+// times the metrics compare, from the start of the first day to the end of
+// the last one in loc. Unix times are kept as they are
+func (r PaymentDateRange) InLocation(loc *time.Location) PaymentDateRange {
+	if day, err := time.ParseInLocation("2006-01-02", r.StartDate, loc); err == nil {
+		r.StartDate = strconv.FormatInt(day.Unix(), 10)
+	}
+	if day, err := time.ParseInLocation("2006-01-02", r.EndDate, loc); err == nil {
+		r.EndDate = strconv.FormatInt(day.AddDate(0, 0, 1).Unix()-1, 10)
+	}
+	return r
+}
+
+// LocationFilter narrows the people directory to regions, and to the people
+// whose working hours overlap the ones of Timezone by Overlap hours or more
+type LocationFilter struct {
+	Regions  []string
+	Timezone *time.Location
+	Overlap  int
+}
+
+// ParseLocationFilter reads ?region=EU,NA and ?tz_overlap=4&tz=Europe/Berlin
+func ParseLocationFilter(r *http.Request) (LocationFilter, error) {
+	filter := LocationFilter{}
+	if r == nil {
+		return filter, nil
+	}
+	keys := r.URL.Query()
+
+	if value := keys.Get("region"); value != "" {
+		for _, region := range strings.Split(value, ",") {
+			region = strings.ToUpper(strings.TrimSpace(region))
+			if !ValidRegion(region) {
+				return filter, errors.New("region is one of " + strings.Join(Regions, ", "))
+			}
+			filter.Regions = append(filter.Regions, region)
+		}
+	}
+
+	if value := keys.Get("tz_overlap"); value != "" {
+		overlap, err := strconv.Atoi(value)
+		if err != nil || overlap < 1 || overlap > utils.WorkdayEnd-utils.WorkdayStart {
+			return filter, errors.New("tz_overlap is a number of hours from 1 to 8")
+		}
+		if filter.Timezone, err = LoadTimezone(keys.Get("tz")); err != nil {
+			return filter, errors.New("tz_overlap needs tz, an IANA time zone like Europe/Berlin")
+		}
+		filter.Overlap = overlap
+	}
+	return filter, nil
+}
+
+// overlappingTimezones returns the time zones of the profiles whose working
+// hours overlap the ones of the filter today
+func (db database) overlappingTimezones(filter LocationFilter) []string {
+	timezones := []string{}
+	db.db.Model(&Person{}).Where("timezone != ''").Distinct().Pluck("timezone", &timezones)
+
+	now := time.Now()
+	overlapping := []string{}
+	for _, name := range timezones {
+		loc, err := LoadTimezone(name)
+		if err == nil && utils.OverlapHours(filter.Timezone, loc, now) >= filter.Overlap {
+			overlapping = append(overlapping, name)
+		}
+	}
+	return overlapping
+}
+
+// locationFilters is the where clause of the location filters of a request,
+// a request with invalid filters is not filtered
+func (db database) locationFilters(r *http.Request) (string, []interface{}) {
+	filter, err := ParseLocationFilter(r)
+	if err != nil {
+		return "", nil
+	}
+
+	query := ""
+	args := []interface{}{}
+	if len(filter.Regions) > 0 {
+		query += " AND region IN ?"
+		args = append(args, filter.Regions)
+	}
+	if filter.Overlap > 0 {
+		query += " AND timezone IN ?"
+		args = append(args, db.overlappingTimezones(filter))
+	}
+	return query, args
+}
@@ -0,0 +1,73 @@
+package db
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLocationFilter(t *testing.T) {
+	t.Run("should read the regions and the overlap", func(t *testing.T) {
+		filter, err := ParseLocationFilter(httptest.NewRequest("GET", "/people?region=eu,NA&tz_overlap=4&tz=Europe/Berlin", nil))
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"EU", "NA"}, filter.Regions)
+		assert.Equal(t, 4, filter.Overlap)
+		assert.Equal(t, "Europe/Berlin", filter.Timezone.String())
+	})
+
+	t.Run("should not filter without parameters", func(t *testing.T) {
+		filter, err := ParseLocationFilter(httptest.NewRequest("GET", "/people", nil))
+
+		assert.NoError(t, err)
+		assert.Equal(t, LocationFilter{}, filter)
+	})
+
+	t.Run("should refuse invalid filters", func(t *testing.T) {
+		for _, query := range []string{"region=MARS", "tz_overlap=9&tz=UTC", "tz_overlap=4", "tz_overlap=4&tz=Mars/Olympus"} {
+			_, err := ParseLocationFilter(httptest.NewRequest("GET", "/people?"+query, nil))
+			assert.Error(t, err, query)
+		}
+	})
+}
+
+func TestValidLocale(t *testing.T) {
+	for _, locale := range []string{"en", "pt-BR", "zh-Hant-TW", "es-419"} {
+		assert.True(t, ValidLocale(locale), locale)
+	}
+	for _, locale := range []string{"", "english", "en_US", "EN-us", "en-US-x"} {
+		assert.False(t, ValidLocale(locale), locale)
+	}
+}
+
+func TestWorkspaceLocation(t *testing.T) {
+	assert.Equal(t, time.UTC, Workspace{}.Location())
+	assert.Equal(t, time.UTC, Workspace{Timezone: "Mars/Olympus"}.Location())
+	assert.Equal(t, "Asia/Tokyo", Workspace{Timezone: "Asia/Tokyo"}.Location().String())
+}
+
+func TestBountyDeadline(t *testing.T) {
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+
+	deadline, ok := BountyDeadline("2024-05-01", tokyo)
+	assert.True(t, ok)
+	assert.True(t, deadline.Equal(time.Date(2024, 5, 1, 15, 0, 0, 0, time.UTC)), deadline)
+
+	deadline, ok = BountyDeadline("2024-05-01T12:00:00Z", tokyo)
+	assert.True(t, ok)
+	assert.True(t, deadline.Equal(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)), deadline)
+
+	_, ok = BountyDeadline("next week", tokyo)
+	assert.False(t, ok)
+}
+
+func TestPaymentDateRangeInLocation(t *testing.T) {
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+
+	assert.Equal(t, PaymentDateRange{StartDate: "1714489200", EndDate: "1717167599"},
+		PaymentDateRange{StartDate: "2024-05-01", EndDate: "2024-05-31"}.InLocation(tokyo))
+	assert.Equal(t, PaymentDateRange{StartDate: "1111", EndDate: "2222"},
+		PaymentDateRange{StartDate: "1111", EndDate: "2222"}.InLocation(tokyo))
+}
@@ -0,0 +1,84 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// the id of the single maintenance row
+const maintenanceID = 1
+
+// the maintenance mode as last read by this instance, see WatchMaintenance
+var currentMaintenance atomic.Value
+
+func (db database) GetMaintenance() (Maintenance, error) {
+	m := Maintenance{}
+	err := db.db.Where("id = ?", maintenanceID).Limit(1).Find(&m).Error
+	return m, err
+}
+
+// SetMaintenance turns the maintenance mode on or off. StartedAt is kept
+// while maintenance stays on, so a new estimate doesn't restart it
+func (db database) SetMaintenance(m Maintenance) (Maintenance, error) {
+	current, err := db.GetMaintenance()
+	if err != nil {
+		return m, err
+	}
+
+	now := time.Now()
+	m.ID = maintenanceID
+	m.Updated = &now
+	m.StartedAt = current.StartedAt
+	if m.Enabled && !current.Enabled {
+		m.StartedAt = &now
+	}
+	if !m.Enabled {
+		m.EndsAt = nil
+	}
+	if err := db.db.Save(&m).Error; err != nil {
+		return m, err
+	}
+	SetCurrentMaintenance(m)
+	return m, nil
+}
+
+// CurrentMaintenance is the maintenance mode of the API as this instance
+// knows it, without a query
+func CurrentMaintenance() Maintenance {
+	m, _ := currentMaintenance.Load().(Maintenance)
+	return m
+}
+
+// InMaintenance reports if the API is in maintenance mode
+func InMaintenance() bool {
+	return CurrentMaintenance().Enabled
+}
+
+func SetCurrentMaintenance(m Maintenance) {
+	currentMaintenance.Store(m)
+}
+
+// WatchMaintenance reads the maintenance mode every interval until ctx is
+// cancelled, so the toggle of one instance reaches the others. When the
+// database can't be read, during a migration, the last mode is kept
+func WatchMaintenance(ctx context.Context, database Database, interval time.Duration) {
+	failing := false
+	for {
+		m, err := database.GetMaintenance()
+		if err != nil && !failing {
+			fmt.Println("[maintenance] could not read the maintenance mode, keeping the last one", err)
+		}
+		if err == nil {
+			SetCurrentMaintenance(m)
+		}
+		failing = err != nil
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(interval):
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package db
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// PersonGithubLogin is the GitHub login a person linked on their profile,
+// lowercased, or "" when they linked none. The profile keeps it in the
+// github extra as a list of {value} entries, some older profiles as a
+// plain string or a profile url
+func PersonGithubLogin(person Person) string {
+	login := ""
+	switch github := person.Extras["github"].(type) {
+	case string:
+		login = github
+	case []interface{}:
+		if len(github) > 0 {
+			if entry, ok := github[0].(map[string]interface{}); ok {
+				login, _ = entry["value"].(string)
+			}
+		}
+	}
+
+	login = strings.ToLower(strings.TrimSpace(login))
+	login = strings.TrimPrefix(login, "https://")
+	login = strings.TrimPrefix(login, "http://")
+	login = strings.TrimPrefix(login, "github.com/")
+	login = strings.TrimPrefix(login, "@")
+	return strings.Trim(login, "/")
+}
+
+// GetGithubSyncWorkspaces returns the workspaces linked to a GitHub
+// organization
+func (db database) GetGithubSyncWorkspaces() ([]Workspace, error) {
+	workspaces := []Workspace{}
+	err := db.db.Where("github_org != '' AND (deleted = false OR deleted IS NULL)").Find(&workspaces).Error
+	return workspaces, err
+}
+
+// SyncWorkspaceMemberSuggestions replaces the logins of the GitHub
+// organization of a workspace, each matched to the person who linked it
+func (db database) SyncWorkspaceMemberSuggestions(workspaceUuid string, logins []GithubOrgLogin, at time.Time) error {
+	people := []Person{}
+	err := db.db.Select("owner_pub_key", "extras").
+		Where("extras -> 'github' IS NOT NULL AND (deleted = false OR deleted IS NULL)").Find(&people).Error
+	if err != nil {
+		return err
+	}
+	pubkeys := map[string]string{}
+	for _, person := range people {
+		if login := PersonGithubLogin(person); login != "" {
+			pubkeys[login] = person.OwnerPubKey
+		}
+	}
+
+	suggestions := []WorkspaceMemberSuggestion{}
+	for _, login := range logins {
+		name := strings.ToLower(login.Login)
+		suggestions = append(suggestions, WorkspaceMemberSuggestion{
+			WorkspaceUuid: workspaceUuid,
+			GithubLogin:   name,
+			OwnerPubKey:   pubkeys[name],
+			OrgMember:     login.OrgMember,
+			Contributions: login.Contributions,
+			Synced:        &at,
+		})
+	}
+
+	return db.transaction(func(tx database) error {
+		if err := tx.db.Where("workspace_uuid = ?", workspaceUuid).Delete(&WorkspaceMemberSuggestion{}).Error; err != nil {
+			return err
+		}
+		if len(suggestions) == 0 {
+			return nil
+		}
+		return tx.db.CreateInBatches(&suggestions, 500).Error
+	})
+}
+
+// GetWorkspaceMemberSuggestions returns the logins of the GitHub
+// organization of a workspace that a person linked and who is not yet a
+// member of the workspace. Organization members come first, then the
+// contributors with the most commits
+func (db database) GetWorkspaceMemberSuggestions(workspace Workspace) ([]WorkspaceMemberSuggestion, error) {
+	suggestions := []WorkspaceMemberSuggestion{}
+	err := db.db.Where("workspace_uuid = ? AND owner_pub_key != '' AND owner_pub_key != ?", workspace.Uuid, workspace.OwnerPubKey).
+		Where("owner_pub_key NOT IN (?)", db.db.Model(&WorkspaceUsers{}).Select("owner_pub_key").Where("workspace_uuid = ?", workspace.Uuid)).
+		Order("org_member DESC, contributions DESC, github_login ASC").Find(&suggestions).Error
+	if err != nil || len(suggestions) == 0 {
+		return suggestions, err
+	}
+
+	pubkeys := []string{}
+	for _, suggestion := range suggestions {
+		pubkeys = append(pubkeys, suggestion.OwnerPubKey)
+	}
+	people := []Person{}
+	if err := db.db.Select("owner_pub_key", "owner_alias", "img").Where("owner_pub_key IN ?", pubkeys).Find(&people).Error; err != nil {
+		return suggestions, err
+	}
+	profiles := map[string]Person{}
+	for _, person := range people {
+		profiles[person.OwnerPubKey] = person
+	}
+	for i := range suggestions {
+		suggestions[i].OwnerAlias = profiles[suggestions[i].OwnerPubKey].OwnerAlias
+		suggestions[i].Img = profiles[suggestions[i].OwnerPubKey].Img
+	}
+	return suggestions, nil
+}
+
+// MergeGithubOrgLogins lists the members of an organization and the
+// contributors of its repositories once each, by lowercased login. The
+// members come first, then the contributors with the most commits
+func MergeGithubOrgLogins(members []string, contributions map[string]int) []GithubOrgLogin {
+	byLogin := map[string]*GithubOrgLogin{}
+	add := func(login string) *GithubOrgLogin {
+		login = strings.ToLower(strings.TrimSpace(login))
+		if login == "" {
+			return nil
+		}
+		if _, ok := byLogin[login]; !ok {
+			byLogin[login] = &GithubOrgLogin{Login: login}
+		}
+		return byLogin[login]
+	}
+	for _, member := range members {
+		if entry := add(member); entry != nil {
+			entry.OrgMember = true
+		}
+	}
+	for login, count := range contributions {
+		if entry := add(login); entry != nil {
+			entry.Contributions += count
+		}
+	}
+
+	logins := []GithubOrgLogin{}
+	for _, entry := range byLogin {
+		logins = append(logins, *entry)
+	}
+	sort.Slice(logins, func(i, j int) bool {
+		a, b := logins[i], logins[j]
+		if a.OrgMember != b.OrgMember {
+			return a.OrgMember
+		}
+		if a.Contributions != b.Contributions {
+			return a.Contributions > b.Contributions
+		}
+		return a.Login < b.Login
+	})
+	return logins
+}
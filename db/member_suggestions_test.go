@@ -0,0 +1,30 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPersonGithubLogin(t *testing.T) {
+	linked := func(github interface{}) Person {
+		return Person{Extras: PropertyMap{"github": github}}
+	}
+
+	assert.Equal(t, "ada", PersonGithubLogin(linked([]interface{}{map[string]interface{}{"value": " Ada "}})))
+	assert.Equal(t, "ada", PersonGithubLogin(linked("https://github.com/Ada/")))
+	assert.Equal(t, "ada", PersonGithubLogin(linked("@ada")))
+	assert.Equal(t, "", PersonGithubLogin(linked([]interface{}{})))
+	assert.Equal(t, "", PersonGithubLogin(Person{}))
+}
+
+func TestMergeGithubOrgLogins(t *testing.T) {
+	logins := MergeGithubOrgLogins([]string{"Ada", "grace"}, map[string]int{"ada": 5, "linus": 40, "ken": 40, "": 3})
+
+	assert.Equal(t, []GithubOrgLogin{
+		{Login: "ada", OrgMember: true, Contributions: 5},
+		{Login: "grace", OrgMember: true},
+		{Login: "ken", Contributions: 40},
+		{Login: "linus", Contributions: 40},
+	}, logins)
+}
@@ -0,0 +1,65 @@
+package db
+
+import (
+	"time"
+
+	"gorm.io/gorm/clause"
+)
+
+// SaveMentions replaces the mentions of a bounty or feature with the ones
+// of its saved text and returns the mentions it did not have before
+func (db database) SaveMentions(sourceType string, sourceID string, mentions []Mention) ([]Mention, error) {
+	added := []Mention{}
+	err := db.transaction(func(tx database) error {
+		existing := map[string]bool{}
+		for _, m := range tx.GetMentions(sourceType, sourceID) {
+			existing[m.TargetType+":"+m.TargetID] = true
+		}
+
+		query := tx.db.Where("source_type = ? AND source_id = ?", sourceType, sourceID)
+		kept := []string{}
+		for _, m := range mentions {
+			kept = append(kept, m.TargetType+":"+m.TargetID)
+		}
+		if len(kept) > 0 {
+			query = query.Where("target_type || ':' || target_id NOT IN ?", kept)
+		}
+		if err := query.Delete(&Mention{}).Error; err != nil {
+			return err
+		}
+		if len(mentions) == 0 {
+			return nil
+		}
+
+		now := time.Now()
+		for i := range mentions {
+			mentions[i].SourceType = sourceType
+			mentions[i].SourceID = sourceID
+			mentions[i].Created = &now
+			if !existing[mentions[i].TargetType+":"+mentions[i].TargetID] {
+				added = append(added, mentions[i])
+			}
+		}
+		// the titles are refreshed on every save
+		return tx.db.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "source_type"}, {Name: "source_id"}, {Name: "target_type"}, {Name: "target_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"text", "title", "img"}),
+		}).Create(&mentions).Error
+	})
+	return added, err
+}
+
+// GetMentions returns what the text of a bounty or feature references
+func (db database) GetMentions(sourceType string, sourceID string) []Mention {
+	mentions := []Mention{}
+	db.db.Where("source_type = ? AND source_id = ?", sourceType, sourceID).Order("id").Find(&mentions)
+	return mentions
+}
+
+// GetMentionsOf returns the bounties and features that reference a person,
+// bounty or feature, newest first
+func (db database) GetMentionsOf(targetType string, targetID string) []Mention {
+	mentions := []Mention{}
+	db.db.Where("target_type = ? AND target_id = ?", targetType, targetID).Order("id DESC").Find(&mentions)
+	return mentions
+}
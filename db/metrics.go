@@ -5,6 +5,7 @@ import (
 	"math"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/stakwork/sphinx-tribes/utils"
 )
@@ -96,7 +97,7 @@ func (db database) TotalAssignedBounties(r PaymentDateRange, workspace string) i
 
 func (db database) TotalHuntersPaid(r PaymentDateRange, workspace string) int64 {
 	var count int64
-	query := fmt.Sprintf(`SELECT COUNT(DISTINCT assignee) FROM bounty WHERE assignee !='' AND paid=true AND created >= %s AND created <= %s`, r.StartDate, r.EndDate)
+	query := fmt.Sprintf(`SELECT COUNT(DISTINCT assignee) FROM bounty WHERE assignee !='' AND paid=true AND deleted_at IS NULL AND created >= %s AND created <= %s`, r.StartDate, r.EndDate)
 
 	var workspaceQuery string
 	if workspace != "" {
@@ -132,7 +133,7 @@ func (db database) NewHuntersPaid(r PaymentDateRange, workspace string) int64 {
 
 func (db database) TotalBountiesPosted(r PaymentDateRange, workspace string) int64 {
 	var count int64
-	query := db.db.Model(&Bounty{}).Where("created >= ?", r.StartDate).Where("created <= ?", r.EndDate)
+	query := db.db.Model(&NewBounty{}).Where("created >= ?", r.StartDate).Where("created <= ?", r.EndDate)
 
 	if workspace != "" {
 		query.Where("workspace_uuid", workspace)
@@ -156,7 +157,7 @@ func (db database) BountiesPaidPercentage(r PaymentDateRange, workspace string)
 func (db database) PaidDifference(r PaymentDateRange, workspace string) []DateDifference {
 	ms := []DateDifference{}
 
-	query := fmt.Sprintf("SELECT EXTRACT(EPOCH FROM (paid_date - TO_TIMESTAMP(created))) as diff FROM public.bounty WHERE paid_date IS NOT NULL AND created >= %s AND created <= %s", r.StartDate, r.EndDate)
+	query := fmt.Sprintf("SELECT EXTRACT(EPOCH FROM (paid_date - TO_TIMESTAMP(created))) as diff FROM public.bounty WHERE paid_date IS NOT NULL AND deleted_at IS NULL AND created >= %s AND created <= %s", r.StartDate, r.EndDate)
 
 	var workspaceQuery string
 	if workspace != "" {
@@ -188,7 +189,7 @@ func (db database) AveragePaidTime(r PaymentDateRange, workspace string) uint {
 func (db database) CompletedDifference(r PaymentDateRange, workspace string) []DateDifference {
 	ms := []DateDifference{}
 
-	query := fmt.Sprintf("SELECT EXTRACT(EPOCH FROM (completion_date - TO_TIMESTAMP(created))) as diff FROM public.bounty WHERE completion_date IS NOT NULL AND created >= %s AND created <= %s", r.StartDate, r.EndDate)
+	query := fmt.Sprintf("SELECT EXTRACT(EPOCH FROM (completion_date - TO_TIMESTAMP(created))) as diff FROM public.bounty WHERE completion_date IS NOT NULL AND deleted_at IS NULL AND created >= %s AND created <= %s", r.StartDate, r.EndDate)
 
 	var workspaceQuery string
 	if workspace != "" {
@@ -277,7 +278,7 @@ func (db database) GetBountiesByDateRange(r PaymentDateRange, re *http.Request)
 		providerCondition = " AND owner_id IN ('" + strings.Join(providerSlice, "','") + "')"
 	}
 
-	query := `SELECT * FROM public.bounty WHERE created >= '` + r.StartDate + `'  AND created <= '` + r.EndDate + `'` + providerCondition
+	query := `SELECT * FROM public.bounty WHERE deleted_at IS NULL AND created >= '` + r.StartDate + `'  AND created <= '` + r.EndDate + `'` + providerCondition
 	allQuery := query + " " + workspaceQuery + " " + statusQuery + " " + orderQuery + " " + limitQuery
 
 	b := []NewBounty{}
@@ -325,7 +326,7 @@ func (db database) GetBountiesByDateRangeCount(r PaymentDateRange, re *http.Requ
 
 	var count int64
 
-	query := `SELECT COUNT(*) FROM public.bounty WHERE created >= '` + r.StartDate + `'  AND created <= '` + r.EndDate + `'` + providerCondition
+	query := `SELECT COUNT(*) FROM public.bounty WHERE deleted_at IS NULL AND created >= '` + r.StartDate + `'  AND created <= '` + r.EndDate + `'` + providerCondition
 	allQuery := query + " " + workspaceQuery + " " + statusQuery
 	db.db.Raw(allQuery).Scan(&count)
 	return count
@@ -373,7 +374,7 @@ func (db database) GetBountiesProviders(r PaymentDateRange, re *http.Request) []
 	bountyOwners := []BountyOwners{}
 	bountyProviders := []Person{}
 
-	query := `SELECT DISTINCT owner_id FROM public.bounty WHERE created >= '` + r.StartDate + `'  AND created <= '` + r.EndDate + `'` + providerCondition
+	query := `SELECT DISTINCT owner_id FROM public.bounty WHERE deleted_at IS NULL AND created >= '` + r.StartDate + `'  AND created <= '` + r.EndDate + `'` + providerCondition
 	allQuery := query + " " + statusQuery + " " + limitQuery
 	db.db.Raw(allQuery).Scan(&bountyOwners)
 
@@ -383,3 +384,33 @@ func (db database) GetBountiesProviders(r PaymentDateRange, re *http.Request) []
 	}
 	return bountyProviders
 }
+
+// GetPublicMetrics adds up every paid bounty that is not deleted. The
+// median time to pay runs from the creation of a bounty to its payment
+func (db database) GetPublicMetrics(activeSince time.Time) (PublicMetrics, error) {
+	metrics := PublicMetrics{}
+	paid := struct {
+		Count int64
+		Sats  uint64
+		Hours float64
+	}{}
+	err := db.db.Model(&NewBounty{}).
+		Select(`COUNT(*) AS count, COALESCE(SUM(price), 0) AS sats,
+			COALESCE(PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM (paid_date - TO_TIMESTAMP(created))) / 3600), 0) AS hours`).
+		Where("paid = true AND paid_date IS NOT NULL").Scan(&paid).Error
+	if err != nil {
+		return metrics, err
+	}
+
+	err = db.db.Model(&NewBounty{}).
+		Where("assignee != '' AND (assigned_date >= ? OR paid_date >= ?)", activeSince, activeSince).
+		Distinct("assignee").Count(&metrics.ActiveHunters).Error
+	if err != nil {
+		return metrics, err
+	}
+
+	metrics.BountiesPaid = paid.Count
+	metrics.SatsPaid = paid.Sats
+	metrics.MedianHoursToPay = math.Round(paid.Hours*10) / 10
+	return metrics, nil
+}
@@ -0,0 +1,239 @@
+package db
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Migration is a versioned schema change. Versions are applied in order and
+// recorded in schema_migrations, each one in its own transaction. Migrations
+// without a Down can not be rolled back
+type Migration struct {
+	Version int64
+	Name    string
+	Up      func(tx *gorm.DB) error
+	Down    func(tx *gorm.DB) error
+}
+
+type SchemaMigration struct {
+	Version   int64     `gorm:"primaryKey;autoIncrement:false" json:"version"`
+	Name      string    `json:"name"`
+	AppliedAt time.Time `json:"applied_at"`
+}
+
+type MigrationStatus struct {
+	Version   int64      `json:"version"`
+	Name      string     `json:"name"`
+	Applied   bool       `json:"applied"`
+	AppliedAt *time.Time `json:"applied_at"`
+}
+
+func sortedMigrations() []Migration {
+	sorted := make([]Migration, len(migrations))
+	copy(sorted, migrations)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Version < sorted[j].Version })
+	return sorted
+}
+
+func (db database) appliedMigrations() (map[int64]SchemaMigration, error) {
+	if err := db.db.AutoMigrate(&SchemaMigration{}); err != nil {
+		return nil, err
+	}
+
+	rows := []SchemaMigration{}
+	if err := db.db.Order("version ASC").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	applied := map[int64]SchemaMigration{}
+	for _, row := range rows {
+		applied[row.Version] = row
+	}
+	return applied, nil
+}
+
+// PendingMigrations returns the migrations that are not applied yet
+func (db database) PendingMigrations() ([]Migration, error) {
+	applied, err := db.appliedMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	pending := []Migration{}
+	for _, m := range sortedMigrations() {
+		if _, ok := applied[m.Version]; !ok {
+			pending = append(pending, m)
+		}
+	}
+	return pending, nil
+}
+
+// UnknownMigrations returns versions recorded in the database that this
+// binary doesn't know about, i.e the schema is newer than the code
+func (db database) UnknownMigrations() ([]SchemaMigration, error) {
+	applied, err := db.appliedMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	known := map[int64]bool{}
+	for _, m := range migrations {
+		known[m.Version] = true
+	}
+
+	unknown := []SchemaMigration{}
+	for version, row := range applied {
+		if !known[version] {
+			unknown = append(unknown, row)
+		}
+	}
+	sort.Slice(unknown, func(i, j int) bool { return unknown[i].Version < unknown[j].Version })
+	return unknown, nil
+}
+
+// MigrateUp applies every pending migration and returns the ones applied
+func (db database) MigrateUp() ([]Migration, error) {
+	pending, err := db.PendingMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	applied := []Migration{}
+	for _, m := range pending {
+		err := db.db.Transaction(func(tx *gorm.DB) error {
+			if err := m.Up(tx); err != nil {
+				return err
+			}
+			return tx.Create(&SchemaMigration{Version: m.Version, Name: m.Name, AppliedAt: time.Now()}).Error
+		})
+		if err != nil {
+			return applied, fmt.Errorf("migration %d %s failed: %w", m.Version, m.Name, err)
+		}
+		fmt.Printf("[migrate] applied %d %s\n", m.Version, m.Name)
+		applied = append(applied, m)
+	}
+	return applied, nil
+}
+
+// MigrateDown rolls back the last steps applied migrations
+func (db database) MigrateDown(steps int) ([]Migration, error) {
+	applied, err := db.appliedMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	sorted := sortedMigrations()
+	rolledBack := []Migration{}
+	for i := len(sorted) - 1; i >= 0 && len(rolledBack) < steps; i-- {
+		m := sorted[i]
+		if _, ok := applied[m.Version]; !ok {
+			continue
+		}
+		if m.Down == nil {
+			return rolledBack, fmt.Errorf("migration %d %s can not be rolled back", m.Version, m.Name)
+		}
+
+		err := db.db.Transaction(func(tx *gorm.DB) error {
+			if err := m.Down(tx); err != nil {
+				return err
+			}
+			return tx.Delete(&SchemaMigration{}, "version = ?", m.Version).Error
+		})
+		if err != nil {
+			return rolledBack, fmt.Errorf("rollback of %d %s failed: %w", m.Version, m.Name, err)
+		}
+		fmt.Printf("[migrate] rolled back %d %s\n", m.Version, m.Name)
+		rolledBack = append(rolledBack, m)
+	}
+	return rolledBack, nil
+}
+
+func (db database) MigrationStatuses() ([]MigrationStatus, error) {
+	applied, err := db.appliedMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := []MigrationStatus{}
+	for _, m := range sortedMigrations() {
+		status := MigrationStatus{Version: m.Version, Name: m.Name}
+		if row, ok := applied[m.Version]; ok {
+			appliedAt := row.AppliedAt
+			status.Applied = true
+			status.AppliedAt = &appliedAt
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// CheckMigrations is the startup safety check. Pending migrations are applied
+// unless MIGRATE_ON_START is false, in which case the app refuses to start
+// until `migrate up` is run. A schema newer than the binary is always refused
+func (db database) CheckMigrations() error {
+	unknown, err := db.UnknownMigrations()
+	if err != nil {
+		return err
+	}
+	if len(unknown) > 0 {
+		return fmt.Errorf("database has %d migrations this build doesn't know about (latest %d %s)", len(unknown), unknown[len(unknown)-1].Version, unknown[len(unknown)-1].Name)
+	}
+
+	pending, err := db.PendingMigrations()
+	if err != nil {
+		return err
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+
+	if os.Getenv("MIGRATE_ON_START") == "false" {
+		return fmt.Errorf("database has %d pending migrations, run `migrate up` first", len(pending))
+	}
+
+	_, err = db.MigrateUp()
+	return err
+}
+
+// execSQL builds the Up or Down of a migration from plain statements
+func execSQL(statements ...string) func(tx *gorm.DB) error {
+	return func(tx *gorm.DB) error {
+		for _, statement := range statements {
+			if err := tx.Exec(statement).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// createTables and dropTables build the Up and Down of a migration that only
+// adds new tables
+func createTables(models ...interface{}) func(tx *gorm.DB) error {
+	return func(tx *gorm.DB) error {
+		for _, model := range models {
+			if tx.Migrator().HasTable(model) {
+				continue
+			}
+			if err := tx.Migrator().CreateTable(model); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func dropTables(models ...interface{}) func(tx *gorm.DB) error {
+	return func(tx *gorm.DB) error {
+		for i := len(models) - 1; i >= 0; i-- {
+			if err := tx.Migrator().DropTable(models[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,23 @@
+package db
+
+import (
+	"testing"
+)
+
+func TestMigrationsAreOrdered(t *testing.T) {
+	seen := map[int64]bool{}
+	var last int64
+	for _, m := range migrations {
+		if m.Version <= last {
+			t.Errorf("migration %d %s is out of order, versions must be appended in increasing order", m.Version, m.Name)
+		}
+		if seen[m.Version] {
+			t.Errorf("duplicate migration version %d", m.Version)
+		}
+		if m.Name == "" || m.Up == nil {
+			t.Errorf("migration %d needs a name and an Up", m.Version)
+		}
+		seen[m.Version] = true
+		last = m.Version
+	}
+}
@@ -0,0 +1,687 @@
+package db
+
+import "gorm.io/gorm"
+
+// migrations lists every schema change, new changes are appended with the
+// next version. Applied migrations must never be edited
+var migrations = []Migration{
+	{
+		// the schema as it was when migrations were introduced, see
+		// migrations_baseline.go
+		Version: 1,
+		Name:    "baseline",
+		Up:      execSQL(append([]string{baselineRenames}, baselineSchema...)...),
+	},
+	{
+		Version: 2,
+		Name:    "create_hive_chats",
+		Up:      createTables(&Chat{}, &ChatMessage{}),
+		Down:    dropTables(&Chat{}, &ChatMessage{}),
+	},
+	{
+		Version: 3,
+		Name:    "create_jobs",
+		Up:      createTables(&Job{}),
+		Down:    dropTables(&Job{}),
+	},
+	{
+		// the filters behind the bounty lists, the phase bounties and the
+		// workspace payment history
+		Version: 4,
+		Name:    "add_hot_query_indexes",
+		Up: execSQL(
+			"CREATE INDEX IF NOT EXISTS idx_bounty_workspace_status_created ON bounty (workspace_uuid, paid, completed, created DESC)",
+			"CREATE INDEX IF NOT EXISTS idx_bounty_phase_priority ON bounty (phase_uuid, phase_priority)",
+			"CREATE INDEX IF NOT EXISTS idx_feature_phases_feature_uuid ON feature_phases (feature_uuid, uuid)",
+			"CREATE INDEX IF NOT EXISTS idx_payment_histories_workspace_created ON payment_histories (workspace_uuid, created DESC)",
+		),
+		Down: execSQL(
+			"DROP INDEX IF EXISTS idx_payment_histories_workspace_created",
+			"DROP INDEX IF EXISTS idx_feature_phases_feature_uuid",
+			"DROP INDEX IF EXISTS idx_bounty_phase_priority",
+			"DROP INDEX IF EXISTS idx_bounty_workspace_status_created",
+		),
+	},
+	{
+		Version: 5,
+		Name:    "create_events",
+		Up:      createTables(&Event{}, &EventConsumer{}),
+		Down:    dropTables(&Event{}, &EventConsumer{}),
+	},
+	{
+		Version: 6,
+		Name:    "create_platform_stats",
+		Up:      createTables(&PlatformStats{}),
+		Down:    dropTables(&PlatformStats{}),
+	},
+	{
+		Version: 7,
+		Name:    "create_moderation",
+		Up:      createTables(&Report{}, &BannedPubkey{}),
+		Down:    dropTables(&Report{}, &BannedPubkey{}),
+	},
+	{
+		// the full text search of the postgres search engine
+		Version: 8,
+		Name:    "add_search_indexes",
+		Up: execSQL(
+			"CREATE INDEX IF NOT EXISTS idx_tribes_tsv ON tribes USING GIN (tsv)",
+			"CREATE INDEX IF NOT EXISTS idx_people_search ON people USING GIN ("+peopleSearchVector+")",
+			"CREATE INDEX IF NOT EXISTS idx_bounty_search ON bounty USING GIN ("+bountySearchVector+")",
+		),
+		Down: execSQL(
+			"DROP INDEX IF EXISTS idx_bounty_search",
+			"DROP INDEX IF EXISTS idx_people_search",
+			"DROP INDEX IF EXISTS idx_tribes_tsv",
+		),
+	},
+	{
+		Version: 9,
+		Name:    "create_retention",
+		Up:      createTables(&RetentionRun{}, &EventArchive{}),
+		Down:    dropTables(&RetentionRun{}, &EventArchive{}),
+	},
+	{
+		// soft deletes, the deleted tribes and channels keep their flag and
+		// get the time of the migration as their deletion time
+		Version: 10,
+		Name:    "add_soft_deletes",
+		Up: execSQL(
+			"ALTER TABLE tribes ADD COLUMN IF NOT EXISTS deleted_at timestamptz",
+			"ALTER TABLE channels ADD COLUMN IF NOT EXISTS deleted_at timestamptz",
+			"ALTER TABLE bounty ADD COLUMN IF NOT EXISTS deleted_at timestamptz",
+			"ALTER TABLE workspace_features ADD COLUMN IF NOT EXISTS deleted_at timestamptz",
+			"CREATE INDEX IF NOT EXISTS idx_tribes_deleted_at ON tribes (deleted_at)",
+			"CREATE INDEX IF NOT EXISTS idx_channels_deleted_at ON channels (deleted_at)",
+			"CREATE INDEX IF NOT EXISTS idx_bounty_deleted_at ON bounty (deleted_at)",
+			"CREATE INDEX IF NOT EXISTS idx_workspace_features_deleted_at ON workspace_features (deleted_at)",
+			"UPDATE tribes SET deleted_at = now() WHERE deleted = true AND deleted_at IS NULL",
+			"UPDATE channels SET deleted_at = now() WHERE deleted = true AND deleted_at IS NULL",
+		),
+		Down: execSQL(
+			"ALTER TABLE workspace_features DROP COLUMN IF EXISTS deleted_at",
+			"ALTER TABLE bounty DROP COLUMN IF EXISTS deleted_at",
+			"ALTER TABLE channels DROP COLUMN IF EXISTS deleted_at",
+			"ALTER TABLE tribes DROP COLUMN IF EXISTS deleted_at",
+		),
+	},
+	{
+		// the activity feed, the events saved before it get the pubkeys
+		// their payload names
+		Version: 11,
+		Name:    "add_event_pub_keys",
+		Up: execSQL(
+			"ALTER TABLE events ADD COLUMN IF NOT EXISTS pub_keys text[]",
+			"CREATE INDEX IF NOT EXISTS idx_events_pub_keys ON events USING GIN (pub_keys)",
+			`UPDATE events SET pub_keys = array_remove(array_remove(ARRAY[
+				payload->>'owner_id', payload->>'owner_pubkey', payload->>'assignee',
+				payload->>'sender_pubkey', payload->>'receiver_pubkey', payload->>'reporter_pubkey'
+			], NULL), '') WHERE pub_keys IS NULL`,
+		),
+		Down: execSQL(
+			"DROP INDEX IF EXISTS idx_events_pub_keys",
+			"ALTER TABLE events DROP COLUMN IF EXISTS pub_keys",
+		),
+	},
+	{
+		Version: 12,
+		Name:    "create_notifications",
+		Up: func(tx *gorm.DB) error {
+			if err := tx.Exec("ALTER TABLE events ADD COLUMN IF NOT EXISTS actor text").Error; err != nil {
+				return err
+			}
+			return createTables(&Notification{})(tx)
+		},
+		Down: func(tx *gorm.DB) error {
+			if err := dropTables(&Notification{})(tx); err != nil {
+				return err
+			}
+			return tx.Exec("ALTER TABLE events DROP COLUMN IF EXISTS actor").Error
+		},
+	},
+	{
+		Version: 13,
+		Name:    "create_workspace_artifacts",
+		Up:      createTables(&WorkspaceArtifact{}, &WorkspaceArtifactVersion{}),
+		Down:    dropTables(&WorkspaceArtifact{}, &WorkspaceArtifactVersion{}),
+	},
+	{
+		Version: 14,
+		Name:    "add_feature_budgets",
+		Up: func(tx *gorm.DB) error {
+			if err := tx.Exec("ALTER TABLE workspace_features ADD COLUMN IF NOT EXISTS budget bigint NOT NULL DEFAULT 0").Error; err != nil {
+				return err
+			}
+			return createTables(&FeatureBudgetHistory{})(tx)
+		},
+		Down: func(tx *gorm.DB) error {
+			if err := dropTables(&FeatureBudgetHistory{})(tx); err != nil {
+				return err
+			}
+			return tx.Exec("ALTER TABLE workspace_features DROP COLUMN IF EXISTS budget").Error
+		},
+	},
+	{
+		Version: 15,
+		Name:    "create_mentions",
+		Up:      createTables(&Mention{}),
+		Down:    dropTables(&Mention{}),
+	},
+	{
+		Version: 16,
+		Name:    "create_workspace_briefs",
+		Up:      createTables(&WorkspaceBrief{}),
+		Down:    dropTables(&WorkspaceBrief{}),
+	},
+	{
+		Version: 17,
+		Name:    "create_workspace_reserves",
+		Up: func(tx *gorm.DB) error {
+			if err := execSQL(
+				"ALTER TABLE bounty_budgets ADD COLUMN IF NOT EXISTS reserve_percent bigint NOT NULL DEFAULT 0",
+				"ALTER TABLE bounty_budgets ADD COLUMN IF NOT EXISTS reserve_balance bigint NOT NULL DEFAULT 0",
+			)(tx); err != nil {
+				return err
+			}
+			return createTables(&ReserveEntry{})(tx)
+		},
+		Down: func(tx *gorm.DB) error {
+			if err := dropTables(&ReserveEntry{})(tx); err != nil {
+				return err
+			}
+			return execSQL(
+				"ALTER TABLE bounty_budgets DROP COLUMN IF EXISTS reserve_balance",
+				"ALTER TABLE bounty_budgets DROP COLUMN IF EXISTS reserve_percent",
+			)(tx)
+		},
+	},
+	{
+		Version: 18,
+		Name:    "add_workspace_auto_pay",
+		Up: execSQL(
+			"ALTER TABLE workspaces ADD COLUMN IF NOT EXISTS auto_pay boolean NOT NULL DEFAULT false",
+			"ALTER TABLE workspaces ADD COLUMN IF NOT EXISTS auto_pay_cap bigint NOT NULL DEFAULT 0",
+			"ALTER TABLE payment_histories ADD COLUMN IF NOT EXISTS auto_initiated boolean NOT NULL DEFAULT false",
+		),
+		Down: execSQL(
+			"ALTER TABLE payment_histories DROP COLUMN IF EXISTS auto_initiated",
+			"ALTER TABLE workspaces DROP COLUMN IF EXISTS auto_pay_cap",
+			"ALTER TABLE workspaces DROP COLUMN IF EXISTS auto_pay",
+		),
+	},
+	{
+		Version: 19,
+		Name:    "create_time_entries",
+		Up: func(tx *gorm.DB) error {
+			if err := tx.Exec("ALTER TABLE payment_histories ADD COLUMN IF NOT EXISTS tracked_minutes bigint NOT NULL DEFAULT 0").Error; err != nil {
+				return err
+			}
+			return createTables(&TimeEntry{})(tx)
+		},
+		Down: func(tx *gorm.DB) error {
+			if err := dropTables(&TimeEntry{})(tx); err != nil {
+				return err
+			}
+			return tx.Exec("ALTER TABLE payment_histories DROP COLUMN IF EXISTS tracked_minutes").Error
+		},
+	},
+	{
+		Version: 20,
+		Name:    "create_quests",
+		Up:      createTables(&Quest{}, &QuestBounty{}),
+		Down:    dropTables(&Quest{}, &QuestBounty{}),
+	},
+	{
+		Version: 21,
+		Name:    "add_connection_code_campaigns",
+		Up: execSQL(
+			"ALTER TABLE connectioncodes ADD COLUMN IF NOT EXISTS campaign text NOT NULL DEFAULT ''",
+			"ALTER TABLE connectioncodes ADD COLUMN IF NOT EXISTS owner_pub_key text NOT NULL DEFAULT ''",
+			"ALTER TABLE connectioncodes ADD COLUMN IF NOT EXISTS date_used timestamptz",
+			"CREATE INDEX IF NOT EXISTS idx_connectioncodes_campaign ON connectioncodes (campaign)",
+		),
+		Down: execSQL(
+			"DROP INDEX IF EXISTS idx_connectioncodes_campaign",
+			"ALTER TABLE connectioncodes DROP COLUMN IF EXISTS date_used",
+			"ALTER TABLE connectioncodes DROP COLUMN IF EXISTS owner_pub_key",
+			"ALTER TABLE connectioncodes DROP COLUMN IF EXISTS campaign",
+		),
+	},
+	{
+		Version: 22,
+		Name:    "add_bounty_versions",
+		Up:      execSQL("ALTER TABLE bounty ADD COLUMN IF NOT EXISTS version integer NOT NULL DEFAULT 1"),
+		Down:    execSQL("ALTER TABLE bounty DROP COLUMN IF EXISTS version"),
+	},
+	{
+		Version: 23,
+		Name:    "create_workspace_exports",
+		Up:      createTables(&WorkspaceExport{}),
+		Down:    dropTables(&WorkspaceExport{}),
+	},
+	{
+		Version: 24,
+		Name:    "create_maintenance",
+		Up:      createTables(&Maintenance{}),
+		Down:    dropTables(&Maintenance{}),
+	},
+	{
+		Version: 25,
+		Name:    "create_bounty_recommendations",
+		Up:      createTables(&BountyRecommendation{}),
+		Down:    dropTables(&BountyRecommendation{}),
+	},
+	{
+		Version: 26,
+		Name:    "create_workspace_digests",
+		Up:      createTables(&WorkspaceDigest{}),
+		Down:    dropTables(&WorkspaceDigest{}),
+	},
+	{
+		Version: 27,
+		Name:    "add_people_location",
+		Up: execSQL(
+			"ALTER TABLE people ADD COLUMN IF NOT EXISTS timezone text NOT NULL DEFAULT ''",
+			"ALTER TABLE people ADD COLUMN IF NOT EXISTS region text NOT NULL DEFAULT ''",
+			"CREATE INDEX IF NOT EXISTS idx_people_region ON people (region)",
+		),
+		Down: execSQL(
+			"DROP INDEX IF EXISTS idx_people_region",
+			"ALTER TABLE people DROP COLUMN IF EXISTS region",
+			"ALTER TABLE people DROP COLUMN IF EXISTS timezone",
+		),
+	},
+	{
+		Version: 28,
+		Name:    "add_definition_of_done",
+		Up: execSQL(
+			"ALTER TABLE workspaces ADD COLUMN IF NOT EXISTS done_requires_checklist boolean NOT NULL DEFAULT false",
+			"ALTER TABLE workspaces ADD COLUMN IF NOT EXISTS done_requires_pull_request boolean NOT NULL DEFAULT false",
+			"ALTER TABLE bounty ADD COLUMN IF NOT EXISTS pull_request_url text NOT NULL DEFAULT ''",
+		),
+		Down: execSQL(
+			"ALTER TABLE bounty DROP COLUMN IF EXISTS pull_request_url",
+			"ALTER TABLE workspaces DROP COLUMN IF EXISTS done_requires_pull_request",
+			"ALTER TABLE workspaces DROP COLUMN IF EXISTS done_requires_checklist",
+		),
+	},
+	{
+		Version: 29,
+		Name:    "create_tribe_spam_scores",
+		Up: func(tx *gorm.DB) error {
+			if err := execSQL(
+				"ALTER TABLE tribes ADD COLUMN IF NOT EXISTS shadow_listed boolean NOT NULL DEFAULT false",
+				"ALTER TABLE tribes ADD COLUMN IF NOT EXISTS spam_cleared boolean NOT NULL DEFAULT false",
+			)(tx); err != nil {
+				return err
+			}
+			return createTables(&TribeSpamScore{}, &TribeAppeal{})(tx)
+		},
+		Down: func(tx *gorm.DB) error {
+			if err := dropTables(&TribeSpamScore{}, &TribeAppeal{})(tx); err != nil {
+				return err
+			}
+			return execSQL(
+				"ALTER TABLE tribes DROP COLUMN IF EXISTS spam_cleared",
+				"ALTER TABLE tribes DROP COLUMN IF EXISTS shadow_listed",
+			)(tx)
+		},
+	},
+	{
+		Version: 30,
+		Name:    "create_bounty_routing_rules",
+		Up: func(tx *gorm.DB) error {
+			if err := execSQL(
+				"ALTER TABLE bounty ADD COLUMN IF NOT EXISTS labels text[] NOT NULL DEFAULT '{}'",
+			)(tx); err != nil {
+				return err
+			}
+			return createTables(&BountyRoutingRule{}, &BountyRouting{})(tx)
+		},
+		Down: func(tx *gorm.DB) error {
+			if err := dropTables(&BountyRoutingRule{}, &BountyRouting{})(tx); err != nil {
+				return err
+			}
+			return execSQL(
+				"ALTER TABLE bounty DROP COLUMN IF EXISTS labels",
+			)(tx)
+		},
+	},
+	{
+		Version: 31,
+		Name:    "add_payment_history_tips",
+		Up: execSQL(
+			"ALTER TABLE payment_histories ADD COLUMN IF NOT EXISTS memo text NOT NULL DEFAULT ''",
+			"ALTER TABLE payment_histories ADD COLUMN IF NOT EXISTS payment_request text NOT NULL DEFAULT ''",
+		),
+		Down: execSQL(
+			"ALTER TABLE payment_histories DROP COLUMN IF EXISTS payment_request",
+			"ALTER TABLE payment_histories DROP COLUMN IF EXISTS memo",
+		),
+	},
+	{
+		Version: 32,
+		Name:    "create_workspace_usage_counts",
+		Up: func(tx *gorm.DB) error {
+			if err := execSQL(
+				"ALTER TABLE workspaces ADD COLUMN IF NOT EXISTS plan text NOT NULL DEFAULT ''",
+			)(tx); err != nil {
+				return err
+			}
+			return createTables(&WorkspaceUsageCount{})(tx)
+		},
+		Down: func(tx *gorm.DB) error {
+			if err := dropTables(&WorkspaceUsageCount{})(tx); err != nil {
+				return err
+			}
+			return execSQL(
+				"ALTER TABLE workspaces DROP COLUMN IF EXISTS plan",
+			)(tx)
+		},
+	},
+	{
+		Version: 33,
+		Name:    "add_feature_phase_dependencies",
+		Up: execSQL(
+			"ALTER TABLE feature_phases ADD COLUMN IF NOT EXISTS depends_on text NOT NULL DEFAULT ''",
+			"ALTER TABLE feature_phases ADD COLUMN IF NOT EXISTS gate_overridden timestamptz",
+			"ALTER TABLE feature_phases ADD COLUMN IF NOT EXISTS gate_overridden_by text NOT NULL DEFAULT ''",
+		),
+		Down: execSQL(
+			"ALTER TABLE feature_phases DROP COLUMN IF EXISTS gate_overridden_by",
+			"ALTER TABLE feature_phases DROP COLUMN IF EXISTS gate_overridden",
+			"ALTER TABLE feature_phases DROP COLUMN IF EXISTS depends_on",
+		),
+	},
+	{
+		Version: 34,
+		Name:    "add_people_verified_skills",
+		Up: execSQL(
+			"ALTER TABLE people ADD COLUMN IF NOT EXISTS verified_skills text[] NOT NULL DEFAULT '{}'",
+		),
+		Down: execSQL(
+			"ALTER TABLE people DROP COLUMN IF EXISTS verified_skills",
+		),
+	},
+	{
+		Version: 35,
+		Name:    "create_tribe_announcements",
+		Up:      createTables(&TribeAnnouncement{}),
+		Down:    dropTables(&TribeAnnouncement{}),
+	},
+	{
+		Version: 36,
+		Name:    "create_workspace_secrets",
+		Up:      createTables(&WorkspaceSecret{}),
+		Down:    dropTables(&WorkspaceSecret{}),
+	},
+	{
+		Version: 37,
+		Name:    "create_upload_sessions",
+		Up:      createTables(&UploadSession{}, &UploadChunk{}),
+		Down:    dropTables(&UploadSession{}, &UploadChunk{}),
+	},
+	{
+		Version: 38,
+		Name:    "add_bounty_view_counts",
+		Up: func(tx *gorm.DB) error {
+			if err := execSQL(
+				"ALTER TABLE bounty ADD COLUMN IF NOT EXISTS view_count bigint NOT NULL DEFAULT 0",
+				"ALTER TABLE bounty ADD COLUMN IF NOT EXISTS unique_viewers bigint NOT NULL DEFAULT 0",
+				"CREATE INDEX IF NOT EXISTS idx_bounty_view_count ON bounty (view_count DESC, unique_viewers DESC)",
+			)(tx); err != nil {
+				return err
+			}
+			return createTables(&BountyViewer{})(tx)
+		},
+		Down: func(tx *gorm.DB) error {
+			if err := dropTables(&BountyViewer{})(tx); err != nil {
+				return err
+			}
+			return execSQL(
+				"DROP INDEX IF EXISTS idx_bounty_view_count",
+				"ALTER TABLE bounty DROP COLUMN IF EXISTS unique_viewers",
+				"ALTER TABLE bounty DROP COLUMN IF EXISTS view_count",
+			)(tx)
+		},
+	},
+	{
+		Version: 39,
+		Name:    "create_proof_reviews",
+		Up: func(tx *gorm.DB) error {
+			if err := execSQL(
+				"ALTER TABLE workspaces ADD COLUMN IF NOT EXISTS default_reviewers text[] NOT NULL DEFAULT '{}'",
+				"ALTER TABLE workspaces ADD COLUMN IF NOT EXISTS required_approvals bigint NOT NULL DEFAULT 0",
+				"ALTER TABLE workspace_features ADD COLUMN IF NOT EXISTS default_reviewers text[] NOT NULL DEFAULT '{}'",
+			)(tx); err != nil {
+				return err
+			}
+			return createTables(&BountyProof{}, &ReviewAssignment{})(tx)
+		},
+		Down: func(tx *gorm.DB) error {
+			if err := dropTables(&BountyProof{}, &ReviewAssignment{})(tx); err != nil {
+				return err
+			}
+			return execSQL(
+				"ALTER TABLE workspace_features DROP COLUMN IF EXISTS default_reviewers",
+				"ALTER TABLE workspaces DROP COLUMN IF EXISTS required_approvals",
+				"ALTER TABLE workspaces DROP COLUMN IF EXISTS default_reviewers",
+			)(tx)
+		},
+	},
+	{
+		Version: 40,
+		Name:    "create_ai_submissions",
+		Up: func(tx *gorm.DB) error {
+			if err := execSQL(
+				"ALTER TABLE workspaces ADD COLUMN IF NOT EXISTS ai_monthly_cap bigint NOT NULL DEFAULT 0",
+			)(tx); err != nil {
+				return err
+			}
+			return createTables(&AiSubmission{})(tx)
+		},
+		Down: func(tx *gorm.DB) error {
+			if err := dropTables(&AiSubmission{})(tx); err != nil {
+				return err
+			}
+			return execSQL("ALTER TABLE workspaces DROP COLUMN IF EXISTS ai_monthly_cap")(tx)
+		},
+	},
+	{
+		Version: 41,
+		Name:    "create_tribe_events",
+		Up:      createTables(&TribeEvent{}),
+		Down:    dropTables(&TribeEvent{}),
+	},
+	{
+		Version: 42,
+		Name:    "create_person_endorsements",
+		Up:      createTables(&PersonEndorsement{}),
+		Down:    dropTables(&PersonEndorsement{}),
+	},
+	{
+		Version: 43,
+		Name:    "create_bounty_terms",
+		Up: func(tx *gorm.DB) error {
+			if err := execSQL(
+				"ALTER TABLE bounty ADD COLUMN IF NOT EXISTS terms text NOT NULL DEFAULT ''",
+				"ALTER TABLE bounty ADD COLUMN IF NOT EXISTS pending_assignee text NOT NULL DEFAULT ''",
+			)(tx); err != nil {
+				return err
+			}
+			return createTables(&BountyTermsAcceptance{})(tx)
+		},
+		Down: func(tx *gorm.DB) error {
+			if err := dropTables(&BountyTermsAcceptance{})(tx); err != nil {
+				return err
+			}
+			return execSQL(
+				"ALTER TABLE bounty DROP COLUMN IF EXISTS pending_assignee",
+				"ALTER TABLE bounty DROP COLUMN IF EXISTS terms",
+			)(tx)
+		},
+	},
+	{
+		Version: 44,
+		Name:    "add_payment_proofs",
+		Up: execSQL(
+			"ALTER TABLE payment_histories ADD COLUMN IF NOT EXISTS payment_hash text NOT NULL DEFAULT ''",
+			"ALTER TABLE bounty ADD COLUMN IF NOT EXISTS show_payment_amount boolean NOT NULL DEFAULT false",
+			"CREATE INDEX IF NOT EXISTS idx_payment_histories_bounty_id ON payment_histories (bounty_id)",
+		),
+		Down: execSQL(
+			"DROP INDEX IF EXISTS idx_payment_histories_bounty_id",
+			"ALTER TABLE bounty DROP COLUMN IF EXISTS show_payment_amount",
+			"ALTER TABLE payment_histories DROP COLUMN IF EXISTS payment_hash",
+		),
+	},
+	{
+		Version: 45,
+		Name:    "add_workspace_timezones",
+		Up: execSQL(
+			"ALTER TABLE workspaces ADD COLUMN IF NOT EXISTS timezone text NOT NULL DEFAULT ''",
+			"ALTER TABLE workspaces ADD COLUMN IF NOT EXISTS locale text NOT NULL DEFAULT ''",
+			"ALTER TABLE workspace_digests ADD COLUMN IF NOT EXISTS overdue_bounties bigint NOT NULL DEFAULT 0",
+			"ALTER TABLE workspace_digests ADD COLUMN IF NOT EXISTS overdue_bounty_ids bigint[]",
+		),
+		Down: execSQL(
+			"ALTER TABLE workspace_digests DROP COLUMN IF EXISTS overdue_bounty_ids",
+			"ALTER TABLE workspace_digests DROP COLUMN IF EXISTS overdue_bounties",
+			"ALTER TABLE workspaces DROP COLUMN IF EXISTS locale",
+			"ALTER TABLE workspaces DROP COLUMN IF EXISTS timezone",
+		),
+	},
+	{
+		Version: 46,
+		Name:    "create_bounty_price_suggestions",
+		Up:      createTables(&BountyPriceSuggestion{}),
+		Down:    dropTables(&BountyPriceSuggestion{}),
+	},
+	{
+		Version: 47,
+		Name:    "create_tribe_members",
+		Up: func(tx *gorm.DB) error {
+			if err := createTables(&TribeMember{})(tx); err != nil {
+				return err
+			}
+			return execSQL("ALTER TABLE tribes ADD COLUMN IF NOT EXISTS joined_members bigint NOT NULL DEFAULT 0")(tx)
+		},
+		Down: func(tx *gorm.DB) error {
+			if err := execSQL("ALTER TABLE tribes DROP COLUMN IF EXISTS joined_members")(tx); err != nil {
+				return err
+			}
+			return dropTables(&TribeMember{})(tx)
+		},
+	},
+	{
+		Version: 48,
+		Name:    "create_workspace_member_suggestions",
+		Up: func(tx *gorm.DB) error {
+			if err := createTables(&WorkspaceMemberSuggestion{})(tx); err != nil {
+				return err
+			}
+			return execSQL("ALTER TABLE workspaces ADD COLUMN IF NOT EXISTS github_org text NOT NULL DEFAULT ''")(tx)
+		},
+		Down: func(tx *gorm.DB) error {
+			if err := execSQL("ALTER TABLE workspaces DROP COLUMN IF EXISTS github_org")(tx); err != nil {
+				return err
+			}
+			return dropTables(&WorkspaceMemberSuggestion{})(tx)
+		},
+	},
+	{
+		Version: 49,
+		Name:    "create_bounty_contact_exchanges",
+		Up:      createTables(&BountyContactExchange{}),
+		Down:    dropTables(&BountyContactExchange{}),
+	},
+	{
+		Version: 50,
+		Name:    "add_workspace_verification",
+		Up: func(tx *gorm.DB) error {
+			if err := createTables(&WorkspaceVerification{})(tx); err != nil {
+				return err
+			}
+			return execSQL(
+				"ALTER TABLE workspaces ADD COLUMN IF NOT EXISTS flagged boolean NOT NULL DEFAULT false",
+				"ALTER TABLE workspaces ADD COLUMN IF NOT EXISTS flag_reasons text[] NOT NULL DEFAULT '{}'",
+				"ALTER TABLE workspaces ADD COLUMN IF NOT EXISTS verified timestamptz",
+				"ALTER TABLE workspaces ADD COLUMN IF NOT EXISTS verification_method text NOT NULL DEFAULT ''",
+				// the workspaces that exist already keep receiving deposits
+				"UPDATE workspaces SET verified = COALESCE(created, now()), verification_method = 'existing' WHERE verified IS NULL",
+			)(tx)
+		},
+		Down: func(tx *gorm.DB) error {
+			if err := execSQL(
+				"ALTER TABLE workspaces DROP COLUMN IF EXISTS verification_method",
+				"ALTER TABLE workspaces DROP COLUMN IF EXISTS verified",
+				"ALTER TABLE workspaces DROP COLUMN IF EXISTS flag_reasons",
+				"ALTER TABLE workspaces DROP COLUMN IF EXISTS flagged",
+			)(tx); err != nil {
+				return err
+			}
+			return dropTables(&WorkspaceVerification{})(tx)
+		},
+	},
+	{
+		Version: 51,
+		Name:    "create_tribe_categories",
+		Up: func(tx *gorm.DB) error {
+			if err := createTables(&Category{}, &TribeCategory{})(tx); err != nil {
+				return err
+			}
+			return seedCategories(tx)
+		},
+		Down: dropTables(&Category{}, &TribeCategory{}),
+	},
+	{
+		Version: 52,
+		Name:    "create_tribe_previews",
+		Up:      createTables(&TribePreview{}),
+		Down:    dropTables(&TribePreview{}),
+	},
+	{
+		Version: 53,
+		Name:    "create_bounty_certificates",
+		Up:      createTables(&BountyCertificate{}),
+		Down:    dropTables(&BountyCertificate{}),
+	},
+	{
+		Version: 54,
+		Name:    "create_tribe_webhooks",
+		Up:      createTables(&TribeWebhook{}),
+		Down:    dropTables(&TribeWebhook{}),
+	},
+	{
+		Version: 55,
+		Name:    "add_workspace_payment_requires_proof",
+		Up: execSQL(
+			"ALTER TABLE workspaces ADD COLUMN IF NOT EXISTS payment_requires_proof boolean NOT NULL DEFAULT false",
+		),
+		Down: execSQL(
+			"ALTER TABLE workspaces DROP COLUMN IF EXISTS payment_requires_proof",
+		),
+	},
+	{
+		Version: 56,
+		Name:    "add_payment_history_state",
+		Up: execSQL(
+			"ALTER TABLE payment_histories ADD COLUMN IF NOT EXISTS state text NOT NULL DEFAULT ''",
+			"CREATE INDEX IF NOT EXISTS idx_payment_histories_pending ON payment_histories (created) WHERE state = 'pending'",
+		),
+		Down: execSQL(
+			"DROP INDEX IF EXISTS idx_payment_histories_pending",
+			"ALTER TABLE payment_histories DROP COLUMN IF EXISTS state",
+		),
+	},
+	{
+		Version: 57,
+		Name:    "add_payment_preimage",
+		Up: execSQL(
+			"ALTER TABLE payment_histories ADD COLUMN IF NOT EXISTS payment_preimage text NOT NULL DEFAULT ''",
+		),
+		Down: execSQL(
+			"ALTER TABLE payment_histories DROP COLUMN IF EXISTS payment_preimage",
+		),
+	},
+}
@@ -0,0 +1,306 @@
+package db
+
+// baselineRenames moves databases from before workspaces replaced
+// organizations to the workspace tables and columns
+var baselineRenames = `DO $$
+BEGIN
+	IF to_regclass('organizations') IS NOT NULL AND to_regclass('workspaces') IS NULL THEN
+		ALTER TABLE organizations RENAME TO workspaces;
+	END IF;
+	IF to_regclass('organization_users') IS NOT NULL AND to_regclass('workspace_users') IS NULL THEN
+		IF EXISTS (SELECT 1 FROM information_schema.columns WHERE table_name = 'organization_users' AND column_name = 'org_uuid') THEN
+			ALTER TABLE organization_users RENAME COLUMN org_uuid TO workspace_uuid;
+		END IF;
+		ALTER TABLE organization_users RENAME TO workspace_users;
+	END IF;
+	IF to_regclass('user_roles') IS NOT NULL AND to_regclass('workspace_user_roles') IS NULL THEN
+		IF EXISTS (SELECT 1 FROM information_schema.columns WHERE table_name = 'user_roles' AND column_name = 'org_uuid') THEN
+			ALTER TABLE user_roles RENAME COLUMN org_uuid TO workspace_uuid;
+		END IF;
+		ALTER TABLE user_roles RENAME TO workspace_user_roles;
+	END IF;
+	IF EXISTS (SELECT 1 FROM information_schema.columns WHERE table_name = 'bounty' AND column_name = 'org_uuid') THEN
+		ALTER TABLE bounty RENAME COLUMN org_uuid TO workspace_uuid;
+	END IF;
+	IF EXISTS (SELECT 1 FROM information_schema.columns WHERE table_name = 'bounty_budgets' AND column_name = 'org_uuid') THEN
+		ALTER TABLE bounty_budgets RENAME COLUMN org_uuid TO workspace_uuid;
+	END IF;
+	IF EXISTS (SELECT 1 FROM information_schema.columns WHERE table_name = 'budget_histories' AND column_name = 'org_uuid') THEN
+		ALTER TABLE budget_histories RENAME COLUMN org_uuid TO workspace_uuid;
+	END IF;
+	IF EXISTS (SELECT 1 FROM information_schema.columns WHERE table_name = 'payment_histories' AND column_name = 'org_uuid') THEN
+		ALTER TABLE payment_histories RENAME COLUMN org_uuid TO workspace_uuid;
+	END IF;
+	IF EXISTS (SELECT 1 FROM information_schema.columns WHERE table_name = 'invoice_lists' AND column_name = 'org_uuid') THEN
+		ALTER TABLE invoice_lists RENAME COLUMN org_uuid TO workspace_uuid;
+	END IF;
+END $$`
+
+// baselineSchema is the schema the backend had when migrations were
+// introduced: the tables of tribes.sql and the ones AutoMigrate created on
+// startup. It is written out so that later changes to the structs don't
+// change what version 1 creates. Never edit it, add a migration instead
+var baselineSchema = []string{
+	`CREATE TABLE IF NOT EXISTS tribes (
+		uuid text NOT NULL PRIMARY KEY,
+		owner_pub_key text NOT NULL,
+		owner_alias text,
+		group_key text,
+		name text,
+		unique_name text,
+		description text,
+		tags text[] NOT NULL DEFAULT '{}',
+		img text,
+		price_to_join bigint,
+		price_per_message bigint,
+		escrow_amount bigint,
+		escrow_millis bigint,
+		created timestamptz,
+		updated timestamptz,
+		member_count bigint,
+		unlisted boolean,
+		private boolean,
+		deleted boolean,
+		app_url text,
+		feed_url text,
+		second_brain_url text,
+		feed_type bigint,
+		last_active bigint,
+		bots text,
+		owner_route_hint text,
+		pin text,
+		preview text,
+		profile_filters text,
+		badges text[],
+		tsv tsvector
+	)`,
+	`CREATE TABLE IF NOT EXISTS bots (
+		uuid text NOT NULL PRIMARY KEY,
+		owner_pub_key text NOT NULL,
+		owner_alias text,
+		name text,
+		unique_name text,
+		description text,
+		tags text[] NOT NULL DEFAULT '{}',
+		img text,
+		price_per_use bigint,
+		created timestamptz,
+		updated timestamptz,
+		member_count bigint,
+		unlisted boolean,
+		deleted boolean,
+		owner_route_hint text,
+		tsv tsvector
+	)`,
+	`CREATE TABLE IF NOT EXISTS people (
+		id bigserial PRIMARY KEY,
+		uuid text,
+		owner_pub_key text NOT NULL,
+		owner_alias text,
+		unique_name text,
+		description text,
+		tags text[] NOT NULL DEFAULT '{}',
+		img text,
+		created timestamptz,
+		updated timestamptz,
+		unlisted boolean,
+		deleted boolean,
+		last_login bigint,
+		owner_route_hint text,
+		owner_contact_key text,
+		price_to_meet bigint,
+		new_ticket_time bigint,
+		twitter_confirmed boolean,
+		referred_by bigint,
+		extras jsonb,
+		github_issues jsonb,
+		tsv tsvector
+	)`,
+	`CREATE TABLE IF NOT EXISTS channels (
+		id bigserial PRIMARY KEY,
+		tribe_uuid text,
+		name text,
+		created timestamptz,
+		deleted boolean
+	)`,
+	`CREATE TABLE IF NOT EXISTS leader_boards (
+		tribe_uuid text,
+		alias text,
+		spent bigint,
+		earned bigint,
+		reputation bigint
+	)`,
+	`CREATE TABLE IF NOT EXISTS connectioncodes (
+		id bigserial PRIMARY KEY,
+		connection_string text,
+		is_used boolean,
+		date_created timestamptz
+	)`,
+	`CREATE TABLE IF NOT EXISTS bounty_roles (
+		name text
+	)`,
+	`CREATE TABLE IF NOT EXISTS user_invoice_data (
+		id bigserial PRIMARY KEY,
+		amount bigint,
+		payment_request text,
+		created bigint,
+		user_pubkey text,
+		assigned_hours bigint,
+		commitment_fee bigint,
+		bounty_expires text,
+		route_hint text
+	)`,
+	`CREATE TABLE IF NOT EXISTS workspace_repositories (
+		id bigserial PRIMARY KEY,
+		uuid text NOT NULL,
+		workspace_uuid text NOT NULL,
+		name text NOT NULL,
+		url text,
+		created timestamptz,
+		updated timestamptz,
+		created_by text,
+		updated_by text
+	)`,
+	`CREATE TABLE IF NOT EXISTS workspace_features (
+		id bigserial PRIMARY KEY,
+		uuid text NOT NULL,
+		workspace_uuid text NOT NULL,
+		name text NOT NULL,
+		brief text,
+		requirements text,
+		architecture text,
+		url text,
+		priority bigint,
+		created timestamptz,
+		updated timestamptz,
+		created_by text,
+		updated_by text
+	)`,
+	`CREATE TABLE IF NOT EXISTS feature_phases (
+		uuid text PRIMARY KEY,
+		feature_uuid text,
+		name text,
+		priority bigint,
+		created timestamptz,
+		updated timestamptz,
+		created_by text,
+		updated_by text
+	)`,
+	`CREATE TABLE IF NOT EXISTS feature_stories (
+		id bigserial PRIMARY KEY,
+		uuid text,
+		feature_uuid text,
+		description text,
+		priority bigint,
+		created timestamptz,
+		updated timestamptz,
+		created_by text,
+		updated_by text
+	)`,
+	`CREATE TABLE IF NOT EXISTS bounty (
+		id bigserial PRIMARY KEY,
+		owner_id text,
+		paid boolean,
+		show boolean DEFAULT false,
+		completed boolean DEFAULT false,
+		type text,
+		award text,
+		assigned_hours smallint,
+		bounty_expires text,
+		commitment_fee bigint,
+		price bigint,
+		title text,
+		tribe text,
+		assignee text,
+		ticket_url text,
+		workspace_uuid text,
+		description text,
+		wanted_type text,
+		deliverables text,
+		github_description boolean,
+		one_sentence_summary text,
+		estimated_session_length text,
+		estimated_completion_date text,
+		created bigint,
+		updated timestamptz,
+		assigned_date timestamptz,
+		completion_date timestamptz,
+		mark_as_paid_date timestamptz,
+		paid_date timestamptz,
+		coding_languages text[],
+		phase_uuid text,
+		phase_priority bigint
+	)`,
+	`CREATE TABLE IF NOT EXISTS budget_histories (
+		id bigserial PRIMARY KEY,
+		workspace_uuid text,
+		amount bigint,
+		sender_pub_key text,
+		created timestamptz,
+		updated timestamptz,
+		status boolean,
+		payment_type text
+	)`,
+	`CREATE TABLE IF NOT EXISTS payment_histories (
+		id bigserial PRIMARY KEY,
+		amount bigint,
+		bounty_id bigint,
+		payment_type text,
+		workspace_uuid text,
+		sender_pub_key text,
+		receiver_pub_key text,
+		created timestamptz,
+		updated timestamptz,
+		status boolean
+	)`,
+	`CREATE TABLE IF NOT EXISTS invoice_lists (
+		id bigserial PRIMARY KEY,
+		payment_request text,
+		status boolean,
+		type text,
+		owner_pubkey text,
+		workspace_uuid text,
+		created timestamptz,
+		updated timestamptz
+	)`,
+	`CREATE TABLE IF NOT EXISTS bounty_budgets (
+		id bigserial PRIMARY KEY,
+		workspace_uuid text,
+		total_budget bigint,
+		created timestamptz,
+		updated timestamptz
+	)`,
+	`CREATE TABLE IF NOT EXISTS workspace_user_roles (
+		role text,
+		owner_pub_key text,
+		workspace_uuid text,
+		created timestamptz
+	)`,
+	`CREATE TABLE IF NOT EXISTS workspaces (
+		id bigserial PRIMARY KEY,
+		uuid text,
+		name text NOT NULL UNIQUE,
+		owner_pub_key text,
+		img text,
+		created timestamptz,
+		updated timestamptz,
+		show boolean,
+		deleted boolean DEFAULT false,
+		bounty_count bigint,
+		budget bigint,
+		website text,
+		github text,
+		description text,
+		mission text,
+		tactics text,
+		schematic_url text,
+		schematic_img text
+	)`,
+	`CREATE TABLE IF NOT EXISTS workspace_users (
+		id bigserial PRIMARY KEY,
+		owner_pub_key text,
+		workspace_uuid text,
+		created timestamptz,
+		updated timestamptz
+	)`,
+}
@@ -0,0 +1,116 @@
+package db
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/utils"
+	"gorm.io/gorm/clause"
+)
+
+func (db database) CreateReport(report Report) (Report, error) {
+	if report.Uuid == "" {
+		return Report{}, errors.New("report uuid is required")
+	}
+
+	now := time.Now()
+	report.Status = ReportPending
+	report.Created = &now
+
+	if err := db.db.Create(&report).Error; err != nil {
+		return Report{}, err
+	}
+	return report, nil
+}
+
+func (db database) GetReportByUuid(uuid string) (Report, error) {
+	report := Report{}
+	result := db.db.Model(&Report{}).Where("uuid = ?", uuid).First(&report)
+	if result.RowsAffected == 0 {
+		return report, errors.New("no report found")
+	}
+	return report, nil
+}
+
+// GetPendingReport finds the open report of a reporter on a target, so the
+// same target is not queued twice by one reporter
+func (db database) GetPendingReport(targetType string, targetUuid string, reporter string) (Report, error) {
+	report := Report{}
+	result := db.db.Model(&Report{}).
+		Where("target_type = ? AND target_uuid = ? AND reporter_pub_key = ? AND status = ?", targetType, targetUuid, reporter, ReportPending).
+		First(&report)
+	if result.RowsAffected == 0 {
+		return report, errors.New("no report found")
+	}
+	return report, nil
+}
+
+// GetReports is the moderation queue, oldest first so reports are handled
+// in the order they came in
+func (db database) GetReports(r *http.Request) ([]Report, error) {
+	reports := []Report{}
+	offset, limit, _, _, _ := utils.GetPaginationParams(r)
+	if r.URL.Query().Get("limit") == "" {
+		limit = 50
+	}
+
+	query := db.forRequest(r).Model(&Report{})
+	if status := r.URL.Query().Get("status"); status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if targetType := r.URL.Query().Get("target_type"); targetType != "" {
+		query = query.Where("target_type = ?", targetType)
+	}
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if offset > 0 {
+		query = query.Offset(offset)
+	}
+
+	err := query.Order("created ASC").Find(&reports).Error
+	return reports, err
+}
+
+// ResolveTargetReports closes every pending report on the target of
+// resolution with its status, action and note, and returns the closed
+// reports so their reporters can be notified
+func (db database) ResolveTargetReports(resolution Report) ([]Report, error) {
+	reports := []Report{}
+	now := time.Now()
+	result := db.db.Model(&reports).Clauses(clause.Returning{}).
+		Where("target_type = ? AND target_uuid = ? AND status = ?", resolution.TargetType, resolution.TargetUuid, ReportPending).
+		Updates(map[string]interface{}{
+			"status":      resolution.Status,
+			"action":      resolution.Action,
+			"note":        resolution.Note,
+			"resolved_by": resolution.ResolvedBy,
+			"resolved":    &now,
+		})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, errors.New("no pending report found to resolve")
+	}
+	return reports, nil
+}
+
+func (db database) BanPubkey(ban BannedPubkey) error {
+	if ban.Pubkey == "" {
+		return errors.New("pubkey is required")
+	}
+	now := time.Now()
+	ban.Created = &now
+	return db.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&ban).Error
+}
+
+func (db database) IsBannedPubkey(pubkey string) bool {
+	if pubkey == "" {
+		return false
+	}
+	var count int64
+	db.db.Model(&BannedPubkey{}).Where("pubkey = ?", pubkey).Count(&count)
+	return count > 0
+}
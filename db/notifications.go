@@ -0,0 +1,78 @@
+package db
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/utils"
+	"gorm.io/gorm/clause"
+)
+
+// CreateNotifications saves the notifications of an event, the ones a user
+// already got for that event are skipped so a retried event is not sent
+// twice
+func (db database) CreateNotifications(notifications []Notification) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+	now := time.Now()
+	for i := range notifications {
+		notifications[i].Created = &now
+	}
+	return db.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&notifications).Error
+}
+
+// GetNotifications returns the inbox of a user newest first, only the
+// unread notifications with ?unread=true
+func (db database) GetNotifications(pubkey string, r *http.Request) ([]Notification, error) {
+	notifications := []Notification{}
+	offset, limit, _, _, _ := utils.GetPaginationParams(r)
+	if r.URL.Query().Get("limit") == "" {
+		limit = 50
+	}
+
+	query := db.forRequest(r).Model(&Notification{}).Where("pub_key = ?", pubkey)
+	if r.URL.Query().Get("unread") == "true" {
+		query = query.Where("read = false")
+	}
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if offset > 0 {
+		query = query.Offset(offset)
+	}
+
+	err := query.Order("id DESC").Find(&notifications).Error
+	return notifications, err
+}
+
+func (db database) GetNotificationsCount(pubkey string, unread bool) int64 {
+	var count int64
+	query := db.db.Model(&Notification{}).Where("pub_key = ?", pubkey)
+	if unread {
+		query = query.Where("read = false")
+	}
+	query.Count(&count)
+	return count
+}
+
+// ReadNotification marks a notification of a user read, found is false when
+// the user has no notification with that id
+func (db database) ReadNotification(pubkey string, id uint) (bool, error) {
+	var count int64
+	if err := db.db.Model(&Notification{}).Where("id = ? AND pub_key = ?", id, pubkey).Count(&count).Error; err != nil {
+		return false, err
+	}
+	if count == 0 {
+		return false, nil
+	}
+	err := db.db.Model(&Notification{}).Where("id = ? AND pub_key = ?", id, pubkey).Update("read", true).Error
+	return true, err
+}
+
+// ReadAllNotifications marks every notification of a user read and returns
+// how many were unread
+func (db database) ReadAllNotifications(pubkey string) (int64, error) {
+	result := db.db.Model(&Notification{}).Where("pub_key = ? AND read = false", pubkey).Update("read", true)
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,113 @@
+package db
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// states of a payment that did not settle
+const (
+	// PaymentPending is a payment the relay was sent but didn't answer. It
+	// may still settle, so it is not sent again until it is reconciled
+	PaymentPending = "pending"
+	// PaymentFailed is a payment that didn't go out and is not retried
+	PaymentFailed = "failed"
+)
+
+var ErrPaymentNotPending = errors.New("the payment is not pending")
+
+// CreatePendingPayment records a payment before it is sent, or one whose
+// outcome the relay didn't give. It is taken from the workspace budget as a
+// settled payment would be, so the amount can't be spent again while the
+// payment is in flight. ErrBudgetNotEnough is returned when the budget
+// can't cover it
+func (db database) CreatePendingPayment(payment NewPaymentHistory) (NewPaymentHistory, error) {
+	payment.Status = false
+	payment.State = PaymentPending
+	err := db.transaction(func(tx database) error {
+		if err := tx.db.Create(&payment).Error; err != nil {
+			return err
+		}
+		if payment.WorkspaceUuid == "" {
+			return nil
+		}
+		return tx.debitBudget(payment.WorkspaceUuid, payment.Amount)
+	})
+	return payment, err
+}
+
+// SetPaymentHash records the payment hash of a pending payment once the
+// node gives it
+func (db database) SetPaymentHash(id uint, paymentHash string) error {
+	return db.db.Model(&NewPaymentHistory{}).Where("id = ? AND state = ?", id, PaymentPending).
+		Update("payment_hash", paymentHash).Error
+}
+
+// SetPaymentPreimage records the preimage of a settled payment, the caller
+// checks that it matches the payment hash
+func (db database) SetPaymentPreimage(id uint, preimage string) error {
+	return db.db.Model(&NewPaymentHistory{}).Where("id = ? AND status = ?", id, true).
+		Update("payment_preimage", preimage).Error
+}
+
+// GetPendingPayments lists the payments waiting to be reconciled, oldest
+// first
+func (db database) GetPendingPayments() []NewPaymentHistory {
+	payments := []NewPaymentHistory{}
+	db.db.Where("state = ?", PaymentPending).Order("created ASC").Find(&payments)
+	return payments
+}
+
+// ReconcilePayment settles or fails a pending payment once its outcome is
+// known. A settled payout marks its bounty paid. A failed payment gives its
+// amount back to the workspace budget and lets its bounty be paid again
+func (db database) ReconcilePayment(id uint, settled bool, paymentHash string) (NewPaymentHistory, error) {
+	payment := NewPaymentHistory{}
+	err := db.transaction(func(tx database) error {
+		now := time.Now()
+		updates := map[string]interface{}{"state": PaymentFailed, "updated": &now}
+		if settled {
+			updates = map[string]interface{}{"state": "", "status": true, "updated": &now}
+			if paymentHash != "" {
+				updates["payment_hash"] = paymentHash
+			}
+		}
+		result := tx.db.Model(&NewPaymentHistory{}).Where("id = ? AND state = ?", id, PaymentPending).Updates(updates)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrPaymentNotPending
+		}
+		if err := tx.db.First(&payment, id).Error; err != nil {
+			return err
+		}
+
+		payout := payment.PaymentType == Payment && payment.BountyId != 0
+		if settled {
+			if !payout {
+				return nil
+			}
+			return tx.db.Model(&NewBounty{}).Where("id = ?", payment.BountyId).Updates(map[string]interface{}{
+				"paid":            true,
+				"paid_date":       &now,
+				"completed":       true,
+				"completion_date": gorm.Expr("COALESCE(completion_date, ?)", now),
+			}).Error
+		}
+
+		if payment.WorkspaceUuid != "" {
+			if err := tx.db.Model(&NewBountyBudget{}).Where("workspace_uuid = ?", payment.WorkspaceUuid).
+				Update("total_budget", gorm.Expr("total_budget + ?", payment.Amount)).Error; err != nil {
+				return err
+			}
+		}
+		if payout {
+			return tx.ReleaseBountyPayment(payment.BountyId)
+		}
+		return nil
+	})
+	return payment, err
+}
@@ -0,0 +1,144 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/config"
+	"gorm.io/gorm"
+)
+
+const slowQueryStartKey = "slow_query:start"
+
+// PoolStats is the state of the database connection pool, the durations
+// are in milliseconds
+type PoolStats struct {
+	MaxOpenConnections int   `json:"max_open_connections"`
+	OpenConnections    int   `json:"open_connections"`
+	InUse              int   `json:"in_use"`
+	Idle               int   `json:"idle"`
+	WaitCount          int64 `json:"wait_count"`
+	WaitDuration       int64 `json:"wait_duration_ms"`
+	MaxIdleClosed      int64 `json:"max_idle_closed"`
+	MaxIdleTimeClosed  int64 `json:"max_idle_time_closed"`
+	MaxLifetimeClosed  int64 `json:"max_lifetime_closed"`
+	SlowQueries        int64 `json:"slow_queries"`
+	SlowQueryThreshold int   `json:"slow_query_threshold_ms"`
+	TenantViolations   int64 `json:"tenant_violations"`
+}
+
+var slowQueries int64
+
+// configurePool applies the pool settings of the config, a setting of 0
+// keeps the driver default
+func configurePool(db *gorm.DB) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+
+	cfg := config.Get()
+	if cfg.DbMaxOpenConns > 0 {
+		sqlDB.SetMaxOpenConns(cfg.DbMaxOpenConns)
+	}
+	if cfg.DbMaxIdleConns > 0 {
+		sqlDB.SetMaxIdleConns(cfg.DbMaxIdleConns)
+	}
+	if cfg.DbConnMaxLifetime > 0 {
+		sqlDB.SetConnMaxLifetime(time.Duration(cfg.DbConnMaxLifetime) * time.Second)
+	}
+	if cfg.DbConnMaxIdleTime > 0 {
+		sqlDB.SetConnMaxIdleTime(time.Duration(cfg.DbConnMaxIdleTime) * time.Second)
+	}
+	return nil
+}
+
+func (db database) GetPoolStats() (PoolStats, error) {
+	sqlDB, err := db.db.DB()
+	if err != nil {
+		return PoolStats{}, err
+	}
+
+	stats := sqlDB.Stats()
+	return PoolStats{
+		MaxOpenConnections: stats.MaxOpenConnections,
+		OpenConnections:    stats.OpenConnections,
+		InUse:              stats.InUse,
+		Idle:               stats.Idle,
+		WaitCount:          stats.WaitCount,
+		WaitDuration:       stats.WaitDuration.Milliseconds(),
+		MaxIdleClosed:      stats.MaxIdleClosed,
+		MaxIdleTimeClosed:  stats.MaxIdleTimeClosed,
+		MaxLifetimeClosed:  stats.MaxLifetimeClosed,
+		SlowQueries:        atomic.LoadInt64(&slowQueries),
+		SlowQueryThreshold: config.Get().SlowQueryMs,
+		TenantViolations:   atomic.LoadInt64(&tenantViolations),
+	}, nil
+}
+
+// slowQueryPlugin logs the queries slower than SLOW_QUERY_MS. Only the
+// statement with its placeholders is logged, the bound values can hold
+// pubkeys and tokens
+type slowQueryPlugin struct{}
+
+func (slowQueryPlugin) Name() string {
+	return "slow_query"
+}
+
+func (p slowQueryPlugin) Initialize(db *gorm.DB) error {
+	callbacks := []struct {
+		name     string
+		register func(string, func(*gorm.DB)) error
+		after    func(string, func(*gorm.DB)) error
+	}{
+		{"create", db.Callback().Create().Before("gorm:create").Register, db.Callback().Create().After("gorm:create").Register},
+		{"query", db.Callback().Query().Before("gorm:query").Register, db.Callback().Query().After("gorm:query").Register},
+		{"update", db.Callback().Update().Before("gorm:update").Register, db.Callback().Update().After("gorm:update").Register},
+		{"delete", db.Callback().Delete().Before("gorm:delete").Register, db.Callback().Delete().After("gorm:delete").Register},
+		{"row", db.Callback().Row().Before("gorm:row").Register, db.Callback().Row().After("gorm:row").Register},
+		{"raw", db.Callback().Raw().Before("gorm:raw").Register, db.Callback().Raw().After("gorm:raw").Register},
+	}
+
+	for _, c := range callbacks {
+		if err := c.register("slow_query:before_"+c.name, p.before); err != nil {
+			return err
+		}
+		if err := c.after("slow_query:after_"+c.name, p.after); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (slowQueryPlugin) before(tx *gorm.DB) {
+	tx.InstanceSet(slowQueryStartKey, time.Now())
+}
+
+func (slowQueryPlugin) after(tx *gorm.DB) {
+	threshold := time.Duration(config.Get().SlowQueryMs) * time.Millisecond
+	if threshold <= 0 {
+		return
+	}
+	value, ok := tx.InstanceGet(slowQueryStartKey)
+	if !ok {
+		return
+	}
+	start, ok := value.(time.Time)
+	if !ok {
+		return
+	}
+
+	elapsed := time.Since(start)
+	if elapsed < threshold {
+		return
+	}
+	atomic.AddInt64(&slowQueries, 1)
+	fmt.Println(slowQueryLine(elapsed, tx.Statement.SQL.String(), len(tx.Statement.Vars), tx.RowsAffected))
+}
+
+func slowQueryLine(elapsed time.Duration, sql string, params int, rows int64) string {
+	return fmt.Sprintf("[slow query] %dms rows=%d params=%d (redacted) %s",
+		elapsed.Milliseconds(), rows, params, strings.Join(strings.Fields(sql), " "))
+}
@@ -0,0 +1,15 @@
+package db
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSlowQueryLine(t *testing.T) {
+	line := slowQueryLine(1500*time.Millisecond, "SELECT * FROM people\n\t\tWHERE owner_pub_key = $1 AND uuid = $2", 2, 1)
+
+	expected := "[slow query] 1500ms rows=1 params=2 (redacted) SELECT * FROM people WHERE owner_pub_key = $1 AND uuid = $2"
+	if line != expected {
+		t.Errorf("expected %q, got %q", expected, line)
+	}
+}
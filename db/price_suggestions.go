@@ -0,0 +1,225 @@
+package db
+
+import (
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// a group needs this many paid bounties before its range is suggested
+const priceSuggestionMinSamples = 3
+
+// PriceHourBuckets are the estimates paid bounties are grouped by, in hours:
+// an hour, three hours, a day, three days, a week, and longer
+var PriceHourBuckets = []int{1, 3, 8, 24, 40, 160}
+
+// PriceHoursBucket is the bucket of an estimate in hours, 0 when there is no
+// estimate
+func PriceHoursBucket(hours float64) int {
+	if hours <= 0 {
+		return 0
+	}
+	for _, bucket := range PriceHourBuckets {
+		if hours <= float64(bucket) {
+			return bucket
+		}
+	}
+	return PriceHourBuckets[len(PriceHourBuckets)-1]
+}
+
+var estimatePattern = regexp.MustCompile(`(\d+(?:\.\d+)?)\s*(minute|min|hour|hr|h|day|week)`)
+
+// EstimateHours reads the estimated session length of a bounty, like
+// "2 hours" or "Less than 3 hours". A day is 8 hours and a week 40, it is
+// false when the estimate names no duration
+func EstimateHours(estimate string) (float64, bool) {
+	match := estimatePattern.FindStringSubmatch(strings.ToLower(estimate))
+	if match == nil {
+		return 0, false
+	}
+	hours, err := strconv.ParseFloat(match[1], 64)
+	if err != nil || hours <= 0 {
+		return 0, false
+	}
+	switch match[2] {
+	case "minute", "min":
+		hours /= 60
+	case "day":
+		hours *= 8
+	case "week":
+		hours *= 40
+	}
+	return hours, true
+}
+
+// priceTags are the coding languages and labels of a bounty, lowercased
+func priceTags(bounty NewBounty) []string {
+	seen := map[string]bool{}
+	tags := []string{}
+	for _, tag := range append(append([]string{}, bounty.CodingLanguages...), bounty.Labels...) {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag != "" && !seen[tag] {
+			seen[tag] = true
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// percentile is the nearest-rank percentile of sorted prices
+func percentile(prices []uint, p float64) uint {
+	rank := int(math.Ceil(p * float64(len(prices))))
+	if rank < 1 {
+		rank = 1
+	}
+	return prices[rank-1]
+}
+
+// priceSuggestionGroups puts the price of every paid bounty in the groups of
+// its workspace and of all workspaces, of each of its tags and of any tag,
+// and of its estimate and of any estimate. Groups with too few bounties are
+// left out
+func priceSuggestionGroups(bounties []NewBounty, at time.Time) []BountyPriceSuggestion {
+	type group struct {
+		workspace string
+		tag       string
+		bucket    int
+	}
+	prices := map[group][]uint{}
+	for _, bounty := range bounties {
+		if bounty.Price == 0 {
+			continue
+		}
+		workspaces := []string{""}
+		if bounty.WorkspaceUuid != "" {
+			workspaces = append(workspaces, bounty.WorkspaceUuid)
+		}
+		tags := append([]string{""}, priceTags(bounty)...)
+		buckets := []int{0}
+		if hours, ok := EstimateHours(bounty.EstimatedSessionLength); ok {
+			buckets = append(buckets, PriceHoursBucket(hours))
+		}
+		for _, workspace := range workspaces {
+			for _, tag := range tags {
+				for _, bucket := range buckets {
+					g := group{workspace, tag, bucket}
+					prices[g] = append(prices[g], bounty.Price)
+				}
+			}
+		}
+	}
+
+	suggestions := []BountyPriceSuggestion{}
+	for g, list := range prices {
+		if len(list) < priceSuggestionMinSamples {
+			continue
+		}
+		sort.Slice(list, func(i, j int) bool { return list[i] < list[j] })
+		suggestions = append(suggestions, BountyPriceSuggestion{
+			WorkspaceUuid: g.workspace,
+			Tag:           g.tag,
+			HoursBucket:   g.bucket,
+			Samples:       len(list),
+			Low:           percentile(list, 0.25),
+			Median:        percentile(list, 0.5),
+			High:          percentile(list, 0.75),
+			Computed:      at,
+		})
+	}
+	sort.Slice(suggestions, func(i, j int) bool {
+		a, b := suggestions[i], suggestions[j]
+		if a.WorkspaceUuid != b.WorkspaceUuid {
+			return a.WorkspaceUuid < b.WorkspaceUuid
+		}
+		if a.Tag != b.Tag {
+			return a.Tag < b.Tag
+		}
+		return a.HoursBucket < b.HoursBucket
+	})
+	return suggestions
+}
+
+// pickPriceSuggestion finds the closest group of a workspace to the tags
+// and the estimate: the tags and the estimate, the tags only, the estimate
+// only, then any bounty. Of the groups of several tags the one with the
+// most samples wins
+func pickPriceSuggestion(rows []BountyPriceSuggestion, workspaceUuid string, tags []string, hoursBucket int) *BountyPriceSuggestion {
+	find := func(tags []string, bucket int) *BountyPriceSuggestion {
+		var best *BountyPriceSuggestion
+		for i, row := range rows {
+			if row.WorkspaceUuid != workspaceUuid || row.HoursBucket != bucket {
+				continue
+			}
+			for _, tag := range tags {
+				if row.Tag == tag && (best == nil || row.Samples > best.Samples) {
+					best = &rows[i]
+				}
+			}
+		}
+		return best
+	}
+
+	steps := []struct {
+		tags   []string
+		bucket int
+	}{{tags, hoursBucket}, {tags, 0}, {[]string{""}, hoursBucket}, {[]string{""}, 0}}
+	for _, step := range steps {
+		if len(step.tags) == 0 {
+			continue
+		}
+		if best := find(step.tags, step.bucket); best != nil {
+			return best
+		}
+	}
+	return nil
+}
+
+// RefreshBountyPriceSuggestions computes the price ranges of the paid
+// bounties again and replaces the stored ones
+func (db database) RefreshBountyPriceSuggestions(at time.Time) error {
+	paid := []NewBounty{}
+	err := db.db.Model(&NewBounty{}).Select("workspace_uuid", "coding_languages", "labels", "estimated_session_length", "price").
+		Where("paid = true AND price > 0").Find(&paid).Error
+	if err != nil {
+		return err
+	}
+	suggestions := priceSuggestionGroups(paid, at)
+
+	return db.transaction(func(tx database) error {
+		if err := tx.db.Where("1 = 1").Delete(&BountyPriceSuggestion{}).Error; err != nil {
+			return err
+		}
+		if len(suggestions) == 0 {
+			return nil
+		}
+		return tx.db.CreateInBatches(&suggestions, 500).Error
+	})
+}
+
+// GetBountyPriceSuggestions suggests the price of a bounty with the tags and
+// the estimate, from the paid bounties of its workspace and of all of them
+func (db database) GetBountyPriceSuggestions(workspaceUuid string, tags []string, hoursBucket int) (BountyPriceSuggestions, error) {
+	normalized := []string{}
+	for _, tag := range tags {
+		if tag = strings.ToLower(strings.TrimSpace(tag)); tag != "" {
+			normalized = append(normalized, tag)
+		}
+	}
+	suggestions := BountyPriceSuggestions{Tags: normalized, HoursBucket: hoursBucket}
+
+	rows := []BountyPriceSuggestion{}
+	err := db.db.Where("workspace_uuid IN ? AND tag IN ? AND hours_bucket IN ?",
+		[]string{"", workspaceUuid}, append([]string{""}, normalized...), []int{0, hoursBucket}).Find(&rows).Error
+	if err != nil {
+		return suggestions, err
+	}
+
+	suggestions.Global = pickPriceSuggestion(rows, "", normalized, hoursBucket)
+	if workspaceUuid != "" {
+		suggestions.Workspace = pickPriceSuggestion(rows, workspaceUuid, normalized, hoursBucket)
+	}
+	return suggestions, nil
+}
@@ -0,0 +1,77 @@
+package db
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEstimateHours(t *testing.T) {
+	for estimate, expected := range map[string]float64{
+		"2 hours":           2,
+		"Less than 3 hours": 3,
+		"90 minutes":        1.5,
+		"1.5h":              1.5,
+		"2 days":            16,
+		"1 week":            40,
+	} {
+		hours, ok := EstimateHours(estimate)
+		assert.True(t, ok, estimate)
+		assert.Equal(t, expected, hours, estimate)
+	}
+	for _, estimate := range []string{"", "Not sure yet", "0 hours"} {
+		_, ok := EstimateHours(estimate)
+		assert.False(t, ok, estimate)
+	}
+}
+
+func TestPriceHoursBucket(t *testing.T) {
+	assert.Equal(t, 0, PriceHoursBucket(0))
+	assert.Equal(t, 1, PriceHoursBucket(0.5))
+	assert.Equal(t, 3, PriceHoursBucket(3))
+	assert.Equal(t, 8, PriceHoursBucket(4))
+	assert.Equal(t, 160, PriceHoursBucket(500))
+}
+
+func TestPriceSuggestionGroups(t *testing.T) {
+	at := time.Date(2024, 5, 1, 2, 0, 0, 0, time.UTC)
+	bounties := []NewBounty{
+		{WorkspaceUuid: "workspace-a", Price: 1000, CodingLanguages: []string{"Go"}, EstimatedSessionLength: "2 hours"},
+		{WorkspaceUuid: "workspace-a", Price: 3000, CodingLanguages: []string{"go"}, Labels: []string{"Go"}, EstimatedSessionLength: "3 hours"},
+		{WorkspaceUuid: "workspace-a", Price: 2000, CodingLanguages: []string{"Go"}},
+		{WorkspaceUuid: "workspace-b", Price: 8000, CodingLanguages: []string{"Rust"}, EstimatedSessionLength: "2 hours"},
+		{WorkspaceUuid: "workspace-b", Price: 0, CodingLanguages: []string{"Go"}},
+	}
+
+	groups := map[string]BountyPriceSuggestion{}
+	for _, s := range priceSuggestionGroups(bounties, at) {
+		groups[s.WorkspaceUuid+"/"+s.Tag+"/"+strconv.Itoa(s.HoursBucket)] = s
+	}
+
+	assert.Equal(t, BountyPriceSuggestion{WorkspaceUuid: "workspace-a", Tag: "go", Samples: 3, Low: 1000, Median: 2000, High: 3000, Computed: at}, groups["workspace-a/go/0"])
+	assert.Equal(t, BountyPriceSuggestion{Tag: "", Samples: 4, Low: 1000, Median: 2000, High: 3000, Computed: at}, groups["//0"])
+	assert.Equal(t, BountyPriceSuggestion{Tag: "", HoursBucket: 3, Samples: 3, Low: 1000, Median: 3000, High: 8000, Computed: at}, groups["//3"])
+	// two go bounties with an estimate and one rust bounty are too few
+	assert.NotContains(t, groups, "workspace-a/go/3")
+	assert.NotContains(t, groups, "/rust/0")
+}
+
+func TestPickPriceSuggestion(t *testing.T) {
+	rows := []BountyPriceSuggestion{
+		{WorkspaceUuid: "", Tag: "", HoursBucket: 0, Samples: 40, Median: 1000},
+		{WorkspaceUuid: "", Tag: "go", HoursBucket: 0, Samples: 12, Median: 2000},
+		{WorkspaceUuid: "", Tag: "rust", HoursBucket: 0, Samples: 20, Median: 4000},
+		{WorkspaceUuid: "", Tag: "go", HoursBucket: 8, Samples: 5, Median: 3000},
+		{WorkspaceUuid: "workspace-a", Tag: "", HoursBucket: 8, Samples: 3, Median: 5000},
+	}
+
+	assert.Equal(t, uint(3000), pickPriceSuggestion(rows, "", []string{"go"}, 8).Median)
+	assert.Equal(t, uint(4000), pickPriceSuggestion(rows, "", []string{"go", "rust"}, 0).Median)
+	assert.Equal(t, uint(2000), pickPriceSuggestion(rows, "", []string{"go"}, 24).Median)
+	assert.Equal(t, uint(1000), pickPriceSuggestion(rows, "", []string{"python"}, 24).Median)
+	assert.Equal(t, uint(1000), pickPriceSuggestion(rows, "", []string{}, 0).Median)
+	assert.Equal(t, uint(5000), pickPriceSuggestion(rows, "workspace-a", []string{"go"}, 8).Median)
+	assert.Nil(t, pickPriceSuggestion(rows, "workspace-a", []string{"go"}, 0))
+}
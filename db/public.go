@@ -0,0 +1,101 @@
+package db
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// Public is the view of v served without authentication. Struct fields
+// tagged private:"true" are left out, a map field tagged with a list of keys
+// (private:"email,phone") loses those keys. Nested structs, slices and maps
+// are walked too, so a BountyResponse drops the private fields of its people
+func Public(v interface{}) interface{} {
+	return publicValue(reflect.ValueOf(v))
+}
+
+var jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+
+func publicValue(v reflect.Value) interface{} {
+	if !v.IsValid() {
+		return nil
+	}
+	// time.Time and the like encode themselves
+	if v.Type().Implements(jsonMarshalerType) {
+		return v.Interface()
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return publicValue(v.Elem())
+	case reflect.Struct:
+		out := map[string]interface{}{}
+		publicFields(v, out)
+		return out
+	case reflect.Slice:
+		if v.IsNil() {
+			return nil
+		}
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return v.Interface()
+		}
+		fallthrough
+	case reflect.Array:
+		out := make([]interface{}, v.Len())
+		for i := range out {
+			out[i] = publicValue(v.Index(i))
+		}
+		return out
+	case reflect.Map:
+		if v.IsNil() || v.Type().Key().Kind() != reflect.String {
+			return v.Interface()
+		}
+		out := map[string]interface{}{}
+		for _, key := range v.MapKeys() {
+			out[key.String()] = publicValue(v.MapIndex(key))
+		}
+		return out
+	}
+	return v.Interface()
+}
+
+func publicFields(v reflect.Value, out map[string]interface{}) {
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		private := field.Tag.Get("private")
+		if private == "true" {
+			continue
+		}
+
+		tag := strings.Split(field.Tag.Get("json"), ",")
+		name := tag[0]
+		if name == "-" {
+			continue
+		}
+		value := v.Field(i)
+		if field.Anonymous && name == "" && value.Kind() == reflect.Struct {
+			publicFields(value, out)
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		if value.IsZero() && strings.Contains(field.Tag.Get("json"), ",omitempty") {
+			continue
+		}
+
+		public := publicValue(value)
+		if keys, ok := public.(map[string]interface{}); ok && private != "" {
+			for _, key := range strings.Split(private, ",") {
+				delete(keys, key)
+			}
+		}
+		out[name] = public
+	}
+}
@@ -0,0 +1,49 @@
+package db
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPublic(t *testing.T) {
+	now := time.Now()
+	person := Person{
+		Uuid:            "person-uuid",
+		OwnerAlias:      "alice",
+		OwnerRouteHint:  "route-hint",
+		OwnerContactKey: "contact-key",
+		LastLogin:       12,
+		Created:         &now,
+		Extras:          PropertyMap{"email": "alice@example.com", "github": "alice"},
+	}
+
+	public := Public(person).(map[string]interface{})
+	assert.Equal(t, "alice", public["owner_alias"])
+	assert.NotContains(t, public, "owner_route_hint")
+	assert.NotContains(t, public, "owner_contact_key")
+	assert.NotContains(t, public, "last_login")
+	assert.Equal(t, map[string]interface{}{"github": "alice"}, public["extras"])
+	assert.Equal(t, "alice@example.com", person.Extras["email"])
+
+	response := Public([]BountyResponse{{
+		Bounty:    NewBounty{ID: 1, Title: "bounty"},
+		Owner:     person,
+		Workspace: WorkspaceShort{Uuid: "workspace-uuid"},
+	}}).([]interface{})
+	owner := response[0].(map[string]interface{})["owner"].(map[string]interface{})
+	assert.NotContains(t, owner, "owner_route_hint")
+	bounty := response[0].(map[string]interface{})["bounty"].(map[string]interface{})
+	assert.NotContains(t, bounty, "paid_date")
+
+	encoded, err := json.Marshal(Public(Workspace{Uuid: "workspace-uuid", Budget: 1000, AutoPayCap: 50, Created: &now}))
+	assert.NoError(t, err)
+	decoded := map[string]interface{}{}
+	json.Unmarshal(encoded, &decoded)
+	assert.Equal(t, "workspace-uuid", decoded["uuid"])
+	assert.NotContains(t, decoded, "budget")
+	assert.NotContains(t, decoded, "auto_pay_cap")
+	assert.Equal(t, now.Format(time.RFC3339Nano), decoded["created"])
+}
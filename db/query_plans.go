@@ -0,0 +1,58 @@
+package db
+
+// HotQuery is one of the queries behind the busiest endpoints, with sample
+// arguments so it can be explained without a request
+type HotQuery struct {
+	Name  string
+	Query string
+	Args  []interface{}
+}
+
+type QueryPlan struct {
+	Name  string   `json:"name"`
+	Query string   `json:"query"`
+	Plan  []string `json:"plan"`
+}
+
+var hotQueries = []HotQuery{
+	{
+		Name:  "workspace_open_bounties",
+		Query: `SELECT * FROM bounty WHERE workspace_uuid = ? AND paid = false AND completed = false AND deleted_at IS NULL ORDER BY created DESC LIMIT 20`,
+		Args:  []interface{}{"workspace_uuid"},
+	},
+	{
+		Name:  "feature_phase_bounties",
+		Query: `SELECT bounty.* FROM bounty INNER JOIN feature_phases ON feature_phases.uuid = bounty.phase_uuid WHERE feature_phases.feature_uuid = ? AND feature_phases.uuid = ? AND bounty.deleted_at IS NULL ORDER BY bounty.phase_priority`,
+		Args:  []interface{}{"feature_uuid", "phase_uuid"},
+	},
+	{
+		Name:  "workspace_payment_history",
+		Query: `SELECT * FROM payment_histories WHERE workspace_uuid = ? AND status = true ORDER BY created DESC`,
+		Args:  []interface{}{"workspace_uuid"},
+	},
+}
+
+// ExplainHotQueries returns the planner output of the hot queries, so a
+// missing or unused index shows up as a sequential scan
+func (db database) ExplainHotQueries() ([]QueryPlan, error) {
+	plans := []QueryPlan{}
+	for _, q := range hotQueries {
+		rows, err := db.db.Raw("EXPLAIN "+q.Query, q.Args...).Rows()
+		if err != nil {
+			return nil, err
+		}
+
+		plan := QueryPlan{Name: q.Name, Query: q.Query, Plan: []string{}}
+		for rows.Next() {
+			var line string
+			if err := rows.Scan(&line); err != nil {
+				rows.Close()
+				return nil, err
+			}
+			plan.Plan = append(plan.Plan, line)
+		}
+		rows.Close()
+		plans = append(plans, plan)
+	}
+	return plans, nil
+}
@@ -0,0 +1,116 @@
+package db
+
+import (
+	"errors"
+	"time"
+)
+
+// CreateOrEditQuest saves a quest and replaces its bounties with the ones
+// of BountyIds, in that order
+func (db database) CreateOrEditQuest(quest Quest) (Quest, error) {
+	err := db.transaction(func(tx database) error {
+		now := time.Now()
+		quest.Updated = &now
+		if quest.ID == 0 {
+			quest.Created = &now
+			if err := tx.db.Create(&quest).Error; err != nil {
+				return err
+			}
+		} else if err := tx.db.Save(&quest).Error; err != nil {
+			return err
+		}
+
+		if err := tx.db.Where("quest_uuid = ?", quest.Uuid).Delete(&QuestBounty{}).Error; err != nil {
+			return err
+		}
+		for i, bountyId := range quest.BountyIds {
+			if err := tx.db.Create(&QuestBounty{QuestUuid: quest.Uuid, BountyId: bountyId, Position: i}).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return quest, err
+}
+
+func (db database) GetQuest(uuid string) (Quest, error) {
+	quest := Quest{}
+	if err := db.db.Where("uuid = ?", uuid).First(&quest).Error; err != nil {
+		return quest, err
+	}
+	quest.BountyIds = db.getQuestBountyIds(quest.Uuid)
+	return quest, nil
+}
+
+// GetQuests lists the quests of a workspace, or of every workspace when
+// workspaceUuid is empty, newest first
+func (db database) GetQuests(workspaceUuid string, activeOnly bool) []Quest {
+	quests := []Quest{}
+	query := db.db.Model(&Quest{})
+	if workspaceUuid != "" {
+		query = query.Where("workspace_uuid = ?", workspaceUuid)
+	}
+	if activeOnly {
+		query = query.Where("active = ?", true)
+	}
+	query.Order("id DESC").Find(&quests)
+	for i := range quests {
+		quests[i].BountyIds = db.getQuestBountyIds(quests[i].Uuid)
+	}
+	return quests
+}
+
+func (db database) getQuestBountyIds(questUuid string) []uint {
+	ids := []uint{}
+	db.db.Model(&QuestBounty{}).Where("quest_uuid = ?", questUuid).Order("position").Pluck("bounty_id", &ids)
+	return ids
+}
+
+// GetQuestBounties returns the bounties of a quest in their order
+func (db database) GetQuestBounties(questUuid string) []NewBounty {
+	bounties := []NewBounty{}
+	db.db.Model(&NewBounty{}).
+		Joins("JOIN quest_bounties ON quest_bounties.bounty_id = bounty.id").
+		Where("quest_bounties.quest_uuid = ?", questUuid).
+		Order("quest_bounties.position").
+		Find(&bounties)
+	return bounties
+}
+
+func (db database) DeleteQuest(uuid string) error {
+	return db.transaction(func(tx database) error {
+		if err := tx.db.Where("quest_uuid = ?", uuid).Delete(&QuestBounty{}).Error; err != nil {
+			return err
+		}
+		return tx.db.Where("uuid = ?", uuid).Delete(&Quest{}).Error
+	})
+}
+
+// PayQuestBonus records the bonus of a quest paid to a hunter and takes it
+// from the workspace budget, a quest pays its bonus once
+func (db database) PayQuestBonus(quest Quest, payment NewPaymentHistory) (Quest, error) {
+	err := db.transaction(func(tx database) error {
+		now := time.Now()
+		result := tx.db.Model(&Quest{}).
+			Where("uuid = ? AND (bonus_paid_to IS NULL OR bonus_paid_to = '')", quest.Uuid).
+			Updates(map[string]interface{}{"bonus_paid_to": payment.ReceiverPubKey, "bonus_paid_at": &now, "updated": &now})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("the bonus of the quest is already paid")
+		}
+
+		if err := tx.db.Create(&payment).Error; err != nil {
+			return err
+		}
+
+		return tx.debitBudget(payment.WorkspaceUuid, payment.Amount)
+	})
+	if err == nil {
+		now := time.Now()
+		quest.BonusPaidTo = payment.ReceiverPubKey
+		quest.BonusPaidAt = &now
+	}
+	return quest, err
+}
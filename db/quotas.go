@@ -0,0 +1,75 @@
+package db
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// usagePeriod is the month a metered quota is counted in
+func usagePeriod(at time.Time) string {
+	return at.UTC().Format("2006-01")
+}
+
+// CountWorkspaceUsage adds one use of a metered quota to the month of at
+func (db database) CountWorkspaceUsage(workspaceUuid string, quota string, at time.Time) error {
+	now := time.Now()
+	return db.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "workspace_uuid"}, {Name: "quota"}, {Name: "period"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"count":   gorm.Expr("workspace_usage_counts.count + 1"),
+			"updated": &now,
+		}),
+	}).Create(&WorkspaceUsageCount{
+		WorkspaceUuid: workspaceUuid,
+		Quota:         quota,
+		Period:        usagePeriod(at),
+		Count:         1,
+		Updated:       &now,
+	}).Error
+}
+
+// GetWorkspaceUsage returns what a workspace uses of each quota: its
+// unpaid and uncompleted bounties, its Stakwork submissions in the month of
+// at and the bytes of its artifacts, every version counts
+func (db database) GetWorkspaceUsage(workspaceUuid string, at time.Time) (map[string]int64, error) {
+	var activeBounties, submissions, storage int64
+	counts := []struct {
+		into  *int64
+		query string
+		args  []interface{}
+	}{
+		{&activeBounties, "SELECT COUNT(*) FROM bounty WHERE workspace_uuid = ? AND paid = false AND completed = false AND deleted_at IS NULL",
+			[]interface{}{workspaceUuid}},
+		{&submissions, "SELECT COALESCE(SUM(count), 0) FROM workspace_usage_counts WHERE workspace_uuid = ? AND quota = ? AND period = ?",
+			[]interface{}{workspaceUuid, QuotaStakworkSubmissions, usagePeriod(at)}},
+		{&storage, `SELECT COALESCE(SUM(octet_length(COALESCE(v.content, ''))), 0) FROM workspace_artifact_versions v
+			JOIN workspace_artifacts a ON a.uuid = v.artifact_uuid WHERE a.workspace_uuid = ?`,
+			[]interface{}{workspaceUuid}},
+	}
+	for _, c := range counts {
+		if err := db.db.Raw(c.query, c.args...).Scan(c.into).Error; err != nil {
+			return nil, err
+		}
+	}
+	return map[string]int64{
+		QuotaActiveBounties:      activeBounties,
+		QuotaStakworkSubmissions: submissions,
+		QuotaStorage:             storage,
+	}, nil
+}
+
+// SetWorkspacePlan moves a workspace to a plan, empty is the default plan
+func (db database) SetWorkspacePlan(uuid string, plan string) (Workspace, error) {
+	workspace := Workspace{}
+	now := time.Now()
+	if err := db.db.Model(&Workspace{}).Where("uuid = ?", uuid).Updates(map[string]interface{}{
+		"plan":    plan,
+		"updated": &now,
+	}).Error; err != nil {
+		return workspace, err
+	}
+	err := db.db.Where("uuid = ?", uuid).First(&workspace).Error
+	return workspace, err
+}
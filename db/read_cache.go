@@ -0,0 +1,378 @@
+package db
+
+import (
+	"container/list"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/utils"
+	"gorm.io/gorm"
+)
+
+// Cache namespaces for the public directory reads. Keys are the namespace
+// followed by whatever identifies the request (query string, uuid...)
+const (
+	TribesCacheKey                 = "tribes:"
+	PeopleCacheKey                 = "people:"
+	LeaderboardCacheKey            = "leaderboard:"
+	WorkspaceBountiesCountCacheKey = "workspace_bounties_count:"
+	PublicMetricsCacheKey          = "public_metrics:"
+	TribeUpdatesCacheKey           = "tribe_updates:"
+	WidgetBountiesCacheKey         = "widget_bounties:"
+	readCachePrefix                = "read_cache:"
+)
+
+// tables whose writes make the cached reads stale
+var readCacheInvalidations = map[string][]string{
+	"tribes": {TribesCacheKey},
+	"people": {PeopleCacheKey},
+	"bounty": {LeaderboardCacheKey, WorkspaceBountiesCountCacheKey, WidgetBountiesCacheKey},
+
+	"person_endorsements": {LeaderboardCacheKey},
+	"tribe_announcements": {TribeUpdatesCacheKey},
+	"tribe_events":        {TribeUpdatesCacheKey},
+	"workspaces":          {WidgetBountiesCacheKey},
+	"tribe_categories":    {TribesCacheKey},
+	"categories":          {TribesCacheKey},
+}
+
+type readCacheBackend interface {
+	get(key string) ([]byte, bool)
+	set(key string, value []byte, ttl time.Duration)
+	deletePrefix(prefix string)
+}
+
+type redisReadCache struct{}
+
+func (redisReadCache) get(key string) ([]byte, bool) {
+	value, err := RedisClient.Get(ctx, readCachePrefix+key).Bytes()
+	if err != nil {
+		return nil, false
+	}
+	return value, true
+}
+
+func (redisReadCache) set(key string, value []byte, ttl time.Duration) {
+	if err := RedisClient.Set(ctx, readCachePrefix+key, value, ttl).Err(); err != nil {
+		fmt.Println("[read cache] redis set error", err)
+	}
+}
+
+func (redisReadCache) deletePrefix(prefix string) {
+	iter := RedisClient.Scan(ctx, 0, readCachePrefix+prefix+"*", 100).Iterator()
+	keys := []string{}
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+	}
+	if len(keys) > 0 {
+		RedisClient.Del(ctx, keys...)
+	}
+}
+
+// memoryReadCache keeps up to size entries in the instance. The least
+// recently read entry is dropped first, so the keys made of the query
+// strings of clients can't grow it without bound
+type memoryReadCache struct {
+	mu      sync.Mutex
+	size    int
+	entries map[string]*list.Element
+	order   *list.List
+}
+
+type memoryReadCacheEntry struct {
+	key     string
+	value   []byte
+	expires time.Time
+}
+
+func newMemoryReadCache(size int) *memoryReadCache {
+	return &memoryReadCache{size: size, entries: map[string]*list.Element{}, order: list.New()}
+}
+
+func (m *memoryReadCache) get(key string) ([]byte, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	element, found := m.entries[key]
+	if !found {
+		return nil, false
+	}
+	entry := element.Value.(*memoryReadCacheEntry)
+	if time.Now().After(entry.expires) {
+		m.order.Remove(element)
+		delete(m.entries, key)
+		return nil, false
+	}
+	m.order.MoveToFront(element)
+	return entry.value, true
+}
+
+func (m *memoryReadCache) set(key string, value []byte, ttl time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	entry := &memoryReadCacheEntry{key: key, value: value, expires: time.Now().Add(ttl)}
+	if element, found := m.entries[key]; found {
+		element.Value = entry
+		m.order.MoveToFront(element)
+		return
+	}
+	m.entries[key] = m.order.PushFront(entry)
+	for m.order.Len() > m.size {
+		oldest := m.order.Back()
+		m.order.Remove(oldest)
+		delete(m.entries, oldest.Value.(*memoryReadCacheEntry).key)
+	}
+}
+
+func (m *memoryReadCache) deletePrefix(prefix string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for key, element := range m.entries {
+		if strings.HasPrefix(key, prefix) {
+			m.order.Remove(element)
+			delete(m.entries, key)
+		}
+	}
+}
+
+type ReadCacheStats struct {
+	Backend string           `json:"backend"`
+	TTL     int              `json:"ttl_seconds"`
+	Hits    map[string]int64 `json:"hits"`
+	Misses  map[string]int64 `json:"misses"`
+}
+
+var (
+	readCache       readCacheBackend
+	readCacheName   string
+	readCacheTTL    time.Duration
+	readCacheHits   sync.Map
+	readCacheMisses sync.Map
+)
+
+// InitReadCache turns on caching of the hot directory reads. Redis is used
+// when it is reachable, otherwise entries are kept in memory, up to
+// READ_CACHE_ENTRIES of them. READ_CACHE_TTL sets the lifetime in seconds, 0
+// turns the cache off
+func InitReadCache() {
+	ttl := 30
+	if value := os.Getenv("READ_CACHE_TTL"); value != "" {
+		ttl, _ = strconv.Atoi(value)
+	}
+	if ttl <= 0 {
+		fmt.Println("[read cache] disabled")
+		return
+	}
+	readCacheTTL = time.Duration(ttl) * time.Second
+	entries := 1000
+	if value := os.Getenv("READ_CACHE_ENTRIES"); value != "" {
+		if parsed, err := strconv.Atoi(value); err == nil && parsed > 0 {
+			entries = parsed
+		}
+	}
+
+	if RedisClient != nil && RedisError == nil {
+		readCache = redisReadCache{}
+		readCacheName = "redis"
+	} else {
+		readCache = newMemoryReadCache(entries)
+		readCacheName = "memory"
+	}
+	fmt.Println("[read cache] using", readCacheName)
+}
+
+func namespaceOf(key string) string {
+	if i := strings.Index(key, ":"); i >= 0 {
+		return key[:i]
+	}
+	return key
+}
+
+func countCacheResult(counters *sync.Map, key string) {
+	counter, _ := counters.LoadOrStore(namespaceOf(key), new(int64))
+	atomic.AddInt64(counter.(*int64), 1)
+}
+
+// ListCacheKey is the key of a list read, the enveloped and the bare
+// response of the same query are cached apart
+func ListCacheKey(namespace string, r *http.Request) string {
+	if utils.WantsListEnvelope(r) {
+		return namespace + "envelope:" + r.URL.RawQuery
+	}
+	return namespace + r.URL.RawQuery
+}
+
+// the X-Cache values of a cached read: served from the cache, loaded into
+// it, or loaded without a cache
+const (
+	CacheHit    = "HIT"
+	CacheMiss   = "MISS"
+	CacheBypass = "BYPASS"
+)
+
+// CachedJSON returns the JSON encoding of load(), served from the read cache
+// when a fresh entry exists. Without a cache, load is always called
+func CachedJSON(key string, load func() interface{}) ([]byte, error) {
+	return CachedJSONFor(key, readCacheTTL, load)
+}
+
+// CachedJSONFor is CachedJSON with the entry kept for ttl instead of
+// READ_CACHE_TTL, for reads that are too costly to redo that often
+func CachedJSONFor(key string, ttl time.Duration, load func() interface{}) ([]byte, error) {
+	value, _, err := cachedJSON(key, ttl, func() (interface{}, error) {
+		return load(), nil
+	})
+	return value, err
+}
+
+// CachedRead is CachedJSON for a load that can fail, a failed load is not
+// cached and its error is returned. It also returns where the value came
+// from, CacheHit, CacheMiss or CacheBypass, for the X-Cache header
+func CachedRead(key string, load func() (interface{}, error)) ([]byte, string, error) {
+	return cachedJSON(key, readCacheTTL, load)
+}
+
+func cachedJSON(key string, ttl time.Duration, load func() (interface{}, error)) ([]byte, string, error) {
+	if readCache == nil {
+		data, err := load()
+		if err != nil {
+			return nil, CacheBypass, err
+		}
+		value, err := json.Marshal(data)
+		return value, CacheBypass, err
+	}
+
+	if value, found := readCache.get(key); found {
+		countCacheResult(&readCacheHits, key)
+		return value, CacheHit, nil
+	}
+	countCacheResult(&readCacheMisses, key)
+
+	data, err := load()
+	if err != nil {
+		return nil, CacheMiss, err
+	}
+	value, err := json.Marshal(data)
+	if err != nil {
+		return nil, CacheMiss, err
+	}
+	readCache.set(key, value, ttl)
+	return value, CacheMiss, nil
+}
+
+// InvalidateReadCache drops every cached entry under the given namespaces
+func InvalidateReadCache(prefixes ...string) {
+	if readCache == nil {
+		return
+	}
+	for _, prefix := range prefixes {
+		readCache.deletePrefix(prefix)
+	}
+}
+
+func GetReadCacheStats() ReadCacheStats {
+	stats := ReadCacheStats{
+		Backend: readCacheName,
+		TTL:     int(readCacheTTL / time.Second),
+		Hits:    map[string]int64{},
+		Misses:  map[string]int64{},
+	}
+	if readCache == nil {
+		stats.Backend = "disabled"
+	}
+
+	collect := func(counters *sync.Map, into map[string]int64) {
+		counters.Range(func(key, value interface{}) bool {
+			into[key.(string)] = atomic.LoadInt64(value.(*int64))
+			return true
+		})
+	}
+	collect(&readCacheHits, stats.Hits)
+	collect(&readCacheMisses, stats.Misses)
+	return stats
+}
+
+// readCachePlugin invalidates the cached reads after writes to the tables
+// they are built from, so handlers don't have to remember to do it. Inside a
+// transaction the entries are dropped once it commits
+type readCachePlugin struct{}
+
+// rawWrites finds the tables an Exec or Raw statement writes to
+var rawWrites = regexp.MustCompile(`(?i)\b(?:INSERT\s+INTO|UPDATE|DELETE\s+FROM)\s+"?(\w+)"?`)
+
+type pendingInvalidationsKey struct{}
+
+// pendingInvalidations are the namespaces a transaction made stale
+type pendingInvalidations struct {
+	mu       sync.Mutex
+	prefixes []string
+}
+
+func (p *pendingInvalidations) add(prefixes ...string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.prefixes = append(p.prefixes, prefixes...)
+}
+
+func (readCachePlugin) Name() string {
+	return "read_cache"
+}
+
+func (p readCachePlugin) Initialize(db *gorm.DB) error {
+	if err := db.Callback().Create().After("gorm:create").Register("read_cache:after_create", p.invalidate); err != nil {
+		return err
+	}
+	if err := db.Callback().Update().After("gorm:update").Register("read_cache:after_update", p.invalidate); err != nil {
+		return err
+	}
+	if err := db.Callback().Delete().After("gorm:delete").Register("read_cache:after_delete", p.invalidate); err != nil {
+		return err
+	}
+	if err := db.Callback().Raw().After("gorm:raw").Register("read_cache:after_raw", p.invalidateRaw); err != nil {
+		return err
+	}
+	return db.Callback().Row().After("gorm:row").Register("read_cache:after_row", p.invalidateRaw)
+}
+
+func (readCachePlugin) invalidate(tx *gorm.DB) {
+	if tx.Error != nil || tx.RowsAffected == 0 {
+		return
+	}
+	invalidateTables(tx, tx.Statement.Table)
+}
+
+// invalidateRaw is invalidate for Exec and Raw, whose statement has no
+// table. The tables are read from the sql, and a Raw write that returns
+// rows has no count of affected rows
+func (readCachePlugin) invalidateRaw(tx *gorm.DB) {
+	if tx.Error != nil {
+		return
+	}
+	tables := []string{}
+	for _, match := range rawWrites.FindAllStringSubmatch(tx.Statement.SQL.String(), -1) {
+		tables = append(tables, strings.ToLower(match[1]))
+	}
+	invalidateTables(tx, tables...)
+}
+
+func invalidateTables(tx *gorm.DB, tables ...string) {
+	prefixes := []string{}
+	for _, table := range tables {
+		prefixes = append(prefixes, readCacheInvalidations[table]...)
+	}
+	if len(prefixes) == 0 {
+		return
+	}
+	if pending, ok := tx.Statement.Context.Value(pendingInvalidationsKey{}).(*pendingInvalidations); ok {
+		pending.add(prefixes...)
+		return
+	}
+	InvalidateReadCache(prefixes...)
+}
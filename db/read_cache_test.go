@@ -0,0 +1,191 @@
+package db
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestCachedJSON(t *testing.T) {
+	readCache = newMemoryReadCache(100)
+	readCacheTTL = time.Minute
+	defer func() { readCache = nil }()
+
+	calls := 0
+	load := func() interface{} {
+		calls++
+		return []Tribe{{UUID: "tribe-uuid"}}
+	}
+
+	first, err := CachedJSON(TribesCacheKey+"page=1", load)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, _ := CachedJSON(TribesCacheKey+"page=1", load)
+
+	if calls != 1 {
+		t.Errorf("expected one load, got %d", calls)
+	}
+	if string(first) != string(second) {
+		t.Error("cached value differs from the loaded one")
+	}
+
+	CachedJSON(TribesCacheKey+"page=2", load)
+	if calls != 2 {
+		t.Errorf("expected a load for a different key, got %d", calls)
+	}
+
+	stats := GetReadCacheStats()
+	if stats.Hits["tribes"] < 1 || stats.Misses["tribes"] < 2 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+}
+
+func TestInvalidateReadCache(t *testing.T) {
+	readCache = newMemoryReadCache(100)
+	readCacheTTL = time.Minute
+	defer func() { readCache = nil }()
+
+	calls := 0
+	load := func() interface{} {
+		calls++
+		return calls
+	}
+
+	CachedJSON(TribesCacheKey+"page=1", load)
+	CachedJSON(PeopleCacheKey+"page=1", load)
+
+	InvalidateReadCache(TribesCacheKey)
+
+	CachedJSON(TribesCacheKey+"page=1", load)
+	CachedJSON(PeopleCacheKey+"page=1", load)
+
+	if calls != 3 {
+		t.Errorf("expected only the tribes entry to be reloaded, got %d loads", calls)
+	}
+}
+
+func TestMemoryReadCache(t *testing.T) {
+	m := newMemoryReadCache(2)
+	m.set("a", []byte("1"), time.Minute)
+	m.set("b", []byte("2"), time.Minute)
+	m.get("a")
+	m.set("c", []byte("3"), time.Minute)
+
+	if _, found := m.get("b"); found {
+		t.Error("expected the least recently read entry to be dropped")
+	}
+	if value, found := m.get("a"); !found || string(value) != "1" {
+		t.Error("expected the recently read entry to be kept")
+	}
+
+	m.set("d", []byte("4"), -time.Second)
+	if _, found := m.get("d"); found {
+		t.Error("expected an expired entry to be missed")
+	}
+}
+
+func TestCachedRead(t *testing.T) {
+	_, status, _ := CachedRead(TribesCacheKey+"page=1", func() (interface{}, error) { return 1, nil })
+	if status != CacheBypass {
+		t.Errorf("expected %s without a cache, got %s", CacheBypass, status)
+	}
+
+	readCache = newMemoryReadCache(100)
+	readCacheTTL = time.Minute
+	defer func() { readCache = nil }()
+
+	failed := errors.New("failed")
+	if _, _, err := CachedRead(TribesCacheKey+"page=1", func() (interface{}, error) { return nil, failed }); err != failed {
+		t.Errorf("expected the error of the load, got %v", err)
+	}
+	_, status, _ = CachedRead(TribesCacheKey+"page=1", func() (interface{}, error) { return 1, nil })
+	if status != CacheMiss {
+		t.Errorf("expected a failed load not to be cached, got %s", status)
+	}
+	value, status, _ := CachedRead(TribesCacheKey+"page=1", func() (interface{}, error) { return 2, nil })
+	if status != CacheHit || string(value) != "1" {
+		t.Errorf("expected the cached value, got %s %s", status, value)
+	}
+}
+
+func TestReadCachePlugin(t *testing.T) {
+	readCache = newMemoryReadCache(100)
+	readCacheTTL = time.Minute
+	defer func() { readCache = nil }()
+
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sqlDB.Close()
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{SkipDefaultTransaction: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gormDB.Use(readCachePlugin{}); err != nil {
+		t.Fatal(err)
+	}
+	store := database{db: gormDB}
+	cached := func() bool {
+		_, found := readCache.get(LeaderboardCacheKey + "top")
+		return found
+	}
+
+	t.Run("should invalidate after a raw write", func(t *testing.T) {
+		readCache.set(LeaderboardCacheKey+"top", []byte("[]"), time.Minute)
+		mock.ExpectExec("UPDATE bounty").WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		store.db.Exec("UPDATE bounty SET view_count = view_count + 1 WHERE id = ?", 1)
+
+		if cached() {
+			t.Error("expected the leaderboard to be dropped by a raw bounty update")
+		}
+	})
+
+	t.Run("should invalidate once the transaction commits", func(t *testing.T) {
+		readCache.set(LeaderboardCacheKey+"top", []byte("[]"), time.Minute)
+		mock.ExpectBegin()
+		mock.ExpectExec("DELETE FROM bounty").WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := store.transaction(func(tx database) error {
+			tx.db.Exec("DELETE FROM bounty WHERE id = ?", 1)
+			if !cached() {
+				t.Error("expected the leaderboard to stay until the commit")
+			}
+			return nil
+		})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cached() {
+			t.Error("expected the leaderboard to be dropped after the commit")
+		}
+	})
+
+	t.Run("should keep the entries of a rolled back transaction", func(t *testing.T) {
+		readCache.set(LeaderboardCacheKey+"top", []byte("[]"), time.Minute)
+		mock.ExpectBegin()
+		mock.ExpectExec("DELETE FROM bounty").WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectRollback()
+
+		store.transaction(func(tx database) error {
+			tx.db.Exec("DELETE FROM bounty WHERE id = ?", 1)
+			return errors.New("rolled back")
+		})
+
+		if !cached() {
+			t.Error("expected the leaderboard to stay after a rollback")
+		}
+	})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
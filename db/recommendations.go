@@ -0,0 +1,255 @@
+package db
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// open bounties ranked per run, the newest first
+	recommendationCandidates = 1000
+	// recommendations kept per person
+	recommendationsKept = 50
+	// stored recommendations older than this are computed again on demand
+	RecommendationMaxAge = 3 * time.Hour
+)
+
+// weights of the signals of a recommendation
+const (
+	skillWeight         = 3.0
+	paidTagWeight       = 1.0
+	paidTagCap          = 5
+	memberWeight        = 2.0
+	paidWorkspaceWeight = 1.0
+	paidWorkspaceCap    = 3
+	priceWeight         = 2.0
+)
+
+// recommendationProfile is what a person's profile and paid work say about
+// the bounties they would pick
+type recommendationProfile struct {
+	skills     map[string]bool
+	paidTags   map[string]int
+	member     map[string]bool
+	paidIn     map[string]int
+	priceScale float64
+}
+
+// personSkills reads the coding languages a person lists on their profile
+func personSkills(p Person) []string {
+	languages, _ := p.Extras["coding_languages"].([]interface{})
+	skills := []string{}
+	for _, l := range languages {
+		language, _ := l.(map[string]interface{})
+		label, _ := language["label"].(string)
+		if label == "" {
+			label, _ = language["value"].(string)
+		}
+		if label != "" {
+			skills = append(skills, label)
+		}
+	}
+	return skills
+}
+
+func (db database) recommendationProfile(pubkey string) (recommendationProfile, error) {
+	profile := recommendationProfile{
+		skills:   map[string]bool{},
+		paidTags: map[string]int{},
+		member:   map[string]bool{},
+		paidIn:   map[string]int{},
+	}
+
+	for _, skill := range personSkills(db.GetPersonByPubkey(pubkey)) {
+		profile.skills[strings.ToLower(skill)] = true
+	}
+	for _, w := range db.GetUserAssignedWorkspaces(pubkey) {
+		profile.member[w.WorkspaceUuid] = true
+	}
+	for _, w := range db.GetUserCreatedWorkspaces(pubkey) {
+		profile.member[w.Uuid] = true
+	}
+
+	paid := []NewBounty{}
+	err := db.db.Model(&NewBounty{}).Select("coding_languages, workspace_uuid, price").
+		Where("assignee = ? AND paid = true", pubkey).Find(&paid).Error
+	if err != nil {
+		return profile, err
+	}
+	prices := []float64{}
+	for _, b := range paid {
+		for _, language := range b.CodingLanguages {
+			profile.paidTags[strings.ToLower(language)]++
+		}
+		if b.WorkspaceUuid != "" {
+			profile.paidIn[b.WorkspaceUuid]++
+		}
+		if b.Price > 0 {
+			prices = append(prices, float64(b.Price))
+		}
+	}
+	if len(prices) > 0 {
+		sort.Float64s(prices)
+		profile.priceScale = prices[len(prices)/2]
+	}
+	return profile, nil
+}
+
+// scoreBounty ranks an open bounty for a profile, with the reasons that
+// added to the score. Each signal is capped so none of them drowns the others
+func scoreBounty(profile recommendationProfile, bounty NewBounty) (float64, []string) {
+	score := 0.0
+	reasons := []string{}
+
+	for _, language := range bounty.CodingLanguages {
+		language = strings.ToLower(language)
+		if profile.skills[language] {
+			score += skillWeight
+			reasons = append(reasons, "skill:"+language)
+		}
+		if count := profile.paidTags[language]; count > 0 {
+			score += paidTagWeight * math.Min(float64(count), paidTagCap)
+			reasons = append(reasons, "paid_work:"+language)
+		}
+	}
+
+	affinity := 0.0
+	if profile.member[bounty.WorkspaceUuid] {
+		affinity += memberWeight
+	}
+	if count := profile.paidIn[bounty.WorkspaceUuid]; count > 0 {
+		affinity += paidWorkspaceWeight * math.Min(float64(count), paidWorkspaceCap)
+	}
+	if affinity > 0 {
+		score += affinity
+		reasons = append(reasons, "workspace")
+	}
+
+	// full weight at the median price of the paid work, nothing at 10 times
+	// more or less
+	if profile.priceScale > 0 && bounty.Price > 0 {
+		distance := math.Abs(math.Log(float64(bounty.Price) / profile.priceScale))
+		if fit := 1 - distance/math.Ln10; fit > 0 {
+			score += priceWeight * fit
+			if fit >= 0.5 {
+				reasons = append(reasons, "price")
+			}
+		}
+	}
+
+	return math.Round(score*100) / 100, reasons
+}
+
+// rankBounties orders the candidates for a profile, the best first and the
+// newest first among equals
+func rankBounties(profile recommendationProfile, pubkey string, candidates []NewBounty, at time.Time) []BountyRecommendation {
+	recommendations := []BountyRecommendation{}
+	for _, bounty := range candidates {
+		if bounty.OwnerID == pubkey {
+			continue
+		}
+		score, reasons := scoreBounty(profile, bounty)
+		recommendations = append(recommendations, BountyRecommendation{
+			Pubkey:   pubkey,
+			BountyID: bounty.ID,
+			Score:    score,
+			Reasons:  reasons,
+			Computed: at,
+			created:  bounty.Created,
+		})
+	}
+
+	sort.SliceStable(recommendations, func(i, j int) bool {
+		if recommendations[i].Score != recommendations[j].Score {
+			return recommendations[i].Score > recommendations[j].Score
+		}
+		return recommendations[i].created > recommendations[j].created
+	})
+	if len(recommendations) > recommendationsKept {
+		recommendations = recommendations[:recommendationsKept]
+	}
+	return recommendations
+}
+
+func (db database) openBounties() ([]NewBounty, error) {
+	candidates := []NewBounty{}
+	err := db.db.Model(&NewBounty{}).
+		Where("show != false AND assignee = '' AND paid = false AND completed = false").
+		Order("created DESC").Limit(recommendationCandidates).Find(&candidates).Error
+	return candidates, err
+}
+
+// RefreshBountyRecommendations ranks the open bounties for each pubkey and
+// replaces their stored recommendations
+func (db database) RefreshBountyRecommendations(pubkeys []string, at time.Time) error {
+	candidates, err := db.openBounties()
+	if err != nil {
+		return err
+	}
+
+	for _, pubkey := range pubkeys {
+		profile, err := db.recommendationProfile(pubkey)
+		if err != nil {
+			return err
+		}
+		recommendations := rankBounties(profile, pubkey, candidates, at)
+
+		err = db.transaction(func(tx database) error {
+			if err := tx.db.Where("pubkey = ?", pubkey).Delete(&BountyRecommendation{}).Error; err != nil {
+				return err
+			}
+			if len(recommendations) == 0 {
+				return nil
+			}
+			return tx.db.Create(&recommendations).Error
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetBountyRecommendations returns the stored recommendations of a pubkey
+// computed after since, with their bounties. Bounties that were assigned or
+// paid since the run are left out
+func (db database) GetBountyRecommendations(pubkey string, since time.Time, limit int) ([]BountyRecommendation, error) {
+	recommendations := []BountyRecommendation{}
+	err := db.db.Table("bounty_recommendations r").Select("r.*").
+		Joins("JOIN bounty b ON b.id = r.bounty_id").
+		Where("r.pubkey = ? AND r.computed >= ?", pubkey, since).
+		Where("b.assignee = '' AND b.paid = false AND b.completed = false AND b.deleted_at IS NULL").
+		Order("r.score DESC, b.created DESC").Limit(limit).Find(&recommendations).Error
+	if err != nil || len(recommendations) == 0 {
+		return recommendations, err
+	}
+
+	ids := make([]uint, len(recommendations))
+	for i, r := range recommendations {
+		ids[i] = r.BountyID
+	}
+	bounties := []NewBounty{}
+	if err := db.db.Where("id IN ?", ids).Find(&bounties).Error; err != nil {
+		return nil, err
+	}
+	byID := map[uint]NewBounty{}
+	for _, b := range bounties {
+		byID[b.ID] = b
+	}
+	for i := range recommendations {
+		recommendations[i].Bounty = byID[recommendations[i].BountyID]
+	}
+	return recommendations, nil
+}
+
+// GetRecommendationPubkeys returns the people the recommendation job ranks
+// bounties for, the ones active within the active user window
+func (db database) GetRecommendationPubkeys(at time.Time) ([]string, error) {
+	pubkeys := []string{}
+	err := db.db.Model(&Person{}).
+		Where("last_login >= ? AND (deleted = false OR deleted IS NULL)", at.Add(-activeUserWindow).Unix()).
+		Pluck("owner_pub_key", &pubkeys).Error
+	return pubkeys, err
+}
@@ -0,0 +1,61 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRankBounties(t *testing.T) {
+	profile := recommendationProfile{
+		skills:     map[string]bool{"golang": true},
+		paidTags:   map[string]int{"typescript": 2},
+		member:     map[string]bool{"workspace-a": true},
+		paidIn:     map[string]int{},
+		priceScale: 10000,
+	}
+	now := time.Now()
+
+	t.Run("should score each signal with its reason", func(t *testing.T) {
+		score, reasons := scoreBounty(profile, NewBounty{CodingLanguages: []string{"Golang", "Typescript"}, WorkspaceUuid: "workspace-a", Price: 10000})
+
+		assert.Equal(t, skillWeight+2*paidTagWeight+memberWeight+priceWeight, score)
+		assert.Equal(t, []string{"skill:golang", "paid_work:typescript", "workspace", "price"}, reasons)
+	})
+
+	t.Run("should not reward a price far from the paid work", func(t *testing.T) {
+		score, reasons := scoreBounty(profile, NewBounty{Price: 1000000})
+
+		assert.Zero(t, score)
+		assert.Empty(t, reasons)
+	})
+
+	t.Run("should rank the best match first and skip the bounties of the caller", func(t *testing.T) {
+		candidates := []NewBounty{
+			{ID: 1, Created: 3, CodingLanguages: []string{"Python"}},
+			{ID: 2, Created: 2, CodingLanguages: []string{"Golang"}},
+			{ID: 3, Created: 4, OwnerID: "hunter", CodingLanguages: []string{"Golang"}},
+			{ID: 4, Created: 5},
+		}
+
+		ranked := rankBounties(profile, "hunter", candidates, now)
+
+		ids := []uint{}
+		for _, r := range ranked {
+			ids = append(ids, r.BountyID)
+		}
+		assert.Equal(t, []uint{2, 4, 1}, ids)
+		assert.Equal(t, "hunter", ranked[0].Pubkey)
+	})
+}
+
+func TestPersonSkills(t *testing.T) {
+	person := Person{Extras: PropertyMap{"coding_languages": []interface{}{
+		map[string]interface{}{"label": "Golang", "value": "Golang"},
+		map[string]interface{}{"value": "Rust"},
+	}}}
+
+	assert.Equal(t, []string{"Golang", "Rust"}, personSkills(person))
+	assert.Empty(t, personSkills(Person{}))
+}
@@ -0,0 +1,176 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/utils"
+)
+
+// RetentionRules are the default retention rules, RETENTION_DAYS changes
+// their age or turns them off. Auth challenges only live in the cache, the
+// used connection codes are the stale auth data kept in the database
+var RetentionRules = []RetentionRule{
+	{
+		Name:      "expired_invoices",
+		Action:    RetentionPurge,
+		Table:     "invoice_lists",
+		AgeColumn: "created",
+		Where:     "status = false",
+		Days:      90,
+	},
+	{
+		Name:      "used_connection_codes",
+		Action:    RetentionAnonymize,
+		Table:     "connectioncodes",
+		AgeColumn: "date_created",
+		Where:     "is_used = true AND connection_string != ''",
+		Set:       "connection_string = ''",
+		Days:      30,
+	},
+	{
+		Name:           "bounty_events",
+		Action:         RetentionArchive,
+		Table:          "events",
+		AgeColumn:      "created",
+		Where:          "(type LIKE 'bounty.%' OR type = 'payment.settled')",
+		ArchiveTable:   "events_archive",
+		ArchiveColumns: "id, uuid, type, subject, payload, created",
+		Days:           730,
+	},
+	{
+		Name:      "finished_jobs",
+		Action:    RetentionPurge,
+		Table:     "jobs",
+		AgeColumn: "updated",
+		Where:     "status IN ('completed', 'dead')",
+		Days:      30,
+	},
+	{
+		Name:      "resolved_reports",
+		Action:    RetentionAnonymize,
+		Table:     "reports",
+		AgeColumn: "resolved",
+		Where:     "status != 'pending' AND reporter_pub_key != ''",
+		Set:       "reporter_pub_key = ''",
+		Days:      365,
+	},
+	{
+		Name:      "read_notifications",
+		Action:    RetentionPurge,
+		Table:     "notifications",
+		AgeColumn: "created",
+		Where:     "read = true",
+		Days:      90,
+	},
+	{
+		Name:      "expired_uploads",
+		Action:    RetentionPurge,
+		Table:     "upload_sessions",
+		AgeColumn: "expires",
+		Days:      1,
+	},
+	{
+		Name:      "orphaned_upload_chunks",
+		Action:    RetentionPurge,
+		Table:     "upload_chunks",
+		AgeColumn: "created",
+		Where:     "NOT EXISTS (SELECT 1 FROM upload_sessions WHERE upload_sessions.uuid = upload_chunks.session_uuid AND upload_sessions.expires > now())",
+		Days:      1,
+	},
+	{
+		Name:      "deleted_tribes",
+		Action:    RetentionPurge,
+		Table:     "tribes",
+		AgeColumn: "deleted_at",
+		Days:      90,
+	},
+	{
+		Name:      "deleted_channels",
+		Action:    RetentionPurge,
+		Table:     "channels",
+		AgeColumn: "deleted_at",
+		Days:      90,
+	},
+	{
+		Name:      "deleted_bounties",
+		Action:    RetentionPurge,
+		Table:     "bounty",
+		AgeColumn: "deleted_at",
+		Days:      90,
+	},
+	{
+		Name:      "deleted_features",
+		Action:    RetentionPurge,
+		Table:     "workspace_features",
+		AgeColumn: "deleted_at",
+		Days:      90,
+	},
+}
+
+func (rule RetentionRule) where() string {
+	where := rule.AgeColumn + " < ?"
+	if rule.Where != "" {
+		where += " AND " + rule.Where
+	}
+	return where
+}
+
+// ApplyRetentionRule purges, anonymizes or archives the rows of a rule
+// older than before and returns how many rows it changed. A dry run only
+// counts them
+func (db database) ApplyRetentionRule(rule RetentionRule, before time.Time, dryRun bool) (int64, error) {
+	where := rule.where()
+	if dryRun {
+		var count int64
+		err := db.db.Raw(fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE %s", rule.Table, where), before).Scan(&count).Error
+		return count, err
+	}
+
+	var query string
+	switch rule.Action {
+	case RetentionPurge:
+		query = fmt.Sprintf("DELETE FROM %s WHERE %s", rule.Table, where)
+	case RetentionAnonymize:
+		query = fmt.Sprintf("UPDATE %s SET %s WHERE %s", rule.Table, rule.Set, where)
+	case RetentionArchive:
+		// one statement, the rows leave the table only if the archive got them
+		query = fmt.Sprintf(`WITH moved AS (DELETE FROM %s WHERE %s RETURNING %s)
+			INSERT INTO %s (%s) SELECT %s FROM moved`,
+			rule.Table, where, rule.ArchiveColumns, rule.ArchiveTable, rule.ArchiveColumns, rule.ArchiveColumns)
+	default:
+		return 0, errors.New("unknown retention action " + rule.Action)
+	}
+
+	result := db.db.Exec(query, before)
+	return result.RowsAffected, result.Error
+}
+
+func (db database) CreateRetentionRuns(runs []RetentionRun) error {
+	if len(runs) == 0 {
+		return nil
+	}
+	return db.db.Create(&runs).Error
+}
+
+// GetRetentionRuns returns the latest retention runs, newest first
+func (db database) GetRetentionRuns(r *http.Request) ([]RetentionRun, error) {
+	runs := []RetentionRun{}
+	offset, limit, _, _, _ := utils.GetPaginationParams(r)
+	if r.URL.Query().Get("limit") == "" {
+		limit = 50
+	}
+
+	query := db.db.Model(&RetentionRun{})
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if offset > 0 {
+		query = query.Offset(offset)
+	}
+
+	err := query.Order("created DESC, id DESC").Find(&runs).Error
+	return runs, err
+}
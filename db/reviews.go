@@ -0,0 +1,193 @@
+package db
+
+import (
+	"errors"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+var ErrNotReviewer = errors.New("the proof is not assigned to this reviewer")
+
+// ProofStatus is the status of a proof from the decisions of its reviewers
+func ProofStatus(required int, reviews []ReviewAssignment) string {
+	approvals, changes := 0, 0
+	for _, review := range reviews {
+		switch review.Decision {
+		case ProofApproved:
+			approvals++
+		case ProofChangesRequested:
+			changes++
+		}
+	}
+	if required < 1 {
+		required = 1
+	}
+	switch {
+	case approvals >= required:
+		return ProofApproved
+	case changes > 0:
+		return ProofChangesRequested
+	}
+	return ProofPending
+}
+
+// SetWorkspaceReviewers sets the default reviewers of a workspace, how many
+// approvals the proofs of its bounties need and if their payment needs one
+func (db database) SetWorkspaceReviewers(uuid string, settings ReviewerSettings) (Workspace, error) {
+	workspace := Workspace{}
+	now := time.Now()
+	if err := db.db.Model(&Workspace{}).Where("uuid = ?", uuid).Updates(map[string]interface{}{
+		"default_reviewers":      pq.StringArray(settings.Reviewers),
+		"required_approvals":     settings.RequiredApprovals,
+		"payment_requires_proof": settings.PaymentRequiresProof,
+		"updated":                &now,
+	}).Error; err != nil {
+		return workspace, err
+	}
+	err := db.db.Where("uuid = ?", uuid).First(&workspace).Error
+	return workspace, err
+}
+
+// SetFeatureReviewers sets the default reviewers of the bounties in the
+// phases of a feature
+func (db database) SetFeatureReviewers(uuid string, reviewers []string, pubkey string) (WorkspaceFeatures, error) {
+	now := time.Now()
+	if err := db.db.Model(&WorkspaceFeatures{}).Where("uuid = ?", uuid).Updates(map[string]interface{}{
+		"default_reviewers": pq.StringArray(reviewers),
+		"updated":           &now,
+		"updated_by":        pubkey,
+	}).Error; err != nil {
+		return WorkspaceFeatures{}, err
+	}
+	return db.GetFeatureByUuid(uuid), nil
+}
+
+// CreateBountyProof saves a proof with a review assignment for each of its
+// reviewers
+func (db database) CreateBountyProof(proof BountyProof, reviewers []string) (BountyProof, error) {
+	if proof.Uuid == "" {
+		return proof, errors.New("proof uuid is required")
+	}
+	now := time.Now()
+	proof.Created = &now
+	proof.Updated = &now
+	proof.Status = ProofPending
+	proof.Reviews = []ReviewAssignment{}
+	for _, reviewer := range reviewers {
+		proof.Reviews = append(proof.Reviews, ReviewAssignment{
+			ProofUuid: proof.Uuid,
+			BountyID:  proof.BountyID,
+			Reviewer:  reviewer,
+			Created:   &now,
+		})
+	}
+
+	err := db.transaction(func(tx database) error {
+		if err := tx.db.Create(&proof).Error; err != nil {
+			return err
+		}
+		if len(proof.Reviews) == 0 {
+			return nil
+		}
+		return tx.db.Create(&proof.Reviews).Error
+	})
+	return proof, err
+}
+
+// withReviews loads the review assignments of proofs
+func (db database) withReviews(proofs []BountyProof) ([]BountyProof, error) {
+	if len(proofs) == 0 {
+		return proofs, nil
+	}
+	uuids := make([]string, 0, len(proofs))
+	for _, proof := range proofs {
+		uuids = append(uuids, proof.Uuid)
+	}
+	reviews := []ReviewAssignment{}
+	if err := db.db.Where("proof_uuid IN ?", uuids).Order("id ASC").Find(&reviews).Error; err != nil {
+		return proofs, err
+	}
+	for i := range proofs {
+		proofs[i].Reviews = []ReviewAssignment{}
+		for _, review := range reviews {
+			if review.ProofUuid == proofs[i].Uuid {
+				proofs[i].Reviews = append(proofs[i].Reviews, review)
+			}
+		}
+	}
+	return proofs, nil
+}
+
+// GetBountyProofs returns the proofs of a bounty with their reviews, the
+// latest first
+func (db database) GetBountyProofs(bountyId uint) ([]BountyProof, error) {
+	proofs := []BountyProof{}
+	if err := db.db.Where("bounty_id = ?", bountyId).Order("id DESC").Find(&proofs).Error; err != nil {
+		return proofs, err
+	}
+	return db.withReviews(proofs)
+}
+
+// GetLatestBountyProof returns the last proof submitted on a bounty, the
+// one its completion waits on
+func (db database) GetLatestBountyProof(bountyId uint) (BountyProof, error) {
+	proofs := []BountyProof{}
+	if err := db.db.Where("bounty_id = ?", bountyId).Order("id DESC").Limit(1).Find(&proofs).Error; err != nil {
+		return BountyProof{}, err
+	}
+	if len(proofs) == 0 {
+		return BountyProof{}, errors.New("no proof submitted")
+	}
+	proofs, err := db.withReviews(proofs)
+	return proofs[0], err
+}
+
+// HasApprovedBountyProof reports if one of the proofs of a bounty was
+// approved, not only the latest
+func (db database) HasApprovedBountyProof(bountyId uint) bool {
+	var count int64
+	db.db.Model(&BountyProof{}).Where("bounty_id = ? AND status = ?", bountyId, ProofApproved).Count(&count)
+	return count > 0
+}
+
+// ReviewBountyProof records the decision of a reviewer of a proof and
+// updates its status. The proof is locked so two reviews don't both miss
+// the approval that completes it
+func (db database) ReviewBountyProof(proofUuid string, reviewer string, decision string, comment string, at time.Time) (BountyProof, error) {
+	proof := BountyProof{}
+	err := db.transaction(func(tx database) error {
+		result := tx.db.Raw("SELECT * FROM bounty_proofs WHERE uuid = ? FOR UPDATE", proofUuid).Scan(&proof)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("proof not found")
+		}
+
+		result = tx.db.Model(&ReviewAssignment{}).Where("proof_uuid = ? AND reviewer = ?", proofUuid, reviewer).Updates(map[string]interface{}{
+			"decision": decision,
+			"comment":  comment,
+			"decided":  &at,
+		})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrNotReviewer
+		}
+
+		proofs, err := tx.withReviews([]BountyProof{proof})
+		if err != nil {
+			return err
+		}
+		proof = proofs[0]
+		proof.Status = ProofStatus(proof.Required, proof.Reviews)
+		proof.Updated = &at
+		return tx.db.Model(&BountyProof{}).Where("uuid = ?", proofUuid).Updates(map[string]interface{}{
+			"status":  proof.Status,
+			"updated": &at,
+		}).Error
+	})
+	return proof, err
+}
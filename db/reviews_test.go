@@ -0,0 +1,29 @@
+package db
+
+import "testing"
+
+func TestProofStatus(t *testing.T) {
+	approved := ReviewAssignment{Decision: ProofApproved}
+	changes := ReviewAssignment{Decision: ProofChangesRequested}
+	waiting := ReviewAssignment{}
+
+	tests := []struct {
+		name     string
+		required int
+		reviews  []ReviewAssignment
+		want     string
+	}{
+		{"no decision yet", 2, []ReviewAssignment{waiting, waiting}, ProofPending},
+		{"not enough approvals", 2, []ReviewAssignment{approved, waiting}, ProofPending},
+		{"enough approvals", 2, []ReviewAssignment{approved, approved, changes}, ProofApproved},
+		{"changes requested", 2, []ReviewAssignment{approved, changes}, ProofChangesRequested},
+		{"one approval at least", 0, []ReviewAssignment{waiting}, ProofPending},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ProofStatus(tt.required, tt.reviews); got != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package db
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// hasLabel reports if labels has label, ignoring case
+func hasLabel(labels []string, label string) bool {
+	for _, l := range labels {
+		if strings.EqualFold(strings.TrimSpace(l), strings.TrimSpace(label)) {
+			return true
+		}
+	}
+	return false
+}
+
+// RouteBounty is what rules, in their order, do to bounty. The rules in
+// applied and the disabled ones are skipped. The first matching rule with
+// an assignee, or a phase, sets it when the bounty has none, and every
+// matching rule adds the pubkeys it notifies
+func RouteBounty(rules []BountyRoutingRule, bounty NewBounty, applied []string) BountyRoutingPlan {
+	done := map[string]bool{}
+	for _, uuid := range applied {
+		done[uuid] = true
+	}
+
+	plan := BountyRoutingPlan{Rules: []string{}, NotifyPubkeys: []string{}}
+	notified := map[string]bool{}
+	for _, rule := range rules {
+		if rule.Disabled || done[rule.Uuid] || !hasLabel(bounty.Labels, rule.Label) {
+			continue
+		}
+		plan.Rules = append(plan.Rules, rule.Uuid)
+		if rule.Assignee != "" && bounty.Assignee == "" && plan.Assignee == "" {
+			plan.Assignee = rule.Assignee
+		}
+		if rule.PhaseUuid != "" && bounty.PhaseUuid == "" && plan.PhaseUuid == "" {
+			plan.PhaseUuid = rule.PhaseUuid
+		}
+		for _, pubkey := range rule.NotifyPubkeys {
+			if pubkey != "" && !notified[pubkey] {
+				notified[pubkey] = true
+				plan.NotifyPubkeys = append(plan.NotifyPubkeys, pubkey)
+			}
+		}
+	}
+	return plan
+}
+
+// GetBountyRoutingRules returns the rules of a workspace in the order they
+// apply, the oldest first
+func (db database) GetBountyRoutingRules(workspaceUuid string) ([]BountyRoutingRule, error) {
+	rules := []BountyRoutingRule{}
+	err := db.db.Where("workspace_uuid = ?", workspaceUuid).Order("id ASC").Find(&rules).Error
+	return rules, err
+}
+
+func (db database) GetBountyRoutingRule(uuid string) (BountyRoutingRule, error) {
+	rule := BountyRoutingRule{}
+	result := db.db.Where("uuid = ?", uuid).Find(&rule)
+	if result.Error != nil {
+		return rule, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return rule, errors.New("routing rule not found")
+	}
+	return rule, nil
+}
+
+// CreateOrEditBountyRoutingRule saves a rule, an edit keeps its workspace
+// and creator
+func (db database) CreateOrEditBountyRoutingRule(rule BountyRoutingRule) (BountyRoutingRule, error) {
+	if rule.Uuid == "" {
+		return rule, errors.New("rule uuid is required")
+	}
+	rule.Label = strings.TrimSpace(rule.Label)
+	if rule.NotifyPubkeys == nil {
+		rule.NotifyPubkeys = []string{}
+	}
+
+	now := time.Now()
+	rule.Updated = &now
+	existing := BountyRoutingRule{}
+	result := db.db.Where("uuid = ?", rule.Uuid).Find(&existing)
+	if result.Error != nil {
+		return rule, result.Error
+	}
+	if result.RowsAffected == 0 {
+		rule.Created = &now
+		return rule, db.db.Create(&rule).Error
+	}
+
+	rule.ID = existing.ID
+	rule.WorkspaceUuid = existing.WorkspaceUuid
+	rule.CreatedBy = existing.CreatedBy
+	rule.Created = existing.Created
+	return rule, db.db.Save(&rule).Error
+}
+
+func (db database) DeleteBountyRoutingRule(uuid string) error {
+	return db.db.Where("uuid = ?", uuid).Delete(&BountyRoutingRule{}).Error
+}
+
+// GetAppliedBountyRoutings returns the uuids of the rules already applied
+// to a bounty
+func (db database) GetAppliedBountyRoutings(bountyID uint) ([]string, error) {
+	uuids := []string{}
+	err := db.db.Model(&BountyRouting{}).Where("bounty_id = ?", bountyID).Pluck("rule_uuid", &uuids).Error
+	return uuids, err
+}
+
+// ApplyBountyRouting records the rules of plan as applied to a bounty and
+// makes their changes. The assignee and the phase are only set when the
+// bounty still has none, so a triage done meanwhile is kept. The assignee of
+// a bounty with terms is pending until they accept them. It returns
+// false when every rule of plan was already applied, by another instance
+func (db database) ApplyBountyRouting(bountyID uint, plan BountyRoutingPlan) (NewBounty, bool, error) {
+	bounty := NewBounty{}
+	applied := false
+	err := db.gormTransaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		routings := make([]BountyRouting, len(plan.Rules))
+		for i, uuid := range plan.Rules {
+			routings[i] = BountyRouting{BountyID: bountyID, RuleUuid: uuid, Created: &now}
+		}
+		if len(routings) > 0 {
+			result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&routings)
+			if result.Error != nil {
+				return result.Error
+			}
+			applied = result.RowsAffected > 0
+		}
+		if !applied {
+			return nil
+		}
+
+		if plan.Assignee != "" {
+			err := tx.Model(&NewBounty{}).Where("id = ? AND (assignee = '' OR assignee IS NULL) AND terms = ''", bountyID).
+				Updates(map[string]interface{}{"assignee": plan.Assignee, "assigned_date": &now, "updated": &now}).Error
+			if err != nil {
+				return err
+			}
+			// a bounty with terms waits for the assignee to accept them
+			err = tx.Model(&NewBounty{}).Where("id = ? AND (assignee = '' OR assignee IS NULL) AND pending_assignee = '' AND terms <> ''", bountyID).
+				Updates(map[string]interface{}{"pending_assignee": plan.Assignee, "updated": &now}).Error
+			if err != nil {
+				return err
+			}
+			if err := openContactExchange(tx, bountyID); err != nil {
+				return err
+			}
+		}
+		if plan.PhaseUuid != "" {
+			err := tx.Model(&NewBounty{}).Where("id = ? AND (phase_uuid = '' OR phase_uuid IS NULL)", bountyID).
+				Updates(map[string]interface{}{"phase_uuid": plan.PhaseUuid, "updated": &now}).Error
+			if err != nil {
+				return err
+			}
+		}
+		return tx.Where("id = ?", bountyID).First(&bounty).Error
+	})
+	return bounty, applied, err
+}
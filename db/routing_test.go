@@ -0,0 +1,39 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRouteBounty(t *testing.T) {
+	rules := []BountyRoutingRule{
+		{Uuid: "design", Label: "design", Assignee: "designer", NotifyPubkeys: []string{"lead"}},
+		{Uuid: "ui", Label: "UI", Assignee: "frontend", PhaseUuid: "ui-phase", NotifyPubkeys: []string{"lead", "pm"}},
+		{Uuid: "off", Label: "design", PhaseUuid: "other-phase", Disabled: true},
+	}
+
+	t.Run("should apply the first assignee and phase of the matching rules", func(t *testing.T) {
+		plan := RouteBounty(rules, NewBounty{Labels: []string{"Design", "ui "}}, nil)
+
+		assert.Equal(t, []string{"design", "ui"}, plan.Rules)
+		assert.Equal(t, "designer", plan.Assignee)
+		assert.Equal(t, "ui-phase", plan.PhaseUuid)
+		assert.Equal(t, []string{"lead", "pm"}, plan.NotifyPubkeys)
+	})
+
+	t.Run("should keep the assignee and phase a bounty has", func(t *testing.T) {
+		plan := RouteBounty(rules, NewBounty{Labels: []string{"ui"}, Assignee: "hunter", PhaseUuid: "phase"}, nil)
+
+		assert.Equal(t, []string{"ui"}, plan.Rules)
+		assert.Empty(t, plan.Assignee)
+		assert.Empty(t, plan.PhaseUuid)
+	})
+
+	t.Run("should skip the applied and disabled rules", func(t *testing.T) {
+		plan := RouteBounty(rules, NewBounty{Labels: []string{"design"}}, []string{"design"})
+
+		assert.Empty(t, plan.Rules)
+		assert.Empty(t, plan.NotifyPubkeys)
+	})
+}
@@ -0,0 +1,112 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// searchSource describes how a search index maps to its table. vector is
+// the tsvector matched against the query, the people and bounty vectors
+// are backed by the expression indexes of the add_search_indexes migration
+type searchSource struct {
+	table   string
+	id      string
+	title   string
+	img     string
+	visible string
+	vector  string
+	query   string
+}
+
+var searchSources = map[string]searchSource{
+	SearchIndexTribes: {
+		table:   "tribes",
+		id:      "uuid",
+		title:   "name",
+		img:     "img",
+		visible: "(deleted = 'f' OR deleted is null) AND (unlisted = 'f' OR unlisted is null) AND shadow_listed = false",
+		vector:  "tsv",
+		query:   "websearch_to_tsquery(?)",
+	},
+	SearchIndexPeople: {
+		table:   "people",
+		id:      "uuid",
+		title:   "owner_alias",
+		img:     "img",
+		visible: "(deleted = 'f' OR deleted is null) AND (unlisted = 'f' OR unlisted is null)",
+		vector:  peopleSearchVector,
+		query:   "websearch_to_tsquery('english', ?)",
+	},
+	SearchIndexBounties: {
+		table:   "bounty",
+		id:      "CAST(id AS text)",
+		title:   "title",
+		img:     "''",
+		visible: "show = true AND deleted_at IS NULL",
+		vector:  bountySearchVector,
+		query:   "websearch_to_tsquery('english', ?)",
+	},
+}
+
+const (
+	peopleSearchVector = "to_tsvector('english', coalesce(owner_alias, '') || ' ' || coalesce(unique_name, '') || ' ' || coalesce(description, ''))"
+	bountySearchVector = "to_tsvector('english', coalesce(title, '') || ' ' || coalesce(description, ''))"
+)
+
+func (s searchSource) columns(index string) string {
+	return fmt.Sprintf("'%s' AS index, %s AS id, %s AS title, description, %s AS img", index, s.id, s.title, s.img)
+}
+
+func sourceFor(index string) (searchSource, error) {
+	source, ok := searchSources[index]
+	if !ok {
+		return source, errors.New("unknown search index " + index)
+	}
+	return source, nil
+}
+
+// SearchDocuments runs a full text search on an index, best match first
+func (db database) SearchDocuments(ctx context.Context, index string, text string, limit int, offset int) ([]SearchDocument, error) {
+	docs := []SearchDocument{}
+	source, err := sourceFor(index)
+	if err != nil {
+		return docs, err
+	}
+
+	query := fmt.Sprintf(`SELECT %s, ts_rank(%s, q) AS rank
+		FROM %s, %s q
+		WHERE %s @@ q AND %s
+		ORDER BY rank DESC LIMIT ? OFFSET ?`,
+		source.columns(index), source.vector, source.table, source.query, source.vector, source.visible)
+	err = db.db.WithContext(ctx).Raw(query, text, limit, offset).Scan(&docs).Error
+	return docs, err
+}
+
+// GetSearchDocument returns a visible record of an index, found is false
+// when the record was deleted or hidden and should leave the index
+func (db database) GetSearchDocument(index string, id string) (SearchDocument, bool, error) {
+	doc := SearchDocument{}
+	source, err := sourceFor(index)
+	if err != nil {
+		return doc, false, err
+	}
+
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE %s = ? AND %s", source.columns(index), source.table, source.id, source.visible)
+	result := db.db.Raw(query, id).Scan(&doc)
+	return doc, result.RowsAffected > 0, result.Error
+}
+
+// GetSearchDocuments pages through the visible records of an index in id
+// order, for rebuilding an external index
+func (db database) GetSearchDocuments(index string, limit int, offset int) ([]SearchDocument, error) {
+	docs := []SearchDocument{}
+	source, err := sourceFor(index)
+	if err != nil {
+		return docs, err
+	}
+
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE %s ORDER BY %s LIMIT ? OFFSET ?", source.columns(index), source.table, source.visible, source.id)
+	err = db.db.Raw(query, limit, offset).Scan(&docs).Error
+	return docs, err
+}
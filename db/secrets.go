@@ -0,0 +1,77 @@
+package db
+
+import (
+	"errors"
+	"time"
+)
+
+// GetWorkspaceSecrets returns the secrets of a workspace by name, their
+// values stay sealed
+func (db database) GetWorkspaceSecrets(workspaceUuid string) ([]WorkspaceSecret, error) {
+	secrets := []WorkspaceSecret{}
+	err := db.db.Where("workspace_uuid = ?", workspaceUuid).Order("name ASC").Find(&secrets).Error
+	return secrets, err
+}
+
+func (db database) GetWorkspaceSecret(workspaceUuid string, name string) (WorkspaceSecret, error) {
+	secret := WorkspaceSecret{}
+	result := db.db.Where("workspace_uuid = ? AND name = ?", workspaceUuid, name).Find(&secret)
+	if result.Error != nil {
+		return secret, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return secret, errors.New("secret not found")
+	}
+	return secret, nil
+}
+
+// CreateOrEditWorkspaceSecret saves a secret by workspace and name. A
+// secret that exists gets the new sealed value as a rotation, it keeps its
+// uuid, kind and creator
+func (db database) CreateOrEditWorkspaceSecret(secret WorkspaceSecret) (WorkspaceSecret, error) {
+	if secret.WorkspaceUuid == "" || secret.Name == "" {
+		return secret, errors.New("secret workspace and name are required")
+	}
+
+	now := time.Now()
+	secret.Updated = &now
+	existing := WorkspaceSecret{}
+	result := db.db.Where("workspace_uuid = ? AND name = ?", secret.WorkspaceUuid, secret.Name).Find(&existing)
+	if result.Error != nil {
+		return secret, result.Error
+	}
+	if result.RowsAffected == 0 {
+		secret.Created = &now
+		return secret, db.db.Create(&secret).Error
+	}
+
+	secret.ID = existing.ID
+	secret.Uuid = existing.Uuid
+	secret.Kind = existing.Kind
+	secret.CreatedBy = existing.CreatedBy
+	secret.Created = existing.Created
+	secret.Rotated = &now
+	return secret, db.db.Save(&secret).Error
+}
+
+func (db database) DeleteWorkspaceSecret(workspaceUuid string, name string) error {
+	return db.db.Where("workspace_uuid = ? AND name = ?", workspaceUuid, name).Delete(&WorkspaceSecret{}).Error
+}
+
+// GetWorkspaceSecretsToRewrap returns the secrets of every workspace that
+// are not sealed with a master key
+func (db database) GetWorkspaceSecretsToRewrap(masterKeyId string) ([]WorkspaceSecret, error) {
+	secrets := []WorkspaceSecret{}
+	err := db.db.Where("master_key_id <> ?", masterKeyId).Order("id ASC").Find(&secrets).Error
+	return secrets, err
+}
+
+// RewrapWorkspaceSecret replaces the sealed data key of a secret, its
+// sealed value and rotation are unchanged
+func (db database) RewrapWorkspaceSecret(uuid string, dataKey []byte, masterKeyId string) error {
+	return db.db.Model(&WorkspaceSecret{}).Where("uuid = ?", uuid).Updates(map[string]interface{}{
+		"data_key":      dataKey,
+		"master_key_id": masterKeyId,
+		"updated":       time.Now(),
+	}).Error
+}
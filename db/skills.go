@@ -0,0 +1,65 @@
+package db
+
+import (
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// VerifiedSkills are the skills of a profile that paid counts at least
+// threshold times, lowercased. paid counts the paid bounties of the person
+// by lowercased coding language
+func VerifiedSkills(skills []string, paid map[string]int, threshold int) []string {
+	verified := []string{}
+	if threshold <= 0 {
+		return verified
+	}
+	seen := map[string]bool{}
+	for _, skill := range skills {
+		skill = strings.ToLower(strings.TrimSpace(skill))
+		if skill == "" || seen[skill] {
+			continue
+		}
+		seen[skill] = true
+		if paid[skill] >= threshold {
+			verified = append(verified, skill)
+		}
+	}
+	return verified
+}
+
+// UpdateVerifiedSkills verifies the skills of a profile against the paid
+// bounties of the person. It returns the person and if its verified skills
+// changed
+func (db database) UpdateVerifiedSkills(pubkey string, threshold int) (Person, bool, error) {
+	person := db.GetPersonByPubkey(pubkey)
+	if person.ID == 0 {
+		return person, false, nil
+	}
+
+	languages := []pq.StringArray{}
+	err := db.db.Model(&NewBounty{}).Where("assignee = ? AND paid = true", pubkey).
+		Pluck("coding_languages", &languages).Error
+	if err != nil {
+		return person, false, err
+	}
+	paid := map[string]int{}
+	for _, bountyLanguages := range languages {
+		tagged := map[string]bool{}
+		for _, language := range bountyLanguages {
+			language = strings.ToLower(strings.TrimSpace(language))
+			if language != "" && !tagged[language] {
+				tagged[language] = true
+				paid[language]++
+			}
+		}
+	}
+
+	verified := VerifiedSkills(personSkills(person), paid, threshold)
+	if strings.Join(verified, ",") == strings.Join(person.VerifiedSkills, ",") {
+		return person, false, nil
+	}
+	person.VerifiedSkills = verified
+	err = db.db.Model(&Person{}).Where("id = ?", person.ID).Update("verified_skills", person.VerifiedSkills).Error
+	return person, err == nil, err
+}
@@ -0,0 +1,16 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifiedSkills(t *testing.T) {
+	paid := map[string]int{"golang": 3, "rust": 1, "typescript": 2}
+
+	assert.Equal(t, []string{"golang", "typescript"}, VerifiedSkills([]string{"Golang", "Rust", "Typescript", "golang"}, paid, 2))
+	assert.Equal(t, []string{"golang"}, VerifiedSkills([]string{"Golang", "Typescript"}, paid, 3))
+	assert.Empty(t, VerifiedSkills([]string{"Golang"}, paid, 0))
+	assert.Empty(t, VerifiedSkills([]string{"Python"}, paid, 1))
+}
@@ -0,0 +1,108 @@
+package db
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/utils"
+)
+
+// Soft deleted kinds, as named in the admin routes
+const (
+	SoftDeleteTribes   = "tribes"
+	SoftDeleteChannels = "channels"
+	SoftDeleteBounties = "bounties"
+	SoftDeleteFeatures = "features"
+)
+
+// softDeletable is a model with a deleted_at column, gorm leaves its
+// deleted rows out of every model query. flag is set for the models that
+// also have the older deleted column the raw queries filter on, it is kept
+// in step with deleted_at
+type softDeletable struct {
+	model interface{}
+	table string
+	key   string
+	title string
+	flag  bool
+}
+
+var softDeletables = map[string]softDeletable{
+	SoftDeleteTribes:   {model: &Tribe{}, table: "tribes", key: "uuid", title: "name", flag: true},
+	SoftDeleteChannels: {model: &Channel{}, table: "channels", key: "id", title: "name", flag: true},
+	SoftDeleteBounties: {model: &NewBounty{}, table: "bounty", key: "id", title: "title"},
+	SoftDeleteFeatures: {model: &WorkspaceFeatures{}, table: "workspace_features", key: "uuid", title: "name"},
+}
+
+func softDeletableFor(kind string) (softDeletable, error) {
+	sd, ok := softDeletables[kind]
+	if !ok {
+		return sd, errors.New("unknown soft deleted kind " + kind)
+	}
+	return sd, nil
+}
+
+func IsSoftDeletable(kind string) bool {
+	_, ok := softDeletables[kind]
+	return ok
+}
+
+// SoftDelete marks a record deleted, it is gone from the lists until an
+// admin restores it or retention purges it
+func (db database) SoftDelete(kind string, id string) (bool, error) {
+	sd, err := softDeletableFor(kind)
+	if err != nil {
+		return false, err
+	}
+
+	updates := map[string]interface{}{"deleted_at": time.Now()}
+	if sd.flag {
+		updates["deleted"] = true
+	}
+	result := db.db.Model(sd.model).Where(sd.key+" = ?", id).Updates(updates)
+	return result.RowsAffected > 0, result.Error
+}
+
+// RestoreDeleted brings back a soft deleted record, found is false when
+// there is no deleted record with that id
+func (db database) RestoreDeleted(kind string, id string) (bool, error) {
+	sd, err := softDeletableFor(kind)
+	if err != nil {
+		return false, err
+	}
+
+	updates := map[string]interface{}{"deleted_at": nil}
+	if sd.flag {
+		updates["deleted"] = false
+	}
+	result := db.db.Unscoped().Model(sd.model).Where(sd.key+" = ? AND deleted_at IS NOT NULL", id).Updates(updates)
+	return result.RowsAffected > 0, result.Error
+}
+
+// GetSoftDeleted lists the deleted records of a kind, last deleted first
+func (db database) GetSoftDeleted(kind string, r *http.Request) ([]SoftDeleted, error) {
+	deleted := []SoftDeleted{}
+	sd, err := softDeletableFor(kind)
+	if err != nil {
+		return deleted, err
+	}
+
+	offset, limit, _, _, _ := utils.GetPaginationParams(r)
+	if r.URL.Query().Get("limit") == "" {
+		limit = 50
+	}
+
+	query := db.db.Unscoped().Table(sd.table).
+		Select("? AS kind, CAST("+sd.key+" AS text) AS id, "+sd.title+" AS title, deleted_at", kind).
+		Where("deleted_at IS NOT NULL")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if offset > 0 {
+		query = query.Offset(offset)
+	}
+
+	err = query.Order("deleted_at DESC").Scan(&deleted).Error
+	return deleted, err
+}
@@ -0,0 +1,310 @@
+package db
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/stakwork/sphinx-tribes/utils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// weights of the signals of a spam score, they add up to 100
+const (
+	newOwnerWeight  = 20.0
+	duplicateWeight = 35.0
+	deadFeedWeight  = 15.0
+	reportWeight    = 6.0
+	reportCap       = 5
+)
+
+const (
+	// owners with a profile younger than this are new
+	newOwnerDays = 7
+	// words of a shingle, descriptions with fewer words are not compared
+	shingleWords = 3
+	// tribes sharing a shingle with more tribes than this don't compare on
+	// it, it is a common phrase rather than a copy
+	shingleMaxTribes = 1000
+)
+
+// TribeSpamSignals is what is known of a tribe and its owner when it is
+// scored, see ScoreTribes
+type TribeSpamSignals struct {
+	People    []Person
+	Reports   map[string]int64
+	DeadFeeds map[string]bool
+}
+
+// shingles are the runs of shingleWords words of a text, lowercased and
+// without punctuation
+func shingles(text string) map[string]bool {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+	set := map[string]bool{}
+	for i := 0; i+shingleWords <= len(words); i++ {
+		set[strings.Join(words[i:i+shingleWords], " ")] = true
+	}
+	return set
+}
+
+type duplicate struct {
+	similarity float64
+	of         string
+}
+
+// duplicateDescriptions finds for each tribe the description of another
+// owner's tribe it is the most similar to, as the jaccard similarity of
+// their shingles. Only the tribes sharing a shingle are compared
+func duplicateDescriptions(tribes []Tribe) map[string]duplicate {
+	sets := make([]map[string]bool, len(tribes))
+	index := map[string][]int{}
+	for i, tribe := range tribes {
+		sets[i] = shingles(tribe.Description)
+		for shingle := range sets[i] {
+			index[shingle] = append(index[shingle], i)
+		}
+	}
+
+	duplicates := map[string]duplicate{}
+	for i, tribe := range tribes {
+		shared := map[int]int{}
+		for shingle := range sets[i] {
+			others := index[shingle]
+			if len(others) > shingleMaxTribes {
+				continue
+			}
+			for _, j := range others {
+				if j != i && tribes[j].OwnerPubKey != tribe.OwnerPubKey {
+					shared[j]++
+				}
+			}
+		}
+
+		best := duplicate{}
+		for j, count := range shared {
+			similarity := float64(count) / float64(len(sets[i])+len(sets[j])-count)
+			if similarity > best.similarity || (similarity == best.similarity && tribes[j].UUID < best.of) {
+				best = duplicate{similarity: similarity, of: tribes[j].UUID}
+			}
+		}
+		if best.of != "" {
+			duplicates[tribe.UUID] = best
+		}
+	}
+	return duplicates
+}
+
+// ScoreTribeSpam weighs the signals of a score into 0 to 100
+func ScoreTribeSpam(score TribeSpamScore) float64 {
+	total := 0.0
+	if score.OwnerAgeDays < newOwnerDays {
+		total += newOwnerWeight
+	}
+	total += duplicateWeight * score.DuplicateSimilarity
+	if score.DeadFeed {
+		total += deadFeedWeight
+	}
+	total += reportWeight * math.Min(float64(score.Reports), reportCap)
+	return math.Round(total*10) / 10
+}
+
+// ScoreTribes computes the spam score of each tribe at now
+func ScoreTribes(tribes []Tribe, signals TribeSpamSignals, now time.Time) []TribeSpamScore {
+	owners := map[string]Person{}
+	for _, p := range signals.People {
+		owners[p.OwnerPubKey] = p
+	}
+	duplicates := duplicateDescriptions(tribes)
+
+	scores := make([]TribeSpamScore, 0, len(tribes))
+	for _, tribe := range tribes {
+		score := TribeSpamScore{
+			TribeUuid:    tribe.UUID,
+			OwnerAgeDays: -1,
+			DeadFeed:     signals.DeadFeeds[tribe.UUID],
+			Reports:      signals.Reports[tribe.UUID],
+			Scored:       &now,
+		}
+		if owner, ok := owners[tribe.OwnerPubKey]; ok && owner.Created != nil {
+			score.OwnerAgeDays = int(now.Sub(*owner.Created).Hours() / 24)
+		}
+		if d, ok := duplicates[tribe.UUID]; ok {
+			score.DuplicateSimilarity = math.Round(d.similarity*100) / 100
+			score.DuplicateOf = d.of
+		}
+		score.Score = ScoreTribeSpam(score)
+		scores = append(scores, score)
+	}
+	return scores
+}
+
+// GetSpamScoringTribes returns the tribes that are scored, every tribe that
+// is not deleted
+func (db database) GetSpamScoringTribes() ([]Tribe, error) {
+	tribes := []Tribe{}
+	err := db.db.Select("uuid, owner_pub_key, name, description, feed_url, shadow_listed, spam_cleared").
+		Where("(deleted = 'f' OR deleted is null)").Find(&tribes).Error
+	return tribes, err
+}
+
+// GetTribeReportCounts counts the reports on each tribe that were not
+// dismissed
+func (db database) GetTribeReportCounts() (map[string]int64, error) {
+	rows := []struct {
+		TargetUuid string
+		Count      int64
+	}{}
+	err := db.db.Model(&Report{}).Select("target_uuid, count(*) AS count").
+		Where("target_type = ? AND status != ?", "tribe", ReportDismissed).
+		Group("target_uuid").Scan(&rows).Error
+	counts := map[string]int64{}
+	for _, row := range rows {
+		counts[row.TargetUuid] = row.Count
+	}
+	return counts, err
+}
+
+// SaveTribeSpamScore stores the latest score of a tribe, and shadow-lists
+// the tribe when the score reaches threshold. A tribe restored by an admin
+// stays listed, and a threshold of 0 shadow-lists nothing. It reports if
+// the tribe was just shadow-listed
+func (db database) SaveTribeSpamScore(score TribeSpamScore, threshold float64) (bool, error) {
+	shadowListed := false
+	err := db.gormTransaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.OnConflict{UpdateAll: true}).Create(&score).Error; err != nil {
+			return err
+		}
+		if threshold <= 0 || score.Score < threshold {
+			return nil
+		}
+		result := tx.Model(&Tribe{}).
+			Where("uuid = ? AND shadow_listed = false AND spam_cleared = false", score.TribeUuid).
+			Updates(map[string]interface{}{"shadow_listed": true, "updated": time.Now()})
+		shadowListed = result.RowsAffected > 0
+		return result.Error
+	})
+	return shadowListed, err
+}
+
+// GetShadowListedTribes is the spam review queue, the highest scores first,
+// with the pending appeal of each tribe
+func (db database) GetShadowListedTribes(r *http.Request) ([]ShadowListedTribe, error) {
+	offset, limit, _, _, _ := utils.GetPaginationParams(r)
+	if r.URL.Query().Get("limit") == "" {
+		limit = 50
+	}
+
+	tribes := []Tribe{}
+	query := db.forRequest(r).Model(&Tribe{}).
+		Joins("LEFT JOIN tribe_spam_scores ON tribe_spam_scores.tribe_uuid = tribes.uuid").
+		Where("tribes.shadow_listed = true AND (tribes.deleted = 'f' OR tribes.deleted is null)").
+		Order("tribe_spam_scores.score DESC NULLS LAST")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if offset > 0 {
+		query = query.Offset(offset)
+	}
+	if err := query.Select("tribes.*").Find(&tribes).Error; err != nil {
+		return nil, err
+	}
+
+	uuids := make([]string, len(tribes))
+	for i, tribe := range tribes {
+		uuids[i] = tribe.UUID
+	}
+	scores := []TribeSpamScore{}
+	appeals := []TribeAppeal{}
+	if len(uuids) > 0 {
+		if err := db.db.Where("tribe_uuid IN ?", uuids).Find(&scores).Error; err != nil {
+			return nil, err
+		}
+		if err := db.db.Where("tribe_uuid IN ? AND status = ?", uuids, AppealPending).Find(&appeals).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	queue := make([]ShadowListedTribe, len(tribes))
+	for i, tribe := range tribes {
+		queue[i].Tribe = tribe
+		for _, score := range scores {
+			if score.TribeUuid == tribe.UUID {
+				queue[i].Score = score
+			}
+		}
+		for a := range appeals {
+			if appeals[a].TribeUuid == tribe.UUID {
+				queue[i].Appeal = &appeals[a]
+			}
+		}
+	}
+	return queue, nil
+}
+
+// ReviewShadowListedTribe ends the shadow-listing of a tribe. Restoring it
+// lists it again for good, unlisting it hides it like a moderation report
+// does. The pending appeal of the tribe is closed with the review
+func (db database) ReviewShadowListedTribe(uuid string, review TribeSpamReview, reviewer string) (Tribe, error) {
+	tribe := Tribe{}
+	now := time.Now()
+	err := db.gormTransaction(func(tx *gorm.DB) error {
+		updates := map[string]interface{}{"shadow_listed": false, "updated": &now}
+		appealStatus := AppealRejected
+		if review.Action == SpamReviewRestore {
+			updates["spam_cleared"] = true
+			appealStatus = AppealApproved
+		} else {
+			updates["unlisted"] = true
+		}
+
+		result := tx.Model(&Tribe{}).Where("uuid = ? AND shadow_listed = true", uuid).Updates(updates)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("tribe is not shadow-listed")
+		}
+
+		if err := tx.Model(&TribeAppeal{}).Where("tribe_uuid = ? AND status = ?", uuid, AppealPending).
+			Updates(map[string]interface{}{
+				"status":      appealStatus,
+				"reviewed_by": reviewer,
+				"note":        review.Note,
+				"reviewed":    &now,
+			}).Error; err != nil {
+			return err
+		}
+		return tx.Where("uuid = ?", uuid).First(&tribe).Error
+	})
+	return tribe, err
+}
+
+func (db database) CreateTribeAppeal(appeal TribeAppeal) (TribeAppeal, error) {
+	if appeal.Uuid == "" {
+		return TribeAppeal{}, errors.New("appeal uuid is required")
+	}
+	now := time.Now()
+	appeal.Status = AppealPending
+	appeal.Created = &now
+	if err := db.db.Create(&appeal).Error; err != nil {
+		return TribeAppeal{}, err
+	}
+	return appeal, nil
+}
+
+// GetPendingTribeAppeal finds the open appeal of a tribe, a tribe has one at
+// a time
+func (db database) GetPendingTribeAppeal(tribeUuid string) (TribeAppeal, error) {
+	appeal := TribeAppeal{}
+	result := db.db.Model(&TribeAppeal{}).Where("tribe_uuid = ? AND status = ?", tribeUuid, AppealPending).First(&appeal)
+	if result.RowsAffected == 0 {
+		return appeal, errors.New("no appeal found")
+	}
+	return appeal, nil
+}
@@ -0,0 +1,59 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScoreTribes(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	longAgo := now.AddDate(-1, 0, 0)
+	yesterday := now.AddDate(0, 0, -1)
+	description := "Join the best bitcoin giveaway, double your sats in one hour guaranteed"
+
+	tribes := []Tribe{
+		{UUID: "original", OwnerPubKey: "veteran", Description: description},
+		{UUID: "copy", OwnerPubKey: "newcomer", Description: description + " now"},
+		{UUID: "same-owner", OwnerPubKey: "veteran", Description: description},
+		{UUID: "quiet", OwnerPubKey: "veteran", Description: "A tribe about gardening and growing tomatoes"},
+	}
+	signals := TribeSpamSignals{
+		People: []Person{
+			{OwnerPubKey: "veteran", Created: &longAgo},
+			{OwnerPubKey: "newcomer", Created: &yesterday},
+		},
+		Reports:   map[string]int64{"copy": 9},
+		DeadFeeds: map[string]bool{"copy": true},
+	}
+
+	scores := ScoreTribes(tribes, signals, now)
+
+	t.Run("should score every signal of a copied tribe", func(t *testing.T) {
+		copied := scores[1]
+		assert.Equal(t, 1, copied.OwnerAgeDays)
+		assert.Equal(t, "original", copied.DuplicateOf)
+		assert.Equal(t, 0.91, copied.DuplicateSimilarity)
+		assert.True(t, copied.DeadFeed)
+		assert.Equal(t, int64(9), copied.Reports)
+		assert.Equal(t, 96.9, copied.Score)
+	})
+
+	t.Run("should not compare the tribes of one owner", func(t *testing.T) {
+		assert.Equal(t, "copy", scores[0].DuplicateOf)
+		assert.Equal(t, "copy", scores[2].DuplicateOf)
+		assert.Equal(t, 366, scores[0].OwnerAgeDays)
+	})
+
+	t.Run("should leave a tribe without signals at 0", func(t *testing.T) {
+		assert.Equal(t, "", scores[3].DuplicateOf)
+		assert.Zero(t, scores[3].Score)
+	})
+
+	t.Run("should count an owner without a profile as new", func(t *testing.T) {
+		scores := ScoreTribes([]Tribe{{UUID: "orphan", OwnerPubKey: "unknown"}}, TribeSpamSignals{}, now)
+		assert.Equal(t, -1, scores[0].OwnerAgeDays)
+		assert.Equal(t, newOwnerWeight, scores[0].Score)
+	})
+}
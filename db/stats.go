@@ -0,0 +1,52 @@
+package db
+
+import (
+	"time"
+
+	"gorm.io/gorm/clause"
+)
+
+// users that logged in within this window count as active
+const activeUserWindow = 30 * 24 * time.Hour
+
+// AggregatePlatformStats computes the platform totals and saves them as the
+// snapshot of the day of at, replacing an earlier snapshot of that day
+func (db database) AggregatePlatformStats(at time.Time) (PlatformStats, error) {
+	day := time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, time.UTC)
+	stats := PlatformStats{Day: day, Updated: &at}
+
+	counts := []struct {
+		into  *int64
+		query string
+		args  []interface{}
+	}{
+		{&stats.Tribes, "SELECT COUNT(*) FROM tribes WHERE deleted = false OR deleted IS NULL", nil},
+		{&stats.People, "SELECT COUNT(*) FROM people WHERE deleted = false OR deleted IS NULL", nil},
+		{&stats.Workspaces, "SELECT COUNT(*) FROM workspaces WHERE deleted = false OR deleted IS NULL", nil},
+		{&stats.BountiesOpen, "SELECT COUNT(*) FROM bounty WHERE assignee = '' AND paid = false AND deleted_at IS NULL", nil},
+		{&stats.BountiesAssigned, "SELECT COUNT(*) FROM bounty WHERE assignee != '' AND completed = false AND paid = false AND deleted_at IS NULL", nil},
+		{&stats.BountiesCompleted, "SELECT COUNT(*) FROM bounty WHERE completed = true AND paid = false AND deleted_at IS NULL", nil},
+		{&stats.BountiesPaid, "SELECT COUNT(*) FROM bounty WHERE paid = true AND deleted_at IS NULL", nil},
+		{&stats.SatsPaid, "SELECT COALESCE(SUM(amount), 0) FROM payment_histories WHERE payment_type = ? AND status = true", []interface{}{Payment}},
+		{&stats.ActiveUsers, "SELECT COUNT(*) FROM people WHERE last_login >= ?", []interface{}{at.Add(-activeUserWindow).Unix()}},
+	}
+	for _, c := range counts {
+		if err := db.db.Raw(c.query, c.args...).Scan(c.into).Error; err != nil {
+			return PlatformStats{}, err
+		}
+	}
+
+	err := db.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "day"}},
+		UpdateAll: true,
+	}).Create(&stats).Error
+	return stats, err
+}
+
+// GetPlatformStats returns the daily snapshots since the given day, oldest
+// first
+func (db database) GetPlatformStats(since time.Time) ([]PlatformStats, error) {
+	stats := []PlatformStats{}
+	err := db.db.Model(&PlatformStats{}).Where("day >= ?", since.Format("2006-01-02")).Order("day ASC").Find(&stats).Error
+	return stats, err
+}
@@ -7,7 +7,6 @@ import (
 	"errors"
 	"time"
 
-	"github.com/gorilla/websocket"
 	"github.com/lib/pq"
 	"gorm.io/gorm"
 	"gorm.io/gorm/schema"
@@ -34,17 +33,96 @@ type Tribe struct {
 	Unlisted        bool           `json:"unlisted"`
 	Private         bool           `json:"private"`
 	Deleted         bool           `json:"deleted"`
-	AppURL          string         `json:"app_url"`
-	FeedURL         string         `json:"feed_url"`
-	SecondBrainUrl  string         `json:"second_brain_url"`
+	AppURL          string         `json:"app_url" validate:"omitempty,uri"`
+	FeedURL         string         `json:"feed_url" validate:"omitempty,uri"`
+	SecondBrainUrl  string         `json:"second_brain_url" validate:"omitempty,uri"`
 	FeedType        uint64         `json:"feed_type"`
 	LastActive      int64          `json:"last_active"`
 	Bots            string         `json:"bots"`
-	OwnerRouteHint  string         `json:"owner_route_hint"`
+	OwnerRouteHint  string         `json:"owner_route_hint" private:"true"`
 	Pin             string         `json:"pin"`
 	Preview         string         `json:"preview"`
 	ProfileFilters  string         `json:"profile_filters"` // "twitter,github"
 	Badges          pq.StringArray `gorm:"type:text[]" json:"badges"`
+	// a tribe with a spam score over the threshold is shadow-listed, left
+	// out of the listings and search until an admin reviews it. SpamCleared
+	// is set when an admin restored it, it isn't shadow-listed again
+	ShadowListed bool           `gorm:"not null;default:false" json:"shadow_listed,omitempty" private:"true"`
+	SpamCleared  bool           `gorm:"not null;default:false" json:"-"`
+	DeletedAt    gorm.DeletedAt `gorm:"index" json:"-"`
+	// the members who joined through the backend, MemberCount is the count
+	// the relay of the owner reports
+	JoinedMembers uint64 `gorm:"not null;default:0" json:"joined_members"`
+	// the slugs of the curated categories the tribe is filed under, kept in
+	// tribe_categories. A tribe edit without them leaves them as they are
+	Categories []string `gorm:"-" json:"categories,omitempty" validate:"max=3,dive,max=50"`
+}
+
+// Category is a node of the curated tree tribes are filed under, besides
+// their free-form tags. A category without a parent is at the top
+type Category struct {
+	ID       uint       `json:"id"`
+	Slug     string     `gorm:"uniqueIndex;not null" json:"slug" validate:"required,max=50,slug"`
+	Name     string     `gorm:"not null" json:"name" validate:"required,max=100"`
+	ParentID *uint      `gorm:"index" json:"parent_id"`
+	Position int        `gorm:"not null;default:0" json:"position"`
+	Created  *time.Time `json:"created"`
+	Updated  *time.Time `json:"updated"`
+	Children []Category `gorm:"-" json:"children,omitempty"`
+}
+
+// TribeCategory files a tribe under a category
+type TribeCategory struct {
+	ID         uint      `json:"-"`
+	TribeUuid  string    `gorm:"uniqueIndex:idx_tribe_categories_tribe;not null" json:"tribe_uuid"`
+	CategoryID uint      `gorm:"uniqueIndex:idx_tribe_categories_tribe;index;not null" json:"category_id"`
+	Category   *Category `gorm:"constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// CategoryRequest creates or edits the category of its slug, under the
+// category of ParentSlug or at the top when it is empty
+type CategoryRequest struct {
+	Slug       string `json:"slug" validate:"required,max=50,slug"`
+	Name       string `json:"name" validate:"required,max=100"`
+	ParentSlug string `json:"parent_slug" validate:"omitempty,max=50,slug"`
+	Position   int    `json:"position"`
+}
+
+// TribeMember is a person who joined a tribe through the backend. A kicked
+// member keeps its row with Kicked set, so they can't join again
+type TribeMember struct {
+	ID        uint       `json:"-"`
+	TribeUuid string     `gorm:"uniqueIndex:idx_tribe_members_member;not null" json:"tribe_uuid"`
+	PubKey    string     `gorm:"uniqueIndex:idx_tribe_members_member;not null" json:"pubkey"`
+	Alias     string     `gorm:"not null;default:''" json:"alias" validate:"max=50"`
+	Joined    *time.Time `json:"joined"`
+	Kicked    *time.Time `json:"kicked,omitempty"`
+}
+
+// TribeInvite is the payload of a tribe deep link. The group key is a
+// placeholder the app fills in from the tribe once it is opened, so
+// websites embedding the link never hold it
+type TribeInvite struct {
+	Action    string `json:"action"`
+	UUID      string `json:"uuid"`
+	Host      string `json:"host"`
+	GroupKey  string `json:"group_key"`
+	Expires   int64  `json:"expires"`
+	Signature string `json:"sig"`
+}
+
+// TribeInviteMeta is what a website needs to render a "Join tribe" button,
+// the OpenGraph tags of the tribe and the deep link that opens the app
+type TribeInviteMeta struct {
+	UUID        string            `json:"uuid"`
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	Img         string            `json:"img"`
+	MemberCount uint64            `json:"member_count"`
+	PriceToJoin int64             `json:"price_to_join"`
+	OpenGraph   map[string]string `json:"open_graph"`
+	DeepLink    string            `json:"deep_link"`
+	Invite      TribeInvite       `json:"invite"`
 }
 
 // Bot struct
@@ -112,15 +190,18 @@ type Person struct {
 	Updated          *time.Time     `json:"updated"`
 	Unlisted         bool           `json:"unlisted"`
 	Deleted          bool           `json:"deleted"`
-	LastLogin        int64          `json:"last_login"`
-	OwnerRouteHint   string         `json:"owner_route_hint"`
-	OwnerContactKey  string         `json:"owner_contact_key"`
+	LastLogin        int64          `json:"last_login" private:"true"`
+	OwnerRouteHint   string         `json:"owner_route_hint" private:"true"`
+	OwnerContactKey  string         `json:"owner_contact_key" private:"true"`
 	PriceToMeet      int64          `json:"price_to_meet"`
 	NewTicketTime    int64          `json:"new_ticket_time", gorm: "-:all"`
 	TwitterConfirmed bool           `json:"twitter_confirmed"`
 	ReferredBy       uint           `json:"referred_by"`
-	Extras           PropertyMap    `json:"extras", type: jsonb not null default '{}'::jsonb`
+	Extras           PropertyMap    `gorm:"type:jsonb not null default '{}'::jsonb" json:"extras" private:"email,phone"`
 	GithubIssues     PropertyMap    `json:"github_issues", type: jsonb not null default '{}'::jsonb`
+	Timezone         string         `gorm:"not null;default:''" json:"timezone" validate:"omitempty,timezone"`
+	Region           string         `gorm:"not null;default:''" json:"region" validate:"omitempty,region"`
+	VerifiedSkills   pq.StringArray `gorm:"type:text[];not null;default:'{}'" json:"verified_skills"`
 }
 
 type GormDataTypeInterface interface {
@@ -167,11 +248,211 @@ type Pagination struct {
 }
 
 type Channel struct {
+	ID        uint           `json:"id"`
+	TribeUUID string         `json:"tribe_uuid"`
+	Name      string         `json:"name"`
+	Created   *time.Time     `json:"created"`
+	Deleted   bool           `json:"deleted"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// PersonEndorsement is a person vouching for the work of another, for a
+// skill or in general. It adds to the reputation of the endorsee by the
+// reputation of the endorser
+type PersonEndorsement struct {
+	ID       uint       `json:"id"`
+	Endorser string     `gorm:"not null;index:idx_person_endorsements_pair" json:"endorser"`
+	Endorsee string     `gorm:"not null;index:idx_person_endorsements_pair;index" json:"endorsee"`
+	Skill    string     `gorm:"not null;default:''" json:"skill" validate:"max=50"`
+	Note     string     `gorm:"not null;default:''" json:"note" validate:"max=500"`
+	Created  *time.Time `gorm:"index" json:"created"`
+}
+
+// Reputation is the reputation of a person, from the bounties they were
+// paid for and the endorsements they received once weighted
+type Reputation struct {
+	Pubkey       string              `json:"pubkey"`
+	PaidBounties int64               `json:"paid_bounties"`
+	Endorsements float64             `json:"endorsements"`
+	Reputation   float64             `json:"reputation"`
+	Received     []PersonEndorsement `json:"received"`
+}
+
+// TribeAnnouncement is an update the owner of a tribe pins to it. It is
+// returned with the tribe until PinUntil, or until it is deleted when
+// PinUntil is nil
+type TribeAnnouncement struct {
+	ID        uint       `json:"id"`
+	Uuid      string     `gorm:"uniqueIndex;not null" json:"uuid"`
+	TribeUuid string     `gorm:"index;not null" json:"tribe_uuid"`
+	Title     string     `gorm:"not null" json:"title" validate:"required,max=120"`
+	Body      string     `gorm:"not null;default:''" json:"body" validate:"max=4000"`
+	PinUntil  *time.Time `json:"pin_until"`
+	Author    string     `gorm:"not null" json:"author"`
+	Created   *time.Time `json:"created"`
+	Updated   *time.Time `json:"updated"`
+}
+
+// TribeEvent is a meeting, stream or other event the owner of a tribe
+// schedules for its members
+type TribeEvent struct {
+	ID          uint       `json:"id"`
+	Uuid        string     `gorm:"uniqueIndex;not null" json:"uuid"`
+	TribeUuid   string     `gorm:"index;not null" json:"tribe_uuid"`
+	Title       string     `gorm:"not null" json:"title" validate:"required,max=120"`
+	Description string     `gorm:"not null;default:''" json:"description" validate:"max=4000"`
+	Location    string     `gorm:"not null;default:''" json:"location" validate:"max=500"`
+	StartsAt    *time.Time `gorm:"not null" json:"starts_at" validate:"required"`
+	EndsAt      *time.Time `json:"ends_at"`
+	Author      string     `gorm:"not null" json:"author"`
+	Created     *time.Time `json:"created"`
+	Updated     *time.Time `json:"updated"`
+}
+
+// TribeUpdates are the announcements and events of a tribe its feed is
+// built from
+type TribeUpdates struct {
+	Announcements []TribeAnnouncement `json:"announcements"`
+	Events        []TribeEvent        `json:"events"`
+}
+
+// WorkspaceSecret is a third-party secret of a workspace, such as a GitHub
+// token or a webhook secret. Only its sealed value is stored, see the vault
+// package, and it is never returned: Hint is the end of the value for long
+// enough values
+type WorkspaceSecret struct {
+	ID            uint       `json:"id"`
+	Uuid          string     `gorm:"uniqueIndex;not null" json:"uuid"`
+	WorkspaceUuid string     `gorm:"uniqueIndex:workspace_secret_name;not null" json:"workspace_uuid"`
+	Name          string     `gorm:"uniqueIndex:workspace_secret_name;not null" json:"name"`
+	Kind          string     `gorm:"not null" json:"kind"`
+	Ciphertext    []byte     `gorm:"not null" json:"-"`
+	DataKey       []byte     `gorm:"not null" json:"-"`
+	MasterKeyId   string     `gorm:"index;not null" json:"master_key_id"`
+	Hint          string     `gorm:"not null;default:''" json:"hint"`
+	CreatedBy     string     `gorm:"not null" json:"created_by"`
+	RotatedBy     string     `gorm:"not null;default:''" json:"rotated_by"`
+	Rotated       *time.Time `json:"rotated"`
+	Created       *time.Time `json:"created"`
+	Updated       *time.Time `json:"updated"`
+}
+
+// kinds of workspace secrets
+const (
+	SecretGithubToken   = "github_token"
+	SecretStakworkKey   = "stakwork_key"
+	SecretWebhookSecret = "webhook_secret"
+	SecretOther         = "other"
+)
+
+// WorkspaceSecretRequest stores a secret of a workspace, or rotates its
+// value when only Value is set
+type WorkspaceSecretRequest struct {
+	Name  string `json:"name" validate:"omitempty,max=64"`
+	Kind  string `json:"kind" validate:"omitempty,oneof=github_token stakwork_key webhook_secret other"`
+	Value string `json:"value" validate:"required,max=8192"`
+}
+
+// SecretRewrap is what rewrapping the secrets on the current master key
+// changed
+type SecretRewrap struct {
+	MasterKeyId string   `json:"master_key_id"`
+	Rewrapped   int      `json:"rewrapped"`
+	Failed      []string `json:"failed"`
+}
+
+// UploadSession is a resumable upload of a large file, such as a video
+// proof. The file is sent in chunks that each start where the previous one
+// ended, and it is assembled and stored once Received reaches Size
+type UploadSession struct {
+	ID          uint       `json:"id"`
+	Uuid        string     `gorm:"uniqueIndex;not null" json:"uuid"`
+	OwnerPubKey string     `gorm:"index;not null" json:"owner_pubkey"`
+	Filename    string     `gorm:"not null" json:"filename"`
+	ContentType string     `gorm:"not null" json:"content_type"`
+	Size        int64      `gorm:"not null" json:"size"`
+	Checksum    string     `gorm:"not null" json:"checksum"`
+	Received    int64      `gorm:"not null;default:0" json:"offset"`
+	Status      string     `gorm:"not null" json:"status"`
+	Url         string     `gorm:"not null;default:''" json:"url"`
+	Error       string     `gorm:"not null;default:''" json:"error"`
+	Expires     *time.Time `gorm:"index;not null" json:"expires"`
+	Created     *time.Time `json:"created"`
+	Updated     *time.Time `json:"updated"`
+}
+
+// statuses of an upload session
+const (
+	UploadPending    = "pending"
+	UploadAssembling = "assembling"
+	UploadComplete   = "complete"
+	UploadFailed     = "failed"
+)
+
+// UploadChunk is a received part of an upload, at Position bytes into the
+// file. The chunks are removed once the upload is assembled
+type UploadChunk struct {
+	ID          uint       `json:"id"`
+	SessionUuid string     `gorm:"uniqueIndex:upload_chunk_position;not null" json:"session_uuid"`
+	Position    int64      `gorm:"uniqueIndex:upload_chunk_position;not null" json:"position"`
+	Data        []byte     `gorm:"not null" json:"-"`
+	Created     *time.Time `json:"created"`
+}
+
+// UploadSessionRequest opens a resumable upload, Checksum is the hex
+// sha256 of the whole file
+type UploadSessionRequest struct {
+	Filename    string `json:"filename" validate:"required,max=255"`
+	ContentType string `json:"content_type" validate:"required,max=255"`
+	Size        int64  `json:"size" validate:"required,min=1"`
+	Checksum    string `json:"checksum" validate:"required,len=64,hexadecimal"`
+}
+
+// Proof statuses, a proof is approved once enough of its reviewers approve
+// it and changes are requested when one of them does before that
+const (
+	ProofPending          = "pending"
+	ProofApproved         = "approved"
+	ProofChangesRequested = "changes_requested"
+)
+
+// BountyProof is the work the assignee of a bounty submitted for review.
+// Required is how many of its reviewers have to approve it
+type BountyProof struct {
+	ID          uint               `json:"id"`
+	Uuid        string             `gorm:"uniqueIndex;not null" json:"uuid"`
+	BountyID    uint               `gorm:"index;not null" json:"bounty_id"`
+	SubmittedBy string             `gorm:"not null" json:"submitted_by"`
+	Description string             `json:"description"`
+	Url         string             `gorm:"not null;default:''" json:"url"`
+	Status      string             `gorm:"not null;default:'pending'" json:"status"`
+	Required    int                `gorm:"not null;default:1" json:"required_approvals"`
+	Created     *time.Time         `json:"created"`
+	Updated     *time.Time         `json:"updated"`
+	Reviews     []ReviewAssignment `gorm:"-" json:"reviews"`
+}
+
+// ReviewAssignment asks a reviewer to review a proof, Decision stays empty
+// until they do
+type ReviewAssignment struct {
 	ID        uint       `json:"id"`
-	TribeUUID string     `json:"tribe_uuid"`
-	Name      string     `json:"name"`
+	ProofUuid string     `gorm:"uniqueIndex:idx_review_assignments_proof_reviewer;not null" json:"proof_uuid"`
+	BountyID  uint       `gorm:"index;not null" json:"bounty_id"`
+	Reviewer  string     `gorm:"uniqueIndex:idx_review_assignments_proof_reviewer;index;not null" json:"reviewer"`
+	Decision  string     `gorm:"not null;default:''" json:"decision"`
+	Comment   string     `json:"comment"`
+	Decided   *time.Time `json:"decided"`
 	Created   *time.Time `json:"created"`
-	Deleted   bool       `json:"deleted"`
+}
+
+type BountyProofRequest struct {
+	Description string `json:"description" validate:"required,max=5000"`
+	Url         string `json:"url" validate:"omitempty,uri"`
+}
+
+type ProofReviewRequest struct {
+	Decision string `json:"decision" validate:"required,oneof=approved changes_requested"`
+	Comment  string `json:"comment" validate:"max=2000"`
 }
 
 type AssetTx struct {
@@ -225,6 +506,9 @@ type ConnectionCodes struct {
 	ConnectionString string     `json:"connection_string"`
 	IsUsed           bool       `json:"is_used"`
 	DateCreated      *time.Time `json:"date_created"`
+	Campaign         string     `gorm:"index" json:"campaign"`
+	OwnerPubKey      string     `json:"owner_pubkey"`
+	DateUsed         *time.Time `json:"date_used"`
 }
 
 type ConnectionCodesShort struct {
@@ -232,6 +516,15 @@ type ConnectionCodesShort struct {
 	DateCreated      *time.Time `json:"date_created"`
 }
 
+// ConnectionCodeStats is the redemption funnel of a campaign, the codes
+// created without a campaign are counted under ""
+type ConnectionCodeStats struct {
+	Campaign     string     `json:"campaign"`
+	Created      int64      `json:"created"`
+	Redeemed     int64      `json:"redeemed"`
+	LastRedeemed *time.Time `json:"last_redeemed"`
+}
+
 type InvoiceRequest struct {
 	Amount          string `json:"amount"`
 	Memo            string `json:"memo"`
@@ -352,44 +645,16 @@ type YoutubeDownload struct {
 	YoutubeUrls []string `json:"youtube_urls"`
 }
 
-type Client struct {
-	Host string
-	Conn *websocket.Conn
+// SocketWriter sends a message on a websocket connection, it is the
+// websocket.Client of the connection, which queues the message for its
+// writer so handlers never write to the connection themselves
+type SocketWriter interface {
+	WriteJSON(v interface{}) error
 }
 
-type Bounty struct {
-	ID                      uint           `json:"id"`
-	OwnerID                 string         `json:"owner_id"`
-	Paid                    bool           `json:"paid"`
-	Show                    bool           `gorm:"default:false" json:"show"`
-	Completed               bool           `gorm:"default:false" json:"completed"`
-	Type                    string         `json:"type"`
-	Award                   string         `json:"award"`
-	AssignedHours           uint8          `json:"assigned_hours"`
-	BountyExpires           string         `json:"bounty_expires"`
-	CommitmentFee           uint64         `json:"commitment_fee"`
-	Price                   uint           `json:"price"`
-	Title                   string         `json:"title"`
-	Tribe                   string         `json:"tribe"`
-	Assignee                string         `json:"assignee"`
-	TicketUrl               string         `json:"ticket_url"`
-	OrgUuid                 string         `json:"org_uuid"`
-	Description             string         `json:"description"`
-	WantedType              string         `json:"wanted_type"`
-	Deliverables            string         `json:"deliverables"`
-	GithubDescription       bool           `json:"github_description"`
-	OneSentenceSummary      string         `json:"one_sentence_summary"`
-	EstimatedSessionLength  string         `json:"estimated_session_length"`
-	EstimatedCompletionDate string         `json:"estimated_completion_date"`
-	Created                 int64          `json:"created"`
-	Updated                 *time.Time     `json:"updated"`
-	AssignedDate            *time.Time     `json:"assigned_date,omitempty"`
-	CompletionDate          *time.Time     `json:"completion_date,omitempty"`
-	MarkAsPaidDate          *time.Time     `json:"mark_as_paid_date,omitempty"`
-	PaidDate                *time.Time     `json:"paid_date,omitempty"`
-	CodingLanguages         pq.StringArray `gorm:"type:text[];not null default:'[]'" json:"coding_languages"`
-	PhaseUuid               *string        `json:"phase_uuid"`
-	PhasePriority           *int           `json:"phase_priority"`
+type Client struct {
+	Host   string
+	Writer SocketWriter
 }
 
 // Todo: Change back to Bounty
@@ -399,19 +664,20 @@ type NewBounty struct {
 	Paid                    bool           `json:"paid"`
 	Show                    bool           `gorm:"default:false" json:"show"`
 	Completed               bool           `gorm:"default:false" json:"completed"`
-	Type                    string         `json:"type"`
+	Type                    string         `json:"type" validate:"required"`
 	Award                   string         `json:"award"`
 	AssignedHours           uint8          `json:"assigned_hours"`
 	BountyExpires           string         `json:"bounty_expires"`
 	CommitmentFee           uint64         `json:"commitment_fee"`
 	Price                   uint           `json:"price"`
-	Title                   string         `json:"title"`
+	Title                   string         `json:"title" validate:"required"`
 	Tribe                   string         `json:"tribe"`
 	Assignee                string         `json:"assignee"`
 	TicketUrl               string         `json:"ticket_url"`
+	PullRequestUrl          string         `gorm:"not null;default:''" json:"pull_request_url" validate:"omitempty,uri"`
 	OrgUuid                 string         `gorm:"-" json:"org_uuid"`
 	WorkspaceUuid           string         `json:"workspace_uuid"`
-	Description             string         `json:"description"`
+	Description             string         `json:"description" validate:"required"`
 	WantedType              string         `json:"wanted_type"`
 	Deliverables            string         `json:"deliverables"`
 	GithubDescription       bool           `json:"github_description"`
@@ -427,6 +693,179 @@ type NewBounty struct {
 	CodingLanguages         pq.StringArray `gorm:"type:text[];not null default:'[]'" json:"coding_languages"`
 	PhaseUuid               string         `json:"phase_uuid"`
 	PhasePriority           int            `json:"phase_priority"`
+	Labels                  pq.StringArray `gorm:"type:text[];not null;default:'{}'" json:"labels"`
+	Terms                   string         `gorm:"not null;default:''" json:"terms" validate:"max=20000"`
+	PendingAssignee         string         `gorm:"not null;default:''" json:"pending_assignee"`
+	ShowPaymentAmount       bool           `gorm:"not null;default:false" json:"show_payment_amount"`
+	Version                 int            `gorm:"not null;default:1" json:"version"`
+	ViewCount               int64          `gorm:"not null;default:0;->" json:"view_count"`
+	UniqueViewers           int64          `gorm:"not null;default:0;->" json:"unique_viewers"`
+	DeletedAt               gorm.DeletedAt `gorm:"index" json:"-"`
+	Links                   []Mention      `gorm:"-" json:"links,omitempty"`
+}
+
+// BountyMigrationReport is what a run of MigrateLegacyBounties did, or
+// would do on a dry run
+type BountyMigrationReport struct {
+	DryRun bool `json:"dry_run"`
+	// the bounties found in the wanted lists of profiles
+	LegacyBounties  int `json:"legacy_bounties"`
+	Migrated        int `json:"migrated"`
+	AlreadyMigrated int `json:"already_migrated"`
+	// the entries without a title or a created time
+	Skipped int `json:"skipped"`
+	// the rows whose NULL columns were set, per column
+	Backfilled map[string]int64          `json:"backfilled"`
+	Mismatches []BountyMigrationMismatch `json:"mismatches"`
+}
+
+// BountyMigrationMismatch is a legacy bounty whose row differs from it
+type BountyMigrationMismatch struct {
+	BountyID uint     `json:"bounty_id"`
+	OwnerID  string   `json:"owner_id"`
+	Created  int64    `json:"created"`
+	Fields   []string `json:"fields"`
+}
+
+// BountyTermsAcceptance is the signed acknowledgment of the terms of a
+// bounty by the person assigned to it. Signature signs the message of
+// BountyTermsMessage for TermsHash
+type BountyTermsAcceptance struct {
+	ID        uint       `json:"id"`
+	BountyID  uint       `gorm:"index;not null" json:"bounty_id"`
+	Pubkey    string     `gorm:"not null" json:"pubkey"`
+	TermsHash string     `gorm:"not null" json:"terms_hash"`
+	Signature string     `gorm:"not null" json:"signature"`
+	Created   *time.Time `json:"created"`
+}
+
+// BountyTermsAcceptRequest accepts the terms of a bounty. TermsHash is the
+// hash of the terms that were read, when it is set they must not have
+// changed since
+type BountyTermsAcceptRequest struct {
+	TermsHash string `json:"terms_hash"`
+	Signature string `json:"signature" validate:"required"`
+}
+
+// BountyTerms are the terms of a bounty, the message to sign to accept them
+// and the acceptances so far
+type BountyTerms struct {
+	BountyID        uint                    `json:"bounty_id"`
+	Terms           string                  `json:"terms"`
+	TermsHash       string                  `json:"terms_hash"`
+	Message         string                  `json:"message"`
+	PendingAssignee string                  `json:"pending_assignee"`
+	Acceptances     []BountyTermsAcceptance `json:"acceptances"`
+}
+
+// WidgetConfig is what the bounty widget of a workspace shows: the Fields
+// of its open bounties, out of the widget fields, and at most Limit of them
+type WidgetConfig struct {
+	Fields []string `json:"fields"`
+	Limit  int      `json:"limit" validate:"omitempty,min=1,max=50"`
+}
+
+// WidgetLink is a signed widget config and the urls that embed it. Config
+// is the base64 JSON of the WidgetConfig, Signature signs it for the
+// workspace so the embedding website can't change it
+type WidgetLink struct {
+	Config    string `json:"config"`
+	Signature string `json:"sig"`
+	JsonUrl   string `json:"json_url"`
+	HtmlUrl   string `json:"html_url"`
+	Embed     string `json:"embed"`
+}
+
+// BountyViewer records that a viewer saw a bounty, to count its unique
+// viewers. Viewer is a hash of their pubkey or address
+type BountyViewer struct {
+	BountyID uint       `gorm:"primaryKey;autoIncrement:false" json:"bounty_id"`
+	Viewer   string     `gorm:"primaryKey" json:"viewer"`
+	Created  *time.Time `json:"created"`
+}
+
+// BountyViews are the views of a bounty buffered since the last flush, and
+// the viewers that made them
+type BountyViews struct {
+	Views   int64
+	Viewers []string
+}
+
+// BountyRoutingRule routes the bounties of a workspace that have Label when
+// they are created or edited. Assignee becomes the assignee of a bounty
+// without one, PhaseUuid the phase of a bounty without one, and
+// NotifyPubkeys are notified of the bounty. A rule applies once to a bounty
+type BountyRoutingRule struct {
+	ID            uint           `json:"id"`
+	Uuid          string         `gorm:"uniqueIndex;not null" json:"uuid"`
+	WorkspaceUuid string         `gorm:"index;not null" json:"workspace_uuid"`
+	Name          string         `json:"name" validate:"max=60"`
+	Label         string         `gorm:"not null" json:"label" validate:"required,max=40"`
+	Assignee      string         `gorm:"not null;default:''" json:"assignee"`
+	PhaseUuid     string         `gorm:"not null;default:''" json:"phase_uuid"`
+	NotifyPubkeys pq.StringArray `gorm:"type:text[];not null;default:'{}'" json:"notify_pubkeys"`
+	Disabled      bool           `gorm:"not null;default:false" json:"disabled"`
+	CreatedBy     string         `json:"created_by"`
+	Created       *time.Time     `json:"created"`
+	Updated       *time.Time     `json:"updated"`
+}
+
+// BountyRouting records that a rule was applied to a bounty
+type BountyRouting struct {
+	BountyID uint       `gorm:"primaryKey;autoIncrement:false" json:"bounty_id"`
+	RuleUuid string     `gorm:"primaryKey" json:"rule_uuid"`
+	Created  *time.Time `json:"created"`
+}
+
+// BountyRoutingPlan is what the routing rules do to a bounty, the uuids of
+// the rules that match and the changes they make
+type BountyRoutingPlan struct {
+	Rules         []string `json:"rules"`
+	Assignee      string   `json:"assignee,omitempty"`
+	PhaseUuid     string   `json:"phase_uuid,omitempty"`
+	NotifyPubkeys []string `json:"notify_pubkeys"`
+}
+
+// BountyRoutingTest is a bounty to try the routing rules on, the bounty of
+// BountyID or a draft with the other fields
+type BountyRoutingTest struct {
+	BountyID  uint     `json:"bounty_id"`
+	Labels    []string `json:"labels"`
+	Assignee  string   `json:"assignee"`
+	PhaseUuid string   `json:"phase_uuid"`
+}
+
+type BountyDescriptionRequest struct {
+	Prompt        string `json:"prompt"`
+	WorkspaceUuid string `json:"workspace_uuid"`
+	FeatureUuid   string `json:"feature_uuid"`
+	PhaseUuid     string `json:"phase_uuid"`
+}
+
+type BountyDescriptionContext struct {
+	Prompt      string             `json:"prompt"`
+	Workspace   WorkspaceShort     `json:"workspace"`
+	Mission     string             `json:"mission"`
+	Tactics     string             `json:"tactics"`
+	Feature     *WorkspaceFeatures `json:"feature,omitempty"`
+	Phase       *FeaturePhase      `json:"phase,omitempty"`
+	RequestedBy string             `json:"requested_by"`
+}
+
+// StakworkPreview is a call to Stakwork as it would be made, with the
+// secrets of its headers redacted
+type StakworkPreview struct {
+	Url     string            `json:"url"`
+	Method  string            `json:"method"`
+	Headers map[string]string `json:"headers"`
+	Payload interface{}       `json:"payload"`
+}
+
+type BountyDescriptionDraft struct {
+	Title              string   `json:"title"`
+	Description        string   `json:"description"`
+	AcceptanceCriteria []string `json:"acceptance_criteria"`
+	Estimate           string   `json:"estimate"`
 }
 
 type BountyOwners struct {
@@ -497,7 +936,7 @@ type Organization struct {
 	Show         bool       `json:"show"`
 	Deleted      bool       `gorm:"default:false" json:"deleted"`
 	BountyCount  int64      `json:"bounty_count,omitempty"`
-	Budget       uint       `json:"budget,omitempty"`
+	Budget       uint       `json:"budget,omitempty" private:"true"`
 	Website      string     `json:"website" validate:"omitempty,uri"`
 	Github       string     `json:"github" validate:"omitempty,uri"`
 	Description  string     `json:"description" validate:"omitempty,lte=120"`
@@ -510,7 +949,7 @@ type Organization struct {
 type Workspace struct {
 	ID           uint       `json:"id"`
 	Uuid         string     `json:"uuid"`
-	Name         string     `gorm:"unique;not null" json:"name"`
+	Name         string     `gorm:"unique;not null" json:"name" validate:"required,max=20"`
 	OwnerPubKey  string     `json:"owner_pubkey"`
 	Img          string     `json:"img"`
 	Created      *time.Time `json:"created"`
@@ -518,7 +957,7 @@ type Workspace struct {
 	Show         bool       `json:"show"`
 	Deleted      bool       `gorm:"default:false" json:"deleted"`
 	BountyCount  int64      `json:"bounty_count,omitempty"`
-	Budget       uint       `json:"budget,omitempty"`
+	Budget       uint       `json:"budget,omitempty" private:"true"`
 	Website      string     `json:"website" validate:"omitempty,uri"`
 	Github       string     `json:"github" validate:"omitempty,uri"`
 	Description  string     `json:"description" validate:"omitempty,lte=120"`
@@ -526,6 +965,168 @@ type Workspace struct {
 	Tactics      string     `json:"tactics"`
 	SchematicUrl string     `json:"schematic_url"`
 	SchematicImg string     `json:"schematic_img"`
+	// pay the bounties as soon as their completion is accepted, up to
+	// AutoPayCap sats when it is set
+	AutoPay    bool `gorm:"not null;default:false" json:"auto_pay"`
+	AutoPayCap uint `gorm:"not null;default:0" json:"auto_pay_cap" private:"true"`
+	// the definition of done, the conditions a bounty must meet before its
+	// completion is accepted
+	DoneRequiresChecklist   bool `gorm:"not null;default:false" json:"done_requires_checklist"`
+	DoneRequiresPullRequest bool `gorm:"not null;default:false" json:"done_requires_pull_request"`
+	// the plan the quotas of the workspace come from, empty is the default
+	// plan of the config
+	Plan string `gorm:"not null;default:''" json:"plan"`
+	// the reviewers of the proofs submitted on its bounties, and how many
+	// of them have to approve a proof before its bounty can be completed
+	DefaultReviewers  pq.StringArray `gorm:"type:text[];not null;default:'{}'" json:"default_reviewers"`
+	RequiredApprovals uint           `gorm:"not null;default:0" json:"required_approvals"`
+	// a bounty of the workspace can't be paid, or marked paid, before one
+	// of its proofs is approved
+	PaymentRequiresProof bool `gorm:"not null;default:false" json:"payment_requires_proof"`
+	// the most AI submissions the workspace makes in a month, 0 is no cap
+	AiMonthlyCap uint `gorm:"not null;default:0" json:"ai_monthly_cap"`
+	// the IANA time zone the weeks of the digests, the deadlines and the
+	// days of the metrics are read in, UTC when empty, and the BCP 47
+	// language tag its members read them in
+	Timezone string `gorm:"not null;default:''" json:"timezone" validate:"omitempty,timezone"`
+	Locale   string `gorm:"not null;default:''" json:"locale" validate:"omitempty,locale"`
+	// the GitHub organization whose members and contributors are suggested
+	// as members of the workspace
+	GithubOrg string `gorm:"not null;default:''" json:"github_org" validate:"omitempty,max=39"`
+	// a workspace created by a new or busy pubkey is flagged, it is left out
+	// of the directory and receives no deposits until an admin reviews it
+	Flagged     bool           `gorm:"not null;default:false" json:"flagged"`
+	FlagReasons pq.StringArray `gorm:"type:text[];not null;default:'{}'" json:"flag_reasons"`
+	// when and how the owner verified the workspace, see
+	// WORKSPACE_VERIFICATION
+	Verified           *time.Time `json:"verified"`
+	VerificationMethod string     `gorm:"not null;default:''" json:"verification_method"`
+}
+
+// the quotas of a workspace plan
+const (
+	QuotaActiveBounties      = "active_bounties"
+	QuotaStakworkSubmissions = "stakwork_submissions"
+	QuotaStorage             = "storage"
+)
+
+// WorkspaceUsageCount is how many times a workspace used a metered quota in
+// a month, Period is the month as 2006-01
+type WorkspaceUsageCount struct {
+	WorkspaceUuid string     `gorm:"primaryKey" json:"workspace_uuid"`
+	Quota         string     `gorm:"primaryKey" json:"quota"`
+	Period        string     `gorm:"primaryKey" json:"period"`
+	Count         int64      `gorm:"not null;default:0" json:"count"`
+	Updated       *time.Time `json:"updated"`
+}
+
+// WorkspaceQuota is how much of a quota a workspace used, a nil Limit is
+// unlimited. Period is set for the quotas counted per month
+type WorkspaceQuota struct {
+	Quota  string `json:"quota"`
+	Unit   string `json:"unit"`
+	Used   int64  `json:"used"`
+	Limit  *int64 `json:"limit"`
+	Period string `json:"period,omitempty"`
+}
+
+// WorkspaceUsage is the plan of a workspace and how much of its quotas
+// it used
+type WorkspaceUsage struct {
+	WorkspaceUuid string           `json:"workspace_uuid"`
+	Plan          string           `json:"plan"`
+	Quotas        []WorkspaceQuota `json:"quotas"`
+}
+
+// WorkspacePlanRequest sets the plan of a workspace, empty is the default
+// plan
+type WorkspacePlanRequest struct {
+	Plan string `json:"plan"`
+}
+
+// the AI features that submit to Stakwork, and the statuses of a submission
+const (
+	AiHiveChat          = "hive_chat"
+	AiWorkspaceBrief    = "workspace_brief"
+	AiBountyDescription = "bounty_description"
+
+	AiSubmissionSent   = "sent"
+	AiSubmissionFailed = "failed"
+)
+
+// AiSubmission is one submission of a workspace to Stakwork. Reference is
+// what its webhook answers with, Cost is set when the webhook reports the
+// credits the run used
+type AiSubmission struct {
+	ID            uint       `json:"id"`
+	WorkspaceUuid string     `gorm:"not null;index:idx_ai_submissions_workspace_created" json:"workspace_uuid"`
+	Kind          string     `gorm:"not null" json:"kind"`
+	WorkflowId    string     `gorm:"not null;default:''" json:"workflow_id"`
+	Reference     string     `gorm:"not null;default:'';index" json:"reference"`
+	Status        string     `gorm:"not null" json:"status"`
+	Error         string     `gorm:"not null;default:''" json:"error,omitempty"`
+	DurationMs    int64      `gorm:"not null;default:0" json:"duration_ms"`
+	Cost          *float64   `json:"cost"`
+	Created       *time.Time `gorm:"index:idx_ai_submissions_workspace_created" json:"created"`
+}
+
+// AiWorkflowUsage is what a workspace submitted to one workflow in a month,
+// Cost is nil when no run reported one
+type AiWorkflowUsage struct {
+	Kind        string   `json:"kind"`
+	WorkflowId  string   `json:"workflow_id"`
+	Submissions int64    `json:"submissions"`
+	Failed      int64    `json:"failed"`
+	DurationMs  int64    `json:"duration_ms"`
+	Cost        *float64 `json:"cost"`
+}
+
+// AiUsage is what a workspace submitted to Stakwork in a month, Month is
+// 2006-01. A MonthlyCap of 0 is no cap
+type AiUsage struct {
+	WorkspaceUuid string            `json:"workspace_uuid"`
+	Month         string            `json:"month"`
+	Submissions   int64             `json:"submissions"`
+	Failed        int64             `json:"failed"`
+	DurationMs    int64             `json:"duration_ms"`
+	Cost          *float64          `json:"cost"`
+	MonthlyCap    uint              `json:"monthly_cap"`
+	Workflows     []AiWorkflowUsage `json:"workflows"`
+}
+
+// AiCapRequest sets the monthly cap of AI submissions of a workspace, 0
+// removes it
+type AiCapRequest struct {
+	MonthlyCap uint `json:"monthly_cap"`
+}
+
+// DefinitionOfDone is the part of a workspace that sets the conditions of a
+// completed bounty
+type DefinitionOfDone struct {
+	RequiresChecklist   bool `json:"done_requires_checklist"`
+	RequiresPullRequest bool `json:"done_requires_pull_request"`
+}
+
+// ReviewerSettings sets the default reviewers of a workspace and how many
+// of them have to approve a proof, 0 doesn't require approvals, and if the
+// bounties need an approved proof to be paid
+type ReviewerSettings struct {
+	Reviewers            []string `json:"default_reviewers" validate:"max=20,dive,required"`
+	RequiredApprovals    uint     `json:"required_approvals"`
+	PaymentRequiresProof bool     `json:"payment_requires_proof"`
+}
+
+// FeatureReviewersRequest sets the default reviewers of a feature, none
+// falls back to the reviewers of its workspace
+type FeatureReviewersRequest struct {
+	Reviewers []string `json:"default_reviewers" validate:"max=20,dive,required"`
+}
+
+// UnmetCondition is a condition of the definition of done that a bounty
+// doesn't meet
+type UnmetCondition struct {
+	Condition string `json:"condition"`
+	Message   string `json:"message"`
 }
 
 type WorkspaceShort struct {
@@ -561,43 +1162,199 @@ type WorkspaceUsersData struct {
 type WorkspaceRepositories struct {
 	ID            uint       `json:"id"`
 	Uuid          string     `gorm:"not null" json:"uuid"`
-	WorkspaceUuid string     `gorm:"not null" json:"workspace_uuid"`
-	Name          string     `gorm:"not null" json:"name"`
-	Url           string     `json:"url"`
+	WorkspaceUuid string     `gorm:"not null" json:"workspace_uuid" validate:"required"`
+	Name          string     `gorm:"not null" json:"name" validate:"required"`
+	Url           string     `json:"url" validate:"omitempty,uri"`
 	Created       *time.Time `json:"created"`
 	Updated       *time.Time `json:"updated"`
 	CreatedBy     string     `json:"created_by"`
 	UpdatedBy     string     `json:"updated_by"`
 }
 
+// Workspace artifact kinds
+const (
+	ArtifactDiagram    = "diagram"
+	ArtifactSchema     = "schema"
+	ArtifactAttachment = "attachment"
+	ArtifactDocument   = "document"
+
+	// the type of the chat context tags that point to an artifact
+	ArtifactContextTag = "artifact"
+)
+
+// WorkspaceArtifact is a document kept as context of a workspace, like an
+// architecture diagram, a schema doc or an attachment of a feature brief.
+// It holds its latest version, every edit is also kept as a
+// WorkspaceArtifactVersion
+type WorkspaceArtifact struct {
+	ID            uint           `json:"id"`
+	Uuid          string         `gorm:"not null;unique" json:"uuid"`
+	WorkspaceUuid string         `gorm:"not null;index" json:"workspace_uuid" validate:"required"`
+	FeatureUuid   string         `gorm:"index" json:"feature_uuid"`
+	Kind          string         `gorm:"not null" json:"kind" validate:"required,oneof=diagram schema attachment document"`
+	Name          string         `gorm:"not null" json:"name" validate:"required"`
+	Content       string         `json:"content"`
+	Url           string         `json:"url" validate:"omitempty,uri"`
+	Tags          pq.StringArray `gorm:"type:text[]" json:"tags"`
+	Version       int            `json:"version"`
+	Created       *time.Time     `json:"created"`
+	Updated       *time.Time     `json:"updated"`
+	CreatedBy     string         `json:"created_by"`
+	UpdatedBy     string         `json:"updated_by"`
+}
+
+type WorkspaceArtifactVersion struct {
+	ID           uint           `json:"id"`
+	ArtifactUuid string         `gorm:"not null;uniqueIndex:idx_artifact_versions" json:"artifact_uuid"`
+	Version      int            `gorm:"not null;uniqueIndex:idx_artifact_versions" json:"version"`
+	Name         string         `json:"name"`
+	Content      string         `json:"content"`
+	Url          string         `json:"url"`
+	Tags         pq.StringArray `gorm:"type:text[]" json:"tags"`
+	Created      *time.Time     `json:"created"`
+	CreatedBy    string         `json:"created_by"`
+}
+
 type WorkspaceFeatures struct {
-	ID                     uint       `json:"id"`
-	Uuid                   string     `gorm:"not null" json:"uuid"`
-	WorkspaceUuid          string     `gorm:"not null" json:"workspace_uuid"`
-	Name                   string     `gorm:"not null" json:"name"`
-	Brief                  string     `json:"brief"`
-	Requirements           string     `json:"requirements"`
-	Architecture           string     `json:"architecture"`
-	Url                    string     `json:"url"`
-	Priority               int        `json:"priority"`
-	Created                *time.Time `json:"created"`
-	Updated                *time.Time `json:"updated"`
-	CreatedBy              string     `json:"created_by"`
-	UpdatedBy              string     `json:"updated_by"`
-	BountiesCountCompleted int        `gorm:"-" json:"bounties_count_completed"`
-	BountiesCountAssigned  int        `gorm:"-" json:"bounties_count_assigned"`
-	BountiesCountOpen      int        `gorm:"-" json:"bounties_count_open"`
+	ID                     uint           `json:"id"`
+	Uuid                   string         `gorm:"not null" json:"uuid"`
+	WorkspaceUuid          string         `gorm:"not null" json:"workspace_uuid" validate:"required"`
+	Name                   string         `gorm:"not null" json:"name" validate:"required"`
+	Brief                  string         `json:"brief"`
+	Requirements           string         `json:"requirements"`
+	Architecture           string         `json:"architecture"`
+	Url                    string         `json:"url"`
+	Priority               int            `json:"priority"`
+	Budget                 uint           `gorm:"not null;default:0" json:"budget"`
+	Created                *time.Time     `json:"created"`
+	Updated                *time.Time     `json:"updated"`
+	CreatedBy              string         `json:"created_by"`
+	UpdatedBy              string         `json:"updated_by"`
+	DeletedAt              gorm.DeletedAt `gorm:"index" json:"-"`
+	DefaultReviewers       pq.StringArray `gorm:"type:text[];not null;default:'{}'" json:"default_reviewers"`
+	BountiesCountCompleted int            `gorm:"-" json:"bounties_count_completed"`
+	BountiesCountAssigned  int            `gorm:"-" json:"bounties_count_assigned"`
+	BountiesCountOpen      int            `gorm:"-" json:"bounties_count_open"`
+	Links                  []Mention      `gorm:"-" json:"links,omitempty"`
+}
+
+// FeatureBudgetHistory records every allocation of the workspace budget
+// to a feature, the amount is the new allocation
+type FeatureBudgetHistory struct {
+	ID            uint       `json:"id"`
+	FeatureUuid   string     `gorm:"index" json:"feature_uuid"`
+	WorkspaceUuid string     `json:"workspace_uuid"`
+	Amount        uint       `json:"amount"`
+	Created       *time.Time `json:"created"`
+	CreatedBy     string     `json:"created_by"`
+}
+
+// FeatureBurndown compares the allocation of a feature with what its
+// bounties spent (paid) and committed (not paid yet), day by day
+type FeatureBurndown struct {
+	FeatureUuid string          `json:"feature_uuid"`
+	Allocated   uint            `json:"allocated"`
+	Spent       uint            `json:"spent"`
+	Committed   uint            `json:"committed"`
+	Remaining   int             `json:"remaining"`
+	Points      []BurndownPoint `json:"points"`
+}
+
+type BurndownPoint struct {
+	Date      string `json:"date"`
+	Allocated uint   `json:"allocated"`
+	Spent     uint   `json:"spent"`
+	Committed uint   `json:"committed"`
 }
 
+// FeaturePhase is a phase of a feature. DependsOn is the phase of the same
+// feature that has to be complete before bounties are opened in this one,
+// unless the workspace admin overrode the gate
 type FeaturePhase struct {
-	Uuid        string     `json:"uuid" gorm:"primary_key"`
-	FeatureUuid string     `json:"feature_uuid"`
-	Name        string     `json:"name"`
-	Priority    int        `json:"priority"`
-	Created     *time.Time `json:"created"`
-	Updated     *time.Time `json:"updated"`
-	CreatedBy   string     `json:"created_by"`
-	UpdatedBy   string     `json:"updated_by"`
+	Uuid             string     `json:"uuid" gorm:"primary_key"`
+	FeatureUuid      string     `json:"feature_uuid"`
+	Name             string     `json:"name"`
+	Priority         int        `json:"priority"`
+	DependsOn        string     `gorm:"not null;default:''" json:"depends_on"`
+	GateOverridden   *time.Time `json:"gate_overridden"`
+	GateOverriddenBy string     `gorm:"not null;default:''" json:"gate_overridden_by"`
+	Created          *time.Time `json:"created"`
+	Updated          *time.Time `json:"updated"`
+	CreatedBy        string     `json:"created_by"`
+	UpdatedBy        string     `json:"updated_by"`
+}
+
+// PhaseGate is whether bounties can be opened in a phase, with the progress
+// of the phase it depends on. A phase is complete when it has bounties and
+// all of them are completed or paid
+type PhaseGate struct {
+	PhaseUuid         string `json:"phase_uuid"`
+	DependsOn         string `json:"depends_on"`
+	Locked            bool   `json:"locked"`
+	Overridden        bool   `json:"overridden"`
+	Bounties          int64  `json:"bounties"`
+	CompletedBounties int64  `json:"completed_bounties"`
+}
+
+// TicketMoveRequest moves a bounty, the ticket of a phase, to a phase of the
+// same or another feature. Position is its place in the phase from 1, 0
+// puts it last
+type TicketMoveRequest struct {
+	PhaseUuid string `json:"phase_uuid" validate:"required"`
+	Position  int    `json:"position" validate:"gte=0"`
+}
+
+// the statuses of a ticket on the board, from its assignee and its
+// completed and paid flags, see TicketStatusOf
+const (
+	TicketOpen      = "open"
+	TicketAssigned  = "assigned"
+	TicketCompleted = "completed"
+	TicketPaid      = "paid"
+)
+
+// the results of a ticket of a TicketStatusRequest
+const (
+	TicketStatusUpdated   = "updated"
+	TicketStatusUnchanged = "unchanged"
+	TicketStatusRejected  = "rejected"
+)
+
+// TicketStatusRequest moves tickets of a phase, by the ids of their
+// bounties, to a status. Paid is not a status tickets are moved to, they
+// are paid on the payment routes
+type TicketStatusRequest struct {
+	Tickets []uint `json:"tickets" validate:"required,min=1,max=100,dive,required"`
+	Status  string `json:"status" validate:"required,oneof=open assigned completed"`
+}
+
+// TicketStatusChange is a ticket moved from the status it had when it was
+// checked to another
+type TicketStatusChange struct {
+	ID   uint
+	From string
+	To   string
+}
+
+// TicketStatusResult is what a TicketStatusRequest did to one of its
+// tickets. A rejected ticket has the reason in Error, and the conditions of
+// the definition of done it doesn't meet in Unmet
+type TicketStatusResult struct {
+	TicketID uint             `json:"ticket_id"`
+	From     string           `json:"from,omitempty"`
+	To       string           `json:"to"`
+	Result   string           `json:"result"`
+	Error    string           `json:"error,omitempty"`
+	Unmet    []UnmetCondition `json:"unmet,omitempty"`
+	Version  int              `json:"version,omitempty"`
+}
+
+type PhaseDependencyRequest struct {
+	DependsOn string `json:"depends_on"`
+}
+
+type PhaseGateOverrideRequest struct {
+	Override bool `json:"override"`
 }
 
 type BountyRoles struct {
@@ -631,12 +1388,14 @@ type BountyBudget struct {
 
 // Rename back to BountyBudget
 type NewBountyBudget struct {
-	ID            uint       `json:"id"`
-	OrgUuid       string     `gorm:"-" json:"org_uuid"`
-	WorkspaceUuid string     `json:"workspace_uuid"`
-	TotalBudget   uint       `json:"total_budget"`
-	Created       *time.Time `json:"created"`
-	Updated       *time.Time `json:"updated"`
+	ID             uint       `json:"id"`
+	OrgUuid        string     `gorm:"-" json:"org_uuid"`
+	WorkspaceUuid  string     `json:"workspace_uuid"`
+	TotalBudget    uint       `json:"total_budget"`
+	ReservePercent uint       `gorm:"not null;default:0" json:"reserve_percent"`
+	ReserveBalance uint       `gorm:"not null;default:0" json:"reserve_balance"`
+	Created        *time.Time `json:"created"`
+	Updated        *time.Time `json:"updated"`
 }
 
 type StatusBudget struct {
@@ -678,6 +1437,12 @@ const (
 	Deposit  PaymentType = "deposit"
 	Withdraw PaymentType = "withdraw"
 	Payment  PaymentType = "payment"
+	// a reserve release credited back to the budget
+	ReserveRelease PaymentType = "reserve_release"
+	// the bonus of a quest paid to the hunter who completed it
+	QuestBonus PaymentType = "quest_bonus"
+	// a tip from a person to another, outside of bounties
+	Tip PaymentType = "tip"
 )
 
 type BudgetHistory struct {
@@ -733,6 +1498,37 @@ type NewPaymentHistory struct {
 	Created        *time.Time  `json:"created"`
 	Updated        *time.Time  `json:"updated"`
 	Status         bool        `json:"status"`
+	AutoInitiated  bool        `gorm:"not null;default:false" json:"auto_initiated"`
+	TrackedMinutes uint        `gorm:"not null;default:0" json:"tracked_minutes,omitempty"`
+	Memo           string      `gorm:"not null;default:''" json:"memo,omitempty"`
+	// the invoice the sender paid a tip with, when not from a budget
+	PaymentRequest string `gorm:"not null;default:''" json:"payment_request,omitempty"`
+	// the hash of a keysend the relay settled, a proof of the payment
+	PaymentHash string `gorm:"not null;default:''" json:"payment_hash,omitempty"`
+	// the preimage of PaymentHash, only kept once it was checked against it
+	PaymentPreimage string `gorm:"not null;default:''" json:"payment_preimage,omitempty"`
+	// PaymentPending or PaymentFailed while the payment is not settled
+	State string `gorm:"not null;default:''" json:"state,omitempty"`
+}
+
+// PaymentReconciliation is the outcome of a pending payment, as an admin
+// found it on the node
+type PaymentReconciliation struct {
+	Outcome     string `json:"outcome" validate:"required,oneof=settled failed"`
+	PaymentHash string `json:"payment_hash"`
+	Preimage    string `json:"preimage"`
+}
+
+// PaymentProof lets anyone check that the payout of a bounty settled, the
+// sha256 of Preimage is PaymentHash and only the receiver's node could
+// reveal it. It names nobody, and Amount is only set when the bounty owner
+// shows it
+type PaymentProof struct {
+	BountyID    uint       `json:"bounty_id"`
+	PaymentHash string     `json:"payment_hash"`
+	Preimage    string     `json:"preimage"`
+	SettledAt   *time.Time `json:"settled_at"`
+	Amount      *uint      `json:"amount,omitempty"`
 }
 
 type PaymentHistoryData struct {
@@ -758,6 +1554,8 @@ type PaymentData struct {
 
 type BountyPayRequest struct {
 	Websocket_token string `json:"websocket_token,omitempty"`
+	// record the time the assignee tracked on the bounty with the payment
+	IncludeTrackedTime bool `json:"include_tracked_time,omitempty"`
 }
 
 type InvoiceType string
@@ -766,6 +1564,9 @@ const (
 	Keysend    InvoiceType = "KEYSEND"
 	Budget     InvoiceType = "BUDGET"
 	PayInvoice InvoiceType = "ASSIGN"
+	// an invoice paid by the sender of a tip, its amount is sent on to
+	// the receiver once it settles
+	TipInvoice InvoiceType = "TIP"
 )
 
 type InvoiceList struct {
@@ -817,6 +1618,20 @@ type NewWithdrawBudgetRequest struct {
 	WorkspaceUuid   string `json:"workspace_uuid"`
 }
 
+// TipRequest is a tip to a person, paid from the budget of WorkspaceUuid or
+// with an invoice the sender pays when it is empty
+type TipRequest struct {
+	Amount        uint   `json:"amount" validate:"required"`
+	Memo          string `json:"memo" validate:"max=200"`
+	WorkspaceUuid string `json:"workspace_uuid"`
+}
+
+// TipResponse is the recorded tip of a budget, or the invoice to pay
+type TipResponse struct {
+	Payment *NewPaymentHistory `json:"payment,omitempty"`
+	Invoice string             `json:"invoice,omitempty"`
+}
+
 type PaymentDateRange struct {
 	StartDate   string      `json:"start_date"`
 	EndDate     string      `json:"end_date"`
@@ -878,6 +1693,18 @@ type BountyMetrics struct {
 	NewHuntersPaid         int64 `json:"new_hunters_paid"`
 }
 
+// PublicMetrics are the aggregate bounty numbers of GET /metrics/public,
+// no bounty or person can be told from them. Hunters are active when they
+// were assigned or paid a bounty in the last ActiveDays days
+type PublicMetrics struct {
+	BountiesPaid     int64      `json:"bounties_paid"`
+	SatsPaid         uint64     `json:"sats_paid"`
+	ActiveHunters    int64      `json:"active_hunters"`
+	ActiveDays       int        `json:"active_days"`
+	MedianHoursToPay float64    `json:"median_hours_to_pay"`
+	Generated        *time.Time `json:"generated"`
+}
+
 type MetricsBountyCsv struct {
 	DatePosted   *time.Time `json:"date_posted"`
 	Organization string     `json:"organization"`
@@ -898,6 +1725,703 @@ type FilterStattuCount struct {
 	Paid      int64 `json:"paid"`
 }
 
+type ChatStatus string
+
+const (
+	ActiveStatus  ChatStatus = "active"
+	ArchiveStatus ChatStatus = "archived"
+)
+
+type ChatRole string
+
+const (
+	UserRole      ChatRole = "user"
+	AssistantRole ChatRole = "assistant"
+)
+
+type ChatMessageStatus string
+
+const (
+	SendingStatus ChatMessageStatus = "sending"
+	SentStatus    ChatMessageStatus = "sent"
+	ErrorStatus   ChatMessageStatus = "error"
+)
+
+type Chat struct {
+	ID            uint       `json:"id"`
+	Uuid          string     `gorm:"not null;unique" json:"uuid"`
+	WorkspaceUuid string     `gorm:"index" json:"workspace_uuid"`
+	Title         string     `json:"title"`
+	Status        ChatStatus `json:"status"`
+	CreatedBy     string     `json:"created_by"`
+	Created       *time.Time `json:"created"`
+	Updated       *time.Time `json:"updated"`
+}
+
+// ContextTag references a workspace artifact (feature, phase, bounty, brief)
+// that a chat message was written about
+type ContextTag struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+}
+
+type ChatMessage struct {
+	ID                uint              `json:"id"`
+	Uuid              string            `gorm:"not null;unique" json:"uuid"`
+	ChatUuid          string            `gorm:"index" json:"chat_uuid"`
+	WorkspaceUuid     string            `json:"workspace_uuid"`
+	Role              ChatRole          `json:"role"`
+	Message           string            `gorm:"type:text" json:"message"`
+	Status            ChatMessageStatus `json:"status"`
+	ContextTags       JSONB             `gorm:"type:jsonb" json:"context_tags"`
+	SourceWebsocketId string            `json:"source_websocket_id"`
+	CreatedBy         string            `json:"created_by"`
+	Created           *time.Time        `json:"created"`
+	Updated           *time.Time        `json:"updated"`
+}
+
+type ChatMessageRequest struct {
+	ChatUuid          string       `json:"chat_uuid"`
+	Message           string       `json:"message"`
+	ContextTags       []ContextTag `json:"context_tags"`
+	SourceWebsocketId string       `json:"source_websocket_id"`
+}
+
+// ChatWebhookResponse is the payload Stakwork posts back while
+// the assistant reply is being generated
+type ChatWebhookResponse struct {
+	ChatUuid          string   `json:"chat_uuid"`
+	MessageUuid       string   `json:"message_uuid"`
+	Response          string   `json:"response"`
+	Done              bool     `json:"done"`
+	SourceWebsocketId string   `json:"source_websocket_id"`
+	Cost              *float64 `json:"cost"`
+}
+
+type JobStatus string
+
+const (
+	JobPending   JobStatus = "pending"
+	JobRunning   JobStatus = "running"
+	JobCompleted JobStatus = "completed"
+	JobFailed    JobStatus = "failed"
+	JobDead      JobStatus = "dead"
+)
+
+// Job is a unit of background work. Pending jobs are claimed by the workers
+// once RunAt has passed, failed jobs are retried until MaxAttempts is
+// reached and then moved to the dead status
+type Job struct {
+	ID          uint        `json:"id"`
+	Uuid        string      `gorm:"not null;unique" json:"uuid"`
+	Type        string      `gorm:"index" json:"type"`
+	Payload     PropertyMap `gorm:"type:jsonb" json:"payload"`
+	Status      JobStatus   `gorm:"index" json:"status"`
+	Attempts    int         `json:"attempts"`
+	MaxAttempts int         `json:"max_attempts"`
+	RunAt       *time.Time  `gorm:"index" json:"run_at"`
+	LastError   string      `gorm:"type:text" json:"last_error"`
+	LockedAt    *time.Time  `json:"locked_at"`
+	Created     *time.Time  `json:"created"`
+	Updated     *time.Time  `json:"updated"`
+}
+
+// Event is an entry of the domain event log. Events are only ever appended,
+// Subject is the "<kind>:<id>" of the record the event is about
+type Event struct {
+	ID      uint   `json:"id"`
+	Uuid    string `gorm:"not null;unique" json:"uuid"`
+	Type    string `gorm:"index" json:"type"`
+	Subject string `gorm:"index" json:"subject"`
+	// the pubkey of the user whose request published the event, empty
+	// for the events of background work
+	Actor   string      `json:"actor"`
+	Payload PropertyMap `gorm:"type:jsonb" json:"payload"`
+	// the pubkeys of the users the event concerns, the one who caused it
+	// and the ones named in the payload. They make up the activity feed
+	PubKeys pq.StringArray `gorm:"type:text[]" json:"-"`
+	Created *time.Time     `gorm:"index" json:"created"`
+}
+
+// EventConsumer is the position of a durable consumer in the event log, the
+// id of the last event it handled
+type EventConsumer struct {
+	Name        string     `gorm:"primaryKey" json:"name"`
+	LastEventID uint       `json:"last_event_id"`
+	Updated     *time.Time `json:"updated"`
+}
+
+// PlatformStats is a daily snapshot of the platform totals. Snapshots are
+// written by the stats aggregation job so the admin dashboard never has to
+// scan the big tables on request
+type PlatformStats struct {
+	ID                uint       `json:"-"`
+	Day               time.Time  `gorm:"type:date;not null;unique" json:"day"`
+	Tribes            int64      `json:"tribes"`
+	People            int64      `json:"people"`
+	Workspaces        int64      `json:"workspaces"`
+	BountiesOpen      int64      `json:"bounties_open"`
+	BountiesAssigned  int64      `json:"bounties_assigned"`
+	BountiesCompleted int64      `json:"bounties_completed"`
+	BountiesPaid      int64      `json:"bounties_paid"`
+	SatsPaid          int64      `json:"sats_paid"`
+	ActiveUsers       int64      `json:"active_users"`
+	Updated           *time.Time `json:"updated"`
+}
+
+// PlatformStatsResponse is the latest snapshot and the daily trend before it
+type PlatformStatsResponse struct {
+	Totals PlatformStats   `json:"totals"`
+	Trend  []PlatformStats `json:"trend"`
+}
+
+type ReportStatus string
+
+const (
+	ReportPending   ReportStatus = "pending"
+	ReportDismissed ReportStatus = "dismissed"
+	ReportResolved  ReportStatus = "resolved"
+)
+
+// Moderation actions an admin can take on a report
+const (
+	ModerationDismiss  = "dismiss"
+	ModerationUnlist   = "unlist"
+	ModerationDelete   = "delete"
+	ModerationBanOwner = "ban_owner"
+)
+
+// Report flags a tribe or a bounty for the moderators. TargetUuid is the
+// tribe uuid or the bounty id
+type Report struct {
+	ID             uint         `json:"id"`
+	Uuid           string       `gorm:"not null;unique" json:"uuid"`
+	TargetType     string       `gorm:"index:idx_reports_target" json:"target_type" validate:"required,oneof=tribe bounty"`
+	TargetUuid     string       `gorm:"index:idx_reports_target" json:"target_uuid" validate:"required"`
+	Reason         string       `gorm:"type:text" json:"reason" validate:"required,max=500"`
+	ReporterPubKey string       `gorm:"index" json:"reporter_pubkey"`
+	Status         ReportStatus `gorm:"index" json:"status"`
+	Action         string       `json:"action,omitempty"`
+	Note           string       `gorm:"type:text" json:"note,omitempty"`
+	ResolvedBy     string       `json:"resolved_by,omitempty"`
+	Created        *time.Time   `json:"created"`
+	Resolved       *time.Time   `json:"resolved"`
+}
+
+type ReportResolution struct {
+	Action string `json:"action" validate:"required,oneof=dismiss unlist delete ban_owner"`
+	Note   string `json:"note" validate:"max=500"`
+}
+
+// TribeSpamScore is the latest spam score of a tribe, 0 to 100, with the
+// signals it was computed from. OwnerAgeDays is -1 when the owner has no
+// profile
+type TribeSpamScore struct {
+	TribeUuid           string     `gorm:"primaryKey" json:"tribe_uuid"`
+	Score               float64    `gorm:"not null;default:0" json:"score"`
+	OwnerAgeDays        int        `gorm:"not null;default:0" json:"owner_age_days"`
+	DuplicateSimilarity float64    `gorm:"not null;default:0" json:"duplicate_similarity"`
+	DuplicateOf         string     `json:"duplicate_of,omitempty"`
+	DeadFeed            bool       `gorm:"not null;default:false" json:"dead_feed"`
+	Reports             int64      `gorm:"not null;default:0" json:"reports"`
+	Scored              *time.Time `json:"scored"`
+}
+
+type AppealStatus string
+
+const (
+	AppealPending  AppealStatus = "pending"
+	AppealApproved AppealStatus = "approved"
+	AppealRejected AppealStatus = "rejected"
+)
+
+// Review actions an admin can take on a shadow-listed tribe
+const (
+	SpamReviewRestore = "restore"
+	SpamReviewUnlist  = "unlist"
+)
+
+// TribeAppeal is the request of an owner to review their shadow-listed
+// tribe, it is closed by the review of the tribe
+type TribeAppeal struct {
+	ID          uint         `json:"id"`
+	Uuid        string       `gorm:"not null;unique" json:"uuid"`
+	TribeUuid   string       `gorm:"index" json:"tribe_uuid"`
+	OwnerPubKey string       `json:"owner_pubkey"`
+	Message     string       `gorm:"type:text" json:"message" validate:"required,max=1000"`
+	Status      AppealStatus `gorm:"index" json:"status"`
+	ReviewedBy  string       `json:"reviewed_by,omitempty"`
+	Note        string       `gorm:"type:text" json:"note,omitempty"`
+	Created     *time.Time   `json:"created"`
+	Reviewed    *time.Time   `json:"reviewed"`
+}
+
+type TribeSpamReview struct {
+	Action string `json:"action" validate:"required,oneof=restore unlist"`
+	Note   string `json:"note" validate:"max=500"`
+}
+
+// ShadowListedTribe is a tribe in the spam review queue of the admins
+type ShadowListedTribe struct {
+	Tribe  Tribe          `json:"tribe"`
+	Score  TribeSpamScore `json:"score"`
+	Appeal *TribeAppeal   `json:"appeal,omitempty"`
+}
+
+// BannedPubkey is an owner banned by a moderator, banned pubkeys can't
+// create or edit tribes and bounties
+type BannedPubkey struct {
+	Pubkey     string     `gorm:"primaryKey" json:"pubkey"`
+	ReportUuid string     `json:"report_uuid"`
+	BannedBy   string     `json:"banned_by"`
+	Created    *time.Time `json:"created"`
+}
+
+// SoftDeleted is a soft deleted record in the admin list, ID is the uuid
+// or the id of the record
+type SoftDeleted struct {
+	Kind      string     `json:"kind"`
+	ID        string     `json:"id"`
+	Title     string     `json:"title"`
+	DeletedAt *time.Time `json:"deleted_at"`
+}
+
+// Notification is an entry of the inbox of a user, made from an event that
+// concerns them
+type Notification struct {
+	ID      uint       `json:"id"`
+	PubKey  string     `gorm:"uniqueIndex:idx_notifications_pub_key_event" json:"pub_key"`
+	EventID uint       `gorm:"uniqueIndex:idx_notifications_pub_key_event" json:"event_id"`
+	Type    string     `json:"type"`
+	Subject string     `json:"subject"`
+	Message string     `json:"message"`
+	Read    bool       `gorm:"default:false" json:"read"`
+	Created *time.Time `gorm:"index" json:"created"`
+}
+
+// Workspace brief statuses, a generated brief waits as pending until a
+// workspace admin approves or rejects it
+const (
+	BriefGenerating = "generating"
+	BriefPending    = "pending"
+	BriefApproved   = "approved"
+	BriefRejected   = "rejected"
+	BriefFailed     = "failed"
+)
+
+// WorkspaceBrief is a version of the mission and tactics of a workspace
+// drafted by Stakwork, it only replaces them once approved
+type WorkspaceBrief struct {
+	ID            uint       `json:"-"`
+	Uuid          string     `gorm:"uniqueIndex" json:"uuid"`
+	WorkspaceUuid string     `gorm:"index" json:"workspace_uuid"`
+	Mission       string     `json:"mission"`
+	Tactics       string     `json:"tactics"`
+	Status        string     `json:"status"`
+	Error         string     `json:"error,omitempty"`
+	RequestedBy   string     `json:"requested_by"`
+	ReviewedBy    string     `json:"reviewed_by,omitempty"`
+	Created       *time.Time `json:"created"`
+	Updated       *time.Time `json:"updated"`
+}
+
+// BriefWebhookResponse is the payload Stakwork posts back with the drafted
+// brief
+type BriefWebhookResponse struct {
+	BriefUuid string   `json:"brief_uuid"`
+	Mission   string   `json:"mission"`
+	Tactics   string   `json:"tactics"`
+	Error     string   `json:"error"`
+	Cost      *float64 `json:"cost"`
+}
+
+// Reserve entry types, release reasons and release statuses. Deposits
+// feed the reserve, releases come out of it once a workspace admin
+// approves them
+const (
+	ReserveEntryDeposit = "deposit"
+	ReserveEntryRelease = "release"
+
+	ReserveDispute        = "dispute"
+	ReserveFailedDelivery = "failed_delivery"
+
+	ReservePending  = "pending"
+	ReserveApproved = "approved"
+	ReserveRejected = "rejected"
+)
+
+// ReserveEntry is a movement of the dispute reserve of a workspace
+type ReserveEntry struct {
+	ID            uint       `json:"-"`
+	Uuid          string     `gorm:"uniqueIndex" json:"uuid"`
+	WorkspaceUuid string     `gorm:"index" json:"workspace_uuid"`
+	Type          string     `json:"type"`
+	Amount        uint       `json:"amount"`
+	BountyId      uint       `json:"bounty_id,omitempty"`
+	Reason        string     `json:"reason,omitempty"`
+	Outcome       string     `gorm:"type:text" json:"outcome,omitempty"`
+	Status        string     `json:"status"`
+	RequestedBy   string     `json:"requested_by,omitempty"`
+	ReviewedBy    string     `json:"reviewed_by,omitempty"`
+	Created       *time.Time `json:"created"`
+	Reviewed      *time.Time `json:"reviewed,omitempty"`
+}
+
+// WorkspaceReserve is the state of the dispute reserve of a workspace,
+// Pending sums the releases waiting for a review
+type WorkspaceReserve struct {
+	WorkspaceUuid string `json:"workspace_uuid"`
+	Percent       uint   `json:"percent"`
+	Balance       uint   `json:"balance"`
+	Pending       uint   `json:"pending"`
+}
+
+// ReserveReleaseRequest asks to release a part of the reserve for the
+// outcome of a dispute or a failed delivery on a bounty
+type ReserveReleaseRequest struct {
+	BountyId uint   `json:"bounty_id" validate:"required"`
+	Amount   uint   `json:"amount" validate:"required"`
+	Reason   string `json:"reason" validate:"required,oneof=dispute failed_delivery"`
+	Outcome  string `json:"outcome" validate:"required,max=1000"`
+}
+
+// TimeEntry is time a person spent on a bounty, from a timer or entered by
+// hand. A running timer has no Ended yet
+type TimeEntry struct {
+	ID            uint       `json:"-"`
+	Uuid          string     `gorm:"uniqueIndex" json:"uuid"`
+	BountyId      uint       `gorm:"index" json:"bounty_id"`
+	WorkspaceUuid string     `gorm:"index" json:"workspace_uuid,omitempty"`
+	PubKey        string     `gorm:"index" json:"pubkey"`
+	Started       *time.Time `gorm:"index" json:"started"`
+	Ended         *time.Time `json:"ended"`
+	Minutes       uint       `json:"minutes"`
+	Manual        bool       `json:"manual"`
+	Note          string     `json:"note,omitempty"`
+	Created       *time.Time `json:"created"`
+}
+
+// ManualTimeEntry is time entered by hand, on Date (YYYY-MM-DD) or today
+type ManualTimeEntry struct {
+	Minutes uint   `json:"minutes" validate:"required,max=1440"`
+	Date    string `json:"date"`
+	Note    string `json:"note" validate:"max=500"`
+}
+
+// TimeTotal sums the ended time entries of a person on a bounty
+type TimeTotal struct {
+	PubKey        string `json:"pubkey,omitempty"`
+	BountyId      uint   `json:"bounty_id,omitempty"`
+	WorkspaceUuid string `json:"workspace_uuid,omitempty"`
+	Minutes       uint   `json:"minutes"`
+	Entries       int64  `json:"entries"`
+}
+
+// TimeReport is the tracked time of a workspace or a person, in total and
+// per person and per bounty
+type TimeReport struct {
+	Minutes  uint        `json:"minutes"`
+	ByPerson []TimeTotal `json:"by_person"`
+	ByBounty []TimeTotal `json:"by_bounty"`
+}
+
+// Quest is an ordered series of bounties of a workspace. The hunter who
+// completes every bounty of the series earns the Bonus on top of their
+// prices
+type Quest struct {
+	ID            uint        `json:"-"`
+	Uuid          string      `gorm:"uniqueIndex" json:"uuid"`
+	WorkspaceUuid string      `gorm:"index" json:"workspace_uuid" validate:"required"`
+	Title         string      `json:"title" validate:"required,max=100"`
+	Description   string      `gorm:"type:text" json:"description" validate:"max=2000"`
+	Bonus         uint        `json:"bonus"`
+	Active        bool        `gorm:"index" json:"active"`
+	BonusPaidTo   string      `json:"bonus_paid_to,omitempty"`
+	BonusPaidAt   *time.Time  `json:"bonus_paid_at,omitempty"`
+	CreatedBy     string      `json:"created_by"`
+	Created       *time.Time  `json:"created"`
+	Updated       *time.Time  `json:"updated"`
+	BountyIds     []uint      `gorm:"-" json:"bounty_ids" validate:"required,min=1,max=50"`
+	Bounties      []NewBounty `gorm:"-" json:"bounties,omitempty"`
+}
+
+// QuestBounty places a bounty in a quest
+type QuestBounty struct {
+	ID        uint   `json:"-"`
+	QuestUuid string `gorm:"uniqueIndex:idx_quest_bounties" json:"quest_uuid"`
+	BountyId  uint   `gorm:"uniqueIndex:idx_quest_bounties;index" json:"bounty_id"`
+	Position  int    `json:"position"`
+}
+
+// QuestProgress is how far a hunter got in a quest, NextBountyId is the
+// first bounty of the series still open to them
+type QuestProgress struct {
+	Hunter       string `json:"hunter"`
+	Completed    int    `json:"completed"`
+	Total        int    `json:"total"`
+	Done         bool   `json:"done"`
+	NextBountyId uint   `json:"next_bounty_id,omitempty"`
+}
+
+// Batch reference types
+const (
+	BatchPerson = "person"
+	BatchBounty = "bounty"
+	BatchTribe  = "tribe"
+)
+
+// BatchRef is one resource of a batch read, the pubkey of a person, the id
+// of a bounty or the uuid of a tribe
+type BatchRef struct {
+	Type string `json:"type" validate:"required,oneof=person bounty tribe"`
+	Id   string `json:"id" validate:"required"`
+}
+
+type BatchGetRequest struct {
+	Refs []BatchRef `json:"refs" validate:"required,min=1,max=100,dive"`
+}
+
+// BatchItem is the result of one reference, in the order of the request
+type BatchItem struct {
+	Type   string      `json:"type"`
+	Id     string      `json:"id"`
+	Status int         `json:"status"`
+	Data   interface{} `json:"data,omitempty"`
+	Error  string      `json:"error,omitempty"`
+}
+
+// SyncResponse is what changed for a user since a sync cursor. Profile is
+// only set when it changed, Cursor is passed back as ?since= on the next
+// sync
+type SyncResponse struct {
+	Profile         *Person        `json:"profile,omitempty"`
+	Bounties        []NewBounty    `json:"bounties"`
+	DeletedBounties []uint         `json:"deleted_bounties"`
+	Notifications   []Notification `json:"notifications"`
+	Cursor          string         `json:"cursor"`
+}
+
+// Mention target and source types
+const (
+	MentionPerson  = "person"
+	MentionBounty  = "bounty"
+	MentionFeature = "feature"
+)
+
+// Mention links a bounty or feature to the person, bounty or feature its
+// text references, with the title it had when the text was saved
+type Mention struct {
+	ID         uint       `json:"-"`
+	SourceType string     `gorm:"uniqueIndex:idx_mentions_source_target;not null" json:"source_type"`
+	SourceID   string     `gorm:"uniqueIndex:idx_mentions_source_target;not null" json:"source_id"`
+	TargetType string     `gorm:"uniqueIndex:idx_mentions_source_target;index:idx_mentions_target;not null" json:"type"`
+	TargetID   string     `gorm:"uniqueIndex:idx_mentions_source_target;index:idx_mentions_target;not null" json:"id"`
+	Text       string     `json:"text"`
+	Title      string     `json:"title"`
+	Img        string     `json:"img,omitempty"`
+	Created    *time.Time `json:"created"`
+}
+
+// Search indexes
+const (
+	SearchIndexTribes   = "tribes"
+	SearchIndexPeople   = "people"
+	SearchIndexBounties = "bounties"
+)
+
+// SearchDocument is a searchable tribe, person or bounty. ID is the tribe
+// uuid, the person uuid or the bounty id
+type SearchDocument struct {
+	Index       string  `json:"index"`
+	ID          string  `json:"id"`
+	Title       string  `json:"title"`
+	Description string  `json:"description"`
+	Img         string  `json:"img"`
+	Rank        float64 `json:"rank,omitempty"`
+}
+
+// Retention actions
+const (
+	RetentionPurge     = "purge"
+	RetentionAnonymize = "anonymize"
+	RetentionArchive   = "archive"
+)
+
+// RetentionRule cleans up the rows of Table whose AgeColumn is older than
+// Days. Where narrows the rows, Set is the assignment of an anonymize rule
+// and ArchiveTable with ArchiveColumns is where an archive rule moves them
+type RetentionRule struct {
+	Name           string `json:"name"`
+	Action         string `json:"action"`
+	Table          string `json:"table"`
+	AgeColumn      string `json:"age_column"`
+	Where          string `json:"where,omitempty"`
+	Set            string `json:"set,omitempty"`
+	ArchiveTable   string `json:"archive_table,omitempty"`
+	ArchiveColumns string `json:"-"`
+	Days           int    `json:"days"`
+}
+
+// RetentionRun is the outcome of one rule in a retention job, the rows of
+// a job share its uuid. Affected is what a dry run would have changed
+type RetentionRun struct {
+	ID       uint       `json:"id"`
+	JobUuid  string     `gorm:"index" json:"job_uuid"`
+	Rule     string     `json:"rule"`
+	Action   string     `json:"action"`
+	DryRun   bool       `json:"dry_run"`
+	Before   *time.Time `json:"before"`
+	Affected int64      `json:"affected"`
+	Error    string     `gorm:"type:text" json:"error,omitempty"`
+	Created  *time.Time `gorm:"index" json:"created"`
+}
+
+// RetentionReport is the retention rules in effect and the latest runs
+type RetentionReport struct {
+	DryRun bool            `json:"dry_run"`
+	Rules  []RetentionRule `json:"rules"`
+	Runs   []RetentionRun  `json:"runs"`
+}
+
+// EventArchive holds the events moved out of the event log by retention
+type EventArchive struct {
+	ID      uint        `gorm:"primaryKey;autoIncrement:false" json:"id"`
+	Uuid    string      `gorm:"not null;unique" json:"uuid"`
+	Type    string      `gorm:"index" json:"type"`
+	Subject string      `gorm:"index" json:"subject"`
+	Payload PropertyMap `gorm:"type:jsonb" json:"payload"`
+	Created *time.Time  `json:"created"`
+}
+
+// Workspace export statuses
+const (
+	ExportPending = "pending"
+	ExportReady   = "ready"
+	ExportFailed  = "failed"
+)
+
+// WorkspaceExport is a bundle of a workspace built by a background job,
+// Bundle is the json of the WorkspaceBundle once it is ready
+type WorkspaceExport struct {
+	ID            uint       `json:"-"`
+	Uuid          string     `gorm:"not null;unique" json:"uuid"`
+	WorkspaceUuid string     `gorm:"index" json:"workspace_uuid"`
+	Status        string     `json:"status"`
+	Error         string     `gorm:"type:text" json:"error,omitempty"`
+	Bundle        string     `gorm:"type:jsonb" json:"-"`
+	RequestedBy   string     `json:"requested_by"`
+	Created       *time.Time `json:"created"`
+	Completed     *time.Time `json:"completed,omitempty"`
+}
+
+// WorkspaceBundleFormat is the version of the bundle layout, imports refuse
+// the bundles of other formats
+const WorkspaceBundleFormat = 1
+
+// WorkspaceBundle is the structure of a workspace, for backups and to clone
+// it into another workspace or environment. Payments, invoices, budgets and
+// members are left out
+type WorkspaceBundle struct {
+	Format       int                     `json:"format"`
+	Exported     *time.Time              `json:"exported"`
+	Workspace    Workspace               `json:"workspace"`
+	Repositories []WorkspaceRepositories `json:"repositories"`
+	Features     []WorkspaceFeatures     `json:"features"`
+	Phases       []FeaturePhase          `json:"phases"`
+	Stories      []FeatureStory          `json:"stories"`
+	Bounties     []NewBounty             `json:"bounties"`
+	Briefs       []WorkspaceBrief        `json:"briefs"`
+	Artifacts    []WorkspaceArtifact     `json:"artifacts"`
+}
+
+// Maintenance is the maintenance mode of the API, a single row shared by
+// every instance. EndsAt is the estimate announced to clients, maintenance
+// lasts until it is turned off
+type Maintenance struct {
+	ID        uint       `json:"-"`
+	Enabled   bool       `gorm:"not null;default:false" json:"enabled"`
+	Message   string     `json:"message"`
+	EndsAt    *time.Time `json:"ends_at,omitempty"`
+	StartedAt *time.Time `json:"started_at,omitempty"`
+	UpdatedBy string     `json:"updated_by"`
+	Updated   *time.Time `json:"updated"`
+}
+
+// MaintenanceRequest turns the maintenance mode on or off, ends_at is the
+// estimated end shown to clients
+type MaintenanceRequest struct {
+	Enabled bool       `json:"enabled"`
+	Message string     `json:"message" validate:"max=280"`
+	EndsAt  *time.Time `json:"ends_at"`
+}
+
+// BountyRecommendation is an open bounty ranked for a person by the
+// recommendation job. Reasons name the signals that matched, like
+// "skill:go", "paid_work:go", "workspace" or "price"
+type BountyRecommendation struct {
+	ID       uint           `json:"-"`
+	Pubkey   string         `gorm:"index;not null" json:"-"`
+	BountyID uint           `gorm:"not null" json:"bounty_id"`
+	Score    float64        `json:"score"`
+	Reasons  pq.StringArray `gorm:"type:text[]" json:"reasons"`
+	Computed time.Time      `gorm:"not null" json:"computed"`
+	Bounty   NewBounty      `gorm:"-" json:"bounty"`
+	created  int64
+}
+
+// BountyPriceSuggestion is the price range of the paid bounties of a tag and
+// an estimate, in a workspace or across all of them when WorkspaceUuid is
+// empty. An empty Tag is any tag and an HoursBucket of 0 any estimate. The
+// rows are computed again every night
+type BountyPriceSuggestion struct {
+	ID            uint      `json:"-"`
+	WorkspaceUuid string    `gorm:"uniqueIndex:idx_bounty_price_suggestions_group;not null;default:''" json:"workspace_uuid,omitempty"`
+	Tag           string    `gorm:"uniqueIndex:idx_bounty_price_suggestions_group;not null;default:''" json:"tag"`
+	HoursBucket   int       `gorm:"uniqueIndex:idx_bounty_price_suggestions_group;not null;default:0" json:"hours_bucket"`
+	Samples       int       `gorm:"not null" json:"samples"`
+	Low           uint      `gorm:"not null" json:"low"`
+	Median        uint      `gorm:"not null" json:"median"`
+	High          uint      `gorm:"not null" json:"high"`
+	Computed      time.Time `gorm:"not null" json:"computed"`
+}
+
+// BountyPriceSuggestions is the suggested price of a bounty from the paid
+// bounties of its workspace and of all of them, nil where too few bounties
+// were paid
+type BountyPriceSuggestions struct {
+	Tags        []string               `json:"tags"`
+	HoursBucket int                    `json:"hours_bucket"`
+	Workspace   *BountyPriceSuggestion `json:"workspace"`
+	Global      *BountyPriceSuggestion `json:"global"`
+}
+
+// WorkspaceDigest is the summary of a week of a workspace, written by the
+// weekly digest job. BudgetDelta is what came into the budget minus what
+// left it, stale bounties are assigned ones without an update for two weeks
+type WorkspaceDigest struct {
+	ID             uint          `json:"-"`
+	Uuid           string        `gorm:"unique;not null" json:"uuid"`
+	WorkspaceUuid  string        `gorm:"uniqueIndex:idx_workspace_digests_period;not null" json:"workspace_uuid"`
+	PeriodStart    time.Time     `gorm:"uniqueIndex:idx_workspace_digests_period;not null" json:"period_start"`
+	PeriodEnd      time.Time     `gorm:"not null" json:"period_end"`
+	NewBounties    int64         `json:"new_bounties"`
+	Completed      int64         `json:"completed"`
+	Paid           int64         `json:"paid"`
+	SatsPaid       int64         `json:"sats_paid"`
+	BudgetDelta    int64         `json:"budget_delta"`
+	StaleBounties  int64         `json:"stale_bounties"`
+	StaleBountyIDs pq.Int64Array `gorm:"type:bigint[]" json:"stale_bounty_ids"`
+	// the open bounties whose deadline passed before the end of the week
+	OverdueBounties  int64         `gorm:"not null;default:0" json:"overdue_bounties"`
+	OverdueBountyIDs pq.Int64Array `gorm:"type:bigint[]" json:"overdue_bounty_ids"`
+	Created          *time.Time    `json:"created"`
+}
+
+// Empty is true when nothing happened in the workspace that week
+func (d WorkspaceDigest) Empty() bool {
+	return d.NewBounties == 0 && d.Completed == 0 && d.Paid == 0 && d.BudgetDelta == 0 && d.StaleBounties == 0 && d.OverdueBounties == 0
+}
+
 func (Person) TableName() string {
 	return "people"
 }
@@ -906,10 +2430,6 @@ func (PersonInShort) TableName() string {
 	return "people"
 }
 
-func (Bounty) TableName() string {
-	return "bounty"
-}
-
 func (NewBounty) TableName() string {
 	return "bounty"
 }
@@ -934,6 +2454,10 @@ func (ConnectionCodesShort) TableName() string {
 	return "connectioncodes"
 }
 
+func (EventArchive) TableName() string {
+	return "events_archive"
+}
+
 // PropertyMap ...
 type PropertyMap map[string]interface{}
 
@@ -978,3 +2502,278 @@ func (a *JSONB) Scan(value interface{}) error {
 	}
 	return json.Unmarshal(b, &a)
 }
+
+// GithubOrgLogin is a member or a contributor of the repositories of a
+// GitHub organization, Contributions counts its commits to them
+type GithubOrgLogin struct {
+	Login         string `json:"login"`
+	OrgMember     bool   `json:"org_member"`
+	Contributions int    `json:"contributions"`
+}
+
+// WorkspaceMemberSuggestion is a login of the GitHub organization of a
+// workspace, the nightly sync replaces the rows of a workspace. OwnerPubKey
+// is the person who linked the login on their profile, empty when nobody
+// did. The alias and image are read from the profile
+type WorkspaceMemberSuggestion struct {
+	ID            uint       `json:"-"`
+	WorkspaceUuid string     `gorm:"uniqueIndex:idx_member_suggestions_login;not null" json:"workspace_uuid"`
+	GithubLogin   string     `gorm:"uniqueIndex:idx_member_suggestions_login;not null" json:"github_login"`
+	OwnerPubKey   string     `gorm:"index;not null;default:''" json:"owner_pubkey"`
+	OrgMember     bool       `gorm:"not null;default:false" json:"org_member"`
+	Contributions int        `gorm:"not null;default:0" json:"contributions"`
+	Synced        *time.Time `json:"synced"`
+	OwnerAlias    string     `gorm:"-" json:"owner_alias"`
+	Img           string     `gorm:"-" json:"img"`
+}
+
+// BountyFacetFilters are the filters of the bounty marketplace, read like
+// the query of /bounties/all. Statuses are ORed, a bounty has every tag and
+// at least one of the languages
+type BountyFacetFilters struct {
+	Statuses      []string
+	Tags          []string
+	Languages     []string
+	WorkspaceUuid string
+	PhaseUuid     string
+	Search        string
+	PriceBand     string
+}
+
+// BountyPriceBand is a price range of the marketplace filters in sats, Max
+// is 0 for the last band
+type BountyPriceBand struct {
+	Key string `json:"key"`
+	Min uint   `json:"min"`
+	Max uint   `json:"max,omitempty"`
+}
+
+// BountyFacetCount is the number of bounties with a value of a facet, Label
+// is the name of a workspace
+type BountyFacetCount struct {
+	Value string `json:"value"`
+	Label string `json:"label,omitempty"`
+	Count int64  `json:"count"`
+}
+
+// BountyFacets are the counts of the bounties matching a filter set, per
+// value of each marketplace filter
+type BountyFacets struct {
+	Total      int64              `json:"total"`
+	Tags       []BountyFacetCount `json:"tags"`
+	Languages  []BountyFacetCount `json:"languages"`
+	PriceBands []BountyFacetCount `json:"price_bands"`
+	Workspaces []BountyFacetCount `json:"workspaces"`
+}
+
+// BountyContactExchange lets the owner and the assignee of a bounty read
+// each other's contact key and route hint once both consented. It is
+// opened when the bounty is assigned, and only serves the current assignee
+type BountyContactExchange struct {
+	ID              uint           `json:"-"`
+	BountyID        uint           `gorm:"uniqueIndex:idx_bounty_contact_exchange;not null" json:"bounty_id"`
+	OwnerPubKey     string         `gorm:"not null" json:"owner_pubkey"`
+	AssigneePubKey  string         `gorm:"uniqueIndex:idx_bounty_contact_exchange;not null" json:"assignee_pubkey"`
+	OwnerConsent    bool           `gorm:"not null;default:false" json:"owner_consent"`
+	AssigneeConsent bool           `gorm:"not null;default:false" json:"assignee_consent"`
+	Created         *time.Time     `json:"created"`
+	Updated         *time.Time     `json:"updated"`
+	Contact         *BountyContact `gorm:"-" json:"contact,omitempty"`
+}
+
+// BountyContact is how to reach the other party of a contact exchange
+type BountyContact struct {
+	OwnerPubKey     string `json:"owner_pubkey"`
+	OwnerAlias      string `json:"owner_alias"`
+	OwnerContactKey string `json:"owner_contact_key"`
+	OwnerRouteHint  string `json:"owner_route_hint"`
+}
+
+// BountyContactConsentRequest is the body of PUT /gobounties/{id}/contact
+type BountyContactConsentRequest struct {
+	Consent bool `json:"consent"`
+}
+
+// reasons a workspace is flagged when it is created
+const (
+	FlagNewOwner       = "new_owner"
+	FlagRepeatCreation = "repeat_creation"
+)
+
+// actions of the review of a flagged workspace
+const (
+	WorkspaceReviewApprove = "approve"
+	WorkspaceReviewReject  = "reject"
+)
+
+// WorkspaceReview approves a flagged workspace, which lists it, or rejects
+// it, which deletes it
+type WorkspaceReview struct {
+	Action string `json:"action" validate:"required,oneof=approve reject"`
+}
+
+// methods of workspace verification
+const (
+	VerifyByEmail   = "email"
+	VerifyByPhone   = "phone"
+	VerifyByPayment = "payment"
+)
+
+// WorkspaceVerification is the pending verification of a workspace, a code
+// sent to an email or a phone, or an invoice to pay. A workspace has one
+// at a time, it is dropped once the workspace is verified
+type WorkspaceVerification struct {
+	ID             uint       `json:"-"`
+	WorkspaceUuid  string     `gorm:"uniqueIndex;not null" json:"workspace_uuid"`
+	Method         string     `gorm:"not null" json:"method"`
+	Destination    string     `gorm:"not null;default:''" json:"-"`
+	CodeHash       string     `gorm:"not null;default:''" json:"-"`
+	PaymentRequest string     `gorm:"not null;default:''" json:"payment_request,omitempty"`
+	Attempts       int        `gorm:"not null;default:0" json:"attempts"`
+	Expires        *time.Time `json:"expires"`
+	Created        *time.Time `json:"created"`
+}
+
+// WorkspaceVerificationRequest starts the verification of a workspace, To
+// is the email or phone number a code is sent to
+type WorkspaceVerificationRequest struct {
+	Method string `json:"method" validate:"required,oneof=email phone payment"`
+	To     string `json:"to" validate:"max=320"`
+}
+
+// WorkspaceVerificationConfirm is the code sent to verify a workspace, a
+// payment verification is confirmed without one
+type WorkspaceVerificationConfirm struct {
+	Code string `json:"code" validate:"max=16"`
+}
+
+// WorkspaceVerificationCode is the body posted to
+// WORKSPACE_VERIFICATION_WEBHOOK for it to send the code
+type WorkspaceVerificationCode struct {
+	WorkspaceUuid string `json:"workspace_uuid"`
+	WorkspaceName string `json:"workspace_name"`
+	Channel       string `json:"channel"`
+	To            string `json:"to"`
+	Code          string `json:"code"`
+}
+
+// statuses of a tribe preview
+const (
+	PreviewPending = "pending"
+	PreviewReady   = "ready"
+	PreviewFailed  = "failed"
+)
+
+// TribePreview is the preview card of a tribe, built by the server from
+// the open graph metadata of its app url. Card is the SVG served at
+// /tribes/{uuid}/preview.svg
+type TribePreview struct {
+	ID          uint       `json:"-"`
+	TribeUuid   string     `gorm:"uniqueIndex;not null" json:"tribe_uuid"`
+	Url         string     `gorm:"not null" json:"url"`
+	Status      string     `gorm:"not null;default:'pending'" json:"status"`
+	Title       string     `gorm:"not null;default:''" json:"title"`
+	Description string     `gorm:"not null;default:''" json:"description"`
+	Image       string     `gorm:"not null;default:''" json:"image"`
+	SiteName    string     `gorm:"not null;default:''" json:"site_name"`
+	Card        string     `gorm:"not null;default:''" json:"-"`
+	Error       string     `gorm:"not null;default:''" json:"error,omitempty"`
+	Fetched     *time.Time `json:"fetched"`
+	Created     *time.Time `json:"created"`
+	Updated     *time.Time `json:"updated"`
+}
+
+// BountyCertificateType is the type of the completion certificates
+const BountyCertificateType = "bounty_completion"
+
+// BountyCertificateClaim is what a completion certificate asserts: the
+// hunter of Pubkey completed a bounty of a workspace on the Completed date.
+// Issuer is the host of the server that signed it
+type BountyCertificateClaim struct {
+	Type          string `json:"type"`
+	Uuid          string `json:"uuid"`
+	Issuer        string `json:"issuer"`
+	Pubkey        string `json:"pubkey"`
+	BountyID      uint   `json:"bounty_id"`
+	BountyTitle   string `json:"bounty_title"`
+	WorkspaceUuid string `json:"workspace_uuid"`
+	WorkspaceName string `json:"workspace_name"`
+	Completed     string `json:"completed"`
+	Issued        int64  `json:"issued"`
+}
+
+// BountyCertificate is the completion certificate of a paid bounty. Claim
+// is the signed json of the BountyCertificateClaim, kept as it was signed
+// so the signature still verifies
+type BountyCertificate struct {
+	ID            uint       `json:"-"`
+	Uuid          string     `gorm:"uniqueIndex;not null" json:"uuid"`
+	BountyID      uint       `gorm:"uniqueIndex;not null" json:"bounty_id"`
+	Pubkey        string     `gorm:"index;not null" json:"pubkey"`
+	WorkspaceUuid string     `gorm:"not null;default:''" json:"workspace_uuid"`
+	Claim         string     `gorm:"type:text;not null" json:"-"`
+	Signature     string     `gorm:"not null" json:"signature"`
+	KeyId         string     `gorm:"not null" json:"key_id"`
+	Created       *time.Time `json:"created"`
+}
+
+// SignedBountyCertificate is a completion certificate as it is served, the
+// signature is over the exact bytes of Certificate
+type SignedBountyCertificate struct {
+	Certificate json.RawMessage `json:"certificate"`
+	Algorithm   string          `json:"algorithm"`
+	KeyId       string          `json:"key_id"`
+	Signature   string          `json:"signature"`
+}
+
+// the tribe lifecycle events a webhook can subscribe to
+const (
+	TribeWebhookCreated      = "tribe.created"
+	TribeWebhookUpdated      = "tribe.updated"
+	TribeWebhookDeleted      = "tribe.deleted"
+	TribeWebhookMemberJoined = "tribe.member_joined"
+)
+
+// TribeWebhook is a callback url of a tribe owner, posted the lifecycle
+// events of the tribe in Events, or all of them when it is empty. The body
+// is signed with Secret, which is only shown when the webhook is created
+type TribeWebhook struct {
+	ID            uint           `json:"-"`
+	Uuid          string         `gorm:"uniqueIndex;not null" json:"uuid"`
+	TribeUuid     string         `gorm:"index;not null" json:"tribe_uuid"`
+	Url           string         `gorm:"not null" json:"url"`
+	Events        pq.StringArray `gorm:"type:text[];not null;default:'{}'" json:"events"`
+	Secret        string         `gorm:"not null" json:"secret,omitempty"`
+	LastStatus    int            `gorm:"not null;default:0" json:"last_status"`
+	LastError     string         `gorm:"not null;default:''" json:"last_error,omitempty"`
+	LastDelivered *time.Time     `json:"last_delivered"`
+	Created       *time.Time     `json:"created"`
+	Updated       *time.Time     `json:"updated"`
+}
+
+// TribeWebhookRequest registers a webhook of a tribe
+type TribeWebhookRequest struct {
+	Url    string   `json:"url" validate:"required,url,max=500"`
+	Events []string `json:"events" validate:"max=4,dive,oneof=tribe.created tribe.updated tribe.deleted tribe.member_joined"`
+}
+
+// the kinds of the entries of the activity timeline of a tribe
+const (
+	TribeActivityBounty  = "bounty"
+	TribeActivityTicket  = "ticket"
+	TribeActivityChannel = "channel"
+	TribeActivityPayment = "payment"
+)
+
+// TribeActivity is an entry of the activity timeline of a tribe: a bounty,
+// a ticket, the creation of a channel or the payment of a bounty. ID is the
+// id of the bounty for bounties, tickets and payments, so clients can link
+// to it, and of the channel for channels
+type TribeActivity struct {
+	Kind    string     `json:"kind"`
+	ID      uint       `json:"id"`
+	Title   string     `json:"title"`
+	Pubkey  string     `json:"pubkey,omitempty"`
+	Amount  uint       `json:"amount,omitempty"`
+	Created *time.Time `json:"created"`
+}
@@ -0,0 +1,43 @@
+package db
+
+// GetLastEventId returns the id of the newest event of the log, 0 when it
+// is empty
+func (db database) GetLastEventId() uint {
+	var id uint
+	db.db.Model(&Event{}).Select("COALESCE(MAX(id), 0)").Scan(&id)
+	return id
+}
+
+func (db database) GetLastNotificationId() uint {
+	var id uint
+	db.db.Model(&Notification{}).Select("COALESCE(MAX(id), 0)").Scan(&id)
+	return id
+}
+
+// GetUserEventSubjects returns the subjects of the events that concern a
+// user with an id above after and up to upTo, once each
+func (db database) GetUserEventSubjects(pubkey string, after uint, upTo uint) ([]string, error) {
+	subjects := []string{}
+	err := activityQuery(db.db, pubkey, nil).
+		Where("id > ? AND id <= ?", after, upTo).
+		Distinct("subject").
+		Pluck("subject", &subjects).Error
+	return subjects, err
+}
+
+// GetNotificationsBetween returns the notifications of a user with an id
+// above after and up to upTo, newest first
+func (db database) GetNotificationsBetween(pubkey string, after uint, upTo uint, limit int) ([]Notification, error) {
+	notifications := []Notification{}
+	err := db.db.Where("pub_key = ? AND id > ? AND id <= ?", pubkey, after, upTo).
+		Order("id DESC").
+		Limit(limit).
+		Find(&notifications).Error
+	return notifications, err
+}
+
+func (db database) GetBountiesAssignedTo(pubkey string) []NewBounty {
+	bounties := []NewBounty{}
+	db.db.Where("assignee = ?", pubkey).Order("id ASC").Find(&bounties)
+	return bounties
+}
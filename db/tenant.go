@@ -0,0 +1,291 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/stakwork/sphinx-tribes/config"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+const tenantColumn = "workspace_uuid"
+
+// ErrTenantViolation is the error of a query the tenant guard rejected
+var ErrTenantViolation = errors.New("query on a workspace table is not scoped to the workspace of the request")
+
+// the models whose rows belong to a workspace, a query on their tables in
+// a workspace request has to filter on workspace_uuid
+var tenantModels = []interface{}{
+	&NewBounty{},
+	&NewBountyBudget{},
+	&NewInvoiceList{},
+	&NewPaymentHistory{},
+	&WorkspaceUsers{},
+	&WorkspaceUserRoles{},
+	&WorkspaceRepositories{},
+	&WorkspaceFeatures{},
+	&WorkspaceArtifact{},
+	&WorkspaceBrief{},
+	&FeatureBudgetHistory{},
+	&ReserveEntry{},
+	&TimeEntry{},
+	&Chat{},
+	&ChatMessage{},
+	&Quest{},
+	&WorkspaceExport{},
+}
+
+var (
+	tenantTablesOnce sync.Once
+	tenantTableSet   map[string]bool
+	tenantRawTable   *regexp.Regexp
+
+	tenantViolations int64
+)
+
+type tenantKey struct{}
+
+// WithTenant marks ctx as a request of a workspace. The uuid is resolved
+// when a query runs, so a middleware can set it up before the router has
+// parsed the url params
+func WithTenant(ctx context.Context, tenant func() string) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenant)
+}
+
+// TenantFrom returns the workspace of the request of ctx, or "" outside
+// of a workspace request
+func TenantFrom(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	tenant, ok := ctx.Value(tenantKey{}).(func() string)
+	if !ok {
+		return ""
+	}
+	return tenant()
+}
+
+func tenantTables() (map[string]bool, *regexp.Regexp) {
+	tenantTablesOnce.Do(func() {
+		tenantTableSet = map[string]bool{}
+		names := []string{}
+		cache := &sync.Map{}
+		for _, model := range tenantModels {
+			s, err := schema.Parse(model, cache, schema.NamingStrategy{})
+			if err != nil {
+				continue
+			}
+			tenantTableSet[s.Table] = true
+			names = append(names, regexp.QuoteMeta(s.Table))
+		}
+		tenantRawTable = regexp.MustCompile(`(?i)\b(?:from|join|update)\s+"?(` + strings.Join(names, "|") + `)"?(?:\s|$|,|\))`)
+	})
+	return tenantTableSet, tenantRawTable
+}
+
+// tenantGuardPlugin checks that the queries of a workspace request on a
+// workspace table filter on the workspace of the request, so the rows of
+// one workspace can't end up in the listing of another. Only queries run
+// with the request context, see forRequest, of a request marked with
+// WithTenant are checked, which is a small part of the queries of the
+// /workspaces routes. Violations are logged and counted, TENANT_GUARD=reject
+// fails them before they run
+type tenantGuardPlugin struct{}
+
+func (tenantGuardPlugin) Name() string {
+	return "tenant_guard"
+}
+
+func (p tenantGuardPlugin) Initialize(db *gorm.DB) error {
+	callbacks := []struct {
+		name     string
+		register func(string, func(*gorm.DB)) error
+	}{
+		{"query", db.Callback().Query().Before("gorm:query").Register},
+		{"update", db.Callback().Update().Before("gorm:update").Register},
+		{"delete", db.Callback().Delete().Before("gorm:delete").Register},
+		{"row", db.Callback().Row().Before("gorm:row").Register},
+		{"raw", db.Callback().Raw().Before("gorm:raw").Register},
+	}
+
+	for _, c := range callbacks {
+		if err := c.register("tenant_guard:before_"+c.name, p.check(c.name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (tenantGuardPlugin) check(operation string) func(*gorm.DB) {
+	return func(tx *gorm.DB) {
+		mode := config.Get().TenantGuard
+		if mode != config.TenantGuardLog && mode != config.TenantGuardReject {
+			return
+		}
+		if tx.Error != nil || tx.Statement == nil {
+			return
+		}
+		tenant := TenantFrom(tx.Statement.Context)
+		if tenant == "" {
+			return
+		}
+
+		table, scoped := tenantScoped(tx.Statement, tenant)
+		if scoped {
+			return
+		}
+		atomic.AddInt64(&tenantViolations, 1)
+		fmt.Println(tenantViolationLine(operation, table, tenant, mode == config.TenantGuardReject))
+		if mode == config.TenantGuardReject {
+			tx.AddError(ErrTenantViolation)
+		}
+	}
+}
+
+// tenantScoped reports whether the statement is scoped to tenant, with the
+// workspace table it reads when it is not. Statements that don't touch a
+// workspace table are scoped
+func tenantScoped(stmt *gorm.Statement, tenant string) (string, bool) {
+	tables, rawTable := tenantTables()
+
+	if stmt.SQL.Len() > 0 {
+		sql := stmt.SQL.String()
+		match := rawTable.FindStringSubmatch(sql)
+		if match == nil {
+			return "", true
+		}
+		if !strings.Contains(strings.ToLower(sql), tenantColumn) {
+			return match[1], false
+		}
+		if strings.Contains(sql, "'"+tenant+"'") || hasTenant(stmt.Vars, tenant) {
+			return "", true
+		}
+		return match[1], false
+	}
+
+	if !tables[stmt.Table] {
+		return "", true
+	}
+	where, ok := stmt.Clauses["WHERE"].Expression.(clause.Where)
+	if !ok {
+		return stmt.Table, false
+	}
+	if scopedAll(where.Exprs, tenant) {
+		return "", true
+	}
+	return stmt.Table, false
+}
+
+// scopedAll reports whether the AND of exprs only matches rows of tenant,
+// gorm joins an Or condition to everything before it
+func scopedAll(exprs []clause.Expression, tenant string) bool {
+	scoped := false
+	for _, expr := range exprs {
+		if or, ok := expr.(clause.OrConditions); ok {
+			scoped = scoped && scopedAll(or.Exprs, tenant)
+			continue
+		}
+		scoped = scoped || scopedExpr(expr, tenant)
+	}
+	return scoped
+}
+
+func scopedExpr(expr clause.Expression, tenant string) bool {
+	switch e := expr.(type) {
+	case clause.Eq:
+		return isTenantColumn(e.Column) && isTenant(e.Value, tenant)
+	case clause.IN:
+		if !isTenantColumn(e.Column) || len(e.Values) == 0 {
+			return false
+		}
+		for _, value := range e.Values {
+			if !isTenant(value, tenant) {
+				return false
+			}
+		}
+		return true
+	case clause.Expr:
+		return strings.Contains(strings.ToLower(e.SQL), tenantColumn) && hasTenant(e.Vars, tenant)
+	case clause.NamedExpr:
+		return strings.Contains(strings.ToLower(e.SQL), tenantColumn) && hasTenant(e.Vars, tenant)
+	case clause.AndConditions:
+		return scopedAll(e.Exprs, tenant)
+	case clause.Where:
+		return scopedAll(e.Exprs, tenant)
+	}
+	return false
+}
+
+func isTenantColumn(column interface{}) bool {
+	var name string
+	switch c := column.(type) {
+	case string:
+		name = c
+	case clause.Column:
+		name = c.Name
+	default:
+		return false
+	}
+	name = strings.Trim(name[strings.LastIndex(name, ".")+1:], `"`)
+	return strings.EqualFold(name, tenantColumn)
+}
+
+func isTenant(value interface{}, tenant string) bool {
+	switch v := value.(type) {
+	case string:
+		return v == tenant
+	case *string:
+		return v != nil && *v == tenant
+	}
+	return false
+}
+
+func hasTenant(vars []interface{}, tenant string) bool {
+	for _, v := range vars {
+		if isTenant(v, tenant) {
+			return true
+		}
+		if values, ok := v.([]string); ok && len(values) > 0 {
+			all := true
+			for _, value := range values {
+				all = all && value == tenant
+			}
+			if all {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func tenantViolationLine(operation, table, tenant string, rejected bool) string {
+	action := "logged"
+	if rejected {
+		action = "rejected"
+	}
+	return fmt.Sprintf("[tenant] %s %s on %s without %s = %s at %s", action, operation, table, tenantColumn, tenant, tenantCaller())
+}
+
+// tenantCaller is the first frame outside of gorm and this file, the db
+// method that built the query
+func tenantCaller() string {
+	pcs := make([]uintptr, 32)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(3, pcs)])
+	for {
+		frame, more := frames.Next()
+		if !strings.Contains(frame.File, "gorm.io/") && !strings.HasSuffix(frame.File, "db/tenant.go") {
+			return fmt.Sprintf("%s:%d", frame.File, frame.Line)
+		}
+		if !more {
+			return "unknown"
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestTenantScoped(t *testing.T) {
+	conn, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{DryRun: true, DisableAutomaticPing: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tenant := "workspace-1"
+
+	model := func(tx *gorm.DB) *gorm.Statement {
+		if err := tx.Statement.Parse(tx.Statement.Model); err != nil {
+			t.Fatal(err)
+		}
+		return tx.Statement
+	}
+
+	tests := []struct {
+		name   string
+		stmt   *gorm.Statement
+		table  string
+		scoped bool
+	}{
+		{"scoped by an expression", model(conn.Model(&NewBounty{}).Where("workspace_uuid = ?", tenant).Where("show = ?", true)), "", true},
+		{"scoped by a struct", model(conn.Model(&WorkspaceFeatures{}).Where(&WorkspaceFeatures{WorkspaceUuid: tenant})), "", true},
+		{"scoped by a map", model(conn.Model(&TimeEntry{}).Where(map[string]interface{}{"workspace_uuid": tenant})), "", true},
+		{"scoped by a list of the tenant", model(conn.Model(&NewInvoiceList{}).Where("workspace_uuid IN ?", []string{tenant})), "", true},
+		{"not scoped", model(conn.Model(&NewBounty{}).Where("show = ?", true)), "bounty", false},
+		{"no conditions", model(conn.Model(&WorkspaceArtifact{})), "workspace_artifacts", false},
+		{"another workspace", model(conn.Model(&NewBounty{}).Where("workspace_uuid = ?", "workspace-2")), "bounty", false},
+		{"widened by or", model(conn.Model(&NewBounty{}).Where("workspace_uuid = ?", tenant).Or("show = ?", true)), "bounty", false},
+		{"or of scoped conditions", model(conn.Model(&NewBounty{}).Where("workspace_uuid = ? AND show = ?", tenant, true).Or("workspace_uuid = ? AND assignee = ?", tenant, "")), "", true},
+		{"not a workspace table", model(conn.Model(&Person{}).Where("owner_pub_key = ?", "pubkey")), "", true},
+		{"raw scoped", conn.Raw("SELECT * FROM bounty WHERE workspace_uuid = ?", tenant).Statement, "", true},
+		{"raw scoped by a literal", conn.Raw("SELECT COUNT(*) FROM bounty WHERE workspace_uuid = '" + tenant + "'").Statement, "", true},
+		{"raw not scoped", conn.Raw("SELECT * FROM bounty WHERE show = ?", true).Statement, "bounty", false},
+		{"raw join not scoped", conn.Raw("SELECT p.* FROM people p JOIN workspace_users wu ON wu.owner_pub_key = p.owner_pub_key").Statement, "workspace_users", false},
+		{"raw not a workspace table", conn.Raw("SELECT * FROM tribes WHERE uuid = ?", "tribe").Statement, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table, scoped := tenantScoped(tt.stmt, tenant)
+			assert.Equal(t, tt.scoped, scoped)
+			assert.Equal(t, tt.table, table)
+		})
+	}
+}
+
+func TestTenantFrom(t *testing.T) {
+	assert.Equal(t, "", TenantFrom(context.Background()))
+
+	tenant := ""
+	ctx := WithTenant(context.Background(), func() string { return tenant })
+	tenant = "workspace-1"
+	assert.Equal(t, "workspace-1", TenantFrom(ctx))
+}
+
+func TestTenantViolationLine(t *testing.T) {
+	line := tenantViolationLine("query", "bounty", "workspace-1", true)
+	assert.Regexp(t, `^\[tenant\] rejected query on bounty without workspace_uuid = workspace-1 at .+:\d+$`, line)
+}
@@ -1,72 +1,46 @@
 package db
 
 import (
-	"fmt"
-
-	"github.com/rs/xid"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
 
-var TestDB database
-
-func InitTestDB() {
-	rdsHost := "172.17.0.1"
-	rdsPort := fmt.Sprintf("%d", 5532)
-	rdsDbName := "test_db"
-	rdsUsername := "test_user"
-	rdsPassword := "test_password"
-	dbURL := fmt.Sprintf("postgres://%s:%s@%s:%s/%s", rdsUsername, rdsPassword, rdsHost, rdsPort, rdsDbName)
-
-	if dbURL == "" {
-		panic("TESTDB URL is not set")
-	}
-
-	var err error
-
-	db, err := gorm.Open(postgres.New(postgres.Config{
+// ConnectTestDB opens a test database and brings its schema up to date, it
+// is used by the dbtest harness
+func ConnectTestDB(dbURL string) (database, error) {
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
 		DSN:                  dbURL,
 		PreferSimpleProtocol: true,
 	}), &gorm.Config{})
-
 	if err != nil {
-		panic(err)
+		return database{}, err
 	}
-
-	TestDB.db = db
-
-	fmt.Println("DB CONNECTED")
+	if err := gormDB.Use(versionPlugin{}); err != nil {
+		return database{}, err
+	}
+	db := database{db: gormDB}
 
 	// migrate table changes
-	db.AutoMigrate(&Tribe{})
-	db.AutoMigrate(&Person{})
-	db.AutoMigrate(&Channel{})
-	db.AutoMigrate(&LeaderBoard{})
-	db.AutoMigrate(&ConnectionCodes{})
-	db.AutoMigrate(&BountyRoles{})
-	db.AutoMigrate(&UserInvoiceData{})
-	db.AutoMigrate(&WorkspaceRepositories{})
-	db.AutoMigrate(&WorkspaceFeatures{})
-	db.AutoMigrate(&FeaturePhase{})
-	db.AutoMigrate(&FeatureStory{})
-	db.AutoMigrate(&NewBounty{})
-	db.AutoMigrate(&BudgetHistory{})
-	db.AutoMigrate(&NewPaymentHistory{})
-	db.AutoMigrate(&NewInvoiceList{})
-	db.AutoMigrate(&NewBountyBudget{})
-	db.AutoMigrate(&Workspace{})
-	db.AutoMigrate(&WorkspaceUsers{})
-	db.AutoMigrate(&WorkspaceUserRoles{})
-	db.AutoMigrate(&Bot{})
-
-	people := TestDB.GetAllPeople()
-	for _, p := range people {
-		if p.Uuid == "" {
-			TestDB.AddUuidToPerson(p.ID, xid.New().String())
-		}
+	if _, err := db.MigrateUp(); err != nil {
+		return database{}, err
 	}
+	gormDB.AutoMigrate(&NewBounty{})
+	gormDB.AutoMigrate(&BudgetHistory{})
+	gormDB.AutoMigrate(&NewPaymentHistory{})
+	gormDB.AutoMigrate(&NewInvoiceList{})
+	gormDB.AutoMigrate(&NewBountyBudget{})
+	gormDB.AutoMigrate(&Workspace{})
+	gormDB.AutoMigrate(&WorkspaceUsers{})
+	gormDB.AutoMigrate(&WorkspaceUserRoles{})
+	gormDB.AutoMigrate(&Bot{})
+	return db, nil
 }
 
-func CleanDB() {
-	TestDB.db.Exec("DELETE FROM people")
+// Close closes the connections of the database
+func (db database) Close() error {
+	sqlDB, err := db.db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.Close()
 }
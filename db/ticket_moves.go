@@ -0,0 +1,95 @@
+package db
+
+import (
+	"errors"
+
+	"gorm.io/gorm/clause"
+)
+
+var ErrTicketNotInPhase = errors.New("the bounty is not in a phase")
+
+// phaseTickets are the bounties of a phase in their order, locked until the
+// transaction ends
+func (db database) phaseTickets(phaseUuid string, except uint) ([]NewBounty, error) {
+	tickets := []NewBounty{}
+	err := db.db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("phase_uuid = ? AND id != ?", phaseUuid, except).
+		Order("phase_priority ASC").Order("id ASC").Find(&tickets).Error
+	return tickets, err
+}
+
+// placeTicket puts a bounty at a place among the bounties of a phase, from
+// 1 with 0 or a place past the end putting it last
+func placeTicket(tickets []NewBounty, bounty NewBounty, position int) []NewBounty {
+	if position < 1 || position > len(tickets) {
+		position = len(tickets) + 1
+	}
+	placed := append([]NewBounty{}, tickets[:position-1]...)
+	placed = append(placed, bounty)
+	return append(placed, tickets[position-1:]...)
+}
+
+// resequenceTickets numbers the bounties of a phase from 1 in their order,
+// and returns the ones whose phase or number changed
+func resequenceTickets(phaseUuid string, tickets []NewBounty) []NewBounty {
+	changed := []NewBounty{}
+	for i, ticket := range tickets {
+		if ticket.PhaseUuid == phaseUuid && ticket.PhasePriority == i+1 {
+			continue
+		}
+		ticket.PhaseUuid = phaseUuid
+		ticket.PhasePriority = i + 1
+		changed = append(changed, ticket)
+	}
+	return changed
+}
+
+func (db database) sequenceTickets(phaseUuid string, tickets []NewBounty) error {
+	for _, ticket := range resequenceTickets(phaseUuid, tickets) {
+		err := db.db.Model(&NewBounty{}).Where("id = ?", ticket.ID).Updates(map[string]interface{}{
+			"phase_uuid":     ticket.PhaseUuid,
+			"phase_priority": ticket.PhasePriority,
+		}).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MoveTicket moves a bounty to a place in a phase, from 1 with 0 putting it
+// last. The bounties of the phase it leaves and of the one it joins are
+// numbered again from 1 in the same transaction, so neither has gaps or
+// twice the same number
+func (db database) MoveTicket(bountyId uint, phaseUuid string, position int) (NewBounty, error) {
+	bounty := NewBounty{}
+	err := db.transaction(func(tx database) error {
+		if err := tx.db.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", bountyId).First(&bounty).Error; err != nil {
+			return err
+		}
+		if bounty.PhaseUuid == "" {
+			return ErrTicketNotInPhase
+		}
+		from := bounty.PhaseUuid
+
+		tickets, err := tx.phaseTickets(phaseUuid, bounty.ID)
+		if err != nil {
+			return err
+		}
+		if err := tx.sequenceTickets(phaseUuid, placeTicket(tickets, bounty, position)); err != nil {
+			return err
+		}
+
+		if from != phaseUuid {
+			left, err := tx.phaseTickets(from, bounty.ID)
+			if err != nil {
+				return err
+			}
+			if err := tx.sequenceTickets(from, left); err != nil {
+				return err
+			}
+		}
+		return tx.db.Where("id = ?", bounty.ID).First(&bounty).Error
+	})
+	return bounty, err
+}
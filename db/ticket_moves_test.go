@@ -0,0 +1,41 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlaceTicket(t *testing.T) {
+	tickets := []NewBounty{{ID: 1}, {ID: 2}, {ID: 3}}
+	ids := func(bounties []NewBounty) []uint {
+		list := []uint{}
+		for _, b := range bounties {
+			list = append(list, b.ID)
+		}
+		return list
+	}
+
+	assert.Equal(t, []uint{9, 1, 2, 3}, ids(placeTicket(tickets, NewBounty{ID: 9}, 1)))
+	assert.Equal(t, []uint{1, 9, 2, 3}, ids(placeTicket(tickets, NewBounty{ID: 9}, 2)))
+	assert.Equal(t, []uint{1, 2, 3, 9}, ids(placeTicket(tickets, NewBounty{ID: 9}, 0)))
+	assert.Equal(t, []uint{1, 2, 3, 9}, ids(placeTicket(tickets, NewBounty{ID: 9}, 12)))
+	assert.Equal(t, []uint{9}, ids(placeTicket([]NewBounty{}, NewBounty{ID: 9}, 3)))
+	assert.Equal(t, []uint{1, 2, 3}, ids(tickets))
+}
+
+func TestResequenceTickets(t *testing.T) {
+	tickets := []NewBounty{
+		{ID: 1, PhaseUuid: "phase-b", PhasePriority: 1},
+		{ID: 9, PhaseUuid: "phase-a", PhasePriority: 2},
+		{ID: 2, PhaseUuid: "phase-b", PhasePriority: 2},
+		{ID: 3, PhaseUuid: "phase-b", PhasePriority: 5},
+	}
+
+	assert.Equal(t, []NewBounty{
+		{ID: 9, PhaseUuid: "phase-b", PhasePriority: 2},
+		{ID: 2, PhaseUuid: "phase-b", PhasePriority: 3},
+		{ID: 3, PhaseUuid: "phase-b", PhasePriority: 4},
+	}, resequenceTickets("phase-b", tickets))
+	assert.Empty(t, resequenceTickets("phase-b", []NewBounty{{ID: 1, PhaseUuid: "phase-b", PhasePriority: 1}}))
+}
@@ -0,0 +1,91 @@
+package db
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"gorm.io/gorm/clause"
+)
+
+var ErrTicketStatusChanged = errors.New("a ticket was changed meanwhile")
+
+// TicketStatusOf is the status of a ticket on the board
+func TicketStatusOf(bounty NewBounty) string {
+	switch {
+	case bounty.Paid:
+		return TicketPaid
+	case bounty.Assignee == "":
+		return TicketOpen
+	case bounty.Completed:
+		return TicketCompleted
+	}
+	return TicketAssigned
+}
+
+// ticketTransitions are the statuses a ticket can be moved to from each
+// status. Assigning a ticket needs a person and paying it goes through the
+// payment routes, so an open or paid ticket can't be moved
+var ticketTransitions = map[string][]string{
+	TicketAssigned:  {TicketOpen, TicketCompleted},
+	TicketCompleted: {TicketAssigned},
+}
+
+// TicketTransitionAllowed reports if a ticket can be moved from a status to
+// another
+func TicketTransitionAllowed(from string, to string) bool {
+	for _, status := range ticketTransitions[from] {
+		if status == to {
+			return true
+		}
+	}
+	return false
+}
+
+// ticketStatusUpdates are the columns that move an assigned or completed
+// ticket to a status
+func ticketStatusUpdates(to string, now time.Time) map[string]interface{} {
+	switch to {
+	case TicketOpen:
+		return map[string]interface{}{"assignee": "", "assigned_date": nil, "updated": &now}
+	case TicketCompleted:
+		return map[string]interface{}{"completed": true, "completion_date": &now, "updated": &now}
+	}
+	return map[string]interface{}{"completed": false, "completion_date": nil, "updated": &now}
+}
+
+// SetTicketStatuses applies the changes to the tickets of a phase in one
+// transaction. The tickets are locked in the order of their ids and each
+// has to still be in the phase with the status it is moved from, otherwise
+// none of the changes is applied and ErrTicketStatusChanged is returned.
+// The updated bounties come back in the order of their ids
+func (db database) SetTicketStatuses(phaseUuid string, changes []TicketStatusChange) ([]NewBounty, error) {
+	sorted := append([]TicketStatusChange{}, changes...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].ID < sorted[j].ID })
+
+	updated := []NewBounty{}
+	now := time.Now()
+	err := db.transaction(func(tx database) error {
+		for _, change := range sorted {
+			bounty := NewBounty{}
+			if err := tx.db.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", change.ID).First(&bounty).Error; err != nil {
+				return err
+			}
+			if bounty.PhaseUuid != phaseUuid || TicketStatusOf(bounty) != change.From || !TicketTransitionAllowed(change.From, change.To) {
+				return ErrTicketStatusChanged
+			}
+			if err := tx.db.Model(&NewBounty{}).Where("id = ?", bounty.ID).Updates(ticketStatusUpdates(change.To, now)).Error; err != nil {
+				return err
+			}
+			if err := tx.db.Where("id = ?", bounty.ID).First(&bounty).Error; err != nil {
+				return err
+			}
+			updated = append(updated, bounty)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
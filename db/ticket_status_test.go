@@ -0,0 +1,24 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTicketStatusOf(t *testing.T) {
+	assert.Equal(t, TicketOpen, TicketStatusOf(NewBounty{}))
+	assert.Equal(t, TicketAssigned, TicketStatusOf(NewBounty{Assignee: "hunter"}))
+	assert.Equal(t, TicketCompleted, TicketStatusOf(NewBounty{Assignee: "hunter", Completed: true}))
+	assert.Equal(t, TicketPaid, TicketStatusOf(NewBounty{Assignee: "hunter", Completed: true, Paid: true}))
+}
+
+func TestTicketTransitionAllowed(t *testing.T) {
+	assert.True(t, TicketTransitionAllowed(TicketAssigned, TicketOpen))
+	assert.True(t, TicketTransitionAllowed(TicketAssigned, TicketCompleted))
+	assert.True(t, TicketTransitionAllowed(TicketCompleted, TicketAssigned))
+	assert.False(t, TicketTransitionAllowed(TicketOpen, TicketAssigned))
+	assert.False(t, TicketTransitionAllowed(TicketCompleted, TicketOpen))
+	assert.False(t, TicketTransitionAllowed(TicketPaid, TicketCompleted))
+	assert.False(t, TicketTransitionAllowed(TicketCompleted, TicketPaid))
+}
@@ -0,0 +1,91 @@
+package db
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+func (db database) CreateTimeEntry(entry TimeEntry) (TimeEntry, error) {
+	now := time.Now()
+	entry.Created = &now
+	err := db.db.Create(&entry).Error
+	return entry, err
+}
+
+func (db database) GetTimeEntry(uuid string) (TimeEntry, error) {
+	entry := TimeEntry{}
+	err := db.db.Where("uuid = ?", uuid).First(&entry).Error
+	return entry, err
+}
+
+// GetRunningTimeEntry returns the timer a person has running, a person runs
+// one timer at a time
+func (db database) GetRunningTimeEntry(pubkey string) (TimeEntry, error) {
+	entry := TimeEntry{}
+	err := db.db.Where("pub_key = ? AND ended IS NULL", pubkey).First(&entry).Error
+	return entry, err
+}
+
+// StopTimeEntry ends a running timer, the started minute counts as a
+// whole one
+func (db database) StopTimeEntry(entry TimeEntry, ended time.Time) (TimeEntry, error) {
+	minutes := uint(math.Ceil(ended.Sub(*entry.Started).Minutes()))
+	result := db.db.Model(&TimeEntry{}).
+		Where("uuid = ? AND ended IS NULL", entry.Uuid).
+		Updates(map[string]interface{}{"ended": &ended, "minutes": minutes})
+	if result.Error != nil {
+		return entry, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return entry, errors.New("the timer is not running")
+	}
+	entry.Ended = &ended
+	entry.Minutes = minutes
+	return entry, nil
+}
+
+// GetBountyTimeEntries lists the time entries of a bounty, newest first
+func (db database) GetBountyTimeEntries(bountyId uint) []TimeEntry {
+	entries := []TimeEntry{}
+	db.db.Where("bounty_id = ?", bountyId).Order("started DESC").Find(&entries)
+	return entries
+}
+
+func (db database) DeleteTimeEntry(uuid string) error {
+	return db.db.Where("uuid = ?", uuid).Delete(&TimeEntry{}).Error
+}
+
+// GetBountyTrackedMinutes sums the ended time entries of a person on a
+// bounty
+func (db database) GetBountyTrackedMinutes(bountyId uint, pubkey string) uint {
+	var minutes uint
+	db.db.Model(&TimeEntry{}).
+		Where("bounty_id = ? AND pub_key = ? AND ended IS NOT NULL", bountyId, pubkey).
+		Select("COALESCE(SUM(minutes), 0)").Row().Scan(&minutes)
+	return minutes
+}
+
+// GetTimeTotals sums the ended time entries per person and bounty, of a
+// workspace or of a person, started between from and to when they are set
+func (db database) GetTimeTotals(workspaceUuid string, pubkey string, from *time.Time, to *time.Time) []TimeTotal {
+	totals := []TimeTotal{}
+	query := db.db.Model(&TimeEntry{}).Where("ended IS NOT NULL")
+	if workspaceUuid != "" {
+		query = query.Where("workspace_uuid = ?", workspaceUuid)
+	}
+	if pubkey != "" {
+		query = query.Where("pub_key = ?", pubkey)
+	}
+	if from != nil {
+		query = query.Where("started >= ?", from)
+	}
+	if to != nil {
+		query = query.Where("started < ?", to)
+	}
+	query.Select("pub_key, bounty_id, workspace_uuid, SUM(minutes) AS minutes, COUNT(*) AS entries").
+		Group("pub_key, bounty_id, workspace_uuid").
+		Order("pub_key, bounty_id").
+		Scan(&totals)
+	return totals
+}
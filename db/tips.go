@@ -0,0 +1,55 @@
+package db
+
+import (
+	"errors"
+	"time"
+)
+
+// CreateTipInvoice saves the invoice the sender of a tip pays, with the
+// receiver it is sent on to and the tip waiting in the payment history
+func (db database) CreateTipInvoice(invoice NewInvoiceList, userData UserInvoiceData, payment NewPaymentHistory) error {
+	return db.transaction(func(tx database) error {
+		if err := tx.db.Create(&invoice).Error; err != nil {
+			return err
+		}
+		if err := tx.db.Create(&userData).Error; err != nil {
+			return err
+		}
+		return tx.db.Create(&payment).Error
+	})
+}
+
+// SettleTip marks the tip of an invoice as paid, and the invoice as
+// settled. A tip settles once
+func (db database) SettleTip(paymentRequest string) (NewPaymentHistory, error) {
+	payment := NewPaymentHistory{}
+	err := db.transaction(func(tx database) error {
+		now := time.Now()
+		result := tx.db.Model(&NewPaymentHistory{}).
+			Where("payment_request = ? AND payment_type = ? AND status = false", paymentRequest, Tip).
+			Updates(map[string]interface{}{"status": true, "updated": &now})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("the tip is already settled")
+		}
+
+		if err := tx.db.Model(&NewInvoiceList{}).Where("payment_request = ?", paymentRequest).
+			Updates(map[string]interface{}{"status": true, "updated": &now}).Error; err != nil {
+			return err
+		}
+		return tx.db.Where("payment_request = ? AND payment_type = ?", paymentRequest, Tip).First(&payment).Error
+	})
+	return payment, err
+}
+
+// MarkTipState marks the tip of an invoice PaymentPending, when the relay
+// was sent its keysend but didn't answer, or PaymentFailed, when the relay
+// refused it. The invoice stays settled, so the tip is not sent again
+func (db database) MarkTipState(paymentRequest string, state string) error {
+	now := time.Now()
+	return db.db.Model(&NewPaymentHistory{}).
+		Where("payment_request = ? AND payment_type = ? AND status = false", paymentRequest, Tip).
+		Updates(map[string]interface{}{"state": state, "updated": &now}).Error
+}
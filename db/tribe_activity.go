@@ -0,0 +1,86 @@
+package db
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/stakwork/sphinx-tribes/utils"
+)
+
+// ErrUnknownActivity is the error of asking for a kind of activity a tribe
+// doesn't have
+var ErrUnknownActivity = errors.New("types are bounties, tickets, channels or payments")
+
+// tribeActivitySelects are the selects of each kind of the activity of a
+// tribe, by the name of the kind in the types query param. Tickets are the
+// bounties of a phase, and only the bounties shown on the board are public
+var tribeActivitySelects = map[string]string{
+	"bounties": `SELECT 'bounty' AS kind, id, title, owner_id AS pubkey, price AS amount, to_timestamp(created) AS created
+		FROM bounty WHERE tribe = @tribe AND show = true AND COALESCE(phase_uuid, '') = '' AND deleted_at IS NULL`,
+	"tickets": `SELECT 'ticket' AS kind, id, title, owner_id AS pubkey, price AS amount, to_timestamp(created) AS created
+		FROM bounty WHERE tribe = @tribe AND show = true AND COALESCE(phase_uuid, '') <> '' AND deleted_at IS NULL`,
+	"channels": `SELECT 'channel' AS kind, id, name AS title, '' AS pubkey, 0 AS amount, created
+		FROM channels WHERE tribe_uuid = @tribe AND deleted = false AND deleted_at IS NULL AND created IS NOT NULL`,
+	"payments": `SELECT 'payment' AS kind, bounty.id, bounty.title, payment_histories.receiver_pubkey AS pubkey, payment_histories.amount, payment_histories.created
+		FROM payment_histories JOIN bounty ON bounty.id = payment_histories.bounty_id
+		WHERE bounty.tribe = @tribe AND bounty.show = true AND bounty.deleted_at IS NULL
+		AND payment_histories.payment_type = 'payment' AND payment_histories.status = true`,
+}
+
+// the union is sorted as a whole, so a page is at most this long
+const maxTribeActivity = 100
+
+// TribeActivityKinds are the kinds of the activity of a tribe, in the order
+// of the types query param
+var TribeActivityKinds = []string{"bounties", "tickets", "channels", "payments"}
+
+// tribeActivityQuery is the union of the selects of kinds, all of them when
+// kinds is empty
+func tribeActivityQuery(kinds []string) (string, error) {
+	if len(kinds) == 0 {
+		kinds = TribeActivityKinds
+	}
+	selects := []string{}
+	for _, kind := range kinds {
+		query, ok := tribeActivitySelects[kind]
+		if !ok {
+			return "", ErrUnknownActivity
+		}
+		selects = append(selects, query)
+	}
+	return strings.Join(selects, "\nUNION ALL\n"), nil
+}
+
+// GetTribeActivity returns the bounties, tickets, channel creations and
+// payments of a tribe as one timeline, newest first, of the given kinds or
+// of every kind when kinds is empty. A page has 50 entries by default and
+// 100 at most
+func (db database) GetTribeActivity(tribeUuid string, kinds []string, r *http.Request) ([]TribeActivity, error) {
+	activity := []TribeActivity{}
+	union, err := tribeActivityQuery(kinds)
+	if err != nil {
+		return activity, err
+	}
+	offset, limit, _, _, _ := utils.GetPaginationParams(r)
+	if r.URL.Query().Get("limit") == "" || limit < 1 {
+		limit = 50
+	}
+	if limit > maxTribeActivity {
+		limit = maxTribeActivity
+	}
+
+	err = db.forRequest(r).Raw("SELECT * FROM ("+union+") AS activity ORDER BY created DESC, kind ASC, id DESC LIMIT @limit OFFSET @offset",
+		map[string]interface{}{"tribe": tribeUuid, "limit": limit, "offset": offset}).Scan(&activity).Error
+	return activity, err
+}
+
+func (db database) GetTribeActivityCount(tribeUuid string, kinds []string) int64 {
+	var count int64
+	union, err := tribeActivityQuery(kinds)
+	if err != nil {
+		return 0
+	}
+	db.db.Raw("SELECT COUNT(*) FROM ("+union+") AS activity", map[string]interface{}{"tribe": tribeUuid}).Scan(&count)
+	return count
+}
@@ -0,0 +1,22 @@
+package db
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTribeActivityQuery(t *testing.T) {
+	query, err := tribeActivityQuery(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, len(TribeActivityKinds)-1, strings.Count(query, "UNION ALL"))
+
+	query, err = tribeActivityQuery([]string{"channels"})
+	assert.NoError(t, err)
+	assert.NotContains(t, query, "UNION ALL")
+	assert.Contains(t, query, "FROM channels")
+
+	_, err = tribeActivityQuery([]string{"channels", "mentions"})
+	assert.Equal(t, ErrUnknownActivity, err)
+}
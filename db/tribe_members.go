@@ -0,0 +1,119 @@
+package db
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/utils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrTribeMemberKicked = errors.New("the member was kicked from the tribe")
+	ErrNotTribeMember    = errors.New("not a member of the tribe")
+)
+
+// countTribeMembers sets the joined members of a tribe to its members that
+// were not kicked
+func (db database) countTribeMembers(tribeUuid string) error {
+	return db.db.Model(&Tribe{}).Where("uuid = ?", tribeUuid).UpdateColumn("joined_members",
+		gorm.Expr("(SELECT COUNT(*) FROM tribe_members WHERE tribe_uuid = ? AND kicked IS NULL)", tribeUuid)).Error
+}
+
+// tribeMember is the membership of a pubkey in a tribe, kicked or not,
+// locked until the transaction ends
+func (db database) tribeMember(tribeUuid string, pubkey string) (TribeMember, error) {
+	member := TribeMember{}
+	err := db.db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("tribe_uuid = ? AND pub_key = ?", tribeUuid, pubkey).Limit(1).Find(&member).Error
+	return member, err
+}
+
+// JoinTribe makes a pubkey a member of a tribe, joining again only updates
+// the alias. A kicked member can't join again
+func (db database) JoinTribe(tribeUuid string, pubkey string, alias string) (TribeMember, error) {
+	member := TribeMember{}
+	err := db.transaction(func(tx database) error {
+		var err error
+		if member, err = tx.tribeMember(tribeUuid, pubkey); err != nil {
+			return err
+		}
+		if member.Kicked != nil {
+			return ErrTribeMemberKicked
+		}
+
+		if member.ID != 0 {
+			member.Alias = alias
+			return tx.db.Model(&TribeMember{}).Where("id = ?", member.ID).Update("alias", alias).Error
+		}
+		now := time.Now()
+		member = TribeMember{TribeUuid: tribeUuid, PubKey: pubkey, Alias: alias, Joined: &now}
+		if err := tx.db.Create(&member).Error; err != nil {
+			return err
+		}
+		return tx.countTribeMembers(tribeUuid)
+	})
+	return member, err
+}
+
+// LeaveTribe ends the membership of a pubkey in a tribe
+func (db database) LeaveTribe(tribeUuid string, pubkey string) error {
+	return db.transaction(func(tx database) error {
+		member, err := tx.tribeMember(tribeUuid, pubkey)
+		if err != nil {
+			return err
+		}
+		if member.ID == 0 || member.Kicked != nil {
+			return ErrNotTribeMember
+		}
+		if err := tx.db.Delete(&TribeMember{}, member.ID).Error; err != nil {
+			return err
+		}
+		return tx.countTribeMembers(tribeUuid)
+	})
+}
+
+// KickTribeMember removes a member from a tribe for good
+func (db database) KickTribeMember(tribeUuid string, pubkey string) error {
+	return db.transaction(func(tx database) error {
+		member, err := tx.tribeMember(tribeUuid, pubkey)
+		if err != nil {
+			return err
+		}
+		if member.ID == 0 || member.Kicked != nil {
+			return ErrNotTribeMember
+		}
+		if err := tx.db.Model(&TribeMember{}).Where("id = ?", member.ID).Update("kicked", time.Now()).Error; err != nil {
+			return err
+		}
+		return tx.countTribeMembers(tribeUuid)
+	})
+}
+
+// GetTribeMember is the membership of a pubkey in a tribe, the zero value
+// when there is none
+func (db database) GetTribeMember(tribeUuid string, pubkey string) TribeMember {
+	member := TribeMember{}
+	db.db.Where("tribe_uuid = ? AND pub_key = ?", tribeUuid, pubkey).Limit(1).Find(&member)
+	return member
+}
+
+// GetTribeMembers returns the members of a tribe that were not kicked, the
+// earliest to join first, paginated by page and limit
+func (db database) GetTribeMembers(tribeUuid string, r *http.Request) ([]TribeMember, error) {
+	members := []TribeMember{}
+	offset, limit, _, _, _ := utils.GetPaginationParams(r)
+
+	query := db.db.Model(&TribeMember{}).Where("tribe_uuid = ? AND kicked IS NULL", tribeUuid)
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if offset > 0 {
+		query = query.Offset(offset)
+	}
+
+	err := query.Order("joined ASC, id ASC").Find(&members).Error
+	return members, err
+}
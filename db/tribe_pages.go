@@ -0,0 +1,129 @@
+package db
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidCursor = errors.New("the cursor is not a cursor of this list")
+	ErrUnpagedSort   = errors.New("the list can't be paged in this order")
+)
+
+// the columns tribes can be paged by, NULLs sort as the zero value so the
+// position of every row is known
+var tribeCursorColumns = map[string]string{
+	"created":      "COALESCE(created, 'epoch'::timestamptz)",
+	"updated":      "COALESCE(updated, 'epoch'::timestamptz)",
+	"last_active":  "COALESCE(last_active, 0)",
+	"member_count": "COALESCE(member_count, 0)",
+	"name":         "COALESCE(name, '')",
+}
+
+// tribeCursor is the position after the last tribe of a page. It holds the
+// sort of the list, so the next pages only need the cursor
+type tribeCursor struct {
+	SortBy    string `json:"s"`
+	Direction string `json:"d"`
+	Value     string `json:"v"`
+	Uuid      string `json:"u"`
+}
+
+func (c tribeCursor) encode() string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodeTribeCursor(after string) (tribeCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(after)
+	if err != nil {
+		return tribeCursor{}, ErrInvalidCursor
+	}
+	cursor := tribeCursor{}
+	if err := json.Unmarshal(b, &cursor); err != nil || cursor.Uuid == "" {
+		return tribeCursor{}, ErrInvalidCursor
+	}
+	if _, ok := tribeCursorColumns[cursor.SortBy]; !ok || (cursor.Direction != "asc" && cursor.Direction != "desc") {
+		return tribeCursor{}, ErrInvalidCursor
+	}
+	return cursor, nil
+}
+
+// tribeSortValue is the value of the sort column of a tribe, as the cursor
+// keeps it
+func tribeSortValue(tribe Tribe, sortBy string) string {
+	timestamp := func(t *time.Time) string {
+		if t == nil {
+			return time.Unix(0, 0).UTC().Format(time.RFC3339Nano)
+		}
+		return t.UTC().Format(time.RFC3339Nano)
+	}
+	switch sortBy {
+	case "created":
+		return timestamp(tribe.Created)
+	case "updated":
+		return timestamp(tribe.Updated)
+	case "last_active":
+		return strconv.FormatInt(tribe.LastActive, 10)
+	case "member_count":
+		return strconv.FormatUint(tribe.MemberCount, 10)
+	}
+	return tribe.Name
+}
+
+// GetListedTribesPaginated returns the listed tribes after the cursor, and
+// the cursor of the next page or "" on the last one. Tribes are paged by
+// their sort column and uuid, so a page never repeats or skips a tribe
+// when tribes are added meanwhile. The first page takes the sortBy and
+// direction of r, the cursor keeps them for the next ones
+func (db database) GetListedTribesPaginated(r *http.Request, limit int, after string) ([]Tribe, string, error) {
+	cursor := tribeCursor{SortBy: "created", Direction: "desc"}
+	if after != "" {
+		var err error
+		if cursor, err = decodeTribeCursor(after); err != nil {
+			return nil, "", err
+		}
+	} else {
+		keys := r.URL.Query()
+		if sortBy := keys.Get("sortBy"); sortBy != "" {
+			cursor.SortBy = sortBy
+		}
+		if direction := strings.ToLower(keys.Get("direction")); direction != "" {
+			cursor.Direction = direction
+		}
+		if _, ok := tribeCursorColumns[cursor.SortBy]; !ok || (cursor.Direction != "asc" && cursor.Direction != "desc") {
+			return nil, "", ErrUnpagedSort
+		}
+	}
+
+	column := tribeCursorColumns[cursor.SortBy]
+	query := db.listedTribesQuery(r)
+	if after != "" {
+		compare := "<"
+		if cursor.Direction == "asc" {
+			compare = ">"
+		}
+		query = query.Where("("+column+", uuid) "+compare+" (?, ?)", cursor.Value, cursor.Uuid)
+	}
+
+	tribes := []Tribe{}
+	err := query.Order(column + " " + cursor.Direction).Order("uuid " + cursor.Direction).Limit(limit + 1).Find(&tribes).Error
+	if err != nil {
+		return nil, "", err
+	}
+	if len(tribes) <= limit {
+		db.attachTribeCategories(tribes)
+		return tribes, "", nil
+	}
+
+	tribes = tribes[:limit]
+	db.attachTribeCategories(tribes)
+	last := tribes[limit-1]
+	next := tribeCursor{SortBy: cursor.SortBy, Direction: cursor.Direction, Value: tribeSortValue(last, cursor.SortBy), Uuid: last.UUID}
+	return tribes, next.encode(), nil
+}
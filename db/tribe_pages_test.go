@@ -0,0 +1,42 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTribeCursor(t *testing.T) {
+	t.Run("should decode the cursor it encodes", func(t *testing.T) {
+		cursor := tribeCursor{SortBy: "member_count", Direction: "desc", Value: "12", Uuid: "tribe-uuid"}
+
+		decoded, err := decodeTribeCursor(cursor.encode())
+
+		assert.NoError(t, err)
+		assert.Equal(t, cursor, decoded)
+	})
+
+	t.Run("should refuse cursors it did not make", func(t *testing.T) {
+		for _, after := range []string{
+			"not base64!",
+			tribeCursor{SortBy: "owner_pub_key", Direction: "desc", Uuid: "tribe-uuid"}.encode(),
+			tribeCursor{SortBy: "created", Direction: "sideways", Uuid: "tribe-uuid"}.encode(),
+			tribeCursor{SortBy: "created", Direction: "asc"}.encode(),
+		} {
+			_, err := decodeTribeCursor(after)
+			assert.Equal(t, ErrInvalidCursor, err, after)
+		}
+	})
+
+	t.Run("should keep the sort value of the last tribe", func(t *testing.T) {
+		created := time.Date(2026, time.October, 15, 10, 30, 0, 123456000, time.UTC)
+		tribe := Tribe{UUID: "tribe-uuid", Name: "Sphinx", Created: &created, LastActive: 1700000000, MemberCount: 42}
+
+		assert.Equal(t, "2026-10-15T10:30:00.123456Z", tribeSortValue(tribe, "created"))
+		assert.Equal(t, "1970-01-01T00:00:00Z", tribeSortValue(tribe, "updated"))
+		assert.Equal(t, "1700000000", tribeSortValue(tribe, "last_active"))
+		assert.Equal(t, "42", tribeSortValue(tribe, "member_count"))
+		assert.Equal(t, "Sphinx", tribeSortValue(tribe, "name"))
+	})
+}
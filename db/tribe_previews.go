@@ -0,0 +1,61 @@
+package db
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// QueueTribePreview marks the preview of the tribe pending for the url, the
+// preview job fetches it
+func (db database) QueueTribePreview(tribeUuid string, url string) (TribePreview, error) {
+	now := time.Now()
+	preview := TribePreview{TribeUuid: tribeUuid, Url: url, Status: PreviewPending, Created: &now, Updated: &now}
+	err := db.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "tribe_uuid"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"url":     url,
+			"status":  PreviewPending,
+			"error":   "",
+			"updated": &now,
+		}),
+	}).Create(&preview).Error
+	if err != nil {
+		return preview, err
+	}
+	return db.GetTribePreview(tribeUuid), nil
+}
+
+// GetTribePreview returns the preview of the tribe, with an ID of 0 when it
+// has none
+func (db database) GetTribePreview(tribeUuid string) TribePreview {
+	preview := TribePreview{}
+	db.db.Where("tribe_uuid = ?", tribeUuid).Find(&preview)
+	return preview
+}
+
+// CompleteTribePreview stores the result of the preview job. A ready
+// preview becomes the preview of the tribe, at previewUrl
+func (db database) CompleteTribePreview(preview TribePreview, previewUrl string) error {
+	now := time.Now()
+	return db.gormTransaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&TribePreview{}).Where("id = ?", preview.ID).Updates(map[string]interface{}{
+			"status":      preview.Status,
+			"title":       preview.Title,
+			"description": preview.Description,
+			"image":       preview.Image,
+			"site_name":   preview.SiteName,
+			"card":        preview.Card,
+			"error":       preview.Error,
+			"fetched":     &now,
+			"updated":     &now,
+		}).Error; err != nil {
+			return err
+		}
+		if preview.Status != PreviewReady {
+			return nil
+		}
+		return tx.Model(&Tribe{}).Where("uuid = ?", preview.TribeUuid).Update("preview", previewUrl).Error
+	})
+}
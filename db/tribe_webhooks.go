@@ -0,0 +1,57 @@
+package db
+
+import (
+	"time"
+)
+
+// Wants reports if the webhook is posted the events of the type
+func (webhook TribeWebhook) Wants(eventType string) bool {
+	if len(webhook.Events) == 0 {
+		return true
+	}
+	for _, t := range webhook.Events {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+func (db database) CreateTribeWebhook(webhook TribeWebhook) (TribeWebhook, error) {
+	now := time.Now()
+	webhook.Created = &now
+	webhook.Updated = &now
+	err := db.db.Create(&webhook).Error
+	return webhook, err
+}
+
+// GetTribeWebhooks returns the webhooks of a tribe, the oldest first
+func (db database) GetTribeWebhooks(tribeUuid string) ([]TribeWebhook, error) {
+	webhooks := []TribeWebhook{}
+	err := db.db.Where("tribe_uuid = ?", tribeUuid).Order("id ASC").Find(&webhooks).Error
+	return webhooks, err
+}
+
+// GetTribeWebhook returns the webhook of the uuid, with an ID of 0 when
+// there is none
+func (db database) GetTribeWebhook(uuid string) TribeWebhook {
+	webhook := TribeWebhook{}
+	db.db.Where("uuid = ?", uuid).Find(&webhook)
+	return webhook
+}
+
+func (db database) DeleteTribeWebhook(uuid string) error {
+	return db.db.Where("uuid = ?", uuid).Delete(&TribeWebhook{}).Error
+}
+
+// RecordTribeWebhookDelivery keeps the outcome of the last delivery to a
+// webhook, so its owner can tell why events don't arrive
+func (db database) RecordTribeWebhookDelivery(uuid string, status int, deliveryErr string) error {
+	now := time.Now()
+	return db.db.Model(&TribeWebhook{}).Where("uuid = ?", uuid).Updates(map[string]interface{}{
+		"last_status":    status,
+		"last_error":     deliveryErr,
+		"last_delivered": &now,
+		"updated":        &now,
+	}).Error
+}
@@ -0,0 +1,49 @@
+package db
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// WithTx runs fn in a transaction. The Database passed to fn is bound to the
+// transaction, returning an error or panicking from fn rolls back every write
+// made through it. Nested calls use savepoints
+func (db database) WithTx(fn func(Database) error) error {
+	return db.transaction(func(tx database) error {
+		return fn(tx)
+	})
+}
+
+// transaction is WithTx for code inside the db package that also needs the
+// underlying gorm transaction
+func (db database) transaction(fn func(tx database) error) error {
+	return db.gormTransaction(func(gormTx *gorm.DB) error {
+		tx := db
+		tx.db = gormTx
+		return fn(tx)
+	})
+}
+
+// gormTransaction is gorm's Transaction, except that the read cache entries
+// its writes make stale are only dropped once it commits. Dropping them
+// before would let a read in between cache the old rows again
+func (db database) gormTransaction(fn func(tx *gorm.DB) error) error {
+	ctx := db.db.Statement.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	pending, nested := ctx.Value(pendingInvalidationsKey{}).(*pendingInvalidations)
+	if !nested {
+		pending = &pendingInvalidations{}
+		ctx = context.WithValue(ctx, pendingInvalidationsKey{}, pending)
+	}
+
+	err := db.db.WithContext(ctx).Transaction(fn)
+	// a nested transaction is only a savepoint, the outer one drops the
+	// entries when it commits
+	if err == nil && !nested {
+		InvalidateReadCache(pending.prefixes...)
+	}
+	return err
+}
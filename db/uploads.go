@@ -0,0 +1,122 @@
+package db
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrUploadOffset  = errors.New("the chunk does not start at the offset of the upload")
+	ErrUploadClosed  = errors.New("the upload does not take chunks anymore")
+	ErrUploadExpired = errors.New("the upload session expired")
+	ErrUploadTooLong = errors.New("the chunk goes past the size of the upload")
+)
+
+func (db database) CreateUploadSession(session UploadSession) (UploadSession, error) {
+	if session.Uuid == "" {
+		return session, errors.New("upload uuid is required")
+	}
+	now := time.Now()
+	session.Created = &now
+	session.Updated = &now
+	if session.Status == "" {
+		session.Status = UploadPending
+	}
+	return session, db.db.Create(&session).Error
+}
+
+func (db database) GetUploadSession(uuid string) (UploadSession, error) {
+	session := UploadSession{}
+	result := db.db.Where("uuid = ?", uuid).Find(&session)
+	if result.Error != nil {
+		return session, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return session, errors.New("upload not found")
+	}
+	return session, nil
+}
+
+// AppendUploadChunk stores the chunk of an upload that starts at offset. The
+// session is locked so two chunks can't claim the same offset, and it
+// moves to assembling once the last byte is received
+func (db database) AppendUploadChunk(uuid string, offset int64, data []byte, at time.Time) (UploadSession, error) {
+	session := UploadSession{}
+	err := db.transaction(func(tx database) error {
+		result := tx.db.Raw("SELECT * FROM upload_sessions WHERE uuid = ? FOR UPDATE", uuid).Scan(&session)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("upload not found")
+		}
+		switch {
+		case session.Status != UploadPending:
+			return ErrUploadClosed
+		case session.Expires != nil && !session.Expires.After(at):
+			return ErrUploadExpired
+		case offset != session.Received:
+			return ErrUploadOffset
+		case offset+int64(len(data)) > session.Size:
+			return ErrUploadTooLong
+		}
+
+		if err := tx.db.Create(&UploadChunk{SessionUuid: uuid, Position: offset, Data: data, Created: &at}).Error; err != nil {
+			return err
+		}
+		session.Received += int64(len(data))
+		if session.Received == session.Size {
+			session.Status = UploadAssembling
+		}
+		session.Updated = &at
+		return tx.db.Model(&UploadSession{}).Where("uuid = ?", uuid).Updates(map[string]interface{}{
+			"received": session.Received,
+			"status":   session.Status,
+			"updated":  at,
+		}).Error
+	})
+	return session, err
+}
+
+// GetUploadChunks returns the chunks of an upload in file order
+func (db database) GetUploadChunks(uuid string) ([]UploadChunk, error) {
+	chunks := []UploadChunk{}
+	err := db.db.Where("session_uuid = ?", uuid).Order("position ASC").Find(&chunks).Error
+	return chunks, err
+}
+
+// CompleteUploadSession records the url of an assembled upload, or why it
+// failed, and removes its chunks
+func (db database) CompleteUploadSession(uuid string, url string, errMsg string) (UploadSession, error) {
+	session := UploadSession{}
+	err := db.transaction(func(tx database) error {
+		status := UploadComplete
+		if errMsg != "" {
+			status = UploadFailed
+		}
+		err := tx.db.Model(&UploadSession{}).Where("uuid = ?", uuid).Updates(map[string]interface{}{
+			"status":  status,
+			"url":     url,
+			"error":   errMsg,
+			"updated": time.Now(),
+		}).Error
+		if err != nil {
+			return err
+		}
+		if err := tx.db.Where("session_uuid = ?", uuid).Delete(&UploadChunk{}).Error; err != nil {
+			return err
+		}
+		return tx.db.Where("uuid = ?", uuid).First(&session).Error
+	})
+	return session, err
+}
+
+// DeleteUploadSession removes an upload and the chunks it received
+func (db database) DeleteUploadSession(uuid string) error {
+	return db.transaction(func(tx database) error {
+		if err := tx.db.Where("session_uuid = ?", uuid).Delete(&UploadChunk{}).Error; err != nil {
+			return err
+		}
+		return tx.db.Where("uuid = ?", uuid).Delete(&UploadSession{}).Error
+	})
+}
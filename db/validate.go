@@ -0,0 +1,139 @@
+package db
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"gopkg.in/go-playground/validator.v9"
+)
+
+// FieldError is one failed rule of a request payload, sent as the details
+// of the validation error envelope
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Param   string `json:"param,omitempty"`
+	Message string `json:"message"`
+}
+
+// a tribe uuid is a 4 byte timestamp and the 65 byte signature of it by the
+// node of the owner
+const tribeUUIDLength = 69
+
+var pubkeyPattern = regexp.MustCompile(`^0[23][0-9a-f]{64}$`)
+
+// ValidTribeUUID is true for the base64 tribe uuids, which have a leading
+// dot when they were signed by a CLN node
+func ValidTribeUUID(uuid string) bool {
+	decoded, err := base64.URLEncoding.DecodeString(strings.TrimPrefix(uuid, "."))
+	return err == nil && len(decoded) == tribeUUIDLength
+}
+
+// ValidPubkey is true for compressed node pubkeys in lowercase hex
+func ValidPubkey(pubkey string) bool {
+	return pubkeyPattern.MatchString(pubkey)
+}
+
+// NewValidator returns the validator for request payloads. Rules are the
+// validate struct tags, fields are reported by their json name
+func NewValidator() *validator.Validate {
+	v := validator.New()
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
+	v.RegisterValidation("timezone", func(fl validator.FieldLevel) bool {
+		_, err := LoadTimezone(fl.Field().String())
+		return err == nil
+	})
+	v.RegisterValidation("region", func(fl validator.FieldLevel) bool {
+		return ValidRegion(fl.Field().String())
+	})
+	v.RegisterValidation("locale", func(fl validator.FieldLevel) bool {
+		return ValidLocale(fl.Field().String())
+	})
+	v.RegisterValidation("slug", func(fl validator.FieldLevel) bool {
+		return slugPattern.MatchString(fl.Field().String())
+	})
+	v.RegisterValidation("tribe_uuid", func(fl validator.FieldLevel) bool {
+		return ValidTribeUUID(fl.Field().String())
+	})
+	v.RegisterValidation("pubkey", func(fl validator.FieldLevel) bool {
+		return ValidPubkey(fl.Field().String())
+	})
+	return v
+}
+
+// ValidationErrors turns the error of Validate.Struct into field errors
+func ValidationErrors(err error) []FieldError {
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return []FieldError{{Message: err.Error()}}
+	}
+
+	fieldErrors := []FieldError{}
+	for _, fe := range validationErrors {
+		fieldErrors = append(fieldErrors, FieldError{
+			Field:   fe.Field(),
+			Rule:    fe.Tag(),
+			Param:   fe.Param(),
+			Message: fieldErrorMessage(fe.Field(), fe),
+		})
+	}
+	return fieldErrors
+}
+
+// ValidateVar checks a single value, like a url param, against the rules.
+// The errors are reported as the field
+func ValidateVar(field string, value string, rules string) []FieldError {
+	var validationErrors validator.ValidationErrors
+	if err := Validate.Var(value, rules); !errors.As(err, &validationErrors) {
+		return nil
+	}
+
+	fieldErrors := []FieldError{}
+	for _, fe := range validationErrors {
+		fieldErrors = append(fieldErrors, FieldError{
+			Field:   field,
+			Rule:    fe.Tag(),
+			Param:   fe.Param(),
+			Message: fieldErrorMessage(field, fe),
+		})
+	}
+	return fieldErrors
+}
+
+func fieldErrorMessage(field string, fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return fmt.Sprintf("%s is a required field", field)
+	case "max", "lte":
+		return fmt.Sprintf("%s should not exceed %s characters", field, fe.Param())
+	case "min", "gte":
+		return fmt.Sprintf("%s should be at least %s characters", field, fe.Param())
+	case "uri", "url":
+		return fmt.Sprintf("%s is not a valid url", field)
+	case "timezone":
+		return fmt.Sprintf("%s is not an IANA time zone, like Europe/Berlin", field)
+	case "region":
+		return fmt.Sprintf("%s is one of %s", field, strings.Join(Regions, ", "))
+	case "locale":
+		return fmt.Sprintf("%s is not a language tag, like en-US", field)
+	case "slug":
+		return fmt.Sprintf("%s has lowercase letters, digits and dashes only, like open-source", field)
+	case "tribe_uuid":
+		return fmt.Sprintf("%s is not a tribe uuid", field)
+	case "pubkey":
+		return fmt.Sprintf("%s is not a node pubkey, 66 lowercase hex characters", field)
+	case "number":
+		return fmt.Sprintf("%s is not a number", field)
+	}
+	return fmt.Sprintf("%s failed the %s rule", field, fe.Tag())
+}
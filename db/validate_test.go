@@ -0,0 +1,59 @@
+package db
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidationErrors(t *testing.T) {
+	v := NewValidator()
+
+	err := v.Struct(Workspace{Name: "a workspace name over twenty characters", Website: "not a url"})
+	fieldErrors := ValidationErrors(err)
+
+	assert.ElementsMatch(t, []FieldError{
+		{Field: "name", Rule: "max", Param: "20", Message: "name should not exceed 20 characters"},
+		{Field: "website", Rule: "uri", Message: "website is not a valid url"},
+	}, fieldErrors)
+
+	err = v.Struct(NewBounty{Type: "coding", Title: "bounty"})
+	assert.Equal(t, []FieldError{
+		{Field: "description", Rule: "required", Message: "description is a required field"},
+	}, ValidationErrors(err))
+
+	assert.NoError(t, v.Struct(NewBounty{Type: "coding", Title: "bounty", Description: "description"}))
+
+	err = v.Struct(Person{Timezone: "Mars/Olympus", Region: "MARS"})
+	assert.ElementsMatch(t, []FieldError{
+		{Field: "timezone", Rule: "timezone", Message: "timezone is not an IANA time zone, like Europe/Berlin"},
+		{Field: "region", Rule: "region", Message: "region is one of AF, AS, EU, NA, OC, SA"},
+	}, ValidationErrors(err))
+	assert.NoError(t, v.Struct(Person{Timezone: "America/New_York", Region: "NA"}))
+
+	err = v.Struct(Workspace{Name: "workspace", Timezone: "Local", Locale: "english"})
+	assert.ElementsMatch(t, []FieldError{
+		{Field: "timezone", Rule: "timezone", Message: "timezone is not an IANA time zone, like Europe/Berlin"},
+		{Field: "locale", Rule: "locale", Message: "locale is not a language tag, like en-US"},
+	}, ValidationErrors(err))
+	assert.NoError(t, v.Struct(Workspace{Name: "workspace", Timezone: "Asia/Tokyo", Locale: "ja-JP"}))
+
+	assert.Equal(t, []FieldError{{Message: "boom"}}, ValidationErrors(errors.New("boom")))
+}
+
+func TestValidateVar(t *testing.T) {
+	tribeUuid := base64.URLEncoding.EncodeToString(make([]byte, 69))
+
+	assert.Nil(t, ValidateVar("uuid", tribeUuid, "tribe_uuid"))
+	assert.Nil(t, ValidateVar("uuid", "."+tribeUuid, "tribe_uuid"))
+	assert.Equal(t, []FieldError{
+		{Field: "uuid", Rule: "tribe_uuid", Message: "uuid is not a tribe uuid"},
+	}, ValidateVar("uuid", "nonexistent_uuid", "tribe_uuid"))
+
+	assert.Nil(t, ValidateVar("pubkey", "02"+strings.Repeat("ab", 32), "pubkey"))
+	assert.NotNil(t, ValidateVar("pubkey", "04"+strings.Repeat("ab", 32), "pubkey"))
+	assert.NotNil(t, ValidateVar("pubkey", "02"+strings.Repeat("AB", 32), "pubkey"))
+}
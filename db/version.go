@@ -0,0 +1,69 @@
+package db
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/callbacks"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	versionColumn = "version"
+	versionSetKey = "version:set"
+)
+
+// tables whose rows carry a version that every update increments, editors
+// send the version they read and the edit is refused when it moved on
+var versionedTables = map[string]bool{
+	"bounty": true,
+}
+
+// versionPlugin adds version = version + 1 to the updates of the versioned
+// tables, whichever code path makes them. A version sent by the client in
+// the updated struct is never written as is
+type versionPlugin struct{}
+
+func (versionPlugin) Name() string {
+	return "version"
+}
+
+func (p versionPlugin) Initialize(db *gorm.DB) error {
+	if err := db.Callback().Update().Before("gorm:update").Register("version:before_update", p.before); err != nil {
+		return err
+	}
+	return db.Callback().Update().After("gorm:update").Register("version:after_update", p.after)
+}
+
+func (versionPlugin) before(tx *gorm.DB) {
+	stmt := tx.Statement
+	if tx.Error != nil || stmt.Schema == nil || stmt.SQL.Len() > 0 || !versionedTables[stmt.Table] {
+		return
+	}
+	if _, ok := stmt.Clauses["SET"]; ok {
+		return
+	}
+
+	set := callbacks.ConvertToAssignments(stmt)
+	if len(set) == 0 {
+		return
+	}
+	bumped := make(clause.Set, 0, len(set)+1)
+	for _, assignment := range set {
+		if assignment.Column.Name != versionColumn {
+			bumped = append(bumped, assignment)
+		}
+	}
+	bumped = append(bumped, clause.Assignment{
+		Column: clause.Column{Name: versionColumn},
+		Value:  gorm.Expr(versionColumn + " + 1"),
+	})
+	stmt.AddClause(bumped)
+	tx.InstanceSet(versionSetKey, true)
+}
+
+// after drops the SET clause like gorm does with its own, so a reused
+// statement builds it again
+func (versionPlugin) after(tx *gorm.DB) {
+	if _, ok := tx.InstanceGet(versionSetKey); ok {
+		delete(tx.Statement.Clauses, "SET")
+	}
+}
@@ -0,0 +1,36 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestVersionPlugin(t *testing.T) {
+	conn, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{DryRun: true, DisableAutomaticPing: true, SkipDefaultTransaction: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := conn.Use(versionPlugin{}); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("should increment the version of a bounty instead of writing the one sent", func(t *testing.T) {
+		stmt := conn.Model(&NewBounty{ID: 1}).Updates(NewBounty{Title: "edited", Version: 9}).Statement
+		assert.Contains(t, stmt.SQL.String(), `"version"=version + 1`)
+		assert.NotContains(t, stmt.Vars, 9)
+		assert.NotContains(t, stmt.Clauses, "SET")
+	})
+
+	t.Run("should increment the version on column updates", func(t *testing.T) {
+		stmt := conn.Model(&NewBounty{ID: 1}).Select("show").UpdateColumns(map[string]interface{}{"show": false}).Statement
+		assert.Contains(t, stmt.SQL.String(), `"version"=version + 1`)
+	})
+
+	t.Run("should leave other tables alone", func(t *testing.T) {
+		stmt := conn.Model(&WorkspaceArtifact{ID: 1}).Updates(WorkspaceArtifact{Name: "edited", Version: 2}).Statement
+		assert.Contains(t, stmt.SQL.String(), `"version"=$`)
+	})
+}
@@ -0,0 +1,11 @@
+package db
+
+// GetOpenWorkspaceBounties lists the open bounties of a workspace shown on
+// the site, the latest first. A bounty waiting for its assignee to accept
+// its terms isn't open anymore
+func (db database) GetOpenWorkspaceBounties(workspaceUuid string, limit int) []NewBounty {
+	bounties := []NewBounty{}
+	db.db.Where("workspace_uuid = ? AND show = true AND paid = false AND (assignee = '' OR assignee IS NULL) AND pending_assignee = ''", workspaceUuid).
+		Order("created DESC, id DESC").Limit(limit).Find(&bounties)
+	return bounties
+}
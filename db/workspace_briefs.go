@@ -0,0 +1,100 @@
+package db
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func (db database) CreateOrEditWorkspaceBrief(brief WorkspaceBrief) (WorkspaceBrief, error) {
+	now := time.Now()
+	brief.Updated = &now
+	if brief.ID == 0 {
+		brief.Created = &now
+		err := db.db.Create(&brief).Error
+		return brief, err
+	}
+	err := db.db.Save(&brief).Error
+	return brief, err
+}
+
+// ErrBriefNotGenerating is the error of finishing a brief that is not
+// generating anymore
+var ErrBriefNotGenerating = errors.New("the brief is not generating")
+
+// FinishWorkspaceBrief saves the draft, or the failure, of a generating
+// brief. A brief leaves generating only once, a later call gets
+// ErrBriefNotGenerating
+func (db database) FinishWorkspaceBrief(brief WorkspaceBrief) (WorkspaceBrief, error) {
+	now := time.Now()
+	result := db.db.Model(&WorkspaceBrief{}).
+		Where("uuid = ? AND status = ?", brief.Uuid, BriefGenerating).
+		Updates(map[string]interface{}{
+			"status":  brief.Status,
+			"error":   brief.Error,
+			"mission": brief.Mission,
+			"tactics": brief.Tactics,
+			"updated": &now,
+		})
+	if result.Error != nil {
+		return brief, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return brief, ErrBriefNotGenerating
+	}
+	brief.Updated = &now
+	return brief, nil
+}
+
+func (db database) GetWorkspaceBrief(uuid string) (WorkspaceBrief, error) {
+	brief := WorkspaceBrief{}
+	err := db.db.Where("uuid = ?", uuid).First(&brief).Error
+	return brief, err
+}
+
+// GetWorkspaceBriefs lists the brief versions of a workspace, newest first
+func (db database) GetWorkspaceBriefs(workspaceUuid string) []WorkspaceBrief {
+	briefs := []WorkspaceBrief{}
+	db.db.Where("workspace_uuid = ?", workspaceUuid).Order("id DESC").Find(&briefs)
+	return briefs
+}
+
+// ApproveWorkspaceBrief replaces the mission and tactics of the workspace
+// with the ones of a pending brief
+func (db database) ApproveWorkspaceBrief(brief WorkspaceBrief, pubkey string) (Workspace, error) {
+	workspace := Workspace{}
+	err := db.transaction(func(tx database) error {
+		now := time.Now()
+		result := tx.db.Model(&WorkspaceBrief{}).
+			Where("uuid = ? AND status = ?", brief.Uuid, BriefPending).
+			Updates(map[string]interface{}{"status": BriefApproved, "reviewed_by": pubkey, "updated": &now})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("the brief is not pending")
+		}
+
+		if err := tx.db.Model(&Workspace{}).Where("uuid = ?", brief.WorkspaceUuid).Updates(map[string]interface{}{
+			"mission": brief.Mission,
+			"tactics": brief.Tactics,
+			"updated": &now,
+		}).Error; err != nil {
+			return err
+		}
+		return tx.db.Where("uuid = ?", brief.WorkspaceUuid).First(&workspace).Error
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return workspace, errors.New("workspace not found")
+	}
+	return workspace, err
+}
+
+// GetRecentWorkspaceBounties returns the last updated bounties of a
+// workspace
+func (db database) GetRecentWorkspaceBounties(workspaceUuid string, limit int) []NewBounty {
+	bounties := []NewBounty{}
+	db.db.Where("workspace_uuid = ?", workspaceUuid).Order("updated DESC NULLS LAST, id DESC").Limit(limit).Find(&bounties)
+	return bounties
+}
@@ -0,0 +1,268 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/rs/xid"
+)
+
+func (db database) CreateWorkspaceExport(export WorkspaceExport) (WorkspaceExport, error) {
+	now := time.Now()
+	export.Created = &now
+	err := db.db.Create(&export).Error
+	return export, err
+}
+
+func (db database) GetWorkspaceExport(workspaceUuid string, uuid string) (WorkspaceExport, error) {
+	export := WorkspaceExport{}
+	err := db.db.Where("workspace_uuid = ? AND uuid = ?", workspaceUuid, uuid).First(&export).Error
+	return export, err
+}
+
+// CompleteWorkspaceExport stores the bundle of an export, or the error of
+// the attempt that failed to build it
+func (db database) CompleteWorkspaceExport(uuid string, bundle string, exportErr string) error {
+	now := time.Now()
+	values := map[string]interface{}{"status": ExportReady, "bundle": bundle, "error": "", "completed": &now}
+	if exportErr != "" {
+		values = map[string]interface{}{"status": ExportFailed, "error": exportErr}
+	}
+	return db.db.Model(&WorkspaceExport{}).Where("uuid = ?", uuid).Updates(values).Error
+}
+
+// GetWorkspaceBundle reads the structure of a workspace for an export. Only
+// approved briefs are kept, the others still wait on a workflow of the
+// source workspace
+func (db database) GetWorkspaceBundle(workspaceUuid string) (WorkspaceBundle, error) {
+	now := time.Now()
+	bundle := WorkspaceBundle{Format: WorkspaceBundleFormat, Exported: &now}
+
+	if err := db.db.Where("uuid = ?", workspaceUuid).First(&bundle.Workspace).Error; err != nil {
+		return bundle, err
+	}
+
+	features := "feature_uuid IN (SELECT uuid FROM workspace_features WHERE workspace_uuid = ? AND deleted_at IS NULL)"
+	queries := []struct {
+		dest  interface{}
+		where string
+		order string
+	}{
+		{&bundle.Repositories, "workspace_uuid = ?", "id ASC"},
+		{&bundle.Features, "workspace_uuid = ?", "id ASC"},
+		{&bundle.Phases, features, "priority ASC"},
+		{&bundle.Stories, features, "id ASC"},
+		{&bundle.Bounties, "workspace_uuid = ?", "id ASC"},
+		{&bundle.Artifacts, "workspace_uuid = ?", "id ASC"},
+	}
+	for _, q := range queries {
+		if err := db.db.Where(q.where, workspaceUuid).Order(q.order).Find(q.dest).Error; err != nil {
+			return bundle, err
+		}
+	}
+	err := db.db.Where("workspace_uuid = ? AND status = ?", workspaceUuid, BriefApproved).Order("id ASC").Find(&bundle.Briefs).Error
+	return bundle, err
+}
+
+// ImportWorkspaceBundle recreates the structure of a bundle in a new
+// workspace named name and owned by pubkey, in one transaction. Every uuid
+// is replaced, the bounties come back open and unassigned
+func (db database) ImportWorkspaceBundle(bundle WorkspaceBundle, name string, pubkey string) (Workspace, error) {
+	if bundle.Format != WorkspaceBundleFormat {
+		return Workspace{}, fmt.Errorf("unsupported bundle format %d", bundle.Format)
+	}
+	if bundle.Workspace.Uuid == "" {
+		return Workspace{}, errors.New("the bundle has no workspace")
+	}
+
+	remapped := remapWorkspaceBundle(bundle, name, pubkey, time.Now(), func() string {
+		return xid.New().String()
+	})
+
+	err := db.transaction(func(tx database) error {
+		if err := tx.db.Create(&remapped.Workspace).Error; err != nil {
+			return err
+		}
+		rows := []interface{}{&remapped.Repositories, &remapped.Features, &remapped.Phases, &remapped.Stories, &remapped.Bounties, &remapped.Briefs}
+		for _, r := range rows {
+			if reflect.ValueOf(r).Elem().Len() == 0 {
+				continue
+			}
+			if err := tx.db.CreateInBatches(r, 100).Error; err != nil {
+				return err
+			}
+		}
+		for _, artifact := range remapped.Artifacts {
+			if _, err := tx.CreateOrEditArtifact(artifact); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return Workspace{}, err
+	}
+	return remapped.Workspace, nil
+}
+
+// remapWorkspaceBundle gives every record of a bundle a new uuid and points
+// the references at the new ones. A reference to a record that isn't in the
+// bundle is cleared
+func remapWorkspaceBundle(bundle WorkspaceBundle, name string, pubkey string, now time.Time, newUuid func() string) WorkspaceBundle {
+	workspaceUuid := newUuid()
+	features := map[string]string{}
+	phases := map[string]string{}
+
+	source := bundle.Workspace
+	remapped := WorkspaceBundle{
+		Format:   bundle.Format,
+		Exported: bundle.Exported,
+		Workspace: Workspace{
+			Uuid:         workspaceUuid,
+			Name:         name,
+			OwnerPubKey:  pubkey,
+			Img:          source.Img,
+			Show:         source.Show,
+			Website:      source.Website,
+			Github:       source.Github,
+			Description:  source.Description,
+			Mission:      source.Mission,
+			Tactics:      source.Tactics,
+			SchematicUrl: source.SchematicUrl,
+			SchematicImg: source.SchematicImg,
+			Created:      &now,
+			Updated:      &now,
+		},
+	}
+
+	for _, repository := range bundle.Repositories {
+		remapped.Repositories = append(remapped.Repositories, WorkspaceRepositories{
+			Uuid:          newUuid(),
+			WorkspaceUuid: workspaceUuid,
+			Name:          repository.Name,
+			Url:           repository.Url,
+			Created:       &now,
+			Updated:       &now,
+			CreatedBy:     pubkey,
+			UpdatedBy:     pubkey,
+		})
+	}
+
+	for _, feature := range bundle.Features {
+		features[feature.Uuid] = newUuid()
+		remapped.Features = append(remapped.Features, WorkspaceFeatures{
+			Uuid:          features[feature.Uuid],
+			WorkspaceUuid: workspaceUuid,
+			Name:          feature.Name,
+			Brief:         feature.Brief,
+			Requirements:  feature.Requirements,
+			Architecture:  feature.Architecture,
+			Url:           feature.Url,
+			Priority:      feature.Priority,
+			Created:       &now,
+			Updated:       &now,
+			CreatedBy:     pubkey,
+			UpdatedBy:     pubkey,
+		})
+	}
+
+	for _, phase := range bundle.Phases {
+		featureUuid, ok := features[phase.FeatureUuid]
+		if !ok {
+			continue
+		}
+		phases[phase.Uuid] = newUuid()
+		remapped.Phases = append(remapped.Phases, FeaturePhase{
+			Uuid:        phases[phase.Uuid],
+			FeatureUuid: featureUuid,
+			Name:        phase.Name,
+			Priority:    phase.Priority,
+			Created:     &now,
+			Updated:     &now,
+			CreatedBy:   pubkey,
+			UpdatedBy:   pubkey,
+		})
+	}
+
+	for _, story := range bundle.Stories {
+		featureUuid, ok := features[story.FeatureUuid]
+		if !ok {
+			continue
+		}
+		remapped.Stories = append(remapped.Stories, FeatureStory{
+			Uuid:        newUuid(),
+			FeatureUuid: featureUuid,
+			Description: story.Description,
+			Priority:    story.Priority,
+			Created:     &now,
+			Updated:     &now,
+			CreatedBy:   pubkey,
+			UpdatedBy:   pubkey,
+		})
+	}
+
+	for _, bounty := range bundle.Bounties {
+		languages := bounty.CodingLanguages
+		if languages == nil {
+			languages = pq.StringArray{}
+		}
+		remapped.Bounties = append(remapped.Bounties, NewBounty{
+			OwnerID:                 pubkey,
+			Show:                    bounty.Show,
+			Type:                    bounty.Type,
+			Award:                   bounty.Award,
+			AssignedHours:           bounty.AssignedHours,
+			BountyExpires:           bounty.BountyExpires,
+			CommitmentFee:           bounty.CommitmentFee,
+			Price:                   bounty.Price,
+			Title:                   bounty.Title,
+			Tribe:                   bounty.Tribe,
+			TicketUrl:               bounty.TicketUrl,
+			WorkspaceUuid:           workspaceUuid,
+			Description:             bounty.Description,
+			WantedType:              bounty.WantedType,
+			Deliverables:            bounty.Deliverables,
+			GithubDescription:       bounty.GithubDescription,
+			OneSentenceSummary:      bounty.OneSentenceSummary,
+			EstimatedSessionLength:  bounty.EstimatedSessionLength,
+			EstimatedCompletionDate: bounty.EstimatedCompletionDate,
+			Created:                 bounty.Created,
+			Updated:                 &now,
+			CodingLanguages:         languages,
+			PhaseUuid:               phases[bounty.PhaseUuid],
+			PhasePriority:           bounty.PhasePriority,
+		})
+	}
+
+	for _, brief := range bundle.Briefs {
+		remapped.Briefs = append(remapped.Briefs, WorkspaceBrief{
+			Uuid:          newUuid(),
+			WorkspaceUuid: workspaceUuid,
+			Mission:       brief.Mission,
+			Tactics:       brief.Tactics,
+			Status:        brief.Status,
+			RequestedBy:   brief.RequestedBy,
+			ReviewedBy:    brief.ReviewedBy,
+			Created:       &now,
+			Updated:       &now,
+		})
+	}
+
+	for _, artifact := range bundle.Artifacts {
+		remapped.Artifacts = append(remapped.Artifacts, WorkspaceArtifact{
+			Uuid:          newUuid(),
+			WorkspaceUuid: workspaceUuid,
+			FeatureUuid:   features[artifact.FeatureUuid],
+			Kind:          artifact.Kind,
+			Name:          artifact.Name,
+			Content:       artifact.Content,
+			Url:           artifact.Url,
+			Tags:          artifact.Tags,
+			CreatedBy:     pubkey,
+		})
+	}
+	return remapped
+}
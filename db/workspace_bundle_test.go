@@ -0,0 +1,79 @@
+package db
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRemapWorkspaceBundle(t *testing.T) {
+	created := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	bundle := WorkspaceBundle{
+		Format:       WorkspaceBundleFormat,
+		Workspace:    Workspace{Uuid: "ws", Name: "Hive", OwnerPubKey: "owner", Mission: "mission", Budget: 5000},
+		Repositories: []WorkspaceRepositories{{Uuid: "repo", WorkspaceUuid: "ws", Name: "tribes", Url: "https://github.com/stakwork/sphinx-tribes"}},
+		Features:     []WorkspaceFeatures{{Uuid: "feature", WorkspaceUuid: "ws", Name: "Export", Priority: 2}},
+		Phases: []FeaturePhase{
+			{Uuid: "phase", FeatureUuid: "feature", Name: "MVP"},
+			{Uuid: "orphan-phase", FeatureUuid: "other-feature", Name: "Lost"},
+		},
+		Stories: []FeatureStory{{Uuid: "story", FeatureUuid: "feature", Description: "As a user"}},
+		Bounties: []NewBounty{
+			{ID: 7, OwnerID: "owner", Assignee: "hunter", Paid: true, Completed: true, Title: "Build it", WorkspaceUuid: "ws", PhaseUuid: "phase", Price: 1000, Created: created.Unix()},
+			{ID: 8, OwnerID: "owner", Title: "Orphan", WorkspaceUuid: "ws", PhaseUuid: "orphan-phase"},
+		},
+		Briefs:    []WorkspaceBrief{{Uuid: "brief", WorkspaceUuid: "ws", Mission: "mission", Status: BriefApproved}},
+		Artifacts: []WorkspaceArtifact{{Uuid: "artifact", WorkspaceUuid: "ws", FeatureUuid: "feature", Kind: "diagram", Name: "flow"}},
+	}
+
+	n := 0
+	newUuid := func() string {
+		n++
+		return fmt.Sprintf("new-%d", n)
+	}
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	remapped := remapWorkspaceBundle(bundle, "Hive Copy", "importer", now, newUuid)
+
+	t.Run("should create the workspace for the importer", func(t *testing.T) {
+		assert.Equal(t, "new-1", remapped.Workspace.Uuid)
+		assert.Equal(t, "Hive Copy", remapped.Workspace.Name)
+		assert.Equal(t, "importer", remapped.Workspace.OwnerPubKey)
+		assert.Equal(t, "mission", remapped.Workspace.Mission)
+		assert.Zero(t, remapped.Workspace.Budget)
+	})
+
+	t.Run("should point the records at the new uuids", func(t *testing.T) {
+		ws := remapped.Workspace.Uuid
+		assert.Equal(t, ws, remapped.Repositories[0].WorkspaceUuid)
+		assert.NotEqual(t, "repo", remapped.Repositories[0].Uuid)
+
+		feature := remapped.Features[0].Uuid
+		assert.NotEqual(t, "feature", feature)
+		assert.Equal(t, ws, remapped.Features[0].WorkspaceUuid)
+
+		assert.Len(t, remapped.Phases, 1)
+		assert.Equal(t, feature, remapped.Phases[0].FeatureUuid)
+		assert.Equal(t, feature, remapped.Stories[0].FeatureUuid)
+		assert.Equal(t, feature, remapped.Artifacts[0].FeatureUuid)
+		assert.Equal(t, ws, remapped.Briefs[0].WorkspaceUuid)
+		assert.Equal(t, ws, remapped.Artifacts[0].WorkspaceUuid)
+
+		assert.Equal(t, remapped.Phases[0].Uuid, remapped.Bounties[0].PhaseUuid)
+		assert.Empty(t, remapped.Bounties[1].PhaseUuid)
+	})
+
+	t.Run("should reopen the bounties", func(t *testing.T) {
+		bounty := remapped.Bounties[0]
+		assert.Zero(t, bounty.ID)
+		assert.Equal(t, "importer", bounty.OwnerID)
+		assert.Equal(t, remapped.Workspace.Uuid, bounty.WorkspaceUuid)
+		assert.Empty(t, bounty.Assignee)
+		assert.False(t, bounty.Paid)
+		assert.False(t, bounty.Completed)
+		assert.Equal(t, uint(1000), bounty.Price)
+		assert.Equal(t, created.Unix(), bounty.Created)
+		assert.NotNil(t, bounty.CodingLanguages)
+	})
+}
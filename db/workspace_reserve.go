@@ -0,0 +1,142 @@
+package db
+
+import (
+	"errors"
+	"time"
+
+	"github.com/rs/xid"
+)
+
+// MaxReservePercent caps the share of the deposits a workspace can keep in
+// its dispute reserve
+const MaxReservePercent = 50
+
+// reserveShare is the part of a deposit that goes to the reserve
+func reserveShare(percent uint, amount uint) uint {
+	return amount * percent / 100
+}
+
+func reserveDeposit(workspaceUuid string, amount uint) *ReserveEntry {
+	now := time.Now()
+	return &ReserveEntry{
+		Uuid:          xid.New().String(),
+		WorkspaceUuid: workspaceUuid,
+		Type:          ReserveEntryDeposit,
+		Amount:        amount,
+		Status:        ReserveApproved,
+		Created:       &now,
+	}
+}
+
+// SetWorkspaceReservePercent sets the percent of the next deposits kept in
+// the reserve, the budget is created when the workspace has none yet
+func (db database) SetWorkspaceReservePercent(workspaceUuid string, percent uint) (NewBountyBudget, error) {
+	budget := db.GetWorkspaceBudget(workspaceUuid)
+	now := time.Now()
+	budget.ReservePercent = percent
+	budget.Updated = &now
+	if budget.WorkspaceUuid == "" {
+		budget.WorkspaceUuid = workspaceUuid
+		budget.Created = &now
+		err := db.db.Create(&budget).Error
+		return budget, err
+	}
+	err := db.db.Model(&NewBountyBudget{}).Where("workspace_uuid = ?", workspaceUuid).Updates(map[string]interface{}{
+		"reserve_percent": percent,
+		"updated":         &now,
+	}).Error
+	return budget, err
+}
+
+func (db database) CreateReserveEntry(entry ReserveEntry) (ReserveEntry, error) {
+	now := time.Now()
+	entry.Created = &now
+	err := db.db.Create(&entry).Error
+	return entry, err
+}
+
+func (db database) GetReserveEntry(uuid string) (ReserveEntry, error) {
+	entry := ReserveEntry{}
+	err := db.db.Where("uuid = ?", uuid).First(&entry).Error
+	return entry, err
+}
+
+// GetReserveEntries lists the deposits and releases of the reserve of a
+// workspace, newest first
+func (db database) GetReserveEntries(workspaceUuid string) []ReserveEntry {
+	entries := []ReserveEntry{}
+	db.db.Where("workspace_uuid = ?", workspaceUuid).Order("id DESC").Find(&entries)
+	return entries
+}
+
+// GetPendingReserveAmount sums the releases that wait for a review
+func (db database) GetPendingReserveAmount(workspaceUuid string) uint {
+	var pending uint
+	db.db.Model(&ReserveEntry{}).
+		Where("workspace_uuid = ? AND type = ? AND status = ?", workspaceUuid, ReserveEntryRelease, ReservePending).
+		Select("COALESCE(SUM(amount), 0)").Row().Scan(&pending)
+	return pending
+}
+
+// ApproveReserveRelease moves the amount of a pending release from the
+// reserve back to the workspace budget, where the compensation is paid
+// from like any bounty
+func (db database) ApproveReserveRelease(entry ReserveEntry, pubkey string) (NewBountyBudget, error) {
+	budget := NewBountyBudget{}
+	err := db.transaction(func(tx database) error {
+		now := time.Now()
+		result := tx.db.Model(&ReserveEntry{}).
+			Where("uuid = ? AND status = ?", entry.Uuid, ReservePending).
+			Updates(map[string]interface{}{"status": ReserveApproved, "reviewed_by": pubkey, "reviewed": &now})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("the release is not pending")
+		}
+
+		budget = tx.GetWorkspaceBudget(entry.WorkspaceUuid)
+		if budget.ReserveBalance < entry.Amount {
+			return errors.New("not enough funds in the reserve")
+		}
+		budget.ReserveBalance -= entry.Amount
+		budget.TotalBudget += entry.Amount
+		budget.Updated = &now
+		if err := tx.db.Model(&NewBountyBudget{}).Where("workspace_uuid = ?", entry.WorkspaceUuid).Updates(map[string]interface{}{
+			"reserve_balance": budget.ReserveBalance,
+			"total_budget":    budget.TotalBudget,
+			"updated":         &now,
+		}).Error; err != nil {
+			return err
+		}
+
+		return tx.db.Create(&NewPaymentHistory{
+			WorkspaceUuid: entry.WorkspaceUuid,
+			Amount:        entry.Amount,
+			BountyId:      entry.BountyId,
+			PaymentType:   ReserveRelease,
+			SenderPubKey:  pubkey,
+			Status:        true,
+			Created:       &now,
+			Updated:       &now,
+		}).Error
+	})
+	return budget, err
+}
+
+func (db database) RejectReserveRelease(entry ReserveEntry, pubkey string) (ReserveEntry, error) {
+	now := time.Now()
+	result := db.db.Model(&ReserveEntry{}).
+		Where("uuid = ? AND status = ?", entry.Uuid, ReservePending).
+		Updates(map[string]interface{}{"status": ReserveRejected, "reviewed_by": pubkey, "reviewed": &now})
+	if result.Error != nil {
+		return entry, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return entry, errors.New("the release is not pending")
+	}
+	entry.Status = ReserveRejected
+	entry.ReviewedBy = pubkey
+	entry.Reviewed = &now
+	return entry, nil
+}
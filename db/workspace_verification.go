@@ -0,0 +1,123 @@
+package db
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/stakwork/sphinx-tribes/utils"
+	"gorm.io/gorm"
+)
+
+// CountWorkspacesCreatedSince counts the workspaces the pubkey created since
+// the time, the deleted ones too so deleting one does not free the quota
+func (db database) CountWorkspacesCreatedSince(pubkey string, since time.Time) int64 {
+	var count int64
+	db.db.Model(&Workspace{}).Where("owner_pub_key = ? AND created >= ?", pubkey, since).Count(&count)
+	return count
+}
+
+// FlagWorkspace takes a workspace out of the directory until an admin
+// reviews it
+func (db database) FlagWorkspace(uuid string, reasons []string) error {
+	return db.db.Model(&Workspace{}).Where("uuid = ?", uuid).Updates(map[string]interface{}{
+		"flagged":      true,
+		"flag_reasons": pq.StringArray(reasons),
+	}).Error
+}
+
+// GetFlaggedWorkspaces is the review queue of the admins, the oldest
+// creations first
+func (db database) GetFlaggedWorkspaces(r *http.Request) ([]Workspace, error) {
+	offset, limit, _, _, _ := utils.GetPaginationParams(r)
+	if r.URL.Query().Get("limit") == "" {
+		limit = 50
+	}
+
+	workspaces := []Workspace{}
+	query := db.forRequest(r).Model(&Workspace{}).Where("flagged = true AND deleted != true").Order("created ASC")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if offset > 0 {
+		query = query.Offset(offset)
+	}
+	err := query.Find(&workspaces).Error
+	return workspaces, err
+}
+
+// ReviewFlaggedWorkspace lists a flagged workspace when it is approved and
+// deletes it when it is rejected
+func (db database) ReviewFlaggedWorkspace(uuid string, review WorkspaceReview) (Workspace, error) {
+	workspace := Workspace{}
+	now := time.Now()
+	updates := map[string]interface{}{"flagged": false, "updated": &now}
+	if review.Action == WorkspaceReviewReject {
+		updates["deleted"] = true
+	} else {
+		updates["flag_reasons"] = pq.StringArray{}
+	}
+
+	result := db.db.Model(&Workspace{}).Where("uuid = ? AND flagged = true", uuid).Updates(updates)
+	if result.Error != nil {
+		return workspace, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return workspace, errors.New("workspace is not flagged")
+	}
+	err := db.db.Where("uuid = ?", uuid).First(&workspace).Error
+	return workspace, err
+}
+
+// SaveWorkspaceVerification starts a verification of the workspace, in
+// place of the one pending
+func (db database) SaveWorkspaceVerification(verification WorkspaceVerification) (WorkspaceVerification, error) {
+	now := time.Now()
+	verification.ID = 0
+	verification.Attempts = 0
+	verification.Created = &now
+	err := db.gormTransaction(func(tx *gorm.DB) error {
+		if err := tx.Where("workspace_uuid = ?", verification.WorkspaceUuid).Delete(&WorkspaceVerification{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(&verification).Error
+	})
+	return verification, err
+}
+
+// GetWorkspaceVerification returns the pending verification of the
+// workspace, with an ID of 0 when there is none
+func (db database) GetWorkspaceVerification(workspaceUuid string) WorkspaceVerification {
+	verification := WorkspaceVerification{}
+	db.db.Where("workspace_uuid = ?", workspaceUuid).Find(&verification)
+	return verification
+}
+
+// AddWorkspaceVerificationAttempt counts a wrong code against the pending
+// verification
+func (db database) AddWorkspaceVerificationAttempt(id uint) error {
+	return db.db.Model(&WorkspaceVerification{}).Where("id = ?", id).
+		Update("attempts", gorm.Expr("attempts + 1")).Error
+}
+
+// VerifyWorkspace marks the workspace verified by the method and drops its
+// pending verification
+func (db database) VerifyWorkspace(uuid string, method string) (Workspace, error) {
+	workspace := Workspace{}
+	now := time.Now()
+	err := db.gormTransaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&Workspace{}).Where("uuid = ?", uuid).Updates(map[string]interface{}{
+			"verified":            &now,
+			"verification_method": method,
+			"updated":             &now,
+		}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("workspace_uuid = ?", uuid).Delete(&WorkspaceVerification{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("uuid = ?", uuid).First(&workspace).Error
+	})
+	return workspace, err
+}
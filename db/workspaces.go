@@ -8,13 +8,36 @@ import (
 	"time"
 
 	"github.com/stakwork/sphinx-tribes/utils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// ErrBudgetNotEnough is returned when a workspace budget can't cover an
+// amount taken from it
+var ErrBudgetNotEnough = errors.New("workspace budget is not enough")
+
+// debitBudget takes an amount from the budget of a workspace in a single
+// statement that checks the budget covers it, so payments made at the same
+// time can't take the budget below 0
+func (db database) debitBudget(workspaceUuid string, amount uint) error {
+	result := db.db.Model(&NewBountyBudget{}).
+		Where("workspace_uuid = ? AND total_budget >= ?", workspaceUuid, amount).
+		Update("total_budget", gorm.Expr("total_budget - ?", amount))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected != 1 {
+		return ErrBudgetNotEnough
+	}
+	return nil
+}
+
 func (db database) GetWorkspaces(r *http.Request) []Workspace {
 	ms := []Workspace{}
 	offset, limit, sortBy, direction, search := utils.GetPaginationParams(r)
 
-	query := db.db.Model(&ms).Where("LOWER(name) LIKE ?", "%"+search+"%").Where("deleted != ?", true)
+	// flagged workspaces stay out of the directory until they are reviewed
+	query := db.forRequest(r).Model(&ms).Where("LOWER(name) LIKE ?", "%"+search+"%").Where("deleted != ?", true).Where("flagged = false")
 
 	if limit > 1 {
 		query.Offset(offset).Limit(limit).Order(sortBy + " " + direction + " ")
@@ -46,18 +69,59 @@ func (db database) GetWorkspaceByName(name string) Workspace {
 	return ms
 }
 
+// the columns of a workspace that only its owner, or an admin for the plan,
+// sets on their own routes
+var workspaceOwnerSettings = []string{"auto_pay", "auto_pay_cap", "done_requires_checklist", "done_requires_pull_request", "plan", "default_reviewers", "required_approvals", "payment_requires_proof", "ai_monthly_cap", "flagged", "flag_reasons", "verified", "verification_method"}
+
 func (db database) CreateOrEditWorkspace(m Workspace) (Workspace, error) {
 	if m.OwnerPubKey == "" {
 		return Workspace{}, errors.New("no pub key")
 	}
 
-	if db.db.Model(&m).Where("uuid = ?", m.Uuid).Updates(&m).RowsAffected == 0 {
-		db.db.Create(&m)
+	// auto-pay, the definition of done, the reviewers and the AI cap are
+	// only set by the owner, with SetWorkspaceAutoPay,
+	// SetWorkspaceDefinitionOfDone, SetWorkspaceReviewers and
+	// SetWorkspaceAiCap, the plan by an admin with SetWorkspacePlan, and the
+	// review flags and verification with FlagWorkspace and VerifyWorkspace
+	if db.db.Model(&m).Where("uuid = ?", m.Uuid).Omit(workspaceOwnerSettings...).Updates(&m).RowsAffected == 0 {
+		db.db.Omit(workspaceOwnerSettings...).Create(&m)
 	}
 
 	return m, nil
 }
 
+// SetWorkspaceAutoPay turns the payment of the bounties on completion on or
+// off, a cap of 0 leaves the amount to the budget check only
+func (db database) SetWorkspaceAutoPay(uuid string, autoPay bool, autoPayCap uint) (Workspace, error) {
+	workspace := Workspace{}
+	now := time.Now()
+	if err := db.db.Model(&Workspace{}).Where("uuid = ?", uuid).Updates(map[string]interface{}{
+		"auto_pay":     autoPay,
+		"auto_pay_cap": autoPayCap,
+		"updated":      &now,
+	}).Error; err != nil {
+		return workspace, err
+	}
+	err := db.db.Where("uuid = ?", uuid).First(&workspace).Error
+	return workspace, err
+}
+
+// SetWorkspaceDefinitionOfDone sets the conditions a bounty of the workspace
+// must meet before its completion is accepted
+func (db database) SetWorkspaceDefinitionOfDone(uuid string, done DefinitionOfDone) (Workspace, error) {
+	workspace := Workspace{}
+	now := time.Now()
+	if err := db.db.Model(&Workspace{}).Where("uuid = ?", uuid).Updates(map[string]interface{}{
+		"done_requires_checklist":    done.RequiresChecklist,
+		"done_requires_pull_request": done.RequiresPullRequest,
+		"updated":                    &now,
+	}).Error; err != nil {
+		return workspace, err
+	}
+	err := db.db.Where("uuid = ?", uuid).First(&workspace).Error
+	return workspace, err
+}
+
 func (db database) CreateOrEditWorkspaceRepository(m WorkspaceRepositories) (WorkspaceRepositories, error) {
 	m.Name = strings.TrimSpace(m.Name)
 	m.Url = strings.TrimSpace(m.Url)
@@ -115,7 +179,7 @@ func (db database) GetWorkspaceUsersCount(uuid string) int64 {
 
 func (db database) GetWorkspaceBountyCount(uuid string) int64 {
 	var count int64
-	db.db.Model(&Bounty{}).Where("workspace_uuid  = ?", uuid).Count(&count)
+	db.db.Model(&NewBounty{}).Where("workspace_uuid  = ?", uuid).Count(&count)
 	return count
 }
 
@@ -296,14 +360,23 @@ func (db database) ProcessUpdateBudget(invoice NewInvoiceList) error {
 				tx.Rollback()
 			}
 		} else {
+			// the reserve percent of the deposit goes to the dispute reserve
+			reserve := reserveShare(WorkspaceBudget.ReservePercent, paymentHistory.Amount)
 			totalBudget := WorkspaceBudget.TotalBudget
-			WorkspaceBudget.TotalBudget = totalBudget + paymentHistory.Amount
+			WorkspaceBudget.TotalBudget = totalBudget + paymentHistory.Amount - reserve
 
 			if err = tx.Model(&NewBountyBudget{}).Where("workspace_uuid = ?", WorkspaceBudget.WorkspaceUuid).Updates(map[string]interface{}{
-				"total_budget": WorkspaceBudget.TotalBudget,
+				"total_budget":    WorkspaceBudget.TotalBudget,
+				"reserve_balance": WorkspaceBudget.ReserveBalance + reserve,
 			}).Error; err != nil {
 				tx.Rollback()
 			}
+
+			if reserve > 0 {
+				if err = tx.Create(reserveDeposit(workspace_uuid, reserve)).Error; err != nil {
+					tx.Rollback()
+				}
+			}
 		}
 
 		// update invoice
@@ -315,81 +388,59 @@ func (db database) ProcessUpdateBudget(invoice NewInvoiceList) error {
 	return tx.Commit().Error
 }
 
-func (db database) AddAndUpdateBudget(invoice NewInvoiceList) NewPaymentHistory {
+// AddAndUpdateBudget credits the workspace of a budget invoice with its
+// payment, less the share kept in the reserve. It runs in the transaction
+// that settles the invoice, so an error rolls both back
+func (db database) AddAndUpdateBudget(invoice NewInvoiceList) (NewPaymentHistory, error) {
 	created := invoice.Created
 	workspace_uuid := invoice.WorkspaceUuid
 
 	paymentHistory := db.GetPaymentHistoryByCreated(created, workspace_uuid)
 
-	if paymentHistory.WorkspaceUuid != "" && paymentHistory.Amount != 0 {
-		paymentHistory.Status = true
-		db.db.Where("created = ?", created).Where("workspace_uuid = ? ", workspace_uuid).Updates(paymentHistory)
-
-		// get Workspace budget and add payment to total budget
-		WorkspaceBudget := db.GetWorkspaceBudget(workspace_uuid)
-
-		if WorkspaceBudget.WorkspaceUuid == "" {
-			now := time.Now()
-			workBudget := NewBountyBudget{
-				WorkspaceUuid: workspace_uuid,
-				TotalBudget:   paymentHistory.Amount,
-				Created:       &now,
-				Updated:       &now,
-			}
-			db.CreateWorkspaceBudget(workBudget)
-		} else {
-			totalBudget := WorkspaceBudget.TotalBudget
-			WorkspaceBudget.TotalBudget = totalBudget + paymentHistory.Amount
-			db.UpdateWorkspaceBudget(WorkspaceBudget)
-		}
+	if paymentHistory.WorkspaceUuid == "" || paymentHistory.Amount == 0 {
+		return paymentHistory, nil
 	}
 
-	return paymentHistory
-}
+	paymentHistory.Status = true
+	if err := db.db.Where("created = ?", created).Where("workspace_uuid = ? ", workspace_uuid).Updates(paymentHistory).Error; err != nil {
+		return paymentHistory, err
+	}
 
-func (db database) WithdrawBudget(sender_pubkey string, workspace_uuid string, amount uint) {
-	tx := db.db.Begin()
-	var err error
+	// get Workspace budget and add payment to total budget, the row is
+	// locked so deposits of the same workspace add up
+	WorkspaceBudget := NewBountyBudget{}
+	if err := db.db.Clauses(clause.Locking{Strength: "UPDATE"}).Where("workspace_uuid = ?", workspace_uuid).Find(&WorkspaceBudget).Error; err != nil {
+		return paymentHistory, err
+	}
 
-	defer func() {
-		if r := recover(); r != nil {
-			tx.Rollback()
+	if WorkspaceBudget.WorkspaceUuid == "" {
+		now := time.Now()
+		workBudget := NewBountyBudget{
+			WorkspaceUuid: workspace_uuid,
+			TotalBudget:   paymentHistory.Amount,
+			Created:       &now,
+			Updated:       &now,
 		}
-	}()
-
-	if err = tx.Error; err != nil {
-		return
+		return paymentHistory, db.db.Create(&workBudget).Error
 	}
 
-	// get Workspace budget and add payment to total budget
-	WorkspaceBudget := db.GetWorkspaceBudget(workspace_uuid)
-	totalBudget := WorkspaceBudget.TotalBudget
-
-	newBudget := totalBudget - amount
-
-	if err = tx.Model(&NewBountyBudget{}).Where("workspace_uuid = ?", workspace_uuid).Updates(map[string]interface{}{
-		"total_budget": newBudget,
-	}).Error; err != nil {
-		tx.Rollback()
+	reserve := reserveShare(WorkspaceBudget.ReservePercent, paymentHistory.Amount)
+	updates := map[string]interface{}{
+		"total_budget": WorkspaceBudget.TotalBudget + paymentHistory.Amount - reserve,
 	}
-
-	now := time.Now()
-	budgetHistory := NewPaymentHistory{
-		WorkspaceUuid:  workspace_uuid,
-		Amount:         amount,
-		Status:         true,
-		PaymentType:    "withdraw",
-		Created:        &now,
-		Updated:        &now,
-		SenderPubKey:   sender_pubkey,
-		ReceiverPubKey: "",
-		BountyId:       0,
-	}
-
-	if err = tx.Create(&budgetHistory).Error; err != nil {
-		tx.Rollback()
+	if reserve > 0 {
+		updates["reserve_balance"] = WorkspaceBudget.ReserveBalance + reserve
+	}
+	if err := db.db.Model(&NewBountyBudget{}).Where("workspace_uuid = ?", workspace_uuid).Updates(updates).Error; err != nil {
+		return paymentHistory, err
+	}
+	if reserve > 0 {
+		if err := db.db.Create(reserveDeposit(workspace_uuid, reserve)).Error; err != nil {
+			return paymentHistory, err
+		}
 	}
-	tx.Commit()
+
+	return paymentHistory, nil
 }
 
 func (db database) AddPaymentHistory(payment NewPaymentHistory) NewPaymentHistory {
@@ -409,46 +460,20 @@ func (db database) AddPaymentHistory(payment NewPaymentHistory) NewPaymentHistor
 	return payment
 }
 
-func (db database) ProcessBountyPayment(payment NewPaymentHistory, bounty NewBounty) error {
-	tx := db.db.Begin()
-	var err error
-
-	defer func() {
-		if r := recover(); r != nil {
-			tx.Rollback()
-		}
-	}()
-
-	if err = tx.Error; err != nil {
-		return err
-	}
-
-	// add to payment history
-	if err = tx.Create(&payment).Error; err != nil {
-		tx.Rollback()
-		return err
-	}
-
-	// get Workspace budget and subtract payment from total budget
-	WorkspaceBudget := db.GetWorkspaceBudget(payment.WorkspaceUuid)
-	totalBudget := WorkspaceBudget.TotalBudget
-
-	// update budget
-	WorkspaceBudget.TotalBudget = totalBudget - payment.Amount
-	if err = tx.Model(&NewBountyBudget{}).Where("workspace_uuid = ?", payment.WorkspaceUuid).Updates(map[string]interface{}{
-		"total_budget": WorkspaceBudget.TotalBudget,
-	}).Error; err != nil {
-		tx.Rollback()
-		return err
-	}
-
-	// updatge bounty status
-	if err = tx.Where("created", bounty.Created).Updates(&bounty).Error; err != nil {
-		tx.Rollback()
-		return err
-	}
+// ClaimBountyPayment marks an unpaid bounty as paid before its payout is
+// sent, so two payouts of the same bounty can't both go out, whichever
+// handler or instance sends them. It is false when the bounty is already
+// paid or its payout is being sent
+func (db database) ClaimBountyPayment(id uint) (bool, error) {
+	result := db.db.Model(&NewBounty{}).Where("id = ? AND paid = false", id).Update("paid", true)
+	return result.RowsAffected == 1, result.Error
+}
 
-	return tx.Commit().Error
+// ReleaseBountyPayment undoes the claim of a payout the relay refused, so
+// the bounty can be paid again. A bounty whose payment was recorded keeps
+// its paid date and is not released
+func (db database) ReleaseBountyPayment(id uint) error {
+	return db.db.Model(&NewBounty{}).Where("id = ? AND paid = true AND paid_date IS NULL", id).Update("paid", false).Error
 }
 
 func (db database) GetPaymentHistory(workspace_uuid string, r *http.Request) []NewPaymentHistory {
@@ -461,7 +486,15 @@ func (db database) GetPaymentHistory(workspace_uuid string, r *http.Request) []N
 
 	query := `SELECT * FROM payment_histories WHERE workspace_uuid = '` + workspace_uuid + `' AND status = true ORDER BY created DESC`
 
-	db.db.Raw(query + " " + limitQuery).Find(&payment)
+	db.forRequest(r).Raw(query + " " + limitQuery).Find(&payment)
+	return payment
+}
+
+// GetBountyPayment returns the last settled payout of a bounty, an empty
+// payment when it has none
+func (db database) GetBountyPayment(bountyId uint) NewPaymentHistory {
+	payment := NewPaymentHistory{}
+	db.db.Where("bounty_id = ?", bountyId).Where("payment_type = ?", Payment).Where("status = ?", true).Order("created DESC").Limit(1).Find(&payment)
 	return payment
 }
 
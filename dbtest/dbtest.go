@@ -0,0 +1,248 @@
+// Package dbtest gives integration tests a Postgres database of their own.
+// The migrations and the fixture sets a test asks for are applied once to a
+// template database, the snapshot, and every test gets a copy of it that is
+// dropped when the test ends. Tests don't see each other's rows and don't
+// have to delete them.
+//
+// The server is TEST_DATABASE_URL when it is set, e.g. a CI service, the
+// user needs the CREATEDB privilege. Otherwise a Postgres container is
+// started through the local docker daemon. Tests are skipped when neither
+// is available.
+package dbtest
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ory/dockertest/v3"
+	"github.com/ory/dockertest/v3/docker"
+	"github.com/rs/xid"
+	"github.com/stakwork/sphinx-tribes/db"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+const (
+	postgresImage = "postgres"
+	postgresTag   = "14"
+	// the container is removed after this long even if Purge never runs
+	containerExpiry = 10 * time.Minute
+)
+
+var (
+	serverOnce sync.Once
+	serverURL  *url.URL
+	serverErr  error
+	pool       *dockertest.Pool
+	container  *dockertest.Resource
+
+	// templates are named after this run, so test binaries that share a
+	// server don't build over each other's snapshots
+	runID       = xid.New().String()
+	templatesMu sync.Mutex
+	templates   = map[string]error{}
+
+	// postgres refuses to copy a template that another session is copying
+	copyMu sync.Mutex
+)
+
+// New returns an empty database for t with the migrations applied and the
+// named fixture sets loaded, in order. It is dropped when t ends
+func New(t testing.TB, fixtures ...string) db.Database {
+	t.Helper()
+
+	server, err := start()
+	if err != nil {
+		t.Skipf("dbtest: no postgres server: %s", err)
+	}
+
+	template, err := snapshot(server, fixtures)
+	if err != nil {
+		t.Fatalf("dbtest: %s", err)
+	}
+
+	name := "test_" + xid.New().String()
+	copyMu.Lock()
+	err = exec(server, fmt.Sprintf("CREATE DATABASE %s TEMPLATE %s", name, template))
+	copyMu.Unlock()
+	if err != nil {
+		t.Fatalf("dbtest: %s", err)
+	}
+	database, err := db.ConnectTestDB(databaseURL(server, name))
+	if err != nil {
+		t.Fatalf("dbtest: %s", err)
+	}
+
+	t.Cleanup(func() {
+		database.Close()
+		if err := exec(server, fmt.Sprintf("DROP DATABASE IF EXISTS %s", name)); err != nil {
+			t.Logf("dbtest: %s", err)
+		}
+	})
+	return database
+}
+
+// Main runs the tests of a package and then drops the snapshots and
+// removes the container, call it from TestMain
+func Main(m *testing.M) {
+	code := m.Run()
+	Purge()
+	os.Exit(code)
+}
+
+// Purge drops the snapshots of this run and removes the container
+func Purge() {
+	if serverURL == nil {
+		return
+	}
+
+	templatesMu.Lock()
+	defer templatesMu.Unlock()
+	for name, err := range templates {
+		if err == nil {
+			exec(serverURL, fmt.Sprintf("DROP DATABASE IF EXISTS %s", name))
+		}
+	}
+	templates = map[string]error{}
+
+	if container != nil {
+		pool.Purge(container)
+		container = nil
+	}
+}
+
+// start finds or starts the postgres server once per test binary
+func start() (*url.URL, error) {
+	serverOnce.Do(func() {
+		if dsn := os.Getenv("TEST_DATABASE_URL"); dsn != "" {
+			serverURL, serverErr = url.Parse(dsn)
+			return
+		}
+		serverURL, serverErr = startContainer()
+	})
+	return serverURL, serverErr
+}
+
+func startContainer() (*url.URL, error) {
+	var err error
+	if pool, err = dockertest.NewPool(""); err != nil {
+		return nil, err
+	}
+	if err = pool.Client.Ping(); err != nil {
+		return nil, err
+	}
+
+	container, err = pool.RunWithOptions(&dockertest.RunOptions{
+		Repository: postgresImage,
+		Tag:        postgresTag,
+		Env:        []string{"POSTGRES_USER=test_user", "POSTGRES_PASSWORD=test_password", "POSTGRES_DB=postgres"},
+	}, func(config *docker.HostConfig) {
+		config.AutoRemove = true
+		config.RestartPolicy = docker.RestartPolicy{Name: "no"}
+	})
+	if err != nil {
+		return nil, err
+	}
+	container.Expire(uint(containerExpiry.Seconds()))
+
+	server := &url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword("test_user", "test_password"),
+		Host:     container.GetHostPort("5432/tcp"),
+		Path:     "/postgres",
+		RawQuery: "sslmode=disable",
+	}
+	err = pool.Retry(func() error {
+		return exec(server, "SELECT 1")
+	})
+	if err != nil {
+		pool.Purge(container)
+		container = nil
+		return nil, err
+	}
+	return server, nil
+}
+
+// snapshot builds the template database with the migrations and fixture
+// sets once, and returns its name
+func snapshot(server *url.URL, fixtures []string) (string, error) {
+	name := templateName(fixtures)
+
+	templatesMu.Lock()
+	defer templatesMu.Unlock()
+	if err, ok := templates[name]; ok {
+		return name, err
+	}
+
+	err := buildSnapshot(server, name, fixtures)
+	templates[name] = err
+	return name, err
+}
+
+func buildSnapshot(server *url.URL, name string, fixtures []string) error {
+	for _, fixture := range fixtures {
+		if _, ok := fixtureSets[fixture]; !ok {
+			return fmt.Errorf("unknown fixture set %q", fixture)
+		}
+	}
+
+	if err := exec(server, fmt.Sprintf("CREATE DATABASE %s", name)); err != nil {
+		return err
+	}
+	database, err := db.ConnectTestDB(databaseURL(server, name))
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	for _, fixture := range fixtures {
+		if err := fixtureSets[fixture](database); err != nil {
+			return fmt.Errorf("fixture set %q: %s", fixture, err)
+		}
+	}
+	return nil
+}
+
+func templateName(fixtures []string) string {
+	name := "snapshot_" + runID
+	for _, fixture := range fixtures {
+		name += "_" + strings.Map(func(r rune) rune {
+			if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+				return r
+			}
+			return '_'
+		}, strings.ToLower(fixture))
+	}
+	return name
+}
+
+func databaseURL(server *url.URL, name string) string {
+	u := *server
+	u.Path = "/" + name
+	return u.String()
+}
+
+// exec runs a statement on the database of the server url with a
+// connection of its own, it is used for the CREATE and DROP DATABASE of the
+// snapshots and their copies
+func exec(server *url.URL, statement string) error {
+	conn, err := gorm.Open(postgres.New(postgres.Config{
+		DSN:                  server.String(),
+		PreferSimpleProtocol: true,
+	}), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		return err
+	}
+	sqlDB, err := conn.DB()
+	if err != nil {
+		return err
+	}
+	defer sqlDB.Close()
+	return conn.Exec(statement).Error
+}
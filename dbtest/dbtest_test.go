@@ -0,0 +1,49 @@
+package dbtest
+
+import (
+	"testing"
+
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/seed"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMain(m *testing.M) {
+	Main(m)
+}
+
+func TestTemplateName(t *testing.T) {
+	assert.Equal(t, "snapshot_"+runID, templateName(nil))
+	assert.Equal(t, "snapshot_"+runID+"_seed_open_bounties", templateName([]string{"seed", "Open-Bounties"}))
+}
+
+func TestNew(t *testing.T) {
+	t.Run("should give every test a database of its own", func(t *testing.T) {
+		first := New(t)
+		second := New(t)
+
+		_, err := first.CreateOrEditPerson(db.Person{Uuid: "dbtest-person", OwnerPubKey: "dbtest-pubkey", OwnerAlias: "dbtest"})
+		assert.NoError(t, err)
+
+		assert.NotZero(t, first.GetPersonByPubkey("dbtest-pubkey").ID)
+		assert.Zero(t, second.GetPersonByPubkey("dbtest-pubkey").ID)
+	})
+
+	t.Run("should load the fixture sets", func(t *testing.T) {
+		database := New(t, "seed")
+
+		assert.NotZero(t, database.GetPersonByPubkey(seed.Pubkey(0)).ID)
+	})
+
+	t.Run("should load a registered fixture set", func(t *testing.T) {
+		Register("dbtest_codes", func(database db.Database) error {
+			_, err := database.CreateConnectionCode([]db.ConnectionCodes{{ConnectionString: "fixture-code"}})
+			return err
+		})
+		database := New(t, "dbtest_codes")
+
+		code, err := database.RedeemConnectionCode()
+		assert.NoError(t, err)
+		assert.Equal(t, "fixture-code", code.ConnectionString)
+	})
+}
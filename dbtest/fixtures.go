@@ -0,0 +1,34 @@
+package dbtest
+
+import (
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/seed"
+)
+
+// Fixture loads a set of rows into a snapshot
+type Fixture func(database db.Database) error
+
+// SeedTime is the creation time of the rows of the seed fixture set
+var SeedTime = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+var fixtureSets = map[string]Fixture{
+	// the demo data of `sphinx-tribes seed`, see the seed package for
+	// its uuids and pubkeys
+	"seed": func(database db.Database) error {
+		_, err := seed.Run(database, SeedTime)
+		return err
+	},
+}
+
+// Register adds a named fixture set, it is meant to be called from an init
+// or TestMain before the tests that use it
+func Register(name string, fixture Fixture) {
+	templatesMu.Lock()
+	defer templatesMu.Unlock()
+	if _, ok := fixtureSets[name]; ok {
+		panic("dbtest: fixture set " + name + " is already registered")
+	}
+	fixtureSets[name] = fixture
+}
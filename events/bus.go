@@ -0,0 +1,284 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rs/xid"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/tracing"
+)
+
+// Event types, the subject of an event is the "<kind>:<id>" of its record
+const (
+	BountyCreated   = "bounty.created"
+	BountyUpdated   = "bounty.updated"
+	BountyDeleted   = "bounty.deleted"
+	BountyRouted    = "bounty.routed"
+	ProofSubmitted  = "bounty.proof_submitted"
+	ProofReviewed   = "bounty.proof_reviewed"
+	PaymentSettled  = "payment.settled"
+	TipReceived     = "tip.received"
+	BudgetUpdated   = "budget.updated"
+	TicketUpdated   = "ticket.updated"
+	TribeCreated    = "tribe.created"
+	TribeUpdated    = "tribe.updated"
+	TribeJoined     = "tribe.joined"
+	TribeAnnounced  = "tribe.announced"
+	TribeScheduled  = "tribe.scheduled"
+	PersonUpdated   = "person.updated"
+	PersonMentioned = "person.mentioned"
+	ReportResolved  = "report.resolved"
+
+	TribeShadowListed = "tribe.shadow_listed"
+
+	WorkspaceDigested = "workspace.digest"
+
+	ConnectionCodeRedeemed = "connection_code.redeemed"
+)
+
+// Handler consumes a single event
+type Handler func(ctx context.Context, event db.Event) error
+
+const (
+	defaultPollInterval = 5 * time.Second
+	replayBatchSize     = 100
+)
+
+type consumer struct {
+	name    string
+	types   []string
+	durable bool
+	handle  Handler
+}
+
+func (c *consumer) wants(eventType string) bool {
+	if len(c.types) == 0 {
+		return true
+	}
+	for _, t := range c.types {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// Bus persists every published event to the event log and hands it to the
+// consumers. Live consumers are called as events are published, durable
+// consumers read the log from their saved position so they never miss an
+// event, and a new durable consumer replays the log from the start
+type Bus struct {
+	db           db.Database
+	mu           sync.RWMutex
+	consumers    []*consumer
+	pollInterval time.Duration
+	wake         chan struct{}
+}
+
+// Default is the bus used by the rest of the app, it is set up in main
+var Default *Bus
+
+func NewBus(database db.Database) *Bus {
+	return &Bus{
+		db:           database,
+		pollInterval: defaultPollInterval,
+		wake:         make(chan struct{}, 1),
+	}
+}
+
+func InitBus(database db.Database) {
+	Default = NewBus(database)
+	registerConsumers(Default)
+}
+
+// Subscribe registers a live consumer of the given event types, or of every
+// event when no type is given
+func (b *Bus) Subscribe(name string, handle Handler, types ...string) {
+	b.add(&consumer{name: name, types: types, handle: handle})
+}
+
+// SubscribeDurable registers a consumer whose position in the log is saved.
+// A failed event is retried on the next poll and blocks the events after it
+func (b *Bus) SubscribeDurable(name string, handle Handler, types ...string) {
+	b.add(&consumer{name: name, types: types, durable: true, handle: handle})
+}
+
+func (b *Bus) add(c *consumer) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.consumers = append(b.consumers, c)
+}
+
+func (b *Bus) list(durable bool) []*consumer {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	consumers := []*consumer{}
+	for _, c := range b.consumers {
+		if c.durable == durable {
+			consumers = append(consumers, c)
+		}
+	}
+	return consumers
+}
+
+// Publish appends an event to the log and hands it to the live consumers.
+// The live consumers still get the event when it could not be saved
+func (b *Bus) Publish(ctx context.Context, eventType string, subject string, payload interface{}) (db.Event, error) {
+	event := db.Event{
+		Uuid:    xid.New().String(),
+		Type:    eventType,
+		Subject: subject,
+	}
+
+	props, err := toPayload(payload)
+	if err == nil {
+		event.Payload = props
+		event.Actor, _ = ctx.Value(auth.ContextKey).(string)
+		event.PubKeys = pubKeysOf(event.Actor, props)
+		var saved db.Event
+		if saved, err = b.db.CreateEvent(event); err == nil {
+			event = saved
+		}
+	}
+
+	for _, c := range b.list(false) {
+		if c.wants(eventType) {
+			if handleErr := b.run(ctx, c, event); handleErr != nil {
+				fmt.Printf("[events] %s could not handle %s %s: %s\n", c.name, event.Type, event.Uuid, handleErr)
+			}
+		}
+	}
+
+	select {
+	case b.wake <- struct{}{}:
+	default:
+	}
+	return event, err
+}
+
+func (b *Bus) run(ctx context.Context, c *consumer, event db.Event) (err error) {
+	ctx, span := tracing.Start(ctx, "event."+c.name)
+	defer span.End()
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("consumer panicked: %v", r)
+		}
+	}()
+	return c.handle(ctx, event)
+}
+
+// CatchUp hands every durable consumer the events it has not handled yet. A
+// consumer stops at its first failed event and picks it up again next time
+func (b *Bus) CatchUp(ctx context.Context) {
+	for _, c := range b.list(true) {
+		if err := b.catchUp(ctx, c); err != nil {
+			fmt.Printf("[events] %s is behind: %s\n", c.name, err)
+		}
+	}
+}
+
+func (b *Bus) catchUp(ctx context.Context, c *consumer) error {
+	position := b.db.GetEventConsumer(c.name).LastEventID
+	for ctx.Err() == nil {
+		events, err := b.db.GetEventsAfter(position, c.types, replayBatchSize)
+		if err != nil {
+			return err
+		}
+		if len(events) == 0 {
+			return nil
+		}
+
+		for _, event := range events {
+			if err := b.run(ctx, c, event); err != nil {
+				return fmt.Errorf("event %d: %s", event.ID, err)
+			}
+			position = event.ID
+			if err := b.db.SaveEventConsumer(c.name, position); err != nil {
+				return err
+			}
+		}
+	}
+	return ctx.Err()
+}
+
+// Start runs the durable consumers until ctx is cancelled, they catch up as
+// soon as an event is published and on every poll
+func (b *Bus) Start(ctx context.Context) {
+	for ctx.Err() == nil {
+		b.CatchUp(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-b.wake:
+		case <-time.After(b.pollInterval):
+		}
+	}
+}
+
+// Publish sends an event through the default bus. Failures are logged, a
+// request that already succeeded doesn't fail because of its event
+func Publish(ctx context.Context, eventType string, subject string, payload interface{}) {
+	if Default == nil {
+		return
+	}
+	if _, err := Default.Publish(ctx, eventType, subject, payload); err != nil {
+		fmt.Printf("[events] could not save %s %s: %s\n", eventType, subject, err)
+	}
+}
+
+// payload fields that name the users an event concerns, the list field
+// names several of them
+var pubKeyFields = []string{"owner_id", "owner_pubkey", "assignee", "sender_pubkey", "receiver_pubkey", "reporter_pubkey", "mentioned_pubkey"}
+
+const pubKeyListField = "member_pubkeys"
+
+// pubKeysOf returns the user who published the event and the users its
+// payload names, once each
+func pubKeysOf(actor string, props db.PropertyMap) []string {
+	pubKeys := []string{}
+	add := func(pubkey string) {
+		if pubkey == "" {
+			return
+		}
+		for _, p := range pubKeys {
+			if p == pubkey {
+				return
+			}
+		}
+		pubKeys = append(pubKeys, pubkey)
+	}
+
+	add(actor)
+	for _, field := range pubKeyFields {
+		value, _ := props[field].(string)
+		add(value)
+	}
+	members, _ := props[pubKeyListField].([]interface{})
+	for _, member := range members {
+		value, _ := member.(string)
+		add(value)
+	}
+	return pubKeys
+}
+
+func toPayload(payload interface{}) (db.PropertyMap, error) {
+	props := db.PropertyMap{}
+	if payload == nil {
+		return props, nil
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &props); err != nil {
+		return nil, err
+	}
+	return props, nil
+}
@@ -0,0 +1,116 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stakwork/sphinx-tribes/db"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPublish(t *testing.T) {
+	t.Run("should save the event and hand it to the live consumers of its type", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		bus := NewBus(mockDb)
+
+		received := []db.Event{}
+		bus.Subscribe("bounties", func(ctx context.Context, event db.Event) error {
+			received = append(received, event)
+			return nil
+		}, BountyCreated)
+		bus.Subscribe("payments", func(ctx context.Context, event db.Event) error {
+			t.Fatal("payments consumer should not get bounty events")
+			return nil
+		}, PaymentSettled)
+
+		mockDb.On("CreateEvent", mock.MatchedBy(func(e db.Event) bool {
+			return e.Uuid != "" && e.Type == BountyCreated && e.Subject == "bounty:1" && e.Payload["title"] == "a bounty"
+		})).Return(func(e db.Event) db.Event {
+			e.ID = 7
+			return e
+		}, nil).Once()
+
+		event, err := bus.Publish(context.Background(), BountyCreated, "bounty:1", db.NewBounty{ID: 1, Title: "a bounty"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint(7), event.ID)
+		assert.Len(t, received, 1)
+		assert.Equal(t, uint(7), received[0].ID)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should still reach live consumers when the event can not be saved", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		bus := NewBus(mockDb)
+
+		called := false
+		bus.Subscribe("all", func(ctx context.Context, event db.Event) error {
+			called = true
+			panic("consumer failure")
+		})
+
+		mockDb.On("CreateEvent", mock.Anything).Return(db.Event{}, errors.New("db down")).Once()
+
+		_, err := bus.Publish(context.Background(), BountyUpdated, "bounty:1", nil)
+
+		assert.Error(t, err)
+		assert.True(t, called)
+	})
+}
+
+func TestPubKeysOf(t *testing.T) {
+	props := db.PropertyMap{"owner_id": "owner", "assignee": "hunter", "title": "a bounty", "owner_pubkey": ""}
+
+	assert.Equal(t, []string{"owner", "hunter"}, pubKeysOf("owner", props))
+	assert.Equal(t, []string{"reporter"}, pubKeysOf("", db.PropertyMap{"reporter_pubkey": "reporter"}))
+	assert.Equal(t, []string{"owner", "mentioned"}, pubKeysOf("owner", db.PropertyMap{"mentioned_pubkey": "mentioned"}))
+	assert.Equal(t, []string{"owner", "member"}, pubKeysOf("", db.PropertyMap{"member_pubkeys": []interface{}{"owner", "member", "owner"}}))
+}
+
+func TestCatchUp(t *testing.T) {
+	t.Run("should replay the log for a new durable consumer and save its position", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		bus := NewBus(mockDb)
+
+		handled := []uint{}
+		bus.SubscribeDurable("webhooks", func(ctx context.Context, event db.Event) error {
+			handled = append(handled, event.ID)
+			return nil
+		}, BountyCreated)
+
+		mockDb.On("GetEventConsumer", "webhooks").Return(db.EventConsumer{Name: "webhooks"}).Once()
+		mockDb.On("GetEventsAfter", uint(0), []string{BountyCreated}, replayBatchSize).Return([]db.Event{{ID: 1}, {ID: 4}}, nil).Once()
+		mockDb.On("SaveEventConsumer", "webhooks", uint(1)).Return(nil).Once()
+		mockDb.On("SaveEventConsumer", "webhooks", uint(4)).Return(nil).Once()
+		mockDb.On("GetEventsAfter", uint(4), []string{BountyCreated}, replayBatchSize).Return([]db.Event{}, nil).Once()
+
+		bus.CatchUp(context.Background())
+
+		assert.Equal(t, []uint{1, 4}, handled)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should stop at a failed event without moving past it", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		bus := NewBus(mockDb)
+
+		bus.SubscribeDurable("webhooks", func(ctx context.Context, event db.Event) error {
+			if event.ID == 6 {
+				return errors.New("endpoint down")
+			}
+			return nil
+		})
+
+		mockDb.On("GetEventConsumer", "webhooks").Return(db.EventConsumer{Name: "webhooks", LastEventID: 4}).Once()
+		mockDb.On("GetEventsAfter", uint(4), []string(nil), replayBatchSize).Return([]db.Event{{ID: 5}, {ID: 6}, {ID: 7}}, nil).Once()
+		mockDb.On("SaveEventConsumer", "webhooks", uint(5)).Return(nil).Once()
+
+		bus.CatchUp(context.Background())
+
+		mockDb.AssertExpectations(t)
+		mockDb.AssertNotCalled(t, "SaveEventConsumer", "webhooks", uint(6))
+	})
+}
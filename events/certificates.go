@@ -0,0 +1,80 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/rs/xid"
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/credentials"
+	"github.com/stakwork/sphinx-tribes/db"
+)
+
+// RegisterBountyCertificates issues the completion certificates of paid
+// bounties, through a durable consumer so a payment made while the
+// instance was down still gets one. A new consumer replays the log, so the
+// bounties paid before the key was set get their certificate too
+func RegisterBountyCertificates(b *Bus, database db.Database) {
+	b.SubscribeDurable("bounty_certificates", IssueBountyCertificate(database), PaymentSettled)
+}
+
+// IssueBountyCertificate signs a certificate for the assignee of a paid
+// bounty. Nothing is issued without BOUNTY_CERTIFICATE_KEY
+func IssueBountyCertificate(database db.Database) Handler {
+	return func(ctx context.Context, event db.Event) error {
+		if !credentials.Configured() {
+			return nil
+		}
+		id, _ := event.Payload["id"].(float64)
+		if id == 0 {
+			return nil
+		}
+		bounty := database.GetBounty(uint(id))
+		if bounty.ID == 0 || !bounty.Paid || bounty.Assignee == "" {
+			return nil
+		}
+
+		now := time.Now()
+		completed := &now
+		if bounty.PaidDate != nil {
+			completed = bounty.PaidDate
+		} else if event.Created != nil {
+			completed = event.Created
+		}
+		claim := db.BountyCertificateClaim{
+			Type:          db.BountyCertificateType,
+			Uuid:          xid.New().String(),
+			Issuer:        config.Host,
+			Pubkey:        bounty.Assignee,
+			BountyID:      bounty.ID,
+			BountyTitle:   bounty.Title,
+			WorkspaceUuid: bounty.WorkspaceUuid,
+			Completed:     completed.UTC().Format("2006-01-02"),
+			Issued:        now.Unix(),
+		}
+		if bounty.WorkspaceUuid != "" {
+			claim.WorkspaceName = database.GetWorkspaceByUuid(bounty.WorkspaceUuid).Name
+		}
+		signed, err := json.Marshal(claim)
+		if err != nil {
+			return err
+		}
+		signature, keyId, err := credentials.Sign(signed)
+		if err != nil {
+			return err
+		}
+
+		_, err = database.CreateBountyCertificate(db.BountyCertificate{
+			Uuid:          claim.Uuid,
+			BountyID:      bounty.ID,
+			Pubkey:        bounty.Assignee,
+			WorkspaceUuid: bounty.WorkspaceUuid,
+			Claim:         string(signed),
+			Signature:     signature,
+			KeyId:         keyId,
+			Created:       &now,
+		})
+		return err
+	}
+}
@@ -0,0 +1,73 @@
+package events
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/credentials"
+	"github.com/stakwork/sphinx-tribes/db"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestIssueBountyCertificate(t *testing.T) {
+	t.Setenv("RELAY_AUTH_KEY", "TEST")
+	defer func() {
+		t.Setenv("BOUNTY_CERTIFICATE_KEY", "")
+		config.InitConfig()
+	}()
+	paid := time.Date(2024, 5, 2, 15, 0, 0, 0, time.UTC)
+	event := db.Event{Type: PaymentSettled, Subject: "bounty:7", Payload: db.PropertyMap{"id": float64(7)}}
+	bounty := db.NewBounty{ID: 7, Title: "Fix the relay", Assignee: "hunter", WorkspaceUuid: "workspace-uuid", Paid: true, PaidDate: &paid}
+
+	t.Run("should not issue without a signing key", func(t *testing.T) {
+		config.InitConfig()
+		mockDb := &dbMocks.Database{}
+
+		assert.NoError(t, IssueBountyCertificate(mockDb)(context.Background(), event))
+		mockDb.AssertNotCalled(t, "GetBounty", mock.Anything)
+	})
+
+	t.Setenv("BOUNTY_CERTIFICATE_KEY", base64.StdEncoding.EncodeToString([]byte(strings.Repeat("k", 32))))
+	config.InitConfig()
+
+	t.Run("should sign a certificate for the assignee of a paid bounty", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mockDb.On("GetBounty", uint(7)).Return(bounty).Once()
+		mockDb.On("GetWorkspaceByUuid", "workspace-uuid").Return(db.Workspace{Name: "Sphinx"}).Once()
+		var saved db.BountyCertificate
+		mockDb.On("CreateBountyCertificate", mock.AnythingOfType("db.BountyCertificate")).Return(func(c db.BountyCertificate) (db.BountyCertificate, error) {
+			saved = c
+			return c, nil
+		}).Once()
+
+		assert.NoError(t, IssueBountyCertificate(mockDb)(context.Background(), event))
+		mockDb.AssertExpectations(t)
+
+		claim := db.BountyCertificateClaim{}
+		assert.NoError(t, json.Unmarshal([]byte(saved.Claim), &claim))
+		assert.Equal(t, "hunter", claim.Pubkey)
+		assert.Equal(t, uint(7), claim.BountyID)
+		assert.Equal(t, "Sphinx", claim.WorkspaceName)
+		assert.Equal(t, "2024-05-02", claim.Completed)
+		assert.Equal(t, claim.Uuid, saved.Uuid)
+		published, _ := credentials.Published()
+		assert.True(t, credentials.Verify(published, []byte(saved.Claim), saved.Signature))
+	})
+
+	t.Run("should not issue for a bounty that is not paid", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		unpaid := bounty
+		unpaid.Paid = false
+		mockDb.On("GetBounty", uint(7)).Return(unpaid).Once()
+
+		assert.NoError(t, IssueBountyCertificate(mockDb)(context.Background(), event))
+		mockDb.AssertNotCalled(t, "CreateBountyCertificate", mock.Anything)
+	})
+}
@@ -0,0 +1,65 @@
+package events
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/websocket"
+)
+
+func registerConsumers(b *Bus) {
+	b.Subscribe("websocket", publishToTopics, BountyCreated, BountyUpdated, PaymentSettled, BudgetUpdated, TicketUpdated, ReportResolved, WorkspaceDigested, TribeAnnounced, TribeScheduled)
+	b.Subscribe("metrics", countEvent)
+}
+
+// publishToTopics forwards events to the websocket topics under the message
+// names the clients already listen for
+func publishToTopics(ctx context.Context, event db.Event) error {
+	workspace, _ := event.Payload["workspace_uuid"].(string)
+	data := map[string]interface{}(event.Payload)
+
+	switch event.Type {
+	case BountyCreated, BountyUpdated:
+		websocket.Publish(event.Subject, "bounty_updated", data)
+		if workspace != "" {
+			websocket.Publish(websocket.Topic(websocket.TopicWorkspace, workspace), "bounty_updated", data)
+		}
+	case PaymentSettled:
+		websocket.Publish(websocket.Topic(websocket.TopicPayment, workspace), "bounty_paid", data)
+		websocket.Publish(event.Subject, "bounty_paid", data)
+	case BudgetUpdated:
+		websocket.Publish(websocket.Topic(websocket.TopicPayment, workspace), "budget_updated", data)
+	case TicketUpdated:
+		websocket.Publish(event.Subject, "ticket_updated", data)
+	case ReportResolved:
+		reporter, _ := event.Payload["reporter_pubkey"].(string)
+		websocket.Publish(websocket.Topic(websocket.TopicUser, reporter), "report_resolved", data)
+	case WorkspaceDigested:
+		websocket.Publish(websocket.Topic(websocket.TopicWorkspace, workspace), "workspace_digest", data)
+	case TribeAnnounced:
+		websocket.Publish(event.Subject, "tribe_announcement", data)
+	case TribeScheduled:
+		websocket.Publish(event.Subject, "tribe_event", data)
+	}
+	return nil
+}
+
+var eventCounts sync.Map
+
+func countEvent(ctx context.Context, event db.Event) error {
+	count, _ := eventCounts.LoadOrStore(event.Type, new(int64))
+	atomic.AddInt64(count.(*int64), 1)
+	return nil
+}
+
+// Stats returns the number of events of each type published since startup
+func Stats() map[string]int64 {
+	stats := map[string]int64{}
+	eventCounts.Range(func(key, value interface{}) bool {
+		stats[key.(string)] = atomic.LoadInt64(value.(*int64))
+		return true
+	})
+	return stats
+}
@@ -0,0 +1,139 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/websocket"
+)
+
+// the websocket message with the unread count, sent on the user topic
+const UnreadNotificationsMessage = "notifications_unread"
+
+// a new dispatcher replays the whole log, the events older than this are
+// not worth a notification anymore
+const notificationMaxAge = 24 * time.Hour
+
+var notificationTypes = []string{
+	BountyCreated, BountyUpdated, BountyDeleted, PaymentSettled, BudgetUpdated,
+	TicketUpdated, TribeUpdated, TribeJoined, ReportResolved, PersonMentioned,
+	ConnectionCodeRedeemed, WorkspaceDigested, TribeShadowListed, BountyRouted, TipReceived,
+	ProofSubmitted, ProofReviewed,
+}
+
+// RegisterNotifications fills the inboxes of the users an event concerns
+// through a durable consumer, everyone but the user who caused the event
+func RegisterNotifications(b *Bus, database db.Database) {
+	b.SubscribeDurable("notifications", Notify(database), notificationTypes...)
+}
+
+func Notify(database db.Database) Handler {
+	return func(ctx context.Context, event db.Event) error {
+		if event.Created != nil && time.Since(*event.Created) > notificationMaxAge {
+			return nil
+		}
+
+		notifications := []db.Notification{}
+		for _, pubkey := range event.PubKeys {
+			if pubkey == event.Actor {
+				continue
+			}
+			notifications = append(notifications, db.Notification{
+				PubKey:  pubkey,
+				EventID: event.ID,
+				Type:    event.Type,
+				Subject: event.Subject,
+				Message: notificationMessage(event),
+			})
+		}
+		if len(notifications) == 0 {
+			return nil
+		}
+
+		if err := database.CreateNotifications(notifications); err != nil {
+			return err
+		}
+		for _, n := range notifications {
+			PublishUnreadCount(database, n.PubKey)
+		}
+		return nil
+	}
+}
+
+// PublishUnreadCount sends the unread count of a user to their websocket
+// topic, for the bell icon
+func PublishUnreadCount(database db.Database, pubkey string) {
+	websocket.Publish(websocket.Topic(websocket.TopicUser, pubkey), UnreadNotificationsMessage, map[string]interface{}{
+		"unread": database.GetNotificationsCount(pubkey, true),
+	})
+}
+
+func notificationMessage(event db.Event) string {
+	title := func(keys ...string) string {
+		for _, key := range keys {
+			if value, ok := event.Payload[key].(string); ok && value != "" {
+				return value
+			}
+		}
+		return event.Subject
+	}
+
+	switch event.Type {
+	case BountyCreated:
+		return fmt.Sprintf("Bounty %q was created", title("title"))
+	case BountyUpdated:
+		return fmt.Sprintf("Bounty %q was updated", title("title"))
+	case BountyDeleted:
+		return fmt.Sprintf("Bounty %q was deleted", title("title"))
+	case BountyRouted:
+		return fmt.Sprintf("Bounty %q matched a routing rule that notifies you", title("title"))
+	case ProofSubmitted:
+		return fmt.Sprintf("A proof on bounty %q is waiting for your review", title("title"))
+	case ProofReviewed:
+		if status, _ := event.Payload["status"].(string); status == db.ProofApproved {
+			return fmt.Sprintf("The proof on bounty %q was approved", title("title"))
+		}
+		if decision, _ := event.Payload["decision"].(string); decision == db.ProofChangesRequested {
+			return fmt.Sprintf("Changes were requested on the proof of bounty %q", title("title"))
+		}
+		return fmt.Sprintf("The proof on bounty %q was reviewed", title("title"))
+	case PaymentSettled:
+		return fmt.Sprintf("Bounty %q was paid", title("title"))
+	case TipReceived:
+		if memo, _ := event.Payload["memo"].(string); memo != "" {
+			return fmt.Sprintf("You received a tip of %v sats: %q", event.Payload["amount"], memo)
+		}
+		return fmt.Sprintf("You received a tip of %v sats", event.Payload["amount"])
+	case BudgetUpdated:
+		return "The workspace budget was updated"
+	case TicketUpdated:
+		if moved, _ := event.Payload["moved"].(bool); moved {
+			return fmt.Sprintf("Ticket %q was moved to phase %q", title("name", "uuid"), title("to_phase_name", "to_phase_uuid"))
+		}
+		return fmt.Sprintf("Ticket %q was updated", title("name", "uuid"))
+	case TribeUpdated:
+		if deleted, _ := event.Payload["deleted"].(bool); deleted {
+			return fmt.Sprintf("Tribe %q was deleted", title("name", "uuid"))
+		}
+		return fmt.Sprintf("Tribe %q was updated", title("name", "uuid"))
+	case TribeJoined:
+		return fmt.Sprintf("Someone joined tribe %q", title("name", "uuid"))
+	case TribeShadowListed:
+		return fmt.Sprintf("Tribe %q is hidden from the listings until it is reviewed, you can appeal", title("name", "uuid"))
+	case PersonMentioned:
+		return fmt.Sprintf("You were mentioned in %q", title("title"))
+	case ReportResolved:
+		return fmt.Sprintf("Your report was %s", title("status"))
+	case ConnectionCodeRedeemed:
+		if campaign, _ := event.Payload["campaign"].(string); campaign != "" {
+			return fmt.Sprintf("A connection code of campaign %q was redeemed", campaign)
+		}
+		return "One of your connection codes was redeemed"
+	case WorkspaceDigested:
+		return fmt.Sprintf("Week in %q: %v new bounties, %v completed, %v paid, %v stale", title("name", "workspace_uuid"),
+			event.Payload["new_bounties"], event.Payload["completed"], event.Payload["paid"], event.Payload["stale_bounties"])
+	}
+	return event.Type
+}
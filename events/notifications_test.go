@@ -0,0 +1,84 @@
+package events
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/db"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stakwork/sphinx-tribes/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestNotify(t *testing.T) {
+	now := time.Now()
+
+	t.Run("should notify everyone the event concerns but its actor", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		listener, _, err := websocket.WebsocketPool.Listen("hunter", []string{websocket.Topic(websocket.TopicUser, "hunter")}, "")
+		assert.NoError(t, err)
+		defer websocket.WebsocketPool.StopListening(listener)
+
+		mockDb.On("CreateNotifications", []db.Notification{{
+			PubKey:  "hunter",
+			EventID: 3,
+			Type:    PaymentSettled,
+			Subject: "bounty:1",
+			Message: `Bounty "a bounty" was paid`,
+		}}).Return(nil).Once()
+		mockDb.On("GetNotificationsCount", "hunter", true).Return(int64(2)).Once()
+
+		err = Notify(mockDb)(context.Background(), db.Event{
+			ID:      3,
+			Type:    PaymentSettled,
+			Subject: "bounty:1",
+			Actor:   "owner",
+			PubKeys: []string{"owner", "hunter"},
+			Payload: db.PropertyMap{"title": "a bounty"},
+			Created: &now,
+		})
+
+		assert.NoError(t, err)
+		mockDb.AssertExpectations(t)
+		select {
+		case message := <-listener.Messages:
+			assert.Equal(t, UnreadNotificationsMessage, message.Msg)
+			assert.Equal(t, map[string]interface{}{"unread": int64(2)}, message.Data)
+		case <-time.After(time.Second):
+			t.Fatal("the unread count was not published")
+		}
+	})
+
+	t.Run("should skip the events nobody else is concerned by", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+
+		err := Notify(mockDb)(context.Background(), db.Event{ID: 4, Type: TribeUpdated, Actor: "owner", PubKeys: []string{"owner"}, Created: &now})
+
+		assert.NoError(t, err)
+		mockDb.AssertNotCalled(t, "CreateNotifications", mock.Anything)
+	})
+
+	t.Run("should skip the old events a new dispatcher replays", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		old := now.Add(-48 * time.Hour)
+
+		err := Notify(mockDb)(context.Background(), db.Event{ID: 1, Type: BountyUpdated, PubKeys: []string{"hunter"}, Created: &old})
+
+		assert.NoError(t, err)
+		mockDb.AssertNotCalled(t, "CreateNotifications", mock.Anything)
+	})
+}
+
+func TestNotificationMessage(t *testing.T) {
+	assert.Equal(t, `Tribe "Sphinx Devs" was updated`, notificationMessage(db.Event{Type: TribeUpdated, Payload: db.PropertyMap{"name": "Sphinx Devs"}}))
+	assert.Equal(t, `Tribe "tribe-uuid" was deleted`, notificationMessage(db.Event{Type: TribeUpdated, Payload: db.PropertyMap{"uuid": "tribe-uuid", "deleted": true}}))
+	assert.Equal(t, `You were mentioned in "Fix the build"`, notificationMessage(db.Event{Type: PersonMentioned, Payload: db.PropertyMap{"title": "Fix the build"}}))
+	assert.Equal(t, `Ticket "Add the wallet" was moved to phase "Launch"`, notificationMessage(db.Event{Type: TicketUpdated, Payload: db.PropertyMap{"name": "Add the wallet", "moved": true, "to_phase_name": "Launch"}}))
+	assert.Equal(t, "Your report was dismissed", notificationMessage(db.Event{Type: ReportResolved, Payload: db.PropertyMap{"status": "dismissed"}}))
+	assert.Equal(t, `You received a tip of 100 sats: "thanks"`, notificationMessage(db.Event{Type: TipReceived, Payload: db.PropertyMap{"amount": float64(100), "memo": "thanks"}}))
+	assert.Equal(t, `Week in "Sphinx": 3 new bounties, 1 completed, 2 paid, 0 stale`, notificationMessage(db.Event{Type: WorkspaceDigested, Payload: db.PropertyMap{
+		"name": "Sphinx", "new_bounties": float64(3), "completed": float64(1), "paid": float64(2), "stale_bounties": float64(0),
+	}}))
+}
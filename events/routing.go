@@ -0,0 +1,69 @@
+package events
+
+import (
+	"context"
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/db"
+)
+
+// a new routing consumer replays the whole log, the bounties of older
+// events were triaged by hand already
+const routingMaxAge = 24 * time.Hour
+
+// RegisterBountyRouting applies the routing rules of a workspace to its
+// bounties as they are created and edited, through a durable consumer so a
+// restart doesn't skip a bounty
+func RegisterBountyRouting(b *Bus, database db.Database) {
+	b.SubscribeDurable("bounty_routing", RouteBounty(database), BountyCreated, BountyUpdated)
+}
+
+// RouteBounty applies the rules that match the bounty of an event and were
+// not applied to it yet. The pubkeys of the rules are told with a
+// bounty.routed event, and a changed bounty is published again
+func RouteBounty(database db.Database) Handler {
+	return func(ctx context.Context, event db.Event) error {
+		if event.Created != nil && time.Since(*event.Created) > routingMaxAge {
+			return nil
+		}
+		workspace, _ := event.Payload["workspace_uuid"].(string)
+		id, _ := event.Payload["id"].(float64)
+		if workspace == "" || id == 0 {
+			return nil
+		}
+
+		rules, err := database.GetBountyRoutingRules(workspace)
+		if err != nil || len(rules) == 0 {
+			return err
+		}
+		bounty := database.GetBounty(uint(id))
+		if bounty.ID == 0 || bounty.WorkspaceUuid != workspace {
+			return nil
+		}
+		applied, err := database.GetAppliedBountyRoutings(bounty.ID)
+		if err != nil {
+			return err
+		}
+
+		plan := db.RouteBounty(rules, bounty, applied)
+		if len(plan.Rules) == 0 {
+			return nil
+		}
+		routed, ok, err := database.ApplyBountyRouting(bounty.ID, plan)
+		if err != nil || !ok {
+			return err
+		}
+
+		Publish(ctx, BountyRouted, event.Subject, map[string]interface{}{
+			"id":             routed.ID,
+			"title":          routed.Title,
+			"workspace_uuid": routed.WorkspaceUuid,
+			"rules":          plan.Rules,
+			"member_pubkeys": plan.NotifyPubkeys,
+		})
+		if routed.Assignee != bounty.Assignee || routed.PhaseUuid != bounty.PhaseUuid {
+			Publish(ctx, BountyUpdated, event.Subject, routed)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,103 @@
+package events
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/db"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRouteBounty(t *testing.T) {
+	now := time.Now()
+	rules := []db.BountyRoutingRule{
+		{Uuid: "design", WorkspaceUuid: "workspace-uuid", Label: "design", Assignee: "designer", NotifyPubkeys: []string{"lead"}},
+		{Uuid: "backend", WorkspaceUuid: "workspace-uuid", Label: "backend", PhaseUuid: "backend-phase"},
+	}
+	event := db.Event{
+		Type:    BountyCreated,
+		Subject: "bounty:7",
+		Payload: db.PropertyMap{"id": float64(7), "workspace_uuid": "workspace-uuid"},
+		Created: &now,
+	}
+	published := func(mockDb *dbMocks.Database) *[]db.Event {
+		events := &[]db.Event{}
+		mockDb.On("CreateEvent", mock.Anything).Return(func(e db.Event) (db.Event, error) {
+			*events = append(*events, e)
+			return e, nil
+		})
+		previous := Default
+		Default = NewBus(mockDb)
+		t.Cleanup(func() { Default = previous })
+		return events
+	}
+
+	t.Run("should apply the matching rules and tell their pubkeys", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		events := published(mockDb)
+		bounty := db.NewBounty{ID: 7, Title: "a bounty", WorkspaceUuid: "workspace-uuid", Labels: []string{"Design"}}
+		routed := bounty
+		routed.Assignee = "designer"
+
+		mockDb.On("GetBountyRoutingRules", "workspace-uuid").Return(rules, nil).Once()
+		mockDb.On("GetBounty", uint(7)).Return(bounty).Once()
+		mockDb.On("GetAppliedBountyRoutings", uint(7)).Return([]string{}, nil).Once()
+		mockDb.On("ApplyBountyRouting", uint(7), db.BountyRoutingPlan{
+			Rules:         []string{"design"},
+			Assignee:      "designer",
+			NotifyPubkeys: []string{"lead"},
+		}).Return(routed, true, nil).Once()
+
+		err := RouteBounty(mockDb)(context.Background(), event)
+
+		assert.NoError(t, err)
+		mockDb.AssertExpectations(t)
+		assert.Len(t, *events, 2)
+		assert.Equal(t, BountyRouted, (*events)[0].Type)
+		assert.Equal(t, []string{"lead"}, []string((*events)[0].PubKeys))
+		assert.Equal(t, BountyUpdated, (*events)[1].Type)
+		assert.Equal(t, "designer", (*events)[1].Payload["assignee"])
+	})
+
+	t.Run("should do nothing when the rules were already applied", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		events := published(mockDb)
+		bounty := db.NewBounty{ID: 7, WorkspaceUuid: "workspace-uuid", Labels: []string{"design"}, Assignee: "designer"}
+
+		mockDb.On("GetBountyRoutingRules", "workspace-uuid").Return(rules, nil).Once()
+		mockDb.On("GetBounty", uint(7)).Return(bounty).Once()
+		mockDb.On("GetAppliedBountyRoutings", uint(7)).Return([]string{"design"}, nil).Once()
+
+		err := RouteBounty(mockDb)(context.Background(), event)
+
+		assert.NoError(t, err)
+		mockDb.AssertNotCalled(t, "ApplyBountyRouting", mock.Anything, mock.Anything)
+		assert.Empty(t, *events)
+	})
+
+	t.Run("should skip the workspaces without rules", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+
+		mockDb.On("GetBountyRoutingRules", "workspace-uuid").Return([]db.BountyRoutingRule{}, nil).Once()
+
+		err := RouteBounty(mockDb)(context.Background(), event)
+
+		assert.NoError(t, err)
+		mockDb.AssertNotCalled(t, "GetBounty", mock.Anything)
+	})
+
+	t.Run("should skip the old events a new consumer replays", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		old := now.Add(-48 * time.Hour)
+		replayed := event
+		replayed.Created = &old
+
+		err := RouteBounty(mockDb)(context.Background(), replayed)
+
+		assert.NoError(t, err)
+		mockDb.AssertNotCalled(t, "GetBountyRoutingRules", mock.Anything)
+	})
+}
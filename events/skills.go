@@ -0,0 +1,51 @@
+package events
+
+import (
+	"context"
+
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/db"
+)
+
+// RegisterSkillVerification verifies the skills of the profiles against
+// their paid bounties, through a durable consumer so a payment made while
+// the instance was down still counts
+func RegisterSkillVerification(b *Bus, database db.Database) {
+	b.SubscribeDurable("skill_verification", VerifySkills(database), PaymentSettled, BountyUpdated, PersonUpdated)
+}
+
+// VerifySkills verifies again the skills of the assignee of a paid or
+// edited bounty, or of an edited profile. A profile whose verified skills
+// changed is published again
+func VerifySkills(database db.Database) Handler {
+	return func(ctx context.Context, event db.Event) error {
+		threshold := config.Get().SkillVerificationBounties
+		if threshold == 0 {
+			return nil
+		}
+
+		pubkey := ""
+		switch event.Type {
+		case PaymentSettled, BountyUpdated:
+			pubkey, _ = event.Payload["assignee"].(string)
+		case PersonUpdated:
+			if deleted, _ := event.Payload["deleted"].(bool); deleted {
+				return nil
+			}
+			uuid, _ := event.Payload["uuid"].(string)
+			if uuid != "" {
+				pubkey = database.GetPersonByUuid(uuid).OwnerPubKey
+			}
+		}
+		if pubkey == "" {
+			return nil
+		}
+
+		person, changed, err := database.UpdateVerifiedSkills(pubkey, threshold)
+		if err != nil || !changed {
+			return err
+		}
+		Publish(ctx, PersonUpdated, "person:"+person.Uuid, map[string]interface{}{"uuid": person.Uuid})
+		return nil
+	}
+}
@@ -0,0 +1,86 @@
+package events
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/db"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestVerifySkills(t *testing.T) {
+	t.Setenv("RELAY_AUTH_KEY", "TEST")
+	t.Setenv("SKILL_VERIFICATION_BOUNTIES", "2")
+	config.InitConfig()
+
+	now := time.Now()
+	published := func(mockDb *dbMocks.Database) *[]db.Event {
+		events := &[]db.Event{}
+		mockDb.On("CreateEvent", mock.Anything).Return(func(e db.Event) (db.Event, error) {
+			*events = append(*events, e)
+			return e, nil
+		}).Maybe()
+		previous := Default
+		Default = NewBus(mockDb)
+		t.Cleanup(func() { Default = previous })
+		return events
+	}
+
+	t.Run("should verify the skills of the assignee of a paid bounty", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		events := published(mockDb)
+		person := db.Person{Uuid: "person-uuid", VerifiedSkills: []string{"go"}}
+
+		mockDb.On("UpdateVerifiedSkills", "hunter", 2).Return(person, true, nil).Once()
+
+		err := VerifySkills(mockDb)(context.Background(), db.Event{
+			Type:    PaymentSettled,
+			Subject: "bounty:7",
+			Payload: db.PropertyMap{"id": float64(7), "assignee": "hunter", "paid": true},
+			Created: &now,
+		})
+
+		assert.NoError(t, err)
+		mockDb.AssertExpectations(t)
+		assert.Len(t, *events, 1)
+		assert.Equal(t, PersonUpdated, (*events)[0].Type)
+		assert.Equal(t, "person:person-uuid", (*events)[0].Subject)
+	})
+
+	t.Run("should verify the skills of an edited profile once", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		events := published(mockDb)
+
+		mockDb.On("GetPersonByUuid", "person-uuid").Return(db.Person{Uuid: "person-uuid", OwnerPubKey: "hunter"}).Once()
+		mockDb.On("UpdateVerifiedSkills", "hunter", 2).Return(db.Person{Uuid: "person-uuid"}, false, nil).Once()
+
+		err := VerifySkills(mockDb)(context.Background(), db.Event{
+			Type:    PersonUpdated,
+			Subject: "person:person-uuid",
+			Payload: db.PropertyMap{"uuid": "person-uuid"},
+			Created: &now,
+		})
+
+		assert.NoError(t, err)
+		mockDb.AssertExpectations(t)
+		assert.Empty(t, *events)
+	})
+
+	t.Run("should skip the bounties without an assignee", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+
+		err := VerifySkills(mockDb)(context.Background(), db.Event{
+			Type:    BountyUpdated,
+			Subject: "bounty:7",
+			Payload: db.PropertyMap{"id": float64(7), "assignee": ""},
+			Created: &now,
+		})
+
+		assert.NoError(t, err)
+		mockDb.AssertNotCalled(t, "UpdateVerifiedSkills", mock.Anything, mock.Anything)
+	})
+}
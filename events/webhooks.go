@@ -0,0 +1,83 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/db"
+)
+
+// a redemption older than this is dropped instead of posted, the receiver
+// has most likely moved on and a new consumer would replay the whole log
+const webhookMaxAge = 24 * time.Hour
+
+// WebhookEvent is the body posted to a webhook
+type WebhookEvent struct {
+	Uuid    string         `json:"uuid"`
+	Type    string         `json:"type"`
+	Subject string         `json:"subject"`
+	Payload db.PropertyMap `json:"payload"`
+	Created *time.Time     `json:"created"`
+}
+
+// RegisterWebhooks posts the connection code redemptions to
+// CONNECTION_CODE_WEBHOOK through a durable consumer, so a receiver that is
+// down gets them once it is back
+func RegisterWebhooks(b *Bus, client *http.Client) {
+	b.SubscribeDurable("connection_code_webhook", PostWebhook(client), ConnectionCodeRedeemed)
+}
+
+// PostWebhook posts an event to the configured webhook, with the hex HMAC
+// of the body in the x-hub-signature-256 header when a secret is set. A
+// response other than 2xx is an error so the event is tried again
+func PostWebhook(client *http.Client) Handler {
+	return func(ctx context.Context, event db.Event) error {
+		cfg := config.Get()
+		if cfg.ConnectionCodeWebhook == "" {
+			return nil
+		}
+		if event.Created != nil && time.Since(*event.Created) > webhookMaxAge {
+			return nil
+		}
+
+		body, err := json.Marshal(WebhookEvent{
+			Uuid:    event.Uuid,
+			Type:    event.Type,
+			Subject: event.Subject,
+			Payload: event.Payload,
+			Created: event.Created,
+		})
+		if err != nil {
+			return err
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, cfg.ConnectionCodeWebhook, bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		if cfg.ConnectionCodeWebhookSecret != "" {
+			mac := hmac.New(sha256.New, []byte(cfg.ConnectionCodeWebhookSecret))
+			mac.Write(body)
+			req.Header.Set("x-hub-signature-256", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+		}
+
+		res, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		defer res.Body.Close()
+		if res.StatusCode < 200 || res.StatusCode > 299 {
+			return fmt.Errorf("webhook answered %d", res.StatusCode)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,98 @@
+package events
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPostWebhook(t *testing.T) {
+	now := time.Now()
+	event := db.Event{
+		ID:      5,
+		Uuid:    "event-uuid",
+		Type:    ConnectionCodeRedeemed,
+		Subject: "connection_code:7",
+		Payload: db.PropertyMap{"campaign": "launch", "source": "landing"},
+		Created: &now,
+	}
+
+	configure := func(t *testing.T, url string, secret string) {
+		t.Setenv("RELAY_AUTH_KEY", "TEST")
+		t.Setenv("CONNECTION_CODE_WEBHOOK", url)
+		t.Setenv("CONNECTION_CODE_WEBHOOK_SECRET", secret)
+		config.InitConfig()
+	}
+
+	t.Run("should post the signed event", func(t *testing.T) {
+		var received WebhookEvent
+		var signature string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			mac := hmac.New(sha256.New, []byte("secret"))
+			mac.Write(body)
+			signature = "sha256=" + hex.EncodeToString(mac.Sum(nil))
+			assert.Equal(t, signature, r.Header.Get("x-hub-signature-256"))
+			json.Unmarshal(body, &received)
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+		configure(t, server.URL, "secret")
+
+		err := PostWebhook(server.Client())(context.Background(), event)
+
+		assert.NoError(t, err)
+		assert.NotEmpty(t, signature)
+		assert.Equal(t, "event-uuid", received.Uuid)
+		assert.Equal(t, "connection_code:7", received.Subject)
+		assert.Equal(t, "launch", received.Payload["campaign"])
+	})
+
+	t.Run("should fail when the webhook doesn't answer 2xx", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+		defer server.Close()
+		configure(t, server.URL, "")
+
+		err := PostWebhook(server.Client())(context.Background(), event)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("should drop the events older than a day", func(t *testing.T) {
+		called := false
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			called = true
+		}))
+		defer server.Close()
+		configure(t, server.URL, "")
+
+		old := now.Add(-2 * webhookMaxAge)
+		stale := event
+		stale.Created = &old
+		err := PostWebhook(server.Client())(context.Background(), stale)
+
+		assert.NoError(t, err)
+		assert.False(t, called)
+	})
+
+	t.Run("should skip the events when no webhook is set", func(t *testing.T) {
+		configure(t, "", "")
+
+		err := PostWebhook(http.DefaultClient)(context.Background(), event)
+
+		assert.NoError(t, err)
+	})
+}
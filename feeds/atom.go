@@ -0,0 +1,67 @@
+package feeds
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+const atomNamespace = "http://www.w3.org/2005/Atom"
+
+type AtomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+type AtomPerson struct {
+	Name string `xml:"name"`
+}
+
+type AtomText struct {
+	Type string `xml:"type,attr,omitempty"`
+	Body string `xml:",chardata"`
+}
+
+type AtomEntry struct {
+	ID        string        `xml:"id"`
+	Title     string        `xml:"title"`
+	Updated   string        `xml:"updated"`
+	Published string        `xml:"published,omitempty"`
+	Author    *AtomPerson   `xml:"author,omitempty"`
+	Link      *AtomLink     `xml:"link,omitempty"`
+	Category  *AtomCategory `xml:"category,omitempty"`
+	Content   *AtomText     `xml:"content,omitempty"`
+}
+
+type AtomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+// AtomFeed is an Atom feed as in RFC 4287, for the feeds this server serves
+type AtomFeed struct {
+	XMLName  xml.Name    `xml:"feed"`
+	Xmlns    string      `xml:"xmlns,attr"`
+	ID       string      `xml:"id"`
+	Title    string      `xml:"title"`
+	Subtitle string      `xml:"subtitle,omitempty"`
+	Updated  string      `xml:"updated"`
+	Icon     string      `xml:"icon,omitempty"`
+	Author   *AtomPerson `xml:"author,omitempty"`
+	Links    []AtomLink  `xml:"link"`
+	Entries  []AtomEntry `xml:"entry"`
+}
+
+// AtomTime formats a time the way Atom dates are written
+func AtomTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+// Render encodes the feed as an indented XML document
+func (f AtomFeed) Render() ([]byte, error) {
+	f.Xmlns = atomNamespace
+	body, err := xml.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), body...), nil
+}
@@ -7,9 +7,10 @@ import (
 	"fmt"
 	"io"
 	"net/http"
-	"os"
 	"strconv"
 	"time"
+
+	"github.com/stakwork/sphinx-tribes/config"
 )
 
 const PodcastIndexBaseURL = "https://api.podcastindex.org/api/1.0/"
@@ -62,8 +63,8 @@ func PodcastToGeneric(url string, p *Podcast) (Feed, error) {
 }
 
 func PodcastIndexHeaders() map[string]string {
-	apiKey := os.Getenv("PODCAST_INDEX_KEY")
-	apiSecret := os.Getenv("PODCAST_INDEX_SECRET")
+	apiKey := config.Get().PodcastIndexKey
+	apiSecret := config.Get().PodcastIndexSecret
 	ts := unix()
 	s := apiKey + apiSecret + ts
 	h := sha1.New()
@@ -3,15 +3,15 @@ package feeds
 import (
 	"context"
 	"fmt"
-	"os"
 
 	"github.com/araddon/dateparse"
+	"github.com/stakwork/sphinx-tribes/config"
 	"google.golang.org/api/option"
 	"google.golang.org/api/youtube/v3"
 )
 
 func YoutubeSearch(term string) ([]Feed, error) {
-	apiKey := os.Getenv("YOUTUBE_KEY")
+	apiKey := config.Get().YoutubeKey
 	ctx := context.Background()
 	tube, err := youtube.NewService(ctx, option.WithAPIKey(apiKey))
 	if err != nil {
@@ -56,7 +56,7 @@ func YoutubeSearch(term string) ([]Feed, error) {
 }
 
 func YoutubeVideosForChannel(channelId string) ([]Item, error) {
-	apiKey := os.Getenv("YOUTUBE_KEY")
+	apiKey := config.Get().YoutubeKey
 	ctx := context.Background()
 	tube, err := youtube.NewService(ctx, option.WithAPIKey(apiKey))
 	if err != nil {
@@ -101,7 +101,7 @@ func YoutubeVideosForChannel(channelId string) ([]Item, error) {
 }
 
 func YoutubeVideoSearch(term string) ([]Item, error) {
-	apiKey := os.Getenv("YOUTUBE_KEY")
+	apiKey := config.Get().YoutubeKey
 	ctx := context.Background()
 	tube, err := youtube.NewService(ctx, option.WithAPIKey(apiKey))
 	if err != nil {
@@ -0,0 +1,69 @@
+package gql
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/stakwork/sphinx-tribes/db"
+)
+
+type graphqlRequest struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+type graphqlHandler struct {
+	db     db.Database
+	schema *graphql.Schema
+}
+
+func NewGraphqlHandler(database db.Database) *graphqlHandler {
+	schema := graphql.MustParseSchema(schemaString, &rootResolver{db: database},
+		graphql.MaxDepth(8),
+		graphql.MaxParallelism(10),
+	)
+	return &graphqlHandler{
+		db:     database,
+		schema: schema,
+	}
+}
+
+func (gh *graphqlHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	request := graphqlRequest{}
+
+	if r.Method == http.MethodGet {
+		request.Query = r.URL.Query().Get("query")
+		request.OperationName = r.URL.Query().Get("operationName")
+		if variables := r.URL.Query().Get("variables"); variables != "" {
+			if err := json.Unmarshal([]byte(variables), &request.Variables); err != nil {
+				w.WriteHeader(http.StatusNotAcceptable)
+				json.NewEncoder(w).Encode("Invalid variables")
+				return
+			}
+		}
+	} else {
+		body, _ := io.ReadAll(r.Body)
+		r.Body.Close()
+		if err := json.Unmarshal(body, &request); err != nil {
+			w.WriteHeader(http.StatusNotAcceptable)
+			json.NewEncoder(w).Encode("Invalid graphql request body")
+			return
+		}
+	}
+
+	if request.Query == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Query is required")
+		return
+	}
+
+	ctx := withLoaders(r.Context(), newLoaders(gh.db))
+	response := gh.schema.Exec(ctx, request.Query, request.OperationName, request.Variables)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
@@ -0,0 +1,111 @@
+package gql
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stakwork/sphinx-tribes/db"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type graphqlResponse struct {
+	Data   map[string]interface{}   `json:"data"`
+	Errors []map[string]interface{} `json:"errors"`
+}
+
+func execute(t *testing.T, handler http.Handler, query string) graphqlResponse {
+	body, _ := json.Marshal(map[string]interface{}{"query": query})
+	rr := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/graphql", bytes.NewReader(body))
+
+	handler.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var res graphqlResponse
+	err := json.Unmarshal(rr.Body.Bytes(), &res)
+	assert.NoError(t, err)
+	return res
+}
+
+func TestGraphqlHandler(t *testing.T) {
+	t.Run("should return bad request for an empty query", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		handler := NewGraphqlHandler(mockDb)
+
+		rr := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/graphql", bytes.NewBufferString(`{"query": ""}`))
+		handler.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("should not expose the plans of a workspace", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		handler := NewGraphqlHandler(mockDb)
+
+		res := execute(t, handler, `{ workspace(uuid: "workspace-uuid") { mission tactics features { brief requirements architecture } } }`)
+
+		assert.Len(t, res.Errors, 5)
+		mockDb.AssertNotCalled(t, "GetWorkspaceByUuid", mock.Anything)
+	})
+
+	t.Run("should resolve nested relations and load shared owners once", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		handler := NewGraphqlHandler(mockDb)
+
+		mockDb.On("GetWorkspaceByUuid", "workspace-uuid").Return(db.Workspace{Uuid: "workspace-uuid", Name: "Hive", OwnerPubKey: "owner"}).Once()
+		mockDb.On("GetWorkspaceBounties", mock.AnythingOfType("*http.Request"), "workspace-uuid").Return([]db.NewBounty{
+			{ID: 1, Title: "first", OwnerID: "owner", WorkspaceUuid: "workspace-uuid"},
+			{ID: 2, Title: "second", OwnerID: "owner", WorkspaceUuid: "workspace-uuid"},
+		}).Once()
+		mockDb.On("GetPersonByPubkey", "owner").Return(db.Person{ID: 7, OwnerPubKey: "owner", OwnerAlias: "alice"}).Once()
+
+		res := execute(t, handler, `{ workspace(uuid: "workspace-uuid") { name owner { ownerAlias } bounties(limit: 2) { id title owner { ownerAlias } workspace { name } } } }`)
+
+		assert.Empty(t, res.Errors)
+		workspace := res.Data["workspace"].(map[string]interface{})
+		assert.Equal(t, "Hive", workspace["name"])
+		assert.Equal(t, "alice", workspace["owner"].(map[string]interface{})["ownerAlias"])
+		bounties := workspace["bounties"].([]interface{})
+		assert.Len(t, bounties, 2)
+		assert.Equal(t, "alice", bounties[1].(map[string]interface{})["owner"].(map[string]interface{})["ownerAlias"])
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should pass pagination args to the db layer", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		handler := NewGraphqlHandler(mockDb)
+
+		mockDb.On("GetListedTribes", mock.MatchedBy(func(r *http.Request) bool {
+			q := r.URL.Query()
+			return q.Get("page") == "2" && q.Get("limit") == "100" && q.Get("search") == "music"
+		})).Return([]db.Tribe{{UUID: "tribe-uuid", Name: "Music"}}).Once()
+
+		res := execute(t, handler, `{ tribes(page: 2, limit: 500, search: "music") { uuid name tags } }`)
+
+		assert.Empty(t, res.Errors)
+		assert.Len(t, res.Data["tribes"], 1)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should resolve phases through features", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		handler := NewGraphqlHandler(mockDb)
+
+		mockDb.On("GetFeatureByUuid", "feature-uuid").Return(db.WorkspaceFeatures{Uuid: "feature-uuid", Name: "Chat", WorkspaceUuid: "workspace-uuid"}).Once()
+		mockDb.On("GetPhasesByFeatureUuid", "feature-uuid").Return([]db.FeaturePhase{{Uuid: "phase-uuid", FeatureUuid: "feature-uuid", Name: "MVP"}}).Once()
+		mockDb.On("GetBountiesByFeatureAndPhaseUuid", "feature-uuid", "phase-uuid", mock.AnythingOfType("*http.Request")).Return([]db.NewBounty{{ID: 3, Title: "build it"}}, nil).Once()
+
+		res := execute(t, handler, `{ feature(uuid: "feature-uuid") { name phases { name bounties { title } } } }`)
+
+		assert.Empty(t, res.Errors)
+		phases := res.Data["feature"].(map[string]interface{})["phases"].([]interface{})
+		assert.Equal(t, "MVP", phases[0].(map[string]interface{})["name"])
+		mockDb.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,124 @@
+package gql
+
+import (
+	"context"
+	"sync"
+
+	"github.com/stakwork/sphinx-tribes/db"
+)
+
+type loadersKey struct{}
+
+// loaders caches lookups for the lifetime of a single graphql request so nested
+// fields that point at the same person, workspace, feature or phase only hit
+// the database once
+type loaders struct {
+	db db.Database
+
+	mu         sync.Mutex
+	people     map[string]*db.Person
+	workspaces map[string]*db.Workspace
+	features   map[string]*db.WorkspaceFeatures
+	phases     map[string]*db.FeaturePhase
+}
+
+func newLoaders(database db.Database) *loaders {
+	return &loaders{
+		db:         database,
+		people:     map[string]*db.Person{},
+		workspaces: map[string]*db.Workspace{},
+		features:   map[string]*db.WorkspaceFeatures{},
+		phases:     map[string]*db.FeaturePhase{},
+	}
+}
+
+func withLoaders(ctx context.Context, l *loaders) context.Context {
+	return context.WithValue(ctx, loadersKey{}, l)
+}
+
+func loadersFrom(ctx context.Context, database db.Database) *loaders {
+	if l, ok := ctx.Value(loadersKey{}).(*loaders); ok {
+		return l
+	}
+	return newLoaders(database)
+}
+
+func (l *loaders) person(pubkey string) *db.Person {
+	if pubkey == "" {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if p, ok := l.people[pubkey]; ok {
+		return p
+	}
+
+	var result *db.Person
+	p := l.db.GetPersonByPubkey(pubkey)
+	if p.ID != 0 {
+		result = &p
+	}
+	l.people[pubkey] = result
+	return result
+}
+
+func (l *loaders) workspace(uuid string) *db.Workspace {
+	if uuid == "" {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if w, ok := l.workspaces[uuid]; ok {
+		return w
+	}
+
+	var result *db.Workspace
+	w := l.db.GetWorkspaceByUuid(uuid)
+	if w.Uuid != "" && !w.Deleted {
+		result = &w
+	}
+	l.workspaces[uuid] = result
+	return result
+}
+
+func (l *loaders) feature(uuid string) *db.WorkspaceFeatures {
+	if uuid == "" {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if f, ok := l.features[uuid]; ok {
+		return f
+	}
+
+	var result *db.WorkspaceFeatures
+	f := l.db.GetFeatureByUuid(uuid)
+	if f.Uuid != "" {
+		result = &f
+	}
+	l.features[uuid] = result
+	return result
+}
+
+func (l *loaders) phase(uuid string) *db.FeaturePhase {
+	if uuid == "" {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if p, ok := l.phases[uuid]; ok {
+		return p
+	}
+
+	var result *db.FeaturePhase
+	p, err := l.db.GetPhaseByUuid(uuid)
+	if err == nil && p.Uuid != "" {
+		result = &p
+	}
+	l.phases[uuid] = result
+	return result
+}
@@ -0,0 +1,370 @@
+package gql
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/go-chi/chi"
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/stakwork/sphinx-tribes/db"
+)
+
+const maxPageLimit = 100
+
+type pageArgs struct {
+	Page   *int32
+	Limit  *int32
+	Search *string
+}
+
+// pageRequest builds the request the db layer reads its pagination and
+// route params from
+func pageRequest(ctx context.Context, args pageArgs, urlParams map[string]string) *http.Request {
+	query := url.Values{}
+	page, limit := int32(1), int32(20)
+	if args.Page != nil && *args.Page > 0 {
+		page = *args.Page
+	}
+	if args.Limit != nil && *args.Limit > 0 {
+		limit = *args.Limit
+	}
+	if limit > maxPageLimit {
+		limit = maxPageLimit
+	}
+	query.Set("page", strconv.Itoa(int(page)))
+	query.Set("limit", strconv.Itoa(int(limit)))
+	if args.Search != nil {
+		query.Set("search", *args.Search)
+	}
+
+	rctx := chi.NewRouteContext()
+	for k, v := range urlParams {
+		rctx.URLParams.Add(k, v)
+	}
+
+	r, _ := http.NewRequestWithContext(context.WithValue(ctx, chi.RouteCtxKey, rctx), http.MethodGet, "/?"+query.Encode(), nil)
+	return r
+}
+
+type rootResolver struct {
+	db db.Database
+}
+
+func (r *rootResolver) Tribe(ctx context.Context, args struct{ Uuid string }) *tribeResolver {
+	tribe := r.db.GetTribe(args.Uuid)
+	if tribe.UUID == "" || tribe.Deleted {
+		return nil
+	}
+	return &tribeResolver{root: r, t: tribe}
+}
+
+func (r *rootResolver) Tribes(ctx context.Context, args pageArgs) []*tribeResolver {
+	tribes := r.db.GetListedTribes(pageRequest(ctx, args, nil))
+	return r.tribeResolvers(tribes)
+}
+
+func (r *rootResolver) tribeResolvers(tribes []db.Tribe) []*tribeResolver {
+	res := make([]*tribeResolver, 0, len(tribes))
+	for _, t := range tribes {
+		res = append(res, &tribeResolver{root: r, t: t})
+	}
+	return res
+}
+
+func (r *rootResolver) Person(ctx context.Context, args struct {
+	Pubkey *string
+	Uuid   *string
+}) *personResolver {
+	var person db.Person
+	if args.Pubkey != nil {
+		person = r.db.GetPersonByPubkey(*args.Pubkey)
+	} else if args.Uuid != nil {
+		person = r.db.GetPersonByUuid(*args.Uuid)
+	}
+	if person.ID == 0 || person.Deleted {
+		return nil
+	}
+	return &personResolver{root: r, p: person}
+}
+
+func (r *rootResolver) People(ctx context.Context, args pageArgs) []*personResolver {
+	people := r.db.GetListedPeople(pageRequest(ctx, args, nil))
+	res := make([]*personResolver, 0, len(people))
+	for _, p := range people {
+		res = append(res, &personResolver{root: r, p: p})
+	}
+	return res
+}
+
+func (r *rootResolver) Workspace(ctx context.Context, args struct{ Uuid string }) *workspaceResolver {
+	workspace := loadersFrom(ctx, r.db).workspace(args.Uuid)
+	if workspace == nil {
+		return nil
+	}
+	return &workspaceResolver{root: r, w: *workspace}
+}
+
+func (r *rootResolver) Workspaces(ctx context.Context, args pageArgs) []*workspaceResolver {
+	workspaces := r.db.GetWorkspaces(pageRequest(ctx, args, nil))
+	res := make([]*workspaceResolver, 0, len(workspaces))
+	for _, w := range workspaces {
+		res = append(res, &workspaceResolver{root: r, w: w})
+	}
+	return res
+}
+
+func (r *rootResolver) Feature(ctx context.Context, args struct{ Uuid string }) *featureResolver {
+	feature := loadersFrom(ctx, r.db).feature(args.Uuid)
+	if feature == nil {
+		return nil
+	}
+	return &featureResolver{root: r, f: *feature}
+}
+
+func (r *rootResolver) Phase(ctx context.Context, args struct{ Uuid string }) *phaseResolver {
+	phase := loadersFrom(ctx, r.db).phase(args.Uuid)
+	if phase == nil {
+		return nil
+	}
+	return &phaseResolver{root: r, p: *phase}
+}
+
+func (r *rootResolver) Bounty(ctx context.Context, args struct{ Id graphql.ID }) (*bountyResolver, error) {
+	bounties, err := r.db.GetBountyById(string(args.Id))
+	if err != nil {
+		return nil, err
+	}
+	if len(bounties) == 0 {
+		return nil, nil
+	}
+	return &bountyResolver{root: r, b: bounties[0]}, nil
+}
+
+func (r *rootResolver) Bounties(ctx context.Context, args pageArgs) []*bountyResolver {
+	return r.bountyResolvers(r.db.GetAllBounties(pageRequest(ctx, args, nil)))
+}
+
+func (r *rootResolver) bountyResolvers(bounties []db.NewBounty) []*bountyResolver {
+	res := make([]*bountyResolver, 0, len(bounties))
+	for _, b := range bounties {
+		res = append(res, &bountyResolver{root: r, b: b})
+	}
+	return res
+}
+
+type tribeResolver struct {
+	root *rootResolver
+	t    db.Tribe
+}
+
+func (t *tribeResolver) Uuid() string             { return t.t.UUID }
+func (t *tribeResolver) Name() string             { return t.t.Name }
+func (t *tribeResolver) UniqueName() string       { return t.t.UniqueName }
+func (t *tribeResolver) Description() string      { return t.t.Description }
+func (t *tribeResolver) Img() string              { return t.t.Img }
+func (t *tribeResolver) Tags() []string           { return nonNilStrings(t.t.Tags) }
+func (t *tribeResolver) PriceToJoin() float64     { return float64(t.t.PriceToJoin) }
+func (t *tribeResolver) PricePerMessage() float64 { return float64(t.t.PricePerMessage) }
+func (t *tribeResolver) MemberCount() float64     { return float64(t.t.MemberCount) }
+func (t *tribeResolver) LastActive() float64      { return float64(t.t.LastActive) }
+func (t *tribeResolver) OwnerPubkey() string      { return t.t.OwnerPubKey }
+
+func (t *tribeResolver) Owner(ctx context.Context) *personResolver {
+	person := loadersFrom(ctx, t.root.db).person(t.t.OwnerPubKey)
+	if person == nil {
+		return nil
+	}
+	return &personResolver{root: t.root, p: *person}
+}
+
+type personResolver struct {
+	root *rootResolver
+	p    db.Person
+}
+
+func (p *personResolver) Id() graphql.ID       { return graphql.ID(fmt.Sprint(p.p.ID)) }
+func (p *personResolver) Uuid() string         { return p.p.Uuid }
+func (p *personResolver) OwnerPubkey() string  { return p.p.OwnerPubKey }
+func (p *personResolver) OwnerAlias() string   { return p.p.OwnerAlias }
+func (p *personResolver) UniqueName() string   { return p.p.UniqueName }
+func (p *personResolver) Description() string  { return p.p.Description }
+func (p *personResolver) Img() string          { return p.p.Img }
+func (p *personResolver) Tags() []string       { return nonNilStrings(p.p.Tags) }
+func (p *personResolver) PriceToMeet() float64 { return float64(p.p.PriceToMeet) }
+
+func (p *personResolver) Tribes() []*tribeResolver {
+	return p.root.tribeResolvers(p.root.db.GetTribesByOwner(p.p.OwnerPubKey))
+}
+
+func (p *personResolver) CreatedBounties(ctx context.Context, args pageArgs) ([]*bountyResolver, error) {
+	bounties, err := p.root.db.GetCreatedBounties(pageRequest(ctx, args, map[string]string{"uuid": p.p.Uuid}))
+	if err != nil {
+		return nil, err
+	}
+	return p.root.bountyResolvers(bounties), nil
+}
+
+func (p *personResolver) AssignedBounties(ctx context.Context, args pageArgs) ([]*bountyResolver, error) {
+	bounties, err := p.root.db.GetAssignedBounties(pageRequest(ctx, args, map[string]string{"uuid": p.p.Uuid}))
+	if err != nil {
+		return nil, err
+	}
+	return p.root.bountyResolvers(bounties), nil
+}
+
+type workspaceResolver struct {
+	root *rootResolver
+	w    db.Workspace
+}
+
+func (w *workspaceResolver) Uuid() string        { return w.w.Uuid }
+func (w *workspaceResolver) Name() string        { return w.w.Name }
+func (w *workspaceResolver) Description() string { return w.w.Description }
+func (w *workspaceResolver) Img() string         { return w.w.Img }
+func (w *workspaceResolver) Website() string     { return w.w.Website }
+func (w *workspaceResolver) Github() string      { return w.w.Github }
+func (w *workspaceResolver) OwnerPubkey() string { return w.w.OwnerPubKey }
+
+func (w *workspaceResolver) Owner(ctx context.Context) *personResolver {
+	person := loadersFrom(ctx, w.root.db).person(w.w.OwnerPubKey)
+	if person == nil {
+		return nil
+	}
+	return &personResolver{root: w.root, p: *person}
+}
+
+func (w *workspaceResolver) Features(ctx context.Context, args struct {
+	Page  *int32
+	Limit *int32
+}) []*featureResolver {
+	features := w.root.db.GetFeaturesByWorkspaceUuid(w.w.Uuid, pageRequest(ctx, pageArgs{Page: args.Page, Limit: args.Limit}, nil))
+	res := make([]*featureResolver, 0, len(features))
+	for _, f := range features {
+		res = append(res, &featureResolver{root: w.root, f: f})
+	}
+	return res
+}
+
+func (w *workspaceResolver) Bounties(ctx context.Context, args struct {
+	Page  *int32
+	Limit *int32
+}) []*bountyResolver {
+	bounties := w.root.db.GetWorkspaceBounties(pageRequest(ctx, pageArgs{Page: args.Page, Limit: args.Limit}, nil), w.w.Uuid)
+	return w.root.bountyResolvers(bounties)
+}
+
+type featureResolver struct {
+	root *rootResolver
+	f    db.WorkspaceFeatures
+}
+
+func (f *featureResolver) Uuid() string          { return f.f.Uuid }
+func (f *featureResolver) Name() string          { return f.f.Name }
+func (f *featureResolver) Url() string           { return f.f.Url }
+func (f *featureResolver) Priority() int32       { return int32(f.f.Priority) }
+func (f *featureResolver) WorkspaceUuid() string { return f.f.WorkspaceUuid }
+
+func (f *featureResolver) Workspace(ctx context.Context) *workspaceResolver {
+	workspace := loadersFrom(ctx, f.root.db).workspace(f.f.WorkspaceUuid)
+	if workspace == nil {
+		return nil
+	}
+	return &workspaceResolver{root: f.root, w: *workspace}
+}
+
+func (f *featureResolver) Phases() []*phaseResolver {
+	phases := f.root.db.GetPhasesByFeatureUuid(f.f.Uuid)
+	res := make([]*phaseResolver, 0, len(phases))
+	for _, p := range phases {
+		res = append(res, &phaseResolver{root: f.root, p: p})
+	}
+	return res
+}
+
+type phaseResolver struct {
+	root *rootResolver
+	p    db.FeaturePhase
+}
+
+func (p *phaseResolver) Uuid() string        { return p.p.Uuid }
+func (p *phaseResolver) Name() string        { return p.p.Name }
+func (p *phaseResolver) Priority() int32     { return int32(p.p.Priority) }
+func (p *phaseResolver) FeatureUuid() string { return p.p.FeatureUuid }
+
+func (p *phaseResolver) Feature(ctx context.Context) *featureResolver {
+	feature := loadersFrom(ctx, p.root.db).feature(p.p.FeatureUuid)
+	if feature == nil {
+		return nil
+	}
+	return &featureResolver{root: p.root, f: *feature}
+}
+
+func (p *phaseResolver) Bounties(ctx context.Context, args struct {
+	Page  *int32
+	Limit *int32
+}) ([]*bountyResolver, error) {
+	bounties, err := p.root.db.GetBountiesByFeatureAndPhaseUuid(p.p.FeatureUuid, p.p.Uuid, pageRequest(ctx, pageArgs{Page: args.Page, Limit: args.Limit}, nil))
+	if err != nil {
+		return nil, err
+	}
+	return p.root.bountyResolvers(bounties), nil
+}
+
+type bountyResolver struct {
+	root *rootResolver
+	b    db.NewBounty
+}
+
+func (b *bountyResolver) Id() graphql.ID            { return graphql.ID(fmt.Sprint(b.b.ID)) }
+func (b *bountyResolver) Title() string             { return b.b.Title }
+func (b *bountyResolver) Description() string       { return b.b.Description }
+func (b *bountyResolver) Price() float64            { return float64(b.b.Price) }
+func (b *bountyResolver) Type() string              { return b.b.Type }
+func (b *bountyResolver) Paid() bool                { return b.b.Paid }
+func (b *bountyResolver) Completed() bool           { return b.b.Completed }
+func (b *bountyResolver) CodingLanguages() []string { return nonNilStrings(b.b.CodingLanguages) }
+func (b *bountyResolver) Created() float64          { return float64(b.b.Created) }
+func (b *bountyResolver) OwnerPubkey() string       { return b.b.OwnerID }
+func (b *bountyResolver) AssigneePubkey() string    { return b.b.Assignee }
+
+func (b *bountyResolver) Owner(ctx context.Context) *personResolver {
+	person := loadersFrom(ctx, b.root.db).person(b.b.OwnerID)
+	if person == nil {
+		return nil
+	}
+	return &personResolver{root: b.root, p: *person}
+}
+
+func (b *bountyResolver) Assignee(ctx context.Context) *personResolver {
+	person := loadersFrom(ctx, b.root.db).person(b.b.Assignee)
+	if person == nil {
+		return nil
+	}
+	return &personResolver{root: b.root, p: *person}
+}
+
+func (b *bountyResolver) Workspace(ctx context.Context) *workspaceResolver {
+	workspace := loadersFrom(ctx, b.root.db).workspace(b.b.WorkspaceUuid)
+	if workspace == nil {
+		return nil
+	}
+	return &workspaceResolver{root: b.root, w: *workspace}
+}
+
+func (b *bountyResolver) Phase(ctx context.Context) *phaseResolver {
+	phase := loadersFrom(ctx, b.root.db).phase(b.b.PhaseUuid)
+	if phase == nil {
+		return nil
+	}
+	return &phaseResolver{root: b.root, p: *phase}
+}
+
+func nonNilStrings(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
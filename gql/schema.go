@@ -0,0 +1,103 @@
+package gql
+
+// the endpoint is public, so the schema only has what anyone can read. The
+// mission and tactics of a workspace and the brief, requirements and
+// architecture of a feature are left out, they are for its members
+const schemaString = `
+schema {
+	query: Query
+}
+
+type Query {
+	tribe(uuid: String!): Tribe
+	tribes(page: Int, limit: Int, search: String): [Tribe!]!
+	person(pubkey: String, uuid: String): Person
+	people(page: Int, limit: Int, search: String): [Person!]!
+	workspace(uuid: String!): Workspace
+	workspaces(page: Int, limit: Int, search: String): [Workspace!]!
+	feature(uuid: String!): Feature
+	phase(uuid: String!): Phase
+	bounty(id: ID!): Bounty
+	bounties(page: Int, limit: Int, search: String): [Bounty!]!
+}
+
+type Tribe {
+	uuid: String!
+	name: String!
+	uniqueName: String!
+	description: String!
+	img: String!
+	tags: [String!]!
+	priceToJoin: Float!
+	pricePerMessage: Float!
+	memberCount: Float!
+	lastActive: Float!
+	ownerPubkey: String!
+	owner: Person
+}
+
+type Person {
+	id: ID!
+	uuid: String!
+	ownerPubkey: String!
+	ownerAlias: String!
+	uniqueName: String!
+	description: String!
+	img: String!
+	tags: [String!]!
+	priceToMeet: Float!
+	tribes: [Tribe!]!
+	createdBounties(page: Int, limit: Int): [Bounty!]!
+	assignedBounties(page: Int, limit: Int): [Bounty!]!
+}
+
+type Workspace {
+	uuid: String!
+	name: String!
+	description: String!
+	img: String!
+	website: String!
+	github: String!
+	ownerPubkey: String!
+	owner: Person
+	features(page: Int, limit: Int): [Feature!]!
+	bounties(page: Int, limit: Int): [Bounty!]!
+}
+
+type Feature {
+	uuid: String!
+	name: String!
+	url: String!
+	priority: Int!
+	workspaceUuid: String!
+	workspace: Workspace
+	phases: [Phase!]!
+}
+
+type Phase {
+	uuid: String!
+	name: String!
+	priority: Int!
+	featureUuid: String!
+	feature: Feature
+	bounties(page: Int, limit: Int): [Bounty!]!
+}
+
+type Bounty {
+	id: ID!
+	title: String!
+	description: String!
+	price: Float!
+	type: String!
+	paid: Boolean!
+	completed: Boolean!
+	codingLanguages: [String!]!
+	created: Float!
+	ownerPubkey: String!
+	assigneePubkey: String!
+	owner: Person
+	assignee: Person
+	workspace: Workspace
+	phase: Phase
+}
+`
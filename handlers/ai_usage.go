@@ -0,0 +1,153 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/httpio"
+)
+
+// withinAiCap writes a 429 and returns false when the workspace already
+// made its monthly cap of AI submissions, it can submit again next month
+func withinAiCap(w http.ResponseWriter, r *http.Request, database db.Database, workspaceUuid string) bool {
+	if workspaceUuid == "" {
+		return true
+	}
+	workspace := database.GetWorkspaceByUuid(workspaceUuid)
+	if workspace.AiMonthlyCap == 0 {
+		return true
+	}
+
+	now := time.Now()
+	month, _ := db.AiUsageMonth("", now)
+	used, err := database.CountAiSubmissions(workspaceUuid, month)
+	if err != nil {
+		log.Printf("[ai usage] could not count the submissions of workspace %s: %s", workspaceUuid, err)
+		return true
+	}
+	if used < int64(workspace.AiMonthlyCap) {
+		return true
+	}
+
+	next := month.AddDate(0, 1, 0)
+	w.Header().Set("Retry-After", strconv.Itoa(int(next.Sub(now).Seconds())+1))
+	httpio.WriteErrorDetails(w, r, http.StatusTooManyRequests, "The workspace made its AI submissions of the month", map[string]interface{}{
+		"month":       month.Format("2006-01"),
+		"monthly_cap": workspace.AiMonthlyCap,
+		"used":        used,
+	})
+	return false
+}
+
+// recordAiSubmission records a submission of the workspace to a workflow,
+// started when the call to Stakwork was made and failed when err is set
+func recordAiSubmission(database db.Database, workspaceUuid string, kind string, workflowId string, reference string, started time.Time, err error) {
+	if workspaceUuid == "" {
+		return
+	}
+	submission := db.AiSubmission{
+		WorkspaceUuid: workspaceUuid,
+		Kind:          kind,
+		WorkflowId:    workflowId,
+		Reference:     reference,
+		Status:        db.AiSubmissionSent,
+		DurationMs:    time.Since(started).Milliseconds(),
+		Created:       &started,
+	}
+	if err != nil {
+		submission.Status = db.AiSubmissionFailed
+		submission.Error = err.Error()
+	}
+	if err := database.CreateAiSubmission(submission); err != nil {
+		log.Printf("[ai usage] could not record a %s submission of workspace %s: %s", kind, workspaceUuid, err)
+	}
+}
+
+// addAiCost adds the credits a webhook reports to the submission it answers
+func addAiCost(database db.Database, kind string, reference string, cost *float64) {
+	if cost == nil {
+		return
+	}
+	if err := database.AddAiSubmissionCost(kind, reference, *cost); err != nil {
+		log.Printf("[ai usage] could not add the cost of %s %s: %s", kind, reference, err)
+	}
+}
+
+// GetAiUsage returns what the workspace of the route submitted to Stakwork
+// in the month of ?month=2006-01, this month by default, to its members
+func (qh *quotaHandler) GetAiUsage(w http.ResponseWriter, r *http.Request) {
+	pubKeyFromAuth, _ := r.Context().Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[ai usage] no pubkey from auth")
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
+		return
+	}
+	workspace := qh.db.GetWorkspaceByUuid(chi.URLParam(r, "workspace_uuid"))
+	if workspace.Uuid == "" || !isWorkspaceMember(qh.db, pubKeyFromAuth, workspace.Uuid) {
+		httpio.WriteError(w, r, http.StatusUnauthorized, "Don't have access to this workspace")
+		return
+	}
+	month, err := db.AiUsageMonth(r.URL.Query().Get("month"), time.Now())
+	if err != nil {
+		httpio.WriteError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	usage, err := qh.db.GetAiUsage(workspace.Uuid, month)
+	if err != nil {
+		fmt.Println("[ai usage]", err)
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to get the AI usage")
+		return
+	}
+	usage.MonthlyCap = workspace.AiMonthlyCap
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(usage)
+}
+
+// SetAiCap sets the monthly cap of AI submissions of the workspace of the
+// route, for its owner
+func (qh *quotaHandler) SetAiCap(w http.ResponseWriter, r *http.Request) {
+	pubKeyFromAuth, _ := r.Context().Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[ai usage] no pubkey from auth")
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
+		return
+	}
+	workspace := qh.db.GetWorkspaceByUuid(chi.URLParam(r, "workspace_uuid"))
+	if workspace.Uuid == "" {
+		httpio.WriteError(w, r, http.StatusNotFound, "Workspace not found")
+		return
+	}
+	if workspace.OwnerPubKey != pubKeyFromAuth {
+		httpio.WriteError(w, r, http.StatusUnauthorized, "Only the workspace admin can set the AI cap")
+		return
+	}
+
+	request := db.AiCapRequest{}
+	body, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err := json.Unmarshal(body, &request); err != nil {
+		fmt.Println("[ai usage]", err)
+		httpio.WriteError(w, r, http.StatusNotAcceptable, "")
+		return
+	}
+
+	workspace, err := qh.db.SetWorkspaceAiCap(workspace.Uuid, request.MonthlyCap)
+	if err != nil {
+		fmt.Println("[ai usage]", err)
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to set the AI cap")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(workspace)
+}
@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/handlers/mocks"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAiUsage(t *testing.T) {
+	workspace := db.Workspace{Uuid: "workspace-uuid", OwnerPubKey: "owner-pubkey", AiMonthlyCap: 100}
+	newRequest := func(method string, pubkey string, target string, body string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("workspace_uuid", workspace.Uuid)
+		ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+		ctx = context.WithValue(ctx, auth.ContextKey, pubkey)
+		req, _ := http.NewRequestWithContext(ctx, method, target, bytes.NewBufferString(body))
+		return req
+	}
+
+	t.Run("should return the usage of the month per workflow", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		qHandler := NewQuotaHandler(mockDb)
+		cost := 12.5
+
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(workspace).Twice()
+		mockDb.On("GetAiUsage", workspace.Uuid, time.Date(2026, time.September, 1, 0, 0, 0, 0, time.UTC)).Return(db.AiUsage{
+			WorkspaceUuid: workspace.Uuid, Month: "2026-09", Submissions: 3, Cost: &cost,
+			Workflows: []db.AiWorkflowUsage{{Kind: db.AiHiveChat, WorkflowId: "42", Submissions: 3, Cost: &cost}},
+		}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(qHandler.GetAiUsage).ServeHTTP(rr, newRequest(http.MethodGet, "owner-pubkey", "/workspaces/workspace-uuid/ai_usage?month=2026-09", ""))
+
+		usage := db.AiUsage{}
+		json.Unmarshal(rr.Body.Bytes(), &usage)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, int64(3), usage.Submissions)
+		assert.Equal(t, uint(100), usage.MonthlyCap)
+		assert.Equal(t, 12.5, *usage.Cost)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should refuse a month that is not formatted", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		qHandler := NewQuotaHandler(mockDb)
+
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(workspace).Twice()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(qHandler.GetAiUsage).ServeHTTP(rr, newRequest(http.MethodGet, "owner-pubkey", "/workspaces/workspace-uuid/ai_usage?month=september", ""))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockDb.AssertNotCalled(t, "GetAiUsage", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should only let the workspace admin set the cap", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		qHandler := NewQuotaHandler(mockDb)
+
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(workspace).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(qHandler.SetAiCap).ServeHTTP(rr, newRequest(http.MethodPut, "member-pubkey", "/workspaces/workspace-uuid/ai_usage", `{"monthly_cap": 10}`))
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		mockDb.AssertNotCalled(t, "SetWorkspaceAiCap", mock.Anything, mock.Anything)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should not submit once the workspace made its monthly cap", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mockHttpClient := &mocks.HttpClient{}
+		bHandler := NewBriefHandler(mockHttpClient, mockDb)
+		config.BriefWorkflowId = "42"
+		config.StakworkKey = "stakwork-key"
+		defer func() {
+			config.BriefWorkflowId = ""
+			config.StakworkKey = ""
+		}()
+
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(workspace).Twice()
+		mockDb.On("CountAiSubmissions", workspace.Uuid, mock.Anything).Return(int64(100), nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(bHandler.RegenerateBrief).ServeHTTP(rr, newRequest(http.MethodPost, "owner-pubkey", "/workspaces/workspace-uuid/brief/regenerate", ""))
+
+		assert.Equal(t, http.StatusTooManyRequests, rr.Code)
+		assert.NotEmpty(t, rr.Header().Get("Retry-After"))
+		mockDb.AssertNotCalled(t, "CreateOrEditWorkspaceBrief", mock.Anything)
+		mockHttpClient.AssertNotCalled(t, "Do", mock.Anything)
+		mockDb.AssertExpectations(t)
+	})
+}
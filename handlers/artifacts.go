@@ -0,0 +1,198 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+	"github.com/rs/xid"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/httpio"
+	"github.com/stakwork/sphinx-tribes/utils"
+)
+
+type artifactHandler struct {
+	db db.Database
+}
+
+func NewArtifactHandler(database db.Database) *artifactHandler {
+	return &artifactHandler{db: database}
+}
+
+// memberOf writes the error and returns false unless the caller is a member
+// of the workspace of the route
+func (ah *artifactHandler) memberOf(w http.ResponseWriter, r *http.Request) (string, bool) {
+	pubKeyFromAuth, _ := r.Context().Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[artifacts] no pubkey from auth")
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
+		return "", false
+	}
+	if !isWorkspaceMember(ah.db, pubKeyFromAuth, chi.URLParam(r, "workspace_uuid")) {
+		httpio.WriteError(w, r, http.StatusUnauthorized, "Don't have access to this workspace")
+		return "", false
+	}
+	return pubKeyFromAuth, true
+}
+
+// artifactOf returns the artifact of the route when it belongs to the
+// workspace of the route
+func (ah *artifactHandler) artifactOf(w http.ResponseWriter, r *http.Request) (db.WorkspaceArtifact, bool) {
+	artifact, err := ah.db.GetArtifact(chi.URLParam(r, "uuid"))
+	if err != nil || artifact.WorkspaceUuid != chi.URLParam(r, "workspace_uuid") {
+		httpio.WriteError(w, r, http.StatusNotFound, "Artifact not found")
+		return artifact, false
+	}
+	return artifact, true
+}
+
+func (ah *artifactHandler) GetArtifacts(w http.ResponseWriter, r *http.Request) {
+	if _, ok := ah.memberOf(w, r); !ok {
+		return
+	}
+	workspaceUuid := chi.URLParam(r, "workspace_uuid")
+
+	artifacts, err := ah.db.GetArtifacts(workspaceUuid, r)
+	if err != nil {
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to get the artifacts")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(utils.ListBody(r, artifacts, func() int64 {
+		return ah.db.GetArtifactsCount(workspaceUuid, r)
+	}))
+}
+
+// CreateOrEditArtifact creates an artifact, or saves the next version of the
+// artifact with the uuid of the body
+func (ah *artifactHandler) CreateOrEditArtifact(w http.ResponseWriter, r *http.Request) {
+	pubKeyFromAuth, ok := ah.memberOf(w, r)
+	if !ok {
+		return
+	}
+
+	artifact := db.WorkspaceArtifact{}
+	body, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err := json.Unmarshal(body, &artifact); err != nil {
+		fmt.Println("[artifacts]", err)
+		httpio.WriteError(w, r, http.StatusNotAcceptable, "")
+		return
+	}
+	artifact.WorkspaceUuid = chi.URLParam(r, "workspace_uuid")
+
+	if artifact.Uuid == "" {
+		artifact.Uuid = xid.New().String()
+	} else if existing, err := ah.db.GetArtifact(artifact.Uuid); err == nil && existing.WorkspaceUuid != artifact.WorkspaceUuid {
+		httpio.WriteError(w, r, http.StatusNotFound, "Artifact not found")
+		return
+	}
+	// an edit keeps the creator of the first version
+	artifact.CreatedBy = pubKeyFromAuth
+	artifact.UpdatedBy = pubKeyFromAuth
+
+	if !validatePayload(w, r, artifact) {
+		return
+	}
+	if artifact.Content == "" && artifact.Url == "" {
+		httpio.WriteError(w, r, http.StatusBadRequest, "An artifact needs a content or a url")
+		return
+	}
+	if artifact.FeatureUuid != "" && ah.db.GetFeatureByUuid(artifact.FeatureUuid).WorkspaceUuid != artifact.WorkspaceUuid {
+		httpio.WriteError(w, r, http.StatusBadRequest, "Feature not found in this workspace")
+		return
+	}
+	// every version is kept, each save adds its content to the storage
+	if !withinQuota(w, r, ah.db, artifact.WorkspaceUuid, db.QuotaStorage, int64(len(artifact.Content))) {
+		return
+	}
+
+	saved, err := ah.db.CreateOrEditArtifact(artifact)
+	if err != nil {
+		fmt.Println("[artifacts]", err)
+		httpio.WriteError(w, r, http.StatusBadRequest, "")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(saved)
+}
+
+func (ah *artifactHandler) GetArtifact(w http.ResponseWriter, r *http.Request) {
+	if _, ok := ah.memberOf(w, r); !ok {
+		return
+	}
+	artifact, ok := ah.artifactOf(w, r)
+	if !ok {
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(artifact)
+}
+
+func (ah *artifactHandler) GetArtifactVersions(w http.ResponseWriter, r *http.Request) {
+	if _, ok := ah.memberOf(w, r); !ok {
+		return
+	}
+	artifact, ok := ah.artifactOf(w, r)
+	if !ok {
+		return
+	}
+
+	versions, err := ah.db.GetArtifactVersions(artifact.Uuid)
+	if err != nil {
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to get the versions")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(versions)
+}
+
+func (ah *artifactHandler) GetArtifactVersion(w http.ResponseWriter, r *http.Request) {
+	if _, ok := ah.memberOf(w, r); !ok {
+		return
+	}
+	artifact, ok := ah.artifactOf(w, r)
+	if !ok {
+		return
+	}
+
+	version, err := strconv.Atoi(chi.URLParam(r, "version"))
+	if err != nil {
+		httpio.WriteError(w, r, http.StatusBadRequest, "Invalid version")
+		return
+	}
+	artifactVersion, err := ah.db.GetArtifactVersion(artifact.Uuid, version)
+	if err != nil {
+		httpio.WriteError(w, r, http.StatusNotFound, "Version not found")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(artifactVersion)
+}
+
+func (ah *artifactHandler) DeleteArtifact(w http.ResponseWriter, r *http.Request) {
+	if _, ok := ah.memberOf(w, r); !ok {
+		return
+	}
+	artifact, ok := ah.artifactOf(w, r)
+	if !ok {
+		return
+	}
+
+	if err := ah.db.DeleteArtifact(artifact.Uuid); err != nil {
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to delete the artifact")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(true)
+}
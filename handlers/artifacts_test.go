@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestArtifacts(t *testing.T) {
+	workspace := db.Workspace{Uuid: "workspace-uuid", OwnerPubKey: "owner-pubkey"}
+	newRequest := func(method string, pubkey string, params map[string]string, body string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("workspace_uuid", workspace.Uuid)
+		for key, value := range params {
+			rctx.URLParams.Add(key, value)
+		}
+		ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+		ctx = context.WithValue(ctx, auth.ContextKey, pubkey)
+		req, _ := http.NewRequestWithContext(ctx, method, "/workspaces/workspace-uuid/artifacts", bytes.NewBufferString(body))
+		return req
+	}
+
+	t.Run("should create the first version of an artifact", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		aHandler := NewArtifactHandler(mockDb)
+
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(workspace).Once()
+		mockDb.On("CreateOrEditArtifact", mock.MatchedBy(func(a db.WorkspaceArtifact) bool {
+			return a.Uuid != "" && a.WorkspaceUuid == workspace.Uuid && a.Kind == db.ArtifactDiagram && a.CreatedBy == "owner-pubkey"
+		})).Return(func(a db.WorkspaceArtifact) (db.WorkspaceArtifact, error) {
+			a.Version = 1
+			return a, nil
+		}).Once()
+
+		rr := httptest.NewRecorder()
+		body := `{"kind": "diagram", "name": "Architecture", "url": "https://example.com/arch.png", "tags": ["backend"]}`
+		http.HandlerFunc(aHandler.CreateOrEditArtifact).ServeHTTP(rr, newRequest(http.MethodPost, "owner-pubkey", nil, body))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var artifact db.WorkspaceArtifact
+		json.Unmarshal(rr.Body.Bytes(), &artifact)
+		assert.Equal(t, 1, artifact.Version)
+		assert.Equal(t, []string{"backend"}, []string(artifact.Tags))
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should reject an unknown kind", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		aHandler := NewArtifactHandler(mockDb)
+
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(workspace).Once()
+
+		rr := httptest.NewRecorder()
+		body := `{"kind": "video", "name": "Demo", "url": "https://example.com/demo.mp4"}`
+		http.HandlerFunc(aHandler.CreateOrEditArtifact).ServeHTTP(rr, newRequest(http.MethodPost, "owner-pubkey", nil, body))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockDb.AssertNotCalled(t, "CreateOrEditArtifact", mock.Anything)
+	})
+
+	t.Run("should refuse users outside the workspace", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		aHandler := NewArtifactHandler(mockDb)
+
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(workspace).Once()
+		mockDb.On("GetWorkspaceUser", "stranger", workspace.Uuid).Return(db.WorkspaceUsers{}).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(aHandler.GetArtifacts).ServeHTTP(rr, newRequest(http.MethodGet, "stranger", nil, ""))
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should not return the artifact of another workspace", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		aHandler := NewArtifactHandler(mockDb)
+
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(workspace).Once()
+		mockDb.On("GetArtifact", "artifact-uuid").Return(db.WorkspaceArtifact{Uuid: "artifact-uuid", WorkspaceUuid: "other-workspace"}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(aHandler.GetArtifact).ServeHTTP(rr, newRequest(http.MethodGet, "owner-pubkey", map[string]string{"uuid": "artifact-uuid"}, ""))
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("should return a previous version", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		aHandler := NewArtifactHandler(mockDb)
+
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(workspace).Once()
+		mockDb.On("GetArtifact", "artifact-uuid").Return(db.WorkspaceArtifact{Uuid: "artifact-uuid", WorkspaceUuid: workspace.Uuid, Version: 3}, nil).Once()
+		mockDb.On("GetArtifactVersion", "artifact-uuid", 2).Return(db.WorkspaceArtifactVersion{ArtifactUuid: "artifact-uuid", Version: 2, Content: "v2"}, nil).Once()
+		mockDb.On("GetArtifactVersion", "artifact-uuid", 7).Return(db.WorkspaceArtifactVersion{}, gorm.ErrRecordNotFound).Maybe()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(aHandler.GetArtifactVersion).ServeHTTP(rr, newRequest(http.MethodGet, "owner-pubkey", map[string]string{"uuid": "artifact-uuid", "version": "2"}, ""))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var version db.WorkspaceArtifactVersion
+		json.Unmarshal(rr.Body.Bytes(), &version)
+		assert.Equal(t, "v2", version.Content)
+	})
+}
@@ -10,6 +10,8 @@ import (
 	"github.com/stakwork/sphinx-tribes/auth"
 	"github.com/stakwork/sphinx-tribes/config"
 	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/events"
+	"github.com/stakwork/sphinx-tribes/httpio"
 )
 
 type authHandler struct {
@@ -61,10 +63,17 @@ func (ah *authHandler) CreateConnectionCode(w http.ResponseWriter, r *http.Reque
 
 	err = json.Unmarshal(body, &codeStrArr)
 
+	// the codes of a campaign are counted together, and its owner is told
+	// when one of them is redeemed
+	campaign := r.URL.Query().Get("campaign")
+	ownerPubKey := r.URL.Query().Get("owner_pubkey")
+
 	for _, code := range codeStrArr {
 		code := db.ConnectionCodes{
 			ConnectionString: code,
 			IsUsed:           false,
+			Campaign:         campaign,
+			OwnerPubKey:      ownerPubKey,
 		}
 		codeArr = append(codeArr, code)
 	}
@@ -86,11 +95,44 @@ func (ah *authHandler) CreateConnectionCode(w http.ResponseWriter, r *http.Reque
 	json.NewEncoder(w).Encode("Codes created successfully")
 }
 
-func (ah *authHandler) GetConnectionCode(w http.ResponseWriter, _ *http.Request) {
-	connectionCode := ah.db.GetConnectionCode()
+func (ah *authHandler) GetConnectionCode(w http.ResponseWriter, r *http.Request) {
+	code, err := ah.db.RedeemConnectionCode()
+	if err != nil {
+		fmt.Println("[auth] => ERR redeem connection code", err)
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to get a connection code")
+		return
+	}
+
+	if code.ID != 0 {
+		events.Publish(r.Context(), events.ConnectionCodeRedeemed, fmt.Sprintf("connection_code:%d", code.ID), map[string]interface{}{
+			"id":           code.ID,
+			"campaign":     code.Campaign,
+			"owner_pubkey": code.OwnerPubKey,
+			"date_created": code.DateCreated,
+			"date_used":    code.DateUsed,
+			"source":       r.URL.Query().Get("source"),
+			"user_agent":   r.UserAgent(),
+		})
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(db.ConnectionCodesShort{
+		ConnectionString: code.ConnectionString,
+		DateCreated:      code.DateCreated,
+	})
+}
+
+// GetConnectionCodeStats lists how many codes each campaign created and
+// how many of them were redeemed
+func (ah *authHandler) GetConnectionCodeStats(w http.ResponseWriter, r *http.Request) {
+	stats, err := ah.db.GetConnectionCodeStats()
+	if err != nil {
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to get the connection code stats")
+		return
+	}
 
 	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(connectionCode)
+	json.NewEncoder(w).Encode(stats)
 }
 
 func GetLnurlAuth(w http.ResponseWriter, r *http.Request) {
@@ -113,8 +155,8 @@ func GetLnurlAuth(w http.ResponseWriter, r *http.Request) {
 
 	// add socket to store with K1, so the LNURL return data can use it
 	db.Store.SetSocketConnections(db.Client{
-		Host: encodeData.K1[0:20],
-		Conn: socket.Conn,
+		Host:   encodeData.K1[0:20],
+		Writer: socket.Writer,
 	})
 
 	responseData["k1"] = encodeData.K1
@@ -171,7 +213,7 @@ func ReceiveLnAuthData(w http.ResponseWriter, r *http.Request) {
 		socket, err := db.Store.GetSocketConnections(k1[0:20])
 
 		if err == nil {
-			socket.Conn.WriteJSON(socketMsg)
+			socket.Writer.WriteJSON(socketMsg)
 			db.Store.DeleteCache(k1[0:20])
 		} else {
 			fmt.Println("[auth] Socket Error", err)
@@ -19,6 +19,7 @@ import (
 	"github.com/stakwork/sphinx-tribes/auth"
 	"github.com/stakwork/sphinx-tribes/config"
 	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/dbtest"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -26,7 +27,7 @@ func TestGetAdminPubkeys(t *testing.T) {
 	t.Run("Should test that all admin pubkeys is returned", func(t *testing.T) {
 		// set the admins and init the config to update superadmins
 		os.Setenv("ADMINS", "test")
-		os.Setenv("RELAY_URL", "RelayUrl")
+		os.Setenv("RELAY_URL", "http://localhost:3001")
 		os.Setenv("RELAY_AUTH_KEY", "RelayAuthKey")
 		config.InitConfig()
 
@@ -139,57 +140,134 @@ func TestCreateConnectionCode(t *testing.T) {
 }
 
 func TestGetConnectionCode(t *testing.T) {
-	teardownSuite := SetupSuite(t)
-	defer teardownSuite(t)
-	aHandler := NewAuthHandler(db.TestDB)
+	database := dbtest.New(t)
+	aHandler := NewAuthHandler(database)
 
 	t.Run("should return connection code from db", func(t *testing.T) {
+		now := time.Now()
+		codeArr := []db.ConnectionCodes{{
+			ConnectionString: "sampleCode1",
+			IsUsed:           false,
+			DateCreated:      &now,
+		}}
+		database.CreateConnectionCode(codeArr)
 
+		req, err := http.NewRequest("GET", "/connectioncodes", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
 		rr := httptest.NewRecorder()
-		handler := http.HandlerFunc(aHandler.GetConnectionCode)
+		http.HandlerFunc(aHandler.GetConnectionCode).ServeHTTP(rr, req)
 
-		codeStrArr := []string{"sampleCode1"}
+		assert.Equal(t, http.StatusOK, rr.Code)
+		fetchedCode := db.ConnectionCodesShort{}
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &fetchedCode))
+		assert.EqualValues(t, codeArr[0].ConnectionString, fetchedCode.ConnectionString)
+		if assert.NotNil(t, fetchedCode.DateCreated) {
+			assert.WithinDuration(t, now, *fetchedCode.DateCreated, time.Millisecond)
+		}
+	})
+}
 
-		codeArr := []db.ConnectionCodes{}
-		now := time.Now()
+func TestRedeemConnectionCode(t *testing.T) {
+	now := time.Now()
+
+	t.Run("should return the redeemed code", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		aHandler := NewAuthHandler(mockDb)
+		mockDb.On("RedeemConnectionCode").Return(db.ConnectionCodes{
+			ID:               7,
+			ConnectionString: "campaign-code",
+			IsUsed:           true,
+			DateCreated:      &now,
+			Campaign:         "launch",
+			OwnerPubKey:      "owner",
+			DateUsed:         &now,
+		}, nil).Once()
+
+		req, _ := http.NewRequest("GET", "/connectioncodes?source=landing", nil)
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(aHandler.GetConnectionCode).ServeHTTP(rr, req)
 
-		for i, code := range codeStrArr {
-			code := db.ConnectionCodes{
-				ID:               uint(i),
-				ConnectionString: code,
-				IsUsed:           false,
-				DateCreated:      &now,
-			}
+		assert.Equal(t, http.StatusOK, rr.Code)
+		code := map[string]interface{}{}
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &code))
+		assert.Equal(t, "campaign-code", code["connection_string"])
+		assert.NotContains(t, code, "campaign")
+	})
 
-			codeArr = append(codeArr, code)
-		}
+	t.Run("should return an empty code when none is left", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		aHandler := NewAuthHandler(mockDb)
+		mockDb.On("RedeemConnectionCode").Return(db.ConnectionCodes{}, nil).Once()
 
-		// Ensure codeArr has at least one element
-		codeShort := db.ConnectionCodesShort{
-			ConnectionString: codeArr[0].ConnectionString,
-			DateCreated:      codeArr[0].DateCreated,
-		}
+		req, _ := http.NewRequest("GET", "/connectioncodes", nil)
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(aHandler.GetConnectionCode).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		code := db.ConnectionCodesShort{}
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &code))
+		assert.Equal(t, "", code.ConnectionString)
+	})
 
-		db.TestDB.CreateConnectionCode(codeArr)
+	t.Run("should return 500 when the code can't be redeemed", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		aHandler := NewAuthHandler(mockDb)
+		mockDb.On("RedeemConnectionCode").Return(db.ConnectionCodes{}, errors.New("db down")).Once()
 
-		req, err := http.NewRequest("GET", "/connectioncodes", nil)
-		if err != nil {
-			t.Fatal(err)
-		}
+		req, _ := http.NewRequest("GET", "/connectioncodes", nil)
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(aHandler.GetConnectionCode).ServeHTTP(rr, req)
 
-		fetchedCodes := db.TestDB.GetConnectionCode()
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	})
 
-		handler.ServeHTTP(rr, req)
+	t.Run("should create the codes of a campaign", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		aHandler := NewAuthHandler(mockDb)
+		codeArr := []db.ConnectionCodes{{ConnectionString: "code", Campaign: "launch", OwnerPubKey: "owner"}}
+		mockDb.On("CreateConnectionCode", codeArr).Return(codeArr, nil).Once()
+
+		body, _ := json.Marshal([]string{"code"})
+		req, _ := http.NewRequest("POST", "/connectioncodes?campaign=launch&owner_pubkey=owner", bytes.NewBuffer(body))
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(aHandler.CreateConnectionCode).ServeHTTP(rr, req)
 
 		assert.Equal(t, http.StatusOK, rr.Code)
-		assert.EqualValues(t, codeShort.ConnectionString, fetchedCodes.ConnectionString)
-		tolerance := time.Millisecond
-		timeDifference := codeShort.DateCreated.Sub(*fetchedCodes.DateCreated)
-		if timeDifference < 0 {
-			timeDifference = -timeDifference
+	})
+}
+
+func TestGetConnectionCodeStats(t *testing.T) {
+	t.Run("should list the stats of every campaign", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		aHandler := NewAuthHandler(mockDb)
+		stats := []db.ConnectionCodeStats{
+			{Campaign: "", Created: 4, Redeemed: 1},
+			{Campaign: "launch", Created: 10, Redeemed: 3},
 		}
-		assert.True(t, timeDifference <= tolerance, "Expected DateCreated to be within tolerance")
+		mockDb.On("GetConnectionCodeStats").Return(stats, nil).Once()
+
+		req, _ := http.NewRequest("GET", "/admin/connectioncodes/stats", nil)
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(aHandler.GetConnectionCodeStats).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		returned := []db.ConnectionCodeStats{}
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &returned))
+		assert.Equal(t, stats, returned)
+	})
+
+	t.Run("should return 500 when the stats can't be read", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		aHandler := NewAuthHandler(mockDb)
+		mockDb.On("GetConnectionCodeStats").Return(nil, errors.New("db down")).Once()
+
+		req, _ := http.NewRequest("GET", "/admin/connectioncodes/stats", nil)
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(aHandler.GetConnectionCodeStats).ServeHTTP(rr, req)
 
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
 	})
 }
 
@@ -233,9 +311,8 @@ func TestGetIsAdmin(t *testing.T) {
 }
 
 func TestRefreshToken(t *testing.T) {
-	teardownSuite := SetupSuite(t)
-	defer teardownSuite(t)
-	aHandler := NewAuthHandler(db.TestDB)
+	database := dbtest.New(t)
+	aHandler := NewAuthHandler(database)
 
 	t.Run("Should test that a user token can be refreshed", func(t *testing.T) {
 		mockToken := "mock_token"
@@ -249,7 +326,7 @@ func TestRefreshToken(t *testing.T) {
 			Extras:       db.PropertyMap{},
 			GithubIssues: db.PropertyMap{},
 		}
-		db.TestDB.CreateOrEditPerson(person)
+		database.CreateOrEditPerson(person)
 
 		// Mock JWT decoding
 		mockClaims := jwt.MapClaims{
@@ -274,7 +351,7 @@ func TestRefreshToken(t *testing.T) {
 		}
 		req.Header.Set("x-jwt", mockToken)
 
-		fetchedPerson := db.TestDB.GetPersonByUuid(person.Uuid)
+		fetchedPerson := database.GetPersonByUuid(person.Uuid)
 		person.ID = fetchedPerson.ID
 		// Serve request
 		rr := httptest.NewRecorder()
@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/httpio"
+)
+
+type batchHandler struct {
+	db db.Database
+}
+
+func NewBatchHandler(database db.Database) *batchHandler {
+	return &batchHandler{db: database}
+}
+
+// BatchGet returns the people, bounties and tribes of the refs in one
+// response. Each ref gets its own status, a missing one doesn't fail the
+// others. The data is what the single reads return: the person, the bounty
+// with its owner, assignee and workspace, and the tribe
+func (bh *batchHandler) BatchGet(w http.ResponseWriter, r *http.Request) {
+	request := db.BatchGetRequest{}
+	body, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err := json.Unmarshal(body, &request); err != nil {
+		httpio.WriteError(w, r, http.StatusNotAcceptable, "Could not parse the batch request")
+		return
+	}
+	if !validatePayload(w, r, request) {
+		return
+	}
+
+	pubkeys := []string{}
+	bountyIds := []uint{}
+	tribeUuids := []string{}
+	for _, ref := range request.Refs {
+		switch ref.Type {
+		case db.BatchPerson:
+			pubkeys = append(pubkeys, ref.Id)
+		case db.BatchBounty:
+			if id, err := strconv.ParseUint(ref.Id, 10, 32); err == nil {
+				bountyIds = append(bountyIds, uint(id))
+			}
+		case db.BatchTribe:
+			tribeUuids = append(tribeUuids, ref.Id)
+		}
+	}
+
+	found := map[string]interface{}{}
+	for _, person := range bh.db.GetPeopleByPubkeys(pubkeys) {
+		found[db.BatchPerson+":"+person.OwnerPubKey] = person
+	}
+	bounties := bh.db.GetBountiesByIds(bountyIds)
+	for _, bounty := range bh.db.GetBountyResponses(bounties) {
+		found[db.BatchBounty+":"+strconv.FormatUint(uint64(bounty.Bounty.ID), 10)] = bounty
+	}
+	for _, tribe := range bh.db.GetTribesByUuids(tribeUuids) {
+		found[db.BatchTribe+":"+tribe.UUID] = tribe
+	}
+
+	items := []db.BatchItem{}
+	for _, ref := range request.Refs {
+		item := db.BatchItem{Type: ref.Type, Id: ref.Id}
+		key, ok := batchKey(ref)
+		if !ok {
+			item.Status = http.StatusBadRequest
+			item.Error = "Invalid bounty id"
+		} else if data, ok := found[key]; ok {
+			item.Status = http.StatusOK
+			item.Data = data
+		} else {
+			item.Status = http.StatusNotFound
+			item.Error = "Not found"
+		}
+		items = append(items, item)
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(items)
+}
+
+// batchKey identifies a ref in the results, bounty ids are compared as
+// numbers. It is false for a bounty id that isn't one
+func batchKey(ref db.BatchRef) (string, bool) {
+	if ref.Type != db.BatchBounty {
+		return ref.Type + ":" + ref.Id, true
+	}
+	id, err := strconv.ParseUint(ref.Id, 10, 32)
+	if err != nil {
+		return "", false
+	}
+	return db.BatchBounty + ":" + strconv.FormatUint(id, 10), true
+}
@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stakwork/sphinx-tribes/db"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestBatchGet(t *testing.T) {
+	newRequest := func(body string) *http.Request {
+		req, _ := http.NewRequest(http.MethodPost, "/batch/get", bytes.NewBufferString(body))
+		return req
+	}
+
+	t.Run("should return every ref with its status in order", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		bHandler := NewBatchHandler(mockDb)
+
+		bounty := db.NewBounty{ID: 7, Title: "bounty", OwnerID: "alice-pubkey"}
+		mockDb.On("GetPeopleByPubkeys", []string{"alice-pubkey", "missing-pubkey"}).Return([]db.Person{{OwnerPubKey: "alice-pubkey", OwnerAlias: "alice"}}).Once()
+		mockDb.On("GetBountiesByIds", []uint{7}).Return([]db.NewBounty{bounty}).Once()
+		mockDb.On("GetBountyResponses", []db.NewBounty{bounty}).Return([]db.BountyResponse{{Bounty: bounty}}).Once()
+		mockDb.On("GetTribesByUuids", []string{"tribe-uuid"}).Return([]db.Tribe{{UUID: "tribe-uuid", Name: "tribe"}}).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(bHandler.BatchGet).ServeHTTP(rr, newRequest(`{"refs":[
+			{"type":"person","id":"alice-pubkey"},
+			{"type":"bounty","id":"007"},
+			{"type":"tribe","id":"tribe-uuid"},
+			{"type":"person","id":"missing-pubkey"},
+			{"type":"bounty","id":"seven"}
+		]}`))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		items := []db.BatchItem{}
+		json.Unmarshal(rr.Body.Bytes(), &items)
+		assert.Len(t, items, 5)
+		assert.Equal(t, []int{http.StatusOK, http.StatusOK, http.StatusOK, http.StatusNotFound, http.StatusBadRequest},
+			[]int{items[0].Status, items[1].Status, items[2].Status, items[3].Status, items[4].Status})
+		assert.Equal(t, "alice", items[0].Data.(map[string]interface{})["owner_alias"])
+		assert.Equal(t, "007", items[1].Id)
+		assert.Equal(t, "bounty", items[1].Data.(map[string]interface{})["bounty"].(map[string]interface{})["title"])
+		assert.Nil(t, items[3].Data)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should refuse unknown types", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		bHandler := NewBatchHandler(mockDb)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(bHandler.BatchGet).ServeHTTP(rr, newRequest(`{"refs":[{"type":"workspace","id":"uuid"}]}`))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockDb.AssertNotCalled(t, "GetPeopleByPubkeys", mock.Anything)
+	})
+
+	t.Run("should refuse an empty batch", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		bHandler := NewBatchHandler(mockDb)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(bHandler.BatchGet).ServeHTTP(rr, newRequest(`{"refs":[]}`))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
@@ -14,14 +14,14 @@ import (
 	"github.com/lib/pq"
 	"github.com/stakwork/sphinx-tribes/auth"
 	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/dbtest"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestGetBotByUniqueName(t *testing.T) {
-	teardownSuite := SetupSuite(t)
-	defer teardownSuite(t)
+	database := dbtest.New(t)
 
-	btHandler := NewBotHandler(db.TestDB)
+	btHandler := NewBotHandler(database)
 
 	t.Run("successful retrieval of bots by uniqueName", func(t *testing.T) {
 		rr := httptest.NewRecorder()
@@ -45,7 +45,7 @@ func TestGetBotByUniqueName(t *testing.T) {
 			OwnerRouteHint: "route-hint",
 		}
 
-		db.TestDB.CreateOrEditBot(bot)
+		database.CreateOrEditBot(bot)
 
 		rctx := chi.NewRouteContext()
 		rctx.URLParams.Add("name", bot.UniqueName)
@@ -65,10 +65,9 @@ func TestGetBotByUniqueName(t *testing.T) {
 }
 
 func TestGetBotsByOwner(t *testing.T) {
-	teardownSuite := SetupSuite(t)
-	defer teardownSuite(t)
+	database := dbtest.New(t)
 
-	btHandler := NewBotHandler(db.TestDB)
+	btHandler := NewBotHandler(database)
 
 	t.Run("empty list is returned when a user has no bots", func(t *testing.T) {
 		rr := httptest.NewRecorder()
@@ -85,7 +84,7 @@ func TestGetBotsByOwner(t *testing.T) {
 			Extras:       db.PropertyMap{},
 			GithubIssues: db.PropertyMap{},
 		}
-		db.TestDB.CreateOrEditPerson(person)
+		database.CreateOrEditPerson(person)
 
 		rctx := chi.NewRouteContext()
 		rctx.URLParams.Add("pubkey", person.OwnerPubKey)
@@ -116,7 +115,7 @@ func TestGetBotsByOwner(t *testing.T) {
 			Extras:       db.PropertyMap{},
 			GithubIssues: db.PropertyMap{},
 		}
-		db.TestDB.CreateOrEditPerson(person)
+		database.CreateOrEditPerson(person)
 
 		bot := db.Bot{
 			UUID:           "bot1_uuid",
@@ -154,8 +153,8 @@ func TestGetBotsByOwner(t *testing.T) {
 			OwnerRouteHint: "route-hint",
 		}
 
-		db.TestDB.CreateOrEditBot(bot)
-		db.TestDB.CreateOrEditBot(bot2)
+		database.CreateOrEditBot(bot)
+		database.CreateOrEditBot(bot2)
 
 		rctx := chi.NewRouteContext()
 		rctx.URLParams.Add("pubkey", person.OwnerPubKey)
@@ -174,10 +173,9 @@ func TestGetBotsByOwner(t *testing.T) {
 }
 
 func TestSearchBots(t *testing.T) {
-	teardownSuite := SetupSuite(t)
-	defer teardownSuite(t)
+	database := dbtest.New(t)
 
-	btHandler := NewBotHandler(db.TestDB)
+	btHandler := NewBotHandler(database)
 
 	t.Run("successful search query returns data", func(t *testing.T) {
 		rr := httptest.NewRecorder()
@@ -195,7 +193,7 @@ func TestSearchBots(t *testing.T) {
 			PricePerUse: 100,
 		}
 
-		bot, err := db.TestDB.CreateOrEditBot(bot)
+		bot, err := database.CreateOrEditBot(bot)
 		if err != nil {
 			t.Fatalf("Failed to create bot: %v", err)
 		}
@@ -255,10 +253,9 @@ func TestSearchBots(t *testing.T) {
 }
 
 func TestDeleteBot(t *testing.T) {
-	teardownSuite := SetupSuite(t)
-	defer teardownSuite(t)
+	database := dbtest.New(t)
 
-	btHandler := NewBotHandler(db.TestDB)
+	btHandler := NewBotHandler(database)
 
 	btHandler.verifyTribeUUID = func(uuid string, checkTimestamp bool) (string, error) {
 		return "owner-pubkey-123", nil
@@ -279,7 +276,7 @@ func TestDeleteBot(t *testing.T) {
 			PricePerUse: 100,
 		}
 
-		db.TestDB.CreateOrEditBot(bot)
+		database.CreateOrEditBot(bot)
 
 		ctx := context.WithValue(context.Background(), auth.ContextKey, bot.OwnerPubKey)
 
@@ -293,16 +290,15 @@ func TestDeleteBot(t *testing.T) {
 		handler.ServeHTTP(rr, req)
 
 		assert.Equal(t, http.StatusOK, rr.Code)
-		deletedBot := db.TestDB.GetBot(bot.UUID)
+		deletedBot := database.GetBot(bot.UUID)
 		assert.Empty(t, deletedBot)
 	})
 }
 
 func TestCreateOrEditBot(t *testing.T) {
-	teardownSuite := SetupSuite(t)
-	defer teardownSuite(t)
+	database := dbtest.New(t)
 
-	bHandler := NewBotHandler(db.TestDB)
+	bHandler := NewBotHandler(database)
 
 	t.Run("should test that a 401 error is returned during bot creation if there is no bot uuid", func(t *testing.T) {
 		requestBody := map[string]interface{}{
@@ -390,7 +386,7 @@ func TestCreateOrEditBot(t *testing.T) {
 			MemberCount:    10,
 			OwnerRouteHint: "route-hint",
 		}
-		db.TestDB.CreateOrEditBot(bot)
+		database.CreateOrEditBot(bot)
 
 		req, err := http.NewRequest("POST", "/", bytes.NewBuffer(requestBodyBytes))
 		if err != nil {
@@ -412,7 +408,7 @@ func TestCreateOrEditBot(t *testing.T) {
 		}
 		assert.Equal(t, bot.UUID, responseData["uuid"])
 
-		createdBot := db.TestDB.GetBot(bot.UUID)
+		createdBot := database.GetBot(bot.UUID)
 		assert.Equal(t, bot.UUID, createdBot.UUID)
 		assert.Equal(t, bot.OwnerPubKey, createdBot.OwnerPubKey)
 		assert.Equal(t, bot.OwnerAlias, createdBot.OwnerAlias)
@@ -449,7 +445,7 @@ func TestCreateOrEditBot(t *testing.T) {
 			Description: "bot description",
 			Tags:        pq.StringArray{},
 		}
-		db.TestDB.CreateOrEditBot(bot)
+		database.CreateOrEditBot(bot)
 
 		req, err := http.NewRequest("POST", "/", bytes.NewBuffer(requestBodyBytes))
 		if err != nil {
@@ -471,15 +467,14 @@ func TestCreateOrEditBot(t *testing.T) {
 		assert.Equal(t, "bot_uuid", responseData["uuid"])
 		assert.Equal(t, "Updated Test Bot", responseData["name"])
 
-		updatedBot := db.TestDB.GetBot("bot_uuid")
+		updatedBot := database.GetBot("bot_uuid")
 		assert.Equal(t, "Updated Test Bot", updatedBot.Name)
 	})
 }
 
 func TestGetBot(t *testing.T) {
-	teardownSuite := SetupSuite(t)
-	defer teardownSuite(t)
-	bHandler := NewBotHandler(db.TestDB)
+	database := dbtest.New(t)
+	bHandler := NewBotHandler(database)
 
 	t.Run("should test that a bot can be fetched with its uuid", func(t *testing.T) {
 		rr := httptest.NewRecorder()
@@ -497,7 +492,7 @@ func TestGetBot(t *testing.T) {
 			Tsv:         "'bot':2A,5B 'bot-descript':4B 'bot-nam':1A 'descript':6B 'name':3A 'tag1' 'tag2'",
 		}
 
-		db.TestDB.CreateOrEditBot(bot)
+		database.CreateOrEditBot(bot)
 
 		rctx := chi.NewRouteContext()
 		rctx.URLParams.Add("uuid", bot.UUID)
@@ -507,7 +502,7 @@ func TestGetBot(t *testing.T) {
 			t.Fatal(err)
 		}
 
-		fetchedBot := db.TestDB.GetBot(bot.UUID)
+		fetchedBot := database.GetBot(bot.UUID)
 
 		handler.ServeHTTP(rr, req)
 
@@ -521,17 +516,14 @@ func TestGetBot(t *testing.T) {
 }
 
 func TestGetListedBots(t *testing.T) {
-	teardownSuite := SetupSuite(t)
-	defer teardownSuite(t)
+	database := dbtest.New(t)
 
-	bHandler := NewBotHandler(db.TestDB)
+	bHandler := NewBotHandler(database)
 
 	t.Run("should test that all bots that are not unlisted or deleted get listed", func(t *testing.T) {
 		rr := httptest.NewRecorder()
 		handler := http.HandlerFunc(bHandler.GetListedBots)
 
-		db.TestDB.DeleteBot()
-
 		bot := db.Bot{
 			UUID:        "bot_uuid1",
 			OwnerPubKey: "your_pubkey1",
@@ -555,8 +547,8 @@ func TestGetListedBots(t *testing.T) {
 			Unlisted:    true,
 		}
 
-		db.TestDB.CreateOrEditBot(bot)
-		db.TestDB.CreateOrEditBot(bot2)
+		database.CreateOrEditBot(bot)
+		database.CreateOrEditBot(bot2)
 
 		rctx := chi.NewRouteContext()
 		req, err := http.NewRequestWithContext(context.WithValue(context.Background(), chi.RouteCtxKey, rctx), http.MethodGet, "/", nil)
@@ -6,11 +6,12 @@ import (
 	"io"
 	"log"
 	"net/http"
-	"reflect"
+	"sort"
 	"strconv"
+	"time"
 
-	"github.com/lib/pq"
 	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/httpio"
 )
 
 func GetWantedsHeader(w http.ResponseWriter, r *http.Request) {
@@ -30,18 +31,31 @@ func GetWantedsHeader(w http.ResponseWriter, r *http.Request) {
 func GetListedOffers(w http.ResponseWriter, r *http.Request) {
 	people, err := db.DB.GetListedOffers(r)
 	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
+		httpio.WriteError(w, r, http.StatusBadRequest, "")
 	} else {
 		w.WriteHeader(http.StatusOK)
 		json.NewEncoder(w).Encode(people)
 	}
 }
 
-func GetBountiesLeaderboard(w http.ResponseWriter, _ *http.Request) {
-	leaderBoard := db.DB.GetBountiesLeaderboard()
+// GetBountiesLeaderboard ranks the hunters by the sats they earned, or by
+// their reputation with sort=reputation
+func GetBountiesLeaderboard(w http.ResponseWriter, r *http.Request) {
+	byReputation := r.URL.Query().Get("sort") == "reputation"
+	key := db.LeaderboardCacheKey + "bounties"
+	if byReputation {
+		key += ":reputation"
+	}
+	leaderBoard, err := db.CachedJSON(key, func() interface{} {
+		return withReputation(db.DB, db.DB.GetBountiesLeaderboard(), byReputation)
+	})
+	if err != nil {
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to encode leaderboard")
+		return
+	}
 
 	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(leaderBoard)
+	w.Write(leaderBoard)
 }
 
 func DeleteBountyAssignee(w http.ResponseWriter, r *http.Request) {
@@ -55,7 +69,7 @@ func DeleteBountyAssignee(w http.ResponseWriter, r *http.Request) {
 
 	if err != nil {
 		fmt.Println(err)
-		w.WriteHeader(http.StatusNotAcceptable)
+		httpio.WriteError(w, r, http.StatusNotAcceptable, "")
 		return
 	}
 
@@ -76,171 +90,35 @@ func DeleteBountyAssignee(w http.ResponseWriter, r *http.Request) {
 		deletedAssignee = true
 	} else {
 		log.Printf("Could not delete bounty assignee")
-
-		deletedAssignee = false
-
-		w.WriteHeader(http.StatusBadRequest)
-		json.NewEncoder(w).Encode(deletedAssignee)
+		httpio.WriteError(w, r, http.StatusBadRequest, "Could not delete bounty assignee")
+		return
 	}
 
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(deletedAssignee)
 }
 
-func MigrateBounties(w http.ResponseWriter, r *http.Request) {
-	peeps := db.DB.GetAllPeople()
-
-	for indexPeep, peep := range peeps {
-		fmt.Println("peep: ", indexPeep)
-		bounties, ok := peep.Extras["wanted"].([]interface{})
-
-		if !ok {
-			fmt.Println("Wanted not there")
-			continue
-		}
-
-		for index, bounty := range bounties {
-
-			fmt.Println("looping bounties: ", index)
-			migrateBounty := bounty.(map[string]interface{})
-
-			migrateBountyFinal := db.Bounty{}
-			migrateBountyFinal.Title, ok = migrateBounty["title"].(string)
-
-			migrateBountyFinal.OwnerID = peep.OwnerPubKey
-
-			Paid, ok1 := migrateBounty["paid"].(bool)
-			if !ok1 {
-				migrateBountyFinal.Paid = false
-			} else {
-				migrateBountyFinal.Paid = Paid
-			}
-
-			Show, ok2 := migrateBounty["show"].(bool)
-			if !ok2 {
-				migrateBountyFinal.Show = true
-			} else {
-				migrateBountyFinal.Show = Show
-			}
-
-			Type, ok3 := migrateBounty["type"].(string)
-			if !ok3 {
-				migrateBountyFinal.Type = ""
-			} else {
-				migrateBountyFinal.Type = Type
-			}
-
-			Award, ok4 := migrateBounty["award"].(string)
-			if !ok4 {
-				migrateBountyFinal.Award = ""
-			} else {
-				migrateBountyFinal.Award = Award
-			}
-
-			Price, ok5 := migrateBounty["price"].(uint)
-			if !ok5 {
-				migrateBountyFinal.Price = 0
-			} else {
-				migrateBountyFinal.Price = Price
-			}
-
-			Tribe, ok6 := migrateBounty["tribe"].(string)
-			if !ok6 {
-				migrateBountyFinal.Tribe = ""
-			} else {
-				migrateBountyFinal.Tribe = Tribe
-			}
-
-			Created, ok7 := migrateBounty["created"].(float64)
-			CreatedInt64 := int64(Created)
-			if !ok7 {
-				migrateBountyFinal.Created = 0
-			} else {
-				fmt.Println(reflect.TypeOf(CreatedInt64))
-				fmt.Println("Timestamp:", CreatedInt64)
-				migrateBountyFinal.Created = CreatedInt64
-			}
-
-			Assignee, ok8 := migrateBounty["assignee"].(map[string]interface{})
-			if !ok8 {
-				migrateBountyFinal.Assignee = ""
-			} else {
-				assigneePubkey := Assignee["owner_pubkey"].(string)
-				assigneeId := ""
-				for _, peep := range peeps {
-					if peep.OwnerPubKey == assigneePubkey {
-						assigneeId = peep.OwnerPubKey
-					}
-				}
-				migrateBountyFinal.Assignee = assigneeId
-			}
-
-			TicketUrl, ok9 := migrateBounty["ticketUrl"].(string)
-			if !ok9 {
-				migrateBountyFinal.TicketUrl = ""
-			} else {
-				migrateBountyFinal.TicketUrl = TicketUrl
-			}
-
-			Description, ok10 := migrateBounty["description"].(string)
-			if !ok10 {
-				migrateBountyFinal.Description = ""
-			} else {
-				migrateBountyFinal.Description = Description
-			}
-
-			WantedType, ok11 := migrateBounty["wanted_type"].(string)
-			if !ok11 {
-				migrateBountyFinal.WantedType = ""
-			} else {
-				migrateBountyFinal.WantedType = WantedType
-			}
-
-			Deliverables, ok12 := migrateBounty["deliverables"].(string)
-			if !ok12 {
-				migrateBountyFinal.Deliverables = ""
-			} else {
-				migrateBountyFinal.Deliverables = Deliverables
-			}
-
-			CodingLanguages, ok13 := migrateBounty["coding_language"].(db.PropertyMap)
-			if !ok13 {
-				migrateBountyFinal.CodingLanguages = pq.StringArray{}
-			} else {
-				migrateBountyFinal.CodingLanguages = CodingLanguages["value"].(pq.StringArray)
-			}
-
-			GithuDescription, ok14 := migrateBounty["github_description"].(bool)
-			if !ok14 {
-				migrateBountyFinal.GithubDescription = false
-			} else {
-				migrateBountyFinal.GithubDescription = GithuDescription
-			}
-
-			OneSentenceSummary, ok15 := migrateBounty["one_sentence_summary"].(string)
-			if !ok15 {
-				migrateBountyFinal.OneSentenceSummary = ""
-			} else {
-				migrateBountyFinal.OneSentenceSummary = OneSentenceSummary
-			}
-
-			EstimatedSessionLength, ok16 := migrateBounty["estimated_session_length"].(string)
-			if !ok16 {
-				migrateBountyFinal.EstimatedSessionLength = ""
-			} else {
-				migrateBountyFinal.EstimatedSessionLength = EstimatedSessionLength
-			}
-
-			EstimatedCompletionDate, ok17 := migrateBounty["estimated_completion_date"].(string)
-			if !ok17 {
-				migrateBountyFinal.EstimatedCompletionDate = ""
-			} else {
-				migrateBountyFinal.EstimatedCompletionDate = EstimatedCompletionDate
-			}
-			fmt.Println("Bounty about to be added ")
-			db.DB.AddBounty(migrateBountyFinal)
-			//Migrate the bounties here
-		}
+// withReputation adds the reputation of each hunter to the leaderboard, and
+// orders it by reputation when byReputation is set
+func withReputation(database db.Database, leaders []db.LeaderData, byReputation bool) []db.LeaderData {
+	pubkeys := make([]string, 0, len(leaders))
+	for _, leader := range leaders {
+		pubkey, _ := leader["owner_pubkey"].(string)
+		pubkeys = append(pubkeys, pubkey)
+	}
+	reputations, err := database.GetReputations(pubkeys, time.Now(), endorsementHalfLife())
+	if err != nil {
+		log.Printf("[leaderboard] could not get the reputations: %s", err)
+		return leaders
+	}
+
+	for i, pubkey := range pubkeys {
+		leaders[i]["reputation"] = reputations[pubkey].Reputation
+	}
+	if byReputation {
+		sort.SliceStable(leaders, func(i, j int) bool {
+			return reputations[pubkeys[i]].Reputation > reputations[pubkeys[j]].Reputation
+		})
 	}
-	return
+	return leaders
 }
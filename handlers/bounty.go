@@ -2,12 +2,15 @@ package handlers
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
 	"net/http"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -15,10 +18,20 @@ import (
 	"github.com/stakwork/sphinx-tribes/auth"
 	"github.com/stakwork/sphinx-tribes/config"
 	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/events"
+	"github.com/stakwork/sphinx-tribes/httpio"
+	"github.com/stakwork/sphinx-tribes/jobs"
+	"github.com/stakwork/sphinx-tribes/tracing"
+	"github.com/stakwork/sphinx-tribes/upstream"
 	"github.com/stakwork/sphinx-tribes/utils"
+	"github.com/stakwork/sphinx-tribes/websocket"
+	"go.opentelemetry.io/otel/attribute"
 	"gorm.io/gorm"
 )
 
+// AutoPayJob is the job of an auto-pay queued while the relay was unavailable
+const AutoPayJob = "bounty.autopay"
+
 type bountyHandler struct {
 	httpClient               HttpClient
 	db                       db.Database
@@ -46,29 +59,51 @@ func (h *bountyHandler) GetAllBounties(w http.ResponseWriter, r *http.Request) {
 	var bountyResponse []db.BountyResponse = h.GenerateBountyResponse(bounties)
 
 	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(bountyResponse)
+	json.NewEncoder(w).Encode(utils.ListBody(r, bountyResponse, func() int64 {
+		return h.db.GetBountiesCount(r)
+	}))
 }
 
 func (h *bountyHandler) GetBountyById(w http.ResponseWriter, r *http.Request) {
 	bountyId := chi.URLParam(r, "bountyId")
 	if bountyId == "" {
-		w.WriteHeader(http.StatusNotFound)
+		httpio.WriteError(w, r, http.StatusNotFound, "Bounty id is required")
+		return
 	}
 	bounties, err := h.db.GetBountyById(bountyId)
 	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
+		httpio.WriteError(w, r, http.StatusBadRequest, "")
 		fmt.Println("[bounty] Error", err)
 	} else {
 		var bountyResponse []db.BountyResponse = h.GenerateBountyResponse(bounties)
+		viewer, pubkey := bountyViewer(r)
+		for i := range bountyResponse {
+			bountyResponse[i].Bounty.Links = h.db.GetMentions(db.MentionBounty, strconv.FormatUint(uint64(bountyResponse[i].Bounty.ID), 10))
+			if pubkey == "" || pubkey != bountyResponse[i].Bounty.OwnerID {
+				db.RecordBountyView(bountyResponse[i].Bounty.ID, viewer)
+			}
+		}
 		w.WriteHeader(http.StatusOK)
 		json.NewEncoder(w).Encode(bountyResponse)
 	}
 }
 
+// bountyViewer tells the viewers of a bounty apart, by the pubkey of a
+// signed in viewer and by their address otherwise. The pubkey is returned
+// so the owner's own views aren't counted
+func bountyViewer(r *http.Request) (string, string) {
+	if token := r.Header.Get("x-jwt"); token != "" {
+		if pubkey, err := auth.PubKeyFromToken(token); err == nil {
+			return "pubkey:" + pubkey, pubkey
+		}
+	}
+	return "address:" + httpio.ClientAddress(r), ""
+}
+
 func (h *bountyHandler) GetNextBountyByCreated(w http.ResponseWriter, r *http.Request) {
 	bounties, err := h.db.GetNextBountyByCreated(r)
 	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
+		httpio.WriteError(w, r, http.StatusBadRequest, "")
 		fmt.Println("[bounty] Error", err)
 	} else {
 		w.WriteHeader(http.StatusOK)
@@ -79,7 +114,7 @@ func (h *bountyHandler) GetNextBountyByCreated(w http.ResponseWriter, r *http.Re
 func (h *bountyHandler) GetPreviousBountyByCreated(w http.ResponseWriter, r *http.Request) {
 	bounties, err := h.db.GetPreviousBountyByCreated(r)
 	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
+		httpio.WriteError(w, r, http.StatusBadRequest, "")
 		fmt.Println("[bounty] Error", err)
 	} else {
 		w.WriteHeader(http.StatusOK)
@@ -90,7 +125,7 @@ func (h *bountyHandler) GetPreviousBountyByCreated(w http.ResponseWriter, r *htt
 func (h *bountyHandler) GetWorkspaceNextBountyByCreated(w http.ResponseWriter, r *http.Request) {
 	bounties, err := h.db.GetNextWorkspaceBountyByCreated(r)
 	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
+		httpio.WriteError(w, r, http.StatusBadRequest, "")
 		fmt.Println("[bounty] Error", err)
 	} else {
 		w.WriteHeader(http.StatusOK)
@@ -101,7 +136,7 @@ func (h *bountyHandler) GetWorkspaceNextBountyByCreated(w http.ResponseWriter, r
 func (h *bountyHandler) GetWorkspacePreviousBountyByCreated(w http.ResponseWriter, r *http.Request) {
 	bounties, err := h.db.GetPreviousWorkspaceBountyByCreated(r)
 	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
+		httpio.WriteError(w, r, http.StatusBadRequest, "")
 		fmt.Println("[bounty] Error", err)
 	} else {
 		w.WriteHeader(http.StatusOK)
@@ -112,7 +147,7 @@ func (h *bountyHandler) GetWorkspacePreviousBountyByCreated(w http.ResponseWrite
 func (h *bountyHandler) GetBountyIndexById(w http.ResponseWriter, r *http.Request) {
 	bountyId := chi.URLParam(r, "bountyId")
 	if bountyId == "" {
-		w.WriteHeader(http.StatusNotFound)
+		httpio.WriteError(w, r, http.StatusNotFound, "")
 		return
 	}
 	bountyIndex := h.db.GetBountyIndexById(bountyId)
@@ -123,11 +158,12 @@ func (h *bountyHandler) GetBountyIndexById(w http.ResponseWriter, r *http.Reques
 func (h *bountyHandler) GetBountyByCreated(w http.ResponseWriter, r *http.Request) {
 	created := chi.URLParam(r, "created")
 	if created == "" {
-		w.WriteHeader(http.StatusNotFound)
+		httpio.WriteError(w, r, http.StatusNotFound, "Bounty created time is required")
+		return
 	}
 	bounties, err := h.db.GetBountyDataByCreated(created)
 	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
+		httpio.WriteError(w, r, http.StatusBadRequest, "")
 		fmt.Println("[bounty] Error", err)
 	} else {
 		var bountyResponse []db.BountyResponse = h.GenerateBountyResponse(bounties)
@@ -142,7 +178,8 @@ func GetUserBountyCount(w http.ResponseWriter, r *http.Request) {
 	tabType := chi.URLParam(r, "tabType")
 
 	if personKey == "" || tabType == "" {
-		w.WriteHeader(http.StatusNotFound)
+		httpio.WriteError(w, r, http.StatusNotFound, "Person key and tab type are required")
+		return
 	}
 	bountyCount := db.DB.GetUserBountiesCount(personKey, tabType)
 
@@ -159,7 +196,7 @@ func GetBountyCount(w http.ResponseWriter, r *http.Request) {
 func (h *bountyHandler) GetPersonCreatedBounties(w http.ResponseWriter, r *http.Request) {
 	bounties, err := h.db.GetCreatedBounties(r)
 	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
+		httpio.WriteError(w, r, http.StatusBadRequest, "")
 		fmt.Println("[bounty] Error", err)
 	} else {
 		var bountyResponse []db.BountyResponse = h.GenerateBountyResponse(bounties)
@@ -171,7 +208,7 @@ func (h *bountyHandler) GetPersonCreatedBounties(w http.ResponseWriter, r *http.
 func (h *bountyHandler) GetPersonAssignedBounties(w http.ResponseWriter, r *http.Request) {
 	bounties, err := h.db.GetAssignedBounties(r)
 	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
+		httpio.WriteError(w, r, http.StatusBadRequest, "")
 		fmt.Println("[bounty] Error", err)
 	} else {
 		var bountyResponse []db.BountyResponse = h.GenerateBountyResponse(bounties)
@@ -190,14 +227,19 @@ func (h *bountyHandler) CreateOrEditBounty(w http.ResponseWriter, r *http.Reques
 
 	if err != nil {
 		fmt.Println("[bounty read]", err)
-		w.WriteHeader(http.StatusNotAcceptable)
+		httpio.WriteError(w, r, http.StatusNotAcceptable, "")
 		return
 	}
 
 	err = json.Unmarshal(body, &bounty)
 	if err != nil {
 		fmt.Println("[bounty]", err)
-		w.WriteHeader(http.StatusNotAcceptable)
+		httpio.WriteError(w, r, http.StatusNotAcceptable, "")
+		return
+	}
+
+	version, ok := bountyVersion(w, r, bounty.Version)
+	if !ok {
 		return
 	}
 
@@ -210,22 +252,22 @@ func (h *bountyHandler) CreateOrEditBounty(w http.ResponseWriter, r *http.Reques
 	//Check if bounty exists
 	bounty.Updated = &now
 
-	if bounty.Type == "" {
-		w.WriteHeader(http.StatusBadRequest)
-		json.NewEncoder(w).Encode("Type is a required field")
+	if !validatePayload(w, r, bounty) {
 		return
 	}
 
-	if bounty.Title == "" {
-		w.WriteHeader(http.StatusBadRequest)
-		json.NewEncoder(w).Encode("Title is a required field")
-		return
+	// the assignee of a bounty with terms is pending until they accept them
+	bounty.Terms = strings.TrimSpace(bounty.Terms)
+	if bounty.Assignee == "" {
+		bounty.Assignee = bounty.PendingAssignee
 	}
-
-	if bounty.Description == "" {
-		w.WriteHeader(http.StatusBadRequest)
-		json.NewEncoder(w).Encode("Description is a required field")
-		return
+	bounty.PendingAssignee = ""
+	if bounty.Terms != "" && bounty.Assignee != "" {
+		hash := db.BountyTermsHash(bounty.Terms)
+		if bounty.ID == 0 || !h.db.HasAcceptedBountyTerms(bounty.ID, bounty.Assignee, hash) {
+			bounty.PendingAssignee = bounty.Assignee
+			bounty.Assignee = ""
+		}
 	}
 
 	if bounty.Assignee != "" {
@@ -237,21 +279,14 @@ func (h *bountyHandler) CreateOrEditBounty(w http.ResponseWriter, r *http.Reques
 		bounty.Tribe = "None"
 	}
 
-	if !bounty.Show && bounty.ID != 0 {
-		h.db.UpdateBountyBoolColumn(bounty, "show")
-	}
-
-	if bounty.Title != "" && bounty.Assignee == "" {
-		h.db.UpdateBountyNullColumn(bounty, "assignee")
-	}
-
 	if bounty.ID == 0 && bounty.Created == 0 {
 		bounty.Created = time.Now().Unix()
 	}
 
+	dbBounty := db.NewBounty{}
 	if bounty.Title != "" && bounty.ID != 0 {
 		// get bounty from DB
-		dbBounty := h.db.GetBounty(bounty.ID)
+		dbBounty = h.db.GetBounty(bounty.ID)
 
 		// trying to update
 		// check if bounty belongs to user
@@ -261,52 +296,193 @@ func (h *bountyHandler) CreateOrEditBounty(w http.ResponseWriter, r *http.Reques
 				if !hasBountyRoles {
 					msg := "You don't have a=the right permission ton update bounty"
 					fmt.Println("[bounty]", msg)
-					w.WriteHeader(http.StatusBadRequest)
-					json.NewEncoder(w).Encode(msg)
+					httpio.WriteError(w, r, http.StatusBadRequest, msg)
 					return
 				}
 			} else {
 				msg := "Cannot edit another user's bounty"
 				fmt.Println("[bounty]", msg)
-				w.WriteHeader(http.StatusBadRequest)
-				json.NewEncoder(w).Encode(msg)
+				httpio.WriteError(w, r, http.StatusBadRequest, msg)
 				return
 			}
 		}
+
 	}
 
 	if bounty.PhaseUuid != "" {
 		phase, err := h.db.GetPhaseByUuid(bounty.PhaseUuid)
 		if err != nil {
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode("Phase Error")
+			httpio.WriteError(w, r, http.StatusBadRequest, "Phase Error")
 			return
 		}
 		if bounty.PhaseUuid != phase.Uuid {
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode("Not a valid phase")
+			httpio.WriteError(w, r, http.StatusBadRequest, "Not a valid phase")
+			return
+		}
+		if !h.withinFeatureBudget(w, r, bounty, phase.FeatureUuid) {
 			return
 		}
+		// a bounty can't be opened in, or moved to, a locked phase
+		if bounty.PhaseUuid != dbBounty.PhaseUuid && !withinPhaseGate(w, r, h.db, phase) {
+			return
+		}
+	}
+
+	if bounty.ID == 0 && !withinQuota(w, r, h.db, bounty.WorkspaceUuid, db.QuotaActiveBounties, 1) {
+		return
+	}
+
+	if h.db.IsBannedPubkey(pubKeyFromAuth) {
+		httpio.WriteError(w, r, http.StatusForbidden, "This account is banned")
+		return
+	}
+
+	claim, ok := claimRequest(w, r, DedupBounty, pubKeyFromAuth, body, func(id string) (interface{}, bool) {
+		bountyId, _ := strconv.ParseUint(id, 10, 32)
+		existing := h.db.GetBounty(uint(bountyId))
+		return existing, existing.ID != 0
+	})
+	if !ok {
+		return
 	}
 
-	b, err := h.db.CreateOrEditBounty(bounty)
+	// clearing the visibility and assignee has to roll back
+	// together with the edit if it fails. Only an edit that sent its
+	// version is checked against the current one, older clients keep
+	// overwriting the bounty
+	var b db.NewBounty
+	var current int
+	err = h.db.WithTx(func(tx db.Database) error {
+		if bounty.ID != 0 && version != 0 {
+			var err error
+			if current, err = tx.LockBountyVersion(bounty.ID); err != nil {
+				return err
+			}
+			if current != 0 && current != version {
+				return errVersionConflict
+			}
+		}
+
+		if !bounty.Show && bounty.ID != 0 {
+			if _, err := tx.UpdateBountyBoolColumn(bounty, "show"); err != nil {
+				return err
+			}
+		}
+
+		if bounty.Title != "" && bounty.Assignee == "" {
+			if _, err := tx.UpdateBountyNullColumn(bounty, "assignee"); err != nil {
+				return err
+			}
+		}
+		if dbBounty.PendingAssignee != "" && bounty.PendingAssignee == "" {
+			if _, err := tx.UpdateBountyNullColumn(bounty, "pending_assignee"); err != nil {
+				return err
+			}
+		}
+		if dbBounty.Terms != "" && bounty.Terms == "" {
+			if _, err := tx.UpdateBountyNullColumn(bounty, "terms"); err != nil {
+				return err
+			}
+		}
+		if dbBounty.ShowPaymentAmount && !bounty.ShowPaymentAmount {
+			if _, err := tx.UpdateBountyBoolColumn(bounty, "show_payment_amount"); err != nil {
+				return err
+			}
+		}
+
+		var err error
+		b, err = tx.CreateOrEditBounty(bounty)
+		return err
+	})
+	if errors.Is(err, errVersionConflict) {
+		claim.done("")
+		httpio.WriteErrorDetails(w, r, http.StatusConflict, "The bounty was changed since this version", map[string]interface{}{
+			"version": current,
+		})
+		return
+	}
 	if err != nil {
+		claim.done("")
 		fmt.Println("[bounty]", err)
-		w.WriteHeader(http.StatusBadRequest)
+		httpio.WriteError(w, r, http.StatusBadRequest, "")
 		return
 	}
+	claim.done(strconv.FormatUint(uint64(b.ID), 10))
+	b.Links = saveMentions(r.Context(), h.db, db.MentionBounty, strconv.FormatUint(uint64(b.ID), 10), b.Title, b.Description)
 
+	eventType := events.BountyUpdated
+	if bounty.ID == 0 {
+		eventType = events.BountyCreated
+	}
+	events.Publish(r.Context(), eventType, websocket.Topic(websocket.TopicBounty, b.ID), b)
+
+	if b.Version != 0 {
+		w.Header().Set("ETag", strconv.Quote(strconv.Itoa(b.Version)))
+	}
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(b)
 }
 
+var errVersionConflict = errors.New("version conflict")
+
+// errBountyPaid is returned when the payout of a bounty was claimed by
+// another payment
+var errBountyPaid = errors.New("the bounty is already paid")
+
+// errPaymentPending is returned when the relay was sent a payment but
+// didn't answer, the payment is recorded as pending until it is reconciled
+var errPaymentPending = errors.New("the outcome of the payment is unknown")
+
+// bountyVersion is the version an edit was made from, the If-Match header
+// when it is set, like "3" or W/"3", or the version of the body
+func bountyVersion(w http.ResponseWriter, r *http.Request, bodyVersion int) (int, bool) {
+	match := r.Header.Get("If-Match")
+	if match == "" {
+		return bodyVersion, true
+	}
+	version, err := strconv.Atoi(strings.Trim(strings.TrimPrefix(match, "W/"), `"`))
+	if err != nil || version < 1 {
+		httpio.WriteError(w, r, http.StatusBadRequest, "If-Match is not a bounty version")
+		return 0, false
+	}
+	return version, true
+}
+
+// withinFeatureBudget writes the error and returns false when the bounties
+// of a feature with an allocated budget would cost more than the allocation
+func (h *bountyHandler) withinFeatureBudget(w http.ResponseWriter, r *http.Request, bounty db.NewBounty, featureUuid string) bool {
+	feature := h.db.GetFeatureByUuid(featureUuid)
+	if feature.Budget == 0 {
+		return true
+	}
+
+	var used uint
+	for _, other := range h.db.GetFeatureBounties(featureUuid) {
+		if other.ID != bounty.ID || bounty.ID == 0 {
+			used += other.Price
+		}
+	}
+	if used+bounty.Price > feature.Budget {
+		available := 0
+		if feature.Budget > used {
+			available = int(feature.Budget - used)
+		}
+		httpio.WriteErrorDetails(w, r, http.StatusBadRequest, "The bounty exceeds the feature budget", map[string]interface{}{
+			"budget":    feature.Budget,
+			"available": available,
+		})
+		return false
+	}
+	return true
+}
+
 func (h *bountyHandler) DeleteBounty(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
 
 	if pubKeyFromAuth == "" {
 		fmt.Println("[bounty] no pubkey from auth")
-		w.WriteHeader(http.StatusUnauthorized)
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
 		return
 	}
 
@@ -315,12 +491,12 @@ func (h *bountyHandler) DeleteBounty(w http.ResponseWriter, r *http.Request) {
 
 	if pubkey == "" {
 		fmt.Println("[bounty] no pubkey from route")
-		w.WriteHeader(http.StatusUnauthorized)
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
 		return
 	}
 	if created == "" {
 		fmt.Println("[bounty] no created timestamp from route")
-		w.WriteHeader(http.StatusUnauthorized)
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
 		return
 	}
 
@@ -329,25 +505,23 @@ func (h *bountyHandler) DeleteBounty(w http.ResponseWriter, r *http.Request) {
 	createdBounty, err := h.db.GetBountyByCreated(createdUint)
 	if err != nil {
 		fmt.Println("[bounty] failed to delete bounty", err.Error())
-		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode("failed to delete bounty")
+		httpio.WriteError(w, r, http.StatusInternalServerError, "failed to delete bounty")
 		return
 	}
 
 	if createdBounty.ID == 0 {
 		fmt.Println("[bounty] failed to delete bounty")
-		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode("failed to delete bounty")
+		httpio.WriteError(w, r, http.StatusInternalServerError, "failed to delete bounty")
 		return
 	}
 
 	b, err := h.db.DeleteBounty(pubkey, created)
 	if err != nil {
 		fmt.Println("[bounty] failed to delete bounty", err.Error())
-		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode("failed to delete bounty")
+		httpio.WriteError(w, r, http.StatusInternalServerError, "failed to delete bounty")
 		return
 	}
+	events.Publish(ctx, events.BountyDeleted, websocket.Topic(websocket.TopicBounty, createdBounty.ID), b)
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(b)
 }
@@ -358,6 +532,15 @@ func UpdatePaymentStatus(w http.ResponseWriter, r *http.Request) {
 
 	bounty, _ := db.DB.GetBountyByCreated(uint(created))
 	if bounty.ID != 0 && bounty.Created == int64(created) {
+		// marking paid is a payment to the workspace, it may require an
+		// approved proof first
+		if !bounty.Paid && bounty.WorkspaceUuid != "" {
+			workspace := db.DB.GetWorkspaceByUuid(bounty.WorkspaceUuid)
+			if unmet := unmetPaymentConditions(db.DB, workspace, bounty); len(unmet) > 0 {
+				httpio.WriteErrorCode(w, r, http.StatusUnprocessableEntity, httpio.CodeUnprocessableEntity, "The workspace requires an approved proof before the bounty is paid", map[string]interface{}{"unmet": unmet})
+				return
+			}
+		}
 		bounty.Paid = !bounty.Paid
 		now := time.Now()
 		// if setting paid as true by mark as paid
@@ -376,113 +559,177 @@ func UpdatePaymentStatus(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(bounty)
 }
 
-func UpdateCompletedStatus(w http.ResponseWriter, r *http.Request) {
+// UpdateCompletedStatus accepts the work on a bounty as completed, once it
+// meets the definition of done of its workspace and its proof has the
+// approvals the workspace requires. When the workspace has auto-pay on, the
+// bounty is paid right away
+func (h *bountyHandler) UpdateCompletedStatus(w http.ResponseWriter, r *http.Request) {
+	pubKeyFromAuth, _ := r.Context().Value(auth.ContextKey).(string)
 	createdParam := chi.URLParam(r, "created")
 	created, _ := strconv.ParseUint(createdParam, 10, 32)
 
-	bounty, _ := db.DB.GetBountyByCreated(uint(created))
+	bounty, _ := h.db.GetBountyByCreated(uint(created))
 	if bounty.ID != 0 && bounty.Created == int64(created) {
 		now := time.Now()
+		accepted := false
+		workspace := db.Workspace{}
 		// set bounty as completed
 		if !bounty.Paid && !bounty.Completed {
+			if bounty.WorkspaceUuid != "" {
+				workspace = h.db.GetWorkspaceByUuid(bounty.WorkspaceUuid)
+			}
+			unmet := unmetDoneConditions(r.Context(), workspace, bounty)
+			unmet = append(unmet, unmetReviewConditions(h.db, workspace, bounty)...)
+			if len(unmet) > 0 {
+				httpio.WriteErrorCode(w, r, http.StatusUnprocessableEntity, httpio.CodeUnprocessableEntity, "The bounty doesn't meet the definition of done of its workspace", map[string]interface{}{"unmet": unmet})
+				return
+			}
 			bounty.CompletionDate = &now
 			bounty.Completed = true
+			accepted = true
+		}
+		h.db.UpdateBountyCompleted(bounty)
+
+		if accepted {
+			bounty = h.autoPayBounty(r.Context(), workspace, bounty, pubKeyFromAuth)
 		}
-		db.DB.UpdateBountyCompleted(bounty)
 	}
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(bounty)
 }
 
+// autoPayBounty pays a bounty just accepted as completed when its workspace
+// has auto-pay on. It takes the same checks as a manual payment: the pay
+// bounty role and the workspace budget, plus the auto-pay cap of the
+// workspace. Otherwise the bounty waits for a manual payment
+func (h *bountyHandler) autoPayBounty(ctx context.Context, workspace db.Workspace, bounty db.NewBounty, pubKeyFromAuth string) db.NewBounty {
+	if !autoPayable(bounty, pubKeyFromAuth) {
+		return bounty
+	}
+	paidBounty, _ := h.payWorkspaceAutoPay(ctx, workspace, bounty, pubKeyFromAuth, false)
+	return paidBounty
+}
+
+// payAutoPay is autoPayBounty with the error of the payment. While the
+// breaker of the relay is open the payment is queued for later, unless
+// queued is set because this already is the queued attempt
+func (h *bountyHandler) payAutoPay(ctx context.Context, bounty db.NewBounty, pubKeyFromAuth string, queued bool) (db.NewBounty, error) {
+	if !autoPayable(bounty, pubKeyFromAuth) {
+		return bounty, nil
+	}
+	return h.payWorkspaceAutoPay(ctx, h.db.GetWorkspaceByUuid(bounty.WorkspaceUuid), bounty, pubKeyFromAuth, queued)
+}
+
+func autoPayable(bounty db.NewBounty, pubKeyFromAuth string) bool {
+	return bounty.WorkspaceUuid != "" && bounty.Assignee != "" && bounty.Price != 0 && pubKeyFromAuth != ""
+}
+
+func (h *bountyHandler) payWorkspaceAutoPay(ctx context.Context, workspace db.Workspace, bounty db.NewBounty, pubKeyFromAuth string, queued bool) (db.NewBounty, error) {
+	if !workspace.AutoPay {
+		return bounty, nil
+	}
+	if !h.userHasAccess(pubKeyFromAuth, workspace.Uuid, db.PayBounty) {
+		log.Printf("[bounty] auto-pay of bounty %d skipped, %s can't pay bounties", bounty.ID, pubKeyFromAuth)
+		return bounty, nil
+	}
+	if workspace.AutoPayCap > 0 && bounty.Price > workspace.AutoPayCap {
+		log.Printf("[bounty] auto-pay of bounty %d skipped, %d sats is over the cap of %d", bounty.ID, bounty.Price, workspace.AutoPayCap)
+		return bounty, nil
+	}
+	if len(unmetPaymentConditions(h.db, workspace, bounty)) > 0 {
+		log.Printf("[bounty] auto-pay of bounty %d skipped, it has no approved proof", bounty.ID)
+		return bounty, nil
+	}
+
+	h.m.Lock()
+	defer h.m.Unlock()
+
+	// another accept, the auto-pay job or a manual payment may have paid the
+	// bounty since it was loaded. The claim of keysendBountyPayment is what
+	// keeps it from being paid twice, this only skips the work
+	current := h.db.GetBounty(bounty.ID)
+	if current.ID != bounty.ID {
+		return bounty, nil
+	}
+	if current.Paid {
+		log.Printf("[bounty] auto-pay of bounty %d skipped, it is already paid", bounty.ID)
+		return current, nil
+	}
+	bounty = current
+
+	if h.db.GetWorkspaceBudget(workspace.Uuid).TotalBudget < bounty.Price {
+		log.Printf("[bounty] auto-pay of bounty %d skipped, the workspace budget is not enough", bounty.ID)
+		return bounty, nil
+	}
+
+	paidBounty, paid, err := h.keysendBountyPayment(ctx, bounty, pubKeyFromAuth, true, 0)
+	if errors.Is(err, errBountyPaid) {
+		log.Printf("[bounty] auto-pay of bounty %d skipped, it is already paid", bounty.ID)
+		return bounty, nil
+	}
+	if errors.Is(err, db.ErrBudgetNotEnough) {
+		log.Printf("[bounty] auto-pay of bounty %d skipped, the workspace budget is not enough", bounty.ID)
+		return bounty, nil
+	}
+	// a payment the relay didn't answer may still settle, it is in flight
+	// until an admin reconciles it and must not be paid by hand meanwhile
+	if errors.Is(err, errPaymentPending) {
+		log.Printf("[bounty] auto-pay of bounty %d is pending until it is reconciled", bounty.ID)
+		return bounty, err
+	}
+	// the relay was not called, the payment can't have gone out
+	if errors.Is(err, upstream.ErrCircuitOpen) && !queued {
+		h.queueAutoPay(bounty, pubKeyFromAuth, err)
+		return bounty, err
+	}
+	if err != nil || !paid {
+		log.Printf("[bounty] auto-pay of bounty %d failed, it waits for a manual payment", bounty.ID)
+		return bounty, err
+	}
+	return paidBounty, nil
+}
+
+// queueAutoPay retries the auto-pay of a bounty in a job once the breaker
+// of the relay lets calls through again. The relay was not called, so the
+// payment can't have gone out
+func (h *bountyHandler) queueAutoPay(bounty db.NewBounty, pubKeyFromAuth string, err error) {
+	runAt := time.Now()
+	upstreamErr := &upstream.Error{}
+	if errors.As(err, &upstreamErr) {
+		runAt = runAt.Add(upstreamErr.RetryAfter)
+	}
+	_, queueErr := jobs.Default.Schedule(AutoPayJob, map[string]interface{}{
+		"bounty_id": bounty.ID,
+		"pubkey":    pubKeyFromAuth,
+	}, runAt)
+	if queueErr != nil {
+		log.Printf("[bounty] auto-pay of bounty %d could not be queued: %s", bounty.ID, queueErr)
+		return
+	}
+	log.Printf("[bounty] relay is unavailable, auto-pay of bounty %d is queued", bounty.ID)
+}
+
+// RegisterAutoPay runs the auto-pay of the bounties that were accepted while
+// the relay was unavailable. The job fails, and is retried with the backoff
+// of the queue, while the breaker of the relay is still open
+func (h *bountyHandler) RegisterAutoPay(q *jobs.Queue) {
+	q.Register(AutoPayJob, func(ctx context.Context, job db.Job) error {
+		id, _ := job.Payload["bounty_id"].(float64)
+		pubkey, _ := job.Payload["pubkey"].(string)
+		bounty := h.db.GetBounty(uint(id))
+		if bounty.ID == 0 || bounty.Paid || !bounty.Completed {
+			return nil
+		}
+		_, err := h.payAutoPay(ctx, bounty, pubkey, true)
+		if errors.Is(err, upstream.ErrCircuitOpen) {
+			return err
+		}
+		return nil
+	})
+}
+
 func (h *bountyHandler) GenerateBountyResponse(bounties []db.NewBounty) []db.BountyResponse {
-	var bountyResponse []db.BountyResponse
-
-	for i := 0; i < len(bounties); i++ {
-		bounty := bounties[i]
-
-		owner := h.db.GetPersonByPubkey(bounty.OwnerID)
-		assignee := h.db.GetPersonByPubkey(bounty.Assignee)
-		workspace := h.db.GetWorkspaceByUuid(bounty.WorkspaceUuid)
-
-		b := db.BountyResponse{
-			Bounty: db.NewBounty{
-				ID:                      bounty.ID,
-				OwnerID:                 bounty.OwnerID,
-				Paid:                    bounty.Paid,
-				Show:                    bounty.Show,
-				Type:                    bounty.Type,
-				Award:                   bounty.Award,
-				AssignedHours:           bounty.AssignedHours,
-				BountyExpires:           bounty.BountyExpires,
-				CommitmentFee:           bounty.CommitmentFee,
-				Price:                   bounty.Price,
-				Title:                   bounty.Title,
-				Tribe:                   bounty.Tribe,
-				Created:                 bounty.Created,
-				Assignee:                bounty.Assignee,
-				TicketUrl:               bounty.TicketUrl,
-				Description:             bounty.Description,
-				WantedType:              bounty.WantedType,
-				Deliverables:            bounty.Deliverables,
-				GithubDescription:       bounty.GithubDescription,
-				OneSentenceSummary:      bounty.OneSentenceSummary,
-				EstimatedSessionLength:  bounty.EstimatedSessionLength,
-				EstimatedCompletionDate: bounty.EstimatedCompletionDate,
-				OrgUuid:                 bounty.WorkspaceUuid,
-				WorkspaceUuid:           bounty.WorkspaceUuid,
-				Updated:                 bounty.Updated,
-				CodingLanguages:         bounty.CodingLanguages,
-				Completed:               bounty.Completed,
-			},
-			Assignee: db.Person{
-				ID:               assignee.ID,
-				Uuid:             assignee.Uuid,
-				OwnerPubKey:      assignee.OwnerPubKey,
-				OwnerAlias:       assignee.OwnerAlias,
-				UniqueName:       assignee.UniqueName,
-				Description:      assignee.Description,
-				Tags:             assignee.Tags,
-				Img:              assignee.Img,
-				Created:          assignee.Created,
-				Updated:          assignee.Updated,
-				LastLogin:        assignee.LastLogin,
-				OwnerRouteHint:   assignee.OwnerRouteHint,
-				OwnerContactKey:  assignee.OwnerContactKey,
-				PriceToMeet:      assignee.PriceToMeet,
-				TwitterConfirmed: assignee.TwitterConfirmed,
-			},
-			Owner: db.Person{
-				ID:               owner.ID,
-				Uuid:             owner.Uuid,
-				OwnerPubKey:      owner.OwnerPubKey,
-				OwnerAlias:       owner.OwnerAlias,
-				UniqueName:       owner.UniqueName,
-				Description:      owner.Description,
-				Tags:             owner.Tags,
-				Img:              owner.Img,
-				Created:          owner.Created,
-				Updated:          owner.Updated,
-				LastLogin:        owner.LastLogin,
-				OwnerRouteHint:   owner.OwnerRouteHint,
-				OwnerContactKey:  owner.OwnerContactKey,
-				PriceToMeet:      owner.PriceToMeet,
-				TwitterConfirmed: owner.TwitterConfirmed,
-			},
-			Organization: db.WorkspaceShort{
-				Name: workspace.Name,
-				Uuid: workspace.Uuid,
-				Img:  workspace.Img,
-			},
-			Workspace: db.WorkspaceShort{
-				Name: workspace.Name,
-				Uuid: workspace.Uuid,
-				Img:  workspace.Img,
-			},
-		}
-		bountyResponse = append(bountyResponse, b)
-	}
-
-	return bountyResponse
+	return h.db.GetBountyResponses(bounties)
 }
 
 func (h *bountyHandler) MakeBountyPayment(w http.ResponseWriter, r *http.Request) {
@@ -495,14 +742,14 @@ func (h *bountyHandler) MakeBountyPayment(w http.ResponseWriter, r *http.Request
 	id, err := utils.ConvertStringToUint(idParam)
 	if err != nil {
 		fmt.Println("[bounty] could not parse id")
-		w.WriteHeader(http.StatusForbidden)
+		httpio.WriteError(w, r, http.StatusForbidden, "")
 		h.m.Unlock()
 		return
 	}
 
 	if pubKeyFromAuth == "" {
 		fmt.Println("[bounty] no pubkey from auth")
-		w.WriteHeader(http.StatusUnauthorized)
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
 		h.m.Unlock()
 		return
 	}
@@ -510,20 +757,15 @@ func (h *bountyHandler) MakeBountyPayment(w http.ResponseWriter, r *http.Request
 	bounty := h.db.GetBounty(id)
 	amount := bounty.Price
 
-	if bounty.WorkspaceUuid == "" && bounty.OrgUuid != "" {
-		bounty.WorkspaceUuid = bounty.OrgUuid
-	}
-
 	if bounty.ID != id {
-		w.WriteHeader(http.StatusNotFound)
+		httpio.WriteError(w, r, http.StatusNotFound, "")
 		h.m.Unlock()
 		return
 	}
 
 	// check if the bounty has been paid already to avoid double payment
 	if bounty.Paid {
-		w.WriteHeader(http.StatusMethodNotAllowed)
-		json.NewEncoder(w).Encode("Bounty has already been paid")
+		httpio.WriteError(w, r, http.StatusMethodNotAllowed, "Bounty has already been paid")
 		h.m.Unlock()
 		return
 	}
@@ -532,8 +774,7 @@ func (h *bountyHandler) MakeBountyPayment(w http.ResponseWriter, r *http.Request
 	// or has a pay bounty role
 	hasRole := h.userHasAccess(pubKeyFromAuth, bounty.WorkspaceUuid, db.PayBounty)
 	if !hasRole {
-		w.WriteHeader(http.StatusUnauthorized)
-		json.NewEncoder(w).Encode("You don't have appropriate permissions to pay bounties")
+		httpio.WriteError(w, r, http.StatusUnauthorized, "You don't have appropriate permissions to pay bounties")
 		h.m.Unlock()
 		return
 	}
@@ -542,8 +783,14 @@ func (h *bountyHandler) MakeBountyPayment(w http.ResponseWriter, r *http.Request
 	// is greater than the amount
 	orgBudget := h.db.GetWorkspaceBudget(bounty.WorkspaceUuid)
 	if orgBudget.TotalBudget < amount {
-		w.WriteHeader(http.StatusForbidden)
-		json.NewEncoder(w).Encode("workspace budget is not enough to pay the amount")
+		httpio.WriteError(w, r, http.StatusForbidden, "workspace budget is not enough to pay the amount")
+		h.m.Unlock()
+		return
+	}
+
+	// the workspace may require an approved proof before any payment
+	if unmet := unmetPaymentConditions(h.db, h.db.GetWorkspaceByUuid(bounty.WorkspaceUuid), bounty); len(unmet) > 0 {
+		httpio.WriteErrorCode(w, r, http.StatusUnprocessableEntity, httpio.CodeUnprocessableEntity, "The workspace requires an approved proof before the bounty is paid", map[string]interface{}{"unmet": unmet})
 		h.m.Unlock()
 		return
 	}
@@ -553,7 +800,7 @@ func (h *bountyHandler) MakeBountyPayment(w http.ResponseWriter, r *http.Request
 	r.Body.Close()
 	if err != nil {
 		fmt.Println("[read body]", err)
-		w.WriteHeader(http.StatusNotAcceptable)
+		httpio.WriteError(w, r, http.StatusNotAcceptable, "")
 		h.m.Unlock()
 		return
 	}
@@ -561,94 +808,201 @@ func (h *bountyHandler) MakeBountyPayment(w http.ResponseWriter, r *http.Request
 	err = json.Unmarshal(body, &request)
 	if err != nil {
 		fmt.Println("[bounty]", err)
-		w.WriteHeader(http.StatusNotAcceptable)
+		httpio.WriteError(w, r, http.StatusNotAcceptable, "")
 		h.m.Unlock()
 		return
 	}
 
+	var trackedMinutes uint
+	if request.IncludeTrackedTime {
+		trackedMinutes = h.db.GetBountyTrackedMinutes(bounty.ID, bounty.Assignee)
+	}
+
+	_, paid, err := h.keysendBountyPayment(ctx, bounty, pubKeyFromAuth, false, trackedMinutes)
+	if errors.Is(err, errBountyPaid) {
+		httpio.WriteError(w, r, http.StatusMethodNotAllowed, "Bounty has already been paid")
+		h.m.Unlock()
+		return
+	}
+	if errors.Is(err, db.ErrBudgetNotEnough) {
+		httpio.WriteError(w, r, http.StatusForbidden, "workspace budget is not enough to pay the amount")
+		h.m.Unlock()
+		return
+	}
+	pending := errors.Is(err, errPaymentPending)
+	if err != nil && !pending {
+		upstream.WriteError(w, r, err, "The payment could not be sent to the relay")
+		h.m.Unlock()
+		return
+	}
+
+	msg := make(map[string]interface{})
+	switch {
+	case pending:
+		msg["msg"] = "keysend_pending"
+	case paid:
+		msg["msg"] = "keysend_success"
+	default:
+		msg["msg"] = "keysend_error"
+	}
+	msg["invoice"] = ""
+
+	socket, err := h.getSocketConnections(request.Websocket_token)
+	if err == nil {
+		socket.Writer.WriteJSON(msg)
+	}
+
+	// the relay didn't answer, the payment is not known to have failed
+	if pending {
+		w.WriteHeader(http.StatusAccepted)
+	}
+
+	h.m.Unlock()
+}
+
+// relayKeysend sends an amount to a person through the relay, it returns
+// the payment hash and preimage the relay settled, or false when it refused
+// the payment. A payment the node took but has not settled returns its
+// payment hash and upstream.ErrPaymentPending
+func relayKeysend(ctx context.Context, httpClient HttpClient, amount uint, person db.Person) (string, string, bool, error) {
 	url := fmt.Sprintf("%s/payment", config.RelayUrl)
 
-	assignee := h.db.GetPersonByPubkey(bounty.Assignee)
-	bodyData := utils.BuildKeysendBodyData(amount, assignee.OwnerPubKey, assignee.OwnerRouteHint)
+	ctx, span := tracing.Start(ctx, "relay.keysend")
+	defer span.End()
+
+	bodyData := utils.BuildKeysendBodyData(amount, person.OwnerPubKey, person.OwnerRouteHint)
 
 	jsonBody := []byte(bodyData)
 
-	req, _ := http.NewRequest(http.MethodPost, url, bytes.NewBuffer(jsonBody))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return "", "", false, err
+	}
 	req.Header.Set("x-user-token", config.RelayAuthKey)
 	req.Header.Set("Content-Type", "application/json")
-	log.Printf("[bounty] Making Bounty Payment: amount: %d, pubkey: %s, route_hint: %s", amount, assignee.OwnerPubKey, assignee.OwnerRouteHint)
-	res, err := h.httpClient.Do(req)
+	log.Printf("[relay] Making Keysend Payment: amount: %d, pubkey: %s, route_hint: %s", amount, person.OwnerPubKey, person.OwnerRouteHint)
+	res, err := httpClient.Do(req)
 
 	if err != nil {
-		log.Printf("[bounty] Request Failed: %s", err)
-		h.m.Unlock()
-		return
+		log.Printf("[relay] Request Failed: %s", err)
+		span.RecordError(err)
+		return "", "", false, err
 	}
+	span.SetAttributes(attribute.Int("http.status_code", res.StatusCode))
 
 	defer res.Body.Close()
-	body, err = io.ReadAll(res.Body)
+	body, err := io.ReadAll(res.Body)
 	if err != nil {
 		fmt.Println("[read body]", err)
-		w.WriteHeader(http.StatusNotAcceptable)
-		h.m.Unlock()
-		return
+		return "", "", false, err
 	}
 
-	msg := make(map[string]interface{})
+	if res.StatusCode != http.StatusOK && res.StatusCode != http.StatusAccepted {
+		return "", "", false, nil
+	}
 
-	// payment is successful add to payment history
-	// and reduce workspaces budget
-	if res.StatusCode == 200 {
-		// Unmarshal result
-		keysendRes := db.KeysendSuccess{}
-		err = json.Unmarshal(body, &keysendRes)
+	keysendRes := db.KeysendSuccess{}
+	if err := json.Unmarshal(body, &keysendRes); err != nil {
+		fmt.Println("[Unmarshal]", err)
+		return "", "", false, err
+	}
+	paymentHash, _ := keysendRes.Response["payment_hash"].(string)
+	preimage, _ := keysendRes.Response["preimage"].(string)
+	if res.StatusCode == http.StatusAccepted {
+		return paymentHash, "", false, upstream.ErrPaymentPending
+	}
+	return paymentHash, preimage, true, nil
+}
+
+// keysendBountyPayment pays the price of a bounty to its assignee through
+// the relay. The bounty is claimed in the database first, errBountyPaid is
+// returned when another payment holds the claim. The payment is then
+// recorded as pending and taken from the workspace budget before it is
+// sent, db.ErrBudgetNotEnough is returned when the budget can't cover it.
+// A successful payment settles, with the minutes the assignee tracked when
+// they are given. It returns the paid bounty or false when the relay
+// refused the payment, the payment then fails and the claim is released.
+// When the relay was called but didn't answer the payment may still
+// settle, it stays pending and the bounty claimed until an admin
+// reconciles it, errPaymentPending is returned
+func (h *bountyHandler) keysendBountyPayment(ctx context.Context, bounty db.NewBounty, senderPubKey string, autoInitiated bool, trackedMinutes uint) (db.NewBounty, bool, error) {
+	amount := bounty.Price
+
+	ctx, span := tracing.Start(ctx, "bounty.keysend_payment")
+	span.SetAttributes(attribute.Int64("bounty.id", int64(bounty.ID)), attribute.Int64("bounty.amount", int64(amount)))
+	defer span.End()
 
+	claimed, err := h.db.ClaimBountyPayment(bounty.ID)
+	if err != nil {
+		span.RecordError(err)
+		return bounty, false, err
+	}
+	if !claimed {
+		return bounty, false, errBountyPaid
+	}
+
+	assignee := h.db.GetPersonByPubkey(bounty.Assignee)
+	now := time.Now()
+	paymentHistory, err := h.db.CreatePendingPayment(db.NewPaymentHistory{
+		Amount:         amount,
+		SenderPubKey:   senderPubKey,
+		ReceiverPubKey: assignee.OwnerPubKey,
+		WorkspaceUuid:  bounty.WorkspaceUuid,
+		BountyId:       bounty.ID,
+		Created:        &now,
+		Updated:        &now,
+		PaymentType:    db.Payment,
+		AutoInitiated:  autoInitiated,
+		TrackedMinutes: trackedMinutes,
+	})
+	if err != nil {
+		span.RecordError(err)
+		h.releaseBountyPayment(bounty.ID)
+		return bounty, false, err
+	}
+
+	paymentHash, preimage, paid, err := relayKeysend(ctx, h.httpClient, amount, assignee)
+	if err != nil && upstream.Attempted(err) {
+		span.RecordError(err)
+		log.Printf("[bounty] the outcome of the payout of bounty %d is unknown, it is pending: %s", bounty.ID, err)
+		if paymentHash != "" {
+			if err := h.db.SetPaymentHash(paymentHistory.ID, paymentHash); err != nil {
+				log.Printf("[bounty] could not record the payment hash of the pending payout of bounty %d: %s", bounty.ID, err)
+			}
+		}
+		return bounty, false, errPaymentPending
+	}
+	if err != nil || !paid {
 		if err != nil {
-			fmt.Println("[Unmarshal]", err)
-			w.WriteHeader(http.StatusNotAcceptable)
-			h.m.Unlock()
-			return
+			span.RecordError(err)
 		}
+		// failing the payment gives its amount back to the budget and
+		// releases the claim of the bounty
+		failPayment(h.db, paymentHistory.ID)
+		return bounty, false, err
+	}
 
-		now := time.Now()
+	if _, err := h.db.ReconcilePayment(paymentHistory.ID, true, paymentHash); err != nil {
+		log.Printf("[bounty] keysend for bounty %d succeeded but the payment could not be recorded: %s", bounty.ID, err)
+	} else if preimage != "" {
+		savePaymentPreimage(h.db, paymentHistory.ID, paymentHash, preimage)
+	}
 
-		paymentHistory := db.NewPaymentHistory{
-			Amount:         amount,
-			SenderPubKey:   pubKeyFromAuth,
-			ReceiverPubKey: assignee.OwnerPubKey,
-			WorkspaceUuid:  bounty.WorkspaceUuid,
-			BountyId:       id,
-			Created:        &now,
-			Updated:        &now,
-			Status:         true,
-			PaymentType:    "payment",
-		}
-
-		bounty.Paid = true
-		bounty.PaidDate = &now
-		bounty.Completed = true
+	bounty.Paid = true
+	bounty.PaidDate = &now
+	bounty.Completed = true
+	if bounty.CompletionDate == nil {
 		bounty.CompletionDate = &now
+	}
 
-		h.db.ProcessBountyPayment(paymentHistory, bounty)
-
-		msg["msg"] = "keysend_success"
-		msg["invoice"] = ""
-
-		socket, err := h.getSocketConnections(request.Websocket_token)
-		if err == nil {
-			socket.Conn.WriteJSON(msg)
-		}
-	} else {
-		msg["msg"] = "keysend_error"
-		msg["invoice"] = ""
+	events.Publish(ctx, events.PaymentSettled, websocket.Topic(websocket.TopicBounty, bounty.ID), bounty)
+	return bounty, true, nil
+}
 
-		socket, err := h.getSocketConnections(request.Websocket_token)
-		if err == nil {
-			socket.Conn.WriteJSON(msg)
-		}
+func (h *bountyHandler) releaseBountyPayment(id uint) {
+	if err := h.db.ReleaseBountyPayment(id); err != nil {
+		log.Printf("[bounty] the claim of the payout of bounty %d could not be released: %s", id, err)
 	}
-
-	h.m.Unlock()
 }
 
 func (h *bountyHandler) BountyBudgetWithdraw(w http.ResponseWriter, r *http.Request) {
@@ -659,7 +1013,7 @@ func (h *bountyHandler) BountyBudgetWithdraw(w http.ResponseWriter, r *http.Requ
 
 	if pubKeyFromAuth == "" {
 		fmt.Println("[bounty] no pubkey from auth")
-		w.WriteHeader(http.StatusUnauthorized)
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
 		h.m.Unlock()
 		return
 	}
@@ -669,14 +1023,14 @@ func (h *bountyHandler) BountyBudgetWithdraw(w http.ResponseWriter, r *http.Requ
 	r.Body.Close()
 
 	if err != nil {
-		w.WriteHeader(http.StatusNotAcceptable)
+		httpio.WriteError(w, r, http.StatusNotAcceptable, "")
 		h.m.Unlock()
 		return
 	}
 
 	err = json.Unmarshal(body, &request)
 	if err != nil {
-		w.WriteHeader(http.StatusNotAcceptable)
+		httpio.WriteError(w, r, http.StatusNotAcceptable, "")
 		h.m.Unlock()
 		return
 	}
@@ -687,9 +1041,7 @@ func (h *bountyHandler) BountyBudgetWithdraw(w http.ResponseWriter, r *http.Requ
 	// or has a withdraw bounty budget role
 	hasRole := h.userHasAccess(pubKeyFromAuth, request.OrgUuid, db.WithdrawBudget)
 	if !hasRole {
-		w.WriteHeader(http.StatusUnauthorized)
-		errMsg := formatPayError("You don't have appropriate permissions to withdraw bounty budget")
-		json.NewEncoder(w).Encode(errMsg)
+		httpio.WriteError(w, r, http.StatusUnauthorized, "You don't have appropriate permissions to withdraw bounty budget")
 		h.m.Unlock()
 		return
 	}
@@ -700,26 +1052,13 @@ func (h *bountyHandler) BountyBudgetWithdraw(w http.ResponseWriter, r *http.Requ
 		// is greater than the amount
 		orgBudget := h.db.GetWorkspaceBudget(request.OrgUuid)
 		if amount > orgBudget.TotalBudget {
-			w.WriteHeader(http.StatusForbidden)
-			errMsg := formatPayError("Workspace budget is not enough to withdraw the amount")
-			json.NewEncoder(w).Encode(errMsg)
+			httpio.WriteError(w, r, http.StatusForbidden, "Workspace budget is not enough to withdraw the amount")
 			h.m.Unlock()
 			return
 		}
-		paymentSuccess, paymentError := h.PayLightningInvoice(request.PaymentRequest)
-		if paymentSuccess.Success {
-			// withdraw amount from workspace budget
-			h.db.WithdrawBudget(pubKeyFromAuth, request.OrgUuid, amount)
-			w.WriteHeader(http.StatusOK)
-			json.NewEncoder(w).Encode(paymentSuccess)
-		} else {
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(paymentError)
-		}
+		h.withdrawBudget(w, r, pubKeyFromAuth, request.OrgUuid, request.PaymentRequest, amount)
 	} else {
-		w.WriteHeader(http.StatusForbidden)
-		errMsg := formatPayError("Could not pay lightning invoice")
-		json.NewEncoder(w).Encode(errMsg)
+		httpio.WriteError(w, r, http.StatusForbidden, "Could not pay lightning invoice")
 	}
 
 	h.m.Unlock()
@@ -734,7 +1073,7 @@ func (h *bountyHandler) NewBountyBudgetWithdraw(w http.ResponseWriter, r *http.R
 
 	if pubKeyFromAuth == "" {
 		fmt.Println("[bounty] no pubkey from auth")
-		w.WriteHeader(http.StatusUnauthorized)
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
 		h.m.Unlock()
 		return
 	}
@@ -744,14 +1083,14 @@ func (h *bountyHandler) NewBountyBudgetWithdraw(w http.ResponseWriter, r *http.R
 	r.Body.Close()
 
 	if err != nil {
-		w.WriteHeader(http.StatusNotAcceptable)
+		httpio.WriteError(w, r, http.StatusNotAcceptable, "")
 		h.m.Unlock()
 		return
 	}
 
 	err = json.Unmarshal(body, &request)
 	if err != nil {
-		w.WriteHeader(http.StatusNotAcceptable)
+		httpio.WriteError(w, r, http.StatusNotAcceptable, "")
 		h.m.Unlock()
 		return
 	}
@@ -760,9 +1099,7 @@ func (h *bountyHandler) NewBountyBudgetWithdraw(w http.ResponseWriter, r *http.R
 	// or has a withdraw bounty budget role
 	hasRole := h.userHasAccess(pubKeyFromAuth, request.WorkspaceUuid, db.WithdrawBudget)
 	if !hasRole {
-		w.WriteHeader(http.StatusUnauthorized)
-		errMsg := formatPayError("You don't have appropriate permissions to withdraw bounty budget")
-		json.NewEncoder(w).Encode(errMsg)
+		httpio.WriteError(w, r, http.StatusUnauthorized, "You don't have appropriate permissions to withdraw bounty budget")
 		h.m.Unlock()
 		return
 	}
@@ -774,38 +1111,18 @@ func (h *bountyHandler) NewBountyBudgetWithdraw(w http.ResponseWriter, r *http.R
 		// is greater than the amount
 		orgBudget := h.db.GetWorkspaceBudget(request.WorkspaceUuid)
 		if amount > orgBudget.TotalBudget {
-			w.WriteHeader(http.StatusForbidden)
-			errMsg := formatPayError("Workspace budget is not enough to withdraw the amount")
-			json.NewEncoder(w).Encode(errMsg)
+			httpio.WriteError(w, r, http.StatusForbidden, "Workspace budget is not enough to withdraw the amount")
 			h.m.Unlock()
 			return
 		}
-		paymentSuccess, paymentError := h.PayLightningInvoice(request.PaymentRequest)
-		if paymentSuccess.Success {
-			// withdraw amount from workspace budget
-			h.db.WithdrawBudget(pubKeyFromAuth, request.WorkspaceUuid, amount)
-			w.WriteHeader(http.StatusOK)
-			json.NewEncoder(w).Encode(paymentSuccess)
-		} else {
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(paymentError)
-		}
+		h.withdrawBudget(w, r, pubKeyFromAuth, request.WorkspaceUuid, request.PaymentRequest, amount)
 	} else {
-		w.WriteHeader(http.StatusForbidden)
-		errMsg := formatPayError("Could not pay lightning invoice")
-		json.NewEncoder(w).Encode(errMsg)
+		httpio.WriteError(w, r, http.StatusForbidden, "Could not pay lightning invoice")
 	}
 
 	h.m.Unlock()
 }
 
-func formatPayError(errorMsg string) db.InvoicePayError {
-	return db.InvoicePayError{
-		Success: false,
-		Error:   errorMsg,
-	}
-}
-
 func (h *bountyHandler) GetLightningInvoice(payment_request string) (db.InvoiceResult, db.InvoiceError) {
 	url := fmt.Sprintf("%s/invoice?payment_request=%s", config.RelayUrl, payment_request)
 
@@ -813,11 +1130,11 @@ func (h *bountyHandler) GetLightningInvoice(payment_request string) (db.InvoiceR
 
 	req.Header.Set("x-user-token", config.RelayAuthKey)
 	req.Header.Set("Content-Type", "application/json")
-	res, _ := h.httpClient.Do(req)
+	res, err := h.httpClient.Do(req)
 
 	if err != nil {
 		log.Printf("[bounty] Request Failed: %s", err)
-		return db.InvoiceResult{}, db.InvoiceError{}
+		return db.InvoiceResult{}, db.InvoiceError{Error: err.Error()}
 	}
 
 	defer res.Body.Close()
@@ -849,12 +1166,75 @@ func (h *bountyHandler) GetLightningInvoice(payment_request string) (db.InvoiceR
 	}
 }
 
+// withdrawBudget pays an invoice from the budget of a workspace. When the
+// relay was sent the invoice but didn't answer, the withdrawal is recorded
+// as pending and taken from the budget until an admin reconciles it
+func (h *bountyHandler) withdrawBudget(w http.ResponseWriter, r *http.Request, pubKeyFromAuth string, workspaceUuid string, paymentRequest string, amount uint) {
+	// the withdrawal is taken from the budget before the invoice is paid,
+	// so payments made at the same time can't spend the budget twice
+	now := time.Now()
+	payment, err := h.db.CreatePendingPayment(db.NewPaymentHistory{
+		WorkspaceUuid:  workspaceUuid,
+		Amount:         amount,
+		PaymentType:    db.Withdraw,
+		Created:        &now,
+		Updated:        &now,
+		SenderPubKey:   pubKeyFromAuth,
+		PaymentRequest: paymentRequest,
+		PaymentHash:    utils.GetInvoicePaymentHash(paymentRequest),
+	})
+	if errors.Is(err, db.ErrBudgetNotEnough) {
+		httpio.WriteError(w, r, http.StatusForbidden, "Workspace budget is not enough to withdraw the amount")
+		return
+	}
+	if err != nil {
+		log.Printf("[bounty] could not record the withdrawal of %s: %s", paymentRequest, err)
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Could not record the withdrawal")
+		return
+	}
+
+	paymentSuccess, paymentError, err := h.payLightningInvoice(r.Context(), paymentRequest)
+	if err != nil && upstream.Attempted(err) {
+		log.Printf("[bounty] the outcome of the withdrawal of %s is unknown, it is pending: %s", paymentRequest, err)
+		w.WriteHeader(http.StatusAccepted)
+		json.NewEncoder(w).Encode(payment)
+		return
+	}
+	if err != nil {
+		failPayment(h.db, payment.ID)
+		upstream.WriteError(w, r, err, "The invoice could not be sent to the relay")
+		return
+	}
+	if !paymentSuccess.Success {
+		failPayment(h.db, payment.ID)
+		httpio.WriteError(w, r, http.StatusBadRequest, paymentError.Error)
+		return
+	}
+
+	if _, err := h.db.ReconcilePayment(payment.ID, true, ""); err != nil {
+		log.Printf("[bounty] the withdrawal of %s was paid but could not be recorded: %s", paymentRequest, err)
+	}
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(paymentSuccess)
+}
+
 func (h *bountyHandler) PayLightningInvoice(payment_request string) (db.InvoicePaySuccess, db.InvoicePayError) {
+	success, payErr, _ := h.payLightningInvoice(context.Background(), payment_request)
+	return success, payErr
+}
+
+// payLightningInvoice pays an invoice through the relay, the error is the
+// error of the call, which leaves the outcome of the payment unknown when
+// upstream.Attempted
+func (h *bountyHandler) payLightningInvoice(ctx context.Context, payment_request string) (db.InvoicePaySuccess, db.InvoicePayError, error) {
 	url := fmt.Sprintf("%s/invoices", config.RelayUrl)
 	bodyData := fmt.Sprintf(`{"payment_request": "%s"}`, payment_request)
 	jsonBody := []byte(bodyData)
 
-	req, err := http.NewRequest(http.MethodPut, url, bytes.NewBuffer(jsonBody))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, url, bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return db.InvoicePaySuccess{}, db.InvoicePayError{}, err
+	}
 
 	req.Header.Set("x-user-token", config.RelayAuthKey)
 	req.Header.Set("Content-Type", "application/json")
@@ -862,11 +1242,15 @@ func (h *bountyHandler) PayLightningInvoice(payment_request string) (db.InvoiceP
 
 	if err != nil {
 		log.Printf("[bounty] Request Failed: %s", err)
-		return db.InvoicePaySuccess{}, db.InvoicePayError{}
+		return db.InvoicePaySuccess{}, db.InvoicePayError{}, err
 	}
 
 	defer res.Body.Close()
 
+	if res.StatusCode == http.StatusAccepted {
+		return db.InvoicePaySuccess{}, db.InvoicePayError{}, upstream.ErrPaymentPending
+	}
+
 	body, err := io.ReadAll(res.Body)
 
 	if res.StatusCode != 200 {
@@ -875,20 +1259,20 @@ func (h *bountyHandler) PayLightningInvoice(payment_request string) (db.InvoiceP
 
 		if err != nil {
 			log.Printf("[bounty] Reading Invoice pay error body failed: %s", err)
-			return db.InvoicePaySuccess{}, db.InvoicePayError{}
+			return db.InvoicePaySuccess{}, db.InvoicePayError{}, nil
 		}
 
-		return db.InvoicePaySuccess{}, invoiceError
+		return db.InvoicePaySuccess{}, invoiceError, nil
 	} else {
 		invoiceSuccess := db.InvoicePaySuccess{}
 		err = json.Unmarshal(body, &invoiceSuccess)
 
 		if err != nil {
 			log.Printf("[bounty] Reading Invoice pay success body failed: %s", err)
-			return db.InvoicePaySuccess{}, db.InvoicePayError{}
+			return db.InvoicePaySuccess{}, db.InvoicePayError{}, nil
 		}
 
-		return invoiceSuccess, db.InvoicePayError{}
+		return invoiceSuccess, db.InvoicePayError{}, nil
 	}
 }
 
@@ -897,8 +1281,7 @@ func (h *bountyHandler) GetInvoiceData(w http.ResponseWriter, r *http.Request) {
 	invoiceData, invoiceErr := h.GetLightningInvoice(paymentRequest)
 
 	if invoiceErr.Error != "" {
-		w.WriteHeader(http.StatusForbidden)
-		json.NewEncoder(w).Encode(invoiceErr)
+		httpio.WriteError(w, r, http.StatusForbidden, invoiceErr.Error)
 		return
 	}
 
@@ -910,19 +1293,17 @@ func (h *bountyHandler) PollInvoice(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
 	paymentRequest := chi.URLParam(r, "paymentRequest")
-	var err error
 
 	if pubKeyFromAuth == "" {
 		fmt.Println("[bounty] no pubkey from auth")
-		w.WriteHeader(http.StatusUnauthorized)
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
 		return
 	}
 
 	invoiceRes, invoiceErr := h.GetLightningInvoice(paymentRequest)
 
 	if invoiceErr.Error != "" {
-		w.WriteHeader(http.StatusForbidden)
-		json.NewEncoder(w).Encode(invoiceErr)
+		httpio.WriteError(w, r, http.StatusForbidden, invoiceErr.Error)
 		return
 	}
 
@@ -935,56 +1316,59 @@ func (h *bountyHandler) PollInvoice(w http.ResponseWriter, r *http.Request) {
 		// Make any change only if the invoice has not been settled
 		if !dbInvoice.Status {
 			if invoice.Type == "BUDGET" {
-				h.db.AddAndUpdateBudget(invoice)
+				// settle the invoice and credit the budget together. Only
+				// the poll that settles the invoice credits it, so
+				// concurrent polls can't credit the same invoice twice
+				credited := false
+				err := h.db.WithTx(func(tx db.Database) error {
+					settled, err := tx.UpdateInvoice(paymentRequest)
+					if err != nil || !settled {
+						return err
+					}
+					if _, err := tx.AddAndUpdateBudget(invoice); err != nil {
+						return err
+					}
+					credited = true
+					return nil
+				})
+				if err != nil {
+					log.Printf("[bounty] could not credit the budget invoice %s: %s", paymentRequest, err)
+					httpio.WriteError(w, r, http.StatusInternalServerError, "Could not update the workspace budget")
+					return
+				}
+				if credited {
+					events.Publish(r.Context(), events.BudgetUpdated, websocket.Topic(websocket.TopicWorkspace, dbInvoice.WorkspaceUuid), dbInvoice)
+				}
+			} else if invoice.Type == db.TipInvoice {
+				settleTip(r.Context(), h.httpClient, h.db, paymentRequest)
 			} else if invoice.Type == "KEYSEND" {
-				url := fmt.Sprintf("%s/payment", config.RelayUrl)
-
-				amount := invData.Amount
-
-				bodyData := utils.BuildKeysendBodyData(amount, invData.UserPubkey, invData.RouteHint)
-
-				jsonBody := []byte(bodyData)
-
-				req, _ := http.NewRequest(http.MethodPost, url, bytes.NewBuffer(jsonBody))
-
-				req.Header.Set("x-user-token", config.RelayAuthKey)
-				req.Header.Set("Content-Type", "application/json")
-				res, _ := h.httpClient.Do(req)
-
-				defer res.Body.Close()
-
-				body, _ := io.ReadAll(res.Body)
-
-				if res.StatusCode == 200 {
-					// Unmarshal result
-					keysendRes := db.KeysendSuccess{}
-					err = json.Unmarshal(body, &keysendRes)
-
-					if err != nil {
-						w.WriteHeader(http.StatusForbidden)
-						json.NewEncoder(w).Encode("Could not decode keysend response")
+				// claim the invoice before its keysend. Only the poll that
+				// settles the invoice pays it, so concurrent polls can't
+				// send the same keysend twice
+				claimed, err := h.db.UpdateInvoice(paymentRequest)
+				if err != nil {
+					log.Printf("[bounty] could not settle the invoice %s: %s", paymentRequest, err)
+					httpio.WriteError(w, r, http.StatusInternalServerError, "Could not settle the invoice")
+					return
+				}
+				if claimed {
+					err := h.keysendInvoice(ctx, paymentRequest, invoice, invData)
+					if errors.Is(err, errPaymentPending) {
+						w.WriteHeader(http.StatusAccepted)
+						json.NewEncoder(w).Encode(invoiceRes)
 						return
 					}
-
-					bounty, err := h.db.GetBountyByCreated(uint(invData.Created))
-					if err == nil {
-						now := time.Now()
-						bounty.Paid = true
-						bounty.PaidDate = &now
-						bounty.Completed = true
-						bounty.CompletionDate = &now
+					if err != nil {
+						upstream.WriteError(w, r, err, "The keysend could not be sent to the relay")
+						return
 					}
-
-					h.db.UpdateBounty(bounty)
-				} else {
-					// Unmarshal result
-					keysendError := db.KeysendError{}
-					err = json.Unmarshal(body, &keysendError)
-					log.Printf("[bounty] Keysend Payment to %s Failed, with Error: %s", invData.UserPubkey, err)
+				}
+			} else {
+				// Update the invoice status
+				if _, err := h.db.UpdateInvoice(paymentRequest); err != nil {
+					log.Printf("[bounty] could not settle the invoice %s: %s", paymentRequest, err)
 				}
 			}
-			// Update the invoice status
-			h.db.UpdateInvoice(paymentRequest)
 		}
 	} else {
 		// Cheeck if time has expired
@@ -999,8 +1383,298 @@ func (h *bountyHandler) PollInvoice(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(invoiceRes)
 }
 
+// keysendInvoice sends the keysend of a settled invoice the poll claimed.
+// When the keysend didn't go out the claim is released, so a later poll
+// sends it, and the error of the call is returned. When the relay didn't
+// answer it is recorded as pending and errPaymentPending is returned. A
+// keysend the relay refused leaves the invoice settled
+func (h *bountyHandler) keysendInvoice(ctx context.Context, paymentRequest string, invoice db.NewInvoiceList, invData db.UserInvoiceData) error {
+	url := fmt.Sprintf("%s/payment", config.RelayUrl)
+	bodyData := utils.BuildKeysendBodyData(invData.Amount, invData.UserPubkey, invData.RouteHint)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBufferString(bodyData))
+	if err != nil {
+		h.releaseInvoice(paymentRequest)
+		return err
+	}
+	req.Header.Set("x-user-token", config.RelayAuthKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := h.httpClient.Do(req)
+	if err != nil {
+		if !upstream.Attempted(err) {
+			h.releaseInvoice(paymentRequest)
+			return err
+		}
+		h.pendingKeysend(paymentRequest, invoice, invData, "", err)
+		return errPaymentPending
+	}
+	defer res.Body.Close()
+
+	body, _ := io.ReadAll(res.Body)
+	if res.StatusCode == http.StatusAccepted {
+		keysendRes := db.KeysendSuccess{}
+		json.Unmarshal(body, &keysendRes)
+		paymentHash, _ := keysendRes.Response["payment_hash"].(string)
+		h.pendingKeysend(paymentRequest, invoice, invData, paymentHash, upstream.ErrPaymentPending)
+		return errPaymentPending
+	}
+	if res.StatusCode != 200 {
+		keysendError := db.KeysendError{}
+		json.Unmarshal(body, &keysendError)
+		log.Printf("[bounty] Keysend Payment to %s Failed, with Error: %s", invData.UserPubkey, keysendError.Error)
+		return nil
+	}
+
+	bounty, err := h.db.GetBountyByCreated(uint(invData.Created))
+	if err != nil {
+		log.Printf("[bounty] keysend to %s succeeded but its bounty was not found: %s", invData.UserPubkey, err)
+		return nil
+	}
+	now := time.Now()
+	bounty.Paid = true
+	bounty.PaidDate = &now
+	bounty.Completed = true
+	bounty.CompletionDate = &now
+	if _, err := h.db.UpdateBounty(bounty); err != nil {
+		log.Printf("[bounty] keysend to %s succeeded but the bounty could not be updated: %s", invData.UserPubkey, err)
+	}
+	return nil
+}
+
+// pendingKeysend records the keysend of a claimed invoice the relay didn't
+// answer as a pending payment, an admin reconciles it
+func (h *bountyHandler) pendingKeysend(paymentRequest string, invoice db.NewInvoiceList, invData db.UserInvoiceData, paymentHash string, relayErr error) {
+	log.Printf("[bounty] the outcome of the keysend of invoice %s is unknown, it is pending: %s", paymentRequest, relayErr)
+
+	now := time.Now()
+	payment := db.NewPaymentHistory{
+		Amount:         invData.Amount,
+		SenderPubKey:   invoice.OwnerPubkey,
+		ReceiverPubKey: invData.UserPubkey,
+		PaymentType:    db.Payment,
+		PaymentRequest: paymentRequest,
+		PaymentHash:    paymentHash,
+		Created:        &now,
+		Updated:        &now,
+	}
+	if bounty, err := h.db.GetBountyByCreated(uint(invData.Created)); err == nil {
+		payment.BountyId = bounty.ID
+	}
+	if _, err := h.db.CreatePendingPayment(payment); err != nil {
+		log.Printf("[bounty] the pending keysend of invoice %s could not be recorded: %s", paymentRequest, err)
+	}
+}
+
+func (h *bountyHandler) releaseInvoice(paymentRequest string) {
+	if err := h.db.ReleaseInvoice(paymentRequest); err != nil {
+		log.Printf("[bounty] the claim of the invoice %s could not be released: %s", paymentRequest, err)
+	}
+}
+
 func GetFilterCount(w http.ResponseWriter, r *http.Request) {
 	filterCount := db.DB.GetFilterStatusCount()
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(filterCount)
 }
+
+func (h *bountyHandler) GenerateBountyDescription(w http.ResponseWriter, r *http.Request) {
+	pubKeyFromAuth, request, ok := h.descriptionRequest(w, r)
+	if !ok {
+		return
+	}
+
+	if config.BountyDescriptionUrl == "" {
+		httpio.WriteError(w, r, http.StatusServiceUnavailable, "Bounty description generator is not configured")
+		return
+	}
+
+	descriptionCtx, ok := h.descriptionContext(w, r, pubKeyFromAuth, request)
+	if !ok {
+		return
+	}
+	if !withinQuota(w, r, h.db, request.WorkspaceUuid, db.QuotaStakworkSubmissions, 1) || !withinAiCap(w, r, h.db, request.WorkspaceUuid) {
+		return
+	}
+
+	started := time.Now()
+	draft, err := h.RequestBountyDescription(r.Context(), descriptionCtx)
+	recordAiSubmission(h.db, request.WorkspaceUuid, db.AiBountyDescription, "", "", started, err)
+	if err != nil {
+		fmt.Println("[bounty] generate description error", err)
+		upstream.WriteError(w, r, err, "Could not generate bounty description")
+		return
+	}
+	countQuota(h.db, request.WorkspaceUuid, db.QuotaStakworkSubmissions)
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(draft)
+}
+
+// PreviewBountyDescription returns the call GenerateBountyDescription would
+// make to the description generator for the same body, with the secrets of
+// its headers redacted, without making it
+func (h *bountyHandler) PreviewBountyDescription(w http.ResponseWriter, r *http.Request) {
+	pubKeyFromAuth, request, ok := h.descriptionRequest(w, r)
+	if !ok {
+		return
+	}
+	descriptionCtx, ok := h.descriptionContext(w, r, pubKeyFromAuth, request)
+	if !ok {
+		return
+	}
+
+	headers := map[string]string{}
+	for key, values := range descriptionHeaders() {
+		headers[key] = strings.Join(values, ", ")
+	}
+	if headers["Authorization"] != "" {
+		headers["Authorization"] = "[redacted]"
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(db.StakworkPreview{
+		Url:     config.BountyDescriptionUrl,
+		Method:  http.MethodPost,
+		Headers: headers,
+		Payload: descriptionCtx,
+	})
+}
+
+// descriptionRequest reads the body of a description request, it writes
+// the error and returns false when it has no prompt
+func (h *bountyHandler) descriptionRequest(w http.ResponseWriter, r *http.Request) (string, db.BountyDescriptionRequest, bool) {
+	request := db.BountyDescriptionRequest{}
+	pubKeyFromAuth, _ := r.Context().Value(auth.ContextKey).(string)
+
+	if pubKeyFromAuth == "" {
+		fmt.Println("[bounty] no pubkey from auth")
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
+		return "", request, false
+	}
+
+	body, err := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err != nil {
+		fmt.Println("[read body]", err)
+		httpio.WriteError(w, r, http.StatusNotAcceptable, "")
+		return "", request, false
+	}
+
+	err = json.Unmarshal(body, &request)
+	if err != nil {
+		fmt.Println("[bounty]", err)
+		httpio.WriteError(w, r, http.StatusNotAcceptable, "")
+		return "", request, false
+	}
+
+	if strings.TrimSpace(request.Prompt) == "" {
+		httpio.WriteError(w, r, http.StatusBadRequest, "Prompt is a required field")
+		return "", request, false
+	}
+	return pubKeyFromAuth, request, true
+}
+
+// descriptionContext resolves the workspace, feature and phase of a
+// description request into what is sent to the generator. They are private
+// to the members of their workspace, so the caller has to be one
+func (h *bountyHandler) descriptionContext(w http.ResponseWriter, r *http.Request, pubKeyFromAuth string, request db.BountyDescriptionRequest) (db.BountyDescriptionContext, bool) {
+	descriptionCtx := db.BountyDescriptionContext{
+		Prompt:      strings.TrimSpace(request.Prompt),
+		RequestedBy: pubKeyFromAuth,
+	}
+
+	if request.WorkspaceUuid != "" {
+		workspace := h.db.GetWorkspaceByUuid(request.WorkspaceUuid)
+		if workspace.Uuid != request.WorkspaceUuid {
+			httpio.WriteError(w, r, http.StatusNotFound, "Workspace does not exists")
+			return descriptionCtx, false
+		}
+		if !isWorkspaceMember(h.db, pubKeyFromAuth, workspace.Uuid) {
+			httpio.WriteError(w, r, http.StatusUnauthorized, "Don't have access to this workspace")
+			return descriptionCtx, false
+		}
+		descriptionCtx.Workspace = db.WorkspaceShort{
+			Uuid: workspace.Uuid,
+			Name: workspace.Name,
+			Img:  workspace.Img,
+		}
+		descriptionCtx.Mission = workspace.Mission
+		descriptionCtx.Tactics = workspace.Tactics
+	}
+
+	if request.FeatureUuid != "" {
+		feature := h.db.GetFeatureByUuid(request.FeatureUuid)
+		if feature.Uuid != request.FeatureUuid {
+			httpio.WriteError(w, r, http.StatusNotFound, "Feature does not exists")
+			return descriptionCtx, false
+		}
+		if feature.WorkspaceUuid != request.WorkspaceUuid && !isWorkspaceMember(h.db, pubKeyFromAuth, feature.WorkspaceUuid) {
+			httpio.WriteError(w, r, http.StatusUnauthorized, "Don't have access to this workspace")
+			return descriptionCtx, false
+		}
+		descriptionCtx.Feature = &feature
+
+		if request.PhaseUuid != "" {
+			phase, err := h.db.GetFeaturePhaseByUuid(request.FeatureUuid, request.PhaseUuid)
+			if err != nil {
+				httpio.WriteError(w, r, http.StatusNotFound, "Phase does not exists")
+				return descriptionCtx, false
+			}
+			descriptionCtx.Phase = &phase
+		}
+	}
+	return descriptionCtx, true
+}
+
+// descriptionHeaders are the headers of a call to the description generator
+func descriptionHeaders() http.Header {
+	headers := http.Header{}
+	headers.Set("Content-Type", "application/json")
+	if config.StakworkKey != "" {
+		headers.Set("Authorization", fmt.Sprintf("Token token=%s", config.StakworkKey))
+	}
+	return headers
+}
+
+func (h *bountyHandler) RequestBountyDescription(ctx context.Context, descriptionCtx db.BountyDescriptionContext) (db.BountyDescriptionDraft, error) {
+	draft := db.BountyDescriptionDraft{}
+
+	buf, err := json.Marshal(descriptionCtx)
+	if err != nil {
+		return draft, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, config.BountyDescriptionUrl, bytes.NewBuffer(buf))
+	if err != nil {
+		return draft, err
+	}
+
+	req.Header = descriptionHeaders()
+
+	res, err := h.httpClient.Do(req)
+	if err != nil {
+		return draft, err
+	}
+
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return draft, err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return draft, upstream.StatusError(upstream.Stakwork, res)
+	}
+
+	err = json.Unmarshal(body, &draft)
+	if err != nil {
+		return draft, err
+	}
+
+	if draft.Title == "" && draft.Description == "" {
+		return draft, errors.New("description generator returned an empty draft")
+	}
+
+	return draft, nil
+}
@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/credentials"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/httpio"
+)
+
+type certificateHandler struct {
+	db db.Database
+}
+
+func NewCertificateHandler(database db.Database) *certificateHandler {
+	return &certificateHandler{db: database}
+}
+
+// signedCertificate is a certificate as served, its claim is sent as it
+// was signed
+func signedCertificate(certificate db.BountyCertificate) db.SignedBountyCertificate {
+	return db.SignedBountyCertificate{
+		Certificate: json.RawMessage(certificate.Claim),
+		Algorithm:   credentials.Algorithm,
+		KeyId:       certificate.KeyId,
+		Signature:   certificate.Signature,
+	}
+}
+
+// GetCertificateKey returns the public key the completion certificates
+// are verified with
+func (ch *certificateHandler) GetCertificateKey(w http.ResponseWriter, r *http.Request) {
+	published, err := credentials.Published()
+	if err != nil {
+		httpio.WriteError(w, r, http.StatusNotFound, "This server doesn't issue certificates")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(published)
+}
+
+// GetBountyCertificate returns a completion certificate, for the websites
+// its hunter shares it with
+func (ch *certificateHandler) GetBountyCertificate(w http.ResponseWriter, r *http.Request) {
+	certificate := ch.db.GetBountyCertificate(chi.URLParam(r, "uuid"))
+	if certificate.ID == 0 {
+		httpio.WriteError(w, r, http.StatusNotFound, "Certificate not found")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(signedCertificate(certificate))
+}
+
+// GetMyBountyCertificates returns the completion certificates of the
+// bounties the caller was paid for, the newest first
+func (ch *certificateHandler) GetMyBountyCertificates(w http.ResponseWriter, r *http.Request) {
+	pubKeyFromAuth, _ := r.Context().Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
+		return
+	}
+
+	certificates, err := ch.db.GetBountyCertificatesByPubkey(pubKeyFromAuth)
+	if err != nil {
+		fmt.Println("[certificates]", err)
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to get the certificates")
+		return
+	}
+	signed := []db.SignedBountyCertificate{}
+	for _, certificate := range certificates {
+		signed = append(signed, signedCertificate(certificate))
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(signed)
+}
@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBountyCertificates(t *testing.T) {
+	claim := `{"type":"bounty_completion","uuid":"certificate-uuid","pubkey":"hunter","bounty_id":7}`
+	certificate := db.BountyCertificate{ID: 1, Uuid: "certificate-uuid", BountyID: 7, Pubkey: "hunter", Claim: claim, Signature: "signature", KeyId: "key-id"}
+
+	t.Run("should serve the claim as it was signed", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mockDb.On("GetBountyCertificate", "certificate-uuid").Return(certificate).Once()
+
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("uuid", "certificate-uuid")
+		req, _ := http.NewRequestWithContext(context.WithValue(context.Background(), chi.RouteCtxKey, rctx), http.MethodGet, "/bounty_certificates/certificate-uuid", nil)
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(NewCertificateHandler(mockDb).GetBountyCertificate).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		signed := map[string]json.RawMessage{}
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &signed))
+		assert.Equal(t, claim, string(signed["certificate"]))
+		assert.Equal(t, `"Ed25519"`, string(signed["algorithm"]))
+		assert.Equal(t, `"signature"`, string(signed["signature"]))
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should return the certificates of the caller", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mockDb.On("GetBountyCertificatesByPubkey", "hunter").Return([]db.BountyCertificate{certificate}, nil).Once()
+
+		req, _ := http.NewRequestWithContext(context.WithValue(context.Background(), auth.ContextKey, "hunter"), http.MethodGet, "/me/bounty_certificates", nil)
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(NewCertificateHandler(mockDb).GetMyBountyCertificates).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		signed := []db.SignedBountyCertificate{}
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &signed))
+		assert.Len(t, signed, 1)
+		assert.Equal(t, "key-id", signed[0].KeyId)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should not publish a key without BOUNTY_CERTIFICATE_KEY", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "/bounty_certificates/key", nil)
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(NewCertificateHandler(&dbMocks.Database{}).GetCertificateKey).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
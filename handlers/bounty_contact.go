@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/httpio"
+)
+
+// contactExchangeOf is the contact exchange of the bounty of the route, for
+// its owner or its assignee. It writes the error when the caller is neither
+// or the bounty is not assigned
+func (th *termsHandler) contactExchangeOf(w http.ResponseWriter, r *http.Request) (string, db.BountyContactExchange, bool) {
+	pubKeyFromAuth, bounty, ok := th.bountyOf(w, r)
+	if !ok {
+		return "", db.BountyContactExchange{}, false
+	}
+	if bounty.Assignee == "" {
+		httpio.WriteError(w, r, http.StatusNotFound, "The bounty is not assigned")
+		return "", db.BountyContactExchange{}, false
+	}
+	if pubKeyFromAuth != bounty.OwnerID && pubKeyFromAuth != bounty.Assignee {
+		httpio.WriteError(w, r, http.StatusForbidden, "Only the owner and the assignee of the bounty exchange contacts")
+		return "", db.BountyContactExchange{}, false
+	}
+
+	exchange, err := th.db.GetBountyContactExchange(bounty)
+	if errors.Is(err, db.ErrNoContactExchange) {
+		httpio.WriteError(w, r, http.StatusNotFound, err.Error())
+		return "", exchange, false
+	}
+	if err != nil {
+		fmt.Println("[contact]", err)
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to get the contact exchange")
+		return "", exchange, false
+	}
+	return pubKeyFromAuth, exchange, true
+}
+
+// writeContactExchange answers with the exchange, and the contact of the
+// other party once both consented
+func (th *termsHandler) writeContactExchange(w http.ResponseWriter, pubKeyFromAuth string, exchange db.BountyContactExchange) {
+	if exchange.OwnerConsent && exchange.AssigneeConsent {
+		other := exchange.AssigneePubKey
+		if pubKeyFromAuth == exchange.AssigneePubKey {
+			other = exchange.OwnerPubKey
+		}
+		person := th.db.GetPersonByPubkey(other)
+		exchange.Contact = &db.BountyContact{
+			OwnerPubKey:     other,
+			OwnerAlias:      person.OwnerAlias,
+			OwnerContactKey: person.OwnerContactKey,
+			OwnerRouteHint:  person.OwnerRouteHint,
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(exchange)
+}
+
+// GetBountyContact returns the contact exchange of a bounty to its owner or
+// assignee, with the contact key and route hint of the other party once
+// both consented to share them
+func (th *termsHandler) GetBountyContact(w http.ResponseWriter, r *http.Request) {
+	pubKeyFromAuth, exchange, ok := th.contactExchangeOf(w, r)
+	if !ok {
+		return
+	}
+	th.writeContactExchange(w, pubKeyFromAuth, exchange)
+}
+
+// SetBountyContactConsent gives or takes back the consent of the caller to
+// share their contact with the other party of the bounty
+func (th *termsHandler) SetBountyContactConsent(w http.ResponseWriter, r *http.Request) {
+	pubKeyFromAuth, exchange, ok := th.contactExchangeOf(w, r)
+	if !ok {
+		return
+	}
+
+	request := db.BountyContactConsentRequest{}
+	body, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err := json.Unmarshal(body, &request); err != nil {
+		fmt.Println("[contact]", err)
+		httpio.WriteError(w, r, http.StatusNotAcceptable, "")
+		return
+	}
+
+	exchange, err := th.db.SetBountyContactConsent(exchange, pubKeyFromAuth == exchange.OwnerPubKey, request.Consent)
+	if err != nil {
+		fmt.Println("[contact]", err)
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to save the consent")
+		return
+	}
+
+	th.writeContactExchange(w, pubKeyFromAuth, exchange)
+}
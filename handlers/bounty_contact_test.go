@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestBountyContact(t *testing.T) {
+	bounty := db.NewBounty{ID: 1, OwnerID: "owner-pubkey", Assignee: "hunter-pubkey", Title: "bounty"}
+	exchange := db.BountyContactExchange{ID: 3, BountyID: 1, OwnerPubKey: "owner-pubkey", AssigneePubKey: "hunter-pubkey"}
+	newRequest := func(method string, pubkey string, body string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", "1")
+		ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+		ctx = context.WithValue(ctx, auth.ContextKey, pubkey)
+		req, _ := http.NewRequestWithContext(ctx, method, "/gobounties/1/contact", bytes.NewBufferString(body))
+		return req
+	}
+
+	t.Run("should keep the contact until both parties consent", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mockDb.On("GetBounty", uint(1)).Return(bounty).Once()
+		mockDb.On("GetBountyContactExchange", bounty).Return(db.BountyContactExchange{ID: 3, OwnerPubKey: "owner-pubkey", AssigneePubKey: "hunter-pubkey", OwnerConsent: true}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(NewTermsHandler(mockDb).GetBountyContact).ServeHTTP(rr, newRequest(http.MethodGet, "owner-pubkey", ""))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		res := db.BountyContactExchange{}
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &res))
+		assert.True(t, res.OwnerConsent)
+		assert.Nil(t, res.Contact)
+		mockDb.AssertNotCalled(t, "GetPersonByPubkey", mock.Anything)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should give the assignee the contact of the owner once both consented", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mockDb.On("GetBounty", uint(1)).Return(bounty).Once()
+		mockDb.On("GetBountyContactExchange", bounty).Return(exchange, nil).Once()
+		mockDb.On("SetBountyContactConsent", exchange, false, true).Return(db.BountyContactExchange{ID: 3, OwnerPubKey: "owner-pubkey", AssigneePubKey: "hunter-pubkey", OwnerConsent: true, AssigneeConsent: true}, nil).Once()
+		mockDb.On("GetPersonByPubkey", "owner-pubkey").Return(db.Person{OwnerPubKey: "owner-pubkey", OwnerAlias: "owner", OwnerContactKey: "contact-key", OwnerRouteHint: "route-hint"}).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(NewTermsHandler(mockDb).SetBountyContactConsent).ServeHTTP(rr, newRequest(http.MethodPut, "hunter-pubkey", `{"consent": true}`))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		res := db.BountyContactExchange{}
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &res))
+		assert.Equal(t, &db.BountyContact{OwnerPubKey: "owner-pubkey", OwnerAlias: "owner", OwnerContactKey: "contact-key", OwnerRouteHint: "route-hint"}, res.Contact)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should refuse someone who is not a party of the bounty", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mockDb.On("GetBounty", uint(1)).Return(bounty).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(NewTermsHandler(mockDb).GetBountyContact).ServeHTTP(rr, newRequest(http.MethodGet, "someone-else", ""))
+
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+		mockDb.AssertNotCalled(t, "GetBountyContactExchange", mock.Anything)
+	})
+
+	t.Run("should answer 404 for a bounty that is not assigned", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mockDb.On("GetBounty", uint(1)).Return(db.NewBounty{ID: 1, OwnerID: "owner-pubkey"}).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(NewTermsHandler(mockDb).GetBountyContact).ServeHTTP(rr, newRequest(http.MethodGet, "owner-pubkey", ""))
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("should leave contacts out of the profiles of others", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "/person/owner-pubkey", nil)
+		person := withoutContact(req, db.Person{OwnerPubKey: "owner-pubkey", OwnerContactKey: "contact-key", OwnerRouteHint: "route-hint"})
+
+		assert.Equal(t, "", person.OwnerContactKey)
+		assert.Equal(t, "", person.OwnerRouteHint)
+		assert.Equal(t, "owner-pubkey", person.OwnerPubKey)
+	})
+}
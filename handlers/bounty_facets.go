@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/httpio"
+)
+
+// the status flags of the /bounties/all query, and their facet status
+var bountyStatusFlags = [][2]string{
+	{"Open", "open"},
+	{"Assigned", "assigned"},
+	{"Completed", "completed"},
+	{"Paid", "paid"},
+}
+
+// splitList is the non empty values of a comma separated list
+func splitList(value string) []string {
+	values := []string{}
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// bountyFacetFilters reads the filter set of the marketplace, a query string
+// with the parameters of /bounties/all plus price_band
+func bountyFacetFilters(value string) (db.BountyFacetFilters, error) {
+	keys, err := url.ParseQuery(value)
+	if err != nil {
+		return db.BountyFacetFilters{}, err
+	}
+
+	filters := db.BountyFacetFilters{
+		Tags:          splitList(keys.Get("tags")),
+		Languages:     splitList(keys.Get("languages")),
+		WorkspaceUuid: keys.Get("workspace_uuid"),
+		PhaseUuid:     keys.Get("phase_uuid"),
+		Search:        keys.Get("search"),
+		PriceBand:     keys.Get("price_band"),
+	}
+	if filters.WorkspaceUuid == "" {
+		filters.WorkspaceUuid = keys.Get("org_uuid")
+	}
+	for _, flag := range bountyStatusFlags {
+		if keys.Get(flag[0]) == "true" {
+			filters.Statuses = append(filters.Statuses, flag[1])
+		}
+	}
+	if filters.PriceBand != "" {
+		if _, ok := db.PriceBandOf(filters.PriceBand); !ok {
+			return filters, fmt.Errorf("unknown price_band %q", filters.PriceBand)
+		}
+	}
+	return filters, nil
+}
+
+// GetBountyFacets counts the bounties of the filter set in filters per tag,
+// language, price band and workspace, for the marketplace sidebar
+func (h *bountyHandler) GetBountyFacets(w http.ResponseWriter, r *http.Request) {
+	filters, err := bountyFacetFilters(r.URL.Query().Get("filters"))
+	if err != nil {
+		httpio.WriteError(w, r, http.StatusBadRequest, "Error: "+err.Error())
+		return
+	}
+
+	facets, err := h.db.GetBountyFacets(filters)
+	if err != nil {
+		fmt.Println("[bounty]", err)
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to get the bounty facets")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(facets)
+}
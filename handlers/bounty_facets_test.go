@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/handlers/mocks"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetBountyFacets(t *testing.T) {
+	serve := func(mockDb *dbMocks.Database, filters string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/gobounties/facets?filters="+url.QueryEscape(filters), nil)
+		http.HandlerFunc(NewBountyHandler(&mocks.HttpClient{}, mockDb).GetBountyFacets).ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("should count the bounties of the filter set", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		filters := db.BountyFacetFilters{
+			Statuses:      []string{"open", "paid"},
+			Tags:          []string{"backend"},
+			Languages:     []string{"Go", "Rust"},
+			WorkspaceUuid: "workspace-a",
+			Search:        "relay",
+			PriceBand:     "10k_50k",
+		}
+		facets := db.BountyFacets{
+			Total:      3,
+			Tags:       []db.BountyFacetCount{{Value: "backend", Count: 3}},
+			Languages:  []db.BountyFacetCount{{Value: "Go", Count: 2}, {Value: "Rust", Count: 1}},
+			PriceBands: []db.BountyFacetCount{{Value: "under_10k"}, {Value: "10k_50k", Count: 3}},
+			Workspaces: []db.BountyFacetCount{{Value: "workspace-a", Label: "Sphinx", Count: 3}},
+		}
+		mockDb.On("GetBountyFacets", filters).Return(facets, nil).Once()
+
+		rr := serve(mockDb, "Open=true&Paid=true&Assigned=false&tags=backend&languages=Go,Rust&org_uuid=workspace-a&search=relay&price_band=10k_50k")
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		res := db.BountyFacets{}
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &res))
+		assert.Equal(t, facets, res)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should count every shown bounty without filters", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mockDb.On("GetBountyFacets", db.BountyFacetFilters{Tags: []string{}, Languages: []string{}}).Return(db.BountyFacets{Total: 10}, nil).Once()
+
+		rr := serve(mockDb, "")
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should refuse an unknown price band", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+
+		rr := serve(mockDb, "price_band=cheap")
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockDb.AssertNotCalled(t, "GetBountyFacets", mock.Anything)
+	})
+}
@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/events"
+	"github.com/stakwork/sphinx-tribes/httpio"
+	"github.com/stakwork/sphinx-tribes/utils"
+	"github.com/stakwork/sphinx-tribes/websocket"
+)
+
+type termsHandler struct {
+	db db.Database
+}
+
+func NewTermsHandler(database db.Database) *termsHandler {
+	return &termsHandler{db: database}
+}
+
+// bountyOf is the bounty of the route and the caller, it writes the error
+// when either is missing
+func (th *termsHandler) bountyOf(w http.ResponseWriter, r *http.Request) (string, db.NewBounty, bool) {
+	pubKeyFromAuth, _ := r.Context().Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[terms] no pubkey from auth")
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
+		return "", db.NewBounty{}, false
+	}
+
+	id, err := utils.ConvertStringToUint(chi.URLParam(r, "id"))
+	if err != nil {
+		httpio.WriteError(w, r, http.StatusBadRequest, "Invalid bounty id")
+		return "", db.NewBounty{}, false
+	}
+	bounty := th.db.GetBounty(id)
+	if bounty.ID == 0 {
+		httpio.WriteError(w, r, http.StatusNotFound, "Bounty not found")
+		return "", bounty, false
+	}
+	return pubKeyFromAuth, bounty, true
+}
+
+// GetBountyTerms returns the terms of a bounty, the message that accepts
+// them and the acceptances so far. Both parties see them: the owner and the
+// members of its workspace, and the assignee or the person that was
+// assigned and has yet to accept
+func (th *termsHandler) GetBountyTerms(w http.ResponseWriter, r *http.Request) {
+	pubKeyFromAuth, bounty, ok := th.bountyOf(w, r)
+	if !ok {
+		return
+	}
+
+	allowed := pubKeyFromAuth == bounty.OwnerID || pubKeyFromAuth == bounty.Assignee || pubKeyFromAuth == bounty.PendingAssignee
+	if !allowed && (bounty.WorkspaceUuid == "" || !isWorkspaceMember(th.db, pubKeyFromAuth, bounty.WorkspaceUuid)) {
+		httpio.WriteError(w, r, http.StatusUnauthorized, "Don't have access to the terms of this bounty")
+		return
+	}
+
+	acceptances, err := th.db.GetBountyTermsAcceptances(bounty.ID)
+	if err != nil {
+		fmt.Println("[terms]", err)
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to get the acceptances")
+		return
+	}
+
+	terms := db.BountyTerms{
+		BountyID:        bounty.ID,
+		Terms:           bounty.Terms,
+		PendingAssignee: bounty.PendingAssignee,
+		Acceptances:     acceptances,
+	}
+	if bounty.Terms != "" {
+		terms.TermsHash = db.BountyTermsHash(bounty.Terms)
+		terms.Message = db.BountyTermsMessage(bounty.ID, terms.TermsHash)
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(terms)
+}
+
+// AcceptBountyTerms takes the signed acknowledgment of the terms of a bounty
+// from the person assigned to it, and completes their assignment
+func (th *termsHandler) AcceptBountyTerms(w http.ResponseWriter, r *http.Request) {
+	pubKeyFromAuth, bounty, ok := th.bountyOf(w, r)
+	if !ok {
+		return
+	}
+	if bounty.Terms == "" {
+		httpio.WriteError(w, r, http.StatusBadRequest, "The bounty has no terms")
+		return
+	}
+	if pubKeyFromAuth != bounty.PendingAssignee {
+		httpio.WriteError(w, r, http.StatusForbidden, "The bounty is not waiting for you to accept its terms")
+		return
+	}
+
+	request := db.BountyTermsAcceptRequest{}
+	body, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err := json.Unmarshal(body, &request); err != nil {
+		fmt.Println("[terms]", err)
+		httpio.WriteError(w, r, http.StatusNotAcceptable, "")
+		return
+	}
+	if !validatePayload(w, r, request) {
+		return
+	}
+
+	hash := db.BountyTermsHash(bounty.Terms)
+	if request.TermsHash != "" && request.TermsHash != hash {
+		httpio.WriteErrorDetails(w, r, http.StatusConflict, "The terms changed since they were read", map[string]interface{}{
+			"terms_hash": hash,
+		})
+		return
+	}
+	signer, err := auth.VerifyArbitrary(request.Signature, db.BountyTermsMessage(bounty.ID, hash))
+	if err != nil || signer != pubKeyFromAuth {
+		httpio.WriteError(w, r, http.StatusUnauthorized, "The signature is not yours for these terms")
+		return
+	}
+
+	acceptance := db.BountyTermsAcceptance{
+		BountyID:  bounty.ID,
+		Pubkey:    pubKeyFromAuth,
+		TermsHash: hash,
+		Signature: request.Signature,
+	}
+	assigned, err := th.db.AcceptBountyTerms(acceptance)
+	if errors.Is(err, db.ErrBountyTermsChanged) || errors.Is(err, db.ErrNotPendingAssignee) {
+		httpio.WriteError(w, r, http.StatusConflict, err.Error())
+		return
+	}
+	if err != nil {
+		fmt.Println("[terms]", err)
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to accept the terms")
+		return
+	}
+
+	events.Publish(r.Context(), events.BountyUpdated, websocket.Topic(websocket.TopicBounty, assigned.ID), assigned)
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(assigned)
+}
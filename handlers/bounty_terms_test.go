@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestBountyTerms(t *testing.T) {
+	privKey, err := btcec.NewPrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	hunter := hex.EncodeToString(privKey.PubKey().SerializeCompressed())
+	terms := "The code is assigned to the workspace owner"
+	hash := db.BountyTermsHash(terms)
+	bounty := db.NewBounty{ID: 1, OwnerID: "owner-pubkey", Title: "bounty", Terms: terms, PendingAssignee: hunter}
+
+	sign := func(msg string) string {
+		sig, err := auth.Sign([]byte(msg), privKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return base64.URLEncoding.EncodeToString(sig)
+	}
+	newRequest := func(method string, pubkey string, body string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", "1")
+		ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+		ctx = context.WithValue(ctx, auth.ContextKey, pubkey)
+		req, _ := http.NewRequestWithContext(ctx, method, "/gobounties/1/terms/accept", bytes.NewBufferString(body))
+		return req
+	}
+
+	t.Run("should keep the assignee of a bounty with terms pending", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		bHandler := NewBountyHandler(http.DefaultClient, mockDb)
+
+		mockDb.On("IsBannedPubkey", "owner-pubkey").Return(false).Once()
+		mockDb.On("UpdateBountyNullColumn", mock.AnythingOfType("db.NewBounty"), "assignee").Return(db.NewBounty{}, nil).Once()
+		mockDb.On("CreateOrEditBounty", mock.MatchedBy(func(b db.NewBounty) bool {
+			return b.Assignee == "" && b.PendingAssignee == hunter && b.AssignedDate == nil
+		})).Return(func(b db.NewBounty) (db.NewBounty, error) { b.ID = 1; return b, nil }).Once()
+		mockDb.On("WithTx", mock.Anything).Return(func(fn func(db.Database) error) error { return fn(mockDb) }).Once()
+		mockDb.On("SaveMentions", db.MentionBounty, "1", []db.Mention{}).Return([]db.Mention{}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		body := `{"type": "coding", "title": "bounty", "description": "description", "show": true, "assignee": "` + hunter + `", "terms": "` + terms + `"}`
+		req, _ := http.NewRequestWithContext(context.WithValue(context.Background(), auth.ContextKey, "owner-pubkey"), http.MethodPost, "/", bytes.NewBufferString(body))
+		http.HandlerFunc(bHandler.CreateOrEditBounty).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should complete the assignment with a signed acceptance", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		th := NewTermsHandler(mockDb)
+
+		mockDb.On("GetBounty", uint(1)).Return(bounty).Once()
+		mockDb.On("AcceptBountyTerms", mock.MatchedBy(func(a db.BountyTermsAcceptance) bool {
+			return a.BountyID == 1 && a.Pubkey == hunter && a.TermsHash == hash && a.Signature != ""
+		})).Return(db.NewBounty{ID: 1, Assignee: hunter, Terms: terms}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		body := `{"terms_hash": "` + hash + `", "signature": "` + sign(db.BountyTermsMessage(1, hash)) + `"}`
+		http.HandlerFunc(th.AcceptBountyTerms).ServeHTTP(rr, newRequest(http.MethodPost, hunter, body))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should refuse a signature of other terms", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		th := NewTermsHandler(mockDb)
+
+		mockDb.On("GetBounty", uint(1)).Return(bounty).Once()
+
+		rr := httptest.NewRecorder()
+		body := `{"signature": "` + sign(db.BountyTermsMessage(1, db.BountyTermsHash("other terms"))) + `"}`
+		http.HandlerFunc(th.AcceptBountyTerms).ServeHTTP(rr, newRequest(http.MethodPost, hunter, body))
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		mockDb.AssertNotCalled(t, "AcceptBountyTerms", mock.Anything)
+	})
+
+	t.Run("should refuse terms that changed since they were read", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		th := NewTermsHandler(mockDb)
+
+		mockDb.On("GetBounty", uint(1)).Return(bounty).Once()
+
+		rr := httptest.NewRecorder()
+		body := `{"terms_hash": "` + db.BountyTermsHash("old terms") + `", "signature": "sig"}`
+		http.HandlerFunc(th.AcceptBountyTerms).ServeHTTP(rr, newRequest(http.MethodPost, hunter, body))
+
+		assert.Equal(t, http.StatusConflict, rr.Code)
+		assert.Contains(t, rr.Body.String(), hash)
+	})
+
+	t.Run("should only let the pending assignee accept", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		th := NewTermsHandler(mockDb)
+
+		mockDb.On("GetBounty", uint(1)).Return(bounty).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(th.AcceptBountyTerms).ServeHTTP(rr, newRequest(http.MethodPost, "someone-else", `{"signature": "sig"}`))
+
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+
+	t.Run("should show the terms and acceptances to the owner", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		th := NewTermsHandler(mockDb)
+
+		mockDb.On("GetBounty", uint(1)).Return(bounty).Once()
+		mockDb.On("GetBountyTermsAcceptances", uint(1)).Return([]db.BountyTermsAcceptance{}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(th.GetBountyTerms).ServeHTTP(rr, newRequest(http.MethodGet, "owner-pubkey", ""))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), db.BountyTermsMessage(1, hash))
+	})
+}
@@ -3,11 +3,12 @@ package handlers
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
-	"log"
 	"net/http"
 	"net/http/httptest"
 	"strconv"
@@ -23,8 +24,12 @@ import (
 	"github.com/stakwork/sphinx-tribes/auth"
 	"github.com/stakwork/sphinx-tribes/config"
 	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/dbtest"
 	"github.com/stakwork/sphinx-tribes/handlers/mocks"
+	"github.com/stakwork/sphinx-tribes/httpio"
+	"github.com/stakwork/sphinx-tribes/jobs"
 	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stakwork/sphinx-tribes/upstream"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -101,25 +106,16 @@ var workBountyNext = db.NewBounty{
 	Created:       111111114,
 }
 
-func SetupSuite(_ *testing.T) func(tb testing.TB) {
-	db.InitTestDB()
-
-	return func(_ testing.TB) {
-		log.Println("Teardown test")
-	}
-}
-
-func AddExisitingDB(existingBounty db.NewBounty) {
-	bounty := db.TestDB.GetBounty(1)
+func AddExisitingDB(database db.Database, existingBounty db.NewBounty) {
+	bounty := database.GetBounty(1)
 	if bounty.ID == 0 {
 		// add existing bounty to db
-		db.TestDB.CreateOrEditBounty(existingBounty)
+		database.CreateOrEditBounty(existingBounty)
 	}
 }
 
 func TestCreateOrEditBounty(t *testing.T) {
-	teardownSuite := SetupSuite(t)
-	defer teardownSuite(t)
+	database := dbtest.New(t)
 
 	existingBounty := db.NewBounty{
 		Type:          "coding",
@@ -131,7 +127,7 @@ func TestCreateOrEditBounty(t *testing.T) {
 	}
 
 	// Add initial Bounty
-	AddExisitingDB(existingBounty)
+	AddExisitingDB(database, existingBounty)
 
 	newBounty := db.NewBounty{
 		Type:          "coding",
@@ -158,7 +154,7 @@ func TestCreateOrEditBounty(t *testing.T) {
 	mockUserHasManageBountyRolesFalse := func(pubKeyFromAuth string, uuid string) bool {
 		return false
 	}
-	bHandler := NewBountyHandler(mockClient, db.TestDB)
+	bHandler := NewBountyHandler(mockClient, database)
 
 	t.Run("should return error if body is not a valid json", func(t *testing.T) {
 		rr := httptest.NewRecorder()
@@ -272,6 +268,7 @@ func TestCreateOrEditBounty(t *testing.T) {
 		updatedBounty := existingBounty
 		updatedBounty.Title = "first bounty updated"
 		updatedBounty.ID = 1
+		updatedBounty.Version = 1
 
 		body, _ := json.Marshal(updatedBounty)
 		req, err := http.NewRequestWithContext(ctx, http.MethodPost, "/", bytes.NewReader(body))
@@ -282,7 +279,7 @@ func TestCreateOrEditBounty(t *testing.T) {
 		handler.ServeHTTP(rr, req)
 		assert.Equal(t, http.StatusOK, rr.Code)
 
-		bounty := db.TestDB.GetBounty(1)
+		bounty := database.GetBounty(1)
 		assert.Equal(t, bounty.Title, updatedBounty.Title)
 	})
 
@@ -303,7 +300,7 @@ func TestCreateOrEditBounty(t *testing.T) {
 		handler.ServeHTTP(rr, req)
 		assert.Equal(t, http.StatusOK, rr.Code)
 
-		var returnedBounty db.Bounty
+		var returnedBounty db.NewBounty
 		err = json.Unmarshal(rr.Body.Bytes(), &returnedBounty)
 		assert.NoError(t, err)
 		assert.NotEqual(t, returnedBounty.Created, returnedBounty.Updated)
@@ -452,8 +449,7 @@ func TestPayLightningInvoice(t *testing.T) {
 }
 
 func TestDeleteBounty(t *testing.T) {
-	teardownSuite := SetupSuite(t)
-	defer teardownSuite(t)
+	database := dbtest.New(t)
 
 	existingBounty := db.NewBounty{
 		Type:          "coding",
@@ -465,10 +461,10 @@ func TestDeleteBounty(t *testing.T) {
 	}
 
 	// Add initial Bounty
-	AddExisitingDB(existingBounty)
+	AddExisitingDB(database, existingBounty)
 
 	mockHttpClient := mocks.NewHttpClient(t)
-	bHandler := NewBountyHandler(mockHttpClient, db.TestDB)
+	bHandler := NewBountyHandler(mockHttpClient, database)
 	ctx := context.WithValue(context.Background(), auth.ContextKey, "test-key")
 
 	t.Run("should return unauthorized error if users public key not present", func(t *testing.T) {
@@ -537,7 +533,7 @@ func TestDeleteBounty(t *testing.T) {
 	t.Run("should successfully delete bounty from db", func(t *testing.T) {
 		rr := httptest.NewRecorder()
 		handler := http.HandlerFunc(bHandler.DeleteBounty)
-		existingBounty := db.TestDB.GetBounty(1)
+		existingBounty := database.GetBounty(1)
 
 		rctx := chi.NewRouteContext()
 		rctx.URLParams.Add("pubkey", existingBounty.OwnerID)
@@ -554,7 +550,7 @@ func TestDeleteBounty(t *testing.T) {
 		handler.ServeHTTP(rr, req)
 
 		// get Bounty from DB
-		checkBounty := db.TestDB.GetBounty(1)
+		checkBounty := database.GetBounty(1)
 		// chcek that the bounty's ID is now zero
 		assert.Equal(t, 0, int(checkBounty.ID))
 	})
@@ -613,9 +609,7 @@ func TestGetBountyByCreated(t *testing.T) {
 		rctx.URLParams.Add("created", "1707991475")
 		req, _ := http.NewRequestWithContext(context.WithValue(context.Background(), chi.RouteCtxKey, rctx), http.MethodGet, "/created/1707991475", nil)
 		mockDb.On("GetBountyDataByCreated", createdStr).Return([]db.NewBounty{bounty}, nil).Once()
-		mockDb.On("GetPersonByPubkey", "owner-1").Return(db.Person{}).Once()
-		mockDb.On("GetPersonByPubkey", "user1").Return(db.Person{}).Once()
-		mockDb.On("GetWorkspaceByUuid", "work-1").Return(db.Workspace{}).Once()
+		mockDb.On("GetBountyResponses", []db.NewBounty{bounty}).Return(mockGenerateBountyResponse([]db.NewBounty{bounty})).Once()
 		handler.ServeHTTP(rr, req)
 
 		var returnedBounty []db.BountyResponse
@@ -634,21 +628,18 @@ func TestGetBountyByCreated(t *testing.T) {
 		rctx.URLParams.Add("created", createdStr)
 		req, _ := http.NewRequestWithContext(context.WithValue(context.Background(), chi.RouteCtxKey, rctx), http.MethodGet, "/created/"+createdStr, nil)
 
-		mockDb.On("GetBountyDataByCreated", createdStr).Return([]db.NewBounty{}, nil).Once()
-
 		handler.ServeHTTP(rr, req)
 		assert.Equal(t, http.StatusNotFound, rr.Code, "Expected 404 Not Found for nonexistent bounty")
 
-		mockDb.AssertExpectations(t)
+		mockDb.AssertNotCalled(t, "GetBountyDataByCreated", createdStr)
 	})
 
 }
 
 func TestGetPersonAssignedBounties(t *testing.T) {
-	teardownSuite := SetupSuite(t)
-	defer teardownSuite(t)
+	database := dbtest.New(t)
 	mockHttpClient := mocks.NewHttpClient(t)
-	bHandler := NewBountyHandler(mockHttpClient, db.TestDB)
+	bHandler := NewBountyHandler(mockHttpClient, database)
 
 	bountyOwner := db.Person{
 		Uuid:        "user_1_uuid",
@@ -684,11 +675,11 @@ func TestGetPersonAssignedBounties(t *testing.T) {
 		handler := http.HandlerFunc(bHandler.GetPersonAssignedBounties)
 
 		// create users
-		db.TestDB.CreateOrEditPerson(bountyOwner)
-		db.TestDB.CreateOrEditPerson(bountyAssignee)
+		database.CreateOrEditPerson(bountyOwner)
+		database.CreateOrEditPerson(bountyAssignee)
 
 		// create bounty
-		db.TestDB.CreateOrEditBounty(bounty)
+		database.CreateOrEditBounty(bounty)
 
 		rctx := chi.NewRouteContext()
 		rctx.URLParams.Add("uuid", bountyAssignee.Uuid)
@@ -703,7 +694,7 @@ func TestGetPersonAssignedBounties(t *testing.T) {
 		handler.ServeHTTP(rr, req)
 
 		// bounty from db
-		expectedBounty, _ := db.TestDB.GetAssignedBounties(req)
+		expectedBounty, _ := database.GetAssignedBounties(req)
 
 		var returnedBounty []db.BountyResponse
 		err := json.Unmarshal(rr.Body.Bytes(), &returnedBounty)
@@ -715,12 +706,11 @@ func TestGetPersonAssignedBounties(t *testing.T) {
 }
 
 func TestGetPersonCreatedBounties(t *testing.T) {
-	teardownSuite := SetupSuite(t)
-	defer teardownSuite(t)
+	database := dbtest.New(t)
 
 	ctx := context.Background()
 	mockHttpClient := mocks.NewHttpClient(t)
-	bHandler := NewBountyHandler(mockHttpClient, db.TestDB)
+	bHandler := NewBountyHandler(mockHttpClient, database)
 
 	bounty := db.NewBounty{
 		Type:          "coding",
@@ -756,13 +746,13 @@ func TestGetPersonCreatedBounties(t *testing.T) {
 	}
 
 	// create users
-	db.TestDB.CreateOrEditPerson(bountyOwner)
-	db.TestDB.CreateOrEditPerson(bountyAssignee)
+	database.CreateOrEditPerson(bountyOwner)
+	database.CreateOrEditPerson(bountyAssignee)
 
 	// create bounty
-	db.TestDB.CreateOrEditBounty(bounty)
-	db.TestDB.CreateOrEditBounty(bounty2)
-	db.TestDB.CreateOrEditBounty(bounty3)
+	database.CreateOrEditBounty(bounty)
+	database.CreateOrEditBounty(bounty2)
+	database.CreateOrEditBounty(bounty3)
 
 	t.Run("should return bounties created by the user", func(t *testing.T) {
 		rr := httptest.NewRecorder()
@@ -786,7 +776,7 @@ func TestGetPersonCreatedBounties(t *testing.T) {
 		}
 
 		// bounty from db
-		expectedBounty, _ := db.TestDB.GetCreatedBounties(req)
+		expectedBounty, _ := database.GetCreatedBounties(req)
 
 		assert.NotEmpty(t, responseData)
 		assert.Equal(t, len(expectedBounty), len(responseData))
@@ -845,20 +835,19 @@ func TestGetPersonCreatedBounties(t *testing.T) {
 
 		// Assert that bounties are filtered correctly
 		// bounty from db
-		expectedBounty, _ := db.TestDB.GetCreatedBounties(req)
+		expectedBounty, _ := database.GetCreatedBounties(req)
 		assert.Equal(t, len(expectedBounty), len(responseData))
 	})
 }
 
 func TestGetNextBountyByCreated(t *testing.T) {
-	teardownSuite := SetupSuite(t)
-	defer teardownSuite(t)
+	database := dbtest.New(t)
 
-	db.TestDB.CreateOrEditBounty(bountyPrev)
-	db.TestDB.CreateOrEditBounty(bountyNext)
+	database.CreateOrEditBounty(bountyPrev)
+	database.CreateOrEditBounty(bountyNext)
 
 	mockHttpClient := mocks.NewHttpClient(t)
-	bHandler := NewBountyHandler(mockHttpClient, db.TestDB)
+	bHandler := NewBountyHandler(mockHttpClient, database)
 
 	t.Run("Should test that the next bounty on the bounties homepage can be gotten by its created value and the selected filters", func(t *testing.T) {
 		rr := httptest.NewRecorder()
@@ -886,11 +875,10 @@ func TestGetNextBountyByCreated(t *testing.T) {
 }
 
 func TestGetPreviousBountyByCreated(t *testing.T) {
-	teardownSuite := SetupSuite(t)
-	defer teardownSuite(t)
+	database := dbtest.New(t)
 
 	mockHttpClient := mocks.NewHttpClient(t)
-	bHandler := NewBountyHandler(mockHttpClient, db.TestDB)
+	bHandler := NewBountyHandler(mockHttpClient, database)
 
 	t.Run("Should test that the previous bounty on the bounties homepage can be gotten by its created value and the selected filters", func(t *testing.T) {
 		rr := httptest.NewRecorder()
@@ -919,15 +907,14 @@ func TestGetPreviousBountyByCreated(t *testing.T) {
 }
 
 func TestGetWorkspaceNextBountyByCreated(t *testing.T) {
-	teardownSuite := SetupSuite(t)
-	defer teardownSuite(t)
+	database := dbtest.New(t)
 
-	db.TestDB.CreateOrEditWorkspace(workspace)
-	db.TestDB.CreateOrEditBounty(workBountyPrev)
-	db.TestDB.CreateOrEditBounty(workBountyNext)
+	database.CreateOrEditWorkspace(workspace)
+	database.CreateOrEditBounty(workBountyPrev)
+	database.CreateOrEditBounty(workBountyNext)
 
 	mockHttpClient := mocks.NewHttpClient(t)
-	bHandler := NewBountyHandler(mockHttpClient, db.TestDB)
+	bHandler := NewBountyHandler(mockHttpClient, database)
 
 	t.Run("Should test that the next bounty on the workspace bounties homepage can be gotten by its created value and the selected filters", func(t *testing.T) {
 		rr := httptest.NewRecorder()
@@ -957,11 +944,10 @@ func TestGetWorkspaceNextBountyByCreated(t *testing.T) {
 }
 
 func TestGetWorkspacePreviousBountyByCreated(t *testing.T) {
-	teardownSuite := SetupSuite(t)
-	defer teardownSuite(t)
+	database := dbtest.New(t)
 
 	mockHttpClient := mocks.NewHttpClient(t)
-	bHandler := NewBountyHandler(mockHttpClient, db.TestDB)
+	bHandler := NewBountyHandler(mockHttpClient, database)
 
 	t.Run("Should test that the previous bounty on the workspace bounties homepage can be gotten by its created value and the selected filters", func(t *testing.T) {
 		rr := httptest.NewRecorder()
@@ -991,11 +977,10 @@ func TestGetWorkspacePreviousBountyByCreated(t *testing.T) {
 }
 
 func TestGetBountyById(t *testing.T) {
-	teardownSuite := SetupSuite(t)
-	defer teardownSuite(t)
+	database := dbtest.New(t)
 
 	mockHttpClient := mocks.NewHttpClient(t)
-	bHandler := NewBountyHandler(mockHttpClient, db.TestDB)
+	bHandler := NewBountyHandler(mockHttpClient, database)
 
 	t.Run("successful retrieval of bounty by ID", func(t *testing.T) {
 		rr := httptest.NewRecorder()
@@ -1013,9 +998,9 @@ func TestGetBountyById(t *testing.T) {
 			Created:       now,
 		}
 
-		db.TestDB.CreateOrEditBounty(bounty)
+		database.CreateOrEditBounty(bounty)
 
-		bountyInDb, err := db.TestDB.GetBountyByCreated(uint(bounty.Created))
+		bountyInDb, err := database.GetBountyByCreated(uint(bounty.Created))
 		assert.NoError(t, err)
 		assert.NotNil(t, bountyInDb)
 
@@ -1049,11 +1034,10 @@ func TestGetBountyById(t *testing.T) {
 }
 
 func TestGetBountyIndexById(t *testing.T) {
-	teardownSuite := SetupSuite(t)
-	defer teardownSuite(t)
+	database := dbtest.New(t)
 
 	mockHttpClient := mocks.NewHttpClient(t)
-	bHandler := NewBountyHandler(mockHttpClient, db.TestDB)
+	bHandler := NewBountyHandler(mockHttpClient, database)
 
 	t.Run("successful retrieval of bounty by Index ID", func(t *testing.T) {
 		rr := httptest.NewRecorder()
@@ -1072,13 +1056,13 @@ func TestGetBountyIndexById(t *testing.T) {
 			Created:       now,
 		}
 
-		db.TestDB.CreateOrEditBounty(bounty)
+		database.CreateOrEditBounty(bounty)
 
-		bountyInDb, err := db.TestDB.GetBountyByCreated(uint(bounty.Created))
+		bountyInDb, err := database.GetBountyByCreated(uint(bounty.Created))
 		assert.Equal(t, bounty, bountyInDb)
 		assert.NoError(t, err)
 
-		bountyIndex := db.TestDB.GetBountyIndexById(strconv.Itoa(int(bountyInDb.ID)))
+		bountyIndex := database.GetBountyIndexById(strconv.Itoa(int(bountyInDb.ID)))
 
 		rctx := chi.NewRouteContext()
 		rctx.URLParams.Add("bountyId", strconv.Itoa(int(bountyInDb.ID)))
@@ -1112,11 +1096,10 @@ func TestGetBountyIndexById(t *testing.T) {
 }
 
 func TestGetAllBounties(t *testing.T) {
-	teardownSuite := SetupSuite(t)
-	defer teardownSuite(t)
+	database := dbtest.New(t)
 
 	mockHttpClient := mocks.NewHttpClient(t)
-	bHandler := NewBountyHandler(mockHttpClient, db.TestDB)
+	bHandler := NewBountyHandler(mockHttpClient, database)
 
 	t.Run("Should successfully return all bounties", func(t *testing.T) {
 		now := time.Now().Unix()
@@ -1130,9 +1113,9 @@ func TestGetAllBounties(t *testing.T) {
 			Show:          true,
 			Created:       now,
 		}
-		db.TestDB.CreateOrEditBounty(bounty)
+		database.CreateOrEditBounty(bounty)
 
-		bountyInDb, err := db.TestDB.GetBountyByCreated(uint(bounty.Created))
+		bountyInDb, err := database.GetBountyByCreated(uint(bounty.Created))
 		assert.NoError(t, err)
 		assert.NotNil(t, bountyInDb)
 
@@ -1175,6 +1158,194 @@ func MockNewWSServer(t *testing.T) (*httptest.Server, *websocket.Conn) {
 	return s, ws
 }
 
+func TestCreateOrEditBountyRollback(t *testing.T) {
+	ctx := context.Background()
+	authorizedCtx := context.WithValue(ctx, auth.ContextKey, "owner-pubkey")
+
+	t.Run("should return 400 when the edit fails inside the transaction", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		bHandler := NewBountyHandler(http.DefaultClient, mockDb)
+
+		mockDb.On("IsBannedPubkey", "owner-pubkey").Return(false)
+		mockDb.On("GetBounty", uint(1)).Return(db.NewBounty{ID: 1, OwnerID: "owner-pubkey", Version: 3})
+		mockDb.On("LockBountyVersion", uint(1)).Return(3, nil)
+		mockDb.On("UpdateBountyBoolColumn", mock.AnythingOfType("db.NewBounty"), "show").Return(db.NewBounty{}, nil)
+		mockDb.On("UpdateBountyNullColumn", mock.AnythingOfType("db.NewBounty"), "assignee").Return(db.NewBounty{}, nil)
+		mockDb.On("CreateOrEditBounty", mock.AnythingOfType("db.NewBounty")).Return(db.NewBounty{}, errors.New("edit failed"))
+		mockDb.On("WithTx", mock.Anything).Return(func(fn func(db.Database) error) error { return fn(mockDb) })
+
+		body := []byte(`{"id": 1, "type": "coding", "title": "bounty", "description": "description", "show": false, "version": 3}`)
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequestWithContext(authorizedCtx, http.MethodPost, "/", bytes.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		http.HandlerFunc(bHandler.CreateOrEditBounty).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockDb.AssertExpectations(t)
+	})
+}
+
+func TestCreateOrEditBountyVersion(t *testing.T) {
+	authorizedCtx := context.WithValue(context.Background(), auth.ContextKey, "owner-pubkey")
+	body := []byte(`{"id": 1, "type": "coding", "title": "bounty", "description": "description", "show": true, "assignee": "hunter"}`)
+
+	t.Run("should edit the bounty without a version like before", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		bHandler := NewBountyHandler(http.DefaultClient, mockDb)
+
+		mockDb.On("GetBounty", uint(1)).Return(db.NewBounty{ID: 1, OwnerID: "owner-pubkey", Version: 4}).Once()
+		mockDb.On("IsBannedPubkey", "owner-pubkey").Return(false).Once()
+		mockDb.On("CreateOrEditBounty", mock.AnythingOfType("db.NewBounty")).Return(db.NewBounty{ID: 1, Title: "bounty", Version: 5}, nil).Once()
+		mockDb.On("WithTx", mock.Anything).Return(func(fn func(db.Database) error) error { return fn(mockDb) }).Once()
+		mockDb.On("SaveMentions", db.MentionBounty, "1", []db.Mention{}).Return([]db.Mention{}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		req, _ := http.NewRequestWithContext(authorizedCtx, http.MethodPost, "/", bytes.NewReader(body))
+		http.HandlerFunc(bHandler.CreateOrEditBounty).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, `"5"`, rr.Header().Get("ETag"))
+		mockDb.AssertNotCalled(t, "LockBountyVersion", mock.Anything)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should return 409 with the latest version when the bounty moved on", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		bHandler := NewBountyHandler(http.DefaultClient, mockDb)
+
+		mockDb.On("GetBounty", uint(1)).Return(db.NewBounty{ID: 1, OwnerID: "owner-pubkey", Version: 4}).Once()
+		mockDb.On("IsBannedPubkey", "owner-pubkey").Return(false).Once()
+		mockDb.On("LockBountyVersion", uint(1)).Return(5, nil).Once()
+		mockDb.On("WithTx", mock.Anything).Return(func(fn func(db.Database) error) error { return fn(mockDb) }).Once()
+
+		rr := httptest.NewRecorder()
+		req, _ := http.NewRequestWithContext(authorizedCtx, http.MethodPost, "/", bytes.NewReader(body))
+		req.Header.Set("If-Match", `"4"`)
+		http.HandlerFunc(bHandler.CreateOrEditBounty).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusConflict, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"version":5`)
+		mockDb.AssertNotCalled(t, "CreateOrEditBounty", mock.Anything)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should edit the bounty at the version of If-Match", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		bHandler := NewBountyHandler(http.DefaultClient, mockDb)
+
+		mockDb.On("GetBounty", uint(1)).Return(db.NewBounty{ID: 1, OwnerID: "owner-pubkey", Version: 4}).Once()
+		mockDb.On("IsBannedPubkey", "owner-pubkey").Return(false).Once()
+		mockDb.On("LockBountyVersion", uint(1)).Return(4, nil).Once()
+		mockDb.On("CreateOrEditBounty", mock.AnythingOfType("db.NewBounty")).Return(db.NewBounty{ID: 1, Title: "bounty", Version: 5}, nil).Once()
+		mockDb.On("WithTx", mock.Anything).Return(func(fn func(db.Database) error) error { return fn(mockDb) }).Once()
+		mockDb.On("SaveMentions", db.MentionBounty, "1", []db.Mention{}).Return([]db.Mention{}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		req, _ := http.NewRequestWithContext(authorizedCtx, http.MethodPost, "/", bytes.NewReader(body))
+		req.Header.Set("If-Match", `W/"4"`)
+		http.HandlerFunc(bHandler.CreateOrEditBounty).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, `"5"`, rr.Header().Get("ETag"))
+		assert.Contains(t, rr.Body.String(), `"version":5`)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should refuse an If-Match that is not a version", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		bHandler := NewBountyHandler(http.DefaultClient, mockDb)
+
+		rr := httptest.NewRecorder()
+		req, _ := http.NewRequestWithContext(authorizedCtx, http.MethodPost, "/", bytes.NewReader(body))
+		req.Header.Set("If-Match", "*")
+		http.HandlerFunc(bHandler.CreateOrEditBounty).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockDb.AssertNotCalled(t, "GetBounty", mock.Anything)
+	})
+}
+
+func TestCreateOrEditBountyFeatureBudget(t *testing.T) {
+	authorizedCtx := context.WithValue(context.Background(), auth.ContextKey, "owner-pubkey")
+	phase := db.FeaturePhase{Uuid: "phase-uuid", FeatureUuid: "feature-uuid"}
+
+	t.Run("should refuse a bounty over the feature budget", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		bHandler := NewBountyHandler(http.DefaultClient, mockDb)
+
+		mockDb.On("GetPhaseByUuid", "phase-uuid").Return(phase, nil).Once()
+		mockDb.On("GetFeatureByUuid", "feature-uuid").Return(db.WorkspaceFeatures{Uuid: "feature-uuid", Budget: 5000}).Once()
+		mockDb.On("GetFeatureBounties", "feature-uuid").Return([]db.NewBounty{{ID: 1, Price: 3000}, {ID: 2, Price: 1500}}).Once()
+
+		body := []byte(`{"type": "coding", "title": "bounty", "description": "description", "price": 1000, "phase_uuid": "phase-uuid"}`)
+		rr := httptest.NewRecorder()
+		req, _ := http.NewRequestWithContext(authorizedCtx, http.MethodPost, "/", bytes.NewReader(body))
+
+		http.HandlerFunc(bHandler.CreateOrEditBounty).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"available":500`)
+		mockDb.AssertNotCalled(t, "WithTx", mock.Anything)
+	})
+
+	t.Run("should leave the edited bounty out of the used budget", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		bHandler := NewBountyHandler(http.DefaultClient, mockDb)
+
+		mockDb.On("GetBounty", uint(2)).Return(db.NewBounty{ID: 2, OwnerID: "owner-pubkey", Price: 1500, Version: 1}).Once()
+		mockDb.On("GetPhaseByUuid", "phase-uuid").Return(phase, nil).Once()
+		mockDb.On("GetFeatureByUuid", "feature-uuid").Return(db.WorkspaceFeatures{Uuid: "feature-uuid", Budget: 5000}).Once()
+		mockDb.On("GetFeatureBounties", "feature-uuid").Return([]db.NewBounty{{ID: 1, Price: 3000}, {ID: 2, Price: 1500}}).Once()
+		mockDb.On("IsBannedPubkey", "owner-pubkey").Return(false).Once()
+		mockDb.On("LockBountyVersion", uint(2)).Return(1, nil).Once()
+		mockDb.On("UpdateBountyBoolColumn", mock.AnythingOfType("db.NewBounty"), "show").Return(db.NewBounty{}, nil)
+		mockDb.On("CreateOrEditBounty", mock.AnythingOfType("db.NewBounty")).Return(db.NewBounty{ID: 2, Price: 2000}, nil).Once()
+		mockDb.On("WithTx", mock.Anything).Return(func(fn func(db.Database) error) error { return fn(mockDb) }).Once()
+		mockDb.On("SaveMentions", db.MentionBounty, "2", []db.Mention{}).Return([]db.Mention{}, nil).Once()
+
+		body := []byte(`{"id": 2, "type": "coding", "title": "bounty", "description": "description", "price": 2000, "assignee": "hunter", "phase_uuid": "phase-uuid", "version": 1}`)
+		rr := httptest.NewRecorder()
+		req, _ := http.NewRequestWithContext(authorizedCtx, http.MethodPost, "/", bytes.NewReader(body))
+
+		http.HandlerFunc(bHandler.CreateOrEditBounty).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		mockDb.AssertExpectations(t)
+	})
+}
+
+func TestWithTxRollback(t *testing.T) {
+	database := dbtest.New(t)
+
+	t.Run("should keep the assignee when the transaction is rolled back", func(t *testing.T) {
+
+		bounty := db.NewBounty{
+			Type:          "coding",
+			Title:         "rollback bounty",
+			Description:   "rollback bounty description",
+			WorkspaceUuid: "work-rollback",
+			OwnerID:       "owner-pubkey",
+			Assignee:      "assignee-pubkey",
+			Show:          true,
+			Created:       111111199,
+		}
+		created, err := database.CreateOrEditBounty(bounty)
+		assert.NoError(t, err)
+
+		err = database.WithTx(func(tx db.Database) error {
+			tx.UpdateBountyNullColumn(created, "assignee")
+			return errors.New("edit failed")
+		})
+		assert.Error(t, err)
+
+		stored := database.GetBounty(created.ID)
+		assert.Equal(t, "assignee-pubkey", stored.Assignee)
+	})
+}
+
 func TestMakeBountyPayment(t *testing.T) {
 	ctx := context.Background()
 	mockDb := &dbMocks.Database{}
@@ -1191,8 +1362,8 @@ func TestMakeBountyPayment(t *testing.T) {
 		defer ws.Close()
 
 		mockClient := db.Client{
-			Host: "mocked_host",
-			Conn: ws,
+			Host:   "mocked_host",
+			Writer: ws,
 		}
 
 		return mockClient, nil
@@ -1349,6 +1520,34 @@ func TestMakeBountyPayment(t *testing.T) {
 
 	})
 
+	t.Run("422 error when the workspace requires an approved proof the bounty doesn't have", func(t *testing.T) {
+		ctx := context.WithValue(context.Background(), auth.ContextKey, "valid-key")
+
+		mockDb := dbMocks.NewDatabase(t)
+		mockHttpClient := mocks.NewHttpClient(t)
+		bHandler := NewBountyHandler(mockHttpClient, mockDb)
+		bHandler.userHasAccess = mockUserHasAccessTrue
+		mockDb.On("GetBounty", mock.AnythingOfType("uint")).Return(bounty, nil)
+		mockDb.On("GetWorkspaceBudget", "work-1").Return(db.NewBountyBudget{TotalBudget: 2000}, nil)
+		mockDb.On("GetWorkspaceByUuid", "work-1").Return(db.Workspace{Uuid: "work-1", PaymentRequiresProof: true})
+		mockDb.On("HasApprovedBountyProof", bounty.ID).Return(false)
+
+		r := chi.NewRouter()
+		r.Post("/gobounties/pay/{id}", bHandler.MakeBountyPayment)
+
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, "/gobounties/pay/1", bytes.NewBufferString("{}"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		r.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+		assert.Contains(t, rr.Body.String(), DoneProofApproved)
+		mockHttpClient.AssertNotCalled(t, "Do", mock.Anything)
+	})
+
 	t.Run("Should test that a successful WebSocket message is sent if the payment is successful", func(t *testing.T) {
 		mockDb.ExpectedCalls = nil
 		bHandler.getSocketConnections = mockGetSocketConnections
@@ -1356,8 +1555,11 @@ func TestMakeBountyPayment(t *testing.T) {
 
 		mockDb.On("GetBounty", bountyID).Return(bounty, nil)
 		mockDb.On("GetWorkspaceBudget", bounty.WorkspaceUuid).Return(db.NewBountyBudget{TotalBudget: 2000}, nil)
+		mockDb.On("GetWorkspaceByUuid", bounty.WorkspaceUuid).Return(db.Workspace{Uuid: bounty.WorkspaceUuid})
+		mockDb.On("ClaimBountyPayment", bountyID).Return(true, nil).Once()
 		mockDb.On("GetPersonByPubkey", bounty.Assignee).Return(db.Person{OwnerPubKey: "assignee-1", OwnerRouteHint: "OwnerRouteHint"}, nil)
-		mockDb.On("ProcessBountyPayment", mock.AnythingOfType("db.NewPaymentHistory"), mock.AnythingOfType("db.NewBounty")).Return(nil)
+		mockDb.On("CreatePendingPayment", mock.AnythingOfType("db.NewPaymentHistory")).Return(db.NewPaymentHistory{ID: 1, State: db.PaymentPending}, nil).Once()
+		mockDb.On("ReconcilePayment", uint(1), true, "").Return(db.NewPaymentHistory{ID: 1, Status: true}, nil).Once()
 
 		expectedUrl := fmt.Sprintf("%s/payment", config.RelayUrl)
 		expectedBody := `{"amount": 1000, "destination_key": "assignee-1", "route_hint": "OwnerRouteHint", "text": "memotext added for notification"}`
@@ -1399,7 +1601,11 @@ func TestMakeBountyPayment(t *testing.T) {
 
 		mockDb2.On("GetBounty", bountyID).Return(bounty, nil)
 		mockDb2.On("GetWorkspaceBudget", bounty.WorkspaceUuid).Return(db.NewBountyBudget{TotalBudget: 2000}, nil)
+		mockDb2.On("GetWorkspaceByUuid", bounty.WorkspaceUuid).Return(db.Workspace{Uuid: bounty.WorkspaceUuid})
 		mockDb2.On("GetPersonByPubkey", bounty.Assignee).Return(db.Person{OwnerPubKey: "assignee-1", OwnerRouteHint: "OwnerRouteHint"}, nil)
+		mockDb2.On("ClaimBountyPayment", bountyID).Return(true, nil).Once()
+		mockDb2.On("CreatePendingPayment", mock.AnythingOfType("db.NewPaymentHistory")).Return(db.NewPaymentHistory{ID: 1, State: db.PaymentPending}, nil).Once()
+		mockDb2.On("ReconcilePayment", uint(1), false, "").Return(db.NewPaymentHistory{ID: 1, State: db.PaymentFailed}, nil).Once()
 
 		expectedUrl := fmt.Sprintf("%s/payment", config.RelayUrl)
 		expectedBody := `{"amount": 1000, "destination_key": "assignee-1", "route_hint": "OwnerRouteHint", "text": "memotext added for notification"}`
@@ -1429,179 +1635,654 @@ func TestMakeBountyPayment(t *testing.T) {
 		mockDb2.AssertExpectations(t)
 		mockHttpClient2.AssertExpectations(t)
 	})
-}
 
-func TestBountyBudgetWithdraw(t *testing.T) {
-	ctx := context.Background()
-	mockDb := dbMocks.NewDatabase(t)
-	mockHttpClient := mocks.NewHttpClient(t)
-	mockUserHasAccessTrue := func(pubKeyFromAuth string, uuid string, role string) bool {
-		return true
-	}
-	mockUserHasAccessFalse := func(pubKeyFromAuth string, uuid string, role string) bool {
-		return false
-	}
-	bHandler := NewBountyHandler(mockHttpClient, mockDb)
-	unauthorizedCtx := context.WithValue(context.Background(), auth.ContextKey, "")
-	authorizedCtx := context.WithValue(ctx, auth.ContextKey, "valid-key")
+	t.Run("Should record the tracked time with the payment when asked", func(t *testing.T) {
+		mockDb3 := &dbMocks.Database{}
+		mockHttpClient3 := &mocks.HttpClient{}
+
+		bHandler3 := NewBountyHandler(mockHttpClient3, mockDb3)
+		bHandler3.getSocketConnections = mockGetSocketConnections
+		bHandler3.userHasAccess = mockUserHasAccessTrue
+
+		mockDb3.On("GetBounty", bountyID).Return(bounty, nil)
+		mockDb3.On("GetWorkspaceBudget", bounty.WorkspaceUuid).Return(db.NewBountyBudget{TotalBudget: 2000}, nil)
+		mockDb3.On("GetWorkspaceByUuid", bounty.WorkspaceUuid).Return(db.Workspace{Uuid: bounty.WorkspaceUuid})
+		mockDb3.On("GetBountyTrackedMinutes", bountyID, bounty.Assignee).Return(uint(150)).Once()
+		mockDb3.On("ClaimBountyPayment", bountyID).Return(true, nil).Once()
+		mockDb3.On("GetPersonByPubkey", bounty.Assignee).Return(db.Person{OwnerPubKey: "assignee-1", OwnerRouteHint: "OwnerRouteHint"}, nil)
+		mockDb3.On("CreatePendingPayment", mock.MatchedBy(func(p db.NewPaymentHistory) bool {
+			return p.TrackedMinutes == 150 && !p.AutoInitiated
+		})).Return(db.NewPaymentHistory{ID: 1, State: db.PaymentPending}, nil).Once()
+		mockDb3.On("ReconcilePayment", uint(1), true, "").Return(db.NewPaymentHistory{ID: 1, Status: true}, nil).Once()
+		mockHttpClient3.On("Do", mock.Anything).Return(&http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(bytes.NewReader([]byte(`{"success": true, "response": { "sumAmount": "1"}}`))),
+		}, nil).Once()
 
-	t.Run("401 error if user is unauthorized", func(t *testing.T) {
-		rr := httptest.NewRecorder()
-		handler := http.HandlerFunc(bHandler.BountyBudgetWithdraw)
+		ro := chi.NewRouter()
+		ro.Post("/gobounties/pay/{id}", bHandler3.MakeBountyPayment)
 
-		req, err := http.NewRequestWithContext(unauthorizedCtx, http.MethodPost, "/budget/withdraw", nil)
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequestWithContext(authorizedCtx, http.MethodPost, "/gobounties/pay/1", bytes.NewBufferString(`{"include_tracked_time": true}`))
 		if err != nil {
 			t.Fatal(err)
 		}
 
-		handler.ServeHTTP(rr, req)
+		ro.ServeHTTP(rr, req)
 
-		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		mockDb3.AssertExpectations(t)
+		mockHttpClient3.AssertExpectations(t)
 	})
 
-	t.Run("Should test that a 406 error is returned if wrong data is passed", func(t *testing.T) {
-		rr := httptest.NewRecorder()
-		handler := http.HandlerFunc(bHandler.BountyBudgetWithdraw)
+	t.Run("Should record the payment hash and preimage the relay settled", func(t *testing.T) {
+		preimage := strings.Repeat("ab", 32)
+		raw, _ := hex.DecodeString(preimage)
+		sum := sha256.Sum256(raw)
+		paymentHash := hex.EncodeToString(sum[:])
+		mockDb4 := &dbMocks.Database{}
+		mockHttpClient4 := &mocks.HttpClient{}
+
+		bHandler4 := NewBountyHandler(mockHttpClient4, mockDb4)
+		bHandler4.getSocketConnections = mockGetSocketConnections
+		bHandler4.userHasAccess = mockUserHasAccessTrue
+
+		mockDb4.On("GetBounty", bountyID).Return(bounty, nil)
+		mockDb4.On("GetWorkspaceBudget", bounty.WorkspaceUuid).Return(db.NewBountyBudget{TotalBudget: 2000}, nil)
+		mockDb4.On("GetWorkspaceByUuid", bounty.WorkspaceUuid).Return(db.Workspace{Uuid: bounty.WorkspaceUuid})
+		mockDb4.On("ClaimBountyPayment", bountyID).Return(true, nil).Once()
+		mockDb4.On("GetPersonByPubkey", bounty.Assignee).Return(db.Person{OwnerPubKey: "assignee-1", OwnerRouteHint: "OwnerRouteHint"}, nil)
+		mockDb4.On("CreatePendingPayment", mock.AnythingOfType("db.NewPaymentHistory")).Return(db.NewPaymentHistory{ID: 1, State: db.PaymentPending}, nil).Once()
+		mockDb4.On("ReconcilePayment", uint(1), true, paymentHash).Return(db.NewPaymentHistory{ID: 1, Status: true, PaymentHash: paymentHash}, nil).Once()
+		mockDb4.On("SetPaymentPreimage", uint(1), preimage).Return(nil).Once()
+		mockHttpClient4.On("Do", mock.Anything).Return(&http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(bytes.NewReader([]byte(`{"success": true, "response": { "payment_hash": "` + paymentHash + `", "preimage": "` + preimage + `"}}`))),
+		}, nil).Once()
 
-		invalidJson := []byte(`"key": "value"`)
+		ro := chi.NewRouter()
+		ro.Post("/gobounties/pay/{id}", bHandler4.MakeBountyPayment)
 
-		req, err := http.NewRequestWithContext(authorizedCtx, http.MethodPost, "/budget/withdraw", bytes.NewReader(invalidJson))
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequestWithContext(authorizedCtx, http.MethodPost, "/gobounties/pay/1", bytes.NewBufferString(`{}`))
 		if err != nil {
 			t.Fatal(err)
 		}
-		handler.ServeHTTP(rr, req)
-		assert.Equal(t, http.StatusNotAcceptable, rr.Code)
+
+		ro.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		mockDb4.AssertExpectations(t)
 	})
 
-	t.Run("401 error if user is not the workspace admin or does not have WithdrawBudget role", func(t *testing.T) {
-		bHandler.userHasAccess = mockUserHasAccessFalse
+	t.Run("405 when another payment claimed the bounty first", func(t *testing.T) {
+		mockDb5 := &dbMocks.Database{}
+		mockHttpClient5 := &mocks.HttpClient{}
 
-		rr := httptest.NewRecorder()
-		handler := http.HandlerFunc(bHandler.BountyBudgetWithdraw)
+		bHandler5 := NewBountyHandler(mockHttpClient5, mockDb5)
+		bHandler5.getSocketConnections = mockGetSocketConnections
+		bHandler5.userHasAccess = mockUserHasAccessTrue
 
-		validData := []byte(`{"orgUuid": "org-1", "paymentRequest": "invoice"}`)
-		req, err := http.NewRequestWithContext(authorizedCtx, http.MethodPost, "/budget/withdraw", bytes.NewReader(validData))
+		mockDb5.On("GetBounty", bountyID).Return(bounty, nil)
+		mockDb5.On("GetWorkspaceBudget", bounty.WorkspaceUuid).Return(db.NewBountyBudget{TotalBudget: 2000}, nil)
+		mockDb5.On("GetWorkspaceByUuid", bounty.WorkspaceUuid).Return(db.Workspace{Uuid: bounty.WorkspaceUuid})
+		mockDb5.On("ClaimBountyPayment", bountyID).Return(false, nil).Once()
+
+		ro := chi.NewRouter()
+		ro.Post("/gobounties/pay/{id}", bHandler5.MakeBountyPayment)
+
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequestWithContext(authorizedCtx, http.MethodPost, "/gobounties/pay/1", bytes.NewBufferString(`{}`))
 		if err != nil {
 			t.Fatal(err)
 		}
 
-		handler.ServeHTTP(rr, req)
+		ro.ServeHTTP(rr, req)
 
-		assert.Equal(t, http.StatusUnauthorized, rr.Code)
-		assert.Contains(t, rr.Body.String(), "You don't have appropriate permissions to withdraw bounty budget")
+		assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+		mockDb5.AssertExpectations(t)
+		mockHttpClient5.AssertNotCalled(t, "Do", mock.Anything)
 	})
 
-	t.Run("403 error when amount exceeds workspace's budget", func(t *testing.T) {
-		ctxs := context.WithValue(context.Background(), auth.ContextKey, "valid-key")
-		mockDb := dbMocks.NewDatabase(t)
-		mockHttpClient := mocks.NewHttpClient(t)
-		bHandler := NewBountyHandler(mockHttpClient, mockDb)
-		bHandler.userHasAccess = mockUserHasAccessTrue
-
-		mockDb.On("GetWorkspaceBudget", "org-1").Return(db.NewBountyBudget{
-			TotalBudget: 500,
-		}, nil)
-		invoice := "lnbc15u1p3xnhl2pp5jptserfk3zk4qy42tlucycrfwxhydvlemu9pqr93tuzlv9cc7g3sdqsvfhkcap3xyhx7un8cqzpgxqzjcsp5f8c52y2stc300gl6s4xswtjpc37hrnnr3c9wvtgjfuvqmpm35evq9qyyssqy4lgd8tj637qcjp05rdpxxykjenthxftej7a2zzmwrmrl70fyj9hvj0rewhzj7jfyuwkwcg9g2jpwtk3wkjtwnkdks84hsnu8xps5vsq4gj5hs"
-
-		amount := utils.GetInvoiceAmount(invoice)
-		assert.Equal(t, uint(1500), amount)
+	t.Run("202 and a pending payment with its hash when the payment is in flight", func(t *testing.T) {
+		mockDb7 := &dbMocks.Database{}
+		mockHttpClient7 := &mocks.HttpClient{}
+
+		bHandler7 := NewBountyHandler(mockHttpClient7, mockDb7)
+		bHandler7.getSocketConnections = mockGetSocketConnections
+		bHandler7.userHasAccess = mockUserHasAccessTrue
+
+		mockDb7.On("GetBounty", bountyID).Return(bounty, nil)
+		mockDb7.On("GetWorkspaceBudget", bounty.WorkspaceUuid).Return(db.NewBountyBudget{TotalBudget: 2000}, nil)
+		mockDb7.On("GetWorkspaceByUuid", bounty.WorkspaceUuid).Return(db.Workspace{Uuid: bounty.WorkspaceUuid})
+		mockDb7.On("ClaimBountyPayment", bountyID).Return(true, nil).Once()
+		mockDb7.On("GetPersonByPubkey", bounty.Assignee).Return(db.Person{OwnerPubKey: "assignee-1"}, nil)
+		mockDb7.On("CreatePendingPayment", mock.MatchedBy(func(p db.NewPaymentHistory) bool {
+			return p.BountyId == bountyID
+		})).Return(db.NewPaymentHistory{ID: 1, State: db.PaymentPending}, nil).Once()
+		mockDb7.On("SetPaymentHash", uint(1), "payment_hash").Return(nil).Once()
+		mockHttpClient7.On("Do", mock.Anything).Return(&http.Response{
+			StatusCode: http.StatusAccepted,
+			Body:       io.NopCloser(bytes.NewReader([]byte(`{"success": true, "response": { "payment_hash": "payment_hash"}}`))),
+		}, nil).Once()
 
-		withdrawRequest := db.WithdrawBudgetRequest{
-			PaymentRequest: invoice,
-			OrgUuid:        "org-1",
-		}
-		requestBody, _ := json.Marshal(withdrawRequest)
-		req, _ := http.NewRequestWithContext(ctxs, http.MethodPost, "/budget/withdraw", bytes.NewReader(requestBody))
+		ro := chi.NewRouter()
+		ro.Post("/gobounties/pay/{id}", bHandler7.MakeBountyPayment)
 
 		rr := httptest.NewRecorder()
+		req, _ := http.NewRequestWithContext(authorizedCtx, http.MethodPost, "/gobounties/pay/1", bytes.NewBufferString(`{}`))
+		ro.ServeHTTP(rr, req)
 
-		bHandler.BountyBudgetWithdraw(rr, req)
-
-		assert.Equal(t, http.StatusForbidden, rr.Code, "Expected 403 Forbidden when the payment exceeds the workspace's budget")
-		assert.Contains(t, rr.Body.String(), "Workspace budget is not enough to withdraw the amount", "Expected specific error message")
+		assert.Equal(t, http.StatusAccepted, rr.Code)
+		mockDb7.AssertExpectations(t)
+		mockDb7.AssertNotCalled(t, "ReconcilePayment", mock.Anything, mock.Anything, mock.Anything)
 	})
 
-	t.Run("budget invoices get paid if amount is lesser than workspace's budget", func(t *testing.T) {
-		ctxs := context.WithValue(context.Background(), auth.ContextKey, "valid-key")
-		mockDb := dbMocks.NewDatabase(t)
-		mockHttpClient := mocks.NewHttpClient(t)
-		bHandler := NewBountyHandler(mockHttpClient, mockDb)
-		bHandler.userHasAccess = mockUserHasAccessTrue
-
-		paymentAmount := uint(1500)
+	t.Run("202 and a pending payment when the relay times out", func(t *testing.T) {
+		mockDb6 := &dbMocks.Database{}
+		mockHttpClient6 := &mocks.HttpClient{}
 
-		mockDb.On("GetWorkspaceBudget", "org-1").Return(db.NewBountyBudget{
-			TotalBudget: 5000,
-		}, nil)
-		mockDb.On("WithdrawBudget", "valid-key", "org-1", paymentAmount).Return(nil)
-		mockHttpClient.On("Do", mock.AnythingOfType("*http.Request")).Return(&http.Response{
-			StatusCode: 200,
-			Body:       io.NopCloser(bytes.NewBufferString(`{"success": true}`)),
-		}, nil)
+		bHandler6 := NewBountyHandler(mockHttpClient6, mockDb6)
+		bHandler6.getSocketConnections = mockGetSocketConnections
+		bHandler6.userHasAccess = mockUserHasAccessTrue
 
-		invoice := "lnbc15u1p3xnhl2pp5jptserfk3zk4qy42tlucycrfwxhydvlemu9pqr93tuzlv9cc7g3sdqsvfhkcap3xyhx7un8cqzpgxqzjcsp5f8c52y2stc300gl6s4xswtjpc37hrnnr3c9wvtgjfuvqmpm35evq9qyyssqy4lgd8tj637qcjp05rdpxxykjenthxftej7a2zzmwrmrl70fyj9hvj0rewhzj7jfyuwkwcg9g2jpwtk3wkjtwnkdks84hsnu8xps5vsq4gj5hs"
+		mockDb6.On("GetBounty", bountyID).Return(bounty, nil)
+		mockDb6.On("GetWorkspaceBudget", bounty.WorkspaceUuid).Return(db.NewBountyBudget{TotalBudget: 2000}, nil)
+		mockDb6.On("GetWorkspaceByUuid", bounty.WorkspaceUuid).Return(db.Workspace{Uuid: bounty.WorkspaceUuid})
+		mockDb6.On("ClaimBountyPayment", bountyID).Return(true, nil).Once()
+		mockDb6.On("GetPersonByPubkey", bounty.Assignee).Return(db.Person{OwnerPubKey: "assignee-1", OwnerRouteHint: "OwnerRouteHint"}, nil)
+		mockDb6.On("CreatePendingPayment", mock.MatchedBy(func(p db.NewPaymentHistory) bool {
+			return p.BountyId == bountyID && p.Amount == bounty.Price && p.WorkspaceUuid == bounty.WorkspaceUuid
+		})).Return(db.NewPaymentHistory{ID: 1, State: db.PaymentPending}, nil).Once()
+		mockHttpClient6.On("Do", mock.Anything).Return(nil, &upstream.Error{Service: upstream.Relay, Timeout: true, Err: context.DeadlineExceeded}).Once()
 
-		withdrawRequest := db.WithdrawBudgetRequest{
-			PaymentRequest: invoice,
-			OrgUuid:        "org-1",
-		}
-		requestBody, _ := json.Marshal(withdrawRequest)
-		req, _ := http.NewRequestWithContext(ctxs, http.MethodPost, "/budget/withdraw", bytes.NewReader(requestBody))
+		ro := chi.NewRouter()
+		ro.Post("/gobounties/pay/{id}", bHandler6.MakeBountyPayment)
 
 		rr := httptest.NewRecorder()
+		req, err := http.NewRequestWithContext(authorizedCtx, http.MethodPost, "/gobounties/pay/1", bytes.NewBufferString(`{}`))
+		if err != nil {
+			t.Fatal(err)
+		}
 
-		bHandler.BountyBudgetWithdraw(rr, req)
-		assert.Equal(t, http.StatusOK, rr.Code)
-		var response db.InvoicePaySuccess
-		err := json.Unmarshal(rr.Body.Bytes(), &response)
-		assert.NoError(t, err)
-		assert.True(t, response.Success, "Expected invoice payment to succeed")
+		ro.ServeHTTP(rr, req)
 
-		mockDb.AssertCalled(t, "WithdrawBudget", "valid-key", "org-1", paymentAmount)
+		assert.Equal(t, http.StatusAccepted, rr.Code)
+		mockDb6.AssertExpectations(t)
+		mockDb6.AssertNotCalled(t, "ReconcilePayment", mock.Anything, mock.Anything, mock.Anything)
 	})
 
-	t.Run("400 BadRequest error if there is an error with invoice payment", func(t *testing.T) {
-		ctxs := context.WithValue(context.Background(), auth.ContextKey, "valid-key")
-		mockDb := dbMocks.NewDatabase(t)
-		mockHttpClient := mocks.NewHttpClient(t)
-		bHandler := NewBountyHandler(mockHttpClient, mockDb)
-		bHandler.userHasAccess = mockUserHasAccessTrue
+	t.Run("403 when another payment spent the budget first", func(t *testing.T) {
+		mockDb8 := &dbMocks.Database{}
+		mockHttpClient8 := &mocks.HttpClient{}
 
-		mockDb.On("GetWorkspaceBudget", "org-1").Return(db.NewBountyBudget{
-			TotalBudget: 5000,
-		}, nil)
-		mockHttpClient.On("Do", mock.AnythingOfType("*http.Request")).Return(&http.Response{
-			StatusCode: 400,
-			Body:       io.NopCloser(bytes.NewBufferString(`{"success": false, "error": "Payment error"}`)),
-		}, nil)
+		bHandler8 := NewBountyHandler(mockHttpClient8, mockDb8)
+		bHandler8.getSocketConnections = mockGetSocketConnections
+		bHandler8.userHasAccess = mockUserHasAccessTrue
 
-		invoice := "lnbc15u1p3xnhl2pp5jptserfk3zk4qy42tlucycrfwxhydvlemu9pqr93tuzlv9cc7g3sdqsvfhkcap3xyhx7un8cqzpgxqzjcsp5f8c52y2stc300gl6s4xswtjpc37hrnnr3c9wvtgjfuvqmpm35evq9qyyssqy4lgd8tj637qcjp05rdpxxykjenthxftej7a2zzmwrmrl70fyj9hvj0rewhzj7jfyuwkwcg9g2jpwtk3wkjtwnkdks84hsnu8xps5vsq4gj5hs"
+		mockDb8.On("GetBounty", bountyID).Return(bounty, nil)
+		mockDb8.On("GetWorkspaceBudget", bounty.WorkspaceUuid).Return(db.NewBountyBudget{TotalBudget: 2000}, nil)
+		mockDb8.On("GetWorkspaceByUuid", bounty.WorkspaceUuid).Return(db.Workspace{Uuid: bounty.WorkspaceUuid})
+		mockDb8.On("ClaimBountyPayment", bountyID).Return(true, nil).Once()
+		mockDb8.On("GetPersonByPubkey", bounty.Assignee).Return(db.Person{OwnerPubKey: "assignee-1"}, nil)
+		mockDb8.On("CreatePendingPayment", mock.AnythingOfType("db.NewPaymentHistory")).Return(db.NewPaymentHistory{}, db.ErrBudgetNotEnough).Once()
+		mockDb8.On("ReleaseBountyPayment", bountyID).Return(nil).Once()
 
-		withdrawRequest := db.WithdrawBudgetRequest{
-			PaymentRequest: invoice,
-			OrgUuid:        "org-1",
-		}
-		requestBody, _ := json.Marshal(withdrawRequest)
-		req, _ := http.NewRequestWithContext(ctxs, http.MethodPost, "/budget/withdraw", bytes.NewReader(requestBody))
+		ro := chi.NewRouter()
+		ro.Post("/gobounties/pay/{id}", bHandler8.MakeBountyPayment)
 
 		rr := httptest.NewRecorder()
+		req, _ := http.NewRequestWithContext(authorizedCtx, http.MethodPost, "/gobounties/pay/1", bytes.NewBufferString(`{}`))
+		ro.ServeHTTP(rr, req)
 
-		bHandler.BountyBudgetWithdraw(rr, req)
-
-		assert.Equal(t, http.StatusBadRequest, rr.Code)
-		var response map[string]interface{}
-		err := json.Unmarshal(rr.Body.Bytes(), &response)
-		assert.NoError(t, err)
-		assert.False(t, response["success"].(bool))
-		assert.Equal(t, "Payment error", response["error"].(string))
-		mockHttpClient.AssertCalled(t, "Do", mock.AnythingOfType("*http.Request"))
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+		mockDb8.AssertExpectations(t)
+		mockHttpClient8.AssertNotCalled(t, "Do", mock.Anything)
 	})
+}
 
-	t.Run("Should test that an Workspace's Budget Total Amount is accurate after three (3) successful 'Budget Withdrawal Requests'", func(t *testing.T) {
-		ctxs := context.WithValue(context.Background(), auth.ContextKey, "valid-key")
-		mockDb := dbMocks.NewDatabase(t)
-		mockHttpClient := mocks.NewHttpClient(t)
-		bHandler := NewBountyHandler(mockHttpClient, mockDb)
+func TestUpdateCompletedStatus(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.ContextKey, "owner-pubkey")
+	bounty := db.NewBounty{
+		ID:            1,
+		Created:       1700000000,
+		WorkspaceUuid: "work-1",
+		Assignee:      "assignee-1",
+		Price:         1000,
+	}
+	completed := bounty
+	completed.Completed = true
+	newRequest := func() *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("created", "1700000000")
+		req, _ := http.NewRequestWithContext(context.WithValue(ctx, chi.RouteCtxKey, rctx), http.MethodPost, "/gobounties/completedstatus/1700000000", nil)
+		return req
+	}
+	payOk := func(mockHttpClient *mocks.HttpClient) {
+		mockHttpClient.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+			return req.URL.String() == fmt.Sprintf("%s/payment", config.RelayUrl)
+		})).Return(&http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(bytes.NewReader([]byte(`{"success": true, "response": { "sumAmount": "1"}}`))),
+		}, nil).Once()
+	}
+
+	t.Run("should only mark the bounty completed without auto-pay", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mockHttpClient := &mocks.HttpClient{}
+		bHandler := NewBountyHandler(mockHttpClient, mockDb)
+
+		mockDb.On("GetBountyByCreated", uint(1700000000)).Return(bounty, nil).Once()
+		mockDb.On("UpdateBountyCompleted", mock.MatchedBy(func(b db.NewBounty) bool { return b.Completed })).Return(bounty, nil).Once()
+		mockDb.On("GetWorkspaceByUuid", "work-1").Return(db.Workspace{Uuid: "work-1", OwnerPubKey: "owner-pubkey"}).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(bHandler.UpdateCompletedStatus).ServeHTTP(rr, newRequest())
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		result := db.NewBounty{}
+		json.Unmarshal(rr.Body.Bytes(), &result)
+		assert.True(t, result.Completed)
+		assert.False(t, result.Paid)
+		mockDb.AssertExpectations(t)
+		mockHttpClient.AssertNotCalled(t, "Do", mock.Anything)
+	})
+
+	t.Run("should pay the bounty when the workspace has auto-pay", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mockHttpClient := &mocks.HttpClient{}
+		bHandler := NewBountyHandler(mockHttpClient, mockDb)
+		bHandler.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool { return role == db.PayBounty }
+
+		mockDb.On("GetBountyByCreated", uint(1700000000)).Return(bounty, nil).Once()
+		mockDb.On("UpdateBountyCompleted", mock.Anything).Return(bounty, nil).Once()
+		mockDb.On("GetWorkspaceByUuid", "work-1").Return(db.Workspace{Uuid: "work-1", OwnerPubKey: "owner-pubkey", AutoPay: true, AutoPayCap: 5000}).Once()
+		mockDb.On("GetBounty", uint(1)).Return(completed).Once()
+		mockDb.On("GetWorkspaceBudget", "work-1").Return(db.NewBountyBudget{TotalBudget: 2000}).Once()
+		mockDb.On("ClaimBountyPayment", uint(1)).Return(true, nil).Once()
+		mockDb.On("GetPersonByPubkey", "assignee-1").Return(db.Person{OwnerPubKey: "assignee-1"}).Once()
+		mockDb.On("CreatePendingPayment", mock.MatchedBy(func(p db.NewPaymentHistory) bool {
+			return p.AutoInitiated && p.Amount == 1000 && p.SenderPubKey == "owner-pubkey" && p.ReceiverPubKey == "assignee-1"
+		})).Return(db.NewPaymentHistory{ID: 1, State: db.PaymentPending}, nil).Once()
+		mockDb.On("ReconcilePayment", uint(1), true, "").Return(db.NewPaymentHistory{ID: 1, Status: true}, nil).Once()
+		payOk(mockHttpClient)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(bHandler.UpdateCompletedStatus).ServeHTTP(rr, newRequest())
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		result := db.NewBounty{}
+		json.Unmarshal(rr.Body.Bytes(), &result)
+		assert.True(t, result.Paid)
+		mockDb.AssertExpectations(t)
+		mockHttpClient.AssertExpectations(t)
+	})
+
+	t.Run("should not auto-pay a bounty that was paid meanwhile", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mockHttpClient := &mocks.HttpClient{}
+		bHandler := NewBountyHandler(mockHttpClient, mockDb)
+		bHandler.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool { return true }
+		paid := completed
+		paid.Paid = true
+
+		mockDb.On("GetBountyByCreated", uint(1700000000)).Return(bounty, nil).Once()
+		mockDb.On("UpdateBountyCompleted", mock.Anything).Return(bounty, nil).Once()
+		mockDb.On("GetWorkspaceByUuid", "work-1").Return(db.Workspace{Uuid: "work-1", OwnerPubKey: "owner-pubkey", AutoPay: true}).Once()
+		mockDb.On("GetBounty", uint(1)).Return(paid).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(bHandler.UpdateCompletedStatus).ServeHTTP(rr, newRequest())
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		result := db.NewBounty{}
+		json.Unmarshal(rr.Body.Bytes(), &result)
+		assert.True(t, result.Paid)
+		mockDb.AssertExpectations(t)
+		mockDb.AssertNotCalled(t, "GetWorkspaceBudget", mock.Anything)
+		mockHttpClient.AssertNotCalled(t, "Do", mock.Anything)
+	})
+
+	t.Run("should leave a bounty over the cap to a manual payment", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mockHttpClient := &mocks.HttpClient{}
+		bHandler := NewBountyHandler(mockHttpClient, mockDb)
+		bHandler.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool { return true }
+
+		mockDb.On("GetBountyByCreated", uint(1700000000)).Return(bounty, nil).Once()
+		mockDb.On("UpdateBountyCompleted", mock.Anything).Return(bounty, nil).Once()
+		mockDb.On("GetWorkspaceByUuid", "work-1").Return(db.Workspace{Uuid: "work-1", OwnerPubKey: "owner-pubkey", AutoPay: true, AutoPayCap: 500}).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(bHandler.UpdateCompletedStatus).ServeHTTP(rr, newRequest())
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		mockDb.AssertExpectations(t)
+		mockHttpClient.AssertNotCalled(t, "Do", mock.Anything)
+	})
+
+	t.Run("should not auto-pay a bounty without an approved proof when the workspace requires one", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mockHttpClient := &mocks.HttpClient{}
+		bHandler := NewBountyHandler(mockHttpClient, mockDb)
+		bHandler.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool { return true }
+
+		mockDb.On("GetBountyByCreated", uint(1700000000)).Return(bounty, nil).Once()
+		mockDb.On("UpdateBountyCompleted", mock.Anything).Return(bounty, nil).Once()
+		mockDb.On("GetWorkspaceByUuid", "work-1").Return(db.Workspace{Uuid: "work-1", OwnerPubKey: "owner-pubkey", AutoPay: true, PaymentRequiresProof: true}).Once()
+		mockDb.On("HasApprovedBountyProof", uint(1)).Return(false).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(bHandler.UpdateCompletedStatus).ServeHTTP(rr, newRequest())
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		result := db.NewBounty{}
+		json.Unmarshal(rr.Body.Bytes(), &result)
+		assert.False(t, result.Paid)
+		mockDb.AssertExpectations(t)
+		mockHttpClient.AssertNotCalled(t, "Do", mock.Anything)
+	})
+
+	t.Run("should leave the bounty to a manual payment when the budget is short", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mockHttpClient := &mocks.HttpClient{}
+		bHandler := NewBountyHandler(mockHttpClient, mockDb)
+		bHandler.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool { return true }
+
+		mockDb.On("GetBountyByCreated", uint(1700000000)).Return(bounty, nil).Once()
+		mockDb.On("UpdateBountyCompleted", mock.Anything).Return(bounty, nil).Once()
+		mockDb.On("GetWorkspaceByUuid", "work-1").Return(db.Workspace{Uuid: "work-1", OwnerPubKey: "owner-pubkey", AutoPay: true}).Once()
+		mockDb.On("GetBounty", uint(1)).Return(completed).Once()
+		mockDb.On("GetWorkspaceBudget", "work-1").Return(db.NewBountyBudget{TotalBudget: 500}).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(bHandler.UpdateCompletedStatus).ServeHTTP(rr, newRequest())
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		mockDb.AssertExpectations(t)
+		mockHttpClient.AssertNotCalled(t, "Do", mock.Anything)
+	})
+
+	t.Run("should not auto-pay for a caller without the pay bounty role", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mockHttpClient := &mocks.HttpClient{}
+		bHandler := NewBountyHandler(mockHttpClient, mockDb)
+		bHandler.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool { return false }
+
+		mockDb.On("GetBountyByCreated", uint(1700000000)).Return(bounty, nil).Once()
+		mockDb.On("UpdateBountyCompleted", mock.Anything).Return(bounty, nil).Once()
+		mockDb.On("GetWorkspaceByUuid", "work-1").Return(db.Workspace{Uuid: "work-1", OwnerPubKey: "owner-pubkey", AutoPay: true}).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(bHandler.UpdateCompletedStatus).ServeHTTP(rr, newRequest())
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		mockDb.AssertExpectations(t)
+		mockHttpClient.AssertNotCalled(t, "Do", mock.Anything)
+	})
+
+	t.Run("should queue the auto-pay while the relay is unavailable", func(t *testing.T) {
+		defer func(queue *jobs.Queue) { jobs.Default = queue }(jobs.Default)
+		mockDb := &dbMocks.Database{}
+		jobs.Default = jobs.NewQueue(mockDb)
+		mockHttpClient := &mocks.HttpClient{}
+		bHandler := NewBountyHandler(mockHttpClient, mockDb)
+		bHandler.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool { return true }
+
+		mockDb.On("GetBountyByCreated", uint(1700000000)).Return(bounty, nil).Once()
+		mockDb.On("UpdateBountyCompleted", mock.Anything).Return(bounty, nil).Once()
+		mockDb.On("GetWorkspaceByUuid", "work-1").Return(db.Workspace{Uuid: "work-1", OwnerPubKey: "owner-pubkey", AutoPay: true}).Once()
+		mockDb.On("GetBounty", uint(1)).Return(completed).Once()
+		mockDb.On("GetWorkspaceBudget", "work-1").Return(db.NewBountyBudget{TotalBudget: 2000}).Once()
+		mockDb.On("ClaimBountyPayment", uint(1)).Return(true, nil).Once()
+		mockDb.On("GetPersonByPubkey", "assignee-1").Return(db.Person{OwnerPubKey: "assignee-1"}).Once()
+		mockDb.On("CreatePendingPayment", mock.AnythingOfType("db.NewPaymentHistory")).Return(db.NewPaymentHistory{ID: 1, State: db.PaymentPending}, nil).Once()
+		mockHttpClient.On("Do", mock.Anything).Return(nil, &upstream.Error{Service: upstream.Relay, Err: upstream.ErrCircuitOpen, RetryAfter: 30 * time.Second}).Once()
+		mockDb.On("ReconcilePayment", uint(1), false, "").Return(db.NewPaymentHistory{ID: 1, State: db.PaymentFailed}, nil).Once()
+		mockDb.On("EnqueueJob", mock.MatchedBy(func(j db.Job) bool {
+			return j.Type == AutoPayJob && j.Payload["bounty_id"] == bounty.ID && j.Payload["pubkey"] == "owner-pubkey" && j.RunAt.After(time.Now().Add(20*time.Second))
+		})).Return(db.Job{Uuid: "job-uuid", Type: AutoPayJob}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(bHandler.UpdateCompletedStatus).ServeHTTP(rr, newRequest())
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		result := db.NewBounty{}
+		json.Unmarshal(rr.Body.Bytes(), &result)
+		assert.True(t, result.Completed)
+		assert.False(t, result.Paid)
+		mockDb.AssertExpectations(t)
+		mockDb.AssertNotCalled(t, "ReconcilePayment", uint(1), true, mock.Anything)
+	})
+
+	t.Run("should keep an auto-pay the relay didn't answer pending", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mockHttpClient := &mocks.HttpClient{}
+		bHandler := NewBountyHandler(mockHttpClient, mockDb)
+		bHandler.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool { return true }
+
+		mockDb.On("GetBountyByCreated", uint(1700000000)).Return(bounty, nil).Once()
+		mockDb.On("UpdateBountyCompleted", mock.Anything).Return(bounty, nil).Once()
+		mockDb.On("GetWorkspaceByUuid", "work-1").Return(db.Workspace{Uuid: "work-1", OwnerPubKey: "owner-pubkey", AutoPay: true}).Once()
+		mockDb.On("GetBounty", uint(1)).Return(completed).Once()
+		mockDb.On("GetWorkspaceBudget", "work-1").Return(db.NewBountyBudget{TotalBudget: 2000}).Once()
+		mockDb.On("ClaimBountyPayment", uint(1)).Return(true, nil).Once()
+		mockDb.On("GetPersonByPubkey", "assignee-1").Return(db.Person{OwnerPubKey: "assignee-1"}).Once()
+		mockHttpClient.On("Do", mock.Anything).Return(nil, &upstream.Error{Service: upstream.Relay, Timeout: true, Err: context.DeadlineExceeded}).Once()
+		mockDb.On("CreatePendingPayment", mock.MatchedBy(func(p db.NewPaymentHistory) bool {
+			return p.BountyId == bounty.ID && p.AutoInitiated
+		})).Return(db.NewPaymentHistory{ID: 1, State: db.PaymentPending}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(bHandler.UpdateCompletedStatus).ServeHTTP(rr, newRequest())
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		mockDb.AssertExpectations(t)
+		mockDb.AssertNotCalled(t, "ReconcilePayment", mock.Anything, mock.Anything, mock.Anything)
+		mockDb.AssertNotCalled(t, "EnqueueJob", mock.Anything)
+	})
+}
+
+func TestBountyBudgetWithdraw(t *testing.T) {
+	ctx := context.Background()
+	mockDb := dbMocks.NewDatabase(t)
+	mockHttpClient := mocks.NewHttpClient(t)
+	mockUserHasAccessTrue := func(pubKeyFromAuth string, uuid string, role string) bool {
+		return true
+	}
+	mockUserHasAccessFalse := func(pubKeyFromAuth string, uuid string, role string) bool {
+		return false
+	}
+	bHandler := NewBountyHandler(mockHttpClient, mockDb)
+	unauthorizedCtx := context.WithValue(context.Background(), auth.ContextKey, "")
+	authorizedCtx := context.WithValue(ctx, auth.ContextKey, "valid-key")
+
+	t.Run("401 error if user is unauthorized", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(bHandler.BountyBudgetWithdraw)
+
+		req, err := http.NewRequestWithContext(unauthorizedCtx, http.MethodPost, "/budget/withdraw", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		handler.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("Should test that a 406 error is returned if wrong data is passed", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(bHandler.BountyBudgetWithdraw)
+
+		invalidJson := []byte(`"key": "value"`)
+
+		req, err := http.NewRequestWithContext(authorizedCtx, http.MethodPost, "/budget/withdraw", bytes.NewReader(invalidJson))
+		if err != nil {
+			t.Fatal(err)
+		}
+		handler.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusNotAcceptable, rr.Code)
+	})
+
+	t.Run("401 error if user is not the workspace admin or does not have WithdrawBudget role", func(t *testing.T) {
+		bHandler.userHasAccess = mockUserHasAccessFalse
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(bHandler.BountyBudgetWithdraw)
+
+		validData := []byte(`{"orgUuid": "org-1", "paymentRequest": "invoice"}`)
+		req, err := http.NewRequestWithContext(authorizedCtx, http.MethodPost, "/budget/withdraw", bytes.NewReader(validData))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		handler.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		assert.Contains(t, rr.Body.String(), "You don't have appropriate permissions to withdraw bounty budget")
+	})
+
+	t.Run("403 error when amount exceeds workspace's budget", func(t *testing.T) {
+		ctxs := context.WithValue(context.Background(), auth.ContextKey, "valid-key")
+		mockDb := dbMocks.NewDatabase(t)
+		mockHttpClient := mocks.NewHttpClient(t)
+		bHandler := NewBountyHandler(mockHttpClient, mockDb)
+		bHandler.userHasAccess = mockUserHasAccessTrue
+
+		mockDb.On("GetWorkspaceBudget", "org-1").Return(db.NewBountyBudget{
+			TotalBudget: 500,
+		}, nil)
+		invoice := "lnbc15u1p3xnhl2pp5jptserfk3zk4qy42tlucycrfwxhydvlemu9pqr93tuzlv9cc7g3sdqsvfhkcap3xyhx7un8cqzpgxqzjcsp5f8c52y2stc300gl6s4xswtjpc37hrnnr3c9wvtgjfuvqmpm35evq9qyyssqy4lgd8tj637qcjp05rdpxxykjenthxftej7a2zzmwrmrl70fyj9hvj0rewhzj7jfyuwkwcg9g2jpwtk3wkjtwnkdks84hsnu8xps5vsq4gj5hs"
+
+		amount := utils.GetInvoiceAmount(invoice)
+		assert.Equal(t, uint(1500), amount)
+
+		withdrawRequest := db.WithdrawBudgetRequest{
+			PaymentRequest: invoice,
+			OrgUuid:        "org-1",
+		}
+		requestBody, _ := json.Marshal(withdrawRequest)
+		req, _ := http.NewRequestWithContext(ctxs, http.MethodPost, "/budget/withdraw", bytes.NewReader(requestBody))
+
+		rr := httptest.NewRecorder()
+
+		bHandler.BountyBudgetWithdraw(rr, req)
+
+		assert.Equal(t, http.StatusForbidden, rr.Code, "Expected 403 Forbidden when the payment exceeds the workspace's budget")
+		assert.Contains(t, rr.Body.String(), "Workspace budget is not enough to withdraw the amount", "Expected specific error message")
+	})
+
+	t.Run("budget invoices get paid if amount is lesser than workspace's budget", func(t *testing.T) {
+		ctxs := context.WithValue(context.Background(), auth.ContextKey, "valid-key")
+		mockDb := dbMocks.NewDatabase(t)
+		mockHttpClient := mocks.NewHttpClient(t)
+		bHandler := NewBountyHandler(mockHttpClient, mockDb)
+		bHandler.userHasAccess = mockUserHasAccessTrue
+
+		paymentAmount := uint(1500)
+
+		mockDb.On("GetWorkspaceBudget", "org-1").Return(db.NewBountyBudget{
+			TotalBudget: 5000,
+		}, nil)
+		mockDb.On("CreatePendingPayment", mock.MatchedBy(func(p db.NewPaymentHistory) bool {
+			return p.PaymentType == db.Withdraw && p.Amount == paymentAmount
+		})).Return(db.NewPaymentHistory{ID: 1, State: db.PaymentPending}, nil)
+		mockDb.On("ReconcilePayment", uint(1), true, "").Return(db.NewPaymentHistory{ID: 1, Status: true}, nil)
+		mockHttpClient.On("Do", mock.AnythingOfType("*http.Request")).Return(&http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(bytes.NewBufferString(`{"success": true}`)),
+		}, nil)
+
+		invoice := "lnbc15u1p3xnhl2pp5jptserfk3zk4qy42tlucycrfwxhydvlemu9pqr93tuzlv9cc7g3sdqsvfhkcap3xyhx7un8cqzpgxqzjcsp5f8c52y2stc300gl6s4xswtjpc37hrnnr3c9wvtgjfuvqmpm35evq9qyyssqy4lgd8tj637qcjp05rdpxxykjenthxftej7a2zzmwrmrl70fyj9hvj0rewhzj7jfyuwkwcg9g2jpwtk3wkjtwnkdks84hsnu8xps5vsq4gj5hs"
+
+		withdrawRequest := db.WithdrawBudgetRequest{
+			PaymentRequest: invoice,
+			OrgUuid:        "org-1",
+		}
+		requestBody, _ := json.Marshal(withdrawRequest)
+		req, _ := http.NewRequestWithContext(ctxs, http.MethodPost, "/budget/withdraw", bytes.NewReader(requestBody))
+
+		rr := httptest.NewRecorder()
+
+		bHandler.BountyBudgetWithdraw(rr, req)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var response db.InvoicePaySuccess
+		err := json.Unmarshal(rr.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.True(t, response.Success, "Expected invoice payment to succeed")
+
+		mockDb.AssertCalled(t, "ReconcilePayment", uint(1), true, "")
+	})
+
+	t.Run("400 BadRequest error if there is an error with invoice payment", func(t *testing.T) {
+		ctxs := context.WithValue(context.Background(), auth.ContextKey, "valid-key")
+		mockDb := dbMocks.NewDatabase(t)
+		mockHttpClient := mocks.NewHttpClient(t)
+		bHandler := NewBountyHandler(mockHttpClient, mockDb)
+		bHandler.userHasAccess = mockUserHasAccessTrue
+
+		mockDb.On("GetWorkspaceBudget", "org-1").Return(db.NewBountyBudget{
+			TotalBudget: 5000,
+		}, nil)
+		mockDb.On("CreatePendingPayment", mock.AnythingOfType("db.NewPaymentHistory")).Return(db.NewPaymentHistory{ID: 1, State: db.PaymentPending}, nil).Once()
+		mockDb.On("ReconcilePayment", uint(1), false, "").Return(db.NewPaymentHistory{ID: 1, State: db.PaymentFailed}, nil).Once()
+		mockHttpClient.On("Do", mock.AnythingOfType("*http.Request")).Return(&http.Response{
+			StatusCode: 400,
+			Body:       io.NopCloser(bytes.NewBufferString(`{"success": false, "error": "Payment error"}`)),
+		}, nil)
+
+		invoice := "lnbc15u1p3xnhl2pp5jptserfk3zk4qy42tlucycrfwxhydvlemu9pqr93tuzlv9cc7g3sdqsvfhkcap3xyhx7un8cqzpgxqzjcsp5f8c52y2stc300gl6s4xswtjpc37hrnnr3c9wvtgjfuvqmpm35evq9qyyssqy4lgd8tj637qcjp05rdpxxykjenthxftej7a2zzmwrmrl70fyj9hvj0rewhzj7jfyuwkwcg9g2jpwtk3wkjtwnkdks84hsnu8xps5vsq4gj5hs"
+
+		withdrawRequest := db.WithdrawBudgetRequest{
+			PaymentRequest: invoice,
+			OrgUuid:        "org-1",
+		}
+		requestBody, _ := json.Marshal(withdrawRequest)
+		req, _ := http.NewRequestWithContext(ctxs, http.MethodPost, "/budget/withdraw", bytes.NewReader(requestBody))
+
+		rr := httptest.NewRecorder()
+
+		bHandler.BountyBudgetWithdraw(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		var response httpio.ErrorResponse
+		err := json.Unmarshal(rr.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, "Payment error", response.Message)
+		mockHttpClient.AssertCalled(t, "Do", mock.AnythingOfType("*http.Request"))
+	})
+
+	t.Run("202 and a pending withdrawal when the relay times out", func(t *testing.T) {
+		ctxs := context.WithValue(context.Background(), auth.ContextKey, "valid-key")
+		mockDb := dbMocks.NewDatabase(t)
+		mockHttpClient := mocks.NewHttpClient(t)
+		bHandler := NewBountyHandler(mockHttpClient, mockDb)
+		bHandler.userHasAccess = mockUserHasAccessTrue
+
+		invoice := "lnbc15u1p3xnhl2pp5jptserfk3zk4qy42tlucycrfwxhydvlemu9pqr93tuzlv9cc7g3sdqsvfhkcap3xyhx7un8cqzpgxqzjcsp5f8c52y2stc300gl6s4xswtjpc37hrnnr3c9wvtgjfuvqmpm35evq9qyyssqy4lgd8tj637qcjp05rdpxxykjenthxftej7a2zzmwrmrl70fyj9hvj0rewhzj7jfyuwkwcg9g2jpwtk3wkjtwnkdks84hsnu8xps5vsq4gj5hs"
+
+		mockDb.On("GetWorkspaceBudget", "org-1").Return(db.NewBountyBudget{TotalBudget: 5000}, nil)
+		mockHttpClient.On("Do", mock.AnythingOfType("*http.Request")).Return(nil, &upstream.Error{Service: upstream.Relay, Timeout: true, Err: context.DeadlineExceeded}).Once()
+		mockDb.On("CreatePendingPayment", mock.MatchedBy(func(p db.NewPaymentHistory) bool {
+			return p.PaymentType == db.Withdraw && p.Amount == 1500 && p.PaymentRequest == invoice && p.PaymentHash != ""
+		})).Return(db.NewPaymentHistory{ID: 1, State: db.PaymentPending}, nil).Once()
+
+		requestBody, _ := json.Marshal(db.WithdrawBudgetRequest{PaymentRequest: invoice, OrgUuid: "org-1"})
+		req, _ := http.NewRequestWithContext(ctxs, http.MethodPost, "/budget/withdraw", bytes.NewReader(requestBody))
+
+		rr := httptest.NewRecorder()
+		bHandler.BountyBudgetWithdraw(rr, req)
+
+		assert.Equal(t, http.StatusAccepted, rr.Code)
+		mockDb.AssertNotCalled(t, "ReconcilePayment", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should test that an Workspace's Budget Total Amount is accurate after three (3) successful 'Budget Withdrawal Requests'", func(t *testing.T) {
+		ctxs := context.WithValue(context.Background(), auth.ContextKey, "valid-key")
+		mockDb := dbMocks.NewDatabase(t)
+		mockHttpClient := mocks.NewHttpClient(t)
+		bHandler := NewBountyHandler(mockHttpClient, mockDb)
 		bHandler.userHasAccess = mockUserHasAccessTrue
 
 		paymentAmount := uint(1500)
@@ -1619,7 +2300,10 @@ func TestBountyBudgetWithdraw(t *testing.T) {
 			mockDb.On("GetWorkspaceBudget", "org-1").Return(db.NewBountyBudget{
 				TotalBudget: expectedFinalBudget,
 			}, nil)
-			mockDb.On("WithdrawBudget", "valid-key", "org-1", paymentAmount).Return(nil)
+			mockDb.On("CreatePendingPayment", mock.MatchedBy(func(p db.NewPaymentHistory) bool {
+				return p.PaymentType == db.Withdraw && p.Amount == paymentAmount
+			})).Return(db.NewPaymentHistory{ID: uint(i + 1), State: db.PaymentPending}, nil)
+			mockDb.On("ReconcilePayment", uint(i+1), true, "").Return(db.NewPaymentHistory{ID: uint(i + 1), Status: true}, nil)
 			mockHttpClient.On("Do", mock.AnythingOfType("*http.Request")).Return(&http.Response{
 				StatusCode: 200,
 				Body:       io.NopCloser(bytes.NewBufferString(`{"success": true}`)),
@@ -1691,51 +2375,241 @@ func TestPollInvoice(t *testing.T) {
 	mockHttpClient := &mocks.HttpClient{}
 	bHandler := NewBountyHandler(mockHttpClient, mockDb)
 
-	unauthorizedCtx := context.WithValue(ctx, auth.ContextKey, "")
-	authorizedCtx := context.WithValue(ctx, auth.ContextKey, "valid-key")
+	unauthorizedCtx := context.WithValue(ctx, auth.ContextKey, "")
+	authorizedCtx := context.WithValue(ctx, auth.ContextKey, "valid-key")
+
+	t.Run("Should test that a 401 error is returned if a user is unauthorized", func(t *testing.T) {
+		r := chi.NewRouter()
+		r.Post("/poll/invoice/{paymentRequest}", bHandler.PollInvoice)
+
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequestWithContext(unauthorizedCtx, http.MethodPost, "/poll/invoice/1", bytes.NewBufferString(`{}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		r.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code, "Expected 401 error if a user is unauthorized")
+	})
+
+	t.Run("Should test that a 403 error is returned if there is an invoice error", func(t *testing.T) {
+		expectedUrl := fmt.Sprintf("%s/invoice?payment_request=%s", config.RelayUrl, "1")
+
+		r := io.NopCloser(bytes.NewReader([]byte(`{"success": false, "error": "Internel server error"}`)))
+		mockHttpClient.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+			return req.Method == http.MethodGet && expectedUrl == req.URL.String() && req.Header.Get("x-user-token") == config.RelayAuthKey
+		})).Return(&http.Response{
+			StatusCode: 500,
+			Body:       r,
+		}, nil).Once()
+
+		ro := chi.NewRouter()
+		ro.Post("/poll/invoice/{paymentRequest}", bHandler.PollInvoice)
+
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequestWithContext(authorizedCtx, http.MethodPost, "/poll/invoice/1", bytes.NewBufferString(`{}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ro.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusForbidden, rr.Code, "Expected 403 error if there is an invoice error")
+		mockHttpClient.AssertExpectations(t)
+	})
+
+	t.Run("Should mock relay payment is successful update the bounty associated with the invoice and set the paid as true", func(t *testing.T) {
+		expectedUrl := fmt.Sprintf("%s/invoice?payment_request=%s", config.RelayUrl, "1")
+
+		r := io.NopCloser(bytes.NewReader([]byte(`{"success": true, "response": { "settled": true, "payment_request": "1", "payment_hash": "payment_hash", "preimage": "preimage", "Amount": "1000"}}`)))
+		mockHttpClient.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+			return req.Method == http.MethodGet && expectedUrl == req.URL.String() && req.Header.Get("x-user-token") == config.RelayAuthKey
+		})).Return(&http.Response{
+			StatusCode: 200,
+			Body:       r,
+		}, nil).Once()
+
+		bountyID := uint(1)
+		bounty := db.NewBounty{
+			ID:            bountyID,
+			WorkspaceUuid: "work-1",
+			OrgUuid:       "org-1",
+			Assignee:      "assignee-1",
+			Price:         uint(1000),
+		}
+
+		now := time.Now()
+		expectedBounty := db.NewBounty{
+			ID:             bountyID,
+			WorkspaceUuid:  "work-1",
+			OrgUuid:        "org-1",
+			Assignee:       "assignee-1",
+			Price:          uint(1000),
+			Paid:           true,
+			PaidDate:       &now,
+			CompletionDate: &now,
+		}
+
+		mockDb.On("GetInvoice", "1").Return(db.NewInvoiceList{Type: "KEYSEND"})
+		mockDb.On("GetUserInvoiceData", "1").Return(db.UserInvoiceData{Amount: 1000, UserPubkey: "UserPubkey", RouteHint: "RouteHint", Created: 1234})
+		mockDb.On("GetInvoice", "1").Return(db.NewInvoiceList{Status: false})
+		mockDb.On("GetBountyByCreated", uint(1234)).Return(bounty, nil)
+		mockDb.On("UpdateBounty", mock.AnythingOfType("db.NewBounty")).Run(func(args mock.Arguments) {
+			updatedBounty := args.Get(0).(db.NewBounty)
+			assert.True(t, updatedBounty.Paid)
+		}).Return(expectedBounty, nil).Once()
+		mockDb.On("UpdateInvoice", "1").Return(true, nil).Once()
+
+		expectedPaymentUrl := fmt.Sprintf("%s/payment", config.RelayUrl)
+		expectedPaymentBody := `{"amount": 1000, "destination_key": "UserPubkey", "route_hint": "RouteHint", "text": "memotext added for notification"}`
+
+		r2 := io.NopCloser(bytes.NewReader([]byte(`{"success": true, "response": { "sumAmount": "1"}}`)))
+		mockHttpClient.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+			bodyByt, _ := io.ReadAll(req.Body)
+			return req.Method == http.MethodPost && expectedPaymentUrl == req.URL.String() && req.Header.Get("x-user-token") == config.RelayAuthKey && expectedPaymentBody == string(bodyByt)
+		})).Return(&http.Response{
+			StatusCode: 200,
+			Body:       r2,
+		}, nil).Once()
+
+		ro := chi.NewRouter()
+		ro.Post("/poll/invoice/{paymentRequest}", bHandler.PollInvoice)
+
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequestWithContext(authorizedCtx, http.MethodPost, "/poll/invoice/1", bytes.NewBufferString(`{}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ro.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		mockHttpClient.AssertExpectations(t)
+	})
+
+	t.Run("Should record a pending payment and keep the invoice settled when the keysend times out", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mockHttpClient := &mocks.HttpClient{}
+		bHandler := NewBountyHandler(mockHttpClient, mockDb)
+
+		expectedUrl := fmt.Sprintf("%s/invoice?payment_request=%s", config.RelayUrl, "1")
+		mockHttpClient.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+			return req.Method == http.MethodGet && expectedUrl == req.URL.String()
+		})).Return(&http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(bytes.NewReader([]byte(`{"success": true, "response": { "settled": true, "payment_request": "1"}}`))),
+		}, nil).Once()
+		mockHttpClient.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+			return req.Method == http.MethodPost
+		})).Return(nil, &upstream.Error{Service: upstream.Relay, Timeout: true, Err: context.DeadlineExceeded}).Once()
+
+		mockDb.On("GetInvoice", "1").Return(db.NewInvoiceList{Type: "KEYSEND", OwnerPubkey: "owner"})
+		mockDb.On("GetUserInvoiceData", "1").Return(db.UserInvoiceData{Amount: 1000, UserPubkey: "UserPubkey", Created: 1234})
+		mockDb.On("GetBountyByCreated", uint(1234)).Return(db.NewBounty{ID: 7}, nil).Once()
+		mockDb.On("CreatePendingPayment", mock.MatchedBy(func(p db.NewPaymentHistory) bool {
+			return p.BountyId == 7 && p.Amount == 1000 && p.ReceiverPubKey == "UserPubkey" && p.PaymentRequest == "1"
+		})).Return(db.NewPaymentHistory{ID: 1, State: db.PaymentPending}, nil).Once()
+		mockDb.On("UpdateInvoice", "1").Return(true, nil).Once()
+
+		ro := chi.NewRouter()
+		ro.Post("/poll/invoice/{paymentRequest}", bHandler.PollInvoice)
+
+		rr := httptest.NewRecorder()
+		req, _ := http.NewRequestWithContext(authorizedCtx, http.MethodPost, "/poll/invoice/1", nil)
+		ro.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusAccepted, rr.Code)
+		mockDb.AssertExpectations(t)
+		mockDb.AssertNotCalled(t, "UpdateBounty", mock.Anything)
+		mockDb.AssertNotCalled(t, "ReleaseInvoice", mock.Anything)
+	})
+
+	keysendInvoice := func(mockDb *dbMocks.Database, mockHttpClient *mocks.HttpClient) {
+		mockHttpClient.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+			return req.Method == http.MethodGet
+		})).Return(&http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(bytes.NewReader([]byte(`{"success": true, "response": { "settled": true, "payment_request": "1"}}`))),
+		}, nil).Once()
+		mockDb.On("GetInvoice", "1").Return(db.NewInvoiceList{Type: "KEYSEND"})
+		mockDb.On("GetUserInvoiceData", "1").Return(db.UserInvoiceData{Amount: 1000, UserPubkey: "UserPubkey", Created: 1234})
+	}
+
+	t.Run("Should not send the keysend when a concurrent poll claimed the invoice", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mockHttpClient := &mocks.HttpClient{}
+		bHandler := NewBountyHandler(mockHttpClient, mockDb)
+		keysendInvoice(mockDb, mockHttpClient)
+		mockDb.On("UpdateInvoice", "1").Return(false, nil).Once()
+
+		ro := chi.NewRouter()
+		ro.Post("/poll/invoice/{paymentRequest}", bHandler.PollInvoice)
+
+		rr := httptest.NewRecorder()
+		req, _ := http.NewRequestWithContext(authorizedCtx, http.MethodPost, "/poll/invoice/1", nil)
+		ro.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		mockDb.AssertExpectations(t)
+		mockHttpClient.AssertNumberOfCalls(t, "Do", 1)
+	})
 
-	t.Run("Should test that a 401 error is returned if a user is unauthorized", func(t *testing.T) {
-		r := chi.NewRouter()
-		r.Post("/poll/invoice/{paymentRequest}", bHandler.PollInvoice)
+	t.Run("Should release the invoice when the breaker of the relay is open", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mockHttpClient := &mocks.HttpClient{}
+		bHandler := NewBountyHandler(mockHttpClient, mockDb)
+		keysendInvoice(mockDb, mockHttpClient)
+		mockDb.On("UpdateInvoice", "1").Return(true, nil).Once()
+		mockDb.On("ReleaseInvoice", "1").Return(nil).Once()
+		mockHttpClient.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+			return req.Method == http.MethodPost
+		})).Return(nil, &upstream.Error{Service: upstream.Relay, Err: upstream.ErrCircuitOpen, RetryAfter: 30 * time.Second}).Once()
 
-		rr := httptest.NewRecorder()
-		req, err := http.NewRequestWithContext(unauthorizedCtx, http.MethodPost, "/poll/invoice/1", bytes.NewBufferString(`{}`))
-		if err != nil {
-			t.Fatal(err)
-		}
+		ro := chi.NewRouter()
+		ro.Post("/poll/invoice/{paymentRequest}", bHandler.PollInvoice)
 
-		r.ServeHTTP(rr, req)
+		rr := httptest.NewRecorder()
+		req, _ := http.NewRequestWithContext(authorizedCtx, http.MethodPost, "/poll/invoice/1", nil)
+		ro.ServeHTTP(rr, req)
 
-		assert.Equal(t, http.StatusUnauthorized, rr.Code, "Expected 401 error if a user is unauthorized")
+		assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+		mockDb.AssertExpectations(t)
+		mockDb.AssertNotCalled(t, "CreatePendingPayment", mock.Anything)
 	})
 
-	t.Run("Should test that a 403 error is returned if there is an invoice error", func(t *testing.T) {
-		expectedUrl := fmt.Sprintf("%s/invoice?payment_request=%s", config.RelayUrl, "1")
-
-		r := io.NopCloser(bytes.NewReader([]byte(`{"success": false, "error": "Internel server error"}`)))
+	t.Run("Should not update a bounty that was not found after the keysend", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mockHttpClient := &mocks.HttpClient{}
+		bHandler := NewBountyHandler(mockHttpClient, mockDb)
+		keysendInvoice(mockDb, mockHttpClient)
+		mockDb.On("UpdateInvoice", "1").Return(true, nil).Once()
+		mockDb.On("GetBountyByCreated", uint(1234)).Return(db.NewBounty{}, errors.New("record not found")).Once()
 		mockHttpClient.On("Do", mock.MatchedBy(func(req *http.Request) bool {
-			return req.Method == http.MethodGet && expectedUrl == req.URL.String() && req.Header.Get("x-user-token") == config.RelayAuthKey
+			return req.Method == http.MethodPost
 		})).Return(&http.Response{
-			StatusCode: 500,
-			Body:       r,
+			StatusCode: 200,
+			Body:       io.NopCloser(bytes.NewReader([]byte(`{"success": true, "response": { "sumAmount": "1"}}`))),
 		}, nil).Once()
 
 		ro := chi.NewRouter()
 		ro.Post("/poll/invoice/{paymentRequest}", bHandler.PollInvoice)
 
 		rr := httptest.NewRecorder()
-		req, err := http.NewRequestWithContext(authorizedCtx, http.MethodPost, "/poll/invoice/1", bytes.NewBufferString(`{}`))
-		if err != nil {
-			t.Fatal(err)
-		}
-
+		req, _ := http.NewRequestWithContext(authorizedCtx, http.MethodPost, "/poll/invoice/1", nil)
 		ro.ServeHTTP(rr, req)
 
-		assert.Equal(t, http.StatusForbidden, rr.Code, "Expected 403 error if there is an invoice error")
-		mockHttpClient.AssertExpectations(t)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		mockDb.AssertExpectations(t)
+		mockDb.AssertNotCalled(t, "UpdateBounty", mock.Anything)
 	})
 
-	t.Run("Should mock relay payment is successful update the bounty associated with the invoice and set the paid as true", func(t *testing.T) {
+	t.Run("If the invoice is settled and the invoice.Type is equal to BUDGET the invoice amount should be added to the workspace budget and the payment status of the related invoice should be sent to true on the payment history table", func(t *testing.T) {
+		ctx := context.Background()
+		mockDb := &dbMocks.Database{}
+		mockHttpClient := &mocks.HttpClient{}
+		bHandler := NewBountyHandler(mockHttpClient, mockDb)
+		authorizedCtx := context.WithValue(ctx, auth.ContextKey, "valid-key")
 		expectedUrl := fmt.Sprintf("%s/invoice?payment_request=%s", config.RelayUrl, "1")
 
 		r := io.NopCloser(bytes.NewReader([]byte(`{"success": true, "response": { "settled": true, "payment_request": "1", "payment_hash": "payment_hash", "preimage": "preimage", "Amount": "1000"}}`)))
@@ -1746,48 +2620,12 @@ func TestPollInvoice(t *testing.T) {
 			Body:       r,
 		}, nil).Once()
 
-		bountyID := uint(1)
-		bounty := db.NewBounty{
-			ID:            bountyID,
-			WorkspaceUuid: "work-1",
-			OrgUuid:       "org-1",
-			Assignee:      "assignee-1",
-			Price:         uint(1000),
-		}
-
-		now := time.Now()
-		expectedBounty := db.NewBounty{
-			ID:             bountyID,
-			WorkspaceUuid:  "work-1",
-			OrgUuid:        "org-1",
-			Assignee:       "assignee-1",
-			Price:          uint(1000),
-			Paid:           true,
-			PaidDate:       &now,
-			CompletionDate: &now,
-		}
-
-		mockDb.On("GetInvoice", "1").Return(db.NewInvoiceList{Type: "KEYSEND"})
+		mockDb.On("GetInvoice", "1").Return(db.NewInvoiceList{Type: "BUDGET"})
 		mockDb.On("GetUserInvoiceData", "1").Return(db.UserInvoiceData{Amount: 1000, UserPubkey: "UserPubkey", RouteHint: "RouteHint", Created: 1234})
 		mockDb.On("GetInvoice", "1").Return(db.NewInvoiceList{Status: false})
-		mockDb.On("GetBountyByCreated", uint(1234)).Return(bounty, nil)
-		mockDb.On("UpdateBounty", mock.AnythingOfType("db.NewBounty")).Run(func(args mock.Arguments) {
-			updatedBounty := args.Get(0).(db.NewBounty)
-			assert.True(t, updatedBounty.Paid)
-		}).Return(expectedBounty, nil).Once()
-		mockDb.On("UpdateInvoice", "1").Return(db.NewInvoiceList{}).Once()
-
-		expectedPaymentUrl := fmt.Sprintf("%s/payment", config.RelayUrl)
-		expectedPaymentBody := `{"amount": 1000, "destination_key": "UserPubkey", "route_hint": "RouteHint", "text": "memotext added for notification"}`
-
-		r2 := io.NopCloser(bytes.NewReader([]byte(`{"success": true, "response": { "sumAmount": "1"}}`)))
-		mockHttpClient.On("Do", mock.MatchedBy(func(req *http.Request) bool {
-			bodyByt, _ := io.ReadAll(req.Body)
-			return req.Method == http.MethodPost && expectedPaymentUrl == req.URL.String() && req.Header.Get("x-user-token") == config.RelayAuthKey && expectedPaymentBody == string(bodyByt)
-		})).Return(&http.Response{
-			StatusCode: 200,
-			Body:       r2,
-		}, nil).Once()
+		mockDb.On("AddAndUpdateBudget", mock.Anything).Return(db.NewPaymentHistory{}, nil).Once()
+		mockDb.On("UpdateInvoice", "1").Return(true, nil).Once()
+		mockDb.On("WithTx", mock.Anything).Return(func(fn func(db.Database) error) error { return fn(mockDb) })
 
 		ro := chi.NewRouter()
 		ro.Post("/poll/invoice/{paymentRequest}", bHandler.PollInvoice)
@@ -1804,7 +2642,34 @@ func TestPollInvoice(t *testing.T) {
 		mockHttpClient.AssertExpectations(t)
 	})
 
-	t.Run("If the invoice is settled and the invoice.Type is equal to BUDGET the invoice amount should be added to the workspace budget and the payment status of the related invoice should be sent to true on the payment history table", func(t *testing.T) {
+	t.Run("Should not credit the budget again when a concurrent poll settled the invoice", func(t *testing.T) {
+		ctx := context.Background()
+		mockDb := &dbMocks.Database{}
+		mockHttpClient := &mocks.HttpClient{}
+		bHandler := NewBountyHandler(mockHttpClient, mockDb)
+		authorizedCtx := context.WithValue(ctx, auth.ContextKey, "valid-key")
+
+		mockHttpClient.On("Do", mock.Anything).Return(&http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(bytes.NewReader([]byte(`{"success": true, "response": { "settled": true, "payment_request": "1", "Amount": "1000"}}`))),
+		}, nil).Once()
+		mockDb.On("GetInvoice", "1").Return(db.NewInvoiceList{Type: "BUDGET"})
+		mockDb.On("GetUserInvoiceData", "1").Return(db.UserInvoiceData{})
+		mockDb.On("UpdateInvoice", "1").Return(false, nil).Once()
+		mockDb.On("WithTx", mock.Anything).Return(func(fn func(db.Database) error) error { return fn(mockDb) }).Once()
+
+		ro := chi.NewRouter()
+		ro.Post("/poll/invoice/{paymentRequest}", bHandler.PollInvoice)
+		rr := httptest.NewRecorder()
+		req, _ := http.NewRequestWithContext(authorizedCtx, http.MethodPost, "/poll/invoice/1", bytes.NewBufferString(`{}`))
+		ro.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		mockDb.AssertExpectations(t)
+		mockDb.AssertNotCalled(t, "AddAndUpdateBudget", mock.Anything)
+	})
+
+	t.Run("Should return 500 and not settle the invoice when the budget transaction fails", func(t *testing.T) {
 		ctx := context.Background()
 		mockDb := &dbMocks.Database{}
 		mockHttpClient := &mocks.HttpClient{}
@@ -1822,9 +2687,7 @@ func TestPollInvoice(t *testing.T) {
 
 		mockDb.On("GetInvoice", "1").Return(db.NewInvoiceList{Type: "BUDGET"})
 		mockDb.On("GetUserInvoiceData", "1").Return(db.UserInvoiceData{Amount: 1000, UserPubkey: "UserPubkey", RouteHint: "RouteHint", Created: 1234})
-		mockDb.On("GetInvoice", "1").Return(db.NewInvoiceList{Status: false})
-		mockDb.On("AddAndUpdateBudget", mock.Anything).Return(db.NewPaymentHistory{})
-		mockDb.On("UpdateInvoice", "1").Return(db.NewInvoiceList{}).Once()
+		mockDb.On("WithTx", mock.Anything).Return(errors.New("commit failed"))
 
 		ro := chi.NewRouter()
 		ro.Post("/poll/invoice/{paymentRequest}", bHandler.PollInvoice)
@@ -1837,7 +2700,276 @@ func TestPollInvoice(t *testing.T) {
 
 		ro.ServeHTTP(rr, req)
 
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+		mockDb.AssertNotCalled(t, "UpdateInvoice", "1")
+		mockHttpClient.AssertExpectations(t)
+	})
+}
+
+func TestGenerateBountyDescription(t *testing.T) {
+	ctx := context.Background()
+	authorizedCtx := context.WithValue(ctx, auth.ContextKey, "valid-key")
+	expectedUrl := "http://description.test/generate"
+	config.BountyDescriptionUrl = expectedUrl
+	defer func() { config.BountyDescriptionUrl = "" }()
+
+	t.Run("should return unauthorized if pubkey is not present", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mockHttpClient := &mocks.HttpClient{}
+		bHandler := NewBountyHandler(mockHttpClient, mockDb)
+
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, "/generate_description", bytes.NewBufferString(`{"prompt": "build a login page"}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		http.HandlerFunc(bHandler.GenerateBountyDescription).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("should return bad request if prompt is empty", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mockHttpClient := &mocks.HttpClient{}
+		bHandler := NewBountyHandler(mockHttpClient, mockDb)
+
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequestWithContext(authorizedCtx, http.MethodPost, "/generate_description", bytes.NewBufferString(`{"prompt": "  "}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		http.HandlerFunc(bHandler.GenerateBountyDescription).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("should return not found if workspace does not exist", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mockHttpClient := &mocks.HttpClient{}
+		bHandler := NewBountyHandler(mockHttpClient, mockDb)
+		mockDb.On("GetWorkspaceByUuid", "workspace-uuid").Return(db.Workspace{}).Once()
+
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequestWithContext(authorizedCtx, http.MethodPost, "/generate_description", bytes.NewBufferString(`{"prompt": "build a login page", "workspace_uuid": "workspace-uuid"}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		http.HandlerFunc(bHandler.GenerateBountyDescription).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should send workspace and feature context and return the generated draft", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mockHttpClient := &mocks.HttpClient{}
+		bHandler := NewBountyHandler(mockHttpClient, mockDb)
+
+		mockDb.On("GetWorkspaceByUuid", "workspace-uuid").Return(db.Workspace{Uuid: "workspace-uuid", Name: "workspace", Mission: "mission"}).Times(3)
+		mockDb.On("GetWorkspaceUser", "valid-key", "workspace-uuid").Return(db.WorkspaceUsers{ID: 1}).Once()
+		mockDb.On("GetFeatureByUuid", "feature-uuid").Return(db.WorkspaceFeatures{Uuid: "feature-uuid", WorkspaceUuid: "workspace-uuid", Name: "feature"}).Once()
+		mockDb.On("CreateAiSubmission", mock.MatchedBy(func(s db.AiSubmission) bool {
+			return s.WorkspaceUuid == "workspace-uuid" && s.Kind == db.AiBountyDescription && s.Status == db.AiSubmissionSent
+		})).Return(nil).Once()
+		mockDb.On("CountWorkspaceUsage", "workspace-uuid", db.QuotaStakworkSubmissions, mock.Anything).Return(nil).Once()
+
+		r := io.NopCloser(bytes.NewReader([]byte(`{"title": "Login page", "description": "Build the login page", "acceptance_criteria": ["user can log in"], "estimate": "2 days"}`)))
+		mockHttpClient.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+			descriptionCtx := db.BountyDescriptionContext{}
+			bodyByt, _ := io.ReadAll(req.Body)
+			json.Unmarshal(bodyByt, &descriptionCtx)
+			return req.Method == http.MethodPost && req.URL.String() == expectedUrl && descriptionCtx.Mission == "mission" && descriptionCtx.Feature.Uuid == "feature-uuid" && descriptionCtx.RequestedBy == "valid-key"
+		})).Return(&http.Response{
+			StatusCode: 200,
+			Body:       r,
+		}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequestWithContext(authorizedCtx, http.MethodPost, "/generate_description", bytes.NewBufferString(`{"prompt": "build a login page", "workspace_uuid": "workspace-uuid", "feature_uuid": "feature-uuid"}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		http.HandlerFunc(bHandler.GenerateBountyDescription).ServeHTTP(rr, req)
+
+		draft := db.BountyDescriptionDraft{}
+		json.Unmarshal(rr.Body.Bytes(), &draft)
+
 		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "Login page", draft.Title)
+		assert.Equal(t, []string{"user can log in"}, draft.AcceptanceCriteria)
+		mockDb.AssertExpectations(t)
+		mockHttpClient.AssertExpectations(t)
+	})
+
+	t.Run("should not send the context of a workspace the caller is not a member of", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mockHttpClient := &mocks.HttpClient{}
+		bHandler := NewBountyHandler(mockHttpClient, mockDb)
+		mockDb.On("GetWorkspaceByUuid", "workspace-uuid").Return(db.Workspace{Uuid: "workspace-uuid", OwnerPubKey: "owner-pubkey", Mission: "private mission"}).Twice()
+		mockDb.On("GetWorkspaceUser", "valid-key", "workspace-uuid").Return(db.WorkspaceUsers{}).Once()
+
+		rr := httptest.NewRecorder()
+		req, _ := http.NewRequestWithContext(authorizedCtx, http.MethodPost, "/generate_description", bytes.NewBufferString(`{"prompt": "build a login page", "workspace_uuid": "workspace-uuid"}`))
+		http.HandlerFunc(bHandler.GenerateBountyDescription).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		mockDb.AssertExpectations(t)
+		mockHttpClient.AssertNotCalled(t, "Do", mock.Anything)
+	})
+
+	t.Run("should not send a feature of another workspace", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mockHttpClient := &mocks.HttpClient{}
+		bHandler := NewBountyHandler(mockHttpClient, mockDb)
+		mockDb.On("GetFeatureByUuid", "feature-uuid").Return(db.WorkspaceFeatures{Uuid: "feature-uuid", WorkspaceUuid: "other-workspace"}).Once()
+		mockDb.On("GetWorkspaceByUuid", "other-workspace").Return(db.Workspace{Uuid: "other-workspace", OwnerPubKey: "owner-pubkey"}).Once()
+		mockDb.On("GetWorkspaceUser", "valid-key", "other-workspace").Return(db.WorkspaceUsers{}).Once()
+
+		rr := httptest.NewRecorder()
+		req, _ := http.NewRequestWithContext(authorizedCtx, http.MethodPost, "/generate_description", bytes.NewBufferString(`{"prompt": "build a login page", "feature_uuid": "feature-uuid"}`))
+		http.HandlerFunc(bHandler.GenerateBountyDescription).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		mockDb.AssertExpectations(t)
+		mockHttpClient.AssertNotCalled(t, "Do", mock.Anything)
+	})
+
+	t.Run("should return bad gateway if generator fails", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mockHttpClient := &mocks.HttpClient{}
+		bHandler := NewBountyHandler(mockHttpClient, mockDb)
+
+		mockHttpClient.On("Do", mock.Anything).Return(&http.Response{
+			StatusCode: 500,
+			Body:       io.NopCloser(bytes.NewReader([]byte(`"error"`))),
+		}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequestWithContext(authorizedCtx, http.MethodPost, "/generate_description", bytes.NewBufferString(`{"prompt": "build a login page"}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		http.HandlerFunc(bHandler.GenerateBountyDescription).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusBadGateway, rr.Code)
 		mockHttpClient.AssertExpectations(t)
 	})
 }
+
+func TestPreviewBountyDescription(t *testing.T) {
+	authorizedCtx := context.WithValue(context.Background(), auth.ContextKey, "valid-key")
+	config.BountyDescriptionUrl = "http://description.test/generate"
+	config.StakworkKey = "stakwork-secret"
+	defer func() {
+		config.BountyDescriptionUrl = ""
+		config.StakworkKey = ""
+	}()
+
+	t.Run("should return the payload the generator would get without calling it", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mockHttpClient := &mocks.HttpClient{}
+		bHandler := NewBountyHandler(mockHttpClient, mockDb)
+
+		mockDb.On("GetWorkspaceByUuid", "workspace-uuid").Return(db.Workspace{Uuid: "workspace-uuid", OwnerPubKey: "valid-key", Name: "workspace", Mission: "mission", Tactics: "tactics"}).Twice()
+		mockDb.On("GetFeatureByUuid", "feature-uuid").Return(db.WorkspaceFeatures{Uuid: "feature-uuid", WorkspaceUuid: "workspace-uuid", Brief: "feature brief"}).Once()
+
+		rr := httptest.NewRecorder()
+		req, _ := http.NewRequestWithContext(authorizedCtx, http.MethodPost, "/generate_description/preview", bytes.NewBufferString(`{"prompt": " build a login page ", "workspace_uuid": "workspace-uuid", "feature_uuid": "feature-uuid"}`))
+		http.HandlerFunc(bHandler.PreviewBountyDescription).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.NotContains(t, rr.Body.String(), "stakwork-secret")
+		preview := struct {
+			Url     string                      `json:"url"`
+			Headers map[string]string           `json:"headers"`
+			Payload db.BountyDescriptionContext `json:"payload"`
+		}{}
+		json.Unmarshal(rr.Body.Bytes(), &preview)
+		assert.Equal(t, "http://description.test/generate", preview.Url)
+		assert.Equal(t, "[redacted]", preview.Headers["Authorization"])
+		assert.Equal(t, "build a login page", preview.Payload.Prompt)
+		assert.Equal(t, "tactics", preview.Payload.Tactics)
+		assert.Equal(t, "feature brief", preview.Payload.Feature.Brief)
+		assert.Equal(t, "valid-key", preview.Payload.RequestedBy)
+		mockDb.AssertExpectations(t)
+		mockHttpClient.AssertNotCalled(t, "Do", mock.Anything)
+	})
+
+	t.Run("should return not found if the feature does not exist", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		bHandler := NewBountyHandler(&mocks.HttpClient{}, mockDb)
+
+		mockDb.On("GetFeatureByUuid", "feature-uuid").Return(db.WorkspaceFeatures{}).Once()
+
+		rr := httptest.NewRecorder()
+		req, _ := http.NewRequestWithContext(authorizedCtx, http.MethodPost, "/generate_description/preview", bytes.NewBufferString(`{"prompt": "build a login page", "feature_uuid": "feature-uuid"}`))
+		http.HandlerFunc(bHandler.PreviewBountyDescription).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
+
+// BenchmarkGenerateBountyResponse compares composing the list response for
+// 10k bounties with a lookup per bounty against the batched query
+func BenchmarkGenerateBountyResponse(b *testing.B) {
+	database := dbtest.New(b)
+
+	bounties := []db.NewBounty{}
+	err := database.WithTx(func(tx db.Database) error {
+		for i := 0; i < 10; i++ {
+			tx.CreateOrEditWorkspace(db.Workspace{
+				Uuid:        fmt.Sprintf("bench-workspace-%d", i),
+				Name:        fmt.Sprintf("bench workspace %d", i),
+				OwnerPubKey: "bench-pubkey-0",
+			})
+		}
+		for i := 0; i < 100; i++ {
+			tx.CreateOrEditPerson(db.Person{
+				Uuid:        fmt.Sprintf("bench-person-%d", i),
+				OwnerPubKey: fmt.Sprintf("bench-pubkey-%d", i),
+				OwnerAlias:  fmt.Sprintf("bench%d", i),
+				UniqueName:  fmt.Sprintf("bench%d", i),
+			})
+		}
+		for i := 0; i < 10000; i++ {
+			bounty, err := tx.CreateOrEditBounty(db.NewBounty{
+				Type:          "coding",
+				Title:         fmt.Sprintf("bench bounty %d", i),
+				Description:   "bench bounty description",
+				WorkspaceUuid: fmt.Sprintf("bench-workspace-%d", i%10),
+				OwnerID:       fmt.Sprintf("bench-pubkey-%d", i%100),
+				Assignee:      fmt.Sprintf("bench-pubkey-%d", (i+1)%100),
+				Show:          true,
+				Created:       int64(1800000000 + i),
+			})
+			if err != nil {
+				return err
+			}
+			bounties = append(bounties, bounty)
+		}
+		return nil
+	})
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.Run("per bounty lookups", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			for _, bounty := range bounties {
+				db.NewBountyResponse(bounty, database.GetPersonByPubkey(bounty.OwnerID), database.GetPersonByPubkey(bounty.Assignee), database.GetWorkspaceByUuid(bounty.WorkspaceUuid))
+			}
+		}
+	})
+
+	b.Run("batched", func(b *testing.B) {
+		bHandler := NewBountyHandler(http.DefaultClient, database)
+		for n := 0; n < b.N; n++ {
+			bHandler.GenerateBountyResponse(bounties)
+		}
+	})
+}
@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/db"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestBountyViews(t *testing.T) {
+	bounty := db.NewBounty{ID: 42, OwnerID: "owner-pubkey", Title: "Popular bounty", Show: true}
+	viewBounty := func(mockDb *dbMocks.Database, address string) int {
+		bHandler := NewBountyHandler(nil, mockDb)
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("bountyId", "42")
+		req, _ := http.NewRequestWithContext(context.WithValue(context.Background(), chi.RouteCtxKey, rctx), http.MethodGet, "/gobounties/id/42", nil)
+		req.RemoteAddr = address + ":5000"
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(bHandler.GetBountyById).ServeHTTP(rr, req)
+		return rr.Code
+	}
+
+	t.Run("should flush the views of a bounty in one write", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		db.FlushBountyViews(mockDb)
+
+		mockDb.On("GetBountyById", "42").Return([]db.NewBounty{bounty}, nil)
+		mockDb.On("GetBountyResponses", []db.NewBounty{bounty}).Return([]db.BountyResponse{{Bounty: bounty}})
+		mockDb.On("GetMentions", db.MentionBounty, "42").Return([]db.Mention{})
+
+		assert.Equal(t, http.StatusOK, viewBounty(mockDb, "10.0.0.1"))
+		assert.Equal(t, http.StatusOK, viewBounty(mockDb, "10.0.0.1"))
+		assert.Equal(t, http.StatusOK, viewBounty(mockDb, "10.0.0.2"))
+		mockDb.AssertNotCalled(t, "AddBountyViews", mock.Anything)
+
+		mockDb.On("AddBountyViews", mock.MatchedBy(func(views map[uint]db.BountyViews) bool {
+			return len(views) == 1 && views[42].Views == 3 && len(views[42].Viewers) == 2
+		})).Return(nil).Once()
+
+		assert.NoError(t, db.FlushBountyViews(mockDb))
+		assert.NoError(t, db.FlushBountyViews(mockDb))
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should keep the views when they can't be written", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		db.FlushBountyViews(mockDb)
+
+		mockDb.On("GetBountyById", "42").Return([]db.NewBounty{bounty}, nil)
+		mockDb.On("GetBountyResponses", []db.NewBounty{bounty}).Return([]db.BountyResponse{{Bounty: bounty}})
+		mockDb.On("GetMentions", db.MentionBounty, "42").Return([]db.Mention{})
+		mockDb.On("AddBountyViews", mock.Anything).Return(assert.AnError).Once()
+		mockDb.On("AddBountyViews", mock.MatchedBy(func(views map[uint]db.BountyViews) bool {
+			return views[42].Views == 1
+		})).Return(nil).Once()
+
+		viewBounty(mockDb, "10.0.0.3")
+		assert.Error(t, db.FlushBountyViews(mockDb))
+		assert.NoError(t, db.FlushBountyViews(mockDb))
+		mockDb.AssertExpectations(t)
+	})
+}
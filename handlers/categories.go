@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/httpio"
+)
+
+// GetTribeCategories returns the curated category tree tribes are filed
+// under, for the directory filters
+func (th *tribeHandler) GetTribeCategories(w http.ResponseWriter, r *http.Request) {
+	categories, err := th.db.GetCategories()
+	if err != nil {
+		fmt.Println("[tribes]", err)
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to get the categories")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(db.CategoryTree(categories))
+}
+
+// unknownCategory is the first of the slugs no category has, empty when
+// they all exist
+func unknownCategory(database db.Database, slugs []string) (string, error) {
+	if len(slugs) == 0 {
+		return "", nil
+	}
+	categories, err := database.GetCategories()
+	if err != nil {
+		return "", err
+	}
+	known := map[string]bool{}
+	for _, category := range categories {
+		known[category.Slug] = true
+	}
+	for _, slug := range slugs {
+		if !known[slug] {
+			return slug, nil
+		}
+	}
+	return "", nil
+}
+
+// CreateOrEditCategory adds a category to the tree, or renames or moves the
+// category of the slug
+func (mh *moderationHandler) CreateOrEditCategory(w http.ResponseWriter, r *http.Request) {
+	request := db.CategoryRequest{}
+	body, err := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err == nil {
+		err = json.Unmarshal(body, &request)
+	}
+	if err != nil {
+		httpio.WriteError(w, r, http.StatusNotAcceptable, "")
+		return
+	}
+
+	if !validatePayload(w, r, request) {
+		return
+	}
+
+	category, err := mh.db.CreateOrEditCategory(request)
+	if errors.Is(err, db.ErrUnknownCategory) || errors.Is(err, db.ErrCategoryCycle) {
+		httpio.WriteError(w, r, http.StatusBadRequest, "Error: parent_slug: "+err.Error())
+		return
+	}
+	if err != nil {
+		fmt.Println("[moderation]", err)
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to save the category")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(category)
+}
+
+// DeleteCategory removes a category without subcategories from the tree,
+// its tribes are no longer filed under it
+func (mh *moderationHandler) DeleteCategory(w http.ResponseWriter, r *http.Request) {
+	err := mh.db.DeleteCategory(chi.URLParam(r, "slug"))
+	if errors.Is(err, db.ErrUnknownCategory) {
+		httpio.WriteError(w, r, http.StatusNotFound, "Category not found")
+		return
+	}
+	if errors.Is(err, db.ErrCategoryHasChildren) {
+		httpio.WriteError(w, r, http.StatusConflict, "Move or delete the subcategories first")
+		return
+	}
+	if err != nil {
+		fmt.Println("[moderation]", err)
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to delete the category")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(true)
+}
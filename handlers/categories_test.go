@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestTribeCategories(t *testing.T) {
+	bitcoin := uint(1)
+	categories := []db.Category{
+		{ID: 1, Slug: "bitcoin", Name: "Bitcoin"},
+		{ID: 2, Slug: "lightning", Name: "Lightning", ParentID: &bitcoin},
+		{ID: 3, Slug: "art", Name: "Art", Position: 1},
+	}
+
+	t.Run("should return the category tree", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mockDb.On("GetCategories").Return(categories, nil).Once()
+
+		req, _ := http.NewRequest(http.MethodGet, "/tribes/categories", nil)
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(NewTribeHandler(mockDb).GetTribeCategories).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		tree := []db.Category{}
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &tree))
+		assert.Len(t, tree, 2)
+		assert.Equal(t, "bitcoin", tree[0].Slug)
+		assert.Equal(t, "lightning", tree[0].Children[0].Slug)
+		mockDb.AssertExpectations(t)
+	})
+
+	newTribeRequest := func(pubkey string, categories []string) *http.Request {
+		body, _ := json.Marshal(map[string]interface{}{
+			"uuid":       "category-uuid-" + pubkey,
+			"name":       "Category Tribe",
+			"tags":       []string{},
+			"categories": categories,
+		})
+		ctx := context.WithValue(context.Background(), auth.ContextKey, pubkey)
+		req, _ := http.NewRequestWithContext(ctx, http.MethodPost, "/", bytes.NewReader(body))
+		return req
+	}
+	newTribeHandler := func(mockDb *dbMocks.Database, pubkey string) *tribeHandler {
+		tHandler := NewTribeHandler(mockDb)
+		tHandler.verifyTribeUUID = func(uuid string, checkTimestamp bool) (string, error) {
+			return pubkey, nil
+		}
+		tHandler.tribeUniqueNameFromName = func(name string) (string, error) {
+			return "category-tribe", nil
+		}
+		return tHandler
+	}
+
+	t.Run("should file a tribe under its categories", func(t *testing.T) {
+		pubkey := "category-owner"
+		mockDb := &dbMocks.Database{}
+		mockDb.On("IsBannedPubkey", pubkey).Return(false).Once()
+		mockDb.On("GetCategories").Return(categories, nil).Once()
+		mockDb.On("GetTribe", "category-uuid-"+pubkey).Return(db.Tribe{})
+		mockDb.On("CreateOrEditTribe", mock.AnythingOfType("db.Tribe")).Return(db.Tribe{UUID: "category-uuid-" + pubkey}, nil).Once()
+		mockDb.On("SetTribeCategories", "category-uuid-"+pubkey, []string{"lightning", "art"}).Return(nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(newTribeHandler(mockDb, pubkey).CreateOrEditTribe).ServeHTTP(rr, newTribeRequest(pubkey, []string{"lightning", "art"}))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should refuse a category that is not in the tree", func(t *testing.T) {
+		pubkey := "category-unknown"
+		mockDb := &dbMocks.Database{}
+		mockDb.On("IsBannedPubkey", pubkey).Return(false).Once()
+		mockDb.On("GetCategories").Return(categories, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(newTribeHandler(mockDb, pubkey).CreateOrEditTribe).ServeHTTP(rr, newTribeRequest(pubkey, []string{"gardening"}))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockDb.AssertNotCalled(t, "CreateOrEditTribe", mock.Anything)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should keep a category with subcategories", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mockDb.On("DeleteCategory", "bitcoin").Return(db.ErrCategoryHasChildren).Once()
+
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("slug", "bitcoin")
+		req, _ := http.NewRequestWithContext(context.WithValue(context.Background(), chi.RouteCtxKey, rctx), http.MethodDelete, "/admin/categories/bitcoin", nil)
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(NewModerationHandler(mockDb).DeleteCategory).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusConflict, rr.Code)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should refuse a slug that is not lowercase", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+
+		req, _ := http.NewRequest(http.MethodPost, "/admin/categories", bytes.NewBufferString(`{"slug": "Open Source", "name": "Open source"}`))
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(NewModerationHandler(mockDb).CreateOrEditCategory).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockDb.AssertNotCalled(t, "CreateOrEditCategory", mock.Anything)
+	})
+}
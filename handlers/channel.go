@@ -53,9 +53,11 @@ func (ch *channelHandler) DeleteChannel(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	ch.db.UpdateChannel(uint(id), map[string]interface{}{
-		"deleted": true,
-	})
+	if _, err := ch.db.SoftDelete(db.SoftDeleteChannels, strconv.Itoa(id)); err != nil {
+		fmt.Println("could not delete channel", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
 
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(true)
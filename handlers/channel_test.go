@@ -17,13 +17,13 @@ import (
 	"github.com/go-chi/chi"
 	"github.com/stakwork/sphinx-tribes/auth"
 	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/dbtest"
 )
 
 func TestCreateChannel(t *testing.T) {
-	teardownSuite := SetupSuite(t)
-	defer teardownSuite(t)
+	database := dbtest.New(t)
 
-	cHandler := NewChannelHandler(db.TestDB)
+	cHandler := NewChannelHandler(database)
 
 	createTestPersonAndTribe := func(pubKey, tribeUUID, tribeName string) (db.Person, db.Tribe) {
 		person := db.Person{
@@ -38,7 +38,7 @@ func TestCreateChannel(t *testing.T) {
 			GithubIssues: db.PropertyMap{},
 			Extras:       db.PropertyMap{"coding_languages": "Lightning"},
 		}
-		db.TestDB.CreateOrEditPerson(person)
+		database.CreateOrEditPerson(person)
 
 		tribe := db.Tribe{
 			UUID:        tribeUUID,
@@ -48,7 +48,7 @@ func TestCreateChannel(t *testing.T) {
 			Unlisted:    false,
 			UniqueName:  tribeName,
 		}
-		db.TestDB.CreateOrEditTribe(tribe)
+		database.CreateOrEditTribe(tribe)
 
 		return person, tribe
 	}
@@ -91,7 +91,7 @@ func TestCreateChannel(t *testing.T) {
 
 		assert.Equal(t, http.StatusOK, rr.Code)
 
-		channels := db.TestDB.GetChannelsByTribe(tribe.UUID)
+		channels := database.GetChannelsByTribe(tribe.UUID)
 		assert.Equal(t, 1, len(channels))
 		assert.Equal(t, "New Channel", channels[0].Name)
 	})
@@ -104,7 +104,7 @@ func TestCreateChannel(t *testing.T) {
 			Name:      "Test Channel",
 			Deleted:   false,
 		}
-		db.TestDB.CreateChannel(channel)
+		database.CreateChannel(channel)
 
 		requestBody := map[string]interface{}{
 			"tribe_uuid": tribe.UUID,
@@ -125,10 +125,9 @@ func TestCreateChannel(t *testing.T) {
 }
 
 func TestDeleteChannel(t *testing.T) {
-	teardownSuite := SetupSuite(t)
-	defer teardownSuite(t)
+	database := dbtest.New(t)
 
-	cHandler := NewChannelHandler(db.TestDB)
+	cHandler := NewChannelHandler(database)
 
 	person := db.Person{
 		Uuid:         "person_chan1_uuid",
@@ -161,11 +160,11 @@ func TestDeleteChannel(t *testing.T) {
 	t.Run("Should test that the owner of a channel can delete the channel", func(t *testing.T) {
 		ctx := context.WithValue(context.Background(), auth.ContextKey, "mock_pubkey")
 
-		db.TestDB.CreateOrEditPerson(person)
-		db.TestDB.CreateOrEditTribe(tribe)
-		db.TestDB.CreateChannel(channel)
+		database.CreateOrEditPerson(person)
+		database.CreateOrEditTribe(tribe)
+		database.CreateChannel(channel)
 
-		channels := db.TestDB.GetChannelsByTribe(tribe.UUID)
+		channels := database.GetChannelsByTribe(tribe.UUID)
 		channelId := strconv.FormatUint(uint64(channels[0].ID), 10)
 
 		// Create and Serve request
@@ -188,11 +187,11 @@ func TestDeleteChannel(t *testing.T) {
 	t.Run("Should test that non-channel owners cannot delete the channel, it should return a 401 error", func(t *testing.T) {
 		ctx := context.WithValue(context.Background(), auth.ContextKey, "other_pubkey")
 
-		db.TestDB.CreateOrEditPerson(person)
-		db.TestDB.CreateOrEditTribe(tribe)
-		db.TestDB.CreateChannel(channel)
+		database.CreateOrEditPerson(person)
+		database.CreateOrEditTribe(tribe)
+		database.CreateChannel(channel)
 
-		channels := db.TestDB.GetChannelsByTribe(tribe.UUID)
+		channels := database.GetChannelsByTribe(tribe.UUID)
 		channelId := strconv.FormatUint(uint64(channels[0].ID), 10)
 
 		// Create and Serve request
@@ -0,0 +1,448 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/rs/xid"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/httpio"
+	"github.com/stakwork/sphinx-tribes/upstream"
+)
+
+type chatHandler struct {
+	httpClient           HttpClient
+	db                   db.Database
+	getSocketConnections func(host string) (db.Client, error)
+}
+
+func NewChatHandler(httpClient HttpClient, database db.Database) *chatHandler {
+	return &chatHandler{
+		httpClient:           httpClient,
+		db:                   database,
+		getSocketConnections: db.Store.GetSocketConnections,
+	}
+}
+
+func (ch *chatHandler) userCanAccessWorkspace(pubKeyFromAuth string, workspaceUuid string) bool {
+	return isWorkspaceMember(ch.db, pubKeyFromAuth, workspaceUuid)
+}
+
+func (ch *chatHandler) CreateChat(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[chat] no pubkey from auth")
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
+		return
+	}
+
+	chat := db.Chat{}
+	body, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	err := json.Unmarshal(body, &chat)
+	if err != nil {
+		fmt.Println("[chat]", err)
+		httpio.WriteError(w, r, http.StatusNotAcceptable, "")
+		return
+	}
+
+	if chat.WorkspaceUuid == "" {
+		httpio.WriteError(w, r, http.StatusBadRequest, "Workspace uuid is required")
+		return
+	}
+
+	if !ch.userCanAccessWorkspace(pubKeyFromAuth, chat.WorkspaceUuid) {
+		httpio.WriteError(w, r, http.StatusUnauthorized, "Don't have access to this workspace")
+		return
+	}
+
+	chat.Uuid = xid.New().String()
+	chat.Status = db.ActiveStatus
+	chat.CreatedBy = pubKeyFromAuth
+
+	createdChat, err := ch.db.CreateChat(chat)
+	if err != nil {
+		httpio.WriteError(w, r, http.StatusInternalServerError, fmt.Sprintf("Failed to create chat: %v", err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(createdChat)
+}
+
+func (ch *chatHandler) UpdateChat(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[chat] no pubkey from auth")
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
+		return
+	}
+
+	chatUuid := chi.URLParam(r, "uuid")
+	existingChat, err := ch.db.GetChatByUuid(chatUuid)
+	if err != nil {
+		httpio.WriteError(w, r, http.StatusNotFound, "Chat not found")
+		return
+	}
+
+	if !ch.userCanAccessWorkspace(pubKeyFromAuth, existingChat.WorkspaceUuid) {
+		httpio.WriteError(w, r, http.StatusUnauthorized, "Don't have access to this chat")
+		return
+	}
+
+	chat := db.Chat{}
+	body, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	err = json.Unmarshal(body, &chat)
+	if err != nil {
+		fmt.Println("[chat]", err)
+		httpio.WriteError(w, r, http.StatusNotAcceptable, "")
+		return
+	}
+
+	if chat.Title != "" {
+		existingChat.Title = chat.Title
+	}
+	if chat.Status != "" {
+		if chat.Status != db.ActiveStatus && chat.Status != db.ArchiveStatus {
+			httpio.WriteError(w, r, http.StatusBadRequest, "Invalid chat status")
+			return
+		}
+		existingChat.Status = chat.Status
+	}
+
+	updatedChat, err := ch.db.UpdateChat(existingChat)
+	if err != nil {
+		httpio.WriteError(w, r, http.StatusInternalServerError, fmt.Sprintf("Failed to update chat: %v", err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(updatedChat)
+}
+
+func (ch *chatHandler) GetChat(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[chat] no pubkey from auth")
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
+		return
+	}
+
+	chat, err := ch.db.GetChatByUuid(chi.URLParam(r, "uuid"))
+	if err != nil {
+		httpio.WriteError(w, r, http.StatusNotFound, "Chat not found")
+		return
+	}
+
+	if !ch.userCanAccessWorkspace(pubKeyFromAuth, chat.WorkspaceUuid) {
+		httpio.WriteError(w, r, http.StatusUnauthorized, "Don't have access to this chat")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(chat)
+}
+
+func (ch *chatHandler) GetChatsForWorkspace(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[chat] no pubkey from auth")
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
+		return
+	}
+
+	workspaceUuid := r.URL.Query().Get("workspace_id")
+	status := r.URL.Query().Get("status")
+
+	if workspaceUuid == "" {
+		httpio.WriteError(w, r, http.StatusBadRequest, "workspace_id query param is required")
+		return
+	}
+
+	if !ch.userCanAccessWorkspace(pubKeyFromAuth, workspaceUuid) {
+		httpio.WriteError(w, r, http.StatusUnauthorized, "Don't have access to this workspace")
+		return
+	}
+
+	chats, err := ch.db.GetChatsForWorkspace(workspaceUuid, status)
+	if err != nil {
+		httpio.WriteError(w, r, http.StatusInternalServerError, fmt.Sprintf("Failed to fetch chats: %v", err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(chats)
+}
+
+func (ch *chatHandler) GetChatHistory(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[chat] no pubkey from auth")
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
+		return
+	}
+
+	chat, err := ch.db.GetChatByUuid(chi.URLParam(r, "uuid"))
+	if err != nil {
+		httpio.WriteError(w, r, http.StatusNotFound, "Chat not found")
+		return
+	}
+
+	if !ch.userCanAccessWorkspace(pubKeyFromAuth, chat.WorkspaceUuid) {
+		httpio.WriteError(w, r, http.StatusUnauthorized, "Don't have access to this chat")
+		return
+	}
+
+	messages, err := ch.db.GetChatMessagesForChatID(chat.Uuid)
+	if err != nil {
+		httpio.WriteError(w, r, http.StatusInternalServerError, fmt.Sprintf("Failed to fetch chat history: %v", err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(messages)
+}
+
+func (ch *chatHandler) SendMessage(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[chat] no pubkey from auth")
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
+		return
+	}
+
+	request := db.ChatMessageRequest{}
+	body, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	err := json.Unmarshal(body, &request)
+	if err != nil {
+		fmt.Println("[chat]", err)
+		httpio.WriteError(w, r, http.StatusNotAcceptable, "")
+		return
+	}
+
+	if request.Message == "" {
+		httpio.WriteError(w, r, http.StatusBadRequest, "Message is required")
+		return
+	}
+
+	chat, err := ch.db.GetChatByUuid(request.ChatUuid)
+	if err != nil {
+		httpio.WriteError(w, r, http.StatusNotFound, "Chat not found")
+		return
+	}
+
+	if !ch.userCanAccessWorkspace(pubKeyFromAuth, chat.WorkspaceUuid) {
+		httpio.WriteError(w, r, http.StatusUnauthorized, "Don't have access to this chat")
+		return
+	}
+	if !withinQuota(w, r, ch.db, chat.WorkspaceUuid, db.QuotaStakworkSubmissions, 1) || !withinAiCap(w, r, ch.db, chat.WorkspaceUuid) {
+		return
+	}
+
+	contextTags := db.JSONB{}
+	for _, tag := range request.ContextTags {
+		contextTags = append(contextTags, tag)
+	}
+
+	message, err := ch.db.AddChatMessage(db.ChatMessage{
+		Uuid:              xid.New().String(),
+		ChatUuid:          chat.Uuid,
+		WorkspaceUuid:     chat.WorkspaceUuid,
+		Role:              db.UserRole,
+		Message:           request.Message,
+		Status:            db.SendingStatus,
+		ContextTags:       contextTags,
+		SourceWebsocketId: request.SourceWebsocketId,
+		CreatedBy:         pubKeyFromAuth,
+	})
+	if err != nil {
+		httpio.WriteError(w, r, http.StatusInternalServerError, fmt.Sprintf("Failed to save message: %v", err))
+		return
+	}
+
+	history, err := ch.db.GetChatMessagesForChatID(chat.Uuid)
+	if err != nil {
+		history = []db.ChatMessage{message}
+	}
+
+	artifacts, err := ch.contextArtifacts(chat.WorkspaceUuid, request.ContextTags)
+	if err != nil {
+		fmt.Println("[chat] failed to get the artifacts of the message", err)
+	}
+
+	replyUuid := xid.New().String()
+	started := time.Now()
+	err = ch.sendToStakwork(r.Context(), chat, message, replyUuid, history, artifacts)
+	recordAiSubmission(ch.db, chat.WorkspaceUuid, db.AiHiveChat, config.HiveChatWorkflowId, replyUuid, started, err)
+	if err != nil {
+		fmt.Println("[chat] failed to send message to stakwork", err)
+		message.Status = db.ErrorStatus
+		ch.db.UpdateChatMessage(message)
+
+		upstream.WriteError(w, r, err, "Failed to send message to the assistant")
+		return
+	}
+
+	countQuota(ch.db, chat.WorkspaceUuid, db.QuotaStakworkSubmissions)
+
+	message.Status = db.SentStatus
+	if updated, err := ch.db.UpdateChatMessage(message); err == nil {
+		message = updated
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(message)
+}
+
+// contextArtifacts returns the workspace artifacts the context tags of a
+// message point to, they are sent with the message as persistent context
+func (ch *chatHandler) contextArtifacts(workspaceUuid string, tags []db.ContextTag) ([]db.WorkspaceArtifact, error) {
+	uuids := []string{}
+	for _, tag := range tags {
+		if tag.Type == db.ArtifactContextTag {
+			uuids = append(uuids, tag.ID)
+		}
+	}
+	if len(uuids) == 0 {
+		return []db.WorkspaceArtifact{}, nil
+	}
+	return ch.db.GetArtifactsByUuid(workspaceUuid, uuids)
+}
+
+// sendToStakwork submits a message to the chat workflow, its reply comes
+// back on the webhook as replyUuid
+func (ch *chatHandler) sendToStakwork(ctx context.Context, chat db.Chat, message db.ChatMessage, replyUuid string, history []db.ChatMessage, artifacts []db.WorkspaceArtifact) error {
+	if config.StakworkKey == "" {
+		return fmt.Errorf("stakwork key not set")
+	}
+
+	body := map[string]interface{}{
+		"name":        "Hive Chat",
+		"workflow_id": config.HiveChatWorkflowId,
+		"workflow_params": map[string]interface{}{
+			"set_var": map[string]interface{}{
+				"attributes": map[string]interface{}{
+					"vars": map[string]interface{}{
+						"chat_uuid":           chat.Uuid,
+						"user_message_uuid":   message.Uuid,
+						"message_uuid":        replyUuid,
+						"workspace_uuid":      chat.WorkspaceUuid,
+						"message":             message.Message,
+						"context_tags":        message.ContextTags,
+						"history":             history,
+						"artifacts":           artifacts,
+						"source_websocket_id": message.SourceWebsocketId,
+						"webhook_url":         fmt.Sprintf("%s/hivechat/response", config.Host),
+					},
+				},
+			},
+		},
+	}
+
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, config.StakworkProjectsUrl, bytes.NewBuffer(buf))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", fmt.Sprintf("Token token=%s", config.StakworkKey))
+
+	res, err := ch.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK && res.StatusCode != http.StatusCreated {
+		return upstream.StatusError(upstream.Stakwork, res)
+	}
+	return nil
+}
+
+// ProcessChatResponse handles the Stakwork webhook. Replies may arrive in
+// several chunks, they are appended to a single assistant message until done
+func (ch *chatHandler) ProcessChatResponse(w http.ResponseWriter, r *http.Request) {
+	response := db.ChatWebhookResponse{}
+	body, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	err := json.Unmarshal(body, &response)
+	if err != nil {
+		fmt.Println("[chat]", err)
+		httpio.WriteError(w, r, http.StatusNotAcceptable, "")
+		return
+	}
+
+	chat, err := ch.db.GetChatByUuid(response.ChatUuid)
+	if err != nil {
+		httpio.WriteError(w, r, http.StatusNotFound, "Chat not found")
+		return
+	}
+	addAiCost(ch.db, db.AiHiveChat, response.MessageUuid, response.Cost)
+
+	status := db.SendingStatus
+	if response.Done {
+		status = db.SentStatus
+	}
+
+	var message db.ChatMessage
+	existing, err := ch.db.GetChatMessageByUuid(response.MessageUuid)
+	if response.MessageUuid != "" && err == nil && existing.Role == db.AssistantRole {
+		existing.Message += response.Response
+		existing.Status = status
+		message, err = ch.db.UpdateChatMessage(existing)
+	} else {
+		messageUuid := response.MessageUuid
+		if messageUuid == "" || err == nil {
+			messageUuid = xid.New().String()
+		}
+		message, err = ch.db.AddChatMessage(db.ChatMessage{
+			Uuid:              messageUuid,
+			ChatUuid:          chat.Uuid,
+			WorkspaceUuid:     chat.WorkspaceUuid,
+			Role:              db.AssistantRole,
+			Message:           response.Response,
+			Status:            status,
+			ContextTags:       db.JSONB{},
+			SourceWebsocketId: response.SourceWebsocketId,
+		})
+	}
+
+	if err != nil {
+		httpio.WriteError(w, r, http.StatusInternalServerError, fmt.Sprintf("Failed to save response: %v", err))
+		return
+	}
+
+	if response.SourceWebsocketId != "" {
+		socket, err := ch.getSocketConnections(response.SourceWebsocketId)
+		if err == nil {
+			msg := map[string]interface{}{
+				"msg":     "hive_chat",
+				"message": message,
+			}
+			socket.Writer.WriteJSON(msg)
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(message)
+}
@@ -0,0 +1,284 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/handlers/mocks"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateChat(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.ContextKey, "owner-pubkey")
+
+	t.Run("should return unauthorized if pubkey is not present", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		chHandler := NewChatHandler(&mocks.HttpClient{}, mockDb)
+
+		rr := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/hivechat", bytes.NewBufferString(`{"workspace_uuid": "workspace-uuid"}`))
+
+		http.HandlerFunc(chHandler.CreateChat).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("should return unauthorized if user is not part of the workspace", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		chHandler := NewChatHandler(&mocks.HttpClient{}, mockDb)
+		mockDb.On("GetWorkspaceByUuid", "workspace-uuid").Return(db.Workspace{Uuid: "workspace-uuid", OwnerPubKey: "other-pubkey"}).Once()
+		mockDb.On("GetWorkspaceUser", "owner-pubkey", "workspace-uuid").Return(db.WorkspaceUsers{}).Once()
+
+		rr := httptest.NewRecorder()
+		req, _ := http.NewRequestWithContext(ctx, http.MethodPost, "/hivechat", bytes.NewBufferString(`{"workspace_uuid": "workspace-uuid"}`))
+
+		http.HandlerFunc(chHandler.CreateChat).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should create an active chat for the workspace owner", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		chHandler := NewChatHandler(&mocks.HttpClient{}, mockDb)
+		mockDb.On("GetWorkspaceByUuid", "workspace-uuid").Return(db.Workspace{Uuid: "workspace-uuid", OwnerPubKey: "owner-pubkey"}).Once()
+		mockDb.On("CreateChat", mock.MatchedBy(func(c db.Chat) bool {
+			return c.Uuid != "" && c.WorkspaceUuid == "workspace-uuid" && c.Status == db.ActiveStatus && c.CreatedBy == "owner-pubkey"
+		})).Return(func(c db.Chat) (db.Chat, error) {
+			return c, nil
+		}).Once()
+
+		rr := httptest.NewRecorder()
+		req, _ := http.NewRequestWithContext(ctx, http.MethodPost, "/hivechat", bytes.NewBufferString(`{"workspace_uuid": "workspace-uuid", "title": "Planning"}`))
+
+		http.HandlerFunc(chHandler.CreateChat).ServeHTTP(rr, req)
+
+		var chat db.Chat
+		err := json.Unmarshal(rr.Body.Bytes(), &chat)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "Planning", chat.Title)
+		mockDb.AssertExpectations(t)
+	})
+}
+
+func TestGetChatHistory(t *testing.T) {
+	t.Run("should return not found for an unknown chat", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		chHandler := NewChatHandler(&mocks.HttpClient{}, mockDb)
+		mockDb.On("GetChatByUuid", "chat-uuid").Return(db.Chat{}, errors.New("no chat found")).Once()
+
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("uuid", "chat-uuid")
+		ctx := context.WithValue(context.WithValue(context.Background(), auth.ContextKey, "owner-pubkey"), chi.RouteCtxKey, rctx)
+		rr := httptest.NewRecorder()
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "/hivechat/history/chat-uuid", nil)
+
+		http.HandlerFunc(chHandler.GetChatHistory).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should return the chat messages", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		chHandler := NewChatHandler(&mocks.HttpClient{}, mockDb)
+		messages := []db.ChatMessage{
+			{Uuid: "m1", ChatUuid: "chat-uuid", Role: db.UserRole, Message: "hello"},
+			{Uuid: "m2", ChatUuid: "chat-uuid", Role: db.AssistantRole, Message: "hi"},
+		}
+		mockDb.On("GetChatByUuid", "chat-uuid").Return(db.Chat{Uuid: "chat-uuid", WorkspaceUuid: "workspace-uuid"}, nil).Once()
+		mockDb.On("GetWorkspaceByUuid", "workspace-uuid").Return(db.Workspace{Uuid: "workspace-uuid", OwnerPubKey: "owner-pubkey"}).Once()
+		mockDb.On("GetChatMessagesForChatID", "chat-uuid").Return(messages, nil).Once()
+
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("uuid", "chat-uuid")
+		ctx := context.WithValue(context.WithValue(context.Background(), auth.ContextKey, "owner-pubkey"), chi.RouteCtxKey, rctx)
+		rr := httptest.NewRecorder()
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "/hivechat/history/chat-uuid", nil)
+
+		http.HandlerFunc(chHandler.GetChatHistory).ServeHTTP(rr, req)
+
+		var returned []db.ChatMessage
+		err := json.Unmarshal(rr.Body.Bytes(), &returned)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Len(t, returned, 2)
+		mockDb.AssertExpectations(t)
+	})
+}
+
+func TestSendMessage(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.ContextKey, "owner-pubkey")
+	config.StakworkKey = "stakwork-key"
+	defer func() { config.StakworkKey = "" }()
+
+	t.Run("should store the message and forward it to stakwork", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mockHttpClient := &mocks.HttpClient{}
+		chHandler := NewChatHandler(mockHttpClient, mockDb)
+
+		mockDb.On("GetChatByUuid", "chat-uuid").Return(db.Chat{Uuid: "chat-uuid", WorkspaceUuid: "workspace-uuid"}, nil).Once()
+		mockDb.On("GetWorkspaceByUuid", "workspace-uuid").Return(db.Workspace{Uuid: "workspace-uuid", OwnerPubKey: "owner-pubkey"}).Twice()
+		mockDb.On("AddChatMessage", mock.MatchedBy(func(m db.ChatMessage) bool {
+			return m.Role == db.UserRole && m.Status == db.SendingStatus && m.Message == "What is left in phase 1?" && len(m.ContextTags) == 1
+		})).Return(func(m db.ChatMessage) (db.ChatMessage, error) {
+			return m, nil
+		}).Once()
+		mockDb.On("GetChatMessagesForChatID", "chat-uuid").Return([]db.ChatMessage{}, nil).Once()
+		mockHttpClient.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+			return req.URL.String() == config.StakworkProjectsUrl && req.Header.Get("Authorization") == "Token token=stakwork-key"
+		})).Return(&http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(bytes.NewBufferString(`{"success": true}`)),
+		}, nil).Once()
+		mockDb.On("CreateAiSubmission", mock.MatchedBy(func(s db.AiSubmission) bool {
+			return s.WorkspaceUuid == "workspace-uuid" && s.Kind == db.AiHiveChat && s.Status == db.AiSubmissionSent && s.Reference != ""
+		})).Return(nil).Once()
+		mockDb.On("CountWorkspaceUsage", "workspace-uuid", db.QuotaStakworkSubmissions, mock.Anything).Return(nil).Once()
+		mockDb.On("UpdateChatMessage", mock.MatchedBy(func(m db.ChatMessage) bool {
+			return m.Status == db.SentStatus
+		})).Return(func(m db.ChatMessage) (db.ChatMessage, error) {
+			return m, nil
+		}).Once()
+
+		rr := httptest.NewRecorder()
+		body := `{"chat_uuid": "chat-uuid", "message": "What is left in phase 1?", "context_tags": [{"type": "phase", "id": "phase-uuid"}]}`
+		req, _ := http.NewRequestWithContext(ctx, http.MethodPost, "/hivechat/send", bytes.NewBufferString(body))
+
+		http.HandlerFunc(chHandler.SendMessage).ServeHTTP(rr, req)
+
+		var message db.ChatMessage
+		err := json.Unmarshal(rr.Body.Bytes(), &message)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, db.SentStatus, message.Status)
+		mockDb.AssertExpectations(t)
+		mockHttpClient.AssertExpectations(t)
+	})
+
+	t.Run("should send the artifacts of the context tags to stakwork", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mockHttpClient := &mocks.HttpClient{}
+		chHandler := NewChatHandler(mockHttpClient, mockDb)
+		artifact := db.WorkspaceArtifact{Uuid: "artifact-uuid", WorkspaceUuid: "workspace-uuid", Kind: db.ArtifactSchema, Name: "Schema", Content: "CREATE TABLE bounty", Version: 2}
+
+		mockDb.On("GetChatByUuid", "chat-uuid").Return(db.Chat{Uuid: "chat-uuid", WorkspaceUuid: "workspace-uuid"}, nil).Once()
+		mockDb.On("GetWorkspaceByUuid", "workspace-uuid").Return(db.Workspace{Uuid: "workspace-uuid", OwnerPubKey: "owner-pubkey"}).Twice()
+		mockDb.On("AddChatMessage", mock.Anything).Return(func(m db.ChatMessage) (db.ChatMessage, error) {
+			return m, nil
+		}).Once()
+		mockDb.On("GetChatMessagesForChatID", "chat-uuid").Return([]db.ChatMessage{}, nil).Once()
+		mockDb.On("GetArtifactsByUuid", "workspace-uuid", []string{"artifact-uuid"}).Return([]db.WorkspaceArtifact{artifact}, nil).Once()
+		mockHttpClient.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+			body, _ := io.ReadAll(req.Body)
+			return bytes.Contains(body, []byte(`"artifacts":[{`)) && bytes.Contains(body, []byte(`"content":"CREATE TABLE bounty"`))
+		})).Return(&http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(bytes.NewBufferString(`{"success": true}`)),
+		}, nil).Once()
+		mockDb.On("CreateAiSubmission", mock.Anything).Return(nil).Once()
+		mockDb.On("CountWorkspaceUsage", "workspace-uuid", db.QuotaStakworkSubmissions, mock.Anything).Return(nil).Once()
+		mockDb.On("UpdateChatMessage", mock.Anything).Return(func(m db.ChatMessage) (db.ChatMessage, error) {
+			return m, nil
+		}).Once()
+
+		rr := httptest.NewRecorder()
+		body := `{"chat_uuid": "chat-uuid", "message": "Add a column", "context_tags": [{"type": "artifact", "id": "artifact-uuid"}]}`
+		req, _ := http.NewRequestWithContext(ctx, http.MethodPost, "/hivechat/send", bytes.NewBufferString(body))
+
+		http.HandlerFunc(chHandler.SendMessage).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		mockDb.AssertExpectations(t)
+		mockHttpClient.AssertExpectations(t)
+	})
+
+	t.Run("should mark the message as errored if stakwork fails", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mockHttpClient := &mocks.HttpClient{}
+		chHandler := NewChatHandler(mockHttpClient, mockDb)
+
+		mockDb.On("GetChatByUuid", "chat-uuid").Return(db.Chat{Uuid: "chat-uuid", WorkspaceUuid: "workspace-uuid"}, nil).Once()
+		mockDb.On("GetWorkspaceByUuid", "workspace-uuid").Return(db.Workspace{Uuid: "workspace-uuid", OwnerPubKey: "owner-pubkey"}).Twice()
+		mockDb.On("AddChatMessage", mock.Anything).Return(func(m db.ChatMessage) (db.ChatMessage, error) {
+			return m, nil
+		}).Once()
+		mockDb.On("GetChatMessagesForChatID", "chat-uuid").Return([]db.ChatMessage{}, nil).Once()
+		mockHttpClient.On("Do", mock.Anything).Return(&http.Response{
+			StatusCode: http.StatusInternalServerError,
+			Body:       io.NopCloser(bytes.NewBufferString(`{}`)),
+		}, nil).Once()
+		mockDb.On("CreateAiSubmission", mock.MatchedBy(func(s db.AiSubmission) bool {
+			return s.Status == db.AiSubmissionFailed && s.Error != ""
+		})).Return(nil).Once()
+		mockDb.On("UpdateChatMessage", mock.MatchedBy(func(m db.ChatMessage) bool {
+			return m.Status == db.ErrorStatus
+		})).Return(db.ChatMessage{}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		req, _ := http.NewRequestWithContext(ctx, http.MethodPost, "/hivechat/send", bytes.NewBufferString(`{"chat_uuid": "chat-uuid", "message": "hello"}`))
+
+		http.HandlerFunc(chHandler.SendMessage).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusBadGateway, rr.Code)
+		mockDb.AssertExpectations(t)
+	})
+}
+
+func TestProcessChatResponse(t *testing.T) {
+	t.Run("should create the assistant message on the first chunk", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		chHandler := NewChatHandler(&mocks.HttpClient{}, mockDb)
+
+		mockDb.On("GetChatByUuid", "chat-uuid").Return(db.Chat{Uuid: "chat-uuid", WorkspaceUuid: "workspace-uuid"}, nil).Once()
+		mockDb.On("GetChatMessageByUuid", "reply-uuid").Return(db.ChatMessage{}, errors.New("no chat message found")).Once()
+		mockDb.On("AddChatMessage", mock.MatchedBy(func(m db.ChatMessage) bool {
+			return m.Uuid == "reply-uuid" && m.Role == db.AssistantRole && m.Message == "Phase 1 has" && m.Status == db.SendingStatus
+		})).Return(func(m db.ChatMessage) (db.ChatMessage, error) {
+			return m, nil
+		}).Once()
+
+		rr := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/hivechat/response", bytes.NewBufferString(`{"chat_uuid": "chat-uuid", "message_uuid": "reply-uuid", "response": "Phase 1 has"}`))
+
+		http.HandlerFunc(chHandler.ProcessChatResponse).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should append later chunks and mark the message sent when done", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		chHandler := NewChatHandler(&mocks.HttpClient{}, mockDb)
+
+		existing := db.ChatMessage{Uuid: "reply-uuid", ChatUuid: "chat-uuid", Role: db.AssistantRole, Message: "Phase 1 has", Status: db.SendingStatus}
+		mockDb.On("GetChatByUuid", "chat-uuid").Return(db.Chat{Uuid: "chat-uuid", WorkspaceUuid: "workspace-uuid"}, nil).Once()
+		mockDb.On("GetChatMessageByUuid", "reply-uuid").Return(existing, nil).Once()
+		mockDb.On("UpdateChatMessage", mock.MatchedBy(func(m db.ChatMessage) bool {
+			return m.Message == "Phase 1 has two open bounties" && m.Status == db.SentStatus
+		})).Return(func(m db.ChatMessage) (db.ChatMessage, error) {
+			return m, nil
+		}).Once()
+
+		rr := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/hivechat/response", bytes.NewBufferString(`{"chat_uuid": "chat-uuid", "message_uuid": "reply-uuid", "response": " two open bounties", "done": true}`))
+
+		http.HandlerFunc(chHandler.ProcessChatResponse).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		mockDb.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,31 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/httpio"
+)
+
+type ConfigReloadResponse struct {
+	Changed []string `json:"changed"`
+}
+
+// GetConfig returns the running config with the secrets redacted
+func GetConfig(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(config.Get().Redacted())
+}
+
+// ReloadConfig swaps the reloadable values, like a SIGHUP does
+func ReloadConfig(w http.ResponseWriter, r *http.Request) {
+	changed, err := config.Reload()
+	if err != nil {
+		httpio.WriteError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(ConfigReloadResponse{Changed: changed})
+}
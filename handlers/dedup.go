@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/httpio"
+)
+
+// Endpoints with duplicate request suppression, as named in DEDUP_WINDOWS
+// and DEDUP_CONFLICT
+const (
+	DedupTribe  = "tribe"
+	DedupBounty = "bounty"
+)
+
+type dedupClaim struct {
+	key    string
+	window time.Duration
+}
+
+// done records the id of the resource the request created or edited, an
+// empty id drops the claim so a failed request can be sent again
+func (c *dedupClaim) done(id string) {
+	if c == nil {
+		return
+	}
+	if id == "" {
+		db.ReleaseDedup(c.key)
+		return
+	}
+	db.FinishDedup(c.key, id, c.window)
+}
+
+func dedupKey(endpoint string, pubkey string, body []byte) string {
+	hash := sha256.Sum256(body)
+	return endpoint + ":" + pubkey + ":" + hex.EncodeToString(hash[:])
+}
+
+func dedupConflict(endpoint string) bool {
+	for _, e := range config.Get().DedupConflict {
+		if e == endpoint {
+			return true
+		}
+	}
+	return false
+}
+
+// claimRequest suppresses double submitted forms. The first request of a
+// pubkey with a body claims it for the window of the endpoint, a repeat
+// within the window gets the resource the first one returned, found with
+// load. It gets a 409 instead when the first one is still running or the
+// endpoint is listed in DEDUP_CONFLICT. ok is false when the repeat was
+// answered, the claim is nil when there is nothing to suppress
+func claimRequest(w http.ResponseWriter, r *http.Request, endpoint string, pubkey string, body []byte, load func(id string) (interface{}, bool)) (claim *dedupClaim, ok bool) {
+	seconds := config.Get().DedupWindows[endpoint]
+	if seconds <= 0 || pubkey == "" {
+		return nil, true
+	}
+
+	key := dedupKey(endpoint, pubkey, body)
+	window := time.Duration(seconds) * time.Second
+	id, claimed := db.ClaimDedup(key, window)
+	if claimed {
+		return &dedupClaim{key: key, window: window}, true
+	}
+
+	if id == db.DedupPending {
+		httpio.WriteError(w, r, http.StatusConflict, "The same request is already being processed")
+		return nil, false
+	}
+	details := map[string]string{"id": id}
+	if dedupConflict(endpoint) {
+		httpio.WriteErrorDetails(w, r, http.StatusConflict, "Duplicate request", details)
+		return nil, false
+	}
+	existing, found := load(id)
+	if !found {
+		httpio.WriteErrorDetails(w, r, http.StatusConflict, "Duplicate request", details)
+		return nil, false
+	}
+
+	w.Header().Set("X-Duplicate-Request", "true")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(existing)
+	return nil, false
+}
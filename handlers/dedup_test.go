@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/httpio"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateOrEditTribeDedup(t *testing.T) {
+	t.Setenv("RELAY_URL", "http://localhost:3001")
+	t.Setenv("RELAY_AUTH_KEY", "RelayAuthKey")
+	t.Setenv("DEDUP_WINDOWS", "tribe=10")
+
+	newRequest := func(pubkey string) *http.Request {
+		body, _ := json.Marshal(map[string]interface{}{
+			"uuid":        "dedup-uuid-" + pubkey,
+			"name":        "Dedup Tribe",
+			"description": "created once",
+			"tags":        []string{},
+		})
+		ctx := context.WithValue(context.Background(), auth.ContextKey, pubkey)
+		req, _ := http.NewRequestWithContext(ctx, http.MethodPost, "/", bytes.NewReader(body))
+		return req
+	}
+	newHandler := func(mockDb *dbMocks.Database, pubkey string) *tribeHandler {
+		tHandler := NewTribeHandler(mockDb)
+		tHandler.verifyTribeUUID = func(uuid string, checkTimestamp bool) (string, error) {
+			return pubkey, nil
+		}
+		tHandler.tribeUniqueNameFromName = func(name string) (string, error) {
+			return "dedup-tribe", nil
+		}
+		return tHandler
+	}
+
+	t.Run("should return the existing tribe for a repeated request", func(t *testing.T) {
+		config.InitConfig()
+		pubkey := "dedup-repeat"
+		created := db.Tribe{UUID: "dedup-uuid-" + pubkey, OwnerPubKey: pubkey, Name: "Dedup Tribe"}
+
+		mockDb := dbMocks.NewDatabase(t)
+		tHandler := newHandler(mockDb, pubkey)
+		mockDb.On("IsBannedPubkey", pubkey).Return(false)
+		mockDb.On("GetTribe", created.UUID).Return(db.Tribe{}).Once()
+		mockDb.On("CreateOrEditTribe", mock.AnythingOfType("db.Tribe")).Return(created, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(tHandler.CreateOrEditTribe).ServeHTTP(rr, newRequest(pubkey))
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Empty(t, rr.Header().Get("X-Duplicate-Request"))
+
+		mockDb.On("GetTribe", created.UUID).Return(created)
+		rr = httptest.NewRecorder()
+		http.HandlerFunc(tHandler.CreateOrEditTribe).ServeHTTP(rr, newRequest(pubkey))
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "true", rr.Header().Get("X-Duplicate-Request"))
+
+		var tribe db.Tribe
+		json.Unmarshal(rr.Body.Bytes(), &tribe)
+		assert.Equal(t, created.UUID, tribe.UUID)
+		mockDb.AssertNumberOfCalls(t, "CreateOrEditTribe", 1)
+	})
+
+	t.Run("should answer 409 when the endpoint is configured to conflict", func(t *testing.T) {
+		t.Setenv("DEDUP_CONFLICT", "tribe")
+		config.InitConfig()
+		pubkey := "dedup-conflict"
+		created := db.Tribe{UUID: "dedup-uuid-" + pubkey, OwnerPubKey: pubkey, Name: "Dedup Tribe"}
+
+		mockDb := dbMocks.NewDatabase(t)
+		tHandler := newHandler(mockDb, pubkey)
+		mockDb.On("IsBannedPubkey", pubkey).Return(false)
+		mockDb.On("GetTribe", created.UUID).Return(db.Tribe{})
+		mockDb.On("CreateOrEditTribe", mock.AnythingOfType("db.Tribe")).Return(created, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(tHandler.CreateOrEditTribe).ServeHTTP(rr, newRequest(pubkey))
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		rr = httptest.NewRecorder()
+		http.HandlerFunc(tHandler.CreateOrEditTribe).ServeHTTP(rr, newRequest(pubkey))
+		assert.Equal(t, http.StatusConflict, rr.Code)
+
+		var res httpio.ErrorResponse
+		json.Unmarshal(rr.Body.Bytes(), &res)
+		assert.Equal(t, httpio.CodeConflict, res.Code)
+		assert.Equal(t, map[string]interface{}{"id": created.UUID}, res.Details)
+	})
+
+	t.Run("should let a failed request be sent again", func(t *testing.T) {
+		config.InitConfig()
+		pubkey := "dedup-failed"
+		created := db.Tribe{UUID: "dedup-uuid-" + pubkey, OwnerPubKey: pubkey, Name: "Dedup Tribe"}
+
+		mockDb := dbMocks.NewDatabase(t)
+		tHandler := newHandler(mockDb, pubkey)
+		mockDb.On("IsBannedPubkey", pubkey).Return(false)
+		mockDb.On("GetTribe", created.UUID).Return(db.Tribe{})
+		mockDb.On("CreateOrEditTribe", mock.AnythingOfType("db.Tribe")).Return(db.Tribe{}, assert.AnError).Once()
+		mockDb.On("CreateOrEditTribe", mock.AnythingOfType("db.Tribe")).Return(created, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(tHandler.CreateOrEditTribe).ServeHTTP(rr, newRequest(pubkey))
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+		rr = httptest.NewRecorder()
+		http.HandlerFunc(tHandler.CreateOrEditTribe).ServeHTTP(rr, newRequest(pubkey))
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Empty(t, rr.Header().Get("X-Duplicate-Request"))
+	})
+}
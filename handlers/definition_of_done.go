@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/stakwork/sphinx-tribes/db"
+)
+
+// the conditions of the definition of done of a workspace
+const (
+	DoneChecklist         = "checklist_complete"
+	DonePullRequestLinked = "pull_request_linked"
+	DonePullRequestMerged = "pull_request_merged"
+	DoneProofApproved     = "proof_approved"
+)
+
+var (
+	// an open item of a markdown task list, "- [ ] write the tests"
+	openChecklistItem = regexp.MustCompile(`(?m)^\s*[-*+]\s+\[ \]`)
+	pullRequestUrl    = regexp.MustCompile(`^https://github\.com/([^/]+)/([^/]+)/pull/(\d+)/?$`)
+)
+
+// pullRequestMerged asks GitHub if a pull request is merged, tests replace it
+var pullRequestMerged = func(ctx context.Context, owner string, repo string, number int) (bool, error) {
+	merged, _, err := githubClient().PullRequests.IsMerged(ctx, owner, repo, number)
+	return merged, err
+}
+
+// openChecklistItems counts the unchecked items of the task lists in a text
+func openChecklistItems(text string) int {
+	return len(openChecklistItem.FindAllString(text, -1))
+}
+
+// parsePullRequestUrl splits the url of a GitHub pull request, ok is false
+// for any other url
+func parsePullRequestUrl(url string) (owner string, repo string, number int, ok bool) {
+	match := pullRequestUrl.FindStringSubmatch(url)
+	if match == nil {
+		return "", "", 0, false
+	}
+	number, err := strconv.Atoi(match[3])
+	if err != nil || number < 1 {
+		return "", "", 0, false
+	}
+	return match[1], match[2], number, true
+}
+
+// unmetDoneConditions lists the conditions of the definition of done of the
+// workspace that a bounty doesn't meet, it is empty when the bounty can be
+// accepted as completed
+func unmetDoneConditions(ctx context.Context, workspace db.Workspace, bounty db.NewBounty) []db.UnmetCondition {
+	unmet := []db.UnmetCondition{}
+
+	if workspace.DoneRequiresChecklist {
+		if open := openChecklistItems(bounty.Description) + openChecklistItems(bounty.Deliverables); open > 0 {
+			unmet = append(unmet, db.UnmetCondition{
+				Condition: DoneChecklist,
+				Message:   fmt.Sprintf("%d checklist item(s) are not checked", open),
+			})
+		}
+	}
+
+	if workspace.DoneRequiresPullRequest {
+		owner, repo, number, ok := parsePullRequestUrl(bounty.PullRequestUrl)
+		if !ok {
+			return append(unmet, db.UnmetCondition{
+				Condition: DonePullRequestLinked,
+				Message:   "the bounty has no linked GitHub pull request",
+			})
+		}
+		merged, err := pullRequestMerged(ctx, owner, repo, number)
+		if err != nil {
+			fmt.Println("[bounty] could not check the pull request", bounty.PullRequestUrl, err)
+			return append(unmet, db.UnmetCondition{
+				Condition: DonePullRequestMerged,
+				Message:   "the linked pull request could not be checked on GitHub",
+			})
+		}
+		if !merged {
+			unmet = append(unmet, db.UnmetCondition{
+				Condition: DonePullRequestMerged,
+				Message:   "the linked pull request is not merged",
+			})
+		}
+	}
+
+	return unmet
+}
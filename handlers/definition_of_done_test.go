@@ -0,0 +1,133 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/handlers/mocks"
+	"github.com/stakwork/sphinx-tribes/httpio"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestOpenChecklistItems(t *testing.T) {
+	text := "Deliverables:\n- [x] the endpoint\n- [ ] the tests\n  * [ ] the docs\nnot a [ ] list item"
+	assert.Equal(t, 2, openChecklistItems(text))
+	assert.Equal(t, 0, openChecklistItems("- [x] done\n- [X] done too"))
+}
+
+func TestParsePullRequestUrl(t *testing.T) {
+	owner, repo, number, ok := parsePullRequestUrl("https://github.com/stakwork/sphinx-tribes/pull/1234")
+	assert.True(t, ok)
+	assert.Equal(t, "stakwork", owner)
+	assert.Equal(t, "sphinx-tribes", repo)
+	assert.Equal(t, 1234, number)
+
+	for _, url := range []string{"", "https://github.com/stakwork/sphinx-tribes/issues/12", "https://gitlab.com/a/b/pull/1", "https://github.com/a/b/pull/0"} {
+		_, _, _, ok := parsePullRequestUrl(url)
+		assert.False(t, ok, url)
+	}
+}
+
+func TestDefinitionOfDone(t *testing.T) {
+	defer func(merged func(ctx context.Context, owner string, repo string, number int) (bool, error)) {
+		pullRequestMerged = merged
+	}(pullRequestMerged)
+
+	ctx := context.WithValue(context.Background(), auth.ContextKey, "owner-pubkey")
+	workspace := db.Workspace{Uuid: "work-1", OwnerPubKey: "owner-pubkey", DoneRequiresChecklist: true, DoneRequiresPullRequest: true}
+	bounty := db.NewBounty{
+		ID:             1,
+		Created:        1700000000,
+		WorkspaceUuid:  "work-1",
+		Assignee:       "assignee-1",
+		Price:          1000,
+		Deliverables:   "- [x] the endpoint\n- [ ] the tests",
+		PullRequestUrl: "https://github.com/stakwork/sphinx-tribes/pull/1234",
+	}
+	newRequest := func() *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("created", "1700000000")
+		req, _ := http.NewRequestWithContext(context.WithValue(ctx, chi.RouteCtxKey, rctx), http.MethodPost, "/gobounties/completedstatus/1700000000", nil)
+		return req
+	}
+
+	t.Run("should refuse the completion with the unmet conditions", func(t *testing.T) {
+		pullRequestMerged = func(ctx context.Context, owner string, repo string, number int) (bool, error) { return false, nil }
+		mockDb := &dbMocks.Database{}
+		bHandler := NewBountyHandler(&mocks.HttpClient{}, mockDb)
+
+		mockDb.On("GetBountyByCreated", uint(1700000000)).Return(bounty, nil).Once()
+		mockDb.On("GetWorkspaceByUuid", "work-1").Return(workspace).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(bHandler.UpdateCompletedStatus).ServeHTTP(rr, newRequest())
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+		res := struct {
+			Code    string `json:"code"`
+			Details struct {
+				Unmet []db.UnmetCondition `json:"unmet"`
+			} `json:"details"`
+		}{}
+		json.Unmarshal(rr.Body.Bytes(), &res)
+		assert.Equal(t, httpio.CodeUnprocessableEntity, res.Code)
+		assert.Len(t, res.Details.Unmet, 2)
+		assert.Equal(t, DoneChecklist, res.Details.Unmet[0].Condition)
+		assert.Equal(t, DonePullRequestMerged, res.Details.Unmet[1].Condition)
+		mockDb.AssertNotCalled(t, "UpdateBountyCompleted", mock.Anything)
+	})
+
+	t.Run("should ask for a linked pull request", func(t *testing.T) {
+		unlinked := bounty
+		unlinked.Deliverables = ""
+		unlinked.PullRequestUrl = ""
+
+		unmet := unmetDoneConditions(context.Background(), workspace, unlinked)
+
+		assert.Equal(t, []db.UnmetCondition{{Condition: DonePullRequestLinked, Message: "the bounty has no linked GitHub pull request"}}, unmet)
+	})
+
+	t.Run("should report a pull request that can't be checked", func(t *testing.T) {
+		pullRequestMerged = func(ctx context.Context, owner string, repo string, number int) (bool, error) {
+			return false, errors.New("rate limited")
+		}
+		done := bounty
+		done.Deliverables = "- [x] the endpoint"
+
+		unmet := unmetDoneConditions(context.Background(), workspace, done)
+
+		assert.Len(t, unmet, 1)
+		assert.Equal(t, DonePullRequestMerged, unmet[0].Condition)
+	})
+
+	t.Run("should accept a bounty that meets the definition of done", func(t *testing.T) {
+		pullRequestMerged = func(ctx context.Context, owner string, repo string, number int) (bool, error) {
+			assert.Equal(t, "stakwork", owner)
+			assert.Equal(t, 1234, number)
+			return true, nil
+		}
+		done := bounty
+		done.Deliverables = "- [x] the endpoint\n- [x] the tests"
+		mockDb := &dbMocks.Database{}
+		bHandler := NewBountyHandler(&mocks.HttpClient{}, mockDb)
+
+		mockDb.On("GetBountyByCreated", uint(1700000000)).Return(done, nil).Once()
+		mockDb.On("GetWorkspaceByUuid", "work-1").Return(workspace).Once()
+		mockDb.On("UpdateBountyCompleted", mock.MatchedBy(func(b db.NewBounty) bool { return b.Completed })).Return(done, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(bHandler.UpdateCompletedStatus).ServeHTTP(rr, newRequest())
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		mockDb.AssertExpectations(t)
+	})
+}
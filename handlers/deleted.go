@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/events"
+	"github.com/stakwork/sphinx-tribes/httpio"
+	"github.com/stakwork/sphinx-tribes/websocket"
+)
+
+type deletedHandler struct {
+	db db.Database
+}
+
+func NewDeletedHandler(database db.Database) *deletedHandler {
+	return &deletedHandler{db: database}
+}
+
+// GetDeleted lists the soft deleted tribes, channels, bounties or features
+func (dh *deletedHandler) GetDeleted(w http.ResponseWriter, r *http.Request) {
+	kind := chi.URLParam(r, "kind")
+	if !db.IsSoftDeletable(kind) {
+		httpio.WriteError(w, r, http.StatusBadRequest, "Deleted tribes, channels, bounties or features")
+		return
+	}
+
+	deleted, err := dh.db.GetSoftDeleted(kind, r)
+	if err != nil {
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to get the deleted records")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(deleted)
+}
+
+// RestoreDeleted brings back a soft deleted record before retention purges
+// it
+func (dh *deletedHandler) RestoreDeleted(w http.ResponseWriter, r *http.Request) {
+	kind := chi.URLParam(r, "kind")
+	id := chi.URLParam(r, "id")
+	if !db.IsSoftDeletable(kind) {
+		httpio.WriteError(w, r, http.StatusBadRequest, "Deleted tribes, channels, bounties or features")
+		return
+	}
+
+	found, err := dh.db.RestoreDeleted(kind, id)
+	if err != nil {
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to restore the record")
+		return
+	}
+	if !found {
+		httpio.WriteError(w, r, http.StatusNotFound, "No deleted record with this id")
+		return
+	}
+
+	switch kind {
+	case db.SoftDeleteTribes:
+		events.Publish(r.Context(), events.TribeUpdated, "tribe:"+id, map[string]interface{}{"uuid": id, "deleted": false})
+	case db.SoftDeleteBounties:
+		bountyId, _ := strconv.ParseUint(id, 10, 32)
+		bounty := dh.db.GetBounty(uint(bountyId))
+		events.Publish(r.Context(), events.BountyUpdated, websocket.Topic(websocket.TopicBounty, bounty.ID), bounty)
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(true)
+}
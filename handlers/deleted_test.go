@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/db"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetDeleted(t *testing.T) {
+	newRequest := func(kind string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("kind", kind)
+		req, _ := http.NewRequestWithContext(context.WithValue(context.Background(), chi.RouteCtxKey, rctx), http.MethodGet, "/admin/deleted/"+kind, nil)
+		return req
+	}
+
+	t.Run("should reject a kind that is not soft deleted", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		dHandler := NewDeletedHandler(mockDb)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(dHandler.GetDeleted).ServeHTTP(rr, newRequest("people"))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockDb.AssertNotCalled(t, "GetSoftDeleted", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should list the deleted bounties", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		dHandler := NewDeletedHandler(mockDb)
+		deleted := []db.SoftDeleted{{Kind: db.SoftDeleteBounties, ID: "12", Title: "Fix the build"}}
+		mockDb.On("GetSoftDeleted", db.SoftDeleteBounties, mock.AnythingOfType("*http.Request")).Return(deleted, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(dHandler.GetDeleted).ServeHTTP(rr, newRequest(db.SoftDeleteBounties))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var body []db.SoftDeleted
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+		assert.Equal(t, deleted, body)
+		mockDb.AssertExpectations(t)
+	})
+}
+
+func TestRestoreDeleted(t *testing.T) {
+	newRequest := func(kind string, id string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("kind", kind)
+		rctx.URLParams.Add("id", id)
+		req, _ := http.NewRequestWithContext(context.WithValue(context.Background(), chi.RouteCtxKey, rctx), http.MethodPost, "/admin/deleted/"+kind+"/"+id+"/restore", nil)
+		return req
+	}
+
+	t.Run("should return not found when nothing was deleted", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		dHandler := NewDeletedHandler(mockDb)
+		mockDb.On("RestoreDeleted", db.SoftDeleteTribes, "tribe-uuid").Return(false, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(dHandler.RestoreDeleted).ServeHTTP(rr, newRequest(db.SoftDeleteTribes, "tribe-uuid"))
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should restore a deleted bounty", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		dHandler := NewDeletedHandler(mockDb)
+		mockDb.On("RestoreDeleted", db.SoftDeleteBounties, "12").Return(true, nil).Once()
+		mockDb.On("GetBounty", uint(12)).Return(db.NewBounty{ID: 12}).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(dHandler.RestoreDeleted).ServeHTTP(rr, newRequest(db.SoftDeleteBounties, "12"))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		mockDb.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/httpio"
+)
+
+// endorsementHalfLife is how long an endorsement takes to lose half of its
+// weight, 0 doesn't decay
+func endorsementHalfLife() time.Duration {
+	return time.Duration(config.Get().EndorsementHalfLifeDays) * 24 * time.Hour
+}
+
+// EndorsePerson lets the caller vouch for the person of the route, once per
+// ENDORSEMENT_PAIR_DAYS. What it adds to their reputation depends on the
+// reputation of the caller
+func (ph *peopleHandler) EndorsePerson(w http.ResponseWriter, r *http.Request) {
+	pubKeyFromAuth, _ := r.Context().Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[endorsements] no pubkey from auth")
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
+		return
+	}
+	endorsee := chi.URLParam(r, "pubkey")
+	if endorsee == pubKeyFromAuth {
+		httpio.WriteError(w, r, http.StatusBadRequest, "You can't endorse yourself")
+		return
+	}
+	if ph.db.GetPersonByPubkey(endorsee).ID == 0 {
+		httpio.WriteError(w, r, http.StatusNotFound, "Person not found")
+		return
+	}
+	if ph.db.GetPersonByPubkey(pubKeyFromAuth).ID == 0 {
+		httpio.WriteError(w, r, http.StatusUnauthorized, "You need a profile to endorse someone")
+		return
+	}
+
+	endorsement := db.PersonEndorsement{}
+	body, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	if len(body) > 0 {
+		if err := json.Unmarshal(body, &endorsement); err != nil {
+			fmt.Println("[endorsements]", err)
+			httpio.WriteError(w, r, http.StatusNotAcceptable, "")
+			return
+		}
+	}
+	if !validatePayload(w, r, endorsement) {
+		return
+	}
+
+	now := time.Now()
+	if days := config.Get().EndorsementPairDays; days > 0 {
+		last, err := ph.db.GetLastEndorsement(pubKeyFromAuth, endorsee)
+		if err == nil && last.Created != nil {
+			next := last.Created.Add(time.Duration(days) * 24 * time.Hour)
+			if now.Before(next) {
+				w.Header().Set("Retry-After", strconv.Itoa(int(next.Sub(now).Seconds())+1))
+				httpio.WriteErrorDetails(w, r, http.StatusTooManyRequests, "You already endorsed this person recently", map[string]interface{}{
+					"last_endorsed": last.Created,
+					"next_allowed":  next,
+				})
+				return
+			}
+		}
+	}
+
+	saved, err := ph.db.CreatePersonEndorsement(db.PersonEndorsement{
+		Endorser: pubKeyFromAuth,
+		Endorsee: endorsee,
+		Skill:    strings.ToLower(strings.TrimSpace(endorsement.Skill)),
+		Note:     strings.TrimSpace(endorsement.Note),
+		Created:  &now,
+	})
+	if err != nil {
+		fmt.Println("[endorsements]", err)
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to save the endorsement")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(saved)
+}
+
+// GetPersonReputation returns the reputation of the person of the route
+// with the endorsements it counts
+func (ph *peopleHandler) GetPersonReputation(w http.ResponseWriter, r *http.Request) {
+	pubkey := chi.URLParam(r, "pubkey")
+	if ph.db.GetPersonByPubkey(pubkey).ID == 0 {
+		httpio.WriteError(w, r, http.StatusNotFound, "Person not found")
+		return
+	}
+
+	reputations, err := ph.db.GetReputations([]string{pubkey}, time.Now(), endorsementHalfLife())
+	if err != nil {
+		fmt.Println("[endorsements]", err)
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to get the reputation")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(reputations[pubkey])
+}
@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/db"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestEndorsements(t *testing.T) {
+	t.Setenv("RELAY_URL", "http://localhost:3001")
+	t.Setenv("RELAY_AUTH_KEY", "RelayAuthKey")
+	config.InitConfig()
+
+	newRequest := func(method string, pubkey string, endorsee string, body string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("pubkey", endorsee)
+		ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+		ctx = context.WithValue(ctx, auth.ContextKey, pubkey)
+		req, _ := http.NewRequestWithContext(ctx, method, "/people/"+endorsee+"/endorsements", bytes.NewBufferString(body))
+		return req
+	}
+
+	t.Run("should refuse to endorse yourself", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		pHandler := NewPeopleHandler(mockDb)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(pHandler.EndorsePerson).ServeHTTP(rr, newRequest(http.MethodPost, "alice", "alice", ""))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockDb.AssertNotCalled(t, "CreatePersonEndorsement", mock.Anything)
+	})
+
+	t.Run("should endorse a person for a skill", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		pHandler := NewPeopleHandler(mockDb)
+
+		mockDb.On("GetPersonByPubkey", "bob").Return(db.Person{ID: 2, OwnerPubKey: "bob"}).Once()
+		mockDb.On("GetPersonByPubkey", "alice").Return(db.Person{ID: 1, OwnerPubKey: "alice"}).Once()
+		mockDb.On("GetLastEndorsement", "alice", "bob").Return(db.PersonEndorsement{}, nil).Once()
+		mockDb.On("CreatePersonEndorsement", mock.MatchedBy(func(e db.PersonEndorsement) bool {
+			return e.Endorser == "alice" && e.Endorsee == "bob" && e.Skill == "golang" && e.Created != nil
+		})).Return(func(e db.PersonEndorsement) (db.PersonEndorsement, error) { return e, nil }).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(pHandler.EndorsePerson).ServeHTTP(rr, newRequest(http.MethodPost, "alice", "bob", `{"skill": " Golang "}`))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should refuse to endorse the same person again within the pair window", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		pHandler := NewPeopleHandler(mockDb)
+		last := time.Now().Add(-24 * time.Hour)
+
+		mockDb.On("GetPersonByPubkey", "bob").Return(db.Person{ID: 2, OwnerPubKey: "bob"}).Once()
+		mockDb.On("GetPersonByPubkey", "alice").Return(db.Person{ID: 1, OwnerPubKey: "alice"}).Once()
+		mockDb.On("GetLastEndorsement", "alice", "bob").Return(db.PersonEndorsement{Endorser: "alice", Endorsee: "bob", Created: &last}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(pHandler.EndorsePerson).ServeHTTP(rr, newRequest(http.MethodPost, "alice", "bob", ""))
+
+		assert.Equal(t, http.StatusTooManyRequests, rr.Code)
+		assert.NotEmpty(t, rr.Header().Get("Retry-After"))
+		mockDb.AssertNotCalled(t, "CreatePersonEndorsement", mock.Anything)
+	})
+
+	t.Run("should return the reputation of a person with its endorsements", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		pHandler := NewPeopleHandler(mockDb)
+		created := time.Now()
+
+		mockDb.On("GetPersonByPubkey", "bob").Return(db.Person{ID: 2, OwnerPubKey: "bob"}).Once()
+		mockDb.On("GetReputations", []string{"bob"}, mock.Anything, endorsementHalfLife()).Return(map[string]db.Reputation{
+			"bob": {Pubkey: "bob", PaidBounties: 3, Endorsements: 0.5, Reputation: 3.5, Received: []db.PersonEndorsement{{Endorser: "alice", Endorsee: "bob", Created: &created}}},
+		}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(pHandler.GetPersonReputation).ServeHTTP(rr, newRequest(http.MethodGet, "", "bob", ""))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		reputation := db.Reputation{}
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &reputation))
+		assert.Equal(t, 3.5, reputation.Reputation)
+		assert.Len(t, reputation.Received, 1)
+	})
+
+	t.Run("should order the leaderboard by reputation", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		leaders := []db.LeaderData{
+			{"owner_pubkey": "alice", "total_sats_earned": 5000},
+			{"owner_pubkey": "bob", "total_sats_earned": 1000},
+		}
+
+		mockDb.On("GetReputations", []string{"alice", "bob"}, mock.Anything, endorsementHalfLife()).Return(map[string]db.Reputation{
+			"alice": {Pubkey: "alice", Reputation: 1},
+			"bob":   {Pubkey: "bob", Reputation: 4.25},
+		}, nil).Once()
+
+		ranked := withReputation(mockDb, leaders, true)
+
+		assert.Equal(t, "bob", ranked[0]["owner_pubkey"])
+		assert.Equal(t, 4.25, ranked[0]["reputation"])
+		assert.Equal(t, 1.0, ranked[1]["reputation"])
+	})
+}
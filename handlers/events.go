@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/events"
+	"github.com/stakwork/sphinx-tribes/httpio"
+	"github.com/stakwork/sphinx-tribes/utils"
+)
+
+type eventHandler struct {
+	db db.Database
+}
+
+func NewEventHandler(database db.Database) *eventHandler {
+	return &eventHandler{db: database}
+}
+
+// GetEvents lists the event log newest first, filtered by the type and
+// subject query params
+func (eh *eventHandler) GetEvents(w http.ResponseWriter, r *http.Request) {
+	list, err := eh.db.GetEvents(r)
+	if err != nil {
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to get events")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(list)
+}
+
+// activityTypes are the event types of each kind of the activity feed
+var activityTypes = map[string][]string{
+	"tribes":   {events.TribeCreated, events.TribeUpdated, events.TribeJoined},
+	"bounties": {events.BountyCreated, events.BountyUpdated, events.BountyDeleted},
+	"tickets":  {events.TicketUpdated},
+	"payments": {events.PaymentSettled, events.BudgetUpdated},
+	"mentions": {events.PersonMentioned},
+}
+
+// GetMyActivity lists the events that concern the caller newest first, the
+// types query param keeps the tribes, bounties, tickets, payments or
+// mentions ones
+func (eh *eventHandler) GetMyActivity(w http.ResponseWriter, r *http.Request) {
+	pubKeyFromAuth, _ := r.Context().Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
+		return
+	}
+
+	kinds := []string{"tribes", "bounties", "tickets", "payments"}
+	if value := r.URL.Query().Get("types"); value != "" {
+		kinds = strings.Split(value, ",")
+	}
+	types := []string{}
+	for _, kind := range kinds {
+		kindTypes, ok := activityTypes[strings.TrimSpace(kind)]
+		if !ok {
+			httpio.WriteError(w, r, http.StatusBadRequest, "types are tribes, bounties, tickets, payments or mentions")
+			return
+		}
+		types = append(types, kindTypes...)
+	}
+
+	list, err := eh.db.GetUserActivity(pubKeyFromAuth, types, r)
+	if err != nil {
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to get the activity")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(utils.ListBody(r, list, func() int64 {
+		return eh.db.GetUserActivityCount(pubKeyFromAuth, types)
+	}))
+}
+
+func GetEventStats(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(events.Stats())
+}
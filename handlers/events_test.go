@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/events"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetMyActivity(t *testing.T) {
+	newRequest := func(pubkey string, query string) *http.Request {
+		ctx := context.WithValue(context.Background(), auth.ContextKey, pubkey)
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "/me/activity"+query, nil)
+		return req
+	}
+
+	t.Run("should require an authenticated user", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		eHandler := NewEventHandler(mockDb)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(eHandler.GetMyActivity).ServeHTTP(rr, newRequest("", ""))
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		mockDb.AssertNotCalled(t, "GetUserActivity", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should list the activity of every kind by default", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		eHandler := NewEventHandler(mockDb)
+		activity := []db.Event{{ID: 2, Type: events.PaymentSettled}, {ID: 1, Type: events.BountyCreated}}
+
+		mockDb.On("GetUserActivity", "pubkey", mock.MatchedBy(func(types []string) bool {
+			return len(types) == 9
+		}), mock.Anything).Return(activity, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(eHandler.GetMyActivity).ServeHTTP(rr, newRequest("pubkey", ""))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var res []db.Event
+		json.Unmarshal(rr.Body.Bytes(), &res)
+		assert.Equal(t, []uint{2, 1}, []uint{res[0].ID, res[1].ID})
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should filter the activity by kind", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		eHandler := NewEventHandler(mockDb)
+
+		mockDb.On("GetUserActivity", "pubkey", []string{events.PaymentSettled, events.BudgetUpdated, events.TicketUpdated}, mock.Anything).Return([]db.Event{}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(eHandler.GetMyActivity).ServeHTTP(rr, newRequest("pubkey", "?types=payments,tickets"))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should reject an unknown kind", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		eHandler := NewEventHandler(mockDb)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(eHandler.GetMyActivity).ServeHTTP(rr, newRequest("pubkey", "?types=people"))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
@@ -0,0 +1,181 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/httpio"
+)
+
+type featureBudgetRequest struct {
+	Amount uint `json:"amount"`
+}
+
+// AllocateFeatureBudget allocates a part of the workspace budget to a
+// feature. The features can't hold more than the workspace budget, and an
+// allocation can't drop below what the feature bounties already use
+func (oh *featureHandler) AllocateFeatureBudget(w http.ResponseWriter, r *http.Request) {
+	pubKeyFromAuth, _ := r.Context().Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[features] no pubkey from auth")
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
+		return
+	}
+
+	feature := oh.db.GetFeatureByUuid(chi.URLParam(r, "uuid"))
+	if feature.Uuid == "" {
+		httpio.WriteError(w, r, http.StatusNotFound, "Feature not found")
+		return
+	}
+	if !oh.userHasAccess(pubKeyFromAuth, feature.WorkspaceUuid, db.AddBudget) {
+		httpio.WriteError(w, r, http.StatusUnauthorized, "Don't have access to allocate the budget")
+		return
+	}
+
+	request := featureBudgetRequest{}
+	body, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err := json.Unmarshal(body, &request); err != nil {
+		fmt.Println("[features]", err)
+		httpio.WriteError(w, r, http.StatusNotAcceptable, "")
+		return
+	}
+
+	spent, committed := bountiesUsage(oh.db.GetFeatureBounties(feature.Uuid))
+	if request.Amount > 0 && request.Amount < spent+committed {
+		httpio.WriteErrorDetails(w, r, http.StatusBadRequest, "The allocation is lower than what the feature bounties use", map[string]interface{}{
+			"spent":     spent,
+			"committed": committed,
+		})
+		return
+	}
+
+	// the allocations of the other features, what this feature still holds
+	// is freed by the new allocation
+	held := int64(oh.db.GetWorkspaceAllocatedBudget(feature.WorkspaceUuid))
+	if feature.Budget > spent {
+		held -= int64(feature.Budget - spent)
+	}
+	available := int64(oh.db.GetWorkspaceBudget(feature.WorkspaceUuid).TotalBudget) - held
+	if request.Amount > spent && int64(request.Amount-spent) > available {
+		httpio.WriteErrorDetails(w, r, http.StatusBadRequest, "Not enough unallocated workspace budget", map[string]interface{}{
+			"available": available,
+		})
+		return
+	}
+
+	updated, err := oh.db.AllocateFeatureBudget(feature, request.Amount, pubKeyFromAuth)
+	if err != nil {
+		fmt.Println("[features]", err)
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to allocate the budget")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(updated)
+}
+
+// GetFeatureBurndown returns the allocation of a feature against what its
+// bounties spent and committed over time, one point per day
+func (oh *featureHandler) GetFeatureBurndown(w http.ResponseWriter, r *http.Request) {
+	pubKeyFromAuth, _ := r.Context().Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[features] no pubkey from auth")
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
+		return
+	}
+
+	feature := oh.db.GetFeatureByUuid(chi.URLParam(r, "uuid"))
+	if feature.Uuid == "" {
+		httpio.WriteError(w, r, http.StatusNotFound, "Feature not found")
+		return
+	}
+	if !oh.userHasAccess(pubKeyFromAuth, feature.WorkspaceUuid, db.ViewReport) {
+		httpio.WriteError(w, r, http.StatusUnauthorized, "Don't have access to view budget")
+		return
+	}
+
+	burndown := featureBurndown(feature, oh.db.GetFeatureBudgetHistory(feature.Uuid), oh.db.GetFeatureBounties(feature.Uuid), time.Now())
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(burndown)
+}
+
+// bountiesUsage splits the prices of bounties into what was paid and what
+// is still owed
+func bountiesUsage(bounties []db.NewBounty) (spent uint, committed uint) {
+	for _, bounty := range bounties {
+		if bounty.Paid {
+			spent += bounty.Price
+		} else {
+			committed += bounty.Price
+		}
+	}
+	return spent, committed
+}
+
+// bountyPaidAt guesses when a paid bounty was paid for the bounties paid
+// before the paid date was recorded
+func bountyPaidAt(bounty db.NewBounty) time.Time {
+	switch {
+	case bounty.PaidDate != nil:
+		return *bounty.PaidDate
+	case bounty.MarkAsPaidDate != nil:
+		return *bounty.MarkAsPaidDate
+	case bounty.Updated != nil:
+		return *bounty.Updated
+	}
+	return time.Unix(bounty.Created, 0)
+}
+
+func featureBurndown(feature db.WorkspaceFeatures, history []db.FeatureBudgetHistory, bounties []db.NewBounty, now time.Time) db.FeatureBurndown {
+	spent, committed := bountiesUsage(bounties)
+	burndown := db.FeatureBurndown{
+		FeatureUuid: feature.Uuid,
+		Allocated:   feature.Budget,
+		Spent:       spent,
+		Committed:   committed,
+		Remaining:   int(feature.Budget) - int(spent+committed),
+		Points:      []db.BurndownPoint{},
+	}
+
+	start := now
+	if len(history) > 0 && history[0].Created != nil && history[0].Created.Before(start) {
+		start = *history[0].Created
+	}
+	for _, bounty := range bounties {
+		if created := time.Unix(bounty.Created, 0); created.Before(start) {
+			start = created
+		}
+	}
+
+	day := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, now.Location())
+	for !day.After(now) {
+		end := day.AddDate(0, 0, 1)
+		point := db.BurndownPoint{Date: day.Format("2006-01-02")}
+		for _, allocation := range history {
+			if allocation.Created != nil && allocation.Created.Before(end) {
+				point.Allocated = allocation.Amount
+			}
+		}
+		for _, bounty := range bounties {
+			if !time.Unix(bounty.Created, 0).Before(end) {
+				continue
+			}
+			if bounty.Paid && bountyPaidAt(bounty).Before(end) {
+				point.Spent += bounty.Price
+			} else {
+				point.Committed += bounty.Price
+			}
+		}
+		burndown.Points = append(burndown.Points, point)
+		day = end
+	}
+	return burndown
+}
@@ -0,0 +1,129 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAllocateFeatureBudget(t *testing.T) {
+	feature := db.WorkspaceFeatures{Uuid: "feature-uuid", WorkspaceUuid: "workspace-uuid", Budget: 2000}
+	newRequest := func(body string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("uuid", feature.Uuid)
+		ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+		ctx = context.WithValue(ctx, auth.ContextKey, "admin-pubkey")
+		req, _ := http.NewRequestWithContext(ctx, http.MethodPut, "/features/feature-uuid/budget", bytes.NewBufferString(body))
+		return req
+	}
+	hasAccess := func(pubKeyFromAuth string, uuid string, role string) bool {
+		return pubKeyFromAuth == "admin-pubkey" && role == db.AddBudget
+	}
+
+	t.Run("should allocate out of the unallocated workspace budget", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		fHandler := NewFeatureHandler(mockDb)
+		fHandler.userHasAccess = hasAccess
+
+		mockDb.On("GetFeatureByUuid", feature.Uuid).Return(feature).Once()
+		mockDb.On("GetFeatureBounties", feature.Uuid).Return([]db.NewBounty{{Price: 500, Paid: true}, {Price: 1000}}).Once()
+		// the other features hold 6000, this one still holds 1500
+		mockDb.On("GetWorkspaceAllocatedBudget", feature.WorkspaceUuid).Return(uint(7500)).Once()
+		mockDb.On("GetWorkspaceBudget", feature.WorkspaceUuid).Return(db.NewBountyBudget{TotalBudget: 10000}).Once()
+		mockDb.On("AllocateFeatureBudget", feature, uint(4500), "admin-pubkey").Return(db.WorkspaceFeatures{Uuid: feature.Uuid, Budget: 4500}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(fHandler.AllocateFeatureBudget).ServeHTTP(rr, newRequest(`{"amount": 4500}`))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should refuse more than the unallocated workspace budget", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		fHandler := NewFeatureHandler(mockDb)
+		fHandler.userHasAccess = hasAccess
+
+		mockDb.On("GetFeatureByUuid", feature.Uuid).Return(feature).Once()
+		mockDb.On("GetFeatureBounties", feature.Uuid).Return([]db.NewBounty{{Price: 500, Paid: true}, {Price: 1000}}).Once()
+		mockDb.On("GetWorkspaceAllocatedBudget", feature.WorkspaceUuid).Return(uint(7500)).Once()
+		mockDb.On("GetWorkspaceBudget", feature.WorkspaceUuid).Return(db.NewBountyBudget{TotalBudget: 10000}).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(fHandler.AllocateFeatureBudget).ServeHTTP(rr, newRequest(`{"amount": 4600}`))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"available":4000`)
+		mockDb.AssertNotCalled(t, "AllocateFeatureBudget", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should refuse an allocation below what the bounties use", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		fHandler := NewFeatureHandler(mockDb)
+		fHandler.userHasAccess = hasAccess
+
+		mockDb.On("GetFeatureByUuid", feature.Uuid).Return(feature).Once()
+		mockDb.On("GetFeatureBounties", feature.Uuid).Return([]db.NewBounty{{Price: 500, Paid: true}, {Price: 1000}}).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(fHandler.AllocateFeatureBudget).ServeHTTP(rr, newRequest(`{"amount": 1000}`))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("should refuse users without the budget role", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		fHandler := NewFeatureHandler(mockDb)
+		fHandler.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool { return false }
+
+		mockDb.On("GetFeatureByUuid", feature.Uuid).Return(feature).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(fHandler.AllocateFeatureBudget).ServeHTTP(rr, newRequest(`{"amount": 1000}`))
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+}
+
+func TestFeatureBurndown(t *testing.T) {
+	day := func(d int, hour int) *time.Time {
+		at := time.Date(2024, 3, d, hour, 0, 0, 0, time.Local)
+		return &at
+	}
+	feature := db.WorkspaceFeatures{Uuid: "feature-uuid", Budget: 3000}
+	history := []db.FeatureBudgetHistory{
+		{Amount: 2000, Created: day(1, 10)},
+		{Amount: 3000, Created: day(3, 9)},
+	}
+	bounties := []db.NewBounty{
+		{Price: 1000, Created: day(2, 12).Unix(), Paid: true, PaidDate: day(4, 8)},
+		{Price: 500, Created: day(3, 15).Unix()},
+	}
+
+	burndown := featureBurndown(feature, history, bounties, *day(4, 18))
+
+	assert.Equal(t, uint(3000), burndown.Allocated)
+	assert.Equal(t, uint(1000), burndown.Spent)
+	assert.Equal(t, uint(500), burndown.Committed)
+	assert.Equal(t, 1500, burndown.Remaining)
+	assert.Equal(t, []db.BurndownPoint{
+		{Date: "2024-03-01", Allocated: 2000},
+		{Date: "2024-03-02", Allocated: 2000, Committed: 1000},
+		{Date: "2024-03-03", Allocated: 3000, Committed: 1500},
+		{Date: "2024-03-04", Allocated: 3000, Spent: 1000, Committed: 500},
+	}, burndown.Points)
+
+	encoded, _ := json.Marshal(featureBurndown(db.WorkspaceFeatures{}, nil, nil, time.Now()))
+	assert.Contains(t, string(encoded), `"points":[{`)
+}
@@ -10,18 +10,21 @@ import (
 	"github.com/rs/xid"
 	"github.com/stakwork/sphinx-tribes/auth"
 	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/upstream"
 )
 
 type featureHandler struct {
 	db                    db.Database
 	generateBountyHandler func(bounties []db.NewBounty) []db.BountyResponse
+	userHasAccess         func(pubKeyFromAuth string, uuid string, role string) bool
 }
 
 func NewFeatureHandler(database db.Database) *featureHandler {
-	bHandler := NewBountyHandler(http.DefaultClient, database)
+	bHandler := NewBountyHandler(upstream.Default, database)
 	return &featureHandler{
 		db:                    database,
 		generateBountyHandler: bHandler.GenerateBountyResponse,
+		userHasAccess:         bHandler.userHasAccess,
 	}
 }
 
@@ -53,12 +56,7 @@ func (oh *featureHandler) CreateOrEditFeatures(w http.ResponseWriter, r *http.Re
 		features.UpdatedBy = pubKeyFromAuth
 	}
 
-	// Validate struct data
-	err = db.Validate.Struct(features)
-	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
-		msg := fmt.Sprintf("Error: did not pass validation test : %s", err)
-		json.NewEncoder(w).Encode(msg)
+	if !validatePayload(w, r, features) {
 		return
 	}
 
@@ -75,6 +73,7 @@ func (oh *featureHandler) CreateOrEditFeatures(w http.ResponseWriter, r *http.Re
 		w.WriteHeader(http.StatusBadRequest)
 		return
 	}
+	p.Links = saveMentions(ctx, oh.db, db.MentionFeature, p.Uuid, p.Name, p.Brief, p.Requirements, p.Architecture)
 
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(p)
@@ -145,6 +144,7 @@ func (oh *featureHandler) GetFeatureByUuid(w http.ResponseWriter, r *http.Reques
 
 	uuid := chi.URLParam(r, "uuid")
 	workspaceFeature := oh.db.GetFeatureByUuid(uuid)
+	workspaceFeature.Links = oh.db.GetMentions(db.MentionFeature, uuid)
 
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(workspaceFeature)
@@ -179,6 +179,10 @@ func (oh *featureHandler) CreateOrEditFeaturePhase(w http.ResponseWriter, r *htt
 	}
 
 	newPhase.UpdatedBy = pubKeyFromAuth
+	// the gate of a phase is only changed by its own endpoints
+	newPhase.DependsOn = existingPhase.DependsOn
+	newPhase.GateOverridden = existingPhase.GateOverridden
+	newPhase.GateOverriddenBy = existingPhase.GateOverriddenBy
 
 	// Check if feature exists
 	feature := oh.db.GetFeatureByUuid(newPhase.FeatureUuid)
@@ -8,11 +8,13 @@ import (
 	"fmt"
 	"io"
 	"net/http"
-	"os"
 	"strings"
+	"time"
 
+	"github.com/stakwork/sphinx-tribes/config"
 	"github.com/stakwork/sphinx-tribes/db"
 	"github.com/stakwork/sphinx-tribes/feeds"
+	"github.com/stakwork/sphinx-tribes/httpio"
 	"google.golang.org/api/option"
 	"google.golang.org/api/youtube/v3"
 )
@@ -35,6 +37,7 @@ func GetGenericFeed(w http.ResponseWriter, r *http.Request) {
 	}
 
 	feed.Value = feeds.AddedValue(feed.Value, tribe.OwnerPubKey)
+	httpio.SetLastModified(w, time.Unix(feed.DateUpdated, 0))
 
 	var data [][]string
 	for z := 0; z < len(feed.Items); z++ {
@@ -48,7 +51,7 @@ func GetGenericFeed(w http.ResponseWriter, r *http.Request) {
 }
 
 func DownloadYoutubeFeed(w http.ResponseWriter, r *http.Request) {
-	apiKey := os.Getenv("YOUTUBE_KEY")
+	apiKey := config.Get().YoutubeKey
 	ctx := context.Background()
 	tube, err := youtube.NewService(ctx, option.WithAPIKey(apiKey))
 
@@ -92,8 +95,7 @@ func DownloadYoutubeFeed(w http.ResponseWriter, r *http.Request) {
 }
 
 func processYoutubeDownload(data []string) {
-	stakworkKey := fmt.Sprintf("Token token=%s", os.Getenv("STAKWORK_KEY"))
-	if stakworkKey == "" {
+	if config.StakworkKey == "" {
 		fmt.Println("[feed] Youtube Download Error: Stakwork key not found")
 	} else {
 		type Vars struct {
@@ -135,7 +137,7 @@ func processYoutubeDownload(data []string) {
 		requestUrl := "https://jobs.stakwork.com/api/v1/projects"
 		request, err := http.NewRequest(http.MethodPost, requestUrl, bytes.NewBuffer(buf))
 		request.Header.Set("Content-Type", "application/json")
-		request.Header.Set("Authorization", stakworkKey)
+		request.Header.Set("Authorization", fmt.Sprintf("Token token=%s", config.StakworkKey))
 
 		client := &http.Client{}
 		response, err := client.Do(request)
@@ -163,6 +165,7 @@ func GetPodcast(w http.ResponseWriter, r *http.Request) {
 	}
 	podcast.Episodes = episodes
 
+	httpio.SetLastModified(w, time.Unix(int64(podcast.LastUpdateTime), 0))
 	w.WriteHeader(http.StatusOK)
 	err = json.NewEncoder(w).Encode(podcast)
 	if err != nil {
@@ -6,13 +6,13 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
-	"os"
 	"strconv"
 	"strings"
 
 	"github.com/go-chi/chi"
 	"github.com/google/go-github/v39/github"
 	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/config"
 	"github.com/stakwork/sphinx-tribes/db"
 	"golang.org/x/oauth2"
 )
@@ -45,10 +45,15 @@ func GetOpenGithubIssues(w http.ResponseWriter, r *http.Request) {
 }
 
 func githubClient() *github.Client {
-	gh_token := os.Getenv("GITHUB_TOKEN")
+	return githubClientWithToken(config.Get().GithubToken)
+}
+
+// githubClientWithToken is a client authenticated with token, a workspace
+// token instead of the one of the instance
+func githubClientWithToken(token string) *github.Client {
 	ctx := context.Background()
 	ts := oauth2.StaticTokenSource(
-		&oauth2.Token{AccessToken: gh_token},
+		&oauth2.Token{AccessToken: token},
 	)
 	tc := oauth2.NewClient(ctx, ts)
 	gc := github.NewClient(tc)
@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/upstream"
+)
+
+// Health is the answer of the health endpoint, the instance is degraded
+// while the breaker of a service is not closed. Sandbox is set when the
+// payments go to the lightning sandbox
+type Health struct {
+	Status      string                  `json:"status"`
+	Maintenance bool                    `json:"maintenance"`
+	Sandbox     bool                    `json:"sandbox,omitempty"`
+	Upstreams   []upstream.BreakerState `json:"upstreams"`
+}
+
+type healthHandler struct {
+	client *upstream.Client
+}
+
+func NewHealthHandler(client *upstream.Client) *healthHandler {
+	return &healthHandler{client: client}
+}
+
+// GetHealth always answers 200 while the instance serves requests, an
+// outage of the relay or Stakwork shows as degraded and should not take the
+// instance out of a load balancer
+func (hh *healthHandler) GetHealth(w http.ResponseWriter, r *http.Request) {
+	health := Health{
+		Status:      "ok",
+		Maintenance: db.InMaintenance(),
+		Sandbox:     config.Get().LightningBackend == config.LightningSandbox,
+		Upstreams:   hh.client.States(),
+	}
+	for _, state := range health.Upstreams {
+		if state.State != upstream.BreakerClosed {
+			health.Status = "degraded"
+		}
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(health)
+}
@@ -0,0 +1,27 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stakwork/sphinx-tribes/upstream"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetHealth(t *testing.T) {
+	hHandler := NewHealthHandler(upstream.NewClient(http.DefaultTransport))
+
+	rr := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/health", nil)
+	http.HandlerFunc(hHandler.GetHealth).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	health := Health{}
+	json.Unmarshal(rr.Body.Bytes(), &health)
+	assert.Equal(t, "ok", health.Status)
+	assert.Len(t, health.Upstreams, 2)
+	assert.Equal(t, upstream.Relay, health.Upstreams[0].Service)
+	assert.Equal(t, upstream.BreakerClosed, health.Upstreams[0].State)
+}
@@ -0,0 +1,244 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/go-chi/chi"
+	"github.com/rs/xid"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/httpio"
+	"github.com/stakwork/sphinx-tribes/images"
+)
+
+// largest image file accepted, the upload routes allow a bit more for the
+// rest of the multipart body
+const maxImageSize = 8 << 20
+
+var imageKinds = map[string]bool{"profile": true, "tribe": true, "bounty": true}
+
+// ImageUploadResponse has the url of every standard size of an upload
+type ImageUploadResponse struct {
+	Thumbnail string `json:"thumbnail"`
+	Card      string `json:"card"`
+	Full      string `json:"full"`
+}
+
+// imageStore saves the processed variants of an upload and returns their
+// urls by variant name
+type imageStore interface {
+	Save(ctx context.Context, prefix string, imgs []images.Image) (map[string]string, error)
+}
+
+type imageHandler struct {
+	store imageStore
+}
+
+func NewImageHandler() *imageHandler {
+	if config.Get().ImageStore == config.ImageStoreS3 {
+		return &imageHandler{store: s3ImageStore{}}
+	}
+	return &imageHandler{store: memeImageStore{}}
+}
+
+// UploadImage converts a profile, tribe or bounty image to the standard
+// sizes without its metadata and stores them
+func (ih *imageHandler) UploadImage(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		httpio.WriteError(w, r, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	kind := chi.URLParam(r, "kind")
+	if !imageKinds[kind] {
+		httpio.WriteError(w, r, http.StatusBadRequest, "Images are uploaded for a profile, tribe or bounty")
+		return
+	}
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		httpio.WriteError(w, r, http.StatusBadRequest, "Unable to parse file")
+		return
+	}
+	defer file.Close()
+
+	if header.Size > maxImageSize {
+		httpio.WriteErrorDetails(w, r, http.StatusRequestEntityTooLarge,
+			fmt.Sprintf("The image is larger than %d bytes", maxImageSize),
+			map[string]int64{"max_bytes": maxImageSize})
+		return
+	}
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		httpio.WriteError(w, r, http.StatusBadRequest, "Unable to read file")
+		return
+	}
+
+	processed, err := images.Process(data)
+	if err == images.ErrUnsupportedType {
+		httpio.WriteError(w, r, http.StatusUnsupportedMediaType, err.Error())
+		return
+	} else if err != nil {
+		httpio.WriteError(w, r, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	urls, err := ih.store.Save(ctx, kind+"/"+xid.New().String(), processed)
+	if err != nil {
+		fmt.Println("[images] could not store the upload", err)
+		httpio.WriteError(w, r, http.StatusBadGateway, "Could not store the image")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(ImageUploadResponse{
+		Thumbnail: urls["thumbnail"],
+		Card:      urls["card"],
+		Full:      urls["full"],
+	})
+}
+
+// memeImageStore uploads the variants to the meme server with one token
+type memeImageStore struct{}
+
+func (memeImageStore) Save(ctx context.Context, prefix string, imgs []images.Image) (map[string]string, error) {
+	challenge := GetMemeChallenge()
+	signer := SignChallenge(challenge.Challenge)
+	mErr, mToken := GetMemeToken(challenge.Id, signer.Response.Sig)
+	if mErr != "" {
+		return nil, errors.New(mErr)
+	}
+
+	name := strings.Replace(prefix, "/", "-", -1)
+	urls := map[string]string{}
+	for _, img := range imgs {
+		url, err := uploadMemeBytes(ctx, mToken.Token, name+"-"+img.Variant+"."+img.Extension, img.Data)
+		if err != nil {
+			return nil, err
+		}
+		urls[img.Variant] = url
+	}
+	return urls, nil
+}
+
+// SaveFile uploads an assembled upload to the meme server as is
+func (memeImageStore) SaveFile(ctx context.Context, key string, contentType string, data []byte) (string, error) {
+	challenge := GetMemeChallenge()
+	signer := SignChallenge(challenge.Challenge)
+	mErr, mToken := GetMemeToken(challenge.Id, signer.Response.Sig)
+	if mErr != "" {
+		return "", errors.New(mErr)
+	}
+	return uploadMemeBytes(ctx, mToken.Token, strings.Replace(key, "/", "-", -1), data)
+}
+
+func uploadMemeBytes(ctx context.Context, token string, fileName string, data []byte) (string, error) {
+	fileBody := &bytes.Buffer{}
+	writer := multipart.NewWriter(fileBody)
+	part, err := writer.CreateFormFile("file", fileName)
+	if err != nil {
+		return "", err
+	}
+	part.Write(data)
+	writer.Close()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, config.MemeUrl+"/public", fileBody)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Authorization", "BEARER "+token)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+
+	meme := db.Meme{}
+	if err := json.NewDecoder(res.Body).Decode(&meme); err != nil {
+		return "", err
+	}
+	if meme.Muid == "" {
+		return "", fmt.Errorf("meme server returned %d without a muid", res.StatusCode)
+	}
+	return config.MemeUrl + "/public/" + meme.Muid, nil
+}
+
+// s3ImageStore puts the variants in the S3_BUCKET_NAME bucket, they are
+// served from S3_URL and never change
+type s3ImageStore struct{}
+
+func (s3ImageStore) Save(ctx context.Context, prefix string, imgs []images.Image) (map[string]string, error) {
+	urls := map[string]string{}
+	for _, img := range imgs {
+		key := "images/" + prefix + "/" + img.Variant + "." + img.Extension
+		_, err := config.S3Client.PutObject(ctx, &s3.PutObjectInput{
+			Bucket:       aws.String(config.S3BucketName),
+			Key:          aws.String(key),
+			Body:         bytes.NewReader(img.Data),
+			ContentType:  aws.String(img.ContentType),
+			CacheControl: aws.String("public, max-age=31536000, immutable"),
+		})
+		if err != nil {
+			return nil, err
+		}
+		urls[img.Variant] = strings.TrimRight(config.S3Url, "/") + "/" + key
+	}
+	return urls, nil
+}
+
+// inlineUploadTypes are the sniffed types an upload is served as, anything
+// else is served as a download so a browser never renders it from our bucket
+var inlineUploadTypes = map[string]bool{
+	"image/png":  true,
+	"image/jpeg": true,
+	"image/gif":  true,
+	"image/webp": true,
+}
+
+// uploadServedAs returns the content type and disposition of an upload from
+// its bytes, the type the client declared is never trusted
+func uploadServedAs(data []byte) (string, string) {
+	contentType := http.DetectContentType(data)
+	if inlineUploadTypes[contentType] {
+		return contentType, ""
+	}
+	return "application/octet-stream", "attachment"
+}
+
+// SaveFile puts an assembled upload in the bucket under uploads/, with the
+// content type sniffed from the data
+func (s3ImageStore) SaveFile(ctx context.Context, key string, _ string, data []byte) (string, error) {
+	key = "uploads/" + key
+	contentType, disposition := uploadServedAs(data)
+	input := &s3.PutObjectInput{
+		Bucket:       aws.String(config.S3BucketName),
+		Key:          aws.String(key),
+		Body:         bytes.NewReader(data),
+		ContentType:  aws.String(contentType),
+		CacheControl: aws.String("public, max-age=31536000, immutable"),
+	}
+	if disposition != "" {
+		input.ContentDisposition = aws.String(disposition)
+	}
+	_, err := config.S3Client.PutObject(ctx, input)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(config.S3Url, "/") + "/" + key, nil
+}
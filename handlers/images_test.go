@@ -0,0 +1,127 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"image"
+	"image/jpeg"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/images"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeImageStore struct {
+	prefix string
+	saved  []images.Image
+	err    error
+}
+
+func (s *fakeImageStore) Save(ctx context.Context, prefix string, imgs []images.Image) (map[string]string, error) {
+	s.prefix, s.saved = prefix, imgs
+	urls := map[string]string{}
+	for _, img := range imgs {
+		urls[img.Variant] = "https://images.test/" + prefix + "/" + img.Variant
+	}
+	return urls, s.err
+}
+
+func TestUploadImage(t *testing.T) {
+	newRequest := func(kind string, pubkey string, file []byte) *http.Request {
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		part, _ := writer.CreateFormFile("file", "upload.jpg")
+		part.Write(file)
+		writer.Close()
+
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("kind", kind)
+		ctx := context.WithValue(context.WithValue(context.Background(), chi.RouteCtxKey, rctx), auth.ContextKey, pubkey)
+		req, _ := http.NewRequestWithContext(ctx, http.MethodPost, "/images/"+kind, body)
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+		return req
+	}
+
+	jpg := &bytes.Buffer{}
+	jpeg.Encode(jpg, image.NewRGBA(image.Rect(0, 0, 800, 400)), nil)
+
+	t.Run("should store the standard sizes of an upload", func(t *testing.T) {
+		store := &fakeImageStore{}
+		iHandler := &imageHandler{store: store}
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(iHandler.UploadImage).ServeHTTP(rr, newRequest("tribe", "owner", jpg.Bytes()))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Len(t, store.saved, 3)
+		assert.Regexp(t, "^tribe/", store.prefix)
+
+		res := ImageUploadResponse{}
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &res))
+		assert.Equal(t, "https://images.test/"+store.prefix+"/thumbnail", res.Thumbnail)
+		assert.Equal(t, "https://images.test/"+store.prefix+"/full", res.Full)
+	})
+
+	t.Run("should need a pubkey", func(t *testing.T) {
+		iHandler := &imageHandler{store: &fakeImageStore{}}
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(iHandler.UploadImage).ServeHTTP(rr, newRequest("tribe", "", jpg.Bytes()))
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("should refuse unknown kinds", func(t *testing.T) {
+		iHandler := &imageHandler{store: &fakeImageStore{}}
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(iHandler.UploadImage).ServeHTTP(rr, newRequest("workspace", "owner", jpg.Bytes()))
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("should refuse files that are not images", func(t *testing.T) {
+		store := &fakeImageStore{}
+		iHandler := &imageHandler{store: store}
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(iHandler.UploadImage).ServeHTTP(rr, newRequest("profile", "owner", []byte("%PDF-1.4")))
+		assert.Equal(t, http.StatusUnsupportedMediaType, rr.Code)
+		assert.Nil(t, store.saved)
+	})
+
+	t.Run("should refuse images over the size limit", func(t *testing.T) {
+		iHandler := &imageHandler{store: &fakeImageStore{}}
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(iHandler.UploadImage).ServeHTTP(rr, newRequest("profile", "owner", make([]byte, maxImageSize+1)))
+		assert.Equal(t, http.StatusRequestEntityTooLarge, rr.Code)
+	})
+
+	t.Run("should return 502 when the store fails", func(t *testing.T) {
+		iHandler := &imageHandler{store: &fakeImageStore{err: errors.New("meme server down")}}
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(iHandler.UploadImage).ServeHTTP(rr, newRequest("bounty", "owner", jpg.Bytes()))
+		assert.Equal(t, http.StatusBadGateway, rr.Code)
+	})
+}
+
+func TestUploadServedAs(t *testing.T) {
+	t.Run("should serve sniffed images inline", func(t *testing.T) {
+		contentType, disposition := uploadServedAs([]byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR"))
+		assert.Equal(t, "image/png", contentType)
+		assert.Equal(t, "", disposition)
+	})
+
+	t.Run("should serve html and svg as a download", func(t *testing.T) {
+		for _, data := range []string{
+			"<html><script>alert(1)</script></html>",
+			`<svg xmlns="http://www.w3.org/2000/svg" onload="alert(1)"></svg>`,
+		} {
+			contentType, disposition := uploadServedAs([]byte(data))
+			assert.Equal(t, "application/octet-stream", contentType)
+			assert.Equal(t, "attachment", disposition)
+		}
+	})
+}
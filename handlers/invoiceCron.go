@@ -13,6 +13,7 @@ import (
 	"github.com/go-co-op/gocron"
 	"github.com/stakwork/sphinx-tribes/config"
 	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/upstream"
 	"github.com/stakwork/sphinx-tribes/utils"
 )
 
@@ -22,6 +23,10 @@ func InitInvoiceCron() {
 	msg := make(map[string]interface{})
 
 	s.Every(5).Seconds().Do(func() {
+		// the invoices wait in the cache until maintenance ends
+		if db.InMaintenance() {
+			return
+		}
 		invoiceList, _ := db.Store.GetInvoiceCache()
 		invoiceCount := len(invoiceList)
 
@@ -29,12 +34,11 @@ func InitInvoiceCron() {
 			for index, inv := range invoiceList {
 				url := fmt.Sprintf("%s/invoice?payment_request=%s", config.RelayUrl, inv.Invoice)
 
-				client := &http.Client{}
 				req, err := http.NewRequest(http.MethodGet, url, nil)
 
 				req.Header.Set("x-user-token", config.RelayAuthKey)
 				req.Header.Set("Content-Type", "application/json")
-				res, _ := client.Do(req)
+				res, err := upstream.Default.Do(req)
 
 				if err != nil {
 					log.Printf("Request Failed: %s", err)
@@ -67,7 +71,7 @@ func InitInvoiceCron() {
 						socket, err := db.Store.GetSocketConnections(inv.Host)
 
 						if err == nil {
-							socket.Conn.WriteJSON(msg)
+							socket.Writer.WriteJSON(msg)
 						}
 
 						if inv.Type == "KEYSEND" {
@@ -79,12 +83,11 @@ func InitInvoiceCron() {
 
 							jsonBody := []byte(bodyData)
 
-							client := &http.Client{}
 							req, _ := http.NewRequest(http.MethodPost, url, bytes.NewBuffer(jsonBody))
 
 							req.Header.Set("x-user-token", config.RelayAuthKey)
 							req.Header.Set("Content-Type", "application/json")
-							res, _ := client.Do(req)
+							res, err := upstream.Default.Do(req)
 
 							if err != nil {
 								log.Printf("Request Failed: %s", err)
@@ -117,7 +120,7 @@ func InitInvoiceCron() {
 
 								socket, err := db.Store.GetSocketConnections(inv.Host)
 								if err == nil {
-									socket.Conn.WriteJSON(msg)
+									socket.Writer.WriteJSON(msg)
 								}
 							} else {
 								// Unmarshal result
@@ -131,7 +134,7 @@ func InitInvoiceCron() {
 								socket, err := db.Store.GetSocketConnections(inv.Host)
 
 								if err == nil {
-									socket.Conn.WriteJSON(msg)
+									socket.Writer.WriteJSON(msg)
 								}
 
 								updateInvoiceCache(invoiceList, index)
@@ -163,7 +166,7 @@ func InitInvoiceCron() {
 
 							socket, err := db.Store.GetSocketConnections(inv.Host)
 							if err == nil {
-								socket.Conn.WriteJSON(msg)
+								socket.Writer.WriteJSON(msg)
 							}
 						}
 					}
@@ -173,6 +176,9 @@ func InitInvoiceCron() {
 	})
 
 	s.Every(5).Seconds().Do(func() {
+		if db.InMaintenance() {
+			return
+		}
 		invoiceList, _ := db.Store.GetBudgetInvoiceCache()
 		invoiceCount := len(invoiceList)
 
@@ -180,12 +186,11 @@ func InitInvoiceCron() {
 			for index, inv := range invoiceList {
 				url := fmt.Sprintf("%s/invoice?payment_request=%s", config.RelayUrl, inv.Invoice)
 
-				client := &http.Client{}
 				req, err := http.NewRequest(http.MethodGet, url, nil)
 
 				req.Header.Set("x-user-token", config.RelayAuthKey)
 				req.Header.Set("Content-Type", "application/json")
-				res, _ := client.Do(req)
+				res, err := upstream.Default.Do(req)
 
 				if err != nil {
 					log.Printf("Request Failed: %s", err)
@@ -218,7 +223,7 @@ func InitInvoiceCron() {
 						socket, err := db.Store.GetSocketConnections(inv.Host)
 
 						if err == nil {
-							socket.Conn.WriteJSON(msg)
+							socket.Writer.WriteJSON(msg)
 						}
 
 						// db.DB.AddAndUpdateBudget(inv)
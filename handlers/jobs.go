@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/httpio"
+)
+
+type jobHandler struct {
+	db db.Database
+}
+
+func NewJobHandler(database db.Database) *jobHandler {
+	return &jobHandler{db: database}
+}
+
+// GetJobs lists queued jobs, filtered by the status and type query params
+func (jh *jobHandler) GetJobs(w http.ResponseWriter, r *http.Request) {
+	status := r.URL.Query().Get("status")
+	switch db.JobStatus(status) {
+	case "", db.JobPending, db.JobRunning, db.JobCompleted, db.JobFailed, db.JobDead:
+	default:
+		httpio.WriteError(w, r, http.StatusBadRequest, "Invalid job status")
+		return
+	}
+
+	jobs, err := jh.db.GetJobs(r)
+	if err != nil {
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to get jobs")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(jobs)
+}
+
+func (jh *jobHandler) GetJob(w http.ResponseWriter, r *http.Request) {
+	uuid := chi.URLParam(r, "uuid")
+
+	job, err := jh.db.GetJobByUuid(uuid)
+	if err != nil {
+		httpio.WriteError(w, r, http.StatusNotFound, "Job not found")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(job)
+}
+
+// RequeueJob puts a failed or dead job back in the queue
+func (jh *jobHandler) RequeueJob(w http.ResponseWriter, r *http.Request) {
+	uuid := chi.URLParam(r, "uuid")
+
+	job, err := jh.db.GetJobByUuid(uuid)
+	if err != nil {
+		httpio.WriteError(w, r, http.StatusNotFound, "Job not found")
+		return
+	}
+	if job.Status == db.JobRunning {
+		httpio.WriteError(w, r, http.StatusConflict, "Job is currently running")
+		return
+	}
+
+	requeued, err := jh.db.RequeueJob(uuid)
+	if err != nil {
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to requeue job")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(requeued)
+}
@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/db"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetJobs(t *testing.T) {
+	t.Run("should reject an unknown status", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		jHandler := NewJobHandler(mockDb)
+
+		rr := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/admin/jobs?status=unknown", nil)
+
+		http.HandlerFunc(jHandler.GetJobs).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockDb.AssertNotCalled(t, "GetJobs", mock.Anything)
+	})
+
+	t.Run("should list dead jobs", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		jHandler := NewJobHandler(mockDb)
+		mockDb.On("GetJobs", mock.AnythingOfType("*http.Request")).Return([]db.Job{{Uuid: "job-uuid", Status: db.JobDead}}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/admin/jobs?status=dead", nil)
+
+		http.HandlerFunc(jHandler.GetJobs).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var jobs []db.Job
+		err := json.Unmarshal(rr.Body.Bytes(), &jobs)
+		assert.NoError(t, err)
+		assert.Len(t, jobs, 1)
+		mockDb.AssertExpectations(t)
+	})
+}
+
+func TestRequeueJob(t *testing.T) {
+	newRequest := func(uuid string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("uuid", uuid)
+		req, _ := http.NewRequestWithContext(context.WithValue(context.Background(), chi.RouteCtxKey, rctx), http.MethodPost, "/admin/jobs/"+uuid+"/requeue", nil)
+		return req
+	}
+
+	t.Run("should return not found for an unknown job", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		jHandler := NewJobHandler(mockDb)
+		mockDb.On("GetJobByUuid", "job-uuid").Return(db.Job{}, errors.New("no job found")).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(jHandler.RequeueJob).ServeHTTP(rr, newRequest("job-uuid"))
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should not requeue a running job", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		jHandler := NewJobHandler(mockDb)
+		mockDb.On("GetJobByUuid", "job-uuid").Return(db.Job{Uuid: "job-uuid", Status: db.JobRunning}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(jHandler.RequeueJob).ServeHTTP(rr, newRequest("job-uuid"))
+
+		assert.Equal(t, http.StatusConflict, rr.Code)
+		mockDb.AssertNotCalled(t, "RequeueJob", "job-uuid")
+	})
+
+	t.Run("should requeue a dead job", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		jHandler := NewJobHandler(mockDb)
+		mockDb.On("GetJobByUuid", "job-uuid").Return(db.Job{Uuid: "job-uuid", Status: db.JobDead, Attempts: 5}, nil).Once()
+		mockDb.On("RequeueJob", "job-uuid").Return(db.Job{Uuid: "job-uuid", Status: db.JobPending}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(jHandler.RequeueJob).ServeHTTP(rr, newRequest("job-uuid"))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var job db.Job
+		err := json.Unmarshal(rr.Body.Bytes(), &job)
+		assert.NoError(t, err)
+		assert.Equal(t, db.JobPending, job.Status)
+		mockDb.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,11 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/stakwork/sphinx-tribes/dbtest"
+)
+
+func TestMain(m *testing.M) {
+	dbtest.Main(m)
+}
@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/httpio"
+)
+
+type maintenanceHandler struct {
+	db db.Database
+}
+
+func NewMaintenanceHandler(database db.Database) *maintenanceHandler {
+	return &maintenanceHandler{db: database}
+}
+
+func (mh *maintenanceHandler) GetMaintenance(w http.ResponseWriter, r *http.Request) {
+	m, err := mh.db.GetMaintenance()
+	if err != nil {
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to get the maintenance mode")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(m)
+}
+
+// SetMaintenance turns the maintenance mode on or off. While it is on the
+// requests that change data get a 503 and the job workers stop claiming
+// jobs, the other instances follow within a few seconds
+func (mh *maintenanceHandler) SetMaintenance(w http.ResponseWriter, r *http.Request) {
+	pubKeyFromAuth, _ := r.Context().Value(auth.ContextKey).(string)
+
+	request := db.MaintenanceRequest{}
+	body, err := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err == nil {
+		err = json.Unmarshal(body, &request)
+	}
+	if err != nil {
+		httpio.WriteError(w, r, http.StatusNotAcceptable, "")
+		return
+	}
+
+	if !validatePayload(w, r, request) {
+		return
+	}
+	if request.Enabled && request.EndsAt != nil && !request.EndsAt.After(time.Now()) {
+		httpio.WriteError(w, r, http.StatusBadRequest, "ends_at must be in the future")
+		return
+	}
+
+	m, err := mh.db.SetMaintenance(db.Maintenance{
+		Enabled:   request.Enabled,
+		Message:   request.Message,
+		EndsAt:    request.EndsAt,
+		UpdatedBy: pubKeyFromAuth,
+	})
+	if err != nil {
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to set the maintenance mode")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(m)
+}
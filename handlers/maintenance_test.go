@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSetMaintenance(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.ContextKey, "super-admin")
+
+	t.Run("should reject an end in the past", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mHandler := NewMaintenanceHandler(mockDb)
+		endsAt := time.Now().Add(-time.Minute)
+		body, _ := json.Marshal(db.MaintenanceRequest{Enabled: true, EndsAt: &endsAt})
+
+		rr := httptest.NewRecorder()
+		req, _ := http.NewRequestWithContext(ctx, http.MethodPut, "/admin/maintenance", bytes.NewReader(body))
+
+		http.HandlerFunc(mHandler.SetMaintenance).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockDb.AssertNotCalled(t, "SetMaintenance", mock.Anything)
+	})
+
+	t.Run("should turn maintenance on", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mHandler := NewMaintenanceHandler(mockDb)
+		endsAt := time.Now().Add(30 * time.Minute)
+		body, _ := json.Marshal(db.MaintenanceRequest{Enabled: true, Message: "Upgrading the database", EndsAt: &endsAt})
+		mockDb.On("SetMaintenance", mock.MatchedBy(func(m db.Maintenance) bool {
+			return m.Enabled && m.Message == "Upgrading the database" && m.UpdatedBy == "super-admin"
+		})).Return(db.Maintenance{Enabled: true, Message: "Upgrading the database", EndsAt: &endsAt}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		req, _ := http.NewRequestWithContext(ctx, http.MethodPut, "/admin/maintenance", bytes.NewReader(body))
+
+		http.HandlerFunc(mHandler.SetMaintenance).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var m db.Maintenance
+		err := json.Unmarshal(rr.Body.Bytes(), &m)
+		assert.NoError(t, err)
+		assert.True(t, m.Enabled)
+		mockDb.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+
+	"github.com/go-chi/chi"
+	"github.com/google/go-github/v39/github"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/httpio"
+)
+
+// the repositories of an organization whose contributors are synced, the
+// most recently pushed first
+const githubSyncMaxRepos = 30
+
+var githubLoginPattern = regexp.MustCompile(`^[A-Za-z0-9](?:[A-Za-z0-9-]{0,38})$`)
+
+// GithubOrgLogins lists the members of the GitHub organization of a
+// workspace and the contributors of its recently pushed repositories. It
+// calls GitHub with the github_token secret of the workspace, or the token
+// of the instance, private members are only listed with a token of the
+// organization
+func (oh *workspaceHandler) GithubOrgLogins(ctx context.Context, workspace db.Workspace) ([]db.GithubOrgLogin, error) {
+	token := workspaceSecret(oh.db, workspace.Uuid, db.SecretGithubToken)
+	if token == "" {
+		token = config.Get().GithubToken
+	}
+	client := githubClientWithToken(token)
+
+	members := []string{}
+	memberOptions := &github.ListMembersOptions{ListOptions: github.ListOptions{PerPage: 100}}
+	for {
+		page, res, err := client.Organizations.ListMembers(ctx, workspace.GithubOrg, memberOptions)
+		if err != nil {
+			return nil, err
+		}
+		for _, member := range page {
+			members = append(members, member.GetLogin())
+		}
+		if res.NextPage == 0 {
+			break
+		}
+		memberOptions.Page = res.NextPage
+	}
+
+	repos, _, err := client.Repositories.ListByOrg(ctx, workspace.GithubOrg, &github.RepositoryListByOrgOptions{
+		Type:        "sources",
+		Sort:        "pushed",
+		ListOptions: github.ListOptions{PerPage: githubSyncMaxRepos},
+	})
+	if err != nil {
+		return nil, err
+	}
+	contributions := map[string]int{}
+	for _, repo := range repos {
+		if repo.GetArchived() {
+			continue
+		}
+		contributors, _, err := client.Repositories.ListContributors(ctx, workspace.GithubOrg, repo.GetName(), &github.ListContributorsOptions{
+			ListOptions: github.ListOptions{PerPage: 100},
+		})
+		if err != nil {
+			fmt.Println("[workspaces] could not list the contributors of", repo.GetFullName(), err)
+			continue
+		}
+		for _, contributor := range contributors {
+			if contributor.GetType() == "Bot" {
+				continue
+			}
+			contributions[contributor.GetLogin()] += contributor.GetContributions()
+		}
+	}
+
+	return db.MergeGithubOrgLogins(members, contributions), nil
+}
+
+// GetWorkspaceMemberSuggestions lists the people of the GitHub organization
+// of the workspace of the route who linked their GitHub login and are not
+// members yet, to those who can add users
+func (oh *workspaceHandler) GetWorkspaceMemberSuggestions(w http.ResponseWriter, r *http.Request) {
+	pubKeyFromAuth, _ := r.Context().Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[workspaces] no pubkey from auth")
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
+		return
+	}
+
+	workspace := oh.db.GetWorkspaceByUuid(chi.URLParam(r, "workspace_uuid"))
+	if workspace.Uuid == "" {
+		httpio.WriteError(w, r, http.StatusNotFound, "Workspace not found")
+		return
+	}
+	if !oh.userHasAccess(pubKeyFromAuth, workspace.Uuid, db.AddUser) {
+		httpio.WriteError(w, r, http.StatusUnauthorized, "Don't have access to add user")
+		return
+	}
+
+	suggestions, err := oh.db.GetWorkspaceMemberSuggestions(workspace)
+	if err != nil {
+		fmt.Println("[workspaces]", err)
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to get the member suggestions")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(suggestions)
+}
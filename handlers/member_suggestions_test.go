@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetWorkspaceMemberSuggestions(t *testing.T) {
+	workspace := db.Workspace{Uuid: "workspace-a", OwnerPubKey: "owner", GithubOrg: "stakwork"}
+	request := func(pubkey string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("workspace_uuid", workspace.Uuid)
+		ctx := context.WithValue(context.WithValue(context.Background(), chi.RouteCtxKey, rctx), auth.ContextKey, pubkey)
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "/workspace-a/member_suggestions", nil)
+		return req
+	}
+
+	t.Run("should refuse someone who can't add users", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		wHandler := NewWorkspaceHandler(mockDb)
+		wHandler.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool { return false }
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(workspace).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(wHandler.GetWorkspaceMemberSuggestions).ServeHTTP(rr, request("member"))
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		mockDb.AssertNotCalled(t, "GetWorkspaceMemberSuggestions", mock.Anything)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should return the suggestions", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		wHandler := NewWorkspaceHandler(mockDb)
+		wHandler.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool { return role == db.AddUser }
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(workspace).Once()
+		mockDb.On("GetWorkspaceMemberSuggestions", workspace).Return([]db.WorkspaceMemberSuggestion{
+			{GithubLogin: "ada", OwnerPubKey: "ada-pubkey", OrgMember: true, OwnerAlias: "Ada"},
+		}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(wHandler.GetWorkspaceMemberSuggestions).ServeHTTP(rr, request("owner"))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var suggestions []db.WorkspaceMemberSuggestion
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &suggestions))
+		assert.Equal(t, "ada-pubkey", suggestions[0].OwnerPubKey)
+		assert.Equal(t, "Ada", suggestions[0].OwnerAlias)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should answer 404 for an unknown workspace", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		wHandler := NewWorkspaceHandler(mockDb)
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(db.Workspace{}).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(wHandler.GetWorkspaceMemberSuggestions).ServeHTTP(rr, request("owner"))
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
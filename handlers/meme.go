@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -16,6 +17,7 @@ import (
 	"github.com/stakwork/sphinx-tribes/auth"
 	"github.com/stakwork/sphinx-tribes/config"
 	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/upstream"
 )
 
 func MemeImageUpload(w http.ResponseWriter, r *http.Request) {
@@ -65,7 +67,7 @@ func MemeImageUpload(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusNoContent)
 		json.NewEncoder(w).Encode(msg)
 	} else {
-		err, memeImgUrl := UploadMemeImage(file, mToken.Token, header.Filename)
+		err, memeImgUrl := UploadMemeImage(ctx, file, mToken.Token, header.Filename)
 		if err == nil {
 			w.WriteHeader(http.StatusOK)
 			json.NewEncoder(w).Encode(memeImgUrl)
@@ -111,15 +113,15 @@ func GetMemeChallenge() db.MemeChallenge {
 func SignChallenge(challenge string) db.RelaySignerResponse {
 	url := fmt.Sprintf("%s/signer/%s", config.RelayUrl, challenge)
 
-	client := &http.Client{}
 	req, err := http.NewRequest(http.MethodGet, url, nil)
 
 	req.Header.Set("x-user-token", config.RelayAuthKey)
 	req.Header.Set("Content-Type", "application/json")
-	res, _ := client.Do(req)
+	res, err := upstream.Default.Do(req)
 
 	if err != nil {
 		log.Printf("Request Failed: %s", err)
+		return db.RelaySignerResponse{}
 	}
 
 	defer res.Body.Close()
@@ -182,7 +184,7 @@ func GetMemeToken(id string, sig string) (string, db.MemeTokenSuccess) {
 	}
 }
 
-func UploadMemeImage(file multipart.File, token string, fileName string) (error, string) {
+func UploadMemeImage(ctx context.Context, file multipart.File, token string, fileName string) (error, string) {
 	url := fmt.Sprintf("%s/public", config.MemeUrl)
 	filePath := path.Join("./uploads", fileName)
 	fileW, _ := os.Open(filePath)
@@ -195,7 +197,7 @@ func UploadMemeImage(file multipart.File, token string, fileName string) (error,
 	writer.Close()
 
 	client := &http.Client{}
-	req, err := http.NewRequest(http.MethodPost, url, fileBody)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, fileBody)
 	req.Header.Set("Authorization", "BEARER "+token)
 	req.Header.Set("Content-Type", writer.FormDataContentType())
 	res, err := client.Do(req)
@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/events"
+	"github.com/stakwork/sphinx-tribes/httpio"
+)
+
+var (
+	// @alias, not the @ of an email address
+	aliasMention = regexp.MustCompile(`(?:^|[^\w@])@([A-Za-z0-9_\-]+)`)
+	// #12, not an html entity like &#39;
+	bountyMention = regexp.MustCompile(`(?:^|[^\w&#])#(\d+)\b`)
+	// the xid uuids of the features
+	featureMention = regexp.MustCompile(`\b([0-9a-v]{20})\b`)
+)
+
+type mentionReference struct {
+	Type string
+	ID   string
+	Text string
+}
+
+// parseMentions finds the @alias, #bounty-id and feature uuid references
+// of texts, once each
+func parseMentions(texts ...string) []mentionReference {
+	refs := []mentionReference{}
+	seen := map[string]bool{}
+	add := func(kind string, id string, text string) {
+		if seen[kind+":"+id] {
+			return
+		}
+		seen[kind+":"+id] = true
+		refs = append(refs, mentionReference{Type: kind, ID: id, Text: text})
+	}
+
+	for _, text := range texts {
+		for _, match := range aliasMention.FindAllStringSubmatch(text, -1) {
+			add(db.MentionPerson, match[1], "@"+match[1])
+		}
+		for _, match := range bountyMention.FindAllStringSubmatch(text, -1) {
+			add(db.MentionBounty, match[1], "#"+match[1])
+		}
+		for _, match := range featureMention.FindAllStringSubmatch(text, -1) {
+			add(db.MentionFeature, match[1], match[1])
+		}
+	}
+	return refs
+}
+
+// resolveMentions looks up the references, the ones that don't match a
+// person, bounty or feature are left out
+func resolveMentions(database db.Database, refs []mentionReference) []db.Mention {
+	mentions := []db.Mention{}
+	for _, ref := range refs {
+		mention := db.Mention{TargetType: ref.Type, Text: ref.Text}
+		switch ref.Type {
+		case db.MentionPerson:
+			person := database.GetPersonByUniqueName(ref.ID)
+			if person.ID == 0 {
+				continue
+			}
+			mention.TargetID = person.OwnerPubKey
+			mention.Title = person.OwnerAlias
+			mention.Img = person.Img
+		case db.MentionBounty:
+			id, err := strconv.ParseUint(ref.ID, 10, 32)
+			if err != nil {
+				continue
+			}
+			bounty := database.GetBounty(uint(id))
+			if bounty.ID == 0 {
+				continue
+			}
+			mention.TargetID = ref.ID
+			mention.Title = bounty.Title
+		case db.MentionFeature:
+			feature := database.GetFeatureByUuid(ref.ID)
+			if feature.Uuid == "" {
+				continue
+			}
+			mention.TargetID = feature.Uuid
+			mention.Title = feature.Name
+		}
+		mentions = append(mentions, mention)
+	}
+	return mentions
+}
+
+// saveMentions links a saved bounty or feature to what its texts reference
+// and notifies the people mentioned for the first time. It returns the
+// links for the response
+func saveMentions(ctx context.Context, database db.Database, sourceType string, sourceID string, title string, texts ...string) []db.Mention {
+	mentions := resolveMentions(database, parseMentions(texts...))
+	added, err := database.SaveMentions(sourceType, sourceID, mentions)
+	if err != nil {
+		fmt.Println("[mentions]", err)
+		return mentions
+	}
+
+	actor, _ := ctx.Value(auth.ContextKey).(string)
+	for _, mention := range added {
+		if mention.TargetType != db.MentionPerson || mention.TargetID == actor {
+			continue
+		}
+		events.Publish(ctx, events.PersonMentioned, sourceType+":"+sourceID, map[string]interface{}{
+			"mentioned_pubkey": mention.TargetID,
+			"source_type":      sourceType,
+			"source_id":        sourceID,
+			"title":            title,
+		})
+	}
+	return mentions
+}
+
+type mentionHandler struct {
+	db db.Database
+}
+
+func NewMentionHandler(database db.Database) *mentionHandler {
+	return &mentionHandler{db: database}
+}
+
+// GetMentionsOf lists the bounties and features that reference a person
+// (by pubkey), a bounty (by id) or a feature (by uuid)
+func (mh *mentionHandler) GetMentionsOf(w http.ResponseWriter, r *http.Request) {
+	targetType := chi.URLParam(r, "type")
+	if targetType != db.MentionPerson && targetType != db.MentionBounty && targetType != db.MentionFeature {
+		httpio.WriteError(w, r, http.StatusBadRequest, "type is person, bounty or feature")
+		return
+	}
+
+	mentions := mh.db.GetMentionsOf(targetType, chi.URLParam(r, "id"))
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(mentions)
+}
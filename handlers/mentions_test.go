@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestParseMentions(t *testing.T) {
+	refs := parseMentions("Ask @alice and @bob_2 about #12 (see #12 and feature cn8s2h6d3b9fhc3nq1a0), mail dev@example.com, &#39;", "@alice again")
+
+	assert.Equal(t, []mentionReference{
+		{Type: db.MentionPerson, ID: "alice", Text: "@alice"},
+		{Type: db.MentionPerson, ID: "bob_2", Text: "@bob_2"},
+		{Type: db.MentionBounty, ID: "12", Text: "#12"},
+		{Type: db.MentionFeature, ID: "cn8s2h6d3b9fhc3nq1a0", Text: "cn8s2h6d3b9fhc3nq1a0"},
+	}, refs)
+	assert.Empty(t, parseMentions("no references here"))
+}
+
+func TestSaveMentions(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.ContextKey, "owner-pubkey")
+
+	t.Run("should resolve the references and leave the unknown ones out", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+
+		mockDb.On("GetPersonByUniqueName", "alice").Return(db.Person{ID: 1, OwnerPubKey: "alice-pubkey", OwnerAlias: "Alice", Img: "alice.png"}).Once()
+		mockDb.On("GetPersonByUniqueName", "nobody").Return(db.Person{}).Once()
+		mockDb.On("GetBounty", uint(12)).Return(db.NewBounty{ID: 12, Title: "Fix the build"}).Once()
+		expected := []db.Mention{
+			{TargetType: db.MentionPerson, TargetID: "alice-pubkey", Text: "@alice", Title: "Alice", Img: "alice.png"},
+			{TargetType: db.MentionBounty, TargetID: "12", Text: "#12", Title: "Fix the build"},
+		}
+		mockDb.On("SaveMentions", db.MentionBounty, "3", expected).Return([]db.Mention{expected[0]}, nil).Once()
+
+		links := saveMentions(ctx, mockDb, db.MentionBounty, "3", "A bounty", "@alice @nobody #12")
+
+		assert.Equal(t, expected, links)
+		mockDb.AssertExpectations(t)
+	})
+}
+
+func TestGetMentionsOf(t *testing.T) {
+	newRequest := func(targetType string, id string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("type", targetType)
+		rctx.URLParams.Add("id", id)
+		req, _ := http.NewRequestWithContext(context.WithValue(context.Background(), chi.RouteCtxKey, rctx), http.MethodGet, "/mentions/"+targetType+"/"+id, nil)
+		return req
+	}
+
+	t.Run("should list what references a bounty", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mHandler := NewMentionHandler(mockDb)
+
+		mockDb.On("GetMentionsOf", db.MentionBounty, "12").Return([]db.Mention{{SourceType: db.MentionFeature, SourceID: "feature-uuid", TargetType: db.MentionBounty, TargetID: "12"}}).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(mHandler.GetMentionsOf).ServeHTTP(rr, newRequest(db.MentionBounty, "12"))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"source_id":"feature-uuid"`)
+	})
+
+	t.Run("should refuse an unknown type", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mHandler := NewMentionHandler(mockDb)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(mHandler.GetMentionsOf).ServeHTTP(rr, newRequest("tribe", "12"))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockDb.AssertNotCalled(t, "GetMentionsOf", mock.Anything, mock.Anything)
+	})
+}
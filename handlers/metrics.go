@@ -19,6 +19,7 @@ import (
 	"github.com/stakwork/sphinx-tribes/auth"
 	"github.com/stakwork/sphinx-tribes/config"
 	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/httpio"
 	"github.com/tuan78/jsonconv"
 )
 
@@ -136,7 +137,14 @@ func (mh *metricHandler) BountyMetrics(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	metricsKey := fmt.Sprintf("metrics - %s - %s", request.StartDate, request.EndDate)
+	// days are days of the workspace, or of UTC across workspaces
+	loc := time.UTC
+	if workspace != "" {
+		loc = mh.db.GetWorkspaceByUuid(workspace).Location()
+	}
+	request = request.InLocation(loc)
+
+	metricsKey := fmt.Sprintf("metrics - %s - %s - %s", workspace, request.StartDate, request.EndDate)
 	/**
 	check redis if cache id available for the date range
 	or add to redis
@@ -490,3 +498,96 @@ func getPresignedUrl(path string) (error, string) {
 
 	return nil, presignedUrl.URL
 }
+
+func GetReadCacheStats(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(db.GetReadCacheStats())
+}
+
+// GetPoolStats shows how saturated the database connection pool is
+func (mh *metricHandler) GetPoolStats(w http.ResponseWriter, r *http.Request) {
+	stats, err := mh.db.GetPoolStats()
+	if err != nil {
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to get the pool stats")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(stats)
+}
+
+// GetQueryPlans explains the canned hot queries so a plan regression, like
+// an index no longer being used, is visible without a database shell
+func (mh *metricHandler) GetQueryPlans(w http.ResponseWriter, r *http.Request) {
+	plans, err := mh.db.ExplainHotQueries()
+	if err != nil {
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to explain queries")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(plans)
+}
+
+// GetPlatformStats returns the latest platform totals with the daily
+// snapshots of the last 30 days. The snapshots come from the hourly
+// aggregation job, only a fresh install without any snapshot is computed on
+// request
+func (mh *metricHandler) GetPlatformStats(w http.ResponseWriter, r *http.Request) {
+	now := time.Now()
+	trend, err := mh.db.GetPlatformStats(now.AddDate(0, 0, -30))
+	if err != nil {
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to get platform stats")
+		return
+	}
+
+	if len(trend) == 0 {
+		stats, err := mh.db.AggregatePlatformStats(now)
+		if err != nil {
+			httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to get platform stats")
+			return
+		}
+		trend = append(trend, stats)
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(db.PlatformStatsResponse{
+		Totals: trend[len(trend)-1],
+		Trend:  trend,
+	})
+}
+
+// the public metrics are aggregates over every bounty, they are cached for
+// publicMetricsTTL. Hunters count as active for activeHunterDays
+const (
+	publicMetricsTTL = 10 * time.Minute
+	activeHunterDays = 30
+)
+
+// GetPublicMetrics serves the aggregate bounty numbers to ecosystem
+// dashboards, so they don't have to page through the bounty list
+func (mh *metricHandler) GetPublicMetrics(w http.ResponseWriter, r *http.Request) {
+	var loadErr error
+	metrics, err := db.CachedJSONFor(db.PublicMetricsCacheKey, publicMetricsTTL, func() interface{} {
+		now := time.Now()
+		metrics, err := mh.db.GetPublicMetrics(now.AddDate(0, 0, -activeHunterDays))
+		loadErr = err
+		metrics.ActiveDays = activeHunterDays
+		metrics.Generated = &now
+		return metrics
+	})
+	if loadErr != nil {
+		// a failed read must not be served from the cache
+		db.InvalidateReadCache(db.PublicMetricsCacheKey)
+		log.Println("[metrics] public metrics:", loadErr)
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to get the metrics")
+		return
+	}
+	if err != nil {
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to encode the metrics")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write(metrics)
+}
@@ -69,6 +69,7 @@ func TestBountyMetrics(t *testing.T) {
 		if err != nil {
 			t.Fatal(err)
 		}
+		mockDb.On("GetWorkspaceByUuid", workspace).Return(db.Workspace{Uuid: workspace}).Once()
 		mockDb.On("TotalBountiesPosted", dateRange, workspace).Return(int64(1)).Once()
 		mockDb.On("TotalPaidBounties", dateRange, workspace).Return(int64(1)).Once()
 		mockDb.On("TotalAssignedBounties", dateRange, workspace).Return(int64(2)).Once()
@@ -101,6 +102,36 @@ func TestBountyMetrics(t *testing.T) {
 		assert.EqualValues(t, expectedMetricRes, res)
 		assert.Equal(t, http.StatusOK, rr.Code)
 	})
+
+	t.Run("should read days in the time zone of the workspace", func(t *testing.T) {
+		db.RedisError = errors.New("redis not initialized")
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(mh.BountyMetrics)
+		workspace := "tokyo-workspace"
+
+		body, _ := json.Marshal(db.PaymentDateRange{StartDate: "2024-05-01", EndDate: "2024-05-31"})
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, "/bounty_stats?workspace="+workspace, bytes.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		// midnight of May 1 and the last second of May 31 in Tokyo
+		dateRange := db.PaymentDateRange{StartDate: "1714489200", EndDate: "1717167599"}
+		mockDb.On("GetWorkspaceByUuid", workspace).Return(db.Workspace{Uuid: workspace, Timezone: "Asia/Tokyo"}).Once()
+		mockDb.On("TotalBountiesPosted", dateRange, workspace).Return(int64(1)).Once()
+		mockDb.On("TotalPaidBounties", dateRange, workspace).Return(int64(1)).Once()
+		mockDb.On("TotalAssignedBounties", dateRange, workspace).Return(int64(2)).Once()
+		mockDb.On("BountiesPaidPercentage", dateRange, workspace).Return(uint(1)).Once()
+		mockDb.On("TotalSatsPosted", dateRange, workspace).Return(uint(1)).Once()
+		mockDb.On("TotalSatsPaid", dateRange, workspace).Return(uint(1)).Once()
+		mockDb.On("SatsPaidPercentage", dateRange, workspace).Return(uint(1)).Once()
+		mockDb.On("AveragePaidTime", dateRange, workspace).Return(uint(1)).Once()
+		mockDb.On("AverageCompletedTime", dateRange, workspace).Return(uint(1)).Once()
+		mockDb.On("TotalHuntersPaid", dateRange, workspace).Return(int64(1)).Once()
+		mockDb.On("NewHuntersPaid", dateRange, workspace).Return(int64(1)).Once()
+		handler.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
 }
 
 func TestMetricsBounties(t *testing.T) {
@@ -416,3 +447,209 @@ func TestMetricsBountiesProviders(t *testing.T) {
 		assert.EqualValues(t, expectedProviders, actualProviders)
 	})
 }
+
+func TestGetQueryPlans(t *testing.T) {
+	mockDb := mocks.NewDatabase(t)
+	mh := NewMetricHandler(mockDb)
+
+	t.Run("should return the query plans", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(mh.GetQueryPlans)
+
+		req, err := http.NewRequest(http.MethodGet, "/debug/query-plans", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expectedPlans := []db.QueryPlan{
+			{Name: "workspace_open_bounties", Query: "SELECT * FROM bounty", Plan: []string{"Index Scan using idx_bounty_workspace_status_created on bounty"}},
+		}
+		mockDb.On("ExplainHotQueries").Return(expectedPlans, nil).Once()
+
+		handler.ServeHTTP(rr, req)
+
+		var actualPlans []db.QueryPlan
+		err = json.Unmarshal(rr.Body.Bytes(), &actualPlans)
+		if err != nil {
+			t.Fatal("Failed to unmarshal response:", err)
+		}
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.EqualValues(t, expectedPlans, actualPlans)
+	})
+
+	t.Run("should return 500 if the queries can not be explained", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(mh.GetQueryPlans)
+
+		req, err := http.NewRequest(http.MethodGet, "/debug/query-plans", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		mockDb.On("ExplainHotQueries").Return(nil, errors.New("connection refused")).Once()
+
+		handler.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	})
+}
+
+func TestGetPoolStats(t *testing.T) {
+	mockDb := mocks.NewDatabase(t)
+	mh := NewMetricHandler(mockDb)
+
+	t.Run("should return the pool stats", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(mh.GetPoolStats)
+
+		req, err := http.NewRequest(http.MethodGet, "/metrics/db", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expectedStats := db.PoolStats{MaxOpenConnections: 20, OpenConnections: 20, InUse: 20, WaitCount: 4, WaitDuration: 1500, SlowQueries: 2}
+		mockDb.On("GetPoolStats").Return(expectedStats, nil).Once()
+
+		handler.ServeHTTP(rr, req)
+
+		var actualStats db.PoolStats
+		err = json.Unmarshal(rr.Body.Bytes(), &actualStats)
+		if err != nil {
+			t.Fatal("Failed to unmarshal response:", err)
+		}
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, expectedStats, actualStats)
+	})
+
+	t.Run("should return 500 if the pool is not available", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(mh.GetPoolStats)
+
+		req, err := http.NewRequest(http.MethodGet, "/metrics/db", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		mockDb.On("GetPoolStats").Return(db.PoolStats{}, errors.New("sql: database is closed")).Once()
+
+		handler.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	})
+}
+
+func TestGetPublicMetrics(t *testing.T) {
+	mockDb := mocks.NewDatabase(t)
+	mh := NewMetricHandler(mockDb)
+
+	t.Run("should return the aggregates", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/metrics/public", nil)
+
+		expected := db.PublicMetrics{BountiesPaid: 120, SatsPaid: 2400000, ActiveHunters: 18, MedianHoursToPay: 52.5}
+		mockDb.On("GetPublicMetrics", mock.MatchedBy(func(since time.Time) bool {
+			return time.Since(since) > 29*24*time.Hour
+		})).Return(expected, nil).Once()
+
+		http.HandlerFunc(mh.GetPublicMetrics).ServeHTTP(rr, req)
+
+		var metrics db.PublicMetrics
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &metrics))
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, int64(120), metrics.BountiesPaid)
+		assert.Equal(t, uint64(2400000), metrics.SatsPaid)
+		assert.Equal(t, 30, metrics.ActiveDays)
+		assert.NotNil(t, metrics.Generated)
+	})
+
+	t.Run("should return 500 if the aggregates fail", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/metrics/public", nil)
+
+		mockDb.On("GetPublicMetrics", mock.Anything).Return(db.PublicMetrics{}, errors.New("sql: database is closed")).Once()
+
+		http.HandlerFunc(mh.GetPublicMetrics).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	})
+}
+
+func TestGetPlatformStats(t *testing.T) {
+	mockDb := mocks.NewDatabase(t)
+	mh := NewMetricHandler(mockDb)
+
+	yesterday := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	today := yesterday.AddDate(0, 0, 1)
+
+	t.Run("should return the latest snapshot and the trend", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(mh.GetPlatformStats)
+
+		req, err := http.NewRequest(http.MethodGet, "/stats", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		trend := []db.PlatformStats{
+			{Day: yesterday, Tribes: 10, BountiesPaid: 3, SatsPaid: 3000},
+			{Day: today, Tribes: 12, BountiesPaid: 4, SatsPaid: 5000},
+		}
+		mockDb.On("GetPlatformStats", mock.AnythingOfType("time.Time")).Return(trend, nil).Once()
+
+		handler.ServeHTTP(rr, req)
+
+		var res db.PlatformStatsResponse
+		err = json.Unmarshal(rr.Body.Bytes(), &res)
+		if err != nil {
+			t.Fatal("Failed to unmarshal response:", err)
+		}
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, int64(12), res.Totals.Tribes)
+		assert.Equal(t, int64(5000), res.Totals.SatsPaid)
+		assert.Len(t, res.Trend, 2)
+	})
+
+	t.Run("should aggregate a first snapshot when there is none", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(mh.GetPlatformStats)
+
+		req, err := http.NewRequest(http.MethodGet, "/stats", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		mockDb.On("GetPlatformStats", mock.AnythingOfType("time.Time")).Return([]db.PlatformStats{}, nil).Once()
+		mockDb.On("AggregatePlatformStats", mock.AnythingOfType("time.Time")).Return(db.PlatformStats{Day: today, People: 7}, nil).Once()
+
+		handler.ServeHTTP(rr, req)
+
+		var res db.PlatformStatsResponse
+		err = json.Unmarshal(rr.Body.Bytes(), &res)
+		if err != nil {
+			t.Fatal("Failed to unmarshal response:", err)
+		}
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, int64(7), res.Totals.People)
+		assert.Len(t, res.Trend, 1)
+	})
+
+	t.Run("should return 500 if the snapshots can not be read", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(mh.GetPlatformStats)
+
+		req, err := http.NewRequest(http.MethodGet, "/stats", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		mockDb.On("GetPlatformStats", mock.AnythingOfType("time.Time")).Return(nil, errors.New("connection refused")).Once()
+
+		handler.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	})
+}
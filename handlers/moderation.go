@@ -0,0 +1,228 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+	"github.com/rs/xid"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/events"
+	"github.com/stakwork/sphinx-tribes/httpio"
+)
+
+var errReportTargetNotFound = errors.New("reported target not found")
+
+type moderationHandler struct {
+	db db.Database
+}
+
+func NewModerationHandler(database db.Database) *moderationHandler {
+	return &moderationHandler{db: database}
+}
+
+// CreateReport flags a tribe or a bounty for the moderators. Reporting the
+// same target again while the first report is pending returns that report
+func (mh *moderationHandler) CreateReport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
+		return
+	}
+
+	report := db.Report{}
+	body, err := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err == nil {
+		err = json.Unmarshal(body, &report)
+	}
+	if err != nil {
+		httpio.WriteError(w, r, http.StatusNotAcceptable, "")
+		return
+	}
+
+	if !validatePayload(w, r, report) {
+		return
+	}
+
+	if _, err := reportTargetOwner(mh.db, report); err != nil {
+		httpio.WriteError(w, r, http.StatusNotFound, "Reported target not found")
+		return
+	}
+
+	if pending, err := mh.db.GetPendingReport(report.TargetType, report.TargetUuid, pubKeyFromAuth); err == nil {
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(pending)
+		return
+	}
+
+	report.Uuid = xid.New().String()
+	report.ReporterPubKey = pubKeyFromAuth
+	created, err := mh.db.CreateReport(report)
+	if err != nil {
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to save report")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(created)
+}
+
+// GetReports lists the moderation queue, filtered by the status and
+// target_type query params
+func (mh *moderationHandler) GetReports(w http.ResponseWriter, r *http.Request) {
+	status := r.URL.Query().Get("status")
+	switch db.ReportStatus(status) {
+	case "", db.ReportPending, db.ReportDismissed, db.ReportResolved:
+	default:
+		httpio.WriteError(w, r, http.StatusBadRequest, "Invalid report status")
+		return
+	}
+
+	reports, err := mh.db.GetReports(r)
+	if err != nil {
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to get reports")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(reports)
+}
+
+// ResolveReport applies a moderation action to the reported target and
+// closes every pending report on it. The reporters are notified on their
+// user topic
+func (mh *moderationHandler) ResolveReport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	uuid := chi.URLParam(r, "uuid")
+
+	report, err := mh.db.GetReportByUuid(uuid)
+	if err != nil {
+		httpio.WriteError(w, r, http.StatusNotFound, "Report not found")
+		return
+	}
+	if report.Status != db.ReportPending {
+		httpio.WriteError(w, r, http.StatusConflict, "Report is already resolved")
+		return
+	}
+
+	resolution := db.ReportResolution{}
+	body, err := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err == nil {
+		err = json.Unmarshal(body, &resolution)
+	}
+	if err != nil {
+		httpio.WriteError(w, r, http.StatusNotAcceptable, "")
+		return
+	}
+
+	if !validatePayload(w, r, resolution) {
+		return
+	}
+
+	report.Action = resolution.Action
+	report.Note = resolution.Note
+	report.ResolvedBy = pubKeyFromAuth
+	report.Status = db.ReportResolved
+	if resolution.Action == db.ModerationDismiss {
+		report.Status = db.ReportDismissed
+	}
+
+	var resolved []db.Report
+	err = mh.db.WithTx(func(tx db.Database) error {
+		if err := moderateTarget(tx, report, pubKeyFromAuth); err != nil {
+			return err
+		}
+		var err error
+		resolved, err = tx.ResolveTargetReports(report)
+		return err
+	})
+	if err == errReportTargetNotFound {
+		httpio.WriteError(w, r, http.StatusNotFound, "Reported target not found")
+		return
+	}
+	if err != nil {
+		fmt.Println("[moderation]", err)
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to resolve report")
+		return
+	}
+
+	for _, closed := range resolved {
+		events.Publish(ctx, events.ReportResolved, "report:"+closed.Uuid, closed)
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(resolved)
+}
+
+// moderateTarget applies the action of report to its target. Banning the
+// owner also unlists the target
+func moderateTarget(tx db.Database, report db.Report, moderator string) error {
+	if report.Action == db.ModerationDismiss {
+		return nil
+	}
+
+	owner, err := reportTargetOwner(tx, report)
+	if err != nil {
+		return err
+	}
+
+	action := report.Action
+	if action == db.ModerationBanOwner {
+		ban := db.BannedPubkey{Pubkey: owner, ReportUuid: report.Uuid, BannedBy: moderator}
+		if err := tx.BanPubkey(ban); err != nil {
+			return err
+		}
+		action = db.ModerationUnlist
+	}
+
+	switch report.TargetType {
+	case "tribe":
+		if action == db.ModerationDelete {
+			_, err = tx.SoftDelete(db.SoftDeleteTribes, report.TargetUuid)
+			return err
+		}
+		tx.UpdateTribe(report.TargetUuid, map[string]interface{}{"unlisted": true})
+	case "bounty":
+		id, _ := strconv.ParseUint(report.TargetUuid, 10, 32)
+		bounty := tx.GetBounty(uint(id))
+		if action == db.ModerationDelete {
+			_, err = tx.DeleteBounty(bounty.OwnerID, strconv.FormatInt(bounty.Created, 10))
+			return err
+		}
+		_, err = tx.UpdateBountyBoolColumn(bounty, "show")
+		return err
+	}
+	return nil
+}
+
+// reportTargetOwner returns the owner pubkey of the reported tribe or bounty
+func reportTargetOwner(database db.Database, report db.Report) (string, error) {
+	switch report.TargetType {
+	case "tribe":
+		tribe := database.GetTribe(report.TargetUuid)
+		if tribe.UUID == "" {
+			return "", errReportTargetNotFound
+		}
+		return tribe.OwnerPubKey, nil
+	case "bounty":
+		id, err := strconv.ParseUint(report.TargetUuid, 10, 32)
+		if err != nil {
+			return "", errReportTargetNotFound
+		}
+		bounty := database.GetBounty(uint(id))
+		if bounty.ID == 0 {
+			return "", errReportTargetNotFound
+		}
+		return bounty.OwnerID, nil
+	}
+	return "", errReportTargetNotFound
+}
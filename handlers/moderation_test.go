@@ -0,0 +1,181 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateReport(t *testing.T) {
+	newRequest := func(pubkey string, body string) *http.Request {
+		ctx := context.WithValue(context.Background(), auth.ContextKey, pubkey)
+		req, _ := http.NewRequestWithContext(ctx, http.MethodPost, "/report", bytes.NewBufferString(body))
+		return req
+	}
+
+	t.Run("should return 401 without a pubkey", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mHandler := NewModerationHandler(mockDb)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(mHandler.CreateReport).ServeHTTP(rr, newRequest("", `{}`))
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("should reject an unknown target type", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mHandler := NewModerationHandler(mockDb)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(mHandler.CreateReport).ServeHTTP(rr, newRequest("reporter", `{"target_type": "person", "target_uuid": "uuid", "reason": "spam"}`))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockDb.AssertNotCalled(t, "CreateReport", mock.Anything)
+	})
+
+	t.Run("should return 404 for a missing tribe", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mHandler := NewModerationHandler(mockDb)
+		mockDb.On("GetTribe", "tribe-uuid").Return(db.Tribe{}).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(mHandler.CreateReport).ServeHTTP(rr, newRequest("reporter", `{"target_type": "tribe", "target_uuid": "tribe-uuid", "reason": "scam"}`))
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should queue a report on a bounty", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mHandler := NewModerationHandler(mockDb)
+		mockDb.On("GetBounty", uint(12)).Return(db.NewBounty{ID: 12, OwnerID: "owner"}).Once()
+		mockDb.On("GetPendingReport", "bounty", "12", "reporter").Return(db.Report{}, errors.New("no report found")).Once()
+		mockDb.On("CreateReport", mock.MatchedBy(func(r db.Report) bool {
+			return r.Uuid != "" && r.ReporterPubKey == "reporter" && r.TargetType == "bounty" && r.TargetUuid == "12"
+		})).Return(db.Report{Uuid: "report-uuid", Status: db.ReportPending}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(mHandler.CreateReport).ServeHTTP(rr, newRequest("reporter", `{"target_type": "bounty", "target_uuid": "12", "reason": "spam"}`))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var report db.Report
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &report))
+		assert.Equal(t, "report-uuid", report.Uuid)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should return the pending report when reporting again", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mHandler := NewModerationHandler(mockDb)
+		mockDb.On("GetTribe", "tribe-uuid").Return(db.Tribe{UUID: "tribe-uuid"}).Once()
+		mockDb.On("GetPendingReport", "tribe", "tribe-uuid", "reporter").Return(db.Report{Uuid: "first-report"}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(mHandler.CreateReport).ServeHTTP(rr, newRequest("reporter", `{"target_type": "tribe", "target_uuid": "tribe-uuid", "reason": "scam"}`))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), "first-report")
+		mockDb.AssertNotCalled(t, "CreateReport", mock.Anything)
+	})
+}
+
+func TestResolveReport(t *testing.T) {
+	newRequest := func(uuid string, body string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("uuid", uuid)
+		ctx := context.WithValue(context.WithValue(context.Background(), chi.RouteCtxKey, rctx), auth.ContextKey, "admin")
+		req, _ := http.NewRequestWithContext(ctx, http.MethodPost, "/admin/reports/"+uuid+"/resolve", bytes.NewBufferString(body))
+		return req
+	}
+	pending := db.Report{Uuid: "report-uuid", TargetType: "tribe", TargetUuid: "tribe-uuid", ReporterPubKey: "reporter", Status: db.ReportPending}
+
+	t.Run("should not resolve a closed report", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mHandler := NewModerationHandler(mockDb)
+		mockDb.On("GetReportByUuid", "report-uuid").Return(db.Report{Uuid: "report-uuid", Status: db.ReportDismissed}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(mHandler.ResolveReport).ServeHTTP(rr, newRequest("report-uuid", `{"action": "dismiss"}`))
+
+		assert.Equal(t, http.StatusConflict, rr.Code)
+	})
+
+	t.Run("should reject an unknown action", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mHandler := NewModerationHandler(mockDb)
+		mockDb.On("GetReportByUuid", "report-uuid").Return(pending, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(mHandler.ResolveReport).ServeHTTP(rr, newRequest("report-uuid", `{"action": "shame"}`))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockDb.AssertNotCalled(t, "WithTx", mock.Anything)
+	})
+
+	t.Run("should dismiss a report without touching the target", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mHandler := NewModerationHandler(mockDb)
+		mockDb.On("GetReportByUuid", "report-uuid").Return(pending, nil).Once()
+		mockDb.On("WithTx", mock.Anything).Return(func(fn func(db.Database) error) error { return fn(mockDb) })
+		mockDb.On("ResolveTargetReports", mock.MatchedBy(func(r db.Report) bool {
+			return r.Status == db.ReportDismissed && r.ResolvedBy == "admin"
+		})).Return([]db.Report{{Uuid: "report-uuid", Status: db.ReportDismissed}}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(mHandler.ResolveReport).ServeHTTP(rr, newRequest("report-uuid", `{"action": "dismiss"}`))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		mockDb.AssertExpectations(t)
+		mockDb.AssertNotCalled(t, "UpdateTribe", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should ban the owner and unlist the tribe", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mHandler := NewModerationHandler(mockDb)
+		mockDb.On("GetReportByUuid", "report-uuid").Return(pending, nil).Once()
+		mockDb.On("WithTx", mock.Anything).Return(func(fn func(db.Database) error) error { return fn(mockDb) })
+		mockDb.On("GetTribe", "tribe-uuid").Return(db.Tribe{UUID: "tribe-uuid", OwnerPubKey: "scammer"}).Once()
+		mockDb.On("BanPubkey", mock.MatchedBy(func(b db.BannedPubkey) bool {
+			return b.Pubkey == "scammer" && b.BannedBy == "admin" && b.ReportUuid == "report-uuid"
+		})).Return(nil).Once()
+		mockDb.On("UpdateTribe", "tribe-uuid", map[string]interface{}{"unlisted": true}).Return(true).Once()
+		mockDb.On("ResolveTargetReports", mock.MatchedBy(func(r db.Report) bool {
+			return r.Status == db.ReportResolved && r.Action == db.ModerationBanOwner
+		})).Return([]db.Report{{Uuid: "report-uuid", Status: db.ReportResolved}}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(mHandler.ResolveReport).ServeHTTP(rr, newRequest("report-uuid", `{"action": "ban_owner", "note": "scam tribe"}`))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should delete a reported bounty", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mHandler := NewModerationHandler(mockDb)
+		report := db.Report{Uuid: "report-uuid", TargetType: "bounty", TargetUuid: "12", Status: db.ReportPending}
+		mockDb.On("GetReportByUuid", "report-uuid").Return(report, nil).Once()
+		mockDb.On("WithTx", mock.Anything).Return(func(fn func(db.Database) error) error { return fn(mockDb) })
+		mockDb.On("GetBounty", uint(12)).Return(db.NewBounty{ID: 12, OwnerID: "owner", Created: 1700000000})
+		mockDb.On("DeleteBounty", "owner", "1700000000").Return(db.NewBounty{}, nil).Once()
+		mockDb.On("ResolveTargetReports", mock.AnythingOfType("db.Report")).Return([]db.Report{report}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(mHandler.ResolveReport).ServeHTTP(rr, newRequest("report-uuid", `{"action": "delete"}`))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		mockDb.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/events"
+	"github.com/stakwork/sphinx-tribes/httpio"
+	"github.com/stakwork/sphinx-tribes/utils"
+)
+
+type notificationHandler struct {
+	db db.Database
+}
+
+func NewNotificationHandler(database db.Database) *notificationHandler {
+	return &notificationHandler{db: database}
+}
+
+// GetNotifications lists the inbox of the caller newest first, the unread
+// count is in the X-Unread-Count header
+func (nh *notificationHandler) GetNotifications(w http.ResponseWriter, r *http.Request) {
+	pubKeyFromAuth, _ := r.Context().Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
+		return
+	}
+
+	list, err := nh.db.GetNotifications(pubKeyFromAuth, r)
+	if err != nil {
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to get the notifications")
+		return
+	}
+
+	unread := nh.db.GetNotificationsCount(pubKeyFromAuth, true)
+	w.Header().Set("X-Unread-Count", strconv.FormatInt(unread, 10))
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(utils.ListBody(r, list, func() int64 {
+		if r.URL.Query().Get("unread") == "true" {
+			return unread
+		}
+		return nh.db.GetNotificationsCount(pubKeyFromAuth, false)
+	}))
+}
+
+func (nh *notificationHandler) ReadNotification(w http.ResponseWriter, r *http.Request) {
+	pubKeyFromAuth, _ := r.Context().Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
+		return
+	}
+
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		httpio.WriteError(w, r, http.StatusBadRequest, "Invalid notification id")
+		return
+	}
+
+	found, err := nh.db.ReadNotification(pubKeyFromAuth, uint(id))
+	if err != nil {
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to read the notification")
+		return
+	}
+	if !found {
+		httpio.WriteError(w, r, http.StatusNotFound, "Notification not found")
+		return
+	}
+	events.PublishUnreadCount(nh.db, pubKeyFromAuth)
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(true)
+}
+
+func (nh *notificationHandler) ReadAllNotifications(w http.ResponseWriter, r *http.Request) {
+	pubKeyFromAuth, _ := r.Context().Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
+		return
+	}
+
+	read, err := nh.db.ReadAllNotifications(pubKeyFromAuth)
+	if err != nil {
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to read the notifications")
+		return
+	}
+	events.PublishUnreadCount(nh.db, pubKeyFromAuth)
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]int64{"read": read})
+}
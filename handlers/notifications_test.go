@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestNotifications(t *testing.T) {
+	newRequest := func(method string, path string, pubkey string, id string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", id)
+		ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+		ctx = context.WithValue(ctx, auth.ContextKey, pubkey)
+		req, _ := http.NewRequestWithContext(ctx, method, path, nil)
+		return req
+	}
+
+	t.Run("should list the inbox with the unread count", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		nHandler := NewNotificationHandler(mockDb)
+		inbox := []db.Notification{{ID: 2, PubKey: "pubkey", Message: "Bounty \"a bounty\" was paid"}, {ID: 1, PubKey: "pubkey", Read: true}}
+
+		mockDb.On("GetNotifications", "pubkey", mock.Anything).Return(inbox, nil).Once()
+		mockDb.On("GetNotificationsCount", "pubkey", true).Return(int64(1)).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(nHandler.GetNotifications).ServeHTTP(rr, newRequest(http.MethodGet, "/me/notifications", "pubkey", ""))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "1", rr.Header().Get("X-Unread-Count"))
+		var res []db.Notification
+		json.Unmarshal(rr.Body.Bytes(), &res)
+		assert.Len(t, res, 2)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should require an authenticated user", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		nHandler := NewNotificationHandler(mockDb)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(nHandler.GetNotifications).ServeHTTP(rr, newRequest(http.MethodGet, "/me/notifications", "", ""))
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("should mark a notification of the caller read", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		nHandler := NewNotificationHandler(mockDb)
+
+		mockDb.On("ReadNotification", "pubkey", uint(2)).Return(true, nil).Once()
+		mockDb.On("GetNotificationsCount", "pubkey", true).Return(int64(0)).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(nHandler.ReadNotification).ServeHTTP(rr, newRequest(http.MethodPut, "/me/notifications/2/read", "pubkey", "2"))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should not find the notification of another user", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		nHandler := NewNotificationHandler(mockDb)
+
+		mockDb.On("ReadNotification", "pubkey", uint(9)).Return(false, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(nHandler.ReadNotification).ServeHTTP(rr, newRequest(http.MethodPut, "/me/notifications/9/read", "pubkey", "9"))
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("should mark every notification read", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		nHandler := NewNotificationHandler(mockDb)
+
+		mockDb.On("ReadAllNotifications", "pubkey").Return(int64(3), nil).Once()
+		mockDb.On("GetNotificationsCount", "pubkey", true).Return(int64(0)).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(nHandler.ReadAllNotifications).ServeHTTP(rr, newRequest(http.MethodPut, "/me/notifications/read_all", "pubkey", ""))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, `{"read": 3}`, rr.Body.String())
+		mockDb.AssertExpectations(t)
+	})
+}
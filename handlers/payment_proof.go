@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/httpio"
+	"github.com/stakwork/sphinx-tribes/utils"
+)
+
+// GetBountyPaymentProof returns the hash, the preimage and the settlement
+// time of the payout of a bounty. Only the node paid could reveal the
+// preimage, so its sha256 matching the hash proves the payout settled.
+// The people paid are never named, and the amount only shows when the
+// bounty owner opted in with show_payment_amount
+func (h *bountyHandler) GetBountyPaymentProof(w http.ResponseWriter, r *http.Request) {
+	id, err := utils.ConvertStringToUint(chi.URLParam(r, "id"))
+	if err != nil {
+		httpio.WriteError(w, r, http.StatusBadRequest, "Invalid bounty id")
+		return
+	}
+	bounty := h.db.GetBounty(id)
+	if bounty.ID == 0 {
+		httpio.WriteError(w, r, http.StatusNotFound, "Bounty not found")
+		return
+	}
+
+	payment := h.db.GetBountyPayment(bounty.ID)
+	if payment.ID == 0 {
+		httpio.WriteError(w, r, http.StatusNotFound, "The bounty has no settled payment")
+		return
+	}
+	// payments marked as paid by hand, or sent before preimages were kept,
+	// have nothing to verify
+	if payment.PaymentHash == "" || !preimageMatches(payment.PaymentHash, payment.PaymentPreimage) {
+		httpio.WriteError(w, r, http.StatusNotFound, "The payment of the bounty has no proof")
+		return
+	}
+
+	proof := db.PaymentProof{
+		BountyID:    bounty.ID,
+		PaymentHash: payment.PaymentHash,
+		Preimage:    payment.PaymentPreimage,
+		SettledAt:   payment.Created,
+	}
+	if bounty.ShowPaymentAmount {
+		amount := payment.Amount
+		proof.Amount = &amount
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(proof)
+}
+
+// preimageMatches tells whether the sha256 of the hex preimage is the hex
+// payment hash
+func preimageMatches(paymentHash string, preimage string) bool {
+	raw, err := hex.DecodeString(preimage)
+	if err != nil || len(raw) == 0 {
+		return false
+	}
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:]) == paymentHash
+}
+
+// savePaymentPreimage keeps the preimage of a settled payment as its proof
+// and returns whether it was kept, a preimage that doesn't match the
+// payment hash is dropped
+func savePaymentPreimage(database db.Database, id uint, paymentHash string, preimage string) bool {
+	if !preimageMatches(paymentHash, preimage) {
+		log.Printf("[payments] the preimage of payment %d does not match its hash, it is not kept", id)
+		return false
+	}
+	if err := database.SetPaymentPreimage(id, preimage); err != nil {
+		log.Printf("[payments] the preimage of payment %d could not be recorded: %s", id, err)
+		return false
+	}
+	return true
+}
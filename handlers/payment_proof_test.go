@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/handlers/mocks"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetBountyPaymentProof(t *testing.T) {
+	settled := time.Now().UTC().Truncate(time.Second)
+	preimage := strings.Repeat("ab", 32)
+	raw, _ := hex.DecodeString(preimage)
+	hash := sha256.Sum256(raw)
+	paymentHash := hex.EncodeToString(hash[:])
+	payment := db.NewPaymentHistory{
+		ID:              7,
+		Amount:          5000,
+		BountyId:        1,
+		SenderPubKey:    "owner-pubkey",
+		ReceiverPubKey:  "assignee-pubkey",
+		Created:         &settled,
+		Status:          true,
+		PaymentHash:     paymentHash,
+		PaymentPreimage: preimage,
+	}
+	serve := func(mockDb *dbMocks.Database, id string) *httptest.ResponseRecorder {
+		ro := chi.NewRouter()
+		ro.Get("/gobounties/{id}/payment_proof", NewBountyHandler(&mocks.HttpClient{}, mockDb).GetBountyPaymentProof)
+		rr := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/gobounties/"+id+"/payment_proof", nil)
+		ro.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("should prove the payout without naming anybody", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mockDb.On("GetBounty", uint(1)).Return(db.NewBounty{ID: 1, Paid: true}).Once()
+		mockDb.On("GetBountyPayment", uint(1)).Return(payment).Once()
+
+		rr := serve(mockDb, "1")
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		proof := db.PaymentProof{}
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &proof))
+		assert.Equal(t, paymentHash, proof.PaymentHash)
+		assert.Equal(t, preimage, proof.Preimage)
+		assert.True(t, settled.Equal(*proof.SettledAt))
+		assert.Nil(t, proof.Amount)
+		assert.NotContains(t, rr.Body.String(), "pubkey")
+	})
+
+	t.Run("should show the amount when the owner opted in", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mockDb.On("GetBounty", uint(1)).Return(db.NewBounty{ID: 1, Paid: true, ShowPaymentAmount: true}).Once()
+		mockDb.On("GetBountyPayment", uint(1)).Return(payment).Once()
+
+		rr := serve(mockDb, "1")
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		proof := db.PaymentProof{}
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &proof))
+		assert.Equal(t, uint(5000), *proof.Amount)
+	})
+
+	t.Run("should return 404 when the payout has no hash", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mockDb.On("GetBounty", uint(1)).Return(db.NewBounty{ID: 1, Paid: true}).Once()
+		mockDb.On("GetBountyPayment", uint(1)).Return(db.NewPaymentHistory{ID: 7, BountyId: 1, Status: true}).Once()
+
+		rr := serve(mockDb, "1")
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("should return 404 when the payout has no preimage", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mockDb.On("GetBounty", uint(1)).Return(db.NewBounty{ID: 1, Paid: true}).Once()
+		mockDb.On("GetBountyPayment", uint(1)).Return(db.NewPaymentHistory{ID: 7, BountyId: 1, Status: true, PaymentHash: paymentHash}).Once()
+
+		rr := serve(mockDb, "1")
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("should return 404 when the bounty was not paid", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mockDb.On("GetBounty", uint(1)).Return(db.NewBounty{ID: 1}).Once()
+		mockDb.On("GetBountyPayment", uint(1)).Return(db.NewPaymentHistory{}).Once()
+
+		rr := serve(mockDb, "1")
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("should return 400 for an invalid id", func(t *testing.T) {
+		rr := serve(&dbMocks.Database{}, "abc")
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/httpio"
+	"github.com/stakwork/sphinx-tribes/utils"
+)
+
+type pendingPaymentHandler struct {
+	db db.Database
+}
+
+func NewPendingPaymentHandler(database db.Database) *pendingPaymentHandler {
+	return &pendingPaymentHandler{db: database}
+}
+
+// GetPendingPayments lists the payments the relay was sent but didn't
+// answer, they are not sent again until they are reconciled
+func (ph *pendingPaymentHandler) GetPendingPayments(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(ph.db.GetPendingPayments())
+}
+
+// ReconcilePayment settles or fails a pending payment with the outcome an
+// admin found on the node
+func (ph *pendingPaymentHandler) ReconcilePayment(w http.ResponseWriter, r *http.Request) {
+	id, err := utils.ConvertStringToUint(chi.URLParam(r, "id"))
+	if err != nil {
+		httpio.WriteError(w, r, http.StatusBadRequest, "Invalid payment id")
+		return
+	}
+
+	reconciliation := db.PaymentReconciliation{}
+	body, err := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err == nil {
+		err = json.Unmarshal(body, &reconciliation)
+	}
+	if err != nil {
+		httpio.WriteError(w, r, http.StatusNotAcceptable, "")
+		return
+	}
+
+	if !validatePayload(w, r, reconciliation) {
+		return
+	}
+	if reconciliation.Preimage != "" && reconciliation.PaymentHash != "" && !preimageMatches(reconciliation.PaymentHash, reconciliation.Preimage) {
+		httpio.WriteError(w, r, http.StatusBadRequest, "The preimage does not match the payment hash")
+		return
+	}
+
+	payment, err := ph.db.ReconcilePayment(id, reconciliation.Outcome == "settled", reconciliation.PaymentHash)
+	if errors.Is(err, db.ErrPaymentNotPending) {
+		httpio.WriteError(w, r, http.StatusConflict, "The payment is not pending")
+		return
+	}
+	if err != nil {
+		fmt.Println("[payments]", err)
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to reconcile the payment")
+		return
+	}
+	if payment.Status && reconciliation.Preimage != "" && savePaymentPreimage(ph.db, payment.ID, payment.PaymentHash, reconciliation.Preimage) {
+		payment.PaymentPreimage = reconciliation.Preimage
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(payment)
+}
+
+// failPayment marks a reserved payment that didn't go out as failed, which
+// gives its amount back to the workspace budget
+func failPayment(database db.Database, id uint) {
+	if _, err := database.ReconcilePayment(id, false, ""); err != nil {
+		log.Printf("[payments] payment %d could not be marked failed: %s", id, err)
+	}
+}
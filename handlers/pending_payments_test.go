@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/db"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestReconcilePayment(t *testing.T) {
+	newRequest := func(id string, body string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", id)
+		req, _ := http.NewRequestWithContext(context.WithValue(context.Background(), chi.RouteCtxKey, rctx), http.MethodPost, "/admin/payments/"+id+"/reconcile", bytes.NewBufferString(body))
+		return req
+	}
+
+	t.Run("should reject an unknown outcome", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		pHandler := NewPendingPaymentHandler(mockDb)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(pHandler.ReconcilePayment).ServeHTTP(rr, newRequest("4", `{"outcome": "retry"}`))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockDb.AssertNotCalled(t, "ReconcilePayment", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should settle a pending payment", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		pHandler := NewPendingPaymentHandler(mockDb)
+		settled := db.NewPaymentHistory{ID: 4, BountyId: 9, Status: true, PaymentHash: "hash"}
+		mockDb.On("ReconcilePayment", uint(4), true, "hash").Return(settled, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(pHandler.ReconcilePayment).ServeHTTP(rr, newRequest("4", `{"outcome": "settled", "payment_hash": "hash"}`))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var body db.NewPaymentHistory
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+		assert.Equal(t, settled.PaymentHash, body.PaymentHash)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should refuse a preimage that doesn't match the hash", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		pHandler := NewPendingPaymentHandler(mockDb)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(pHandler.ReconcilePayment).ServeHTTP(rr, newRequest("4", `{"outcome": "settled", "payment_hash": "hash", "preimage": "abab"}`))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockDb.AssertNotCalled(t, "ReconcilePayment", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should answer 409 when the payment is not pending", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		pHandler := NewPendingPaymentHandler(mockDb)
+		mockDb.On("ReconcilePayment", uint(4), false, "").Return(db.NewPaymentHistory{}, db.ErrPaymentNotPending).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(pHandler.ReconcilePayment).ServeHTTP(rr, newRequest("4", `{"outcome": "failed"}`))
+
+		assert.Equal(t, http.StatusConflict, rr.Code)
+		mockDb.AssertExpectations(t)
+	})
+}
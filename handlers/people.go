@@ -7,7 +7,6 @@ import (
 	"io"
 	"log"
 	"net/http"
-	"os"
 	"strconv"
 	"strings"
 	"time"
@@ -15,12 +14,13 @@ import (
 	"github.com/go-chi/chi"
 	"github.com/rs/xid"
 	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/config"
 	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/events"
+	"github.com/stakwork/sphinx-tribes/httpio"
 	"github.com/stakwork/sphinx-tribes/utils"
 )
 
-const liquidTestModeUrl = "TEST_ASSET_URL"
-
 type peopleHandler struct {
 	db db.Database
 }
@@ -93,8 +93,14 @@ func (ph *peopleHandler) CreateOrEditPerson(w http.ResponseWriter, r *http.Reque
 		}
 	}
 
+	if !validatePayload(w, r, person) {
+		return
+	}
+
 	person.OwnerPubKey = pubKeyFromAuth
 	person.Updated = &now
+	// skills are only verified by paid bounties
+	person.VerifiedSkills = existing.VerifiedSkills
 
 	if person.NewTicketTime != 0 {
 		go ph.db.ProcessAlerts(person)
@@ -112,6 +118,7 @@ func (ph *peopleHandler) CreateOrEditPerson(w http.ResponseWriter, r *http.Reque
 		w.WriteHeader(http.StatusBadRequest)
 		return
 	}
+	events.Publish(r.Context(), events.PersonUpdated, "person:"+p.Uuid, map[string]interface{}{"uuid": p.Uuid})
 
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(p)
@@ -152,8 +159,14 @@ func (ph *peopleHandler) UpsertLogin(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	if !validatePayload(w, r, person) {
+		return
+	}
+
 	person.OwnerPubKey = pubKeyFromAuth
 	person.Updated = &now
+	// skills are only verified by paid bounties
+	person.VerifiedSkills = existing.VerifiedSkills
 
 	if person.NewTicketTime != 0 {
 		go ph.db.ProcessAlerts(person)
@@ -188,12 +201,7 @@ func (ph *peopleHandler) UpsertLogin(w http.ResponseWriter, r *http.Request) {
 }
 
 func PersonIsAdmin(pk string) bool {
-	adminPubkeys := os.Getenv("ADMIN_PUBKEYS")
-	if adminPubkeys == "" {
-		return false
-	}
-	admins := strings.Split(adminPubkeys, ",")
-	for _, admin := range admins {
+	for _, admin := range config.Get().AdminPubkeys {
 		if admin == pk {
 			return true
 		}
@@ -290,8 +298,7 @@ func DeleteTicketByAdmin(w http.ResponseWriter, r *http.Request) {
 }
 
 func ProcessTwitterConfirmationsLoop() {
-	twitterToken := os.Getenv("TWITTER_TOKEN")
-	if twitterToken == "" {
+	if config.Get().TwitterToken == "" {
 		return
 	}
 	peeps := db.DB.GetUnconfirmedTwitter()
@@ -411,12 +418,34 @@ func processGithubConfirmationsLoop() {
 	processGithubConfirmationsLoop()
 }
 
+// withoutContact leaves the contact key and route hint out of a profile
+// read by anyone but its owner. The owner and the assignee of a bounty
+// exchange them with GET /gobounties/{id}/contact
+func withoutContact(r *http.Request, person db.Person) db.Person {
+	if _, pubkey := bountyViewer(r); pubkey != "" && pubkey == person.OwnerPubKey {
+		return person
+	}
+	person.OwnerContactKey = ""
+	person.OwnerRouteHint = ""
+	return person
+}
+
+// withoutContacts is a list of profiles without their contact keys and
+// route hints, see withoutContact
+func withoutContacts(people []db.Person) []db.Person {
+	for i := range people {
+		people[i].OwnerContactKey = ""
+		people[i].OwnerRouteHint = ""
+	}
+	return people
+}
+
 func (ph *peopleHandler) GetPersonByPubkey(w http.ResponseWriter, r *http.Request) {
 	pubkey := chi.URLParam(r, "pubkey")
 
 	person := ph.db.GetPersonByPubkey(pubkey)
 	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(person)
+	json.NewEncoder(w).Encode(withoutContact(r, person))
 }
 
 func (ph *peopleHandler) GetPersonById(w http.ResponseWriter, r *http.Request) {
@@ -425,12 +454,12 @@ func (ph *peopleHandler) GetPersonById(w http.ResponseWriter, r *http.Request) {
 
 	person := ph.db.GetPerson(uint(id))
 	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(person)
+	json.NewEncoder(w).Encode(withoutContact(r, person))
 }
 
 func (ph *peopleHandler) GetPersonByUuid(w http.ResponseWriter, r *http.Request) {
 	uuid := chi.URLParam(r, "uuid")
-	person := ph.db.GetPersonByUuid(uuid)
+	person := withoutContact(r, ph.db.GetPersonByUuid(uuid))
 	assetBalanceData, err := GetAssetByPubkey(person.OwnerPubKey)
 
 	personResponse := make(map[string]interface{})
@@ -482,7 +511,7 @@ func GetPersonByGithubName(w http.ResponseWriter, r *http.Request) {
 	github := chi.URLParam(r, "github")
 	person := db.DB.GetPersonByGithubName(github)
 	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(person)
+	json.NewEncoder(w).Encode(withoutContact(r, person))
 }
 
 func (ph *peopleHandler) DeletePerson(w http.ResponseWriter, r *http.Request) {
@@ -518,6 +547,7 @@ func (ph *peopleHandler) DeletePerson(w http.ResponseWriter, r *http.Request) {
 	ph.db.UpdatePerson(uint(id), map[string]interface{}{
 		"deleted": true,
 	})
+	events.Publish(ctx, events.PersonUpdated, "person:"+existing.Uuid, map[string]interface{}{"uuid": existing.Uuid, "deleted": true})
 
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(true)
@@ -525,15 +555,10 @@ func (ph *peopleHandler) DeletePerson(w http.ResponseWriter, r *http.Request) {
 
 func GetAssetByPubkey(pubkey string) ([]db.AssetBalanceData, error) {
 	client := &http.Client{}
-	testMode, err := strconv.ParseBool(os.Getenv("TEST_MODE"))
-	if err != nil {
-		testMode = false
-	}
+	cfg := config.Get()
 
-	url := os.Getenv(liquidTestModeUrl)
-	if testMode && (url != "") {
-		url = os.Getenv(liquidTestModeUrl)
-	} else {
+	url := cfg.TestAssetUrl
+	if !cfg.TestMode || url == "" {
 		url = "https://liquid.sphinx.chat/balances?pubkey=" + pubkey
 	}
 
@@ -563,12 +588,7 @@ func GetAssetByPubkey(pubkey string) ([]db.AssetBalanceData, error) {
 func GetAssetList(pubkey string) ([]db.AssetListData, error) {
 	client := &http.Client{}
 
-	url := os.Getenv("ASSET_LIST_URL")
-	if url == "" {
-		url = "https://liquid.sphinx.chat/assets"
-	}
-
-	url = url + "?pubkey=" + pubkey
+	url := config.Get().AssetListUrl + "?pubkey=" + pubkey
 
 	req, err := http.NewRequest("GET", url, nil)
 
@@ -693,15 +713,31 @@ func GetPeopleShortList(w http.ResponseWriter, r *http.Request) {
 }
 
 func (ph *peopleHandler) GetPeopleBySearch(w http.ResponseWriter, r *http.Request) {
-	people := ph.db.GetPeopleBySearch(r)
+	if _, err := db.ParseLocationFilter(r); err != nil {
+		httpio.WriteError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	people := withoutContacts(ph.db.GetPeopleBySearch(r))
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(people)
 }
 
 func (ph *peopleHandler) GetListedPeople(w http.ResponseWriter, r *http.Request) {
-	people := ph.db.GetListedPeople(r)
+	if _, err := db.ParseLocationFilter(r); err != nil {
+		httpio.WriteError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	people, err := db.CachedJSON(db.ListCacheKey(db.PeopleCacheKey, r), func() interface{} {
+		return utils.ListBody(r, withoutContacts(ph.db.GetListedPeople(r)), func() int64 {
+			return ph.db.GetListedPeopleCount(r)
+		})
+	})
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
 	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(people)
+	w.Write(people)
 }
 
 func GetListedPosts(w http.ResponseWriter, r *http.Request) {
@@ -14,14 +14,14 @@ import (
 	"github.com/lib/pq"
 	"github.com/stakwork/sphinx-tribes/auth"
 	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/dbtest"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestGetPersonByPuKey(t *testing.T) {
-	teardownSuite := SetupSuite(t)
-	defer teardownSuite(t)
+	database := dbtest.New(t)
 
-	pHandler := NewPeopleHandler(db.TestDB)
+	pHandler := NewPeopleHandler(database)
 	t.Run("should return person if present in db", func(t *testing.T) {
 		rr := httptest.NewRecorder()
 		handler := http.HandlerFunc(pHandler.GetPersonByPubkey)
@@ -36,7 +36,7 @@ func TestGetPersonByPuKey(t *testing.T) {
 			Extras:       db.PropertyMap{},
 			GithubIssues: db.PropertyMap{},
 		}
-		db.TestDB.CreateOrEditPerson(person)
+		database.CreateOrEditPerson(person)
 
 		rctx := chi.NewRouteContext()
 		rctx.URLParams.Add("pubkey", person.OwnerPubKey)
@@ -55,9 +55,8 @@ func TestGetPersonByPuKey(t *testing.T) {
 }
 
 func TestCreateOrEditPerson(t *testing.T) {
-	teardownSuite := SetupSuite(t)
-	defer teardownSuite(t)
-	pHandler := NewPeopleHandler(db.TestDB)
+	database := dbtest.New(t)
+	pHandler := NewPeopleHandler(database)
 
 	t.Run("should return error if body is not a valid json", func(t *testing.T) {
 		rr := httptest.NewRecorder()
@@ -140,9 +139,9 @@ func TestCreateOrEditPerson(t *testing.T) {
 			Img:          "img-url",
 		}
 
-		db.TestDB.CreateOrEditPerson(person)
+		database.CreateOrEditPerson(person)
 
-		fetchedUpdatedPerson := db.TestDB.GetPersonByUuid(person.Uuid)
+		fetchedUpdatedPerson := database.GetPersonByUuid(person.Uuid)
 
 		person.ID = fetchedUpdatedPerson.ID
 
@@ -194,7 +193,7 @@ func TestCreateOrEditPerson(t *testing.T) {
 			Description: "this is a test user",
 			Img:         "img-url",
 		}
-		createdPerson, err := db.TestDB.CreateOrEditPerson(person)
+		createdPerson, err := database.CreateOrEditPerson(person)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -215,7 +214,7 @@ func TestCreateOrEditPerson(t *testing.T) {
 		}
 		requestBody, _ := json.Marshal(updatePerson)
 
-		db.TestDB.CreateOrEditPerson(updatePerson)
+		database.CreateOrEditPerson(updatePerson)
 
 		ctx := context.WithValue(context.Background(), auth.ContextKey, updatePerson.OwnerPubKey)
 
@@ -224,7 +223,7 @@ func TestCreateOrEditPerson(t *testing.T) {
 			t.Fatal(err)
 		}
 
-		fetchedUpdatedPerson := db.TestDB.GetPersonByUuid(updatePerson.Uuid)
+		fetchedUpdatedPerson := database.GetPersonByUuid(updatePerson.Uuid)
 
 		updatePerson.ID = fetchedUpdatedPerson.ID
 		updatePerson.Created = fetchedUpdatedPerson.Created
@@ -238,10 +237,9 @@ func TestCreateOrEditPerson(t *testing.T) {
 }
 
 func TestGetPersonById(t *testing.T) {
-	teardownSuite := SetupSuite(t)
-	defer teardownSuite(t)
+	database := dbtest.New(t)
 
-	pHandler := NewPeopleHandler(db.TestDB)
+	pHandler := NewPeopleHandler(database)
 
 	t.Run("successful retrieval", func(t *testing.T) {
 		rr := httptest.NewRecorder()
@@ -264,8 +262,8 @@ func TestGetPersonById(t *testing.T) {
 		req, err := http.NewRequestWithContext(context.WithValue(context.Background(), chi.RouteCtxKey, rctx), http.MethodGet, "/person", nil)
 		assert.NoError(t, err)
 
-		db.TestDB.CreateOrEditPerson(person)
-		fetchedPerson := db.TestDB.GetPerson(person.ID)
+		database.CreateOrEditPerson(person)
+		fetchedPerson := database.GetPerson(person.ID)
 
 		handler.ServeHTTP(rr, req)
 
@@ -293,10 +291,9 @@ func TestGetPersonById(t *testing.T) {
 }
 
 func TestDeletePerson(t *testing.T) {
-	teardownSuite := SetupSuite(t)
-	defer teardownSuite(t)
+	database := dbtest.New(t)
 
-	pHandler := NewPeopleHandler(db.TestDB)
+	pHandler := NewPeopleHandler(database)
 
 	t.Run("successful deletion", func(t *testing.T) {
 		rr := httptest.NewRecorder()
@@ -322,23 +319,22 @@ func TestDeletePerson(t *testing.T) {
 		req, err := http.NewRequestWithContext(ctx, http.MethodDelete, "/person", nil)
 		assert.NoError(t, err)
 
-		db.TestDB.CreateOrEditPerson(person)
-		fetchedPerson := db.TestDB.GetPerson(person.ID)
+		database.CreateOrEditPerson(person)
+		fetchedPerson := database.GetPerson(person.ID)
 		assert.Equal(t, person, fetchedPerson)
 
 		handler.ServeHTTP(rr, req)
 
 		assert.Equal(t, http.StatusOK, rr.Code)
 
-		deletedPerson := db.TestDB.GetPerson(person.ID)
+		deletedPerson := database.GetPerson(person.ID)
 		assert.Empty(t, deletedPerson)
 	})
 }
 
 func TestGetPeopleBySearch(t *testing.T) {
-	teardownSuite := SetupSuite(t)
-	defer teardownSuite(t)
-	pHandler := NewPeopleHandler(db.TestDB)
+	database := dbtest.New(t)
+	pHandler := NewPeopleHandler(database)
 
 	person := db.Person{
 		ID:           102,
@@ -364,8 +360,8 @@ func TestGetPeopleBySearch(t *testing.T) {
 		Extras:       db.PropertyMap{},
 		GithubIssues: db.PropertyMap{},
 	}
-	db.TestDB.CreateOrEditPerson(person)
-	db.TestDB.CreateOrEditPerson(person2)
+	database.CreateOrEditPerson(person)
+	database.CreateOrEditPerson(person2)
 
 	t.Run("should return users that match the search text", func(t *testing.T) {
 		rr := httptest.NewRecorder()
@@ -375,8 +371,8 @@ func TestGetPeopleBySearch(t *testing.T) {
 		req, err := http.NewRequestWithContext(context.WithValue(context.Background(), chi.RouteCtxKey, rctx), http.MethodGet, "/search?search="+person.OwnerAlias, nil)
 		assert.NoError(t, err)
 
-		fetchedPerson := db.TestDB.GetPerson(person.ID)
-		fetchedPerson2 := db.TestDB.GetPerson(person2.ID)
+		fetchedPerson := database.GetPerson(person.ID)
+		fetchedPerson2 := database.GetPerson(person2.ID)
 
 		expectedPeople := []db.Person{
 			fetchedPerson,
@@ -413,12 +409,9 @@ func TestGetPeopleBySearch(t *testing.T) {
 }
 
 func TestGetListedPeople(t *testing.T) {
-	teardownSuite := SetupSuite(t)
-	defer teardownSuite(t)
+	database := dbtest.New(t)
 
-	pHandler := NewPeopleHandler(db.TestDB)
-
-	db.CleanDB()
+	pHandler := NewPeopleHandler(database)
 
 	person := db.Person{
 		ID:           101,
@@ -445,6 +438,8 @@ func TestGetListedPeople(t *testing.T) {
 		Tags:         pq.StringArray{},
 		GithubIssues: db.PropertyMap{},
 		Extras:       db.PropertyMap{"coding_languages": "Golang"},
+		Timezone:     "Europe/Berlin",
+		Region:       "EU",
 	}
 	person3 := db.Person{
 		ID:           103,
@@ -458,14 +453,16 @@ func TestGetListedPeople(t *testing.T) {
 		Tags:         pq.StringArray{},
 		GithubIssues: db.PropertyMap{},
 		Extras:       db.PropertyMap{"coding_languages": "Lightning"},
+		Timezone:     "Asia/Tokyo",
+		Region:       "AS",
 	}
 
-	db.TestDB.CreateOrEditPerson(person)
-	db.TestDB.CreateOrEditPerson(person2)
-	db.TestDB.CreateOrEditPerson(person3)
+	database.CreateOrEditPerson(person)
+	database.CreateOrEditPerson(person2)
+	database.CreateOrEditPerson(person3)
 
-	fetchedPerson := db.TestDB.GetPerson(person2.ID)
-	fetchedPerson2 := db.TestDB.GetPerson(person3.ID)
+	fetchedPerson := database.GetPerson(person2.ID)
+	fetchedPerson2 := database.GetPerson(person3.ID)
 
 	t.Run("should return all listed users", func(t *testing.T) {
 		rr := httptest.NewRecorder()
@@ -540,13 +537,37 @@ func TestGetListedPeople(t *testing.T) {
 		assert.EqualValues(t, expectedPeople, returnedPeople)
 	})
 
+	t.Run("should return only users in a region or with overlapping working hours", func(t *testing.T) {
+		for _, query := range []string{"region=EU", "tz_overlap=6&tz=Europe/Paris"} {
+			rr := httptest.NewRecorder()
+			req, err := http.NewRequest(http.MethodGet, "/?page=1&limit=10&"+query, nil)
+			assert.NoError(t, err)
+
+			http.HandlerFunc(pHandler.GetListedPeople).ServeHTTP(rr, req)
+
+			var returnedPeople []db.Person
+			err = json.Unmarshal(rr.Body.Bytes(), &returnedPeople)
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, rr.Code)
+			assert.EqualValues(t, []db.Person{fetchedPerson}, returnedPeople, query)
+		}
+	})
+
+	t.Run("should reject an unknown region", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodGet, "/?region=MARS", nil)
+		assert.NoError(t, err)
+
+		http.HandlerFunc(pHandler.GetListedPeople).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
 }
 
 func TestGetPersonByUuid(t *testing.T) {
-	teardownSuite := SetupSuite(t)
-	defer teardownSuite(t)
+	database := dbtest.New(t)
 
-	pHandler := NewPeopleHandler(db.TestDB)
+	pHandler := NewPeopleHandler(database)
 
 	t.Run("should return a user with the right UUID", func(t *testing.T) {
 		rr := httptest.NewRecorder()
@@ -563,8 +584,8 @@ func TestGetPersonByUuid(t *testing.T) {
 			Extras:       db.PropertyMap{},
 			GithubIssues: db.PropertyMap{},
 		}
-		db.TestDB.CreateOrEditPerson(person)
-		fetchedPerson := db.TestDB.GetPerson(person.ID)
+		database.CreateOrEditPerson(person)
+		fetchedPerson := database.GetPerson(person.ID)
 
 		rctx := chi.NewRouteContext()
 		rctx.URLParams.Add("uuid", person.Uuid)
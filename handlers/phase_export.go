@@ -0,0 +1,204 @@
+package handlers
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/httpio"
+	"github.com/stakwork/sphinx-tribes/utils"
+)
+
+const (
+	exportLinkDays    = 7
+	exportLinkMaxDays = 30
+)
+
+var exportFileName = regexp.MustCompile(`[^A-Za-z0-9]+`)
+
+// exportToken signs a phase export link, anyone holding it can read the
+// export until it expires
+func exportToken(featureUuid string, phaseUuid string, expires int64) string {
+	mac := hmac.New(sha256.New, []byte(config.JwtKey))
+	fmt.Fprintf(mac, "%s:%s:%d", featureUuid, phaseUuid, expires)
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func validExportToken(featureUuid string, phaseUuid string, expires int64, token string) bool {
+	if expires < time.Now().Unix() {
+		return false
+	}
+	return hmac.Equal([]byte(token), []byte(exportToken(featureUuid, phaseUuid, expires)))
+}
+
+// ExportPhase renders the plan of a phase as markdown or, with
+// ?format=pdf, as a PDF. Workspace members call it signed in, the shared
+// route needs the token of an export link instead
+func (oh *featureHandler) ExportPhase(w http.ResponseWriter, r *http.Request) {
+	featureUuid := chi.URLParam(r, "feature_uuid")
+	phaseUuid := chi.URLParam(r, "phase_uuid")
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "md"
+	}
+	if format != "md" && format != "pdf" {
+		httpio.WriteError(w, r, http.StatusBadRequest, "format is md or pdf")
+		return
+	}
+
+	feature := oh.db.GetFeatureByUuid(featureUuid)
+	phase, err := oh.db.GetFeaturePhaseByUuid(featureUuid, phaseUuid)
+	if feature.Uuid == "" || err != nil {
+		httpio.WriteError(w, r, http.StatusNotFound, "Phase not found")
+		return
+	}
+
+	pubKeyFromAuth, _ := r.Context().Value(auth.ContextKey).(string)
+	if pubKeyFromAuth != "" {
+		if !isWorkspaceMember(oh.db, pubKeyFromAuth, feature.WorkspaceUuid) {
+			httpio.WriteError(w, r, http.StatusUnauthorized, "Don't have access to this workspace")
+			return
+		}
+	} else {
+		expires, _ := strconv.ParseInt(r.URL.Query().Get("expires"), 10, 64)
+		if !validExportToken(featureUuid, phaseUuid, expires, r.URL.Query().Get("token")) {
+			httpio.WriteError(w, r, http.StatusUnauthorized, "Invalid or expired export link")
+			return
+		}
+	}
+
+	stories, _ := oh.db.GetFeatureStoriesByFeatureUuid(featureUuid)
+	bounties := oh.db.GetBountiesByPhaseUuid(phaseUuid)
+	assignees := map[string]string{}
+	for _, bounty := range bounties {
+		if _, ok := assignees[bounty.Assignee]; bounty.Assignee != "" && !ok {
+			assignees[bounty.Assignee] = oh.db.GetPersonByPubkey(bounty.Assignee).OwnerAlias
+		}
+	}
+	markdown := phaseMarkdown(feature, phase, stories, bounties, assignees, time.Now())
+
+	name := strings.Trim(exportFileName.ReplaceAllString(strings.ToLower(feature.Name+"-"+phase.Name), "-"), "-")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.%s"`, name, format))
+	if format == "pdf" {
+		w.Header().Set("Content-Type", "application/pdf")
+		w.WriteHeader(http.StatusOK)
+		w.Write(utils.MarkdownPDF(markdown))
+		return
+	}
+	w.Header().Set("Content-Type", "text/markdown; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(markdown))
+}
+
+// CreatePhaseExportLink returns a signed link to the export of a phase for
+// people without an account, valid 7 days or ?days= up to 30
+func (oh *featureHandler) CreatePhaseExportLink(w http.ResponseWriter, r *http.Request) {
+	pubKeyFromAuth, _ := r.Context().Value(auth.ContextKey).(string)
+	featureUuid := chi.URLParam(r, "feature_uuid")
+	phaseUuid := chi.URLParam(r, "phase_uuid")
+
+	feature := oh.db.GetFeatureByUuid(featureUuid)
+	if _, err := oh.db.GetFeaturePhaseByUuid(featureUuid, phaseUuid); feature.Uuid == "" || err != nil {
+		httpio.WriteError(w, r, http.StatusNotFound, "Phase not found")
+		return
+	}
+	if pubKeyFromAuth == "" || !isWorkspaceMember(oh.db, pubKeyFromAuth, feature.WorkspaceUuid) {
+		httpio.WriteError(w, r, http.StatusUnauthorized, "Don't have access to this workspace")
+		return
+	}
+
+	days := exportLinkDays
+	if value := r.URL.Query().Get("days"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 || parsed > exportLinkMaxDays {
+			httpio.WriteError(w, r, http.StatusBadRequest, fmt.Sprintf("days is between 1 and %d", exportLinkMaxDays))
+			return
+		}
+		days = parsed
+	}
+
+	expires := time.Now().AddDate(0, 0, days).Unix()
+	url := fmt.Sprintf("%s/features/%s/phase/%s/export/shared?expires=%d&token=%s", config.Host, featureUuid, phaseUuid, expires, exportToken(featureUuid, phaseUuid, expires))
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"url":     url,
+		"expires": expires,
+	})
+}
+
+// phaseMarkdown assembles the brief of the feature and the bounties of the
+// phase in their planned order
+func phaseMarkdown(feature db.WorkspaceFeatures, phase db.FeaturePhase, stories []db.FeatureStory, bounties []db.NewBounty, assignees map[string]string, now time.Time) string {
+	sort.SliceStable(bounties, func(i, j int) bool { return bounties[i].PhasePriority < bounties[j].PhasePriority })
+	sort.SliceStable(stories, func(i, j int) bool { return stories[i].Priority < stories[j].Priority })
+
+	md := &strings.Builder{}
+	fmt.Fprintf(md, "# %s: %s\n\n", feature.Name, phase.Name)
+	fmt.Fprintf(md, "Exported on %s\n", now.Format("January 2, 2006"))
+
+	sections := []struct{ title, text string }{
+		{"Brief", feature.Brief},
+		{"Requirements", feature.Requirements},
+		{"Architecture", feature.Architecture},
+	}
+	for _, section := range sections {
+		if strings.TrimSpace(section.text) != "" {
+			fmt.Fprintf(md, "\n## %s\n\n%s\n", section.title, strings.TrimSpace(section.text))
+		}
+	}
+
+	if len(stories) > 0 {
+		md.WriteString("\n## User Stories\n\n")
+		for _, story := range stories {
+			fmt.Fprintf(md, "- %s\n", story.Description)
+		}
+	}
+
+	md.WriteString("\n## Tickets\n")
+	if len(bounties) == 0 {
+		md.WriteString("\nNo tickets planned yet.\n")
+	}
+	var total uint
+	for i, bounty := range bounties {
+		total += bounty.Price
+		fmt.Fprintf(md, "\n### %d. %s\n\n", i+1, bounty.Title)
+		fmt.Fprintf(md, "- Status: %s\n", bountyOutcome(bounty))
+		if bounty.Price > 0 {
+			fmt.Fprintf(md, "- Price: %d sats\n", bounty.Price)
+		}
+		if bounty.EstimatedSessionLength != "" {
+			fmt.Fprintf(md, "- Estimate: %s\n", bounty.EstimatedSessionLength)
+		}
+		if bounty.EstimatedCompletionDate != "" {
+			fmt.Fprintf(md, "- Due: %s\n", bounty.EstimatedCompletionDate)
+		}
+		if alias := assignees[bounty.Assignee]; alias != "" {
+			fmt.Fprintf(md, "- Assignee: %s\n", alias)
+		}
+		fmt.Fprintf(md, "- Bounty: https://community.sphinx.chat/bounty/%d\n", bounty.ID)
+		if description := strings.TrimSpace(bounty.Description); description != "" {
+			fmt.Fprintf(md, "\n%s\n", description)
+		}
+		if deliverables := strings.TrimSpace(bounty.Deliverables); deliverables != "" {
+			fmt.Fprintf(md, "\nAcceptance criteria:\n\n%s\n", deliverables)
+		}
+	}
+	if total > 0 {
+		fmt.Fprintf(md, "\nTotal: %d sats over %d tickets\n", total, len(bounties))
+	}
+	return md.String()
+}
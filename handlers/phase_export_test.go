@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportPhase(t *testing.T) {
+	feature := db.WorkspaceFeatures{Uuid: "feature-uuid", WorkspaceUuid: "workspace-uuid", Name: "Payments", Brief: "Pay hunters faster"}
+	phase := db.FeaturePhase{Uuid: "phase-uuid", FeatureUuid: "feature-uuid", Name: "MVP"}
+	bounties := []db.NewBounty{
+		{ID: 8, Title: "Send the invoice", Price: 2000, PhasePriority: 2},
+		{ID: 7, Title: "Add the wallet", Price: 1000, PhasePriority: 1, Assignee: "hunter-pubkey", Deliverables: "Tests pass", EstimatedSessionLength: "2 hours"},
+	}
+	newRequest := func(pubkey string, query string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("feature_uuid", feature.Uuid)
+		rctx.URLParams.Add("phase_uuid", phase.Uuid)
+		ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+		if pubkey != "" {
+			ctx = context.WithValue(ctx, auth.ContextKey, pubkey)
+		}
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "/features/feature-uuid/phase/phase-uuid/export?"+query, nil)
+		return req
+	}
+	mockPhase := func(mockDb *dbMocks.Database) {
+		mockDb.On("GetFeatureByUuid", feature.Uuid).Return(feature)
+		mockDb.On("GetFeaturePhaseByUuid", feature.Uuid, phase.Uuid).Return(phase, nil)
+		mockDb.On("GetFeatureStoriesByFeatureUuid", feature.Uuid).Return([]db.FeatureStory{{Description: "As a hunter I get paid"}}, nil)
+		mockDb.On("GetBountiesByPhaseUuid", phase.Uuid).Return(bounties)
+		mockDb.On("GetPersonByPubkey", "hunter-pubkey").Return(db.Person{OwnerAlias: "Hunter"})
+	}
+
+	t.Run("should export the tickets of the phase in order as markdown", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		fHandler := NewFeatureHandler(mockDb)
+		mockPhase(mockDb)
+		mockDb.On("GetWorkspaceByUuid", feature.WorkspaceUuid).Return(db.Workspace{Uuid: feature.WorkspaceUuid, OwnerPubKey: "owner-pubkey"})
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(fHandler.ExportPhase).ServeHTTP(rr, newRequest("owner-pubkey", ""))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, `attachment; filename="payments-mvp.md"`, rr.Header().Get("Content-Disposition"))
+		body := rr.Body.String()
+		assert.Contains(t, body, "# Payments: MVP")
+		assert.Contains(t, body, "- As a hunter I get paid")
+		assert.Regexp(t, `(?s)### 1\. Add the wallet.*- Estimate: 2 hours.*- Assignee: Hunter.*Acceptance criteria:\n\nTests pass.*### 2\. Send the invoice`, body)
+		assert.Contains(t, body, "Total: 3000 sats over 2 tickets")
+	})
+
+	t.Run("should export a pdf from a signed link", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		fHandler := NewFeatureHandler(mockDb)
+		mockPhase(mockDb)
+		expires := time.Now().Add(time.Hour).Unix()
+		token := exportToken(feature.Uuid, phase.Uuid, expires)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(fHandler.ExportPhase).ServeHTTP(rr, newRequest("", "format=pdf&expires="+strconv.FormatInt(expires, 10)+"&token="+token))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "application/pdf", rr.Header().Get("Content-Type"))
+		assert.Contains(t, rr.Body.String(), "%PDF-1.4")
+	})
+
+	t.Run("should refuse an expired or forged link", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		fHandler := NewFeatureHandler(mockDb)
+		mockPhase(mockDb)
+		expired := time.Now().Add(-time.Hour).Unix()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(fHandler.ExportPhase).ServeHTTP(rr, newRequest("", "expires="+strconv.FormatInt(expired, 10)+"&token="+exportToken(feature.Uuid, phase.Uuid, expired)))
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+
+		rr = httptest.NewRecorder()
+		http.HandlerFunc(fHandler.ExportPhase).ServeHTTP(rr, newRequest("", "expires=9999999999&token=forged"))
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("should refuse an unknown format", func(t *testing.T) {
+		fHandler := NewFeatureHandler(&dbMocks.Database{})
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(fHandler.ExportPhase).ServeHTTP(rr, newRequest("owner-pubkey", "format=docx"))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
@@ -0,0 +1,168 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/httpio"
+)
+
+// phaseGate is whether bounties can be opened in a phase. A phase that
+// depends on a deleted phase is open
+func phaseGate(database db.Database, phase db.FeaturePhase) db.PhaseGate {
+	gate := db.PhaseGate{PhaseUuid: phase.Uuid, DependsOn: phase.DependsOn, Overridden: phase.GateOverridden != nil}
+	if phase.DependsOn == "" {
+		return gate
+	}
+	if _, err := database.GetPhaseByUuid(phase.DependsOn); err != nil {
+		return gate
+	}
+	gate.Bounties, gate.CompletedBounties = database.GetPhaseCompletion(phase.DependsOn)
+	complete := gate.Bounties > 0 && gate.CompletedBounties == gate.Bounties
+	gate.Locked = !complete && !gate.Overridden
+	return gate
+}
+
+// withinPhaseGate writes a 409 and returns false when the phase is locked
+// until the phase it depends on is complete
+func withinPhaseGate(w http.ResponseWriter, r *http.Request, database db.Database, phase db.FeaturePhase) bool {
+	gate := phaseGate(database, phase)
+	if !gate.Locked {
+		return true
+	}
+	httpio.WriteErrorDetails(w, r, http.StatusConflict, "The phase is locked until the phase it depends on is complete", map[string]interface{}{
+		"phase_uuid":         gate.PhaseUuid,
+		"depends_on":         gate.DependsOn,
+		"bounties":           gate.Bounties,
+		"completed_bounties": gate.CompletedBounties,
+	})
+	return false
+}
+
+// routePhase is the phase of the route, it writes a 404 when the phase is
+// not one of the feature of the route
+func (oh *featureHandler) routePhase(w http.ResponseWriter, r *http.Request) (db.FeaturePhase, db.WorkspaceFeatures, bool) {
+	phase, err := oh.db.GetFeaturePhaseByUuid(chi.URLParam(r, "feature_uuid"), chi.URLParam(r, "phase_uuid"))
+	if err != nil {
+		httpio.WriteError(w, r, http.StatusNotFound, "Phase not found")
+		return phase, db.WorkspaceFeatures{}, false
+	}
+	feature := oh.db.GetFeatureByUuid(phase.FeatureUuid)
+	if feature.Uuid == "" {
+		httpio.WriteError(w, r, http.StatusNotFound, "Feature not found")
+		return phase, feature, false
+	}
+	return phase, feature, true
+}
+
+// GetPhaseGate returns whether bounties can be opened in the phase of the
+// route
+func (oh *featureHandler) GetPhaseGate(w http.ResponseWriter, r *http.Request) {
+	phase, _, ok := oh.routePhase(w, r)
+	if !ok {
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(phaseGate(oh.db, phase))
+}
+
+// SetPhaseDependency sets the phase of the same feature that has to be
+// complete before bounties are opened in the phase of the route, for the
+// bounty managers of the workspace. An empty depends_on removes the gate
+func (oh *featureHandler) SetPhaseDependency(w http.ResponseWriter, r *http.Request) {
+	pubKeyFromAuth, _ := r.Context().Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[features] no pubkey from auth")
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
+		return
+	}
+
+	phase, feature, ok := oh.routePhase(w, r)
+	if !ok {
+		return
+	}
+	if !oh.db.UserHasManageBountyRoles(pubKeyFromAuth, feature.WorkspaceUuid) {
+		httpio.WriteError(w, r, http.StatusUnauthorized, "Don't have access to set the phase dependency")
+		return
+	}
+
+	request := db.PhaseDependencyRequest{}
+	body, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err := json.Unmarshal(body, &request); err != nil {
+		fmt.Println("[features]", err)
+		httpio.WriteError(w, r, http.StatusNotAcceptable, "")
+		return
+	}
+
+	// the phases it depends on, directly or not, can't depend on it
+	seen := map[string]bool{}
+	for uuid := request.DependsOn; uuid != "" && !seen[uuid]; {
+		if uuid == phase.Uuid {
+			httpio.WriteError(w, r, http.StatusBadRequest, "A phase can't depend on itself")
+			return
+		}
+		seen[uuid] = true
+		dependency, err := oh.db.GetFeaturePhaseByUuid(phase.FeatureUuid, uuid)
+		if err != nil {
+			httpio.WriteError(w, r, http.StatusBadRequest, "The phase it depends on is not a phase of the feature")
+			return
+		}
+		uuid = dependency.DependsOn
+	}
+
+	updated, err := oh.db.SetPhaseDependency(phase.Uuid, request.DependsOn, pubKeyFromAuth)
+	if err != nil {
+		fmt.Println("[features]", err)
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to set the phase dependency")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(updated)
+}
+
+// OverridePhaseGate lets the workspace admin open the phase of the route
+// before the phase it depends on is complete, or lock it again
+func (oh *featureHandler) OverridePhaseGate(w http.ResponseWriter, r *http.Request) {
+	pubKeyFromAuth, _ := r.Context().Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[features] no pubkey from auth")
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
+		return
+	}
+
+	phase, feature, ok := oh.routePhase(w, r)
+	if !ok {
+		return
+	}
+	if pubKeyFromAuth != oh.db.GetWorkspaceByUuid(feature.WorkspaceUuid).OwnerPubKey {
+		httpio.WriteError(w, r, http.StatusUnauthorized, "only workspace admin can override a phase gate")
+		return
+	}
+
+	request := db.PhaseGateOverrideRequest{}
+	body, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err := json.Unmarshal(body, &request); err != nil {
+		fmt.Println("[features]", err)
+		httpio.WriteError(w, r, http.StatusNotAcceptable, "")
+		return
+	}
+
+	updated, err := oh.db.SetPhaseGateOverride(phase.Uuid, request.Override, pubKeyFromAuth)
+	if err != nil {
+		fmt.Println("[features]", err)
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to override the phase gate")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(phaseGate(oh.db, updated))
+}
@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPhaseGates(t *testing.T) {
+	feature := db.WorkspaceFeatures{Uuid: "feature-uuid", WorkspaceUuid: "workspace-uuid"}
+	design := db.FeaturePhase{Uuid: "design-phase", FeatureUuid: feature.Uuid}
+	build := db.FeaturePhase{Uuid: "build-phase", FeatureUuid: feature.Uuid, DependsOn: design.Uuid}
+	newRequest := func(method string, pubkey string, phaseUuid string, body string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("feature_uuid", feature.Uuid)
+		rctx.URLParams.Add("phase_uuid", phaseUuid)
+		ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+		ctx = context.WithValue(ctx, auth.ContextKey, pubkey)
+		req, _ := http.NewRequestWithContext(ctx, method, "/features/feature-uuid/phase/"+phaseUuid+"/gate", bytes.NewBufferString(body))
+		return req
+	}
+
+	t.Run("should lock a phase until the phase it depends on is complete", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+
+		mockDb.On("GetPhaseByUuid", design.Uuid).Return(design, nil)
+		mockDb.On("GetPhaseCompletion", design.Uuid).Return(int64(3), int64(2)).Once()
+		assert.True(t, phaseGate(mockDb, build).Locked)
+
+		mockDb.On("GetPhaseCompletion", design.Uuid).Return(int64(0), int64(0)).Once()
+		assert.True(t, phaseGate(mockDb, build).Locked)
+
+		mockDb.On("GetPhaseCompletion", design.Uuid).Return(int64(3), int64(3)).Once()
+		assert.False(t, phaseGate(mockDb, build).Locked)
+
+		overridden := build
+		now := time.Now()
+		overridden.GateOverridden = &now
+		mockDb.On("GetPhaseCompletion", design.Uuid).Return(int64(3), int64(0)).Once()
+		gate := phaseGate(mockDb, overridden)
+		assert.False(t, gate.Locked)
+		assert.True(t, gate.Overridden)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should not open a bounty in a locked phase", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		bHandler := NewBountyHandler(http.DefaultClient, mockDb)
+
+		mockDb.On("GetPhaseByUuid", build.Uuid).Return(build, nil).Once()
+		mockDb.On("GetFeatureByUuid", feature.Uuid).Return(feature).Once()
+		mockDb.On("GetPhaseByUuid", design.Uuid).Return(design, nil).Once()
+		mockDb.On("GetPhaseCompletion", design.Uuid).Return(int64(2), int64(1)).Once()
+
+		body := []byte(`{"type": "coding", "title": "bounty", "description": "description", "price": 1000, "phase_uuid": "build-phase"}`)
+		rr := httptest.NewRecorder()
+		ctx := context.WithValue(context.Background(), auth.ContextKey, "owner-pubkey")
+		req, _ := http.NewRequestWithContext(ctx, http.MethodPost, "/", bytes.NewReader(body))
+		http.HandlerFunc(bHandler.CreateOrEditBounty).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusConflict, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"depends_on":"design-phase"`)
+		mockDb.AssertNotCalled(t, "WithTx", mock.Anything)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should set the phase a phase depends on", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		fHandler := NewFeatureHandler(mockDb)
+		open := build
+		open.DependsOn = ""
+
+		mockDb.On("GetFeaturePhaseByUuid", feature.Uuid, build.Uuid).Return(open, nil).Once()
+		mockDb.On("GetFeatureByUuid", feature.Uuid).Return(feature).Once()
+		mockDb.On("UserHasManageBountyRoles", "manager-pubkey", feature.WorkspaceUuid).Return(true).Once()
+		mockDb.On("GetFeaturePhaseByUuid", feature.Uuid, design.Uuid).Return(design, nil).Once()
+		mockDb.On("SetPhaseDependency", build.Uuid, design.Uuid, "manager-pubkey").Return(build, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(fHandler.SetPhaseDependency).ServeHTTP(rr, newRequest(http.MethodPut, "manager-pubkey", build.Uuid, `{"depends_on": "design-phase"}`))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var phase db.FeaturePhase
+		json.Unmarshal(rr.Body.Bytes(), &phase)
+		assert.Equal(t, design.Uuid, phase.DependsOn)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should refuse a dependency cycle", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		fHandler := NewFeatureHandler(mockDb)
+
+		mockDb.On("GetFeaturePhaseByUuid", feature.Uuid, design.Uuid).Return(design, nil).Once()
+		mockDb.On("GetFeatureByUuid", feature.Uuid).Return(feature).Once()
+		mockDb.On("UserHasManageBountyRoles", "manager-pubkey", feature.WorkspaceUuid).Return(true).Once()
+		mockDb.On("GetFeaturePhaseByUuid", feature.Uuid, build.Uuid).Return(build, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(fHandler.SetPhaseDependency).ServeHTTP(rr, newRequest(http.MethodPut, "manager-pubkey", design.Uuid, `{"depends_on": "build-phase"}`))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockDb.AssertNotCalled(t, "SetPhaseDependency", mock.Anything, mock.Anything, mock.Anything)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should let only the workspace admin override a gate", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		fHandler := NewFeatureHandler(mockDb)
+
+		mockDb.On("GetFeaturePhaseByUuid", feature.Uuid, build.Uuid).Return(build, nil).Once()
+		mockDb.On("GetFeatureByUuid", feature.Uuid).Return(feature).Once()
+		mockDb.On("GetWorkspaceByUuid", feature.WorkspaceUuid).Return(db.Workspace{Uuid: feature.WorkspaceUuid, OwnerPubKey: "owner-pubkey"}).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(fHandler.OverridePhaseGate).ServeHTTP(rr, newRequest(http.MethodPut, "manager-pubkey", build.Uuid, `{"override": true}`))
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		mockDb.AssertNotCalled(t, "SetPhaseGateOverride", mock.Anything, mock.Anything, mock.Anything)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should open a locked phase on override", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		fHandler := NewFeatureHandler(mockDb)
+		overridden := build
+		now := time.Now()
+		overridden.GateOverridden = &now
+		overridden.GateOverriddenBy = "owner-pubkey"
+
+		mockDb.On("GetFeaturePhaseByUuid", feature.Uuid, build.Uuid).Return(build, nil).Once()
+		mockDb.On("GetFeatureByUuid", feature.Uuid).Return(feature).Once()
+		mockDb.On("GetWorkspaceByUuid", feature.WorkspaceUuid).Return(db.Workspace{Uuid: feature.WorkspaceUuid, OwnerPubKey: "owner-pubkey"}).Once()
+		mockDb.On("SetPhaseGateOverride", build.Uuid, true, "owner-pubkey").Return(overridden, nil).Once()
+		mockDb.On("GetPhaseByUuid", design.Uuid).Return(design, nil).Once()
+		mockDb.On("GetPhaseCompletion", design.Uuid).Return(int64(2), int64(0)).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(fHandler.OverridePhaseGate).ServeHTTP(rr, newRequest(http.MethodPut, "owner-pubkey", build.Uuid, `{"override": true}`))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var gate db.PhaseGate
+		json.Unmarshal(rr.Body.Bytes(), &gate)
+		assert.False(t, gate.Locked)
+		assert.True(t, gate.Overridden)
+		mockDb.AssertExpectations(t)
+	})
+}
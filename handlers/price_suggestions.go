@@ -0,0 +1,42 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/httpio"
+)
+
+// GetBountyPriceSuggestion suggests a price range for a bounty from the paid
+// bounties with its tags and estimate, in the workspace of workspace_uuid
+// and across all workspaces. The ranges are computed every night
+func (h *bountyHandler) GetBountyPriceSuggestion(w http.ResponseWriter, r *http.Request) {
+	keys := r.URL.Query()
+
+	tags := []string{}
+	if value := keys.Get("tags"); value != "" {
+		tags = strings.Split(value, ",")
+	}
+
+	hoursBucket := 0
+	if value := keys.Get("estimate_hours"); value != "" {
+		hours, err := strconv.ParseFloat(value, 64)
+		if err != nil || hours <= 0 {
+			httpio.WriteError(w, r, http.StatusBadRequest, "estimate_hours is a number of hours above 0")
+			return
+		}
+		hoursBucket = db.PriceHoursBucket(hours)
+	}
+
+	suggestions, err := h.db.GetBountyPriceSuggestions(keys.Get("workspace_uuid"), tags, hoursBucket)
+	if err != nil {
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to get the price suggestions")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(suggestions)
+}
@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/handlers/mocks"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetBountyPriceSuggestion(t *testing.T) {
+	serve := func(mockDb *dbMocks.Database, query string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/gobounties/price_suggestion?"+query, nil)
+		http.HandlerFunc(NewBountyHandler(&mocks.HttpClient{}, mockDb).GetBountyPriceSuggestion).ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("should suggest a price from the workspace and all workspaces", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		suggestions := db.BountyPriceSuggestions{
+			Tags:        []string{"go", "rust"},
+			HoursBucket: 8,
+			Workspace:   &db.BountyPriceSuggestion{WorkspaceUuid: "workspace-a", Tag: "go", HoursBucket: 8, Samples: 4, Low: 1000, Median: 2000, High: 3000},
+			Global:      &db.BountyPriceSuggestion{Tag: "go", HoursBucket: 8, Samples: 30, Low: 1500, Median: 2500, High: 5000},
+		}
+		mockDb.On("GetBountyPriceSuggestions", "workspace-a", []string{"go", "rust"}, 8).Return(suggestions, nil).Once()
+
+		rr := serve(mockDb, "tags=go,rust&estimate_hours=4&workspace_uuid=workspace-a")
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		res := db.BountyPriceSuggestions{}
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &res))
+		assert.Equal(t, uint(2000), res.Workspace.Median)
+		assert.Equal(t, uint(2500), res.Global.Median)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should suggest from any bounty without tags or estimate", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mockDb.On("GetBountyPriceSuggestions", "", []string{}, 0).Return(db.BountyPriceSuggestions{Tags: []string{}}, nil).Once()
+
+		rr := serve(mockDb, "")
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, `{"tags": [], "hours_bucket": 0, "workspace": null, "global": null}`, rr.Body.String())
+	})
+
+	t.Run("should refuse an invalid estimate", func(t *testing.T) {
+		for _, query := range []string{"estimate_hours=soon", "estimate_hours=0", "estimate_hours=-2"} {
+			mockDb := &dbMocks.Database{}
+
+			rr := serve(mockDb, query)
+
+			assert.Equal(t, http.StatusBadRequest, rr.Code, query)
+			mockDb.AssertNotCalled(t, "GetBountyPriceSuggestions")
+		}
+	})
+
+	t.Run("should fail when the suggestions can't be read", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mockDb.On("GetBountyPriceSuggestions", "", []string{"go"}, 0).Return(db.BountyPriceSuggestions{}, errors.New("boom")).Once()
+
+		rr := serve(mockDb, "tags=go")
+
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	})
+}
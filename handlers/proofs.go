@@ -0,0 +1,340 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/rs/xid"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/events"
+	"github.com/stakwork/sphinx-tribes/httpio"
+	"github.com/stakwork/sphinx-tribes/utils"
+	"github.com/stakwork/sphinx-tribes/websocket"
+)
+
+type proofHandler struct {
+	db db.Database
+}
+
+func NewProofHandler(database db.Database) *proofHandler {
+	return &proofHandler{db: database}
+}
+
+// proofReviewers are the reviewers of the proofs of a bounty: the default
+// reviewers of the feature of its phase, else those of its workspace, else
+// the workspace admin. The assignee doesn't review their own proof
+func proofReviewers(database db.Database, workspace db.Workspace, bounty db.NewBounty) []string {
+	reviewers := []string{}
+	if bounty.PhaseUuid != "" {
+		if phase, err := database.GetPhaseByUuid(bounty.PhaseUuid); err == nil {
+			reviewers = database.GetFeatureByUuid(phase.FeatureUuid).DefaultReviewers
+		}
+	}
+	if len(reviewers) == 0 {
+		reviewers = workspace.DefaultReviewers
+	}
+	if len(reviewers) == 0 && workspace.OwnerPubKey != "" {
+		reviewers = []string{workspace.OwnerPubKey}
+	}
+
+	assigned := []string{}
+	seen := map[string]bool{bounty.Assignee: true}
+	for _, reviewer := range reviewers {
+		if !seen[reviewer] {
+			seen[reviewer] = true
+			assigned = append(assigned, reviewer)
+		}
+	}
+	return assigned
+}
+
+// unknownReviewers lists the reviewers that have no profile
+func unknownReviewers(database db.Database, reviewers []string) []string {
+	unknown := []string{}
+	for _, reviewer := range reviewers {
+		if database.GetPersonByPubkey(reviewer).OwnerPubKey == "" {
+			unknown = append(unknown, reviewer)
+		}
+	}
+	return unknown
+}
+
+// requiredApprovals is how many reviewers have to approve a proof, never
+// more than it has reviewers
+func requiredApprovals(workspace db.Workspace, reviewers []string) int {
+	required := int(workspace.RequiredApprovals)
+	if required > len(reviewers) {
+		required = len(reviewers)
+	}
+	if required < 1 {
+		required = 1
+	}
+	return required
+}
+
+// unmetReviewConditions is the review condition of the definition of done
+// of a workspace that requires approvals, the latest proof of the bounty
+// has to be approved
+func unmetReviewConditions(database db.Database, workspace db.Workspace, bounty db.NewBounty) []db.UnmetCondition {
+	if workspace.RequiredApprovals == 0 {
+		return nil
+	}
+	proof, err := database.GetLatestBountyProof(bounty.ID)
+	if err != nil {
+		return []db.UnmetCondition{{Condition: DoneProofApproved, Message: "no proof was submitted for review"}}
+	}
+	if proof.Status != db.ProofApproved {
+		approvals := 0
+		for _, review := range proof.Reviews {
+			if review.Decision == db.ProofApproved {
+				approvals++
+			}
+		}
+		return []db.UnmetCondition{{
+			Condition: DoneProofApproved,
+			Message:   fmt.Sprintf("the proof has %d of the %d approvals it needs", approvals, proof.Required),
+		}}
+	}
+	return nil
+}
+
+// unmetPaymentConditions is the condition of a workspace that requires an
+// approved proof before a bounty is paid. Any of the proofs of the bounty
+// counts, a later one doesn't undo an approval
+func unmetPaymentConditions(database db.Database, workspace db.Workspace, bounty db.NewBounty) []db.UnmetCondition {
+	if !workspace.PaymentRequiresProof || database.HasApprovedBountyProof(bounty.ID) {
+		return nil
+	}
+	return []db.UnmetCondition{{Condition: DoneProofApproved, Message: "the bounty has no approved proof"}}
+}
+
+// bountyOf is the bounty of the route and the caller, it writes the error
+// when either is missing
+func (ph *proofHandler) bountyOf(w http.ResponseWriter, r *http.Request) (string, db.NewBounty, bool) {
+	pubKeyFromAuth, _ := r.Context().Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[proofs] no pubkey from auth")
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
+		return "", db.NewBounty{}, false
+	}
+
+	id, err := utils.ConvertStringToUint(chi.URLParam(r, "id"))
+	if err != nil {
+		httpio.WriteError(w, r, http.StatusBadRequest, "Invalid bounty id")
+		return "", db.NewBounty{}, false
+	}
+	bounty := ph.db.GetBounty(id)
+	if bounty.ID == 0 {
+		httpio.WriteError(w, r, http.StatusNotFound, "Bounty not found")
+		return "", bounty, false
+	}
+	return pubKeyFromAuth, bounty, true
+}
+
+// SubmitBountyProof lets the assignee of a bounty submit their work for
+// review. Its reviewers get a review assignment and a notification
+func (ph *proofHandler) SubmitBountyProof(w http.ResponseWriter, r *http.Request) {
+	pubKeyFromAuth, bounty, ok := ph.bountyOf(w, r)
+	if !ok {
+		return
+	}
+	if pubKeyFromAuth != bounty.Assignee {
+		httpio.WriteError(w, r, http.StatusUnauthorized, "only the assignee can submit a proof")
+		return
+	}
+	if bounty.Completed || bounty.Paid {
+		httpio.WriteError(w, r, http.StatusConflict, "The bounty is already completed")
+		return
+	}
+
+	request := db.BountyProofRequest{}
+	body, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err := json.Unmarshal(body, &request); err != nil {
+		fmt.Println("[proofs]", err)
+		httpio.WriteError(w, r, http.StatusNotAcceptable, "")
+		return
+	}
+	if !validatePayload(w, r, request) {
+		return
+	}
+
+	workspace := db.Workspace{}
+	if bounty.WorkspaceUuid != "" {
+		workspace = ph.db.GetWorkspaceByUuid(bounty.WorkspaceUuid)
+	}
+	reviewers := proofReviewers(ph.db, workspace, bounty)
+	proof, err := ph.db.CreateBountyProof(db.BountyProof{
+		Uuid:        xid.New().String(),
+		BountyID:    bounty.ID,
+		SubmittedBy: pubKeyFromAuth,
+		Description: strings.TrimSpace(request.Description),
+		Url:         request.Url,
+		Required:    requiredApprovals(workspace, reviewers),
+	}, reviewers)
+	if err != nil {
+		fmt.Println("[proofs]", err)
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to submit the proof")
+		return
+	}
+
+	events.Publish(r.Context(), events.ProofSubmitted, websocket.Topic(websocket.TopicBounty, bounty.ID), map[string]interface{}{
+		"id":             bounty.ID,
+		"title":          bounty.Title,
+		"workspace_uuid": bounty.WorkspaceUuid,
+		"proof_uuid":     proof.Uuid,
+		"member_pubkeys": reviewers,
+	})
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(proof)
+}
+
+// GetBountyProofs lists the proofs of a bounty with their reviews, for its
+// owner, its assignee, the members of its workspace and its reviewers
+func (ph *proofHandler) GetBountyProofs(w http.ResponseWriter, r *http.Request) {
+	pubKeyFromAuth, bounty, ok := ph.bountyOf(w, r)
+	if !ok {
+		return
+	}
+
+	proofs, err := ph.db.GetBountyProofs(bounty.ID)
+	if err != nil {
+		fmt.Println("[proofs]", err)
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to get the proofs")
+		return
+	}
+
+	allowed := pubKeyFromAuth == bounty.Assignee || pubKeyFromAuth == bounty.OwnerID
+	for _, proof := range proofs {
+		for _, review := range proof.Reviews {
+			allowed = allowed || review.Reviewer == pubKeyFromAuth
+		}
+	}
+	if !allowed && (bounty.WorkspaceUuid == "" || !isWorkspaceMember(ph.db, pubKeyFromAuth, bounty.WorkspaceUuid)) {
+		httpio.WriteError(w, r, http.StatusUnauthorized, "Don't have access to the proofs of this bounty")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(proofs)
+}
+
+// ReviewBountyProof records the decision of a reviewer assigned to the
+// proof of the route. The assignee is told of it, and the owner too once
+// the proof is approved
+func (ph *proofHandler) ReviewBountyProof(w http.ResponseWriter, r *http.Request) {
+	pubKeyFromAuth, bounty, ok := ph.bountyOf(w, r)
+	if !ok {
+		return
+	}
+
+	request := db.ProofReviewRequest{}
+	body, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err := json.Unmarshal(body, &request); err != nil {
+		fmt.Println("[proofs]", err)
+		httpio.WriteError(w, r, http.StatusNotAcceptable, "")
+		return
+	}
+	if !validatePayload(w, r, request) {
+		return
+	}
+
+	latest, err := ph.db.GetLatestBountyProof(bounty.ID)
+	if err != nil {
+		httpio.WriteError(w, r, http.StatusNotFound, "Proof not found")
+		return
+	}
+	if latest.Uuid != chi.URLParam(r, "proof_uuid") {
+		httpio.WriteError(w, r, http.StatusConflict, "Only the latest proof of the bounty can be reviewed")
+		return
+	}
+	if bounty.Completed || bounty.Paid {
+		httpio.WriteError(w, r, http.StatusConflict, "The bounty is already completed")
+		return
+	}
+
+	proof, err := ph.db.ReviewBountyProof(latest.Uuid, pubKeyFromAuth, request.Decision, strings.TrimSpace(request.Comment), time.Now())
+	if errors.Is(err, db.ErrNotReviewer) {
+		httpio.WriteError(w, r, http.StatusUnauthorized, "The proof is not assigned to you for review")
+		return
+	}
+	if err != nil {
+		fmt.Println("[proofs]", err)
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to review the proof")
+		return
+	}
+
+	payload := map[string]interface{}{
+		"id":             bounty.ID,
+		"title":          bounty.Title,
+		"workspace_uuid": bounty.WorkspaceUuid,
+		"proof_uuid":     proof.Uuid,
+		"decision":       request.Decision,
+		"status":         proof.Status,
+		"assignee":       proof.SubmittedBy,
+	}
+	if proof.Status == db.ProofApproved {
+		payload["owner_id"] = bounty.OwnerID
+	}
+	events.Publish(r.Context(), events.ProofReviewed, websocket.Topic(websocket.TopicBounty, bounty.ID), payload)
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(proof)
+}
+
+// SetFeatureReviewers sets who reviews the proofs of the bounties in the
+// phases of the feature of the route, for the bounty managers of its
+// workspace. No reviewers falls back to those of the workspace
+func (oh *featureHandler) SetFeatureReviewers(w http.ResponseWriter, r *http.Request) {
+	pubKeyFromAuth, _ := r.Context().Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[features] no pubkey from auth")
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
+		return
+	}
+
+	feature := oh.db.GetFeatureByUuid(chi.URLParam(r, "feature_uuid"))
+	if feature.Uuid == "" {
+		httpio.WriteError(w, r, http.StatusNotFound, "Feature not found")
+		return
+	}
+	if !oh.db.UserHasManageBountyRoles(pubKeyFromAuth, feature.WorkspaceUuid) {
+		httpio.WriteError(w, r, http.StatusUnauthorized, "Don't have access to set the reviewers")
+		return
+	}
+
+	request := db.FeatureReviewersRequest{}
+	body, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err := json.Unmarshal(body, &request); err != nil {
+		fmt.Println("[features]", err)
+		httpio.WriteError(w, r, http.StatusNotAcceptable, "")
+		return
+	}
+	if !validatePayload(w, r, request) {
+		return
+	}
+	if unknown := unknownReviewers(oh.db, request.Reviewers); len(unknown) > 0 {
+		httpio.WriteErrorDetails(w, r, http.StatusBadRequest, "Reviewers need a profile", map[string]interface{}{"unknown": unknown})
+		return
+	}
+
+	updated, err := oh.db.SetFeatureReviewers(feature.Uuid, request.Reviewers, pubKeyFromAuth)
+	if err != nil {
+		fmt.Println("[features]", err)
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to set the reviewers")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(updated)
+}
@@ -0,0 +1,162 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestProofReviews(t *testing.T) {
+	bounty := db.NewBounty{ID: 1, Created: 1700000000, Title: "Add dark mode", OwnerID: "owner-pubkey", Assignee: "hunter-pubkey",
+		WorkspaceUuid: "workspace-uuid", PhaseUuid: "phase-uuid"}
+	workspace := db.Workspace{Uuid: "workspace-uuid", OwnerPubKey: "owner-pubkey", DefaultReviewers: []string{"lead-pubkey"}, RequiredApprovals: 2}
+	newRequest := func(method string, pubkey string, proofUuid string, body string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", "1")
+		rctx.URLParams.Add("proof_uuid", proofUuid)
+		rctx.URLParams.Add("created", "1700000000")
+		ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+		ctx = context.WithValue(ctx, auth.ContextKey, pubkey)
+		req, _ := http.NewRequestWithContext(ctx, method, "/gobounties/1/proofs", bytes.NewBufferString(body))
+		return req
+	}
+
+	t.Run("should assign the reviewers of the feature to a proof", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		pHandler := NewProofHandler(mockDb)
+
+		mockDb.On("GetBounty", uint(1)).Return(bounty).Once()
+		mockDb.On("GetWorkspaceByUuid", "workspace-uuid").Return(workspace).Once()
+		mockDb.On("GetPhaseByUuid", "phase-uuid").Return(db.FeaturePhase{Uuid: "phase-uuid", FeatureUuid: "feature-uuid"}, nil).Once()
+		mockDb.On("GetFeatureByUuid", "feature-uuid").Return(db.WorkspaceFeatures{Uuid: "feature-uuid", DefaultReviewers: []string{"design-pubkey", "hunter-pubkey", "qa-pubkey"}}).Once()
+		mockDb.On("CreateBountyProof", mock.MatchedBy(func(p db.BountyProof) bool {
+			return p.BountyID == 1 && p.SubmittedBy == "hunter-pubkey" && p.Required == 2 && p.Url == "https://example.com/demo.mp4"
+		}), []string{"design-pubkey", "qa-pubkey"}).Return(func(p db.BountyProof, reviewers []string) (db.BountyProof, error) { return p, nil }).Once()
+
+		rr := httptest.NewRecorder()
+		body := `{"description": "Dark mode is behind the theme toggle", "url": "https://example.com/demo.mp4"}`
+		http.HandlerFunc(pHandler.SubmitBountyProof).ServeHTTP(rr, newRequest(http.MethodPost, "hunter-pubkey", "", body))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should fall back to the workspace admin without reviewers", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		noPhase := bounty
+		noPhase.PhaseUuid = ""
+
+		reviewers := proofReviewers(mockDb, db.Workspace{OwnerPubKey: "owner-pubkey", RequiredApprovals: 2}, noPhase)
+
+		assert.Equal(t, []string{"owner-pubkey"}, reviewers)
+		assert.Equal(t, 1, requiredApprovals(db.Workspace{RequiredApprovals: 2}, reviewers))
+	})
+
+	t.Run("should only take a proof from the assignee", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		pHandler := NewProofHandler(mockDb)
+
+		mockDb.On("GetBounty", uint(1)).Return(bounty).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(pHandler.SubmitBountyProof).ServeHTTP(rr, newRequest(http.MethodPost, "other-pubkey", "", `{"description": "done"}`))
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		mockDb.AssertNotCalled(t, "CreateBountyProof", mock.Anything, mock.Anything)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should refuse a review from someone not assigned", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		pHandler := NewProofHandler(mockDb)
+
+		mockDb.On("GetBounty", uint(1)).Return(bounty).Once()
+		mockDb.On("GetLatestBountyProof", uint(1)).Return(db.BountyProof{Uuid: "proof-uuid", BountyID: 1}, nil).Once()
+		mockDb.On("ReviewBountyProof", "proof-uuid", "other-pubkey", db.ProofApproved, "", mock.Anything).Return(db.BountyProof{}, db.ErrNotReviewer).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(pHandler.ReviewBountyProof).ServeHTTP(rr, newRequest(http.MethodPost, "other-pubkey", "proof-uuid", `{"decision": "approved"}`))
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should only review the latest proof", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		pHandler := NewProofHandler(mockDb)
+
+		mockDb.On("GetBounty", uint(1)).Return(bounty).Once()
+		mockDb.On("GetLatestBountyProof", uint(1)).Return(db.BountyProof{Uuid: "newer-proof-uuid", BountyID: 1}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(pHandler.ReviewBountyProof).ServeHTTP(rr, newRequest(http.MethodPost, "lead-pubkey", "proof-uuid", `{"decision": "changes_requested", "comment": "the toggle is hidden"}`))
+
+		assert.Equal(t, http.StatusConflict, rr.Code)
+		mockDb.AssertNotCalled(t, "ReviewBountyProof", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should not complete a bounty before its proof has the approvals", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		bHandler := NewBountyHandler(nil, mockDb)
+
+		mockDb.On("GetBountyByCreated", uint(1700000000)).Return(bounty, nil).Once()
+		mockDb.On("GetWorkspaceByUuid", "workspace-uuid").Return(workspace).Once()
+		mockDb.On("GetLatestBountyProof", uint(1)).Return(db.BountyProof{Uuid: "proof-uuid", Status: db.ProofPending, Required: 2,
+			Reviews: []db.ReviewAssignment{{Reviewer: "design-pubkey", Decision: db.ProofApproved}, {Reviewer: "qa-pubkey"}}}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(bHandler.UpdateCompletedStatus).ServeHTTP(rr, newRequest(http.MethodPost, "owner-pubkey", "", ""))
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+		assert.Contains(t, rr.Body.String(), DoneProofApproved)
+		assert.Contains(t, rr.Body.String(), "1 of the 2 approvals")
+		mockDb.AssertNotCalled(t, "UpdateBountyCompleted", mock.Anything)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should complete a bounty once its proof is approved", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		bHandler := NewBountyHandler(nil, mockDb)
+
+		mockDb.On("GetBountyByCreated", uint(1700000000)).Return(bounty, nil).Once()
+		mockDb.On("GetWorkspaceByUuid", "workspace-uuid").Return(workspace).Once()
+		mockDb.On("GetLatestBountyProof", uint(1)).Return(db.BountyProof{Uuid: "proof-uuid", Status: db.ProofApproved, Required: 2}, nil).Once()
+		mockDb.On("UpdateBountyCompleted", mock.MatchedBy(func(b db.NewBounty) bool { return b.Completed })).Return(bounty, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(bHandler.UpdateCompletedStatus).ServeHTTP(rr, newRequest(http.MethodPost, "owner-pubkey", "", ""))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should not require more approvals than reviewers", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		wHandler := NewWorkspaceHandler(mockDb)
+
+		mockDb.On("GetWorkspaceByUuid", "workspace-uuid").Return(workspace).Once()
+		mockDb.On("GetPersonByPubkey", "lead-pubkey").Return(db.Person{OwnerPubKey: "lead-pubkey"}).Once()
+
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("workspace_uuid", "workspace-uuid")
+		ctx := context.WithValue(context.WithValue(context.Background(), chi.RouteCtxKey, rctx), auth.ContextKey, "owner-pubkey")
+		req, _ := http.NewRequestWithContext(ctx, http.MethodPut, "/workspaces/workspace-uuid/reviewers",
+			bytes.NewBufferString(`{"default_reviewers": ["lead-pubkey"], "required_approvals": 2}`))
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(wHandler.SetWorkspaceReviewers).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockDb.AssertNotCalled(t, "SetWorkspaceReviewers", mock.Anything, mock.Anything)
+		mockDb.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/httpio"
+	"github.com/stakwork/sphinx-tribes/utils"
+)
+
+// publicHandler serves the reads of the public website. Every response
+// goes through db.Public, so the fields tagged private never leave the
+// server without authentication
+type publicHandler struct {
+	db db.Database
+}
+
+func NewPublicHandler(database db.Database) *publicHandler {
+	return &publicHandler{db: database}
+}
+
+func (ph *publicHandler) GetTribes(w http.ResponseWriter, r *http.Request) {
+	tribes, status, err := db.CachedRead(db.ListCacheKey(db.TribesCacheKey+"public:", r), func() (interface{}, error) {
+		return utils.ListBody(r, db.Public(ph.db.GetListedTribes(r)), func() int64 {
+			return ph.db.GetListedTribesCount(r)
+		}), nil
+	})
+	if err != nil {
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to encode tribes")
+		return
+	}
+	w.Header().Set("X-Cache", status)
+	w.WriteHeader(http.StatusOK)
+	w.Write(tribes)
+}
+
+func (ph *publicHandler) GetTribe(w http.ResponseWriter, r *http.Request) {
+	tribe := ph.db.GetTribe(chi.URLParam(r, "uuid"))
+	if tribe.UUID == "" || tribe.Unlisted || tribe.Deleted {
+		httpio.WriteError(w, r, http.StatusNotFound, "Tribe not found")
+		return
+	}
+
+	theTribe := db.Public(tribe).(map[string]interface{})
+	theTribe["channels"] = db.Public(ph.db.GetChannelsByTribe(tribe.UUID))
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(theTribe)
+}
+
+func (ph *publicHandler) GetPeople(w http.ResponseWriter, r *http.Request) {
+	people, err := db.CachedJSON(db.ListCacheKey(db.PeopleCacheKey+"public:", r), func() interface{} {
+		return utils.ListBody(r, db.Public(ph.db.GetListedPeople(r)), func() int64 {
+			return ph.db.GetListedPeopleCount(r)
+		})
+	})
+	if err != nil {
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to encode people")
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write(people)
+}
+
+func (ph *publicHandler) GetPerson(w http.ResponseWriter, r *http.Request) {
+	person := ph.db.GetPersonByUuid(chi.URLParam(r, "uuid"))
+	if person.Uuid == "" || person.Unlisted {
+		httpio.WriteError(w, r, http.StatusNotFound, "Person not found")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(db.Public(person))
+}
+
+// GetBounties lists the open bounties, the status filters of the bounty
+// list don't apply
+func (ph *publicHandler) GetBounties(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	query.Set("Open", "true")
+	query.Del("Assigned")
+	query.Del("Completed")
+	query.Del("Paid")
+	r.URL.RawQuery = query.Encode()
+
+	bounties := ph.db.GetBountyResponses(ph.db.GetAllBounties(r))
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(utils.ListBody(r, db.Public(bounties), func() int64 {
+		return ph.db.GetBountiesCount(r)
+	}))
+}
+
+func (ph *publicHandler) GetBounty(w http.ResponseWriter, r *http.Request) {
+	id, err := utils.ConvertStringToUint(chi.URLParam(r, "id"))
+	if err != nil {
+		httpio.WriteError(w, r, http.StatusBadRequest, "Invalid bounty id")
+		return
+	}
+	bounty := ph.db.GetBounty(id)
+	if bounty.ID != id || !bounty.Show || bounty.Assignee != "" || bounty.Paid {
+		httpio.WriteError(w, r, http.StatusNotFound, "Bounty not found")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(db.Public(ph.db.GetBountyResponses([]db.NewBounty{bounty})[0]))
+}
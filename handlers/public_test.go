@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/db"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPublicReads(t *testing.T) {
+	newRequest := func(param string, value string, target string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add(param, value)
+		req, _ := http.NewRequestWithContext(context.WithValue(context.Background(), chi.RouteCtxKey, rctx), http.MethodGet, target, nil)
+		return req
+	}
+	person := db.Person{Uuid: "person-uuid", OwnerPubKey: "owner-pubkey", OwnerAlias: "alice", OwnerRouteHint: "route-hint", OwnerContactKey: "contact-key"}
+
+	t.Run("should redact the private fields of a person", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		pHandler := NewPublicHandler(mockDb)
+
+		mockDb.On("GetPersonByUuid", "person-uuid").Return(person).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(pHandler.GetPerson).ServeHTTP(rr, newRequest("uuid", "person-uuid", "/public/people/person-uuid"))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		body := map[string]interface{}{}
+		json.Unmarshal(rr.Body.Bytes(), &body)
+		assert.Equal(t, "alice", body["owner_alias"])
+		assert.NotContains(t, body, "owner_route_hint")
+		assert.NotContains(t, body, "owner_contact_key")
+	})
+
+	t.Run("should hide unlisted people", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		pHandler := NewPublicHandler(mockDb)
+
+		unlisted := person
+		unlisted.Unlisted = true
+		mockDb.On("GetPersonByUuid", "person-uuid").Return(unlisted).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(pHandler.GetPerson).ServeHTTP(rr, newRequest("uuid", "person-uuid", "/public/people/person-uuid"))
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("should redact the tribe and keep its channels", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		pHandler := NewPublicHandler(mockDb)
+
+		mockDb.On("GetTribe", "tribe-uuid").Return(db.Tribe{UUID: "tribe-uuid", Name: "tribe", OwnerRouteHint: "route-hint"}).Once()
+		mockDb.On("GetChannelsByTribe", "tribe-uuid").Return([]db.Channel{{ID: 1, TribeUUID: "tribe-uuid", Name: "general"}}).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(pHandler.GetTribe).ServeHTTP(rr, newRequest("uuid", "tribe-uuid", "/public/tribes/tribe-uuid"))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		body := map[string]interface{}{}
+		json.Unmarshal(rr.Body.Bytes(), &body)
+		assert.Equal(t, "tribe", body["name"])
+		assert.NotContains(t, body, "owner_route_hint")
+		assert.Len(t, body["channels"], 1)
+	})
+
+	t.Run("should only list open bounties", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		pHandler := NewPublicHandler(mockDb)
+
+		bounties := []db.NewBounty{{ID: 1, Title: "bounty", Show: true, OwnerID: "owner-pubkey"}}
+		mockDb.On("GetAllBounties", mock.MatchedBy(func(r *http.Request) bool {
+			query := r.URL.Query()
+			return query.Get("Open") == "true" && query.Get("Paid") == ""
+		})).Return(bounties).Once()
+		mockDb.On("GetBountyResponses", bounties).Return([]db.BountyResponse{{Bounty: bounties[0], Owner: person}}).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(pHandler.GetBounties).ServeHTTP(rr, newRequest("", "", "/public/bounties?Paid=true"))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		body := []map[string]map[string]interface{}{}
+		json.Unmarshal(rr.Body.Bytes(), &body)
+		assert.Len(t, body, 1)
+		assert.Equal(t, "alice", body[0]["owner"]["owner_alias"])
+		assert.NotContains(t, body[0]["owner"], "owner_contact_key")
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should hide assigned bounties", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		pHandler := NewPublicHandler(mockDb)
+
+		mockDb.On("GetBounty", uint(1)).Return(db.NewBounty{ID: 1, Show: true, Assignee: "hunter-pubkey"}).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(pHandler.GetBounty).ServeHTTP(rr, newRequest("id", "1", "/public/bounties/1"))
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		mockDb.AssertNotCalled(t, "GetBountyResponses", mock.Anything)
+	})
+}
@@ -0,0 +1,317 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/rs/xid"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/httpio"
+	"github.com/stakwork/sphinx-tribes/upstream"
+	"gorm.io/gorm"
+)
+
+type questHandler struct {
+	httpClient    HttpClient
+	db            db.Database
+	userHasAccess func(pubKeyFromAuth string, uuid string, role string) bool
+	m             sync.Mutex
+}
+
+func NewQuestHandler(httpClient HttpClient, database db.Database) *questHandler {
+	dbConf := db.NewDatabaseConfig(&gorm.DB{})
+	return &questHandler{
+		httpClient:    httpClient,
+		db:            database,
+		userHasAccess: dbConf.UserHasAccess,
+	}
+}
+
+// questOf returns the quest of the route
+func (qh *questHandler) questOf(w http.ResponseWriter, r *http.Request) (db.Quest, bool) {
+	quest, err := qh.db.GetQuest(chi.URLParam(r, "uuid"))
+	if err != nil {
+		httpio.WriteError(w, r, http.StatusNotFound, "Quest not found")
+		return quest, false
+	}
+	return quest, true
+}
+
+// CreateOrEditQuest creates a quest, or edits the quest with the uuid of
+// the body, from bounties of its workspace in the order of bounty_ids
+func (qh *questHandler) CreateOrEditQuest(w http.ResponseWriter, r *http.Request) {
+	pubKeyFromAuth, _ := r.Context().Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[quests] no pubkey from auth")
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
+		return
+	}
+
+	quest := db.Quest{}
+	body, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err := json.Unmarshal(body, &quest); err != nil {
+		fmt.Println("[quests]", err)
+		httpio.WriteError(w, r, http.StatusNotAcceptable, "")
+		return
+	}
+
+	if quest.Uuid == "" {
+		quest.Uuid = xid.New().String()
+		quest.CreatedBy = pubKeyFromAuth
+	} else {
+		existing, err := qh.db.GetQuest(quest.Uuid)
+		if err != nil || existing.WorkspaceUuid != quest.WorkspaceUuid {
+			httpio.WriteError(w, r, http.StatusNotFound, "Quest not found")
+			return
+		}
+		if existing.BonusPaidTo != "" {
+			httpio.WriteError(w, r, http.StatusConflict, "The bonus of the quest is already paid")
+			return
+		}
+		quest.ID = existing.ID
+		quest.CreatedBy = existing.CreatedBy
+		quest.Created = existing.Created
+	}
+	quest.BonusPaidTo = ""
+	quest.BonusPaidAt = nil
+	quest.Bounties = nil
+
+	if !validatePayload(w, r, quest) {
+		return
+	}
+	if !qh.userHasAccess(pubKeyFromAuth, quest.WorkspaceUuid, db.AddBounty) {
+		httpio.WriteError(w, r, http.StatusUnauthorized, "Don't have access to create quests in this workspace")
+		return
+	}
+
+	seen := map[uint]bool{}
+	for _, id := range quest.BountyIds {
+		if seen[id] || qh.db.GetBounty(id).WorkspaceUuid != quest.WorkspaceUuid {
+			httpio.WriteErrorDetails(w, r, http.StatusBadRequest, "The bounties of a quest are distinct bounties of its workspace", map[string]interface{}{
+				"bounty_id": id,
+			})
+			return
+		}
+		seen[id] = true
+	}
+
+	saved, err := qh.db.CreateOrEditQuest(quest)
+	if err != nil {
+		fmt.Println("[quests]", err)
+		httpio.WriteError(w, r, http.StatusBadRequest, "")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(saved)
+}
+
+// GetQuests lists the active quests, of a workspace with ?workspace_uuid=.
+// ?active=false lists the inactive quests of the workspace too
+func (qh *questHandler) GetQuests(w http.ResponseWriter, r *http.Request) {
+	workspaceUuid := r.URL.Query().Get("workspace_uuid")
+	activeOnly := workspaceUuid == "" || r.URL.Query().Get("active") != "false"
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(qh.db.GetQuests(workspaceUuid, activeOnly))
+}
+
+// GetQuest returns a quest with its bounties in order
+func (qh *questHandler) GetQuest(w http.ResponseWriter, r *http.Request) {
+	quest, ok := qh.questOf(w, r)
+	if !ok {
+		return
+	}
+	quest.Bounties = qh.db.GetQuestBounties(quest.Uuid)
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(quest)
+}
+
+// GetQuestProgress returns the progress of every hunter assigned to a
+// bounty of the quest, the furthest first
+func (qh *questHandler) GetQuestProgress(w http.ResponseWriter, r *http.Request) {
+	quest, ok := qh.questOf(w, r)
+	if !ok {
+		return
+	}
+	bounties := qh.db.GetQuestBounties(quest.Uuid)
+
+	progress := []db.QuestProgress{}
+	for _, hunter := range questHunters(bounties) {
+		progress = append(progress, questProgress(bounties, hunter))
+	}
+	sort.SliceStable(progress, func(i, j int) bool {
+		return progress[i].Completed > progress[j].Completed
+	})
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(progress)
+}
+
+// GetHunterQuestProgress returns the progress of a hunter in a quest, with
+// the next bounty open to them
+func (qh *questHandler) GetHunterQuestProgress(w http.ResponseWriter, r *http.Request) {
+	quest, ok := qh.questOf(w, r)
+	if !ok {
+		return
+	}
+	bounties := qh.db.GetQuestBounties(quest.Uuid)
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(questProgress(bounties, chi.URLParam(r, "pubkey")))
+}
+
+func (qh *questHandler) DeleteQuest(w http.ResponseWriter, r *http.Request) {
+	pubKeyFromAuth, _ := r.Context().Value(auth.ContextKey).(string)
+	quest, ok := qh.questOf(w, r)
+	if !ok {
+		return
+	}
+	if pubKeyFromAuth == "" || !qh.userHasAccess(pubKeyFromAuth, quest.WorkspaceUuid, db.DeleteBounty) {
+		httpio.WriteError(w, r, http.StatusUnauthorized, "Don't have access to delete quests in this workspace")
+		return
+	}
+
+	if err := qh.db.DeleteQuest(quest.Uuid); err != nil {
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to delete the quest")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(true)
+}
+
+// PayQuestBonus pays the bonus of a quest to the hunter who completed all
+// its bounties, from the workspace budget
+func (qh *questHandler) PayQuestBonus(w http.ResponseWriter, r *http.Request) {
+	pubKeyFromAuth, _ := r.Context().Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[quests] no pubkey from auth")
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
+		return
+	}
+
+	qh.m.Lock()
+	defer qh.m.Unlock()
+
+	quest, ok := qh.questOf(w, r)
+	if !ok {
+		return
+	}
+	if !qh.userHasAccess(pubKeyFromAuth, quest.WorkspaceUuid, db.PayBounty) {
+		httpio.WriteError(w, r, http.StatusUnauthorized, "You don't have appropriate permissions to pay bounties")
+		return
+	}
+	if quest.BonusPaidTo != "" {
+		httpio.WriteError(w, r, http.StatusConflict, "The bonus of the quest is already paid")
+		return
+	}
+	if quest.Bonus == 0 {
+		httpio.WriteError(w, r, http.StatusBadRequest, "The quest has no bonus")
+		return
+	}
+
+	hunter := questCompletedBy(qh.db.GetQuestBounties(quest.Uuid))
+	if hunter == "" {
+		httpio.WriteError(w, r, http.StatusBadRequest, "No hunter completed every bounty of the quest")
+		return
+	}
+	if qh.db.GetWorkspaceBudget(quest.WorkspaceUuid).TotalBudget < quest.Bonus {
+		httpio.WriteError(w, r, http.StatusForbidden, "workspace budget is not enough to pay the amount")
+		return
+	}
+
+	person := qh.db.GetPersonByPubkey(hunter)
+	paymentHash, _, paid, err := relayKeysend(r.Context(), qh.httpClient, quest.Bonus, person)
+	// a bonus the relay didn't answer may still settle, it is recorded as
+	// pending so it is not paid again
+	pending := err != nil && upstream.Attempted(err)
+	if err != nil && !pending {
+		upstream.WriteError(w, r, err, "The bonus payment failed")
+		return
+	}
+	if err == nil && !paid {
+		httpio.WriteError(w, r, http.StatusBadGateway, "The bonus payment failed")
+		return
+	}
+
+	now := time.Now()
+	payment := db.NewPaymentHistory{
+		Amount:         quest.Bonus,
+		SenderPubKey:   pubKeyFromAuth,
+		ReceiverPubKey: hunter,
+		WorkspaceUuid:  quest.WorkspaceUuid,
+		Created:        &now,
+		Updated:        &now,
+		Status:         !pending,
+		PaymentType:    db.QuestBonus,
+		PaymentHash:    paymentHash,
+	}
+	if pending {
+		log.Printf("[quests] the outcome of the bonus of quest %s is unknown, it is pending: %s", quest.Uuid, err)
+		payment.State = db.PaymentPending
+	}
+	quest, err = qh.db.PayQuestBonus(quest, payment)
+	if err != nil {
+		log.Printf("[quests] keysend for quest %s succeeded but the bonus could not be recorded: %s", quest.Uuid, err)
+	}
+
+	if pending {
+		w.WriteHeader(http.StatusAccepted)
+	} else {
+		w.WriteHeader(http.StatusOK)
+	}
+	json.NewEncoder(w).Encode(quest)
+}
+
+// questHunters lists the assignees of the bounties of a quest once each
+func questHunters(bounties []db.NewBounty) []string {
+	hunters := []string{}
+	seen := map[string]bool{}
+	for _, bounty := range bounties {
+		if bounty.Assignee != "" && !seen[bounty.Assignee] {
+			seen[bounty.Assignee] = true
+			hunters = append(hunters, bounty.Assignee)
+		}
+	}
+	return hunters
+}
+
+// questProgress counts the bounties of a quest a hunter completed, the next
+// bounty is the first one neither done nor assigned to someone else
+func questProgress(bounties []db.NewBounty, hunter string) db.QuestProgress {
+	progress := db.QuestProgress{Hunter: hunter, Total: len(bounties)}
+	for _, bounty := range bounties {
+		done := bounty.Completed || bounty.Paid
+		if done && bounty.Assignee == hunter {
+			progress.Completed++
+			continue
+		}
+		if progress.NextBountyId == 0 && !done && (bounty.Assignee == "" || bounty.Assignee == hunter) {
+			progress.NextBountyId = bounty.ID
+		}
+	}
+	progress.Done = progress.Total > 0 && progress.Completed == progress.Total
+	return progress
+}
+
+// questCompletedBy returns the hunter who completed every bounty of a
+// quest, if one did
+func questCompletedBy(bounties []db.NewBounty) string {
+	for _, hunter := range questHunters(bounties) {
+		if questProgress(bounties, hunter).Done {
+			return hunter
+		}
+	}
+	return ""
+}
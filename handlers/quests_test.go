@@ -0,0 +1,207 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/handlers/mocks"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestQuestProgress(t *testing.T) {
+	bounties := []db.NewBounty{
+		{ID: 1, Assignee: "hunter-a", Completed: true},
+		{ID: 2, Assignee: "hunter-a", Paid: true},
+		{ID: 3, Assignee: "hunter-b"},
+		{ID: 4},
+	}
+
+	progress := questProgress(bounties, "hunter-a")
+	assert.Equal(t, 2, progress.Completed)
+	assert.Equal(t, 4, progress.Total)
+	assert.False(t, progress.Done)
+	assert.Equal(t, uint(4), progress.NextBountyId)
+
+	progress = questProgress(bounties, "hunter-b")
+	assert.Equal(t, 0, progress.Completed)
+	assert.Equal(t, uint(3), progress.NextBountyId)
+
+	assert.Equal(t, "", questCompletedBy(bounties))
+
+	bounties[2] = db.NewBounty{ID: 3, Assignee: "hunter-a", Completed: true}
+	bounties[3] = db.NewBounty{ID: 4, Assignee: "hunter-a", Completed: true}
+	assert.True(t, questProgress(bounties, "hunter-a").Done)
+	assert.Equal(t, "hunter-a", questCompletedBy(bounties))
+}
+
+func TestQuests(t *testing.T) {
+	newRequest := func(pubkey string, method string, body string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("uuid", "quest-uuid")
+		ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+		ctx = context.WithValue(ctx, auth.ContextKey, pubkey)
+		req, _ := http.NewRequestWithContext(ctx, method, "/quests", bytes.NewBufferString(body))
+		return req
+	}
+	quest := db.Quest{ID: 1, Uuid: "quest-uuid", WorkspaceUuid: "workspace-uuid", Title: "Onboarding", Bonus: 500, Active: true, BountyIds: []uint{1, 2}}
+	completed := []db.NewBounty{
+		{ID: 1, WorkspaceUuid: "workspace-uuid", Assignee: "hunter-pubkey", Completed: true},
+		{ID: 2, WorkspaceUuid: "workspace-uuid", Assignee: "hunter-pubkey", Paid: true},
+	}
+
+	t.Run("should create a quest from bounties of the workspace", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		qHandler := NewQuestHandler(&mocks.HttpClient{}, mockDb)
+		qHandler.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool { return role == db.AddBounty }
+
+		mockDb.On("GetBounty", uint(1)).Return(completed[0]).Once()
+		mockDb.On("GetBounty", uint(2)).Return(completed[1]).Once()
+		mockDb.On("CreateOrEditQuest", mock.MatchedBy(func(q db.Quest) bool {
+			return q.Uuid != "" && q.CreatedBy == "owner-pubkey" && len(q.BountyIds) == 2
+		})).Return(func(q db.Quest) (db.Quest, error) { return q, nil }).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(qHandler.CreateOrEditQuest).ServeHTTP(rr, newRequest("owner-pubkey", http.MethodPost, `{"workspace_uuid":"workspace-uuid","title":"Onboarding","bonus":500,"active":true,"bounty_ids":[1,2]}`))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should refuse bounties of another workspace", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		qHandler := NewQuestHandler(&mocks.HttpClient{}, mockDb)
+		qHandler.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool { return true }
+
+		mockDb.On("GetBounty", uint(1)).Return(db.NewBounty{ID: 1, WorkspaceUuid: "other-workspace"}).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(qHandler.CreateOrEditQuest).ServeHTTP(rr, newRequest("owner-pubkey", http.MethodPost, `{"workspace_uuid":"workspace-uuid","title":"Onboarding","bounty_ids":[1]}`))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockDb.AssertNotCalled(t, "CreateOrEditQuest", mock.Anything)
+	})
+
+	t.Run("should refuse people without the add bounty role", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		qHandler := NewQuestHandler(&mocks.HttpClient{}, mockDb)
+		qHandler.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool { return false }
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(qHandler.CreateOrEditQuest).ServeHTTP(rr, newRequest("stranger-pubkey", http.MethodPost, `{"workspace_uuid":"workspace-uuid","title":"Onboarding","bounty_ids":[1]}`))
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		mockDb.AssertNotCalled(t, "CreateOrEditQuest", mock.Anything)
+	})
+
+	t.Run("should return the progress of every hunter", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		qHandler := NewQuestHandler(&mocks.HttpClient{}, mockDb)
+
+		mockDb.On("GetQuest", "quest-uuid").Return(quest, nil).Once()
+		mockDb.On("GetQuestBounties", "quest-uuid").Return([]db.NewBounty{completed[0], {ID: 2, Assignee: "other-pubkey"}}).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(qHandler.GetQuestProgress).ServeHTTP(rr, newRequest("", http.MethodGet, ""))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		progress := []db.QuestProgress{}
+		json.Unmarshal(rr.Body.Bytes(), &progress)
+		assert.Len(t, progress, 2)
+		assert.Equal(t, "hunter-pubkey", progress[0].Hunter)
+		assert.Equal(t, 1, progress[0].Completed)
+	})
+
+	t.Run("should pay the bonus to the hunter who completed the quest", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mockHttpClient := &mocks.HttpClient{}
+		qHandler := NewQuestHandler(mockHttpClient, mockDb)
+		qHandler.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool { return role == db.PayBounty }
+
+		mockDb.On("GetQuest", "quest-uuid").Return(quest, nil).Once()
+		mockDb.On("GetQuestBounties", "quest-uuid").Return(completed).Once()
+		mockDb.On("GetWorkspaceBudget", "workspace-uuid").Return(db.NewBountyBudget{TotalBudget: 1000}).Once()
+		mockDb.On("GetPersonByPubkey", "hunter-pubkey").Return(db.Person{OwnerPubKey: "hunter-pubkey"}).Once()
+		mockHttpClient.On("Do", mock.AnythingOfType("*http.Request")).Return(&http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(bytes.NewReader([]byte(`{"success": true, "response": {"settled": true}}`))),
+		}, nil).Once()
+		mockDb.On("PayQuestBonus", quest, mock.MatchedBy(func(p db.NewPaymentHistory) bool {
+			return p.PaymentType == db.QuestBonus && p.Amount == 500 && p.ReceiverPubKey == "hunter-pubkey" && p.SenderPubKey == "payer-pubkey"
+		})).Return(db.Quest{Uuid: "quest-uuid", BonusPaidTo: "hunter-pubkey"}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(qHandler.PayQuestBonus).ServeHTTP(rr, newRequest("payer-pubkey", http.MethodPost, ""))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		mockDb.AssertExpectations(t)
+		mockHttpClient.AssertExpectations(t)
+	})
+
+	t.Run("should record a bonus the node has not settled as pending", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mockHttpClient := &mocks.HttpClient{}
+		qHandler := NewQuestHandler(mockHttpClient, mockDb)
+		qHandler.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool { return true }
+
+		mockDb.On("GetQuest", "quest-uuid").Return(quest, nil).Once()
+		mockDb.On("GetQuestBounties", "quest-uuid").Return(completed).Once()
+		mockDb.On("GetWorkspaceBudget", "workspace-uuid").Return(db.NewBountyBudget{TotalBudget: 1000}).Once()
+		mockDb.On("GetPersonByPubkey", "hunter-pubkey").Return(db.Person{OwnerPubKey: "hunter-pubkey"}).Once()
+		mockHttpClient.On("Do", mock.AnythingOfType("*http.Request")).Return(&http.Response{
+			StatusCode: http.StatusAccepted,
+			Body:       io.NopCloser(bytes.NewReader([]byte(`{"success": true, "response": {"payment_hash": "hash"}}`))),
+		}, nil).Once()
+		mockDb.On("PayQuestBonus", quest, mock.MatchedBy(func(p db.NewPaymentHistory) bool {
+			return !p.Status && p.State == db.PaymentPending && p.PaymentHash == "hash"
+		})).Return(db.Quest{Uuid: "quest-uuid", BonusPaidTo: "hunter-pubkey"}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(qHandler.PayQuestBonus).ServeHTTP(rr, newRequest("payer-pubkey", http.MethodPost, ""))
+
+		assert.Equal(t, http.StatusAccepted, rr.Code)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should not pay the bonus of an unfinished quest", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mockHttpClient := &mocks.HttpClient{}
+		qHandler := NewQuestHandler(mockHttpClient, mockDb)
+		qHandler.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool { return true }
+
+		mockDb.On("GetQuest", "quest-uuid").Return(quest, nil).Once()
+		mockDb.On("GetQuestBounties", "quest-uuid").Return([]db.NewBounty{completed[0], {ID: 2, Assignee: "hunter-pubkey"}}).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(qHandler.PayQuestBonus).ServeHTTP(rr, newRequest("payer-pubkey", http.MethodPost, ""))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockHttpClient.AssertNotCalled(t, "Do", mock.Anything)
+	})
+
+	t.Run("should not pay a bonus twice", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mockHttpClient := &mocks.HttpClient{}
+		qHandler := NewQuestHandler(mockHttpClient, mockDb)
+		qHandler.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool { return true }
+
+		paid := quest
+		paid.BonusPaidTo = "hunter-pubkey"
+		mockDb.On("GetQuest", "quest-uuid").Return(paid, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(qHandler.PayQuestBonus).ServeHTTP(rr, newRequest("payer-pubkey", http.MethodPost, ""))
+
+		assert.Equal(t, http.StatusConflict, rr.Code)
+		mockHttpClient.AssertNotCalled(t, "Do", mock.Anything)
+	})
+}
@@ -0,0 +1,203 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/httpio"
+)
+
+// the quotas of a plan in the order they are listed, with the config that
+// sets them, the unit they are counted in and the factor from the unit
+// they are set in
+var planQuotas = []struct {
+	quota  string
+	limits func(cfg config.Config) map[string]int
+	unit   string
+	factor int64
+}{
+	{db.QuotaActiveBounties, func(cfg config.Config) map[string]int { return cfg.QuotaActiveBounties }, "bounties", 1},
+	{db.QuotaStakworkSubmissions, func(cfg config.Config) map[string]int { return cfg.QuotaStakworkSubmissions }, "submissions", 1},
+	{db.QuotaStorage, func(cfg config.Config) map[string]int { return cfg.QuotaStorageMb }, "bytes", 1024 * 1024},
+}
+
+// workspacePlan is the plan of a workspace, the default plan when it has none
+func workspacePlan(workspace db.Workspace) string {
+	if workspace.Plan != "" {
+		return workspace.Plan
+	}
+	return config.Get().DefaultPlan
+}
+
+// knownPlan reports if a plan has a quota in the config
+func knownPlan(plan string) bool {
+	cfg := config.Get()
+	for _, q := range planQuotas {
+		if _, ok := q.limits(cfg)[plan]; ok {
+			return true
+		}
+	}
+	return plan == cfg.DefaultPlan
+}
+
+// quotaLimit is the limit of a quota on a plan, in the unit it is counted
+// in, nil when the plan is unlimited on it
+func quotaLimit(plan string, quota string) *int64 {
+	cfg := config.Get()
+	for _, q := range planQuotas {
+		if q.quota != quota {
+			continue
+		}
+		if limit, ok := q.limits(cfg)[plan]; ok {
+			l := int64(limit) * q.factor
+			return &l
+		}
+	}
+	return nil
+}
+
+// workspaceUsage is the plan of a workspace with what it used of the quotas
+// of the plan
+func workspaceUsage(database db.Database, workspace db.Workspace) (db.WorkspaceUsage, error) {
+	now := time.Now()
+	usage := db.WorkspaceUsage{WorkspaceUuid: workspace.Uuid, Plan: workspacePlan(workspace), Quotas: []db.WorkspaceQuota{}}
+	used, err := database.GetWorkspaceUsage(workspace.Uuid, now)
+	if err != nil {
+		return usage, err
+	}
+	for _, q := range planQuotas {
+		quota := db.WorkspaceQuota{Quota: q.quota, Unit: q.unit, Used: used[q.quota], Limit: quotaLimit(usage.Plan, q.quota)}
+		if q.quota == db.QuotaStakworkSubmissions {
+			quota.Period = now.UTC().Format("2006-01")
+		}
+		usage.Quotas = append(usage.Quotas, quota)
+	}
+	return usage, nil
+}
+
+// withinQuota writes a 402 and returns false when adding to a quota of the
+// workspace goes over the limit of its plan. Nothing is looked up when no
+// plan has the quota
+func withinQuota(w http.ResponseWriter, r *http.Request, database db.Database, workspaceUuid string, quota string, adding int64) bool {
+	if workspaceUuid == "" || adding <= 0 {
+		return true
+	}
+	for _, q := range planQuotas {
+		if q.quota == quota && len(q.limits(config.Get())) == 0 {
+			return true
+		}
+	}
+
+	workspace := database.GetWorkspaceByUuid(workspaceUuid)
+	plan := workspacePlan(workspace)
+	limit := quotaLimit(plan, quota)
+	if limit == nil {
+		return true
+	}
+	used, err := database.GetWorkspaceUsage(workspaceUuid, time.Now())
+	if err != nil {
+		log.Printf("[quotas] could not get the usage of workspace %s: %s", workspaceUuid, err)
+		return true
+	}
+	if used[quota]+adding <= *limit {
+		return true
+	}
+
+	httpio.WriteErrorDetails(w, r, http.StatusPaymentRequired, fmt.Sprintf("The %s plan of the workspace is over its %s quota", plan, strings.ReplaceAll(quota, "_", " ")), map[string]interface{}{
+		"quota": quota,
+		"plan":  plan,
+		"limit": *limit,
+		"used":  used[quota],
+	})
+	return false
+}
+
+// countQuota adds one use of a metered quota of the workspace
+func countQuota(database db.Database, workspaceUuid string, quota string) {
+	if workspaceUuid == "" {
+		return
+	}
+	if err := database.CountWorkspaceUsage(workspaceUuid, quota, time.Now()); err != nil {
+		log.Printf("[quotas] could not count a %s of workspace %s: %s", quota, workspaceUuid, err)
+	}
+}
+
+type quotaHandler struct {
+	db db.Database
+}
+
+func NewQuotaHandler(database db.Database) *quotaHandler {
+	return &quotaHandler{db: database}
+}
+
+// GetWorkspaceUsage returns the plan of the workspace of the route and what
+// it used of its quotas, to its members
+func (qh *quotaHandler) GetWorkspaceUsage(w http.ResponseWriter, r *http.Request) {
+	pubKeyFromAuth, _ := r.Context().Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[quotas] no pubkey from auth")
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
+		return
+	}
+	workspace := qh.db.GetWorkspaceByUuid(chi.URLParam(r, "workspace_uuid"))
+	if workspace.Uuid == "" || !isWorkspaceMember(qh.db, pubKeyFromAuth, workspace.Uuid) {
+		httpio.WriteError(w, r, http.StatusUnauthorized, "Don't have access to this workspace")
+		return
+	}
+
+	usage, err := workspaceUsage(qh.db, workspace)
+	if err != nil {
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to get the usage")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(usage)
+}
+
+// SetWorkspacePlan moves the workspace of the route to a plan of the
+// config, for admins
+func (qh *quotaHandler) SetWorkspacePlan(w http.ResponseWriter, r *http.Request) {
+	request := db.WorkspacePlanRequest{}
+	body, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err := json.Unmarshal(body, &request); err != nil {
+		fmt.Println("[quotas]", err)
+		httpio.WriteError(w, r, http.StatusNotAcceptable, "")
+		return
+	}
+	request.Plan = strings.TrimSpace(request.Plan)
+	if request.Plan != "" && !knownPlan(request.Plan) {
+		httpio.WriteError(w, r, http.StatusBadRequest, fmt.Sprintf("Plan %q is not in the config", request.Plan))
+		return
+	}
+
+	workspace := qh.db.GetWorkspaceByUuid(chi.URLParam(r, "uuid"))
+	if workspace.Uuid == "" {
+		httpio.WriteError(w, r, http.StatusNotFound, "Workspace not found")
+		return
+	}
+	workspace, err := qh.db.SetWorkspacePlan(workspace.Uuid, request.Plan)
+	if err != nil {
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to set the plan")
+		return
+	}
+
+	usage, err := workspaceUsage(qh.db, workspace)
+	if err != nil {
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to get the usage")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(usage)
+}
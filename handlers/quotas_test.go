@@ -0,0 +1,143 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/db"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestWorkspaceQuotas(t *testing.T) {
+	t.Setenv("RELAY_URL", "http://localhost:3001")
+	t.Setenv("RELAY_AUTH_KEY", "RelayAuthKey")
+	t.Setenv("DEFAULT_PLAN", "free")
+	t.Setenv("QUOTA_ACTIVE_BOUNTIES", "free=2,team=20")
+	t.Setenv("QUOTA_STORAGE_MB", "free=1")
+	config.InitConfig()
+	defer func() {
+		t.Setenv("QUOTA_ACTIVE_BOUNTIES", "")
+		t.Setenv("QUOTA_STORAGE_MB", "")
+		config.InitConfig()
+	}()
+
+	workspace := db.Workspace{Uuid: "workspace-uuid", OwnerPubKey: "owner-pubkey"}
+	newRequest := func(method string, pubkey string, param string, body string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add(param, workspace.Uuid)
+		ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+		ctx = context.WithValue(ctx, auth.ContextKey, pubkey)
+		req, _ := http.NewRequestWithContext(ctx, method, "/workspaces/workspace-uuid/usage", bytes.NewBufferString(body))
+		return req
+	}
+	usage := map[string]int64{db.QuotaActiveBounties: 2, db.QuotaStakworkSubmissions: 5, db.QuotaStorage: 1024}
+
+	t.Run("should return the usage of the plan to a member", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		qHandler := NewQuotaHandler(mockDb)
+
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(workspace)
+		mockDb.On("GetWorkspaceUsage", workspace.Uuid, mock.Anything).Return(usage, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(qHandler.GetWorkspaceUsage).ServeHTTP(rr, newRequest(http.MethodGet, "owner-pubkey", "workspace_uuid", ""))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var response db.WorkspaceUsage
+		json.Unmarshal(rr.Body.Bytes(), &response)
+		assert.Equal(t, "free", response.Plan)
+		assert.Len(t, response.Quotas, 3)
+		assert.Equal(t, int64(2), *response.Quotas[0].Limit)
+		assert.Nil(t, response.Quotas[1].Limit)
+		assert.NotEmpty(t, response.Quotas[1].Period)
+		assert.Equal(t, int64(1024*1024), *response.Quotas[2].Limit)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should not return the usage to a non member", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		qHandler := NewQuotaHandler(mockDb)
+
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(workspace)
+		mockDb.On("GetWorkspaceUser", "stranger", workspace.Uuid).Return(db.WorkspaceUsers{}).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(qHandler.GetWorkspaceUsage).ServeHTTP(rr, newRequest(http.MethodGet, "stranger", "workspace_uuid", ""))
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should refuse what goes over a quota with a 402", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		aHandler := NewArtifactHandler(mockDb)
+
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(workspace)
+		mockDb.On("GetWorkspaceUsage", workspace.Uuid, mock.Anything).Return(map[string]int64{db.QuotaStorage: 1024*1024 - 4}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		body := `{"kind": "schema", "name": "Schema", "content": "CREATE TABLE bounty"}`
+		http.HandlerFunc(aHandler.CreateOrEditArtifact).ServeHTTP(rr, newRequest(http.MethodPost, "owner-pubkey", "workspace_uuid", body))
+
+		assert.Equal(t, http.StatusPaymentRequired, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"quota":"storage"`)
+		assert.Contains(t, rr.Body.String(), `"plan":"free"`)
+		mockDb.AssertNotCalled(t, "CreateOrEditArtifact", mock.Anything)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should let a workspace on a plan without the quota through", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		qHandler := NewQuotaHandler(mockDb)
+		team := workspace
+		team.Plan = "team"
+
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(team)
+
+		rr := httptest.NewRecorder()
+		assert.True(t, withinQuota(rr, newRequest(http.MethodPost, "owner-pubkey", "workspace_uuid", ""), qHandler.db, workspace.Uuid, db.QuotaStorage, 1024*1024*10))
+		mockDb.AssertNotCalled(t, "GetWorkspaceUsage", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should move a workspace to a plan of the config", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		qHandler := NewQuotaHandler(mockDb)
+		team := workspace
+		team.Plan = "team"
+
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(workspace).Once()
+		mockDb.On("SetWorkspacePlan", workspace.Uuid, "team").Return(team, nil).Once()
+		mockDb.On("GetWorkspaceUsage", workspace.Uuid, mock.Anything).Return(usage, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(qHandler.SetWorkspacePlan).ServeHTTP(rr, newRequest(http.MethodPut, "admin-pubkey", "uuid", `{"plan": "team"}`))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var response db.WorkspaceUsage
+		json.Unmarshal(rr.Body.Bytes(), &response)
+		assert.Equal(t, "team", response.Plan)
+		assert.Equal(t, int64(20), *response.Quotas[0].Limit)
+		assert.Nil(t, response.Quotas[2].Limit)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should reject a plan that is not in the config", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		qHandler := NewQuotaHandler(mockDb)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(qHandler.SetWorkspacePlan).ServeHTTP(rr, newRequest(http.MethodPut, "admin-pubkey", "uuid", `{"plan": "platinum"}`))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockDb.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/httpio"
+)
+
+const (
+	recommendationsLimit    = 20
+	recommendationsMaxLimit = 50
+)
+
+type recommendationHandler struct {
+	db db.Database
+}
+
+func NewRecommendationHandler(database db.Database) *recommendationHandler {
+	return &recommendationHandler{db: database}
+}
+
+// GetRecommendedBounties returns the open bounties ranked for the caller by
+// the recommendation job. When the job has not ranked them yet, or its run
+// is too old, they are ranked on demand and stored
+func (rh *recommendationHandler) GetRecommendedBounties(w http.ResponseWriter, r *http.Request) {
+	pubKeyFromAuth, _ := r.Context().Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
+		return
+	}
+
+	limit := recommendationsLimit
+	if value := r.URL.Query().Get("limit"); value != "" {
+		var err error
+		if limit, err = strconv.Atoi(value); err != nil || limit < 1 || limit > recommendationsMaxLimit {
+			httpio.WriteError(w, r, http.StatusBadRequest, "limit is a number from 1 to 50")
+			return
+		}
+	}
+
+	now := time.Now()
+	since := now.Add(-db.RecommendationMaxAge)
+	recommendations, err := rh.db.GetBountyRecommendations(pubKeyFromAuth, since, limit)
+	if err == nil && len(recommendations) == 0 {
+		if err = rh.db.RefreshBountyRecommendations([]string{pubKeyFromAuth}, now); err == nil {
+			recommendations, err = rh.db.GetBountyRecommendations(pubKeyFromAuth, since, limit)
+		}
+	}
+	if err != nil {
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to get the recommended bounties")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(recommendations)
+}
@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetRecommendedBounties(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.ContextKey, "hunter")
+
+	t.Run("should reject a limit out of range", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		rHandler := NewRecommendationHandler(mockDb)
+
+		rr := httptest.NewRecorder()
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "/me/recommended_bounties?limit=500", nil)
+
+		http.HandlerFunc(rHandler.GetRecommendedBounties).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockDb.AssertNotCalled(t, "GetBountyRecommendations", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should return the stored recommendations", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		rHandler := NewRecommendationHandler(mockDb)
+		stored := []db.BountyRecommendation{{BountyID: 7, Score: 5, Reasons: []string{"skill:golang"}}}
+		mockDb.On("GetBountyRecommendations", "hunter", mock.AnythingOfType("time.Time"), 5).Return(stored, nil).Once()
+
+		rr := httptest.NewRecorder()
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "/me/recommended_bounties?limit=5", nil)
+
+		http.HandlerFunc(rHandler.GetRecommendedBounties).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var recommendations []db.BountyRecommendation
+		err := json.Unmarshal(rr.Body.Bytes(), &recommendations)
+		assert.NoError(t, err)
+		assert.Equal(t, uint(7), recommendations[0].BountyID)
+		mockDb.AssertNotCalled(t, "RefreshBountyRecommendations", mock.Anything, mock.Anything)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should rank on demand when nothing is stored", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		rHandler := NewRecommendationHandler(mockDb)
+		mockDb.On("GetBountyRecommendations", "hunter", mock.AnythingOfType("time.Time"), recommendationsLimit).Return([]db.BountyRecommendation{}, nil).Once()
+		mockDb.On("RefreshBountyRecommendations", []string{"hunter"}, mock.AnythingOfType("time.Time")).Return(nil).Once()
+		mockDb.On("GetBountyRecommendations", "hunter", mock.AnythingOfType("time.Time"), recommendationsLimit).Return([]db.BountyRecommendation{{BountyID: 3}}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "/me/recommended_bounties", nil)
+
+		http.HandlerFunc(rHandler.GetRecommendedBounties).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		mockDb.AssertExpectations(t)
+	})
+}
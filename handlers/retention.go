@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/httpio"
+	"github.com/stakwork/sphinx-tribes/jobs"
+)
+
+type retentionHandler struct {
+	db db.Database
+}
+
+func NewRetentionHandler(database db.Database) *retentionHandler {
+	return &retentionHandler{db: database}
+}
+
+// GetRetention reports the retention rules in effect and what the latest
+// runs purged, anonymized or archived
+func (rh *retentionHandler) GetRetention(w http.ResponseWriter, r *http.Request) {
+	runs, err := rh.db.GetRetentionRuns(r)
+	if err != nil {
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to get retention runs")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(db.RetentionReport{
+		DryRun: config.Get().RetentionDryRun,
+		Rules:  jobs.RetentionRules(),
+		Runs:   runs,
+	})
+}
+
+// RunRetention queues a retention run now, dry_run=true only counts the
+// rows the rules would change
+func (rh *retentionHandler) RunRetention(w http.ResponseWriter, r *http.Request) {
+	dryRun := false
+	if value := r.URL.Query().Get("dry_run"); value != "" {
+		var err error
+		if dryRun, err = strconv.ParseBool(value); err != nil {
+			httpio.WriteError(w, r, http.StatusBadRequest, "dry_run is not a boolean")
+			return
+		}
+	}
+
+	job, err := jobs.Default.Enqueue(jobs.RetentionJob, map[string]interface{}{"dry_run": dryRun})
+	if err != nil {
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to queue the retention run")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(job)
+}
@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/jobs"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetRetention(t *testing.T) {
+	mockDb := &dbMocks.Database{}
+	rHandler := NewRetentionHandler(mockDb)
+	runs := []db.RetentionRun{{JobUuid: "job-uuid", Rule: "expired_invoices", Action: db.RetentionPurge, Affected: 12}}
+	mockDb.On("GetRetentionRuns", mock.AnythingOfType("*http.Request")).Return(runs, nil).Once()
+
+	rr := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/admin/retention", nil)
+
+	http.HandlerFunc(rHandler.GetRetention).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var report db.RetentionReport
+	err := json.Unmarshal(rr.Body.Bytes(), &report)
+	assert.NoError(t, err)
+	assert.Len(t, report.Rules, len(db.RetentionRules))
+	assert.Equal(t, int64(12), report.Runs[0].Affected)
+	mockDb.AssertExpectations(t)
+}
+
+func TestRunRetention(t *testing.T) {
+	defer func(queue *jobs.Queue) { jobs.Default = queue }(jobs.Default)
+
+	t.Run("should reject a dry_run that is not a boolean", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		jobs.Default = jobs.NewQueue(mockDb)
+		rHandler := NewRetentionHandler(mockDb)
+
+		rr := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/admin/retention/run?dry_run=maybe", nil)
+
+		http.HandlerFunc(rHandler.RunRetention).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockDb.AssertNotCalled(t, "EnqueueJob", mock.Anything)
+	})
+
+	t.Run("should queue a dry run", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		jobs.Default = jobs.NewQueue(mockDb)
+		rHandler := NewRetentionHandler(mockDb)
+		mockDb.On("EnqueueJob", mock.MatchedBy(func(j db.Job) bool {
+			return j.Type == jobs.RetentionJob && j.Payload["dry_run"] == true
+		})).Return(db.Job{Uuid: "job-uuid", Type: jobs.RetentionJob}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/admin/retention/run?dry_run=true", nil)
+
+		http.HandlerFunc(rHandler.RunRetention).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		mockDb.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,180 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/rs/xid"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/httpio"
+)
+
+type routingRuleHandler struct {
+	db db.Database
+}
+
+func NewRoutingRuleHandler(database db.Database) *routingRuleHandler {
+	return &routingRuleHandler{db: database}
+}
+
+// memberOf writes the error and returns false unless the caller is a member
+// of the workspace of the route, or one who can manage its bounties when
+// manage is set
+func (rh *routingRuleHandler) memberOf(w http.ResponseWriter, r *http.Request, manage bool) (string, bool) {
+	pubKeyFromAuth, _ := r.Context().Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[routing rules] no pubkey from auth")
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
+		return "", false
+	}
+	workspaceUuid := chi.URLParam(r, "workspace_uuid")
+	if !isWorkspaceMember(rh.db, pubKeyFromAuth, workspaceUuid) {
+		httpio.WriteError(w, r, http.StatusUnauthorized, "Don't have access to this workspace")
+		return "", false
+	}
+	if manage && !rh.db.UserHasManageBountyRoles(pubKeyFromAuth, workspaceUuid) {
+		httpio.WriteError(w, r, http.StatusUnauthorized, "You don't have the right permissions to manage routing rules")
+		return "", false
+	}
+	return pubKeyFromAuth, true
+}
+
+func (rh *routingRuleHandler) GetRoutingRules(w http.ResponseWriter, r *http.Request) {
+	if _, ok := rh.memberOf(w, r, false); !ok {
+		return
+	}
+
+	rules, err := rh.db.GetBountyRoutingRules(chi.URLParam(r, "workspace_uuid"))
+	if err != nil {
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to get the routing rules")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(rules)
+}
+
+// CreateOrEditRoutingRule creates a rule, or edits the rule with the uuid of
+// the body. A rule needs something to do, and its assignee, phase and
+// pubkeys to notify must be of the workspace
+func (rh *routingRuleHandler) CreateOrEditRoutingRule(w http.ResponseWriter, r *http.Request) {
+	pubKeyFromAuth, ok := rh.memberOf(w, r, true)
+	if !ok {
+		return
+	}
+
+	rule := db.BountyRoutingRule{}
+	body, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err := json.Unmarshal(body, &rule); err != nil {
+		fmt.Println("[routing rules]", err)
+		httpio.WriteError(w, r, http.StatusNotAcceptable, "")
+		return
+	}
+	rule.WorkspaceUuid = chi.URLParam(r, "workspace_uuid")
+
+	if rule.Uuid == "" {
+		rule.Uuid = xid.New().String()
+	} else if existing, err := rh.db.GetBountyRoutingRule(rule.Uuid); err == nil && existing.WorkspaceUuid != rule.WorkspaceUuid {
+		httpio.WriteError(w, r, http.StatusNotFound, "Routing rule not found")
+		return
+	}
+	rule.CreatedBy = pubKeyFromAuth
+
+	if !validatePayload(w, r, rule) {
+		return
+	}
+	if rule.Assignee == "" && rule.PhaseUuid == "" && len(rule.NotifyPubkeys) == 0 {
+		httpio.WriteError(w, r, http.StatusBadRequest, "A routing rule needs an assignee, a phase or pubkeys to notify")
+		return
+	}
+	if rule.PhaseUuid != "" {
+		phase, err := rh.db.GetPhaseByUuid(rule.PhaseUuid)
+		if err != nil || rh.db.GetFeatureByUuid(phase.FeatureUuid).WorkspaceUuid != rule.WorkspaceUuid {
+			httpio.WriteError(w, r, http.StatusBadRequest, "Phase not found in this workspace")
+			return
+		}
+	}
+	pubkeys := append([]string{rule.Assignee}, rule.NotifyPubkeys...)
+	for _, pubkey := range pubkeys {
+		if pubkey != "" && !isWorkspaceMember(rh.db, pubkey, rule.WorkspaceUuid) {
+			httpio.WriteError(w, r, http.StatusBadRequest, fmt.Sprintf("%s is not a member of this workspace", pubkey))
+			return
+		}
+	}
+
+	saved, err := rh.db.CreateOrEditBountyRoutingRule(rule)
+	if err != nil {
+		fmt.Println("[routing rules]", err)
+		httpio.WriteError(w, r, http.StatusBadRequest, "")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(saved)
+}
+
+func (rh *routingRuleHandler) DeleteRoutingRule(w http.ResponseWriter, r *http.Request) {
+	if _, ok := rh.memberOf(w, r, true); !ok {
+		return
+	}
+	rule, err := rh.db.GetBountyRoutingRule(chi.URLParam(r, "uuid"))
+	if err != nil || rule.WorkspaceUuid != chi.URLParam(r, "workspace_uuid") {
+		httpio.WriteError(w, r, http.StatusNotFound, "Routing rule not found")
+		return
+	}
+
+	if err := rh.db.DeleteBountyRoutingRule(rule.Uuid); err != nil {
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to delete the routing rule")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(true)
+}
+
+// TestRoutingRules is a dry run of the rules of the workspace on a bounty of
+// the workspace, or on a draft, it changes nothing
+func (rh *routingRuleHandler) TestRoutingRules(w http.ResponseWriter, r *http.Request) {
+	if _, ok := rh.memberOf(w, r, false); !ok {
+		return
+	}
+	workspaceUuid := chi.URLParam(r, "workspace_uuid")
+
+	test := db.BountyRoutingTest{}
+	body, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err := json.Unmarshal(body, &test); err != nil {
+		fmt.Println("[routing rules]", err)
+		httpio.WriteError(w, r, http.StatusNotAcceptable, "")
+		return
+	}
+
+	bounty := db.NewBounty{Labels: test.Labels, Assignee: test.Assignee, PhaseUuid: test.PhaseUuid}
+	applied := []string{}
+	if test.BountyID != 0 {
+		bounty = rh.db.GetBounty(test.BountyID)
+		if bounty.ID == 0 || bounty.WorkspaceUuid != workspaceUuid {
+			httpio.WriteError(w, r, http.StatusNotFound, "Bounty not found in this workspace")
+			return
+		}
+		var err error
+		if applied, err = rh.db.GetAppliedBountyRoutings(bounty.ID); err != nil {
+			httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to get the applied routing rules")
+			return
+		}
+	}
+
+	rules, err := rh.db.GetBountyRoutingRules(workspaceUuid)
+	if err != nil {
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to get the routing rules")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(db.RouteBounty(rules, bounty, applied))
+}
@@ -0,0 +1,151 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRoutingRules(t *testing.T) {
+	workspace := db.Workspace{Uuid: "workspace-uuid", OwnerPubKey: "owner-pubkey"}
+	newRequest := func(method string, pubkey string, params map[string]string, body string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("workspace_uuid", workspace.Uuid)
+		for key, value := range params {
+			rctx.URLParams.Add(key, value)
+		}
+		ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+		ctx = context.WithValue(ctx, auth.ContextKey, pubkey)
+		req, _ := http.NewRequestWithContext(ctx, method, "/workspaces/workspace-uuid/routing_rules", bytes.NewBufferString(body))
+		return req
+	}
+
+	t.Run("should create a rule", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		rHandler := NewRoutingRuleHandler(mockDb)
+
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(workspace)
+		mockDb.On("UserHasManageBountyRoles", "owner-pubkey", workspace.Uuid).Return(true).Once()
+		mockDb.On("GetPhaseByUuid", "design-phase").Return(db.FeaturePhase{Uuid: "design-phase", FeatureUuid: "feature-uuid"}, nil).Once()
+		mockDb.On("GetFeatureByUuid", "feature-uuid").Return(db.WorkspaceFeatures{Uuid: "feature-uuid", WorkspaceUuid: workspace.Uuid}).Once()
+		mockDb.On("GetWorkspaceUser", "designer", workspace.Uuid).Return(db.WorkspaceUsers{ID: 2}).Once()
+		mockDb.On("CreateOrEditBountyRoutingRule", mock.MatchedBy(func(rule db.BountyRoutingRule) bool {
+			return rule.Uuid != "" && rule.WorkspaceUuid == workspace.Uuid && rule.Label == "design" && rule.CreatedBy == "owner-pubkey"
+		})).Return(func(rule db.BountyRoutingRule) (db.BountyRoutingRule, error) {
+			return rule, nil
+		}).Once()
+
+		rr := httptest.NewRecorder()
+		body := `{"label": "design", "assignee": "designer", "phase_uuid": "design-phase", "notify_pubkeys": ["owner-pubkey"]}`
+		http.HandlerFunc(rHandler.CreateOrEditRoutingRule).ServeHTTP(rr, newRequest(http.MethodPost, "owner-pubkey", nil, body))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should reject a rule that does nothing", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		rHandler := NewRoutingRuleHandler(mockDb)
+
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(workspace)
+		mockDb.On("UserHasManageBountyRoles", "owner-pubkey", workspace.Uuid).Return(true).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(rHandler.CreateOrEditRoutingRule).ServeHTTP(rr, newRequest(http.MethodPost, "owner-pubkey", nil, `{"label": "design"}`))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockDb.AssertNotCalled(t, "CreateOrEditBountyRoutingRule", mock.Anything)
+	})
+
+	t.Run("should reject an assignee outside the workspace", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		rHandler := NewRoutingRuleHandler(mockDb)
+
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(workspace)
+		mockDb.On("UserHasManageBountyRoles", "owner-pubkey", workspace.Uuid).Return(true).Once()
+		mockDb.On("GetWorkspaceUser", "stranger", workspace.Uuid).Return(db.WorkspaceUsers{}).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(rHandler.CreateOrEditRoutingRule).ServeHTTP(rr, newRequest(http.MethodPost, "owner-pubkey", nil, `{"label": "design", "assignee": "stranger"}`))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockDb.AssertNotCalled(t, "CreateOrEditBountyRoutingRule", mock.Anything)
+	})
+
+	t.Run("should need the manage bounty roles to edit the rules", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		rHandler := NewRoutingRuleHandler(mockDb)
+
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(workspace)
+		mockDb.On("GetWorkspaceUser", "member-pubkey", workspace.Uuid).Return(db.WorkspaceUsers{ID: 3}).Once()
+		mockDb.On("UserHasManageBountyRoles", "member-pubkey", workspace.Uuid).Return(false).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(rHandler.DeleteRoutingRule).ServeHTTP(rr, newRequest(http.MethodDelete, "member-pubkey", map[string]string{"uuid": "rule-uuid"}, ""))
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		mockDb.AssertNotCalled(t, "DeleteBountyRoutingRule", mock.Anything)
+	})
+
+	t.Run("should not delete the rule of another workspace", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		rHandler := NewRoutingRuleHandler(mockDb)
+
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(workspace)
+		mockDb.On("UserHasManageBountyRoles", "owner-pubkey", workspace.Uuid).Return(true).Once()
+		mockDb.On("GetBountyRoutingRule", "rule-uuid").Return(db.BountyRoutingRule{Uuid: "rule-uuid", WorkspaceUuid: "other-workspace"}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(rHandler.DeleteRoutingRule).ServeHTTP(rr, newRequest(http.MethodDelete, "owner-pubkey", map[string]string{"uuid": "rule-uuid"}, ""))
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		mockDb.AssertNotCalled(t, "DeleteBountyRoutingRule", mock.Anything)
+	})
+
+	t.Run("should dry run the rules on a bounty", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		rHandler := NewRoutingRuleHandler(mockDb)
+		rules := []db.BountyRoutingRule{
+			{Uuid: "design", Label: "design", Assignee: "designer"},
+			{Uuid: "ui", Label: "ui", NotifyPubkeys: []string{"lead"}},
+		}
+
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(workspace)
+		mockDb.On("GetBounty", uint(7)).Return(db.NewBounty{ID: 7, WorkspaceUuid: workspace.Uuid, Labels: []string{"design", "ui"}}).Once()
+		mockDb.On("GetAppliedBountyRoutings", uint(7)).Return([]string{"ui"}, nil).Once()
+		mockDb.On("GetBountyRoutingRules", workspace.Uuid).Return(rules, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(rHandler.TestRoutingRules).ServeHTTP(rr, newRequest(http.MethodPost, "owner-pubkey", nil, `{"bounty_id": 7}`))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var plan db.BountyRoutingPlan
+		json.Unmarshal(rr.Body.Bytes(), &plan)
+		assert.Equal(t, db.BountyRoutingPlan{Rules: []string{"design"}, Assignee: "designer", NotifyPubkeys: []string{}}, plan)
+		mockDb.AssertNotCalled(t, "ApplyBountyRouting", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should fail the dry run when the rules can't be read", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		rHandler := NewRoutingRuleHandler(mockDb)
+
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(workspace)
+		mockDb.On("GetBountyRoutingRules", workspace.Uuid).Return(nil, errors.New("connection refused")).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(rHandler.TestRoutingRules).ServeHTTP(rr, newRequest(http.MethodPost, "owner-pubkey", nil, `{"labels": ["design"]}`))
+
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	})
+}
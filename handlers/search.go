@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/httpio"
+	"github.com/stakwork/sphinx-tribes/jobs"
+	"github.com/stakwork/sphinx-tribes/search"
+)
+
+const (
+	defaultSearchLimit = 20
+	maxSearchLimit     = 100
+)
+
+type searchHandler struct {
+	engine search.Engine
+}
+
+func NewSearchHandler(engine search.Engine) *searchHandler {
+	return &searchHandler{engine: engine}
+}
+
+// Search finds the tribes, people or bounties matching the search param,
+// best match first
+func (sh *searchHandler) Search(w http.ResponseWriter, r *http.Request) {
+	index := chi.URLParam(r, "index")
+	if !search.IsIndex(index) {
+		httpio.WriteError(w, r, http.StatusBadRequest, "Search tribes, people or bounties")
+		return
+	}
+
+	keys := r.URL.Query()
+	text := keys.Get("search")
+	page, _ := strconv.Atoi(keys.Get("page"))
+	limit, _ := strconv.Atoi(keys.Get("limit"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = defaultSearchLimit
+	}
+	if limit > maxSearchLimit {
+		limit = maxSearchLimit
+	}
+	offset := (page - 1) * limit
+
+	docs := []db.SearchDocument{}
+	if text != "" {
+		var err error
+		docs, err = sh.engine.Search(r.Context(), index, text, limit, offset)
+		if err != nil {
+			fmt.Println("[search] search failed", err)
+			httpio.WriteError(w, r, http.StatusBadGateway, "Search failed")
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(docs)
+}
+
+// Reindex queues a job copying every record to an external engine
+func (sh *searchHandler) Reindex(w http.ResponseWriter, r *http.Request) {
+	if !sh.engine.External() {
+		httpio.WriteError(w, r, http.StatusConflict, "The postgres search engine reads the tables and has no index to rebuild")
+		return
+	}
+
+	job, err := jobs.Default.Enqueue(search.ReindexJob, nil)
+	if err != nil {
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to queue the reindex")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(job)
+}
@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/db"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stakwork/sphinx-tribes/search"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSearch(t *testing.T) {
+	newRequest := func(index string, query string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("index", index)
+		req, _ := http.NewRequestWithContext(context.WithValue(context.Background(), chi.RouteCtxKey, rctx), http.MethodGet, "/search/"+index+"?"+query, nil)
+		return req
+	}
+
+	t.Run("should search an index with the default page size", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		sHandler := NewSearchHandler(search.NewPostgres(mockDb))
+		docs := []db.SearchDocument{{Index: db.SearchIndexBounties, ID: "12", Title: "Fix the build", Rank: 0.6}}
+		mockDb.On("SearchDocuments", mock.Anything, db.SearchIndexBounties, "build", 20, 0).Return(docs, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(sHandler.Search).ServeHTTP(rr, newRequest(db.SearchIndexBounties, "search=build"))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		res := []db.SearchDocument{}
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &res))
+		assert.Equal(t, docs, res)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should page and cap the limit", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		sHandler := NewSearchHandler(search.NewPostgres(mockDb))
+		mockDb.On("SearchDocuments", mock.Anything, db.SearchIndexPeople, "alice", maxSearchLimit, maxSearchLimit).Return([]db.SearchDocument{}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(sHandler.Search).ServeHTTP(rr, newRequest(db.SearchIndexPeople, "search=alice&limit=500&page=2"))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should return nothing for an empty search", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		sHandler := NewSearchHandler(search.NewPostgres(mockDb))
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(sHandler.Search).ServeHTTP(rr, newRequest(db.SearchIndexTribes, ""))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "[]\n", rr.Body.String())
+		mockDb.AssertNotCalled(t, "SearchDocuments")
+	})
+
+	t.Run("should refuse unknown indexes", func(t *testing.T) {
+		sHandler := NewSearchHandler(search.NewPostgres(&dbMocks.Database{}))
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(sHandler.Search).ServeHTTP(rr, newRequest("workspaces", "search=x"))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
+
+func TestSearchReindex(t *testing.T) {
+	t.Run("should have nothing to rebuild with the postgres engine", func(t *testing.T) {
+		sHandler := NewSearchHandler(search.NewPostgres(&dbMocks.Database{}))
+
+		rr := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/admin/search/reindex", nil)
+		http.HandlerFunc(sHandler.Reindex).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusConflict, rr.Code)
+	})
+}
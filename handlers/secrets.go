@@ -0,0 +1,264 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+
+	"github.com/go-chi/chi"
+	"github.com/rs/xid"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/httpio"
+	"github.com/stakwork/sphinx-tribes/vault"
+)
+
+type secretHandler struct {
+	db db.Database
+}
+
+func NewSecretHandler(database db.Database) *secretHandler {
+	return &secretHandler{db: database}
+}
+
+var secretName = regexp.MustCompile(`^[a-z0-9_.-]{1,64}$`)
+
+// secretHint is the end of a secret, so the admin can tell which value is
+// stored. Values too short to give a hint away get none
+func secretHint(value string) string {
+	runes := []rune(value)
+	if len(runes) < 12 {
+		return ""
+	}
+	return "..." + string(runes[len(runes)-4:])
+}
+
+// workspaceSecret opens a secret of a workspace, it is empty when the
+// workspace has none or the vault is not configured
+func workspaceSecret(database db.Database, workspaceUuid string, name string) string {
+	if !vault.Configured() {
+		return ""
+	}
+	secret, err := database.GetWorkspaceSecret(workspaceUuid, name)
+	if err != nil {
+		return ""
+	}
+	value, err := vault.Open(vault.Sealed{Ciphertext: secret.Ciphertext, DataKey: secret.DataKey, KeyId: secret.MasterKeyId})
+	if err != nil {
+		fmt.Println("[secrets] failed to open", secret.Uuid, err)
+		return ""
+	}
+	return value
+}
+
+// stakworkKeyOf is the Stakwork key a workspace stored, or the key of the
+// instance
+func stakworkKeyOf(database db.Database, workspaceUuid string) string {
+	if key := workspaceSecret(database, workspaceUuid, db.SecretStakworkKey); key != "" {
+		return key
+	}
+	return config.StakworkKey
+}
+
+// adminOf writes the error and returns false unless the caller is the admin
+// of the workspace of the route and the vault is configured
+func (sh *secretHandler) adminOf(w http.ResponseWriter, r *http.Request) (string, string, bool) {
+	pubKeyFromAuth, _ := r.Context().Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[secrets] no pubkey from auth")
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
+		return "", "", false
+	}
+	workspace := sh.db.GetWorkspaceByUuid(chi.URLParam(r, "workspace_uuid"))
+	if workspace.Uuid == "" {
+		httpio.WriteError(w, r, http.StatusNotFound, "Workspace not found")
+		return "", "", false
+	}
+	if workspace.OwnerPubKey != pubKeyFromAuth {
+		httpio.WriteError(w, r, http.StatusUnauthorized, "only workspace admin can manage its secrets")
+		return "", "", false
+	}
+	if !vault.Configured() {
+		httpio.WriteError(w, r, http.StatusServiceUnavailable, "The secrets vault is not configured")
+		return "", "", false
+	}
+	return pubKeyFromAuth, workspace.Uuid, true
+}
+
+// readSecretRequest reads and seals the value of a secret request
+func readSecretRequest(w http.ResponseWriter, r *http.Request) (db.WorkspaceSecretRequest, vault.Sealed, bool) {
+	request := db.WorkspaceSecretRequest{}
+	body, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err := json.Unmarshal(body, &request); err != nil {
+		fmt.Println("[secrets]", err)
+		httpio.WriteError(w, r, http.StatusNotAcceptable, "")
+		return request, vault.Sealed{}, false
+	}
+	if !validatePayload(w, r, request) {
+		return request, vault.Sealed{}, false
+	}
+	sealed, err := vault.Seal(request.Value)
+	if err != nil {
+		fmt.Println("[secrets]", err)
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to seal the secret")
+		return request, sealed, false
+	}
+	return request, sealed, true
+}
+
+// GetWorkspaceSecrets lists the secrets of the workspace of the route with
+// their hints, never their values
+func (sh *secretHandler) GetWorkspaceSecrets(w http.ResponseWriter, r *http.Request) {
+	_, workspaceUuid, ok := sh.adminOf(w, r)
+	if !ok {
+		return
+	}
+
+	secrets, err := sh.db.GetWorkspaceSecrets(workspaceUuid)
+	if err != nil {
+		fmt.Println("[secrets]", err)
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to get the secrets")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(secrets)
+}
+
+// CreateWorkspaceSecret stores a new secret in the workspace of the route,
+// a name that is taken answers 409 as its value is changed by a rotation
+func (sh *secretHandler) CreateWorkspaceSecret(w http.ResponseWriter, r *http.Request) {
+	pubKeyFromAuth, workspaceUuid, ok := sh.adminOf(w, r)
+	if !ok {
+		return
+	}
+
+	request, sealed, ok := readSecretRequest(w, r)
+	if !ok {
+		return
+	}
+	if !secretName.MatchString(request.Name) {
+		httpio.WriteError(w, r, http.StatusBadRequest, "name has to be lowercase letters, digits, dots, dashes or underscores")
+		return
+	}
+	if _, err := sh.db.GetWorkspaceSecret(workspaceUuid, request.Name); err == nil {
+		httpio.WriteError(w, r, http.StatusConflict, "A secret with this name exists, rotate it instead")
+		return
+	}
+	if request.Kind == "" {
+		request.Kind = db.SecretOther
+	}
+
+	secret, err := sh.db.CreateOrEditWorkspaceSecret(db.WorkspaceSecret{
+		Uuid:          xid.New().String(),
+		WorkspaceUuid: workspaceUuid,
+		Name:          request.Name,
+		Kind:          request.Kind,
+		Ciphertext:    sealed.Ciphertext,
+		DataKey:       sealed.DataKey,
+		MasterKeyId:   sealed.KeyId,
+		Hint:          secretHint(request.Value),
+		CreatedBy:     pubKeyFromAuth,
+	})
+	if err != nil {
+		fmt.Println("[secrets]", err)
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to save the secret")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(secret)
+}
+
+// RotateWorkspaceSecret replaces the value of the secret of the route, the
+// new value gets a new data key
+func (sh *secretHandler) RotateWorkspaceSecret(w http.ResponseWriter, r *http.Request) {
+	pubKeyFromAuth, workspaceUuid, ok := sh.adminOf(w, r)
+	if !ok {
+		return
+	}
+
+	existing, err := sh.db.GetWorkspaceSecret(workspaceUuid, chi.URLParam(r, "name"))
+	if err != nil {
+		httpio.WriteError(w, r, http.StatusNotFound, "Secret not found")
+		return
+	}
+	request, sealed, ok := readSecretRequest(w, r)
+	if !ok {
+		return
+	}
+
+	existing.Ciphertext = sealed.Ciphertext
+	existing.DataKey = sealed.DataKey
+	existing.MasterKeyId = sealed.KeyId
+	existing.Hint = secretHint(request.Value)
+	existing.RotatedBy = pubKeyFromAuth
+	secret, err := sh.db.CreateOrEditWorkspaceSecret(existing)
+	if err != nil {
+		fmt.Println("[secrets]", err)
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to rotate the secret")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(secret)
+}
+
+func (sh *secretHandler) DeleteWorkspaceSecret(w http.ResponseWriter, r *http.Request) {
+	_, workspaceUuid, ok := sh.adminOf(w, r)
+	if !ok {
+		return
+	}
+
+	secret, err := sh.db.GetWorkspaceSecret(workspaceUuid, chi.URLParam(r, "name"))
+	if err != nil {
+		httpio.WriteError(w, r, http.StatusNotFound, "Secret not found")
+		return
+	}
+	if err := sh.db.DeleteWorkspaceSecret(workspaceUuid, secret.Name); err != nil {
+		fmt.Println("[secrets]", err)
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to delete the secret")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(true)
+}
+
+// RewrapSecrets seals the data keys of every secret with the current master
+// key, for the super admin once SECRETS_MASTER_KEY is rotated. The values
+// are not encrypted again, and secrets that fail to open are listed
+func (sh *secretHandler) RewrapSecrets(w http.ResponseWriter, r *http.Request) {
+	keyId := vault.CurrentKeyId()
+	if keyId == "" {
+		httpio.WriteError(w, r, http.StatusServiceUnavailable, "The secrets vault is not configured")
+		return
+	}
+
+	secrets, err := sh.db.GetWorkspaceSecretsToRewrap(keyId)
+	if err != nil {
+		fmt.Println("[secrets]", err)
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to get the secrets")
+		return
+	}
+	rewrap := db.SecretRewrap{MasterKeyId: keyId, Failed: []string{}}
+	for _, secret := range secrets {
+		sealed, _, err := vault.Rewrap(vault.Sealed{Ciphertext: secret.Ciphertext, DataKey: secret.DataKey, KeyId: secret.MasterKeyId})
+		if err == nil {
+			err = sh.db.RewrapWorkspaceSecret(secret.Uuid, sealed.DataKey, sealed.KeyId)
+		}
+		if err != nil {
+			fmt.Println("[secrets] failed to rewrap", secret.Uuid, err)
+			rewrap.Failed = append(rewrap.Failed, secret.Uuid)
+			continue
+		}
+		rewrap.Rewrapped++
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(rewrap)
+}
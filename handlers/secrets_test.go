@@ -0,0 +1,169 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/db"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stakwork/sphinx-tribes/vault"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestWorkspaceSecrets(t *testing.T) {
+	t.Setenv("RELAY_URL", "http://localhost:3001")
+	t.Setenv("RELAY_AUTH_KEY", "RelayAuthKey")
+	t.Setenv("SECRETS_MASTER_KEY", base64.StdEncoding.EncodeToString([]byte(strings.Repeat("k", 32))))
+	config.InitConfig()
+	defer func() {
+		t.Setenv("SECRETS_MASTER_KEY", "")
+		t.Setenv("SECRETS_PREVIOUS_MASTER_KEY", "")
+		config.InitConfig()
+	}()
+
+	workspace := db.Workspace{Uuid: "workspace-uuid", OwnerPubKey: "owner-pubkey"}
+	newRequest := func(method string, pubkey string, name string, body string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("workspace_uuid", workspace.Uuid)
+		rctx.URLParams.Add("name", name)
+		ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+		ctx = context.WithValue(ctx, auth.ContextKey, pubkey)
+		req, _ := http.NewRequestWithContext(ctx, method, "/workspaces/workspace-uuid/secrets", bytes.NewBufferString(body))
+		return req
+	}
+	sealedSecret := func(name string, value string) db.WorkspaceSecret {
+		sealed, _ := vault.Seal(value)
+		return db.WorkspaceSecret{Uuid: name + "-uuid", WorkspaceUuid: workspace.Uuid, Name: name, Ciphertext: sealed.Ciphertext, DataKey: sealed.DataKey, MasterKeyId: sealed.KeyId}
+	}
+
+	t.Run("should store a sealed secret without returning its value", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		sHandler := NewSecretHandler(mockDb)
+		var saved db.WorkspaceSecret
+
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(workspace).Once()
+		mockDb.On("GetWorkspaceSecret", workspace.Uuid, "github").Return(db.WorkspaceSecret{}, assert.AnError).Once()
+		mockDb.On("CreateOrEditWorkspaceSecret", mock.MatchedBy(func(s db.WorkspaceSecret) bool {
+			return s.Kind == db.SecretGithubToken && s.CreatedBy == "owner-pubkey" && s.MasterKeyId == vault.CurrentKeyId() &&
+				!bytes.Contains(s.Ciphertext, []byte("ghp_1234567890abcd"))
+		})).Return(func(s db.WorkspaceSecret) (db.WorkspaceSecret, error) {
+			saved = s
+			return s, nil
+		}).Once()
+
+		rr := httptest.NewRecorder()
+		body := `{"name": "github", "kind": "github_token", "value": "ghp_1234567890abcd"}`
+		http.HandlerFunc(sHandler.CreateWorkspaceSecret).ServeHTTP(rr, newRequest(http.MethodPost, "owner-pubkey", "", body))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.NotContains(t, rr.Body.String(), "ghp_1234567890abcd")
+		assert.Contains(t, rr.Body.String(), `"hint":"...abcd"`)
+		value, err := vault.Open(vault.Sealed{Ciphertext: saved.Ciphertext, DataKey: saved.DataKey, KeyId: saved.MasterKeyId})
+		assert.NoError(t, err)
+		assert.Equal(t, "ghp_1234567890abcd", value)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should only let the workspace admin manage secrets", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		sHandler := NewSecretHandler(mockDb)
+
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(workspace).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(sHandler.GetWorkspaceSecrets).ServeHTTP(rr, newRequest(http.MethodGet, "member-pubkey", "", ""))
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		mockDb.AssertNotCalled(t, "GetWorkspaceSecrets", mock.Anything)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should refuse a name that is taken", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		sHandler := NewSecretHandler(mockDb)
+
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(workspace).Once()
+		mockDb.On("GetWorkspaceSecret", workspace.Uuid, "github").Return(sealedSecret("github", "old"), nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(sHandler.CreateWorkspaceSecret).ServeHTTP(rr, newRequest(http.MethodPost, "owner-pubkey", "", `{"name": "github", "value": "new"}`))
+
+		assert.Equal(t, http.StatusConflict, rr.Code)
+		mockDb.AssertNotCalled(t, "CreateOrEditWorkspaceSecret", mock.Anything)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should rotate a secret with a new data key", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		sHandler := NewSecretHandler(mockDb)
+		existing := sealedSecret("github", "ghp_old")
+
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(workspace).Once()
+		mockDb.On("GetWorkspaceSecret", workspace.Uuid, "github").Return(existing, nil).Once()
+		mockDb.On("CreateOrEditWorkspaceSecret", mock.MatchedBy(func(s db.WorkspaceSecret) bool {
+			value, _ := vault.Open(vault.Sealed{Ciphertext: s.Ciphertext, DataKey: s.DataKey, KeyId: s.MasterKeyId})
+			return s.Uuid == existing.Uuid && s.RotatedBy == "owner-pubkey" && !bytes.Equal(s.DataKey, existing.DataKey) && value == "ghp_new"
+		})).Return(func(s db.WorkspaceSecret) (db.WorkspaceSecret, error) { return s, nil }).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(sHandler.RotateWorkspaceSecret).ServeHTTP(rr, newRequest(http.MethodPut, "owner-pubkey", "github", `{"value": "ghp_new"}`))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should answer 503 without a master key", func(t *testing.T) {
+		t.Setenv("SECRETS_MASTER_KEY", "")
+		config.InitConfig()
+		defer func() {
+			t.Setenv("SECRETS_MASTER_KEY", base64.StdEncoding.EncodeToString([]byte(strings.Repeat("k", 32))))
+			config.InitConfig()
+		}()
+		mockDb := &dbMocks.Database{}
+		sHandler := NewSecretHandler(mockDb)
+
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(workspace).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(sHandler.GetWorkspaceSecrets).ServeHTTP(rr, newRequest(http.MethodGet, "owner-pubkey", "", ""))
+
+		assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+		assert.Equal(t, config.StakworkKey, stakworkKeyOf(mockDb, workspace.Uuid))
+	})
+
+	t.Run("should rewrap the secrets on the current master key", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		sHandler := NewSecretHandler(mockDb)
+		existing := sealedSecret("stakwork", "stakwork-key-of-the-workspace")
+
+		t.Setenv("SECRETS_PREVIOUS_MASTER_KEY", base64.StdEncoding.EncodeToString([]byte(strings.Repeat("k", 32))))
+		t.Setenv("SECRETS_MASTER_KEY", base64.StdEncoding.EncodeToString([]byte(strings.Repeat("n", 32))))
+		config.InitConfig()
+
+		mockDb.On("GetWorkspaceSecretsToRewrap", vault.CurrentKeyId()).Return([]db.WorkspaceSecret{existing}, nil).Once()
+		mockDb.On("RewrapWorkspaceSecret", existing.Uuid, mock.Anything, vault.CurrentKeyId()).Return(nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(sHandler.RewrapSecrets).ServeHTTP(rr, newRequest(http.MethodPost, "admin-pubkey", "", ""))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var rewrap db.SecretRewrap
+		json.Unmarshal(rr.Body.Bytes(), &rewrap)
+		assert.Equal(t, 1, rewrap.Rewrapped)
+		assert.Empty(t, rewrap.Failed)
+		mockDb.AssertExpectations(t)
+
+		mockDb.On("GetWorkspaceSecret", workspace.Uuid, db.SecretStakworkKey).Return(sealedSecret(db.SecretStakworkKey, "stakwork-key-of-the-workspace"), nil).Once()
+		assert.Equal(t, "stakwork-key-of-the-workspace", stakworkKeyOf(mockDb, workspace.Uuid))
+	})
+}
@@ -1,12 +1,92 @@
 package handlers
 
 import (
+	"encoding/json"
+	"fmt"
 	"net/http"
+	"strings"
+	"time"
 
+	"github.com/stakwork/sphinx-tribes/httpio"
 	"github.com/stakwork/sphinx-tribes/websocket"
 )
 
+// how often an idle event stream gets a comment line so proxies keep it open
+var eventKeepAlive = 25 * time.Second
+
 func HandleWebSocket(w http.ResponseWriter, r *http.Request) {
 	pool := websocket.WebsocketPool
 	websocket.ServeWs(pool, w, r)
 }
+
+// StreamEvents is the server-sent events fallback of the websocket topics.
+// It streams the messages of the comma separated topics query param and,
+// when the client reconnects with a Last-Event-ID, replays the messages it
+// missed first
+func StreamEvents(w http.ResponseWriter, r *http.Request) {
+	topics := []string{}
+	for _, topic := range strings.Split(r.URL.Query().Get("topics"), ",") {
+		if topic = strings.TrimSpace(topic); topic != "" {
+			topics = append(topics, topic)
+		}
+	}
+	if len(topics) == 0 {
+		httpio.WriteError(w, r, http.StatusBadRequest, "topics are required")
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		httpio.WriteError(w, r, http.StatusInternalServerError, "streaming is not supported")
+		return
+	}
+
+	lastEventID := r.Header.Get("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = r.URL.Query().Get("last_event_id")
+	}
+
+	pool := websocket.WebsocketPool
+	listener, missed, err := pool.Listen(websocket.ConnectionPubkey(r), topics, lastEventID)
+	if err != nil {
+		httpio.WriteError(w, r, http.StatusForbidden, err.Error())
+		return
+	}
+	defer pool.StopListening(listener)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprint(w, "retry: 3000\n\n")
+
+	for _, message := range missed {
+		writeEvent(w, message)
+	}
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(eventKeepAlive)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case message := <-listener.Messages:
+			writeEvent(w, message)
+			flusher.Flush()
+		case <-keepAlive.C:
+			fmt.Fprint(w, ": ping\n\n")
+			flusher.Flush()
+		}
+	}
+}
+
+func writeEvent(w http.ResponseWriter, message websocket.TopicMessage) {
+	data, err := json.Marshal(message)
+	if err != nil {
+		fmt.Println("[events] encode error", err)
+		return
+	}
+	fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", message.ID, message.Msg, data)
+}
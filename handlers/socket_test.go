@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stakwork/sphinx-tribes/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStreamEvents(t *testing.T) {
+	t.Run("should return 400 without topics", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/events", nil)
+
+		http.HandlerFunc(StreamEvents).ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("should return 403 for a workspace topic without a token", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/events?topics=workspace:uuid", nil)
+
+		http.HandlerFunc(StreamEvents).ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+
+	t.Run("should replay missed events and stream new ones", func(t *testing.T) {
+		topic := websocket.Topic(websocket.TopicTribe, "sse-test")
+		websocket.Publish(topic, "tribe_announcement", "seen")
+		websocket.Publish(topic, "tribe_announcement", "missed")
+
+		server := httptest.NewServer(http.HandlerFunc(StreamEvents))
+		defer server.Close()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"?topics="+topic, nil)
+		listener, missed, _ := websocket.WebsocketPool.Listen("", []string{topic}, "0")
+		websocket.WebsocketPool.StopListening(listener)
+		req.Header.Set("Last-Event-ID", missed[0].ID)
+
+		res, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer res.Body.Close()
+		assert.Equal(t, "text/event-stream", res.Header.Get("Content-Type"))
+
+		reader := bufio.NewReader(res.Body)
+		readData := func() string {
+			for {
+				line, err := reader.ReadString('\n')
+				if err != nil {
+					t.Fatal(err)
+				}
+				if strings.HasPrefix(line, "data: ") {
+					return line
+				}
+			}
+		}
+
+		assert.Contains(t, readData(), `"data":"missed"`)
+		websocket.Publish(topic, "tribe_announcement", "live")
+		assert.Contains(t, readData(), `"data":"live"`)
+	})
+}
@@ -0,0 +1,216 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/httpio"
+	"github.com/stakwork/sphinx-tribes/websocket"
+)
+
+const (
+	// a waiting sync has to answer before the route timeout
+	maxSyncWait = 30 * time.Second
+	// notifications a sync returns at most, newest first
+	syncNotificationLimit = 100
+)
+
+// how often a waiting sync looks for changes
+var syncPollInterval = 2 * time.Second
+
+type syncHandler struct {
+	db db.Database
+}
+
+func NewSyncHandler(database db.Database) *syncHandler {
+	return &syncHandler{db: database}
+}
+
+// syncCursor is the position of a client in the event log and in its
+// notifications. Notifications are created after their event, so they
+// get their own position
+type syncCursor struct {
+	event        uint
+	notification uint
+}
+
+func (c syncCursor) String() string {
+	return fmt.Sprintf("%d.%d", c.event, c.notification)
+}
+
+func parseSyncCursor(cursor string) (syncCursor, bool) {
+	parts := strings.Split(cursor, ".")
+	if len(parts) != 2 {
+		return syncCursor{}, false
+	}
+	event, err := strconv.ParseUint(parts[0], 10, 32)
+	if err != nil {
+		return syncCursor{}, false
+	}
+	notification, err := strconv.ParseUint(parts[1], 10, 32)
+	if err != nil {
+		return syncCursor{}, false
+	}
+	return syncCursor{event: uint(event), notification: uint(notification)}, true
+}
+
+// Sync returns the profile, bounties and notifications of the caller. Without
+// ?since= it returns all of them, with the cursor of an earlier sync only
+// what changed since. ?wait= up to 30 seconds holds the request until
+// something changes, so clients can long-poll
+func (sh *syncHandler) Sync(w http.ResponseWriter, r *http.Request) {
+	pubKeyFromAuth, _ := r.Context().Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
+		return
+	}
+
+	query := r.URL.Query()
+	wait := time.Duration(0)
+	if query.Get("wait") != "" {
+		seconds, err := strconv.Atoi(query.Get("wait"))
+		if err != nil || seconds < 0 {
+			httpio.WriteError(w, r, http.StatusBadRequest, "wait is a number of seconds")
+			return
+		}
+		wait = time.Duration(seconds) * time.Second
+		if wait > maxSyncWait {
+			wait = maxSyncWait
+		}
+	}
+
+	if query.Get("since") == "" {
+		changes, err := sh.fullSync(pubKeyFromAuth)
+		if err != nil {
+			httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to sync")
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(changes)
+		return
+	}
+
+	since, ok := parseSyncCursor(query.Get("since"))
+	if !ok {
+		httpio.WriteError(w, r, http.StatusBadRequest, "Invalid sync cursor")
+		return
+	}
+
+	deadline := time.Now().Add(wait)
+	for {
+		changes, changed, err := sh.changesSince(pubKeyFromAuth, since)
+		if err != nil {
+			httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to sync")
+			return
+		}
+		if changed || !time.Now().Before(deadline) {
+			w.WriteHeader(http.StatusOK)
+			json.NewEncoder(w).Encode(changes)
+			return
+		}
+
+		select {
+		case <-r.Context().Done():
+			return
+		case <-time.After(syncPollInterval):
+		}
+	}
+}
+
+func (sh *syncHandler) fullSync(pubkey string) (db.SyncResponse, error) {
+	// the cursor is taken first, what changes while the rest is read is
+	// returned again by the next sync
+	cursor := syncCursor{event: sh.db.GetLastEventId(), notification: sh.db.GetLastNotificationId()}
+
+	notifications, err := sh.db.GetNotificationsBetween(pubkey, 0, cursor.notification, syncNotificationLimit)
+	if err != nil {
+		return db.SyncResponse{}, err
+	}
+
+	changes := db.SyncResponse{
+		Bounties:        sh.db.GetBountiesAssignedTo(pubkey),
+		DeletedBounties: []uint{},
+		Notifications:   notifications,
+		Cursor:          cursor.String(),
+	}
+	if person := sh.db.GetPersonByPubkey(pubkey); person.ID != 0 {
+		changes.Profile = &person
+	}
+	return changes, nil
+}
+
+// changesSince collects what the events that concern the user changed. The
+// bounties are returned as they are now, the ones that no longer exist are
+// listed as deleted
+func (sh *syncHandler) changesSince(pubkey string, since syncCursor) (db.SyncResponse, bool, error) {
+	cursor := syncCursor{event: sh.db.GetLastEventId(), notification: sh.db.GetLastNotificationId()}
+	if cursor.event < since.event {
+		cursor.event = since.event
+	}
+	if cursor.notification < since.notification {
+		cursor.notification = since.notification
+	}
+	changes := db.SyncResponse{
+		Bounties:        []db.NewBounty{},
+		DeletedBounties: []uint{},
+		Notifications:   []db.Notification{},
+		Cursor:          cursor.String(),
+	}
+
+	subjects, err := sh.db.GetUserEventSubjects(pubkey, since.event, cursor.event)
+	if err != nil {
+		return changes, false, err
+	}
+
+	profileChanged := false
+	bountyIds := []uint{}
+	for _, subject := range subjects {
+		parts := strings.SplitN(subject, ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		switch parts[0] {
+		case "person":
+			profileChanged = true
+		case websocket.TopicBounty:
+			if id, err := strconv.ParseUint(parts[1], 10, 32); err == nil {
+				bountyIds = append(bountyIds, uint(id))
+			}
+		}
+	}
+
+	if profileChanged {
+		if person := sh.db.GetPersonByPubkey(pubkey); person.ID != 0 {
+			changes.Profile = &person
+		}
+	}
+
+	if len(bountyIds) > 0 {
+		found := map[uint]bool{}
+		for _, bounty := range sh.db.GetBountiesByIds(bountyIds) {
+			found[bounty.ID] = true
+			if bounty.Assignee == pubkey || bounty.OwnerID == pubkey {
+				changes.Bounties = append(changes.Bounties, bounty)
+			}
+		}
+		for _, id := range bountyIds {
+			if !found[id] {
+				changes.DeletedBounties = append(changes.DeletedBounties, id)
+			}
+		}
+	}
+
+	changes.Notifications, err = sh.db.GetNotificationsBetween(pubkey, since.notification, cursor.notification, syncNotificationLimit)
+	if err != nil {
+		return changes, false, err
+	}
+
+	changed := changes.Profile != nil || len(changes.Bounties) > 0 || len(changes.DeletedBounties) > 0 || len(changes.Notifications) > 0
+	return changes, changed, nil
+}
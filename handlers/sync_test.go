@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSync(t *testing.T) {
+	newRequest := func(pubkey string, target string) *http.Request {
+		ctx := context.WithValue(context.Background(), auth.ContextKey, pubkey)
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+		return req
+	}
+	person := db.Person{ID: 1, Uuid: "person-uuid", OwnerPubKey: "hunter-pubkey", OwnerAlias: "hunter"}
+
+	t.Run("should return everything without a cursor", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		sHandler := NewSyncHandler(mockDb)
+
+		mockDb.On("GetLastEventId").Return(uint(40)).Once()
+		mockDb.On("GetLastNotificationId").Return(uint(9)).Once()
+		mockDb.On("GetNotificationsBetween", "hunter-pubkey", uint(0), uint(9), syncNotificationLimit).Return([]db.Notification{{ID: 9, PubKey: "hunter-pubkey"}}, nil).Once()
+		mockDb.On("GetBountiesAssignedTo", "hunter-pubkey").Return([]db.NewBounty{{ID: 3, Assignee: "hunter-pubkey"}}).Once()
+		mockDb.On("GetPersonByPubkey", "hunter-pubkey").Return(person).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(sHandler.Sync).ServeHTTP(rr, newRequest("hunter-pubkey", "/sync"))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		res := db.SyncResponse{}
+		json.Unmarshal(rr.Body.Bytes(), &res)
+		assert.Equal(t, "40.9", res.Cursor)
+		assert.Equal(t, "hunter", res.Profile.OwnerAlias)
+		assert.Len(t, res.Bounties, 1)
+		assert.Len(t, res.Notifications, 1)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should return what changed since the cursor", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		sHandler := NewSyncHandler(mockDb)
+
+		mockDb.On("GetLastEventId").Return(uint(45)).Once()
+		mockDb.On("GetLastNotificationId").Return(uint(9)).Once()
+		mockDb.On("GetUserEventSubjects", "hunter-pubkey", uint(40), uint(45)).Return([]string{"person:person-uuid", "bounty:3", "bounty:4", "bounty:5", "report:uuid"}, nil).Once()
+		mockDb.On("GetPersonByPubkey", "hunter-pubkey").Return(person).Once()
+		mockDb.On("GetBountiesByIds", []uint{3, 4, 5}).Return([]db.NewBounty{
+			{ID: 3, Assignee: "hunter-pubkey"},
+			{ID: 4, Assignee: "other-pubkey", OwnerID: "owner-pubkey"},
+		}).Once()
+		mockDb.On("GetNotificationsBetween", "hunter-pubkey", uint(9), uint(9), syncNotificationLimit).Return([]db.Notification{}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(sHandler.Sync).ServeHTTP(rr, newRequest("hunter-pubkey", "/sync?since=40.9"))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		res := db.SyncResponse{}
+		json.Unmarshal(rr.Body.Bytes(), &res)
+		assert.Equal(t, "45.9", res.Cursor)
+		assert.NotNil(t, res.Profile)
+		assert.Equal(t, []db.NewBounty{{ID: 3, Assignee: "hunter-pubkey"}}, res.Bounties)
+		assert.Equal(t, []uint{5}, res.DeletedBounties)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should wait for a change", func(t *testing.T) {
+		interval := syncPollInterval
+		syncPollInterval = 10 * time.Millisecond
+		defer func() { syncPollInterval = interval }()
+
+		mockDb := &dbMocks.Database{}
+		sHandler := NewSyncHandler(mockDb)
+
+		mockDb.On("GetLastEventId").Return(uint(40)).Once()
+		mockDb.On("GetLastNotificationId").Return(uint(9)).Once()
+		mockDb.On("GetUserEventSubjects", "hunter-pubkey", uint(40), uint(40)).Return([]string{}, nil).Once()
+		mockDb.On("GetNotificationsBetween", "hunter-pubkey", uint(9), uint(9), syncNotificationLimit).Return([]db.Notification{}, nil).Once()
+
+		mockDb.On("GetLastEventId").Return(uint(41)).Once()
+		mockDb.On("GetLastNotificationId").Return(uint(10)).Once()
+		mockDb.On("GetUserEventSubjects", "hunter-pubkey", uint(40), uint(41)).Return([]string{}, nil).Once()
+		mockDb.On("GetNotificationsBetween", "hunter-pubkey", uint(9), uint(10), syncNotificationLimit).Return([]db.Notification{{ID: 10, PubKey: "hunter-pubkey"}}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(sHandler.Sync).ServeHTTP(rr, newRequest("hunter-pubkey", "/sync?since=40.9&wait=5"))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		res := db.SyncResponse{}
+		json.Unmarshal(rr.Body.Bytes(), &res)
+		assert.Equal(t, "41.10", res.Cursor)
+		assert.Len(t, res.Notifications, 1)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should refuse an invalid cursor", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		sHandler := NewSyncHandler(mockDb)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(sHandler.Sync).ServeHTTP(rr, newRequest("hunter-pubkey", "/sync?since=yesterday"))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockDb.AssertNotCalled(t, "GetUserEventSubjects", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should fail when the events can't be read", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		sHandler := NewSyncHandler(mockDb)
+
+		mockDb.On("GetLastEventId").Return(uint(40)).Once()
+		mockDb.On("GetLastNotificationId").Return(uint(9)).Once()
+		mockDb.On("GetUserEventSubjects", "hunter-pubkey", uint(40), uint(40)).Return(nil, errors.New("boom")).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(sHandler.Sync).ServeHTTP(rr, newRequest("hunter-pubkey", "/sync?since=40.9"))
+
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	})
+
+	t.Run("should refuse anonymous callers", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		sHandler := NewSyncHandler(mockDb)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(sHandler.Sync).ServeHTTP(rr, newRequest("", "/sync"))
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+}
@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/events"
+	"github.com/stakwork/sphinx-tribes/httpio"
+	"github.com/stakwork/sphinx-tribes/utils"
+	"github.com/stakwork/sphinx-tribes/websocket"
+)
+
+// MoveTicket moves a bounty of a phase to another place in its phase, or to
+// a phase of a feature of the same workspace, for its owner and the bounty
+// managers of the workspace. Joining another phase goes through its gate,
+// and joining another feature through its budget, like an edit does
+func (h *bountyHandler) MoveTicket(w http.ResponseWriter, r *http.Request) {
+	pubKeyFromAuth, _ := r.Context().Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[bounty] no pubkey from auth")
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
+		return
+	}
+
+	id, err := utils.ConvertStringToUint(chi.URLParam(r, "id"))
+	if err != nil {
+		httpio.WriteError(w, r, http.StatusBadRequest, "Invalid bounty id")
+		return
+	}
+
+	request := db.TicketMoveRequest{}
+	body, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err := json.Unmarshal(body, &request); err != nil {
+		fmt.Println("[bounty]", err)
+		httpio.WriteError(w, r, http.StatusNotAcceptable, "")
+		return
+	}
+	if !validatePayload(w, r, request) {
+		return
+	}
+
+	bounty := h.db.GetBounty(id)
+	if bounty.ID == 0 {
+		httpio.WriteError(w, r, http.StatusNotFound, "Bounty not found")
+		return
+	}
+	if bounty.PhaseUuid == "" {
+		httpio.WriteError(w, r, http.StatusBadRequest, "The bounty is not in a phase")
+		return
+	}
+	if pubKeyFromAuth != bounty.OwnerID && !h.userHasManageBountyRoles(pubKeyFromAuth, bounty.WorkspaceUuid) {
+		httpio.WriteError(w, r, http.StatusUnauthorized, "Don't have access to move the ticket")
+		return
+	}
+
+	phase, err := h.db.GetPhaseByUuid(request.PhaseUuid)
+	if err != nil {
+		httpio.WriteError(w, r, http.StatusNotFound, "Phase not found")
+		return
+	}
+	feature := h.db.GetFeatureByUuid(phase.FeatureUuid)
+	if feature.Uuid == "" {
+		httpio.WriteError(w, r, http.StatusNotFound, "Feature not found")
+		return
+	}
+	if feature.WorkspaceUuid != bounty.WorkspaceUuid {
+		httpio.WriteError(w, r, http.StatusBadRequest, "The phase is not in the workspace of the bounty")
+		return
+	}
+
+	if phase.Uuid != bounty.PhaseUuid {
+		from, _ := h.db.GetPhaseByUuid(bounty.PhaseUuid)
+		if from.FeatureUuid != phase.FeatureUuid && !h.withinFeatureBudget(w, r, bounty, phase.FeatureUuid) {
+			return
+		}
+		if !withinPhaseGate(w, r, h.db, phase) {
+			return
+		}
+	}
+
+	moved, err := h.db.MoveTicket(bounty.ID, phase.Uuid, request.Position)
+	if err != nil {
+		fmt.Println("[bounty]", err)
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to move the ticket")
+		return
+	}
+
+	events.Publish(r.Context(), events.TicketUpdated, websocket.Topic(websocket.TopicTicket, moved.ID), map[string]interface{}{
+		"bounty_id":         moved.ID,
+		"name":              moved.Title,
+		"owner_id":          moved.OwnerID,
+		"assignee":          moved.Assignee,
+		"workspace_uuid":    moved.WorkspaceUuid,
+		"moved":             true,
+		"from_phase_uuid":   bounty.PhaseUuid,
+		"to_phase_uuid":     moved.PhaseUuid,
+		"to_feature_uuid":   feature.Uuid,
+		"to_phase_name":     phase.Name,
+		"phase_priority":    moved.PhasePriority,
+		"previous_priority": bounty.PhasePriority,
+	})
+
+	w.Header().Set("ETag", strconv.Quote(strconv.Itoa(moved.Version)))
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(moved)
+}
@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/handlers/mocks"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestMoveTicket(t *testing.T) {
+	bounty := db.NewBounty{ID: 7, Title: "Add the wallet", OwnerID: "owner-pubkey", WorkspaceUuid: "workspace-a", PhaseUuid: "phase-a", PhasePriority: 2}
+	phaseA := db.FeaturePhase{Uuid: "phase-a", FeatureUuid: "feature-a", Name: "Build"}
+	phaseB := db.FeaturePhase{Uuid: "phase-b", FeatureUuid: "feature-a", Name: "Launch"}
+	feature := db.WorkspaceFeatures{Uuid: "feature-a", WorkspaceUuid: "workspace-a"}
+
+	serve := func(mockDb *dbMocks.Database, manager bool, pubkey string, body string) *httptest.ResponseRecorder {
+		h := NewBountyHandler(&mocks.HttpClient{}, mockDb)
+		h.userHasManageBountyRoles = func(pubKeyFromAuth string, uuid string) bool { return manager }
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", "7")
+		ctx := context.WithValue(context.WithValue(context.Background(), chi.RouteCtxKey, rctx), auth.ContextKey, pubkey)
+		req, _ := http.NewRequestWithContext(ctx, http.MethodPut, "/gobounties/7/move", bytes.NewBufferString(body))
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(h.MoveTicket).ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("should move the ticket to another phase of the feature", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		moved := bounty
+		moved.PhaseUuid, moved.PhasePriority, moved.Version = "phase-b", 1, 3
+		mockDb.On("GetBounty", uint(7)).Return(bounty).Once()
+		mockDb.On("GetPhaseByUuid", "phase-b").Return(phaseB, nil).Once()
+		mockDb.On("GetFeatureByUuid", "feature-a").Return(feature).Once()
+		mockDb.On("GetPhaseByUuid", "phase-a").Return(phaseA, nil).Once()
+		mockDb.On("MoveTicket", uint(7), "phase-b", 1).Return(moved, nil).Once()
+		mockDb.On("CreateEvent", mock.Anything).Return(db.Event{}, nil).Maybe()
+
+		rr := serve(mockDb, true, "manager-pubkey", `{"phase_uuid": "phase-b", "position": 1}`)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, `"3"`, rr.Header().Get("ETag"))
+		res := db.NewBounty{}
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &res))
+		assert.Equal(t, "phase-b", res.PhaseUuid)
+		assert.Equal(t, 1, res.PhasePriority)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should let the owner reorder the ticket in its phase", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mockDb.On("GetBounty", uint(7)).Return(bounty).Once()
+		mockDb.On("GetPhaseByUuid", "phase-a").Return(phaseA, nil).Once()
+		mockDb.On("GetFeatureByUuid", "feature-a").Return(feature).Once()
+		mockDb.On("MoveTicket", uint(7), "phase-a", 0).Return(bounty, nil).Once()
+		mockDb.On("CreateEvent", mock.Anything).Return(db.Event{}, nil).Maybe()
+
+		rr := serve(mockDb, false, "owner-pubkey", `{"phase_uuid": "phase-a"}`)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should refuse a phase of another workspace", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mockDb.On("GetBounty", uint(7)).Return(bounty).Once()
+		mockDb.On("GetPhaseByUuid", "phase-x").Return(db.FeaturePhase{Uuid: "phase-x", FeatureUuid: "feature-x"}, nil).Once()
+		mockDb.On("GetFeatureByUuid", "feature-x").Return(db.WorkspaceFeatures{Uuid: "feature-x", WorkspaceUuid: "workspace-x"}).Once()
+
+		rr := serve(mockDb, true, "manager-pubkey", `{"phase_uuid": "phase-x"}`)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockDb.AssertNotCalled(t, "MoveTicket", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should refuse a missing phase", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mockDb.On("GetBounty", uint(7)).Return(bounty).Once()
+		mockDb.On("GetPhaseByUuid", "phase-x").Return(db.FeaturePhase{}, assert.AnError).Once()
+
+		rr := serve(mockDb, true, "manager-pubkey", `{"phase_uuid": "phase-x"}`)
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("should only let the owner and the bounty managers move it", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mockDb.On("GetBounty", uint(7)).Return(bounty).Once()
+
+		rr := serve(mockDb, false, "other-pubkey", `{"phase_uuid": "phase-b"}`)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		mockDb.AssertNotCalled(t, "MoveTicket", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should refuse a bounty outside of phases and an invalid request", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mockDb.On("GetBounty", uint(7)).Return(db.NewBounty{ID: 7, OwnerID: "owner-pubkey"}).Once()
+
+		rr := serve(mockDb, true, "owner-pubkey", `{"phase_uuid": "phase-b"}`)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+		rr = serve(&dbMocks.Database{}, true, "owner-pubkey", `{"position": -1}`)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/events"
+	"github.com/stakwork/sphinx-tribes/httpio"
+	"github.com/stakwork/sphinx-tribes/websocket"
+)
+
+// SetTicketStatuses moves tickets of the phase of the route to a status,
+// for the bounty managers of the workspace. Each ticket is checked on its
+// own: it has to be in the phase, the move has to be a transition of the
+// board, and a completed ticket has to meet the definition of done of the
+// workspace. The tickets that pass are changed in one transaction and the
+// result of every ticket is returned
+func (oh *featureHandler) SetTicketStatuses(w http.ResponseWriter, r *http.Request) {
+	pubKeyFromAuth, _ := r.Context().Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[features] no pubkey from auth")
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
+		return
+	}
+
+	phase, feature, ok := oh.routePhase(w, r)
+	if !ok {
+		return
+	}
+	if !oh.db.UserHasManageBountyRoles(pubKeyFromAuth, feature.WorkspaceUuid) {
+		httpio.WriteError(w, r, http.StatusUnauthorized, "Don't have access to change the tickets")
+		return
+	}
+
+	request := db.TicketStatusRequest{}
+	body, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err := json.Unmarshal(body, &request); err != nil {
+		fmt.Println("[features]", err)
+		httpio.WriteError(w, r, http.StatusNotAcceptable, "")
+		return
+	}
+	if !validatePayload(w, r, request) {
+		return
+	}
+
+	workspace := db.Workspace{}
+	if request.Status == db.TicketCompleted {
+		workspace = oh.db.GetWorkspaceByUuid(feature.WorkspaceUuid)
+	}
+
+	results := make([]db.TicketStatusResult, len(request.Tickets))
+	changes := []db.TicketStatusChange{}
+	// the place of each ticket in the results
+	seen := map[uint]int{}
+	for i, id := range request.Tickets {
+		result := db.TicketStatusResult{TicketID: id, To: request.Status, Result: db.TicketStatusRejected}
+		if _, twice := seen[id]; twice {
+			result.Error = "the ticket is listed twice"
+			results[i] = result
+			continue
+		}
+		seen[id] = i
+		bounty := oh.db.GetBounty(id)
+		switch {
+		case bounty.ID == 0 || bounty.PhaseUuid != phase.Uuid:
+			result.Error = "the ticket is not in the phase"
+		default:
+			result.From = db.TicketStatusOf(bounty)
+			result.Version = bounty.Version
+			if result.From == request.Status {
+				result.Result = db.TicketStatusUnchanged
+				break
+			}
+			if !db.TicketTransitionAllowed(result.From, request.Status) {
+				result.Error = fmt.Sprintf("a ticket can't go from %s to %s", result.From, request.Status)
+				break
+			}
+			if request.Status == db.TicketCompleted {
+				result.Unmet = unmetDoneConditions(r.Context(), workspace, bounty)
+				result.Unmet = append(result.Unmet, unmetReviewConditions(oh.db, workspace, bounty)...)
+				if len(result.Unmet) > 0 {
+					result.Error = "the ticket doesn't meet the definition of done of its workspace"
+					break
+				}
+			}
+			changes = append(changes, db.TicketStatusChange{ID: id, From: result.From, To: request.Status})
+			result.Result = db.TicketStatusUpdated
+		}
+		results[i] = result
+	}
+
+	if len(changes) > 0 {
+		updated, err := oh.db.SetTicketStatuses(phase.Uuid, changes)
+		if errors.Is(err, db.ErrTicketStatusChanged) {
+			httpio.WriteError(w, r, http.StatusConflict, "A ticket was changed meanwhile, none were updated")
+			return
+		}
+		if err != nil {
+			fmt.Println("[features]", err)
+			httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to change the tickets")
+			return
+		}
+
+		for _, bounty := range updated {
+			results[seen[bounty.ID]].Version = bounty.Version
+			events.Publish(r.Context(), events.TicketUpdated, websocket.Topic(websocket.TopicTicket, bounty.ID), map[string]interface{}{
+				"bounty_id":      bounty.ID,
+				"name":           bounty.Title,
+				"owner_id":       bounty.OwnerID,
+				"assignee":       bounty.Assignee,
+				"workspace_uuid": bounty.WorkspaceUuid,
+				"status":         db.TicketStatusOf(bounty),
+				"phase_uuid":     bounty.PhaseUuid,
+			})
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(results)
+}
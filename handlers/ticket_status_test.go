@@ -0,0 +1,123 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSetTicketStatuses(t *testing.T) {
+	feature := db.WorkspaceFeatures{Uuid: "feature-uuid", WorkspaceUuid: "workspace-uuid"}
+	phase := db.FeaturePhase{Uuid: "phase-uuid", FeatureUuid: feature.Uuid}
+	assigned := db.NewBounty{ID: 1, Title: "Add the wallet", Assignee: "hunter", PhaseUuid: phase.Uuid, WorkspaceUuid: feature.WorkspaceUuid, Version: 2}
+	completed := db.NewBounty{ID: 2, Assignee: "hunter", Completed: true, PhaseUuid: phase.Uuid, WorkspaceUuid: feature.WorkspaceUuid}
+	open := db.NewBounty{ID: 3, PhaseUuid: phase.Uuid, WorkspaceUuid: feature.WorkspaceUuid}
+	elsewhere := db.NewBounty{ID: 4, Assignee: "hunter", PhaseUuid: "other-phase"}
+
+	serve := func(mockDb *dbMocks.Database, pubkey string, body string) *httptest.ResponseRecorder {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("feature_uuid", feature.Uuid)
+		rctx.URLParams.Add("phase_uuid", phase.Uuid)
+		ctx := context.WithValue(context.WithValue(context.Background(), chi.RouteCtxKey, rctx), auth.ContextKey, pubkey)
+		req, _ := http.NewRequestWithContext(ctx, http.MethodPut, "/features/feature-uuid/phase/phase-uuid/tickets/status", bytes.NewBufferString(body))
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(NewFeatureHandler(mockDb).SetTicketStatuses).ServeHTTP(rr, req)
+		return rr
+	}
+	routed := func(mockDb *dbMocks.Database, manager bool) {
+		mockDb.On("GetFeaturePhaseByUuid", feature.Uuid, phase.Uuid).Return(phase, nil).Once()
+		mockDb.On("GetFeatureByUuid", feature.Uuid).Return(feature).Once()
+		mockDb.On("UserHasManageBountyRoles", "manager-pubkey", feature.WorkspaceUuid).Return(manager).Once()
+	}
+
+	t.Run("should return the result of every ticket", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		routed(mockDb, true)
+		mockDb.On("GetBounty", uint(1)).Return(assigned).Once()
+		mockDb.On("GetBounty", uint(2)).Return(completed).Once()
+		mockDb.On("GetBounty", uint(3)).Return(open).Once()
+		mockDb.On("GetBounty", uint(4)).Return(elsewhere).Once()
+		reopened := completed
+		reopened.Completed, reopened.Version = false, 5
+		mockDb.On("SetTicketStatuses", phase.Uuid, []db.TicketStatusChange{
+			{ID: 2, From: db.TicketCompleted, To: db.TicketAssigned},
+		}).Return([]db.NewBounty{reopened}, nil).Once()
+		mockDb.On("CreateEvent", mock.Anything).Return(db.Event{}, nil).Maybe()
+
+		rr := serve(mockDb, "manager-pubkey", `{"tickets": [1, 2, 3, 4, 2], "status": "assigned"}`)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		results := []db.TicketStatusResult{}
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &results))
+		assert.Len(t, results, 5)
+		assert.Equal(t, db.TicketStatusUnchanged, results[0].Result)
+		assert.Equal(t, db.TicketStatusUpdated, results[1].Result)
+		assert.Equal(t, db.TicketCompleted, results[1].From)
+		assert.Equal(t, 5, results[1].Version)
+		assert.Equal(t, db.TicketStatusRejected, results[2].Result)
+		assert.Equal(t, "a ticket can't go from open to assigned", results[2].Error)
+		assert.Equal(t, "the ticket is not in the phase", results[3].Error)
+		assert.Equal(t, "the ticket is listed twice", results[4].Error)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should not complete a ticket that doesn't meet the definition of done", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		routed(mockDb, true)
+		mockDb.On("GetWorkspaceByUuid", feature.WorkspaceUuid).Return(db.Workspace{Uuid: feature.WorkspaceUuid, DoneRequiresChecklist: true}).Once()
+		unchecked := assigned
+		unchecked.Description = "- [ ] write the tests"
+		mockDb.On("GetBounty", uint(1)).Return(unchecked).Once()
+
+		rr := serve(mockDb, "manager-pubkey", `{"tickets": [1], "status": "completed"}`)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		results := []db.TicketStatusResult{}
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &results))
+		assert.Equal(t, db.TicketStatusRejected, results[0].Result)
+		assert.Len(t, results[0].Unmet, 1)
+		mockDb.AssertNotCalled(t, "SetTicketStatuses", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should not update any ticket when one changed meanwhile", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		routed(mockDb, true)
+		mockDb.On("GetBounty", uint(1)).Return(assigned).Once()
+		mockDb.On("SetTicketStatuses", phase.Uuid, mock.Anything).Return(nil, db.ErrTicketStatusChanged).Once()
+
+		rr := serve(mockDb, "manager-pubkey", `{"tickets": [1], "status": "open"}`)
+
+		assert.Equal(t, http.StatusConflict, rr.Code)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should only let the bounty managers change the tickets", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		routed(mockDb, false)
+
+		rr := serve(mockDb, "manager-pubkey", `{"tickets": [1], "status": "open"}`)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		mockDb.AssertNotCalled(t, "GetBounty", mock.Anything)
+	})
+
+	t.Run("should refuse an invalid request", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		routed(mockDb, true)
+
+		rr := serve(mockDb, "manager-pubkey", `{"tickets": [1], "status": "paid"}`)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockDb.AssertNotCalled(t, "GetBounty", mock.Anything)
+	})
+}
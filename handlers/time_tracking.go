@@ -0,0 +1,293 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/rs/xid"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/httpio"
+	"github.com/stakwork/sphinx-tribes/utils"
+	"gorm.io/gorm"
+)
+
+type timeHandler struct {
+	db            db.Database
+	userHasAccess func(pubKeyFromAuth string, uuid string, role string) bool
+}
+
+func NewTimeHandler(database db.Database) *timeHandler {
+	dbConf := db.NewDatabaseConfig(&gorm.DB{})
+	return &timeHandler{
+		db:            database,
+		userHasAccess: dbConf.UserHasAccess,
+	}
+}
+
+// bountyOf writes the error and returns false unless the caller can track
+// time on the bounty of the route: its assignee, its owner or a member of
+// its workspace
+func (th *timeHandler) bountyOf(w http.ResponseWriter, r *http.Request) (string, db.NewBounty, bool) {
+	pubKeyFromAuth, _ := r.Context().Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[time] no pubkey from auth")
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
+		return "", db.NewBounty{}, false
+	}
+
+	id, err := utils.ConvertStringToUint(chi.URLParam(r, "id"))
+	if err != nil {
+		httpio.WriteError(w, r, http.StatusBadRequest, "Invalid bounty id")
+		return "", db.NewBounty{}, false
+	}
+	bounty := th.db.GetBounty(id)
+	if bounty.ID == 0 {
+		httpio.WriteError(w, r, http.StatusNotFound, "Bounty not found")
+		return "", bounty, false
+	}
+
+	if pubKeyFromAuth != bounty.Assignee && pubKeyFromAuth != bounty.OwnerID &&
+		(bounty.WorkspaceUuid == "" || !isWorkspaceMember(th.db, pubKeyFromAuth, bounty.WorkspaceUuid)) {
+		httpio.WriteError(w, r, http.StatusUnauthorized, "Don't have access to track time on this bounty")
+		return "", bounty, false
+	}
+	return pubKeyFromAuth, bounty, true
+}
+
+// StartTimer starts a timer on a bounty, a person runs one timer at a time
+func (th *timeHandler) StartTimer(w http.ResponseWriter, r *http.Request) {
+	pubKeyFromAuth, bounty, ok := th.bountyOf(w, r)
+	if !ok {
+		return
+	}
+
+	if running, err := th.db.GetRunningTimeEntry(pubKeyFromAuth); err == nil {
+		httpio.WriteErrorDetails(w, r, http.StatusConflict, "A timer is already running", map[string]interface{}{
+			"bounty_id": running.BountyId,
+			"uuid":      running.Uuid,
+		})
+		return
+	}
+
+	now := time.Now()
+	entry, err := th.db.CreateTimeEntry(db.TimeEntry{
+		Uuid:          xid.New().String(),
+		BountyId:      bounty.ID,
+		WorkspaceUuid: bounty.WorkspaceUuid,
+		PubKey:        pubKeyFromAuth,
+		Started:       &now,
+	})
+	if err != nil {
+		fmt.Println("[time]", err)
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to start the timer")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(entry)
+}
+
+// StopTimer stops the timer the caller runs on a bounty
+func (th *timeHandler) StopTimer(w http.ResponseWriter, r *http.Request) {
+	pubKeyFromAuth, bounty, ok := th.bountyOf(w, r)
+	if !ok {
+		return
+	}
+
+	running, err := th.db.GetRunningTimeEntry(pubKeyFromAuth)
+	if err != nil || running.BountyId != bounty.ID {
+		httpio.WriteError(w, r, http.StatusNotFound, "No timer running on this bounty")
+		return
+	}
+
+	entry, err := th.db.StopTimeEntry(running, time.Now())
+	if err != nil {
+		fmt.Println("[time]", err)
+		httpio.WriteError(w, r, http.StatusConflict, "Failed to stop the timer")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(entry)
+}
+
+// AddTimeEntry records time spent on a bounty without a timer
+func (th *timeHandler) AddTimeEntry(w http.ResponseWriter, r *http.Request) {
+	pubKeyFromAuth, bounty, ok := th.bountyOf(w, r)
+	if !ok {
+		return
+	}
+
+	request := db.ManualTimeEntry{}
+	body, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err := json.Unmarshal(body, &request); err != nil {
+		fmt.Println("[time]", err)
+		httpio.WriteError(w, r, http.StatusNotAcceptable, "")
+		return
+	}
+	if !validatePayload(w, r, request) {
+		return
+	}
+
+	started := time.Now().Add(-time.Duration(request.Minutes) * time.Minute)
+	if request.Date != "" {
+		date, err := time.Parse("2006-01-02", request.Date)
+		if err != nil {
+			httpio.WriteError(w, r, http.StatusBadRequest, "date is YYYY-MM-DD")
+			return
+		}
+		started = date
+	}
+	ended := started.Add(time.Duration(request.Minutes) * time.Minute)
+
+	entry, err := th.db.CreateTimeEntry(db.TimeEntry{
+		Uuid:          xid.New().String(),
+		BountyId:      bounty.ID,
+		WorkspaceUuid: bounty.WorkspaceUuid,
+		PubKey:        pubKeyFromAuth,
+		Started:       &started,
+		Ended:         &ended,
+		Minutes:       request.Minutes,
+		Manual:        true,
+		Note:          request.Note,
+	})
+	if err != nil {
+		fmt.Println("[time]", err)
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to add the time entry")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(entry)
+}
+
+func (th *timeHandler) GetBountyTimeEntries(w http.ResponseWriter, r *http.Request) {
+	_, bounty, ok := th.bountyOf(w, r)
+	if !ok {
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(th.db.GetBountyTimeEntries(bounty.ID))
+}
+
+// DeleteTimeEntry deletes a time entry of the caller
+func (th *timeHandler) DeleteTimeEntry(w http.ResponseWriter, r *http.Request) {
+	pubKeyFromAuth, bounty, ok := th.bountyOf(w, r)
+	if !ok {
+		return
+	}
+
+	entry, err := th.db.GetTimeEntry(chi.URLParam(r, "entry_uuid"))
+	if err != nil || entry.BountyId != bounty.ID || entry.PubKey != pubKeyFromAuth {
+		httpio.WriteError(w, r, http.StatusNotFound, "Time entry not found")
+		return
+	}
+
+	if err := th.db.DeleteTimeEntry(entry.Uuid); err != nil {
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to delete the time entry")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(true)
+}
+
+// GetWorkspaceTime reports the time tracked on the bounties of a workspace,
+// ?from= and ?to= (YYYY-MM-DD, both included) narrow it to a period
+func (th *timeHandler) GetWorkspaceTime(w http.ResponseWriter, r *http.Request) {
+	pubKeyFromAuth, _ := r.Context().Value(auth.ContextKey).(string)
+	workspaceUuid := chi.URLParam(r, "workspace_uuid")
+	if pubKeyFromAuth == "" || !th.userHasAccess(pubKeyFromAuth, workspaceUuid, db.ViewReport) {
+		httpio.WriteError(w, r, http.StatusUnauthorized, "Don't have access to the reports of this workspace")
+		return
+	}
+
+	from, to, ok := timeRange(w, r)
+	if !ok {
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(timeReport(th.db.GetTimeTotals(workspaceUuid, "", from, to)))
+}
+
+// GetMyTime reports the time the caller tracked, on every workspace
+func (th *timeHandler) GetMyTime(w http.ResponseWriter, r *http.Request) {
+	pubKeyFromAuth, _ := r.Context().Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
+		return
+	}
+
+	from, to, ok := timeRange(w, r)
+	if !ok {
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(timeReport(th.db.GetTimeTotals("", pubKeyFromAuth, from, to)))
+}
+
+// timeRange reads the from and to days of a report, to is included
+func timeRange(w http.ResponseWriter, r *http.Request) (*time.Time, *time.Time, bool) {
+	var from, to *time.Time
+	if value := r.URL.Query().Get("from"); value != "" {
+		day, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			httpio.WriteError(w, r, http.StatusBadRequest, "from is YYYY-MM-DD")
+			return nil, nil, false
+		}
+		from = &day
+	}
+	if value := r.URL.Query().Get("to"); value != "" {
+		day, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			httpio.WriteError(w, r, http.StatusBadRequest, "to is YYYY-MM-DD")
+			return nil, nil, false
+		}
+		day = day.AddDate(0, 0, 1)
+		to = &day
+	}
+	return from, to, true
+}
+
+// timeReport sums the totals per person and bounty into the totals per
+// person and per bounty
+func timeReport(totals []db.TimeTotal) db.TimeReport {
+	report := db.TimeReport{ByPerson: []db.TimeTotal{}, ByBounty: []db.TimeTotal{}}
+	people := map[string]int{}
+	bounties := map[uint]int{}
+	for _, total := range totals {
+		report.Minutes += total.Minutes
+
+		i, ok := people[total.PubKey]
+		if !ok {
+			i = len(report.ByPerson)
+			people[total.PubKey] = i
+			report.ByPerson = append(report.ByPerson, db.TimeTotal{PubKey: total.PubKey})
+		}
+		report.ByPerson[i].Minutes += total.Minutes
+		report.ByPerson[i].Entries += total.Entries
+
+		j, ok := bounties[total.BountyId]
+		if !ok {
+			j = len(report.ByBounty)
+			bounties[total.BountyId] = j
+			report.ByBounty = append(report.ByBounty, db.TimeTotal{BountyId: total.BountyId, WorkspaceUuid: total.WorkspaceUuid})
+		}
+		report.ByBounty[j].Minutes += total.Minutes
+		report.ByBounty[j].Entries += total.Entries
+	}
+
+	sort.SliceStable(report.ByPerson, func(i, j int) bool { return report.ByPerson[i].Minutes > report.ByPerson[j].Minutes })
+	sort.SliceStable(report.ByBounty, func(i, j int) bool { return report.ByBounty[i].Minutes > report.ByBounty[j].Minutes })
+	return report
+}
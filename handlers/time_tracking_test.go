@@ -0,0 +1,194 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestTimeTracking(t *testing.T) {
+	bounty := db.NewBounty{ID: 7, WorkspaceUuid: "workspace-uuid", OwnerID: "owner-pubkey", Assignee: "hunter-pubkey"}
+	newRequest := func(pubkey string, entryUuid string, target string, body string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", "7")
+		rctx.URLParams.Add("entry_uuid", entryUuid)
+		rctx.URLParams.Add("workspace_uuid", "workspace-uuid")
+		ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+		ctx = context.WithValue(ctx, auth.ContextKey, pubkey)
+		req, _ := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewBufferString(body))
+		return req
+	}
+
+	t.Run("should start a timer for the assignee", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		tHandler := NewTimeHandler(mockDb)
+
+		mockDb.On("GetBounty", uint(7)).Return(bounty).Once()
+		mockDb.On("GetRunningTimeEntry", "hunter-pubkey").Return(db.TimeEntry{}, errors.New("record not found")).Once()
+		mockDb.On("CreateTimeEntry", mock.MatchedBy(func(e db.TimeEntry) bool {
+			return e.BountyId == 7 && e.PubKey == "hunter-pubkey" && e.WorkspaceUuid == "workspace-uuid" && e.Started != nil && e.Ended == nil
+		})).Return(func(e db.TimeEntry) (db.TimeEntry, error) { return e, nil }).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(tHandler.StartTimer).ServeHTTP(rr, newRequest("hunter-pubkey", "", "/gobounties/7/time/start", ""))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should refuse a second running timer", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		tHandler := NewTimeHandler(mockDb)
+
+		mockDb.On("GetBounty", uint(7)).Return(bounty).Once()
+		mockDb.On("GetRunningTimeEntry", "hunter-pubkey").Return(db.TimeEntry{Uuid: "running-uuid", BountyId: 3}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(tHandler.StartTimer).ServeHTTP(rr, newRequest("hunter-pubkey", "", "/gobounties/7/time/start", ""))
+
+		assert.Equal(t, http.StatusConflict, rr.Code)
+		mockDb.AssertNotCalled(t, "CreateTimeEntry", mock.Anything)
+	})
+
+	t.Run("should refuse people outside of the bounty and its workspace", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		tHandler := NewTimeHandler(mockDb)
+
+		mockDb.On("GetBounty", uint(7)).Return(bounty).Once()
+		mockDb.On("GetWorkspaceByUuid", "workspace-uuid").Return(db.Workspace{Uuid: "workspace-uuid", OwnerPubKey: "owner-pubkey"}).Once()
+		mockDb.On("GetWorkspaceUser", "stranger-pubkey", "workspace-uuid").Return(db.WorkspaceUsers{}).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(tHandler.StartTimer).ServeHTTP(rr, newRequest("stranger-pubkey", "", "/gobounties/7/time/start", ""))
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should stop the timer running on the bounty", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		tHandler := NewTimeHandler(mockDb)
+
+		started := time.Now().Add(-90 * time.Minute)
+		running := db.TimeEntry{Uuid: "running-uuid", BountyId: 7, PubKey: "hunter-pubkey", Started: &started}
+		mockDb.On("GetBounty", uint(7)).Return(bounty).Once()
+		mockDb.On("GetRunningTimeEntry", "hunter-pubkey").Return(running, nil).Once()
+		mockDb.On("StopTimeEntry", running, mock.AnythingOfType("time.Time")).Return(db.TimeEntry{Uuid: "running-uuid", Minutes: 90}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(tHandler.StopTimer).ServeHTTP(rr, newRequest("hunter-pubkey", "", "/gobounties/7/time/stop", ""))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should not stop a timer running on another bounty", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		tHandler := NewTimeHandler(mockDb)
+
+		mockDb.On("GetBounty", uint(7)).Return(bounty).Once()
+		mockDb.On("GetRunningTimeEntry", "hunter-pubkey").Return(db.TimeEntry{Uuid: "running-uuid", BountyId: 3}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(tHandler.StopTimer).ServeHTTP(rr, newRequest("hunter-pubkey", "", "/gobounties/7/time/stop", ""))
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		mockDb.AssertNotCalled(t, "StopTimeEntry", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should add a manual time entry on a day", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		tHandler := NewTimeHandler(mockDb)
+
+		mockDb.On("GetBounty", uint(7)).Return(bounty).Once()
+		mockDb.On("CreateTimeEntry", mock.MatchedBy(func(e db.TimeEntry) bool {
+			return e.Manual && e.Minutes == 120 && e.Note == "review" &&
+				e.Started.Format("2006-01-02") == "2026-10-01" && e.Ended.Sub(*e.Started) == 2*time.Hour
+		})).Return(func(e db.TimeEntry) (db.TimeEntry, error) { return e, nil }).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(tHandler.AddTimeEntry).ServeHTTP(rr, newRequest("owner-pubkey", "", "/gobounties/7/time", `{"minutes": 120, "date": "2026-10-01", "note": "review"}`))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should validate a manual time entry", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		tHandler := NewTimeHandler(mockDb)
+
+		mockDb.On("GetBounty", uint(7)).Return(bounty).Twice()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(tHandler.AddTimeEntry).ServeHTTP(rr, newRequest("hunter-pubkey", "", "/gobounties/7/time", `{"minutes": 2000}`))
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+		rr = httptest.NewRecorder()
+		http.HandlerFunc(tHandler.AddTimeEntry).ServeHTTP(rr, newRequest("hunter-pubkey", "", "/gobounties/7/time", `{"minutes": 30, "date": "01/10/2026"}`))
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+		mockDb.AssertNotCalled(t, "CreateTimeEntry", mock.Anything)
+	})
+
+	t.Run("should only delete the entries of the caller", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		tHandler := NewTimeHandler(mockDb)
+
+		mockDb.On("GetBounty", uint(7)).Return(bounty).Once()
+		mockDb.On("GetTimeEntry", "entry-uuid").Return(db.TimeEntry{Uuid: "entry-uuid", BountyId: 7, PubKey: "hunter-pubkey"}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(tHandler.DeleteTimeEntry).ServeHTTP(rr, newRequest("owner-pubkey", "entry-uuid", "/gobounties/7/time/entry-uuid", ""))
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		mockDb.AssertNotCalled(t, "DeleteTimeEntry", mock.Anything)
+	})
+
+	t.Run("should report the workspace time per person and per bounty", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		tHandler := NewTimeHandler(mockDb)
+		tHandler.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool { return role == db.ViewReport }
+
+		from := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+		mockDb.On("GetTimeTotals", "workspace-uuid", "", &from, &to).Return([]db.TimeTotal{
+			{PubKey: "alice", BountyId: 1, Minutes: 60, Entries: 1},
+			{PubKey: "alice", BountyId: 2, Minutes: 30, Entries: 2},
+			{PubKey: "bob", BountyId: 2, Minutes: 120, Entries: 1},
+		}).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(tHandler.GetWorkspaceTime).ServeHTTP(rr, newRequest("owner-pubkey", "", "/workspaces/workspace-uuid/time?from=2026-10-01&to=2026-10-31", ""))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		report := db.TimeReport{}
+		json.Unmarshal(rr.Body.Bytes(), &report)
+		assert.Equal(t, uint(210), report.Minutes)
+		assert.Equal(t, []db.TimeTotal{{PubKey: "bob", Minutes: 120, Entries: 1}, {PubKey: "alice", Minutes: 90, Entries: 3}}, report.ByPerson)
+		assert.Equal(t, []db.TimeTotal{{BountyId: 2, Minutes: 150, Entries: 3}, {BountyId: 1, Minutes: 60, Entries: 1}}, report.ByBounty)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should refuse the workspace report without the view report role", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		tHandler := NewTimeHandler(mockDb)
+		tHandler.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool { return false }
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(tHandler.GetWorkspaceTime).ServeHTTP(rr, newRequest("hunter-pubkey", "", "/workspaces/workspace-uuid/time", ""))
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+}
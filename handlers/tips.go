@@ -0,0 +1,267 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/events"
+	"github.com/stakwork/sphinx-tribes/httpio"
+	"github.com/stakwork/sphinx-tribes/upstream"
+	"gorm.io/gorm"
+)
+
+type tipHandler struct {
+	httpClient    HttpClient
+	db            db.Database
+	userHasAccess func(pubKeyFromAuth string, uuid string, role string) bool
+}
+
+func NewTipHandler(httpClient HttpClient, database db.Database) *tipHandler {
+	dbConf := db.NewDatabaseConfig(&gorm.DB{})
+	return &tipHandler{
+		httpClient:    httpClient,
+		db:            database,
+		userHasAccess: dbConf.UserHasAccess,
+	}
+}
+
+// TipPerson tips the person of the route. A tip with a workspace_uuid is
+// sent right away from the workspace budget by a user who can pay its
+// bounties, any other tip is an invoice for the caller to pay, sent on to
+// the person once it settles
+func (th *tipHandler) TipPerson(w http.ResponseWriter, r *http.Request) {
+	pubKeyFromAuth, _ := r.Context().Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[tips] no pubkey from auth")
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
+		return
+	}
+
+	tip := db.TipRequest{}
+	body, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err := json.Unmarshal(body, &tip); err != nil {
+		fmt.Println("[tips]", err)
+		httpio.WriteError(w, r, http.StatusNotAcceptable, "")
+		return
+	}
+	if !validatePayload(w, r, tip) {
+		return
+	}
+
+	receiver := th.db.GetPersonByPubkey(chi.URLParam(r, "pubkey"))
+	if receiver.OwnerPubKey == "" {
+		httpio.WriteError(w, r, http.StatusNotFound, "Person not found")
+		return
+	}
+	if receiver.OwnerPubKey == pubKeyFromAuth {
+		httpio.WriteError(w, r, http.StatusBadRequest, "You can't tip yourself")
+		return
+	}
+
+	now := time.Now()
+	payment := db.NewPaymentHistory{
+		Amount:         tip.Amount,
+		PaymentType:    db.Tip,
+		WorkspaceUuid:  tip.WorkspaceUuid,
+		SenderPubKey:   pubKeyFromAuth,
+		ReceiverPubKey: receiver.OwnerPubKey,
+		Memo:           strings.TrimSpace(tip.Memo),
+		Created:        &now,
+		Updated:        &now,
+	}
+	if tip.WorkspaceUuid == "" {
+		th.invoiceTip(w, r, receiver, payment)
+		return
+	}
+
+	if !th.userHasAccess(pubKeyFromAuth, tip.WorkspaceUuid, db.PayBounty) {
+		httpio.WriteError(w, r, http.StatusUnauthorized, "You don't have appropriate permissions to pay bounties")
+		return
+	}
+
+	if th.db.GetWorkspaceBudget(tip.WorkspaceUuid).TotalBudget < tip.Amount {
+		httpio.WriteError(w, r, http.StatusForbidden, "workspace budget is not enough to pay the amount")
+		return
+	}
+
+	// the tip is taken from the budget before it is sent, so tips and
+	// payouts sent at the same time can't spend the budget twice
+	payment, err := th.db.CreatePendingPayment(payment)
+	if errors.Is(err, db.ErrBudgetNotEnough) {
+		httpio.WriteError(w, r, http.StatusForbidden, "workspace budget is not enough to pay the amount")
+		return
+	}
+	if err != nil {
+		log.Printf("[tips] could not record the tip to %s: %s", receiver.OwnerPubKey, err)
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Could not record the tip")
+		return
+	}
+
+	paymentHash, _, paid, err := relayKeysend(r.Context(), th.httpClient, tip.Amount, receiver)
+	if err != nil && upstream.Attempted(err) {
+		// the tip may still settle, it stays pending until an admin
+		// reconciles it
+		log.Printf("[tips] the outcome of the tip to %s is unknown, it is pending: %s", receiver.OwnerPubKey, err)
+		if paymentHash != "" {
+			payment.PaymentHash = paymentHash
+			if err := th.db.SetPaymentHash(payment.ID, paymentHash); err != nil {
+				log.Printf("[tips] could not record the payment hash of the pending tip %d: %s", payment.ID, err)
+			}
+		}
+		w.WriteHeader(http.StatusAccepted)
+		json.NewEncoder(w).Encode(db.TipResponse{Payment: &payment})
+		return
+	}
+	if err != nil || !paid {
+		failPayment(th.db, payment.ID)
+		if err != nil {
+			upstream.WriteError(w, r, err, "The tip payment failed")
+			return
+		}
+		httpio.WriteError(w, r, http.StatusBadGateway, "The tip payment failed")
+		return
+	}
+
+	if settled, err := th.db.ReconcilePayment(payment.ID, true, paymentHash); err != nil {
+		log.Printf("[tips] keysend to %s succeeded but the tip could not be recorded: %s", receiver.OwnerPubKey, err)
+		payment.Status = true
+		payment.State = ""
+		payment.PaymentHash = paymentHash
+	} else {
+		payment = settled
+	}
+	events.Publish(r.Context(), events.TipReceived, fmt.Sprintf("payment:%d", payment.ID), payment)
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(db.TipResponse{Payment: &payment})
+}
+
+// invoiceTip creates the invoice the caller pays for a tip, the tip waits
+// in the payment history until the invoice settles
+func (th *tipHandler) invoiceTip(w http.ResponseWriter, r *http.Request, receiver db.Person, payment db.NewPaymentHistory) {
+	memo := "Tip"
+	if payment.Memo != "" {
+		memo = "Tip: " + payment.Memo
+	}
+	invoiceRes, err := relayInvoice(r.Context(), th.httpClient, payment.Amount, memo)
+	if err != nil {
+		upstream.WriteError(w, r, err, "Could not create the invoice")
+		return
+	}
+
+	paymentRequest := invoiceRes.Response.Invoice
+	payment.PaymentRequest = paymentRequest
+	err = th.db.CreateTipInvoice(db.NewInvoiceList{
+		PaymentRequest: paymentRequest,
+		Type:           db.TipInvoice,
+		OwnerPubkey:    payment.SenderPubKey,
+		Created:        payment.Created,
+		Updated:        payment.Updated,
+	}, db.UserInvoiceData{
+		PaymentRequest: paymentRequest,
+		Created:        int(payment.Created.Unix()),
+		Amount:         payment.Amount,
+		UserPubkey:     receiver.OwnerPubKey,
+		RouteHint:      receiver.OwnerRouteHint,
+	}, payment)
+	if err != nil {
+		log.Printf("[tips] could not save the tip invoice: %s", err)
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Could not save the invoice")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(db.TipResponse{Invoice: paymentRequest})
+}
+
+// relayInvoice creates an invoice of the relay node
+func relayInvoice(ctx context.Context, httpClient HttpClient, amount uint, memo string) (db.InvoiceResponse, error) {
+	invoiceRes := db.InvoiceResponse{}
+	jsonBody, _ := json.Marshal(map[string]interface{}{"amount": amount, "memo": memo})
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("%s/invoices", config.RelayUrl), bytes.NewBuffer(jsonBody))
+	req.Header.Set("x-user-token", config.RelayAuthKey)
+	req.Header.Set("Content-Type", "application/json")
+	res, err := httpClient.Do(req)
+	if err != nil {
+		log.Printf("[relay] Request Failed: %s", err)
+		return invoiceRes, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode >= 300 {
+		return invoiceRes, upstream.StatusError(upstream.Relay, res)
+	}
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return invoiceRes, err
+	}
+	err = json.Unmarshal(body, &invoiceRes)
+	return invoiceRes, err
+}
+
+// settleTip sends the tip of a settled invoice on to its receiver and
+// records it. The invoice is claimed first, so only one poll sends the
+// tip. A keysend that never reached the relay gives the claim back for
+// the next poll. A keysend the relay didn't answer, or that is in flight,
+// is marked pending until an admin reconciles it, and a refused one is
+// marked failed and not sent again
+func settleTip(ctx context.Context, httpClient HttpClient, database db.Database, paymentRequest string) {
+	claimed, err := database.UpdateInvoice(paymentRequest)
+	if err != nil {
+		log.Printf("[tips] could not settle the tip invoice %s: %s", paymentRequest, err)
+		return
+	}
+	if !claimed {
+		return
+	}
+
+	invData := database.GetUserInvoiceData(paymentRequest)
+	receiver := db.Person{OwnerPubKey: invData.UserPubkey, OwnerRouteHint: invData.RouteHint}
+	_, _, paid, err := relayKeysend(ctx, httpClient, invData.Amount, receiver)
+	if err != nil && !upstream.Attempted(err) {
+		log.Printf("[tips] keysend of the tip to %s was not sent: %s", invData.UserPubkey, err)
+		if err := database.ReleaseInvoice(paymentRequest); err != nil {
+			log.Printf("[tips] could not release the tip invoice %s: %s", paymentRequest, err)
+		}
+		return
+	}
+	if err != nil {
+		// the tip may still settle, it must not be sent again
+		log.Printf("[tips] the outcome of the tip to %s is unknown, it is pending: %s", invData.UserPubkey, err)
+		markTip(database, paymentRequest, db.PaymentPending)
+		return
+	}
+	if !paid {
+		log.Printf("[tips] keysend of the tip to %s was refused", invData.UserPubkey)
+		markTip(database, paymentRequest, db.PaymentFailed)
+		return
+	}
+
+	payment, err := database.SettleTip(paymentRequest)
+	if err != nil {
+		log.Printf("[tips] keysend to %s succeeded but the tip could not be recorded: %s", invData.UserPubkey, err)
+		return
+	}
+	events.Publish(ctx, events.TipReceived, fmt.Sprintf("payment:%d", payment.ID), payment)
+}
+
+// markTip records the state of a tip that didn't settle
+func markTip(database db.Database, paymentRequest string, state string) {
+	if err := database.MarkTipState(paymentRequest, state); err != nil {
+		log.Printf("[tips] the %s tip of %s could not be recorded: %s", state, paymentRequest, err)
+	}
+}
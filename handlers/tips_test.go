@@ -0,0 +1,281 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/handlers/mocks"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stakwork/sphinx-tribes/upstream"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestTipPerson(t *testing.T) {
+	receiver := db.Person{OwnerPubKey: "helper-pubkey", OwnerRouteHint: "route-hint"}
+	newRequest := func(pubkey string, body string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("pubkey", receiver.OwnerPubKey)
+		ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+		ctx = context.WithValue(ctx, auth.ContextKey, pubkey)
+		req, _ := http.NewRequestWithContext(ctx, http.MethodPost, "/people/helper-pubkey/tip", bytes.NewBufferString(body))
+		return req
+	}
+	relayResponse := func(body string) *http.Response {
+		return &http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewReader([]byte(body)))}
+	}
+
+	t.Run("should keysend a tip from the workspace budget", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mockHttpClient := &mocks.HttpClient{}
+		tHandler := NewTipHandler(mockHttpClient, mockDb)
+		tHandler.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool { return role == db.PayBounty }
+
+		mockDb.On("GetPersonByPubkey", "helper-pubkey").Return(receiver).Once()
+		mockDb.On("GetWorkspaceBudget", "workspace-uuid").Return(db.NewBountyBudget{TotalBudget: 1000}).Once()
+		mockDb.On("CreatePendingPayment", mock.MatchedBy(func(p db.NewPaymentHistory) bool {
+			return p.PaymentType == db.Tip && p.Amount == 100 && p.Memo == "thanks for the answer" &&
+				p.SenderPubKey == "tipper-pubkey" && p.ReceiverPubKey == "helper-pubkey" && p.WorkspaceUuid == "workspace-uuid"
+		})).Return(func(p db.NewPaymentHistory) (db.NewPaymentHistory, error) {
+			p.ID = 9
+			p.State = db.PaymentPending
+			return p, nil
+		}).Once()
+		mockHttpClient.On("Do", mock.AnythingOfType("*http.Request")).Return(relayResponse(`{"success": true, "response": {"payment_hash": "hash"}}`), nil).Once()
+		mockDb.On("ReconcilePayment", uint(9), true, "hash").Return(db.NewPaymentHistory{ID: 9, Status: true, PaymentHash: "hash"}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		body := `{"amount": 100, "memo": " thanks for the answer ", "workspace_uuid": "workspace-uuid"}`
+		http.HandlerFunc(tHandler.TipPerson).ServeHTTP(rr, newRequest("tipper-pubkey", body))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var response db.TipResponse
+		json.Unmarshal(rr.Body.Bytes(), &response)
+		assert.Equal(t, uint(9), response.Payment.ID)
+		assert.True(t, response.Payment.Status)
+		mockDb.AssertExpectations(t)
+		mockHttpClient.AssertExpectations(t)
+	})
+
+	t.Run("should give the tip back to the budget when the keysend is refused", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mockHttpClient := &mocks.HttpClient{}
+		tHandler := NewTipHandler(mockHttpClient, mockDb)
+		tHandler.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool { return true }
+
+		mockDb.On("GetPersonByPubkey", "helper-pubkey").Return(receiver).Once()
+		mockDb.On("GetWorkspaceBudget", "workspace-uuid").Return(db.NewBountyBudget{TotalBudget: 1000}).Once()
+		mockDb.On("CreatePendingPayment", mock.AnythingOfType("db.NewPaymentHistory")).Return(db.NewPaymentHistory{ID: 9, State: db.PaymentPending}, nil).Once()
+		mockHttpClient.On("Do", mock.AnythingOfType("*http.Request")).Return(&http.Response{
+			StatusCode: 400,
+			Body:       io.NopCloser(bytes.NewReader([]byte(`{"success": false}`))),
+		}, nil).Once()
+		mockDb.On("ReconcilePayment", uint(9), false, "").Return(db.NewPaymentHistory{ID: 9, State: db.PaymentFailed}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(tHandler.TipPerson).ServeHTTP(rr, newRequest("tipper-pubkey", `{"amount": 100, "workspace_uuid": "workspace-uuid"}`))
+
+		assert.Equal(t, http.StatusBadGateway, rr.Code)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should not tip when another payment spent the budget first", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mockHttpClient := &mocks.HttpClient{}
+		tHandler := NewTipHandler(mockHttpClient, mockDb)
+		tHandler.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool { return true }
+
+		mockDb.On("GetPersonByPubkey", "helper-pubkey").Return(receiver).Once()
+		mockDb.On("GetWorkspaceBudget", "workspace-uuid").Return(db.NewBountyBudget{TotalBudget: 1000}).Once()
+		mockDb.On("CreatePendingPayment", mock.AnythingOfType("db.NewPaymentHistory")).Return(db.NewPaymentHistory{}, db.ErrBudgetNotEnough).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(tHandler.TipPerson).ServeHTTP(rr, newRequest("tipper-pubkey", `{"amount": 100, "workspace_uuid": "workspace-uuid"}`))
+
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+		mockHttpClient.AssertNotCalled(t, "Do", mock.Anything)
+	})
+
+	t.Run("should hold a tip the node has not settled as pending", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mockHttpClient := &mocks.HttpClient{}
+		tHandler := NewTipHandler(mockHttpClient, mockDb)
+		tHandler.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool { return true }
+
+		mockDb.On("GetPersonByPubkey", "helper-pubkey").Return(receiver).Once()
+		mockDb.On("GetWorkspaceBudget", "workspace-uuid").Return(db.NewBountyBudget{TotalBudget: 1000}).Once()
+		mockDb.On("CreatePendingPayment", mock.MatchedBy(func(p db.NewPaymentHistory) bool {
+			return p.PaymentType == db.Tip && p.WorkspaceUuid == "workspace-uuid"
+		})).Return(db.NewPaymentHistory{ID: 9, State: db.PaymentPending}, nil).Once()
+		mockHttpClient.On("Do", mock.AnythingOfType("*http.Request")).Return(&http.Response{
+			StatusCode: http.StatusAccepted,
+			Body:       io.NopCloser(bytes.NewReader([]byte(`{"success": true, "response": {"payment_hash": "hash"}}`))),
+		}, nil).Once()
+		mockDb.On("SetPaymentHash", uint(9), "hash").Return(nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(tHandler.TipPerson).ServeHTTP(rr, newRequest("tipper-pubkey", `{"amount": 100, "workspace_uuid": "workspace-uuid"}`))
+
+		assert.Equal(t, http.StatusAccepted, rr.Code)
+		mockDb.AssertExpectations(t)
+		mockDb.AssertNotCalled(t, "ReconcilePayment", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should not tip more than the workspace budget", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mockHttpClient := &mocks.HttpClient{}
+		tHandler := NewTipHandler(mockHttpClient, mockDb)
+		tHandler.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool { return true }
+
+		mockDb.On("GetPersonByPubkey", "helper-pubkey").Return(receiver).Once()
+		mockDb.On("GetWorkspaceBudget", "workspace-uuid").Return(db.NewBountyBudget{TotalBudget: 50}).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(tHandler.TipPerson).ServeHTTP(rr, newRequest("tipper-pubkey", `{"amount": 100, "workspace_uuid": "workspace-uuid"}`))
+
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+		mockHttpClient.AssertNotCalled(t, "Do", mock.Anything)
+	})
+
+	t.Run("should need the pay bounty role for a workspace tip", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mockHttpClient := &mocks.HttpClient{}
+		tHandler := NewTipHandler(mockHttpClient, mockDb)
+		tHandler.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool { return false }
+
+		mockDb.On("GetPersonByPubkey", "helper-pubkey").Return(receiver).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(tHandler.TipPerson).ServeHTTP(rr, newRequest("tipper-pubkey", `{"amount": 100, "workspace_uuid": "workspace-uuid"}`))
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		mockDb.AssertNotCalled(t, "CreatePendingPayment", mock.Anything)
+	})
+
+	t.Run("should give an invoice for a personal tip", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mockHttpClient := &mocks.HttpClient{}
+		tHandler := NewTipHandler(mockHttpClient, mockDb)
+
+		mockDb.On("GetPersonByPubkey", "helper-pubkey").Return(receiver).Once()
+		mockHttpClient.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+			body, _ := io.ReadAll(req.Body)
+			return req.URL.Path == "/invoices" && string(body) == `{"amount":100,"memo":"Tip: \"great\" answer"}`
+		})).Return(relayResponse(`{"success": true, "response": {"invoice": "lnbc1tip"}}`), nil).Once()
+		mockDb.On("CreateTipInvoice", mock.MatchedBy(func(i db.NewInvoiceList) bool {
+			return i.Type == db.TipInvoice && i.PaymentRequest == "lnbc1tip" && i.OwnerPubkey == "tipper-pubkey"
+		}), mock.MatchedBy(func(d db.UserInvoiceData) bool {
+			return d.UserPubkey == "helper-pubkey" && d.RouteHint == "route-hint" && d.Amount == 100
+		}), mock.MatchedBy(func(p db.NewPaymentHistory) bool {
+			return p.PaymentType == db.Tip && !p.Status && p.PaymentRequest == "lnbc1tip" && p.WorkspaceUuid == ""
+		})).Return(nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(tHandler.TipPerson).ServeHTTP(rr, newRequest("tipper-pubkey", `{"amount": 100, "memo": "\"great\" answer"}`))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var response db.TipResponse
+		json.Unmarshal(rr.Body.Bytes(), &response)
+		assert.Equal(t, "lnbc1tip", response.Invoice)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should not tip yourself", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		tHandler := NewTipHandler(&mocks.HttpClient{}, mockDb)
+
+		mockDb.On("GetPersonByPubkey", "helper-pubkey").Return(receiver).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(tHandler.TipPerson).ServeHTTP(rr, newRequest("helper-pubkey", `{"amount": 100}`))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
+
+func TestSettleTip(t *testing.T) {
+	invData := db.UserInvoiceData{PaymentRequest: "lnbc1tip", Amount: 100, UserPubkey: "helper-pubkey"}
+	relayResponse := func(status int, body string) *http.Response {
+		return &http.Response{StatusCode: status, Body: io.NopCloser(bytes.NewReader([]byte(body)))}
+	}
+
+	t.Run("should send the tip on and record it", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mockHttpClient := &mocks.HttpClient{}
+
+		mockDb.On("UpdateInvoice", "lnbc1tip").Return(true, nil).Once()
+		mockDb.On("GetUserInvoiceData", "lnbc1tip").Return(invData).Once()
+		mockHttpClient.On("Do", mock.AnythingOfType("*http.Request")).Return(relayResponse(200, `{"success": true, "response": {}}`), nil).Once()
+		mockDb.On("SettleTip", "lnbc1tip").Return(db.NewPaymentHistory{ID: 4, Status: true}, nil).Once()
+
+		settleTip(context.Background(), mockHttpClient, mockDb, "lnbc1tip")
+
+		mockDb.AssertExpectations(t)
+		mockHttpClient.AssertExpectations(t)
+	})
+
+	t.Run("should mark the tip failed when the keysend is refused", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mockHttpClient := &mocks.HttpClient{}
+
+		mockDb.On("UpdateInvoice", "lnbc1tip").Return(true, nil).Once()
+		mockDb.On("GetUserInvoiceData", "lnbc1tip").Return(invData).Once()
+		mockHttpClient.On("Do", mock.AnythingOfType("*http.Request")).Return(relayResponse(400, `{"success": false}`), nil).Once()
+		mockDb.On("MarkTipState", "lnbc1tip", db.PaymentFailed).Return(nil).Once()
+
+		settleTip(context.Background(), mockHttpClient, mockDb, "lnbc1tip")
+
+		mockDb.AssertExpectations(t)
+		mockDb.AssertNotCalled(t, "SettleTip", mock.Anything)
+		mockDb.AssertNotCalled(t, "ReleaseInvoice", mock.Anything)
+	})
+
+	t.Run("should give the invoice back when the relay was not called", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mockHttpClient := &mocks.HttpClient{}
+
+		mockDb.On("UpdateInvoice", "lnbc1tip").Return(true, nil).Once()
+		mockDb.On("GetUserInvoiceData", "lnbc1tip").Return(invData).Once()
+		mockHttpClient.On("Do", mock.AnythingOfType("*http.Request")).Return(nil, upstream.ErrCircuitOpen).Once()
+		mockDb.On("ReleaseInvoice", "lnbc1tip").Return(nil).Once()
+
+		settleTip(context.Background(), mockHttpClient, mockDb, "lnbc1tip")
+
+		mockDb.AssertExpectations(t)
+		mockDb.AssertNotCalled(t, "MarkTipState", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should mark the tip pending when the payment is in flight", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mockHttpClient := &mocks.HttpClient{}
+
+		mockDb.On("UpdateInvoice", "lnbc1tip").Return(true, nil).Once()
+		mockDb.On("GetUserInvoiceData", "lnbc1tip").Return(invData).Once()
+		mockHttpClient.On("Do", mock.AnythingOfType("*http.Request")).Return(relayResponse(http.StatusAccepted, `{"success": true, "response": {"payment_hash": "hash"}}`), nil).Once()
+		mockDb.On("MarkTipState", "lnbc1tip", db.PaymentPending).Return(nil).Once()
+
+		settleTip(context.Background(), mockHttpClient, mockDb, "lnbc1tip")
+
+		mockDb.AssertExpectations(t)
+		mockDb.AssertNotCalled(t, "SettleTip", mock.Anything)
+	})
+
+	t.Run("should not send a tip another poll claimed", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mockHttpClient := &mocks.HttpClient{}
+
+		mockDb.On("UpdateInvoice", "lnbc1tip").Return(false, nil).Once()
+
+		settleTip(context.Background(), mockHttpClient, mockDb, "lnbc1tip")
+
+		mockHttpClient.AssertNotCalled(t, "Do", mock.Anything)
+	})
+}
@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/httpio"
+	"github.com/stakwork/sphinx-tribes/utils"
+)
+
+// GetTribeActivity lists the bounties, tickets, channel creations and
+// payments of the tribe of the route as one timeline, newest first. The
+// types query param keeps the bounties, tickets, channels or payments ones.
+// Private tribes have none
+func (th *tribeHandler) GetTribeActivity(w http.ResponseWriter, r *http.Request) {
+	tribe := th.db.GetTribe(chi.URLParam(r, "uuid"))
+	if tribe.UUID == "" || tribe.Deleted || tribe.Private {
+		httpio.WriteError(w, r, http.StatusNotFound, "Tribe not found")
+		return
+	}
+
+	kinds := []string{}
+	if value := r.URL.Query().Get("types"); value != "" {
+		for _, kind := range strings.Split(value, ",") {
+			kind = strings.TrimSpace(kind)
+			known := false
+			for _, k := range db.TribeActivityKinds {
+				known = known || k == kind
+			}
+			if !known {
+				httpio.WriteError(w, r, http.StatusBadRequest, db.ErrUnknownActivity.Error())
+				return
+			}
+			kinds = append(kinds, kind)
+		}
+	}
+
+	activity, err := th.db.GetTribeActivity(tribe.UUID, kinds, r)
+	if err != nil {
+		fmt.Println("[tribes]", err)
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to get the activity")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(utils.ListBody(r, activity, func() int64 {
+		return th.db.GetTribeActivityCount(tribe.UUID, kinds)
+	}))
+}
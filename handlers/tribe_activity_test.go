@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/db"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetTribeActivity(t *testing.T) {
+	newRequest := func(query string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("uuid", "tribe-uuid")
+		ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "/tribes/tribe-uuid/activity"+query, nil)
+		return req
+	}
+
+	t.Run("should list the activity of every kind by default", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		activity := []db.TribeActivity{{Kind: db.TribeActivityPayment, ID: 3}, {Kind: db.TribeActivityChannel, ID: 1}}
+		mockDb.On("GetTribe", "tribe-uuid").Return(db.Tribe{UUID: "tribe-uuid"}).Once()
+		mockDb.On("GetTribeActivity", "tribe-uuid", []string{}, mock.Anything).Return(activity, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(NewTribeHandler(mockDb).GetTribeActivity).ServeHTTP(rr, newRequest(""))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var res []db.TribeActivity
+		json.Unmarshal(rr.Body.Bytes(), &res)
+		assert.Equal(t, activity, res)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should filter the activity by kind", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mockDb.On("GetTribe", "tribe-uuid").Return(db.Tribe{UUID: "tribe-uuid"}).Once()
+		mockDb.On("GetTribeActivity", "tribe-uuid", []string{"payments", "channels"}, mock.Anything).Return([]db.TribeActivity{}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(NewTribeHandler(mockDb).GetTribeActivity).ServeHTTP(rr, newRequest("?types=payments,%20channels"))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should refuse an unknown kind", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mockDb.On("GetTribe", "tribe-uuid").Return(db.Tribe{UUID: "tribe-uuid"}).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(NewTribeHandler(mockDb).GetTribeActivity).ServeHTTP(rr, newRequest("?types=mentions"))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockDb.AssertNotCalled(t, "GetTribeActivity", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should not show the activity of a private tribe", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mockDb.On("GetTribe", "tribe-uuid").Return(db.Tribe{UUID: "tribe-uuid", Private: true}).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(NewTribeHandler(mockDb).GetTribeActivity).ServeHTTP(rr, newRequest(""))
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		mockDb.AssertNotCalled(t, "GetTribeActivity", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/rs/xid"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/events"
+	"github.com/stakwork/sphinx-tribes/httpio"
+)
+
+// ownedTribe is the tribe of the route when the caller owns it, it writes
+// the error otherwise
+func (th *tribeHandler) ownedTribe(w http.ResponseWriter, r *http.Request) (db.Tribe, bool) {
+	pubKeyFromAuth, _ := r.Context().Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[tribes] no pubkey from auth")
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
+		return db.Tribe{}, false
+	}
+	tribe := th.db.GetTribe(chi.URLParam(r, "uuid"))
+	if tribe.UUID == "" {
+		httpio.WriteError(w, r, http.StatusNotFound, "Tribe not found")
+		return tribe, false
+	}
+	if tribe.OwnerPubKey != pubKeyFromAuth {
+		httpio.WriteError(w, r, http.StatusUnauthorized, "only the tribe owner can manage its announcements, events, members and webhooks")
+		return tribe, false
+	}
+	return tribe, true
+}
+
+// GetTribeAnnouncements returns every announcement of the tribe of the
+// route, pinned or not
+func (th *tribeHandler) GetTribeAnnouncements(w http.ResponseWriter, r *http.Request) {
+	announcements := th.db.GetTribeAnnouncements(chi.URLParam(r, "uuid"))
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(announcements)
+}
+
+// CreateOrEditTribeAnnouncement lets the owner of the tribe of the route
+// publish an announcement, or edit one, and sends it to the subscribers of
+// the tribe topic
+func (th *tribeHandler) CreateOrEditTribeAnnouncement(w http.ResponseWriter, r *http.Request) {
+	tribe, ok := th.ownedTribe(w, r)
+	if !ok {
+		return
+	}
+
+	announcement := db.TribeAnnouncement{}
+	body, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err := json.Unmarshal(body, &announcement); err != nil {
+		fmt.Println("[tribes]", err)
+		httpio.WriteError(w, r, http.StatusNotAcceptable, "")
+		return
+	}
+	if !validatePayload(w, r, announcement) {
+		return
+	}
+	if announcement.PinUntil != nil && !announcement.PinUntil.After(time.Now()) {
+		httpio.WriteError(w, r, http.StatusBadRequest, "pin_until has to be in the future")
+		return
+	}
+
+	if announcement.Uuid == "" {
+		announcement.Uuid = xid.New().String()
+	} else if existing, err := th.db.GetTribeAnnouncement(announcement.Uuid); err == nil && existing.TribeUuid != tribe.UUID {
+		httpio.WriteError(w, r, http.StatusNotFound, "Announcement not found")
+		return
+	}
+	announcement.TribeUuid = tribe.UUID
+	announcement.Author = tribe.OwnerPubKey
+
+	saved, err := th.db.CreateOrEditTribeAnnouncement(announcement)
+	if err != nil {
+		fmt.Println("[tribes]", err)
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to save the announcement")
+		return
+	}
+	events.Publish(r.Context(), events.TribeAnnounced, "tribe:"+tribe.UUID, saved)
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(saved)
+}
+
+// DeleteTribeAnnouncement lets the owner of the tribe of the route remove
+// one of its announcements
+func (th *tribeHandler) DeleteTribeAnnouncement(w http.ResponseWriter, r *http.Request) {
+	tribe, ok := th.ownedTribe(w, r)
+	if !ok {
+		return
+	}
+
+	announcement, err := th.db.GetTribeAnnouncement(chi.URLParam(r, "announcement_uuid"))
+	if err != nil || announcement.TribeUuid != tribe.UUID {
+		httpio.WriteError(w, r, http.StatusNotFound, "Announcement not found")
+		return
+	}
+	if err := th.db.DeleteTribeAnnouncement(announcement.Uuid); err != nil {
+		fmt.Println("[tribes]", err)
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to delete the announcement")
+		return
+	}
+	events.Publish(r.Context(), events.TribeAnnounced, "tribe:"+tribe.UUID, map[string]interface{}{
+		"uuid":       announcement.Uuid,
+		"tribe_uuid": tribe.UUID,
+		"deleted":    true,
+	})
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(true)
+}
@@ -0,0 +1,133 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestTribeAnnouncements(t *testing.T) {
+	tribe := db.Tribe{UUID: "tribe-uuid", OwnerPubKey: "owner-pubkey"}
+	newRequest := func(method string, pubkey string, params map[string]string, body string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("uuid", tribe.UUID)
+		for key, value := range params {
+			rctx.URLParams.Add(key, value)
+		}
+		ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+		ctx = context.WithValue(ctx, auth.ContextKey, pubkey)
+		req, _ := http.NewRequestWithContext(ctx, method, "/tribes/tribe-uuid/announcements", bytes.NewBufferString(body))
+		return req
+	}
+
+	t.Run("should publish an announcement of the owner", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		tHandler := NewTribeHandler(mockDb)
+
+		mockDb.On("GetTribe", tribe.UUID).Return(tribe).Once()
+		mockDb.On("CreateOrEditTribeAnnouncement", mock.MatchedBy(func(a db.TribeAnnouncement) bool {
+			return a.Uuid != "" && a.TribeUuid == tribe.UUID && a.Author == "owner-pubkey" && a.Title == "Meetup" && a.PinUntil != nil
+		})).Return(func(a db.TribeAnnouncement) (db.TribeAnnouncement, error) {
+			return a, nil
+		}).Once()
+
+		rr := httptest.NewRecorder()
+		pinUntil := time.Now().Add(48 * time.Hour).UTC().Format(time.RFC3339)
+		body := `{"title": "Meetup", "body": "Friday at noon", "pin_until": "` + pinUntil + `"}`
+		http.HandlerFunc(tHandler.CreateOrEditTribeAnnouncement).ServeHTTP(rr, newRequest(http.MethodPost, "owner-pubkey", nil, body))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var announcement db.TribeAnnouncement
+		json.Unmarshal(rr.Body.Bytes(), &announcement)
+		assert.Equal(t, "Friday at noon", announcement.Body)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should only let the owner publish", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		tHandler := NewTribeHandler(mockDb)
+
+		mockDb.On("GetTribe", tribe.UUID).Return(tribe).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(tHandler.CreateOrEditTribeAnnouncement).ServeHTTP(rr, newRequest(http.MethodPost, "member-pubkey", nil, `{"title": "Meetup"}`))
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		mockDb.AssertNotCalled(t, "CreateOrEditTribeAnnouncement", mock.Anything)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should refuse a pin in the past", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		tHandler := NewTribeHandler(mockDb)
+
+		mockDb.On("GetTribe", tribe.UUID).Return(tribe).Once()
+
+		rr := httptest.NewRecorder()
+		body := `{"title": "Meetup", "pin_until": "2020-01-01T00:00:00Z"}`
+		http.HandlerFunc(tHandler.CreateOrEditTribeAnnouncement).ServeHTTP(rr, newRequest(http.MethodPost, "owner-pubkey", nil, body))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should not edit the announcement of another tribe", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		tHandler := NewTribeHandler(mockDb)
+
+		mockDb.On("GetTribe", tribe.UUID).Return(tribe).Once()
+		mockDb.On("GetTribeAnnouncement", "other-announcement").Return(db.TribeAnnouncement{Uuid: "other-announcement", TribeUuid: "other-tribe"}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(tHandler.CreateOrEditTribeAnnouncement).ServeHTTP(rr, newRequest(http.MethodPost, "owner-pubkey", nil, `{"uuid": "other-announcement", "title": "Meetup"}`))
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		mockDb.AssertNotCalled(t, "CreateOrEditTribeAnnouncement", mock.Anything)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should delete an announcement of the tribe", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		tHandler := NewTribeHandler(mockDb)
+
+		mockDb.On("GetTribe", tribe.UUID).Return(tribe).Once()
+		mockDb.On("GetTribeAnnouncement", "announcement-uuid").Return(db.TribeAnnouncement{Uuid: "announcement-uuid", TribeUuid: tribe.UUID}, nil).Once()
+		mockDb.On("DeleteTribeAnnouncement", "announcement-uuid").Return(nil).Once()
+
+		rr := httptest.NewRecorder()
+		params := map[string]string{"announcement_uuid": "announcement-uuid"}
+		http.HandlerFunc(tHandler.DeleteTribeAnnouncement).ServeHTTP(rr, newRequest(http.MethodDelete, "owner-pubkey", params, ""))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should return the pinned announcements with the tribe", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		tHandler := NewTribeHandler(mockDb)
+
+		mockDb.On("GetTribe", tribe.UUID).Return(tribe).Once()
+		mockDb.On("GetChannelsByTribe", tribe.UUID).Return([]db.Channel{}).Once()
+		mockDb.On("GetPinnedTribeAnnouncements", tribe.UUID, mock.Anything).Return([]db.TribeAnnouncement{{Uuid: "announcement-uuid", Title: "Meetup"}}).Once()
+		mockDb.On("GetTribesCategories", []string{tribe.UUID}).Return(map[string][]string{}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(tHandler.GetTribe).ServeHTTP(rr, newRequest(http.MethodGet, "", nil, ""))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"announcements":[{`)
+		assert.Contains(t, rr.Body.String(), `"title":"Meetup"`)
+		mockDb.AssertExpectations(t)
+	})
+}
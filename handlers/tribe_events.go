@@ -0,0 +1,231 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/rs/xid"
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/events"
+	"github.com/stakwork/sphinx-tribes/feeds"
+	"github.com/stakwork/sphinx-tribes/httpio"
+)
+
+// how many announcements and events the feed of a tribe carries
+const tribeFeedSize = 50
+
+// GetTribeEvents returns the events of the tribe of the route, the next to
+// start first
+func (th *tribeHandler) GetTribeEvents(w http.ResponseWriter, r *http.Request) {
+	tribeEvents := th.db.GetTribeEvents(chi.URLParam(r, "uuid"))
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(tribeEvents)
+}
+
+// CreateOrEditTribeEvent lets the owner of the tribe of the route schedule
+// an event, or edit one, and sends it to the subscribers of the tribe topic
+func (th *tribeHandler) CreateOrEditTribeEvent(w http.ResponseWriter, r *http.Request) {
+	tribe, ok := th.ownedTribe(w, r)
+	if !ok {
+		return
+	}
+
+	event := db.TribeEvent{}
+	body, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err := json.Unmarshal(body, &event); err != nil {
+		fmt.Println("[tribes]", err)
+		httpio.WriteError(w, r, http.StatusNotAcceptable, "")
+		return
+	}
+	if !validatePayload(w, r, event) {
+		return
+	}
+	if event.EndsAt != nil && !event.EndsAt.After(*event.StartsAt) {
+		httpio.WriteError(w, r, http.StatusBadRequest, "ends_at has to be after starts_at")
+		return
+	}
+
+	if event.Uuid == "" {
+		event.Uuid = xid.New().String()
+	} else if existing, err := th.db.GetTribeEvent(event.Uuid); err == nil && existing.TribeUuid != tribe.UUID {
+		httpio.WriteError(w, r, http.StatusNotFound, "Event not found")
+		return
+	}
+	event.TribeUuid = tribe.UUID
+	event.Author = tribe.OwnerPubKey
+
+	saved, err := th.db.CreateOrEditTribeEvent(event)
+	if err != nil {
+		fmt.Println("[tribes]", err)
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to save the event")
+		return
+	}
+	events.Publish(r.Context(), events.TribeScheduled, "tribe:"+tribe.UUID, saved)
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(saved)
+}
+
+// DeleteTribeEvent lets the owner of the tribe of the route cancel one of
+// its events
+func (th *tribeHandler) DeleteTribeEvent(w http.ResponseWriter, r *http.Request) {
+	tribe, ok := th.ownedTribe(w, r)
+	if !ok {
+		return
+	}
+
+	event, err := th.db.GetTribeEvent(chi.URLParam(r, "event_uuid"))
+	if err != nil || event.TribeUuid != tribe.UUID {
+		httpio.WriteError(w, r, http.StatusNotFound, "Event not found")
+		return
+	}
+	if err := th.db.DeleteTribeEvent(event.Uuid); err != nil {
+		fmt.Println("[tribes]", err)
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to delete the event")
+		return
+	}
+	events.Publish(r.Context(), events.TribeScheduled, "tribe:"+tribe.UUID, map[string]interface{}{
+		"uuid":       event.Uuid,
+		"tribe_uuid": tribe.UUID,
+		"deleted":    true,
+	})
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(true)
+}
+
+// eventSummary is the text of the feed entry of an event
+func eventSummary(event db.TribeEvent) string {
+	lines := []string{"Starts " + event.StartsAt.UTC().Format("Mon, 02 Jan 2006 15:04 MST")}
+	if event.EndsAt != nil {
+		lines = append(lines, "Ends "+event.EndsAt.UTC().Format("Mon, 02 Jan 2006 15:04 MST"))
+	}
+	if event.Location != "" {
+		lines = append(lines, "Where: "+event.Location)
+	}
+	if event.Description != "" {
+		lines = append(lines, "", event.Description)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// feedTime is t, or the start of the epoch for a row written without it
+func feedTime(t *time.Time) time.Time {
+	if t == nil {
+		return time.Unix(0, 0)
+	}
+	return *t
+}
+
+// tribeFeed is the Atom feed of the announcements and events of a tribe,
+// the last updated first
+func tribeFeed(tribe db.Tribe, updates db.TribeUpdates) (feeds.AtomFeed, time.Time) {
+	tribeUrl := fmt.Sprintf("%s/tribes/%s", config.Host, tribe.UUID)
+	author := &feeds.AtomPerson{Name: tribe.OwnerAlias}
+	if author.Name == "" {
+		author.Name = tribe.Name
+	}
+
+	type dated struct {
+		entry   feeds.AtomEntry
+		updated time.Time
+	}
+	entries := []dated{}
+	for _, announcement := range updates.Announcements {
+		updated := feedTime(announcement.Updated)
+		entries = append(entries, dated{updated: updated, entry: feeds.AtomEntry{
+			ID:        tribeUrl + "/announcements/" + announcement.Uuid,
+			Title:     announcement.Title,
+			Updated:   feeds.AtomTime(updated),
+			Published: feeds.AtomTime(feedTime(announcement.Created)),
+			Category:  &feeds.AtomCategory{Term: "announcement"},
+			Content:   &feeds.AtomText{Type: "text", Body: announcement.Body},
+		}})
+	}
+	for _, event := range updates.Events {
+		updated := feedTime(event.Updated)
+		entries = append(entries, dated{updated: updated, entry: feeds.AtomEntry{
+			ID:        tribeUrl + "/events/" + event.Uuid,
+			Title:     event.Title,
+			Updated:   feeds.AtomTime(updated),
+			Published: feeds.AtomTime(feedTime(event.Created)),
+			Category:  &feeds.AtomCategory{Term: "event"},
+			Content:   &feeds.AtomText{Type: "text", Body: eventSummary(event)},
+		}})
+	}
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].updated.After(entries[j].updated) })
+	if len(entries) > tribeFeedSize {
+		entries = entries[:tribeFeedSize]
+	}
+
+	lastModified := feedTime(tribe.Created)
+	if tribe.Updated != nil {
+		lastModified = *tribe.Updated
+	}
+	if len(entries) > 0 {
+		lastModified = entries[0].updated
+	}
+
+	feed := feeds.AtomFeed{
+		ID:       tribeUrl,
+		Title:    tribe.Name,
+		Subtitle: tribe.Description,
+		Updated:  feeds.AtomTime(lastModified),
+		Icon:     tribe.Img,
+		Author:   author,
+		Links: []feeds.AtomLink{
+			{Href: tribeUrl + "/updates.atom", Rel: "self", Type: "application/atom+xml"},
+			{Href: tribeUrl, Rel: "alternate", Type: "application/json"},
+		},
+		Entries: []feeds.AtomEntry{},
+	}
+	for _, e := range entries {
+		feed.Entries = append(feed.Entries, e.entry)
+	}
+	return feed, lastModified
+}
+
+// GetTribeUpdatesFeed serves the announcements and events of the tribe of
+// the route as an Atom feed, for feed readers. Private tribes have none
+func (th *tribeHandler) GetTribeUpdatesFeed(w http.ResponseWriter, r *http.Request) {
+	tribe := th.db.GetTribe(chi.URLParam(r, "uuid"))
+	if tribe.UUID == "" || tribe.Deleted || tribe.Private {
+		httpio.WriteError(w, r, http.StatusNotFound, "Tribe not found")
+		return
+	}
+
+	cached, err := db.CachedJSON(db.TribeUpdatesCacheKey+tribe.UUID, func() interface{} {
+		return th.db.GetTribeUpdates(tribe.UUID, tribeFeedSize)
+	})
+	updates := db.TribeUpdates{}
+	if err == nil {
+		err = json.Unmarshal(cached, &updates)
+	}
+	if err != nil {
+		fmt.Println("[tribes]", err)
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to get the updates")
+		return
+	}
+
+	feed, lastModified := tribeFeed(tribe, updates)
+	body, err := feed.Render()
+	if err != nil {
+		fmt.Println("[tribes]", err)
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to render the feed")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/atom+xml; charset=utf-8")
+	w.Header().Set("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	w.WriteHeader(http.StatusOK)
+	w.Write(body)
+}
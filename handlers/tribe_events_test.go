@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/feeds"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestTribeEvents(t *testing.T) {
+	created := time.Date(2026, time.September, 1, 12, 0, 0, 0, time.UTC)
+	tribe := db.Tribe{UUID: "tribe-uuid", OwnerPubKey: "owner-pubkey", Name: "Bitcoin Builders", OwnerAlias: "satoshi", Created: &created}
+	newRequest := func(method string, pubkey string, body string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("uuid", tribe.UUID)
+		ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+		ctx = context.WithValue(ctx, auth.ContextKey, pubkey)
+		req, _ := http.NewRequestWithContext(ctx, method, "/tribes/tribe-uuid/events", bytes.NewBufferString(body))
+		return req
+	}
+
+	t.Run("should schedule an event of the owner", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		tHandler := NewTribeHandler(mockDb)
+
+		mockDb.On("GetTribe", tribe.UUID).Return(tribe).Once()
+		mockDb.On("CreateOrEditTribeEvent", mock.MatchedBy(func(e db.TribeEvent) bool {
+			return e.Uuid != "" && e.TribeUuid == tribe.UUID && e.Author == "owner-pubkey" && e.Location == "Lisbon"
+		})).Return(func(e db.TribeEvent) (db.TribeEvent, error) { return e, nil }).Once()
+
+		rr := httptest.NewRecorder()
+		body := `{"title": "Meetup", "location": "Lisbon", "starts_at": "2026-11-06T18:00:00Z", "ends_at": "2026-11-06T21:00:00Z"}`
+		http.HandlerFunc(tHandler.CreateOrEditTribeEvent).ServeHTTP(rr, newRequest(http.MethodPost, "owner-pubkey", body))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should refuse an event that ends before it starts", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		tHandler := NewTribeHandler(mockDb)
+
+		mockDb.On("GetTribe", tribe.UUID).Return(tribe).Once()
+
+		rr := httptest.NewRecorder()
+		body := `{"title": "Meetup", "starts_at": "2026-11-06T18:00:00Z", "ends_at": "2026-11-06T17:00:00Z"}`
+		http.HandlerFunc(tHandler.CreateOrEditTribeEvent).ServeHTTP(rr, newRequest(http.MethodPost, "owner-pubkey", body))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockDb.AssertNotCalled(t, "CreateOrEditTribeEvent", mock.Anything)
+	})
+
+	t.Run("should serve the announcements and events as an atom feed", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		tHandler := NewTribeHandler(mockDb)
+		announced := time.Date(2026, time.October, 1, 9, 0, 0, 0, time.UTC)
+		scheduled := time.Date(2026, time.October, 3, 9, 0, 0, 0, time.UTC)
+		startsAt := time.Date(2026, time.November, 6, 18, 0, 0, 0, time.UTC)
+
+		mockDb.On("GetTribe", tribe.UUID).Return(tribe).Once()
+		mockDb.On("GetTribeUpdates", tribe.UUID, tribeFeedSize).Return(db.TribeUpdates{
+			Announcements: []db.TribeAnnouncement{{Uuid: "announcement-uuid", Title: "New channel", Body: "Say hi in #builders", Created: &announced, Updated: &announced}},
+			Events:        []db.TribeEvent{{Uuid: "event-uuid", Title: "Meetup", Location: "Lisbon", StartsAt: &startsAt, Created: &scheduled, Updated: &scheduled}},
+		}).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(tHandler.GetTribeUpdatesFeed).ServeHTTP(rr, newRequest(http.MethodGet, "", ""))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "application/atom+xml; charset=utf-8", rr.Header().Get("Content-Type"))
+		assert.Equal(t, scheduled.Format(http.TimeFormat), rr.Header().Get("Last-Modified"))
+		feed := feeds.AtomFeed{}
+		assert.NoError(t, xml.Unmarshal(rr.Body.Bytes(), &feed))
+		assert.Equal(t, "Bitcoin Builders", feed.Title)
+		assert.Equal(t, "2026-10-03T09:00:00Z", feed.Updated)
+		assert.Len(t, feed.Entries, 2)
+		assert.Equal(t, "Meetup", feed.Entries[0].Title)
+		assert.Contains(t, feed.Entries[0].Content.Body, "Where: Lisbon")
+		assert.Equal(t, "announcement", feed.Entries[1].Category.Term)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should not serve the feed of a private tribe", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		tHandler := NewTribeHandler(mockDb)
+		private := tribe
+		private.Private = true
+
+		mockDb.On("GetTribe", tribe.UUID).Return(private).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(tHandler.GetTribeUpdatesFeed).ServeHTTP(rr, newRequest(http.MethodGet, "", ""))
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		mockDb.AssertNotCalled(t, "GetTribeUpdates", mock.Anything, mock.Anything)
+	})
+}
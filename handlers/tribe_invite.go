@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/httpio"
+)
+
+const (
+	inviteLinkDays = 7
+	// the app fills it in from the tribe, see db.TribeInvite
+	groupKeyPlaceholder = "{group_key}"
+)
+
+// inviteSignature signs the payload of a tribe deep link, so the app can
+// tell a link of this server from one edited on the embedding website
+func inviteSignature(invite db.TribeInvite) string {
+	mac := hmac.New(sha256.New, []byte(config.JwtKey))
+	fmt.Fprintf(mac, "%s:%s:%s:%s:%d", invite.Action, invite.UUID, invite.Host, invite.GroupKey, invite.Expires)
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// relayHost is the host of the relay in the deep link, the app connects to
+// it to join the tribe
+func relayHost() string {
+	u, err := url.Parse(config.RelayUrl)
+	if err != nil || u.Host == "" {
+		return config.RelayUrl
+	}
+	return u.Host
+}
+
+// GetTribeInviteMeta returns the OpenGraph metadata of a tribe and a signed
+// deep link, for websites that embed a "Join tribe" button. The link expires
+// at the end of a day so the answer can be cached for the day
+func (th *tribeHandler) GetTribeInviteMeta(w http.ResponseWriter, r *http.Request) {
+	uuid := chi.URLParam(r, "uuid")
+	tribe := th.db.GetTribe(uuid)
+	if tribe.UUID == "" {
+		httpio.WriteError(w, r, http.StatusNotFound, "Tribe not found")
+		return
+	}
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	invite := db.TribeInvite{
+		Action:   "tribe",
+		UUID:     tribe.UUID,
+		Host:     relayHost(),
+		GroupKey: groupKeyPlaceholder,
+		Expires:  today.AddDate(0, 0, inviteLinkDays).Unix(),
+	}
+	invite.Signature = inviteSignature(invite)
+
+	query := url.Values{}
+	query.Set("action", invite.Action)
+	query.Set("uuid", invite.UUID)
+	query.Set("host", invite.Host)
+	query.Set("group_key", invite.GroupKey)
+	query.Set("expires", strconv.FormatInt(invite.Expires, 10))
+	query.Set("sig", invite.Signature)
+
+	openGraph := map[string]string{
+		"og:type":        "website",
+		"og:title":       tribe.Name,
+		"og:description": tribe.Description,
+		"og:site_name":   "Sphinx",
+	}
+	if tribe.Img != "" {
+		openGraph["og:image"] = tribe.Img
+	}
+	if tribe.AppURL != "" {
+		openGraph["og:url"] = tribe.AppURL
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(db.TribeInviteMeta{
+		UUID:        tribe.UUID,
+		Name:        tribe.Name,
+		Description: tribe.Description,
+		Img:         tribe.Img,
+		MemberCount: tribe.MemberCount,
+		PriceToJoin: tribe.PriceToJoin,
+		OpenGraph:   openGraph,
+		DeepLink:    "sphinx.chat://?" + query.Encode(),
+		Invite:      invite,
+	})
+}
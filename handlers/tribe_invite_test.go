@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/db"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetTribeInviteMeta(t *testing.T) {
+	defer func(relayUrl string) { config.RelayUrl = relayUrl }(config.RelayUrl)
+	config.RelayUrl = "https://relay.example.com:3001"
+
+	request := func(uuid string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("uuid", uuid)
+		req, _ := http.NewRequestWithContext(context.WithValue(context.Background(), chi.RouteCtxKey, rctx), http.MethodGet, "/"+uuid+"/invite_meta", nil)
+		return req
+	}
+
+	t.Run("should return 404 for an unknown tribe", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		tHandler := NewTribeHandler(mockDb)
+		mockDb.On("GetTribe", "missing").Return(db.Tribe{}).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(tHandler.GetTribeInviteMeta).ServeHTTP(rr, request("missing"))
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should return the metadata and a signed deep link", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		tHandler := NewTribeHandler(mockDb)
+		tribe := db.Tribe{UUID: "tribe-uuid", Name: "Bitcoin", Description: "All about it", Img: "https://memes.sphinx.chat/tribe.png", GroupKey: "secret-group-key", MemberCount: 42}
+		mockDb.On("GetTribe", "tribe-uuid").Return(tribe).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(tHandler.GetTribeInviteMeta).ServeHTTP(rr, request("tribe-uuid"))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var meta db.TribeInviteMeta
+		err := json.Unmarshal(rr.Body.Bytes(), &meta)
+		assert.NoError(t, err)
+		assert.Equal(t, "Bitcoin", meta.OpenGraph["og:title"])
+		assert.Equal(t, tribe.Img, meta.OpenGraph["og:image"])
+		assert.Equal(t, "relay.example.com:3001", meta.Invite.Host)
+		assert.Equal(t, groupKeyPlaceholder, meta.Invite.GroupKey)
+		assert.NotContains(t, rr.Body.String(), "secret-group-key")
+
+		link, err := url.Parse(meta.DeepLink)
+		assert.NoError(t, err)
+		assert.Equal(t, "sphinx.chat", link.Scheme)
+		assert.Equal(t, "tribe-uuid", link.Query().Get("uuid"))
+		assert.Equal(t, inviteSignature(meta.Invite), link.Query().Get("sig"))
+
+		meta.Invite.Host = "evil.example.com"
+		assert.NotEqual(t, inviteSignature(meta.Invite), link.Query().Get("sig"))
+		mockDb.AssertExpectations(t)
+	})
+}
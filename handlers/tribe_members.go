@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/events"
+	"github.com/stakwork/sphinx-tribes/httpio"
+)
+
+// JoinTribe makes the caller a member of the tribe of the route. Private
+// tribes are joined through the relay of their owner, not here
+func (th *tribeHandler) JoinTribe(w http.ResponseWriter, r *http.Request) {
+	pubKeyFromAuth, _ := r.Context().Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[tribes] no pubkey from auth")
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
+		return
+	}
+	tribe := th.db.GetTribe(chi.URLParam(r, "uuid"))
+	if tribe.UUID == "" || tribe.Deleted {
+		httpio.WriteError(w, r, http.StatusNotFound, "Tribe not found")
+		return
+	}
+	if tribe.Private {
+		httpio.WriteError(w, r, http.StatusForbidden, "a private tribe is joined through its owner")
+		return
+	}
+
+	member := db.TribeMember{}
+	body, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	if len(body) > 0 {
+		if err := json.Unmarshal(body, &member); err != nil {
+			fmt.Println("[tribes]", err)
+			httpio.WriteError(w, r, http.StatusNotAcceptable, "")
+			return
+		}
+	}
+	if !validatePayload(w, r, member) {
+		return
+	}
+
+	joined, err := th.db.JoinTribe(tribe.UUID, pubKeyFromAuth, member.Alias)
+	if errors.Is(err, db.ErrTribeMemberKicked) {
+		httpio.WriteError(w, r, http.StatusForbidden, err.Error())
+		return
+	}
+	if err != nil {
+		fmt.Println("[tribes]", err)
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to join the tribe")
+		return
+	}
+	events.Publish(r.Context(), events.TribeJoined, "tribe:"+tribe.UUID, map[string]interface{}{
+		"uuid":         tribe.UUID,
+		"name":         tribe.Name,
+		"owner_pubkey": tribe.OwnerPubKey,
+	})
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(joined)
+}
+
+// LeaveTribe ends the membership of the caller in the tribe of the route
+func (th *tribeHandler) LeaveTribe(w http.ResponseWriter, r *http.Request) {
+	pubKeyFromAuth, _ := r.Context().Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[tribes] no pubkey from auth")
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
+		return
+	}
+
+	err := th.db.LeaveTribe(chi.URLParam(r, "uuid"), pubKeyFromAuth)
+	if errors.Is(err, db.ErrNotTribeMember) {
+		httpio.WriteError(w, r, http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		fmt.Println("[tribes]", err)
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to leave the tribe")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(true)
+}
+
+// GetTribeMembers lists the members of the tribe of the route, paginated by
+// page and limit. Only the owner sees the members of a private tribe
+func (th *tribeHandler) GetTribeMembers(w http.ResponseWriter, r *http.Request) {
+	pubKeyFromAuth, _ := r.Context().Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[tribes] no pubkey from auth")
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
+		return
+	}
+	tribe := th.db.GetTribe(chi.URLParam(r, "uuid"))
+	if tribe.UUID == "" {
+		httpio.WriteError(w, r, http.StatusNotFound, "Tribe not found")
+		return
+	}
+	if tribe.Private && tribe.OwnerPubKey != pubKeyFromAuth {
+		httpio.WriteError(w, r, http.StatusUnauthorized, "only the tribe owner can list the members of a private tribe")
+		return
+	}
+
+	members, err := th.db.GetTribeMembers(tribe.UUID, r)
+	if err != nil {
+		fmt.Println("[tribes]", err)
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to list the members")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(members)
+}
+
+// KickTribeMember lets the owner of the tribe of the route remove a member,
+// who can't join it again
+func (th *tribeHandler) KickTribeMember(w http.ResponseWriter, r *http.Request) {
+	tribe, ok := th.ownedTribe(w, r)
+	if !ok {
+		return
+	}
+	pubkey := chi.URLParam(r, "pubkey")
+	if pubkey == tribe.OwnerPubKey {
+		httpio.WriteError(w, r, http.StatusBadRequest, "the owner can't kick themselves")
+		return
+	}
+
+	err := th.db.KickTribeMember(tribe.UUID, pubkey)
+	if errors.Is(err, db.ErrNotTribeMember) {
+		httpio.WriteError(w, r, http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		fmt.Println("[tribes]", err)
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to kick the member")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(true)
+}
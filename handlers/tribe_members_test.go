@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestTribeMembers(t *testing.T) {
+	tribe := db.Tribe{UUID: "tribe-uuid", Name: "Builders", OwnerPubKey: "owner-pubkey"}
+	newRequest := func(method string, pubkey string, params map[string]string, body string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("uuid", tribe.UUID)
+		for key, value := range params {
+			rctx.URLParams.Add(key, value)
+		}
+		ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+		ctx = context.WithValue(ctx, auth.ContextKey, pubkey)
+		req, _ := http.NewRequestWithContext(ctx, method, "/tribes/tribe-uuid/members", bytes.NewBufferString(body))
+		return req
+	}
+
+	t.Run("should join a tribe with an alias", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		tHandler := NewTribeHandler(mockDb)
+
+		mockDb.On("GetTribe", tribe.UUID).Return(tribe).Once()
+		mockDb.On("JoinTribe", tribe.UUID, "member-pubkey", "Ada").Return(db.TribeMember{TribeUuid: tribe.UUID, PubKey: "member-pubkey", Alias: "Ada"}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(tHandler.JoinTribe).ServeHTTP(rr, newRequest(http.MethodPost, "member-pubkey", nil, `{"alias": "Ada"}`))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var member db.TribeMember
+		json.Unmarshal(rr.Body.Bytes(), &member)
+		assert.Equal(t, "member-pubkey", member.PubKey)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should not join a private tribe", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		tHandler := NewTribeHandler(mockDb)
+
+		private := tribe
+		private.Private = true
+		mockDb.On("GetTribe", tribe.UUID).Return(private).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(tHandler.JoinTribe).ServeHTTP(rr, newRequest(http.MethodPost, "member-pubkey", nil, ""))
+
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+		mockDb.AssertNotCalled(t, "JoinTribe", mock.Anything, mock.Anything, mock.Anything)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should not let a kicked member join again", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		tHandler := NewTribeHandler(mockDb)
+
+		mockDb.On("GetTribe", tribe.UUID).Return(tribe).Once()
+		mockDb.On("JoinTribe", tribe.UUID, "member-pubkey", "").Return(db.TribeMember{}, db.ErrTribeMemberKicked).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(tHandler.JoinTribe).ServeHTTP(rr, newRequest(http.MethodPost, "member-pubkey", nil, ""))
+
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should not leave a tribe the caller is not a member of", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		tHandler := NewTribeHandler(mockDb)
+
+		mockDb.On("LeaveTribe", tribe.UUID, "member-pubkey").Return(db.ErrNotTribeMember).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(tHandler.LeaveTribe).ServeHTTP(rr, newRequest(http.MethodPost, "member-pubkey", nil, ""))
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should only list the members of a private tribe to its owner", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		tHandler := NewTribeHandler(mockDb)
+
+		private := tribe
+		private.Private = true
+		mockDb.On("GetTribe", tribe.UUID).Return(private).Twice()
+		mockDb.On("GetTribeMembers", tribe.UUID, mock.Anything).Return([]db.TribeMember{{PubKey: "member-pubkey"}}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(tHandler.GetTribeMembers).ServeHTTP(rr, newRequest(http.MethodGet, "member-pubkey", nil, ""))
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+
+		rr = httptest.NewRecorder()
+		http.HandlerFunc(tHandler.GetTribeMembers).ServeHTTP(rr, newRequest(http.MethodGet, "owner-pubkey", nil, ""))
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"pubkey":"member-pubkey"`)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should let the owner kick a member", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		tHandler := NewTribeHandler(mockDb)
+
+		mockDb.On("GetTribe", tribe.UUID).Return(tribe).Once()
+		mockDb.On("KickTribeMember", tribe.UUID, "member-pubkey").Return(nil).Once()
+
+		rr := httptest.NewRecorder()
+		params := map[string]string{"pubkey": "member-pubkey"}
+		http.HandlerFunc(tHandler.KickTribeMember).ServeHTTP(rr, newRequest(http.MethodDelete, "owner-pubkey", params, ""))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should only let the owner kick", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		tHandler := NewTribeHandler(mockDb)
+
+		mockDb.On("GetTribe", tribe.UUID).Return(tribe).Once()
+
+		rr := httptest.NewRecorder()
+		params := map[string]string{"pubkey": "other-pubkey"}
+		http.HandlerFunc(tHandler.KickTribeMember).ServeHTTP(rr, newRequest(http.MethodDelete, "member-pubkey", params, ""))
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		mockDb.AssertNotCalled(t, "KickTribeMember", mock.Anything, mock.Anything)
+		mockDb.AssertExpectations(t)
+	})
+}
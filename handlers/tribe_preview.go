@@ -0,0 +1,299 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/httpio"
+	"github.com/stakwork/sphinx-tribes/jobs"
+	xhtml "golang.org/x/net/html"
+)
+
+const (
+	previewFetchTimeout = 10 * time.Second
+	// the part of a page read for its metadata, which sits in the head
+	previewMaxBytes  = 1 << 20
+	previewRedirects = 3
+)
+
+var errPrivateAddress = errors.New("the url is not a public address")
+
+// specialNetworks are the ranges the net.IP checks miss that still don't
+// reach the internet: carrier grade NAT, the IETF protocol assignments and
+// the benchmarking networks
+var specialNetworks = []*net.IPNet{
+	mustParseCIDR("100.64.0.0/10"),
+	mustParseCIDR("192.0.0.0/24"),
+	mustParseCIDR("198.18.0.0/15"),
+}
+
+func mustParseCIDR(cidr string) *net.IPNet {
+	_, network, err := net.ParseCIDR(cidr)
+	if err != nil {
+		panic(err)
+	}
+	return network
+}
+
+// publicDial refuses the addresses of the host and its network, so an url
+// of a user, like an app url or a webhook, can't make the server reach its
+// own services
+func publicDial(network string, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsMulticast() {
+		return errPrivateAddress
+	}
+	for _, special := range specialNetworks {
+		if special.Contains(ip) {
+			return errPrivateAddress
+		}
+	}
+	return nil
+}
+
+// previewClient fetches the app urls of the tribes, tests replace it
+var previewClient = &http.Client{
+	Timeout: previewFetchTimeout,
+	Transport: &http.Transport{
+		DialContext: (&net.Dialer{Timeout: previewFetchTimeout, Control: publicDial}).DialContext,
+	},
+	CheckRedirect: func(req *http.Request, via []*http.Request) error {
+		if len(via) >= previewRedirects {
+			return errors.New("the app url redirects too many times")
+		}
+		return nil
+	},
+}
+
+// fetchOpenGraph reads the open graph metadata in the head of the page, the
+// title and description tags standing in for the missing ones
+func fetchOpenGraph(ctx context.Context, client *http.Client, pageUrl string) (db.TribePreview, error) {
+	preview := db.TribePreview{Url: pageUrl}
+	parsed, err := url.Parse(pageUrl)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+		return preview, errors.New("the app url is not a http(s) url")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, pageUrl, nil)
+	if err != nil {
+		return preview, err
+	}
+	req.Header.Set("Accept", "text/html")
+	res, err := client.Do(req)
+	if err != nil {
+		return preview, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode >= http.StatusBadRequest {
+		return preview, fmt.Errorf("the app url answered %d", res.StatusCode)
+	}
+	if mediaType, _, _ := mime.ParseMediaType(res.Header.Get("Content-Type")); mediaType != "text/html" {
+		return preview, fmt.Errorf("the app url is not a html page but %q", mediaType)
+	}
+
+	meta := map[string]string{}
+	title := ""
+	tokenizer := xhtml.NewTokenizer(io.LimitReader(res.Body, previewMaxBytes))
+	for {
+		tokenType := tokenizer.Next()
+		if tokenType == xhtml.ErrorToken {
+			break
+		}
+		token := tokenizer.Token()
+		if token.Data == "body" || (tokenType == xhtml.EndTagToken && token.Data == "head") {
+			break
+		}
+		if tokenType == xhtml.StartTagToken && token.Data == "title" && title == "" {
+			if tokenizer.Next() == xhtml.TextToken {
+				title = strings.TrimSpace(tokenizer.Token().Data)
+			}
+			continue
+		}
+		if token.Data != "meta" {
+			continue
+		}
+		name, content := "", ""
+		for _, attr := range token.Attr {
+			switch attr.Key {
+			case "property", "name":
+				name = strings.ToLower(attr.Val)
+			case "content":
+				content = strings.TrimSpace(attr.Val)
+			}
+		}
+		if _, seen := meta[name]; name != "" && !seen {
+			meta[name] = content
+		}
+	}
+
+	preview.Title = firstNonEmpty(meta["og:title"], title)
+	preview.Description = firstNonEmpty(meta["og:description"], meta["description"])
+	preview.SiteName = firstNonEmpty(meta["og:site_name"], parsed.Hostname())
+	// a relative image is resolved against the page
+	if image, err := parsed.Parse(meta["og:image"]); err == nil && meta["og:image"] != "" && (image.Scheme == "http" || image.Scheme == "https") {
+		preview.Image = image.String()
+	}
+	return preview, nil
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// previewLines wraps text in lines of at most width runes, the last of max
+// lines ending with an ellipsis when the text is longer
+func previewLines(text string, width int, max int) []string {
+	lines := []string{}
+	line := ""
+	for _, word := range strings.Fields(text) {
+		if len([]rune(line))+1+len([]rune(word)) > width && line != "" {
+			if len(lines) == max-1 {
+				return append(lines, line+"…")
+			}
+			lines = append(lines, line)
+			line = ""
+		}
+		if line != "" {
+			line += " "
+		}
+		line += word
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// renderPreviewCard draws the preview card of a tribe, an SVG of the size
+// of an open graph image with its name, description and site
+func renderPreviewCard(tribe db.Tribe, preview db.TribePreview) string {
+	var card strings.Builder
+	card.WriteString(`<svg xmlns="http://www.w3.org/2000/svg" width="1200" height="630" viewBox="0 0 1200 630">`)
+	card.WriteString(`<rect width="1200" height="630" fill="#1a242e"/><rect x="0" y="0" width="16" height="630" fill="#618aff"/>`)
+	y := 170
+	for _, line := range previewLines(firstNonEmpty(tribe.Name, preview.Title), 28, 2) {
+		fmt.Fprintf(&card, `<text x="80" y="%d" font-family="Roboto, sans-serif" font-size="64" font-weight="bold" fill="#ffffff">%s</text>`, y, html.EscapeString(line))
+		y += 80
+	}
+	y += 20
+	for _, line := range previewLines(firstNonEmpty(preview.Description, tribe.Description), 52, 3) {
+		fmt.Fprintf(&card, `<text x="80" y="%d" font-family="Roboto, sans-serif" font-size="34" fill="#c7d0db">%s</text>`, y, html.EscapeString(line))
+		y += 48
+	}
+	fmt.Fprintf(&card, `<text x="80" y="560" font-family="Roboto, sans-serif" font-size="28" fill="#909baa">%s</text>`, html.EscapeString(preview.SiteName))
+	card.WriteString(`</svg>`)
+	return card.String()
+}
+
+// FetchTribePreview reads the metadata of the app url of a tribe and draws
+// its card, for the preview job
+func FetchTribePreview(ctx context.Context, tribe db.Tribe) (db.TribePreview, error) {
+	preview, err := fetchOpenGraph(ctx, previewClient, tribe.AppURL)
+	if err != nil {
+		return preview, err
+	}
+	preview.Card = renderPreviewCard(tribe, preview)
+	return preview, nil
+}
+
+// GetTribePreview returns the status and the metadata of the preview of a
+// tribe
+func (th *tribeHandler) GetTribePreview(w http.ResponseWriter, r *http.Request) {
+	preview := th.db.GetTribePreview(chi.URLParam(r, "uuid"))
+	if preview.ID == 0 {
+		httpio.WriteError(w, r, http.StatusNotFound, "The tribe has no preview")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(preview)
+}
+
+// GetTribePreviewCard serves the preview card of a tribe once it is built
+func (th *tribeHandler) GetTribePreviewCard(w http.ResponseWriter, r *http.Request) {
+	preview := th.db.GetTribePreview(chi.URLParam(r, "uuid"))
+	if preview.Status != db.PreviewReady || preview.Card == "" {
+		httpio.WriteError(w, r, http.StatusNotFound, "The preview is not ready")
+		return
+	}
+
+	w.Header().Set("Content-Type", "image/svg+xml")
+	w.Header().Set("Content-Security-Policy", "default-src 'none'; style-src 'unsafe-inline'")
+	w.WriteHeader(http.StatusOK)
+	io.WriteString(w, preview.Card)
+}
+
+// SetTribePreview has the server build the preview card of a tribe from its
+// app url. The card is built by a job, the preview of the tribe points to
+// it once it is ready
+func (th *tribeHandler) SetTribePreview(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+
+	uuid := chi.URLParam(r, "uuid")
+	if uuid == "" {
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
+		return
+	}
+
+	extractedPubkey, err := th.verifyTribeUUID(uuid, false)
+	if err != nil {
+		fmt.Println(err)
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
+		return
+	}
+
+	// from token must match
+	if pubKeyFromAuth != extractedPubkey {
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
+		return
+	}
+
+	tribe := th.db.GetTribe(uuid)
+	if tribe.UUID == "" {
+		httpio.WriteError(w, r, http.StatusNotFound, "Tribe not found")
+		return
+	}
+	if tribe.AppURL == "" {
+		httpio.WriteError(w, r, http.StatusBadRequest, "The tribe has no app url to preview")
+		return
+	}
+
+	preview, err := th.db.QueueTribePreview(tribe.UUID, tribe.AppURL)
+	if err != nil {
+		fmt.Println("[tribes]", err)
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to queue the preview")
+		return
+	}
+	if _, err := jobs.Default.Enqueue(jobs.TribePreviewJob, map[string]interface{}{"tribe_uuid": tribe.UUID}); err != nil {
+		fmt.Println("[tribes]", err)
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to queue the preview")
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(preview)
+}
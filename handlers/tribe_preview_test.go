@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/db"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFetchTribePreview(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/app":
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			w.Write([]byte(`<html><head><title>Fallback</title>
+				<meta property="og:title" content="Nodes &amp; Friends">
+				<meta property="og:description" content="A tribe for <b>node</b> runners">
+				<meta property="og:image" content="/cover.png">
+				</head><body><meta property="og:title" content="Ignored"></body></html>`))
+		case "/json":
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{}`))
+		}
+	}))
+	defer server.Close()
+	defer func(client *http.Client) { previewClient = client }(previewClient)
+	previewClient = server.Client()
+
+	t.Run("should build the card from the open graph metadata", func(t *testing.T) {
+		preview, err := FetchTribePreview(context.Background(), db.Tribe{Name: "Nodes", AppURL: server.URL + "/app"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Nodes & Friends", preview.Title)
+		assert.Equal(t, "A tribe for <b>node</b> runners", preview.Description)
+		assert.Equal(t, server.URL+"/cover.png", preview.Image)
+		assert.Equal(t, "127.0.0.1", preview.SiteName)
+		assert.Contains(t, preview.Card, "A tribe for &lt;b&gt;node&lt;/b&gt; runners")
+		assert.NotContains(t, preview.Card, "<b>")
+	})
+
+	t.Run("should refuse a page that is not html", func(t *testing.T) {
+		_, err := FetchTribePreview(context.Background(), db.Tribe{AppURL: server.URL + "/json"})
+
+		assert.Error(t, err)
+	})
+
+	t.Run("should not reach the addresses of the server", func(t *testing.T) {
+		previewClient = &http.Client{Transport: &http.Transport{
+			DialContext: (&net.Dialer{Control: publicDial}).DialContext,
+		}}
+		_, err := FetchTribePreview(context.Background(), db.Tribe{AppURL: server.URL + "/app"})
+
+		assert.ErrorIs(t, err, errPrivateAddress)
+	})
+}
+
+func TestPublicDial(t *testing.T) {
+	t.Run("should refuse the networks that are not the internet", func(t *testing.T) {
+		for _, address := range []string{"10.0.0.1:80", "100.64.0.1:80", "100.127.255.254:443", "192.0.0.8:80", "198.18.0.1:80", "198.19.255.255:80", "[::1]:80"} {
+			assert.ErrorIs(t, publicDial("tcp", address, nil), errPrivateAddress, address)
+		}
+	})
+
+	t.Run("should let public addresses through", func(t *testing.T) {
+		for _, address := range []string{"1.1.1.1:443", "100.128.0.1:80", "198.20.0.1:80", "[2606:4700::1111]:443"} {
+			assert.NoError(t, publicDial("tcp", address, nil), address)
+		}
+	})
+}
+
+func TestPreviewLines(t *testing.T) {
+	assert.Equal(t, []string{"one two", "three"}, previewLines("one two three", 8, 3))
+	assert.Equal(t, []string{"one two", "three…"}, previewLines("one two three four", 8, 2))
+	assert.Empty(t, previewLines("", 8, 2))
+}
+
+func TestGetTribePreviewCard(t *testing.T) {
+	newRequest := func(uuid string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("uuid", uuid)
+		req, _ := http.NewRequestWithContext(context.WithValue(context.Background(), chi.RouteCtxKey, rctx), http.MethodGet, "/tribes/"+uuid+"/preview.svg", nil)
+		return req
+	}
+
+	t.Run("should serve a ready card as an svg", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mockDb.On("GetTribePreview", "ready-uuid").Return(db.TribePreview{ID: 1, Status: db.PreviewReady, Card: "<svg></svg>"}).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(NewTribeHandler(mockDb).GetTribePreviewCard).ServeHTTP(rr, newRequest("ready-uuid"))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "image/svg+xml", rr.Header().Get("Content-Type"))
+		assert.True(t, strings.HasPrefix(rr.Header().Get("Content-Security-Policy"), "default-src 'none'"))
+		assert.Equal(t, "<svg></svg>", rr.Body.String())
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should not serve a card that is still pending", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mockDb.On("GetTribePreview", "pending-uuid").Return(db.TribePreview{ID: 1, Status: db.PreviewPending}).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(NewTribeHandler(mockDb).GetTribePreviewCard).ServeHTTP(rr, newRequest("pending-uuid"))
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		mockDb.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/rs/xid"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/events"
+	"github.com/stakwork/sphinx-tribes/httpio"
+)
+
+// AppealTribe lets the owner of a shadow-listed tribe ask the admins to
+// review it. Appealing again while the first appeal is pending returns
+// that appeal
+func (mh *moderationHandler) AppealTribe(w http.ResponseWriter, r *http.Request) {
+	pubKeyFromAuth, _ := r.Context().Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
+		return
+	}
+
+	tribe := mh.db.GetTribe(chi.URLParam(r, "uuid"))
+	if tribe.UUID == "" {
+		httpio.WriteError(w, r, http.StatusNotFound, "Tribe not found")
+		return
+	}
+	if tribe.OwnerPubKey != pubKeyFromAuth {
+		httpio.WriteError(w, r, http.StatusUnauthorized, "only the tribe owner can appeal")
+		return
+	}
+	if !tribe.ShadowListed {
+		httpio.WriteError(w, r, http.StatusConflict, "Tribe is not shadow-listed")
+		return
+	}
+
+	appeal := db.TribeAppeal{}
+	body, err := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err == nil {
+		err = json.Unmarshal(body, &appeal)
+	}
+	if err != nil {
+		httpio.WriteError(w, r, http.StatusNotAcceptable, "")
+		return
+	}
+
+	if !validatePayload(w, r, appeal) {
+		return
+	}
+
+	if pending, err := mh.db.GetPendingTribeAppeal(tribe.UUID); err == nil {
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(pending)
+		return
+	}
+
+	appeal.Uuid = xid.New().String()
+	appeal.TribeUuid = tribe.UUID
+	appeal.OwnerPubKey = pubKeyFromAuth
+	created, err := mh.db.CreateTribeAppeal(appeal)
+	if err != nil {
+		fmt.Println("[moderation]", err)
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to save appeal")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(created)
+}
+
+// GetShadowListedTribes lists the tribes waiting for a spam review, with
+// their score and appeal
+func (mh *moderationHandler) GetShadowListedTribes(w http.ResponseWriter, r *http.Request) {
+	tribes, err := mh.db.GetShadowListedTribes(r)
+	if err != nil {
+		fmt.Println("[moderation]", err)
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to get shadow-listed tribes")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(tribes)
+}
+
+// ReviewShadowListedTribe restores a shadow-listed tribe to the listings or
+// unlists it, and closes its appeal
+func (mh *moderationHandler) ReviewShadowListedTribe(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+
+	tribe := mh.db.GetTribe(chi.URLParam(r, "uuid"))
+	if tribe.UUID == "" {
+		httpio.WriteError(w, r, http.StatusNotFound, "Tribe not found")
+		return
+	}
+	if !tribe.ShadowListed {
+		httpio.WriteError(w, r, http.StatusConflict, "Tribe is not shadow-listed")
+		return
+	}
+
+	review := db.TribeSpamReview{}
+	body, err := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err == nil {
+		err = json.Unmarshal(body, &review)
+	}
+	if err != nil {
+		httpio.WriteError(w, r, http.StatusNotAcceptable, "")
+		return
+	}
+
+	if !validatePayload(w, r, review) {
+		return
+	}
+
+	reviewed, err := mh.db.ReviewShadowListedTribe(tribe.UUID, review, pubKeyFromAuth)
+	if err != nil {
+		fmt.Println("[moderation]", err)
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to review tribe")
+		return
+	}
+	events.Publish(ctx, events.TribeUpdated, "tribe:"+reviewed.UUID, reviewed)
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(reviewed)
+}
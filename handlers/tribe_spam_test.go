@@ -0,0 +1,129 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAppealTribe(t *testing.T) {
+	tribe := db.Tribe{UUID: "tribe-uuid", OwnerPubKey: "owner-pubkey", ShadowListed: true}
+	newRequest := func(pubkey string, body string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("uuid", tribe.UUID)
+		ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+		ctx = context.WithValue(ctx, auth.ContextKey, pubkey)
+		req, _ := http.NewRequestWithContext(ctx, http.MethodPost, "/tribe/tribe-uuid/appeal", strings.NewReader(body))
+		return req
+	}
+
+	t.Run("should only let the owner appeal", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mh := NewModerationHandler(mockDb)
+		mockDb.On("GetTribe", tribe.UUID).Return(tribe).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(mh.AppealTribe).ServeHTTP(rr, newRequest("someone-else", `{"message": "not spam"}`))
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		mockDb.AssertNotCalled(t, "CreateTribeAppeal", mock.Anything)
+	})
+
+	t.Run("should refuse an appeal for a listed tribe", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mh := NewModerationHandler(mockDb)
+		listed := tribe
+		listed.ShadowListed = false
+		mockDb.On("GetTribe", tribe.UUID).Return(listed).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(mh.AppealTribe).ServeHTTP(rr, newRequest("owner-pubkey", `{"message": "not spam"}`))
+
+		assert.Equal(t, http.StatusConflict, rr.Code)
+	})
+
+	t.Run("should create an appeal", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mh := NewModerationHandler(mockDb)
+		mockDb.On("GetTribe", tribe.UUID).Return(tribe).Once()
+		mockDb.On("GetPendingTribeAppeal", tribe.UUID).Return(db.TribeAppeal{}, errors.New("no appeal found")).Once()
+		mockDb.On("CreateTribeAppeal", mock.MatchedBy(func(a db.TribeAppeal) bool {
+			return a.Uuid != "" && a.TribeUuid == tribe.UUID && a.OwnerPubKey == "owner-pubkey" && a.Message == "It is a real podcast"
+		})).Return(db.TribeAppeal{Uuid: "appeal-uuid", Status: db.AppealPending}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(mh.AppealTribe).ServeHTTP(rr, newRequest("owner-pubkey", `{"message": "It is a real podcast"}`))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		appeal := db.TribeAppeal{}
+		json.Unmarshal(rr.Body.Bytes(), &appeal)
+		assert.Equal(t, db.AppealPending, appeal.Status)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should return the pending appeal", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mh := NewModerationHandler(mockDb)
+		mockDb.On("GetTribe", tribe.UUID).Return(tribe).Once()
+		mockDb.On("GetPendingTribeAppeal", tribe.UUID).Return(db.TribeAppeal{Uuid: "appeal-uuid"}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(mh.AppealTribe).ServeHTTP(rr, newRequest("owner-pubkey", `{"message": "again"}`))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		mockDb.AssertNotCalled(t, "CreateTribeAppeal", mock.Anything)
+	})
+}
+
+func TestReviewShadowListedTribe(t *testing.T) {
+	tribe := db.Tribe{UUID: "tribe-uuid", OwnerPubKey: "owner-pubkey", ShadowListed: true}
+	newRequest := func(body string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("uuid", tribe.UUID)
+		ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+		ctx = context.WithValue(ctx, auth.ContextKey, "super-admin")
+		req, _ := http.NewRequestWithContext(ctx, http.MethodPost, "/admin/tribes/tribe-uuid/spam_review", strings.NewReader(body))
+		return req
+	}
+
+	t.Run("should reject an unknown action", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mh := NewModerationHandler(mockDb)
+		mockDb.On("GetTribe", tribe.UUID).Return(tribe).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(mh.ReviewShadowListedTribe).ServeHTTP(rr, newRequest(`{"action": "delete"}`))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockDb.AssertNotCalled(t, "ReviewShadowListedTribe", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should restore the tribe", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mh := NewModerationHandler(mockDb)
+		restored := tribe
+		restored.ShadowListed = false
+		mockDb.On("GetTribe", tribe.UUID).Return(tribe).Once()
+		mockDb.On("ReviewShadowListedTribe", tribe.UUID, db.TribeSpamReview{Action: db.SpamReviewRestore, Note: "a real podcast"}, "super-admin").Return(restored, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(mh.ReviewShadowListedTribe).ServeHTTP(rr, newRequest(`{"action": "restore", "note": "a real podcast"}`))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		result := db.Tribe{}
+		json.Unmarshal(rr.Body.Bytes(), &result)
+		assert.False(t, result.ShadowListed)
+		mockDb.AssertExpectations(t)
+	})
+}
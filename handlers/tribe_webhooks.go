@@ -0,0 +1,133 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/rs/xid"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/httpio"
+	"github.com/stakwork/sphinx-tribes/utils"
+)
+
+const (
+	maxTribeWebhooks    = 5
+	tribeWebhookTimeout = 10 * time.Second
+)
+
+// WebhookClient posts the tribe webhooks. Like the preview fetches it only
+// reaches public addresses, and it doesn't follow redirects
+var WebhookClient = &http.Client{
+	Timeout: tribeWebhookTimeout,
+	Transport: &http.Transport{
+		DialContext: (&net.Dialer{Timeout: tribeWebhookTimeout, Control: publicDial}).DialContext,
+	},
+	CheckRedirect: func(req *http.Request, via []*http.Request) error {
+		return http.ErrUseLastResponse
+	},
+}
+
+// GetTribeWebhooks lists the webhooks of the tribe of the route, for its
+// owner. Their secrets are not returned
+func (th *tribeHandler) GetTribeWebhooks(w http.ResponseWriter, r *http.Request) {
+	tribe, ok := th.ownedTribe(w, r)
+	if !ok {
+		return
+	}
+
+	webhooks, err := th.db.GetTribeWebhooks(tribe.UUID)
+	if err != nil {
+		fmt.Println("[tribes]", err)
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to get the webhooks")
+		return
+	}
+	for i := range webhooks {
+		webhooks[i].Secret = ""
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(webhooks)
+}
+
+// CreateTribeWebhook registers a callback url of the owner of the tribe of
+// the route. The secret the deliveries are signed with is returned once
+func (th *tribeHandler) CreateTribeWebhook(w http.ResponseWriter, r *http.Request) {
+	tribe, ok := th.ownedTribe(w, r)
+	if !ok {
+		return
+	}
+
+	request := db.TribeWebhookRequest{}
+	body, err := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err == nil {
+		err = json.Unmarshal(body, &request)
+	}
+	if err != nil {
+		httpio.WriteError(w, r, http.StatusNotAcceptable, "")
+		return
+	}
+	if !validatePayload(w, r, request) {
+		return
+	}
+	if u, err := url.Parse(request.Url); err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		httpio.WriteError(w, r, http.StatusBadRequest, "Error: url has to be a http(s) url")
+		return
+	}
+
+	webhooks, err := th.db.GetTribeWebhooks(tribe.UUID)
+	if err != nil {
+		fmt.Println("[tribes]", err)
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to save the webhook")
+		return
+	}
+	if len(webhooks) >= maxTribeWebhooks {
+		httpio.WriteError(w, r, http.StatusConflict, fmt.Sprintf("A tribe has at most %d webhooks", maxTribeWebhooks))
+		return
+	}
+
+	webhook, err := th.db.CreateTribeWebhook(db.TribeWebhook{
+		Uuid:      xid.New().String(),
+		TribeUuid: tribe.UUID,
+		Url:       request.Url,
+		Events:    request.Events,
+		Secret:    utils.GetRandomToken(40),
+	})
+	if err != nil {
+		fmt.Println("[tribes]", err)
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to save the webhook")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(webhook)
+}
+
+// DeleteTribeWebhook removes a webhook of the tribe of the route, the
+// deliveries still queued for it are dropped
+func (th *tribeHandler) DeleteTribeWebhook(w http.ResponseWriter, r *http.Request) {
+	tribe, ok := th.ownedTribe(w, r)
+	if !ok {
+		return
+	}
+
+	webhook := th.db.GetTribeWebhook(chi.URLParam(r, "webhook_uuid"))
+	if webhook.ID == 0 || webhook.TribeUuid != tribe.UUID {
+		httpio.WriteError(w, r, http.StatusNotFound, "Webhook not found")
+		return
+	}
+	if err := th.db.DeleteTribeWebhook(webhook.Uuid); err != nil {
+		fmt.Println("[tribes]", err)
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to delete the webhook")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(true)
+}
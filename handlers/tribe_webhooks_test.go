@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestTribeWebhooks(t *testing.T) {
+	tribe := db.Tribe{UUID: "tribe-uuid", OwnerPubKey: "owner"}
+	newRequest := func(pubkey string, method string, body string, params map[string]string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("uuid", tribe.UUID)
+		for key, value := range params {
+			rctx.URLParams.Add(key, value)
+		}
+		ctx := context.WithValue(context.WithValue(context.Background(), auth.ContextKey, pubkey), chi.RouteCtxKey, rctx)
+		req, _ := http.NewRequestWithContext(ctx, method, "/tribes/tribe-uuid/webhooks", bytes.NewBufferString(body))
+		return req
+	}
+
+	t.Run("should register a webhook and return its secret once", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mockDb.On("GetTribe", "tribe-uuid").Return(tribe).Once()
+		mockDb.On("GetTribeWebhooks", "tribe-uuid").Return([]db.TribeWebhook{}, nil).Once()
+		mockDb.On("CreateTribeWebhook", mock.MatchedBy(func(webhook db.TribeWebhook) bool {
+			return webhook.Url == "https://example.com/hook" && len(webhook.Secret) == 40 && webhook.Events[0] == db.TribeWebhookMemberJoined
+		})).Return(func(webhook db.TribeWebhook) (db.TribeWebhook, error) { return webhook, nil }).Once()
+
+		rr := httptest.NewRecorder()
+		body := `{"url": "https://example.com/hook", "events": ["tribe.member_joined"]}`
+		http.HandlerFunc(NewTribeHandler(mockDb).CreateTribeWebhook).ServeHTTP(rr, newRequest("owner", http.MethodPost, body, nil))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		webhook := db.TribeWebhook{}
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &webhook))
+		assert.NotEmpty(t, webhook.Secret)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should refuse an event that is not a lifecycle event", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mockDb.On("GetTribe", "tribe-uuid").Return(tribe).Once()
+
+		rr := httptest.NewRecorder()
+		body := `{"url": "https://example.com/hook", "events": ["bounty.created"]}`
+		http.HandlerFunc(NewTribeHandler(mockDb).CreateTribeWebhook).ServeHTTP(rr, newRequest("owner", http.MethodPost, body, nil))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockDb.AssertNotCalled(t, "CreateTribeWebhook", mock.Anything)
+	})
+
+	t.Run("should keep a tribe to its webhook limit", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mockDb.On("GetTribe", "tribe-uuid").Return(tribe).Once()
+		mockDb.On("GetTribeWebhooks", "tribe-uuid").Return(make([]db.TribeWebhook, maxTribeWebhooks), nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(NewTribeHandler(mockDb).CreateTribeWebhook).ServeHTTP(rr, newRequest("owner", http.MethodPost, `{"url": "https://example.com/hook"}`, nil))
+
+		assert.Equal(t, http.StatusConflict, rr.Code)
+		mockDb.AssertNotCalled(t, "CreateTribeWebhook", mock.Anything)
+	})
+
+	t.Run("should list the webhooks without their secrets", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mockDb.On("GetTribe", "tribe-uuid").Return(tribe).Once()
+		mockDb.On("GetTribeWebhooks", "tribe-uuid").Return([]db.TribeWebhook{{Uuid: "webhook-uuid", Secret: "secret"}}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(NewTribeHandler(mockDb).GetTribeWebhooks).ServeHTTP(rr, newRequest("owner", http.MethodGet, "", nil))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.NotContains(t, rr.Body.String(), "secret")
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should not delete the webhook of another tribe", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mockDb.On("GetTribe", "tribe-uuid").Return(tribe).Once()
+		mockDb.On("GetTribeWebhook", "webhook-uuid").Return(db.TribeWebhook{ID: 1, Uuid: "webhook-uuid", TribeUuid: "other-tribe"}).Once()
+
+		rr := httptest.NewRecorder()
+		req := newRequest("owner", http.MethodDelete, "", map[string]string{"webhook_uuid": "webhook-uuid"})
+		http.HandlerFunc(NewTribeHandler(mockDb).DeleteTribeWebhook).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		mockDb.AssertNotCalled(t, "DeleteTribeWebhook", mock.Anything)
+	})
+
+	t.Run("should only let the owner manage the webhooks", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mockDb.On("GetTribe", "tribe-uuid").Return(tribe).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(NewTribeHandler(mockDb).GetTribeWebhooks).ServeHTTP(rr, newRequest("someone", http.MethodGet, "", nil))
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		mockDb.AssertNotCalled(t, "GetTribeWebhooks", mock.Anything)
+	})
+}
@@ -3,10 +3,12 @@ package handlers
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
@@ -14,6 +16,9 @@ import (
 	"github.com/stakwork/sphinx-tribes/auth"
 	"github.com/stakwork/sphinx-tribes/config"
 	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/events"
+	"github.com/stakwork/sphinx-tribes/httpio"
+	"github.com/stakwork/sphinx-tribes/upstream"
 	"github.com/stakwork/sphinx-tribes/utils"
 )
 
@@ -31,10 +36,19 @@ func NewTribeHandler(db db.Database) *tribeHandler {
 	}
 }
 
+// GetAllTribes lists every tribe that isn't deleted, from the read cache.
+// X-Cache tells if the list came from the cache
 func (th *tribeHandler) GetAllTribes(w http.ResponseWriter, r *http.Request) {
-	tribes := th.db.GetAllTribes()
+	tribes, status, err := db.CachedRead(db.TribesCacheKey+"all", func() (interface{}, error) {
+		return th.db.GetAllTribes(), nil
+	})
+	if err != nil {
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to encode tribes")
+		return
+	}
+	w.Header().Set("X-Cache", status)
 	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(tribes)
+	w.Write(tribes)
 }
 
 func (th *tribeHandler) GetTotalribes(w http.ResponseWriter, r *http.Request) {
@@ -43,10 +57,77 @@ func (th *tribeHandler) GetTotalribes(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(tribesTotal)
 }
 
+// the tribes on a cursor page when ?limit= isn't given, and the most a page
+// can hold
+const (
+	tribePageDefault = 20
+	tribePageMax     = 100
+)
+
+// GetListedTribes lists the listed tribes by page, or by cursor when
+// ?after= is given. The first page by cursor has an empty ?after=. Both
+// are served from the read cache, X-Cache tells if they were
 func (th *tribeHandler) GetListedTribes(w http.ResponseWriter, r *http.Request) {
-	tribes := th.db.GetListedTribes(r)
+	if _, paged := r.URL.Query()["after"]; paged {
+		th.getListedTribesPage(w, r)
+		return
+	}
+
+	tribes, status, err := db.CachedRead(db.ListCacheKey(db.TribesCacheKey, r), func() (interface{}, error) {
+		return utils.ListBody(r, th.db.GetListedTribes(r), func() int64 {
+			return th.db.GetListedTribesCount(r)
+		}), nil
+	})
+	if err != nil {
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to encode tribes")
+		return
+	}
+	w.Header().Set("X-Cache", status)
 	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(tribes)
+	w.Write(tribes)
+}
+
+// getListedTribesPage answers a page of the listed tribes in the list
+// envelope, its next_cursor is passed back as ?after= for the next page
+func (th *tribeHandler) getListedTribesPage(w http.ResponseWriter, r *http.Request) {
+	limit := tribePageDefault
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 1 {
+			httpio.WriteError(w, r, http.StatusBadRequest, "limit has to be a positive number")
+			return
+		}
+		limit = parsed
+	}
+	if limit > tribePageMax {
+		limit = tribePageMax
+	}
+
+	page, status, err := db.CachedRead(db.TribesCacheKey+"page:"+r.URL.RawQuery, func() (interface{}, error) {
+		tribes, next, err := th.db.GetListedTribesPaginated(r, limit, r.URL.Query().Get("after"))
+		if err != nil {
+			return nil, err
+		}
+		return utils.ListResponse{
+			Data:       tribes,
+			Total:      th.db.GetListedTribesCount(r),
+			NextCursor: next,
+			Limit:      limit,
+		}, nil
+	})
+	if errors.Is(err, db.ErrInvalidCursor) || errors.Is(err, db.ErrUnpagedSort) {
+		httpio.WriteError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		fmt.Println("[tribes]", err)
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to get the tribes")
+		return
+	}
+
+	w.Header().Set("X-Cache", status)
+	w.WriteHeader(http.StatusOK)
+	w.Write(page)
 }
 
 func (th *tribeHandler) GetTribesByOwner(w http.ResponseWriter, r *http.Request) {
@@ -92,25 +173,25 @@ func PutTribeStats(w http.ResponseWriter, r *http.Request) {
 	err = json.Unmarshal(body, &tribe)
 	if err != nil {
 		fmt.Println(err)
-		w.WriteHeader(http.StatusNotAcceptable)
+		httpio.WriteError(w, r, http.StatusNotAcceptable, "")
 		return
 	}
 
 	if tribe.UUID == "" {
-		w.WriteHeader(http.StatusUnauthorized)
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
 		return
 	}
 
 	extractedPubkey, err := auth.VerifyTribeUUID(tribe.UUID, false)
 	if err != nil {
 		fmt.Println(err)
-		w.WriteHeader(http.StatusUnauthorized)
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
 		return
 	}
 
 	// from token must match
 	if pubKeyFromAuth != extractedPubkey {
-		w.WriteHeader(http.StatusUnauthorized)
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
 		return
 	}
 
@@ -133,26 +214,28 @@ func (th *tribeHandler) DeleteTribe(w http.ResponseWriter, r *http.Request) {
 	uuid := chi.URLParam(r, "uuid")
 
 	if uuid == "" {
-		w.WriteHeader(http.StatusUnauthorized)
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
 		return
 	}
 
 	extractedPubkey, err := th.verifyTribeUUID(uuid, false)
 	if err != nil {
 		fmt.Println(err)
-		w.WriteHeader(http.StatusUnauthorized)
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
 		return
 	}
 
 	// from token must match
 	if pubKeyFromAuth != extractedPubkey {
-		w.WriteHeader(http.StatusUnauthorized)
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
 		return
 	}
 
-	th.db.UpdateTribe(uuid, map[string]interface{}{
-		"deleted": true,
-	})
+	if _, err := th.db.SoftDelete(db.SoftDeleteTribes, uuid); err != nil {
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to delete the tribe")
+		return
+	}
+	events.Publish(ctx, events.TribeUpdated, "tribe:"+uuid, map[string]interface{}{"uuid": uuid, "deleted": true})
 
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(true)
@@ -167,6 +250,10 @@ func (th *tribeHandler) GetTribe(w http.ResponseWriter, r *http.Request) {
 	json.Unmarshal(j, &theTribe)
 
 	theTribe["channels"] = th.db.GetChannelsByTribe(uuid)
+	theTribe["announcements"] = th.db.GetPinnedTribeAnnouncements(uuid, time.Now())
+	if categories, err := th.db.GetTribesCategories([]string{uuid}); err == nil {
+		theTribe["categories"] = categories[uuid]
+	}
 
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(theTribe)
@@ -177,7 +264,7 @@ func (th *tribeHandler) GetFirstTribeByFeed(w http.ResponseWriter, r *http.Reque
 	tribe := th.db.GetFirstTribeByFeedURL(url)
 
 	if tribe.UUID == "" {
-		w.WriteHeader(http.StatusNotFound)
+		httpio.WriteError(w, r, http.StatusNotFound, "")
 		return
 	}
 
@@ -186,6 +273,7 @@ func (th *tribeHandler) GetFirstTribeByFeed(w http.ResponseWriter, r *http.Reque
 	json.Unmarshal(j, &theTribe)
 
 	theTribe["channels"] = th.db.GetChannelsByTribe(tribe.UUID)
+	theTribe["announcements"] = th.db.GetPinnedTribeAnnouncements(tribe.UUID, time.Now())
 
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(theTribe)
@@ -200,6 +288,7 @@ func (th *tribeHandler) GetTribeByUniqueName(w http.ResponseWriter, r *http.Requ
 	json.Unmarshal(j, &theTribe)
 
 	theTribe["channels"] = th.db.GetChannelsByTribe(tribe.UUID)
+	theTribe["announcements"] = th.db.GetPinnedTribeAnnouncements(tribe.UUID, time.Now())
 
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(theTribe)
@@ -215,13 +304,17 @@ func (th *tribeHandler) CreateOrEditTribe(w http.ResponseWriter, r *http.Request
 	err = json.Unmarshal(body, &tribe)
 	if err != nil {
 		fmt.Println(err)
-		w.WriteHeader(http.StatusNotAcceptable)
+		httpio.WriteError(w, r, http.StatusNotAcceptable, "")
 		return
 	}
 
 	if tribe.UUID == "" {
 		fmt.Println("createOrEditTribe no uuid")
-		w.WriteHeader(http.StatusUnauthorized)
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
+		return
+	}
+
+	if !validatePayload(w, r, tribe) {
 		return
 	}
 
@@ -230,7 +323,7 @@ func (th *tribeHandler) CreateOrEditTribe(w http.ResponseWriter, r *http.Request
 	extractedPubkey, err := th.verifyTribeUUID(tribe.UUID, false)
 	if err != nil {
 		fmt.Println("extract UUID error", err)
-		w.WriteHeader(http.StatusUnauthorized)
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
 		return
 	}
 
@@ -239,11 +332,27 @@ func (th *tribeHandler) CreateOrEditTribe(w http.ResponseWriter, r *http.Request
 	} else { // IF PUBKEY IN CONTEXT, MUST AUTH!
 		if pubKeyFromAuth != extractedPubkey {
 			fmt.Println("createOrEditTribe pubkeys dont match")
-			w.WriteHeader(http.StatusUnauthorized)
+			httpio.WriteError(w, r, http.StatusUnauthorized, "")
 			return
 		}
 	}
 
+	if th.db.IsBannedPubkey(extractedPubkey) {
+		httpio.WriteError(w, r, http.StatusForbidden, "This account is banned")
+		return
+	}
+
+	unknown, err := unknownCategory(th.db, tribe.Categories)
+	if err != nil {
+		fmt.Println("[tribes]", err)
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to get the categories")
+		return
+	}
+	if unknown != "" {
+		httpio.WriteError(w, r, http.StatusBadRequest, "Error: unknown category "+unknown)
+		return
+	}
+
 	existing := th.db.GetTribe(tribe.UUID)
 	if existing.UUID == "" { // if doesn't exist already, create unique name
 		tribe.UniqueName, _ = th.tribeUniqueNameFromName(tribe.Name)
@@ -252,7 +361,7 @@ func (th *tribeHandler) CreateOrEditTribe(w http.ResponseWriter, r *http.Request
 			fmt.Println("createOrEditTribe tribe.ownerPubKey not match")
 			fmt.Println(existing.OwnerPubKey)
 			fmt.Println(extractedPubkey)
-			w.WriteHeader(http.StatusUnauthorized)
+			httpio.WriteError(w, r, http.StatusUnauthorized, "")
 			return
 		}
 	}
@@ -261,12 +370,33 @@ func (th *tribeHandler) CreateOrEditTribe(w http.ResponseWriter, r *http.Request
 	tribe.Updated = &now
 	tribe.LastActive = now.Unix()
 
+	claim, ok := claimRequest(w, r, DedupTribe, extractedPubkey, body, func(id string) (interface{}, bool) {
+		existing := th.db.GetTribe(id)
+		return existing, existing.UUID != ""
+	})
+	if !ok {
+		return
+	}
+
 	_, err = th.db.CreateOrEditTribe(tribe)
 	if err != nil {
+		claim.done("")
 		fmt.Println("=> ERR createOrEditTribe", err)
-		w.WriteHeader(http.StatusBadRequest)
+		httpio.WriteError(w, r, http.StatusBadRequest, "")
 		return
 	}
+	claim.done(tribe.UUID)
+	// categories left out of the body are kept
+	if tribe.Categories != nil {
+		if err := th.db.SetTribeCategories(tribe.UUID, tribe.Categories); err != nil {
+			fmt.Println("[tribes] could not set the categories", err)
+		}
+	}
+	if existing.UUID == "" {
+		events.Publish(r.Context(), events.TribeCreated, "tribe:"+tribe.UUID, tribe)
+	} else {
+		events.Publish(r.Context(), events.TribeUpdated, "tribe:"+tribe.UUID, tribe)
+	}
 
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(tribe)
@@ -278,20 +408,20 @@ func PutTribeActivity(w http.ResponseWriter, r *http.Request) {
 
 	uuid := chi.URLParam(r, "uuid")
 	if uuid == "" {
-		w.WriteHeader(http.StatusUnauthorized)
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
 		return
 	}
 
 	extractedPubkey, err := auth.VerifyTribeUUID(uuid, false)
 	if err != nil {
 		fmt.Println(err)
-		w.WriteHeader(http.StatusUnauthorized)
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
 		return
 	}
 
 	// from token must match
 	if pubKeyFromAuth != extractedPubkey {
-		w.WriteHeader(http.StatusUnauthorized)
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
 		return
 	}
 
@@ -304,38 +434,6 @@ func PutTribeActivity(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(true)
 }
 
-func (th *tribeHandler) SetTribePreview(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
-	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
-
-	uuid := chi.URLParam(r, "uuid")
-	if uuid == "" {
-		w.WriteHeader(http.StatusUnauthorized)
-		return
-	}
-
-	extractedPubkey, err := th.verifyTribeUUID(uuid, false)
-	if err != nil {
-		fmt.Println(err)
-		w.WriteHeader(http.StatusUnauthorized)
-		return
-	}
-
-	// from token must match
-	if pubKeyFromAuth != extractedPubkey {
-		w.WriteHeader(http.StatusUnauthorized)
-		return
-	}
-
-	preview := r.URL.Query().Get("preview")
-	th.db.UpdateTribe(uuid, map[string]interface{}{
-		"preview": preview,
-	})
-
-	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(true)
-}
-
 func CreateLeaderBoard(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
@@ -344,20 +442,20 @@ func CreateLeaderBoard(w http.ResponseWriter, r *http.Request) {
 	leaderBoard := []db.LeaderBoard{}
 
 	if uuid == "" {
-		w.WriteHeader(http.StatusUnauthorized)
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
 		return
 	}
 
 	extractedPubkey, err := auth.VerifyTribeUUID(uuid, false)
 	if err != nil {
 		fmt.Println(err)
-		w.WriteHeader(http.StatusUnauthorized)
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
 		return
 	}
 
 	//from token must match
 	if pubKeyFromAuth != extractedPubkey {
-		w.WriteHeader(http.StatusUnauthorized)
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
 		return
 	}
 
@@ -366,7 +464,7 @@ func CreateLeaderBoard(w http.ResponseWriter, r *http.Request) {
 	err = json.Unmarshal(body, &leaderBoard)
 	if err != nil {
 		fmt.Println(err)
-		w.WriteHeader(http.StatusNotAcceptable)
+		httpio.WriteError(w, r, http.StatusNotAcceptable, "")
 		return
 	}
 
@@ -374,7 +472,7 @@ func CreateLeaderBoard(w http.ResponseWriter, r *http.Request) {
 
 	if err != nil {
 		fmt.Println(err)
-		w.WriteHeader(http.StatusNotAcceptable)
+		httpio.WriteError(w, r, http.StatusNotAcceptable, "")
 		return
 	}
 
@@ -400,7 +498,7 @@ func GetLeaderBoard(w http.ResponseWriter, r *http.Request) {
 		leaderBoardFromDb := db.DB.GetLeaderBoardByUuidAndAlias(uuid, alias)
 
 		if leaderBoardFromDb.Alias != alias {
-			w.WriteHeader(http.StatusNotFound)
+			httpio.WriteError(w, r, http.StatusNotFound, "")
 			return
 		}
 
@@ -415,20 +513,20 @@ func UpdateLeaderBoard(w http.ResponseWriter, r *http.Request) {
 	uuid := chi.URLParam(r, "tribe_uuid")
 
 	if uuid == "" {
-		w.WriteHeader(http.StatusUnauthorized)
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
 		return
 	}
 
 	extractedPubkey, err := auth.VerifyTribeUUID(uuid, false)
 	if err != nil {
 		fmt.Println(err)
-		w.WriteHeader(http.StatusUnauthorized)
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
 		return
 	}
 
 	//from token must match
 	if pubKeyFromAuth != extractedPubkey {
-		w.WriteHeader(http.StatusUnauthorized)
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
 		return
 	}
 
@@ -439,14 +537,14 @@ func UpdateLeaderBoard(w http.ResponseWriter, r *http.Request) {
 	err = json.Unmarshal(body, &leaderBoard)
 	if err != nil {
 		fmt.Println(err)
-		w.WriteHeader(http.StatusNotAcceptable)
+		httpio.WriteError(w, r, http.StatusNotAcceptable, "")
 		return
 	}
 
 	leaderBoardFromDb := db.DB.GetLeaderBoardByUuidAndAlias(uuid, leaderBoard.Alias)
 
 	if leaderBoardFromDb.Alias != leaderBoard.Alias {
-		w.WriteHeader(http.StatusNotFound)
+		httpio.WriteError(w, r, http.StatusNotFound, "")
 		return
 	}
 
@@ -470,7 +568,7 @@ func GenerateInvoice(w http.ResponseWriter, r *http.Request) {
 
 	if err != nil {
 		fmt.Println(err)
-		w.WriteHeader(http.StatusNotAcceptable)
+		httpio.WriteError(w, r, http.StatusNotAcceptable, "")
 		return
 	}
 
@@ -478,7 +576,7 @@ func GenerateInvoice(w http.ResponseWriter, r *http.Request) {
 
 	if err != nil {
 		fmt.Println(err)
-		w.WriteHeader(http.StatusNotAcceptable)
+		httpio.WriteError(w, r, http.StatusNotAcceptable, "")
 		return
 	}
 
@@ -496,19 +594,23 @@ func GenerateInvoice(w http.ResponseWriter, r *http.Request) {
 
 	jsonBody := []byte(bodyData)
 
-	client := &http.Client{}
-	req, _ := http.NewRequest(http.MethodPost, url, bytes.NewBuffer(jsonBody))
+	req, _ := http.NewRequestWithContext(r.Context(), http.MethodPost, url, bytes.NewBuffer(jsonBody))
 
 	req.Header.Set("x-user-token", config.RelayAuthKey)
 	req.Header.Set("Content-Type", "application/json")
-	res, _ := client.Do(req)
+	res, err := upstream.Default.Do(req)
 
 	if err != nil {
 		log.Printf("Request Failed: %s", err)
+		upstream.WriteError(w, r, err, "Could not create the invoice")
 		return
 	}
 
 	defer res.Body.Close()
+	if res.StatusCode >= 300 {
+		upstream.WriteError(w, r, upstream.StatusError(upstream.Relay, res), "Could not create the invoice")
+		return
+	}
 
 	body, err = io.ReadAll(res.Body)
 
@@ -563,7 +665,7 @@ func (th *tribeHandler) GenerateBudgetInvoice(w http.ResponseWriter, r *http.Req
 
 	if err != nil {
 		fmt.Println(err)
-		w.WriteHeader(http.StatusNotAcceptable)
+		httpio.WriteError(w, r, http.StatusNotAcceptable, "")
 		return
 	}
 
@@ -571,7 +673,7 @@ func (th *tribeHandler) GenerateBudgetInvoice(w http.ResponseWriter, r *http.Req
 
 	if err != nil {
 		fmt.Println(err)
-		w.WriteHeader(http.StatusNotAcceptable)
+		httpio.WriteError(w, r, http.StatusNotAcceptable, "")
 		return
 	}
 
@@ -579,25 +681,42 @@ func (th *tribeHandler) GenerateBudgetInvoice(w http.ResponseWriter, r *http.Req
 		invoice.WorkspaceUuid = invoice.OrgUuid
 	}
 
+	// a flagged or unverified workspace gets no deposits
+	if invoice.WorkspaceUuid != "" {
+		workspace := th.db.GetWorkspaceByUuid(invoice.WorkspaceUuid)
+		if workspace.Flagged {
+			httpio.WriteError(w, r, http.StatusForbidden, "The workspace is waiting for a review")
+			return
+		}
+		if workspace.Uuid != "" && workspace.Verified == nil && workspaceVerificationMode() != config.VerificationOff {
+			httpio.WriteError(w, r, http.StatusForbidden, "The workspace must be verified before it receives deposits")
+			return
+		}
+	}
+
 	url := fmt.Sprintf("%s/invoices", config.RelayUrl)
 
 	bodyData := fmt.Sprintf(`{"amount": %d, "memo": "%s"}`, invoice.Amount, "Budget Invoice")
 
 	jsonBody := []byte(bodyData)
 
-	client := &http.Client{}
-	req, _ := http.NewRequest(http.MethodPost, url, bytes.NewBuffer(jsonBody))
+	req, _ := http.NewRequestWithContext(r.Context(), http.MethodPost, url, bytes.NewBuffer(jsonBody))
 
 	req.Header.Set("x-user-token", config.RelayAuthKey)
 	req.Header.Set("Content-Type", "application/json")
-	res, _ := client.Do(req)
+	res, err := upstream.Default.Do(req)
 
 	if err != nil {
 		log.Printf("Request Failed: %s", err)
+		upstream.WriteError(w, r, err, "Could not create the invoice")
 		return
 	}
 
 	defer res.Body.Close()
+	if res.StatusCode >= 300 {
+		upstream.WriteError(w, r, upstream.StatusError(upstream.Relay, res), "Could not create the invoice")
+		return
+	}
 
 	body, err = io.ReadAll(res.Body)
 
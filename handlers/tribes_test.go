@@ -16,7 +16,10 @@ import (
 	"github.com/lib/pq"
 	"github.com/stakwork/sphinx-tribes/auth"
 	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/dbtest"
+	"github.com/stakwork/sphinx-tribes/jobs"
 	mocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stakwork/sphinx-tribes/utils"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -87,9 +90,8 @@ func TestGetTribesByOwner(t *testing.T) {
 }
 
 func TestGetTribe(t *testing.T) {
-	teardownSuite := SetupSuite(t)
-	defer teardownSuite(t)
-	tHandler := NewTribeHandler(db.TestDB)
+	database := dbtest.New(t)
+	tHandler := NewTribeHandler(database)
 
 	tribe := db.Tribe{
 		UUID:        uuid.New().String(),
@@ -99,7 +101,7 @@ func TestGetTribe(t *testing.T) {
 		Tags:        []string{"tag1", "tag2"},
 		Badges:      pq.StringArray{},
 	}
-	db.TestDB.CreateOrEditTribe(tribe)
+	database.CreateOrEditTribe(tribe)
 
 	t.Run("Should test that a tribe can be returned when the right UUID is passed to the request parameter", func(t *testing.T) {
 		// Mock data
@@ -114,7 +116,7 @@ func TestGetTribe(t *testing.T) {
 			t.Fatal(err)
 		}
 
-		fetchedTribe := db.TestDB.GetTribe(mockUUID)
+		fetchedTribe := database.GetTribe(mockUUID)
 
 		handler := http.HandlerFunc(tHandler.GetTribe)
 		handler.ServeHTTP(rr, req)
@@ -158,10 +160,9 @@ func TestGetTribe(t *testing.T) {
 }
 
 func TestGetTribesByAppUrl(t *testing.T) {
-	teardownSuite := SetupSuite(t)
-	defer teardownSuite(t)
+	database := dbtest.New(t)
 
-	tHandler := NewTribeHandler(db.TestDB)
+	tHandler := NewTribeHandler(database)
 
 	t.Run("Should test that a tribe is returned when the right app URL is passed", func(t *testing.T) {
 		tribe := db.Tribe{
@@ -173,7 +174,7 @@ func TestGetTribesByAppUrl(t *testing.T) {
 			AppURL:      "valid_app_url",
 			Badges:      []string{},
 		}
-		db.TestDB.CreateOrEditTribe(tribe)
+		database.CreateOrEditTribe(tribe)
 
 		// Mock data
 		mockAppURL := tribe.AppURL
@@ -203,8 +204,7 @@ func TestGetTribesByAppUrl(t *testing.T) {
 }
 
 func TestDeleteTribe(t *testing.T) {
-	teardownSuite := SetupSuite(t)
-	defer teardownSuite(t)
+	database := dbtest.New(t)
 
 	personUUID := uuid.New().String()
 	person := db.Person{
@@ -215,7 +215,7 @@ func TestDeleteTribe(t *testing.T) {
 		PriceToMeet: 0,
 		Description: "this is test user 1",
 	}
-	db.TestDB.CreateOrEditPerson(person)
+	database.CreateOrEditPerson(person)
 
 	tribeUUID := uuid.New().String()
 	tribe := db.Tribe{
@@ -226,9 +226,9 @@ func TestDeleteTribe(t *testing.T) {
 		Tags:        []string{"tag3", "tag4"},
 		AppURL:      "tribe_app_url",
 	}
-	db.TestDB.CreateOrEditTribe(tribe)
+	database.CreateOrEditTribe(tribe)
 
-	tHandler := NewTribeHandler(db.TestDB)
+	tHandler := NewTribeHandler(database)
 
 	t.Run("Should test that the owner of a tribe can delete a tribe", func(t *testing.T) {
 		mockUUID := tribe.AppURL
@@ -261,7 +261,7 @@ func TestDeleteTribe(t *testing.T) {
 		assert.True(t, responseData)
 
 		// Assert that the tribe is deleted from the DB
-		deletedTribe := db.TestDB.GetTribe(tribeUUID)
+		deletedTribe := database.GetTribe(tribeUUID)
 		assert.NoError(t, err)
 		assert.Empty(t, deletedTribe)
 		assert.Equal(t, db.Tribe{}, deletedTribe)
@@ -311,6 +311,7 @@ func TestGetFirstTribeByFeed(t *testing.T) {
 
 		mockDb.On("GetFirstTribeByFeedURL", mockFeedURL).Return(mockTribe).Once()
 		mockDb.On("GetChannelsByTribe", mockTribe.UUID).Return(mockChannels).Once()
+		mockDb.On("GetPinnedTribeAnnouncements", mockTribe.UUID, mock.Anything).Return([]db.TribeAnnouncement{}).Once()
 
 		// Create request with valid feed URL
 		req, err := http.NewRequest("GET", "/tribe_by_feed?url="+mockFeedURL, nil)
@@ -347,7 +348,13 @@ func TestSetTribePreview(t *testing.T) {
 		mockVerifyTribeUUID := func(uuid string, checkTimestamp bool) (string, error) {
 			return mockOwnerPubKey, nil
 		}
-		mockDb.On("UpdateTribe", mock.Anything, map[string]interface{}{"preview": "preview"}).Return(true)
+		defer func(queue *jobs.Queue) { jobs.Default = queue }(jobs.Default)
+		jobs.Default = jobs.NewQueue(mockDb)
+		mockDb.On("GetTribe", "mockUUID").Return(db.Tribe{UUID: "mockUUID", AppURL: "https://example.com"}).Once()
+		mockDb.On("QueueTribePreview", "mockUUID", "https://example.com").Return(db.TribePreview{ID: 1, TribeUuid: "mockUUID", Url: "https://example.com", Status: db.PreviewPending}, nil).Once()
+		mockDb.On("EnqueueJob", mock.MatchedBy(func(j db.Job) bool {
+			return j.Type == jobs.TribePreviewJob && j.Payload["tribe_uuid"] == "mockUUID"
+		})).Return(db.Job{}, nil).Once()
 
 		tHandler.verifyTribeUUID = mockVerifyTribeUUID
 
@@ -366,11 +373,12 @@ func TestSetTribePreview(t *testing.T) {
 		handler.ServeHTTP(rr, req)
 
 		// Verify response
-		assert.Equal(t, http.StatusOK, rr.Code)
-		var responseData bool
+		assert.Equal(t, http.StatusAccepted, rr.Code)
+		var responseData db.TribePreview
 		errors := json.Unmarshal(rr.Body.Bytes(), &responseData)
 		assert.NoError(t, errors)
-		assert.True(t, responseData)
+		assert.Equal(t, db.PreviewPending, responseData.Status)
+		mockDb.AssertNotCalled(t, "UpdateTribe", mock.Anything, mock.Anything)
 	})
 
 	t.Run("Should test that a 401 error is returned when setting a tribe preview action by someone other than the owner", func(t *testing.T) {
@@ -405,10 +413,9 @@ func TestSetTribePreview(t *testing.T) {
 }
 
 func TestCreateOrEditTribe(t *testing.T) {
-	teardownSuite := SetupSuite(t)
-	defer teardownSuite(t)
+	database := dbtest.New(t)
 
-	tHandler := NewTribeHandler(db.TestDB)
+	tHandler := NewTribeHandler(database)
 
 	t.Run("Should test that a tribe can be created when the right data is passed", func(t *testing.T) {
 
@@ -472,10 +479,9 @@ func TestCreateOrEditTribe(t *testing.T) {
 }
 
 func TestGetTribeByUniqueName(t *testing.T) {
-	teardownSuite := SetupSuite(t)
-	defer teardownSuite(t)
+	database := dbtest.New(t)
 
-	tHandler := NewTribeHandler(db.TestDB)
+	tHandler := NewTribeHandler(database)
 
 	t.Run("Should test that a tribe can be fetched by its unique name", func(t *testing.T) {
 
@@ -489,7 +495,7 @@ func TestGetTribeByUniqueName(t *testing.T) {
 			AppURL:      "valid_app_url",
 			Badges:      []string{},
 		}
-		db.TestDB.CreateOrEditTribe(tribe)
+		database.CreateOrEditTribe(tribe)
 
 		mockUniqueName := tribe.UniqueName
 
@@ -522,16 +528,13 @@ func TestGetTribeByUniqueName(t *testing.T) {
 }
 
 func TestGetAllTribes(t *testing.T) {
-	teardownSuite := SetupSuite(t)
-	defer teardownSuite(t)
+	database := dbtest.New(t)
 
-	tHandler := NewTribeHandler(db.TestDB)
+	tHandler := NewTribeHandler(database)
 	t.Run("should return all tribes", func(t *testing.T) {
 		rr := httptest.NewRecorder()
 		handler := http.HandlerFunc(tHandler.GetAllTribes)
 
-		db.TestDB.DeleteTribe()
-
 		tribe := db.Tribe{
 			UUID:        "uuid",
 			OwnerPubKey: "pubkey",
@@ -554,8 +557,8 @@ func TestGetAllTribes(t *testing.T) {
 			Badges:      []string{},
 		}
 
-		db.TestDB.CreateOrEditTribe(tribe)
-		db.TestDB.CreateOrEditTribe(tribe2)
+		database.CreateOrEditTribe(tribe)
+		database.CreateOrEditTribe(tribe2)
 
 		expectedTribes := []db.Tribe{
 			tribe,
@@ -676,6 +679,104 @@ func TestGetListedTribes(t *testing.T) {
 
 	})
 
+	t.Run("should wrap the tribes in the list envelope when the route asks for it", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		handler := utils.ListEnvelope(http.HandlerFunc(tHandler.GetListedTribes))
+		expectedTribes := []db.Tribe{
+			{UUID: "1", Name: "Tribe 1"},
+			{UUID: "2", Name: "Tribe 2"},
+		}
+
+		req, err := http.NewRequest("GET", "/tribes?page=1&limit=2", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		mockDb.On("GetListedTribes", mock.Anything).Return(expectedTribes).Once()
+		mockDb.On("GetListedTribesCount", mock.Anything).Return(int64(5)).Once()
+		handler.ServeHTTP(rr, req)
+
+		var returned struct {
+			Data       []db.Tribe `json:"data"`
+			Total      int64      `json:"total"`
+			NextCursor string     `json:"next_cursor"`
+			Limit      int        `json:"limit"`
+		}
+		err = json.Unmarshal(rr.Body.Bytes(), &returned)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.EqualValues(t, expectedTribes, returned.Data)
+		assert.Equal(t, int64(5), returned.Total)
+		assert.Equal(t, 2, returned.Limit)
+		assert.Equal(t, utils.EncodeCursor(2), returned.NextCursor)
+	})
+
+	t.Run("should page the tribes by cursor when after is given", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		expectedTribes := []db.Tribe{{UUID: "1", Name: "Tribe 1"}, {UUID: "2", Name: "Tribe 2"}}
+
+		req, err := http.NewRequest("GET", "/tribes?after=&limit=2", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		mockDb.On("GetListedTribesPaginated", req, 2, "").Return(expectedTribes, "next-cursor", nil).Once()
+		mockDb.On("GetListedTribesCount", req).Return(int64(5)).Once()
+		http.HandlerFunc(tHandler.GetListedTribes).ServeHTTP(rr, req)
+
+		returned := struct {
+			Data       []db.Tribe `json:"data"`
+			Total      int64      `json:"total"`
+			NextCursor string     `json:"next_cursor"`
+			Limit      int        `json:"limit"`
+		}{}
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &returned))
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.EqualValues(t, expectedTribes, returned.Data)
+		assert.Equal(t, "next-cursor", returned.NextCursor)
+		assert.Equal(t, int64(5), returned.Total)
+		assert.Equal(t, 2, returned.Limit)
+	})
+
+	t.Run("should pass the cursor on and cap the limit", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest("GET", "/tribes?after=cursor&limit=1000", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		mockDb.On("GetListedTribesPaginated", req, tribePageMax, "cursor").Return([]db.Tribe{}, "", nil).Once()
+		mockDb.On("GetListedTribesCount", req).Return(int64(5)).Once()
+		http.HandlerFunc(tHandler.GetListedTribes).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"next_cursor":""`)
+	})
+
+	t.Run("should return 400 for a cursor of another list", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest("GET", "/tribes?after=bogus", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		mockDb.On("GetListedTribesPaginated", req, tribePageDefault, "bogus").Return(nil, "", db.ErrInvalidCursor).Once()
+		http.HandlerFunc(tHandler.GetListedTribes).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("should return 400 for an invalid limit", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest("GET", "/tribes?after=&limit=-1", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		http.HandlerFunc(tHandler.GetListedTribes).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
 }
 
 func TestGenerateBudgetInvoice(t *testing.T) {
@@ -0,0 +1,317 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/rs/xid"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/httpio"
+	"github.com/stakwork/sphinx-tribes/jobs"
+)
+
+// UploadAssemblyJob assembles and stores an upload once its last chunk is
+// received
+const UploadAssemblyJob = "upload.assemble"
+
+// largest chunk of a resumable upload, the upload routes allow a bit more
+// for the request
+const maxUploadChunk = 8 << 20
+
+// the content type of chunks, and the status of a chunk that doesn't match
+// its Upload-Checksum, as in the tus protocol
+const (
+	uploadChunkType        = "application/offset+octet-stream"
+	statusChecksumMismatch = 460
+)
+
+var (
+	errUploadChecksum = errors.New("the checksum of the assembled file does not match")
+	unsafeFileChars   = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+)
+
+// fileStore saves an assembled upload under a key and returns its url
+type fileStore interface {
+	SaveFile(ctx context.Context, key string, contentType string, data []byte) (string, error)
+}
+
+type uploadHandler struct {
+	db    db.Database
+	store fileStore
+}
+
+func NewUploadHandler(database db.Database) *uploadHandler {
+	if config.Get().ImageStore == config.ImageStoreS3 {
+		return &uploadHandler{db: database, store: s3ImageStore{}}
+	}
+	return &uploadHandler{db: database, store: memeImageStore{}}
+}
+
+// writeUploadHeaders sets the tus headers of the state of an upload
+func writeUploadHeaders(w http.ResponseWriter, session db.UploadSession) {
+	w.Header().Set("Upload-Offset", strconv.FormatInt(session.Received, 10))
+	w.Header().Set("Upload-Length", strconv.FormatInt(session.Size, 10))
+	if session.Expires != nil {
+		w.Header().Set("Upload-Expires", session.Expires.UTC().Format(http.TimeFormat))
+	}
+	w.Header().Set("Cache-Control", "no-store")
+}
+
+// ownedUpload is the upload of the route when the caller opened it, it
+// writes the error otherwise
+func (uh *uploadHandler) ownedUpload(w http.ResponseWriter, r *http.Request) (db.UploadSession, bool) {
+	pubKeyFromAuth, _ := r.Context().Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[uploads] no pubkey from auth")
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
+		return db.UploadSession{}, false
+	}
+	session, err := uh.db.GetUploadSession(chi.URLParam(r, "uuid"))
+	if err != nil || session.OwnerPubKey != pubKeyFromAuth {
+		httpio.WriteError(w, r, http.StatusNotFound, "Upload not found")
+		return session, false
+	}
+	return session, true
+}
+
+// CreateUpload opens a resumable upload of a file of a known size and
+// sha256. The chunks are sent to the Location of the answer
+func (uh *uploadHandler) CreateUpload(w http.ResponseWriter, r *http.Request) {
+	pubKeyFromAuth, _ := r.Context().Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[uploads] no pubkey from auth")
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
+		return
+	}
+
+	request := db.UploadSessionRequest{}
+	body, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err := json.Unmarshal(body, &request); err != nil {
+		fmt.Println("[uploads]", err)
+		httpio.WriteError(w, r, http.StatusNotAcceptable, "")
+		return
+	}
+	if !validatePayload(w, r, request) {
+		return
+	}
+	cfg := config.Get()
+	maxBytes := int64(cfg.UploadMaxMb) << 20
+	if request.Size > maxBytes {
+		httpio.WriteErrorDetails(w, r, http.StatusRequestEntityTooLarge,
+			fmt.Sprintf("The file is larger than %d bytes", maxBytes),
+			map[string]int64{"max_bytes": maxBytes})
+		return
+	}
+
+	expires := time.Now().Add(time.Duration(cfg.UploadSessionHours) * time.Hour)
+	session, err := uh.db.CreateUploadSession(db.UploadSession{
+		Uuid:        xid.New().String(),
+		OwnerPubKey: pubKeyFromAuth,
+		Filename:    request.Filename,
+		ContentType: request.ContentType,
+		Size:        request.Size,
+		Checksum:    strings.ToLower(request.Checksum),
+		Status:      db.UploadPending,
+		Expires:     &expires,
+	})
+	if err != nil {
+		fmt.Println("[uploads]", err)
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to open the upload")
+		return
+	}
+
+	writeUploadHeaders(w, session)
+	w.Header().Set("Location", strings.TrimRight(r.URL.Path, "/")+"/"+session.Uuid)
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(session)
+}
+
+// GetUpload returns the state of an upload, with its url once it is stored
+func (uh *uploadHandler) GetUpload(w http.ResponseWriter, r *http.Request) {
+	session, ok := uh.ownedUpload(w, r)
+	if !ok {
+		return
+	}
+
+	writeUploadHeaders(w, session)
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(session)
+}
+
+// HeadUpload tells a client resuming an upload the offset to send from
+func (uh *uploadHandler) HeadUpload(w http.ResponseWriter, r *http.Request) {
+	session, ok := uh.ownedUpload(w, r)
+	if !ok {
+		return
+	}
+
+	writeUploadHeaders(w, session)
+	w.WriteHeader(http.StatusOK)
+}
+
+// PatchUpload receives the chunk of an upload that starts at its
+// Upload-Offset. A chunk can carry an Upload-Checksum of "sha256 <base64>"
+// that is checked before it is stored. The last chunk queues the assembly
+func (uh *uploadHandler) PatchUpload(w http.ResponseWriter, r *http.Request) {
+	session, ok := uh.ownedUpload(w, r)
+	if !ok {
+		return
+	}
+	if r.Header.Get("Content-Type") != uploadChunkType {
+		httpio.WriteError(w, r, http.StatusUnsupportedMediaType, "Chunks are sent as "+uploadChunkType)
+		return
+	}
+	offset, err := strconv.ParseInt(r.Header.Get("Upload-Offset"), 10, 64)
+	if err != nil || offset < 0 {
+		httpio.WriteError(w, r, http.StatusBadRequest, "Upload-Offset is required")
+		return
+	}
+
+	chunk, err := io.ReadAll(io.LimitReader(r.Body, maxUploadChunk+1))
+	r.Body.Close()
+	if err != nil {
+		httpio.WriteError(w, r, http.StatusBadRequest, "Unable to read the chunk")
+		return
+	}
+	if len(chunk) == 0 {
+		httpio.WriteError(w, r, http.StatusBadRequest, "The chunk is empty")
+		return
+	}
+	if len(chunk) > maxUploadChunk {
+		httpio.WriteErrorDetails(w, r, http.StatusRequestEntityTooLarge,
+			fmt.Sprintf("The chunk is larger than %d bytes", maxUploadChunk),
+			map[string]int64{"max_bytes": maxUploadChunk})
+		return
+	}
+	if checksum := r.Header.Get("Upload-Checksum"); checksum != "" {
+		parts := strings.SplitN(checksum, " ", 2)
+		expected, err := base64.StdEncoding.DecodeString(parts[len(parts)-1])
+		if len(parts) != 2 || parts[0] != "sha256" || err != nil {
+			httpio.WriteError(w, r, http.StatusBadRequest, "Upload-Checksum is not a sha256 in base64")
+			return
+		}
+		if sum := sha256.Sum256(chunk); !bytes.Equal(sum[:], expected) {
+			httpio.WriteError(w, r, statusChecksumMismatch, "The chunk does not match its checksum")
+			return
+		}
+	}
+
+	updated, err := uh.db.AppendUploadChunk(session.Uuid, offset, chunk, time.Now())
+	switch {
+	case errors.Is(err, db.ErrUploadOffset):
+		writeUploadHeaders(w, session)
+		httpio.WriteErrorDetails(w, r, http.StatusConflict, err.Error(), map[string]int64{"offset": session.Received})
+		return
+	case errors.Is(err, db.ErrUploadExpired):
+		httpio.WriteError(w, r, http.StatusGone, err.Error())
+		return
+	case errors.Is(err, db.ErrUploadClosed):
+		httpio.WriteError(w, r, http.StatusConflict, err.Error())
+		return
+	case errors.Is(err, db.ErrUploadTooLong):
+		httpio.WriteError(w, r, http.StatusRequestEntityTooLarge, err.Error())
+		return
+	case err != nil:
+		fmt.Println("[uploads]", err)
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to store the chunk")
+		return
+	}
+
+	if updated.Status == db.UploadAssembling {
+		_, err := jobs.Default.Enqueue(UploadAssemblyJob, map[string]interface{}{"upload_uuid": updated.Uuid})
+		if err != nil {
+			fmt.Println("[uploads] could not queue the assembly of", updated.Uuid, err)
+			updated, _ = uh.db.CompleteUploadSession(updated.Uuid, "", err.Error())
+		}
+	}
+
+	writeUploadHeaders(w, updated)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// DeleteUpload ends an upload and drops the chunks it received
+func (uh *uploadHandler) DeleteUpload(w http.ResponseWriter, r *http.Request) {
+	session, ok := uh.ownedUpload(w, r)
+	if !ok {
+		return
+	}
+
+	if err := uh.db.DeleteUploadSession(session.Uuid); err != nil {
+		fmt.Println("[uploads]", err)
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to delete the upload")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(true)
+}
+
+// assembleUpload joins the chunks of an upload in file order, checks the
+// sha256 of the file and stores it
+func (uh *uploadHandler) assembleUpload(ctx context.Context, session db.UploadSession) (string, error) {
+	chunks, err := uh.db.GetUploadChunks(session.Uuid)
+	if err != nil {
+		return "", err
+	}
+	file := bytes.NewBuffer(make([]byte, 0, session.Size))
+	for _, chunk := range chunks {
+		if chunk.Position != int64(file.Len()) {
+			return "", errUploadChecksum
+		}
+		file.Write(chunk.Data)
+	}
+	sum := sha256.Sum256(file.Bytes())
+	if int64(file.Len()) != session.Size || hex.EncodeToString(sum[:]) != session.Checksum {
+		return "", errUploadChecksum
+	}
+
+	name := unsafeFileChars.ReplaceAllString(path.Base(session.Filename), "_")
+	return uh.store.SaveFile(ctx, session.Uuid+"/"+name, session.ContentType, file.Bytes())
+}
+
+// completeUpload stores an upload whose last chunk was received. A file
+// that doesn't match its checksum fails the upload, the error of a store
+// that can't be reached is returned for a retry
+func (uh *uploadHandler) completeUpload(ctx context.Context, uuid string) error {
+	session, err := uh.db.GetUploadSession(uuid)
+	if err != nil || session.Status != db.UploadAssembling {
+		return nil
+	}
+
+	url, err := uh.assembleUpload(ctx, session)
+	if errors.Is(err, errUploadChecksum) {
+		_, err = uh.db.CompleteUploadSession(session.Uuid, "", err.Error())
+		return err
+	}
+	if err != nil {
+		return err
+	}
+	_, err = uh.db.CompleteUploadSession(session.Uuid, url, "")
+	return err
+}
+
+// RegisterUploadAssembly stores the uploads whose last chunk was received,
+// the queue retries the ones the store failed
+func (uh *uploadHandler) RegisterUploadAssembly(q *jobs.Queue) {
+	q.Register(UploadAssemblyJob, func(ctx context.Context, job db.Job) error {
+		uuid, _ := job.Payload["upload_uuid"].(string)
+		return uh.completeUpload(ctx, uuid)
+	})
+}
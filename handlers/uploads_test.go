@@ -0,0 +1,239 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/jobs"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type fakeFileStore struct {
+	key  string
+	data []byte
+	err  error
+}
+
+func (s *fakeFileStore) SaveFile(ctx context.Context, key string, contentType string, data []byte) (string, error) {
+	s.key, s.data = key, data
+	if s.err != nil {
+		return "", s.err
+	}
+	return "https://files.example.com/" + key, nil
+}
+
+func TestResumableUploads(t *testing.T) {
+	t.Setenv("RELAY_URL", "http://localhost:3001")
+	t.Setenv("RELAY_AUTH_KEY", "RelayAuthKey")
+	t.Setenv("UPLOAD_MAX_MB", "1")
+	config.InitConfig()
+	defer func() {
+		t.Setenv("UPLOAD_MAX_MB", "")
+		config.InitConfig()
+	}()
+
+	file := []byte("a video proof in two chunks")
+	sum := sha256.Sum256(file)
+	expires := time.Now().Add(time.Hour)
+	session := db.UploadSession{Uuid: "upload-uuid", OwnerPubKey: "hunter-pubkey", Filename: "proof video.mp4", ContentType: "video/mp4",
+		Size: int64(len(file)), Checksum: hex.EncodeToString(sum[:]), Status: db.UploadPending, Expires: &expires}
+	newRequest := func(method string, pubkey string, body []byte) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("uuid", session.Uuid)
+		ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+		ctx = context.WithValue(ctx, auth.ContextKey, pubkey)
+		req, _ := http.NewRequestWithContext(ctx, method, "/uploads", bytes.NewReader(body))
+		return req
+	}
+	chunkRequest := func(offset int, chunk []byte) *http.Request {
+		req := newRequest(http.MethodPatch, "hunter-pubkey", chunk)
+		req.Header.Set("Content-Type", uploadChunkType)
+		req.Header.Set("Upload-Offset", strconv.Itoa(offset))
+		return req
+	}
+
+	t.Run("should open an upload at its location", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		uHandler := &uploadHandler{db: mockDb, store: &fakeFileStore{}}
+
+		mockDb.On("CreateUploadSession", mock.MatchedBy(func(s db.UploadSession) bool {
+			return s.OwnerPubKey == "hunter-pubkey" && s.Size == session.Size && s.Status == db.UploadPending && s.Expires.After(time.Now().Add(23*time.Hour))
+		})).Return(func(s db.UploadSession) (db.UploadSession, error) { return s, nil }).Once()
+
+		rr := httptest.NewRecorder()
+		body, _ := json.Marshal(db.UploadSessionRequest{Filename: session.Filename, ContentType: session.ContentType, Size: session.Size, Checksum: session.Checksum})
+		http.HandlerFunc(uHandler.CreateUpload).ServeHTTP(rr, newRequest(http.MethodPost, "hunter-pubkey", body))
+
+		assert.Equal(t, http.StatusCreated, rr.Code)
+		assert.Regexp(t, "^/uploads/.+", rr.Header().Get("Location"))
+		assert.Equal(t, "0", rr.Header().Get("Upload-Offset"))
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should refuse a file over the upload limit", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		uHandler := &uploadHandler{db: mockDb, store: &fakeFileStore{}}
+
+		rr := httptest.NewRecorder()
+		body, _ := json.Marshal(db.UploadSessionRequest{Filename: "big.mov", ContentType: "video/quicktime", Size: 2 << 20, Checksum: session.Checksum})
+		http.HandlerFunc(uHandler.CreateUpload).ServeHTTP(rr, newRequest(http.MethodPost, "hunter-pubkey", body))
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, rr.Code)
+		mockDb.AssertNotCalled(t, "CreateUploadSession", mock.Anything)
+	})
+
+	t.Run("should answer the offset to resume from", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		uHandler := &uploadHandler{db: mockDb, store: &fakeFileStore{}}
+		resumed := session
+		resumed.Received = 10
+
+		mockDb.On("GetUploadSession", session.Uuid).Return(resumed, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(uHandler.HeadUpload).ServeHTTP(rr, newRequest(http.MethodHead, "hunter-pubkey", nil))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "10", rr.Header().Get("Upload-Offset"))
+		assert.Equal(t, strconv.Itoa(len(file)), rr.Header().Get("Upload-Length"))
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should not show an upload to another person", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		uHandler := &uploadHandler{db: mockDb, store: &fakeFileStore{}}
+
+		mockDb.On("GetUploadSession", session.Uuid).Return(session, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(uHandler.GetUpload).ServeHTTP(rr, newRequest(http.MethodGet, "other-pubkey", nil))
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should refuse a chunk at the wrong offset", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		uHandler := &uploadHandler{db: mockDb, store: &fakeFileStore{}}
+		resumed := session
+		resumed.Received = 10
+
+		mockDb.On("GetUploadSession", session.Uuid).Return(resumed, nil).Once()
+		mockDb.On("AppendUploadChunk", session.Uuid, int64(0), file[:10], mock.Anything).Return(resumed, db.ErrUploadOffset).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(uHandler.PatchUpload).ServeHTTP(rr, chunkRequest(0, file[:10]))
+
+		assert.Equal(t, http.StatusConflict, rr.Code)
+		assert.Equal(t, "10", rr.Header().Get("Upload-Offset"))
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should refuse a chunk that doesn't match its checksum", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		uHandler := &uploadHandler{db: mockDb, store: &fakeFileStore{}}
+
+		mockDb.On("GetUploadSession", session.Uuid).Return(session, nil).Once()
+
+		rr := httptest.NewRecorder()
+		req := chunkRequest(0, file[:10])
+		other := sha256.Sum256(file[:9])
+		req.Header.Set("Upload-Checksum", "sha256 "+base64.StdEncoding.EncodeToString(other[:]))
+		http.HandlerFunc(uHandler.PatchUpload).ServeHTTP(rr, req)
+
+		assert.Equal(t, statusChecksumMismatch, rr.Code)
+		mockDb.AssertNotCalled(t, "AppendUploadChunk", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should queue the assembly after the last chunk", func(t *testing.T) {
+		defer func(queue *jobs.Queue) { jobs.Default = queue }(jobs.Default)
+		mockDb := &dbMocks.Database{}
+		jobs.Default = jobs.NewQueue(mockDb)
+		uHandler := &uploadHandler{db: mockDb, store: &fakeFileStore{}}
+		resumed := session
+		resumed.Received = 10
+		assembling := session
+		assembling.Received = session.Size
+		assembling.Status = db.UploadAssembling
+
+		mockDb.On("GetUploadSession", session.Uuid).Return(resumed, nil).Once()
+		mockDb.On("AppendUploadChunk", session.Uuid, int64(10), file[10:], mock.Anything).Return(assembling, nil).Once()
+		mockDb.On("EnqueueJob", mock.MatchedBy(func(j db.Job) bool {
+			return j.Type == UploadAssemblyJob && j.Payload["upload_uuid"] == session.Uuid
+		})).Return(db.Job{Uuid: "job-uuid", Type: UploadAssemblyJob}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		req := chunkRequest(10, file[10:])
+		chunkSum := sha256.Sum256(file[10:])
+		req.Header.Set("Upload-Checksum", "sha256 "+base64.StdEncoding.EncodeToString(chunkSum[:]))
+		http.HandlerFunc(uHandler.PatchUpload).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+		assert.Equal(t, strconv.Itoa(len(file)), rr.Header().Get("Upload-Offset"))
+		mockDb.AssertExpectations(t)
+	})
+
+	assembling := session
+	assembling.Received = session.Size
+	assembling.Status = db.UploadAssembling
+	chunks := []db.UploadChunk{{Position: 0, Data: file[:10]}, {Position: 10, Data: file[10:]}}
+
+	t.Run("should store the assembled file", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		store := &fakeFileStore{}
+		uHandler := &uploadHandler{db: mockDb, store: store}
+
+		mockDb.On("GetUploadSession", session.Uuid).Return(assembling, nil).Once()
+		mockDb.On("GetUploadChunks", session.Uuid).Return(chunks, nil).Once()
+		mockDb.On("CompleteUploadSession", session.Uuid, "https://files.example.com/upload-uuid/proof_video.mp4", "").Return(db.UploadSession{}, nil).Once()
+
+		assert.NoError(t, uHandler.completeUpload(context.Background(), session.Uuid))
+		assert.Equal(t, file, store.data)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should fail an upload that doesn't match its checksum", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		store := &fakeFileStore{}
+		uHandler := &uploadHandler{db: mockDb, store: store}
+		tampered := assembling
+		tampered.Checksum = hex.EncodeToString(make([]byte, 32))
+
+		mockDb.On("GetUploadSession", session.Uuid).Return(tampered, nil).Once()
+		mockDb.On("GetUploadChunks", session.Uuid).Return(chunks, nil).Once()
+		mockDb.On("CompleteUploadSession", session.Uuid, "", errUploadChecksum.Error()).Return(db.UploadSession{}, nil).Once()
+
+		assert.NoError(t, uHandler.completeUpload(context.Background(), session.Uuid))
+		assert.Nil(t, store.data)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should retry when the store can't be reached", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		uHandler := &uploadHandler{db: mockDb, store: &fakeFileStore{err: errors.New("connection refused")}}
+
+		mockDb.On("GetUploadSession", session.Uuid).Return(assembling, nil).Once()
+		mockDb.On("GetUploadChunks", session.Uuid).Return(chunks, nil).Once()
+
+		assert.Error(t, uHandler.completeUpload(context.Background(), session.Uuid))
+		mockDb.AssertNotCalled(t, "CompleteUploadSession", mock.Anything, mock.Anything, mock.Anything)
+		mockDb.AssertExpectations(t)
+	})
+}
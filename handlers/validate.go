@@ -0,0 +1,18 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/httpio"
+)
+
+// validatePayload checks v against its validate struct tags. On failure the
+// field errors are written in the error envelope and false is returned
+func validatePayload(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := db.Validate.Struct(v); err != nil {
+		httpio.WriteErrorCode(w, r, http.StatusBadRequest, httpio.CodeValidation, "Validation failed", db.ValidationErrors(err))
+		return false
+	}
+	return true
+}
@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/httpio"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidatePayload(t *testing.T) {
+	t.Run("should write the field errors in the error envelope", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/gobounties", nil)
+
+		valid := validatePayload(rr, req, db.NewBounty{Title: "bounty"})
+
+		var res struct {
+			Code    string          `json:"code"`
+			Message string          `json:"message"`
+			Details []db.FieldError `json:"details"`
+		}
+		err := json.Unmarshal(rr.Body.Bytes(), &res)
+		assert.NoError(t, err)
+		assert.False(t, valid)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Equal(t, httpio.CodeValidation, res.Code)
+		assert.Equal(t, []db.FieldError{
+			{Field: "type", Rule: "required", Message: "type is a required field"},
+			{Field: "description", Rule: "required", Message: "description is a required field"},
+		}, res.Details)
+	})
+
+	t.Run("should write nothing for a valid payload", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/gobounties", nil)
+
+		valid := validatePayload(rr, req, db.NewBounty{Type: "coding", Title: "bounty", Description: "description"})
+
+		assert.True(t, valid)
+		assert.Equal(t, 0, rr.Body.Len())
+	})
+}
@@ -0,0 +1,268 @@
+package handlers
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/httpio"
+)
+
+// the bounties a widget shows at most, and when its config has no limit
+const (
+	widgetMaxBounties     = 50
+	widgetDefaultBounties = 10
+)
+
+// the fields of a bounty a widget can show, nothing else of it leaves the
+// server. url is the link to the bounty on the site
+var widgetFields = []string{"id", "title", "price", "coding_languages", "estimated_session_length", "estimated_completion_date", "created", "url"}
+
+var widgetDefaultFields = []string{"id", "title", "price", "coding_languages", "url"}
+
+type widgetHandler struct {
+	db db.Database
+}
+
+func NewWidgetHandler(database db.Database) *widgetHandler {
+	return &widgetHandler{db: database}
+}
+
+// widgetSignature signs the config of a workspace widget, so the website
+// embedding it can't show more than the owner picked
+func widgetSignature(workspaceUuid string, encoded string) string {
+	mac := hmac.New(sha256.New, []byte(config.JwtKey))
+	fmt.Fprintf(mac, "widget:%s:%s", workspaceUuid, encoded)
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// widgetConfig reads the signed config of the request, the default config
+// when it has none
+func widgetConfig(r *http.Request, workspaceUuid string) (db.WidgetConfig, bool) {
+	encoded := r.URL.Query().Get("config")
+	if encoded == "" {
+		return db.WidgetConfig{Fields: widgetDefaultFields, Limit: widgetDefaultBounties}, true
+	}
+	sig := r.URL.Query().Get("sig")
+	if !hmac.Equal([]byte(sig), []byte(widgetSignature(workspaceUuid, encoded))) {
+		return db.WidgetConfig{}, false
+	}
+	decoded, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return db.WidgetConfig{}, false
+	}
+	widget := db.WidgetConfig{}
+	if err := json.Unmarshal(decoded, &widget); err != nil {
+		return db.WidgetConfig{}, false
+	}
+	return normalizeWidgetConfig(widget), true
+}
+
+// normalizeWidgetConfig keeps the known fields in their widget order, and
+// bounds the limit
+func normalizeWidgetConfig(widget db.WidgetConfig) db.WidgetConfig {
+	picked := map[string]bool{}
+	for _, field := range widget.Fields {
+		picked[strings.ToLower(strings.TrimSpace(field))] = true
+	}
+	fields := []string{}
+	for _, field := range widgetFields {
+		if picked[field] {
+			fields = append(fields, field)
+		}
+	}
+	if len(fields) == 0 {
+		fields = widgetDefaultFields
+	}
+	limit := widget.Limit
+	if limit <= 0 {
+		limit = widgetDefaultBounties
+	}
+	if limit > widgetMaxBounties {
+		limit = widgetMaxBounties
+	}
+	return db.WidgetConfig{Fields: fields, Limit: limit}
+}
+
+// widgetBounty is the part of a bounty the fields of a widget show
+func widgetBounty(bounty db.NewBounty, fields []string) map[string]interface{} {
+	values := map[string]interface{}{
+		"id":                        bounty.ID,
+		"title":                     bounty.Title,
+		"price":                     bounty.Price,
+		"coding_languages":          bounty.CodingLanguages,
+		"estimated_session_length":  bounty.EstimatedSessionLength,
+		"estimated_completion_date": bounty.EstimatedCompletionDate,
+		"created":                   bounty.Created,
+		"url":                       fmt.Sprintf("https://community.sphinx.chat/bounty/%d", bounty.ID),
+	}
+	shown := map[string]interface{}{}
+	for _, field := range fields {
+		shown[field] = values[field]
+	}
+	return shown
+}
+
+// CreateWidget signs a widget config for the workspace, and returns the
+// urls and the snippet that embed it. Only the workspace admin picks what
+// the widget shows
+func (wh *widgetHandler) CreateWidget(w http.ResponseWriter, r *http.Request) {
+	pubKeyFromAuth, _ := r.Context().Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[widget] no pubkey from auth")
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
+		return
+	}
+	workspace := wh.db.GetWorkspaceByUuid(chi.URLParam(r, "workspace_uuid"))
+	if workspace.Uuid == "" {
+		httpio.WriteError(w, r, http.StatusNotFound, "Workspace not found")
+		return
+	}
+	if workspace.OwnerPubKey != pubKeyFromAuth {
+		httpio.WriteError(w, r, http.StatusUnauthorized, "Only the workspace admin can configure the widget")
+		return
+	}
+
+	widget := db.WidgetConfig{}
+	body, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	if len(body) > 0 {
+		if err := json.Unmarshal(body, &widget); err != nil {
+			fmt.Println("[widget]", err)
+			httpio.WriteError(w, r, http.StatusNotAcceptable, "")
+			return
+		}
+	}
+	if !validatePayload(w, r, widget) {
+		return
+	}
+	known := map[string]bool{}
+	for _, field := range widgetFields {
+		known[field] = true
+	}
+	for _, field := range widget.Fields {
+		if !known[strings.ToLower(strings.TrimSpace(field))] {
+			httpio.WriteErrorDetails(w, r, http.StatusBadRequest, "Unknown widget field", map[string]interface{}{
+				"field":  field,
+				"fields": widgetFields,
+			})
+			return
+		}
+	}
+
+	encoded, _ := json.Marshal(normalizeWidgetConfig(widget))
+	link := db.WidgetLink{Config: base64.RawURLEncoding.EncodeToString(encoded)}
+	link.Signature = widgetSignature(workspace.Uuid, link.Config)
+
+	query := url.Values{}
+	query.Set("config", link.Config)
+	query.Set("sig", link.Signature)
+	base := strings.TrimSuffix(config.Host, "/") + "/widget/workspaces/" + workspace.Uuid + "/bounties?"
+	query.Set("format", "json")
+	link.JsonUrl = base + query.Encode()
+	query.Set("format", "html")
+	link.HtmlUrl = base + query.Encode()
+	link.Embed = `<iframe src="` + template.HTMLEscapeString(link.HtmlUrl) + `" title="` + template.HTMLEscapeString(workspace.Name) + ` bounties" style="border:0;width:100%;height:480px" loading="lazy"></iframe>`
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(link)
+}
+
+var widgetPage = template.Must(template.New("widget").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Name}} bounties</title>
+<style>
+body{font-family:system-ui,sans-serif;margin:0;padding:8px;color:#292c33}
+ul{list-style:none;margin:0;padding:0}
+li{padding:10px 4px;border-bottom:1px solid #e6e7eb}
+a{color:#618aff;text-decoration:none;font-weight:600}
+.meta{color:#8e969c;font-size:13px;margin-top:4px}
+</style>
+</head>
+<body>
+<ul>
+{{- range .Bounties}}
+<li>{{if .url}}<a href="{{.url}}" target="_blank" rel="noopener">{{.title}}</a>{{else}}<strong>{{.title}}</strong>{{end}}
+<div class="meta">{{if .price}}{{.price}} sats{{end}}{{range .coding_languages}} · {{.}}{{end}}{{if .estimated_session_length}} · {{.estimated_session_length}}{{end}}</div></li>
+{{- else}}
+<li>No open bounties</li>
+{{- end}}
+</ul>
+</body>
+</html>
+`))
+
+// GetWidgetBounties serves the open bounties of a workspace for websites
+// that embed them, as JSON or as an html page for an iframe. Only the fields
+// of the signed config, or the default ones, are shown
+func (wh *widgetHandler) GetWidgetBounties(w http.ResponseWriter, r *http.Request) {
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "json"
+	}
+	if format != "json" && format != "html" {
+		httpio.WriteError(w, r, http.StatusBadRequest, "format is json or html")
+		return
+	}
+
+	workspace := wh.db.GetWorkspaceByUuid(chi.URLParam(r, "uuid"))
+	if workspace.Uuid == "" || workspace.Deleted {
+		httpio.WriteError(w, r, http.StatusNotFound, "Workspace not found")
+		return
+	}
+	widget, ok := widgetConfig(r, workspace.Uuid)
+	if !ok {
+		httpio.WriteError(w, r, http.StatusForbidden, "The widget config is not signed for this workspace")
+		return
+	}
+
+	cached, err := db.CachedJSON(db.WidgetBountiesCacheKey+workspace.Uuid, func() interface{} {
+		return wh.db.GetOpenWorkspaceBounties(workspace.Uuid, widgetMaxBounties)
+	})
+	bounties := []db.NewBounty{}
+	if err == nil {
+		err = json.Unmarshal(cached, &bounties)
+	}
+	if err != nil {
+		fmt.Println("[widget]", err)
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to get the bounties")
+		return
+	}
+	if len(bounties) > widget.Limit {
+		bounties = bounties[:widget.Limit]
+	}
+	shown := make([]map[string]interface{}, len(bounties))
+	for i, bounty := range bounties {
+		shown[i] = widgetBounty(bounty, widget.Fields)
+	}
+
+	// any website can embed the widget, it carries nothing private
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Del("Access-Control-Allow-Credentials")
+	if format == "html" {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		widgetPage.Execute(w, map[string]interface{}{"Name": workspace.Name, "Bounties": shown})
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"workspace": map[string]interface{}{"uuid": workspace.Uuid, "name": workspace.Name, "img": workspace.Img},
+		"fields":    widget.Fields,
+		"bounties":  shown,
+	})
+}
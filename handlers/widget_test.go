@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkspaceWidget(t *testing.T) {
+	workspace := db.Workspace{Uuid: "workspace-uuid", Name: "Sphinx", OwnerPubKey: "owner-pubkey"}
+	bounties := []db.NewBounty{
+		{ID: 1, Title: "Fix <login>", Price: 5000, CodingLanguages: []string{"Go"}, OwnerID: "owner-pubkey", Description: "private notes"},
+		{ID: 2, Title: "Add search", Price: 2000},
+	}
+	newRequest := func(method string, target string, param string, pubkey string, body string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add(param, workspace.Uuid)
+		ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+		ctx = context.WithValue(ctx, auth.ContextKey, pubkey)
+		req, _ := http.NewRequestWithContext(ctx, method, target, bytes.NewBufferString(body))
+		return req
+	}
+	signedQuery := func(t *testing.T, body string) url.Values {
+		mockDb := &dbMocks.Database{}
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(workspace).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(NewWidgetHandler(mockDb).CreateWidget).ServeHTTP(rr, newRequest(http.MethodPost, "/workspaces/workspace-uuid/widget", "workspace_uuid", "owner-pubkey", body))
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		link := db.WidgetLink{}
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &link))
+		assert.Contains(t, link.Embed, "<iframe")
+		parsed, _ := url.Parse(link.JsonUrl)
+		return parsed.Query()
+	}
+
+	t.Run("should serve the default fields without a config", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(workspace).Once()
+		mockDb.On("GetOpenWorkspaceBounties", workspace.Uuid, widgetMaxBounties).Return(bounties).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(NewWidgetHandler(mockDb).GetWidgetBounties).ServeHTTP(rr, newRequest(http.MethodGet, "/widget/workspaces/workspace-uuid/bounties", "uuid", "", ""))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "*", rr.Header().Get("Access-Control-Allow-Origin"))
+		assert.Contains(t, rr.Body.String(), `"url":"https://community.sphinx.chat/bounty/1"`)
+		assert.NotContains(t, rr.Body.String(), "owner-pubkey")
+		assert.NotContains(t, rr.Body.String(), "private notes")
+	})
+
+	t.Run("should only show the fields of a signed config", func(t *testing.T) {
+		query := signedQuery(t, `{"fields": ["title", "price"], "limit": 1}`)
+		mockDb := &dbMocks.Database{}
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(workspace).Once()
+		mockDb.On("GetOpenWorkspaceBounties", workspace.Uuid, widgetMaxBounties).Return(bounties).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(NewWidgetHandler(mockDb).GetWidgetBounties).ServeHTTP(rr, newRequest(http.MethodGet, "/widget/workspaces/workspace-uuid/bounties?"+query.Encode(), "uuid", "", ""))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		body := map[string]interface{}{}
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+		assert.Equal(t, []interface{}{map[string]interface{}{"title": "Fix <login>", "price": float64(5000)}}, body["bounties"])
+	})
+
+	t.Run("should refuse a config that was changed", func(t *testing.T) {
+		query := signedQuery(t, `{"fields": ["title"]}`)
+		query.Set("config", "eyJmaWVsZHMiOlsidGl0bGUiLCJpZCJdfQ")
+		mockDb := &dbMocks.Database{}
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(workspace).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(NewWidgetHandler(mockDb).GetWidgetBounties).ServeHTTP(rr, newRequest(http.MethodGet, "/widget/workspaces/workspace-uuid/bounties?"+query.Encode(), "uuid", "", ""))
+
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+		mockDb.AssertNotCalled(t, "GetOpenWorkspaceBounties", workspace.Uuid, widgetMaxBounties)
+	})
+
+	t.Run("should render escaped html", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(workspace).Once()
+		mockDb.On("GetOpenWorkspaceBounties", workspace.Uuid, widgetMaxBounties).Return(bounties).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(NewWidgetHandler(mockDb).GetWidgetBounties).ServeHTTP(rr, newRequest(http.MethodGet, "/widget/workspaces/workspace-uuid/bounties?format=html", "uuid", "", ""))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "text/html; charset=utf-8", rr.Header().Get("Content-Type"))
+		assert.Contains(t, rr.Body.String(), "Fix &lt;login&gt;")
+		assert.Contains(t, rr.Body.String(), "5000 sats")
+	})
+
+	t.Run("should only let the workspace admin sign a config", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(workspace).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(NewWidgetHandler(mockDb).CreateWidget).ServeHTTP(rr, newRequest(http.MethodPost, "/workspaces/workspace-uuid/widget", "workspace_uuid", "member-pubkey", `{"fields": ["title"]}`))
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+}
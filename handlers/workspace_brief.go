@@ -0,0 +1,354 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/rs/xid"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/httpio"
+	"github.com/stakwork/sphinx-tribes/upstream"
+	"github.com/stakwork/sphinx-tribes/websocket"
+	"gorm.io/gorm"
+)
+
+// the websocket message sent on the workspace topic when a brief changes
+const briefMessage = "workspace_brief"
+
+// how many of the last updated bounties are sent as the recent outcomes
+const briefBountyCount = 50
+
+type briefHandler struct {
+	httpClient    HttpClient
+	db            db.Database
+	userHasAccess func(pubKeyFromAuth string, uuid string, role string) bool
+}
+
+func NewBriefHandler(httpClient HttpClient, database db.Database) *briefHandler {
+	dbConf := db.NewDatabaseConfig(&gorm.DB{})
+	return &briefHandler{
+		httpClient:    httpClient,
+		db:            database,
+		userHasAccess: dbConf.UserHasAccess,
+	}
+}
+
+// editorOf writes the error and returns false unless the caller may edit
+// the mission and tactics of the workspace of the route
+func (bh *briefHandler) editorOf(w http.ResponseWriter, r *http.Request) (string, db.Workspace, bool) {
+	pubKeyFromAuth, _ := r.Context().Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[brief] no pubkey from auth")
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
+		return "", db.Workspace{}, false
+	}
+
+	workspace := bh.db.GetWorkspaceByUuid(chi.URLParam(r, "workspace_uuid"))
+	if workspace.Uuid == "" {
+		httpio.WriteError(w, r, http.StatusNotFound, "Workspace not found")
+		return "", workspace, false
+	}
+	if pubKeyFromAuth != workspace.OwnerPubKey && !bh.userHasAccess(pubKeyFromAuth, workspace.Uuid, db.EditOrg) {
+		httpio.WriteError(w, r, http.StatusUnauthorized, "Don't have access to Edit workspace")
+		return "", workspace, false
+	}
+	return pubKeyFromAuth, workspace, true
+}
+
+// pendingBriefOf returns the brief of the route when it belongs to the
+// workspace and still waits for a review
+func (bh *briefHandler) pendingBriefOf(w http.ResponseWriter, r *http.Request, workspace db.Workspace) (db.WorkspaceBrief, bool) {
+	brief, err := bh.db.GetWorkspaceBrief(chi.URLParam(r, "version_uuid"))
+	if err != nil || brief.WorkspaceUuid != workspace.Uuid {
+		httpio.WriteError(w, r, http.StatusNotFound, "Brief not found")
+		return brief, false
+	}
+	if brief.Status != db.BriefPending {
+		httpio.WriteError(w, r, http.StatusConflict, fmt.Sprintf("The brief is %s", brief.Status))
+		return brief, false
+	}
+	return brief, true
+}
+
+// RegenerateBrief asks Stakwork to draft a new mission and tactics from the
+// features, phases and recent bounties of the workspace. The draft arrives
+// on the webhook and waits as a pending version until it is reviewed
+func (bh *briefHandler) RegenerateBrief(w http.ResponseWriter, r *http.Request) {
+	pubKeyFromAuth, workspace, ok := bh.editorOf(w, r)
+	if !ok {
+		return
+	}
+	stakworkKey := stakworkKeyOf(bh.db, workspace.Uuid)
+	if stakworkKey == "" || config.BriefWorkflowId == "" {
+		httpio.WriteError(w, r, http.StatusServiceUnavailable, "Brief generation is not configured")
+		return
+	}
+	if !withinQuota(w, r, bh.db, workspace.Uuid, db.QuotaStakworkSubmissions, 1) || !withinAiCap(w, r, bh.db, workspace.Uuid) {
+		return
+	}
+
+	brief, err := bh.db.CreateOrEditWorkspaceBrief(db.WorkspaceBrief{
+		Uuid:          xid.New().String(),
+		WorkspaceUuid: workspace.Uuid,
+		Status:        db.BriefGenerating,
+		RequestedBy:   pubKeyFromAuth,
+	})
+	if err != nil {
+		fmt.Println("[brief]", err)
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to create the brief")
+		return
+	}
+
+	started := time.Now()
+	err = bh.sendToStakwork(r.Context(), workspace, brief, stakworkKey)
+	recordAiSubmission(bh.db, workspace.Uuid, db.AiWorkspaceBrief, config.BriefWorkflowId, brief.Uuid, started, err)
+	if err != nil {
+		fmt.Println("[brief] failed to send the brief to stakwork", err)
+		brief.Status = db.BriefFailed
+		brief.Error = err.Error()
+		bh.db.CreateOrEditWorkspaceBrief(brief)
+		upstream.WriteError(w, r, err, "Could not request the brief")
+		return
+	}
+	countQuota(bh.db, workspace.Uuid, db.QuotaStakworkSubmissions)
+
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(brief)
+}
+
+type briefFeature struct {
+	Name         string            `json:"name"`
+	Brief        string            `json:"brief"`
+	Requirements string            `json:"requirements"`
+	Architecture string            `json:"architecture"`
+	Priority     int               `json:"priority"`
+	Phases       []db.FeaturePhase `json:"phases"`
+}
+
+type briefBounty struct {
+	ID        uint       `json:"id"`
+	Title     string     `json:"title"`
+	Price     uint       `json:"price"`
+	Status    string     `json:"status"`
+	PhaseUuid string     `json:"phase_uuid,omitempty"`
+	Updated   *time.Time `json:"updated"`
+}
+
+func bountyOutcome(bounty db.NewBounty) string {
+	switch {
+	case bounty.Paid:
+		return "paid"
+	case bounty.Completed:
+		return "completed"
+	case bounty.Assignee != "":
+		return "assigned"
+	}
+	return "open"
+}
+
+func (bh *briefHandler) sendToStakwork(ctx context.Context, workspace db.Workspace, brief db.WorkspaceBrief, stakworkKey string) error {
+	features := []briefFeature{}
+	for _, feature := range bh.db.GetFeaturesByWorkspaceUuid(workspace.Uuid, nil) {
+		features = append(features, briefFeature{
+			Name:         feature.Name,
+			Brief:        feature.Brief,
+			Requirements: feature.Requirements,
+			Architecture: feature.Architecture,
+			Priority:     feature.Priority,
+			Phases:       bh.db.GetPhasesByFeatureUuid(feature.Uuid),
+		})
+	}
+	bounties := []briefBounty{}
+	for _, bounty := range bh.db.GetRecentWorkspaceBounties(workspace.Uuid, briefBountyCount) {
+		bounties = append(bounties, briefBounty{
+			ID:        bounty.ID,
+			Title:     bounty.Title,
+			Price:     bounty.Price,
+			Status:    bountyOutcome(bounty),
+			PhaseUuid: bounty.PhaseUuid,
+			Updated:   bounty.Updated,
+		})
+	}
+
+	body := map[string]interface{}{
+		"name":        "Workspace Brief",
+		"workflow_id": config.BriefWorkflowId,
+		"workflow_params": map[string]interface{}{
+			"set_var": map[string]interface{}{
+				"attributes": map[string]interface{}{
+					"vars": map[string]interface{}{
+						"brief_uuid":     brief.Uuid,
+						"workspace_uuid": workspace.Uuid,
+						"name":           workspace.Name,
+						"mission":        workspace.Mission,
+						"tactics":        workspace.Tactics,
+						"features":       features,
+						"bounties":       bounties,
+						"webhook_url":    fmt.Sprintf("%s/workspaces/brief/response", config.Host),
+					},
+				},
+			},
+		},
+	}
+
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, config.StakworkProjectsUrl, bytes.NewBuffer(buf))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", fmt.Sprintf("Token token=%s", stakworkKey))
+
+	res, err := bh.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK && res.StatusCode != http.StatusCreated {
+		return upstream.StatusError(upstream.Stakwork, res)
+	}
+	return nil
+}
+
+// ProcessBriefResponse handles the Stakwork webhook with the drafted brief
+func (bh *briefHandler) ProcessBriefResponse(w http.ResponseWriter, r *http.Request) {
+	response := db.BriefWebhookResponse{}
+	body, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err := json.Unmarshal(body, &response); err != nil {
+		fmt.Println("[brief]", err)
+		httpio.WriteError(w, r, http.StatusNotAcceptable, "")
+		return
+	}
+
+	brief, err := bh.db.GetWorkspaceBrief(response.BriefUuid)
+	if err != nil {
+		httpio.WriteError(w, r, http.StatusNotFound, "Brief not found")
+		return
+	}
+	if brief.Status != db.BriefGenerating {
+		httpio.WriteError(w, r, http.StatusConflict, fmt.Sprintf("The brief is %s", brief.Status))
+		return
+	}
+
+	switch {
+	case response.Error != "":
+		brief.Status = db.BriefFailed
+		brief.Error = response.Error
+	case response.Mission == "" && response.Tactics == "":
+		brief.Status = db.BriefFailed
+		brief.Error = "empty draft"
+	default:
+		brief.Status = db.BriefPending
+		brief.Mission = response.Mission
+		brief.Tactics = response.Tactics
+	}
+
+	brief, err = bh.db.FinishWorkspaceBrief(brief)
+	if errors.Is(err, db.ErrBriefNotGenerating) {
+		httpio.WriteError(w, r, http.StatusConflict, "The brief is not generating")
+		return
+	}
+	if err != nil {
+		httpio.WriteError(w, r, http.StatusInternalServerError, fmt.Sprintf("Failed to save the brief: %v", err))
+		return
+	}
+	// the cost is added by the one webhook that finished the generation, so
+	// a repeated webhook doesn't count it again
+	addAiCost(bh.db, db.AiWorkspaceBrief, brief.Uuid, response.Cost)
+	websocket.Publish(websocket.Topic(websocket.TopicWorkspace, brief.WorkspaceUuid), briefMessage, brief)
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(brief)
+}
+
+func (bh *briefHandler) GetBriefVersions(w http.ResponseWriter, r *http.Request) {
+	pubKeyFromAuth, _ := r.Context().Value(auth.ContextKey).(string)
+	workspaceUuid := chi.URLParam(r, "workspace_uuid")
+	if pubKeyFromAuth == "" || !isWorkspaceMember(bh.db, pubKeyFromAuth, workspaceUuid) {
+		httpio.WriteError(w, r, http.StatusUnauthorized, "Don't have access to this workspace")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(bh.db.GetWorkspaceBriefs(workspaceUuid))
+}
+
+// ApproveBrief replaces the mission and tactics of the workspace with a
+// pending brief, the body may carry an edited mission or tactics
+func (bh *briefHandler) ApproveBrief(w http.ResponseWriter, r *http.Request) {
+	pubKeyFromAuth, workspace, ok := bh.editorOf(w, r)
+	if !ok {
+		return
+	}
+	brief, ok := bh.pendingBriefOf(w, r, workspace)
+	if !ok {
+		return
+	}
+
+	edits := db.WorkspaceBrief{}
+	body, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	if len(bytes.TrimSpace(body)) > 0 {
+		if err := json.Unmarshal(body, &edits); err != nil {
+			httpio.WriteError(w, r, http.StatusNotAcceptable, "")
+			return
+		}
+	}
+	if edits.Mission != "" {
+		brief.Mission = edits.Mission
+	}
+	if edits.Tactics != "" {
+		brief.Tactics = edits.Tactics
+	}
+
+	updated, err := bh.db.ApproveWorkspaceBrief(brief, pubKeyFromAuth)
+	if err != nil {
+		fmt.Println("[brief]", err)
+		httpio.WriteError(w, r, http.StatusConflict, "Failed to approve the brief")
+		return
+	}
+	brief.Status = db.BriefApproved
+	brief.ReviewedBy = pubKeyFromAuth
+	websocket.Publish(websocket.Topic(websocket.TopicWorkspace, workspace.Uuid), briefMessage, brief)
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(updated)
+}
+
+func (bh *briefHandler) RejectBrief(w http.ResponseWriter, r *http.Request) {
+	pubKeyFromAuth, workspace, ok := bh.editorOf(w, r)
+	if !ok {
+		return
+	}
+	brief, ok := bh.pendingBriefOf(w, r, workspace)
+	if !ok {
+		return
+	}
+
+	brief.Status = db.BriefRejected
+	brief.ReviewedBy = pubKeyFromAuth
+	brief, err := bh.db.CreateOrEditWorkspaceBrief(brief)
+	if err != nil {
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to reject the brief")
+		return
+	}
+	websocket.Publish(websocket.Topic(websocket.TopicWorkspace, workspace.Uuid), briefMessage, brief)
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(brief)
+}
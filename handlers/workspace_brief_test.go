@@ -0,0 +1,182 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/handlers/mocks"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestWorkspaceBrief(t *testing.T) {
+	config.StakworkKey = "stakwork-key"
+	config.BriefWorkflowId = "42"
+	defer func() {
+		config.StakworkKey = ""
+		config.BriefWorkflowId = ""
+	}()
+
+	workspace := db.Workspace{Uuid: "workspace-uuid", Name: "Hive", OwnerPubKey: "owner-pubkey", Mission: "old mission"}
+	newRequest := func(pubkey string, versionUuid string, body string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("workspace_uuid", workspace.Uuid)
+		rctx.URLParams.Add("version_uuid", versionUuid)
+		ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+		ctx = context.WithValue(ctx, auth.ContextKey, pubkey)
+		req, _ := http.NewRequestWithContext(ctx, http.MethodPost, "/workspaces/workspace-uuid/brief", bytes.NewBufferString(body))
+		return req
+	}
+
+	t.Run("should send the features and recent bounties to stakwork", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mockHttpClient := &mocks.HttpClient{}
+		bHandler := NewBriefHandler(mockHttpClient, mockDb)
+
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(workspace).Twice()
+		mockDb.On("CreateOrEditWorkspaceBrief", mock.MatchedBy(func(b db.WorkspaceBrief) bool {
+			return b.Status == db.BriefGenerating && b.RequestedBy == "owner-pubkey"
+		})).Return(func(b db.WorkspaceBrief) (db.WorkspaceBrief, error) { return b, nil }).Once()
+		mockDb.On("GetFeaturesByWorkspaceUuid", workspace.Uuid, (*http.Request)(nil)).Return([]db.WorkspaceFeatures{{Uuid: "feature-uuid", Name: "Payments"}}).Once()
+		mockDb.On("GetPhasesByFeatureUuid", "feature-uuid").Return([]db.FeaturePhase{{Uuid: "phase-uuid", Name: "MVP"}}).Once()
+		mockDb.On("GetRecentWorkspaceBounties", workspace.Uuid, briefBountyCount).Return([]db.NewBounty{{ID: 7, Title: "Add invoices", Paid: true}}).Once()
+		mockHttpClient.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+			body, _ := io.ReadAll(req.Body)
+			return bytes.Contains(body, []byte(`"workflow_id":"42"`)) &&
+				bytes.Contains(body, []byte(`"name":"MVP"`)) &&
+				bytes.Contains(body, []byte(`"status":"paid"`))
+		})).Return(&http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewBufferString(`{"success": true}`))}, nil).Once()
+		mockDb.On("CreateAiSubmission", mock.MatchedBy(func(s db.AiSubmission) bool {
+			return s.Kind == db.AiWorkspaceBrief && s.WorkflowId == "42" && s.Reference != "" && s.Status == db.AiSubmissionSent
+		})).Return(nil).Once()
+		mockDb.On("CountWorkspaceUsage", workspace.Uuid, db.QuotaStakworkSubmissions, mock.Anything).Return(nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(bHandler.RegenerateBrief).ServeHTTP(rr, newRequest("owner-pubkey", "", ""))
+
+		assert.Equal(t, http.StatusAccepted, rr.Code)
+		mockDb.AssertExpectations(t)
+		mockHttpClient.AssertExpectations(t)
+	})
+
+	t.Run("should mark the brief failed when stakwork can't be reached", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mockHttpClient := &mocks.HttpClient{}
+		bHandler := NewBriefHandler(mockHttpClient, mockDb)
+
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(workspace).Twice()
+		mockDb.On("CreateOrEditWorkspaceBrief", mock.Anything).Return(func(b db.WorkspaceBrief) (db.WorkspaceBrief, error) { return b, nil }).Twice()
+		mockDb.On("GetFeaturesByWorkspaceUuid", workspace.Uuid, (*http.Request)(nil)).Return([]db.WorkspaceFeatures{}).Once()
+		mockDb.On("GetRecentWorkspaceBounties", workspace.Uuid, briefBountyCount).Return([]db.NewBounty{}).Once()
+		mockHttpClient.On("Do", mock.Anything).Return(nil, errors.New("connection refused")).Once()
+		mockDb.On("CreateAiSubmission", mock.MatchedBy(func(s db.AiSubmission) bool {
+			return s.Status == db.AiSubmissionFailed && s.Error == "connection refused"
+		})).Return(nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(bHandler.RegenerateBrief).ServeHTTP(rr, newRequest("owner-pubkey", "", ""))
+
+		assert.Equal(t, http.StatusBadGateway, rr.Code)
+		mockDb.AssertCalled(t, "CreateOrEditWorkspaceBrief", mock.MatchedBy(func(b db.WorkspaceBrief) bool {
+			return b.Status == db.BriefFailed && b.Error == "connection refused"
+		}))
+	})
+
+	t.Run("should refuse users who can't edit the workspace", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		bHandler := NewBriefHandler(&mocks.HttpClient{}, mockDb)
+		bHandler.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool { return false }
+
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(workspace).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(bHandler.RegenerateBrief).ServeHTTP(rr, newRequest("member-pubkey", "", ""))
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		mockDb.AssertNotCalled(t, "CreateOrEditWorkspaceBrief", mock.Anything)
+	})
+
+	t.Run("should store the drafted brief as pending", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		bHandler := NewBriefHandler(&mocks.HttpClient{}, mockDb)
+
+		mockDb.On("GetWorkspaceBrief", "brief-uuid").Return(db.WorkspaceBrief{ID: 1, Uuid: "brief-uuid", WorkspaceUuid: workspace.Uuid, Status: db.BriefGenerating}, nil).Once()
+		mockDb.On("FinishWorkspaceBrief", mock.MatchedBy(func(b db.WorkspaceBrief) bool {
+			return b.Status == db.BriefPending && b.Mission == "new mission"
+		})).Return(func(b db.WorkspaceBrief) (db.WorkspaceBrief, error) { return b, nil }).Once()
+		mockDb.On("AddAiSubmissionCost", db.AiWorkspaceBrief, "brief-uuid", 0.25).Return(nil).Once()
+
+		rr := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/workspaces/brief/response", bytes.NewBufferString(`{"brief_uuid": "brief-uuid", "mission": "new mission", "tactics": "ship it", "cost": 0.25}`))
+		http.HandlerFunc(bHandler.ProcessBriefResponse).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should not add the cost of a brief that is not generating", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		bHandler := NewBriefHandler(&mocks.HttpClient{}, mockDb)
+		body := `{"brief_uuid": "brief-uuid", "mission": "new mission", "cost": 0.25}`
+
+		mockDb.On("GetWorkspaceBrief", "brief-uuid").Return(db.WorkspaceBrief{ID: 1, Uuid: "brief-uuid", WorkspaceUuid: workspace.Uuid, Status: db.BriefPending}, nil).Once()
+		rr := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/workspaces/brief/response", bytes.NewBufferString(body))
+		http.HandlerFunc(bHandler.ProcessBriefResponse).ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusConflict, rr.Code)
+
+		// a webhook that finished the brief meanwhile
+		mockDb.On("GetWorkspaceBrief", "brief-uuid").Return(db.WorkspaceBrief{ID: 1, Uuid: "brief-uuid", WorkspaceUuid: workspace.Uuid, Status: db.BriefGenerating}, nil).Once()
+		mockDb.On("FinishWorkspaceBrief", mock.Anything).Return(db.WorkspaceBrief{}, db.ErrBriefNotGenerating).Once()
+		rr = httptest.NewRecorder()
+		req, _ = http.NewRequest(http.MethodPost, "/workspaces/brief/response", bytes.NewBufferString(body))
+		http.HandlerFunc(bHandler.ProcessBriefResponse).ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusConflict, rr.Code)
+
+		mockDb.AssertExpectations(t)
+		mockDb.AssertNotCalled(t, "AddAiSubmissionCost", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should apply the approved brief with the reviewer edits", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		bHandler := NewBriefHandler(&mocks.HttpClient{}, mockDb)
+		pending := db.WorkspaceBrief{Uuid: "brief-uuid", WorkspaceUuid: workspace.Uuid, Status: db.BriefPending, Mission: "new mission", Tactics: "ship it"}
+
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(workspace).Once()
+		mockDb.On("GetWorkspaceBrief", "brief-uuid").Return(pending, nil).Once()
+		approved := pending
+		approved.Tactics = "ship it weekly"
+		mockDb.On("ApproveWorkspaceBrief", approved, "owner-pubkey").Return(db.Workspace{Uuid: workspace.Uuid, Mission: "new mission", Tactics: "ship it weekly"}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(bHandler.ApproveBrief).ServeHTTP(rr, newRequest("owner-pubkey", "brief-uuid", `{"tactics": "ship it weekly"}`))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"tactics":"ship it weekly"`)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should not review a brief twice", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		bHandler := NewBriefHandler(&mocks.HttpClient{}, mockDb)
+
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(workspace).Once()
+		mockDb.On("GetWorkspaceBrief", "brief-uuid").Return(db.WorkspaceBrief{Uuid: "brief-uuid", WorkspaceUuid: workspace.Uuid, Status: db.BriefRejected}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(bHandler.ApproveBrief).ServeHTTP(rr, newRequest("owner-pubkey", "brief-uuid", ""))
+
+		assert.Equal(t, http.StatusConflict, rr.Code)
+		mockDb.AssertNotCalled(t, "ApproveWorkspaceBrief", mock.Anything, mock.Anything)
+	})
+}
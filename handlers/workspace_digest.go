@@ -0,0 +1,30 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/httpio"
+)
+
+// GetWorkspaceDigests returns the weekly digests of a workspace to its
+// members, the latest week first
+func (oh *workspaceHandler) GetWorkspaceDigests(w http.ResponseWriter, r *http.Request) {
+	pubKeyFromAuth, _ := r.Context().Value(auth.ContextKey).(string)
+	workspaceUuid := chi.URLParam(r, "workspace_uuid")
+	if pubKeyFromAuth == "" || !isWorkspaceMember(oh.db, pubKeyFromAuth, workspaceUuid) {
+		httpio.WriteError(w, r, http.StatusUnauthorized, "Don't have access to this workspace")
+		return
+	}
+
+	digests, err := oh.db.GetWorkspaceDigests(workspaceUuid, r)
+	if err != nil {
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to get the digests")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(digests)
+}
@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetWorkspaceDigests(t *testing.T) {
+	request := func(pubkey string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("workspace_uuid", "workspace-a")
+		ctx := context.WithValue(context.WithValue(context.Background(), chi.RouteCtxKey, rctx), auth.ContextKey, pubkey)
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "/workspace-a/digests", nil)
+		return req
+	}
+
+	t.Run("should refuse someone outside the workspace", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		wHandler := NewWorkspaceHandler(mockDb)
+		mockDb.On("GetWorkspaceByUuid", "workspace-a").Return(db.Workspace{Uuid: "workspace-a", OwnerPubKey: "owner"}).Once()
+		mockDb.On("GetWorkspaceUser", "stranger", "workspace-a").Return(db.WorkspaceUsers{}).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(wHandler.GetWorkspaceDigests).ServeHTTP(rr, request("stranger"))
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		mockDb.AssertNotCalled(t, "GetWorkspaceDigests", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should return the digests to a member", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		wHandler := NewWorkspaceHandler(mockDb)
+		mockDb.On("GetWorkspaceByUuid", "workspace-a").Return(db.Workspace{Uuid: "workspace-a", OwnerPubKey: "owner"}).Once()
+		mockDb.On("GetWorkspaceDigests", "workspace-a", mock.AnythingOfType("*http.Request")).Return([]db.WorkspaceDigest{{Uuid: "digest-uuid", NewBounties: 3}}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(wHandler.GetWorkspaceDigests).ServeHTTP(rr, request("owner"))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var digests []db.WorkspaceDigest
+		err := json.Unmarshal(rr.Body.Bytes(), &digests)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), digests[0].NewBounties)
+		mockDb.AssertExpectations(t)
+	})
+}
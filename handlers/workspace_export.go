@@ -0,0 +1,164 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi"
+	"github.com/rs/xid"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/httpio"
+	"github.com/stakwork/sphinx-tribes/jobs"
+)
+
+// the workspace name limit of the Workspace validation
+const maxWorkspaceNameLength = 20
+
+// ExportWorkspace queues the export of the structure of a workspace, the
+// bundle is read from GetWorkspaceExportBundle once the export is ready.
+// Only the workspace admin can export it
+func (oh *workspaceHandler) ExportWorkspace(w http.ResponseWriter, r *http.Request) {
+	pubKeyFromAuth, _ := r.Context().Value(auth.ContextKey).(string)
+	workspace, ok := oh.workspaceAdmin(w, r, pubKeyFromAuth)
+	if !ok {
+		return
+	}
+
+	export, err := oh.db.CreateWorkspaceExport(db.WorkspaceExport{
+		Uuid:          xid.New().String(),
+		WorkspaceUuid: workspace.Uuid,
+		Status:        db.ExportPending,
+		RequestedBy:   pubKeyFromAuth,
+	})
+	if err != nil {
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to create the export")
+		return
+	}
+
+	_, err = jobs.Default.Enqueue(jobs.WorkspaceExportJob, map[string]interface{}{
+		"export_uuid":    export.Uuid,
+		"workspace_uuid": workspace.Uuid,
+	})
+	if err != nil {
+		oh.db.CompleteWorkspaceExport(export.Uuid, "", err.Error())
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to queue the export")
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(export)
+}
+
+// GetWorkspaceExport returns the status of an export
+func (oh *workspaceHandler) GetWorkspaceExport(w http.ResponseWriter, r *http.Request) {
+	pubKeyFromAuth, _ := r.Context().Value(auth.ContextKey).(string)
+	workspace, ok := oh.workspaceAdmin(w, r, pubKeyFromAuth)
+	if !ok {
+		return
+	}
+
+	export, err := oh.db.GetWorkspaceExport(workspace.Uuid, chi.URLParam(r, "export_uuid"))
+	if err != nil {
+		httpio.WriteError(w, r, http.StatusNotFound, "Export not found")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(export)
+}
+
+// GetWorkspaceExportBundle downloads the bundle of a ready export
+func (oh *workspaceHandler) GetWorkspaceExportBundle(w http.ResponseWriter, r *http.Request) {
+	pubKeyFromAuth, _ := r.Context().Value(auth.ContextKey).(string)
+	workspace, ok := oh.workspaceAdmin(w, r, pubKeyFromAuth)
+	if !ok {
+		return
+	}
+
+	export, err := oh.db.GetWorkspaceExport(workspace.Uuid, chi.URLParam(r, "export_uuid"))
+	if err != nil {
+		httpio.WriteError(w, r, http.StatusNotFound, "Export not found")
+		return
+	}
+	if export.Status != db.ExportReady {
+		httpio.WriteError(w, r, http.StatusConflict, fmt.Sprintf("The export is %s", export.Status))
+		return
+	}
+
+	name := strings.Trim(exportFileName.ReplaceAllString(strings.ToLower(workspace.Name), "-"), "-")
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s-%s.json"`, name, export.Uuid))
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(export.Bundle))
+}
+
+// ImportWorkspace recreates the structure of an exported bundle in a new
+// workspace owned by the caller, named ?name= or like the exported one
+func (oh *workspaceHandler) ImportWorkspace(w http.ResponseWriter, r *http.Request) {
+	pubKeyFromAuth, _ := r.Context().Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[workspaces] no pubkey from auth")
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
+		return
+	}
+
+	bundle := db.WorkspaceBundle{}
+	body, err := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err != nil || json.Unmarshal(body, &bundle) != nil {
+		httpio.WriteError(w, r, http.StatusNotAcceptable, "The body is not a workspace bundle")
+		return
+	}
+	if bundle.Format != db.WorkspaceBundleFormat || bundle.Workspace.Uuid == "" {
+		httpio.WriteError(w, r, http.StatusBadRequest, fmt.Sprintf("Only bundles of format %d can be imported", db.WorkspaceBundleFormat))
+		return
+	}
+
+	name := strings.TrimSpace(r.URL.Query().Get("name"))
+	if name == "" {
+		name = bundle.Workspace.Name
+	}
+	if name == "" || len(name) > maxWorkspaceNameLength {
+		httpio.WriteError(w, r, http.StatusBadRequest, fmt.Sprintf("The workspace name is 1 to %d characters", maxWorkspaceNameLength))
+		return
+	}
+	if oh.db.GetWorkspaceByName(name).Name == name {
+		httpio.WriteError(w, r, http.StatusConflict, "Workspace name already exists - "+name)
+		return
+	}
+
+	workspace, err := oh.db.ImportWorkspaceBundle(bundle, name, pubKeyFromAuth)
+	if err != nil {
+		fmt.Println("[workspaces] import", err)
+		httpio.WriteError(w, r, http.StatusBadRequest, "Failed to import the bundle")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(workspace)
+}
+
+// workspaceAdmin writes the error and returns false unless the caller owns
+// the workspace of the {workspace_uuid} param
+func (oh *workspaceHandler) workspaceAdmin(w http.ResponseWriter, r *http.Request, pubkey string) (db.Workspace, bool) {
+	if pubkey == "" {
+		fmt.Println("[workspaces] no pubkey from auth")
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
+		return db.Workspace{}, false
+	}
+
+	workspace := oh.db.GetWorkspaceByUuid(chi.URLParam(r, "workspace_uuid"))
+	if workspace.Uuid == "" {
+		httpio.WriteError(w, r, http.StatusNotFound, "Workspace not found")
+		return db.Workspace{}, false
+	}
+	if workspace.OwnerPubKey != pubkey {
+		httpio.WriteError(w, r, http.StatusUnauthorized, "Only the workspace admin can export it")
+		return db.Workspace{}, false
+	}
+	return workspace, true
+}
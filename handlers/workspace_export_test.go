@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/jobs"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestExportWorkspace(t *testing.T) {
+	defer func(queue *jobs.Queue) { jobs.Default = queue }(jobs.Default)
+	workspace := db.Workspace{Uuid: "workspace-uuid", Name: "Hive", OwnerPubKey: "owner-pubkey"}
+	newRequest := func(pubkey string, exportUuid string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("workspace_uuid", workspace.Uuid)
+		rctx.URLParams.Add("export_uuid", exportUuid)
+		ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+		ctx = context.WithValue(ctx, auth.ContextKey, pubkey)
+		req, _ := http.NewRequestWithContext(ctx, http.MethodPost, "/workspaces/workspace-uuid/export", nil)
+		return req
+	}
+
+	t.Run("should only let the owner export the workspace", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		jobs.Default = jobs.NewQueue(mockDb)
+		oHandler := NewWorkspaceHandler(mockDb)
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(workspace).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(oHandler.ExportWorkspace).ServeHTTP(rr, newRequest("member-pubkey", ""))
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		mockDb.AssertNotCalled(t, "CreateWorkspaceExport", mock.Anything)
+		mockDb.AssertNotCalled(t, "EnqueueJob", mock.Anything)
+	})
+
+	t.Run("should queue the export", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		jobs.Default = jobs.NewQueue(mockDb)
+		oHandler := NewWorkspaceHandler(mockDb)
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(workspace).Once()
+		mockDb.On("CreateWorkspaceExport", mock.MatchedBy(func(e db.WorkspaceExport) bool {
+			return e.WorkspaceUuid == workspace.Uuid && e.Status == db.ExportPending && e.RequestedBy == "owner-pubkey"
+		})).Return(db.WorkspaceExport{Uuid: "export-uuid", WorkspaceUuid: workspace.Uuid, Status: db.ExportPending}, nil).Once()
+		mockDb.On("EnqueueJob", mock.MatchedBy(func(j db.Job) bool {
+			return j.Type == jobs.WorkspaceExportJob && j.Payload["export_uuid"] == "export-uuid" && j.Payload["workspace_uuid"] == workspace.Uuid
+		})).Return(db.Job{Uuid: "job-uuid", Type: jobs.WorkspaceExportJob}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(oHandler.ExportWorkspace).ServeHTTP(rr, newRequest("owner-pubkey", ""))
+
+		assert.Equal(t, http.StatusAccepted, rr.Code)
+		export := db.WorkspaceExport{}
+		json.Unmarshal(rr.Body.Bytes(), &export)
+		assert.Equal(t, "export-uuid", export.Uuid)
+		assert.Equal(t, db.ExportPending, export.Status)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should not download an export that is not ready", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		oHandler := NewWorkspaceHandler(mockDb)
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(workspace).Once()
+		mockDb.On("GetWorkspaceExport", workspace.Uuid, "export-uuid").Return(db.WorkspaceExport{Uuid: "export-uuid", Status: db.ExportPending}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(oHandler.GetWorkspaceExportBundle).ServeHTTP(rr, newRequest("owner-pubkey", "export-uuid"))
+
+		assert.Equal(t, http.StatusConflict, rr.Code)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should download a ready export", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		oHandler := NewWorkspaceHandler(mockDb)
+		bundle := `{"format":1}`
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(workspace).Once()
+		mockDb.On("GetWorkspaceExport", workspace.Uuid, "export-uuid").Return(db.WorkspaceExport{Uuid: "export-uuid", Status: db.ExportReady, Bundle: bundle}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(oHandler.GetWorkspaceExportBundle).ServeHTTP(rr, newRequest("owner-pubkey", "export-uuid"))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, bundle, rr.Body.String())
+		assert.Equal(t, `attachment; filename="hive-export-uuid.json"`, rr.Header().Get("Content-Disposition"))
+	})
+}
+
+func TestImportWorkspace(t *testing.T) {
+	bundle := db.WorkspaceBundle{
+		Format:    db.WorkspaceBundleFormat,
+		Workspace: db.Workspace{Uuid: "source-uuid", Name: "Hive"},
+	}
+	body, _ := json.Marshal(bundle)
+	newRequest := func(query string, body string) *http.Request {
+		ctx := context.WithValue(context.Background(), auth.ContextKey, "importer-pubkey")
+		req, _ := http.NewRequestWithContext(ctx, http.MethodPost, "/workspaces/import"+query, strings.NewReader(body))
+		return req
+	}
+
+	t.Run("should reject a bundle of another format", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		oHandler := NewWorkspaceHandler(mockDb)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(oHandler.ImportWorkspace).ServeHTTP(rr, newRequest("", `{"format": 2, "workspace": {"uuid": "source-uuid", "name": "Hive"}}`))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockDb.AssertNotCalled(t, "ImportWorkspaceBundle", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should not import over an existing workspace name", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		oHandler := NewWorkspaceHandler(mockDb)
+		mockDb.On("GetWorkspaceByName", "Hive").Return(db.Workspace{Uuid: "source-uuid", Name: "Hive"}).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(oHandler.ImportWorkspace).ServeHTTP(rr, newRequest("", string(body)))
+
+		assert.Equal(t, http.StatusConflict, rr.Code)
+		mockDb.AssertNotCalled(t, "ImportWorkspaceBundle", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should import the bundle under the given name", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		oHandler := NewWorkspaceHandler(mockDb)
+		mockDb.On("GetWorkspaceByName", "Hive Copy").Return(db.Workspace{}).Once()
+		mockDb.On("ImportWorkspaceBundle", mock.MatchedBy(func(b db.WorkspaceBundle) bool {
+			return b.Workspace.Uuid == "source-uuid"
+		}), "Hive Copy", "importer-pubkey").Return(db.Workspace{Uuid: "new-uuid", Name: "Hive Copy", OwnerPubKey: "importer-pubkey"}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(oHandler.ImportWorkspace).ServeHTTP(rr, newRequest("?name=Hive+Copy", string(body)))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		workspace := db.Workspace{}
+		json.Unmarshal(rr.Body.Bytes(), &workspace)
+		assert.Equal(t, "new-uuid", workspace.Uuid)
+		assert.Equal(t, "importer-pubkey", workspace.OwnerPubKey)
+		mockDb.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,232 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/rs/xid"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/httpio"
+	"gorm.io/gorm"
+)
+
+type reservePercentRequest struct {
+	Percent uint `json:"percent"`
+}
+
+type reserveHandler struct {
+	db            db.Database
+	userHasAccess func(pubKeyFromAuth string, uuid string, role string) bool
+}
+
+func NewReserveHandler(database db.Database) *reserveHandler {
+	dbConf := db.NewDatabaseConfig(&gorm.DB{})
+	return &reserveHandler{
+		db:            database,
+		userHasAccess: dbConf.UserHasAccess,
+	}
+}
+
+// callerOf writes the error and returns false unless the caller is the
+// owner of the workspace of the route or has the role. The reserve
+// settings and the release reviews are left to the owner, with no role
+func (rh *reserveHandler) callerOf(w http.ResponseWriter, r *http.Request, role string) (string, db.Workspace, bool) {
+	pubKeyFromAuth, _ := r.Context().Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[reserve] no pubkey from auth")
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
+		return "", db.Workspace{}, false
+	}
+
+	workspace := rh.db.GetWorkspaceByUuid(chi.URLParam(r, "workspace_uuid"))
+	if workspace.Uuid == "" {
+		httpio.WriteError(w, r, http.StatusNotFound, "Workspace not found")
+		return "", workspace, false
+	}
+	if pubKeyFromAuth != workspace.OwnerPubKey && (role == "" || !rh.userHasAccess(pubKeyFromAuth, workspace.Uuid, role)) {
+		httpio.WriteError(w, r, http.StatusUnauthorized, "Don't have access to the reserve of this workspace")
+		return "", workspace, false
+	}
+	return pubKeyFromAuth, workspace, true
+}
+
+// pendingReleaseOf returns the release of the route when it belongs to the
+// workspace and still waits for a review
+func (rh *reserveHandler) pendingReleaseOf(w http.ResponseWriter, r *http.Request, workspace db.Workspace) (db.ReserveEntry, bool) {
+	entry, err := rh.db.GetReserveEntry(chi.URLParam(r, "release_uuid"))
+	if err != nil || entry.WorkspaceUuid != workspace.Uuid || entry.Type != db.ReserveEntryRelease {
+		httpio.WriteError(w, r, http.StatusNotFound, "Release not found")
+		return entry, false
+	}
+	if entry.Status != db.ReservePending {
+		httpio.WriteError(w, r, http.StatusConflict, fmt.Sprintf("The release is %s", entry.Status))
+		return entry, false
+	}
+	return entry, true
+}
+
+func (rh *reserveHandler) reserveOf(workspaceUuid string) db.WorkspaceReserve {
+	budget := rh.db.GetWorkspaceBudget(workspaceUuid)
+	return db.WorkspaceReserve{
+		WorkspaceUuid: workspaceUuid,
+		Percent:       budget.ReservePercent,
+		Balance:       budget.ReserveBalance,
+		Pending:       rh.db.GetPendingReserveAmount(workspaceUuid),
+	}
+}
+
+func (rh *reserveHandler) GetReserve(w http.ResponseWriter, r *http.Request) {
+	_, workspace, ok := rh.callerOf(w, r, db.ViewReport)
+	if !ok {
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(rh.reserveOf(workspace.Uuid))
+}
+
+// SetReservePercent sets the percent of the next deposits kept in the
+// reserve, what the reserve already holds stays there
+func (rh *reserveHandler) SetReservePercent(w http.ResponseWriter, r *http.Request) {
+	_, workspace, ok := rh.callerOf(w, r, "")
+	if !ok {
+		return
+	}
+
+	request := reservePercentRequest{}
+	body, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err := json.Unmarshal(body, &request); err != nil {
+		fmt.Println("[reserve]", err)
+		httpio.WriteError(w, r, http.StatusNotAcceptable, "")
+		return
+	}
+	if request.Percent > db.MaxReservePercent {
+		httpio.WriteError(w, r, http.StatusBadRequest, fmt.Sprintf("percent is between 0 and %d", db.MaxReservePercent))
+		return
+	}
+
+	if _, err := rh.db.SetWorkspaceReservePercent(workspace.Uuid, request.Percent); err != nil {
+		fmt.Println("[reserve]", err)
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to set the reserve percent")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(rh.reserveOf(workspace.Uuid))
+}
+
+// GetReserveEntries lists the deposits and releases of the reserve
+func (rh *reserveHandler) GetReserveEntries(w http.ResponseWriter, r *http.Request) {
+	_, workspace, ok := rh.callerOf(w, r, db.ViewReport)
+	if !ok {
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(rh.db.GetReserveEntries(workspace.Uuid))
+}
+
+// RequestRelease asks to release a part of the reserve for the outcome of
+// a dispute or a failed delivery on a bounty of the workspace. The release
+// waits for the owner of the workspace to approve it
+func (rh *reserveHandler) RequestRelease(w http.ResponseWriter, r *http.Request) {
+	pubKeyFromAuth, workspace, ok := rh.callerOf(w, r, db.PayBounty)
+	if !ok {
+		return
+	}
+
+	request := db.ReserveReleaseRequest{}
+	body, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err := json.Unmarshal(body, &request); err != nil {
+		fmt.Println("[reserve]", err)
+		httpio.WriteError(w, r, http.StatusNotAcceptable, "")
+		return
+	}
+	if !validatePayload(w, r, request) {
+		return
+	}
+
+	if bounty := rh.db.GetBounty(request.BountyId); bounty.ID == 0 || bounty.WorkspaceUuid != workspace.Uuid {
+		httpio.WriteError(w, r, http.StatusBadRequest, "Bounty not found in this workspace")
+		return
+	}
+
+	reserve := rh.reserveOf(workspace.Uuid)
+	if reserve.Pending+request.Amount > reserve.Balance {
+		httpio.WriteErrorDetails(w, r, http.StatusBadRequest, "Not enough funds in the reserve", map[string]interface{}{
+			"balance": reserve.Balance,
+			"pending": reserve.Pending,
+		})
+		return
+	}
+
+	entry, err := rh.db.CreateReserveEntry(db.ReserveEntry{
+		Uuid:          xid.New().String(),
+		WorkspaceUuid: workspace.Uuid,
+		Type:          db.ReserveEntryRelease,
+		Amount:        request.Amount,
+		BountyId:      request.BountyId,
+		Reason:        request.Reason,
+		Outcome:       request.Outcome,
+		Status:        db.ReservePending,
+		RequestedBy:   pubKeyFromAuth,
+	})
+	if err != nil {
+		fmt.Println("[reserve]", err)
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to request the release")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(entry)
+}
+
+// ApproveRelease moves a pending release from the reserve to the workspace
+// budget, the compensation is then paid from the budget
+func (rh *reserveHandler) ApproveRelease(w http.ResponseWriter, r *http.Request) {
+	pubKeyFromAuth, workspace, ok := rh.callerOf(w, r, "")
+	if !ok {
+		return
+	}
+	entry, ok := rh.pendingReleaseOf(w, r, workspace)
+	if !ok {
+		return
+	}
+
+	budget, err := rh.db.ApproveReserveRelease(entry, pubKeyFromAuth)
+	if err != nil {
+		fmt.Println("[reserve]", err)
+		httpio.WriteError(w, r, http.StatusConflict, "Failed to approve the release")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(budget)
+}
+
+func (rh *reserveHandler) RejectRelease(w http.ResponseWriter, r *http.Request) {
+	pubKeyFromAuth, workspace, ok := rh.callerOf(w, r, "")
+	if !ok {
+		return
+	}
+	entry, ok := rh.pendingReleaseOf(w, r, workspace)
+	if !ok {
+		return
+	}
+
+	entry, err := rh.db.RejectReserveRelease(entry, pubKeyFromAuth)
+	if err != nil {
+		fmt.Println("[reserve]", err)
+		httpio.WriteError(w, r, http.StatusConflict, "Failed to reject the release")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(entry)
+}
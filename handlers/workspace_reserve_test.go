@@ -0,0 +1,225 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestWorkspaceReserve(t *testing.T) {
+	workspace := db.Workspace{Uuid: "workspace-uuid", Name: "Hive", OwnerPubKey: "owner-pubkey"}
+	newRequest := func(pubkey string, releaseUuid string, body string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("workspace_uuid", workspace.Uuid)
+		rctx.URLParams.Add("release_uuid", releaseUuid)
+		ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+		ctx = context.WithValue(ctx, auth.ContextKey, pubkey)
+		req, _ := http.NewRequestWithContext(ctx, http.MethodPost, "/workspaces/workspace-uuid/reserve", bytes.NewBufferString(body))
+		return req
+	}
+	newHandler := func(mockDb *dbMocks.Database, roles ...string) *reserveHandler {
+		rHandler := NewReserveHandler(mockDb)
+		rHandler.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool {
+			for _, r := range roles {
+				if r == role {
+					return true
+				}
+			}
+			return false
+		}
+		return rHandler
+	}
+
+	t.Run("should return the reserve to users with the view report role", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		rHandler := newHandler(mockDb, db.ViewReport)
+
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(workspace).Once()
+		mockDb.On("GetWorkspaceBudget", workspace.Uuid).Return(db.NewBountyBudget{WorkspaceUuid: workspace.Uuid, ReservePercent: 10, ReserveBalance: 500}).Once()
+		mockDb.On("GetPendingReserveAmount", workspace.Uuid).Return(uint(200)).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(rHandler.GetReserve).ServeHTTP(rr, newRequest("member-pubkey", "", ""))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		reserve := db.WorkspaceReserve{}
+		json.Unmarshal(rr.Body.Bytes(), &reserve)
+		assert.Equal(t, db.WorkspaceReserve{WorkspaceUuid: workspace.Uuid, Percent: 10, Balance: 500, Pending: 200}, reserve)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should only let the owner set the percent", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		rHandler := newHandler(mockDb, db.ViewReport, db.AddBudget, db.PayBounty)
+
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(workspace).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(rHandler.SetReservePercent).ServeHTTP(rr, newRequest("member-pubkey", "", `{"percent": 10}`))
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should cap the percent", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		rHandler := newHandler(mockDb)
+
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(workspace).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(rHandler.SetReservePercent).ServeHTTP(rr, newRequest("owner-pubkey", "", `{"percent": 60}`))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockDb.AssertNotCalled(t, "SetWorkspaceReservePercent", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should set the percent", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		rHandler := newHandler(mockDb)
+
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(workspace).Once()
+		mockDb.On("SetWorkspaceReservePercent", workspace.Uuid, uint(15)).Return(db.NewBountyBudget{WorkspaceUuid: workspace.Uuid, ReservePercent: 15}, nil).Once()
+		mockDb.On("GetWorkspaceBudget", workspace.Uuid).Return(db.NewBountyBudget{WorkspaceUuid: workspace.Uuid, ReservePercent: 15}).Once()
+		mockDb.On("GetPendingReserveAmount", workspace.Uuid).Return(uint(0)).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(rHandler.SetReservePercent).ServeHTTP(rr, newRequest("owner-pubkey", "", `{"percent": 15}`))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should request a release tied to a dispute outcome", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		rHandler := newHandler(mockDb, db.PayBounty)
+
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(workspace).Once()
+		mockDb.On("GetBounty", uint(7)).Return(db.NewBounty{ID: 7, WorkspaceUuid: workspace.Uuid}).Once()
+		mockDb.On("GetWorkspaceBudget", workspace.Uuid).Return(db.NewBountyBudget{WorkspaceUuid: workspace.Uuid, ReserveBalance: 500}).Once()
+		mockDb.On("GetPendingReserveAmount", workspace.Uuid).Return(uint(100)).Once()
+		mockDb.On("CreateReserveEntry", mock.MatchedBy(func(e db.ReserveEntry) bool {
+			return e.Type == db.ReserveEntryRelease && e.Status == db.ReservePending && e.Amount == 300 &&
+				e.BountyId == 7 && e.Reason == db.ReserveDispute && e.RequestedBy == "member-pubkey"
+		})).Return(func(e db.ReserveEntry) (db.ReserveEntry, error) { return e, nil }).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(rHandler.RequestRelease).ServeHTTP(rr, newRequest("member-pubkey", "", `{"bounty_id": 7, "amount": 300, "reason": "dispute", "outcome": "settled for the hunter"}`))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should refuse a release over what the reserve has left", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		rHandler := newHandler(mockDb, db.PayBounty)
+
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(workspace).Once()
+		mockDb.On("GetBounty", uint(7)).Return(db.NewBounty{ID: 7, WorkspaceUuid: workspace.Uuid}).Once()
+		mockDb.On("GetWorkspaceBudget", workspace.Uuid).Return(db.NewBountyBudget{WorkspaceUuid: workspace.Uuid, ReserveBalance: 500}).Once()
+		mockDb.On("GetPendingReserveAmount", workspace.Uuid).Return(uint(300)).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(rHandler.RequestRelease).ServeHTTP(rr, newRequest("member-pubkey", "", `{"bounty_id": 7, "amount": 300, "reason": "failed_delivery", "outcome": "never delivered"}`))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockDb.AssertNotCalled(t, "CreateReserveEntry", mock.Anything)
+	})
+
+	t.Run("should refuse a release for a bounty of another workspace", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		rHandler := newHandler(mockDb, db.PayBounty)
+
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(workspace).Once()
+		mockDb.On("GetBounty", uint(7)).Return(db.NewBounty{ID: 7, WorkspaceUuid: "other-uuid"}).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(rHandler.RequestRelease).ServeHTTP(rr, newRequest("member-pubkey", "", `{"bounty_id": 7, "amount": 300, "reason": "dispute", "outcome": "settled"}`))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should validate the reason", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		rHandler := newHandler(mockDb, db.PayBounty)
+
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(workspace).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(rHandler.RequestRelease).ServeHTTP(rr, newRequest("member-pubkey", "", `{"bounty_id": 7, "amount": 300, "reason": "bonus", "outcome": "settled"}`))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockDb.AssertNotCalled(t, "GetBounty", mock.Anything)
+	})
+
+	t.Run("should only let the owner approve a release", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		rHandler := newHandler(mockDb, db.PayBounty)
+
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(workspace).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(rHandler.ApproveRelease).ServeHTTP(rr, newRequest("member-pubkey", "release-uuid", ""))
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		mockDb.AssertNotCalled(t, "ApproveReserveRelease", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should approve a pending release", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		rHandler := newHandler(mockDb)
+
+		release := db.ReserveEntry{Uuid: "release-uuid", WorkspaceUuid: workspace.Uuid, Type: db.ReserveEntryRelease, Status: db.ReservePending, Amount: 300}
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(workspace).Once()
+		mockDb.On("GetReserveEntry", "release-uuid").Return(release, nil).Once()
+		mockDb.On("ApproveReserveRelease", release, "owner-pubkey").Return(db.NewBountyBudget{WorkspaceUuid: workspace.Uuid, TotalBudget: 1300, ReserveBalance: 200}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(rHandler.ApproveRelease).ServeHTTP(rr, newRequest("owner-pubkey", "release-uuid", ""))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should not review a release twice", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		rHandler := newHandler(mockDb)
+
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(workspace).Once()
+		mockDb.On("GetReserveEntry", "release-uuid").Return(db.ReserveEntry{Uuid: "release-uuid", WorkspaceUuid: workspace.Uuid, Type: db.ReserveEntryRelease, Status: db.ReserveApproved}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(rHandler.RejectRelease).ServeHTTP(rr, newRequest("owner-pubkey", "release-uuid", ""))
+
+		assert.Equal(t, http.StatusConflict, rr.Code)
+		mockDb.AssertNotCalled(t, "RejectReserveRelease", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should report a release the reserve can't cover anymore", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		rHandler := newHandler(mockDb)
+
+		release := db.ReserveEntry{Uuid: "release-uuid", WorkspaceUuid: workspace.Uuid, Type: db.ReserveEntryRelease, Status: db.ReservePending, Amount: 300}
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(workspace).Once()
+		mockDb.On("GetReserveEntry", "release-uuid").Return(release, nil).Once()
+		mockDb.On("ApproveReserveRelease", release, "owner-pubkey").Return(db.NewBountyBudget{}, errors.New("not enough funds in the reserve")).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(rHandler.ApproveRelease).ServeHTTP(rr, newRequest("owner-pubkey", "release-uuid", ""))
+
+		assert.Equal(t, http.StatusConflict, rr.Code)
+		mockDb.AssertExpectations(t)
+	})
+}
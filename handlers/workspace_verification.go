@@ -0,0 +1,345 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/httpio"
+	"github.com/stakwork/sphinx-tribes/upstream"
+)
+
+const (
+	// a pubkey whose profile is younger than this has its new workspaces
+	// reviewed
+	newOwnerAge = 7 * 24 * time.Hour
+	// how long a verification code is good for, and how many wrong codes
+	// end it
+	verificationCodeTTL      = 15 * time.Minute
+	verificationCodeAttempts = 5
+)
+
+// workspaceVerificationMode is the WORKSPACE_VERIFICATION of the config,
+// off when it is not set
+func workspaceVerificationMode() string {
+	if mode := config.Get().WorkspaceVerification; mode != "" {
+		return mode
+	}
+	return config.VerificationOff
+}
+
+// workspaceCreationFlags checks the daily quota of a pubkey creating a
+// workspace and returns why the new workspace goes to the review queue, if
+// it does. It writes the error when the quota is used up. Admins are not
+// limited
+func (oh *workspaceHandler) workspaceCreationFlags(w http.ResponseWriter, r *http.Request, pubkey string, now time.Time) ([]string, bool) {
+	if auth.AdminCheck(pubkey) {
+		return nil, true
+	}
+
+	created := oh.db.CountWorkspacesCreatedSince(pubkey, now.Add(-24*time.Hour))
+	if perDay := config.Get().WorkspaceCreationsPerDay; perDay > 0 && created >= int64(perDay) {
+		httpio.WriteError(w, r, http.StatusTooManyRequests, fmt.Sprintf("A pubkey creates at most %d workspaces a day", perDay))
+		return nil, false
+	}
+
+	reasons := []string{}
+	person := oh.db.GetPersonByPubkey(pubkey)
+	if person.ID == 0 || person.Created == nil || now.Sub(*person.Created) < newOwnerAge {
+		reasons = append(reasons, db.FlagNewOwner)
+	}
+	if created > 0 {
+		reasons = append(reasons, db.FlagRepeatCreation)
+	}
+	return reasons, true
+}
+
+// postVerificationCode posts a verification code to
+// WORKSPACE_VERIFICATION_WEBHOOK, signed like the connection code webhook
+func postVerificationCode(ctx context.Context, code db.WorkspaceVerificationCode) error {
+	cfg := config.Get()
+	body, err := json.Marshal(code)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, cfg.WorkspaceVerificationWebhook, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if cfg.WorkspaceVerificationWebhookSecret != "" {
+		mac := hmac.New(sha256.New, []byte(cfg.WorkspaceVerificationWebhookSecret))
+		mac.Write(body)
+		req.Header.Set("x-hub-signature-256", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	res, err := upstream.Default.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("verification webhook answered %d", res.StatusCode)
+	}
+	return nil
+}
+
+// verificationCodeHash is the hash of a code stored in place of the code
+func verificationCodeHash(code string) string {
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
+
+// newVerificationCode is a random code of 6 digits
+func newVerificationCode() (string, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(1000000))
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%06d", n.Int64()), nil
+}
+
+// verificationWorkspaceOf is the workspace of the route for its owner, when
+// WORKSPACE_VERIFICATION is on. It writes the error otherwise
+func (oh *workspaceHandler) verificationWorkspaceOf(w http.ResponseWriter, r *http.Request) (db.Workspace, bool) {
+	pubKeyFromAuth, _ := r.Context().Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[workspaces] no pubkey from auth")
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
+		return db.Workspace{}, false
+	}
+	if workspaceVerificationMode() == config.VerificationOff {
+		httpio.WriteError(w, r, http.StatusNotFound, "Workspace verification is off")
+		return db.Workspace{}, false
+	}
+
+	workspace := oh.db.GetWorkspaceByUuid(chi.URLParam(r, "workspace_uuid"))
+	if workspace.Uuid == "" {
+		httpio.WriteError(w, r, http.StatusNotFound, "Workspace not found")
+		return workspace, false
+	}
+	if pubKeyFromAuth != workspace.OwnerPubKey {
+		httpio.WriteError(w, r, http.StatusUnauthorized, "only workspace admin can verify the workspace")
+		return workspace, false
+	}
+	if workspace.Verified != nil {
+		httpio.WriteError(w, r, http.StatusConflict, "The workspace is already verified")
+		return workspace, false
+	}
+	return workspace, true
+}
+
+// StartWorkspaceVerification sends a code to the email or phone of the
+// owner, or creates the invoice to pay, depending on WORKSPACE_VERIFICATION.
+// Starting again replaces the pending verification
+func (oh *workspaceHandler) StartWorkspaceVerification(w http.ResponseWriter, r *http.Request) {
+	workspace, ok := oh.verificationWorkspaceOf(w, r)
+	if !ok {
+		return
+	}
+
+	request := db.WorkspaceVerificationRequest{}
+	body, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err := json.Unmarshal(body, &request); err != nil {
+		fmt.Println("[workspaces]", err)
+		httpio.WriteError(w, r, http.StatusNotAcceptable, "")
+		return
+	}
+	request.To = strings.TrimSpace(request.To)
+	if !validatePayload(w, r, request) {
+		return
+	}
+
+	verification := db.WorkspaceVerification{WorkspaceUuid: workspace.Uuid, Method: request.Method}
+	if workspaceVerificationMode() == config.VerificationPayment {
+		if request.Method != db.VerifyByPayment {
+			httpio.WriteError(w, r, http.StatusBadRequest, "Workspaces are verified by payment")
+			return
+		}
+		invoice, err := oh.createInvoice(r.Context(), uint(config.Get().WorkspaceVerificationSats), "Workspace verification")
+		if err != nil {
+			upstream.WriteError(w, r, err, "Could not create the invoice")
+			return
+		}
+		verification.PaymentRequest = invoice.Response.Invoice
+	} else {
+		rule := "email"
+		if request.Method == db.VerifyByPhone {
+			rule = "e164"
+		} else if request.Method != db.VerifyByEmail {
+			httpio.WriteError(w, r, http.StatusBadRequest, "Workspaces are verified by email or phone")
+			return
+		}
+		if err := db.Validate.Var(request.To, "required,"+rule); err != nil {
+			httpio.WriteError(w, r, http.StatusBadRequest, "Error: to is not a valid "+request.Method)
+			return
+		}
+
+		code, err := newVerificationCode()
+		if err != nil {
+			fmt.Println("[workspaces]", err)
+			httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to create the code")
+			return
+		}
+		expires := time.Now().Add(verificationCodeTTL)
+		verification.Destination = request.To
+		verification.CodeHash = verificationCodeHash(code)
+		verification.Expires = &expires
+
+		err = oh.sendVerificationCode(r.Context(), db.WorkspaceVerificationCode{
+			WorkspaceUuid: workspace.Uuid,
+			WorkspaceName: workspace.Name,
+			Channel:       request.Method,
+			To:            request.To,
+			Code:          code,
+		})
+		if err != nil {
+			fmt.Println("[workspaces] could not send the verification code", err)
+			httpio.WriteError(w, r, http.StatusBadGateway, "Could not send the code")
+			return
+		}
+	}
+
+	saved, err := oh.db.SaveWorkspaceVerification(verification)
+	if err != nil {
+		fmt.Println("[workspaces]", err)
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to start the verification")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(saved)
+}
+
+// ConfirmWorkspaceVerification verifies the workspace with the code sent to
+// its owner, or once the verification invoice is paid
+func (oh *workspaceHandler) ConfirmWorkspaceVerification(w http.ResponseWriter, r *http.Request) {
+	workspace, ok := oh.verificationWorkspaceOf(w, r)
+	if !ok {
+		return
+	}
+
+	pending := oh.db.GetWorkspaceVerification(workspace.Uuid)
+	if pending.ID == 0 {
+		httpio.WriteError(w, r, http.StatusNotFound, "No verification is pending")
+		return
+	}
+
+	if pending.Method == db.VerifyByPayment {
+		invoiceRes, invoiceErr := oh.getLightningInvoice(pending.PaymentRequest)
+		if invoiceErr.Error != "" {
+			fmt.Println("[workspaces]", invoiceErr.Error)
+			httpio.WriteError(w, r, http.StatusBadGateway, "Could not check the invoice")
+			return
+		}
+		if !invoiceRes.Response.Settled {
+			httpio.WriteError(w, r, http.StatusPaymentRequired, "The invoice is not paid yet")
+			return
+		}
+	} else {
+		request := db.WorkspaceVerificationConfirm{}
+		body, _ := io.ReadAll(r.Body)
+		r.Body.Close()
+		if err := json.Unmarshal(body, &request); err != nil {
+			fmt.Println("[workspaces]", err)
+			httpio.WriteError(w, r, http.StatusNotAcceptable, "")
+			return
+		}
+
+		if pending.Expires != nil && time.Now().After(*pending.Expires) {
+			httpio.WriteError(w, r, http.StatusGone, "The code expired, start a new verification")
+			return
+		}
+		if pending.Attempts >= verificationCodeAttempts {
+			httpio.WriteError(w, r, http.StatusTooManyRequests, "Too many wrong codes, start a new verification")
+			return
+		}
+		hash := verificationCodeHash(strings.TrimSpace(request.Code))
+		if !hmac.Equal([]byte(hash), []byte(pending.CodeHash)) {
+			if err := oh.db.AddWorkspaceVerificationAttempt(pending.ID); err != nil {
+				fmt.Println("[workspaces]", err)
+			}
+			httpio.WriteError(w, r, http.StatusBadRequest, "Wrong code")
+			return
+		}
+	}
+
+	verified, err := oh.db.VerifyWorkspace(workspace.Uuid, pending.Method)
+	if err != nil {
+		fmt.Println("[workspaces]", err)
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to verify the workspace")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(verified)
+}
+
+// GetFlaggedWorkspaces lists the workspaces waiting for a review, the
+// oldest first
+func (mh *moderationHandler) GetFlaggedWorkspaces(w http.ResponseWriter, r *http.Request) {
+	workspaces, err := mh.db.GetFlaggedWorkspaces(r)
+	if err != nil {
+		fmt.Println("[moderation]", err)
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to get flagged workspaces")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(workspaces)
+}
+
+// ReviewFlaggedWorkspace lists a flagged workspace in the directory or
+// deletes it
+func (mh *moderationHandler) ReviewFlaggedWorkspace(w http.ResponseWriter, r *http.Request) {
+	workspace := mh.db.GetWorkspaceByUuid(chi.URLParam(r, "uuid"))
+	if workspace.Uuid == "" {
+		httpio.WriteError(w, r, http.StatusNotFound, "Workspace not found")
+		return
+	}
+	if !workspace.Flagged {
+		httpio.WriteError(w, r, http.StatusConflict, "Workspace is not flagged")
+		return
+	}
+
+	review := db.WorkspaceReview{}
+	body, err := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err == nil {
+		err = json.Unmarshal(body, &review)
+	}
+	if err != nil {
+		httpio.WriteError(w, r, http.StatusNotAcceptable, "")
+		return
+	}
+
+	if !validatePayload(w, r, review) {
+		return
+	}
+
+	reviewed, err := mh.db.ReviewFlaggedWorkspace(workspace.Uuid, review)
+	if err != nil {
+		fmt.Println("[moderation]", err)
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to review workspace")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(reviewed)
+}
@@ -0,0 +1,228 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/db"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestWorkspaceCreationQuota(t *testing.T) {
+	t.Setenv("RELAY_URL", "http://localhost:3001")
+	t.Setenv("RELAY_AUTH_KEY", "RelayAuthKey")
+	t.Setenv("WORKSPACE_CREATIONS_PER_DAY", "2")
+	config.InitConfig()
+	defer func() {
+		t.Setenv("WORKSPACE_CREATIONS_PER_DAY", "")
+		config.InitConfig()
+	}()
+
+	newRequest := func() *http.Request {
+		ctx := context.WithValue(context.Background(), auth.ContextKey, "owner-pubkey")
+		req, _ := http.NewRequestWithContext(ctx, http.MethodPost, "/workspaces", bytes.NewBufferString(`{"name": "workspace", "owner_pubkey": "owner-pubkey"}`))
+		return req
+	}
+
+	t.Run("should refuse a workspace over the daily quota", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mockDb.On("GetWorkspaceByUuid", "").Return(db.Workspace{}).Once()
+		mockDb.On("GetWorkspaceByName", "workspace").Return(db.Workspace{}).Once()
+		mockDb.On("CountWorkspacesCreatedSince", "owner-pubkey", mock.AnythingOfType("time.Time")).Return(int64(2)).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(NewWorkspaceHandler(mockDb).CreateOrEditWorkspace).ServeHTTP(rr, newRequest())
+
+		assert.Equal(t, http.StatusTooManyRequests, rr.Code)
+		mockDb.AssertNotCalled(t, "CreateOrEditWorkspace", mock.Anything)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should flag a second workspace of a new owner", func(t *testing.T) {
+		yesterday := time.Now().Add(-24 * time.Hour)
+		mockDb := &dbMocks.Database{}
+		mockDb.On("GetWorkspaceByUuid", "").Return(db.Workspace{}).Once()
+		mockDb.On("GetWorkspaceByName", "workspace").Return(db.Workspace{}).Once()
+		mockDb.On("CountWorkspacesCreatedSince", "owner-pubkey", mock.AnythingOfType("time.Time")).Return(int64(1)).Once()
+		mockDb.On("GetPersonByPubkey", "owner-pubkey").Return(db.Person{ID: 1, OwnerPubKey: "owner-pubkey", Created: &yesterday}).Once()
+		mockDb.On("CreateOrEditWorkspace", mock.AnythingOfType("db.Workspace")).Return(func(w db.Workspace) (db.Workspace, error) {
+			return w, nil
+		}).Once()
+		mockDb.On("FlagWorkspace", mock.AnythingOfType("string"), []string{db.FlagNewOwner, db.FlagRepeatCreation}).Return(nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(NewWorkspaceHandler(mockDb).CreateOrEditWorkspace).ServeHTTP(rr, newRequest())
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		res := db.Workspace{}
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &res))
+		assert.True(t, res.Flagged)
+		assert.Equal(t, []string{db.FlagNewOwner, db.FlagRepeatCreation}, []string(res.FlagReasons))
+		mockDb.AssertExpectations(t)
+	})
+}
+
+func TestWorkspaceVerification(t *testing.T) {
+	t.Setenv("RELAY_URL", "http://localhost:3001")
+	t.Setenv("RELAY_AUTH_KEY", "RelayAuthKey")
+	t.Setenv("WORKSPACE_VERIFICATION", "contact")
+	t.Setenv("WORKSPACE_VERIFICATION_WEBHOOK", "http://localhost:3002/verify")
+	config.InitConfig()
+	defer func() {
+		t.Setenv("WORKSPACE_VERIFICATION", "")
+		t.Setenv("WORKSPACE_VERIFICATION_WEBHOOK", "")
+		config.InitConfig()
+	}()
+
+	workspace := db.Workspace{ID: 1, Uuid: "workspace-uuid", Name: "workspace", OwnerPubKey: "owner-pubkey"}
+	newRequest := func(path string, pubkey string, body string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("workspace_uuid", "workspace-uuid")
+		ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+		ctx = context.WithValue(ctx, auth.ContextKey, pubkey)
+		req, _ := http.NewRequestWithContext(ctx, http.MethodPost, "/workspaces/workspace-uuid/"+path, bytes.NewBufferString(body))
+		return req
+	}
+
+	t.Run("should verify a workspace with the code sent to the owner", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		handler := NewWorkspaceHandler(mockDb)
+		sent := db.WorkspaceVerificationCode{}
+		handler.sendVerificationCode = func(ctx context.Context, code db.WorkspaceVerificationCode) error {
+			sent = code
+			return nil
+		}
+		mockDb.On("GetWorkspaceByUuid", "workspace-uuid").Return(workspace).Twice()
+		mockDb.On("SaveWorkspaceVerification", mock.MatchedBy(func(v db.WorkspaceVerification) bool {
+			return v.Method == db.VerifyByEmail && v.Destination == "owner@example.com" && v.CodeHash != "" && v.Expires != nil
+		})).Return(db.WorkspaceVerification{ID: 2, WorkspaceUuid: "workspace-uuid", Method: db.VerifyByEmail}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(handler.StartWorkspaceVerification).ServeHTTP(rr, newRequest("verification", "owner-pubkey", `{"method": "email", "to": "owner@example.com"}`))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "owner@example.com", sent.To)
+		assert.Len(t, sent.Code, 6)
+		assert.NotContains(t, rr.Body.String(), sent.Code)
+
+		expires := time.Now().Add(time.Minute)
+		mockDb.On("GetWorkspaceVerification", "workspace-uuid").Return(db.WorkspaceVerification{ID: 2, WorkspaceUuid: "workspace-uuid", Method: db.VerifyByEmail, CodeHash: verificationCodeHash(sent.Code), Expires: &expires}).Once()
+		mockDb.On("VerifyWorkspace", "workspace-uuid", db.VerifyByEmail).Return(db.Workspace{Uuid: "workspace-uuid", VerificationMethod: db.VerifyByEmail}, nil).Once()
+
+		rr = httptest.NewRecorder()
+		http.HandlerFunc(handler.ConfirmWorkspaceVerification).ServeHTTP(rr, newRequest("verification/confirm", "owner-pubkey", `{"code": "`+sent.Code+`"}`))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should count a wrong code", func(t *testing.T) {
+		expires := time.Now().Add(time.Minute)
+		mockDb := &dbMocks.Database{}
+		mockDb.On("GetWorkspaceByUuid", "workspace-uuid").Return(workspace).Once()
+		mockDb.On("GetWorkspaceVerification", "workspace-uuid").Return(db.WorkspaceVerification{ID: 2, Method: db.VerifyByPhone, CodeHash: verificationCodeHash("123456"), Expires: &expires}).Once()
+		mockDb.On("AddWorkspaceVerificationAttempt", uint(2)).Return(nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(NewWorkspaceHandler(mockDb).ConfirmWorkspaceVerification).ServeHTTP(rr, newRequest("verification/confirm", "owner-pubkey", `{"code": "654321"}`))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockDb.AssertNotCalled(t, "VerifyWorkspace", mock.Anything, mock.Anything)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should refuse a code after too many wrong ones", func(t *testing.T) {
+		expires := time.Now().Add(time.Minute)
+		mockDb := &dbMocks.Database{}
+		mockDb.On("GetWorkspaceByUuid", "workspace-uuid").Return(workspace).Once()
+		mockDb.On("GetWorkspaceVerification", "workspace-uuid").Return(db.WorkspaceVerification{ID: 2, Method: db.VerifyByPhone, CodeHash: verificationCodeHash("123456"), Attempts: verificationCodeAttempts, Expires: &expires}).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(NewWorkspaceHandler(mockDb).ConfirmWorkspaceVerification).ServeHTTP(rr, newRequest("verification/confirm", "owner-pubkey", `{"code": "123456"}`))
+
+		assert.Equal(t, http.StatusTooManyRequests, rr.Code)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should only let the owner verify the workspace", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mockDb.On("GetWorkspaceByUuid", "workspace-uuid").Return(workspace).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(NewWorkspaceHandler(mockDb).StartWorkspaceVerification).ServeHTTP(rr, newRequest("verification", "other-pubkey", `{"method": "email", "to": "owner@example.com"}`))
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should not take deposits before the workspace is verified", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mockDb.On("GetWorkspaceByUuid", "workspace-uuid").Return(workspace).Once()
+
+		ctx := context.WithValue(context.Background(), auth.ContextKey, "owner-pubkey")
+		req, _ := http.NewRequestWithContext(ctx, http.MethodPost, "/budgetinvoices", bytes.NewBufferString(`{"amount": 1000, "workspace_uuid": "workspace-uuid"}`))
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(NewTribeHandler(mockDb).GenerateBudgetInvoice).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+		mockDb.AssertNotCalled(t, "ProcessBudgetInvoice", mock.Anything, mock.Anything)
+		mockDb.AssertExpectations(t)
+	})
+}
+
+func TestReviewFlaggedWorkspace(t *testing.T) {
+	newRequest := func(body string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("uuid", "workspace-uuid")
+		ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+		ctx = context.WithValue(ctx, auth.ContextKey, "admin-pubkey")
+		req, _ := http.NewRequestWithContext(ctx, http.MethodPost, "/admin/workspaces/workspace-uuid/review", bytes.NewBufferString(body))
+		return req
+	}
+
+	t.Run("should list an approved workspace", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mockDb.On("GetWorkspaceByUuid", "workspace-uuid").Return(db.Workspace{Uuid: "workspace-uuid", Flagged: true}).Once()
+		mockDb.On("ReviewFlaggedWorkspace", "workspace-uuid", db.WorkspaceReview{Action: db.WorkspaceReviewApprove}).Return(db.Workspace{Uuid: "workspace-uuid"}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(NewModerationHandler(mockDb).ReviewFlaggedWorkspace).ServeHTTP(rr, newRequest(`{"action": "approve"}`))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should refuse to review a workspace that is not flagged", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mockDb.On("GetWorkspaceByUuid", "workspace-uuid").Return(db.Workspace{Uuid: "workspace-uuid"}).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(NewModerationHandler(mockDb).ReviewFlaggedWorkspace).ServeHTTP(rr, newRequest(`{"action": "reject"}`))
+
+		assert.Equal(t, http.StatusConflict, rr.Code)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should take no deposits to a workspace waiting for a review", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mockDb.On("GetWorkspaceByUuid", "workspace-uuid").Return(db.Workspace{Uuid: "workspace-uuid", Flagged: true}).Once()
+
+		ctx := context.WithValue(context.Background(), auth.ContextKey, "owner-pubkey")
+		req, _ := http.NewRequestWithContext(ctx, http.MethodPost, "/budgetinvoices", bytes.NewBufferString(`{"amount": 1000, "workspace_uuid": "workspace-uuid"}`))
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(NewTribeHandler(mockDb).GenerateBudgetInvoice).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+		mockDb.AssertExpectations(t)
+	})
+}
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -12,6 +13,9 @@ import (
 	"github.com/rs/xid"
 	"github.com/stakwork/sphinx-tribes/auth"
 	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/httpio"
+	"github.com/stakwork/sphinx-tribes/jobs"
+	"github.com/stakwork/sphinx-tribes/upstream"
 	"github.com/stakwork/sphinx-tribes/utils"
 	"gorm.io/gorm"
 )
@@ -22,10 +26,12 @@ type workspaceHandler struct {
 	getLightningInvoice      func(payment_request string) (db.InvoiceResult, db.InvoiceError)
 	userHasAccess            func(pubKeyFromAuth string, uuid string, role string) bool
 	userHasManageBountyRoles func(pubKeyFromAuth string, uuid string) bool
+	createInvoice            func(ctx context.Context, amount uint, memo string) (db.InvoiceResponse, error)
+	sendVerificationCode     func(ctx context.Context, code db.WorkspaceVerificationCode) error
 }
 
 func NewWorkspaceHandler(database db.Database) *workspaceHandler {
-	bHandler := NewBountyHandler(http.DefaultClient, database)
+	bHandler := NewBountyHandler(upstream.Default, database)
 	dbConf := db.NewDatabaseConfig(&gorm.DB{})
 	return &workspaceHandler{
 		db:                       database,
@@ -33,15 +39,31 @@ func NewWorkspaceHandler(database db.Database) *workspaceHandler {
 		getLightningInvoice:      bHandler.GetLightningInvoice,
 		userHasAccess:            dbConf.UserHasAccess,
 		userHasManageBountyRoles: dbConf.UserHasManageBountyRoles,
+		createInvoice: func(ctx context.Context, amount uint, memo string) (db.InvoiceResponse, error) {
+			return relayInvoice(ctx, upstream.Default, amount, memo)
+		},
+		sendVerificationCode: postVerificationCode,
 	}
 }
 
+// isWorkspaceMember reports if pubkey owns the workspace or was added to it
+func isWorkspaceMember(database db.Database, pubkey string, workspaceUuid string) bool {
+	workspace := database.GetWorkspaceByUuid(workspaceUuid)
+	if workspace.Uuid == "" {
+		return false
+	}
+	if workspace.OwnerPubKey == pubkey {
+		return true
+	}
+	return database.GetWorkspaceUser(pubkey, workspaceUuid).ID != 0
+}
+
 func (oh *workspaceHandler) CreateOrEditWorkspace(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
 	if pubKeyFromAuth == "" {
 		fmt.Println("[workspaces] no pubkey from auth")
-		w.WriteHeader(http.StatusUnauthorized)
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
 		return
 	}
 	now := time.Now()
@@ -53,23 +75,13 @@ func (oh *workspaceHandler) CreateOrEditWorkspace(w http.ResponseWriter, r *http
 
 	if err != nil {
 		fmt.Println("[workspaces] ", err)
-		w.WriteHeader(http.StatusNotAcceptable)
+		httpio.WriteError(w, r, http.StatusNotAcceptable, "")
 		return
 	}
 
 	workspace.Name = strings.TrimSpace(workspace.Name)
 
-	if len(workspace.Name) == 0 || len(workspace.Name) > 20 {
-		fmt.Printf("[workspaces] invalid workspace name %s\n", workspace.Name)
-		w.WriteHeader(http.StatusBadRequest)
-		json.NewEncoder(w).Encode("Error: workspace name must be present and should not exceed 20 character")
-		return
-	}
-
-	if len(workspace.Description) > 120 {
-		fmt.Printf("[workspaces] invalid workspace name %s\n", workspace.Description)
-		w.WriteHeader(http.StatusBadRequest)
-		json.NewEncoder(w).Encode("Error: workspace description should not exceed 120 character")
+	if !validatePayload(w, r, workspace) {
 		return
 	}
 
@@ -79,33 +91,28 @@ func (oh *workspaceHandler) CreateOrEditWorkspace(w http.ResponseWriter, r *http
 			fmt.Println("[workspaces] mismatched pubkey")
 			fmt.Println("[workspaces] Auth pubkey:", pubKeyFromAuth)
 			fmt.Println("[workspaces] OwnerPubKey:", workspace.OwnerPubKey)
-			w.WriteHeader(http.StatusUnauthorized)
-			json.NewEncoder(w).Encode("Don't have access to Edit workspace")
+			httpio.WriteError(w, r, http.StatusUnauthorized, "Don't have access to Edit workspace")
 			return
 		}
 	}
 
-	// Validate struct data
-	err = db.Validate.Struct(workspace)
-	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
-		msg := fmt.Sprintf("Error: did not pass validation test : %s", err)
-		json.NewEncoder(w).Encode(msg)
-		return
-	}
-
 	if workspace.Github != "" && !strings.Contains(workspace.Github, "github.com/") {
-		w.WriteHeader(http.StatusBadRequest)
 		msg := "Error: not a valid github"
-		json.NewEncoder(w).Encode(msg)
+		httpio.WriteError(w, r, http.StatusBadRequest, msg)
+		return
+	}
+	workspace.GithubOrg = strings.TrimSpace(workspace.GithubOrg)
+	if workspace.GithubOrg != "" && !githubLoginPattern.MatchString(workspace.GithubOrg) {
+		httpio.WriteError(w, r, http.StatusBadRequest, "Error: not a valid github organization")
 		return
 	}
 
 	existing := oh.db.GetWorkspaceByUuid(workspace.Uuid)
+	flags := []string{}
 	if existing.ID == 0 { // new!
 		if workspace.ID != 0 { // can't try to "edit" if it does not exist already
 			fmt.Println("[workspaces] cant edit non existing")
-			w.WriteHeader(http.StatusUnauthorized)
+			httpio.WriteError(w, r, http.StatusUnauthorized, "")
 			return
 		}
 
@@ -114,8 +121,12 @@ func (oh *workspaceHandler) CreateOrEditWorkspace(w http.ResponseWriter, r *http
 		// check if the workspace name already exists
 		workspaceSameName := oh.db.GetWorkspaceByName(name)
 		if workspaceSameName.Name == name {
-			w.WriteHeader(http.StatusConflict)
-			json.NewEncoder(w).Encode("Workspace name already exists - " + name)
+			httpio.WriteError(w, r, http.StatusConflict, "Workspace name already exists - "+name)
+			return
+		}
+
+		var ok bool
+		if flags, ok = oh.workspaceCreationFlags(w, r, pubKeyFromAuth, now); !ok {
 			return
 		}
 
@@ -131,10 +142,26 @@ func (oh *workspaceHandler) CreateOrEditWorkspace(w http.ResponseWriter, r *http
 
 	p, err := oh.db.CreateOrEditWorkspace(workspace)
 	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
+		httpio.WriteError(w, r, http.StatusBadRequest, "")
 		return
 	}
 
+	if len(flags) > 0 {
+		if err := oh.db.FlagWorkspace(p.Uuid, flags); err != nil {
+			fmt.Println("[workspaces] could not flag the workspace", err)
+		} else {
+			p.Flagged = true
+			p.FlagReasons = flags
+		}
+	}
+
+	// a newly linked organization is synced right away, not the next night
+	if p.GithubOrg != "" && p.GithubOrg != existing.GithubOrg {
+		if _, err := jobs.Default.Enqueue(jobs.WorkspaceGithubSyncJob, map[string]interface{}{"workspace_uuid": p.Uuid}); err != nil {
+			fmt.Println("[workspaces] could not sync the github organization", err)
+		}
+	}
+
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(p)
 }
@@ -143,7 +170,7 @@ func GetWorkspaces(w http.ResponseWriter, r *http.Request) {
 	orgs := db.DB.GetWorkspaces(r)
 
 	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(orgs)
+	json.NewEncoder(w).Encode(utils.ListBody(r, orgs, db.DB.GetWorkspacesCount))
 }
 
 func GetWorkspacesCount(w http.ResponseWriter, r *http.Request) {
@@ -180,43 +207,39 @@ func CreateWorkspaceUser(w http.ResponseWriter, r *http.Request) {
 
 	if err != nil {
 		fmt.Println("[workspaces] ", err)
-		w.WriteHeader(http.StatusNotAcceptable)
+		httpio.WriteError(w, r, http.StatusNotAcceptable, "")
 		return
 	}
 
 	if pubKeyFromAuth == "" {
 		fmt.Println("[workspaces] no pubkey from auth")
-		w.WriteHeader(http.StatusUnauthorized)
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
 		return
 	}
 
 	// check if the user is the workspace admin
 	if workspaceUser.OwnerPubKey == workspace.OwnerPubKey {
-		w.WriteHeader(http.StatusUnauthorized)
-		json.NewEncoder(w).Encode("Cannot add workspace admin as a user")
+		httpio.WriteError(w, r, http.StatusUnauthorized, "Cannot add workspace admin as a user")
 		return
 	}
 
 	// check if the user tries to add their self
 	if pubKeyFromAuth == workspaceUser.OwnerPubKey {
-		w.WriteHeader(http.StatusUnauthorized)
-		json.NewEncoder(w).Encode("Cannot add userself as a user")
+		httpio.WriteError(w, r, http.StatusUnauthorized, "Cannot add userself as a user")
 		return
 	}
 
 	// if not the orgnization admin
 	hasRole := db.UserHasAccess(pubKeyFromAuth, workspaceUser.WorkspaceUuid, db.AddUser)
 	if !hasRole {
-		w.WriteHeader(http.StatusUnauthorized)
-		json.NewEncoder(w).Encode("Don't have access to add user")
+		httpio.WriteError(w, r, http.StatusUnauthorized, "Don't have access to add user")
 		return
 	}
 
 	// check if the user exists on peoples table
 	isUser := db.DB.GetPersonByPubkey(workspaceUser.OwnerPubKey)
 	if isUser.OwnerPubKey != workspaceUser.OwnerPubKey {
-		w.WriteHeader(http.StatusUnauthorized)
-		json.NewEncoder(w).Encode("User doesn't exists in people")
+		httpio.WriteError(w, r, http.StatusUnauthorized, "User doesn't exists in people")
 		return
 	}
 
@@ -224,8 +247,7 @@ func CreateWorkspaceUser(w http.ResponseWriter, r *http.Request) {
 	userExists := db.DB.GetWorkspaceUser(workspaceUser.OwnerPubKey, workspaceUser.WorkspaceUuid)
 
 	if userExists.ID != 0 {
-		w.WriteHeader(http.StatusUnauthorized)
-		json.NewEncoder(w).Encode("User already exists")
+		httpio.WriteError(w, r, http.StatusUnauthorized, "User already exists")
 		return
 	}
 
@@ -252,7 +274,7 @@ func GetWorkspaceUser(w http.ResponseWriter, r *http.Request) {
 
 	if pubKeyFromAuth == "" {
 		fmt.Println("[workspaces] no pubkey from auth")
-		w.WriteHeader(http.StatusUnauthorized)
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
 		return
 	}
 
@@ -286,28 +308,26 @@ func DeleteWorkspaceUser(w http.ResponseWriter, r *http.Request) {
 
 	if err != nil {
 		fmt.Println("[workspaces]", err)
-		w.WriteHeader(http.StatusNotAcceptable)
+		httpio.WriteError(w, r, http.StatusNotAcceptable, "")
 		return
 	}
 
 	if pubKeyFromAuth == "" {
 		fmt.Println("[workspaces] no pubkey from auth")
-		w.WriteHeader(http.StatusUnauthorized)
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
 		return
 	}
 
 	workspace := db.DB.GetWorkspaceByUuid(workspaceUser.WorkspaceUuid)
 
 	if workspaceUser.OwnerPubKey == workspace.OwnerPubKey {
-		w.WriteHeader(http.StatusUnauthorized)
-		json.NewEncoder(w).Encode("Cannot delete workspace admin")
+		httpio.WriteError(w, r, http.StatusUnauthorized, "Cannot delete workspace admin")
 		return
 	}
 
 	hasRole := db.UserHasAccess(pubKeyFromAuth, workspaceUser.WorkspaceUuid, db.DeleteUser)
 	if !hasRole {
-		w.WriteHeader(http.StatusUnauthorized)
-		json.NewEncoder(w).Encode("Don't have access to delete user")
+		httpio.WriteError(w, r, http.StatusUnauthorized, "Don't have access to delete user")
 		return
 	}
 
@@ -332,8 +352,7 @@ func AddUserRoles(w http.ResponseWriter, r *http.Request) {
 	now := time.Now()
 
 	if uuid == "" || user == "" {
-		w.WriteHeader(http.StatusUnauthorized)
-		json.NewEncoder(w).Encode("no uuid, or user pubkey")
+		httpio.WriteError(w, r, http.StatusUnauthorized, "no uuid, or user pubkey")
 		return
 	}
 
@@ -344,13 +363,12 @@ func AddUserRoles(w http.ResponseWriter, r *http.Request) {
 
 	if err != nil {
 		fmt.Println("[workspaces]:", err)
-		w.WriteHeader(http.StatusNotAcceptable)
+		httpio.WriteError(w, r, http.StatusNotAcceptable, "")
 		return
 	}
 
 	if pubKeyFromAuth == "" {
-		w.WriteHeader(http.StatusUnauthorized)
-		json.NewEncoder(w).Encode("no pubkey from auth")
+		httpio.WriteError(w, r, http.StatusUnauthorized, "no pubkey from auth")
 		return
 	}
 
@@ -359,21 +377,18 @@ func AddUserRoles(w http.ResponseWriter, r *http.Request) {
 	isUser := db.CheckUser(roles, pubKeyFromAuth)
 
 	if isUser {
-		w.WriteHeader(http.StatusUnauthorized)
-		json.NewEncoder(w).Encode("cannot add roles for self")
+		httpio.WriteError(w, r, http.StatusUnauthorized, "cannot add roles for self")
 		return
 	}
 
 	// check if the user added his pubkey to the route
 	if pubKeyFromAuth == user {
-		w.WriteHeader(http.StatusUnauthorized)
-		json.NewEncoder(w).Encode("auth pubkey cannot be the same with user's")
+		httpio.WriteError(w, r, http.StatusUnauthorized, "auth pubkey cannot be the same with user's")
 		return
 	}
 
 	if !hasRole {
-		w.WriteHeader(http.StatusUnauthorized)
-		json.NewEncoder(w).Encode("user does not have adequate permissions to add roles")
+		httpio.WriteError(w, r, http.StatusUnauthorized, "user does not have adequate permissions to add roles")
 		return
 	}
 
@@ -388,8 +403,7 @@ func AddUserRoles(w http.ResponseWriter, r *http.Request) {
 		_, ok := rolesMap[role.Role]
 		// if any of the roles does not exists return an error
 		if !ok {
-			w.WriteHeader(http.StatusUnauthorized)
-			json.NewEncoder(w).Encode("not a valid user role")
+			httpio.WriteError(w, r, http.StatusUnauthorized, "not a valid user role")
 			return
 		}
 
@@ -397,8 +411,7 @@ func AddUserRoles(w http.ResponseWriter, r *http.Request) {
 		okUser := db.UserHasAccess(pubKeyFromAuth, uuid, role.Role)
 		// if the user does not have any of the roles he wants to add return an error
 		if !okUser {
-			w.WriteHeader(http.StatusUnauthorized)
-			json.NewEncoder(w).Encode("cannot add a role you don't have")
+			httpio.WriteError(w, r, http.StatusUnauthorized, "cannot add a role you don't have")
 			return
 		}
 
@@ -412,8 +425,7 @@ func AddUserRoles(w http.ResponseWriter, r *http.Request) {
 
 	// if not the workspace admin
 	if userExists.OwnerPubKey != user || userExists.WorkspaceUuid != uuid {
-		w.WriteHeader(http.StatusUnauthorized)
-		json.NewEncoder(w).Encode("User does not exists in the workspace")
+		httpio.WriteError(w, r, http.StatusUnauthorized, "User does not exists in the workspace")
 		return
 	}
 
@@ -439,7 +451,7 @@ func GetUserWorkspaces(w http.ResponseWriter, r *http.Request) {
 
 	if userId == 0 {
 		fmt.Println("[workspaces] provide user id")
-		w.WriteHeader(http.StatusNotAcceptable)
+		httpio.WriteError(w, r, http.StatusNotAcceptable, "")
 		return
 	}
 
@@ -457,7 +469,7 @@ func (oh *workspaceHandler) GetUserDropdownWorkspaces(w http.ResponseWriter, r *
 
 	if userId == 0 {
 		fmt.Println("[workspaces] provide user id")
-		w.WriteHeader(http.StatusNotAcceptable)
+		httpio.WriteError(w, r, http.StatusNotAcceptable, "")
 		return
 	}
 
@@ -524,10 +536,16 @@ func (oh *workspaceHandler) GetWorkspaceBounties(w http.ResponseWriter, r *http.
 func (oh *workspaceHandler) GetWorkspaceBountiesCount(w http.ResponseWriter, r *http.Request) {
 	uuid := chi.URLParam(r, "uuid")
 
-	workspaceBountiesCount := oh.db.GetWorkspaceBountiesCount(r, uuid)
+	workspaceBountiesCount, err := db.CachedJSON(db.WorkspaceBountiesCountCacheKey+uuid+":"+r.URL.RawQuery, func() interface{} {
+		return oh.db.GetWorkspaceBountiesCount(r, uuid)
+	})
+	if err != nil {
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to encode bounties count")
+		return
+	}
 
 	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(workspaceBountiesCount)
+	w.Write(workspaceBountiesCount)
 }
 
 func (oh *workspaceHandler) GetWorkspaceBudget(w http.ResponseWriter, r *http.Request) {
@@ -537,15 +555,14 @@ func (oh *workspaceHandler) GetWorkspaceBudget(w http.ResponseWriter, r *http.Re
 
 	if pubKeyFromAuth == "" {
 		fmt.Println("[workspaces] no pubkey from auth")
-		w.WriteHeader(http.StatusUnauthorized)
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
 		return
 	}
 
 	// if not the workspace admin
 	hasRole := oh.userHasAccess(pubKeyFromAuth, uuid, db.ViewReport)
 	if !hasRole {
-		w.WriteHeader(http.StatusUnauthorized)
-		json.NewEncoder(w).Encode("Don't have access to view budget")
+		httpio.WriteError(w, r, http.StatusUnauthorized, "Don't have access to view budget")
 		return
 	}
 
@@ -564,8 +581,7 @@ func (oh *workspaceHandler) GetWorkspaceBudgetHistory(w http.ResponseWriter, r *
 	// if not the workspace admin
 	hasRole := oh.userHasAccess(pubKeyFromAuth, uuid, db.ViewReport)
 	if !hasRole {
-		w.WriteHeader(http.StatusUnauthorized)
-		json.NewEncoder(w).Encode("Don't have access to view budget history")
+		httpio.WriteError(w, r, http.StatusUnauthorized, "Don't have access to view budget history")
 		return
 	}
 
@@ -583,15 +599,14 @@ func GetPaymentHistory(w http.ResponseWriter, r *http.Request) {
 
 	if pubKeyFromAuth == "" {
 		fmt.Println("[workspaces] no pubkey from auth")
-		w.WriteHeader(http.StatusUnauthorized)
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
 		return
 	}
 
 	// if not the workspace admin
 	hasRole := db.UserHasAccess(pubKeyFromAuth, uuid, db.ViewReport)
 	if !hasRole {
-		w.WriteHeader(http.StatusUnauthorized)
-		json.NewEncoder(w).Encode("Don't have access to view payments")
+		httpio.WriteError(w, r, http.StatusUnauthorized, "Don't have access to view payments")
 		return
 	}
 
@@ -623,23 +638,48 @@ func (oh *workspaceHandler) PollBudgetInvoices(w http.ResponseWriter, r *http.Re
 
 	if pubKeyFromAuth == "" {
 		fmt.Println("[workspaces] no pubkey from auth")
-		w.WriteHeader(http.StatusUnauthorized)
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
+		return
+	}
+
+	if _, invoiceErr := oh.ReconcileInvoices(uuid); invoiceErr.Error != "" {
+		httpio.WriteError(w, r, http.StatusForbidden, invoiceErr.Error)
 		return
 	}
 
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode("Polled invoices")
+}
+
+// InvoiceReconciliation counts what reconciling the invoices of a workspace
+// changed
+type InvoiceReconciliation struct {
+	WorkspaceUuid string `json:"workspace_uuid"`
+	Checked       int    `json:"checked"`
+	Settled       int    `json:"settled"`
+	Expired       int    `json:"expired"`
+}
+
+// ReconcileInvoices checks the invoices of a workspace with the relay, it
+// credits the budget of the paid budget invoices that were not credited yet
+// and deletes the expired unpaid ones. It stops at the first invoice the
+// relay can't report on
+func (oh *workspaceHandler) ReconcileInvoices(uuid string) (InvoiceReconciliation, db.InvoiceError) {
+	result := InvoiceReconciliation{WorkspaceUuid: uuid}
+
 	workInvoices := oh.db.GetWorkspaceInvoices(uuid)
 	for _, inv := range workInvoices {
 		invoiceRes, invoiceErr := oh.getLightningInvoice(inv.PaymentRequest)
 
 		if invoiceErr.Error != "" {
-			w.WriteHeader(http.StatusForbidden)
-			json.NewEncoder(w).Encode(invoiceErr)
-			return
+			return result, invoiceErr
 		}
+		result.Checked++
 
 		if invoiceRes.Response.Settled {
 			if !inv.Status && inv.Type == "BUDGET" {
 				oh.db.ProcessUpdateBudget(inv)
+				result.Settled++
 			}
 		} else {
 			// Cheeck if time has expired
@@ -647,12 +687,11 @@ func (oh *workspaceHandler) PollBudgetInvoices(w http.ResponseWriter, r *http.Re
 			// If the invoice has expired and it is not paid delete from the DB
 			if isInvoiceExpired {
 				oh.db.DeleteInvoice(inv.PaymentRequest)
+				result.Expired++
 			}
 		}
 	}
-
-	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode("Polled invoices")
+	return result, db.InvoiceError{}
 }
 
 func (oh *workspaceHandler) PollUserWorkspacesBudget(w http.ResponseWriter, r *http.Request) {
@@ -661,7 +700,7 @@ func (oh *workspaceHandler) PollUserWorkspacesBudget(w http.ResponseWriter, r *h
 
 	if pubKeyFromAuth == "" {
 		fmt.Println("[workspaces] no pubkey from auth")
-		w.WriteHeader(http.StatusUnauthorized)
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
 		return
 	}
 
@@ -669,30 +708,9 @@ func (oh *workspaceHandler) PollUserWorkspacesBudget(w http.ResponseWriter, r *h
 	workspaces := GetAllUserWorkspaces(pubKeyFromAuth)
 	// loop through the worksppaces and get each workspace invoice
 	for _, space := range workspaces {
-		// get all workspace invoice
-		workInvoices := oh.db.GetWorkspaceInvoices(space.Uuid)
-
-		for _, inv := range workInvoices {
-			invoiceRes, invoiceErr := oh.getLightningInvoice(inv.PaymentRequest)
-
-			if invoiceErr.Error != "" {
-				w.WriteHeader(http.StatusForbidden)
-				json.NewEncoder(w).Encode(invoiceErr)
-				return
-			}
-
-			if invoiceRes.Response.Settled {
-				if !inv.Status && inv.Type == "BUDGET" {
-					oh.db.ProcessUpdateBudget(inv)
-				}
-			} else {
-				// Cheeck if time has expired
-				isInvoiceExpired := utils.GetInvoiceExpired(inv.PaymentRequest)
-				// If the invoice has expired and it is not paid delete from the DB
-				if isInvoiceExpired {
-					oh.db.DeleteInvoice(inv.PaymentRequest)
-				}
-			}
+		if _, invoiceErr := oh.ReconcileInvoices(space.Uuid); invoiceErr.Error != "" {
+			httpio.WriteError(w, r, http.StatusForbidden, invoiceErr.Error)
+			return
 		}
 	}
 
@@ -707,7 +725,7 @@ func GetInvoicesCount(w http.ResponseWriter, r *http.Request) {
 
 	if pubKeyFromAuth == "" {
 		fmt.Println("[workspaces] no pubkey from auth")
-		w.WriteHeader(http.StatusUnauthorized)
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
 		return
 	}
 
@@ -722,7 +740,7 @@ func GetAllUserInvoicesCount(w http.ResponseWriter, r *http.Request) {
 
 	if pubKeyFromAuth == "" {
 		fmt.Println("[workspaces] no pubkey from auth")
-		w.WriteHeader(http.StatusUnauthorized)
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
 		return
 	}
 
@@ -743,7 +761,7 @@ func (oh *workspaceHandler) DeleteWorkspace(w http.ResponseWriter, r *http.Reque
 
 	if pubKeyFromAuth == "" {
 		fmt.Println("[workspaces] no pubkey from auth")
-		w.WriteHeader(http.StatusUnauthorized)
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
 		return
 	}
 
@@ -751,8 +769,7 @@ func (oh *workspaceHandler) DeleteWorkspace(w http.ResponseWriter, r *http.Reque
 	if pubKeyFromAuth != workspace.OwnerPubKey {
 		msg := "only workspace admin can delete an workspace"
 		fmt.Println("[workspaces]", msg)
-		w.WriteHeader(http.StatusUnauthorized)
-		json.NewEncoder(w).Encode(msg)
+		httpio.WriteError(w, r, http.StatusUnauthorized, msg)
 		return
 	}
 
@@ -760,8 +777,7 @@ func (oh *workspaceHandler) DeleteWorkspace(w http.ResponseWriter, r *http.Reque
 	if err := oh.db.ProcessDeleteWorkspace(uuid); err != nil {
 		msg := "Error removing users from workspace"
 		fmt.Println(msg, err)
-		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode(msg)
+		httpio.WriteError(w, r, http.StatusInternalServerError, msg)
 		return
 	}
 
@@ -774,7 +790,7 @@ func (oh *workspaceHandler) UpdateWorkspace(w http.ResponseWriter, r *http.Reque
 	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
 	if pubKeyFromAuth == "" {
 		fmt.Println("[workspaces] no pubkey from auth")
-		w.WriteHeader(http.StatusUnauthorized)
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
 		return
 	}
 
@@ -785,7 +801,7 @@ func (oh *workspaceHandler) UpdateWorkspace(w http.ResponseWriter, r *http.Reque
 
 	if err != nil {
 		fmt.Println("[workspaces]", err)
-		w.WriteHeader(http.StatusNotAcceptable)
+		httpio.WriteError(w, r, http.StatusNotAcceptable, "")
 		return
 	}
 
@@ -795,24 +811,18 @@ func (oh *workspaceHandler) UpdateWorkspace(w http.ResponseWriter, r *http.Reque
 			fmt.Println("[workspaces] mismatched pubkey")
 			fmt.Println("Auth Pubkey:", pubKeyFromAuth)
 			fmt.Println("OwnerPubKey:", workspace.OwnerPubKey)
-			w.WriteHeader(http.StatusUnauthorized)
-			json.NewEncoder(w).Encode("Don't have access to Edit workspace")
+			httpio.WriteError(w, r, http.StatusUnauthorized, "Don't have access to Edit workspace")
 			return
 		}
 	}
 
-	// Validate struct data
-	err = db.Validate.Struct(workspace)
-	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
-		msg := fmt.Sprintf("Error: did not pass validation test : %s", err)
-		json.NewEncoder(w).Encode(msg)
+	if !validatePayload(w, r, workspace) {
 		return
 	}
 
 	p, err := oh.db.CreateOrEditWorkspace(workspace)
 	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
+		httpio.WriteError(w, r, http.StatusBadRequest, "")
 		return
 	}
 
@@ -820,12 +830,150 @@ func (oh *workspaceHandler) UpdateWorkspace(w http.ResponseWriter, r *http.Reque
 	json.NewEncoder(w).Encode(p)
 }
 
+type workspaceAutoPayRequest struct {
+	AutoPay    bool `json:"auto_pay"`
+	AutoPayCap uint `json:"auto_pay_cap"`
+}
+
+// SetWorkspaceAutoPay lets the owner have the bounties paid as soon as
+// their completion is accepted, skipping the manual pay step
+func (oh *workspaceHandler) SetWorkspaceAutoPay(w http.ResponseWriter, r *http.Request) {
+	pubKeyFromAuth, _ := r.Context().Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[workspaces] no pubkey from auth")
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
+		return
+	}
+
+	workspace := oh.db.GetWorkspaceByUuid(chi.URLParam(r, "workspace_uuid"))
+	if workspace.Uuid == "" {
+		httpio.WriteError(w, r, http.StatusNotFound, "Workspace not found")
+		return
+	}
+	if pubKeyFromAuth != workspace.OwnerPubKey {
+		httpio.WriteError(w, r, http.StatusUnauthorized, "only workspace admin can set auto-pay")
+		return
+	}
+
+	request := workspaceAutoPayRequest{}
+	body, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err := json.Unmarshal(body, &request); err != nil {
+		fmt.Println("[workspaces]", err)
+		httpio.WriteError(w, r, http.StatusNotAcceptable, "")
+		return
+	}
+
+	updated, err := oh.db.SetWorkspaceAutoPay(workspace.Uuid, request.AutoPay, request.AutoPayCap)
+	if err != nil {
+		fmt.Println("[workspaces]", err)
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to set auto-pay")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(updated)
+}
+
+// SetWorkspaceDefinitionOfDone lets the owner set the conditions a bounty
+// must meet before its completion is accepted
+func (oh *workspaceHandler) SetWorkspaceDefinitionOfDone(w http.ResponseWriter, r *http.Request) {
+	pubKeyFromAuth, _ := r.Context().Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[workspaces] no pubkey from auth")
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
+		return
+	}
+
+	workspace := oh.db.GetWorkspaceByUuid(chi.URLParam(r, "workspace_uuid"))
+	if workspace.Uuid == "" {
+		httpio.WriteError(w, r, http.StatusNotFound, "Workspace not found")
+		return
+	}
+	if pubKeyFromAuth != workspace.OwnerPubKey {
+		httpio.WriteError(w, r, http.StatusUnauthorized, "only workspace admin can set the definition of done")
+		return
+	}
+
+	request := db.DefinitionOfDone{}
+	body, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err := json.Unmarshal(body, &request); err != nil {
+		fmt.Println("[workspaces]", err)
+		httpio.WriteError(w, r, http.StatusNotAcceptable, "")
+		return
+	}
+
+	updated, err := oh.db.SetWorkspaceDefinitionOfDone(workspace.Uuid, request)
+	if err != nil {
+		fmt.Println("[workspaces]", err)
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to set the definition of done")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(updated)
+}
+
+// SetWorkspaceReviewers lets the owner set who reviews the proofs submitted
+// on the bounties of the workspace, and how many approvals a proof needs
+// before its bounty can be completed
+func (oh *workspaceHandler) SetWorkspaceReviewers(w http.ResponseWriter, r *http.Request) {
+	pubKeyFromAuth, _ := r.Context().Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[workspaces] no pubkey from auth")
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
+		return
+	}
+
+	workspace := oh.db.GetWorkspaceByUuid(chi.URLParam(r, "workspace_uuid"))
+	if workspace.Uuid == "" {
+		httpio.WriteError(w, r, http.StatusNotFound, "Workspace not found")
+		return
+	}
+	if pubKeyFromAuth != workspace.OwnerPubKey {
+		httpio.WriteError(w, r, http.StatusUnauthorized, "only workspace admin can set the reviewers")
+		return
+	}
+
+	request := db.ReviewerSettings{}
+	body, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err := json.Unmarshal(body, &request); err != nil {
+		fmt.Println("[workspaces]", err)
+		httpio.WriteError(w, r, http.StatusNotAcceptable, "")
+		return
+	}
+	if !validatePayload(w, r, request) {
+		return
+	}
+	if unknown := unknownReviewers(oh.db, request.Reviewers); len(unknown) > 0 {
+		httpio.WriteErrorDetails(w, r, http.StatusBadRequest, "Reviewers need a profile", map[string]interface{}{"unknown": unknown})
+		return
+	}
+	// without reviewers the workspace admin reviews alone
+	if reviewers := len(request.Reviewers); request.RequiredApprovals > 1 && int(request.RequiredApprovals) > reviewers {
+		httpio.WriteError(w, r, http.StatusBadRequest, "required_approvals can't be more than the number of reviewers")
+		return
+	}
+
+	updated, err := oh.db.SetWorkspaceReviewers(workspace.Uuid, request)
+	if err != nil {
+		fmt.Println("[workspaces]", err)
+		httpio.WriteError(w, r, http.StatusInternalServerError, "Failed to set the reviewers")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(updated)
+}
+
 func (oh *workspaceHandler) CreateOrEditWorkspaceRepository(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
 	if pubKeyFromAuth == "" {
 		fmt.Println("[workspaces] no pubkey from auth")
-		w.WriteHeader(http.StatusUnauthorized)
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
 		return
 	}
 
@@ -836,7 +984,7 @@ func (oh *workspaceHandler) CreateOrEditWorkspaceRepository(w http.ResponseWrite
 
 	if err != nil {
 		fmt.Println("[workspaces]", err)
-		w.WriteHeader(http.StatusNotAcceptable)
+		httpio.WriteError(w, r, http.StatusNotAcceptable, "")
 		return
 	}
 
@@ -853,26 +1001,20 @@ func (oh *workspaceHandler) CreateOrEditWorkspaceRepository(w http.ResponseWrite
 		workspaceRepo.UpdatedBy = pubKeyFromAuth
 	}
 
-	// Validate struct data
-	err = db.Validate.Struct(workspaceRepo)
-	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
-		msg := fmt.Sprintf("Error: did not pass validation test : %s", err)
-		json.NewEncoder(w).Encode(msg)
+	if !validatePayload(w, r, workspaceRepo) {
 		return
 	}
 
 	// Check if workspace exists
 	workpace := oh.db.GetWorkspaceByUuid(workspaceRepo.WorkspaceUuid)
 	if workpace.Uuid != workspaceRepo.WorkspaceUuid {
-		w.WriteHeader(http.StatusUnauthorized)
-		json.NewEncoder(w).Encode("Workspace does not exists")
+		httpio.WriteError(w, r, http.StatusUnauthorized, "Workspace does not exists")
 		return
 	}
 
 	p, err := oh.db.CreateOrEditWorkspaceRepository(workspaceRepo)
 	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
+		httpio.WriteError(w, r, http.StatusBadRequest, "")
 		return
 	}
 
@@ -885,7 +1027,7 @@ func (oh *workspaceHandler) GetWorkspaceRepositorByWorkspaceUuid(w http.Response
 	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
 	if pubKeyFromAuth == "" {
 		fmt.Println("[workspaces] no pubkey from auth")
-		w.WriteHeader(http.StatusUnauthorized)
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
 		return
 	}
 
@@ -901,7 +1043,7 @@ func (oh *workspaceHandler) GetWorkspaceRepoByWorkspaceUuidAndRepoUuid(w http.Re
 	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
 	if pubKeyFromAuth == "" {
 		fmt.Println("[workspaces] no pubkey from auth")
-		w.WriteHeader(http.StatusUnauthorized)
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
 		return
 	}
 
@@ -910,8 +1052,7 @@ func (oh *workspaceHandler) GetWorkspaceRepoByWorkspaceUuidAndRepoUuid(w http.Re
 	WorkspaceRepository, err := oh.db.GetWorkspaceRepoByWorkspaceUuidAndRepoUuid(workspace_uuid, uuid)
 	if err != nil {
 		fmt.Println("[workspaces] workspace repository not found:", err)
-		w.WriteHeader(http.StatusNotFound)
-		json.NewEncoder(w).Encode(map[string]string{"error": "Repository not found"})
+		httpio.WriteError(w, r, http.StatusNotFound, "Repository not found")
 		return
 	}
 
@@ -926,7 +1067,7 @@ func (oh *workspaceHandler) DeleteWorkspaceRepository(w http.ResponseWriter, r *
 
 	if pubKeyFromAuth == "" {
 		fmt.Println("[workspaces] no pubkey from auth")
-		w.WriteHeader(http.StatusUnauthorized)
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
 		return
 	}
 
@@ -944,7 +1085,7 @@ func (oh *workspaceHandler) GetFeaturesByWorkspaceUuid(w http.ResponseWriter, r
 	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
 	if pubKeyFromAuth == "" {
 		fmt.Println("[workspaces] no pubkey from auth")
-		w.WriteHeader(http.StatusUnauthorized)
+		httpio.WriteError(w, r, http.StatusUnauthorized, "")
 		return
 	}
 
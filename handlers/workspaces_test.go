@@ -16,13 +16,15 @@ import (
 	"github.com/google/uuid"
 	"github.com/stakwork/sphinx-tribes/auth"
 	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/dbtest"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestUnitCreateOrEditWorkspace(t *testing.T) {
-	teardownSuite := SetupSuite(t)
-	defer teardownSuite(t)
-	oHandler := NewWorkspaceHandler(db.TestDB)
+	database := dbtest.New(t)
+	oHandler := NewWorkspaceHandler(database)
 
 	t.Run("should return error if body is not a valid json", func(t *testing.T) {
 		rr := httptest.NewRecorder()
@@ -161,9 +163,9 @@ func TestUnitCreateOrEditWorkspace(t *testing.T) {
 			Website:     "https://www.bountieswebsite.com",
 			Description: "Workspace Bounties Description",
 		}
-		db.TestDB.CreateOrEditWorkspace(workspace)
+		database.CreateOrEditWorkspace(workspace)
 
-		Workspace := db.TestDB.GetWorkspaceByUuid(workspace.Uuid)
+		Workspace := database.GetWorkspaceByUuid(workspace.Uuid)
 		workspace.ID = Workspace.ID
 
 		requestBody, _ := json.Marshal(workspace)
@@ -207,9 +209,8 @@ func TestUnitCreateOrEditWorkspace(t *testing.T) {
 }
 
 func TestDeleteWorkspace(t *testing.T) {
-	teardownSuite := SetupSuite(t)
-	defer teardownSuite(t)
-	oHandler := NewWorkspaceHandler(db.TestDB)
+	database := dbtest.New(t)
+	oHandler := NewWorkspaceHandler(database)
 
 	workspace := db.Workspace{
 		Uuid:        uuid.New().String(),
@@ -219,8 +220,8 @@ func TestDeleteWorkspace(t *testing.T) {
 		Website:     "https://www.testwebsite.com",
 		Description: "Workspace Description",
 	}
-	db.TestDB.CreateOrEditWorkspace(workspace)
-	workspace = db.TestDB.GetWorkspaceByUuid(workspace.Uuid)
+	database.CreateOrEditWorkspace(workspace)
+	workspace = database.GetWorkspaceByUuid(workspace.Uuid)
 	ctx := context.WithValue(context.Background(), auth.ContextKey, workspace.OwnerPubKey)
 
 	t.Run("should return error if not authorized", func(t *testing.T) {
@@ -257,7 +258,7 @@ func TestDeleteWorkspace(t *testing.T) {
 
 		assert.Equal(t, http.StatusOK, rr.Code)
 
-		updatedOrg := db.TestDB.GetWorkspaceByUuid(workspaceUUID)
+		updatedOrg := database.GetWorkspaceByUuid(workspaceUUID)
 		assert.Equal(t, true, updatedOrg.Deleted)
 		assert.Equal(t, "", updatedOrg.Website)
 		assert.Equal(t, "", updatedOrg.Github)
@@ -303,7 +304,7 @@ func TestDeleteWorkspace(t *testing.T) {
 
 		assert.Equal(t, http.StatusOK, rr.Code)
 
-		updatedOrg := db.TestDB.GetWorkspaceByUuid(workspaceUUID)
+		updatedOrg := database.GetWorkspaceByUuid(workspaceUUID)
 		assert.Equal(t, true, updatedOrg.Deleted)
 	})
 
@@ -324,7 +325,7 @@ func TestDeleteWorkspace(t *testing.T) {
 
 		assert.Equal(t, http.StatusOK, rr.Code)
 
-		updatedOrg := db.TestDB.GetWorkspaceByUuid(workspaceUUID)
+		updatedOrg := database.GetWorkspaceByUuid(workspaceUUID)
 		assert.Equal(t, "", updatedOrg.Website)
 		assert.Equal(t, "", updatedOrg.Github)
 		assert.Equal(t, "", updatedOrg.Description)
@@ -347,19 +348,18 @@ func TestDeleteWorkspace(t *testing.T) {
 
 		assert.Equal(t, http.StatusOK, rr.Code)
 
-		updatedOrg := db.TestDB.GetWorkspaceByUuid(workspaceUUID)
+		updatedOrg := database.GetWorkspaceByUuid(workspaceUUID)
 		assert.Equal(t, true, updatedOrg.Deleted)
 	})
 }
 
 func TestGetWorkspaceBounties(t *testing.T) {
 	ctx := context.WithValue(context.Background(), auth.ContextKey, "test-key")
-	teardownSuite := SetupSuite(t)
-	defer teardownSuite(t)
+	database := dbtest.New(t)
 	mockGenerateBountyHandler := func(bounties []db.NewBounty) []db.BountyResponse {
 		return []db.BountyResponse{} // Mocked response
 	}
-	oHandler := NewWorkspaceHandler(db.TestDB)
+	oHandler := NewWorkspaceHandler(database)
 
 	workspace := db.Workspace{
 		Uuid:        uuid.New().String(),
@@ -369,7 +369,7 @@ func TestGetWorkspaceBounties(t *testing.T) {
 		Website:     "https://www.bountieswebsite.com",
 		Description: "Workspace Bounties Description",
 	}
-	db.TestDB.CreateOrEditWorkspace(workspace)
+	database.CreateOrEditWorkspace(workspace)
 
 	bounty := db.NewBounty{
 		Type:          "coding",
@@ -379,7 +379,7 @@ func TestGetWorkspaceBounties(t *testing.T) {
 		OwnerID:       "workspace-user",
 		Price:         2000,
 	}
-	db.TestDB.CreateOrEditBounty(bounty)
+	database.CreateOrEditBounty(bounty)
 
 	t.Run("Should test that a workspace's bounties can be listed without authentication", func(t *testing.T) {
 
@@ -394,10 +394,10 @@ func TestGetWorkspaceBounties(t *testing.T) {
 			t.Fatal(err)
 		}
 
-		fetchedWorkspace := db.TestDB.GetWorkspaceByUuid(workspace.Uuid)
+		fetchedWorkspace := database.GetWorkspaceByUuid(workspace.Uuid)
 		workspace.ID = fetchedWorkspace.ID
 
-		fetchedBounty := db.TestDB.GetWorkspaceBounties(req, bounty.WorkspaceUuid)
+		fetchedBounty := database.GetWorkspaceBounties(req, bounty.WorkspaceUuid)
 		bounty.ID = fetchedBounty[0].ID
 		handler.ServeHTTP(rr, req)
 
@@ -418,7 +418,7 @@ func TestGetWorkspaceBounties(t *testing.T) {
 			t.Fatal(err)
 		}
 
-		fetchedWorkspaceWrong := db.TestDB.GetWorkspaceByUuid(workspaceUUID)
+		fetchedWorkspaceWrong := database.GetWorkspaceByUuid(workspaceUUID)
 
 		handler.ServeHTTP(rr, req)
 
@@ -432,10 +432,9 @@ func TestGetWorkspaceBounties(t *testing.T) {
 }
 
 func TestGetWorkspaceBudget(t *testing.T) {
-	teardownSuite := SetupSuite(t)
-	defer teardownSuite(t)
+	database := dbtest.New(t)
 	ctx := context.WithValue(context.Background(), auth.ContextKey, "test-key")
-	oHandler := NewWorkspaceHandler(db.TestDB)
+	oHandler := NewWorkspaceHandler(database)
 	handlerUserHasAccess := func(pubKeyFromAuth string, uuid string, role string) bool {
 		return true
 	}
@@ -447,16 +446,16 @@ func TestGetWorkspaceBudget(t *testing.T) {
 		Website:     "https://www.budgetwebsite.com",
 		Description: "Workspace Budget Description",
 	}
-	db.TestDB.CreateOrEditWorkspace(workspace)
+	database.CreateOrEditWorkspace(workspace)
 
 	budgetAmount := uint(5000)
 	bounty := db.NewBountyBudget{
 		WorkspaceUuid: workspace.Uuid,
 		TotalBudget:   budgetAmount,
 	}
-	db.TestDB.CreateWorkspaceBudget(bounty)
+	database.CreateWorkspaceBudget(bounty)
 
-	workspace = db.TestDB.GetWorkspaceByUuid(workspace.Uuid)
+	workspace = database.GetWorkspaceByUuid(workspace.Uuid)
 
 	t.Run("Should test that a 401 is returned when trying to view an workspace's budget without a token", func(t *testing.T) {
 		workspaceUUID := workspace.Uuid
@@ -502,9 +501,8 @@ func TestGetWorkspaceBudget(t *testing.T) {
 }
 
 func TestGetWorkspaceBudgetHistory(t *testing.T) {
-	teardownSuite := SetupSuite(t)
-	defer teardownSuite(t)
-	oHandler := NewWorkspaceHandler(db.TestDB)
+	database := dbtest.New(t)
+	oHandler := NewWorkspaceHandler(database)
 
 	workspace := db.Workspace{
 		Uuid:        uuid.New().String(),
@@ -514,7 +512,7 @@ func TestGetWorkspaceBudgetHistory(t *testing.T) {
 		Website:     "https://www.historywebsite.com",
 		Description: "Workspace History Description",
 	}
-	db.TestDB.CreateOrEditWorkspace(workspace)
+	database.CreateOrEditWorkspace(workspace)
 	ctx := context.WithValue(context.Background(), auth.ContextKey, workspace.OwnerPubKey)
 
 	budgetAmount := uint(5000)
@@ -522,7 +520,7 @@ func TestGetWorkspaceBudgetHistory(t *testing.T) {
 		WorkspaceUuid: workspace.Uuid,
 		TotalBudget:   budgetAmount,
 	}
-	db.TestDB.CreateWorkspaceBudget(bounty)
+	database.CreateWorkspaceBudget(bounty)
 
 	now := time.Now()
 	paymentHistory := db.NewPaymentHistory{
@@ -536,9 +534,9 @@ func TestGetWorkspaceBudgetHistory(t *testing.T) {
 		ReceiverPubKey: "",
 		BountyId:       0,
 	}
-	db.TestDB.AddPaymentHistory(paymentHistory)
+	database.AddPaymentHistory(paymentHistory)
 
-	workspace = db.TestDB.GetWorkspaceByUuid(workspace.Uuid)
+	workspace = database.GetWorkspaceByUuid(workspace.Uuid)
 
 	t.Run("Should test that a 401 is returned when trying to view an workspace's budget history without a token", func(t *testing.T) {
 		workspaceUUID := workspace.Uuid
@@ -587,16 +585,15 @@ func TestGetWorkspaceBudgetHistory(t *testing.T) {
 			t.Fatal(err)
 		}
 
-		expectedBudgetHistory := db.TestDB.GetWorkspaceBudgetHistory(workspaceUUID)
+		expectedBudgetHistory := database.GetWorkspaceBudgetHistory(workspaceUUID)
 
 		assert.Equal(t, expectedBudgetHistory, responseBudgetHistory)
 	})
 }
 
 func TestGetWorkspaceBountiesCount(t *testing.T) {
-	teardownSuite := SetupSuite(t)
-	defer teardownSuite(t)
-	oHandler := NewWorkspaceHandler(db.TestDB)
+	database := dbtest.New(t)
+	oHandler := NewWorkspaceHandler(database)
 
 	t.Run("should return the count of workspace bounties", func(t *testing.T) {
 
@@ -613,7 +610,7 @@ func TestGetWorkspaceBountiesCount(t *testing.T) {
 			Website:     "https://www.bountieswebsite.com",
 			Description: "Workspace Bounties Description",
 		}
-		db.TestDB.CreateOrEditWorkspace(workspace)
+		database.CreateOrEditWorkspace(workspace)
 		bounty := db.NewBounty{
 			Type:          "coding",
 			Title:         "existing bounty",
@@ -623,7 +620,7 @@ func TestGetWorkspaceBountiesCount(t *testing.T) {
 			Price:         2000,
 		}
 
-		db.TestDB.CreateOrEditBounty(bounty)
+		database.CreateOrEditBounty(bounty)
 
 		rctx := chi.NewRouteContext()
 		rctx.URLParams.Add("uuid", workspace.Uuid)
@@ -633,10 +630,10 @@ func TestGetWorkspaceBountiesCount(t *testing.T) {
 			t.Fatal(err)
 		}
 
-		fetchedWorkspace := db.TestDB.GetWorkspaceByUuid(workspace.Uuid)
+		fetchedWorkspace := database.GetWorkspaceByUuid(workspace.Uuid)
 		workspace.ID = fetchedWorkspace.ID
 
-		fetchedBounty := db.TestDB.GetWorkspaceBounties(req, bounty.WorkspaceUuid)
+		fetchedBounty := database.GetWorkspaceBounties(req, bounty.WorkspaceUuid)
 		bounty.ID = fetchedBounty[0].ID
 
 		handler.ServeHTTP(rr, req)
@@ -647,3 +644,149 @@ func TestGetWorkspaceBountiesCount(t *testing.T) {
 		assert.Equal(t, bounty, fetchedBounty[0])
 	})
 }
+
+func TestSetWorkspaceAutoPay(t *testing.T) {
+	workspace := db.Workspace{Uuid: "workspace-uuid", Name: "Hive", OwnerPubKey: "owner-pubkey"}
+	newRequest := func(pubkey string, body string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("workspace_uuid", workspace.Uuid)
+		ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+		ctx = context.WithValue(ctx, auth.ContextKey, pubkey)
+		req, _ := http.NewRequestWithContext(ctx, http.MethodPut, "/workspaces/workspace-uuid/autopay", strings.NewReader(body))
+		return req
+	}
+
+	t.Run("should only let the owner set auto-pay", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		oHandler := NewWorkspaceHandler(mockDb)
+
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(workspace).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(oHandler.SetWorkspaceAutoPay).ServeHTTP(rr, newRequest("member-pubkey", `{"auto_pay": true}`))
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		mockDb.AssertNotCalled(t, "SetWorkspaceAutoPay", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should set auto-pay with its cap", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		oHandler := NewWorkspaceHandler(mockDb)
+
+		updated := workspace
+		updated.AutoPay = true
+		updated.AutoPayCap = 50000
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(workspace).Once()
+		mockDb.On("SetWorkspaceAutoPay", workspace.Uuid, true, uint(50000)).Return(updated, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(oHandler.SetWorkspaceAutoPay).ServeHTTP(rr, newRequest("owner-pubkey", `{"auto_pay": true, "auto_pay_cap": 50000}`))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		result := db.Workspace{}
+		json.Unmarshal(rr.Body.Bytes(), &result)
+		assert.True(t, result.AutoPay)
+		assert.Equal(t, uint(50000), result.AutoPayCap)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should return 404 for an unknown workspace", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		oHandler := NewWorkspaceHandler(mockDb)
+
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(db.Workspace{}).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(oHandler.SetWorkspaceAutoPay).ServeHTTP(rr, newRequest("owner-pubkey", `{"auto_pay": true}`))
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
+
+func TestSetWorkspaceDefinitionOfDone(t *testing.T) {
+	workspace := db.Workspace{Uuid: "workspace-uuid", Name: "Hive", OwnerPubKey: "owner-pubkey"}
+	newRequest := func(pubkey string, body string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("workspace_uuid", workspace.Uuid)
+		ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+		ctx = context.WithValue(ctx, auth.ContextKey, pubkey)
+		req, _ := http.NewRequestWithContext(ctx, http.MethodPut, "/workspaces/workspace-uuid/definition_of_done", strings.NewReader(body))
+		return req
+	}
+
+	t.Run("should only let the owner set the definition of done", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		oHandler := NewWorkspaceHandler(mockDb)
+
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(workspace).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(oHandler.SetWorkspaceDefinitionOfDone).ServeHTTP(rr, newRequest("member-pubkey", `{"done_requires_checklist": true}`))
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		mockDb.AssertNotCalled(t, "SetWorkspaceDefinitionOfDone", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should set the definition of done", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		oHandler := NewWorkspaceHandler(mockDb)
+
+		updated := workspace
+		updated.DoneRequiresChecklist = true
+		updated.DoneRequiresPullRequest = true
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(workspace).Once()
+		mockDb.On("SetWorkspaceDefinitionOfDone", workspace.Uuid, db.DefinitionOfDone{RequiresChecklist: true, RequiresPullRequest: true}).Return(updated, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(oHandler.SetWorkspaceDefinitionOfDone).ServeHTTP(rr, newRequest("owner-pubkey", `{"done_requires_checklist": true, "done_requires_pull_request": true}`))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		result := db.Workspace{}
+		json.Unmarshal(rr.Body.Bytes(), &result)
+		assert.True(t, result.DoneRequiresChecklist)
+		assert.True(t, result.DoneRequiresPullRequest)
+		mockDb.AssertExpectations(t)
+	})
+}
+
+func TestReconcileInvoices(t *testing.T) {
+	unpaid := db.NewInvoiceList{PaymentRequest: "lnbc-unpaid", Type: "BUDGET", WorkspaceUuid: "workspace-uuid"}
+	credited := db.NewInvoiceList{PaymentRequest: "lnbc-credited", Type: "BUDGET", Status: true, WorkspaceUuid: "workspace-uuid"}
+	// an invoice that expired long ago
+	expired := db.NewInvoiceList{PaymentRequest: "lnbc15u1p3xnhl2pp5jptserfk3zk4qy42tlucycrfwxhydvlemu9pqr93tuzlv9cc7g3sdqsvfhkcap3xyhx7un8cqzpgxqzjcsp5f8c52y2stc300gl6s4xswtjpc37hrnnr3c9wvtgjfuvqmpm35evq9qyyssqy4lgd8tj637qcjp05rdpxxykjenthxftej7a2zzmwrmrl70fyj9hvj0rewhzj7jfyuwkwcg9g2jpwtk3wkjtwnkdks84hsnu8xps5vsq4gj5hs", Type: "BUDGET", WorkspaceUuid: "workspace-uuid"}
+
+	t.Run("should credit the settled budget invoices and delete the expired ones", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		oHandler := NewWorkspaceHandler(mockDb)
+		oHandler.getLightningInvoice = func(paymentRequest string) (db.InvoiceResult, db.InvoiceError) {
+			settled := paymentRequest != expired.PaymentRequest
+			return db.InvoiceResult{Success: true, Response: db.InvoiceCheckResponse{Settled: settled}}, db.InvoiceError{}
+		}
+
+		mockDb.On("GetWorkspaceInvoices", "workspace-uuid").Return([]db.NewInvoiceList{unpaid, credited, expired}).Once()
+		mockDb.On("ProcessUpdateBudget", unpaid).Return(nil).Once()
+		mockDb.On("DeleteInvoice", expired.PaymentRequest).Return(expired).Once()
+
+		result, invoiceErr := oHandler.ReconcileInvoices("workspace-uuid")
+
+		assert.Equal(t, "", invoiceErr.Error)
+		assert.Equal(t, InvoiceReconciliation{WorkspaceUuid: "workspace-uuid", Checked: 3, Settled: 1, Expired: 1}, result)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should stop at an invoice the relay can't report on", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		oHandler := NewWorkspaceHandler(mockDb)
+		oHandler.getLightningInvoice = func(paymentRequest string) (db.InvoiceResult, db.InvoiceError) {
+			return db.InvoiceResult{}, db.InvoiceError{Error: "relay down"}
+		}
+
+		mockDb.On("GetWorkspaceInvoices", "workspace-uuid").Return([]db.NewInvoiceList{unpaid}).Once()
+
+		result, invoiceErr := oHandler.ReconcileInvoices("workspace-uuid")
+
+		assert.Equal(t, "relay down", invoiceErr.Error)
+		assert.Equal(t, 0, result.Checked)
+		mockDb.AssertNotCalled(t, "ProcessUpdateBudget", mock.Anything)
+	})
+}
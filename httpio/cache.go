@@ -0,0 +1,79 @@
+package httpio
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// SetLastModified announces when the resource of a response last changed,
+// Cacheable answers If-Modified-Since with it. A zero time is left out
+func SetLastModified(w http.ResponseWriter, modified time.Time) {
+	if modified.IsZero() || modified.Unix() <= 0 {
+		return
+	}
+	w.Header().Set("Last-Modified", modified.UTC().Format(http.TimeFormat))
+}
+
+// Cacheable lets browsers and CDNs keep the successful GET responses under
+// it for maxAge. The responses get an ETag of their body, and a Last-Modified
+// when the handler sets one. A request whose If-None-Match or
+// If-Modified-Since still matches gets a 304 without the body
+func Cacheable(maxAge time.Duration) func(http.Handler) http.Handler {
+	cacheControl := fmt.Sprintf("public, max-age=%d", int(maxAge.Seconds()))
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodGet {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			bw := &bufferedWriter{ResponseWriter: w}
+			next.ServeHTTP(bw, r)
+			if bw.status == 0 {
+				bw.status = http.StatusOK
+			}
+			if bw.status != http.StatusOK {
+				w.WriteHeader(bw.status)
+				w.Write(bw.buf.Bytes())
+				return
+			}
+
+			sum := sha1.Sum(bw.buf.Bytes())
+			etag := `"` + hex.EncodeToString(sum[:]) + `"`
+			w.Header().Set("ETag", etag)
+			w.Header().Set("Cache-Control", cacheControl)
+
+			if notModified(r, etag, w.Header().Get("Last-Modified")) {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			w.Write(bw.buf.Bytes())
+		})
+	}
+}
+
+// notModified follows RFC 7232, If-Modified-Since only counts without
+// If-None-Match
+func notModified(r *http.Request, etag string, lastModified string) bool {
+	if match := r.Header.Get("If-None-Match"); match != "" {
+		for _, candidate := range strings.Split(match, ",") {
+			candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+			if candidate == etag || candidate == "*" {
+				return true
+			}
+		}
+		return false
+	}
+
+	since, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+	if err != nil || lastModified == "" {
+		return false
+	}
+	modified, err := http.ParseTime(lastModified)
+	return err == nil && !modified.After(since)
+}
@@ -0,0 +1,68 @@
+package httpio
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCacheable(t *testing.T) {
+	modified := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	feed := Cacheable(10 * time.Minute)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		SetLastModified(w, modified)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"title":"feed"}`))
+	}))
+	serve := func(h http.Handler, headers map[string]string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/feed", nil)
+		for key, value := range headers {
+			req.Header.Set(key, value)
+		}
+		h.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("should add the cache headers", func(t *testing.T) {
+		rr := serve(feed, nil)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "public, max-age=600", rr.Header().Get("Cache-Control"))
+		assert.NotEmpty(t, rr.Header().Get("ETag"))
+		assert.Equal(t, "Fri, 01 Mar 2024 12:00:00 GMT", rr.Header().Get("Last-Modified"))
+		assert.Equal(t, `{"title":"feed"}`, rr.Body.String())
+	})
+
+	t.Run("should answer a matching etag with a 304", func(t *testing.T) {
+		etag := serve(feed, nil).Header().Get("ETag")
+
+		rr := serve(feed, map[string]string{"If-None-Match": `"other", ` + etag})
+		assert.Equal(t, http.StatusNotModified, rr.Code)
+		assert.Empty(t, rr.Body.String())
+
+		rr = serve(feed, map[string]string{"If-None-Match": `"other"`, "If-Modified-Since": modified.Format(http.TimeFormat)})
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("should answer an unchanged resource with a 304", func(t *testing.T) {
+		rr := serve(feed, map[string]string{"If-Modified-Since": modified.Add(time.Hour).Format(http.TimeFormat)})
+		assert.Equal(t, http.StatusNotModified, rr.Code)
+
+		rr = serve(feed, map[string]string{"If-Modified-Since": modified.Add(-time.Hour).Format(http.TimeFormat)})
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("should not cache errors", func(t *testing.T) {
+		missing := Cacheable(time.Minute)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			WriteError(w, r, http.StatusNotFound, "Feed not found")
+		}))
+
+		rr := serve(missing, nil)
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		assert.Empty(t, rr.Header().Get("Cache-Control"))
+		assert.Empty(t, rr.Header().Get("ETag"))
+	})
+}
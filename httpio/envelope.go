@@ -0,0 +1,109 @@
+package httpio
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// envelopeWriter holds back error bodies so they can be rewritten into the
+// error envelope once the handler is done
+type envelopeWriter struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+	buf         bytes.Buffer
+}
+
+func (ew *envelopeWriter) WriteHeader(status int) {
+	if ew.wroteHeader {
+		return
+	}
+	ew.wroteHeader = true
+	ew.status = status
+	if status < http.StatusBadRequest {
+		ew.ResponseWriter.WriteHeader(status)
+	}
+}
+
+func (ew *envelopeWriter) Write(b []byte) (int, error) {
+	if !ew.wroteHeader {
+		ew.WriteHeader(http.StatusOK)
+	}
+	if ew.status >= http.StatusBadRequest {
+		return ew.buf.Write(b)
+	}
+	return ew.ResponseWriter.Write(b)
+}
+
+func (ew *envelopeWriter) Flush() {
+	if ew.status >= http.StatusBadRequest {
+		return
+	}
+	if f, ok := ew.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (ew *envelopeWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	if h, ok := ew.ResponseWriter.(http.Hijacker); ok {
+		return h.Hijack()
+	}
+	return nil, nil, errors.New("hijack not supported")
+}
+
+// EnvelopeErrors makes every error response use the error envelope. Handlers
+// that already write it through WriteError are passed through untouched,
+// plain text and json string bodies become the envelope message
+func EnvelopeErrors(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ew := &envelopeWriter{ResponseWriter: w}
+		next.ServeHTTP(ew, r)
+
+		if ew.status < http.StatusBadRequest {
+			return
+		}
+
+		body := ew.buf.Bytes()
+		if isEnvelope(body) {
+			w.WriteHeader(ew.status)
+			w.Write(body)
+			return
+		}
+
+		WriteError(w, r, ew.status, messageFromBody(body))
+	})
+}
+
+func isEnvelope(body []byte) bool {
+	res := map[string]interface{}{}
+	if err := json.Unmarshal(body, &res); err != nil {
+		return false
+	}
+	_, hasCode := res["code"].(string)
+	_, hasMessage := res["message"].(string)
+	return hasCode && hasMessage
+}
+
+func messageFromBody(body []byte) string {
+	var message string
+	if err := json.Unmarshal(body, &message); err == nil {
+		return message
+	}
+
+	res := map[string]interface{}{}
+	if err := json.Unmarshal(body, &res); err == nil {
+		for _, key := range []string{"error", "message", "msg"} {
+			if value, ok := res[key].(string); ok {
+				return value
+			}
+		}
+		return ""
+	}
+
+	return strings.TrimSpace(string(body))
+}
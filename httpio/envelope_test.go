@@ -0,0 +1,76 @@
+package httpio
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func serveEnveloped(handler http.HandlerFunc) *httptest.ResponseRecorder {
+	rr := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/v2/tribes", nil)
+	EnvelopeErrors(handler).ServeHTTP(rr, req)
+	return rr
+}
+
+func TestEnvelopeErrors(t *testing.T) {
+	t.Run("should pass successful responses through", func(t *testing.T) {
+		rr := serveEnveloped(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+			json.NewEncoder(w).Encode([]string{"tribe"})
+		})
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, `["tribe"]`, rr.Body.String())
+	})
+
+	t.Run("should wrap plain text errors", func(t *testing.T) {
+		rr := serveEnveloped(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		})
+
+		var res ErrorResponse
+		err := json.Unmarshal(rr.Body.Bytes(), &res)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+		assert.Equal(t, CodeUnauthorized, res.Code)
+		assert.Equal(t, "Unauthorized", res.Message)
+	})
+
+	t.Run("should use json string and error field bodies as the message", func(t *testing.T) {
+		rr := serveEnveloped(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode("Invalid workspace uuid")
+		})
+
+		var res ErrorResponse
+		json.Unmarshal(rr.Body.Bytes(), &res)
+		assert.Equal(t, CodeBadRequest, res.Code)
+		assert.Equal(t, "Invalid workspace uuid", res.Message)
+
+		rr = serveEnveloped(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusPaymentRequired)
+			json.NewEncoder(w).Encode(map[string]string{"error": "Insufficient funds"})
+		})
+
+		json.Unmarshal(rr.Body.Bytes(), &res)
+		assert.Equal(t, http.StatusPaymentRequired, rr.Code)
+		assert.Equal(t, "Insufficient funds", res.Message)
+	})
+
+	t.Run("should leave responses that already use the envelope untouched", func(t *testing.T) {
+		rr := serveEnveloped(func(w http.ResponseWriter, r *http.Request) {
+			WriteErrorCode(w, r, http.StatusConflict, "bounty_assigned", "Bounty already assigned", nil)
+		})
+
+		var res ErrorResponse
+		json.Unmarshal(rr.Body.Bytes(), &res)
+		assert.Equal(t, http.StatusConflict, rr.Code)
+		assert.Equal(t, "bounty_assigned", res.Code)
+		assert.Equal(t, "Bounty already assigned", res.Message)
+	})
+}
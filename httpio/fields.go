@@ -0,0 +1,132 @@
+package httpio
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/stakwork/sphinx-tribes/utils"
+)
+
+// fieldSet is a tree of the selected fields, a nil branch keeps the whole
+// value of its field
+type fieldSet map[string]fieldSet
+
+func parseFields(fields string) fieldSet {
+	set := fieldSet{}
+	for _, field := range strings.Split(fields, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		branch := set
+		parts := strings.Split(field, ".")
+		for i, part := range parts {
+			if i == len(parts)-1 {
+				branch[part] = nil
+				break
+			}
+			next, ok := branch[part]
+			if ok && next == nil {
+				break
+			}
+			if !ok {
+				next = fieldSet{}
+				branch[part] = next
+			}
+			branch = next
+		}
+	}
+	return set
+}
+
+func selectFields(value interface{}, set fieldSet) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		out := map[string]interface{}{}
+		for name, branch := range set {
+			field, ok := v[name]
+			if !ok {
+				continue
+			}
+			if branch == nil {
+				out[name] = field
+			} else {
+				out[name] = selectFields(field, branch)
+			}
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, item := range v {
+			out[i] = selectFields(item, set)
+		}
+		return out
+	}
+	return value
+}
+
+// bufferedWriter holds back the whole response so it can be rewritten once
+// the handler is done
+type bufferedWriter struct {
+	http.ResponseWriter
+	status int
+	buf    bytes.Buffer
+}
+
+func (bw *bufferedWriter) WriteHeader(status int) {
+	if bw.status == 0 {
+		bw.status = status
+	}
+}
+
+func (bw *bufferedWriter) Write(b []byte) (int, error) {
+	if bw.status == 0 {
+		bw.status = http.StatusOK
+	}
+	return bw.buf.Write(b)
+}
+
+// SelectFields trims the JSON body of GET responses to the fields listed in
+// ?fields=, comma separated. A dotted field picks inside an object
+// (owner.img), arrays are trimmed item by item and the list envelope keeps
+// its paging keys. Unknown fields are left out, error responses and bodies
+// that aren't JSON pass through untouched
+func SelectFields(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fields := r.URL.Query().Get("fields")
+		if fields == "" || r.Method != http.MethodGet {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		bw := &bufferedWriter{ResponseWriter: w}
+		next.ServeHTTP(bw, r)
+		if bw.status == 0 {
+			bw.status = http.StatusOK
+		}
+
+		body := bw.buf.Bytes()
+		var value interface{}
+		decoder := json.NewDecoder(bytes.NewReader(body))
+		decoder.UseNumber()
+		if bw.status >= http.StatusBadRequest || decoder.Decode(&value) != nil {
+			w.WriteHeader(bw.status)
+			w.Write(body)
+			return
+		}
+
+		set := parseFields(fields)
+		if envelope, ok := value.(map[string]interface{}); ok && utils.WantsListEnvelope(r) {
+			envelope["data"] = selectFields(envelope["data"], set)
+			value = envelope
+		} else {
+			value = selectFields(value, set)
+		}
+
+		w.Header().Del("Content-Length")
+		w.WriteHeader(bw.status)
+		json.NewEncoder(w).Encode(value)
+	})
+}
@@ -0,0 +1,69 @@
+package httpio
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stakwork/sphinx-tribes/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func serveFields(target string, handler http.HandlerFunc, middlewares ...func(http.Handler) http.Handler) *httptest.ResponseRecorder {
+	var h http.Handler = SelectFields(handler)
+	for _, middleware := range middlewares {
+		h = middleware(h)
+	}
+	rr := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, target, nil)
+	h.ServeHTTP(rr, req)
+	return rr
+}
+
+func TestSelectFields(t *testing.T) {
+	people := func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode([]map[string]interface{}{
+			{"id": 1, "owner_alias": "alice", "img": "alice.png", "extras": map[string]interface{}{"github": "alice", "twitter": "al"}, "price_to_meet": 12345678901234},
+		})
+	}
+
+	t.Run("should pass the response through without fields", func(t *testing.T) {
+		rr := serveFields("/people", people)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, `[{"id":1,"owner_alias":"alice","img":"alice.png","extras":{"github":"alice","twitter":"al"},"price_to_meet":12345678901234}]`, rr.Body.String())
+	})
+
+	t.Run("should keep the selected fields of every item", func(t *testing.T) {
+		rr := serveFields("/people?fields=id,img,extras.github,price_to_meet,unknown", people)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, `[{"id":1,"img":"alice.png","extras":{"github":"alice"},"price_to_meet":12345678901234}]`, rr.Body.String())
+	})
+
+	t.Run("should keep a whole object selected along with one of its fields", func(t *testing.T) {
+		rr := serveFields("/people?fields=extras.github,extras", people)
+
+		assert.JSONEq(t, `[{"extras":{"github":"alice","twitter":"al"}}]`, rr.Body.String())
+	})
+
+	t.Run("should trim the data of the list envelope", func(t *testing.T) {
+		rr := serveFields("/people?fields=img", func(w http.ResponseWriter, r *http.Request) {
+			json.NewEncoder(w).Encode(utils.ListResponse{Data: []map[string]interface{}{{"id": 1, "img": "alice.png"}}, Total: 1, Limit: 10})
+		}, utils.ListEnvelope)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, `{"data":[{"img":"alice.png"}],"total":1,"next_cursor":"","limit":10}`, rr.Body.String())
+	})
+
+	t.Run("should pass errors through", func(t *testing.T) {
+		rr := serveFields("/people?fields=img", func(w http.ResponseWriter, r *http.Request) {
+			WriteError(w, r, http.StatusNotFound, "Person not found")
+		})
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		assert.Contains(t, rr.Body.String(), "Person not found")
+	})
+}
@@ -0,0 +1,110 @@
+package httpio
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/middleware"
+)
+
+// machine readable error codes returned in the error envelope
+const (
+	CodeBadRequest          = "bad_request"
+	CodeUnauthorized        = "unauthorized"
+	CodeForbidden           = "forbidden"
+	CodeNotFound            = "not_found"
+	CodeConflict            = "conflict"
+	CodeRequestTimeout      = "request_timeout"
+	CodePayloadTooLarge     = "payload_too_large"
+	CodeNotAcceptable       = "invalid_body"
+	CodeValidation          = "validation_failed"
+	CodePaymentRequired     = "payment_required"
+	CodeTooManyRequests     = "rate_limited"
+	CodeInternal            = "internal_error"
+	CodeBadGateway          = "upstream_error"
+	CodeGatewayTimeout      = "upstream_timeout"
+	CodeMaintenance         = "maintenance"
+	CodeServiceUnavailable  = "service_unavailable"
+	CodeUnprocessableEntity = "unprocessable_entity"
+)
+
+type ErrorResponse struct {
+	Code      string      `json:"code"`
+	Message   string      `json:"message"`
+	Details   interface{} `json:"details,omitempty"`
+	RequestID string      `json:"request_id,omitempty"`
+}
+
+// CodeForStatus returns the default error code for a http status
+func CodeForStatus(status int) string {
+	switch status {
+	case http.StatusBadRequest:
+		return CodeBadRequest
+	case http.StatusUnauthorized:
+		return CodeUnauthorized
+	case http.StatusForbidden:
+		return CodeForbidden
+	case http.StatusNotFound:
+		return CodeNotFound
+	case http.StatusConflict:
+		return CodeConflict
+	case http.StatusRequestTimeout:
+		return CodeRequestTimeout
+	case http.StatusRequestEntityTooLarge:
+		return CodePayloadTooLarge
+	case http.StatusNotAcceptable:
+		return CodeNotAcceptable
+	case http.StatusPaymentRequired:
+		return CodePaymentRequired
+	case http.StatusUnprocessableEntity:
+		return CodeUnprocessableEntity
+	case http.StatusTooManyRequests:
+		return CodeTooManyRequests
+	case http.StatusBadGateway:
+		return CodeBadGateway
+	case http.StatusServiceUnavailable:
+		return CodeServiceUnavailable
+	case http.StatusGatewayTimeout:
+		return CodeGatewayTimeout
+	}
+	if status >= 500 {
+		return CodeInternal
+	}
+	return CodeBadRequest
+}
+
+// WriteJSON writes v as the json body with the given status
+func WriteJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// WriteError writes the error envelope using the default code for the status.
+// An empty message falls back to the http status text
+func WriteError(w http.ResponseWriter, r *http.Request, status int, message string) {
+	WriteErrorCode(w, r, status, CodeForStatus(status), message, nil)
+}
+
+// WriteErrorDetails writes the error envelope with extra details, e.g validation errors
+func WriteErrorDetails(w http.ResponseWriter, r *http.Request, status int, message string, details interface{}) {
+	WriteErrorCode(w, r, status, CodeForStatus(status), message, details)
+}
+
+// WriteErrorCode writes the error envelope with an explicit error code
+func WriteErrorCode(w http.ResponseWriter, r *http.Request, status int, code string, message string, details interface{}) {
+	if message == "" {
+		message = http.StatusText(status)
+	}
+
+	res := ErrorResponse{
+		Code:    code,
+		Message: message,
+		Details: details,
+	}
+	if r != nil {
+		res.RequestID = middleware.GetReqID(r.Context())
+	}
+
+	WriteJSON(w, status, res)
+}
@@ -0,0 +1,58 @@
+package httpio
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteError(t *testing.T) {
+	t.Run("should write the envelope with the default code and request id", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		ctx := context.WithValue(context.Background(), middleware.RequestIDKey, "req-1")
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "/", nil)
+
+		WriteError(rr, req, http.StatusNotFound, "Bounty not found")
+
+		var res ErrorResponse
+		err := json.Unmarshal(rr.Body.Bytes(), &res)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+		assert.Equal(t, CodeNotFound, res.Code)
+		assert.Equal(t, "Bounty not found", res.Message)
+		assert.Equal(t, "req-1", res.RequestID)
+	})
+
+	t.Run("should fall back to the status text for an empty message", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/", nil)
+
+		WriteError(rr, req, http.StatusUnauthorized, "")
+
+		var res ErrorResponse
+		err := json.Unmarshal(rr.Body.Bytes(), &res)
+		assert.NoError(t, err)
+		assert.Equal(t, CodeUnauthorized, res.Code)
+		assert.Equal(t, "Unauthorized", res.Message)
+		assert.Empty(t, res.RequestID)
+	})
+
+	t.Run("should include details and explicit codes", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/", nil)
+
+		WriteErrorCode(rr, req, http.StatusBadRequest, CodeValidation, "Invalid bounty", map[string]string{"title": "required"})
+
+		var res map[string]interface{}
+		err := json.Unmarshal(rr.Body.Bytes(), &res)
+		assert.NoError(t, err)
+		assert.Equal(t, CodeValidation, res["code"])
+		assert.Equal(t, "required", res["details"].(map[string]interface{})["title"])
+	})
+}
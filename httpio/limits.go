@@ -0,0 +1,284 @@
+package httpio
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MaxBodySize rejects request bodies over limit bytes with a 413. Bodies
+// without a Content-Length are read up front so the limit is enforced
+// before the handler runs
+func MaxBodySize(limit int64) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.ContentLength > limit {
+				writeTooLarge(w, r, limit)
+				return
+			}
+
+			if r.ContentLength < 0 {
+				body, err := io.ReadAll(io.LimitReader(r.Body, limit+1))
+				r.Body.Close()
+				if err != nil {
+					WriteError(w, r, http.StatusBadRequest, "Could not read the request body")
+					return
+				}
+				if int64(len(body)) > limit {
+					writeTooLarge(w, r, limit)
+					return
+				}
+				r.Body = io.NopCloser(bytes.NewReader(body))
+				r.ContentLength = int64(len(body))
+			}
+
+			r.Body = http.MaxBytesReader(w, r.Body, limit)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func writeTooLarge(w http.ResponseWriter, r *http.Request, limit int64) {
+	w.Header().Set("Connection", "close")
+	WriteErrorDetails(w, r, http.StatusRequestEntityTooLarge,
+		fmt.Sprintf("The request body is larger than %d bytes", limit),
+		map[string]int64{"max_bytes": limit})
+}
+
+// timeoutWriter keeps the handler of a timed out request from writing
+// after its 408. The handler gets its own header map, copied to the
+// response when it starts writing, so the 408 can be written while the
+// handler is still running
+type timeoutWriter struct {
+	w      http.ResponseWriter
+	header http.Header
+
+	mu          sync.Mutex
+	wroteHeader bool
+	timedOut    bool
+	untimed     bool
+}
+
+func (tw *timeoutWriter) Header() http.Header {
+	return tw.header
+}
+
+func (tw *timeoutWriter) WriteHeader(status int) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	tw.writeHeader(status)
+}
+
+func (tw *timeoutWriter) writeHeader(status int) {
+	if tw.timedOut || tw.wroteHeader {
+		return
+	}
+	tw.wroteHeader = true
+	header := tw.w.Header()
+	for key, values := range tw.header {
+		header[key] = values
+	}
+	tw.w.WriteHeader(status)
+}
+
+func (tw *timeoutWriter) Write(b []byte) (int, error) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	if tw.timedOut {
+		return 0, http.ErrHandlerTimeout
+	}
+	tw.writeHeader(http.StatusOK)
+	return tw.w.Write(b)
+}
+
+func (tw *timeoutWriter) Flush() {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	if tw.timedOut {
+		return
+	}
+	tw.writeHeader(http.StatusOK)
+	if f, ok := tw.w.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (tw *timeoutWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	if tw.timedOut {
+		return nil, nil, http.ErrHandlerTimeout
+	}
+	if h, ok := tw.w.(http.Hijacker); ok {
+		tw.wroteHeader = true
+		return h.Hijack()
+	}
+	return nil, nil, errors.New("hijack not supported")
+}
+
+type timeoutKey struct{}
+
+// Timeout answers a 408 once a request runs past timeout without having
+// started its response, like http.TimeoutHandler, and cancels the request
+// context. Only the database queries and outbound calls made with the
+// request context stop with it, see forRequest in the db package, other
+// queries of the handler run to the end and its late response is dropped.
+// A handler that started its response before the deadline is waited for.
+// Routes that must not be cut off use Untimed
+func Timeout(timeout time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, cancel := context.WithTimeout(r.Context(), timeout)
+			defer cancel()
+
+			tw := &timeoutWriter{w: w, header: w.Header().Clone()}
+			done := make(chan struct{})
+			panicked := make(chan interface{}, 1)
+			go func() {
+				defer func() {
+					if p := recover(); p != nil {
+						panicked <- p
+						return
+					}
+					close(done)
+				}()
+				next.ServeHTTP(tw, r.WithContext(context.WithValue(ctx, timeoutKey{}, tw)))
+			}()
+
+			wait := func() {
+				select {
+				case <-done:
+				case p := <-panicked:
+					panic(p)
+				}
+			}
+			select {
+			case <-done:
+			case p := <-panicked:
+				panic(p)
+			case <-ctx.Done():
+			}
+
+			tw.mu.Lock()
+			if tw.untimed || tw.wroteHeader {
+				tw.mu.Unlock()
+				wait()
+				return
+			}
+			// a handler that returned on its deadline without answering
+			// gets the 408 too
+			tw.timedOut = true
+			tw.mu.Unlock()
+			if ctx.Err() == context.DeadlineExceeded {
+				WriteError(w, r, http.StatusRequestTimeout, "The request took too long to process")
+			}
+		})
+	}
+}
+
+// Untimed takes a route out of the Timeout of its group. Its handler runs
+// to the end with a context that keeps the request values but is not
+// cancelled by the deadline or by the client going away. It is meant for
+// the routes that send payments, which must not stop between the call to
+// the node and recording its outcome, their calls are bounded by the
+// timeouts of the upstream client
+func Untimed(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if tw, ok := r.Context().Value(timeoutKey{}).(*timeoutWriter); ok {
+			tw.mu.Lock()
+			tw.untimed = true
+			tw.mu.Unlock()
+			r = r.WithContext(detachedContext{Context: r.Context()})
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// detachedContext has the values of its parent without its deadline and
+// cancellation
+type detachedContext struct {
+	context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool) {
+	return time.Time{}, false
+}
+
+func (detachedContext) Done() <-chan struct{} {
+	return nil
+}
+
+func (detachedContext) Err() error {
+	return nil
+}
+
+// RateLimit answers 429 to a client that made more than limit requests in
+// the current window, with a Retry-After until the next window starts.
+// Clients are told apart by their address, see ClientAddress. Each instance
+// keeps its own counts
+func RateLimit(limit int, window time.Duration) func(http.Handler) http.Handler {
+	limiter := &rateLimiter{limit: limit, window: window, counts: map[string]int{}}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if retryAfter, ok := limiter.allow(ClientAddress(r), time.Now()); !ok {
+				w.Header().Set("Retry-After", fmt.Sprint(int(math.Ceil(retryAfter.Seconds()))))
+				WriteErrorDetails(w, r, http.StatusTooManyRequests, "Too many requests, try again later",
+					map[string]int{"limit": limit, "window_seconds": int(window.Seconds())})
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// rateLimiter counts the requests of each client in fixed windows
+type rateLimiter struct {
+	limit  int
+	window time.Duration
+
+	mu     sync.Mutex
+	start  time.Time
+	counts map[string]int
+}
+
+// allow counts a request of client at now. It returns false with the time
+// until the next window when the client is over the limit
+func (l *rateLimiter) allow(client string, now time.Time) (time.Duration, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if now.Sub(l.start) >= l.window {
+		l.start = now.Truncate(l.window)
+		l.counts = map[string]int{}
+	}
+	l.counts[client]++
+	if l.counts[client] > l.limit {
+		return l.start.Add(l.window).Sub(now), false
+	}
+	return 0, true
+}
+
+// ClientAddress is the address the proxy in front of the instance appended
+// to X-Forwarded-For, the earlier entries are set by the client and can't
+// be trusted. Without the header it is the remote address
+func ClientAddress(r *http.Request) string {
+	if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+		addresses := strings.Split(forwarded, ",")
+		if address := strings.TrimSpace(addresses[len(addresses)-1]); address != "" {
+			return address
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
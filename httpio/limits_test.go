@@ -0,0 +1,166 @@
+package httpio
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaxBodySize(t *testing.T) {
+	echo := MaxBodySize(10)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		w.WriteHeader(http.StatusOK)
+		w.Write(body)
+	}))
+
+	t.Run("should pass bodies within the limit", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		echo.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/tribes", strings.NewReader("small")))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "small", rr.Body.String())
+	})
+
+	t.Run("should reject a declared length over the limit", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		echo.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/tribes", strings.NewReader("a body that is too large")))
+
+		var res ErrorResponse
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &res))
+		assert.Equal(t, http.StatusRequestEntityTooLarge, rr.Code)
+		assert.Equal(t, CodePayloadTooLarge, res.Code)
+	})
+
+	t.Run("should reject a streamed body over the limit", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/tribes", io.NopCloser(strings.NewReader("a body that is too large")))
+		req.ContentLength = -1
+		echo.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, rr.Code)
+	})
+
+	t.Run("should pass a streamed body within the limit", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/tribes", io.NopCloser(strings.NewReader("small")))
+		req.ContentLength = -1
+		echo.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "small", rr.Body.String())
+	})
+}
+
+func TestTimeout(t *testing.T) {
+	t.Run("should answer 408 when the handler gives up on its deadline", func(t *testing.T) {
+		handler := Timeout(10 * time.Millisecond)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-r.Context().Done()
+		}))
+
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/gobounties/all", nil))
+
+		var res ErrorResponse
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &res))
+		assert.Equal(t, http.StatusRequestTimeout, rr.Code)
+		assert.Equal(t, CodeRequestTimeout, res.Code)
+	})
+
+	t.Run("should keep a response written before the deadline", func(t *testing.T) {
+		handler := Timeout(10 * time.Millisecond)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+			<-r.Context().Done()
+		}))
+
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/gobounties/all", nil))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Empty(t, rr.Body.String())
+	})
+
+	t.Run("should answer 408 on the deadline while the handler still runs", func(t *testing.T) {
+		release := make(chan struct{})
+		finished := make(chan error, 1)
+		handler := Timeout(10 * time.Millisecond)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-release
+			_, err := w.Write([]byte("late"))
+			finished <- err
+		}))
+
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/gobounties/all", nil))
+		close(release)
+
+		assert.Equal(t, http.StatusRequestTimeout, rr.Code)
+		assert.Equal(t, http.ErrHandlerTimeout, <-finished)
+		assert.NotContains(t, rr.Body.String(), "late")
+	})
+
+	t.Run("should let an untimed route finish with a live context", func(t *testing.T) {
+		handler := Timeout(10 * time.Millisecond)(Untimed(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			time.Sleep(30 * time.Millisecond)
+			assert.NoError(t, r.Context().Err())
+			w.WriteHeader(http.StatusAccepted)
+		})))
+
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/gobounties/pay/1", nil))
+
+		assert.Equal(t, http.StatusAccepted, rr.Code)
+	})
+}
+
+func TestRateLimit(t *testing.T) {
+	limited := RateLimit(2, time.Minute)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	request := func(remoteAddr string, forwardedFor string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/metrics/public", nil)
+		req.RemoteAddr = remoteAddr
+		if forwardedFor != "" {
+			req.Header.Set("X-Forwarded-For", forwardedFor)
+		}
+		rr := httptest.NewRecorder()
+		limited.ServeHTTP(rr, req)
+		return rr
+	}
+
+	assert.Equal(t, http.StatusOK, request("10.0.0.1:5000", "").Code)
+	assert.Equal(t, http.StatusOK, request("10.0.0.1:5001", "").Code)
+
+	rr := request("10.0.0.1:5002", "")
+	var res ErrorResponse
+	assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &res))
+	assert.Equal(t, http.StatusTooManyRequests, rr.Code)
+	assert.Equal(t, CodeTooManyRequests, res.Code)
+	assert.NotEmpty(t, rr.Header().Get("Retry-After"))
+
+	// another client, and a spoofed first entry of X-Forwarded-For doesn't
+	// make a new one
+	assert.Equal(t, http.StatusOK, request("10.0.0.2:5000", "").Code)
+	assert.Equal(t, http.StatusOK, request("10.0.0.9:443", "1.1.1.1, 203.0.113.7").Code)
+	assert.Equal(t, http.StatusOK, request("10.0.0.9:443", "2.2.2.2, 203.0.113.7").Code)
+	assert.Equal(t, http.StatusTooManyRequests, request("10.0.0.9:443", "3.3.3.3, 203.0.113.7").Code)
+}
+
+func TestRateLimiterWindow(t *testing.T) {
+	limiter := &rateLimiter{limit: 1, window: time.Minute, counts: map[string]int{}}
+	start := time.Date(2024, 5, 1, 12, 0, 10, 0, time.UTC)
+
+	_, ok := limiter.allow("client", start)
+	assert.True(t, ok)
+	retryAfter, ok := limiter.allow("client", start.Add(20*time.Second))
+	assert.False(t, ok)
+	assert.Equal(t, 30*time.Second, retryAfter)
+
+	_, ok = limiter.allow("client", start.Add(50*time.Second))
+	assert.True(t, ok)
+}
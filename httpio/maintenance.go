@@ -0,0 +1,59 @@
+package httpio
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/db"
+)
+
+// the POST routes that only read, they keep working in maintenance mode
+var maintenanceReads = []string{"/batch/get", "/graphql"}
+
+// ReadOnlyDuringMaintenance refuses the requests that change data while the
+// API is in maintenance mode, with a 503 that carries the estimated end.
+// Reads keep working, and the admin routes stay open so maintenance can be
+// turned off
+func ReadOnlyDuringMaintenance(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		m := db.CurrentMaintenance()
+		if !m.Enabled || allowedInMaintenance(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		message := m.Message
+		if message == "" {
+			message = "The API is in maintenance, changes are refused until it ends"
+		}
+		details := map[string]interface{}{"started_at": m.StartedAt}
+		if m.EndsAt != nil {
+			details["ends_at"] = m.EndsAt
+			if wait := time.Until(*m.EndsAt); wait > 0 {
+				w.Header().Set("Retry-After", fmt.Sprint(int(math.Ceil(wait.Seconds()))))
+			}
+		}
+		WriteErrorCode(w, r, http.StatusServiceUnavailable, CodeMaintenance, message, details)
+	})
+}
+
+func allowedInMaintenance(r *http.Request) bool {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, "/v2")
+	if strings.HasPrefix(path, "/admin/") {
+		return true
+	}
+	for _, read := range maintenanceReads {
+		if path == read {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,53 @@
+package httpio
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadOnlyDuringMaintenance(t *testing.T) {
+	defer db.SetCurrentMaintenance(db.Maintenance{})
+	handler := ReadOnlyDuringMaintenance(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	serve := func(method string, path string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest(method, path, nil))
+		return rr
+	}
+
+	t.Run("should serve every request outside maintenance", func(t *testing.T) {
+		db.SetCurrentMaintenance(db.Maintenance{})
+		assert.Equal(t, http.StatusOK, serve(http.MethodPost, "/gobounties").Code)
+	})
+
+	t.Run("should keep the reads and the admin routes", func(t *testing.T) {
+		db.SetCurrentMaintenance(db.Maintenance{Enabled: true})
+		assert.Equal(t, http.StatusOK, serve(http.MethodGet, "/gobounties/all").Code)
+		assert.Equal(t, http.StatusOK, serve(http.MethodPost, "/batch/get").Code)
+		assert.Equal(t, http.StatusOK, serve(http.MethodPost, "/v2/graphql").Code)
+		assert.Equal(t, http.StatusOK, serve(http.MethodPut, "/v2/admin/maintenance").Code)
+	})
+
+	t.Run("should refuse changes with the estimated end", func(t *testing.T) {
+		endsAt := time.Now().Add(10 * time.Minute)
+		db.SetCurrentMaintenance(db.Maintenance{Enabled: true, Message: "Upgrading the database", EndsAt: &endsAt})
+
+		rr := serve(http.MethodPost, "/gobounties")
+
+		var res ErrorResponse
+		err := json.Unmarshal(rr.Body.Bytes(), &res)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+		assert.Equal(t, CodeMaintenance, res.Code)
+		assert.Equal(t, "Upgrading the database", res.Message)
+		assert.Contains(t, res.Details, "ends_at")
+		assert.NotEmpty(t, rr.Header().Get("Retry-After"))
+	})
+}
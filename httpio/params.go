@@ -0,0 +1,37 @@
+package httpio
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/db"
+)
+
+// ValidateParams checks the url params of the matched route against their
+// validate rules, by param name, and answers 400 with the validation
+// envelope before the handler runs. Params without rules, or that the
+// route doesn't have, are not checked, so one middleware can cover a route
+// group. It must run after routing, in a Group or With
+func ValidateParams(rules map[string]string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			rctx := chi.RouteContext(r.Context())
+			if rctx == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			fieldErrors := []db.FieldError{}
+			for i, key := range rctx.URLParams.Keys {
+				if rule, ok := rules[key]; ok {
+					fieldErrors = append(fieldErrors, db.ValidateVar(key, rctx.URLParams.Values[i], rule)...)
+				}
+			}
+			if len(fieldErrors) > 0 {
+				WriteErrorCode(w, r, http.StatusBadRequest, CodeValidation, "Invalid url params", fieldErrors)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package httpio
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateParams(t *testing.T) {
+	r := chi.NewRouter()
+	r.Group(func(r chi.Router) {
+		r.Use(ValidateParams(map[string]string{"uuid": "tribe_uuid", "pubkey": "pubkey"}))
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}
+		r.Get("/tribes/{uuid}", handler)
+		r.Get("/tribes/{uuid}/members/{pubkey}", handler)
+		r.Get("/bots/{name}", handler)
+	})
+	tribeUuid := base64.URLEncoding.EncodeToString(make([]byte, 69))
+	pubkey := "03" + strings.Repeat("0f", 32)
+
+	tests := []struct {
+		path   string
+		status int
+	}{
+		{"/tribes/" + tribeUuid, http.StatusOK},
+		{"/tribes/nonexistent_uuid", http.StatusBadRequest},
+		{"/tribes/" + tribeUuid + "/members/" + pubkey, http.StatusOK},
+		{"/tribes/" + tribeUuid + "/members/pubkey", http.StatusBadRequest},
+		{"/bots/anything", http.StatusOK},
+	}
+	for _, tt := range tests {
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, tt.path, nil))
+		assert.Equal(t, tt.status, rr.Code, tt.path)
+	}
+
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/tribes/nonexistent_uuid/members/pubkey", nil))
+	res := ErrorResponse{}
+	assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &res))
+	assert.Equal(t, CodeValidation, res.Code)
+	assert.Len(t, res.Details, 2)
+}
@@ -0,0 +1,67 @@
+package httpio
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+
+	"github.com/stakwork/sphinx-tribes/markdown"
+)
+
+// RenderMarkdown renders the markdown of the keys of GET responses to
+// sanitized html when they are asked with ?render=html, at any depth of
+// the JSON body. Error responses and bodies that aren't JSON pass through
+// untouched
+func RenderMarkdown(keys ...string) func(http.Handler) http.Handler {
+	rendered := map[string]bool{}
+	for _, key := range keys {
+		rendered[key] = true
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("render") != "html" || r.Method != http.MethodGet {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			bw := &bufferedWriter{ResponseWriter: w}
+			next.ServeHTTP(bw, r)
+			if bw.status == 0 {
+				bw.status = http.StatusOK
+			}
+
+			body := bw.buf.Bytes()
+			var value interface{}
+			decoder := json.NewDecoder(bytes.NewReader(body))
+			decoder.UseNumber()
+			if bw.status >= http.StatusBadRequest || decoder.Decode(&value) != nil {
+				w.WriteHeader(bw.status)
+				w.Write(body)
+				return
+			}
+
+			policy := markdown.ConfigPolicy()
+			w.Header().Del("Content-Length")
+			w.WriteHeader(bw.status)
+			json.NewEncoder(w).Encode(renderKeys(value, rendered, policy))
+		})
+	}
+}
+
+func renderKeys(value interface{}, keys map[string]bool, policy markdown.Policy) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			if text, ok := field.(string); ok && keys[key] {
+				v[key] = policy.ToHTML(text)
+			} else {
+				v[key] = renderKeys(field, keys, policy)
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = renderKeys(item, keys, policy)
+		}
+	}
+	return value
+}
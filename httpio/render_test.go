@@ -0,0 +1,39 @@
+package httpio
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderMarkdown(t *testing.T) {
+	bounty := func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"bounty": map[string]interface{}{"id": 1, "title": "**title**", "description": "Fix **login**<script>x</script>"},
+			"price":  12345678901234,
+		})
+	}
+	serve := func(target string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, target, nil)
+		RenderMarkdown("description")(http.HandlerFunc(bounty)).ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("should pass the markdown through without render", func(t *testing.T) {
+		rr := serve("/gobounties/id/1")
+
+		assert.Contains(t, rr.Body.String(), `Fix **login**`)
+	})
+
+	t.Run("should render the keys to sanitized html", func(t *testing.T) {
+		rr := serve("/gobounties/id/1?render=html")
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, `{"bounty":{"id":1,"title":"**title**","description":"<p>Fix <strong>login</strong></p>\n"},"price":12345678901234}`, rr.Body.String())
+	})
+}
@@ -0,0 +1,28 @@
+package httpio
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/db"
+)
+
+// TenantScope marks the requests of a router as requests of the workspace
+// in their {workspace_uuid} or {uuid} url param, for the tenant guard of
+// the db package. The param is read when a query runs, after the router
+// has matched the route
+func TenantScope(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		next.ServeHTTP(w, r.WithContext(db.WithTenant(ctx, func() string {
+			rctx := chi.RouteContext(ctx)
+			if rctx == nil {
+				return ""
+			}
+			if uuid := rctx.URLParam("workspace_uuid"); uuid != "" {
+				return uuid
+			}
+			return rctx.URLParam("uuid")
+		})))
+	})
+}
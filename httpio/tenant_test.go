@@ -0,0 +1,39 @@
+package httpio
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTenantScope(t *testing.T) {
+	var tenant string
+	r := chi.NewRouter()
+	r.Route("/workspaces", func(r chi.Router) {
+		r.Use(TenantScope)
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			tenant = db.TenantFrom(r.Context())
+		}
+		r.Get("/bounties/{uuid}", handler)
+		r.Get("/{workspace_uuid}/artifacts/{uuid}", handler)
+		r.Get("/", handler)
+	})
+
+	tests := []struct {
+		path   string
+		tenant string
+	}{
+		{"/workspaces/bounties/workspace-1", "workspace-1"},
+		{"/workspaces/workspace-2/artifacts/artifact-1", "workspace-2"},
+		{"/workspaces/", ""},
+	}
+	for _, tt := range tests {
+		tenant = "unset"
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, tt.path, nil))
+		assert.Equal(t, tt.tenant, tenant, tt.path)
+	}
+}
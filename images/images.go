@@ -0,0 +1,203 @@
+package images
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/draw"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+)
+
+// uploads over this many pixels are refused before they are decoded
+const maxPixels = 40000000
+
+var (
+	ErrUnsupportedType = errors.New("only jpeg, png and gif images are supported")
+	ErrInvalidImage    = errors.New("the image could not be decoded")
+	ErrTooManyPixels   = errors.New("the image has too many pixels")
+)
+
+// Variant is a standard size uploads are converted to. Cropped variants
+// fill Width x Height, the others fit inside it. Images are never upscaled
+type Variant struct {
+	Name   string
+	Width  int
+	Height int
+	Crop   bool
+}
+
+var Variants = []Variant{
+	{Name: "thumbnail", Width: 150, Height: 150, Crop: true},
+	{Name: "card", Width: 600, Height: 600},
+	{Name: "full", Width: 1600, Height: 1600},
+}
+
+// Image is an encoded variant
+type Image struct {
+	Variant     string
+	ContentType string
+	Extension   string
+	Width       int
+	Height      int
+	Data        []byte
+}
+
+// Process validates an uploaded image and encodes every Variant of it.
+// Re-encoding drops the metadata, EXIF included, after the EXIF
+// orientation is applied. Opaque images become jpegs, the others pngs
+func Process(data []byte) ([]Image, error) {
+	contentType := http.DetectContentType(data)
+	if contentType != "image/jpeg" && contentType != "image/png" && contentType != "image/gif" {
+		return nil, ErrUnsupportedType
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrInvalidImage
+	}
+	if cfg.Width*cfg.Height > maxPixels {
+		return nil, ErrTooManyPixels
+	}
+
+	decoded, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrInvalidImage
+	}
+
+	src := image.NewRGBA(image.Rect(0, 0, decoded.Bounds().Dx(), decoded.Bounds().Dy()))
+	draw.Draw(src, src.Bounds(), decoded, decoded.Bounds().Min, draw.Src)
+	if contentType == "image/jpeg" {
+		src = orient(src, exifOrientation(data))
+	}
+
+	images := make([]Image, 0, len(Variants))
+	for _, variant := range Variants {
+		resized := resize(src, variant)
+		img := Image{Variant: variant.Name, Width: resized.Bounds().Dx(), Height: resized.Bounds().Dy()}
+
+		buf := &bytes.Buffer{}
+		if resized.Opaque() {
+			img.ContentType, img.Extension = "image/jpeg", "jpg"
+			err = jpeg.Encode(buf, resized, &jpeg.Options{Quality: 85})
+		} else {
+			img.ContentType, img.Extension = "image/png", "png"
+			err = png.Encode(buf, resized)
+		}
+		if err != nil {
+			return nil, err
+		}
+		img.Data = buf.Bytes()
+		images = append(images, img)
+	}
+	return images, nil
+}
+
+// resize scales src down to the variant by averaging the source pixels
+// that fall in each destination pixel
+func resize(src *image.RGBA, variant Variant) *image.RGBA {
+	sw, sh := src.Bounds().Dx(), src.Bounds().Dy()
+	crop := image.Rect(0, 0, sw, sh)
+	if variant.Crop {
+		// the centered region with the aspect ratio of the variant
+		if sw*variant.Height > sh*variant.Width {
+			w := sh * variant.Width / variant.Height
+			crop = image.Rect((sw-w)/2, 0, (sw-w)/2+w, sh)
+		} else {
+			h := sw * variant.Height / variant.Width
+			crop = image.Rect(0, (sh-h)/2, sw, (sh-h)/2+h)
+		}
+	}
+
+	cw, ch := crop.Dx(), crop.Dy()
+	dw, dh := cw, ch
+	if dw > variant.Width || dh > variant.Height {
+		if cw*variant.Height > ch*variant.Width {
+			dw, dh = variant.Width, ch*variant.Width/cw
+		} else {
+			dw, dh = cw*variant.Height/ch, variant.Height
+		}
+	}
+	if dw < 1 {
+		dw = 1
+	}
+	if dh < 1 {
+		dh = 1
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < dh; y++ {
+		y0 := crop.Min.Y + y*ch/dh
+		y1 := crop.Min.Y + (y+1)*ch/dh
+		if y1 <= y0 {
+			y1 = y0 + 1
+		}
+		for x := 0; x < dw; x++ {
+			x0 := crop.Min.X + x*cw/dw
+			x1 := crop.Min.X + (x+1)*cw/dw
+			if x1 <= x0 {
+				x1 = x0 + 1
+			}
+
+			var r, g, b, a, n int
+			for sy := y0; sy < y1; sy++ {
+				i := src.PixOffset(x0, sy)
+				for sx := x0; sx < x1; sx++ {
+					r += int(src.Pix[i])
+					g += int(src.Pix[i+1])
+					b += int(src.Pix[i+2])
+					a += int(src.Pix[i+3])
+					i += 4
+					n++
+				}
+			}
+			j := dst.PixOffset(x, y)
+			dst.Pix[j] = uint8(r / n)
+			dst.Pix[j+1] = uint8(g / n)
+			dst.Pix[j+2] = uint8(b / n)
+			dst.Pix[j+3] = uint8(a / n)
+		}
+	}
+	return dst
+}
+
+// orient turns src upright for an EXIF orientation, 1 to 8
+func orient(src *image.RGBA, orientation int) *image.RGBA {
+	if orientation < 2 || orientation > 8 {
+		return src
+	}
+
+	sw, sh := src.Bounds().Dx(), src.Bounds().Dy()
+	dw, dh := sw, sh
+	if orientation >= 5 {
+		dw, dh = sh, sw
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < dh; y++ {
+		for x := 0; x < dw; x++ {
+			var sx, sy int
+			switch orientation {
+			case 2:
+				sx, sy = sw-1-x, y
+			case 3:
+				sx, sy = sw-1-x, sh-1-y
+			case 4:
+				sx, sy = x, sh-1-y
+			case 5:
+				sx, sy = y, x
+			case 6:
+				sx, sy = y, sh-1-x
+			case 7:
+				sx, sy = sw-1-y, sh-1-x
+			case 8:
+				sx, sy = sw-1-y, x
+			}
+			i, j := src.PixOffset(sx, sy), dst.PixOffset(x, y)
+			copy(dst.Pix[j:j+4], src.Pix[i:i+4])
+		}
+	}
+	return dst
+}
@@ -0,0 +1,107 @@
+package images
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func encodeJpeg(t *testing.T, w int, h int) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 100, A: 255})
+		}
+	}
+	buf := &bytes.Buffer{}
+	assert.NoError(t, jpeg.Encode(buf, img, nil))
+	return buf.Bytes()
+}
+
+// withOrientation adds an EXIF segment with the orientation tag after the
+// start of image marker
+func withOrientation(data []byte, orientation uint16) []byte {
+	tiff := []byte("MM\x00\x2a\x00\x00\x00\x08\x00\x01")
+	entry := make([]byte, 12)
+	binary.BigEndian.PutUint16(entry[0:2], exifOrientationTag)
+	binary.BigEndian.PutUint16(entry[2:4], 3)
+	binary.BigEndian.PutUint32(entry[4:8], 1)
+	binary.BigEndian.PutUint16(entry[8:10], orientation)
+	segment := append(append([]byte("Exif\x00\x00"), tiff...), entry...)
+
+	header := []byte{0xFF, 0xE1, 0, 0}
+	binary.BigEndian.PutUint16(header[2:4], uint16(len(segment)+2))
+	out := append([]byte{}, data[:2]...)
+	out = append(out, header...)
+	out = append(out, segment...)
+	return append(out, data[2:]...)
+}
+
+func TestProcess(t *testing.T) {
+	t.Run("should encode every variant without upscaling", func(t *testing.T) {
+		processed, err := Process(encodeJpeg(t, 1000, 500))
+		assert.NoError(t, err)
+		assert.Len(t, processed, 3)
+
+		sizes := map[string][2]int{}
+		for _, img := range processed {
+			assert.Equal(t, "image/jpeg", img.ContentType)
+			sizes[img.Variant] = [2]int{img.Width, img.Height}
+		}
+		assert.Equal(t, [2]int{150, 150}, sizes["thumbnail"])
+		assert.Equal(t, [2]int{600, 300}, sizes["card"])
+		assert.Equal(t, [2]int{1000, 500}, sizes["full"])
+	})
+
+	t.Run("should apply and strip the exif orientation", func(t *testing.T) {
+		data := withOrientation(encodeJpeg(t, 200, 100), 6)
+		assert.Equal(t, 6, exifOrientation(data))
+
+		processed, err := Process(data)
+		assert.NoError(t, err)
+		full := processed[2]
+		assert.Equal(t, 100, full.Width)
+		assert.Equal(t, 200, full.Height)
+		assert.Equal(t, 1, exifOrientation(full.Data))
+		assert.False(t, bytes.Contains(full.Data, []byte("Exif")))
+	})
+
+	t.Run("should keep transparent images as png", func(t *testing.T) {
+		img := image.NewNRGBA(image.Rect(0, 0, 40, 40))
+		buf := &bytes.Buffer{}
+		assert.NoError(t, png.Encode(buf, img))
+
+		processed, err := Process(buf.Bytes())
+		assert.NoError(t, err)
+		assert.Equal(t, "image/png", processed[0].ContentType)
+		assert.Equal(t, 40, processed[0].Width)
+	})
+
+	t.Run("should refuse files that are not images", func(t *testing.T) {
+		_, err := Process([]byte("<svg xmlns=\"http://www.w3.org/2000/svg\"></svg>"))
+		assert.Equal(t, ErrUnsupportedType, err)
+
+		_, err = Process(append([]byte{0xFF, 0xD8, 0xFF}, []byte("not really a jpeg")...))
+		assert.Equal(t, ErrInvalidImage, err)
+	})
+}
+
+func TestOrient(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 3, 2))
+	src.Set(0, 0, color.RGBA{R: 255, A: 255})
+
+	// the top left pixel ends up top right after a clockwise turn
+	rotated := orient(src, 6)
+	assert.Equal(t, image.Rect(0, 0, 2, 3), rotated.Bounds())
+	assert.Equal(t, color.RGBA{R: 255, A: 255}, rotated.At(1, 0))
+
+	// and bottom left after a counter clockwise turn
+	rotated = orient(src, 8)
+	assert.Equal(t, color.RGBA{R: 255, A: 255}, rotated.At(0, 2))
+}
@@ -0,0 +1,117 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/events"
+)
+
+const (
+	WorkspaceDigestsJob = "workspaces.digest"
+	WorkspaceDigestJob  = "workspace.digest"
+	digestPeriod        = 7 * 24 * time.Hour
+)
+
+// weekStart is the Monday 00:00 in loc that starts the week of t
+func weekStart(t time.Time, loc *time.Location) time.Time {
+	t = t.In(loc)
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+	return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+}
+
+// localWeekEnd is the Monday 00:00 in loc closest to t. The weekly run is
+// at Monday 00:00 UTC, which is less than a day from the Monday of every
+// time zone
+func localWeekEnd(t time.Time, loc *time.Location) time.Time {
+	end := weekStart(t, loc)
+	if t.Sub(end) > digestPeriod/2 {
+		end = end.AddDate(0, 0, 7)
+	}
+	return end
+}
+
+// RegisterWorkspaceDigests writes the weekly digest of every workspace. The
+// weekly run adds a job per workspace so a failing workspace is retried on
+// its own, and schedules the run of the next week. The week of a digest
+// ends on the Monday of the time zone of its workspace, its job runs then
+func RegisterWorkspaceDigests(q *Queue) {
+	q.Register(WorkspaceDigestsJob, func(ctx context.Context, job db.Job) error {
+		now := time.Now()
+		workspaces, err := q.db.GetDigestWorkspaces()
+		if err != nil {
+			return err
+		}
+		for _, workspace := range workspaces {
+			end := localWeekEnd(now, workspace.Location())
+			// a week is shorter or longer than 7 days when the clocks change
+			start := end.AddDate(0, 0, -7)
+			key := WorkspaceDigestJob + ":" + workspace.Uuid + ":" + start.Format("20060102")
+			payload := map[string]interface{}{"workspace_uuid": workspace.Uuid, "period_start": start.Unix(), "period_end": end.Unix()}
+			if _, err := q.ScheduleOnce(key, WorkspaceDigestJob, payload, end); err != nil {
+				return err
+			}
+		}
+
+		_, err = ScheduleWorkspaceDigests(q, weekStart(now, time.UTC).Add(digestPeriod))
+		return err
+	})
+
+	q.Register(WorkspaceDigestJob, func(ctx context.Context, job db.Job) error {
+		workspaceUuid, _ := job.Payload["workspace_uuid"].(string)
+		periodStart, _ := job.Payload["period_start"].(float64)
+		if workspaceUuid == "" || periodStart == 0 {
+			return errors.New("the job has no workspace or period")
+		}
+		workspace := q.db.GetWorkspaceByUuid(workspaceUuid)
+		if workspace.Uuid == "" {
+			return nil
+		}
+
+		loc := workspace.Location()
+		start := time.Unix(int64(periodStart), 0).In(loc)
+		end := start.Add(digestPeriod)
+		// jobs added before the time zones of workspaces have no end
+		if periodEnd, _ := job.Payload["period_end"].(float64); periodEnd != 0 {
+			end = time.Unix(int64(periodEnd), 0).In(loc)
+		}
+		digest, err := q.db.CreateWorkspaceDigest(workspace.Uuid, start, end)
+		if err != nil {
+			return err
+		}
+		if digest.Empty() {
+			return nil
+		}
+
+		members, err := q.db.GetWorkspaceMemberPubkeys(workspace)
+		if err != nil {
+			return err
+		}
+		events.Publish(ctx, events.WorkspaceDigested, "workspace:"+workspace.Uuid, map[string]interface{}{
+			"workspace_uuid":   workspace.Uuid,
+			"name":             workspace.Name,
+			"digest_uuid":      digest.Uuid,
+			"period_start":     digest.PeriodStart,
+			"new_bounties":     digest.NewBounties,
+			"completed":        digest.Completed,
+			"paid":             digest.Paid,
+			"sats_paid":        digest.SatsPaid,
+			"budget_delta":     digest.BudgetDelta,
+			"stale_bounties":   digest.StaleBounties,
+			"overdue_bounties": digest.OverdueBounties,
+			"timezone":         workspace.Timezone,
+			"locale":           workspace.Locale,
+			"member_pubkeys":   members,
+		})
+		return nil
+	})
+}
+
+// ScheduleWorkspaceDigests adds the weekly digest run of the week of runAt,
+// keyed by the week so instances starting together add it only once
+func ScheduleWorkspaceDigests(q *Queue, runAt time.Time) (db.Job, error) {
+	key := WorkspaceDigestsJob + ":" + weekStart(runAt, time.UTC).Format("20060102")
+	return q.ScheduleOnce(key, WorkspaceDigestsJob, nil, runAt)
+}
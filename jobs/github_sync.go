@@ -0,0 +1,65 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/db"
+)
+
+const (
+	WorkspacesGithubSyncJob = "workspaces.github_sync"
+	WorkspaceGithubSyncJob  = "workspace.github_sync"
+)
+
+// GithubOrgFetcher lists the members and contributors of the GitHub
+// organization of a workspace
+type GithubOrgFetcher func(ctx context.Context, workspace db.Workspace) ([]db.GithubOrgLogin, error)
+
+// RegisterWorkspaceGithubSync syncs the GitHub organization of every linked
+// workspace every night. The nightly run adds a job per workspace so a
+// failing organization is retried on its own, and schedules the next run
+func RegisterWorkspaceGithubSync(q *Queue, fetch GithubOrgFetcher) {
+	q.Register(WorkspacesGithubSyncJob, func(ctx context.Context, job db.Job) error {
+		now := time.Now()
+		workspaces, err := q.db.GetGithubSyncWorkspaces()
+		if err != nil {
+			return err
+		}
+		for _, workspace := range workspaces {
+			key := WorkspaceGithubSyncJob + ":" + workspace.Uuid + ":" + now.UTC().Format("20060102")
+			if _, err := q.ScheduleOnce(key, WorkspaceGithubSyncJob, map[string]interface{}{"workspace_uuid": workspace.Uuid}, now); err != nil {
+				return err
+			}
+		}
+
+		_, err = ScheduleWorkspaceGithubSync(q, nextNight(now))
+		return err
+	})
+
+	q.Register(WorkspaceGithubSyncJob, func(ctx context.Context, job db.Job) error {
+		workspaceUuid, _ := job.Payload["workspace_uuid"].(string)
+		if workspaceUuid == "" {
+			return errors.New("the job has no workspace")
+		}
+		workspace := q.db.GetWorkspaceByUuid(workspaceUuid)
+		// the organization was unlinked since the job was added
+		if workspace.Uuid == "" || workspace.GithubOrg == "" {
+			return nil
+		}
+
+		logins, err := fetch(ctx, workspace)
+		if err != nil {
+			return err
+		}
+		return q.db.SyncWorkspaceMemberSuggestions(workspace.Uuid, logins, time.Now())
+	})
+}
+
+// ScheduleWorkspaceGithubSync adds the GitHub sync run of the day of runAt,
+// keyed by the day like the retention run
+func ScheduleWorkspaceGithubSync(q *Queue, runAt time.Time) (db.Job, error) {
+	key := WorkspacesGithubSyncJob + ":" + runAt.UTC().Format("20060102")
+	return q.ScheduleOnce(key, WorkspacesGithubSyncJob, nil, runAt)
+}
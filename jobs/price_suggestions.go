@@ -0,0 +1,44 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/db"
+)
+
+const (
+	BountyPriceSuggestionsJob = "bounties.price_suggestions"
+	// the hour of the night, UTC, the suggestions are computed at
+	priceSuggestionsHour = 2
+)
+
+// nextNight is the next priceSuggestionsHour UTC after t
+func nextNight(t time.Time) time.Time {
+	t = t.UTC()
+	night := time.Date(t.Year(), t.Month(), t.Day(), priceSuggestionsHour, 0, 0, 0, time.UTC)
+	if !night.After(t) {
+		night = night.AddDate(0, 0, 1)
+	}
+	return night
+}
+
+// RegisterBountyPriceSuggestions computes the price suggestions from the
+// paid bounties every night. Each run schedules the next one
+func RegisterBountyPriceSuggestions(q *Queue) {
+	q.Register(BountyPriceSuggestionsJob, func(ctx context.Context, job db.Job) error {
+		now := time.Now()
+		if err := q.db.RefreshBountyPriceSuggestions(now); err != nil {
+			return err
+		}
+		_, err := ScheduleBountyPriceSuggestions(q, nextNight(now))
+		return err
+	})
+}
+
+// ScheduleBountyPriceSuggestions adds the price suggestions run of the day
+// of runAt, keyed by the day like the retention run
+func ScheduleBountyPriceSuggestions(q *Queue, runAt time.Time) (db.Job, error) {
+	key := BountyPriceSuggestionsJob + ":" + runAt.UTC().Format("20060102")
+	return q.ScheduleOnce(key, BountyPriceSuggestionsJob, nil, runAt)
+}
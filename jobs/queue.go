@@ -0,0 +1,213 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/rs/xid"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/tracing"
+)
+
+// HandlerFunc runs a single job. Returning an error schedules a retry
+type HandlerFunc func(ctx context.Context, job db.Job) error
+
+const (
+	defaultPollInterval = 2 * time.Second
+	defaultJobTimeout   = 5 * time.Minute
+	maxBackoff          = time.Hour
+	staleLockAge        = 15 * time.Minute
+)
+
+type Queue struct {
+	db           db.Database
+	mu           sync.RWMutex
+	handlers     map[string]HandlerFunc
+	workers      int
+	pollInterval time.Duration
+	jobTimeout   time.Duration
+	backoff      func(attempts int) time.Duration
+}
+
+// Default is the queue used by the rest of the app, it is set up in main
+var Default *Queue
+
+func NewQueue(database db.Database) *Queue {
+	workers, _ := strconv.Atoi(os.Getenv("JOB_WORKERS"))
+	if workers <= 0 {
+		workers = 2
+	}
+
+	return &Queue{
+		db:           database,
+		handlers:     map[string]HandlerFunc{},
+		workers:      workers,
+		pollInterval: defaultPollInterval,
+		jobTimeout:   defaultJobTimeout,
+		backoff:      Backoff,
+	}
+}
+
+func InitQueue(database db.Database) {
+	Default = NewQueue(database)
+}
+
+// Backoff doubles the wait after every failed attempt, starting at 30s and
+// capped at an hour
+func Backoff(attempts int) time.Duration {
+	if attempts < 1 {
+		attempts = 1
+	}
+	wait := time.Duration(math.Pow(2, float64(attempts-1))) * 30 * time.Second
+	if wait > maxBackoff || wait <= 0 {
+		return maxBackoff
+	}
+	return wait
+}
+
+func (q *Queue) Register(jobType string, handler HandlerFunc) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.handlers[jobType] = handler
+}
+
+func (q *Queue) types() []string {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	types := make([]string, 0, len(q.handlers))
+	for jobType := range q.handlers {
+		types = append(types, jobType)
+	}
+	return types
+}
+
+func (q *Queue) handler(jobType string) (HandlerFunc, bool) {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	handler, ok := q.handlers[jobType]
+	return handler, ok
+}
+
+// Enqueue schedules a job to run as soon as a worker is free
+func (q *Queue) Enqueue(jobType string, payload map[string]interface{}) (db.Job, error) {
+	return q.Schedule(jobType, payload, time.Now())
+}
+
+// Schedule adds a job that will not be picked up before runAt
+func (q *Queue) Schedule(jobType string, payload map[string]interface{}, runAt time.Time) (db.Job, error) {
+	return q.db.EnqueueJob(db.Job{
+		Uuid:    xid.New().String(),
+		Type:    jobType,
+		Payload: db.PropertyMap(payload),
+		RunAt:   &runAt,
+	})
+}
+
+// ScheduleOnce is Schedule for jobs that must exist only once, like the
+// runs of a recurring job shared by every instance. key becomes the job uuid
+// and nothing is added when a job with that key already exists
+func (q *Queue) ScheduleOnce(key string, jobType string, payload map[string]interface{}, runAt time.Time) (db.Job, error) {
+	if job, err := q.db.GetJobByUuid(key); err == nil {
+		return job, nil
+	}
+	return q.db.EnqueueJob(db.Job{
+		Uuid:    key,
+		Type:    jobType,
+		Payload: db.PropertyMap(payload),
+		RunAt:   &runAt,
+	})
+}
+
+// ProcessNext claims and runs one due job. It reports whether a job was found
+// so the workers know when to back off and wait for the next poll. In
+// maintenance mode no job is claimed, the running ones finish
+func (q *Queue) ProcessNext(ctx context.Context) (bool, error) {
+	if db.InMaintenance() {
+		return false, nil
+	}
+	job, err := q.db.ClaimNextJob(q.types())
+	if err != nil {
+		return false, err
+	}
+	if job.Uuid == "" {
+		return false, nil
+	}
+
+	runErr := q.run(ctx, job)
+	if runErr == nil {
+		return true, q.db.CompleteJob(job.Uuid)
+	}
+
+	retryAt := time.Now().Add(q.backoff(job.Attempts))
+	failed, err := q.db.FailJob(job, runErr.Error(), retryAt)
+	if err != nil {
+		return true, err
+	}
+	if failed.Status == db.JobDead {
+		fmt.Printf("[jobs] job %s (%s) moved to dead letter after %d attempts: %s\n", job.Uuid, job.Type, job.Attempts, runErr.Error())
+	}
+	return true, nil
+}
+
+func (q *Queue) run(ctx context.Context, job db.Job) (err error) {
+	handler, ok := q.handler(job.Type)
+	if !ok {
+		return fmt.Errorf("no handler registered for job type %s", job.Type)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, q.jobTimeout)
+	defer cancel()
+
+	ctx, span := tracing.Start(ctx, "job."+job.Type)
+	defer span.End()
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job panicked: %v", r)
+		}
+	}()
+
+	return handler(ctx, job)
+}
+
+// Start runs the workers until ctx is cancelled
+func (q *Queue) Start(ctx context.Context) {
+	if released, err := q.db.ReleaseStaleJobs(time.Now().Add(-staleLockAge)); err != nil {
+		fmt.Println("[jobs] could not release stale jobs", err)
+	} else if released > 0 {
+		fmt.Printf("[jobs] released %d stale jobs\n", released)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < q.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			q.work(ctx)
+		}()
+	}
+	wg.Wait()
+}
+
+func (q *Queue) work(ctx context.Context) {
+	for ctx.Err() == nil {
+		found, err := q.ProcessNext(ctx)
+		if err != nil {
+			fmt.Println("[jobs] worker error", err)
+		}
+		if found && err == nil {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(q.pollInterval):
+		}
+	}
+}
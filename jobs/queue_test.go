@@ -0,0 +1,489 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/events"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestBackoff(t *testing.T) {
+	assert.Equal(t, 30*time.Second, Backoff(0))
+	assert.Equal(t, 30*time.Second, Backoff(1))
+	assert.Equal(t, 2*time.Minute, Backoff(3))
+	assert.Equal(t, time.Hour, Backoff(10))
+	assert.Equal(t, time.Hour, Backoff(100))
+}
+
+func TestSchedule(t *testing.T) {
+	mockDb := &dbMocks.Database{}
+	queue := NewQueue(mockDb)
+	runAt := time.Now().Add(time.Hour)
+
+	mockDb.On("EnqueueJob", mock.MatchedBy(func(j db.Job) bool {
+		return j.Uuid != "" && j.Type == "webhook" && j.Payload["url"] == "https://example.com" && j.RunAt.Equal(runAt)
+	})).Return(db.Job{Uuid: "job-uuid", Type: "webhook"}, nil).Once()
+
+	job, err := queue.Schedule("webhook", map[string]interface{}{"url": "https://example.com"}, runAt)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "job-uuid", job.Uuid)
+	mockDb.AssertExpectations(t)
+}
+
+func TestProcessNext(t *testing.T) {
+	t.Run("should report no job when nothing is due", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		queue := NewQueue(mockDb)
+		queue.Register("webhook", func(ctx context.Context, job db.Job) error { return nil })
+
+		mockDb.On("ClaimNextJob", []string{"webhook"}).Return(db.Job{}, nil).Once()
+
+		found, err := queue.ProcessNext(context.Background())
+
+		assert.False(t, found)
+		assert.NoError(t, err)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should not claim a job in maintenance mode", func(t *testing.T) {
+		defer db.SetCurrentMaintenance(db.Maintenance{})
+		db.SetCurrentMaintenance(db.Maintenance{Enabled: true})
+		mockDb := &dbMocks.Database{}
+		queue := NewQueue(mockDb)
+		queue.Register("webhook", func(ctx context.Context, job db.Job) error { return nil })
+
+		found, err := queue.ProcessNext(context.Background())
+
+		assert.False(t, found)
+		assert.NoError(t, err)
+		mockDb.AssertNotCalled(t, "ClaimNextJob", mock.Anything)
+	})
+
+	t.Run("should complete a job that succeeds", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		queue := NewQueue(mockDb)
+		var received db.Job
+		queue.Register("webhook", func(ctx context.Context, job db.Job) error {
+			received = job
+			return nil
+		})
+
+		job := db.Job{Uuid: "job-uuid", Type: "webhook", Attempts: 1, MaxAttempts: 5}
+		mockDb.On("ClaimNextJob", []string{"webhook"}).Return(job, nil).Once()
+		mockDb.On("CompleteJob", "job-uuid").Return(nil).Once()
+
+		found, err := queue.ProcessNext(context.Background())
+
+		assert.True(t, found)
+		assert.NoError(t, err)
+		assert.Equal(t, "job-uuid", received.Uuid)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should schedule a retry with backoff when the job fails", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		queue := NewQueue(mockDb)
+		queue.Register("webhook", func(ctx context.Context, job db.Job) error {
+			return errors.New("connection refused")
+		})
+
+		job := db.Job{Uuid: "job-uuid", Type: "webhook", Attempts: 2, MaxAttempts: 5}
+		mockDb.On("ClaimNextJob", []string{"webhook"}).Return(job, nil).Once()
+		mockDb.On("FailJob", job, "connection refused", mock.MatchedBy(func(retryAt time.Time) bool {
+			wait := time.Until(retryAt)
+			return wait > 50*time.Second && wait <= time.Minute
+		})).Return(db.Job{Uuid: "job-uuid", Status: db.JobPending}, nil).Once()
+
+		found, err := queue.ProcessNext(context.Background())
+
+		assert.True(t, found)
+		assert.NoError(t, err)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should record a panic as a failed attempt", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		queue := NewQueue(mockDb)
+		queue.Register("webhook", func(ctx context.Context, job db.Job) error {
+			panic("boom")
+		})
+
+		job := db.Job{Uuid: "job-uuid", Type: "webhook", Attempts: 5, MaxAttempts: 5}
+		mockDb.On("ClaimNextJob", []string{"webhook"}).Return(job, nil).Once()
+		mockDb.On("FailJob", job, "job panicked: boom", mock.AnythingOfType("time.Time")).Return(db.Job{Uuid: "job-uuid", Status: db.JobDead}, nil).Once()
+
+		found, err := queue.ProcessNext(context.Background())
+
+		assert.True(t, found)
+		assert.NoError(t, err)
+		mockDb.AssertExpectations(t)
+	})
+}
+
+func TestScheduleStatsAggregation(t *testing.T) {
+	runAt := time.Date(2024, 5, 1, 14, 20, 0, 0, time.UTC)
+
+	t.Run("should add the run of the hour once", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		queue := NewQueue(mockDb)
+
+		mockDb.On("GetJobByUuid", "stats.aggregate:2024050114").Return(db.Job{}, errors.New("no job found")).Once()
+		mockDb.On("EnqueueJob", mock.MatchedBy(func(j db.Job) bool {
+			return j.Uuid == "stats.aggregate:2024050114" && j.Type == StatsAggregationJob && j.RunAt.Equal(runAt)
+		})).Return(db.Job{Uuid: "stats.aggregate:2024050114"}, nil).Once()
+
+		_, err := ScheduleStatsAggregation(queue, runAt)
+
+		assert.NoError(t, err)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should not add a run that already exists", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		queue := NewQueue(mockDb)
+
+		mockDb.On("GetJobByUuid", "stats.aggregate:2024050114").Return(db.Job{Uuid: "stats.aggregate:2024050114"}, nil).Once()
+
+		job, err := ScheduleStatsAggregation(queue, runAt)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "stats.aggregate:2024050114", job.Uuid)
+		mockDb.AssertNotCalled(t, "EnqueueJob", mock.Anything)
+	})
+
+	t.Run("should aggregate and schedule the next run", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		queue := NewQueue(mockDb)
+		RegisterStatsAggregation(queue)
+
+		mockDb.On("ClaimNextJob", []string{StatsAggregationJob}).Return(db.Job{Uuid: "stats.aggregate:2024050114", Type: StatsAggregationJob}, nil).Once()
+		mockDb.On("AggregatePlatformStats", mock.AnythingOfType("time.Time")).Return(db.PlatformStats{}, nil).Once()
+		mockDb.On("GetJobByUuid", mock.AnythingOfType("string")).Return(db.Job{}, errors.New("no job found")).Once()
+		mockDb.On("EnqueueJob", mock.MatchedBy(func(j db.Job) bool {
+			return j.Type == StatsAggregationJob && j.RunAt.After(time.Now().Add(50*time.Minute))
+		})).Return(db.Job{}, nil).Once()
+		mockDb.On("CompleteJob", "stats.aggregate:2024050114").Return(nil).Once()
+
+		found, err := queue.ProcessNext(context.Background())
+
+		assert.True(t, found)
+		assert.NoError(t, err)
+		mockDb.AssertExpectations(t)
+	})
+}
+
+func TestBountyRecommendations(t *testing.T) {
+	t.Run("should rank for the active people and schedule the next run", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		queue := NewQueue(mockDb)
+		RegisterBountyRecommendations(queue)
+
+		mockDb.On("ClaimNextJob", []string{BountyRecommendationsJob}).Return(db.Job{Uuid: "bounties.recommend:2024050114", Type: BountyRecommendationsJob}, nil).Once()
+		mockDb.On("GetRecommendationPubkeys", mock.AnythingOfType("time.Time")).Return([]string{"hunter"}, nil).Once()
+		mockDb.On("RefreshBountyRecommendations", []string{"hunter"}, mock.AnythingOfType("time.Time")).Return(nil).Once()
+		mockDb.On("GetJobByUuid", mock.AnythingOfType("string")).Return(db.Job{}, errors.New("no job found")).Once()
+		mockDb.On("EnqueueJob", mock.MatchedBy(func(j db.Job) bool {
+			return j.Type == BountyRecommendationsJob && j.RunAt.After(time.Now().Add(50*time.Minute))
+		})).Return(db.Job{}, nil).Once()
+		mockDb.On("CompleteJob", "bounties.recommend:2024050114").Return(nil).Once()
+
+		found, err := queue.ProcessNext(context.Background())
+
+		assert.True(t, found)
+		assert.NoError(t, err)
+		mockDb.AssertExpectations(t)
+	})
+}
+
+func TestBountyPriceSuggestions(t *testing.T) {
+	t.Run("should compute the suggestions and schedule the next night", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		queue := NewQueue(mockDb)
+		RegisterBountyPriceSuggestions(queue)
+
+		mockDb.On("ClaimNextJob", []string{BountyPriceSuggestionsJob}).Return(db.Job{Uuid: "bounties.price_suggestions:20240501", Type: BountyPriceSuggestionsJob}, nil).Once()
+		mockDb.On("RefreshBountyPriceSuggestions", mock.AnythingOfType("time.Time")).Return(nil).Once()
+		mockDb.On("GetJobByUuid", mock.AnythingOfType("string")).Return(db.Job{}, errors.New("no job found")).Once()
+		mockDb.On("EnqueueJob", mock.MatchedBy(func(j db.Job) bool {
+			return j.Type == BountyPriceSuggestionsJob && j.RunAt.Equal(nextNight(time.Now()))
+		})).Return(db.Job{}, nil).Once()
+		mockDb.On("CompleteJob", "bounties.price_suggestions:20240501").Return(nil).Once()
+
+		found, err := queue.ProcessNext(context.Background())
+
+		assert.True(t, found)
+		assert.NoError(t, err)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should run at the next night", func(t *testing.T) {
+		assert.Equal(t, time.Date(2024, 5, 1, 2, 0, 0, 0, time.UTC), nextNight(time.Date(2024, 5, 1, 1, 59, 0, 0, time.UTC)))
+		assert.Equal(t, time.Date(2024, 5, 2, 2, 0, 0, 0, time.UTC), nextNight(time.Date(2024, 5, 1, 2, 0, 0, 0, time.UTC)))
+		assert.Equal(t, time.Date(2024, 5, 2, 2, 0, 0, 0, time.UTC), nextNight(time.Date(2024, 5, 1, 14, 20, 0, 0, time.UTC)))
+	})
+}
+
+func TestWorkspaceGithubSync(t *testing.T) {
+	logins := []db.GithubOrgLogin{{Login: "ada", OrgMember: true}}
+	fetch := func(ctx context.Context, workspace db.Workspace) ([]db.GithubOrgLogin, error) {
+		return logins, nil
+	}
+
+	t.Run("should add a job per linked workspace and schedule the next night", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		queue := NewQueue(mockDb)
+		RegisterWorkspaceGithubSync(queue, fetch)
+
+		mockDb.On("ClaimNextJob", mock.Anything).Return(db.Job{Uuid: "workspaces.github_sync:20240501", Type: WorkspacesGithubSyncJob}, nil).Once()
+		mockDb.On("GetGithubSyncWorkspaces").Return([]db.Workspace{{Uuid: "workspace-a", GithubOrg: "stakwork"}}, nil).Once()
+		mockDb.On("GetJobByUuid", mock.AnythingOfType("string")).Return(db.Job{}, errors.New("no job found")).Twice()
+		mockDb.On("EnqueueJob", mock.MatchedBy(func(j db.Job) bool {
+			return j.Type == WorkspaceGithubSyncJob && j.Payload["workspace_uuid"] == "workspace-a"
+		})).Return(db.Job{}, nil).Once()
+		mockDb.On("EnqueueJob", mock.MatchedBy(func(j db.Job) bool {
+			return j.Type == WorkspacesGithubSyncJob && j.RunAt.Equal(nextNight(time.Now()))
+		})).Return(db.Job{}, nil).Once()
+		mockDb.On("CompleteJob", "workspaces.github_sync:20240501").Return(nil).Once()
+
+		found, err := queue.ProcessNext(context.Background())
+
+		assert.True(t, found)
+		assert.NoError(t, err)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should save the logins of the organization", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		queue := NewQueue(mockDb)
+		RegisterWorkspaceGithubSync(queue, fetch)
+
+		job := db.Job{Uuid: "workspace.github_sync:workspace-a:20240501", Type: WorkspaceGithubSyncJob, Payload: db.PropertyMap{"workspace_uuid": "workspace-a"}}
+		mockDb.On("ClaimNextJob", mock.Anything).Return(job, nil).Once()
+		mockDb.On("GetWorkspaceByUuid", "workspace-a").Return(db.Workspace{Uuid: "workspace-a", GithubOrg: "stakwork"}).Once()
+		mockDb.On("SyncWorkspaceMemberSuggestions", "workspace-a", logins, mock.AnythingOfType("time.Time")).Return(nil).Once()
+		mockDb.On("CompleteJob", job.Uuid).Return(nil).Once()
+
+		found, err := queue.ProcessNext(context.Background())
+
+		assert.True(t, found)
+		assert.NoError(t, err)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should skip a workspace that was unlinked", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		queue := NewQueue(mockDb)
+		RegisterWorkspaceGithubSync(queue, fetch)
+
+		job := db.Job{Uuid: "workspace.github_sync:workspace-a:20240501", Type: WorkspaceGithubSyncJob, Payload: db.PropertyMap{"workspace_uuid": "workspace-a"}}
+		mockDb.On("ClaimNextJob", mock.Anything).Return(job, nil).Once()
+		mockDb.On("GetWorkspaceByUuid", "workspace-a").Return(db.Workspace{Uuid: "workspace-a"}).Once()
+		mockDb.On("CompleteJob", job.Uuid).Return(nil).Once()
+
+		found, err := queue.ProcessNext(context.Background())
+
+		assert.True(t, found)
+		assert.NoError(t, err)
+		mockDb.AssertNotCalled(t, "SyncWorkspaceMemberSuggestions", mock.Anything, mock.Anything, mock.Anything)
+		mockDb.AssertExpectations(t)
+	})
+}
+
+func TestWeekStart(t *testing.T) {
+	monday := time.Date(2024, 4, 29, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, monday, weekStart(time.Date(2024, 5, 1, 14, 20, 0, 0, time.UTC), time.UTC))
+	assert.Equal(t, monday, weekStart(monday, time.UTC))
+	assert.Equal(t, monday, weekStart(time.Date(2024, 5, 5, 23, 59, 0, 0, time.UTC), time.UTC))
+
+	berlin, _ := time.LoadLocation("Europe/Berlin")
+	assert.Equal(t, time.Date(2024, 5, 6, 0, 0, 0, 0, berlin), weekStart(time.Date(2024, 5, 5, 23, 0, 0, 0, time.UTC), berlin))
+}
+
+func TestLocalWeekEnd(t *testing.T) {
+	run := time.Date(2024, 3, 25, 0, 0, 0, 0, time.UTC)
+	berlin, _ := time.LoadLocation("Europe/Berlin")
+	newYork, _ := time.LoadLocation("America/New_York")
+
+	assert.Equal(t, run, localWeekEnd(run, time.UTC))
+	assert.Equal(t, time.Date(2024, 3, 25, 0, 0, 0, 0, berlin), localWeekEnd(run, berlin))
+	assert.Equal(t, time.Date(2024, 3, 25, 0, 0, 0, 0, newYork), localWeekEnd(run, newYork))
+
+	// the clocks of Berlin moved forward on March 31, that week is 167 hours
+	end := localWeekEnd(time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), berlin)
+	assert.Equal(t, 167*time.Hour, end.Sub(end.AddDate(0, 0, -7)))
+}
+
+func TestWorkspaceDigests(t *testing.T) {
+	defer func(bus *events.Bus) { events.Default = bus }(events.Default)
+
+	t.Run("should add a job per workspace and schedule the next week", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		queue := NewQueue(mockDb)
+		RegisterWorkspaceDigests(queue)
+		tokyo, _ := time.LoadLocation("Asia/Tokyo")
+		end := localWeekEnd(time.Now(), tokyo)
+		start := end.AddDate(0, 0, -7)
+
+		mockDb.On("ClaimNextJob", mock.Anything).Return(db.Job{Uuid: "workspaces.digest:20240429", Type: WorkspaceDigestsJob}, nil).Once()
+		mockDb.On("GetDigestWorkspaces").Return([]db.Workspace{{Uuid: "workspace-a", Timezone: "Asia/Tokyo"}}, nil).Once()
+		mockDb.On("GetJobByUuid", "workspace.digest:workspace-a:"+start.Format("20060102")).Return(db.Job{}, errors.New("no job found")).Once()
+		mockDb.On("EnqueueJob", mock.MatchedBy(func(j db.Job) bool {
+			return j.Type == WorkspaceDigestJob && j.Payload["workspace_uuid"] == "workspace-a" &&
+				j.Payload["period_end"] == end.Unix() && j.RunAt.Equal(end)
+		})).Return(db.Job{}, nil).Once()
+		mockDb.On("GetJobByUuid", mock.AnythingOfType("string")).Return(db.Job{}, errors.New("no job found")).Once()
+		mockDb.On("EnqueueJob", mock.MatchedBy(func(j db.Job) bool {
+			return j.Type == WorkspaceDigestsJob && j.RunAt.After(time.Now())
+		})).Return(db.Job{}, nil).Once()
+		mockDb.On("CompleteJob", "workspaces.digest:20240429").Return(nil).Once()
+
+		found, err := queue.ProcessNext(context.Background())
+
+		assert.True(t, found)
+		assert.NoError(t, err)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should save the digest and notify the members", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		queue := NewQueue(mockDb)
+		RegisterWorkspaceDigests(queue)
+		events.Default = events.NewBus(mockDb)
+		start := time.Date(2024, 4, 22, 0, 0, 0, 0, time.UTC)
+		workspace := db.Workspace{Uuid: "workspace-a", Name: "Sphinx", OwnerPubKey: "owner"}
+
+		job := db.Job{Uuid: "workspace.digest:workspace-a:20240422", Type: WorkspaceDigestJob, Payload: db.PropertyMap{"workspace_uuid": "workspace-a", "period_start": float64(start.Unix())}}
+		mockDb.On("ClaimNextJob", mock.Anything).Return(job, nil).Once()
+		mockDb.On("GetWorkspaceByUuid", "workspace-a").Return(workspace).Once()
+		mockDb.On("CreateWorkspaceDigest", "workspace-a", start, start.Add(digestPeriod)).Return(db.WorkspaceDigest{Uuid: "digest-uuid", NewBounties: 3}, nil).Once()
+		mockDb.On("GetWorkspaceMemberPubkeys", workspace).Return([]string{"owner", "member"}, nil).Once()
+		mockDb.On("CreateEvent", mock.MatchedBy(func(e db.Event) bool {
+			return e.Type == events.WorkspaceDigested && assert.ObjectsAreEqual([]string{"owner", "member"}, []string(e.PubKeys))
+		})).Return(db.Event{}, nil).Once()
+		mockDb.On("CompleteJob", job.Uuid).Return(nil).Once()
+
+		found, err := queue.ProcessNext(context.Background())
+
+		assert.True(t, found)
+		assert.NoError(t, err)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should summarize the week of the time zone of the workspace", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		queue := NewQueue(mockDb)
+		RegisterWorkspaceDigests(queue)
+		events.Default = events.NewBus(mockDb)
+		berlin, _ := time.LoadLocation("Europe/Berlin")
+		start := time.Date(2024, 3, 25, 0, 0, 0, 0, berlin)
+		end := time.Date(2024, 4, 1, 0, 0, 0, 0, berlin)
+		workspace := db.Workspace{Uuid: "workspace-a", Timezone: "Europe/Berlin"}
+
+		job := db.Job{Uuid: "workspace.digest:workspace-a:20240325", Type: WorkspaceDigestJob, Payload: db.PropertyMap{
+			"workspace_uuid": "workspace-a", "period_start": float64(start.Unix()), "period_end": float64(end.Unix())}}
+		mockDb.On("ClaimNextJob", mock.Anything).Return(job, nil).Once()
+		mockDb.On("GetWorkspaceByUuid", "workspace-a").Return(workspace).Once()
+		mockDb.On("CreateWorkspaceDigest", "workspace-a", mock.MatchedBy(func(s time.Time) bool {
+			return s.Equal(start) && s.Location().String() == "Europe/Berlin"
+		}), mock.MatchedBy(func(e time.Time) bool {
+			return e.Equal(end)
+		})).Return(db.WorkspaceDigest{}, nil).Once()
+		mockDb.On("CompleteJob", job.Uuid).Return(nil).Once()
+
+		_, err := queue.ProcessNext(context.Background())
+
+		assert.NoError(t, err)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should not notify about a quiet week", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		queue := NewQueue(mockDb)
+		RegisterWorkspaceDigests(queue)
+		events.Default = events.NewBus(mockDb)
+
+		job := db.Job{Uuid: "workspace.digest:workspace-a:20240422", Type: WorkspaceDigestJob, Payload: db.PropertyMap{"workspace_uuid": "workspace-a", "period_start": float64(1713744000)}}
+		mockDb.On("ClaimNextJob", mock.Anything).Return(job, nil).Once()
+		mockDb.On("GetWorkspaceByUuid", "workspace-a").Return(db.Workspace{Uuid: "workspace-a"}).Once()
+		mockDb.On("CreateWorkspaceDigest", "workspace-a", mock.Anything, mock.Anything).Return(db.WorkspaceDigest{}, nil).Once()
+		mockDb.On("CompleteJob", job.Uuid).Return(nil).Once()
+
+		_, err := queue.ProcessNext(context.Background())
+
+		assert.NoError(t, err)
+		mockDb.AssertNotCalled(t, "CreateEvent", mock.Anything)
+		mockDb.AssertExpectations(t)
+	})
+}
+
+func TestScoreTribes(t *testing.T) {
+	defer func(bus *events.Bus) { events.Default = bus }(events.Default)
+	defer func(alive func(ctx context.Context, url string) bool) { FeedAlive = alive }(FeedAlive)
+	t.Setenv("RELAY_AUTH_KEY", "relay-key")
+	t.Setenv("SPAM_SCORE_THRESHOLD", "50")
+	config.InitConfig()
+
+	now := time.Now()
+	tribes := []db.Tribe{
+		{UUID: "spam", Name: "Free sats", OwnerPubKey: "newcomer", FeedURL: "https://feeds.example.com/dead"},
+		{UUID: "podcast", Name: "Podcast", OwnerPubKey: "veteran", FeedURL: "https://feeds.example.com/dead"},
+	}
+	longAgo := now.AddDate(-1, 0, 0)
+
+	mockDb := &dbMocks.Database{}
+	events.Default = events.NewBus(mockDb)
+	checked := 0
+	FeedAlive = func(ctx context.Context, url string) bool {
+		checked++
+		return false
+	}
+	mockDb.On("GetSpamScoringTribes").Return(tribes, nil).Once()
+	mockDb.On("GetTribeReportCounts").Return(map[string]int64{"spam": 3}, nil).Once()
+	mockDb.On("GetPeopleByPubkeys", []string{"newcomer", "veteran"}).Return([]db.Person{{OwnerPubKey: "veteran", Created: &longAgo}}).Once()
+	mockDb.On("SaveTribeSpamScore", mock.MatchedBy(func(s db.TribeSpamScore) bool {
+		return s.TribeUuid == "spam" && s.DeadFeed && s.Reports == 3 && s.Score == 53
+	}), 50.0).Return(true, nil).Once()
+	mockDb.On("SaveTribeSpamScore", mock.MatchedBy(func(s db.TribeSpamScore) bool {
+		return s.TribeUuid == "podcast" && s.Score == 15
+	}), 50.0).Return(false, nil).Once()
+	mockDb.On("CreateEvent", mock.MatchedBy(func(e db.Event) bool {
+		return e.Type == events.TribeShadowListed && e.Subject == "tribe:spam" && assert.ObjectsAreEqual([]string{"newcomer"}, []string(e.PubKeys))
+	})).Return(db.Event{}, nil).Once()
+
+	err := ScoreTribes(context.Background(), mockDb, now)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, checked)
+	mockDb.AssertExpectations(t)
+}
+
+func TestRunRetention(t *testing.T) {
+	now := time.Date(2024, 5, 1, 14, 20, 0, 0, time.UTC)
+	rules := []db.RetentionRule{
+		{Name: "expired_invoices", Action: db.RetentionPurge, Days: 90},
+		{Name: "finished_jobs", Action: db.RetentionPurge, Days: 0},
+		{Name: "bounty_events", Action: db.RetentionArchive, Days: 730},
+	}
+	mockDb := &dbMocks.Database{}
+	mockDb.On("ApplyRetentionRule", rules[0], now.AddDate(0, 0, -90), true).Return(int64(3), nil).Once()
+	mockDb.On("ApplyRetentionRule", rules[2], now.AddDate(0, 0, -730), true).Return(int64(0), errors.New("boom")).Once()
+	mockDb.On("CreateRetentionRuns", mock.MatchedBy(func(runs []db.RetentionRun) bool {
+		return len(runs) == 2 && runs[0].Affected == 3 && runs[0].DryRun && runs[1].Error == "boom"
+	})).Return(nil).Once()
+
+	runs, err := RunRetention(mockDb, rules, "job-uuid", now, true)
+
+	assert.EqualError(t, err, "retention rule bounty_events: boom")
+	assert.Len(t, runs, 2)
+	assert.Equal(t, "job-uuid", runs[0].JobUuid)
+	mockDb.AssertExpectations(t)
+}
@@ -0,0 +1,37 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/db"
+)
+
+const (
+	BountyRecommendationsJob      = "bounties.recommend"
+	bountyRecommendationsInterval = time.Hour
+)
+
+// RegisterBountyRecommendations ranks the open bounties for the active
+// people every hour. Each run schedules the next one
+func RegisterBountyRecommendations(q *Queue) {
+	q.Register(BountyRecommendationsJob, func(ctx context.Context, job db.Job) error {
+		now := time.Now()
+		pubkeys, err := q.db.GetRecommendationPubkeys(now)
+		if err != nil {
+			return err
+		}
+		if err := q.db.RefreshBountyRecommendations(pubkeys, now); err != nil {
+			return err
+		}
+		_, err = ScheduleBountyRecommendations(q, now.Add(bountyRecommendationsInterval))
+		return err
+	})
+}
+
+// ScheduleBountyRecommendations adds the recommendation run of the hour of
+// runAt, keyed by the hour like the stats aggregation
+func ScheduleBountyRecommendations(q *Queue, runAt time.Time) (db.Job, error) {
+	key := BountyRecommendationsJob + ":" + runAt.UTC().Format("2006010215")
+	return q.ScheduleOnce(key, BountyRecommendationsJob, nil, runAt)
+}
@@ -0,0 +1,89 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/db"
+)
+
+const (
+	RetentionJob      = "retention.run"
+	retentionInterval = 24 * time.Hour
+)
+
+// RetentionRules returns the default retention rules with the ages set in
+// RETENTION_DAYS
+func RetentionRules() []db.RetentionRule {
+	days := config.Get().RetentionDays
+	rules := make([]db.RetentionRule, 0, len(db.RetentionRules))
+	for _, rule := range db.RetentionRules {
+		if d, ok := days[rule.Name]; ok {
+			rule.Days = d
+		}
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+// RunRetention applies the rules that are on and records what they
+// changed. A failing rule doesn't stop the others, the first error is
+// returned after all of them ran
+func RunRetention(database db.Database, rules []db.RetentionRule, jobUuid string, now time.Time, dryRun bool) ([]db.RetentionRun, error) {
+	var firstErr error
+	runs := []db.RetentionRun{}
+	for _, rule := range rules {
+		if rule.Days <= 0 {
+			continue
+		}
+		before := now.AddDate(0, 0, -rule.Days)
+		affected, err := database.ApplyRetentionRule(rule, before, dryRun)
+		run := db.RetentionRun{
+			JobUuid:  jobUuid,
+			Rule:     rule.Name,
+			Action:   rule.Action,
+			DryRun:   dryRun,
+			Before:   &before,
+			Affected: affected,
+			Created:  &now,
+		}
+		if err != nil {
+			run.Error = err.Error()
+			if firstErr == nil {
+				firstErr = fmt.Errorf("retention rule %s: %w", rule.Name, err)
+			}
+		}
+		runs = append(runs, run)
+	}
+
+	if err := database.CreateRetentionRuns(runs); err != nil {
+		return runs, err
+	}
+	return runs, firstErr
+}
+
+// RegisterRetention runs the retention rules once a day. A run is dry when
+// RETENTION_DRY_RUN is set or its payload asks for it. Scheduled runs add
+// the next one first so a failing rule doesn't end the schedule
+func RegisterRetention(q *Queue) {
+	q.Register(RetentionJob, func(ctx context.Context, job db.Job) error {
+		now := time.Now()
+		if scheduled, _ := job.Payload["scheduled"].(bool); scheduled {
+			if _, err := ScheduleRetention(q, now.Add(retentionInterval)); err != nil {
+				return err
+			}
+		}
+		dryRun, _ := job.Payload["dry_run"].(bool)
+		_, err := RunRetention(q.db, RetentionRules(), job.Uuid, now, dryRun || config.Get().RetentionDryRun)
+		return err
+	})
+}
+
+// ScheduleRetention adds the retention run of the day of runAt, keyed by
+// the day like the stats aggregation
+func ScheduleRetention(q *Queue, runAt time.Time) (db.Job, error) {
+	key := RetentionJob + ":" + runAt.UTC().Format("20060102")
+	return q.ScheduleOnce(key, RetentionJob, map[string]interface{}{"scheduled": true}, runAt)
+}
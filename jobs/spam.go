@@ -0,0 +1,106 @@
+package jobs
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/events"
+)
+
+const (
+	TribeSpamJob      = "tribes.spam_score"
+	tribeSpamInterval = 24 * time.Hour
+	feedCheckTimeout  = 10 * time.Second
+)
+
+var feedClient = &http.Client{Timeout: feedCheckTimeout}
+
+// FeedAlive reports if the feed of a tribe still answers, tests replace it
+var FeedAlive = func(ctx context.Context, url string) bool {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return false
+	}
+	res, err := feedClient.Do(req)
+	if err != nil {
+		return false
+	}
+	res.Body.Close()
+	return res.StatusCode < http.StatusBadRequest
+}
+
+// RegisterTribeSpamScores scores every tribe once a day. The tribes that
+// reach the threshold are shadow-listed and their owners are told they can
+// appeal. Each run schedules the next one
+func RegisterTribeSpamScores(q *Queue) {
+	q.Register(TribeSpamJob, func(ctx context.Context, job db.Job) error {
+		now := time.Now()
+		if err := ScoreTribes(ctx, q.db, now); err != nil {
+			return err
+		}
+		_, err := ScheduleTribeSpamScores(q, now.Add(tribeSpamInterval))
+		return err
+	})
+}
+
+// ScoreTribes computes and stores the spam score of every tribe
+func ScoreTribes(ctx context.Context, database db.Database, now time.Time) error {
+	tribes, err := database.GetSpamScoringTribes()
+	if err != nil {
+		return err
+	}
+	reports, err := database.GetTribeReportCounts()
+	if err != nil {
+		return err
+	}
+
+	owners := []string{}
+	seen := map[string]bool{}
+	for _, tribe := range tribes {
+		if !seen[tribe.OwnerPubKey] {
+			seen[tribe.OwnerPubKey] = true
+			owners = append(owners, tribe.OwnerPubKey)
+		}
+	}
+
+	// tribes often share a feed, each one is fetched once
+	alive := map[string]bool{}
+	deadFeeds := map[string]bool{}
+	for _, tribe := range tribes {
+		if tribe.FeedURL == "" {
+			continue
+		}
+		if _, checked := alive[tribe.FeedURL]; !checked {
+			alive[tribe.FeedURL] = FeedAlive(ctx, tribe.FeedURL)
+		}
+		deadFeeds[tribe.UUID] = !alive[tribe.FeedURL]
+	}
+
+	signals := db.TribeSpamSignals{People: database.GetPeopleByPubkeys(owners), Reports: reports, DeadFeeds: deadFeeds}
+	threshold := float64(config.Get().SpamScoreThreshold)
+	for i, score := range db.ScoreTribes(tribes, signals, now) {
+		shadowListed, err := database.SaveTribeSpamScore(score, threshold)
+		if err != nil {
+			return err
+		}
+		if shadowListed {
+			tribe := tribes[i]
+			events.Publish(ctx, events.TribeShadowListed, "tribe:"+tribe.UUID, map[string]interface{}{
+				"uuid":         tribe.UUID,
+				"name":         tribe.Name,
+				"owner_pubkey": tribe.OwnerPubKey,
+				"score":        score.Score,
+			})
+		}
+	}
+	return nil
+}
+
+// ScheduleTribeSpamScores adds the spam scoring of the day of runAt
+func ScheduleTribeSpamScores(q *Queue, runAt time.Time) (db.Job, error) {
+	key := TribeSpamJob + ":" + runAt.UTC().Format("20060102")
+	return q.ScheduleOnce(key, TribeSpamJob, nil, runAt)
+}
@@ -0,0 +1,33 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/db"
+)
+
+const (
+	StatsAggregationJob      = "stats.aggregate"
+	statsAggregationInterval = time.Hour
+)
+
+// RegisterStatsAggregation refreshes the platform stats snapshot of the day
+// every hour. Each run schedules the next one
+func RegisterStatsAggregation(q *Queue) {
+	q.Register(StatsAggregationJob, func(ctx context.Context, job db.Job) error {
+		now := time.Now()
+		if _, err := q.db.AggregatePlatformStats(now); err != nil {
+			return err
+		}
+		_, err := ScheduleStatsAggregation(q, now.Add(statsAggregationInterval))
+		return err
+	})
+}
+
+// ScheduleStatsAggregation adds the aggregation run of the hour of runAt, the
+// run is keyed by the hour so instances starting together add it only once
+func ScheduleStatsAggregation(q *Queue, runAt time.Time) (db.Job, error) {
+	key := StatsAggregationJob + ":" + runAt.UTC().Format("2006010215")
+	return q.ScheduleOnce(key, StatsAggregationJob, nil, runAt)
+}
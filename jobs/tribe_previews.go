@@ -0,0 +1,49 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/db"
+)
+
+const TribePreviewJob = "tribe.preview"
+
+// TribePreviewFetcher reads the open graph metadata of the app url of a
+// tribe and renders its preview card
+type TribePreviewFetcher func(ctx context.Context, tribe db.Tribe) (db.TribePreview, error)
+
+// RegisterTribePreviews builds the preview cards of the tribes. A failed
+// fetch is recorded on the preview and retried by the queue
+func RegisterTribePreviews(q *Queue, fetch TribePreviewFetcher) {
+	q.Register(TribePreviewJob, func(ctx context.Context, job db.Job) error {
+		tribeUuid, _ := job.Payload["tribe_uuid"].(string)
+		if tribeUuid == "" {
+			return errors.New("the job has no tribe")
+		}
+		preview := q.db.GetTribePreview(tribeUuid)
+		tribe := q.db.GetTribe(tribeUuid)
+		// the tribe was deleted or its app url changed since the job was
+		// added, the newer job builds the preview
+		if preview.ID == 0 || tribe.UUID == "" || tribe.AppURL != preview.Url {
+			return nil
+		}
+
+		fetched, err := fetch(ctx, tribe)
+		if err != nil {
+			preview.Status = db.PreviewFailed
+			preview.Error = err.Error()
+			if failErr := q.db.CompleteTribePreview(preview, ""); failErr != nil {
+				return failErr
+			}
+			return err
+		}
+
+		fetched.ID = preview.ID
+		fetched.TribeUuid = tribe.UUID
+		fetched.Status = db.PreviewReady
+		fetched.Error = ""
+		return q.db.CompleteTribePreview(fetched, config.Host+"/tribes/"+tribe.UUID+"/preview.svg")
+	})
+}
@@ -0,0 +1,137 @@
+package jobs
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/events"
+)
+
+const TribeWebhookJob = "tribe.webhook"
+
+// an event older than this is not posted, a new dispatcher replays the
+// whole log
+const tribeWebhookMaxAge = 24 * time.Hour
+
+// tribeWebhookType is the lifecycle event a tribe event is to the webhooks,
+// empty when they are not posted it
+func tribeWebhookType(event db.Event) string {
+	switch event.Type {
+	case events.TribeCreated:
+		return db.TribeWebhookCreated
+	case events.TribeUpdated:
+		if deleted, _ := event.Payload["deleted"].(bool); deleted {
+			return db.TribeWebhookDeleted
+		}
+		return db.TribeWebhookUpdated
+	case events.TribeJoined:
+		return db.TribeWebhookMemberJoined
+	}
+	return ""
+}
+
+// RegisterTribeWebhooks posts the lifecycle events of the tribes to the
+// webhooks of their owners. A durable consumer adds a job per webhook for
+// every event, so a webhook that is down doesn't hold back the others and
+// its deliveries are retried by the queue with its exponential backoff
+func RegisterTribeWebhooks(q *Queue, bus *events.Bus, client *http.Client) {
+	bus.SubscribeDurable("tribe_webhooks", DispatchTribeWebhooks(q), events.TribeCreated, events.TribeUpdated, events.TribeJoined)
+	q.Register(TribeWebhookJob, func(ctx context.Context, job db.Job) error {
+		return deliverTribeWebhook(ctx, q.db, client, job)
+	})
+}
+
+// DispatchTribeWebhooks adds the delivery jobs of an event, one for every
+// webhook of the tribe that wants it
+func DispatchTribeWebhooks(q *Queue) events.Handler {
+	return func(ctx context.Context, event db.Event) error {
+		webhookType := tribeWebhookType(event)
+		if webhookType == "" || (event.Created != nil && time.Since(*event.Created) > tribeWebhookMaxAge) {
+			return nil
+		}
+		tribeUuid := strings.TrimPrefix(event.Subject, "tribe:")
+		webhooks, err := q.db.GetTribeWebhooks(tribeUuid)
+		if err != nil {
+			return err
+		}
+
+		payload := map[string]interface{}(event.Payload)
+		if webhookType == db.TribeWebhookMemberJoined {
+			payload = map[string]interface{}{"uuid": tribeUuid, "member_pubkey": event.Actor}
+		}
+		for _, webhook := range webhooks {
+			if !webhook.Wants(webhookType) {
+				continue
+			}
+			if _, err := q.Enqueue(TribeWebhookJob, map[string]interface{}{
+				"webhook_uuid": webhook.Uuid,
+				"type":         webhookType,
+				"event": events.WebhookEvent{
+					Uuid:    event.Uuid,
+					Type:    webhookType,
+					Subject: event.Subject,
+					Payload: payload,
+					Created: event.Created,
+				},
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// deliverTribeWebhook posts an event to a webhook, with the hex HMAC of the
+// body by the secret of the webhook in the x-hub-signature-256 header. A
+// response other than 2xx is an error so the job is retried
+func deliverTribeWebhook(ctx context.Context, database db.Database, client *http.Client, job db.Job) error {
+	webhookUuid, _ := job.Payload["webhook_uuid"].(string)
+	webhook := database.GetTribeWebhook(webhookUuid)
+	// the webhook was deleted since
+	if webhook.ID == 0 {
+		return nil
+	}
+	body, err := json.Marshal(job.Payload["event"])
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.Url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	mac := hmac.New(sha256.New, []byte(webhook.Secret))
+	mac.Write(body)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("x-hub-signature-256", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	if eventType, _ := job.Payload["type"].(string); eventType != "" {
+		req.Header.Set("x-tribe-event", eventType)
+	}
+
+	status := 0
+	res, err := client.Do(req)
+	if err == nil {
+		res.Body.Close()
+		status = res.StatusCode
+		if status < 200 || status > 299 {
+			err = fmt.Errorf("webhook answered %d", status)
+		}
+	}
+	deliveryErr := ""
+	if err != nil {
+		deliveryErr = err.Error()
+	}
+	if recordErr := database.RecordTribeWebhookDelivery(webhook.Uuid, status, deliveryErr); recordErr != nil {
+		fmt.Println("[jobs] could not record the delivery to webhook", webhook.Uuid, recordErr)
+	}
+	return err
+}
@@ -0,0 +1,118 @@
+package jobs
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/events"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDispatchTribeWebhooks(t *testing.T) {
+	now := time.Now()
+	webhooks := []db.TribeWebhook{
+		{Uuid: "all", TribeUuid: "tribe-uuid"},
+		{Uuid: "joins", TribeUuid: "tribe-uuid", Events: []string{db.TribeWebhookMemberJoined}},
+	}
+
+	t.Run("should add a job for every webhook that wants the event", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mockDb.On("GetTribeWebhooks", "tribe-uuid").Return(webhooks, nil).Once()
+		mockDb.On("EnqueueJob", mock.MatchedBy(func(j db.Job) bool {
+			return j.Type == TribeWebhookJob && j.Payload["webhook_uuid"] == "all" && j.Payload["type"] == db.TribeWebhookDeleted
+		})).Return(db.Job{}, nil).Once()
+
+		err := DispatchTribeWebhooks(NewQueue(mockDb))(context.Background(), db.Event{
+			Type:    events.TribeUpdated,
+			Subject: "tribe:tribe-uuid",
+			Payload: db.PropertyMap{"uuid": "tribe-uuid", "deleted": true},
+			Created: &now,
+		})
+
+		assert.NoError(t, err)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should name the member who joined", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mockDb.On("GetTribeWebhooks", "tribe-uuid").Return(webhooks, nil).Once()
+		mockDb.On("EnqueueJob", mock.MatchedBy(func(j db.Job) bool {
+			event := j.Payload["event"].(events.WebhookEvent)
+			return event.Type == db.TribeWebhookMemberJoined && event.Payload["member_pubkey"] == "member"
+		})).Return(db.Job{}, nil).Twice()
+
+		err := DispatchTribeWebhooks(NewQueue(mockDb))(context.Background(), db.Event{
+			Type:    events.TribeJoined,
+			Subject: "tribe:tribe-uuid",
+			Actor:   "member",
+			Created: &now,
+		})
+
+		assert.NoError(t, err)
+		mockDb.AssertExpectations(t)
+	})
+}
+
+func TestDeliverTribeWebhook(t *testing.T) {
+	var signature, eventType string
+	var body []byte
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		signature = r.Header.Get("x-hub-signature-256")
+		eventType = r.Header.Get("x-tribe-event")
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+	webhook := db.TribeWebhook{ID: 1, Uuid: "webhook-uuid", TribeUuid: "tribe-uuid", Url: server.URL, Secret: "secret"}
+	job := db.Job{Payload: db.PropertyMap{
+		"webhook_uuid": "webhook-uuid",
+		"type":         db.TribeWebhookCreated,
+		"event":        map[string]interface{}{"type": db.TribeWebhookCreated, "subject": "tribe:tribe-uuid"},
+	}}
+
+	t.Run("should post the event signed with the secret of the webhook", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mockDb.On("GetTribeWebhook", "webhook-uuid").Return(webhook).Once()
+		mockDb.On("RecordTribeWebhookDelivery", "webhook-uuid", http.StatusOK, "").Return(nil).Once()
+
+		err := deliverTribeWebhook(context.Background(), mockDb, server.Client(), job)
+
+		assert.NoError(t, err)
+		mac := hmac.New(sha256.New, []byte("secret"))
+		mac.Write(body)
+		assert.Equal(t, "sha256="+hex.EncodeToString(mac.Sum(nil)), signature)
+		assert.Equal(t, db.TribeWebhookCreated, eventType)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should fail so the queue retries when the webhook errors", func(t *testing.T) {
+		status = http.StatusBadGateway
+		mockDb := &dbMocks.Database{}
+		mockDb.On("GetTribeWebhook", "webhook-uuid").Return(webhook).Once()
+		mockDb.On("RecordTribeWebhookDelivery", "webhook-uuid", http.StatusBadGateway, "webhook answered 502").Return(nil).Once()
+
+		err := deliverTribeWebhook(context.Background(), mockDb, server.Client(), job)
+
+		assert.EqualError(t, err, "webhook answered 502")
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should drop the deliveries of a deleted webhook", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mockDb.On("GetTribeWebhook", "webhook-uuid").Return(db.TribeWebhook{}).Once()
+
+		assert.NoError(t, deliverTribeWebhook(context.Background(), mockDb, server.Client(), job))
+		mockDb.AssertNotCalled(t, "RecordTribeWebhookDelivery", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,35 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/stakwork/sphinx-tribes/db"
+)
+
+const WorkspaceExportJob = "workspace.export"
+
+// RegisterWorkspaceExport builds the bundle of a workspace export. A failed
+// attempt is recorded on the export and retried by the queue
+func RegisterWorkspaceExport(q *Queue) {
+	q.Register(WorkspaceExportJob, func(ctx context.Context, job db.Job) error {
+		exportUuid, _ := job.Payload["export_uuid"].(string)
+		workspaceUuid, _ := job.Payload["workspace_uuid"].(string)
+		if exportUuid == "" || workspaceUuid == "" {
+			return errors.New("the job has no export")
+		}
+
+		bundle, err := q.db.GetWorkspaceBundle(workspaceUuid)
+		if err == nil {
+			var body []byte
+			if body, err = json.Marshal(bundle); err == nil {
+				return q.db.CompleteWorkspaceExport(exportUuid, string(body), "")
+			}
+		}
+		if failErr := q.db.CompleteWorkspaceExport(exportUuid, "", err.Error()); failErr != nil {
+			return failErr
+		}
+		return err
+	})
+}
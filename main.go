@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
@@ -12,10 +13,14 @@ import (
 	"github.com/stakwork/sphinx-tribes/auth"
 	"github.com/stakwork/sphinx-tribes/config"
 	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/events"
 	"github.com/stakwork/sphinx-tribes/handlers"
+	"github.com/stakwork/sphinx-tribes/jobs"
 	"github.com/stakwork/sphinx-tribes/routes"
+	"github.com/stakwork/sphinx-tribes/search"
+	"github.com/stakwork/sphinx-tribes/tracing"
+	"github.com/stakwork/sphinx-tribes/upstream"
 	"github.com/stakwork/sphinx-tribes/websocket"
-	"gopkg.in/go-playground/validator.v9"
 )
 
 func main() {
@@ -26,28 +31,118 @@ func main() {
 		fmt.Println("no .env file")
 	}
 
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		runMigrate(os.Args[2:])
+		return
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "seed" {
+		runSeed()
+		return
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "admin" {
+		runAdmin(os.Args[2:])
+		return
+	}
+
+	shutdownTracer := tracing.InitTracer()
+	defer shutdownTracer(context.Background())
+
+	// Config has to be inited before JWT, if not it will lead to NO JWT error,
+	// and before the db that reads its pool settings
+	config.InitConfig()
+	switch config.Get().LightningBackend {
+	case config.LightningSandbox:
+		upstream.UseSandbox()
+	case config.LightningV2:
+		upstream.UseV2()
+	}
 	db.InitDB()
 	db.InitRedis()
 	db.InitCache()
+	db.InitReadCache()
+	db.InitDedup()
+	db.InitBountyViews()
 	db.InitRoles()
-	// Config has to be inited before JWT, if not it will lead to NO JWT error
-	config.InitConfig()
 	auth.InitJwt()
 
-	// validate
-	db.Validate = validator.New()
 	// Start websocket pool
 	go websocket.WebsocketPool.Start()
+	websocket.WebsocketPool.StartRedisBridge(context.Background())
+
+	jobs.InitQueue(db.DB)
+	jobs.RegisterStatsAggregation(jobs.Default)
+	jobs.RegisterRetention(jobs.Default)
+	jobs.RegisterWorkspaceExport(jobs.Default)
+	jobs.RegisterBountyRecommendations(jobs.Default)
+	jobs.RegisterWorkspaceDigests(jobs.Default)
+	jobs.RegisterTribeSpamScores(jobs.Default)
+	jobs.RegisterBountyPriceSuggestions(jobs.Default)
+	jobs.RegisterWorkspaceGithubSync(jobs.Default, handlers.NewWorkspaceHandler(db.DB).GithubOrgLogins)
+	jobs.RegisterTribePreviews(jobs.Default, handlers.FetchTribePreview)
+	handlers.NewBountyHandler(upstream.Default, db.DB).RegisterAutoPay(jobs.Default)
+	handlers.NewUploadHandler(db.DB).RegisterUploadAssembly(jobs.Default)
+	events.InitBus(db.DB)
+	events.RegisterNotifications(events.Default, db.DB)
+	events.RegisterBountyRouting(events.Default, db.DB)
+	events.RegisterSkillVerification(events.Default, db.DB)
+	events.RegisterBountyCertificates(events.Default, db.DB)
+	events.RegisterWebhooks(events.Default, http.DefaultClient)
+	jobs.RegisterTribeWebhooks(jobs.Default, events.Default, handlers.WebhookClient)
+	search.Init(db.DB)
+	search.RegisterIndexer(events.Default, search.Default, db.DB)
+	search.RegisterReindex(jobs.Default, search.Default, db.DB)
+
+	go reloadConfigOnHangup()
+	go db.WatchMaintenance(context.Background(), db.DB, 5*time.Second)
+	go db.WatchBountyViews(context.Background(), db.DB, time.Minute)
 
-	skipLoops := os.Getenv("SKIP_LOOPS")
-	if skipLoops != "true" {
+	if !config.Get().SkipLoops {
 		go handlers.ProcessTwitterConfirmationsLoop()
 		go handlers.ProcessGithubIssuesLoop()
+		if _, err := jobs.ScheduleStatsAggregation(jobs.Default, time.Now()); err != nil {
+			fmt.Println("[jobs] could not schedule the stats aggregation", err)
+		}
+		if _, err := jobs.ScheduleRetention(jobs.Default, time.Now()); err != nil {
+			fmt.Println("[jobs] could not schedule the retention run", err)
+		}
+		if _, err := jobs.ScheduleBountyRecommendations(jobs.Default, time.Now()); err != nil {
+			fmt.Println("[jobs] could not schedule the bounty recommendations", err)
+		}
+		if _, err := jobs.ScheduleWorkspaceDigests(jobs.Default, time.Now()); err != nil {
+			fmt.Println("[jobs] could not schedule the workspace digests", err)
+		}
+		if _, err := jobs.ScheduleTribeSpamScores(jobs.Default, time.Now()); err != nil {
+			fmt.Println("[jobs] could not schedule the tribe spam scores", err)
+		}
+		if _, err := jobs.ScheduleBountyPriceSuggestions(jobs.Default, time.Now()); err != nil {
+			fmt.Println("[jobs] could not schedule the bounty price suggestions", err)
+		}
+		if _, err := jobs.ScheduleWorkspaceGithubSync(jobs.Default, time.Now()); err != nil {
+			fmt.Println("[jobs] could not schedule the workspace github sync", err)
+		}
+		go jobs.Default.Start(context.Background())
+		go events.Default.Start(context.Background())
 	}
 
 	run()
 }
 
+// reloadConfigOnHangup reloads the runtime config on SIGHUP
+func reloadConfigOnHangup() {
+	hangup := make(chan os.Signal, 1)
+	signal.Notify(hangup, syscall.SIGHUP)
+	for range hangup {
+		changed, err := config.Reload()
+		if err != nil {
+			fmt.Println("[config] reload failed", err)
+			continue
+		}
+		fmt.Println("[config] reloaded, changed:", changed)
+	}
+}
+
 // Start the MQTT plugin
 func run() {
 
@@ -0,0 +1,68 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSanitize(t *testing.T) {
+	policy := NewPolicy(strings.Split(config.DefaultHtmlAllowedTags, ","), strings.Split(config.DefaultHtmlAllowedAttributes, ","))
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"keeps plain markdown", "# Title\n\n> quote & **bold** 1 < 2", "# Title\n\n> quote & **bold** 1 < 2"},
+		{"drops scripts with their content", "hi<script>alert(1)</script> there", "hi there"},
+		{"drops event handlers", `<img src="https://x.io/a.png" onerror="alert(1)">`, `<img src="https://x.io/a.png">`},
+		{"drops javascript urls", `<a href="java	script:alert(1)">x</a>`, `<a>x</a>`},
+		{"keeps safe links", `<a href="https://sphinx.chat" title="s">x</a>`, `<a href="https://sphinx.chat" title="s">x</a>`},
+		{"drops tags out of the policy", `<div class="x"><b>bold</b></div>`, `<b>bold</b>`},
+		{"keeps autolinks", "see <https://sphinx.chat>", "see <https://sphinx.chat>"},
+		{"drops comments", "a<!-- <script> -->b", "ab"},
+		{"keeps text between angle brackets", "Vec<String> and </T>", "Vec&lt;String&gt; and &lt;/T&gt;"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, policy.Sanitize(test.input))
+		})
+	}
+
+	t.Run("follows the allowlist", func(t *testing.T) {
+		assert.Equal(t, `<div class="note">x</div>`, NewPolicy([]string{"div"}, []string{"class"}).Sanitize(`<div class="note" id="y">x</div>`))
+	})
+}
+
+func TestToHTML(t *testing.T) {
+	policy := NewPolicy(strings.Split(config.DefaultHtmlAllowedTags, ","), strings.Split(config.DefaultHtmlAllowedAttributes, ","))
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"heading and paragraph", "## Scope\nFix the **login** and _signup_ pages", "<h2>Scope</h2>\n<p>Fix the <strong>login</strong> and <em>signup</em> pages</p>\n"},
+		{"list", "- one\n- two `x < y`", "<ul>\n<li>one</li>\n<li>two <code>x &lt; y</code></li>\n</ul>\n"},
+		{"ordered list", "1. first\n2. second", "<ol>\n<li>first</li>\n<li>second</li>\n</ol>\n"},
+		{"fenced code", "```go\nfmt.Println(\"<b>\")\n```", "<pre><code class=\"language-go\">fmt.Println(&#34;&lt;b&gt;&#34;)</code></pre>\n"},
+		{"quote", "> be kind", "<blockquote>\n<p>be kind</p>\n</blockquote>\n"},
+		{"links and images", "[docs](https://sphinx.chat \"Docs\") ![logo](/img/logo.png)", "<p><a href=\"https://sphinx.chat\" title=\"Docs\">docs</a> <img src=\"/img/logo.png\" alt=\"logo\" /></p>\n"},
+		{"unsafe link", "[x](javascript:alert(1))", "<p><a>x</a>)</p>\n"},
+		{"autolink", "<https://sphinx.chat>", "<p><a href=\"https://sphinx.chat\">https://sphinx.chat</a></p>\n"},
+		{"raw html", "hi <script>alert(1)</script>~~old~~", "<p>hi <del>old</del></p>\n"},
+		{"snake_case stays", "use snake_case_names", "<p>use snake_case_names</p>\n"},
+		{"generics stay text", "returns a Vec<String>", "<p>returns a Vec&lt;String&gt;</p>\n"},
+		{"comparisons stay text", "a<b and c>d", "<p>a&lt;b and c&gt;d</p>\n"},
+		{"an open angle bracket stays text", "a<b", "<p>a&lt;b</p>\n"},
+		{"fenced generics", "```\nmap<string,int>\n```", "<pre><code>map&lt;string,int&gt;</code></pre>\n"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, policy.ToHTML(test.input))
+		})
+	}
+}
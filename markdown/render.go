@@ -0,0 +1,292 @@
+package markdown
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+var (
+	headingLine   = regexp.MustCompile(`^(#{1,6})[ \t]+(.*?)[ \t#]*$`)
+	ruleLine      = regexp.MustCompile(`^ {0,3}(-[ \t]*){3,}$|^ {0,3}(\*[ \t]*){3,}$|^ {0,3}(_[ \t]*){3,}$`)
+	bulletLine    = regexp.MustCompile(`^ {0,3}[-*+][ \t]+(.*)$`)
+	orderedLine   = regexp.MustCompile(`^ {0,3}\d{1,9}[.)][ \t]+(.*)$`)
+	fenceLine     = regexp.MustCompile("^ {0,3}(```+|~~~+)[ \t]*([^ \t`]*)")
+	htmlBlockLine = regexp.MustCompile(`^ {0,3}</?[a-zA-Z][a-zA-Z0-9]*[\s/>]`)
+	// an inline tag as commonmark reads raw html, a < that doesn't open one
+	// is text
+	inlineTag = regexp.MustCompile(`^(?:<[a-zA-Z][a-zA-Z0-9-]*(?:\s+[a-zA-Z_:][a-zA-Z0-9_.:-]*(?:\s*=\s*(?:"[^"]*"|'[^']*'|[^\s"'=<>` + "`" + `]+))?)*\s*/?>|</[a-zA-Z][a-zA-Z0-9-]*\s*>|<!--.*?-->)`)
+)
+
+// ToHTML renders markdown to html sanitized with the config policy. It
+// covers the markdown descriptions are written in: headings, paragraphs,
+// fenced code, quotes, lists, rules, emphasis, code, links and images.
+// The html in the markdown is kept as far as the policy allows
+func ToHTML(src string) string {
+	return ConfigPolicy().ToHTML(src)
+}
+
+// ToHTML renders markdown to html sanitized with p
+func (p Policy) ToHTML(src string) string {
+	src = strings.Replace(src, "\r\n", "\n", -1)
+	return p.Sanitize(renderBlocks(strings.Split(src, "\n")))
+}
+
+func renderBlocks(lines []string) string {
+	var b strings.Builder
+	paragraph := []string{}
+	flush := func() {
+		if len(paragraph) > 0 {
+			b.WriteString("<p>" + renderLines(paragraph) + "</p>\n")
+			paragraph = []string{}
+		}
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "":
+			flush()
+		case fenceLine.MatchString(line):
+			flush()
+			match := fenceLine.FindStringSubmatch(line)
+			fence, language := strings.TrimSpace(match[1]), match[2]
+			code := []string{}
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), fence); i++ {
+				code = append(code, lines[i])
+			}
+			class := ""
+			if language != "" {
+				class = ` class="language-` + html.EscapeString(language) + `"`
+			}
+			b.WriteString("<pre><code" + class + ">" + html.EscapeString(strings.Join(code, "\n")) + "</code></pre>\n")
+		case headingLine.MatchString(trimmed):
+			flush()
+			match := headingLine.FindStringSubmatch(trimmed)
+			level := string(rune('0' + len(match[1])))
+			b.WriteString("<h" + level + ">" + renderInline(match[2]) + "</h" + level + ">\n")
+		case ruleLine.MatchString(line):
+			flush()
+			b.WriteString("<hr />\n")
+		case strings.HasPrefix(trimmed, ">"):
+			flush()
+			quoted := []string{}
+			for ; i < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[i]), ">"); i++ {
+				quote := strings.TrimPrefix(strings.TrimSpace(lines[i]), ">")
+				quoted = append(quoted, strings.TrimPrefix(quote, " "))
+			}
+			i--
+			b.WriteString("<blockquote>\n" + renderBlocks(quoted) + "</blockquote>\n")
+		case bulletLine.MatchString(line), orderedLine.MatchString(line):
+			flush()
+			item, tag := bulletLine, "ul"
+			if !bulletLine.MatchString(line) {
+				item, tag = orderedLine, "ol"
+			}
+			items := [][]string{}
+			for ; i < len(lines); i++ {
+				if match := item.FindStringSubmatch(lines[i]); match != nil {
+					items = append(items, []string{match[1]})
+				} else if strings.TrimSpace(lines[i]) != "" && (strings.HasPrefix(lines[i], " ") || strings.HasPrefix(lines[i], "\t")) {
+					items[len(items)-1] = append(items[len(items)-1], strings.TrimSpace(lines[i]))
+				} else {
+					break
+				}
+			}
+			i--
+			b.WriteString("<" + tag + ">\n")
+			for _, lines := range items {
+				b.WriteString("<li>" + renderLines(lines) + "</li>\n")
+			}
+			b.WriteString("</" + tag + ">\n")
+		case htmlBlockLine.MatchString(line) && len(paragraph) == 0:
+			for ; i < len(lines) && strings.TrimSpace(lines[i]) != ""; i++ {
+				b.WriteString(lines[i] + "\n")
+			}
+		default:
+			paragraph = append(paragraph, line)
+		}
+	}
+	flush()
+	return b.String()
+}
+
+// renderLines renders the lines of a paragraph, a line that ends with two
+// spaces or a backslash breaks
+func renderLines(lines []string) string {
+	rendered := make([]string, len(lines))
+	for i, line := range lines {
+		hardBreak := i < len(lines)-1 && (strings.HasSuffix(line, "  ") || strings.HasSuffix(line, "\\"))
+		line = strings.TrimSpace(line)
+		if hardBreak {
+			line = strings.TrimSuffix(line, "\\")
+		}
+		rendered[i] = renderInline(line)
+		if hardBreak {
+			rendered[i] += "<br />"
+		}
+	}
+	return strings.Join(rendered, "\n")
+}
+
+func renderInline(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == '\\' && i+1 < len(s) && strings.IndexByte("\\`*_{}[]()#+-.!<>~|\"'", s[i+1]) >= 0:
+			b.WriteString(html.EscapeString(s[i+1 : i+2]))
+			i += 2
+			continue
+		case c == '`':
+			run := runLength(s, i, '`')
+			fence := s[i : i+run]
+			if end := strings.Index(s[i+run:], fence); end >= 0 {
+				code := strings.TrimSpace(s[i+run : i+run+end])
+				b.WriteString("<code>" + html.EscapeString(code) + "</code>")
+				i += run + end + run
+				continue
+			}
+			b.WriteString(fence)
+			i += run
+			continue
+		case c == '!' && i+1 < len(s) && s[i+1] == '[':
+			if text, href, title, end, ok := parseLink(s, i+1); ok {
+				b.WriteString(`<img src="` + html.EscapeString(href) + `" alt="` + html.EscapeString(text) + `"` + titleAttribute(title) + " />")
+				i = end
+				continue
+			}
+		case c == '[':
+			if text, href, title, end, ok := parseLink(s, i); ok {
+				b.WriteString(`<a href="` + html.EscapeString(href) + `"` + titleAttribute(title) + ">" + renderInline(text) + "</a>")
+				i = end
+				continue
+			}
+		case c == '<':
+			if end := strings.IndexByte(s[i:], '>'); end > 0 && autolink.MatchString(s[i:i+end+1]) {
+				target := s[i+1 : i+end]
+				href := target
+				if !strings.Contains(target, ":") {
+					href = "mailto:" + target
+				}
+				b.WriteString(`<a href="` + html.EscapeString(href) + `">` + html.EscapeString(target) + "</a>")
+				i += end + 1
+				continue
+			}
+			if tag := inlineTag.FindString(s[i:]); tag != "" {
+				b.WriteString(tag)
+				i += len(tag)
+				continue
+			}
+			b.WriteString("&lt;")
+			i++
+			continue
+		case c == '*' || c == '_' || c == '~':
+			if rendered, end, ok := emphasis(s, i); ok {
+				b.WriteString(rendered)
+				i = end
+				continue
+			}
+		}
+		b.WriteByte(c)
+		i++
+	}
+	return b.String()
+}
+
+// emphasis renders the emphasis that opens at i, **strong**, *em* or
+// ~~del~~, and returns where it ends
+func emphasis(s string, i int) (string, int, bool) {
+	c := s[i]
+	run := runLength(s, i, c)
+	if c == '_' && i > 0 && isWordByte(s[i-1]) {
+		return "", 0, false
+	}
+	tag := "em"
+	switch {
+	case c == '~' && run >= 2:
+		run, tag = 2, "del"
+	case c == '~':
+		return "", 0, false
+	case run >= 2:
+		run, tag = 2, "strong"
+	default:
+		run = 1
+	}
+	delimiter := s[i : i+run]
+	start := i + run
+	if start >= len(s) || s[start] == ' ' {
+		return "", 0, false
+	}
+	for from := start; from < len(s); {
+		end := strings.Index(s[from:], delimiter)
+		if end < 0 {
+			return "", 0, false
+		}
+		end += from
+		closes := end > start && s[end-1] != ' ' && (run == 2 || runLength(s, end, c) == 1)
+		if closes && c == '_' && end+run < len(s) && isWordByte(s[end+run]) {
+			closes = false
+		}
+		if closes {
+			return "<" + tag + ">" + renderInline(s[start:end]) + "</" + tag + ">", end + run, true
+		}
+		from = end + runLength(s, end, c)
+	}
+	return "", 0, false
+}
+
+// parseLink parses [text](href "title") from the [ at i and returns where
+// it ends
+func parseLink(s string, i int) (string, string, string, int, bool) {
+	depth := 0
+	closing := -1
+	for j := i; j < len(s) && closing < 0; j++ {
+		switch s[j] {
+		case '\\':
+			j++
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				closing = j
+			}
+		}
+	}
+	if closing < 0 || closing+1 >= len(s) || s[closing+1] != '(' {
+		return "", "", "", 0, false
+	}
+	end := strings.IndexByte(s[closing+2:], ')')
+	if end < 0 {
+		return "", "", "", 0, false
+	}
+	end += closing + 2
+	target := strings.TrimSpace(s[closing+2 : end])
+	href, title := target, ""
+	if space := strings.IndexAny(target, " \t"); space > 0 {
+		href = target[:space]
+		title = strings.Trim(strings.TrimSpace(target[space:]), `"'`)
+	}
+	return s[i+1 : closing], strings.Trim(href, "<>"), title, end + 1, true
+}
+
+func titleAttribute(title string) string {
+	if title == "" {
+		return ""
+	}
+	return ` title="` + html.EscapeString(title) + `"`
+}
+
+func runLength(s string, i int, c byte) int {
+	n := 0
+	for i+n < len(s) && s[i+n] == c {
+		n++
+	}
+	return n
+}
+
+func isWordByte(c byte) bool {
+	return c == '_' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
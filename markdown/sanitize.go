@@ -0,0 +1,179 @@
+package markdown
+
+import (
+	"html"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/stakwork/sphinx-tribes/config"
+	xhtml "golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// the elements that are dropped with their content, not only their tags
+var droppedContent = map[string]bool{
+	"script": true, "style": true, "iframe": true, "object": true, "embed": true,
+	"noscript": true, "noembed": true, "noframes": true, "template": true,
+	"textarea": true, "title": true, "xmp": true, "plaintext": true,
+}
+
+// the attributes that hold a url, they keep only the schemes of safeSchemes
+var urlAttributes = map[string]bool{"href": true, "src": true, "cite": true}
+
+var safeSchemes = map[string]bool{"": true, "http": true, "https": true, "mailto": true}
+
+// a markdown autolink, like <https://example.com> or <satoshi@example.com>.
+// Without quotes or = it can't carry an attribute with a value
+var autolink = regexp.MustCompile("^<(?:[a-zA-Z][a-zA-Z0-9+.-]*:|[^\\s<>\"'`=@]+@)[^\\s<>\"'`=]*>$")
+
+// Policy is the html a markdown field keeps
+type Policy struct {
+	tags       map[string]bool
+	attributes map[string]bool
+}
+
+// NewPolicy allows the tags and the attributes, an attribute is given as
+// tag:attribute or as an attribute allowed on every tag
+func NewPolicy(tags []string, attributes []string) Policy {
+	p := Policy{tags: map[string]bool{}, attributes: map[string]bool{}}
+	for _, tag := range tags {
+		if tag = strings.ToLower(strings.TrimSpace(tag)); tag != "" {
+			p.tags[tag] = true
+		}
+	}
+	for _, attribute := range attributes {
+		attribute = strings.ToLower(strings.TrimSpace(attribute))
+		if !strings.Contains(attribute, ":") {
+			attribute = "*:" + attribute
+		}
+		p.attributes[attribute] = true
+	}
+	return p
+}
+
+// ConfigPolicy is the policy of HTML_ALLOWED_TAGS and HTML_ALLOWED_ATTRIBUTES
+func ConfigPolicy() Policy {
+	cfg := config.Get()
+	tags, attributes := cfg.HtmlAllowedTags, cfg.HtmlAllowedAttributes
+	if len(tags) == 0 {
+		tags = strings.Split(config.DefaultHtmlAllowedTags, ",")
+	}
+	if len(attributes) == 0 {
+		attributes = strings.Split(config.DefaultHtmlAllowedAttributes, ",")
+	}
+	return NewPolicy(tags, attributes)
+}
+
+// Sanitize strips the tags and attributes p doesn't allow from the html s,
+// and the urls that aren't http, https or mailto. It is applied to rendered
+// html, the markdown of a field is stored as it was written. A tag that is
+// not html, like the <String> of Vec<String> or the <b and c> of
+// a<b and c>d, is text and is kept escaped
+func (p Policy) Sanitize(s string) string {
+	if !strings.Contains(s, "<") {
+		return s
+	}
+
+	var b strings.Builder
+	z := xhtml.NewTokenizer(strings.NewReader(s))
+	dropping := ""
+	for {
+		tokenType := z.Next()
+		if tokenType == xhtml.ErrorToken {
+			return b.String()
+		}
+		raw := string(z.Raw())
+		token := z.Token()
+
+		if dropping != "" {
+			if tokenType == xhtml.EndTagToken && token.Data == dropping {
+				dropping = ""
+			}
+			continue
+		}
+
+		switch tokenType {
+		case xhtml.TextToken:
+			b.WriteString(raw)
+		case xhtml.StartTagToken, xhtml.SelfClosingTagToken:
+			if droppedContent[token.Data] {
+				if tokenType == xhtml.StartTagToken {
+					dropping = token.Data
+				}
+				continue
+			}
+			if autolink.MatchString(raw) {
+				b.WriteString(raw)
+				continue
+			}
+			if !isHTML(token) {
+				b.WriteString(html.EscapeString(raw))
+				continue
+			}
+			if p.tags[token.Data] {
+				b.WriteString(p.startTag(token, tokenType == xhtml.SelfClosingTagToken))
+			}
+		case xhtml.EndTagToken:
+			if !isHTML(token) {
+				b.WriteString(html.EscapeString(raw))
+				continue
+			}
+			if p.tags[token.Data] {
+				b.WriteString("</" + token.Data + ">")
+			}
+		}
+	}
+}
+
+// isHTML reports if a tag is an html element with html attributes, and not
+// text between angle brackets
+func isHTML(token xhtml.Token) bool {
+	if token.DataAtom == 0 {
+		return false
+	}
+	for _, attr := range token.Attr {
+		if atom.Lookup([]byte(strings.ToLower(attr.Key))) == 0 && !strings.HasPrefix(attr.Key, "data-") && !strings.HasPrefix(attr.Key, "aria-") {
+			return false
+		}
+	}
+	return true
+}
+
+// startTag writes the tag with the attributes p allows
+func (p Policy) startTag(token xhtml.Token, selfClosing bool) string {
+	var b strings.Builder
+	b.WriteString("<" + token.Data)
+	for _, attr := range token.Attr {
+		key := strings.ToLower(attr.Key)
+		if attr.Namespace != "" || !(p.attributes[token.Data+":"+key] || p.attributes["*:"+key]) {
+			continue
+		}
+		if urlAttributes[key] && !safeUrl(attr.Val) {
+			continue
+		}
+		b.WriteString(" " + key + `="` + html.EscapeString(attr.Val) + `"`)
+	}
+	if selfClosing {
+		b.WriteString(" /")
+	}
+	b.WriteString(">")
+	return b.String()
+}
+
+// safeUrl reports if u is relative or http, https or mailto. Browsers skip
+// the whitespace and control characters of a url, so they are left out
+// before its scheme is read
+func safeUrl(u string) bool {
+	cleaned := strings.Map(func(r rune) rune {
+		if r <= ' ' || r == 0x7f {
+			return -1
+		}
+		return r
+	}, u)
+	parsed, err := url.Parse(cleaned)
+	if err != nil {
+		return false
+	}
+	return safeSchemes[strings.ToLower(parsed.Scheme)]
+}
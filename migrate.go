@@ -0,0 +1,68 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/stakwork/sphinx-tribes/db"
+)
+
+const migrateUsage = "usage: sphinx-tribes migrate up | down [steps] | status"
+
+// runMigrate handles the `migrate` subcommand
+func runMigrate(args []string) {
+	if len(args) == 0 {
+		fmt.Println(migrateUsage)
+		os.Exit(1)
+	}
+
+	db.ConnectDB()
+
+	switch args[0] {
+	case "up":
+		applied, err := db.DB.MigrateUp()
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		fmt.Printf("applied %d migrations\n", len(applied))
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			var err error
+			if steps, err = strconv.Atoi(args[1]); err != nil || steps < 1 {
+				fmt.Println(migrateUsage)
+				os.Exit(1)
+			}
+		}
+		rolledBack, err := db.DB.MigrateDown(steps)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		fmt.Printf("rolled back %d migrations\n", len(rolledBack))
+	case "status":
+		statuses, err := db.DB.MigrationStatuses()
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		for _, s := range statuses {
+			appliedAt := "pending"
+			if s.Applied {
+				appliedAt = s.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Printf("%5d  %-30s %s\n", s.Version, s.Name, appliedAt)
+		}
+		unknown, err := db.DB.UnknownMigrations()
+		if err == nil {
+			for _, u := range unknown {
+				fmt.Printf("%5d  %-30s %s (unknown to this build)\n", u.Version, u.Name, u.AppliedAt.Format("2006-01-02 15:04:05"))
+			}
+		}
+	default:
+		fmt.Println(migrateUsage)
+		os.Exit(1)
+	}
+}
@@ -3,6 +3,7 @@
 package db
 
 import (
+	context "context"
 	http "net/http"
 
 	db "github.com/stakwork/sphinx-tribes/db"
@@ -25,8 +26,112 @@ func (_m *Database) EXPECT() *Database_Expecter {
 	return &Database_Expecter{mock: &_m.Mock}
 }
 
+// AcceptBountyTerms provides a mock function with given fields: acceptance
+func (_m *Database) AcceptBountyTerms(acceptance db.BountyTermsAcceptance) (db.NewBounty, error) {
+	ret := _m.Called(acceptance)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AcceptBountyTerms")
+	}
+
+	var r0 db.NewBounty
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.BountyTermsAcceptance) (db.NewBounty, error)); ok {
+		return rf(acceptance)
+	}
+	if rf, ok := ret.Get(0).(func(db.BountyTermsAcceptance) db.NewBounty); ok {
+		r0 = rf(acceptance)
+	} else {
+		r0 = ret.Get(0).(db.NewBounty)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.BountyTermsAcceptance) error); ok {
+		r1 = rf(acceptance)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_AcceptBountyTerms_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AcceptBountyTerms'
+type Database_AcceptBountyTerms_Call struct {
+	*mock.Call
+}
+
+// AcceptBountyTerms is a helper method to define mock.On call
+//   - acceptance db.BountyTermsAcceptance
+func (_e *Database_Expecter) AcceptBountyTerms(acceptance interface{}) *Database_AcceptBountyTerms_Call {
+	return &Database_AcceptBountyTerms_Call{Call: _e.mock.On("AcceptBountyTerms", acceptance)}
+}
+
+func (_c *Database_AcceptBountyTerms_Call) Run(run func(acceptance db.BountyTermsAcceptance)) *Database_AcceptBountyTerms_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.BountyTermsAcceptance))
+	})
+	return _c
+}
+
+func (_c *Database_AcceptBountyTerms_Call) Return(_a0 db.NewBounty, _a1 error) *Database_AcceptBountyTerms_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_AcceptBountyTerms_Call) RunAndReturn(run func(db.BountyTermsAcceptance) (db.NewBounty, error)) *Database_AcceptBountyTerms_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AddAiSubmissionCost provides a mock function with given fields: kind, reference, cost
+func (_m *Database) AddAiSubmissionCost(kind string, reference string, cost float64) error {
+	ret := _m.Called(kind, reference, cost)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddAiSubmissionCost")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, float64) error); ok {
+		r0 = rf(kind, reference, cost)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_AddAiSubmissionCost_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddAiSubmissionCost'
+type Database_AddAiSubmissionCost_Call struct {
+	*mock.Call
+}
+
+// AddAiSubmissionCost is a helper method to define mock.On call
+//   - kind string
+//   - reference string
+//   - cost float64
+func (_e *Database_Expecter) AddAiSubmissionCost(kind interface{}, reference interface{}, cost interface{}) *Database_AddAiSubmissionCost_Call {
+	return &Database_AddAiSubmissionCost_Call{Call: _e.mock.On("AddAiSubmissionCost", kind, reference, cost)}
+}
+
+func (_c *Database_AddAiSubmissionCost_Call) Run(run func(kind string, reference string, cost float64)) *Database_AddAiSubmissionCost_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(float64))
+	})
+	return _c
+}
+
+func (_c *Database_AddAiSubmissionCost_Call) Return(_a0 error) *Database_AddAiSubmissionCost_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_AddAiSubmissionCost_Call) RunAndReturn(run func(string, string, float64) error) *Database_AddAiSubmissionCost_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // AddAndUpdateBudget provides a mock function with given fields: invoice
-func (_m *Database) AddAndUpdateBudget(invoice db.NewInvoiceList) db.NewPaymentHistory {
+func (_m *Database) AddAndUpdateBudget(invoice db.NewInvoiceList) (db.NewPaymentHistory, error) {
 	ret := _m.Called(invoice)
 
 	if len(ret) == 0 {
@@ -34,13 +139,23 @@ func (_m *Database) AddAndUpdateBudget(invoice db.NewInvoiceList) db.NewPaymentH
 	}
 
 	var r0 db.NewPaymentHistory
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.NewInvoiceList) (db.NewPaymentHistory, error)); ok {
+		return rf(invoice)
+	}
 	if rf, ok := ret.Get(0).(func(db.NewInvoiceList) db.NewPaymentHistory); ok {
 		r0 = rf(invoice)
 	} else {
 		r0 = ret.Get(0).(db.NewPaymentHistory)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(db.NewInvoiceList) error); ok {
+		r1 = rf(invoice)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // Database_AddAndUpdateBudget_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddAndUpdateBudget'
@@ -61,68 +176,58 @@ func (_c *Database_AddAndUpdateBudget_Call) Run(run func(invoice db.NewInvoiceLi
 	return _c
 }
 
-func (_c *Database_AddAndUpdateBudget_Call) Return(_a0 db.NewPaymentHistory) *Database_AddAndUpdateBudget_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_AddAndUpdateBudget_Call) Return(_a0 db.NewPaymentHistory, _a1 error) *Database_AddAndUpdateBudget_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_AddAndUpdateBudget_Call) RunAndReturn(run func(db.NewInvoiceList) db.NewPaymentHistory) *Database_AddAndUpdateBudget_Call {
+func (_c *Database_AddAndUpdateBudget_Call) RunAndReturn(run func(db.NewInvoiceList) (db.NewPaymentHistory, error)) *Database_AddAndUpdateBudget_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// AddBounty provides a mock function with given fields: b
-func (_m *Database) AddBounty(b db.Bounty) (db.Bounty, error) {
-	ret := _m.Called(b)
+// AddBountyViews provides a mock function with given fields: views
+func (_m *Database) AddBountyViews(views map[uint]db.BountyViews) error {
+	ret := _m.Called(views)
 
 	if len(ret) == 0 {
-		panic("no return value specified for AddBounty")
-	}
-
-	var r0 db.Bounty
-	var r1 error
-	if rf, ok := ret.Get(0).(func(db.Bounty) (db.Bounty, error)); ok {
-		return rf(b)
-	}
-	if rf, ok := ret.Get(0).(func(db.Bounty) db.Bounty); ok {
-		r0 = rf(b)
-	} else {
-		r0 = ret.Get(0).(db.Bounty)
+		panic("no return value specified for AddBountyViews")
 	}
 
-	if rf, ok := ret.Get(1).(func(db.Bounty) error); ok {
-		r1 = rf(b)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(map[uint]db.BountyViews) error); ok {
+		r0 = rf(views)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// Database_AddBounty_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddBounty'
-type Database_AddBounty_Call struct {
+// Database_AddBountyViews_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddBountyViews'
+type Database_AddBountyViews_Call struct {
 	*mock.Call
 }
 
-// AddBounty is a helper method to define mock.On call
-//   - b db.Bounty
-func (_e *Database_Expecter) AddBounty(b interface{}) *Database_AddBounty_Call {
-	return &Database_AddBounty_Call{Call: _e.mock.On("AddBounty", b)}
+// AddBountyViews is a helper method to define mock.On call
+//   - views map[uint]db.BountyViews
+func (_e *Database_Expecter) AddBountyViews(views interface{}) *Database_AddBountyViews_Call {
+	return &Database_AddBountyViews_Call{Call: _e.mock.On("AddBountyViews", views)}
 }
 
-func (_c *Database_AddBounty_Call) Run(run func(b db.Bounty)) *Database_AddBounty_Call {
+func (_c *Database_AddBountyViews_Call) Run(run func(views map[uint]db.BountyViews)) *Database_AddBountyViews_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.Bounty))
+		run(args[0].(map[uint]db.BountyViews))
 	})
 	return _c
 }
 
-func (_c *Database_AddBounty_Call) Return(_a0 db.Bounty, _a1 error) *Database_AddBounty_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *Database_AddBountyViews_Call) Return(_a0 error) *Database_AddBountyViews_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_AddBounty_Call) RunAndReturn(run func(db.Bounty) (db.Bounty, error)) *Database_AddBounty_Call {
+func (_c *Database_AddBountyViews_Call) RunAndReturn(run func(map[uint]db.BountyViews) error) *Database_AddBountyViews_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -173,6 +278,62 @@ func (_c *Database_AddBudgetHistory_Call) RunAndReturn(run func(db.BudgetHistory
 	return _c
 }
 
+// AddChatMessage provides a mock function with given fields: message
+func (_m *Database) AddChatMessage(message db.ChatMessage) (db.ChatMessage, error) {
+	ret := _m.Called(message)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddChatMessage")
+	}
+
+	var r0 db.ChatMessage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.ChatMessage) (db.ChatMessage, error)); ok {
+		return rf(message)
+	}
+	if rf, ok := ret.Get(0).(func(db.ChatMessage) db.ChatMessage); ok {
+		r0 = rf(message)
+	} else {
+		r0 = ret.Get(0).(db.ChatMessage)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.ChatMessage) error); ok {
+		r1 = rf(message)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_AddChatMessage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddChatMessage'
+type Database_AddChatMessage_Call struct {
+	*mock.Call
+}
+
+// AddChatMessage is a helper method to define mock.On call
+//   - message db.ChatMessage
+func (_e *Database_Expecter) AddChatMessage(message interface{}) *Database_AddChatMessage_Call {
+	return &Database_AddChatMessage_Call{Call: _e.mock.On("AddChatMessage", message)}
+}
+
+func (_c *Database_AddChatMessage_Call) Run(run func(message db.ChatMessage)) *Database_AddChatMessage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.ChatMessage))
+	})
+	return _c
+}
+
+func (_c *Database_AddChatMessage_Call) Return(_a0 db.ChatMessage, _a1 error) *Database_AddChatMessage_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_AddChatMessage_Call) RunAndReturn(run func(db.ChatMessage) (db.ChatMessage, error)) *Database_AddChatMessage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // AddInvoice provides a mock function with given fields: invoice
 func (_m *Database) AddInvoice(invoice db.NewInvoiceList) db.NewInvoiceList {
 	ret := _m.Called(invoice)
@@ -311,305 +472,368 @@ func (_c *Database_AddUserInvoiceData_Call) RunAndReturn(run func(db.UserInvoice
 	return _c
 }
 
-// AverageCompletedTime provides a mock function with given fields: r, workspace
-func (_m *Database) AverageCompletedTime(r db.PaymentDateRange, workspace string) uint {
-	ret := _m.Called(r, workspace)
+// AddWorkspaceVerificationAttempt provides a mock function with given fields: id
+func (_m *Database) AddWorkspaceVerificationAttempt(id uint) error {
+	ret := _m.Called(id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for AverageCompletedTime")
+		panic("no return value specified for AddWorkspaceVerificationAttempt")
 	}
 
-	var r0 uint
-	if rf, ok := ret.Get(0).(func(db.PaymentDateRange, string) uint); ok {
-		r0 = rf(r, workspace)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint) error); ok {
+		r0 = rf(id)
 	} else {
-		r0 = ret.Get(0).(uint)
+		r0 = ret.Error(0)
 	}
 
 	return r0
 }
 
-// Database_AverageCompletedTime_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AverageCompletedTime'
-type Database_AverageCompletedTime_Call struct {
+// Database_AddWorkspaceVerificationAttempt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddWorkspaceVerificationAttempt'
+type Database_AddWorkspaceVerificationAttempt_Call struct {
 	*mock.Call
 }
 
-// AverageCompletedTime is a helper method to define mock.On call
-//   - r db.PaymentDateRange
-//   - workspace string
-func (_e *Database_Expecter) AverageCompletedTime(r interface{}, workspace interface{}) *Database_AverageCompletedTime_Call {
-	return &Database_AverageCompletedTime_Call{Call: _e.mock.On("AverageCompletedTime", r, workspace)}
+// AddWorkspaceVerificationAttempt is a helper method to define mock.On call
+//   - id uint
+func (_e *Database_Expecter) AddWorkspaceVerificationAttempt(id interface{}) *Database_AddWorkspaceVerificationAttempt_Call {
+	return &Database_AddWorkspaceVerificationAttempt_Call{Call: _e.mock.On("AddWorkspaceVerificationAttempt", id)}
 }
 
-func (_c *Database_AverageCompletedTime_Call) Run(run func(r db.PaymentDateRange, workspace string)) *Database_AverageCompletedTime_Call {
+func (_c *Database_AddWorkspaceVerificationAttempt_Call) Run(run func(id uint)) *Database_AddWorkspaceVerificationAttempt_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.PaymentDateRange), args[1].(string))
+		run(args[0].(uint))
 	})
 	return _c
 }
 
-func (_c *Database_AverageCompletedTime_Call) Return(_a0 uint) *Database_AverageCompletedTime_Call {
+func (_c *Database_AddWorkspaceVerificationAttempt_Call) Return(_a0 error) *Database_AddWorkspaceVerificationAttempt_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_AverageCompletedTime_Call) RunAndReturn(run func(db.PaymentDateRange, string) uint) *Database_AverageCompletedTime_Call {
+func (_c *Database_AddWorkspaceVerificationAttempt_Call) RunAndReturn(run func(uint) error) *Database_AddWorkspaceVerificationAttempt_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// AveragePaidTime provides a mock function with given fields: r, workspace
-func (_m *Database) AveragePaidTime(r db.PaymentDateRange, workspace string) uint {
-	ret := _m.Called(r, workspace)
+// AggregatePlatformStats provides a mock function with given fields: at
+func (_m *Database) AggregatePlatformStats(at time.Time) (db.PlatformStats, error) {
+	ret := _m.Called(at)
 
 	if len(ret) == 0 {
-		panic("no return value specified for AveragePaidTime")
+		panic("no return value specified for AggregatePlatformStats")
 	}
 
-	var r0 uint
-	if rf, ok := ret.Get(0).(func(db.PaymentDateRange, string) uint); ok {
-		r0 = rf(r, workspace)
+	var r0 db.PlatformStats
+	var r1 error
+	if rf, ok := ret.Get(0).(func(time.Time) (db.PlatformStats, error)); ok {
+		return rf(at)
+	}
+	if rf, ok := ret.Get(0).(func(time.Time) db.PlatformStats); ok {
+		r0 = rf(at)
 	} else {
-		r0 = ret.Get(0).(uint)
+		r0 = ret.Get(0).(db.PlatformStats)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(time.Time) error); ok {
+		r1 = rf(at)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_AveragePaidTime_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AveragePaidTime'
-type Database_AveragePaidTime_Call struct {
+// Database_AggregatePlatformStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AggregatePlatformStats'
+type Database_AggregatePlatformStats_Call struct {
 	*mock.Call
 }
 
-// AveragePaidTime is a helper method to define mock.On call
-//   - r db.PaymentDateRange
-//   - workspace string
-func (_e *Database_Expecter) AveragePaidTime(r interface{}, workspace interface{}) *Database_AveragePaidTime_Call {
-	return &Database_AveragePaidTime_Call{Call: _e.mock.On("AveragePaidTime", r, workspace)}
+// AggregatePlatformStats is a helper method to define mock.On call
+//   - at time.Time
+func (_e *Database_Expecter) AggregatePlatformStats(at interface{}) *Database_AggregatePlatformStats_Call {
+	return &Database_AggregatePlatformStats_Call{Call: _e.mock.On("AggregatePlatformStats", at)}
 }
 
-func (_c *Database_AveragePaidTime_Call) Run(run func(r db.PaymentDateRange, workspace string)) *Database_AveragePaidTime_Call {
+func (_c *Database_AggregatePlatformStats_Call) Run(run func(at time.Time)) *Database_AggregatePlatformStats_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.PaymentDateRange), args[1].(string))
+		run(args[0].(time.Time))
 	})
 	return _c
 }
 
-func (_c *Database_AveragePaidTime_Call) Return(_a0 uint) *Database_AveragePaidTime_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_AggregatePlatformStats_Call) Return(_a0 db.PlatformStats, _a1 error) *Database_AggregatePlatformStats_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_AveragePaidTime_Call) RunAndReturn(run func(db.PaymentDateRange, string) uint) *Database_AveragePaidTime_Call {
+func (_c *Database_AggregatePlatformStats_Call) RunAndReturn(run func(time.Time) (db.PlatformStats, error)) *Database_AggregatePlatformStats_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// BountiesPaidPercentage provides a mock function with given fields: r, workspace
-func (_m *Database) BountiesPaidPercentage(r db.PaymentDateRange, workspace string) uint {
-	ret := _m.Called(r, workspace)
+// AllocateFeatureBudget provides a mock function with given fields: feature, amount, pubkey
+func (_m *Database) AllocateFeatureBudget(feature db.WorkspaceFeatures, amount uint, pubkey string) (db.WorkspaceFeatures, error) {
+	ret := _m.Called(feature, amount, pubkey)
 
 	if len(ret) == 0 {
-		panic("no return value specified for BountiesPaidPercentage")
+		panic("no return value specified for AllocateFeatureBudget")
 	}
 
-	var r0 uint
-	if rf, ok := ret.Get(0).(func(db.PaymentDateRange, string) uint); ok {
-		r0 = rf(r, workspace)
+	var r0 db.WorkspaceFeatures
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.WorkspaceFeatures, uint, string) (db.WorkspaceFeatures, error)); ok {
+		return rf(feature, amount, pubkey)
+	}
+	if rf, ok := ret.Get(0).(func(db.WorkspaceFeatures, uint, string) db.WorkspaceFeatures); ok {
+		r0 = rf(feature, amount, pubkey)
 	} else {
-		r0 = ret.Get(0).(uint)
+		r0 = ret.Get(0).(db.WorkspaceFeatures)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(db.WorkspaceFeatures, uint, string) error); ok {
+		r1 = rf(feature, amount, pubkey)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_BountiesPaidPercentage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BountiesPaidPercentage'
-type Database_BountiesPaidPercentage_Call struct {
+// Database_AllocateFeatureBudget_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AllocateFeatureBudget'
+type Database_AllocateFeatureBudget_Call struct {
 	*mock.Call
 }
 
-// BountiesPaidPercentage is a helper method to define mock.On call
-//   - r db.PaymentDateRange
-//   - workspace string
-func (_e *Database_Expecter) BountiesPaidPercentage(r interface{}, workspace interface{}) *Database_BountiesPaidPercentage_Call {
-	return &Database_BountiesPaidPercentage_Call{Call: _e.mock.On("BountiesPaidPercentage", r, workspace)}
+// AllocateFeatureBudget is a helper method to define mock.On call
+//   - feature db.WorkspaceFeatures
+//   - amount uint
+//   - pubkey string
+func (_e *Database_Expecter) AllocateFeatureBudget(feature interface{}, amount interface{}, pubkey interface{}) *Database_AllocateFeatureBudget_Call {
+	return &Database_AllocateFeatureBudget_Call{Call: _e.mock.On("AllocateFeatureBudget", feature, amount, pubkey)}
 }
 
-func (_c *Database_BountiesPaidPercentage_Call) Run(run func(r db.PaymentDateRange, workspace string)) *Database_BountiesPaidPercentage_Call {
+func (_c *Database_AllocateFeatureBudget_Call) Run(run func(feature db.WorkspaceFeatures, amount uint, pubkey string)) *Database_AllocateFeatureBudget_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.PaymentDateRange), args[1].(string))
+		run(args[0].(db.WorkspaceFeatures), args[1].(uint), args[2].(string))
 	})
 	return _c
 }
 
-func (_c *Database_BountiesPaidPercentage_Call) Return(_a0 uint) *Database_BountiesPaidPercentage_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_AllocateFeatureBudget_Call) Return(_a0 db.WorkspaceFeatures, _a1 error) *Database_AllocateFeatureBudget_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_BountiesPaidPercentage_Call) RunAndReturn(run func(db.PaymentDateRange, string) uint) *Database_BountiesPaidPercentage_Call {
+func (_c *Database_AllocateFeatureBudget_Call) RunAndReturn(run func(db.WorkspaceFeatures, uint, string) (db.WorkspaceFeatures, error)) *Database_AllocateFeatureBudget_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ChangeWorkspaceDeleteStatus provides a mock function with given fields: workspace_uuid, status
-func (_m *Database) ChangeWorkspaceDeleteStatus(workspace_uuid string, status bool) db.Workspace {
-	ret := _m.Called(workspace_uuid, status)
+// AppendUploadChunk provides a mock function with given fields: uuid, offset, data, at
+func (_m *Database) AppendUploadChunk(uuid string, offset int64, data []byte, at time.Time) (db.UploadSession, error) {
+	ret := _m.Called(uuid, offset, data, at)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ChangeWorkspaceDeleteStatus")
+		panic("no return value specified for AppendUploadChunk")
 	}
 
-	var r0 db.Workspace
-	if rf, ok := ret.Get(0).(func(string, bool) db.Workspace); ok {
-		r0 = rf(workspace_uuid, status)
+	var r0 db.UploadSession
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, int64, []byte, time.Time) (db.UploadSession, error)); ok {
+		return rf(uuid, offset, data, at)
+	}
+	if rf, ok := ret.Get(0).(func(string, int64, []byte, time.Time) db.UploadSession); ok {
+		r0 = rf(uuid, offset, data, at)
 	} else {
-		r0 = ret.Get(0).(db.Workspace)
+		r0 = ret.Get(0).(db.UploadSession)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(string, int64, []byte, time.Time) error); ok {
+		r1 = rf(uuid, offset, data, at)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_ChangeWorkspaceDeleteStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ChangeWorkspaceDeleteStatus'
-type Database_ChangeWorkspaceDeleteStatus_Call struct {
+// Database_AppendUploadChunk_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AppendUploadChunk'
+type Database_AppendUploadChunk_Call struct {
 	*mock.Call
 }
 
-// ChangeWorkspaceDeleteStatus is a helper method to define mock.On call
-//   - workspace_uuid string
-//   - status bool
-func (_e *Database_Expecter) ChangeWorkspaceDeleteStatus(workspace_uuid interface{}, status interface{}) *Database_ChangeWorkspaceDeleteStatus_Call {
-	return &Database_ChangeWorkspaceDeleteStatus_Call{Call: _e.mock.On("ChangeWorkspaceDeleteStatus", workspace_uuid, status)}
+// AppendUploadChunk is a helper method to define mock.On call
+//   - uuid string
+//   - offset int64
+//   - data []byte
+//   - at time.Time
+func (_e *Database_Expecter) AppendUploadChunk(uuid interface{}, offset interface{}, data interface{}, at interface{}) *Database_AppendUploadChunk_Call {
+	return &Database_AppendUploadChunk_Call{Call: _e.mock.On("AppendUploadChunk", uuid, offset, data, at)}
 }
 
-func (_c *Database_ChangeWorkspaceDeleteStatus_Call) Run(run func(workspace_uuid string, status bool)) *Database_ChangeWorkspaceDeleteStatus_Call {
+func (_c *Database_AppendUploadChunk_Call) Run(run func(uuid string, offset int64, data []byte, at time.Time)) *Database_AppendUploadChunk_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(bool))
+		run(args[0].(string), args[1].(int64), args[2].([]byte), args[3].(time.Time))
 	})
 	return _c
 }
 
-func (_c *Database_ChangeWorkspaceDeleteStatus_Call) Return(_a0 db.Workspace) *Database_ChangeWorkspaceDeleteStatus_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_AppendUploadChunk_Call) Return(_a0 db.UploadSession, _a1 error) *Database_AppendUploadChunk_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_ChangeWorkspaceDeleteStatus_Call) RunAndReturn(run func(string, bool) db.Workspace) *Database_ChangeWorkspaceDeleteStatus_Call {
+func (_c *Database_AppendUploadChunk_Call) RunAndReturn(run func(string, int64, []byte, time.Time) (db.UploadSession, error)) *Database_AppendUploadChunk_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CountBounties provides a mock function with given fields:
-func (_m *Database) CountBounties() uint64 {
-	ret := _m.Called()
+// ApplyBountyRouting provides a mock function with given fields: bountyID, plan
+func (_m *Database) ApplyBountyRouting(bountyID uint, plan db.BountyRoutingPlan) (db.NewBounty, bool, error) {
+	ret := _m.Called(bountyID, plan)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CountBounties")
+		panic("no return value specified for ApplyBountyRouting")
 	}
 
-	var r0 uint64
-	if rf, ok := ret.Get(0).(func() uint64); ok {
-		r0 = rf()
+	var r0 db.NewBounty
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(uint, db.BountyRoutingPlan) (db.NewBounty, bool, error)); ok {
+		return rf(bountyID, plan)
+	}
+	if rf, ok := ret.Get(0).(func(uint, db.BountyRoutingPlan) db.NewBounty); ok {
+		r0 = rf(bountyID, plan)
 	} else {
-		r0 = ret.Get(0).(uint64)
+		r0 = ret.Get(0).(db.NewBounty)
 	}
 
-	return r0
-}
+	if rf, ok := ret.Get(1).(func(uint, db.BountyRoutingPlan) bool); ok {
+		r1 = rf(bountyID, plan)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
 
-// Database_CountBounties_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountBounties'
-type Database_CountBounties_Call struct {
+	if rf, ok := ret.Get(2).(func(uint, db.BountyRoutingPlan) error); ok {
+		r2 = rf(bountyID, plan)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Database_ApplyBountyRouting_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ApplyBountyRouting'
+type Database_ApplyBountyRouting_Call struct {
 	*mock.Call
 }
 
-// CountBounties is a helper method to define mock.On call
-func (_e *Database_Expecter) CountBounties() *Database_CountBounties_Call {
-	return &Database_CountBounties_Call{Call: _e.mock.On("CountBounties")}
+// ApplyBountyRouting is a helper method to define mock.On call
+//   - bountyID uint
+//   - plan db.BountyRoutingPlan
+func (_e *Database_Expecter) ApplyBountyRouting(bountyID interface{}, plan interface{}) *Database_ApplyBountyRouting_Call {
+	return &Database_ApplyBountyRouting_Call{Call: _e.mock.On("ApplyBountyRouting", bountyID, plan)}
 }
 
-func (_c *Database_CountBounties_Call) Run(run func()) *Database_CountBounties_Call {
+func (_c *Database_ApplyBountyRouting_Call) Run(run func(bountyID uint, plan db.BountyRoutingPlan)) *Database_ApplyBountyRouting_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		run(args[0].(uint), args[1].(db.BountyRoutingPlan))
 	})
 	return _c
 }
 
-func (_c *Database_CountBounties_Call) Return(_a0 uint64) *Database_CountBounties_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_ApplyBountyRouting_Call) Return(_a0 db.NewBounty, _a1 bool, _a2 error) *Database_ApplyBountyRouting_Call {
+	_c.Call.Return(_a0, _a1, _a2)
 	return _c
 }
 
-func (_c *Database_CountBounties_Call) RunAndReturn(run func() uint64) *Database_CountBounties_Call {
+func (_c *Database_ApplyBountyRouting_Call) RunAndReturn(run func(uint, db.BountyRoutingPlan) (db.NewBounty, bool, error)) *Database_ApplyBountyRouting_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CountDevelopers provides a mock function with given fields:
-func (_m *Database) CountDevelopers() int64 {
-	ret := _m.Called()
+// ApplyRetentionRule provides a mock function with given fields: rule, before, dryRun
+func (_m *Database) ApplyRetentionRule(rule db.RetentionRule, before time.Time, dryRun bool) (int64, error) {
+	ret := _m.Called(rule, before, dryRun)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CountDevelopers")
+		panic("no return value specified for ApplyRetentionRule")
 	}
 
 	var r0 int64
-	if rf, ok := ret.Get(0).(func() int64); ok {
-		r0 = rf()
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.RetentionRule, time.Time, bool) (int64, error)); ok {
+		return rf(rule, before, dryRun)
+	}
+	if rf, ok := ret.Get(0).(func(db.RetentionRule, time.Time, bool) int64); ok {
+		r0 = rf(rule, before, dryRun)
 	} else {
 		r0 = ret.Get(0).(int64)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(db.RetentionRule, time.Time, bool) error); ok {
+		r1 = rf(rule, before, dryRun)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_CountDevelopers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountDevelopers'
-type Database_CountDevelopers_Call struct {
+// Database_ApplyRetentionRule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ApplyRetentionRule'
+type Database_ApplyRetentionRule_Call struct {
 	*mock.Call
 }
 
-// CountDevelopers is a helper method to define mock.On call
-func (_e *Database_Expecter) CountDevelopers() *Database_CountDevelopers_Call {
-	return &Database_CountDevelopers_Call{Call: _e.mock.On("CountDevelopers")}
+// ApplyRetentionRule is a helper method to define mock.On call
+//   - rule db.RetentionRule
+//   - before time.Time
+//   - dryRun bool
+func (_e *Database_Expecter) ApplyRetentionRule(rule interface{}, before interface{}, dryRun interface{}) *Database_ApplyRetentionRule_Call {
+	return &Database_ApplyRetentionRule_Call{Call: _e.mock.On("ApplyRetentionRule", rule, before, dryRun)}
 }
 
-func (_c *Database_CountDevelopers_Call) Run(run func()) *Database_CountDevelopers_Call {
+func (_c *Database_ApplyRetentionRule_Call) Run(run func(rule db.RetentionRule, before time.Time, dryRun bool)) *Database_ApplyRetentionRule_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		run(args[0].(db.RetentionRule), args[1].(time.Time), args[2].(bool))
 	})
 	return _c
 }
 
-func (_c *Database_CountDevelopers_Call) Return(_a0 int64) *Database_CountDevelopers_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_ApplyRetentionRule_Call) Return(_a0 int64, _a1 error) *Database_ApplyRetentionRule_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_CountDevelopers_Call) RunAndReturn(run func() int64) *Database_CountDevelopers_Call {
+func (_c *Database_ApplyRetentionRule_Call) RunAndReturn(run func(db.RetentionRule, time.Time, bool) (int64, error)) *Database_ApplyRetentionRule_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateChannel provides a mock function with given fields: c
-func (_m *Database) CreateChannel(c db.Channel) (db.Channel, error) {
-	ret := _m.Called(c)
+// ApproveReserveRelease provides a mock function with given fields: entry, pubkey
+func (_m *Database) ApproveReserveRelease(entry db.ReserveEntry, pubkey string) (db.NewBountyBudget, error) {
+	ret := _m.Called(entry, pubkey)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateChannel")
+		panic("no return value specified for ApproveReserveRelease")
 	}
 
-	var r0 db.Channel
+	var r0 db.NewBountyBudget
 	var r1 error
-	if rf, ok := ret.Get(0).(func(db.Channel) (db.Channel, error)); ok {
-		return rf(c)
+	if rf, ok := ret.Get(0).(func(db.ReserveEntry, string) (db.NewBountyBudget, error)); ok {
+		return rf(entry, pubkey)
 	}
-	if rf, ok := ret.Get(0).(func(db.Channel) db.Channel); ok {
-		r0 = rf(c)
+	if rf, ok := ret.Get(0).(func(db.ReserveEntry, string) db.NewBountyBudget); ok {
+		r0 = rf(entry, pubkey)
 	} else {
-		r0 = ret.Get(0).(db.Channel)
+		r0 = ret.Get(0).(db.NewBountyBudget)
 	}
 
-	if rf, ok := ret.Get(1).(func(db.Channel) error); ok {
-		r1 = rf(c)
+	if rf, ok := ret.Get(1).(func(db.ReserveEntry, string) error); ok {
+		r1 = rf(entry, pubkey)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -617,57 +841,56 @@ func (_m *Database) CreateChannel(c db.Channel) (db.Channel, error) {
 	return r0, r1
 }
 
-// Database_CreateChannel_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateChannel'
-type Database_CreateChannel_Call struct {
+// Database_ApproveReserveRelease_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ApproveReserveRelease'
+type Database_ApproveReserveRelease_Call struct {
 	*mock.Call
 }
 
-// CreateChannel is a helper method to define mock.On call
-//   - c db.Channel
-func (_e *Database_Expecter) CreateChannel(c interface{}) *Database_CreateChannel_Call {
-	return &Database_CreateChannel_Call{Call: _e.mock.On("CreateChannel", c)}
+// ApproveReserveRelease is a helper method to define mock.On call
+//   - entry db.ReserveEntry
+//   - pubkey string
+func (_e *Database_Expecter) ApproveReserveRelease(entry interface{}, pubkey interface{}) *Database_ApproveReserveRelease_Call {
+	return &Database_ApproveReserveRelease_Call{Call: _e.mock.On("ApproveReserveRelease", entry, pubkey)}
 }
 
-func (_c *Database_CreateChannel_Call) Run(run func(c db.Channel)) *Database_CreateChannel_Call {
+func (_c *Database_ApproveReserveRelease_Call) Run(run func(entry db.ReserveEntry, pubkey string)) *Database_ApproveReserveRelease_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.Channel))
+		run(args[0].(db.ReserveEntry), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *Database_CreateChannel_Call) Return(_a0 db.Channel, _a1 error) *Database_CreateChannel_Call {
+func (_c *Database_ApproveReserveRelease_Call) Return(_a0 db.NewBountyBudget, _a1 error) *Database_ApproveReserveRelease_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_CreateChannel_Call) RunAndReturn(run func(db.Channel) (db.Channel, error)) *Database_CreateChannel_Call {
+func (_c *Database_ApproveReserveRelease_Call) RunAndReturn(run func(db.ReserveEntry, string) (db.NewBountyBudget, error)) *Database_ApproveReserveRelease_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateConnectionCode provides a mock function with given fields: c
-func (_m *Database) CreateConnectionCode(c []db.ConnectionCodes) ([]db.ConnectionCodes, error) {
-	ret := _m.Called(c)
+// ApproveWorkspaceBrief provides a mock function with given fields: brief, pubkey
+func (_m *Database) ApproveWorkspaceBrief(brief db.WorkspaceBrief, pubkey string) (db.Workspace, error) {
+	ret := _m.Called(brief, pubkey)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateConnectionCode")
+		panic("no return value specified for ApproveWorkspaceBrief")
 	}
 
-	var r0 []db.ConnectionCodes
+	var r0 db.Workspace
 	var r1 error
-	if rf, ok := ret.Get(0).(func([]db.ConnectionCodes) ([]db.ConnectionCodes, error)); ok {
-		return rf(c)
+	if rf, ok := ret.Get(0).(func(db.WorkspaceBrief, string) (db.Workspace, error)); ok {
+		return rf(brief, pubkey)
 	}
-	if rf, ok := ret.Get(0).(func([]db.ConnectionCodes) []db.ConnectionCodes); ok {
-		r0 = rf(c)
+	if rf, ok := ret.Get(0).(func(db.WorkspaceBrief, string) db.Workspace); ok {
+		r0 = rf(brief, pubkey)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.ConnectionCodes)
-		}
+		r0 = ret.Get(0).(db.Workspace)
 	}
 
-	if rf, ok := ret.Get(1).(func([]db.ConnectionCodes) error); ok {
-		r1 = rf(c)
+	if rf, ok := ret.Get(1).(func(db.WorkspaceBrief, string) error); ok {
+		r1 = rf(brief, pubkey)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -675,338 +898,290 @@ func (_m *Database) CreateConnectionCode(c []db.ConnectionCodes) ([]db.Connectio
 	return r0, r1
 }
 
-// Database_CreateConnectionCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateConnectionCode'
-type Database_CreateConnectionCode_Call struct {
+// Database_ApproveWorkspaceBrief_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ApproveWorkspaceBrief'
+type Database_ApproveWorkspaceBrief_Call struct {
 	*mock.Call
 }
 
-// CreateConnectionCode is a helper method to define mock.On call
-//   - c []db.ConnectionCodes
-func (_e *Database_Expecter) CreateConnectionCode(c interface{}) *Database_CreateConnectionCode_Call {
-	return &Database_CreateConnectionCode_Call{Call: _e.mock.On("CreateConnectionCode", c)}
+// ApproveWorkspaceBrief is a helper method to define mock.On call
+//   - brief db.WorkspaceBrief
+//   - pubkey string
+func (_e *Database_Expecter) ApproveWorkspaceBrief(brief interface{}, pubkey interface{}) *Database_ApproveWorkspaceBrief_Call {
+	return &Database_ApproveWorkspaceBrief_Call{Call: _e.mock.On("ApproveWorkspaceBrief", brief, pubkey)}
 }
 
-func (_c *Database_CreateConnectionCode_Call) Run(run func(c []db.ConnectionCodes)) *Database_CreateConnectionCode_Call {
+func (_c *Database_ApproveWorkspaceBrief_Call) Run(run func(brief db.WorkspaceBrief, pubkey string)) *Database_ApproveWorkspaceBrief_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].([]db.ConnectionCodes))
+		run(args[0].(db.WorkspaceBrief), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *Database_CreateConnectionCode_Call) Return(_a0 []db.ConnectionCodes, _a1 error) *Database_CreateConnectionCode_Call {
+func (_c *Database_ApproveWorkspaceBrief_Call) Return(_a0 db.Workspace, _a1 error) *Database_ApproveWorkspaceBrief_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_CreateConnectionCode_Call) RunAndReturn(run func([]db.ConnectionCodes) ([]db.ConnectionCodes, error)) *Database_CreateConnectionCode_Call {
+func (_c *Database_ApproveWorkspaceBrief_Call) RunAndReturn(run func(db.WorkspaceBrief, string) (db.Workspace, error)) *Database_ApproveWorkspaceBrief_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateLeaderBoard provides a mock function with given fields: uuid, leaderboards
-func (_m *Database) CreateLeaderBoard(uuid string, leaderboards []db.LeaderBoard) ([]db.LeaderBoard, error) {
-	ret := _m.Called(uuid, leaderboards)
+// AverageCompletedTime provides a mock function with given fields: r, workspace
+func (_m *Database) AverageCompletedTime(r db.PaymentDateRange, workspace string) uint {
+	ret := _m.Called(r, workspace)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateLeaderBoard")
-	}
-
-	var r0 []db.LeaderBoard
-	var r1 error
-	if rf, ok := ret.Get(0).(func(string, []db.LeaderBoard) ([]db.LeaderBoard, error)); ok {
-		return rf(uuid, leaderboards)
-	}
-	if rf, ok := ret.Get(0).(func(string, []db.LeaderBoard) []db.LeaderBoard); ok {
-		r0 = rf(uuid, leaderboards)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.LeaderBoard)
-		}
+		panic("no return value specified for AverageCompletedTime")
 	}
 
-	if rf, ok := ret.Get(1).(func(string, []db.LeaderBoard) error); ok {
-		r1 = rf(uuid, leaderboards)
+	var r0 uint
+	if rf, ok := ret.Get(0).(func(db.PaymentDateRange, string) uint); ok {
+		r0 = rf(r, workspace)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Get(0).(uint)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// Database_CreateLeaderBoard_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateLeaderBoard'
-type Database_CreateLeaderBoard_Call struct {
+// Database_AverageCompletedTime_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AverageCompletedTime'
+type Database_AverageCompletedTime_Call struct {
 	*mock.Call
 }
 
-// CreateLeaderBoard is a helper method to define mock.On call
-//   - uuid string
-//   - leaderboards []db.LeaderBoard
-func (_e *Database_Expecter) CreateLeaderBoard(uuid interface{}, leaderboards interface{}) *Database_CreateLeaderBoard_Call {
-	return &Database_CreateLeaderBoard_Call{Call: _e.mock.On("CreateLeaderBoard", uuid, leaderboards)}
+// AverageCompletedTime is a helper method to define mock.On call
+//   - r db.PaymentDateRange
+//   - workspace string
+func (_e *Database_Expecter) AverageCompletedTime(r interface{}, workspace interface{}) *Database_AverageCompletedTime_Call {
+	return &Database_AverageCompletedTime_Call{Call: _e.mock.On("AverageCompletedTime", r, workspace)}
 }
 
-func (_c *Database_CreateLeaderBoard_Call) Run(run func(uuid string, leaderboards []db.LeaderBoard)) *Database_CreateLeaderBoard_Call {
+func (_c *Database_AverageCompletedTime_Call) Run(run func(r db.PaymentDateRange, workspace string)) *Database_AverageCompletedTime_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].([]db.LeaderBoard))
+		run(args[0].(db.PaymentDateRange), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *Database_CreateLeaderBoard_Call) Return(_a0 []db.LeaderBoard, _a1 error) *Database_CreateLeaderBoard_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *Database_AverageCompletedTime_Call) Return(_a0 uint) *Database_AverageCompletedTime_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_CreateLeaderBoard_Call) RunAndReturn(run func(string, []db.LeaderBoard) ([]db.LeaderBoard, error)) *Database_CreateLeaderBoard_Call {
+func (_c *Database_AverageCompletedTime_Call) RunAndReturn(run func(db.PaymentDateRange, string) uint) *Database_AverageCompletedTime_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateLnUser provides a mock function with given fields: lnKey
-func (_m *Database) CreateLnUser(lnKey string) (db.Person, error) {
-	ret := _m.Called(lnKey)
+// AveragePaidTime provides a mock function with given fields: r, workspace
+func (_m *Database) AveragePaidTime(r db.PaymentDateRange, workspace string) uint {
+	ret := _m.Called(r, workspace)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateLnUser")
-	}
-
-	var r0 db.Person
-	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (db.Person, error)); ok {
-		return rf(lnKey)
-	}
-	if rf, ok := ret.Get(0).(func(string) db.Person); ok {
-		r0 = rf(lnKey)
-	} else {
-		r0 = ret.Get(0).(db.Person)
+		panic("no return value specified for AveragePaidTime")
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(lnKey)
+	var r0 uint
+	if rf, ok := ret.Get(0).(func(db.PaymentDateRange, string) uint); ok {
+		r0 = rf(r, workspace)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Get(0).(uint)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// Database_CreateLnUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateLnUser'
-type Database_CreateLnUser_Call struct {
+// Database_AveragePaidTime_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AveragePaidTime'
+type Database_AveragePaidTime_Call struct {
 	*mock.Call
 }
 
-// CreateLnUser is a helper method to define mock.On call
-//   - lnKey string
-func (_e *Database_Expecter) CreateLnUser(lnKey interface{}) *Database_CreateLnUser_Call {
-	return &Database_CreateLnUser_Call{Call: _e.mock.On("CreateLnUser", lnKey)}
+// AveragePaidTime is a helper method to define mock.On call
+//   - r db.PaymentDateRange
+//   - workspace string
+func (_e *Database_Expecter) AveragePaidTime(r interface{}, workspace interface{}) *Database_AveragePaidTime_Call {
+	return &Database_AveragePaidTime_Call{Call: _e.mock.On("AveragePaidTime", r, workspace)}
 }
 
-func (_c *Database_CreateLnUser_Call) Run(run func(lnKey string)) *Database_CreateLnUser_Call {
+func (_c *Database_AveragePaidTime_Call) Run(run func(r db.PaymentDateRange, workspace string)) *Database_AveragePaidTime_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(db.PaymentDateRange), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *Database_CreateLnUser_Call) Return(_a0 db.Person, _a1 error) *Database_CreateLnUser_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *Database_AveragePaidTime_Call) Return(_a0 uint) *Database_AveragePaidTime_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_CreateLnUser_Call) RunAndReturn(run func(string) (db.Person, error)) *Database_CreateLnUser_Call {
+func (_c *Database_AveragePaidTime_Call) RunAndReturn(run func(db.PaymentDateRange, string) uint) *Database_AveragePaidTime_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateOrEditBot provides a mock function with given fields: b
-func (_m *Database) CreateOrEditBot(b db.Bot) (db.Bot, error) {
-	ret := _m.Called(b)
+// BanPubkey provides a mock function with given fields: ban
+func (_m *Database) BanPubkey(ban db.BannedPubkey) error {
+	ret := _m.Called(ban)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateOrEditBot")
-	}
-
-	var r0 db.Bot
-	var r1 error
-	if rf, ok := ret.Get(0).(func(db.Bot) (db.Bot, error)); ok {
-		return rf(b)
-	}
-	if rf, ok := ret.Get(0).(func(db.Bot) db.Bot); ok {
-		r0 = rf(b)
-	} else {
-		r0 = ret.Get(0).(db.Bot)
+		panic("no return value specified for BanPubkey")
 	}
 
-	if rf, ok := ret.Get(1).(func(db.Bot) error); ok {
-		r1 = rf(b)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(db.BannedPubkey) error); ok {
+		r0 = rf(ban)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// Database_CreateOrEditBot_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOrEditBot'
-type Database_CreateOrEditBot_Call struct {
+// Database_BanPubkey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BanPubkey'
+type Database_BanPubkey_Call struct {
 	*mock.Call
 }
 
-// CreateOrEditBot is a helper method to define mock.On call
-//   - b db.Bot
-func (_e *Database_Expecter) CreateOrEditBot(b interface{}) *Database_CreateOrEditBot_Call {
-	return &Database_CreateOrEditBot_Call{Call: _e.mock.On("CreateOrEditBot", b)}
+// BanPubkey is a helper method to define mock.On call
+//   - ban db.BannedPubkey
+func (_e *Database_Expecter) BanPubkey(ban interface{}) *Database_BanPubkey_Call {
+	return &Database_BanPubkey_Call{Call: _e.mock.On("BanPubkey", ban)}
 }
 
-func (_c *Database_CreateOrEditBot_Call) Run(run func(b db.Bot)) *Database_CreateOrEditBot_Call {
+func (_c *Database_BanPubkey_Call) Run(run func(ban db.BannedPubkey)) *Database_BanPubkey_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.Bot))
+		run(args[0].(db.BannedPubkey))
 	})
 	return _c
 }
 
-func (_c *Database_CreateOrEditBot_Call) Return(_a0 db.Bot, _a1 error) *Database_CreateOrEditBot_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *Database_BanPubkey_Call) Return(_a0 error) *Database_BanPubkey_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_CreateOrEditBot_Call) RunAndReturn(run func(db.Bot) (db.Bot, error)) *Database_CreateOrEditBot_Call {
+func (_c *Database_BanPubkey_Call) RunAndReturn(run func(db.BannedPubkey) error) *Database_BanPubkey_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateOrEditBounty provides a mock function with given fields: b
-func (_m *Database) CreateOrEditBounty(b db.NewBounty) (db.NewBounty, error) {
-	ret := _m.Called(b)
+// BountiesPaidPercentage provides a mock function with given fields: r, workspace
+func (_m *Database) BountiesPaidPercentage(r db.PaymentDateRange, workspace string) uint {
+	ret := _m.Called(r, workspace)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateOrEditBounty")
+		panic("no return value specified for BountiesPaidPercentage")
 	}
 
-	var r0 db.NewBounty
-	var r1 error
-	if rf, ok := ret.Get(0).(func(db.NewBounty) (db.NewBounty, error)); ok {
-		return rf(b)
-	}
-	if rf, ok := ret.Get(0).(func(db.NewBounty) db.NewBounty); ok {
-		r0 = rf(b)
+	var r0 uint
+	if rf, ok := ret.Get(0).(func(db.PaymentDateRange, string) uint); ok {
+		r0 = rf(r, workspace)
 	} else {
-		r0 = ret.Get(0).(db.NewBounty)
+		r0 = ret.Get(0).(uint)
 	}
 
-	if rf, ok := ret.Get(1).(func(db.NewBounty) error); ok {
-		r1 = rf(b)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
+	return r0
 }
 
-// Database_CreateOrEditBounty_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOrEditBounty'
-type Database_CreateOrEditBounty_Call struct {
+// Database_BountiesPaidPercentage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BountiesPaidPercentage'
+type Database_BountiesPaidPercentage_Call struct {
 	*mock.Call
 }
 
-// CreateOrEditBounty is a helper method to define mock.On call
-//   - b db.NewBounty
-func (_e *Database_Expecter) CreateOrEditBounty(b interface{}) *Database_CreateOrEditBounty_Call {
-	return &Database_CreateOrEditBounty_Call{Call: _e.mock.On("CreateOrEditBounty", b)}
+// BountiesPaidPercentage is a helper method to define mock.On call
+//   - r db.PaymentDateRange
+//   - workspace string
+func (_e *Database_Expecter) BountiesPaidPercentage(r interface{}, workspace interface{}) *Database_BountiesPaidPercentage_Call {
+	return &Database_BountiesPaidPercentage_Call{Call: _e.mock.On("BountiesPaidPercentage", r, workspace)}
 }
 
-func (_c *Database_CreateOrEditBounty_Call) Run(run func(b db.NewBounty)) *Database_CreateOrEditBounty_Call {
+func (_c *Database_BountiesPaidPercentage_Call) Run(run func(r db.PaymentDateRange, workspace string)) *Database_BountiesPaidPercentage_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.NewBounty))
+		run(args[0].(db.PaymentDateRange), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *Database_CreateOrEditBounty_Call) Return(_a0 db.NewBounty, _a1 error) *Database_CreateOrEditBounty_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *Database_BountiesPaidPercentage_Call) Return(_a0 uint) *Database_BountiesPaidPercentage_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_CreateOrEditBounty_Call) RunAndReturn(run func(db.NewBounty) (db.NewBounty, error)) *Database_CreateOrEditBounty_Call {
+func (_c *Database_BountiesPaidPercentage_Call) RunAndReturn(run func(db.PaymentDateRange, string) uint) *Database_BountiesPaidPercentage_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateOrEditFeature provides a mock function with given fields: m
-func (_m *Database) CreateOrEditFeature(m db.WorkspaceFeatures) (db.WorkspaceFeatures, error) {
-	ret := _m.Called(m)
+// ChangeWorkspaceDeleteStatus provides a mock function with given fields: workspace_uuid, status
+func (_m *Database) ChangeWorkspaceDeleteStatus(workspace_uuid string, status bool) db.Workspace {
+	ret := _m.Called(workspace_uuid, status)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateOrEditFeature")
-	}
-
-	var r0 db.WorkspaceFeatures
-	var r1 error
-	if rf, ok := ret.Get(0).(func(db.WorkspaceFeatures) (db.WorkspaceFeatures, error)); ok {
-		return rf(m)
-	}
-	if rf, ok := ret.Get(0).(func(db.WorkspaceFeatures) db.WorkspaceFeatures); ok {
-		r0 = rf(m)
-	} else {
-		r0 = ret.Get(0).(db.WorkspaceFeatures)
+		panic("no return value specified for ChangeWorkspaceDeleteStatus")
 	}
 
-	if rf, ok := ret.Get(1).(func(db.WorkspaceFeatures) error); ok {
-		r1 = rf(m)
+	var r0 db.Workspace
+	if rf, ok := ret.Get(0).(func(string, bool) db.Workspace); ok {
+		r0 = rf(workspace_uuid, status)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Get(0).(db.Workspace)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// Database_CreateOrEditFeature_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOrEditFeature'
-type Database_CreateOrEditFeature_Call struct {
+// Database_ChangeWorkspaceDeleteStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ChangeWorkspaceDeleteStatus'
+type Database_ChangeWorkspaceDeleteStatus_Call struct {
 	*mock.Call
 }
 
-// CreateOrEditFeature is a helper method to define mock.On call
-//   - m db.WorkspaceFeatures
-func (_e *Database_Expecter) CreateOrEditFeature(m interface{}) *Database_CreateOrEditFeature_Call {
-	return &Database_CreateOrEditFeature_Call{Call: _e.mock.On("CreateOrEditFeature", m)}
+// ChangeWorkspaceDeleteStatus is a helper method to define mock.On call
+//   - workspace_uuid string
+//   - status bool
+func (_e *Database_Expecter) ChangeWorkspaceDeleteStatus(workspace_uuid interface{}, status interface{}) *Database_ChangeWorkspaceDeleteStatus_Call {
+	return &Database_ChangeWorkspaceDeleteStatus_Call{Call: _e.mock.On("ChangeWorkspaceDeleteStatus", workspace_uuid, status)}
 }
 
-func (_c *Database_CreateOrEditFeature_Call) Run(run func(m db.WorkspaceFeatures)) *Database_CreateOrEditFeature_Call {
+func (_c *Database_ChangeWorkspaceDeleteStatus_Call) Run(run func(workspace_uuid string, status bool)) *Database_ChangeWorkspaceDeleteStatus_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.WorkspaceFeatures))
+		run(args[0].(string), args[1].(bool))
 	})
 	return _c
 }
 
-func (_c *Database_CreateOrEditFeature_Call) Return(_a0 db.WorkspaceFeatures, _a1 error) *Database_CreateOrEditFeature_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *Database_ChangeWorkspaceDeleteStatus_Call) Return(_a0 db.Workspace) *Database_ChangeWorkspaceDeleteStatus_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_CreateOrEditFeature_Call) RunAndReturn(run func(db.WorkspaceFeatures) (db.WorkspaceFeatures, error)) *Database_CreateOrEditFeature_Call {
+func (_c *Database_ChangeWorkspaceDeleteStatus_Call) RunAndReturn(run func(string, bool) db.Workspace) *Database_ChangeWorkspaceDeleteStatus_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateOrEditFeaturePhase provides a mock function with given fields: phase
-func (_m *Database) CreateOrEditFeaturePhase(phase db.FeaturePhase) (db.FeaturePhase, error) {
-	ret := _m.Called(phase)
+// ClaimBountyPayment provides a mock function with given fields: id
+func (_m *Database) ClaimBountyPayment(id uint) (bool, error) {
+	ret := _m.Called(id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateOrEditFeaturePhase")
+		panic("no return value specified for ClaimBountyPayment")
 	}
 
-	var r0 db.FeaturePhase
+	var r0 bool
 	var r1 error
-	if rf, ok := ret.Get(0).(func(db.FeaturePhase) (db.FeaturePhase, error)); ok {
-		return rf(phase)
+	if rf, ok := ret.Get(0).(func(uint) (bool, error)); ok {
+		return rf(id)
 	}
-	if rf, ok := ret.Get(0).(func(db.FeaturePhase) db.FeaturePhase); ok {
-		r0 = rf(phase)
+	if rf, ok := ret.Get(0).(func(uint) bool); ok {
+		r0 = rf(id)
 	} else {
-		r0 = ret.Get(0).(db.FeaturePhase)
+		r0 = ret.Get(0).(bool)
 	}
 
-	if rf, ok := ret.Get(1).(func(db.FeaturePhase) error); ok {
-		r1 = rf(phase)
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(id)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1014,55 +1189,55 @@ func (_m *Database) CreateOrEditFeaturePhase(phase db.FeaturePhase) (db.FeatureP
 	return r0, r1
 }
 
-// Database_CreateOrEditFeaturePhase_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOrEditFeaturePhase'
-type Database_CreateOrEditFeaturePhase_Call struct {
+// Database_ClaimBountyPayment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimBountyPayment'
+type Database_ClaimBountyPayment_Call struct {
 	*mock.Call
 }
 
-// CreateOrEditFeaturePhase is a helper method to define mock.On call
-//   - phase db.FeaturePhase
-func (_e *Database_Expecter) CreateOrEditFeaturePhase(phase interface{}) *Database_CreateOrEditFeaturePhase_Call {
-	return &Database_CreateOrEditFeaturePhase_Call{Call: _e.mock.On("CreateOrEditFeaturePhase", phase)}
+// ClaimBountyPayment is a helper method to define mock.On call
+//   - id uint
+func (_e *Database_Expecter) ClaimBountyPayment(id interface{}) *Database_ClaimBountyPayment_Call {
+	return &Database_ClaimBountyPayment_Call{Call: _e.mock.On("ClaimBountyPayment", id)}
 }
 
-func (_c *Database_CreateOrEditFeaturePhase_Call) Run(run func(phase db.FeaturePhase)) *Database_CreateOrEditFeaturePhase_Call {
+func (_c *Database_ClaimBountyPayment_Call) Run(run func(id uint)) *Database_ClaimBountyPayment_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.FeaturePhase))
+		run(args[0].(uint))
 	})
 	return _c
 }
 
-func (_c *Database_CreateOrEditFeaturePhase_Call) Return(_a0 db.FeaturePhase, _a1 error) *Database_CreateOrEditFeaturePhase_Call {
+func (_c *Database_ClaimBountyPayment_Call) Return(_a0 bool, _a1 error) *Database_ClaimBountyPayment_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_CreateOrEditFeaturePhase_Call) RunAndReturn(run func(db.FeaturePhase) (db.FeaturePhase, error)) *Database_CreateOrEditFeaturePhase_Call {
+func (_c *Database_ClaimBountyPayment_Call) RunAndReturn(run func(uint) (bool, error)) *Database_ClaimBountyPayment_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateOrEditFeatureStory provides a mock function with given fields: story
-func (_m *Database) CreateOrEditFeatureStory(story db.FeatureStory) (db.FeatureStory, error) {
-	ret := _m.Called(story)
+// ClaimNextJob provides a mock function with given fields: types
+func (_m *Database) ClaimNextJob(types []string) (db.Job, error) {
+	ret := _m.Called(types)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateOrEditFeatureStory")
+		panic("no return value specified for ClaimNextJob")
 	}
 
-	var r0 db.FeatureStory
+	var r0 db.Job
 	var r1 error
-	if rf, ok := ret.Get(0).(func(db.FeatureStory) (db.FeatureStory, error)); ok {
-		return rf(story)
+	if rf, ok := ret.Get(0).(func([]string) (db.Job, error)); ok {
+		return rf(types)
 	}
-	if rf, ok := ret.Get(0).(func(db.FeatureStory) db.FeatureStory); ok {
-		r0 = rf(story)
+	if rf, ok := ret.Get(0).(func([]string) db.Job); ok {
+		r0 = rf(types)
 	} else {
-		r0 = ret.Get(0).(db.FeatureStory)
+		r0 = ret.Get(0).(db.Job)
 	}
 
-	if rf, ok := ret.Get(1).(func(db.FeatureStory) error); ok {
-		r1 = rf(story)
+	if rf, ok := ret.Get(1).(func([]string) error); ok {
+		r1 = rf(types)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1070,167 +1245,148 @@ func (_m *Database) CreateOrEditFeatureStory(story db.FeatureStory) (db.FeatureS
 	return r0, r1
 }
 
-// Database_CreateOrEditFeatureStory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOrEditFeatureStory'
-type Database_CreateOrEditFeatureStory_Call struct {
+// Database_ClaimNextJob_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimNextJob'
+type Database_ClaimNextJob_Call struct {
 	*mock.Call
 }
 
-// CreateOrEditFeatureStory is a helper method to define mock.On call
-//   - story db.FeatureStory
-func (_e *Database_Expecter) CreateOrEditFeatureStory(story interface{}) *Database_CreateOrEditFeatureStory_Call {
-	return &Database_CreateOrEditFeatureStory_Call{Call: _e.mock.On("CreateOrEditFeatureStory", story)}
+// ClaimNextJob is a helper method to define mock.On call
+//   - types []string
+func (_e *Database_Expecter) ClaimNextJob(types interface{}) *Database_ClaimNextJob_Call {
+	return &Database_ClaimNextJob_Call{Call: _e.mock.On("ClaimNextJob", types)}
 }
 
-func (_c *Database_CreateOrEditFeatureStory_Call) Run(run func(story db.FeatureStory)) *Database_CreateOrEditFeatureStory_Call {
+func (_c *Database_ClaimNextJob_Call) Run(run func(types []string)) *Database_ClaimNextJob_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.FeatureStory))
+		run(args[0].([]string))
 	})
 	return _c
 }
 
-func (_c *Database_CreateOrEditFeatureStory_Call) Return(_a0 db.FeatureStory, _a1 error) *Database_CreateOrEditFeatureStory_Call {
+func (_c *Database_ClaimNextJob_Call) Return(_a0 db.Job, _a1 error) *Database_ClaimNextJob_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_CreateOrEditFeatureStory_Call) RunAndReturn(run func(db.FeatureStory) (db.FeatureStory, error)) *Database_CreateOrEditFeatureStory_Call {
+func (_c *Database_ClaimNextJob_Call) RunAndReturn(run func([]string) (db.Job, error)) *Database_ClaimNextJob_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateOrEditPerson provides a mock function with given fields: m
-func (_m *Database) CreateOrEditPerson(m db.Person) (db.Person, error) {
-	ret := _m.Called(m)
+// CompleteJob provides a mock function with given fields: uuid
+func (_m *Database) CompleteJob(uuid string) error {
+	ret := _m.Called(uuid)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateOrEditPerson")
-	}
-
-	var r0 db.Person
-	var r1 error
-	if rf, ok := ret.Get(0).(func(db.Person) (db.Person, error)); ok {
-		return rf(m)
-	}
-	if rf, ok := ret.Get(0).(func(db.Person) db.Person); ok {
-		r0 = rf(m)
-	} else {
-		r0 = ret.Get(0).(db.Person)
+		panic("no return value specified for CompleteJob")
 	}
 
-	if rf, ok := ret.Get(1).(func(db.Person) error); ok {
-		r1 = rf(m)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(uuid)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// Database_CreateOrEditPerson_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOrEditPerson'
-type Database_CreateOrEditPerson_Call struct {
+// Database_CompleteJob_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CompleteJob'
+type Database_CompleteJob_Call struct {
 	*mock.Call
 }
 
-// CreateOrEditPerson is a helper method to define mock.On call
-//   - m db.Person
-func (_e *Database_Expecter) CreateOrEditPerson(m interface{}) *Database_CreateOrEditPerson_Call {
-	return &Database_CreateOrEditPerson_Call{Call: _e.mock.On("CreateOrEditPerson", m)}
+// CompleteJob is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) CompleteJob(uuid interface{}) *Database_CompleteJob_Call {
+	return &Database_CompleteJob_Call{Call: _e.mock.On("CompleteJob", uuid)}
 }
 
-func (_c *Database_CreateOrEditPerson_Call) Run(run func(m db.Person)) *Database_CreateOrEditPerson_Call {
+func (_c *Database_CompleteJob_Call) Run(run func(uuid string)) *Database_CompleteJob_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.Person))
+		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *Database_CreateOrEditPerson_Call) Return(_a0 db.Person, _a1 error) *Database_CreateOrEditPerson_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *Database_CompleteJob_Call) Return(_a0 error) *Database_CompleteJob_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_CreateOrEditPerson_Call) RunAndReturn(run func(db.Person) (db.Person, error)) *Database_CreateOrEditPerson_Call {
+func (_c *Database_CompleteJob_Call) RunAndReturn(run func(string) error) *Database_CompleteJob_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateOrEditTribe provides a mock function with given fields: m
-func (_m *Database) CreateOrEditTribe(m db.Tribe) (db.Tribe, error) {
-	ret := _m.Called(m)
+// CompleteTribePreview provides a mock function with given fields: preview, previewUrl
+func (_m *Database) CompleteTribePreview(preview db.TribePreview, previewUrl string) error {
+	ret := _m.Called(preview, previewUrl)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateOrEditTribe")
-	}
-
-	var r0 db.Tribe
-	var r1 error
-	if rf, ok := ret.Get(0).(func(db.Tribe) (db.Tribe, error)); ok {
-		return rf(m)
-	}
-	if rf, ok := ret.Get(0).(func(db.Tribe) db.Tribe); ok {
-		r0 = rf(m)
-	} else {
-		r0 = ret.Get(0).(db.Tribe)
+		panic("no return value specified for CompleteTribePreview")
 	}
 
-	if rf, ok := ret.Get(1).(func(db.Tribe) error); ok {
-		r1 = rf(m)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(db.TribePreview, string) error); ok {
+		r0 = rf(preview, previewUrl)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// Database_CreateOrEditTribe_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOrEditTribe'
-type Database_CreateOrEditTribe_Call struct {
+// Database_CompleteTribePreview_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CompleteTribePreview'
+type Database_CompleteTribePreview_Call struct {
 	*mock.Call
 }
 
-// CreateOrEditTribe is a helper method to define mock.On call
-//   - m db.Tribe
-func (_e *Database_Expecter) CreateOrEditTribe(m interface{}) *Database_CreateOrEditTribe_Call {
-	return &Database_CreateOrEditTribe_Call{Call: _e.mock.On("CreateOrEditTribe", m)}
+// CompleteTribePreview is a helper method to define mock.On call
+//   - preview db.TribePreview
+//   - previewUrl string
+func (_e *Database_Expecter) CompleteTribePreview(preview interface{}, previewUrl interface{}) *Database_CompleteTribePreview_Call {
+	return &Database_CompleteTribePreview_Call{Call: _e.mock.On("CompleteTribePreview", preview, previewUrl)}
 }
 
-func (_c *Database_CreateOrEditTribe_Call) Run(run func(m db.Tribe)) *Database_CreateOrEditTribe_Call {
+func (_c *Database_CompleteTribePreview_Call) Run(run func(preview db.TribePreview, previewUrl string)) *Database_CompleteTribePreview_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.Tribe))
+		run(args[0].(db.TribePreview), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *Database_CreateOrEditTribe_Call) Return(_a0 db.Tribe, _a1 error) *Database_CreateOrEditTribe_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *Database_CompleteTribePreview_Call) Return(_a0 error) *Database_CompleteTribePreview_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_CreateOrEditTribe_Call) RunAndReturn(run func(db.Tribe) (db.Tribe, error)) *Database_CreateOrEditTribe_Call {
+func (_c *Database_CompleteTribePreview_Call) RunAndReturn(run func(db.TribePreview, string) error) *Database_CompleteTribePreview_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateOrEditWorkspace provides a mock function with given fields: m
-func (_m *Database) CreateOrEditWorkspace(m db.Workspace) (db.Workspace, error) {
-	ret := _m.Called(m)
+// CompleteUploadSession provides a mock function with given fields: uuid, url, errMsg
+func (_m *Database) CompleteUploadSession(uuid string, url string, errMsg string) (db.UploadSession, error) {
+	ret := _m.Called(uuid, url, errMsg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateOrEditWorkspace")
+		panic("no return value specified for CompleteUploadSession")
 	}
 
-	var r0 db.Workspace
+	var r0 db.UploadSession
 	var r1 error
-	if rf, ok := ret.Get(0).(func(db.Workspace) (db.Workspace, error)); ok {
-		return rf(m)
+	if rf, ok := ret.Get(0).(func(string, string, string) (db.UploadSession, error)); ok {
+		return rf(uuid, url, errMsg)
 	}
-	if rf, ok := ret.Get(0).(func(db.Workspace) db.Workspace); ok {
-		r0 = rf(m)
+	if rf, ok := ret.Get(0).(func(string, string, string) db.UploadSession); ok {
+		r0 = rf(uuid, url, errMsg)
 	} else {
-		r0 = ret.Get(0).(db.Workspace)
+		r0 = ret.Get(0).(db.UploadSession)
 	}
 
-	if rf, ok := ret.Get(1).(func(db.Workspace) error); ok {
-		r1 = rf(m)
+	if rf, ok := ret.Get(1).(func(string, string, string) error); ok {
+		r1 = rf(uuid, url, errMsg)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1238,243 +1394,242 @@ func (_m *Database) CreateOrEditWorkspace(m db.Workspace) (db.Workspace, error)
 	return r0, r1
 }
 
-// Database_CreateOrEditWorkspace_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOrEditWorkspace'
-type Database_CreateOrEditWorkspace_Call struct {
+// Database_CompleteUploadSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CompleteUploadSession'
+type Database_CompleteUploadSession_Call struct {
 	*mock.Call
 }
 
-// CreateOrEditWorkspace is a helper method to define mock.On call
-//   - m db.Workspace
-func (_e *Database_Expecter) CreateOrEditWorkspace(m interface{}) *Database_CreateOrEditWorkspace_Call {
-	return &Database_CreateOrEditWorkspace_Call{Call: _e.mock.On("CreateOrEditWorkspace", m)}
+// CompleteUploadSession is a helper method to define mock.On call
+//   - uuid string
+//   - url string
+//   - errMsg string
+func (_e *Database_Expecter) CompleteUploadSession(uuid interface{}, url interface{}, errMsg interface{}) *Database_CompleteUploadSession_Call {
+	return &Database_CompleteUploadSession_Call{Call: _e.mock.On("CompleteUploadSession", uuid, url, errMsg)}
 }
 
-func (_c *Database_CreateOrEditWorkspace_Call) Run(run func(m db.Workspace)) *Database_CreateOrEditWorkspace_Call {
+func (_c *Database_CompleteUploadSession_Call) Run(run func(uuid string, url string, errMsg string)) *Database_CompleteUploadSession_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.Workspace))
+		run(args[0].(string), args[1].(string), args[2].(string))
 	})
 	return _c
 }
 
-func (_c *Database_CreateOrEditWorkspace_Call) Return(_a0 db.Workspace, _a1 error) *Database_CreateOrEditWorkspace_Call {
+func (_c *Database_CompleteUploadSession_Call) Return(_a0 db.UploadSession, _a1 error) *Database_CompleteUploadSession_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_CreateOrEditWorkspace_Call) RunAndReturn(run func(db.Workspace) (db.Workspace, error)) *Database_CreateOrEditWorkspace_Call {
+func (_c *Database_CompleteUploadSession_Call) RunAndReturn(run func(string, string, string) (db.UploadSession, error)) *Database_CompleteUploadSession_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateOrEditWorkspaceRepository provides a mock function with given fields: m
-func (_m *Database) CreateOrEditWorkspaceRepository(m db.WorkspaceRepositories) (db.WorkspaceRepositories, error) {
-	ret := _m.Called(m)
+// CompleteWorkspaceExport provides a mock function with given fields: uuid, bundle, exportErr
+func (_m *Database) CompleteWorkspaceExport(uuid string, bundle string, exportErr string) error {
+	ret := _m.Called(uuid, bundle, exportErr)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateOrEditWorkspaceRepository")
-	}
-
-	var r0 db.WorkspaceRepositories
-	var r1 error
-	if rf, ok := ret.Get(0).(func(db.WorkspaceRepositories) (db.WorkspaceRepositories, error)); ok {
-		return rf(m)
-	}
-	if rf, ok := ret.Get(0).(func(db.WorkspaceRepositories) db.WorkspaceRepositories); ok {
-		r0 = rf(m)
-	} else {
-		r0 = ret.Get(0).(db.WorkspaceRepositories)
+		panic("no return value specified for CompleteWorkspaceExport")
 	}
 
-	if rf, ok := ret.Get(1).(func(db.WorkspaceRepositories) error); ok {
-		r1 = rf(m)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, string) error); ok {
+		r0 = rf(uuid, bundle, exportErr)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// Database_CreateOrEditWorkspaceRepository_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOrEditWorkspaceRepository'
-type Database_CreateOrEditWorkspaceRepository_Call struct {
+// Database_CompleteWorkspaceExport_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CompleteWorkspaceExport'
+type Database_CompleteWorkspaceExport_Call struct {
 	*mock.Call
 }
 
-// CreateOrEditWorkspaceRepository is a helper method to define mock.On call
-//   - m db.WorkspaceRepositories
-func (_e *Database_Expecter) CreateOrEditWorkspaceRepository(m interface{}) *Database_CreateOrEditWorkspaceRepository_Call {
-	return &Database_CreateOrEditWorkspaceRepository_Call{Call: _e.mock.On("CreateOrEditWorkspaceRepository", m)}
+// CompleteWorkspaceExport is a helper method to define mock.On call
+//   - uuid string
+//   - bundle string
+//   - exportErr string
+func (_e *Database_Expecter) CompleteWorkspaceExport(uuid interface{}, bundle interface{}, exportErr interface{}) *Database_CompleteWorkspaceExport_Call {
+	return &Database_CompleteWorkspaceExport_Call{Call: _e.mock.On("CompleteWorkspaceExport", uuid, bundle, exportErr)}
 }
 
-func (_c *Database_CreateOrEditWorkspaceRepository_Call) Run(run func(m db.WorkspaceRepositories)) *Database_CreateOrEditWorkspaceRepository_Call {
+func (_c *Database_CompleteWorkspaceExport_Call) Run(run func(uuid string, bundle string, exportErr string)) *Database_CompleteWorkspaceExport_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.WorkspaceRepositories))
+		run(args[0].(string), args[1].(string), args[2].(string))
 	})
 	return _c
 }
 
-func (_c *Database_CreateOrEditWorkspaceRepository_Call) Return(_a0 db.WorkspaceRepositories, _a1 error) *Database_CreateOrEditWorkspaceRepository_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *Database_CompleteWorkspaceExport_Call) Return(_a0 error) *Database_CompleteWorkspaceExport_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_CreateOrEditWorkspaceRepository_Call) RunAndReturn(run func(db.WorkspaceRepositories) (db.WorkspaceRepositories, error)) *Database_CreateOrEditWorkspaceRepository_Call {
+func (_c *Database_CompleteWorkspaceExport_Call) RunAndReturn(run func(string, string, string) error) *Database_CompleteWorkspaceExport_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateUserRoles provides a mock function with given fields: roles, uuid, pubkey
-func (_m *Database) CreateUserRoles(roles []db.WorkspaceUserRoles, uuid string, pubkey string) []db.WorkspaceUserRoles {
-	ret := _m.Called(roles, uuid, pubkey)
+// CountAiSubmissions provides a mock function with given fields: workspaceUuid, month
+func (_m *Database) CountAiSubmissions(workspaceUuid string, month time.Time) (int64, error) {
+	ret := _m.Called(workspaceUuid, month)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateUserRoles")
+		panic("no return value specified for CountAiSubmissions")
 	}
 
-	var r0 []db.WorkspaceUserRoles
-	if rf, ok := ret.Get(0).(func([]db.WorkspaceUserRoles, string, string) []db.WorkspaceUserRoles); ok {
-		r0 = rf(roles, uuid, pubkey)
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, time.Time) (int64, error)); ok {
+		return rf(workspaceUuid, month)
+	}
+	if rf, ok := ret.Get(0).(func(string, time.Time) int64); ok {
+		r0 = rf(workspaceUuid, month)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.WorkspaceUserRoles)
-		}
+		r0 = ret.Get(0).(int64)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(string, time.Time) error); ok {
+		r1 = rf(workspaceUuid, month)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_CreateUserRoles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateUserRoles'
-type Database_CreateUserRoles_Call struct {
+// Database_CountAiSubmissions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountAiSubmissions'
+type Database_CountAiSubmissions_Call struct {
 	*mock.Call
 }
 
-// CreateUserRoles is a helper method to define mock.On call
-//   - roles []db.WorkspaceUserRoles
-//   - uuid string
-//   - pubkey string
-func (_e *Database_Expecter) CreateUserRoles(roles interface{}, uuid interface{}, pubkey interface{}) *Database_CreateUserRoles_Call {
-	return &Database_CreateUserRoles_Call{Call: _e.mock.On("CreateUserRoles", roles, uuid, pubkey)}
+// CountAiSubmissions is a helper method to define mock.On call
+//   - workspaceUuid string
+//   - month time.Time
+func (_e *Database_Expecter) CountAiSubmissions(workspaceUuid interface{}, month interface{}) *Database_CountAiSubmissions_Call {
+	return &Database_CountAiSubmissions_Call{Call: _e.mock.On("CountAiSubmissions", workspaceUuid, month)}
 }
 
-func (_c *Database_CreateUserRoles_Call) Run(run func(roles []db.WorkspaceUserRoles, uuid string, pubkey string)) *Database_CreateUserRoles_Call {
+func (_c *Database_CountAiSubmissions_Call) Run(run func(workspaceUuid string, month time.Time)) *Database_CountAiSubmissions_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].([]db.WorkspaceUserRoles), args[1].(string), args[2].(string))
+		run(args[0].(string), args[1].(time.Time))
 	})
 	return _c
 }
 
-func (_c *Database_CreateUserRoles_Call) Return(_a0 []db.WorkspaceUserRoles) *Database_CreateUserRoles_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_CountAiSubmissions_Call) Return(_a0 int64, _a1 error) *Database_CountAiSubmissions_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_CreateUserRoles_Call) RunAndReturn(run func([]db.WorkspaceUserRoles, string, string) []db.WorkspaceUserRoles) *Database_CreateUserRoles_Call {
+func (_c *Database_CountAiSubmissions_Call) RunAndReturn(run func(string, time.Time) (int64, error)) *Database_CountAiSubmissions_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateWorkspaceBudget provides a mock function with given fields: budget
-func (_m *Database) CreateWorkspaceBudget(budget db.NewBountyBudget) db.NewBountyBudget {
-	ret := _m.Called(budget)
+// CountBounties provides a mock function with given fields:
+func (_m *Database) CountBounties() uint64 {
+	ret := _m.Called()
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateWorkspaceBudget")
+		panic("no return value specified for CountBounties")
 	}
 
-	var r0 db.NewBountyBudget
-	if rf, ok := ret.Get(0).(func(db.NewBountyBudget) db.NewBountyBudget); ok {
-		r0 = rf(budget)
+	var r0 uint64
+	if rf, ok := ret.Get(0).(func() uint64); ok {
+		r0 = rf()
 	} else {
-		r0 = ret.Get(0).(db.NewBountyBudget)
+		r0 = ret.Get(0).(uint64)
 	}
 
 	return r0
 }
 
-// Database_CreateWorkspaceBudget_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateWorkspaceBudget'
-type Database_CreateWorkspaceBudget_Call struct {
+// Database_CountBounties_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountBounties'
+type Database_CountBounties_Call struct {
 	*mock.Call
 }
 
-// CreateWorkspaceBudget is a helper method to define mock.On call
-//   - budget db.NewBountyBudget
-func (_e *Database_Expecter) CreateWorkspaceBudget(budget interface{}) *Database_CreateWorkspaceBudget_Call {
-	return &Database_CreateWorkspaceBudget_Call{Call: _e.mock.On("CreateWorkspaceBudget", budget)}
+// CountBounties is a helper method to define mock.On call
+func (_e *Database_Expecter) CountBounties() *Database_CountBounties_Call {
+	return &Database_CountBounties_Call{Call: _e.mock.On("CountBounties")}
 }
 
-func (_c *Database_CreateWorkspaceBudget_Call) Run(run func(budget db.NewBountyBudget)) *Database_CreateWorkspaceBudget_Call {
+func (_c *Database_CountBounties_Call) Run(run func()) *Database_CountBounties_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.NewBountyBudget))
+		run()
 	})
 	return _c
 }
 
-func (_c *Database_CreateWorkspaceBudget_Call) Return(_a0 db.NewBountyBudget) *Database_CreateWorkspaceBudget_Call {
+func (_c *Database_CountBounties_Call) Return(_a0 uint64) *Database_CountBounties_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_CreateWorkspaceBudget_Call) RunAndReturn(run func(db.NewBountyBudget) db.NewBountyBudget) *Database_CreateWorkspaceBudget_Call {
+func (_c *Database_CountBounties_Call) RunAndReturn(run func() uint64) *Database_CountBounties_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateWorkspaceUser provides a mock function with given fields: orgUser
-func (_m *Database) CreateWorkspaceUser(orgUser db.WorkspaceUsers) db.WorkspaceUsers {
-	ret := _m.Called(orgUser)
+// CountDevelopers provides a mock function with given fields:
+func (_m *Database) CountDevelopers() int64 {
+	ret := _m.Called()
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateWorkspaceUser")
+		panic("no return value specified for CountDevelopers")
 	}
 
-	var r0 db.WorkspaceUsers
-	if rf, ok := ret.Get(0).(func(db.WorkspaceUsers) db.WorkspaceUsers); ok {
-		r0 = rf(orgUser)
+	var r0 int64
+	if rf, ok := ret.Get(0).(func() int64); ok {
+		r0 = rf()
 	} else {
-		r0 = ret.Get(0).(db.WorkspaceUsers)
+		r0 = ret.Get(0).(int64)
 	}
 
 	return r0
 }
 
-// Database_CreateWorkspaceUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateWorkspaceUser'
-type Database_CreateWorkspaceUser_Call struct {
+// Database_CountDevelopers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountDevelopers'
+type Database_CountDevelopers_Call struct {
 	*mock.Call
 }
 
-// CreateWorkspaceUser is a helper method to define mock.On call
-//   - orgUser db.WorkspaceUsers
-func (_e *Database_Expecter) CreateWorkspaceUser(orgUser interface{}) *Database_CreateWorkspaceUser_Call {
-	return &Database_CreateWorkspaceUser_Call{Call: _e.mock.On("CreateWorkspaceUser", orgUser)}
+// CountDevelopers is a helper method to define mock.On call
+func (_e *Database_Expecter) CountDevelopers() *Database_CountDevelopers_Call {
+	return &Database_CountDevelopers_Call{Call: _e.mock.On("CountDevelopers")}
 }
 
-func (_c *Database_CreateWorkspaceUser_Call) Run(run func(orgUser db.WorkspaceUsers)) *Database_CreateWorkspaceUser_Call {
+func (_c *Database_CountDevelopers_Call) Run(run func()) *Database_CountDevelopers_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.WorkspaceUsers))
+		run()
 	})
 	return _c
 }
 
-func (_c *Database_CreateWorkspaceUser_Call) Return(_a0 db.WorkspaceUsers) *Database_CreateWorkspaceUser_Call {
+func (_c *Database_CountDevelopers_Call) Return(_a0 int64) *Database_CountDevelopers_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_CreateWorkspaceUser_Call) RunAndReturn(run func(db.WorkspaceUsers) db.WorkspaceUsers) *Database_CreateWorkspaceUser_Call {
+func (_c *Database_CountDevelopers_Call) RunAndReturn(run func() int64) *Database_CountDevelopers_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteAllUsersFromWorkspace provides a mock function with given fields: uuid
-func (_m *Database) DeleteAllUsersFromWorkspace(uuid string) error {
-	ret := _m.Called(uuid)
+// CountWorkspaceUsage provides a mock function with given fields: workspaceUuid, quota, at
+func (_m *Database) CountWorkspaceUsage(workspaceUuid string, quota string, at time.Time) error {
+	ret := _m.Called(workspaceUuid, quota, at)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteAllUsersFromWorkspace")
+		panic("no return value specified for CountWorkspaceUsage")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string) error); ok {
-		r0 = rf(uuid)
+	if rf, ok := ret.Get(0).(func(string, string, time.Time) error); ok {
+		r0 = rf(workspaceUuid, quota, at)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -1482,102 +1637,94 @@ func (_m *Database) DeleteAllUsersFromWorkspace(uuid string) error {
 	return r0
 }
 
-// Database_DeleteAllUsersFromWorkspace_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteAllUsersFromWorkspace'
-type Database_DeleteAllUsersFromWorkspace_Call struct {
+// Database_CountWorkspaceUsage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountWorkspaceUsage'
+type Database_CountWorkspaceUsage_Call struct {
 	*mock.Call
 }
 
-// DeleteAllUsersFromWorkspace is a helper method to define mock.On call
-//   - uuid string
-func (_e *Database_Expecter) DeleteAllUsersFromWorkspace(uuid interface{}) *Database_DeleteAllUsersFromWorkspace_Call {
-	return &Database_DeleteAllUsersFromWorkspace_Call{Call: _e.mock.On("DeleteAllUsersFromWorkspace", uuid)}
+// CountWorkspaceUsage is a helper method to define mock.On call
+//   - workspaceUuid string
+//   - quota string
+//   - at time.Time
+func (_e *Database_Expecter) CountWorkspaceUsage(workspaceUuid interface{}, quota interface{}, at interface{}) *Database_CountWorkspaceUsage_Call {
+	return &Database_CountWorkspaceUsage_Call{Call: _e.mock.On("CountWorkspaceUsage", workspaceUuid, quota, at)}
 }
 
-func (_c *Database_DeleteAllUsersFromWorkspace_Call) Run(run func(uuid string)) *Database_DeleteAllUsersFromWorkspace_Call {
+func (_c *Database_CountWorkspaceUsage_Call) Run(run func(workspaceUuid string, quota string, at time.Time)) *Database_CountWorkspaceUsage_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(string), args[1].(string), args[2].(time.Time))
 	})
 	return _c
 }
 
-func (_c *Database_DeleteAllUsersFromWorkspace_Call) Return(_a0 error) *Database_DeleteAllUsersFromWorkspace_Call {
+func (_c *Database_CountWorkspaceUsage_Call) Return(_a0 error) *Database_CountWorkspaceUsage_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_DeleteAllUsersFromWorkspace_Call) RunAndReturn(run func(string) error) *Database_DeleteAllUsersFromWorkspace_Call {
+func (_c *Database_CountWorkspaceUsage_Call) RunAndReturn(run func(string, string, time.Time) error) *Database_CountWorkspaceUsage_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteBounty provides a mock function with given fields: pubkey, created
-func (_m *Database) DeleteBounty(pubkey string, created string) (db.NewBounty, error) {
-	ret := _m.Called(pubkey, created)
+// CountWorkspacesCreatedSince provides a mock function with given fields: pubkey, since
+func (_m *Database) CountWorkspacesCreatedSince(pubkey string, since time.Time) int64 {
+	ret := _m.Called(pubkey, since)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteBounty")
-	}
-
-	var r0 db.NewBounty
-	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string) (db.NewBounty, error)); ok {
-		return rf(pubkey, created)
-	}
-	if rf, ok := ret.Get(0).(func(string, string) db.NewBounty); ok {
-		r0 = rf(pubkey, created)
-	} else {
-		r0 = ret.Get(0).(db.NewBounty)
+		panic("no return value specified for CountWorkspacesCreatedSince")
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(pubkey, created)
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(string, time.Time) int64); ok {
+		r0 = rf(pubkey, since)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Get(0).(int64)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// Database_DeleteBounty_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteBounty'
-type Database_DeleteBounty_Call struct {
+// Database_CountWorkspacesCreatedSince_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountWorkspacesCreatedSince'
+type Database_CountWorkspacesCreatedSince_Call struct {
 	*mock.Call
 }
 
-// DeleteBounty is a helper method to define mock.On call
+// CountWorkspacesCreatedSince is a helper method to define mock.On call
 //   - pubkey string
-//   - created string
-func (_e *Database_Expecter) DeleteBounty(pubkey interface{}, created interface{}) *Database_DeleteBounty_Call {
-	return &Database_DeleteBounty_Call{Call: _e.mock.On("DeleteBounty", pubkey, created)}
+//   - since time.Time
+func (_e *Database_Expecter) CountWorkspacesCreatedSince(pubkey interface{}, since interface{}) *Database_CountWorkspacesCreatedSince_Call {
+	return &Database_CountWorkspacesCreatedSince_Call{Call: _e.mock.On("CountWorkspacesCreatedSince", pubkey, since)}
 }
 
-func (_c *Database_DeleteBounty_Call) Run(run func(pubkey string, created string)) *Database_DeleteBounty_Call {
+func (_c *Database_CountWorkspacesCreatedSince_Call) Run(run func(pubkey string, since time.Time)) *Database_CountWorkspacesCreatedSince_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string))
+		run(args[0].(string), args[1].(time.Time))
 	})
 	return _c
 }
 
-func (_c *Database_DeleteBounty_Call) Return(_a0 db.NewBounty, _a1 error) *Database_DeleteBounty_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *Database_CountWorkspacesCreatedSince_Call) Return(_a0 int64) *Database_CountWorkspacesCreatedSince_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_DeleteBounty_Call) RunAndReturn(run func(string, string) (db.NewBounty, error)) *Database_DeleteBounty_Call {
+func (_c *Database_CountWorkspacesCreatedSince_Call) RunAndReturn(run func(string, time.Time) int64) *Database_CountWorkspacesCreatedSince_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteFeatureByUuid provides a mock function with given fields: uuid
-func (_m *Database) DeleteFeatureByUuid(uuid string) error {
-	ret := _m.Called(uuid)
+// CreateAiSubmission provides a mock function with given fields: submission
+func (_m *Database) CreateAiSubmission(submission db.AiSubmission) error {
+	ret := _m.Called(submission)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteFeatureByUuid")
+		panic("no return value specified for CreateAiSubmission")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string) error); ok {
-		r0 = rf(uuid)
+	if rf, ok := ret.Get(0).(func(db.AiSubmission) error); ok {
+		r0 = rf(submission)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -1585,480 +1732,12397 @@ func (_m *Database) DeleteFeatureByUuid(uuid string) error {
 	return r0
 }
 
-// Database_DeleteFeatureByUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteFeatureByUuid'
-type Database_DeleteFeatureByUuid_Call struct {
+// Database_CreateAiSubmission_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateAiSubmission'
+type Database_CreateAiSubmission_Call struct {
 	*mock.Call
 }
 
-// DeleteFeatureByUuid is a helper method to define mock.On call
-//   - uuid string
-func (_e *Database_Expecter) DeleteFeatureByUuid(uuid interface{}) *Database_DeleteFeatureByUuid_Call {
-	return &Database_DeleteFeatureByUuid_Call{Call: _e.mock.On("DeleteFeatureByUuid", uuid)}
+// CreateAiSubmission is a helper method to define mock.On call
+//   - submission db.AiSubmission
+func (_e *Database_Expecter) CreateAiSubmission(submission interface{}) *Database_CreateAiSubmission_Call {
+	return &Database_CreateAiSubmission_Call{Call: _e.mock.On("CreateAiSubmission", submission)}
 }
 
-func (_c *Database_DeleteFeatureByUuid_Call) Run(run func(uuid string)) *Database_DeleteFeatureByUuid_Call {
+func (_c *Database_CreateAiSubmission_Call) Run(run func(submission db.AiSubmission)) *Database_CreateAiSubmission_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(db.AiSubmission))
 	})
 	return _c
 }
 
-func (_c *Database_DeleteFeatureByUuid_Call) Return(_a0 error) *Database_DeleteFeatureByUuid_Call {
+func (_c *Database_CreateAiSubmission_Call) Return(_a0 error) *Database_CreateAiSubmission_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_DeleteFeatureByUuid_Call) RunAndReturn(run func(string) error) *Database_DeleteFeatureByUuid_Call {
+func (_c *Database_CreateAiSubmission_Call) RunAndReturn(run func(db.AiSubmission) error) *Database_CreateAiSubmission_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteFeaturePhase provides a mock function with given fields: featureUuid, phaseUuid
-func (_m *Database) DeleteFeaturePhase(featureUuid string, phaseUuid string) error {
-	ret := _m.Called(featureUuid, phaseUuid)
+// CreateBountyCertificate provides a mock function with given fields: certificate
+func (_m *Database) CreateBountyCertificate(certificate db.BountyCertificate) (db.BountyCertificate, error) {
+	ret := _m.Called(certificate)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteFeaturePhase")
+		panic("no return value specified for CreateBountyCertificate")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string) error); ok {
-		r0 = rf(featureUuid, phaseUuid)
+	var r0 db.BountyCertificate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.BountyCertificate) (db.BountyCertificate, error)); ok {
+		return rf(certificate)
+	}
+	if rf, ok := ret.Get(0).(func(db.BountyCertificate) db.BountyCertificate); ok {
+		r0 = rf(certificate)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(db.BountyCertificate)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(db.BountyCertificate) error); ok {
+		r1 = rf(certificate)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_DeleteFeaturePhase_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteFeaturePhase'
-type Database_DeleteFeaturePhase_Call struct {
+// Database_CreateBountyCertificate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateBountyCertificate'
+type Database_CreateBountyCertificate_Call struct {
 	*mock.Call
 }
 
-// DeleteFeaturePhase is a helper method to define mock.On call
-//   - featureUuid string
-//   - phaseUuid string
-func (_e *Database_Expecter) DeleteFeaturePhase(featureUuid interface{}, phaseUuid interface{}) *Database_DeleteFeaturePhase_Call {
-	return &Database_DeleteFeaturePhase_Call{Call: _e.mock.On("DeleteFeaturePhase", featureUuid, phaseUuid)}
+// CreateBountyCertificate is a helper method to define mock.On call
+//   - certificate db.BountyCertificate
+func (_e *Database_Expecter) CreateBountyCertificate(certificate interface{}) *Database_CreateBountyCertificate_Call {
+	return &Database_CreateBountyCertificate_Call{Call: _e.mock.On("CreateBountyCertificate", certificate)}
 }
 
-func (_c *Database_DeleteFeaturePhase_Call) Run(run func(featureUuid string, phaseUuid string)) *Database_DeleteFeaturePhase_Call {
+func (_c *Database_CreateBountyCertificate_Call) Run(run func(certificate db.BountyCertificate)) *Database_CreateBountyCertificate_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string))
+		run(args[0].(db.BountyCertificate))
 	})
 	return _c
 }
 
-func (_c *Database_DeleteFeaturePhase_Call) Return(_a0 error) *Database_DeleteFeaturePhase_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_CreateBountyCertificate_Call) Return(_a0 db.BountyCertificate, _a1 error) *Database_CreateBountyCertificate_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_DeleteFeaturePhase_Call) RunAndReturn(run func(string, string) error) *Database_DeleteFeaturePhase_Call {
+func (_c *Database_CreateBountyCertificate_Call) RunAndReturn(run func(db.BountyCertificate) (db.BountyCertificate, error)) *Database_CreateBountyCertificate_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteFeatureStoryByUuid provides a mock function with given fields: featureUuid, storyUuid
-func (_m *Database) DeleteFeatureStoryByUuid(featureUuid string, storyUuid string) error {
-	ret := _m.Called(featureUuid, storyUuid)
+// CreateBountyProof provides a mock function with given fields: proof, reviewers
+func (_m *Database) CreateBountyProof(proof db.BountyProof, reviewers []string) (db.BountyProof, error) {
+	ret := _m.Called(proof, reviewers)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteFeatureStoryByUuid")
+		panic("no return value specified for CreateBountyProof")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string) error); ok {
-		r0 = rf(featureUuid, storyUuid)
+	var r0 db.BountyProof
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.BountyProof, []string) (db.BountyProof, error)); ok {
+		return rf(proof, reviewers)
+	}
+	if rf, ok := ret.Get(0).(func(db.BountyProof, []string) db.BountyProof); ok {
+		r0 = rf(proof, reviewers)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(db.BountyProof)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(db.BountyProof, []string) error); ok {
+		r1 = rf(proof, reviewers)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_DeleteFeatureStoryByUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteFeatureStoryByUuid'
-type Database_DeleteFeatureStoryByUuid_Call struct {
+// Database_CreateBountyProof_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateBountyProof'
+type Database_CreateBountyProof_Call struct {
 	*mock.Call
 }
 
-// DeleteFeatureStoryByUuid is a helper method to define mock.On call
-//   - featureUuid string
-//   - storyUuid string
-func (_e *Database_Expecter) DeleteFeatureStoryByUuid(featureUuid interface{}, storyUuid interface{}) *Database_DeleteFeatureStoryByUuid_Call {
-	return &Database_DeleteFeatureStoryByUuid_Call{Call: _e.mock.On("DeleteFeatureStoryByUuid", featureUuid, storyUuid)}
+// CreateBountyProof is a helper method to define mock.On call
+//   - proof db.BountyProof
+//   - reviewers []string
+func (_e *Database_Expecter) CreateBountyProof(proof interface{}, reviewers interface{}) *Database_CreateBountyProof_Call {
+	return &Database_CreateBountyProof_Call{Call: _e.mock.On("CreateBountyProof", proof, reviewers)}
 }
 
-func (_c *Database_DeleteFeatureStoryByUuid_Call) Run(run func(featureUuid string, storyUuid string)) *Database_DeleteFeatureStoryByUuid_Call {
+func (_c *Database_CreateBountyProof_Call) Run(run func(proof db.BountyProof, reviewers []string)) *Database_CreateBountyProof_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string))
+		run(args[0].(db.BountyProof), args[1].([]string))
 	})
 	return _c
 }
 
-func (_c *Database_DeleteFeatureStoryByUuid_Call) Return(_a0 error) *Database_DeleteFeatureStoryByUuid_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_CreateBountyProof_Call) Return(_a0 db.BountyProof, _a1 error) *Database_CreateBountyProof_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_DeleteFeatureStoryByUuid_Call) RunAndReturn(run func(string, string) error) *Database_DeleteFeatureStoryByUuid_Call {
+func (_c *Database_CreateBountyProof_Call) RunAndReturn(run func(db.BountyProof, []string) (db.BountyProof, error)) *Database_CreateBountyProof_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteInvoice provides a mock function with given fields: payment_request
-func (_m *Database) DeleteInvoice(payment_request string) db.NewInvoiceList {
-	ret := _m.Called(payment_request)
+// CreateChannel provides a mock function with given fields: c
+func (_m *Database) CreateChannel(c db.Channel) (db.Channel, error) {
+	ret := _m.Called(c)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteInvoice")
+		panic("no return value specified for CreateChannel")
 	}
 
-	var r0 db.NewInvoiceList
-	if rf, ok := ret.Get(0).(func(string) db.NewInvoiceList); ok {
-		r0 = rf(payment_request)
-	} else {
-		r0 = ret.Get(0).(db.NewInvoiceList)
+	var r0 db.Channel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.Channel) (db.Channel, error)); ok {
+		return rf(c)
 	}
-
-	return r0
+	if rf, ok := ret.Get(0).(func(db.Channel) db.Channel); ok {
+		r0 = rf(c)
+	} else {
+		r0 = ret.Get(0).(db.Channel)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.Channel) error); ok {
+		r1 = rf(c)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_CreateChannel_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateChannel'
+type Database_CreateChannel_Call struct {
+	*mock.Call
+}
+
+// CreateChannel is a helper method to define mock.On call
+//   - c db.Channel
+func (_e *Database_Expecter) CreateChannel(c interface{}) *Database_CreateChannel_Call {
+	return &Database_CreateChannel_Call{Call: _e.mock.On("CreateChannel", c)}
+}
+
+func (_c *Database_CreateChannel_Call) Run(run func(c db.Channel)) *Database_CreateChannel_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.Channel))
+	})
+	return _c
+}
+
+func (_c *Database_CreateChannel_Call) Return(_a0 db.Channel, _a1 error) *Database_CreateChannel_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_CreateChannel_Call) RunAndReturn(run func(db.Channel) (db.Channel, error)) *Database_CreateChannel_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateChat provides a mock function with given fields: chat
+func (_m *Database) CreateChat(chat db.Chat) (db.Chat, error) {
+	ret := _m.Called(chat)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateChat")
+	}
+
+	var r0 db.Chat
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.Chat) (db.Chat, error)); ok {
+		return rf(chat)
+	}
+	if rf, ok := ret.Get(0).(func(db.Chat) db.Chat); ok {
+		r0 = rf(chat)
+	} else {
+		r0 = ret.Get(0).(db.Chat)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.Chat) error); ok {
+		r1 = rf(chat)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_CreateChat_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateChat'
+type Database_CreateChat_Call struct {
+	*mock.Call
+}
+
+// CreateChat is a helper method to define mock.On call
+//   - chat db.Chat
+func (_e *Database_Expecter) CreateChat(chat interface{}) *Database_CreateChat_Call {
+	return &Database_CreateChat_Call{Call: _e.mock.On("CreateChat", chat)}
+}
+
+func (_c *Database_CreateChat_Call) Run(run func(chat db.Chat)) *Database_CreateChat_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.Chat))
+	})
+	return _c
+}
+
+func (_c *Database_CreateChat_Call) Return(_a0 db.Chat, _a1 error) *Database_CreateChat_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_CreateChat_Call) RunAndReturn(run func(db.Chat) (db.Chat, error)) *Database_CreateChat_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateConnectionCode provides a mock function with given fields: c
+func (_m *Database) CreateConnectionCode(c []db.ConnectionCodes) ([]db.ConnectionCodes, error) {
+	ret := _m.Called(c)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateConnectionCode")
+	}
+
+	var r0 []db.ConnectionCodes
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]db.ConnectionCodes) ([]db.ConnectionCodes, error)); ok {
+		return rf(c)
+	}
+	if rf, ok := ret.Get(0).(func([]db.ConnectionCodes) []db.ConnectionCodes); ok {
+		r0 = rf(c)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.ConnectionCodes)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]db.ConnectionCodes) error); ok {
+		r1 = rf(c)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_CreateConnectionCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateConnectionCode'
+type Database_CreateConnectionCode_Call struct {
+	*mock.Call
+}
+
+// CreateConnectionCode is a helper method to define mock.On call
+//   - c []db.ConnectionCodes
+func (_e *Database_Expecter) CreateConnectionCode(c interface{}) *Database_CreateConnectionCode_Call {
+	return &Database_CreateConnectionCode_Call{Call: _e.mock.On("CreateConnectionCode", c)}
+}
+
+func (_c *Database_CreateConnectionCode_Call) Run(run func(c []db.ConnectionCodes)) *Database_CreateConnectionCode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]db.ConnectionCodes))
+	})
+	return _c
+}
+
+func (_c *Database_CreateConnectionCode_Call) Return(_a0 []db.ConnectionCodes, _a1 error) *Database_CreateConnectionCode_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_CreateConnectionCode_Call) RunAndReturn(run func([]db.ConnectionCodes) ([]db.ConnectionCodes, error)) *Database_CreateConnectionCode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateEvent provides a mock function with given fields: event
+func (_m *Database) CreateEvent(event db.Event) (db.Event, error) {
+	ret := _m.Called(event)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateEvent")
+	}
+
+	var r0 db.Event
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.Event) (db.Event, error)); ok {
+		return rf(event)
+	}
+	if rf, ok := ret.Get(0).(func(db.Event) db.Event); ok {
+		r0 = rf(event)
+	} else {
+		r0 = ret.Get(0).(db.Event)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.Event) error); ok {
+		r1 = rf(event)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_CreateEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateEvent'
+type Database_CreateEvent_Call struct {
+	*mock.Call
+}
+
+// CreateEvent is a helper method to define mock.On call
+//   - event db.Event
+func (_e *Database_Expecter) CreateEvent(event interface{}) *Database_CreateEvent_Call {
+	return &Database_CreateEvent_Call{Call: _e.mock.On("CreateEvent", event)}
+}
+
+func (_c *Database_CreateEvent_Call) Run(run func(event db.Event)) *Database_CreateEvent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.Event))
+	})
+	return _c
+}
+
+func (_c *Database_CreateEvent_Call) Return(_a0 db.Event, _a1 error) *Database_CreateEvent_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_CreateEvent_Call) RunAndReturn(run func(db.Event) (db.Event, error)) *Database_CreateEvent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateLeaderBoard provides a mock function with given fields: uuid, leaderboards
+func (_m *Database) CreateLeaderBoard(uuid string, leaderboards []db.LeaderBoard) ([]db.LeaderBoard, error) {
+	ret := _m.Called(uuid, leaderboards)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateLeaderBoard")
+	}
+
+	var r0 []db.LeaderBoard
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, []db.LeaderBoard) ([]db.LeaderBoard, error)); ok {
+		return rf(uuid, leaderboards)
+	}
+	if rf, ok := ret.Get(0).(func(string, []db.LeaderBoard) []db.LeaderBoard); ok {
+		r0 = rf(uuid, leaderboards)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.LeaderBoard)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, []db.LeaderBoard) error); ok {
+		r1 = rf(uuid, leaderboards)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_CreateLeaderBoard_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateLeaderBoard'
+type Database_CreateLeaderBoard_Call struct {
+	*mock.Call
+}
+
+// CreateLeaderBoard is a helper method to define mock.On call
+//   - uuid string
+//   - leaderboards []db.LeaderBoard
+func (_e *Database_Expecter) CreateLeaderBoard(uuid interface{}, leaderboards interface{}) *Database_CreateLeaderBoard_Call {
+	return &Database_CreateLeaderBoard_Call{Call: _e.mock.On("CreateLeaderBoard", uuid, leaderboards)}
+}
+
+func (_c *Database_CreateLeaderBoard_Call) Run(run func(uuid string, leaderboards []db.LeaderBoard)) *Database_CreateLeaderBoard_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].([]db.LeaderBoard))
+	})
+	return _c
+}
+
+func (_c *Database_CreateLeaderBoard_Call) Return(_a0 []db.LeaderBoard, _a1 error) *Database_CreateLeaderBoard_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_CreateLeaderBoard_Call) RunAndReturn(run func(string, []db.LeaderBoard) ([]db.LeaderBoard, error)) *Database_CreateLeaderBoard_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateLnUser provides a mock function with given fields: lnKey
+func (_m *Database) CreateLnUser(lnKey string) (db.Person, error) {
+	ret := _m.Called(lnKey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateLnUser")
+	}
+
+	var r0 db.Person
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (db.Person, error)); ok {
+		return rf(lnKey)
+	}
+	if rf, ok := ret.Get(0).(func(string) db.Person); ok {
+		r0 = rf(lnKey)
+	} else {
+		r0 = ret.Get(0).(db.Person)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(lnKey)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_CreateLnUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateLnUser'
+type Database_CreateLnUser_Call struct {
+	*mock.Call
+}
+
+// CreateLnUser is a helper method to define mock.On call
+//   - lnKey string
+func (_e *Database_Expecter) CreateLnUser(lnKey interface{}) *Database_CreateLnUser_Call {
+	return &Database_CreateLnUser_Call{Call: _e.mock.On("CreateLnUser", lnKey)}
+}
+
+func (_c *Database_CreateLnUser_Call) Run(run func(lnKey string)) *Database_CreateLnUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_CreateLnUser_Call) Return(_a0 db.Person, _a1 error) *Database_CreateLnUser_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_CreateLnUser_Call) RunAndReturn(run func(string) (db.Person, error)) *Database_CreateLnUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateNotifications provides a mock function with given fields: notifications
+func (_m *Database) CreateNotifications(notifications []db.Notification) error {
+	ret := _m.Called(notifications)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateNotifications")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]db.Notification) error); ok {
+		r0 = rf(notifications)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_CreateNotifications_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateNotifications'
+type Database_CreateNotifications_Call struct {
+	*mock.Call
+}
+
+// CreateNotifications is a helper method to define mock.On call
+//   - notifications []db.Notification
+func (_e *Database_Expecter) CreateNotifications(notifications interface{}) *Database_CreateNotifications_Call {
+	return &Database_CreateNotifications_Call{Call: _e.mock.On("CreateNotifications", notifications)}
+}
+
+func (_c *Database_CreateNotifications_Call) Run(run func(notifications []db.Notification)) *Database_CreateNotifications_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]db.Notification))
+	})
+	return _c
+}
+
+func (_c *Database_CreateNotifications_Call) Return(_a0 error) *Database_CreateNotifications_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_CreateNotifications_Call) RunAndReturn(run func([]db.Notification) error) *Database_CreateNotifications_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateOrEditArtifact provides a mock function with given fields: artifact
+func (_m *Database) CreateOrEditArtifact(artifact db.WorkspaceArtifact) (db.WorkspaceArtifact, error) {
+	ret := _m.Called(artifact)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateOrEditArtifact")
+	}
+
+	var r0 db.WorkspaceArtifact
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.WorkspaceArtifact) (db.WorkspaceArtifact, error)); ok {
+		return rf(artifact)
+	}
+	if rf, ok := ret.Get(0).(func(db.WorkspaceArtifact) db.WorkspaceArtifact); ok {
+		r0 = rf(artifact)
+	} else {
+		r0 = ret.Get(0).(db.WorkspaceArtifact)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.WorkspaceArtifact) error); ok {
+		r1 = rf(artifact)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_CreateOrEditArtifact_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOrEditArtifact'
+type Database_CreateOrEditArtifact_Call struct {
+	*mock.Call
+}
+
+// CreateOrEditArtifact is a helper method to define mock.On call
+//   - artifact db.WorkspaceArtifact
+func (_e *Database_Expecter) CreateOrEditArtifact(artifact interface{}) *Database_CreateOrEditArtifact_Call {
+	return &Database_CreateOrEditArtifact_Call{Call: _e.mock.On("CreateOrEditArtifact", artifact)}
+}
+
+func (_c *Database_CreateOrEditArtifact_Call) Run(run func(artifact db.WorkspaceArtifact)) *Database_CreateOrEditArtifact_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.WorkspaceArtifact))
+	})
+	return _c
+}
+
+func (_c *Database_CreateOrEditArtifact_Call) Return(_a0 db.WorkspaceArtifact, _a1 error) *Database_CreateOrEditArtifact_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_CreateOrEditArtifact_Call) RunAndReturn(run func(db.WorkspaceArtifact) (db.WorkspaceArtifact, error)) *Database_CreateOrEditArtifact_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateOrEditBot provides a mock function with given fields: b
+func (_m *Database) CreateOrEditBot(b db.Bot) (db.Bot, error) {
+	ret := _m.Called(b)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateOrEditBot")
+	}
+
+	var r0 db.Bot
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.Bot) (db.Bot, error)); ok {
+		return rf(b)
+	}
+	if rf, ok := ret.Get(0).(func(db.Bot) db.Bot); ok {
+		r0 = rf(b)
+	} else {
+		r0 = ret.Get(0).(db.Bot)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.Bot) error); ok {
+		r1 = rf(b)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_CreateOrEditBot_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOrEditBot'
+type Database_CreateOrEditBot_Call struct {
+	*mock.Call
+}
+
+// CreateOrEditBot is a helper method to define mock.On call
+//   - b db.Bot
+func (_e *Database_Expecter) CreateOrEditBot(b interface{}) *Database_CreateOrEditBot_Call {
+	return &Database_CreateOrEditBot_Call{Call: _e.mock.On("CreateOrEditBot", b)}
+}
+
+func (_c *Database_CreateOrEditBot_Call) Run(run func(b db.Bot)) *Database_CreateOrEditBot_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.Bot))
+	})
+	return _c
+}
+
+func (_c *Database_CreateOrEditBot_Call) Return(_a0 db.Bot, _a1 error) *Database_CreateOrEditBot_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_CreateOrEditBot_Call) RunAndReturn(run func(db.Bot) (db.Bot, error)) *Database_CreateOrEditBot_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateOrEditBounty provides a mock function with given fields: b
+func (_m *Database) CreateOrEditBounty(b db.NewBounty) (db.NewBounty, error) {
+	ret := _m.Called(b)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateOrEditBounty")
+	}
+
+	var r0 db.NewBounty
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.NewBounty) (db.NewBounty, error)); ok {
+		return rf(b)
+	}
+	if rf, ok := ret.Get(0).(func(db.NewBounty) db.NewBounty); ok {
+		r0 = rf(b)
+	} else {
+		r0 = ret.Get(0).(db.NewBounty)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.NewBounty) error); ok {
+		r1 = rf(b)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_CreateOrEditBounty_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOrEditBounty'
+type Database_CreateOrEditBounty_Call struct {
+	*mock.Call
+}
+
+// CreateOrEditBounty is a helper method to define mock.On call
+//   - b db.NewBounty
+func (_e *Database_Expecter) CreateOrEditBounty(b interface{}) *Database_CreateOrEditBounty_Call {
+	return &Database_CreateOrEditBounty_Call{Call: _e.mock.On("CreateOrEditBounty", b)}
+}
+
+func (_c *Database_CreateOrEditBounty_Call) Run(run func(b db.NewBounty)) *Database_CreateOrEditBounty_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.NewBounty))
+	})
+	return _c
+}
+
+func (_c *Database_CreateOrEditBounty_Call) Return(_a0 db.NewBounty, _a1 error) *Database_CreateOrEditBounty_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_CreateOrEditBounty_Call) RunAndReturn(run func(db.NewBounty) (db.NewBounty, error)) *Database_CreateOrEditBounty_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateOrEditBountyRoutingRule provides a mock function with given fields: rule
+func (_m *Database) CreateOrEditBountyRoutingRule(rule db.BountyRoutingRule) (db.BountyRoutingRule, error) {
+	ret := _m.Called(rule)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateOrEditBountyRoutingRule")
+	}
+
+	var r0 db.BountyRoutingRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.BountyRoutingRule) (db.BountyRoutingRule, error)); ok {
+		return rf(rule)
+	}
+	if rf, ok := ret.Get(0).(func(db.BountyRoutingRule) db.BountyRoutingRule); ok {
+		r0 = rf(rule)
+	} else {
+		r0 = ret.Get(0).(db.BountyRoutingRule)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.BountyRoutingRule) error); ok {
+		r1 = rf(rule)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_CreateOrEditBountyRoutingRule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOrEditBountyRoutingRule'
+type Database_CreateOrEditBountyRoutingRule_Call struct {
+	*mock.Call
+}
+
+// CreateOrEditBountyRoutingRule is a helper method to define mock.On call
+//   - rule db.BountyRoutingRule
+func (_e *Database_Expecter) CreateOrEditBountyRoutingRule(rule interface{}) *Database_CreateOrEditBountyRoutingRule_Call {
+	return &Database_CreateOrEditBountyRoutingRule_Call{Call: _e.mock.On("CreateOrEditBountyRoutingRule", rule)}
+}
+
+func (_c *Database_CreateOrEditBountyRoutingRule_Call) Run(run func(rule db.BountyRoutingRule)) *Database_CreateOrEditBountyRoutingRule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.BountyRoutingRule))
+	})
+	return _c
+}
+
+func (_c *Database_CreateOrEditBountyRoutingRule_Call) Return(_a0 db.BountyRoutingRule, _a1 error) *Database_CreateOrEditBountyRoutingRule_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_CreateOrEditBountyRoutingRule_Call) RunAndReturn(run func(db.BountyRoutingRule) (db.BountyRoutingRule, error)) *Database_CreateOrEditBountyRoutingRule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateOrEditCategory provides a mock function with given fields: request
+func (_m *Database) CreateOrEditCategory(request db.CategoryRequest) (db.Category, error) {
+	ret := _m.Called(request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateOrEditCategory")
+	}
+
+	var r0 db.Category
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.CategoryRequest) (db.Category, error)); ok {
+		return rf(request)
+	}
+	if rf, ok := ret.Get(0).(func(db.CategoryRequest) db.Category); ok {
+		r0 = rf(request)
+	} else {
+		r0 = ret.Get(0).(db.Category)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.CategoryRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_CreateOrEditCategory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOrEditCategory'
+type Database_CreateOrEditCategory_Call struct {
+	*mock.Call
+}
+
+// CreateOrEditCategory is a helper method to define mock.On call
+//   - request db.CategoryRequest
+func (_e *Database_Expecter) CreateOrEditCategory(request interface{}) *Database_CreateOrEditCategory_Call {
+	return &Database_CreateOrEditCategory_Call{Call: _e.mock.On("CreateOrEditCategory", request)}
+}
+
+func (_c *Database_CreateOrEditCategory_Call) Run(run func(request db.CategoryRequest)) *Database_CreateOrEditCategory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.CategoryRequest))
+	})
+	return _c
+}
+
+func (_c *Database_CreateOrEditCategory_Call) Return(_a0 db.Category, _a1 error) *Database_CreateOrEditCategory_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_CreateOrEditCategory_Call) RunAndReturn(run func(db.CategoryRequest) (db.Category, error)) *Database_CreateOrEditCategory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateOrEditFeature provides a mock function with given fields: m
+func (_m *Database) CreateOrEditFeature(m db.WorkspaceFeatures) (db.WorkspaceFeatures, error) {
+	ret := _m.Called(m)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateOrEditFeature")
+	}
+
+	var r0 db.WorkspaceFeatures
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.WorkspaceFeatures) (db.WorkspaceFeatures, error)); ok {
+		return rf(m)
+	}
+	if rf, ok := ret.Get(0).(func(db.WorkspaceFeatures) db.WorkspaceFeatures); ok {
+		r0 = rf(m)
+	} else {
+		r0 = ret.Get(0).(db.WorkspaceFeatures)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.WorkspaceFeatures) error); ok {
+		r1 = rf(m)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_CreateOrEditFeature_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOrEditFeature'
+type Database_CreateOrEditFeature_Call struct {
+	*mock.Call
+}
+
+// CreateOrEditFeature is a helper method to define mock.On call
+//   - m db.WorkspaceFeatures
+func (_e *Database_Expecter) CreateOrEditFeature(m interface{}) *Database_CreateOrEditFeature_Call {
+	return &Database_CreateOrEditFeature_Call{Call: _e.mock.On("CreateOrEditFeature", m)}
+}
+
+func (_c *Database_CreateOrEditFeature_Call) Run(run func(m db.WorkspaceFeatures)) *Database_CreateOrEditFeature_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.WorkspaceFeatures))
+	})
+	return _c
+}
+
+func (_c *Database_CreateOrEditFeature_Call) Return(_a0 db.WorkspaceFeatures, _a1 error) *Database_CreateOrEditFeature_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_CreateOrEditFeature_Call) RunAndReturn(run func(db.WorkspaceFeatures) (db.WorkspaceFeatures, error)) *Database_CreateOrEditFeature_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateOrEditFeaturePhase provides a mock function with given fields: phase
+func (_m *Database) CreateOrEditFeaturePhase(phase db.FeaturePhase) (db.FeaturePhase, error) {
+	ret := _m.Called(phase)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateOrEditFeaturePhase")
+	}
+
+	var r0 db.FeaturePhase
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.FeaturePhase) (db.FeaturePhase, error)); ok {
+		return rf(phase)
+	}
+	if rf, ok := ret.Get(0).(func(db.FeaturePhase) db.FeaturePhase); ok {
+		r0 = rf(phase)
+	} else {
+		r0 = ret.Get(0).(db.FeaturePhase)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.FeaturePhase) error); ok {
+		r1 = rf(phase)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_CreateOrEditFeaturePhase_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOrEditFeaturePhase'
+type Database_CreateOrEditFeaturePhase_Call struct {
+	*mock.Call
+}
+
+// CreateOrEditFeaturePhase is a helper method to define mock.On call
+//   - phase db.FeaturePhase
+func (_e *Database_Expecter) CreateOrEditFeaturePhase(phase interface{}) *Database_CreateOrEditFeaturePhase_Call {
+	return &Database_CreateOrEditFeaturePhase_Call{Call: _e.mock.On("CreateOrEditFeaturePhase", phase)}
+}
+
+func (_c *Database_CreateOrEditFeaturePhase_Call) Run(run func(phase db.FeaturePhase)) *Database_CreateOrEditFeaturePhase_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.FeaturePhase))
+	})
+	return _c
+}
+
+func (_c *Database_CreateOrEditFeaturePhase_Call) Return(_a0 db.FeaturePhase, _a1 error) *Database_CreateOrEditFeaturePhase_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_CreateOrEditFeaturePhase_Call) RunAndReturn(run func(db.FeaturePhase) (db.FeaturePhase, error)) *Database_CreateOrEditFeaturePhase_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateOrEditFeatureStory provides a mock function with given fields: story
+func (_m *Database) CreateOrEditFeatureStory(story db.FeatureStory) (db.FeatureStory, error) {
+	ret := _m.Called(story)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateOrEditFeatureStory")
+	}
+
+	var r0 db.FeatureStory
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.FeatureStory) (db.FeatureStory, error)); ok {
+		return rf(story)
+	}
+	if rf, ok := ret.Get(0).(func(db.FeatureStory) db.FeatureStory); ok {
+		r0 = rf(story)
+	} else {
+		r0 = ret.Get(0).(db.FeatureStory)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.FeatureStory) error); ok {
+		r1 = rf(story)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_CreateOrEditFeatureStory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOrEditFeatureStory'
+type Database_CreateOrEditFeatureStory_Call struct {
+	*mock.Call
+}
+
+// CreateOrEditFeatureStory is a helper method to define mock.On call
+//   - story db.FeatureStory
+func (_e *Database_Expecter) CreateOrEditFeatureStory(story interface{}) *Database_CreateOrEditFeatureStory_Call {
+	return &Database_CreateOrEditFeatureStory_Call{Call: _e.mock.On("CreateOrEditFeatureStory", story)}
+}
+
+func (_c *Database_CreateOrEditFeatureStory_Call) Run(run func(story db.FeatureStory)) *Database_CreateOrEditFeatureStory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.FeatureStory))
+	})
+	return _c
+}
+
+func (_c *Database_CreateOrEditFeatureStory_Call) Return(_a0 db.FeatureStory, _a1 error) *Database_CreateOrEditFeatureStory_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_CreateOrEditFeatureStory_Call) RunAndReturn(run func(db.FeatureStory) (db.FeatureStory, error)) *Database_CreateOrEditFeatureStory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateOrEditPerson provides a mock function with given fields: m
+func (_m *Database) CreateOrEditPerson(m db.Person) (db.Person, error) {
+	ret := _m.Called(m)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateOrEditPerson")
+	}
+
+	var r0 db.Person
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.Person) (db.Person, error)); ok {
+		return rf(m)
+	}
+	if rf, ok := ret.Get(0).(func(db.Person) db.Person); ok {
+		r0 = rf(m)
+	} else {
+		r0 = ret.Get(0).(db.Person)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.Person) error); ok {
+		r1 = rf(m)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_CreateOrEditPerson_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOrEditPerson'
+type Database_CreateOrEditPerson_Call struct {
+	*mock.Call
+}
+
+// CreateOrEditPerson is a helper method to define mock.On call
+//   - m db.Person
+func (_e *Database_Expecter) CreateOrEditPerson(m interface{}) *Database_CreateOrEditPerson_Call {
+	return &Database_CreateOrEditPerson_Call{Call: _e.mock.On("CreateOrEditPerson", m)}
+}
+
+func (_c *Database_CreateOrEditPerson_Call) Run(run func(m db.Person)) *Database_CreateOrEditPerson_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.Person))
+	})
+	return _c
+}
+
+func (_c *Database_CreateOrEditPerson_Call) Return(_a0 db.Person, _a1 error) *Database_CreateOrEditPerson_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_CreateOrEditPerson_Call) RunAndReturn(run func(db.Person) (db.Person, error)) *Database_CreateOrEditPerson_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateOrEditQuest provides a mock function with given fields: quest
+func (_m *Database) CreateOrEditQuest(quest db.Quest) (db.Quest, error) {
+	ret := _m.Called(quest)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateOrEditQuest")
+	}
+
+	var r0 db.Quest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.Quest) (db.Quest, error)); ok {
+		return rf(quest)
+	}
+	if rf, ok := ret.Get(0).(func(db.Quest) db.Quest); ok {
+		r0 = rf(quest)
+	} else {
+		r0 = ret.Get(0).(db.Quest)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.Quest) error); ok {
+		r1 = rf(quest)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_CreateOrEditQuest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOrEditQuest'
+type Database_CreateOrEditQuest_Call struct {
+	*mock.Call
+}
+
+// CreateOrEditQuest is a helper method to define mock.On call
+//   - quest db.Quest
+func (_e *Database_Expecter) CreateOrEditQuest(quest interface{}) *Database_CreateOrEditQuest_Call {
+	return &Database_CreateOrEditQuest_Call{Call: _e.mock.On("CreateOrEditQuest", quest)}
+}
+
+func (_c *Database_CreateOrEditQuest_Call) Run(run func(quest db.Quest)) *Database_CreateOrEditQuest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.Quest))
+	})
+	return _c
+}
+
+func (_c *Database_CreateOrEditQuest_Call) Return(_a0 db.Quest, _a1 error) *Database_CreateOrEditQuest_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_CreateOrEditQuest_Call) RunAndReturn(run func(db.Quest) (db.Quest, error)) *Database_CreateOrEditQuest_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateOrEditTribe provides a mock function with given fields: m
+func (_m *Database) CreateOrEditTribe(m db.Tribe) (db.Tribe, error) {
+	ret := _m.Called(m)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateOrEditTribe")
+	}
+
+	var r0 db.Tribe
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.Tribe) (db.Tribe, error)); ok {
+		return rf(m)
+	}
+	if rf, ok := ret.Get(0).(func(db.Tribe) db.Tribe); ok {
+		r0 = rf(m)
+	} else {
+		r0 = ret.Get(0).(db.Tribe)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.Tribe) error); ok {
+		r1 = rf(m)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_CreateOrEditTribe_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOrEditTribe'
+type Database_CreateOrEditTribe_Call struct {
+	*mock.Call
+}
+
+// CreateOrEditTribe is a helper method to define mock.On call
+//   - m db.Tribe
+func (_e *Database_Expecter) CreateOrEditTribe(m interface{}) *Database_CreateOrEditTribe_Call {
+	return &Database_CreateOrEditTribe_Call{Call: _e.mock.On("CreateOrEditTribe", m)}
+}
+
+func (_c *Database_CreateOrEditTribe_Call) Run(run func(m db.Tribe)) *Database_CreateOrEditTribe_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.Tribe))
+	})
+	return _c
+}
+
+func (_c *Database_CreateOrEditTribe_Call) Return(_a0 db.Tribe, _a1 error) *Database_CreateOrEditTribe_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_CreateOrEditTribe_Call) RunAndReturn(run func(db.Tribe) (db.Tribe, error)) *Database_CreateOrEditTribe_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateOrEditTribeAnnouncement provides a mock function with given fields: announcement
+func (_m *Database) CreateOrEditTribeAnnouncement(announcement db.TribeAnnouncement) (db.TribeAnnouncement, error) {
+	ret := _m.Called(announcement)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateOrEditTribeAnnouncement")
+	}
+
+	var r0 db.TribeAnnouncement
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.TribeAnnouncement) (db.TribeAnnouncement, error)); ok {
+		return rf(announcement)
+	}
+	if rf, ok := ret.Get(0).(func(db.TribeAnnouncement) db.TribeAnnouncement); ok {
+		r0 = rf(announcement)
+	} else {
+		r0 = ret.Get(0).(db.TribeAnnouncement)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.TribeAnnouncement) error); ok {
+		r1 = rf(announcement)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_CreateOrEditTribeAnnouncement_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOrEditTribeAnnouncement'
+type Database_CreateOrEditTribeAnnouncement_Call struct {
+	*mock.Call
+}
+
+// CreateOrEditTribeAnnouncement is a helper method to define mock.On call
+//   - announcement db.TribeAnnouncement
+func (_e *Database_Expecter) CreateOrEditTribeAnnouncement(announcement interface{}) *Database_CreateOrEditTribeAnnouncement_Call {
+	return &Database_CreateOrEditTribeAnnouncement_Call{Call: _e.mock.On("CreateOrEditTribeAnnouncement", announcement)}
+}
+
+func (_c *Database_CreateOrEditTribeAnnouncement_Call) Run(run func(announcement db.TribeAnnouncement)) *Database_CreateOrEditTribeAnnouncement_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.TribeAnnouncement))
+	})
+	return _c
+}
+
+func (_c *Database_CreateOrEditTribeAnnouncement_Call) Return(_a0 db.TribeAnnouncement, _a1 error) *Database_CreateOrEditTribeAnnouncement_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_CreateOrEditTribeAnnouncement_Call) RunAndReturn(run func(db.TribeAnnouncement) (db.TribeAnnouncement, error)) *Database_CreateOrEditTribeAnnouncement_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateOrEditTribeEvent provides a mock function with given fields: event
+func (_m *Database) CreateOrEditTribeEvent(event db.TribeEvent) (db.TribeEvent, error) {
+	ret := _m.Called(event)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateOrEditTribeEvent")
+	}
+
+	var r0 db.TribeEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.TribeEvent) (db.TribeEvent, error)); ok {
+		return rf(event)
+	}
+	if rf, ok := ret.Get(0).(func(db.TribeEvent) db.TribeEvent); ok {
+		r0 = rf(event)
+	} else {
+		r0 = ret.Get(0).(db.TribeEvent)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.TribeEvent) error); ok {
+		r1 = rf(event)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_CreateOrEditTribeEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOrEditTribeEvent'
+type Database_CreateOrEditTribeEvent_Call struct {
+	*mock.Call
+}
+
+// CreateOrEditTribeEvent is a helper method to define mock.On call
+//   - event db.TribeEvent
+func (_e *Database_Expecter) CreateOrEditTribeEvent(event interface{}) *Database_CreateOrEditTribeEvent_Call {
+	return &Database_CreateOrEditTribeEvent_Call{Call: _e.mock.On("CreateOrEditTribeEvent", event)}
+}
+
+func (_c *Database_CreateOrEditTribeEvent_Call) Run(run func(event db.TribeEvent)) *Database_CreateOrEditTribeEvent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.TribeEvent))
+	})
+	return _c
+}
+
+func (_c *Database_CreateOrEditTribeEvent_Call) Return(_a0 db.TribeEvent, _a1 error) *Database_CreateOrEditTribeEvent_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_CreateOrEditTribeEvent_Call) RunAndReturn(run func(db.TribeEvent) (db.TribeEvent, error)) *Database_CreateOrEditTribeEvent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateOrEditWorkspace provides a mock function with given fields: m
+func (_m *Database) CreateOrEditWorkspace(m db.Workspace) (db.Workspace, error) {
+	ret := _m.Called(m)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateOrEditWorkspace")
+	}
+
+	var r0 db.Workspace
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.Workspace) (db.Workspace, error)); ok {
+		return rf(m)
+	}
+	if rf, ok := ret.Get(0).(func(db.Workspace) db.Workspace); ok {
+		r0 = rf(m)
+	} else {
+		r0 = ret.Get(0).(db.Workspace)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.Workspace) error); ok {
+		r1 = rf(m)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_CreateOrEditWorkspace_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOrEditWorkspace'
+type Database_CreateOrEditWorkspace_Call struct {
+	*mock.Call
+}
+
+// CreateOrEditWorkspace is a helper method to define mock.On call
+//   - m db.Workspace
+func (_e *Database_Expecter) CreateOrEditWorkspace(m interface{}) *Database_CreateOrEditWorkspace_Call {
+	return &Database_CreateOrEditWorkspace_Call{Call: _e.mock.On("CreateOrEditWorkspace", m)}
+}
+
+func (_c *Database_CreateOrEditWorkspace_Call) Run(run func(m db.Workspace)) *Database_CreateOrEditWorkspace_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.Workspace))
+	})
+	return _c
+}
+
+func (_c *Database_CreateOrEditWorkspace_Call) Return(_a0 db.Workspace, _a1 error) *Database_CreateOrEditWorkspace_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_CreateOrEditWorkspace_Call) RunAndReturn(run func(db.Workspace) (db.Workspace, error)) *Database_CreateOrEditWorkspace_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateOrEditWorkspaceBrief provides a mock function with given fields: brief
+func (_m *Database) CreateOrEditWorkspaceBrief(brief db.WorkspaceBrief) (db.WorkspaceBrief, error) {
+	ret := _m.Called(brief)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateOrEditWorkspaceBrief")
+	}
+
+	var r0 db.WorkspaceBrief
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.WorkspaceBrief) (db.WorkspaceBrief, error)); ok {
+		return rf(brief)
+	}
+	if rf, ok := ret.Get(0).(func(db.WorkspaceBrief) db.WorkspaceBrief); ok {
+		r0 = rf(brief)
+	} else {
+		r0 = ret.Get(0).(db.WorkspaceBrief)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.WorkspaceBrief) error); ok {
+		r1 = rf(brief)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_CreateOrEditWorkspaceBrief_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOrEditWorkspaceBrief'
+type Database_CreateOrEditWorkspaceBrief_Call struct {
+	*mock.Call
+}
+
+// CreateOrEditWorkspaceBrief is a helper method to define mock.On call
+//   - brief db.WorkspaceBrief
+func (_e *Database_Expecter) CreateOrEditWorkspaceBrief(brief interface{}) *Database_CreateOrEditWorkspaceBrief_Call {
+	return &Database_CreateOrEditWorkspaceBrief_Call{Call: _e.mock.On("CreateOrEditWorkspaceBrief", brief)}
+}
+
+func (_c *Database_CreateOrEditWorkspaceBrief_Call) Run(run func(brief db.WorkspaceBrief)) *Database_CreateOrEditWorkspaceBrief_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.WorkspaceBrief))
+	})
+	return _c
+}
+
+func (_c *Database_CreateOrEditWorkspaceBrief_Call) Return(_a0 db.WorkspaceBrief, _a1 error) *Database_CreateOrEditWorkspaceBrief_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_CreateOrEditWorkspaceBrief_Call) RunAndReturn(run func(db.WorkspaceBrief) (db.WorkspaceBrief, error)) *Database_CreateOrEditWorkspaceBrief_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateOrEditWorkspaceRepository provides a mock function with given fields: m
+func (_m *Database) CreateOrEditWorkspaceRepository(m db.WorkspaceRepositories) (db.WorkspaceRepositories, error) {
+	ret := _m.Called(m)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateOrEditWorkspaceRepository")
+	}
+
+	var r0 db.WorkspaceRepositories
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.WorkspaceRepositories) (db.WorkspaceRepositories, error)); ok {
+		return rf(m)
+	}
+	if rf, ok := ret.Get(0).(func(db.WorkspaceRepositories) db.WorkspaceRepositories); ok {
+		r0 = rf(m)
+	} else {
+		r0 = ret.Get(0).(db.WorkspaceRepositories)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.WorkspaceRepositories) error); ok {
+		r1 = rf(m)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_CreateOrEditWorkspaceRepository_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOrEditWorkspaceRepository'
+type Database_CreateOrEditWorkspaceRepository_Call struct {
+	*mock.Call
+}
+
+// CreateOrEditWorkspaceRepository is a helper method to define mock.On call
+//   - m db.WorkspaceRepositories
+func (_e *Database_Expecter) CreateOrEditWorkspaceRepository(m interface{}) *Database_CreateOrEditWorkspaceRepository_Call {
+	return &Database_CreateOrEditWorkspaceRepository_Call{Call: _e.mock.On("CreateOrEditWorkspaceRepository", m)}
+}
+
+func (_c *Database_CreateOrEditWorkspaceRepository_Call) Run(run func(m db.WorkspaceRepositories)) *Database_CreateOrEditWorkspaceRepository_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.WorkspaceRepositories))
+	})
+	return _c
+}
+
+func (_c *Database_CreateOrEditWorkspaceRepository_Call) Return(_a0 db.WorkspaceRepositories, _a1 error) *Database_CreateOrEditWorkspaceRepository_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_CreateOrEditWorkspaceRepository_Call) RunAndReturn(run func(db.WorkspaceRepositories) (db.WorkspaceRepositories, error)) *Database_CreateOrEditWorkspaceRepository_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateOrEditWorkspaceSecret provides a mock function with given fields: secret
+func (_m *Database) CreateOrEditWorkspaceSecret(secret db.WorkspaceSecret) (db.WorkspaceSecret, error) {
+	ret := _m.Called(secret)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateOrEditWorkspaceSecret")
+	}
+
+	var r0 db.WorkspaceSecret
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.WorkspaceSecret) (db.WorkspaceSecret, error)); ok {
+		return rf(secret)
+	}
+	if rf, ok := ret.Get(0).(func(db.WorkspaceSecret) db.WorkspaceSecret); ok {
+		r0 = rf(secret)
+	} else {
+		r0 = ret.Get(0).(db.WorkspaceSecret)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.WorkspaceSecret) error); ok {
+		r1 = rf(secret)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_CreateOrEditWorkspaceSecret_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOrEditWorkspaceSecret'
+type Database_CreateOrEditWorkspaceSecret_Call struct {
+	*mock.Call
+}
+
+// CreateOrEditWorkspaceSecret is a helper method to define mock.On call
+//   - secret db.WorkspaceSecret
+func (_e *Database_Expecter) CreateOrEditWorkspaceSecret(secret interface{}) *Database_CreateOrEditWorkspaceSecret_Call {
+	return &Database_CreateOrEditWorkspaceSecret_Call{Call: _e.mock.On("CreateOrEditWorkspaceSecret", secret)}
+}
+
+func (_c *Database_CreateOrEditWorkspaceSecret_Call) Run(run func(secret db.WorkspaceSecret)) *Database_CreateOrEditWorkspaceSecret_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.WorkspaceSecret))
+	})
+	return _c
+}
+
+func (_c *Database_CreateOrEditWorkspaceSecret_Call) Return(_a0 db.WorkspaceSecret, _a1 error) *Database_CreateOrEditWorkspaceSecret_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_CreateOrEditWorkspaceSecret_Call) RunAndReturn(run func(db.WorkspaceSecret) (db.WorkspaceSecret, error)) *Database_CreateOrEditWorkspaceSecret_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreatePendingPayment provides a mock function with given fields: payment
+func (_m *Database) CreatePendingPayment(payment db.NewPaymentHistory) (db.NewPaymentHistory, error) {
+	ret := _m.Called(payment)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreatePendingPayment")
+	}
+
+	var r0 db.NewPaymentHistory
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.NewPaymentHistory) (db.NewPaymentHistory, error)); ok {
+		return rf(payment)
+	}
+	if rf, ok := ret.Get(0).(func(db.NewPaymentHistory) db.NewPaymentHistory); ok {
+		r0 = rf(payment)
+	} else {
+		r0 = ret.Get(0).(db.NewPaymentHistory)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.NewPaymentHistory) error); ok {
+		r1 = rf(payment)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_CreatePendingPayment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreatePendingPayment'
+type Database_CreatePendingPayment_Call struct {
+	*mock.Call
+}
+
+// CreatePendingPayment is a helper method to define mock.On call
+//   - payment db.NewPaymentHistory
+func (_e *Database_Expecter) CreatePendingPayment(payment interface{}) *Database_CreatePendingPayment_Call {
+	return &Database_CreatePendingPayment_Call{Call: _e.mock.On("CreatePendingPayment", payment)}
+}
+
+func (_c *Database_CreatePendingPayment_Call) Run(run func(payment db.NewPaymentHistory)) *Database_CreatePendingPayment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.NewPaymentHistory))
+	})
+	return _c
+}
+
+func (_c *Database_CreatePendingPayment_Call) Return(_a0 db.NewPaymentHistory, _a1 error) *Database_CreatePendingPayment_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_CreatePendingPayment_Call) RunAndReturn(run func(db.NewPaymentHistory) (db.NewPaymentHistory, error)) *Database_CreatePendingPayment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreatePersonEndorsement provides a mock function with given fields: endorsement
+func (_m *Database) CreatePersonEndorsement(endorsement db.PersonEndorsement) (db.PersonEndorsement, error) {
+	ret := _m.Called(endorsement)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreatePersonEndorsement")
+	}
+
+	var r0 db.PersonEndorsement
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.PersonEndorsement) (db.PersonEndorsement, error)); ok {
+		return rf(endorsement)
+	}
+	if rf, ok := ret.Get(0).(func(db.PersonEndorsement) db.PersonEndorsement); ok {
+		r0 = rf(endorsement)
+	} else {
+		r0 = ret.Get(0).(db.PersonEndorsement)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.PersonEndorsement) error); ok {
+		r1 = rf(endorsement)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_CreatePersonEndorsement_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreatePersonEndorsement'
+type Database_CreatePersonEndorsement_Call struct {
+	*mock.Call
+}
+
+// CreatePersonEndorsement is a helper method to define mock.On call
+//   - endorsement db.PersonEndorsement
+func (_e *Database_Expecter) CreatePersonEndorsement(endorsement interface{}) *Database_CreatePersonEndorsement_Call {
+	return &Database_CreatePersonEndorsement_Call{Call: _e.mock.On("CreatePersonEndorsement", endorsement)}
+}
+
+func (_c *Database_CreatePersonEndorsement_Call) Run(run func(endorsement db.PersonEndorsement)) *Database_CreatePersonEndorsement_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.PersonEndorsement))
+	})
+	return _c
+}
+
+func (_c *Database_CreatePersonEndorsement_Call) Return(_a0 db.PersonEndorsement, _a1 error) *Database_CreatePersonEndorsement_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_CreatePersonEndorsement_Call) RunAndReturn(run func(db.PersonEndorsement) (db.PersonEndorsement, error)) *Database_CreatePersonEndorsement_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateReport provides a mock function with given fields: report
+func (_m *Database) CreateReport(report db.Report) (db.Report, error) {
+	ret := _m.Called(report)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateReport")
+	}
+
+	var r0 db.Report
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.Report) (db.Report, error)); ok {
+		return rf(report)
+	}
+	if rf, ok := ret.Get(0).(func(db.Report) db.Report); ok {
+		r0 = rf(report)
+	} else {
+		r0 = ret.Get(0).(db.Report)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.Report) error); ok {
+		r1 = rf(report)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_CreateReport_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateReport'
+type Database_CreateReport_Call struct {
+	*mock.Call
+}
+
+// CreateReport is a helper method to define mock.On call
+//   - report db.Report
+func (_e *Database_Expecter) CreateReport(report interface{}) *Database_CreateReport_Call {
+	return &Database_CreateReport_Call{Call: _e.mock.On("CreateReport", report)}
+}
+
+func (_c *Database_CreateReport_Call) Run(run func(report db.Report)) *Database_CreateReport_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.Report))
+	})
+	return _c
+}
+
+func (_c *Database_CreateReport_Call) Return(_a0 db.Report, _a1 error) *Database_CreateReport_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_CreateReport_Call) RunAndReturn(run func(db.Report) (db.Report, error)) *Database_CreateReport_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateReserveEntry provides a mock function with given fields: entry
+func (_m *Database) CreateReserveEntry(entry db.ReserveEntry) (db.ReserveEntry, error) {
+	ret := _m.Called(entry)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateReserveEntry")
+	}
+
+	var r0 db.ReserveEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.ReserveEntry) (db.ReserveEntry, error)); ok {
+		return rf(entry)
+	}
+	if rf, ok := ret.Get(0).(func(db.ReserveEntry) db.ReserveEntry); ok {
+		r0 = rf(entry)
+	} else {
+		r0 = ret.Get(0).(db.ReserveEntry)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.ReserveEntry) error); ok {
+		r1 = rf(entry)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_CreateReserveEntry_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateReserveEntry'
+type Database_CreateReserveEntry_Call struct {
+	*mock.Call
+}
+
+// CreateReserveEntry is a helper method to define mock.On call
+//   - entry db.ReserveEntry
+func (_e *Database_Expecter) CreateReserveEntry(entry interface{}) *Database_CreateReserveEntry_Call {
+	return &Database_CreateReserveEntry_Call{Call: _e.mock.On("CreateReserveEntry", entry)}
+}
+
+func (_c *Database_CreateReserveEntry_Call) Run(run func(entry db.ReserveEntry)) *Database_CreateReserveEntry_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.ReserveEntry))
+	})
+	return _c
+}
+
+func (_c *Database_CreateReserveEntry_Call) Return(_a0 db.ReserveEntry, _a1 error) *Database_CreateReserveEntry_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_CreateReserveEntry_Call) RunAndReturn(run func(db.ReserveEntry) (db.ReserveEntry, error)) *Database_CreateReserveEntry_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateRetentionRuns provides a mock function with given fields: runs
+func (_m *Database) CreateRetentionRuns(runs []db.RetentionRun) error {
+	ret := _m.Called(runs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateRetentionRuns")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]db.RetentionRun) error); ok {
+		r0 = rf(runs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_CreateRetentionRuns_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateRetentionRuns'
+type Database_CreateRetentionRuns_Call struct {
+	*mock.Call
+}
+
+// CreateRetentionRuns is a helper method to define mock.On call
+//   - runs []db.RetentionRun
+func (_e *Database_Expecter) CreateRetentionRuns(runs interface{}) *Database_CreateRetentionRuns_Call {
+	return &Database_CreateRetentionRuns_Call{Call: _e.mock.On("CreateRetentionRuns", runs)}
+}
+
+func (_c *Database_CreateRetentionRuns_Call) Run(run func(runs []db.RetentionRun)) *Database_CreateRetentionRuns_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]db.RetentionRun))
+	})
+	return _c
+}
+
+func (_c *Database_CreateRetentionRuns_Call) Return(_a0 error) *Database_CreateRetentionRuns_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_CreateRetentionRuns_Call) RunAndReturn(run func([]db.RetentionRun) error) *Database_CreateRetentionRuns_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateTimeEntry provides a mock function with given fields: entry
+func (_m *Database) CreateTimeEntry(entry db.TimeEntry) (db.TimeEntry, error) {
+	ret := _m.Called(entry)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateTimeEntry")
+	}
+
+	var r0 db.TimeEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.TimeEntry) (db.TimeEntry, error)); ok {
+		return rf(entry)
+	}
+	if rf, ok := ret.Get(0).(func(db.TimeEntry) db.TimeEntry); ok {
+		r0 = rf(entry)
+	} else {
+		r0 = ret.Get(0).(db.TimeEntry)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.TimeEntry) error); ok {
+		r1 = rf(entry)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_CreateTimeEntry_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateTimeEntry'
+type Database_CreateTimeEntry_Call struct {
+	*mock.Call
+}
+
+// CreateTimeEntry is a helper method to define mock.On call
+//   - entry db.TimeEntry
+func (_e *Database_Expecter) CreateTimeEntry(entry interface{}) *Database_CreateTimeEntry_Call {
+	return &Database_CreateTimeEntry_Call{Call: _e.mock.On("CreateTimeEntry", entry)}
+}
+
+func (_c *Database_CreateTimeEntry_Call) Run(run func(entry db.TimeEntry)) *Database_CreateTimeEntry_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.TimeEntry))
+	})
+	return _c
+}
+
+func (_c *Database_CreateTimeEntry_Call) Return(_a0 db.TimeEntry, _a1 error) *Database_CreateTimeEntry_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_CreateTimeEntry_Call) RunAndReturn(run func(db.TimeEntry) (db.TimeEntry, error)) *Database_CreateTimeEntry_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateTipInvoice provides a mock function with given fields: invoice, userData, payment
+func (_m *Database) CreateTipInvoice(invoice db.NewInvoiceList, userData db.UserInvoiceData, payment db.NewPaymentHistory) error {
+	ret := _m.Called(invoice, userData, payment)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateTipInvoice")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(db.NewInvoiceList, db.UserInvoiceData, db.NewPaymentHistory) error); ok {
+		r0 = rf(invoice, userData, payment)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_CreateTipInvoice_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateTipInvoice'
+type Database_CreateTipInvoice_Call struct {
+	*mock.Call
+}
+
+// CreateTipInvoice is a helper method to define mock.On call
+//   - invoice db.NewInvoiceList
+//   - userData db.UserInvoiceData
+//   - payment db.NewPaymentHistory
+func (_e *Database_Expecter) CreateTipInvoice(invoice interface{}, userData interface{}, payment interface{}) *Database_CreateTipInvoice_Call {
+	return &Database_CreateTipInvoice_Call{Call: _e.mock.On("CreateTipInvoice", invoice, userData, payment)}
+}
+
+func (_c *Database_CreateTipInvoice_Call) Run(run func(invoice db.NewInvoiceList, userData db.UserInvoiceData, payment db.NewPaymentHistory)) *Database_CreateTipInvoice_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.NewInvoiceList), args[1].(db.UserInvoiceData), args[2].(db.NewPaymentHistory))
+	})
+	return _c
+}
+
+func (_c *Database_CreateTipInvoice_Call) Return(_a0 error) *Database_CreateTipInvoice_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_CreateTipInvoice_Call) RunAndReturn(run func(db.NewInvoiceList, db.UserInvoiceData, db.NewPaymentHistory) error) *Database_CreateTipInvoice_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateTribeAppeal provides a mock function with given fields: appeal
+func (_m *Database) CreateTribeAppeal(appeal db.TribeAppeal) (db.TribeAppeal, error) {
+	ret := _m.Called(appeal)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateTribeAppeal")
+	}
+
+	var r0 db.TribeAppeal
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.TribeAppeal) (db.TribeAppeal, error)); ok {
+		return rf(appeal)
+	}
+	if rf, ok := ret.Get(0).(func(db.TribeAppeal) db.TribeAppeal); ok {
+		r0 = rf(appeal)
+	} else {
+		r0 = ret.Get(0).(db.TribeAppeal)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.TribeAppeal) error); ok {
+		r1 = rf(appeal)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_CreateTribeAppeal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateTribeAppeal'
+type Database_CreateTribeAppeal_Call struct {
+	*mock.Call
+}
+
+// CreateTribeAppeal is a helper method to define mock.On call
+//   - appeal db.TribeAppeal
+func (_e *Database_Expecter) CreateTribeAppeal(appeal interface{}) *Database_CreateTribeAppeal_Call {
+	return &Database_CreateTribeAppeal_Call{Call: _e.mock.On("CreateTribeAppeal", appeal)}
+}
+
+func (_c *Database_CreateTribeAppeal_Call) Run(run func(appeal db.TribeAppeal)) *Database_CreateTribeAppeal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.TribeAppeal))
+	})
+	return _c
+}
+
+func (_c *Database_CreateTribeAppeal_Call) Return(_a0 db.TribeAppeal, _a1 error) *Database_CreateTribeAppeal_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_CreateTribeAppeal_Call) RunAndReturn(run func(db.TribeAppeal) (db.TribeAppeal, error)) *Database_CreateTribeAppeal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateTribeWebhook provides a mock function with given fields: webhook
+func (_m *Database) CreateTribeWebhook(webhook db.TribeWebhook) (db.TribeWebhook, error) {
+	ret := _m.Called(webhook)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateTribeWebhook")
+	}
+
+	var r0 db.TribeWebhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.TribeWebhook) (db.TribeWebhook, error)); ok {
+		return rf(webhook)
+	}
+	if rf, ok := ret.Get(0).(func(db.TribeWebhook) db.TribeWebhook); ok {
+		r0 = rf(webhook)
+	} else {
+		r0 = ret.Get(0).(db.TribeWebhook)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.TribeWebhook) error); ok {
+		r1 = rf(webhook)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_CreateTribeWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateTribeWebhook'
+type Database_CreateTribeWebhook_Call struct {
+	*mock.Call
+}
+
+// CreateTribeWebhook is a helper method to define mock.On call
+//   - webhook db.TribeWebhook
+func (_e *Database_Expecter) CreateTribeWebhook(webhook interface{}) *Database_CreateTribeWebhook_Call {
+	return &Database_CreateTribeWebhook_Call{Call: _e.mock.On("CreateTribeWebhook", webhook)}
+}
+
+func (_c *Database_CreateTribeWebhook_Call) Run(run func(webhook db.TribeWebhook)) *Database_CreateTribeWebhook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.TribeWebhook))
+	})
+	return _c
+}
+
+func (_c *Database_CreateTribeWebhook_Call) Return(_a0 db.TribeWebhook, _a1 error) *Database_CreateTribeWebhook_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_CreateTribeWebhook_Call) RunAndReturn(run func(db.TribeWebhook) (db.TribeWebhook, error)) *Database_CreateTribeWebhook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateUploadSession provides a mock function with given fields: session
+func (_m *Database) CreateUploadSession(session db.UploadSession) (db.UploadSession, error) {
+	ret := _m.Called(session)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateUploadSession")
+	}
+
+	var r0 db.UploadSession
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.UploadSession) (db.UploadSession, error)); ok {
+		return rf(session)
+	}
+	if rf, ok := ret.Get(0).(func(db.UploadSession) db.UploadSession); ok {
+		r0 = rf(session)
+	} else {
+		r0 = ret.Get(0).(db.UploadSession)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.UploadSession) error); ok {
+		r1 = rf(session)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_CreateUploadSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateUploadSession'
+type Database_CreateUploadSession_Call struct {
+	*mock.Call
+}
+
+// CreateUploadSession is a helper method to define mock.On call
+//   - session db.UploadSession
+func (_e *Database_Expecter) CreateUploadSession(session interface{}) *Database_CreateUploadSession_Call {
+	return &Database_CreateUploadSession_Call{Call: _e.mock.On("CreateUploadSession", session)}
+}
+
+func (_c *Database_CreateUploadSession_Call) Run(run func(session db.UploadSession)) *Database_CreateUploadSession_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.UploadSession))
+	})
+	return _c
+}
+
+func (_c *Database_CreateUploadSession_Call) Return(_a0 db.UploadSession, _a1 error) *Database_CreateUploadSession_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_CreateUploadSession_Call) RunAndReturn(run func(db.UploadSession) (db.UploadSession, error)) *Database_CreateUploadSession_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateUserRoles provides a mock function with given fields: roles, uuid, pubkey
+func (_m *Database) CreateUserRoles(roles []db.WorkspaceUserRoles, uuid string, pubkey string) []db.WorkspaceUserRoles {
+	ret := _m.Called(roles, uuid, pubkey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateUserRoles")
+	}
+
+	var r0 []db.WorkspaceUserRoles
+	if rf, ok := ret.Get(0).(func([]db.WorkspaceUserRoles, string, string) []db.WorkspaceUserRoles); ok {
+		r0 = rf(roles, uuid, pubkey)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.WorkspaceUserRoles)
+		}
+	}
+
+	return r0
+}
+
+// Database_CreateUserRoles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateUserRoles'
+type Database_CreateUserRoles_Call struct {
+	*mock.Call
+}
+
+// CreateUserRoles is a helper method to define mock.On call
+//   - roles []db.WorkspaceUserRoles
+//   - uuid string
+//   - pubkey string
+func (_e *Database_Expecter) CreateUserRoles(roles interface{}, uuid interface{}, pubkey interface{}) *Database_CreateUserRoles_Call {
+	return &Database_CreateUserRoles_Call{Call: _e.mock.On("CreateUserRoles", roles, uuid, pubkey)}
+}
+
+func (_c *Database_CreateUserRoles_Call) Run(run func(roles []db.WorkspaceUserRoles, uuid string, pubkey string)) *Database_CreateUserRoles_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]db.WorkspaceUserRoles), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *Database_CreateUserRoles_Call) Return(_a0 []db.WorkspaceUserRoles) *Database_CreateUserRoles_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_CreateUserRoles_Call) RunAndReturn(run func([]db.WorkspaceUserRoles, string, string) []db.WorkspaceUserRoles) *Database_CreateUserRoles_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateWorkspaceBudget provides a mock function with given fields: budget
+func (_m *Database) CreateWorkspaceBudget(budget db.NewBountyBudget) db.NewBountyBudget {
+	ret := _m.Called(budget)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateWorkspaceBudget")
+	}
+
+	var r0 db.NewBountyBudget
+	if rf, ok := ret.Get(0).(func(db.NewBountyBudget) db.NewBountyBudget); ok {
+		r0 = rf(budget)
+	} else {
+		r0 = ret.Get(0).(db.NewBountyBudget)
+	}
+
+	return r0
+}
+
+// Database_CreateWorkspaceBudget_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateWorkspaceBudget'
+type Database_CreateWorkspaceBudget_Call struct {
+	*mock.Call
+}
+
+// CreateWorkspaceBudget is a helper method to define mock.On call
+//   - budget db.NewBountyBudget
+func (_e *Database_Expecter) CreateWorkspaceBudget(budget interface{}) *Database_CreateWorkspaceBudget_Call {
+	return &Database_CreateWorkspaceBudget_Call{Call: _e.mock.On("CreateWorkspaceBudget", budget)}
+}
+
+func (_c *Database_CreateWorkspaceBudget_Call) Run(run func(budget db.NewBountyBudget)) *Database_CreateWorkspaceBudget_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.NewBountyBudget))
+	})
+	return _c
+}
+
+func (_c *Database_CreateWorkspaceBudget_Call) Return(_a0 db.NewBountyBudget) *Database_CreateWorkspaceBudget_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_CreateWorkspaceBudget_Call) RunAndReturn(run func(db.NewBountyBudget) db.NewBountyBudget) *Database_CreateWorkspaceBudget_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateWorkspaceDigest provides a mock function with given fields: workspaceUuid, start, end
+func (_m *Database) CreateWorkspaceDigest(workspaceUuid string, start time.Time, end time.Time) (db.WorkspaceDigest, error) {
+	ret := _m.Called(workspaceUuid, start, end)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateWorkspaceDigest")
+	}
+
+	var r0 db.WorkspaceDigest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, time.Time, time.Time) (db.WorkspaceDigest, error)); ok {
+		return rf(workspaceUuid, start, end)
+	}
+	if rf, ok := ret.Get(0).(func(string, time.Time, time.Time) db.WorkspaceDigest); ok {
+		r0 = rf(workspaceUuid, start, end)
+	} else {
+		r0 = ret.Get(0).(db.WorkspaceDigest)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, time.Time, time.Time) error); ok {
+		r1 = rf(workspaceUuid, start, end)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_CreateWorkspaceDigest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateWorkspaceDigest'
+type Database_CreateWorkspaceDigest_Call struct {
+	*mock.Call
+}
+
+// CreateWorkspaceDigest is a helper method to define mock.On call
+//   - workspaceUuid string
+//   - start time.Time
+//   - end time.Time
+func (_e *Database_Expecter) CreateWorkspaceDigest(workspaceUuid interface{}, start interface{}, end interface{}) *Database_CreateWorkspaceDigest_Call {
+	return &Database_CreateWorkspaceDigest_Call{Call: _e.mock.On("CreateWorkspaceDigest", workspaceUuid, start, end)}
+}
+
+func (_c *Database_CreateWorkspaceDigest_Call) Run(run func(workspaceUuid string, start time.Time, end time.Time)) *Database_CreateWorkspaceDigest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(time.Time), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *Database_CreateWorkspaceDigest_Call) Return(_a0 db.WorkspaceDigest, _a1 error) *Database_CreateWorkspaceDigest_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_CreateWorkspaceDigest_Call) RunAndReturn(run func(string, time.Time, time.Time) (db.WorkspaceDigest, error)) *Database_CreateWorkspaceDigest_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateWorkspaceExport provides a mock function with given fields: export
+func (_m *Database) CreateWorkspaceExport(export db.WorkspaceExport) (db.WorkspaceExport, error) {
+	ret := _m.Called(export)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateWorkspaceExport")
+	}
+
+	var r0 db.WorkspaceExport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.WorkspaceExport) (db.WorkspaceExport, error)); ok {
+		return rf(export)
+	}
+	if rf, ok := ret.Get(0).(func(db.WorkspaceExport) db.WorkspaceExport); ok {
+		r0 = rf(export)
+	} else {
+		r0 = ret.Get(0).(db.WorkspaceExport)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.WorkspaceExport) error); ok {
+		r1 = rf(export)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_CreateWorkspaceExport_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateWorkspaceExport'
+type Database_CreateWorkspaceExport_Call struct {
+	*mock.Call
+}
+
+// CreateWorkspaceExport is a helper method to define mock.On call
+//   - export db.WorkspaceExport
+func (_e *Database_Expecter) CreateWorkspaceExport(export interface{}) *Database_CreateWorkspaceExport_Call {
+	return &Database_CreateWorkspaceExport_Call{Call: _e.mock.On("CreateWorkspaceExport", export)}
+}
+
+func (_c *Database_CreateWorkspaceExport_Call) Run(run func(export db.WorkspaceExport)) *Database_CreateWorkspaceExport_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.WorkspaceExport))
+	})
+	return _c
+}
+
+func (_c *Database_CreateWorkspaceExport_Call) Return(_a0 db.WorkspaceExport, _a1 error) *Database_CreateWorkspaceExport_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_CreateWorkspaceExport_Call) RunAndReturn(run func(db.WorkspaceExport) (db.WorkspaceExport, error)) *Database_CreateWorkspaceExport_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateWorkspaceUser provides a mock function with given fields: orgUser
+func (_m *Database) CreateWorkspaceUser(orgUser db.WorkspaceUsers) db.WorkspaceUsers {
+	ret := _m.Called(orgUser)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateWorkspaceUser")
+	}
+
+	var r0 db.WorkspaceUsers
+	if rf, ok := ret.Get(0).(func(db.WorkspaceUsers) db.WorkspaceUsers); ok {
+		r0 = rf(orgUser)
+	} else {
+		r0 = ret.Get(0).(db.WorkspaceUsers)
+	}
+
+	return r0
+}
+
+// Database_CreateWorkspaceUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateWorkspaceUser'
+type Database_CreateWorkspaceUser_Call struct {
+	*mock.Call
+}
+
+// CreateWorkspaceUser is a helper method to define mock.On call
+//   - orgUser db.WorkspaceUsers
+func (_e *Database_Expecter) CreateWorkspaceUser(orgUser interface{}) *Database_CreateWorkspaceUser_Call {
+	return &Database_CreateWorkspaceUser_Call{Call: _e.mock.On("CreateWorkspaceUser", orgUser)}
+}
+
+func (_c *Database_CreateWorkspaceUser_Call) Run(run func(orgUser db.WorkspaceUsers)) *Database_CreateWorkspaceUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.WorkspaceUsers))
+	})
+	return _c
+}
+
+func (_c *Database_CreateWorkspaceUser_Call) Return(_a0 db.WorkspaceUsers) *Database_CreateWorkspaceUser_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_CreateWorkspaceUser_Call) RunAndReturn(run func(db.WorkspaceUsers) db.WorkspaceUsers) *Database_CreateWorkspaceUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteAllUsersFromWorkspace provides a mock function with given fields: uuid
+func (_m *Database) DeleteAllUsersFromWorkspace(uuid string) error {
+	ret := _m.Called(uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAllUsersFromWorkspace")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(uuid)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_DeleteAllUsersFromWorkspace_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteAllUsersFromWorkspace'
+type Database_DeleteAllUsersFromWorkspace_Call struct {
+	*mock.Call
+}
+
+// DeleteAllUsersFromWorkspace is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) DeleteAllUsersFromWorkspace(uuid interface{}) *Database_DeleteAllUsersFromWorkspace_Call {
+	return &Database_DeleteAllUsersFromWorkspace_Call{Call: _e.mock.On("DeleteAllUsersFromWorkspace", uuid)}
+}
+
+func (_c *Database_DeleteAllUsersFromWorkspace_Call) Run(run func(uuid string)) *Database_DeleteAllUsersFromWorkspace_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_DeleteAllUsersFromWorkspace_Call) Return(_a0 error) *Database_DeleteAllUsersFromWorkspace_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_DeleteAllUsersFromWorkspace_Call) RunAndReturn(run func(string) error) *Database_DeleteAllUsersFromWorkspace_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteArtifact provides a mock function with given fields: uuid
+func (_m *Database) DeleteArtifact(uuid string) error {
+	ret := _m.Called(uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteArtifact")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(uuid)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_DeleteArtifact_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteArtifact'
+type Database_DeleteArtifact_Call struct {
+	*mock.Call
+}
+
+// DeleteArtifact is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) DeleteArtifact(uuid interface{}) *Database_DeleteArtifact_Call {
+	return &Database_DeleteArtifact_Call{Call: _e.mock.On("DeleteArtifact", uuid)}
+}
+
+func (_c *Database_DeleteArtifact_Call) Run(run func(uuid string)) *Database_DeleteArtifact_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_DeleteArtifact_Call) Return(_a0 error) *Database_DeleteArtifact_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_DeleteArtifact_Call) RunAndReturn(run func(string) error) *Database_DeleteArtifact_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteBounty provides a mock function with given fields: pubkey, created
+func (_m *Database) DeleteBounty(pubkey string, created string) (db.NewBounty, error) {
+	ret := _m.Called(pubkey, created)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteBounty")
+	}
+
+	var r0 db.NewBounty
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (db.NewBounty, error)); ok {
+		return rf(pubkey, created)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) db.NewBounty); ok {
+		r0 = rf(pubkey, created)
+	} else {
+		r0 = ret.Get(0).(db.NewBounty)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(pubkey, created)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_DeleteBounty_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteBounty'
+type Database_DeleteBounty_Call struct {
+	*mock.Call
+}
+
+// DeleteBounty is a helper method to define mock.On call
+//   - pubkey string
+//   - created string
+func (_e *Database_Expecter) DeleteBounty(pubkey interface{}, created interface{}) *Database_DeleteBounty_Call {
+	return &Database_DeleteBounty_Call{Call: _e.mock.On("DeleteBounty", pubkey, created)}
+}
+
+func (_c *Database_DeleteBounty_Call) Run(run func(pubkey string, created string)) *Database_DeleteBounty_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_DeleteBounty_Call) Return(_a0 db.NewBounty, _a1 error) *Database_DeleteBounty_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_DeleteBounty_Call) RunAndReturn(run func(string, string) (db.NewBounty, error)) *Database_DeleteBounty_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteBountyRoutingRule provides a mock function with given fields: uuid
+func (_m *Database) DeleteBountyRoutingRule(uuid string) error {
+	ret := _m.Called(uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteBountyRoutingRule")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(uuid)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_DeleteBountyRoutingRule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteBountyRoutingRule'
+type Database_DeleteBountyRoutingRule_Call struct {
+	*mock.Call
+}
+
+// DeleteBountyRoutingRule is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) DeleteBountyRoutingRule(uuid interface{}) *Database_DeleteBountyRoutingRule_Call {
+	return &Database_DeleteBountyRoutingRule_Call{Call: _e.mock.On("DeleteBountyRoutingRule", uuid)}
+}
+
+func (_c *Database_DeleteBountyRoutingRule_Call) Run(run func(uuid string)) *Database_DeleteBountyRoutingRule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_DeleteBountyRoutingRule_Call) Return(_a0 error) *Database_DeleteBountyRoutingRule_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_DeleteBountyRoutingRule_Call) RunAndReturn(run func(string) error) *Database_DeleteBountyRoutingRule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteCategory provides a mock function with given fields: slug
+func (_m *Database) DeleteCategory(slug string) error {
+	ret := _m.Called(slug)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteCategory")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(slug)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_DeleteCategory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteCategory'
+type Database_DeleteCategory_Call struct {
+	*mock.Call
+}
+
+// DeleteCategory is a helper method to define mock.On call
+//   - slug string
+func (_e *Database_Expecter) DeleteCategory(slug interface{}) *Database_DeleteCategory_Call {
+	return &Database_DeleteCategory_Call{Call: _e.mock.On("DeleteCategory", slug)}
+}
+
+func (_c *Database_DeleteCategory_Call) Run(run func(slug string)) *Database_DeleteCategory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_DeleteCategory_Call) Return(_a0 error) *Database_DeleteCategory_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_DeleteCategory_Call) RunAndReturn(run func(string) error) *Database_DeleteCategory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteFeatureByUuid provides a mock function with given fields: uuid
+func (_m *Database) DeleteFeatureByUuid(uuid string) error {
+	ret := _m.Called(uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteFeatureByUuid")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(uuid)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_DeleteFeatureByUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteFeatureByUuid'
+type Database_DeleteFeatureByUuid_Call struct {
+	*mock.Call
+}
+
+// DeleteFeatureByUuid is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) DeleteFeatureByUuid(uuid interface{}) *Database_DeleteFeatureByUuid_Call {
+	return &Database_DeleteFeatureByUuid_Call{Call: _e.mock.On("DeleteFeatureByUuid", uuid)}
+}
+
+func (_c *Database_DeleteFeatureByUuid_Call) Run(run func(uuid string)) *Database_DeleteFeatureByUuid_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_DeleteFeatureByUuid_Call) Return(_a0 error) *Database_DeleteFeatureByUuid_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_DeleteFeatureByUuid_Call) RunAndReturn(run func(string) error) *Database_DeleteFeatureByUuid_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteFeaturePhase provides a mock function with given fields: featureUuid, phaseUuid
+func (_m *Database) DeleteFeaturePhase(featureUuid string, phaseUuid string) error {
+	ret := _m.Called(featureUuid, phaseUuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteFeaturePhase")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(featureUuid, phaseUuid)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_DeleteFeaturePhase_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteFeaturePhase'
+type Database_DeleteFeaturePhase_Call struct {
+	*mock.Call
+}
+
+// DeleteFeaturePhase is a helper method to define mock.On call
+//   - featureUuid string
+//   - phaseUuid string
+func (_e *Database_Expecter) DeleteFeaturePhase(featureUuid interface{}, phaseUuid interface{}) *Database_DeleteFeaturePhase_Call {
+	return &Database_DeleteFeaturePhase_Call{Call: _e.mock.On("DeleteFeaturePhase", featureUuid, phaseUuid)}
+}
+
+func (_c *Database_DeleteFeaturePhase_Call) Run(run func(featureUuid string, phaseUuid string)) *Database_DeleteFeaturePhase_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_DeleteFeaturePhase_Call) Return(_a0 error) *Database_DeleteFeaturePhase_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_DeleteFeaturePhase_Call) RunAndReturn(run func(string, string) error) *Database_DeleteFeaturePhase_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteFeatureStoryByUuid provides a mock function with given fields: featureUuid, storyUuid
+func (_m *Database) DeleteFeatureStoryByUuid(featureUuid string, storyUuid string) error {
+	ret := _m.Called(featureUuid, storyUuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteFeatureStoryByUuid")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(featureUuid, storyUuid)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_DeleteFeatureStoryByUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteFeatureStoryByUuid'
+type Database_DeleteFeatureStoryByUuid_Call struct {
+	*mock.Call
+}
+
+// DeleteFeatureStoryByUuid is a helper method to define mock.On call
+//   - featureUuid string
+//   - storyUuid string
+func (_e *Database_Expecter) DeleteFeatureStoryByUuid(featureUuid interface{}, storyUuid interface{}) *Database_DeleteFeatureStoryByUuid_Call {
+	return &Database_DeleteFeatureStoryByUuid_Call{Call: _e.mock.On("DeleteFeatureStoryByUuid", featureUuid, storyUuid)}
+}
+
+func (_c *Database_DeleteFeatureStoryByUuid_Call) Run(run func(featureUuid string, storyUuid string)) *Database_DeleteFeatureStoryByUuid_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_DeleteFeatureStoryByUuid_Call) Return(_a0 error) *Database_DeleteFeatureStoryByUuid_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_DeleteFeatureStoryByUuid_Call) RunAndReturn(run func(string, string) error) *Database_DeleteFeatureStoryByUuid_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteInvoice provides a mock function with given fields: payment_request
+func (_m *Database) DeleteInvoice(payment_request string) db.NewInvoiceList {
+	ret := _m.Called(payment_request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteInvoice")
+	}
+
+	var r0 db.NewInvoiceList
+	if rf, ok := ret.Get(0).(func(string) db.NewInvoiceList); ok {
+		r0 = rf(payment_request)
+	} else {
+		r0 = ret.Get(0).(db.NewInvoiceList)
+	}
+
+	return r0
+}
+
+// Database_DeleteInvoice_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteInvoice'
+type Database_DeleteInvoice_Call struct {
+	*mock.Call
+}
+
+// DeleteInvoice is a helper method to define mock.On call
+//   - payment_request string
+func (_e *Database_Expecter) DeleteInvoice(payment_request interface{}) *Database_DeleteInvoice_Call {
+	return &Database_DeleteInvoice_Call{Call: _e.mock.On("DeleteInvoice", payment_request)}
+}
+
+func (_c *Database_DeleteInvoice_Call) Run(run func(payment_request string)) *Database_DeleteInvoice_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_DeleteInvoice_Call) Return(_a0 db.NewInvoiceList) *Database_DeleteInvoice_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_DeleteInvoice_Call) RunAndReturn(run func(string) db.NewInvoiceList) *Database_DeleteInvoice_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteQuest provides a mock function with given fields: uuid
+func (_m *Database) DeleteQuest(uuid string) error {
+	ret := _m.Called(uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteQuest")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(uuid)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_DeleteQuest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteQuest'
+type Database_DeleteQuest_Call struct {
+	*mock.Call
+}
+
+// DeleteQuest is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) DeleteQuest(uuid interface{}) *Database_DeleteQuest_Call {
+	return &Database_DeleteQuest_Call{Call: _e.mock.On("DeleteQuest", uuid)}
+}
+
+func (_c *Database_DeleteQuest_Call) Run(run func(uuid string)) *Database_DeleteQuest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_DeleteQuest_Call) Return(_a0 error) *Database_DeleteQuest_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_DeleteQuest_Call) RunAndReturn(run func(string) error) *Database_DeleteQuest_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteTimeEntry provides a mock function with given fields: uuid
+func (_m *Database) DeleteTimeEntry(uuid string) error {
+	ret := _m.Called(uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteTimeEntry")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(uuid)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_DeleteTimeEntry_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteTimeEntry'
+type Database_DeleteTimeEntry_Call struct {
+	*mock.Call
+}
+
+// DeleteTimeEntry is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) DeleteTimeEntry(uuid interface{}) *Database_DeleteTimeEntry_Call {
+	return &Database_DeleteTimeEntry_Call{Call: _e.mock.On("DeleteTimeEntry", uuid)}
+}
+
+func (_c *Database_DeleteTimeEntry_Call) Run(run func(uuid string)) *Database_DeleteTimeEntry_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_DeleteTimeEntry_Call) Return(_a0 error) *Database_DeleteTimeEntry_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_DeleteTimeEntry_Call) RunAndReturn(run func(string) error) *Database_DeleteTimeEntry_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteTribeAnnouncement provides a mock function with given fields: uuid
+func (_m *Database) DeleteTribeAnnouncement(uuid string) error {
+	ret := _m.Called(uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteTribeAnnouncement")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(uuid)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_DeleteTribeAnnouncement_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteTribeAnnouncement'
+type Database_DeleteTribeAnnouncement_Call struct {
+	*mock.Call
+}
+
+// DeleteTribeAnnouncement is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) DeleteTribeAnnouncement(uuid interface{}) *Database_DeleteTribeAnnouncement_Call {
+	return &Database_DeleteTribeAnnouncement_Call{Call: _e.mock.On("DeleteTribeAnnouncement", uuid)}
+}
+
+func (_c *Database_DeleteTribeAnnouncement_Call) Run(run func(uuid string)) *Database_DeleteTribeAnnouncement_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_DeleteTribeAnnouncement_Call) Return(_a0 error) *Database_DeleteTribeAnnouncement_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_DeleteTribeAnnouncement_Call) RunAndReturn(run func(string) error) *Database_DeleteTribeAnnouncement_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteTribeEvent provides a mock function with given fields: uuid
+func (_m *Database) DeleteTribeEvent(uuid string) error {
+	ret := _m.Called(uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteTribeEvent")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(uuid)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_DeleteTribeEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteTribeEvent'
+type Database_DeleteTribeEvent_Call struct {
+	*mock.Call
+}
+
+// DeleteTribeEvent is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) DeleteTribeEvent(uuid interface{}) *Database_DeleteTribeEvent_Call {
+	return &Database_DeleteTribeEvent_Call{Call: _e.mock.On("DeleteTribeEvent", uuid)}
+}
+
+func (_c *Database_DeleteTribeEvent_Call) Run(run func(uuid string)) *Database_DeleteTribeEvent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_DeleteTribeEvent_Call) Return(_a0 error) *Database_DeleteTribeEvent_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_DeleteTribeEvent_Call) RunAndReturn(run func(string) error) *Database_DeleteTribeEvent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteTribeWebhook provides a mock function with given fields: uuid
+func (_m *Database) DeleteTribeWebhook(uuid string) error {
+	ret := _m.Called(uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteTribeWebhook")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(uuid)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_DeleteTribeWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteTribeWebhook'
+type Database_DeleteTribeWebhook_Call struct {
+	*mock.Call
+}
+
+// DeleteTribeWebhook is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) DeleteTribeWebhook(uuid interface{}) *Database_DeleteTribeWebhook_Call {
+	return &Database_DeleteTribeWebhook_Call{Call: _e.mock.On("DeleteTribeWebhook", uuid)}
+}
+
+func (_c *Database_DeleteTribeWebhook_Call) Run(run func(uuid string)) *Database_DeleteTribeWebhook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_DeleteTribeWebhook_Call) Return(_a0 error) *Database_DeleteTribeWebhook_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_DeleteTribeWebhook_Call) RunAndReturn(run func(string) error) *Database_DeleteTribeWebhook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteUploadSession provides a mock function with given fields: uuid
+func (_m *Database) DeleteUploadSession(uuid string) error {
+	ret := _m.Called(uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteUploadSession")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(uuid)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_DeleteUploadSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteUploadSession'
+type Database_DeleteUploadSession_Call struct {
+	*mock.Call
+}
+
+// DeleteUploadSession is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) DeleteUploadSession(uuid interface{}) *Database_DeleteUploadSession_Call {
+	return &Database_DeleteUploadSession_Call{Call: _e.mock.On("DeleteUploadSession", uuid)}
+}
+
+func (_c *Database_DeleteUploadSession_Call) Run(run func(uuid string)) *Database_DeleteUploadSession_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_DeleteUploadSession_Call) Return(_a0 error) *Database_DeleteUploadSession_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_DeleteUploadSession_Call) RunAndReturn(run func(string) error) *Database_DeleteUploadSession_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteUserInvoiceData provides a mock function with given fields: payment_request
+func (_m *Database) DeleteUserInvoiceData(payment_request string) db.UserInvoiceData {
+	ret := _m.Called(payment_request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteUserInvoiceData")
+	}
+
+	var r0 db.UserInvoiceData
+	if rf, ok := ret.Get(0).(func(string) db.UserInvoiceData); ok {
+		r0 = rf(payment_request)
+	} else {
+		r0 = ret.Get(0).(db.UserInvoiceData)
+	}
+
+	return r0
+}
+
+// Database_DeleteUserInvoiceData_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteUserInvoiceData'
+type Database_DeleteUserInvoiceData_Call struct {
+	*mock.Call
+}
+
+// DeleteUserInvoiceData is a helper method to define mock.On call
+//   - payment_request string
+func (_e *Database_Expecter) DeleteUserInvoiceData(payment_request interface{}) *Database_DeleteUserInvoiceData_Call {
+	return &Database_DeleteUserInvoiceData_Call{Call: _e.mock.On("DeleteUserInvoiceData", payment_request)}
+}
+
+func (_c *Database_DeleteUserInvoiceData_Call) Run(run func(payment_request string)) *Database_DeleteUserInvoiceData_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_DeleteUserInvoiceData_Call) Return(_a0 db.UserInvoiceData) *Database_DeleteUserInvoiceData_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_DeleteUserInvoiceData_Call) RunAndReturn(run func(string) db.UserInvoiceData) *Database_DeleteUserInvoiceData_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteWorkspaceRepository provides a mock function with given fields: workspace_uuid, uuid
+func (_m *Database) DeleteWorkspaceRepository(workspace_uuid string, uuid string) bool {
+	ret := _m.Called(workspace_uuid, uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteWorkspaceRepository")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string, string) bool); ok {
+		r0 = rf(workspace_uuid, uuid)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// Database_DeleteWorkspaceRepository_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteWorkspaceRepository'
+type Database_DeleteWorkspaceRepository_Call struct {
+	*mock.Call
+}
+
+// DeleteWorkspaceRepository is a helper method to define mock.On call
+//   - workspace_uuid string
+//   - uuid string
+func (_e *Database_Expecter) DeleteWorkspaceRepository(workspace_uuid interface{}, uuid interface{}) *Database_DeleteWorkspaceRepository_Call {
+	return &Database_DeleteWorkspaceRepository_Call{Call: _e.mock.On("DeleteWorkspaceRepository", workspace_uuid, uuid)}
+}
+
+func (_c *Database_DeleteWorkspaceRepository_Call) Run(run func(workspace_uuid string, uuid string)) *Database_DeleteWorkspaceRepository_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_DeleteWorkspaceRepository_Call) Return(_a0 bool) *Database_DeleteWorkspaceRepository_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_DeleteWorkspaceRepository_Call) RunAndReturn(run func(string, string) bool) *Database_DeleteWorkspaceRepository_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteWorkspaceSecret provides a mock function with given fields: workspaceUuid, name
+func (_m *Database) DeleteWorkspaceSecret(workspaceUuid string, name string) error {
+	ret := _m.Called(workspaceUuid, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteWorkspaceSecret")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(workspaceUuid, name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_DeleteWorkspaceSecret_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteWorkspaceSecret'
+type Database_DeleteWorkspaceSecret_Call struct {
+	*mock.Call
+}
+
+// DeleteWorkspaceSecret is a helper method to define mock.On call
+//   - workspaceUuid string
+//   - name string
+func (_e *Database_Expecter) DeleteWorkspaceSecret(workspaceUuid interface{}, name interface{}) *Database_DeleteWorkspaceSecret_Call {
+	return &Database_DeleteWorkspaceSecret_Call{Call: _e.mock.On("DeleteWorkspaceSecret", workspaceUuid, name)}
+}
+
+func (_c *Database_DeleteWorkspaceSecret_Call) Run(run func(workspaceUuid string, name string)) *Database_DeleteWorkspaceSecret_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_DeleteWorkspaceSecret_Call) Return(_a0 error) *Database_DeleteWorkspaceSecret_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_DeleteWorkspaceSecret_Call) RunAndReturn(run func(string, string) error) *Database_DeleteWorkspaceSecret_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteWorkspaceUser provides a mock function with given fields: orgUser, org
+func (_m *Database) DeleteWorkspaceUser(orgUser db.WorkspaceUsersData, org string) db.WorkspaceUsersData {
+	ret := _m.Called(orgUser, org)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteWorkspaceUser")
+	}
+
+	var r0 db.WorkspaceUsersData
+	if rf, ok := ret.Get(0).(func(db.WorkspaceUsersData, string) db.WorkspaceUsersData); ok {
+		r0 = rf(orgUser, org)
+	} else {
+		r0 = ret.Get(0).(db.WorkspaceUsersData)
+	}
+
+	return r0
+}
+
+// Database_DeleteWorkspaceUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteWorkspaceUser'
+type Database_DeleteWorkspaceUser_Call struct {
+	*mock.Call
+}
+
+// DeleteWorkspaceUser is a helper method to define mock.On call
+//   - orgUser db.WorkspaceUsersData
+//   - org string
+func (_e *Database_Expecter) DeleteWorkspaceUser(orgUser interface{}, org interface{}) *Database_DeleteWorkspaceUser_Call {
+	return &Database_DeleteWorkspaceUser_Call{Call: _e.mock.On("DeleteWorkspaceUser", orgUser, org)}
+}
+
+func (_c *Database_DeleteWorkspaceUser_Call) Run(run func(orgUser db.WorkspaceUsersData, org string)) *Database_DeleteWorkspaceUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.WorkspaceUsersData), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_DeleteWorkspaceUser_Call) Return(_a0 db.WorkspaceUsersData) *Database_DeleteWorkspaceUser_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_DeleteWorkspaceUser_Call) RunAndReturn(run func(db.WorkspaceUsersData, string) db.WorkspaceUsersData) *Database_DeleteWorkspaceUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EnqueueJob provides a mock function with given fields: job
+func (_m *Database) EnqueueJob(job db.Job) (db.Job, error) {
+	ret := _m.Called(job)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnqueueJob")
+	}
+
+	var r0 db.Job
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.Job) (db.Job, error)); ok {
+		return rf(job)
+	}
+	if rf, ok := ret.Get(0).(func(db.Job) db.Job); ok {
+		r0 = rf(job)
+	} else {
+		r0 = ret.Get(0).(db.Job)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.Job) error); ok {
+		r1 = rf(job)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_EnqueueJob_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnqueueJob'
+type Database_EnqueueJob_Call struct {
+	*mock.Call
+}
+
+// EnqueueJob is a helper method to define mock.On call
+//   - job db.Job
+func (_e *Database_Expecter) EnqueueJob(job interface{}) *Database_EnqueueJob_Call {
+	return &Database_EnqueueJob_Call{Call: _e.mock.On("EnqueueJob", job)}
+}
+
+func (_c *Database_EnqueueJob_Call) Run(run func(job db.Job)) *Database_EnqueueJob_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.Job))
+	})
+	return _c
+}
+
+func (_c *Database_EnqueueJob_Call) Return(_a0 db.Job, _a1 error) *Database_EnqueueJob_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_EnqueueJob_Call) RunAndReturn(run func(db.Job) (db.Job, error)) *Database_EnqueueJob_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ExplainHotQueries provides a mock function with given fields:
+func (_m *Database) ExplainHotQueries() ([]db.QueryPlan, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExplainHotQueries")
+	}
+
+	var r0 []db.QueryPlan
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]db.QueryPlan, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []db.QueryPlan); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.QueryPlan)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_ExplainHotQueries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExplainHotQueries'
+type Database_ExplainHotQueries_Call struct {
+	*mock.Call
+}
+
+// ExplainHotQueries is a helper method to define mock.On call
+func (_e *Database_Expecter) ExplainHotQueries() *Database_ExplainHotQueries_Call {
+	return &Database_ExplainHotQueries_Call{Call: _e.mock.On("ExplainHotQueries")}
+}
+
+func (_c *Database_ExplainHotQueries_Call) Run(run func()) *Database_ExplainHotQueries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Database_ExplainHotQueries_Call) Return(_a0 []db.QueryPlan, _a1 error) *Database_ExplainHotQueries_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_ExplainHotQueries_Call) RunAndReturn(run func() ([]db.QueryPlan, error)) *Database_ExplainHotQueries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FailJob provides a mock function with given fields: job, lastError, retryAt
+func (_m *Database) FailJob(job db.Job, lastError string, retryAt time.Time) (db.Job, error) {
+	ret := _m.Called(job, lastError, retryAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FailJob")
+	}
+
+	var r0 db.Job
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.Job, string, time.Time) (db.Job, error)); ok {
+		return rf(job, lastError, retryAt)
+	}
+	if rf, ok := ret.Get(0).(func(db.Job, string, time.Time) db.Job); ok {
+		r0 = rf(job, lastError, retryAt)
+	} else {
+		r0 = ret.Get(0).(db.Job)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.Job, string, time.Time) error); ok {
+		r1 = rf(job, lastError, retryAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_FailJob_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FailJob'
+type Database_FailJob_Call struct {
+	*mock.Call
+}
+
+// FailJob is a helper method to define mock.On call
+//   - job db.Job
+//   - lastError string
+//   - retryAt time.Time
+func (_e *Database_Expecter) FailJob(job interface{}, lastError interface{}, retryAt interface{}) *Database_FailJob_Call {
+	return &Database_FailJob_Call{Call: _e.mock.On("FailJob", job, lastError, retryAt)}
+}
+
+func (_c *Database_FailJob_Call) Run(run func(job db.Job, lastError string, retryAt time.Time)) *Database_FailJob_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.Job), args[1].(string), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *Database_FailJob_Call) Return(_a0 db.Job, _a1 error) *Database_FailJob_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_FailJob_Call) RunAndReturn(run func(db.Job, string, time.Time) (db.Job, error)) *Database_FailJob_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FinishWorkspaceBrief provides a mock function with given fields: brief
+func (_m *Database) FinishWorkspaceBrief(brief db.WorkspaceBrief) (db.WorkspaceBrief, error) {
+	ret := _m.Called(brief)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FinishWorkspaceBrief")
+	}
+
+	var r0 db.WorkspaceBrief
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.WorkspaceBrief) (db.WorkspaceBrief, error)); ok {
+		return rf(brief)
+	}
+	if rf, ok := ret.Get(0).(func(db.WorkspaceBrief) db.WorkspaceBrief); ok {
+		r0 = rf(brief)
+	} else {
+		r0 = ret.Get(0).(db.WorkspaceBrief)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.WorkspaceBrief) error); ok {
+		r1 = rf(brief)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_FinishWorkspaceBrief_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FinishWorkspaceBrief'
+type Database_FinishWorkspaceBrief_Call struct {
+	*mock.Call
+}
+
+// FinishWorkspaceBrief is a helper method to define mock.On call
+//   - brief db.WorkspaceBrief
+func (_e *Database_Expecter) FinishWorkspaceBrief(brief interface{}) *Database_FinishWorkspaceBrief_Call {
+	return &Database_FinishWorkspaceBrief_Call{Call: _e.mock.On("FinishWorkspaceBrief", brief)}
+}
+
+func (_c *Database_FinishWorkspaceBrief_Call) Run(run func(brief db.WorkspaceBrief)) *Database_FinishWorkspaceBrief_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.WorkspaceBrief))
+	})
+	return _c
+}
+
+func (_c *Database_FinishWorkspaceBrief_Call) Return(_a0 db.WorkspaceBrief, _a1 error) *Database_FinishWorkspaceBrief_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_FinishWorkspaceBrief_Call) RunAndReturn(run func(db.WorkspaceBrief) (db.WorkspaceBrief, error)) *Database_FinishWorkspaceBrief_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FlagWorkspace provides a mock function with given fields: uuid, reasons
+func (_m *Database) FlagWorkspace(uuid string, reasons []string) error {
+	ret := _m.Called(uuid, reasons)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FlagWorkspace")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, []string) error); ok {
+		r0 = rf(uuid, reasons)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_FlagWorkspace_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FlagWorkspace'
+type Database_FlagWorkspace_Call struct {
+	*mock.Call
+}
+
+// FlagWorkspace is a helper method to define mock.On call
+//   - uuid string
+//   - reasons []string
+func (_e *Database_Expecter) FlagWorkspace(uuid interface{}, reasons interface{}) *Database_FlagWorkspace_Call {
+	return &Database_FlagWorkspace_Call{Call: _e.mock.On("FlagWorkspace", uuid, reasons)}
+}
+
+func (_c *Database_FlagWorkspace_Call) Run(run func(uuid string, reasons []string)) *Database_FlagWorkspace_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *Database_FlagWorkspace_Call) Return(_a0 error) *Database_FlagWorkspace_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_FlagWorkspace_Call) RunAndReturn(run func(string, []string) error) *Database_FlagWorkspace_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAiUsage provides a mock function with given fields: workspaceUuid, month
+func (_m *Database) GetAiUsage(workspaceUuid string, month time.Time) (db.AiUsage, error) {
+	ret := _m.Called(workspaceUuid, month)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAiUsage")
+	}
+
+	var r0 db.AiUsage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, time.Time) (db.AiUsage, error)); ok {
+		return rf(workspaceUuid, month)
+	}
+	if rf, ok := ret.Get(0).(func(string, time.Time) db.AiUsage); ok {
+		r0 = rf(workspaceUuid, month)
+	} else {
+		r0 = ret.Get(0).(db.AiUsage)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, time.Time) error); ok {
+		r1 = rf(workspaceUuid, month)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetAiUsage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAiUsage'
+type Database_GetAiUsage_Call struct {
+	*mock.Call
+}
+
+// GetAiUsage is a helper method to define mock.On call
+//   - workspaceUuid string
+//   - month time.Time
+func (_e *Database_Expecter) GetAiUsage(workspaceUuid interface{}, month interface{}) *Database_GetAiUsage_Call {
+	return &Database_GetAiUsage_Call{Call: _e.mock.On("GetAiUsage", workspaceUuid, month)}
+}
+
+func (_c *Database_GetAiUsage_Call) Run(run func(workspaceUuid string, month time.Time)) *Database_GetAiUsage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *Database_GetAiUsage_Call) Return(_a0 db.AiUsage, _a1 error) *Database_GetAiUsage_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetAiUsage_Call) RunAndReturn(run func(string, time.Time) (db.AiUsage, error)) *Database_GetAiUsage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAllBounties provides a mock function with given fields: r
+func (_m *Database) GetAllBounties(r *http.Request) []db.NewBounty {
+	ret := _m.Called(r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllBounties")
+	}
+
+	var r0 []db.NewBounty
+	if rf, ok := ret.Get(0).(func(*http.Request) []db.NewBounty); ok {
+		r0 = rf(r)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.NewBounty)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetAllBounties_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllBounties'
+type Database_GetAllBounties_Call struct {
+	*mock.Call
+}
+
+// GetAllBounties is a helper method to define mock.On call
+//   - r *http.Request
+func (_e *Database_Expecter) GetAllBounties(r interface{}) *Database_GetAllBounties_Call {
+	return &Database_GetAllBounties_Call{Call: _e.mock.On("GetAllBounties", r)}
+}
+
+func (_c *Database_GetAllBounties_Call) Run(run func(r *http.Request)) *Database_GetAllBounties_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *Database_GetAllBounties_Call) Return(_a0 []db.NewBounty) *Database_GetAllBounties_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetAllBounties_Call) RunAndReturn(run func(*http.Request) []db.NewBounty) *Database_GetAllBounties_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAllTribes provides a mock function with given fields:
+func (_m *Database) GetAllTribes() []db.Tribe {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllTribes")
+	}
+
+	var r0 []db.Tribe
+	if rf, ok := ret.Get(0).(func() []db.Tribe); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Tribe)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetAllTribes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllTribes'
+type Database_GetAllTribes_Call struct {
+	*mock.Call
+}
+
+// GetAllTribes is a helper method to define mock.On call
+func (_e *Database_Expecter) GetAllTribes() *Database_GetAllTribes_Call {
+	return &Database_GetAllTribes_Call{Call: _e.mock.On("GetAllTribes")}
+}
+
+func (_c *Database_GetAllTribes_Call) Run(run func()) *Database_GetAllTribes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Database_GetAllTribes_Call) Return(_a0 []db.Tribe) *Database_GetAllTribes_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetAllTribes_Call) RunAndReturn(run func() []db.Tribe) *Database_GetAllTribes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAllTribesByOwner provides a mock function with given fields: pubkey
+func (_m *Database) GetAllTribesByOwner(pubkey string) []db.Tribe {
+	ret := _m.Called(pubkey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllTribesByOwner")
+	}
+
+	var r0 []db.Tribe
+	if rf, ok := ret.Get(0).(func(string) []db.Tribe); ok {
+		r0 = rf(pubkey)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Tribe)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetAllTribesByOwner_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllTribesByOwner'
+type Database_GetAllTribesByOwner_Call struct {
+	*mock.Call
+}
+
+// GetAllTribesByOwner is a helper method to define mock.On call
+//   - pubkey string
+func (_e *Database_Expecter) GetAllTribesByOwner(pubkey interface{}) *Database_GetAllTribesByOwner_Call {
+	return &Database_GetAllTribesByOwner_Call{Call: _e.mock.On("GetAllTribesByOwner", pubkey)}
+}
+
+func (_c *Database_GetAllTribesByOwner_Call) Run(run func(pubkey string)) *Database_GetAllTribesByOwner_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetAllTribesByOwner_Call) Return(_a0 []db.Tribe) *Database_GetAllTribesByOwner_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetAllTribesByOwner_Call) RunAndReturn(run func(string) []db.Tribe) *Database_GetAllTribesByOwner_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAppliedBountyRoutings provides a mock function with given fields: bountyID
+func (_m *Database) GetAppliedBountyRoutings(bountyID uint) ([]string, error) {
+	ret := _m.Called(bountyID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAppliedBountyRoutings")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint) ([]string, error)); ok {
+		return rf(bountyID)
+	}
+	if rf, ok := ret.Get(0).(func(uint) []string); ok {
+		r0 = rf(bountyID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(bountyID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetAppliedBountyRoutings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAppliedBountyRoutings'
+type Database_GetAppliedBountyRoutings_Call struct {
+	*mock.Call
+}
+
+// GetAppliedBountyRoutings is a helper method to define mock.On call
+//   - bountyID uint
+func (_e *Database_Expecter) GetAppliedBountyRoutings(bountyID interface{}) *Database_GetAppliedBountyRoutings_Call {
+	return &Database_GetAppliedBountyRoutings_Call{Call: _e.mock.On("GetAppliedBountyRoutings", bountyID)}
+}
+
+func (_c *Database_GetAppliedBountyRoutings_Call) Run(run func(bountyID uint)) *Database_GetAppliedBountyRoutings_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *Database_GetAppliedBountyRoutings_Call) Return(_a0 []string, _a1 error) *Database_GetAppliedBountyRoutings_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetAppliedBountyRoutings_Call) RunAndReturn(run func(uint) ([]string, error)) *Database_GetAppliedBountyRoutings_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetArtifact provides a mock function with given fields: uuid
+func (_m *Database) GetArtifact(uuid string) (db.WorkspaceArtifact, error) {
+	ret := _m.Called(uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetArtifact")
+	}
+
+	var r0 db.WorkspaceArtifact
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (db.WorkspaceArtifact, error)); ok {
+		return rf(uuid)
+	}
+	if rf, ok := ret.Get(0).(func(string) db.WorkspaceArtifact); ok {
+		r0 = rf(uuid)
+	} else {
+		r0 = ret.Get(0).(db.WorkspaceArtifact)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(uuid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetArtifact_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetArtifact'
+type Database_GetArtifact_Call struct {
+	*mock.Call
+}
+
+// GetArtifact is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) GetArtifact(uuid interface{}) *Database_GetArtifact_Call {
+	return &Database_GetArtifact_Call{Call: _e.mock.On("GetArtifact", uuid)}
+}
+
+func (_c *Database_GetArtifact_Call) Run(run func(uuid string)) *Database_GetArtifact_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetArtifact_Call) Return(_a0 db.WorkspaceArtifact, _a1 error) *Database_GetArtifact_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetArtifact_Call) RunAndReturn(run func(string) (db.WorkspaceArtifact, error)) *Database_GetArtifact_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetArtifactVersion provides a mock function with given fields: uuid, version
+func (_m *Database) GetArtifactVersion(uuid string, version int) (db.WorkspaceArtifactVersion, error) {
+	ret := _m.Called(uuid, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetArtifactVersion")
+	}
+
+	var r0 db.WorkspaceArtifactVersion
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, int) (db.WorkspaceArtifactVersion, error)); ok {
+		return rf(uuid, version)
+	}
+	if rf, ok := ret.Get(0).(func(string, int) db.WorkspaceArtifactVersion); ok {
+		r0 = rf(uuid, version)
+	} else {
+		r0 = ret.Get(0).(db.WorkspaceArtifactVersion)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, int) error); ok {
+		r1 = rf(uuid, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetArtifactVersion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetArtifactVersion'
+type Database_GetArtifactVersion_Call struct {
+	*mock.Call
+}
+
+// GetArtifactVersion is a helper method to define mock.On call
+//   - uuid string
+//   - version int
+func (_e *Database_Expecter) GetArtifactVersion(uuid interface{}, version interface{}) *Database_GetArtifactVersion_Call {
+	return &Database_GetArtifactVersion_Call{Call: _e.mock.On("GetArtifactVersion", uuid, version)}
+}
+
+func (_c *Database_GetArtifactVersion_Call) Run(run func(uuid string, version int)) *Database_GetArtifactVersion_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *Database_GetArtifactVersion_Call) Return(_a0 db.WorkspaceArtifactVersion, _a1 error) *Database_GetArtifactVersion_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetArtifactVersion_Call) RunAndReturn(run func(string, int) (db.WorkspaceArtifactVersion, error)) *Database_GetArtifactVersion_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetArtifactVersions provides a mock function with given fields: uuid
+func (_m *Database) GetArtifactVersions(uuid string) ([]db.WorkspaceArtifactVersion, error) {
+	ret := _m.Called(uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetArtifactVersions")
+	}
+
+	var r0 []db.WorkspaceArtifactVersion
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]db.WorkspaceArtifactVersion, error)); ok {
+		return rf(uuid)
+	}
+	if rf, ok := ret.Get(0).(func(string) []db.WorkspaceArtifactVersion); ok {
+		r0 = rf(uuid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.WorkspaceArtifactVersion)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(uuid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetArtifactVersions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetArtifactVersions'
+type Database_GetArtifactVersions_Call struct {
+	*mock.Call
+}
+
+// GetArtifactVersions is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) GetArtifactVersions(uuid interface{}) *Database_GetArtifactVersions_Call {
+	return &Database_GetArtifactVersions_Call{Call: _e.mock.On("GetArtifactVersions", uuid)}
+}
+
+func (_c *Database_GetArtifactVersions_Call) Run(run func(uuid string)) *Database_GetArtifactVersions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetArtifactVersions_Call) Return(_a0 []db.WorkspaceArtifactVersion, _a1 error) *Database_GetArtifactVersions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetArtifactVersions_Call) RunAndReturn(run func(string) ([]db.WorkspaceArtifactVersion, error)) *Database_GetArtifactVersions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetArtifacts provides a mock function with given fields: workspaceUuid, r
+func (_m *Database) GetArtifacts(workspaceUuid string, r *http.Request) ([]db.WorkspaceArtifact, error) {
+	ret := _m.Called(workspaceUuid, r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetArtifacts")
+	}
+
+	var r0 []db.WorkspaceArtifact
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, *http.Request) ([]db.WorkspaceArtifact, error)); ok {
+		return rf(workspaceUuid, r)
+	}
+	if rf, ok := ret.Get(0).(func(string, *http.Request) []db.WorkspaceArtifact); ok {
+		r0 = rf(workspaceUuid, r)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.WorkspaceArtifact)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, *http.Request) error); ok {
+		r1 = rf(workspaceUuid, r)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetArtifacts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetArtifacts'
+type Database_GetArtifacts_Call struct {
+	*mock.Call
+}
+
+// GetArtifacts is a helper method to define mock.On call
+//   - workspaceUuid string
+//   - r *http.Request
+func (_e *Database_Expecter) GetArtifacts(workspaceUuid interface{}, r interface{}) *Database_GetArtifacts_Call {
+	return &Database_GetArtifacts_Call{Call: _e.mock.On("GetArtifacts", workspaceUuid, r)}
+}
+
+func (_c *Database_GetArtifacts_Call) Run(run func(workspaceUuid string, r *http.Request)) *Database_GetArtifacts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *Database_GetArtifacts_Call) Return(_a0 []db.WorkspaceArtifact, _a1 error) *Database_GetArtifacts_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetArtifacts_Call) RunAndReturn(run func(string, *http.Request) ([]db.WorkspaceArtifact, error)) *Database_GetArtifacts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetArtifactsByUuid provides a mock function with given fields: workspaceUuid, uuids
+func (_m *Database) GetArtifactsByUuid(workspaceUuid string, uuids []string) ([]db.WorkspaceArtifact, error) {
+	ret := _m.Called(workspaceUuid, uuids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetArtifactsByUuid")
+	}
+
+	var r0 []db.WorkspaceArtifact
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, []string) ([]db.WorkspaceArtifact, error)); ok {
+		return rf(workspaceUuid, uuids)
+	}
+	if rf, ok := ret.Get(0).(func(string, []string) []db.WorkspaceArtifact); ok {
+		r0 = rf(workspaceUuid, uuids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.WorkspaceArtifact)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, []string) error); ok {
+		r1 = rf(workspaceUuid, uuids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetArtifactsByUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetArtifactsByUuid'
+type Database_GetArtifactsByUuid_Call struct {
+	*mock.Call
+}
+
+// GetArtifactsByUuid is a helper method to define mock.On call
+//   - workspaceUuid string
+//   - uuids []string
+func (_e *Database_Expecter) GetArtifactsByUuid(workspaceUuid interface{}, uuids interface{}) *Database_GetArtifactsByUuid_Call {
+	return &Database_GetArtifactsByUuid_Call{Call: _e.mock.On("GetArtifactsByUuid", workspaceUuid, uuids)}
+}
+
+func (_c *Database_GetArtifactsByUuid_Call) Run(run func(workspaceUuid string, uuids []string)) *Database_GetArtifactsByUuid_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *Database_GetArtifactsByUuid_Call) Return(_a0 []db.WorkspaceArtifact, _a1 error) *Database_GetArtifactsByUuid_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetArtifactsByUuid_Call) RunAndReturn(run func(string, []string) ([]db.WorkspaceArtifact, error)) *Database_GetArtifactsByUuid_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetArtifactsCount provides a mock function with given fields: workspaceUuid, r
+func (_m *Database) GetArtifactsCount(workspaceUuid string, r *http.Request) int64 {
+	ret := _m.Called(workspaceUuid, r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetArtifactsCount")
+	}
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(string, *http.Request) int64); ok {
+		r0 = rf(workspaceUuid, r)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	return r0
+}
+
+// Database_GetArtifactsCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetArtifactsCount'
+type Database_GetArtifactsCount_Call struct {
+	*mock.Call
+}
+
+// GetArtifactsCount is a helper method to define mock.On call
+//   - workspaceUuid string
+//   - r *http.Request
+func (_e *Database_Expecter) GetArtifactsCount(workspaceUuid interface{}, r interface{}) *Database_GetArtifactsCount_Call {
+	return &Database_GetArtifactsCount_Call{Call: _e.mock.On("GetArtifactsCount", workspaceUuid, r)}
+}
+
+func (_c *Database_GetArtifactsCount_Call) Run(run func(workspaceUuid string, r *http.Request)) *Database_GetArtifactsCount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *Database_GetArtifactsCount_Call) Return(_a0 int64) *Database_GetArtifactsCount_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetArtifactsCount_Call) RunAndReturn(run func(string, *http.Request) int64) *Database_GetArtifactsCount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAssignedBounties provides a mock function with given fields: r
+func (_m *Database) GetAssignedBounties(r *http.Request) ([]db.NewBounty, error) {
+	ret := _m.Called(r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAssignedBounties")
+	}
+
+	var r0 []db.NewBounty
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*http.Request) ([]db.NewBounty, error)); ok {
+		return rf(r)
+	}
+	if rf, ok := ret.Get(0).(func(*http.Request) []db.NewBounty); ok {
+		r0 = rf(r)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.NewBounty)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*http.Request) error); ok {
+		r1 = rf(r)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetAssignedBounties_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAssignedBounties'
+type Database_GetAssignedBounties_Call struct {
+	*mock.Call
+}
+
+// GetAssignedBounties is a helper method to define mock.On call
+//   - r *http.Request
+func (_e *Database_Expecter) GetAssignedBounties(r interface{}) *Database_GetAssignedBounties_Call {
+	return &Database_GetAssignedBounties_Call{Call: _e.mock.On("GetAssignedBounties", r)}
+}
+
+func (_c *Database_GetAssignedBounties_Call) Run(run func(r *http.Request)) *Database_GetAssignedBounties_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *Database_GetAssignedBounties_Call) Return(_a0 []db.NewBounty, _a1 error) *Database_GetAssignedBounties_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetAssignedBounties_Call) RunAndReturn(run func(*http.Request) ([]db.NewBounty, error)) *Database_GetAssignedBounties_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBot provides a mock function with given fields: uuid
+func (_m *Database) GetBot(uuid string) db.Bot {
+	ret := _m.Called(uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBot")
+	}
+
+	var r0 db.Bot
+	if rf, ok := ret.Get(0).(func(string) db.Bot); ok {
+		r0 = rf(uuid)
+	} else {
+		r0 = ret.Get(0).(db.Bot)
+	}
+
+	return r0
+}
+
+// Database_GetBot_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBot'
+type Database_GetBot_Call struct {
+	*mock.Call
+}
+
+// GetBot is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) GetBot(uuid interface{}) *Database_GetBot_Call {
+	return &Database_GetBot_Call{Call: _e.mock.On("GetBot", uuid)}
+}
+
+func (_c *Database_GetBot_Call) Run(run func(uuid string)) *Database_GetBot_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetBot_Call) Return(_a0 db.Bot) *Database_GetBot_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetBot_Call) RunAndReturn(run func(string) db.Bot) *Database_GetBot_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBotByUniqueName provides a mock function with given fields: un
+func (_m *Database) GetBotByUniqueName(un string) db.Bot {
+	ret := _m.Called(un)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBotByUniqueName")
+	}
+
+	var r0 db.Bot
+	if rf, ok := ret.Get(0).(func(string) db.Bot); ok {
+		r0 = rf(un)
+	} else {
+		r0 = ret.Get(0).(db.Bot)
+	}
+
+	return r0
+}
+
+// Database_GetBotByUniqueName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBotByUniqueName'
+type Database_GetBotByUniqueName_Call struct {
+	*mock.Call
+}
+
+// GetBotByUniqueName is a helper method to define mock.On call
+//   - un string
+func (_e *Database_Expecter) GetBotByUniqueName(un interface{}) *Database_GetBotByUniqueName_Call {
+	return &Database_GetBotByUniqueName_Call{Call: _e.mock.On("GetBotByUniqueName", un)}
+}
+
+func (_c *Database_GetBotByUniqueName_Call) Run(run func(un string)) *Database_GetBotByUniqueName_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetBotByUniqueName_Call) Return(_a0 db.Bot) *Database_GetBotByUniqueName_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetBotByUniqueName_Call) RunAndReturn(run func(string) db.Bot) *Database_GetBotByUniqueName_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBotsByOwner provides a mock function with given fields: pubkey
+func (_m *Database) GetBotsByOwner(pubkey string) []db.Bot {
+	ret := _m.Called(pubkey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBotsByOwner")
+	}
+
+	var r0 []db.Bot
+	if rf, ok := ret.Get(0).(func(string) []db.Bot); ok {
+		r0 = rf(pubkey)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Bot)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetBotsByOwner_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBotsByOwner'
+type Database_GetBotsByOwner_Call struct {
+	*mock.Call
+}
+
+// GetBotsByOwner is a helper method to define mock.On call
+//   - pubkey string
+func (_e *Database_Expecter) GetBotsByOwner(pubkey interface{}) *Database_GetBotsByOwner_Call {
+	return &Database_GetBotsByOwner_Call{Call: _e.mock.On("GetBotsByOwner", pubkey)}
+}
+
+func (_c *Database_GetBotsByOwner_Call) Run(run func(pubkey string)) *Database_GetBotsByOwner_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetBotsByOwner_Call) Return(_a0 []db.Bot) *Database_GetBotsByOwner_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetBotsByOwner_Call) RunAndReturn(run func(string) []db.Bot) *Database_GetBotsByOwner_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBountiesAssignedTo provides a mock function with given fields: pubkey
+func (_m *Database) GetBountiesAssignedTo(pubkey string) []db.NewBounty {
+	ret := _m.Called(pubkey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBountiesAssignedTo")
+	}
+
+	var r0 []db.NewBounty
+	if rf, ok := ret.Get(0).(func(string) []db.NewBounty); ok {
+		r0 = rf(pubkey)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.NewBounty)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetBountiesAssignedTo_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountiesAssignedTo'
+type Database_GetBountiesAssignedTo_Call struct {
+	*mock.Call
+}
+
+// GetBountiesAssignedTo is a helper method to define mock.On call
+//   - pubkey string
+func (_e *Database_Expecter) GetBountiesAssignedTo(pubkey interface{}) *Database_GetBountiesAssignedTo_Call {
+	return &Database_GetBountiesAssignedTo_Call{Call: _e.mock.On("GetBountiesAssignedTo", pubkey)}
+}
+
+func (_c *Database_GetBountiesAssignedTo_Call) Run(run func(pubkey string)) *Database_GetBountiesAssignedTo_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetBountiesAssignedTo_Call) Return(_a0 []db.NewBounty) *Database_GetBountiesAssignedTo_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetBountiesAssignedTo_Call) RunAndReturn(run func(string) []db.NewBounty) *Database_GetBountiesAssignedTo_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBountiesByDateRange provides a mock function with given fields: r, re
+func (_m *Database) GetBountiesByDateRange(r db.PaymentDateRange, re *http.Request) []db.NewBounty {
+	ret := _m.Called(r, re)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBountiesByDateRange")
+	}
+
+	var r0 []db.NewBounty
+	if rf, ok := ret.Get(0).(func(db.PaymentDateRange, *http.Request) []db.NewBounty); ok {
+		r0 = rf(r, re)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.NewBounty)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetBountiesByDateRange_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountiesByDateRange'
+type Database_GetBountiesByDateRange_Call struct {
+	*mock.Call
+}
+
+// GetBountiesByDateRange is a helper method to define mock.On call
+//   - r db.PaymentDateRange
+//   - re *http.Request
+func (_e *Database_Expecter) GetBountiesByDateRange(r interface{}, re interface{}) *Database_GetBountiesByDateRange_Call {
+	return &Database_GetBountiesByDateRange_Call{Call: _e.mock.On("GetBountiesByDateRange", r, re)}
+}
+
+func (_c *Database_GetBountiesByDateRange_Call) Run(run func(r db.PaymentDateRange, re *http.Request)) *Database_GetBountiesByDateRange_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.PaymentDateRange), args[1].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *Database_GetBountiesByDateRange_Call) Return(_a0 []db.NewBounty) *Database_GetBountiesByDateRange_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetBountiesByDateRange_Call) RunAndReturn(run func(db.PaymentDateRange, *http.Request) []db.NewBounty) *Database_GetBountiesByDateRange_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBountiesByDateRangeCount provides a mock function with given fields: r, re
+func (_m *Database) GetBountiesByDateRangeCount(r db.PaymentDateRange, re *http.Request) int64 {
+	ret := _m.Called(r, re)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBountiesByDateRangeCount")
+	}
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(db.PaymentDateRange, *http.Request) int64); ok {
+		r0 = rf(r, re)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	return r0
+}
+
+// Database_GetBountiesByDateRangeCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountiesByDateRangeCount'
+type Database_GetBountiesByDateRangeCount_Call struct {
+	*mock.Call
+}
+
+// GetBountiesByDateRangeCount is a helper method to define mock.On call
+//   - r db.PaymentDateRange
+//   - re *http.Request
+func (_e *Database_Expecter) GetBountiesByDateRangeCount(r interface{}, re interface{}) *Database_GetBountiesByDateRangeCount_Call {
+	return &Database_GetBountiesByDateRangeCount_Call{Call: _e.mock.On("GetBountiesByDateRangeCount", r, re)}
+}
+
+func (_c *Database_GetBountiesByDateRangeCount_Call) Run(run func(r db.PaymentDateRange, re *http.Request)) *Database_GetBountiesByDateRangeCount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.PaymentDateRange), args[1].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *Database_GetBountiesByDateRangeCount_Call) Return(_a0 int64) *Database_GetBountiesByDateRangeCount_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetBountiesByDateRangeCount_Call) RunAndReturn(run func(db.PaymentDateRange, *http.Request) int64) *Database_GetBountiesByDateRangeCount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBountiesByFeatureAndPhaseUuid provides a mock function with given fields: featureUuid, phaseUuid, r
+func (_m *Database) GetBountiesByFeatureAndPhaseUuid(featureUuid string, phaseUuid string, r *http.Request) ([]db.NewBounty, error) {
+	ret := _m.Called(featureUuid, phaseUuid, r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBountiesByFeatureAndPhaseUuid")
+	}
+
+	var r0 []db.NewBounty
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, *http.Request) ([]db.NewBounty, error)); ok {
+		return rf(featureUuid, phaseUuid, r)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, *http.Request) []db.NewBounty); ok {
+		r0 = rf(featureUuid, phaseUuid, r)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.NewBounty)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, *http.Request) error); ok {
+		r1 = rf(featureUuid, phaseUuid, r)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetBountiesByFeatureAndPhaseUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountiesByFeatureAndPhaseUuid'
+type Database_GetBountiesByFeatureAndPhaseUuid_Call struct {
+	*mock.Call
+}
+
+// GetBountiesByFeatureAndPhaseUuid is a helper method to define mock.On call
+//   - featureUuid string
+//   - phaseUuid string
+//   - r *http.Request
+func (_e *Database_Expecter) GetBountiesByFeatureAndPhaseUuid(featureUuid interface{}, phaseUuid interface{}, r interface{}) *Database_GetBountiesByFeatureAndPhaseUuid_Call {
+	return &Database_GetBountiesByFeatureAndPhaseUuid_Call{Call: _e.mock.On("GetBountiesByFeatureAndPhaseUuid", featureUuid, phaseUuid, r)}
+}
+
+func (_c *Database_GetBountiesByFeatureAndPhaseUuid_Call) Run(run func(featureUuid string, phaseUuid string, r *http.Request)) *Database_GetBountiesByFeatureAndPhaseUuid_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *Database_GetBountiesByFeatureAndPhaseUuid_Call) Return(_a0 []db.NewBounty, _a1 error) *Database_GetBountiesByFeatureAndPhaseUuid_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetBountiesByFeatureAndPhaseUuid_Call) RunAndReturn(run func(string, string, *http.Request) ([]db.NewBounty, error)) *Database_GetBountiesByFeatureAndPhaseUuid_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBountiesByIds provides a mock function with given fields: ids
+func (_m *Database) GetBountiesByIds(ids []uint) []db.NewBounty {
+	ret := _m.Called(ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBountiesByIds")
+	}
+
+	var r0 []db.NewBounty
+	if rf, ok := ret.Get(0).(func([]uint) []db.NewBounty); ok {
+		r0 = rf(ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.NewBounty)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetBountiesByIds_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountiesByIds'
+type Database_GetBountiesByIds_Call struct {
+	*mock.Call
+}
+
+// GetBountiesByIds is a helper method to define mock.On call
+//   - ids []uint
+func (_e *Database_Expecter) GetBountiesByIds(ids interface{}) *Database_GetBountiesByIds_Call {
+	return &Database_GetBountiesByIds_Call{Call: _e.mock.On("GetBountiesByIds", ids)}
+}
+
+func (_c *Database_GetBountiesByIds_Call) Run(run func(ids []uint)) *Database_GetBountiesByIds_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]uint))
+	})
+	return _c
+}
+
+func (_c *Database_GetBountiesByIds_Call) Return(_a0 []db.NewBounty) *Database_GetBountiesByIds_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetBountiesByIds_Call) RunAndReturn(run func([]uint) []db.NewBounty) *Database_GetBountiesByIds_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBountiesByPhaseUuid provides a mock function with given fields: phaseUuid
+func (_m *Database) GetBountiesByPhaseUuid(phaseUuid string) []db.NewBounty {
+	ret := _m.Called(phaseUuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBountiesByPhaseUuid")
+	}
+
+	var r0 []db.NewBounty
+	if rf, ok := ret.Get(0).(func(string) []db.NewBounty); ok {
+		r0 = rf(phaseUuid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.NewBounty)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetBountiesByPhaseUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountiesByPhaseUuid'
+type Database_GetBountiesByPhaseUuid_Call struct {
+	*mock.Call
+}
+
+// GetBountiesByPhaseUuid is a helper method to define mock.On call
+//   - phaseUuid string
+func (_e *Database_Expecter) GetBountiesByPhaseUuid(phaseUuid interface{}) *Database_GetBountiesByPhaseUuid_Call {
+	return &Database_GetBountiesByPhaseUuid_Call{Call: _e.mock.On("GetBountiesByPhaseUuid", phaseUuid)}
+}
+
+func (_c *Database_GetBountiesByPhaseUuid_Call) Run(run func(phaseUuid string)) *Database_GetBountiesByPhaseUuid_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetBountiesByPhaseUuid_Call) Return(_a0 []db.NewBounty) *Database_GetBountiesByPhaseUuid_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetBountiesByPhaseUuid_Call) RunAndReturn(run func(string) []db.NewBounty) *Database_GetBountiesByPhaseUuid_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBountiesCount provides a mock function with given fields: r
+func (_m *Database) GetBountiesCount(r *http.Request) int64 {
+	ret := _m.Called(r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBountiesCount")
+	}
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(*http.Request) int64); ok {
+		r0 = rf(r)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	return r0
+}
+
+// Database_GetBountiesCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountiesCount'
+type Database_GetBountiesCount_Call struct {
+	*mock.Call
+}
+
+// GetBountiesCount is a helper method to define mock.On call
+//   - r *http.Request
+func (_e *Database_Expecter) GetBountiesCount(r interface{}) *Database_GetBountiesCount_Call {
+	return &Database_GetBountiesCount_Call{Call: _e.mock.On("GetBountiesCount", r)}
+}
+
+func (_c *Database_GetBountiesCount_Call) Run(run func(r *http.Request)) *Database_GetBountiesCount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *Database_GetBountiesCount_Call) Return(_a0 int64) *Database_GetBountiesCount_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetBountiesCount_Call) RunAndReturn(run func(*http.Request) int64) *Database_GetBountiesCount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBountiesCountByFeatureAndPhaseUuid provides a mock function with given fields: featureUuid, phaseUuid, r
+func (_m *Database) GetBountiesCountByFeatureAndPhaseUuid(featureUuid string, phaseUuid string, r *http.Request) int64 {
+	ret := _m.Called(featureUuid, phaseUuid, r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBountiesCountByFeatureAndPhaseUuid")
+	}
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(string, string, *http.Request) int64); ok {
+		r0 = rf(featureUuid, phaseUuid, r)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	return r0
+}
+
+// Database_GetBountiesCountByFeatureAndPhaseUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountiesCountByFeatureAndPhaseUuid'
+type Database_GetBountiesCountByFeatureAndPhaseUuid_Call struct {
+	*mock.Call
+}
+
+// GetBountiesCountByFeatureAndPhaseUuid is a helper method to define mock.On call
+//   - featureUuid string
+//   - phaseUuid string
+//   - r *http.Request
+func (_e *Database_Expecter) GetBountiesCountByFeatureAndPhaseUuid(featureUuid interface{}, phaseUuid interface{}, r interface{}) *Database_GetBountiesCountByFeatureAndPhaseUuid_Call {
+	return &Database_GetBountiesCountByFeatureAndPhaseUuid_Call{Call: _e.mock.On("GetBountiesCountByFeatureAndPhaseUuid", featureUuid, phaseUuid, r)}
+}
+
+func (_c *Database_GetBountiesCountByFeatureAndPhaseUuid_Call) Run(run func(featureUuid string, phaseUuid string, r *http.Request)) *Database_GetBountiesCountByFeatureAndPhaseUuid_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *Database_GetBountiesCountByFeatureAndPhaseUuid_Call) Return(_a0 int64) *Database_GetBountiesCountByFeatureAndPhaseUuid_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetBountiesCountByFeatureAndPhaseUuid_Call) RunAndReturn(run func(string, string, *http.Request) int64) *Database_GetBountiesCountByFeatureAndPhaseUuid_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBountiesLeaderboard provides a mock function with given fields:
+func (_m *Database) GetBountiesLeaderboard() []db.LeaderData {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBountiesLeaderboard")
+	}
+
+	var r0 []db.LeaderData
+	if rf, ok := ret.Get(0).(func() []db.LeaderData); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.LeaderData)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetBountiesLeaderboard_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountiesLeaderboard'
+type Database_GetBountiesLeaderboard_Call struct {
+	*mock.Call
+}
+
+// GetBountiesLeaderboard is a helper method to define mock.On call
+func (_e *Database_Expecter) GetBountiesLeaderboard() *Database_GetBountiesLeaderboard_Call {
+	return &Database_GetBountiesLeaderboard_Call{Call: _e.mock.On("GetBountiesLeaderboard")}
+}
+
+func (_c *Database_GetBountiesLeaderboard_Call) Run(run func()) *Database_GetBountiesLeaderboard_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Database_GetBountiesLeaderboard_Call) Return(_a0 []db.LeaderData) *Database_GetBountiesLeaderboard_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetBountiesLeaderboard_Call) RunAndReturn(run func() []db.LeaderData) *Database_GetBountiesLeaderboard_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBountiesProviders provides a mock function with given fields: r, re
+func (_m *Database) GetBountiesProviders(r db.PaymentDateRange, re *http.Request) []db.Person {
+	ret := _m.Called(r, re)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBountiesProviders")
+	}
+
+	var r0 []db.Person
+	if rf, ok := ret.Get(0).(func(db.PaymentDateRange, *http.Request) []db.Person); ok {
+		r0 = rf(r, re)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Person)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetBountiesProviders_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountiesProviders'
+type Database_GetBountiesProviders_Call struct {
+	*mock.Call
+}
+
+// GetBountiesProviders is a helper method to define mock.On call
+//   - r db.PaymentDateRange
+//   - re *http.Request
+func (_e *Database_Expecter) GetBountiesProviders(r interface{}, re interface{}) *Database_GetBountiesProviders_Call {
+	return &Database_GetBountiesProviders_Call{Call: _e.mock.On("GetBountiesProviders", r, re)}
+}
+
+func (_c *Database_GetBountiesProviders_Call) Run(run func(r db.PaymentDateRange, re *http.Request)) *Database_GetBountiesProviders_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.PaymentDateRange), args[1].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *Database_GetBountiesProviders_Call) Return(_a0 []db.Person) *Database_GetBountiesProviders_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetBountiesProviders_Call) RunAndReturn(run func(db.PaymentDateRange, *http.Request) []db.Person) *Database_GetBountiesProviders_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBounty provides a mock function with given fields: id
+func (_m *Database) GetBounty(id uint) db.NewBounty {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBounty")
+	}
+
+	var r0 db.NewBounty
+	if rf, ok := ret.Get(0).(func(uint) db.NewBounty); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Get(0).(db.NewBounty)
+	}
+
+	return r0
+}
+
+// Database_GetBounty_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBounty'
+type Database_GetBounty_Call struct {
+	*mock.Call
+}
+
+// GetBounty is a helper method to define mock.On call
+//   - id uint
+func (_e *Database_Expecter) GetBounty(id interface{}) *Database_GetBounty_Call {
+	return &Database_GetBounty_Call{Call: _e.mock.On("GetBounty", id)}
+}
+
+func (_c *Database_GetBounty_Call) Run(run func(id uint)) *Database_GetBounty_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *Database_GetBounty_Call) Return(_a0 db.NewBounty) *Database_GetBounty_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetBounty_Call) RunAndReturn(run func(uint) db.NewBounty) *Database_GetBounty_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBountyByCreated provides a mock function with given fields: created
+func (_m *Database) GetBountyByCreated(created uint) (db.NewBounty, error) {
+	ret := _m.Called(created)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBountyByCreated")
+	}
+
+	var r0 db.NewBounty
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint) (db.NewBounty, error)); ok {
+		return rf(created)
+	}
+	if rf, ok := ret.Get(0).(func(uint) db.NewBounty); ok {
+		r0 = rf(created)
+	} else {
+		r0 = ret.Get(0).(db.NewBounty)
+	}
+
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(created)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetBountyByCreated_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountyByCreated'
+type Database_GetBountyByCreated_Call struct {
+	*mock.Call
+}
+
+// GetBountyByCreated is a helper method to define mock.On call
+//   - created uint
+func (_e *Database_Expecter) GetBountyByCreated(created interface{}) *Database_GetBountyByCreated_Call {
+	return &Database_GetBountyByCreated_Call{Call: _e.mock.On("GetBountyByCreated", created)}
+}
+
+func (_c *Database_GetBountyByCreated_Call) Run(run func(created uint)) *Database_GetBountyByCreated_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *Database_GetBountyByCreated_Call) Return(_a0 db.NewBounty, _a1 error) *Database_GetBountyByCreated_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetBountyByCreated_Call) RunAndReturn(run func(uint) (db.NewBounty, error)) *Database_GetBountyByCreated_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBountyById provides a mock function with given fields: id
+func (_m *Database) GetBountyById(id string) ([]db.NewBounty, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBountyById")
+	}
+
+	var r0 []db.NewBounty
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]db.NewBounty, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(string) []db.NewBounty); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.NewBounty)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetBountyById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountyById'
+type Database_GetBountyById_Call struct {
+	*mock.Call
+}
+
+// GetBountyById is a helper method to define mock.On call
+//   - id string
+func (_e *Database_Expecter) GetBountyById(id interface{}) *Database_GetBountyById_Call {
+	return &Database_GetBountyById_Call{Call: _e.mock.On("GetBountyById", id)}
+}
+
+func (_c *Database_GetBountyById_Call) Run(run func(id string)) *Database_GetBountyById_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetBountyById_Call) Return(_a0 []db.NewBounty, _a1 error) *Database_GetBountyById_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetBountyById_Call) RunAndReturn(run func(string) ([]db.NewBounty, error)) *Database_GetBountyById_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBountyCertificate provides a mock function with given fields: uuid
+func (_m *Database) GetBountyCertificate(uuid string) db.BountyCertificate {
+	ret := _m.Called(uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBountyCertificate")
+	}
+
+	var r0 db.BountyCertificate
+	if rf, ok := ret.Get(0).(func(string) db.BountyCertificate); ok {
+		r0 = rf(uuid)
+	} else {
+		r0 = ret.Get(0).(db.BountyCertificate)
+	}
+
+	return r0
+}
+
+// Database_GetBountyCertificate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountyCertificate'
+type Database_GetBountyCertificate_Call struct {
+	*mock.Call
+}
+
+// GetBountyCertificate is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) GetBountyCertificate(uuid interface{}) *Database_GetBountyCertificate_Call {
+	return &Database_GetBountyCertificate_Call{Call: _e.mock.On("GetBountyCertificate", uuid)}
+}
+
+func (_c *Database_GetBountyCertificate_Call) Run(run func(uuid string)) *Database_GetBountyCertificate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetBountyCertificate_Call) Return(_a0 db.BountyCertificate) *Database_GetBountyCertificate_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetBountyCertificate_Call) RunAndReturn(run func(string) db.BountyCertificate) *Database_GetBountyCertificate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBountyCertificatesByPubkey provides a mock function with given fields: pubkey
+func (_m *Database) GetBountyCertificatesByPubkey(pubkey string) ([]db.BountyCertificate, error) {
+	ret := _m.Called(pubkey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBountyCertificatesByPubkey")
+	}
+
+	var r0 []db.BountyCertificate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]db.BountyCertificate, error)); ok {
+		return rf(pubkey)
+	}
+	if rf, ok := ret.Get(0).(func(string) []db.BountyCertificate); ok {
+		r0 = rf(pubkey)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.BountyCertificate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(pubkey)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetBountyCertificatesByPubkey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountyCertificatesByPubkey'
+type Database_GetBountyCertificatesByPubkey_Call struct {
+	*mock.Call
+}
+
+// GetBountyCertificatesByPubkey is a helper method to define mock.On call
+//   - pubkey string
+func (_e *Database_Expecter) GetBountyCertificatesByPubkey(pubkey interface{}) *Database_GetBountyCertificatesByPubkey_Call {
+	return &Database_GetBountyCertificatesByPubkey_Call{Call: _e.mock.On("GetBountyCertificatesByPubkey", pubkey)}
+}
+
+func (_c *Database_GetBountyCertificatesByPubkey_Call) Run(run func(pubkey string)) *Database_GetBountyCertificatesByPubkey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetBountyCertificatesByPubkey_Call) Return(_a0 []db.BountyCertificate, _a1 error) *Database_GetBountyCertificatesByPubkey_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetBountyCertificatesByPubkey_Call) RunAndReturn(run func(string) ([]db.BountyCertificate, error)) *Database_GetBountyCertificatesByPubkey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBountyContactExchange provides a mock function with given fields: bounty
+func (_m *Database) GetBountyContactExchange(bounty db.NewBounty) (db.BountyContactExchange, error) {
+	ret := _m.Called(bounty)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBountyContactExchange")
+	}
+
+	var r0 db.BountyContactExchange
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.NewBounty) (db.BountyContactExchange, error)); ok {
+		return rf(bounty)
+	}
+	if rf, ok := ret.Get(0).(func(db.NewBounty) db.BountyContactExchange); ok {
+		r0 = rf(bounty)
+	} else {
+		r0 = ret.Get(0).(db.BountyContactExchange)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.NewBounty) error); ok {
+		r1 = rf(bounty)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetBountyContactExchange_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountyContactExchange'
+type Database_GetBountyContactExchange_Call struct {
+	*mock.Call
+}
+
+// GetBountyContactExchange is a helper method to define mock.On call
+//   - bounty db.NewBounty
+func (_e *Database_Expecter) GetBountyContactExchange(bounty interface{}) *Database_GetBountyContactExchange_Call {
+	return &Database_GetBountyContactExchange_Call{Call: _e.mock.On("GetBountyContactExchange", bounty)}
+}
+
+func (_c *Database_GetBountyContactExchange_Call) Run(run func(bounty db.NewBounty)) *Database_GetBountyContactExchange_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.NewBounty))
+	})
+	return _c
+}
+
+func (_c *Database_GetBountyContactExchange_Call) Return(_a0 db.BountyContactExchange, _a1 error) *Database_GetBountyContactExchange_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetBountyContactExchange_Call) RunAndReturn(run func(db.NewBounty) (db.BountyContactExchange, error)) *Database_GetBountyContactExchange_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBountyDataByCreated provides a mock function with given fields: created
+func (_m *Database) GetBountyDataByCreated(created string) ([]db.NewBounty, error) {
+	ret := _m.Called(created)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBountyDataByCreated")
+	}
+
+	var r0 []db.NewBounty
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]db.NewBounty, error)); ok {
+		return rf(created)
+	}
+	if rf, ok := ret.Get(0).(func(string) []db.NewBounty); ok {
+		r0 = rf(created)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.NewBounty)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(created)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetBountyDataByCreated_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountyDataByCreated'
+type Database_GetBountyDataByCreated_Call struct {
+	*mock.Call
+}
+
+// GetBountyDataByCreated is a helper method to define mock.On call
+//   - created string
+func (_e *Database_Expecter) GetBountyDataByCreated(created interface{}) *Database_GetBountyDataByCreated_Call {
+	return &Database_GetBountyDataByCreated_Call{Call: _e.mock.On("GetBountyDataByCreated", created)}
+}
+
+func (_c *Database_GetBountyDataByCreated_Call) Run(run func(created string)) *Database_GetBountyDataByCreated_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetBountyDataByCreated_Call) Return(_a0 []db.NewBounty, _a1 error) *Database_GetBountyDataByCreated_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetBountyDataByCreated_Call) RunAndReturn(run func(string) ([]db.NewBounty, error)) *Database_GetBountyDataByCreated_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBountyFacets provides a mock function with given fields: filters
+func (_m *Database) GetBountyFacets(filters db.BountyFacetFilters) (db.BountyFacets, error) {
+	ret := _m.Called(filters)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBountyFacets")
+	}
+
+	var r0 db.BountyFacets
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.BountyFacetFilters) (db.BountyFacets, error)); ok {
+		return rf(filters)
+	}
+	if rf, ok := ret.Get(0).(func(db.BountyFacetFilters) db.BountyFacets); ok {
+		r0 = rf(filters)
+	} else {
+		r0 = ret.Get(0).(db.BountyFacets)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.BountyFacetFilters) error); ok {
+		r1 = rf(filters)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetBountyFacets_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountyFacets'
+type Database_GetBountyFacets_Call struct {
+	*mock.Call
+}
+
+// GetBountyFacets is a helper method to define mock.On call
+//   - filters db.BountyFacetFilters
+func (_e *Database_Expecter) GetBountyFacets(filters interface{}) *Database_GetBountyFacets_Call {
+	return &Database_GetBountyFacets_Call{Call: _e.mock.On("GetBountyFacets", filters)}
+}
+
+func (_c *Database_GetBountyFacets_Call) Run(run func(filters db.BountyFacetFilters)) *Database_GetBountyFacets_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.BountyFacetFilters))
+	})
+	return _c
+}
+
+func (_c *Database_GetBountyFacets_Call) Return(_a0 db.BountyFacets, _a1 error) *Database_GetBountyFacets_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetBountyFacets_Call) RunAndReturn(run func(db.BountyFacetFilters) (db.BountyFacets, error)) *Database_GetBountyFacets_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBountyIndexById provides a mock function with given fields: id
+func (_m *Database) GetBountyIndexById(id string) int64 {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBountyIndexById")
+	}
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(string) int64); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	return r0
+}
+
+// Database_GetBountyIndexById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountyIndexById'
+type Database_GetBountyIndexById_Call struct {
+	*mock.Call
+}
+
+// GetBountyIndexById is a helper method to define mock.On call
+//   - id string
+func (_e *Database_Expecter) GetBountyIndexById(id interface{}) *Database_GetBountyIndexById_Call {
+	return &Database_GetBountyIndexById_Call{Call: _e.mock.On("GetBountyIndexById", id)}
+}
+
+func (_c *Database_GetBountyIndexById_Call) Run(run func(id string)) *Database_GetBountyIndexById_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetBountyIndexById_Call) Return(_a0 int64) *Database_GetBountyIndexById_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetBountyIndexById_Call) RunAndReturn(run func(string) int64) *Database_GetBountyIndexById_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBountyPayment provides a mock function with given fields: bountyId
+func (_m *Database) GetBountyPayment(bountyId uint) db.NewPaymentHistory {
+	ret := _m.Called(bountyId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBountyPayment")
+	}
+
+	var r0 db.NewPaymentHistory
+	if rf, ok := ret.Get(0).(func(uint) db.NewPaymentHistory); ok {
+		r0 = rf(bountyId)
+	} else {
+		r0 = ret.Get(0).(db.NewPaymentHistory)
+	}
+
+	return r0
+}
+
+// Database_GetBountyPayment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountyPayment'
+type Database_GetBountyPayment_Call struct {
+	*mock.Call
+}
+
+// GetBountyPayment is a helper method to define mock.On call
+//   - bountyId uint
+func (_e *Database_Expecter) GetBountyPayment(bountyId interface{}) *Database_GetBountyPayment_Call {
+	return &Database_GetBountyPayment_Call{Call: _e.mock.On("GetBountyPayment", bountyId)}
+}
+
+func (_c *Database_GetBountyPayment_Call) Run(run func(bountyId uint)) *Database_GetBountyPayment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *Database_GetBountyPayment_Call) Return(_a0 db.NewPaymentHistory) *Database_GetBountyPayment_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetBountyPayment_Call) RunAndReturn(run func(uint) db.NewPaymentHistory) *Database_GetBountyPayment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBountyPriceSuggestions provides a mock function with given fields: workspaceUuid, tags, hoursBucket
+func (_m *Database) GetBountyPriceSuggestions(workspaceUuid string, tags []string, hoursBucket int) (db.BountyPriceSuggestions, error) {
+	ret := _m.Called(workspaceUuid, tags, hoursBucket)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBountyPriceSuggestions")
+	}
+
+	var r0 db.BountyPriceSuggestions
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, []string, int) (db.BountyPriceSuggestions, error)); ok {
+		return rf(workspaceUuid, tags, hoursBucket)
+	}
+	if rf, ok := ret.Get(0).(func(string, []string, int) db.BountyPriceSuggestions); ok {
+		r0 = rf(workspaceUuid, tags, hoursBucket)
+	} else {
+		r0 = ret.Get(0).(db.BountyPriceSuggestions)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, []string, int) error); ok {
+		r1 = rf(workspaceUuid, tags, hoursBucket)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetBountyPriceSuggestions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountyPriceSuggestions'
+type Database_GetBountyPriceSuggestions_Call struct {
+	*mock.Call
+}
+
+// GetBountyPriceSuggestions is a helper method to define mock.On call
+//   - workspaceUuid string
+//   - tags []string
+//   - hoursBucket int
+func (_e *Database_Expecter) GetBountyPriceSuggestions(workspaceUuid interface{}, tags interface{}, hoursBucket interface{}) *Database_GetBountyPriceSuggestions_Call {
+	return &Database_GetBountyPriceSuggestions_Call{Call: _e.mock.On("GetBountyPriceSuggestions", workspaceUuid, tags, hoursBucket)}
+}
+
+func (_c *Database_GetBountyPriceSuggestions_Call) Run(run func(workspaceUuid string, tags []string, hoursBucket int)) *Database_GetBountyPriceSuggestions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].([]string), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *Database_GetBountyPriceSuggestions_Call) Return(_a0 db.BountyPriceSuggestions, _a1 error) *Database_GetBountyPriceSuggestions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetBountyPriceSuggestions_Call) RunAndReturn(run func(string, []string, int) (db.BountyPriceSuggestions, error)) *Database_GetBountyPriceSuggestions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBountyProofs provides a mock function with given fields: bountyId
+func (_m *Database) GetBountyProofs(bountyId uint) ([]db.BountyProof, error) {
+	ret := _m.Called(bountyId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBountyProofs")
+	}
+
+	var r0 []db.BountyProof
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint) ([]db.BountyProof, error)); ok {
+		return rf(bountyId)
+	}
+	if rf, ok := ret.Get(0).(func(uint) []db.BountyProof); ok {
+		r0 = rf(bountyId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.BountyProof)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(bountyId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetBountyProofs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountyProofs'
+type Database_GetBountyProofs_Call struct {
+	*mock.Call
+}
+
+// GetBountyProofs is a helper method to define mock.On call
+//   - bountyId uint
+func (_e *Database_Expecter) GetBountyProofs(bountyId interface{}) *Database_GetBountyProofs_Call {
+	return &Database_GetBountyProofs_Call{Call: _e.mock.On("GetBountyProofs", bountyId)}
+}
+
+func (_c *Database_GetBountyProofs_Call) Run(run func(bountyId uint)) *Database_GetBountyProofs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *Database_GetBountyProofs_Call) Return(_a0 []db.BountyProof, _a1 error) *Database_GetBountyProofs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetBountyProofs_Call) RunAndReturn(run func(uint) ([]db.BountyProof, error)) *Database_GetBountyProofs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBountyRecommendations provides a mock function with given fields: pubkey, since, limit
+func (_m *Database) GetBountyRecommendations(pubkey string, since time.Time, limit int) ([]db.BountyRecommendation, error) {
+	ret := _m.Called(pubkey, since, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBountyRecommendations")
+	}
+
+	var r0 []db.BountyRecommendation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, time.Time, int) ([]db.BountyRecommendation, error)); ok {
+		return rf(pubkey, since, limit)
+	}
+	if rf, ok := ret.Get(0).(func(string, time.Time, int) []db.BountyRecommendation); ok {
+		r0 = rf(pubkey, since, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.BountyRecommendation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, time.Time, int) error); ok {
+		r1 = rf(pubkey, since, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetBountyRecommendations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountyRecommendations'
+type Database_GetBountyRecommendations_Call struct {
+	*mock.Call
+}
+
+// GetBountyRecommendations is a helper method to define mock.On call
+//   - pubkey string
+//   - since time.Time
+//   - limit int
+func (_e *Database_Expecter) GetBountyRecommendations(pubkey interface{}, since interface{}, limit interface{}) *Database_GetBountyRecommendations_Call {
+	return &Database_GetBountyRecommendations_Call{Call: _e.mock.On("GetBountyRecommendations", pubkey, since, limit)}
+}
+
+func (_c *Database_GetBountyRecommendations_Call) Run(run func(pubkey string, since time.Time, limit int)) *Database_GetBountyRecommendations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(time.Time), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *Database_GetBountyRecommendations_Call) Return(_a0 []db.BountyRecommendation, _a1 error) *Database_GetBountyRecommendations_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetBountyRecommendations_Call) RunAndReturn(run func(string, time.Time, int) ([]db.BountyRecommendation, error)) *Database_GetBountyRecommendations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBountyResponses provides a mock function with given fields: bounties
+func (_m *Database) GetBountyResponses(bounties []db.NewBounty) []db.BountyResponse {
+	ret := _m.Called(bounties)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBountyResponses")
+	}
+
+	var r0 []db.BountyResponse
+	if rf, ok := ret.Get(0).(func([]db.NewBounty) []db.BountyResponse); ok {
+		r0 = rf(bounties)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.BountyResponse)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetBountyResponses_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountyResponses'
+type Database_GetBountyResponses_Call struct {
+	*mock.Call
+}
+
+// GetBountyResponses is a helper method to define mock.On call
+//   - bounties []db.NewBounty
+func (_e *Database_Expecter) GetBountyResponses(bounties interface{}) *Database_GetBountyResponses_Call {
+	return &Database_GetBountyResponses_Call{Call: _e.mock.On("GetBountyResponses", bounties)}
+}
+
+func (_c *Database_GetBountyResponses_Call) Run(run func(bounties []db.NewBounty)) *Database_GetBountyResponses_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]db.NewBounty))
+	})
+	return _c
+}
+
+func (_c *Database_GetBountyResponses_Call) Return(_a0 []db.BountyResponse) *Database_GetBountyResponses_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetBountyResponses_Call) RunAndReturn(run func([]db.NewBounty) []db.BountyResponse) *Database_GetBountyResponses_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBountyRoles provides a mock function with given fields:
+func (_m *Database) GetBountyRoles() []db.BountyRoles {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBountyRoles")
+	}
+
+	var r0 []db.BountyRoles
+	if rf, ok := ret.Get(0).(func() []db.BountyRoles); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.BountyRoles)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetBountyRoles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountyRoles'
+type Database_GetBountyRoles_Call struct {
+	*mock.Call
+}
+
+// GetBountyRoles is a helper method to define mock.On call
+func (_e *Database_Expecter) GetBountyRoles() *Database_GetBountyRoles_Call {
+	return &Database_GetBountyRoles_Call{Call: _e.mock.On("GetBountyRoles")}
+}
+
+func (_c *Database_GetBountyRoles_Call) Run(run func()) *Database_GetBountyRoles_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Database_GetBountyRoles_Call) Return(_a0 []db.BountyRoles) *Database_GetBountyRoles_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetBountyRoles_Call) RunAndReturn(run func() []db.BountyRoles) *Database_GetBountyRoles_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBountyRoutingRule provides a mock function with given fields: uuid
+func (_m *Database) GetBountyRoutingRule(uuid string) (db.BountyRoutingRule, error) {
+	ret := _m.Called(uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBountyRoutingRule")
+	}
+
+	var r0 db.BountyRoutingRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (db.BountyRoutingRule, error)); ok {
+		return rf(uuid)
+	}
+	if rf, ok := ret.Get(0).(func(string) db.BountyRoutingRule); ok {
+		r0 = rf(uuid)
+	} else {
+		r0 = ret.Get(0).(db.BountyRoutingRule)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(uuid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetBountyRoutingRule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountyRoutingRule'
+type Database_GetBountyRoutingRule_Call struct {
+	*mock.Call
+}
+
+// GetBountyRoutingRule is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) GetBountyRoutingRule(uuid interface{}) *Database_GetBountyRoutingRule_Call {
+	return &Database_GetBountyRoutingRule_Call{Call: _e.mock.On("GetBountyRoutingRule", uuid)}
+}
+
+func (_c *Database_GetBountyRoutingRule_Call) Run(run func(uuid string)) *Database_GetBountyRoutingRule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetBountyRoutingRule_Call) Return(_a0 db.BountyRoutingRule, _a1 error) *Database_GetBountyRoutingRule_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetBountyRoutingRule_Call) RunAndReturn(run func(string) (db.BountyRoutingRule, error)) *Database_GetBountyRoutingRule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBountyRoutingRules provides a mock function with given fields: workspaceUuid
+func (_m *Database) GetBountyRoutingRules(workspaceUuid string) ([]db.BountyRoutingRule, error) {
+	ret := _m.Called(workspaceUuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBountyRoutingRules")
+	}
+
+	var r0 []db.BountyRoutingRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]db.BountyRoutingRule, error)); ok {
+		return rf(workspaceUuid)
+	}
+	if rf, ok := ret.Get(0).(func(string) []db.BountyRoutingRule); ok {
+		r0 = rf(workspaceUuid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.BountyRoutingRule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(workspaceUuid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetBountyRoutingRules_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountyRoutingRules'
+type Database_GetBountyRoutingRules_Call struct {
+	*mock.Call
+}
+
+// GetBountyRoutingRules is a helper method to define mock.On call
+//   - workspaceUuid string
+func (_e *Database_Expecter) GetBountyRoutingRules(workspaceUuid interface{}) *Database_GetBountyRoutingRules_Call {
+	return &Database_GetBountyRoutingRules_Call{Call: _e.mock.On("GetBountyRoutingRules", workspaceUuid)}
+}
+
+func (_c *Database_GetBountyRoutingRules_Call) Run(run func(workspaceUuid string)) *Database_GetBountyRoutingRules_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetBountyRoutingRules_Call) Return(_a0 []db.BountyRoutingRule, _a1 error) *Database_GetBountyRoutingRules_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetBountyRoutingRules_Call) RunAndReturn(run func(string) ([]db.BountyRoutingRule, error)) *Database_GetBountyRoutingRules_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBountyTermsAcceptances provides a mock function with given fields: bountyID
+func (_m *Database) GetBountyTermsAcceptances(bountyID uint) ([]db.BountyTermsAcceptance, error) {
+	ret := _m.Called(bountyID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBountyTermsAcceptances")
+	}
+
+	var r0 []db.BountyTermsAcceptance
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint) ([]db.BountyTermsAcceptance, error)); ok {
+		return rf(bountyID)
+	}
+	if rf, ok := ret.Get(0).(func(uint) []db.BountyTermsAcceptance); ok {
+		r0 = rf(bountyID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.BountyTermsAcceptance)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(bountyID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetBountyTermsAcceptances_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountyTermsAcceptances'
+type Database_GetBountyTermsAcceptances_Call struct {
+	*mock.Call
+}
+
+// GetBountyTermsAcceptances is a helper method to define mock.On call
+//   - bountyID uint
+func (_e *Database_Expecter) GetBountyTermsAcceptances(bountyID interface{}) *Database_GetBountyTermsAcceptances_Call {
+	return &Database_GetBountyTermsAcceptances_Call{Call: _e.mock.On("GetBountyTermsAcceptances", bountyID)}
+}
+
+func (_c *Database_GetBountyTermsAcceptances_Call) Run(run func(bountyID uint)) *Database_GetBountyTermsAcceptances_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *Database_GetBountyTermsAcceptances_Call) Return(_a0 []db.BountyTermsAcceptance, _a1 error) *Database_GetBountyTermsAcceptances_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetBountyTermsAcceptances_Call) RunAndReturn(run func(uint) ([]db.BountyTermsAcceptance, error)) *Database_GetBountyTermsAcceptances_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBountyTimeEntries provides a mock function with given fields: bountyId
+func (_m *Database) GetBountyTimeEntries(bountyId uint) []db.TimeEntry {
+	ret := _m.Called(bountyId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBountyTimeEntries")
+	}
+
+	var r0 []db.TimeEntry
+	if rf, ok := ret.Get(0).(func(uint) []db.TimeEntry); ok {
+		r0 = rf(bountyId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.TimeEntry)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetBountyTimeEntries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountyTimeEntries'
+type Database_GetBountyTimeEntries_Call struct {
+	*mock.Call
+}
+
+// GetBountyTimeEntries is a helper method to define mock.On call
+//   - bountyId uint
+func (_e *Database_Expecter) GetBountyTimeEntries(bountyId interface{}) *Database_GetBountyTimeEntries_Call {
+	return &Database_GetBountyTimeEntries_Call{Call: _e.mock.On("GetBountyTimeEntries", bountyId)}
+}
+
+func (_c *Database_GetBountyTimeEntries_Call) Run(run func(bountyId uint)) *Database_GetBountyTimeEntries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *Database_GetBountyTimeEntries_Call) Return(_a0 []db.TimeEntry) *Database_GetBountyTimeEntries_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetBountyTimeEntries_Call) RunAndReturn(run func(uint) []db.TimeEntry) *Database_GetBountyTimeEntries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBountyTrackedMinutes provides a mock function with given fields: bountyId, pubkey
+func (_m *Database) GetBountyTrackedMinutes(bountyId uint, pubkey string) uint {
+	ret := _m.Called(bountyId, pubkey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBountyTrackedMinutes")
+	}
+
+	var r0 uint
+	if rf, ok := ret.Get(0).(func(uint, string) uint); ok {
+		r0 = rf(bountyId, pubkey)
+	} else {
+		r0 = ret.Get(0).(uint)
+	}
+
+	return r0
+}
+
+// Database_GetBountyTrackedMinutes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountyTrackedMinutes'
+type Database_GetBountyTrackedMinutes_Call struct {
+	*mock.Call
+}
+
+// GetBountyTrackedMinutes is a helper method to define mock.On call
+//   - bountyId uint
+//   - pubkey string
+func (_e *Database_Expecter) GetBountyTrackedMinutes(bountyId interface{}, pubkey interface{}) *Database_GetBountyTrackedMinutes_Call {
+	return &Database_GetBountyTrackedMinutes_Call{Call: _e.mock.On("GetBountyTrackedMinutes", bountyId, pubkey)}
+}
+
+func (_c *Database_GetBountyTrackedMinutes_Call) Run(run func(bountyId uint, pubkey string)) *Database_GetBountyTrackedMinutes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetBountyTrackedMinutes_Call) Return(_a0 uint) *Database_GetBountyTrackedMinutes_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetBountyTrackedMinutes_Call) RunAndReturn(run func(uint, string) uint) *Database_GetBountyTrackedMinutes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCategories provides a mock function with given fields:
+func (_m *Database) GetCategories() ([]db.Category, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCategories")
+	}
+
+	var r0 []db.Category
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]db.Category, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []db.Category); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Category)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetCategories_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCategories'
+type Database_GetCategories_Call struct {
+	*mock.Call
+}
+
+// GetCategories is a helper method to define mock.On call
+func (_e *Database_Expecter) GetCategories() *Database_GetCategories_Call {
+	return &Database_GetCategories_Call{Call: _e.mock.On("GetCategories")}
+}
+
+func (_c *Database_GetCategories_Call) Run(run func()) *Database_GetCategories_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Database_GetCategories_Call) Return(_a0 []db.Category, _a1 error) *Database_GetCategories_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetCategories_Call) RunAndReturn(run func() ([]db.Category, error)) *Database_GetCategories_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetChannel provides a mock function with given fields: id
+func (_m *Database) GetChannel(id uint) db.Channel {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetChannel")
+	}
+
+	var r0 db.Channel
+	if rf, ok := ret.Get(0).(func(uint) db.Channel); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Get(0).(db.Channel)
+	}
+
+	return r0
+}
+
+// Database_GetChannel_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetChannel'
+type Database_GetChannel_Call struct {
+	*mock.Call
+}
+
+// GetChannel is a helper method to define mock.On call
+//   - id uint
+func (_e *Database_Expecter) GetChannel(id interface{}) *Database_GetChannel_Call {
+	return &Database_GetChannel_Call{Call: _e.mock.On("GetChannel", id)}
+}
+
+func (_c *Database_GetChannel_Call) Run(run func(id uint)) *Database_GetChannel_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *Database_GetChannel_Call) Return(_a0 db.Channel) *Database_GetChannel_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetChannel_Call) RunAndReturn(run func(uint) db.Channel) *Database_GetChannel_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetChannelsByTribe provides a mock function with given fields: tribe_uuid
+func (_m *Database) GetChannelsByTribe(tribe_uuid string) []db.Channel {
+	ret := _m.Called(tribe_uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetChannelsByTribe")
+	}
+
+	var r0 []db.Channel
+	if rf, ok := ret.Get(0).(func(string) []db.Channel); ok {
+		r0 = rf(tribe_uuid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Channel)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetChannelsByTribe_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetChannelsByTribe'
+type Database_GetChannelsByTribe_Call struct {
+	*mock.Call
+}
+
+// GetChannelsByTribe is a helper method to define mock.On call
+//   - tribe_uuid string
+func (_e *Database_Expecter) GetChannelsByTribe(tribe_uuid interface{}) *Database_GetChannelsByTribe_Call {
+	return &Database_GetChannelsByTribe_Call{Call: _e.mock.On("GetChannelsByTribe", tribe_uuid)}
+}
+
+func (_c *Database_GetChannelsByTribe_Call) Run(run func(tribe_uuid string)) *Database_GetChannelsByTribe_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetChannelsByTribe_Call) Return(_a0 []db.Channel) *Database_GetChannelsByTribe_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetChannelsByTribe_Call) RunAndReturn(run func(string) []db.Channel) *Database_GetChannelsByTribe_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetChatByUuid provides a mock function with given fields: uuid
+func (_m *Database) GetChatByUuid(uuid string) (db.Chat, error) {
+	ret := _m.Called(uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetChatByUuid")
+	}
+
+	var r0 db.Chat
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (db.Chat, error)); ok {
+		return rf(uuid)
+	}
+	if rf, ok := ret.Get(0).(func(string) db.Chat); ok {
+		r0 = rf(uuid)
+	} else {
+		r0 = ret.Get(0).(db.Chat)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(uuid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetChatByUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetChatByUuid'
+type Database_GetChatByUuid_Call struct {
+	*mock.Call
+}
+
+// GetChatByUuid is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) GetChatByUuid(uuid interface{}) *Database_GetChatByUuid_Call {
+	return &Database_GetChatByUuid_Call{Call: _e.mock.On("GetChatByUuid", uuid)}
+}
+
+func (_c *Database_GetChatByUuid_Call) Run(run func(uuid string)) *Database_GetChatByUuid_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetChatByUuid_Call) Return(_a0 db.Chat, _a1 error) *Database_GetChatByUuid_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetChatByUuid_Call) RunAndReturn(run func(string) (db.Chat, error)) *Database_GetChatByUuid_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetChatMessageByUuid provides a mock function with given fields: uuid
+func (_m *Database) GetChatMessageByUuid(uuid string) (db.ChatMessage, error) {
+	ret := _m.Called(uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetChatMessageByUuid")
+	}
+
+	var r0 db.ChatMessage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (db.ChatMessage, error)); ok {
+		return rf(uuid)
+	}
+	if rf, ok := ret.Get(0).(func(string) db.ChatMessage); ok {
+		r0 = rf(uuid)
+	} else {
+		r0 = ret.Get(0).(db.ChatMessage)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(uuid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetChatMessageByUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetChatMessageByUuid'
+type Database_GetChatMessageByUuid_Call struct {
+	*mock.Call
+}
+
+// GetChatMessageByUuid is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) GetChatMessageByUuid(uuid interface{}) *Database_GetChatMessageByUuid_Call {
+	return &Database_GetChatMessageByUuid_Call{Call: _e.mock.On("GetChatMessageByUuid", uuid)}
+}
+
+func (_c *Database_GetChatMessageByUuid_Call) Run(run func(uuid string)) *Database_GetChatMessageByUuid_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetChatMessageByUuid_Call) Return(_a0 db.ChatMessage, _a1 error) *Database_GetChatMessageByUuid_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetChatMessageByUuid_Call) RunAndReturn(run func(string) (db.ChatMessage, error)) *Database_GetChatMessageByUuid_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetChatMessagesForChatID provides a mock function with given fields: chatUuid
+func (_m *Database) GetChatMessagesForChatID(chatUuid string) ([]db.ChatMessage, error) {
+	ret := _m.Called(chatUuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetChatMessagesForChatID")
+	}
+
+	var r0 []db.ChatMessage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]db.ChatMessage, error)); ok {
+		return rf(chatUuid)
+	}
+	if rf, ok := ret.Get(0).(func(string) []db.ChatMessage); ok {
+		r0 = rf(chatUuid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.ChatMessage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(chatUuid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetChatMessagesForChatID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetChatMessagesForChatID'
+type Database_GetChatMessagesForChatID_Call struct {
+	*mock.Call
+}
+
+// GetChatMessagesForChatID is a helper method to define mock.On call
+//   - chatUuid string
+func (_e *Database_Expecter) GetChatMessagesForChatID(chatUuid interface{}) *Database_GetChatMessagesForChatID_Call {
+	return &Database_GetChatMessagesForChatID_Call{Call: _e.mock.On("GetChatMessagesForChatID", chatUuid)}
+}
+
+func (_c *Database_GetChatMessagesForChatID_Call) Run(run func(chatUuid string)) *Database_GetChatMessagesForChatID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetChatMessagesForChatID_Call) Return(_a0 []db.ChatMessage, _a1 error) *Database_GetChatMessagesForChatID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetChatMessagesForChatID_Call) RunAndReturn(run func(string) ([]db.ChatMessage, error)) *Database_GetChatMessagesForChatID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetChatsForWorkspace provides a mock function with given fields: workspaceUuid, status
+func (_m *Database) GetChatsForWorkspace(workspaceUuid string, status string) ([]db.Chat, error) {
+	ret := _m.Called(workspaceUuid, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetChatsForWorkspace")
+	}
+
+	var r0 []db.Chat
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) ([]db.Chat, error)); ok {
+		return rf(workspaceUuid, status)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) []db.Chat); ok {
+		r0 = rf(workspaceUuid, status)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Chat)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(workspaceUuid, status)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetChatsForWorkspace_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetChatsForWorkspace'
+type Database_GetChatsForWorkspace_Call struct {
+	*mock.Call
+}
+
+// GetChatsForWorkspace is a helper method to define mock.On call
+//   - workspaceUuid string
+//   - status string
+func (_e *Database_Expecter) GetChatsForWorkspace(workspaceUuid interface{}, status interface{}) *Database_GetChatsForWorkspace_Call {
+	return &Database_GetChatsForWorkspace_Call{Call: _e.mock.On("GetChatsForWorkspace", workspaceUuid, status)}
+}
+
+func (_c *Database_GetChatsForWorkspace_Call) Run(run func(workspaceUuid string, status string)) *Database_GetChatsForWorkspace_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetChatsForWorkspace_Call) Return(_a0 []db.Chat, _a1 error) *Database_GetChatsForWorkspace_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetChatsForWorkspace_Call) RunAndReturn(run func(string, string) ([]db.Chat, error)) *Database_GetChatsForWorkspace_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetConnectionCode provides a mock function with given fields:
+func (_m *Database) GetConnectionCode() db.ConnectionCodesShort {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetConnectionCode")
+	}
+
+	var r0 db.ConnectionCodesShort
+	if rf, ok := ret.Get(0).(func() db.ConnectionCodesShort); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(db.ConnectionCodesShort)
+	}
+
+	return r0
+}
+
+// Database_GetConnectionCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetConnectionCode'
+type Database_GetConnectionCode_Call struct {
+	*mock.Call
+}
+
+// GetConnectionCode is a helper method to define mock.On call
+func (_e *Database_Expecter) GetConnectionCode() *Database_GetConnectionCode_Call {
+	return &Database_GetConnectionCode_Call{Call: _e.mock.On("GetConnectionCode")}
+}
+
+func (_c *Database_GetConnectionCode_Call) Run(run func()) *Database_GetConnectionCode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Database_GetConnectionCode_Call) Return(_a0 db.ConnectionCodesShort) *Database_GetConnectionCode_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetConnectionCode_Call) RunAndReturn(run func() db.ConnectionCodesShort) *Database_GetConnectionCode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetConnectionCodeStats provides a mock function with given fields:
+func (_m *Database) GetConnectionCodeStats() ([]db.ConnectionCodeStats, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetConnectionCodeStats")
+	}
+
+	var r0 []db.ConnectionCodeStats
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]db.ConnectionCodeStats, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []db.ConnectionCodeStats); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.ConnectionCodeStats)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetConnectionCodeStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetConnectionCodeStats'
+type Database_GetConnectionCodeStats_Call struct {
+	*mock.Call
+}
+
+// GetConnectionCodeStats is a helper method to define mock.On call
+func (_e *Database_Expecter) GetConnectionCodeStats() *Database_GetConnectionCodeStats_Call {
+	return &Database_GetConnectionCodeStats_Call{Call: _e.mock.On("GetConnectionCodeStats")}
+}
+
+func (_c *Database_GetConnectionCodeStats_Call) Run(run func()) *Database_GetConnectionCodeStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Database_GetConnectionCodeStats_Call) Return(_a0 []db.ConnectionCodeStats, _a1 error) *Database_GetConnectionCodeStats_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetConnectionCodeStats_Call) RunAndReturn(run func() ([]db.ConnectionCodeStats, error)) *Database_GetConnectionCodeStats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCreatedBounties provides a mock function with given fields: r
+func (_m *Database) GetCreatedBounties(r *http.Request) ([]db.NewBounty, error) {
+	ret := _m.Called(r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCreatedBounties")
+	}
+
+	var r0 []db.NewBounty
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*http.Request) ([]db.NewBounty, error)); ok {
+		return rf(r)
+	}
+	if rf, ok := ret.Get(0).(func(*http.Request) []db.NewBounty); ok {
+		r0 = rf(r)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.NewBounty)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*http.Request) error); ok {
+		r1 = rf(r)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetCreatedBounties_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCreatedBounties'
+type Database_GetCreatedBounties_Call struct {
+	*mock.Call
+}
+
+// GetCreatedBounties is a helper method to define mock.On call
+//   - r *http.Request
+func (_e *Database_Expecter) GetCreatedBounties(r interface{}) *Database_GetCreatedBounties_Call {
+	return &Database_GetCreatedBounties_Call{Call: _e.mock.On("GetCreatedBounties", r)}
+}
+
+func (_c *Database_GetCreatedBounties_Call) Run(run func(r *http.Request)) *Database_GetCreatedBounties_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *Database_GetCreatedBounties_Call) Return(_a0 []db.NewBounty, _a1 error) *Database_GetCreatedBounties_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetCreatedBounties_Call) RunAndReturn(run func(*http.Request) ([]db.NewBounty, error)) *Database_GetCreatedBounties_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDigestWorkspaces provides a mock function with given fields:
+func (_m *Database) GetDigestWorkspaces() ([]db.Workspace, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDigestWorkspaces")
+	}
+
+	var r0 []db.Workspace
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]db.Workspace, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []db.Workspace); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Workspace)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetDigestWorkspaces_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDigestWorkspaces'
+type Database_GetDigestWorkspaces_Call struct {
+	*mock.Call
+}
+
+// GetDigestWorkspaces is a helper method to define mock.On call
+func (_e *Database_Expecter) GetDigestWorkspaces() *Database_GetDigestWorkspaces_Call {
+	return &Database_GetDigestWorkspaces_Call{Call: _e.mock.On("GetDigestWorkspaces")}
+}
+
+func (_c *Database_GetDigestWorkspaces_Call) Run(run func()) *Database_GetDigestWorkspaces_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Database_GetDigestWorkspaces_Call) Return(_a0 []db.Workspace, _a1 error) *Database_GetDigestWorkspaces_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetDigestWorkspaces_Call) RunAndReturn(run func() ([]db.Workspace, error)) *Database_GetDigestWorkspaces_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetEventConsumer provides a mock function with given fields: name
+func (_m *Database) GetEventConsumer(name string) db.EventConsumer {
+	ret := _m.Called(name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEventConsumer")
+	}
+
+	var r0 db.EventConsumer
+	if rf, ok := ret.Get(0).(func(string) db.EventConsumer); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Get(0).(db.EventConsumer)
+	}
+
+	return r0
+}
+
+// Database_GetEventConsumer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEventConsumer'
+type Database_GetEventConsumer_Call struct {
+	*mock.Call
+}
+
+// GetEventConsumer is a helper method to define mock.On call
+//   - name string
+func (_e *Database_Expecter) GetEventConsumer(name interface{}) *Database_GetEventConsumer_Call {
+	return &Database_GetEventConsumer_Call{Call: _e.mock.On("GetEventConsumer", name)}
+}
+
+func (_c *Database_GetEventConsumer_Call) Run(run func(name string)) *Database_GetEventConsumer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetEventConsumer_Call) Return(_a0 db.EventConsumer) *Database_GetEventConsumer_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetEventConsumer_Call) RunAndReturn(run func(string) db.EventConsumer) *Database_GetEventConsumer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetEvents provides a mock function with given fields: r
+func (_m *Database) GetEvents(r *http.Request) ([]db.Event, error) {
+	ret := _m.Called(r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEvents")
+	}
+
+	var r0 []db.Event
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*http.Request) ([]db.Event, error)); ok {
+		return rf(r)
+	}
+	if rf, ok := ret.Get(0).(func(*http.Request) []db.Event); ok {
+		r0 = rf(r)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Event)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*http.Request) error); ok {
+		r1 = rf(r)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEvents'
+type Database_GetEvents_Call struct {
+	*mock.Call
+}
+
+// GetEvents is a helper method to define mock.On call
+//   - r *http.Request
+func (_e *Database_Expecter) GetEvents(r interface{}) *Database_GetEvents_Call {
+	return &Database_GetEvents_Call{Call: _e.mock.On("GetEvents", r)}
+}
+
+func (_c *Database_GetEvents_Call) Run(run func(r *http.Request)) *Database_GetEvents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *Database_GetEvents_Call) Return(_a0 []db.Event, _a1 error) *Database_GetEvents_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetEvents_Call) RunAndReturn(run func(*http.Request) ([]db.Event, error)) *Database_GetEvents_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetEventsAfter provides a mock function with given fields: id, types, limit
+func (_m *Database) GetEventsAfter(id uint, types []string, limit int) ([]db.Event, error) {
+	ret := _m.Called(id, types, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEventsAfter")
+	}
+
+	var r0 []db.Event
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint, []string, int) ([]db.Event, error)); ok {
+		return rf(id, types, limit)
+	}
+	if rf, ok := ret.Get(0).(func(uint, []string, int) []db.Event); ok {
+		r0 = rf(id, types, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Event)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint, []string, int) error); ok {
+		r1 = rf(id, types, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetEventsAfter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEventsAfter'
+type Database_GetEventsAfter_Call struct {
+	*mock.Call
+}
+
+// GetEventsAfter is a helper method to define mock.On call
+//   - id uint
+//   - types []string
+//   - limit int
+func (_e *Database_Expecter) GetEventsAfter(id interface{}, types interface{}, limit interface{}) *Database_GetEventsAfter_Call {
+	return &Database_GetEventsAfter_Call{Call: _e.mock.On("GetEventsAfter", id, types, limit)}
+}
+
+func (_c *Database_GetEventsAfter_Call) Run(run func(id uint, types []string, limit int)) *Database_GetEventsAfter_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint), args[1].([]string), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *Database_GetEventsAfter_Call) Return(_a0 []db.Event, _a1 error) *Database_GetEventsAfter_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetEventsAfter_Call) RunAndReturn(run func(uint, []string, int) ([]db.Event, error)) *Database_GetEventsAfter_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetFeatureBounties provides a mock function with given fields: featureUuid
+func (_m *Database) GetFeatureBounties(featureUuid string) []db.NewBounty {
+	ret := _m.Called(featureUuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFeatureBounties")
+	}
+
+	var r0 []db.NewBounty
+	if rf, ok := ret.Get(0).(func(string) []db.NewBounty); ok {
+		r0 = rf(featureUuid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.NewBounty)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetFeatureBounties_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFeatureBounties'
+type Database_GetFeatureBounties_Call struct {
+	*mock.Call
+}
+
+// GetFeatureBounties is a helper method to define mock.On call
+//   - featureUuid string
+func (_e *Database_Expecter) GetFeatureBounties(featureUuid interface{}) *Database_GetFeatureBounties_Call {
+	return &Database_GetFeatureBounties_Call{Call: _e.mock.On("GetFeatureBounties", featureUuid)}
+}
+
+func (_c *Database_GetFeatureBounties_Call) Run(run func(featureUuid string)) *Database_GetFeatureBounties_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetFeatureBounties_Call) Return(_a0 []db.NewBounty) *Database_GetFeatureBounties_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetFeatureBounties_Call) RunAndReturn(run func(string) []db.NewBounty) *Database_GetFeatureBounties_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetFeatureBudgetHistory provides a mock function with given fields: featureUuid
+func (_m *Database) GetFeatureBudgetHistory(featureUuid string) []db.FeatureBudgetHistory {
+	ret := _m.Called(featureUuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFeatureBudgetHistory")
+	}
+
+	var r0 []db.FeatureBudgetHistory
+	if rf, ok := ret.Get(0).(func(string) []db.FeatureBudgetHistory); ok {
+		r0 = rf(featureUuid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.FeatureBudgetHistory)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetFeatureBudgetHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFeatureBudgetHistory'
+type Database_GetFeatureBudgetHistory_Call struct {
+	*mock.Call
+}
+
+// GetFeatureBudgetHistory is a helper method to define mock.On call
+//   - featureUuid string
+func (_e *Database_Expecter) GetFeatureBudgetHistory(featureUuid interface{}) *Database_GetFeatureBudgetHistory_Call {
+	return &Database_GetFeatureBudgetHistory_Call{Call: _e.mock.On("GetFeatureBudgetHistory", featureUuid)}
+}
+
+func (_c *Database_GetFeatureBudgetHistory_Call) Run(run func(featureUuid string)) *Database_GetFeatureBudgetHistory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetFeatureBudgetHistory_Call) Return(_a0 []db.FeatureBudgetHistory) *Database_GetFeatureBudgetHistory_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetFeatureBudgetHistory_Call) RunAndReturn(run func(string) []db.FeatureBudgetHistory) *Database_GetFeatureBudgetHistory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetFeatureByUuid provides a mock function with given fields: uuid
+func (_m *Database) GetFeatureByUuid(uuid string) db.WorkspaceFeatures {
+	ret := _m.Called(uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFeatureByUuid")
+	}
+
+	var r0 db.WorkspaceFeatures
+	if rf, ok := ret.Get(0).(func(string) db.WorkspaceFeatures); ok {
+		r0 = rf(uuid)
+	} else {
+		r0 = ret.Get(0).(db.WorkspaceFeatures)
+	}
+
+	return r0
+}
+
+// Database_GetFeatureByUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFeatureByUuid'
+type Database_GetFeatureByUuid_Call struct {
+	*mock.Call
+}
+
+// GetFeatureByUuid is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) GetFeatureByUuid(uuid interface{}) *Database_GetFeatureByUuid_Call {
+	return &Database_GetFeatureByUuid_Call{Call: _e.mock.On("GetFeatureByUuid", uuid)}
+}
+
+func (_c *Database_GetFeatureByUuid_Call) Run(run func(uuid string)) *Database_GetFeatureByUuid_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetFeatureByUuid_Call) Return(_a0 db.WorkspaceFeatures) *Database_GetFeatureByUuid_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetFeatureByUuid_Call) RunAndReturn(run func(string) db.WorkspaceFeatures) *Database_GetFeatureByUuid_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetFeaturePhaseByUuid provides a mock function with given fields: featureUuid, phaseUuid
+func (_m *Database) GetFeaturePhaseByUuid(featureUuid string, phaseUuid string) (db.FeaturePhase, error) {
+	ret := _m.Called(featureUuid, phaseUuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFeaturePhaseByUuid")
+	}
+
+	var r0 db.FeaturePhase
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (db.FeaturePhase, error)); ok {
+		return rf(featureUuid, phaseUuid)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) db.FeaturePhase); ok {
+		r0 = rf(featureUuid, phaseUuid)
+	} else {
+		r0 = ret.Get(0).(db.FeaturePhase)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(featureUuid, phaseUuid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetFeaturePhaseByUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFeaturePhaseByUuid'
+type Database_GetFeaturePhaseByUuid_Call struct {
+	*mock.Call
+}
+
+// GetFeaturePhaseByUuid is a helper method to define mock.On call
+//   - featureUuid string
+//   - phaseUuid string
+func (_e *Database_Expecter) GetFeaturePhaseByUuid(featureUuid interface{}, phaseUuid interface{}) *Database_GetFeaturePhaseByUuid_Call {
+	return &Database_GetFeaturePhaseByUuid_Call{Call: _e.mock.On("GetFeaturePhaseByUuid", featureUuid, phaseUuid)}
+}
+
+func (_c *Database_GetFeaturePhaseByUuid_Call) Run(run func(featureUuid string, phaseUuid string)) *Database_GetFeaturePhaseByUuid_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetFeaturePhaseByUuid_Call) Return(_a0 db.FeaturePhase, _a1 error) *Database_GetFeaturePhaseByUuid_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetFeaturePhaseByUuid_Call) RunAndReturn(run func(string, string) (db.FeaturePhase, error)) *Database_GetFeaturePhaseByUuid_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetFeaturePhasesBountiesCount provides a mock function with given fields: bountyType, phaseUuid
+func (_m *Database) GetFeaturePhasesBountiesCount(bountyType string, phaseUuid string) int64 {
+	ret := _m.Called(bountyType, phaseUuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFeaturePhasesBountiesCount")
+	}
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(string, string) int64); ok {
+		r0 = rf(bountyType, phaseUuid)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	return r0
+}
+
+// Database_GetFeaturePhasesBountiesCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFeaturePhasesBountiesCount'
+type Database_GetFeaturePhasesBountiesCount_Call struct {
+	*mock.Call
+}
+
+// GetFeaturePhasesBountiesCount is a helper method to define mock.On call
+//   - bountyType string
+//   - phaseUuid string
+func (_e *Database_Expecter) GetFeaturePhasesBountiesCount(bountyType interface{}, phaseUuid interface{}) *Database_GetFeaturePhasesBountiesCount_Call {
+	return &Database_GetFeaturePhasesBountiesCount_Call{Call: _e.mock.On("GetFeaturePhasesBountiesCount", bountyType, phaseUuid)}
+}
+
+func (_c *Database_GetFeaturePhasesBountiesCount_Call) Run(run func(bountyType string, phaseUuid string)) *Database_GetFeaturePhasesBountiesCount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetFeaturePhasesBountiesCount_Call) Return(_a0 int64) *Database_GetFeaturePhasesBountiesCount_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetFeaturePhasesBountiesCount_Call) RunAndReturn(run func(string, string) int64) *Database_GetFeaturePhasesBountiesCount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetFeatureStoriesByFeatureUuid provides a mock function with given fields: featureUuid
+func (_m *Database) GetFeatureStoriesByFeatureUuid(featureUuid string) ([]db.FeatureStory, error) {
+	ret := _m.Called(featureUuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFeatureStoriesByFeatureUuid")
+	}
+
+	var r0 []db.FeatureStory
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]db.FeatureStory, error)); ok {
+		return rf(featureUuid)
+	}
+	if rf, ok := ret.Get(0).(func(string) []db.FeatureStory); ok {
+		r0 = rf(featureUuid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.FeatureStory)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(featureUuid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetFeatureStoriesByFeatureUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFeatureStoriesByFeatureUuid'
+type Database_GetFeatureStoriesByFeatureUuid_Call struct {
+	*mock.Call
+}
+
+// GetFeatureStoriesByFeatureUuid is a helper method to define mock.On call
+//   - featureUuid string
+func (_e *Database_Expecter) GetFeatureStoriesByFeatureUuid(featureUuid interface{}) *Database_GetFeatureStoriesByFeatureUuid_Call {
+	return &Database_GetFeatureStoriesByFeatureUuid_Call{Call: _e.mock.On("GetFeatureStoriesByFeatureUuid", featureUuid)}
+}
+
+func (_c *Database_GetFeatureStoriesByFeatureUuid_Call) Run(run func(featureUuid string)) *Database_GetFeatureStoriesByFeatureUuid_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetFeatureStoriesByFeatureUuid_Call) Return(_a0 []db.FeatureStory, _a1 error) *Database_GetFeatureStoriesByFeatureUuid_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetFeatureStoriesByFeatureUuid_Call) RunAndReturn(run func(string) ([]db.FeatureStory, error)) *Database_GetFeatureStoriesByFeatureUuid_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetFeatureStoryByUuid provides a mock function with given fields: featureUuid, storyUuid
+func (_m *Database) GetFeatureStoryByUuid(featureUuid string, storyUuid string) (db.FeatureStory, error) {
+	ret := _m.Called(featureUuid, storyUuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFeatureStoryByUuid")
+	}
+
+	var r0 db.FeatureStory
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (db.FeatureStory, error)); ok {
+		return rf(featureUuid, storyUuid)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) db.FeatureStory); ok {
+		r0 = rf(featureUuid, storyUuid)
+	} else {
+		r0 = ret.Get(0).(db.FeatureStory)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(featureUuid, storyUuid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetFeatureStoryByUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFeatureStoryByUuid'
+type Database_GetFeatureStoryByUuid_Call struct {
+	*mock.Call
+}
+
+// GetFeatureStoryByUuid is a helper method to define mock.On call
+//   - featureUuid string
+//   - storyUuid string
+func (_e *Database_Expecter) GetFeatureStoryByUuid(featureUuid interface{}, storyUuid interface{}) *Database_GetFeatureStoryByUuid_Call {
+	return &Database_GetFeatureStoryByUuid_Call{Call: _e.mock.On("GetFeatureStoryByUuid", featureUuid, storyUuid)}
+}
+
+func (_c *Database_GetFeatureStoryByUuid_Call) Run(run func(featureUuid string, storyUuid string)) *Database_GetFeatureStoryByUuid_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetFeatureStoryByUuid_Call) Return(_a0 db.FeatureStory, _a1 error) *Database_GetFeatureStoryByUuid_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetFeatureStoryByUuid_Call) RunAndReturn(run func(string, string) (db.FeatureStory, error)) *Database_GetFeatureStoryByUuid_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetFeaturesByWorkspaceUuid provides a mock function with given fields: uuid, r
+func (_m *Database) GetFeaturesByWorkspaceUuid(uuid string, r *http.Request) []db.WorkspaceFeatures {
+	ret := _m.Called(uuid, r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFeaturesByWorkspaceUuid")
+	}
+
+	var r0 []db.WorkspaceFeatures
+	if rf, ok := ret.Get(0).(func(string, *http.Request) []db.WorkspaceFeatures); ok {
+		r0 = rf(uuid, r)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.WorkspaceFeatures)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetFeaturesByWorkspaceUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFeaturesByWorkspaceUuid'
+type Database_GetFeaturesByWorkspaceUuid_Call struct {
+	*mock.Call
+}
+
+// GetFeaturesByWorkspaceUuid is a helper method to define mock.On call
+//   - uuid string
+//   - r *http.Request
+func (_e *Database_Expecter) GetFeaturesByWorkspaceUuid(uuid interface{}, r interface{}) *Database_GetFeaturesByWorkspaceUuid_Call {
+	return &Database_GetFeaturesByWorkspaceUuid_Call{Call: _e.mock.On("GetFeaturesByWorkspaceUuid", uuid, r)}
+}
+
+func (_c *Database_GetFeaturesByWorkspaceUuid_Call) Run(run func(uuid string, r *http.Request)) *Database_GetFeaturesByWorkspaceUuid_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *Database_GetFeaturesByWorkspaceUuid_Call) Return(_a0 []db.WorkspaceFeatures) *Database_GetFeaturesByWorkspaceUuid_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetFeaturesByWorkspaceUuid_Call) RunAndReturn(run func(string, *http.Request) []db.WorkspaceFeatures) *Database_GetFeaturesByWorkspaceUuid_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetFilterStatusCount provides a mock function with given fields:
+func (_m *Database) GetFilterStatusCount() db.FilterStattuCount {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFilterStatusCount")
+	}
+
+	var r0 db.FilterStattuCount
+	if rf, ok := ret.Get(0).(func() db.FilterStattuCount); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(db.FilterStattuCount)
+	}
+
+	return r0
+}
+
+// Database_GetFilterStatusCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFilterStatusCount'
+type Database_GetFilterStatusCount_Call struct {
+	*mock.Call
+}
+
+// GetFilterStatusCount is a helper method to define mock.On call
+func (_e *Database_Expecter) GetFilterStatusCount() *Database_GetFilterStatusCount_Call {
+	return &Database_GetFilterStatusCount_Call{Call: _e.mock.On("GetFilterStatusCount")}
+}
+
+func (_c *Database_GetFilterStatusCount_Call) Run(run func()) *Database_GetFilterStatusCount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Database_GetFilterStatusCount_Call) Return(_a0 db.FilterStattuCount) *Database_GetFilterStatusCount_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetFilterStatusCount_Call) RunAndReturn(run func() db.FilterStattuCount) *Database_GetFilterStatusCount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetFirstTribeByFeedURL provides a mock function with given fields: feedURL
+func (_m *Database) GetFirstTribeByFeedURL(feedURL string) db.Tribe {
+	ret := _m.Called(feedURL)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFirstTribeByFeedURL")
+	}
+
+	var r0 db.Tribe
+	if rf, ok := ret.Get(0).(func(string) db.Tribe); ok {
+		r0 = rf(feedURL)
+	} else {
+		r0 = ret.Get(0).(db.Tribe)
+	}
+
+	return r0
+}
+
+// Database_GetFirstTribeByFeedURL_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFirstTribeByFeedURL'
+type Database_GetFirstTribeByFeedURL_Call struct {
+	*mock.Call
+}
+
+// GetFirstTribeByFeedURL is a helper method to define mock.On call
+//   - feedURL string
+func (_e *Database_Expecter) GetFirstTribeByFeedURL(feedURL interface{}) *Database_GetFirstTribeByFeedURL_Call {
+	return &Database_GetFirstTribeByFeedURL_Call{Call: _e.mock.On("GetFirstTribeByFeedURL", feedURL)}
+}
+
+func (_c *Database_GetFirstTribeByFeedURL_Call) Run(run func(feedURL string)) *Database_GetFirstTribeByFeedURL_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetFirstTribeByFeedURL_Call) Return(_a0 db.Tribe) *Database_GetFirstTribeByFeedURL_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetFirstTribeByFeedURL_Call) RunAndReturn(run func(string) db.Tribe) *Database_GetFirstTribeByFeedURL_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetFlaggedWorkspaces provides a mock function with given fields: r
+func (_m *Database) GetFlaggedWorkspaces(r *http.Request) ([]db.Workspace, error) {
+	ret := _m.Called(r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFlaggedWorkspaces")
+	}
+
+	var r0 []db.Workspace
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*http.Request) ([]db.Workspace, error)); ok {
+		return rf(r)
+	}
+	if rf, ok := ret.Get(0).(func(*http.Request) []db.Workspace); ok {
+		r0 = rf(r)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Workspace)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*http.Request) error); ok {
+		r1 = rf(r)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetFlaggedWorkspaces_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFlaggedWorkspaces'
+type Database_GetFlaggedWorkspaces_Call struct {
+	*mock.Call
+}
+
+// GetFlaggedWorkspaces is a helper method to define mock.On call
+//   - r *http.Request
+func (_e *Database_Expecter) GetFlaggedWorkspaces(r interface{}) *Database_GetFlaggedWorkspaces_Call {
+	return &Database_GetFlaggedWorkspaces_Call{Call: _e.mock.On("GetFlaggedWorkspaces", r)}
+}
+
+func (_c *Database_GetFlaggedWorkspaces_Call) Run(run func(r *http.Request)) *Database_GetFlaggedWorkspaces_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *Database_GetFlaggedWorkspaces_Call) Return(_a0 []db.Workspace, _a1 error) *Database_GetFlaggedWorkspaces_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetFlaggedWorkspaces_Call) RunAndReturn(run func(*http.Request) ([]db.Workspace, error)) *Database_GetFlaggedWorkspaces_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetGithubSyncWorkspaces provides a mock function with given fields:
+func (_m *Database) GetGithubSyncWorkspaces() ([]db.Workspace, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetGithubSyncWorkspaces")
+	}
+
+	var r0 []db.Workspace
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]db.Workspace, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []db.Workspace); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Workspace)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetGithubSyncWorkspaces_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetGithubSyncWorkspaces'
+type Database_GetGithubSyncWorkspaces_Call struct {
+	*mock.Call
+}
+
+// GetGithubSyncWorkspaces is a helper method to define mock.On call
+func (_e *Database_Expecter) GetGithubSyncWorkspaces() *Database_GetGithubSyncWorkspaces_Call {
+	return &Database_GetGithubSyncWorkspaces_Call{Call: _e.mock.On("GetGithubSyncWorkspaces")}
+}
+
+func (_c *Database_GetGithubSyncWorkspaces_Call) Run(run func()) *Database_GetGithubSyncWorkspaces_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Database_GetGithubSyncWorkspaces_Call) Return(_a0 []db.Workspace, _a1 error) *Database_GetGithubSyncWorkspaces_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetGithubSyncWorkspaces_Call) RunAndReturn(run func() ([]db.Workspace, error)) *Database_GetGithubSyncWorkspaces_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetInvoice provides a mock function with given fields: payment_request
+func (_m *Database) GetInvoice(payment_request string) db.NewInvoiceList {
+	ret := _m.Called(payment_request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetInvoice")
+	}
+
+	var r0 db.NewInvoiceList
+	if rf, ok := ret.Get(0).(func(string) db.NewInvoiceList); ok {
+		r0 = rf(payment_request)
+	} else {
+		r0 = ret.Get(0).(db.NewInvoiceList)
+	}
+
+	return r0
+}
+
+// Database_GetInvoice_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetInvoice'
+type Database_GetInvoice_Call struct {
+	*mock.Call
+}
+
+// GetInvoice is a helper method to define mock.On call
+//   - payment_request string
+func (_e *Database_Expecter) GetInvoice(payment_request interface{}) *Database_GetInvoice_Call {
+	return &Database_GetInvoice_Call{Call: _e.mock.On("GetInvoice", payment_request)}
+}
+
+func (_c *Database_GetInvoice_Call) Run(run func(payment_request string)) *Database_GetInvoice_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetInvoice_Call) Return(_a0 db.NewInvoiceList) *Database_GetInvoice_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetInvoice_Call) RunAndReturn(run func(string) db.NewInvoiceList) *Database_GetInvoice_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetJobByUuid provides a mock function with given fields: uuid
+func (_m *Database) GetJobByUuid(uuid string) (db.Job, error) {
+	ret := _m.Called(uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetJobByUuid")
+	}
+
+	var r0 db.Job
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (db.Job, error)); ok {
+		return rf(uuid)
+	}
+	if rf, ok := ret.Get(0).(func(string) db.Job); ok {
+		r0 = rf(uuid)
+	} else {
+		r0 = ret.Get(0).(db.Job)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(uuid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetJobByUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetJobByUuid'
+type Database_GetJobByUuid_Call struct {
+	*mock.Call
+}
+
+// GetJobByUuid is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) GetJobByUuid(uuid interface{}) *Database_GetJobByUuid_Call {
+	return &Database_GetJobByUuid_Call{Call: _e.mock.On("GetJobByUuid", uuid)}
+}
+
+func (_c *Database_GetJobByUuid_Call) Run(run func(uuid string)) *Database_GetJobByUuid_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetJobByUuid_Call) Return(_a0 db.Job, _a1 error) *Database_GetJobByUuid_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetJobByUuid_Call) RunAndReturn(run func(string) (db.Job, error)) *Database_GetJobByUuid_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetJobs provides a mock function with given fields: r
+func (_m *Database) GetJobs(r *http.Request) ([]db.Job, error) {
+	ret := _m.Called(r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetJobs")
+	}
+
+	var r0 []db.Job
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*http.Request) ([]db.Job, error)); ok {
+		return rf(r)
+	}
+	if rf, ok := ret.Get(0).(func(*http.Request) []db.Job); ok {
+		r0 = rf(r)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Job)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*http.Request) error); ok {
+		r1 = rf(r)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetJobs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetJobs'
+type Database_GetJobs_Call struct {
+	*mock.Call
+}
+
+// GetJobs is a helper method to define mock.On call
+//   - r *http.Request
+func (_e *Database_Expecter) GetJobs(r interface{}) *Database_GetJobs_Call {
+	return &Database_GetJobs_Call{Call: _e.mock.On("GetJobs", r)}
+}
+
+func (_c *Database_GetJobs_Call) Run(run func(r *http.Request)) *Database_GetJobs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *Database_GetJobs_Call) Return(_a0 []db.Job, _a1 error) *Database_GetJobs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetJobs_Call) RunAndReturn(run func(*http.Request) ([]db.Job, error)) *Database_GetJobs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLastEndorsement provides a mock function with given fields: endorser, endorsee
+func (_m *Database) GetLastEndorsement(endorser string, endorsee string) (db.PersonEndorsement, error) {
+	ret := _m.Called(endorser, endorsee)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLastEndorsement")
+	}
+
+	var r0 db.PersonEndorsement
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (db.PersonEndorsement, error)); ok {
+		return rf(endorser, endorsee)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) db.PersonEndorsement); ok {
+		r0 = rf(endorser, endorsee)
+	} else {
+		r0 = ret.Get(0).(db.PersonEndorsement)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(endorser, endorsee)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetLastEndorsement_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLastEndorsement'
+type Database_GetLastEndorsement_Call struct {
+	*mock.Call
+}
+
+// GetLastEndorsement is a helper method to define mock.On call
+//   - endorser string
+//   - endorsee string
+func (_e *Database_Expecter) GetLastEndorsement(endorser interface{}, endorsee interface{}) *Database_GetLastEndorsement_Call {
+	return &Database_GetLastEndorsement_Call{Call: _e.mock.On("GetLastEndorsement", endorser, endorsee)}
+}
+
+func (_c *Database_GetLastEndorsement_Call) Run(run func(endorser string, endorsee string)) *Database_GetLastEndorsement_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetLastEndorsement_Call) Return(_a0 db.PersonEndorsement, _a1 error) *Database_GetLastEndorsement_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetLastEndorsement_Call) RunAndReturn(run func(string, string) (db.PersonEndorsement, error)) *Database_GetLastEndorsement_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLastEventId provides a mock function with given fields:
+func (_m *Database) GetLastEventId() uint {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLastEventId")
+	}
+
+	var r0 uint
+	if rf, ok := ret.Get(0).(func() uint); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint)
+	}
+
+	return r0
+}
+
+// Database_GetLastEventId_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLastEventId'
+type Database_GetLastEventId_Call struct {
+	*mock.Call
+}
+
+// GetLastEventId is a helper method to define mock.On call
+func (_e *Database_Expecter) GetLastEventId() *Database_GetLastEventId_Call {
+	return &Database_GetLastEventId_Call{Call: _e.mock.On("GetLastEventId")}
+}
+
+func (_c *Database_GetLastEventId_Call) Run(run func()) *Database_GetLastEventId_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Database_GetLastEventId_Call) Return(_a0 uint) *Database_GetLastEventId_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetLastEventId_Call) RunAndReturn(run func() uint) *Database_GetLastEventId_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLastNotificationId provides a mock function with given fields:
+func (_m *Database) GetLastNotificationId() uint {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLastNotificationId")
+	}
+
+	var r0 uint
+	if rf, ok := ret.Get(0).(func() uint); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint)
+	}
+
+	return r0
+}
+
+// Database_GetLastNotificationId_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLastNotificationId'
+type Database_GetLastNotificationId_Call struct {
+	*mock.Call
+}
+
+// GetLastNotificationId is a helper method to define mock.On call
+func (_e *Database_Expecter) GetLastNotificationId() *Database_GetLastNotificationId_Call {
+	return &Database_GetLastNotificationId_Call{Call: _e.mock.On("GetLastNotificationId")}
+}
+
+func (_c *Database_GetLastNotificationId_Call) Run(run func()) *Database_GetLastNotificationId_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Database_GetLastNotificationId_Call) Return(_a0 uint) *Database_GetLastNotificationId_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetLastNotificationId_Call) RunAndReturn(run func() uint) *Database_GetLastNotificationId_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLatestBountyProof provides a mock function with given fields: bountyId
+func (_m *Database) GetLatestBountyProof(bountyId uint) (db.BountyProof, error) {
+	ret := _m.Called(bountyId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLatestBountyProof")
+	}
+
+	var r0 db.BountyProof
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint) (db.BountyProof, error)); ok {
+		return rf(bountyId)
+	}
+	if rf, ok := ret.Get(0).(func(uint) db.BountyProof); ok {
+		r0 = rf(bountyId)
+	} else {
+		r0 = ret.Get(0).(db.BountyProof)
+	}
+
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(bountyId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetLatestBountyProof_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLatestBountyProof'
+type Database_GetLatestBountyProof_Call struct {
+	*mock.Call
+}
+
+// GetLatestBountyProof is a helper method to define mock.On call
+//   - bountyId uint
+func (_e *Database_Expecter) GetLatestBountyProof(bountyId interface{}) *Database_GetLatestBountyProof_Call {
+	return &Database_GetLatestBountyProof_Call{Call: _e.mock.On("GetLatestBountyProof", bountyId)}
+}
+
+func (_c *Database_GetLatestBountyProof_Call) Run(run func(bountyId uint)) *Database_GetLatestBountyProof_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *Database_GetLatestBountyProof_Call) Return(_a0 db.BountyProof, _a1 error) *Database_GetLatestBountyProof_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetLatestBountyProof_Call) RunAndReturn(run func(uint) (db.BountyProof, error)) *Database_GetLatestBountyProof_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLeaderBoard provides a mock function with given fields: uuid
+func (_m *Database) GetLeaderBoard(uuid string) []db.LeaderBoard {
+	ret := _m.Called(uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLeaderBoard")
+	}
+
+	var r0 []db.LeaderBoard
+	if rf, ok := ret.Get(0).(func(string) []db.LeaderBoard); ok {
+		r0 = rf(uuid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.LeaderBoard)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetLeaderBoard_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLeaderBoard'
+type Database_GetLeaderBoard_Call struct {
+	*mock.Call
+}
+
+// GetLeaderBoard is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) GetLeaderBoard(uuid interface{}) *Database_GetLeaderBoard_Call {
+	return &Database_GetLeaderBoard_Call{Call: _e.mock.On("GetLeaderBoard", uuid)}
+}
+
+func (_c *Database_GetLeaderBoard_Call) Run(run func(uuid string)) *Database_GetLeaderBoard_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetLeaderBoard_Call) Return(_a0 []db.LeaderBoard) *Database_GetLeaderBoard_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetLeaderBoard_Call) RunAndReturn(run func(string) []db.LeaderBoard) *Database_GetLeaderBoard_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLeaderBoardByUuidAndAlias provides a mock function with given fields: uuid, alias
+func (_m *Database) GetLeaderBoardByUuidAndAlias(uuid string, alias string) db.LeaderBoard {
+	ret := _m.Called(uuid, alias)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLeaderBoardByUuidAndAlias")
+	}
+
+	var r0 db.LeaderBoard
+	if rf, ok := ret.Get(0).(func(string, string) db.LeaderBoard); ok {
+		r0 = rf(uuid, alias)
+	} else {
+		r0 = ret.Get(0).(db.LeaderBoard)
+	}
+
+	return r0
+}
+
+// Database_GetLeaderBoardByUuidAndAlias_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLeaderBoardByUuidAndAlias'
+type Database_GetLeaderBoardByUuidAndAlias_Call struct {
+	*mock.Call
+}
+
+// GetLeaderBoardByUuidAndAlias is a helper method to define mock.On call
+//   - uuid string
+//   - alias string
+func (_e *Database_Expecter) GetLeaderBoardByUuidAndAlias(uuid interface{}, alias interface{}) *Database_GetLeaderBoardByUuidAndAlias_Call {
+	return &Database_GetLeaderBoardByUuidAndAlias_Call{Call: _e.mock.On("GetLeaderBoardByUuidAndAlias", uuid, alias)}
+}
+
+func (_c *Database_GetLeaderBoardByUuidAndAlias_Call) Run(run func(uuid string, alias string)) *Database_GetLeaderBoardByUuidAndAlias_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetLeaderBoardByUuidAndAlias_Call) Return(_a0 db.LeaderBoard) *Database_GetLeaderBoardByUuidAndAlias_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetLeaderBoardByUuidAndAlias_Call) RunAndReturn(run func(string, string) db.LeaderBoard) *Database_GetLeaderBoardByUuidAndAlias_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetListedBots provides a mock function with given fields: r
+func (_m *Database) GetListedBots(r *http.Request) []db.Bot {
+	ret := _m.Called(r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetListedBots")
+	}
+
+	var r0 []db.Bot
+	if rf, ok := ret.Get(0).(func(*http.Request) []db.Bot); ok {
+		r0 = rf(r)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Bot)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetListedBots_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetListedBots'
+type Database_GetListedBots_Call struct {
+	*mock.Call
+}
+
+// GetListedBots is a helper method to define mock.On call
+//   - r *http.Request
+func (_e *Database_Expecter) GetListedBots(r interface{}) *Database_GetListedBots_Call {
+	return &Database_GetListedBots_Call{Call: _e.mock.On("GetListedBots", r)}
+}
+
+func (_c *Database_GetListedBots_Call) Run(run func(r *http.Request)) *Database_GetListedBots_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *Database_GetListedBots_Call) Return(_a0 []db.Bot) *Database_GetListedBots_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetListedBots_Call) RunAndReturn(run func(*http.Request) []db.Bot) *Database_GetListedBots_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetListedOffers provides a mock function with given fields: r
+func (_m *Database) GetListedOffers(r *http.Request) ([]db.PeopleExtra, error) {
+	ret := _m.Called(r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetListedOffers")
+	}
+
+	var r0 []db.PeopleExtra
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*http.Request) ([]db.PeopleExtra, error)); ok {
+		return rf(r)
+	}
+	if rf, ok := ret.Get(0).(func(*http.Request) []db.PeopleExtra); ok {
+		r0 = rf(r)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.PeopleExtra)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*http.Request) error); ok {
+		r1 = rf(r)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetListedOffers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetListedOffers'
+type Database_GetListedOffers_Call struct {
+	*mock.Call
+}
+
+// GetListedOffers is a helper method to define mock.On call
+//   - r *http.Request
+func (_e *Database_Expecter) GetListedOffers(r interface{}) *Database_GetListedOffers_Call {
+	return &Database_GetListedOffers_Call{Call: _e.mock.On("GetListedOffers", r)}
+}
+
+func (_c *Database_GetListedOffers_Call) Run(run func(r *http.Request)) *Database_GetListedOffers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *Database_GetListedOffers_Call) Return(_a0 []db.PeopleExtra, _a1 error) *Database_GetListedOffers_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetListedOffers_Call) RunAndReturn(run func(*http.Request) ([]db.PeopleExtra, error)) *Database_GetListedOffers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetListedPeople provides a mock function with given fields: r
+func (_m *Database) GetListedPeople(r *http.Request) []db.Person {
+	ret := _m.Called(r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetListedPeople")
+	}
+
+	var r0 []db.Person
+	if rf, ok := ret.Get(0).(func(*http.Request) []db.Person); ok {
+		r0 = rf(r)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Person)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetListedPeople_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetListedPeople'
+type Database_GetListedPeople_Call struct {
+	*mock.Call
+}
+
+// GetListedPeople is a helper method to define mock.On call
+//   - r *http.Request
+func (_e *Database_Expecter) GetListedPeople(r interface{}) *Database_GetListedPeople_Call {
+	return &Database_GetListedPeople_Call{Call: _e.mock.On("GetListedPeople", r)}
+}
+
+func (_c *Database_GetListedPeople_Call) Run(run func(r *http.Request)) *Database_GetListedPeople_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *Database_GetListedPeople_Call) Return(_a0 []db.Person) *Database_GetListedPeople_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetListedPeople_Call) RunAndReturn(run func(*http.Request) []db.Person) *Database_GetListedPeople_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetListedPeopleCount provides a mock function with given fields: r
+func (_m *Database) GetListedPeopleCount(r *http.Request) int64 {
+	ret := _m.Called(r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetListedPeopleCount")
+	}
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(*http.Request) int64); ok {
+		r0 = rf(r)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	return r0
+}
+
+// Database_GetListedPeopleCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetListedPeopleCount'
+type Database_GetListedPeopleCount_Call struct {
+	*mock.Call
+}
+
+// GetListedPeopleCount is a helper method to define mock.On call
+//   - r *http.Request
+func (_e *Database_Expecter) GetListedPeopleCount(r interface{}) *Database_GetListedPeopleCount_Call {
+	return &Database_GetListedPeopleCount_Call{Call: _e.mock.On("GetListedPeopleCount", r)}
+}
+
+func (_c *Database_GetListedPeopleCount_Call) Run(run func(r *http.Request)) *Database_GetListedPeopleCount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *Database_GetListedPeopleCount_Call) Return(_a0 int64) *Database_GetListedPeopleCount_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetListedPeopleCount_Call) RunAndReturn(run func(*http.Request) int64) *Database_GetListedPeopleCount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetListedPosts provides a mock function with given fields: r
+func (_m *Database) GetListedPosts(r *http.Request) ([]db.PeopleExtra, error) {
+	ret := _m.Called(r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetListedPosts")
+	}
+
+	var r0 []db.PeopleExtra
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*http.Request) ([]db.PeopleExtra, error)); ok {
+		return rf(r)
+	}
+	if rf, ok := ret.Get(0).(func(*http.Request) []db.PeopleExtra); ok {
+		r0 = rf(r)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.PeopleExtra)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*http.Request) error); ok {
+		r1 = rf(r)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetListedPosts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetListedPosts'
+type Database_GetListedPosts_Call struct {
+	*mock.Call
+}
+
+// GetListedPosts is a helper method to define mock.On call
+//   - r *http.Request
+func (_e *Database_Expecter) GetListedPosts(r interface{}) *Database_GetListedPosts_Call {
+	return &Database_GetListedPosts_Call{Call: _e.mock.On("GetListedPosts", r)}
+}
+
+func (_c *Database_GetListedPosts_Call) Run(run func(r *http.Request)) *Database_GetListedPosts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *Database_GetListedPosts_Call) Return(_a0 []db.PeopleExtra, _a1 error) *Database_GetListedPosts_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetListedPosts_Call) RunAndReturn(run func(*http.Request) ([]db.PeopleExtra, error)) *Database_GetListedPosts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetListedTribes provides a mock function with given fields: r
+func (_m *Database) GetListedTribes(r *http.Request) []db.Tribe {
+	ret := _m.Called(r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetListedTribes")
+	}
+
+	var r0 []db.Tribe
+	if rf, ok := ret.Get(0).(func(*http.Request) []db.Tribe); ok {
+		r0 = rf(r)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Tribe)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetListedTribes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetListedTribes'
+type Database_GetListedTribes_Call struct {
+	*mock.Call
+}
+
+// GetListedTribes is a helper method to define mock.On call
+//   - r *http.Request
+func (_e *Database_Expecter) GetListedTribes(r interface{}) *Database_GetListedTribes_Call {
+	return &Database_GetListedTribes_Call{Call: _e.mock.On("GetListedTribes", r)}
+}
+
+func (_c *Database_GetListedTribes_Call) Run(run func(r *http.Request)) *Database_GetListedTribes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *Database_GetListedTribes_Call) Return(_a0 []db.Tribe) *Database_GetListedTribes_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetListedTribes_Call) RunAndReturn(run func(*http.Request) []db.Tribe) *Database_GetListedTribes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetListedTribesCount provides a mock function with given fields: r
+func (_m *Database) GetListedTribesCount(r *http.Request) int64 {
+	ret := _m.Called(r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetListedTribesCount")
+	}
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(*http.Request) int64); ok {
+		r0 = rf(r)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	return r0
+}
+
+// Database_GetListedTribesCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetListedTribesCount'
+type Database_GetListedTribesCount_Call struct {
+	*mock.Call
+}
+
+// GetListedTribesCount is a helper method to define mock.On call
+//   - r *http.Request
+func (_e *Database_Expecter) GetListedTribesCount(r interface{}) *Database_GetListedTribesCount_Call {
+	return &Database_GetListedTribesCount_Call{Call: _e.mock.On("GetListedTribesCount", r)}
+}
+
+func (_c *Database_GetListedTribesCount_Call) Run(run func(r *http.Request)) *Database_GetListedTribesCount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *Database_GetListedTribesCount_Call) Return(_a0 int64) *Database_GetListedTribesCount_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetListedTribesCount_Call) RunAndReturn(run func(*http.Request) int64) *Database_GetListedTribesCount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetListedTribesPaginated provides a mock function with given fields: r, limit, after
+func (_m *Database) GetListedTribesPaginated(r *http.Request, limit int, after string) ([]db.Tribe, string, error) {
+	ret := _m.Called(r, limit, after)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetListedTribesPaginated")
+	}
+
+	var r0 []db.Tribe
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(*http.Request, int, string) ([]db.Tribe, string, error)); ok {
+		return rf(r, limit, after)
+	}
+	if rf, ok := ret.Get(0).(func(*http.Request, int, string) []db.Tribe); ok {
+		r0 = rf(r, limit, after)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Tribe)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*http.Request, int, string) string); ok {
+		r1 = rf(r, limit, after)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(*http.Request, int, string) error); ok {
+		r2 = rf(r, limit, after)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Database_GetListedTribesPaginated_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetListedTribesPaginated'
+type Database_GetListedTribesPaginated_Call struct {
+	*mock.Call
+}
+
+// GetListedTribesPaginated is a helper method to define mock.On call
+//   - r *http.Request
+//   - limit int
+//   - after string
+func (_e *Database_Expecter) GetListedTribesPaginated(r interface{}, limit interface{}, after interface{}) *Database_GetListedTribesPaginated_Call {
+	return &Database_GetListedTribesPaginated_Call{Call: _e.mock.On("GetListedTribesPaginated", r, limit, after)}
+}
+
+func (_c *Database_GetListedTribesPaginated_Call) Run(run func(r *http.Request, limit int, after string)) *Database_GetListedTribesPaginated_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*http.Request), args[1].(int), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetListedTribesPaginated_Call) Return(_a0 []db.Tribe, _a1 string, _a2 error) *Database_GetListedTribesPaginated_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *Database_GetListedTribesPaginated_Call) RunAndReturn(run func(*http.Request, int, string) ([]db.Tribe, string, error)) *Database_GetListedTribesPaginated_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLnUser provides a mock function with given fields: lnKey
+func (_m *Database) GetLnUser(lnKey string) int64 {
+	ret := _m.Called(lnKey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLnUser")
+	}
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(string) int64); ok {
+		r0 = rf(lnKey)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	return r0
+}
+
+// Database_GetLnUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLnUser'
+type Database_GetLnUser_Call struct {
+	*mock.Call
+}
+
+// GetLnUser is a helper method to define mock.On call
+//   - lnKey string
+func (_e *Database_Expecter) GetLnUser(lnKey interface{}) *Database_GetLnUser_Call {
+	return &Database_GetLnUser_Call{Call: _e.mock.On("GetLnUser", lnKey)}
+}
+
+func (_c *Database_GetLnUser_Call) Run(run func(lnKey string)) *Database_GetLnUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetLnUser_Call) Return(_a0 int64) *Database_GetLnUser_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetLnUser_Call) RunAndReturn(run func(string) int64) *Database_GetLnUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetMaintenance provides a mock function with given fields:
+func (_m *Database) GetMaintenance() (db.Maintenance, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMaintenance")
+	}
+
+	var r0 db.Maintenance
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (db.Maintenance, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() db.Maintenance); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(db.Maintenance)
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetMaintenance_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMaintenance'
+type Database_GetMaintenance_Call struct {
+	*mock.Call
+}
+
+// GetMaintenance is a helper method to define mock.On call
+func (_e *Database_Expecter) GetMaintenance() *Database_GetMaintenance_Call {
+	return &Database_GetMaintenance_Call{Call: _e.mock.On("GetMaintenance")}
+}
+
+func (_c *Database_GetMaintenance_Call) Run(run func()) *Database_GetMaintenance_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Database_GetMaintenance_Call) Return(_a0 db.Maintenance, _a1 error) *Database_GetMaintenance_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetMaintenance_Call) RunAndReturn(run func() (db.Maintenance, error)) *Database_GetMaintenance_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetMentions provides a mock function with given fields: sourceType, sourceID
+func (_m *Database) GetMentions(sourceType string, sourceID string) []db.Mention {
+	ret := _m.Called(sourceType, sourceID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMentions")
+	}
+
+	var r0 []db.Mention
+	if rf, ok := ret.Get(0).(func(string, string) []db.Mention); ok {
+		r0 = rf(sourceType, sourceID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Mention)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetMentions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMentions'
+type Database_GetMentions_Call struct {
+	*mock.Call
+}
+
+// GetMentions is a helper method to define mock.On call
+//   - sourceType string
+//   - sourceID string
+func (_e *Database_Expecter) GetMentions(sourceType interface{}, sourceID interface{}) *Database_GetMentions_Call {
+	return &Database_GetMentions_Call{Call: _e.mock.On("GetMentions", sourceType, sourceID)}
+}
+
+func (_c *Database_GetMentions_Call) Run(run func(sourceType string, sourceID string)) *Database_GetMentions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetMentions_Call) Return(_a0 []db.Mention) *Database_GetMentions_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetMentions_Call) RunAndReturn(run func(string, string) []db.Mention) *Database_GetMentions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetMentionsOf provides a mock function with given fields: targetType, targetID
+func (_m *Database) GetMentionsOf(targetType string, targetID string) []db.Mention {
+	ret := _m.Called(targetType, targetID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMentionsOf")
+	}
+
+	var r0 []db.Mention
+	if rf, ok := ret.Get(0).(func(string, string) []db.Mention); ok {
+		r0 = rf(targetType, targetID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Mention)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetMentionsOf_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMentionsOf'
+type Database_GetMentionsOf_Call struct {
+	*mock.Call
+}
+
+// GetMentionsOf is a helper method to define mock.On call
+//   - targetType string
+//   - targetID string
+func (_e *Database_Expecter) GetMentionsOf(targetType interface{}, targetID interface{}) *Database_GetMentionsOf_Call {
+	return &Database_GetMentionsOf_Call{Call: _e.mock.On("GetMentionsOf", targetType, targetID)}
+}
+
+func (_c *Database_GetMentionsOf_Call) Run(run func(targetType string, targetID string)) *Database_GetMentionsOf_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetMentionsOf_Call) Return(_a0 []db.Mention) *Database_GetMentionsOf_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetMentionsOf_Call) RunAndReturn(run func(string, string) []db.Mention) *Database_GetMentionsOf_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetNextBountyByCreated provides a mock function with given fields: r
+func (_m *Database) GetNextBountyByCreated(r *http.Request) (uint, error) {
+	ret := _m.Called(r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNextBountyByCreated")
+	}
+
+	var r0 uint
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*http.Request) (uint, error)); ok {
+		return rf(r)
+	}
+	if rf, ok := ret.Get(0).(func(*http.Request) uint); ok {
+		r0 = rf(r)
+	} else {
+		r0 = ret.Get(0).(uint)
+	}
+
+	if rf, ok := ret.Get(1).(func(*http.Request) error); ok {
+		r1 = rf(r)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetNextBountyByCreated_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetNextBountyByCreated'
+type Database_GetNextBountyByCreated_Call struct {
+	*mock.Call
+}
+
+// GetNextBountyByCreated is a helper method to define mock.On call
+//   - r *http.Request
+func (_e *Database_Expecter) GetNextBountyByCreated(r interface{}) *Database_GetNextBountyByCreated_Call {
+	return &Database_GetNextBountyByCreated_Call{Call: _e.mock.On("GetNextBountyByCreated", r)}
+}
+
+func (_c *Database_GetNextBountyByCreated_Call) Run(run func(r *http.Request)) *Database_GetNextBountyByCreated_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *Database_GetNextBountyByCreated_Call) Return(_a0 uint, _a1 error) *Database_GetNextBountyByCreated_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetNextBountyByCreated_Call) RunAndReturn(run func(*http.Request) (uint, error)) *Database_GetNextBountyByCreated_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetNextWorkspaceBountyByCreated provides a mock function with given fields: r
+func (_m *Database) GetNextWorkspaceBountyByCreated(r *http.Request) (uint, error) {
+	ret := _m.Called(r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNextWorkspaceBountyByCreated")
+	}
+
+	var r0 uint
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*http.Request) (uint, error)); ok {
+		return rf(r)
+	}
+	if rf, ok := ret.Get(0).(func(*http.Request) uint); ok {
+		r0 = rf(r)
+	} else {
+		r0 = ret.Get(0).(uint)
+	}
+
+	if rf, ok := ret.Get(1).(func(*http.Request) error); ok {
+		r1 = rf(r)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetNextWorkspaceBountyByCreated_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetNextWorkspaceBountyByCreated'
+type Database_GetNextWorkspaceBountyByCreated_Call struct {
+	*mock.Call
+}
+
+// GetNextWorkspaceBountyByCreated is a helper method to define mock.On call
+//   - r *http.Request
+func (_e *Database_Expecter) GetNextWorkspaceBountyByCreated(r interface{}) *Database_GetNextWorkspaceBountyByCreated_Call {
+	return &Database_GetNextWorkspaceBountyByCreated_Call{Call: _e.mock.On("GetNextWorkspaceBountyByCreated", r)}
+}
+
+func (_c *Database_GetNextWorkspaceBountyByCreated_Call) Run(run func(r *http.Request)) *Database_GetNextWorkspaceBountyByCreated_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *Database_GetNextWorkspaceBountyByCreated_Call) Return(_a0 uint, _a1 error) *Database_GetNextWorkspaceBountyByCreated_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetNextWorkspaceBountyByCreated_Call) RunAndReturn(run func(*http.Request) (uint, error)) *Database_GetNextWorkspaceBountyByCreated_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetNotifications provides a mock function with given fields: pubkey, r
+func (_m *Database) GetNotifications(pubkey string, r *http.Request) ([]db.Notification, error) {
+	ret := _m.Called(pubkey, r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNotifications")
+	}
+
+	var r0 []db.Notification
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, *http.Request) ([]db.Notification, error)); ok {
+		return rf(pubkey, r)
+	}
+	if rf, ok := ret.Get(0).(func(string, *http.Request) []db.Notification); ok {
+		r0 = rf(pubkey, r)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Notification)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, *http.Request) error); ok {
+		r1 = rf(pubkey, r)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetNotifications_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetNotifications'
+type Database_GetNotifications_Call struct {
+	*mock.Call
+}
+
+// GetNotifications is a helper method to define mock.On call
+//   - pubkey string
+//   - r *http.Request
+func (_e *Database_Expecter) GetNotifications(pubkey interface{}, r interface{}) *Database_GetNotifications_Call {
+	return &Database_GetNotifications_Call{Call: _e.mock.On("GetNotifications", pubkey, r)}
+}
+
+func (_c *Database_GetNotifications_Call) Run(run func(pubkey string, r *http.Request)) *Database_GetNotifications_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *Database_GetNotifications_Call) Return(_a0 []db.Notification, _a1 error) *Database_GetNotifications_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetNotifications_Call) RunAndReturn(run func(string, *http.Request) ([]db.Notification, error)) *Database_GetNotifications_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetNotificationsBetween provides a mock function with given fields: pubkey, after, upTo, limit
+func (_m *Database) GetNotificationsBetween(pubkey string, after uint, upTo uint, limit int) ([]db.Notification, error) {
+	ret := _m.Called(pubkey, after, upTo, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNotificationsBetween")
+	}
+
+	var r0 []db.Notification
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, uint, uint, int) ([]db.Notification, error)); ok {
+		return rf(pubkey, after, upTo, limit)
+	}
+	if rf, ok := ret.Get(0).(func(string, uint, uint, int) []db.Notification); ok {
+		r0 = rf(pubkey, after, upTo, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Notification)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, uint, uint, int) error); ok {
+		r1 = rf(pubkey, after, upTo, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetNotificationsBetween_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetNotificationsBetween'
+type Database_GetNotificationsBetween_Call struct {
+	*mock.Call
+}
+
+// GetNotificationsBetween is a helper method to define mock.On call
+//   - pubkey string
+//   - after uint
+//   - upTo uint
+//   - limit int
+func (_e *Database_Expecter) GetNotificationsBetween(pubkey interface{}, after interface{}, upTo interface{}, limit interface{}) *Database_GetNotificationsBetween_Call {
+	return &Database_GetNotificationsBetween_Call{Call: _e.mock.On("GetNotificationsBetween", pubkey, after, upTo, limit)}
+}
+
+func (_c *Database_GetNotificationsBetween_Call) Run(run func(pubkey string, after uint, upTo uint, limit int)) *Database_GetNotificationsBetween_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(uint), args[2].(uint), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *Database_GetNotificationsBetween_Call) Return(_a0 []db.Notification, _a1 error) *Database_GetNotificationsBetween_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetNotificationsBetween_Call) RunAndReturn(run func(string, uint, uint, int) ([]db.Notification, error)) *Database_GetNotificationsBetween_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetNotificationsCount provides a mock function with given fields: pubkey, unread
+func (_m *Database) GetNotificationsCount(pubkey string, unread bool) int64 {
+	ret := _m.Called(pubkey, unread)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNotificationsCount")
+	}
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(string, bool) int64); ok {
+		r0 = rf(pubkey, unread)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	return r0
+}
+
+// Database_GetNotificationsCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetNotificationsCount'
+type Database_GetNotificationsCount_Call struct {
+	*mock.Call
+}
+
+// GetNotificationsCount is a helper method to define mock.On call
+//   - pubkey string
+//   - unread bool
+func (_e *Database_Expecter) GetNotificationsCount(pubkey interface{}, unread interface{}) *Database_GetNotificationsCount_Call {
+	return &Database_GetNotificationsCount_Call{Call: _e.mock.On("GetNotificationsCount", pubkey, unread)}
+}
+
+func (_c *Database_GetNotificationsCount_Call) Run(run func(pubkey string, unread bool)) *Database_GetNotificationsCount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(bool))
+	})
+	return _c
+}
+
+func (_c *Database_GetNotificationsCount_Call) Return(_a0 int64) *Database_GetNotificationsCount_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetNotificationsCount_Call) RunAndReturn(run func(string, bool) int64) *Database_GetNotificationsCount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetOpenGithubIssues provides a mock function with given fields: r
+func (_m *Database) GetOpenGithubIssues(r *http.Request) (int64, error) {
+	ret := _m.Called(r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOpenGithubIssues")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*http.Request) (int64, error)); ok {
+		return rf(r)
+	}
+	if rf, ok := ret.Get(0).(func(*http.Request) int64); ok {
+		r0 = rf(r)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(*http.Request) error); ok {
+		r1 = rf(r)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetOpenGithubIssues_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOpenGithubIssues'
+type Database_GetOpenGithubIssues_Call struct {
+	*mock.Call
+}
+
+// GetOpenGithubIssues is a helper method to define mock.On call
+//   - r *http.Request
+func (_e *Database_Expecter) GetOpenGithubIssues(r interface{}) *Database_GetOpenGithubIssues_Call {
+	return &Database_GetOpenGithubIssues_Call{Call: _e.mock.On("GetOpenGithubIssues", r)}
+}
+
+func (_c *Database_GetOpenGithubIssues_Call) Run(run func(r *http.Request)) *Database_GetOpenGithubIssues_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *Database_GetOpenGithubIssues_Call) Return(_a0 int64, _a1 error) *Database_GetOpenGithubIssues_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetOpenGithubIssues_Call) RunAndReturn(run func(*http.Request) (int64, error)) *Database_GetOpenGithubIssues_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetOpenWorkspaceBounties provides a mock function with given fields: workspaceUuid, limit
+func (_m *Database) GetOpenWorkspaceBounties(workspaceUuid string, limit int) []db.NewBounty {
+	ret := _m.Called(workspaceUuid, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOpenWorkspaceBounties")
+	}
+
+	var r0 []db.NewBounty
+	if rf, ok := ret.Get(0).(func(string, int) []db.NewBounty); ok {
+		r0 = rf(workspaceUuid, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.NewBounty)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetOpenWorkspaceBounties_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOpenWorkspaceBounties'
+type Database_GetOpenWorkspaceBounties_Call struct {
+	*mock.Call
+}
+
+// GetOpenWorkspaceBounties is a helper method to define mock.On call
+//   - workspaceUuid string
+//   - limit int
+func (_e *Database_Expecter) GetOpenWorkspaceBounties(workspaceUuid interface{}, limit interface{}) *Database_GetOpenWorkspaceBounties_Call {
+	return &Database_GetOpenWorkspaceBounties_Call{Call: _e.mock.On("GetOpenWorkspaceBounties", workspaceUuid, limit)}
+}
+
+func (_c *Database_GetOpenWorkspaceBounties_Call) Run(run func(workspaceUuid string, limit int)) *Database_GetOpenWorkspaceBounties_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *Database_GetOpenWorkspaceBounties_Call) Return(_a0 []db.NewBounty) *Database_GetOpenWorkspaceBounties_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetOpenWorkspaceBounties_Call) RunAndReturn(run func(string, int) []db.NewBounty) *Database_GetOpenWorkspaceBounties_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPaymentHistory provides a mock function with given fields: workspace_uuid, r
+func (_m *Database) GetPaymentHistory(workspace_uuid string, r *http.Request) []db.NewPaymentHistory {
+	ret := _m.Called(workspace_uuid, r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPaymentHistory")
+	}
+
+	var r0 []db.NewPaymentHistory
+	if rf, ok := ret.Get(0).(func(string, *http.Request) []db.NewPaymentHistory); ok {
+		r0 = rf(workspace_uuid, r)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.NewPaymentHistory)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetPaymentHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPaymentHistory'
+type Database_GetPaymentHistory_Call struct {
+	*mock.Call
+}
+
+// GetPaymentHistory is a helper method to define mock.On call
+//   - workspace_uuid string
+//   - r *http.Request
+func (_e *Database_Expecter) GetPaymentHistory(workspace_uuid interface{}, r interface{}) *Database_GetPaymentHistory_Call {
+	return &Database_GetPaymentHistory_Call{Call: _e.mock.On("GetPaymentHistory", workspace_uuid, r)}
+}
+
+func (_c *Database_GetPaymentHistory_Call) Run(run func(workspace_uuid string, r *http.Request)) *Database_GetPaymentHistory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *Database_GetPaymentHistory_Call) Return(_a0 []db.NewPaymentHistory) *Database_GetPaymentHistory_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetPaymentHistory_Call) RunAndReturn(run func(string, *http.Request) []db.NewPaymentHistory) *Database_GetPaymentHistory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPaymentHistoryByCreated provides a mock function with given fields: created, workspace_uuid
+func (_m *Database) GetPaymentHistoryByCreated(created *time.Time, workspace_uuid string) db.NewPaymentHistory {
+	ret := _m.Called(created, workspace_uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPaymentHistoryByCreated")
+	}
+
+	var r0 db.NewPaymentHistory
+	if rf, ok := ret.Get(0).(func(*time.Time, string) db.NewPaymentHistory); ok {
+		r0 = rf(created, workspace_uuid)
+	} else {
+		r0 = ret.Get(0).(db.NewPaymentHistory)
+	}
+
+	return r0
+}
+
+// Database_GetPaymentHistoryByCreated_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPaymentHistoryByCreated'
+type Database_GetPaymentHistoryByCreated_Call struct {
+	*mock.Call
+}
+
+// GetPaymentHistoryByCreated is a helper method to define mock.On call
+//   - created *time.Time
+//   - workspace_uuid string
+func (_e *Database_Expecter) GetPaymentHistoryByCreated(created interface{}, workspace_uuid interface{}) *Database_GetPaymentHistoryByCreated_Call {
+	return &Database_GetPaymentHistoryByCreated_Call{Call: _e.mock.On("GetPaymentHistoryByCreated", created, workspace_uuid)}
+}
+
+func (_c *Database_GetPaymentHistoryByCreated_Call) Run(run func(created *time.Time, workspace_uuid string)) *Database_GetPaymentHistoryByCreated_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*time.Time), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetPaymentHistoryByCreated_Call) Return(_a0 db.NewPaymentHistory) *Database_GetPaymentHistoryByCreated_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetPaymentHistoryByCreated_Call) RunAndReturn(run func(*time.Time, string) db.NewPaymentHistory) *Database_GetPaymentHistoryByCreated_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPendingPayments provides a mock function with given fields:
+func (_m *Database) GetPendingPayments() []db.NewPaymentHistory {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPendingPayments")
+	}
+
+	var r0 []db.NewPaymentHistory
+	if rf, ok := ret.Get(0).(func() []db.NewPaymentHistory); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.NewPaymentHistory)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetPendingPayments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPendingPayments'
+type Database_GetPendingPayments_Call struct {
+	*mock.Call
+}
+
+// GetPendingPayments is a helper method to define mock.On call
+func (_e *Database_Expecter) GetPendingPayments() *Database_GetPendingPayments_Call {
+	return &Database_GetPendingPayments_Call{Call: _e.mock.On("GetPendingPayments")}
+}
+
+func (_c *Database_GetPendingPayments_Call) Run(run func()) *Database_GetPendingPayments_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Database_GetPendingPayments_Call) Return(_a0 []db.NewPaymentHistory) *Database_GetPendingPayments_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetPendingPayments_Call) RunAndReturn(run func() []db.NewPaymentHistory) *Database_GetPendingPayments_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPendingReport provides a mock function with given fields: targetType, targetUuid, reporter
+func (_m *Database) GetPendingReport(targetType string, targetUuid string, reporter string) (db.Report, error) {
+	ret := _m.Called(targetType, targetUuid, reporter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPendingReport")
+	}
+
+	var r0 db.Report
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, string) (db.Report, error)); ok {
+		return rf(targetType, targetUuid, reporter)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, string) db.Report); ok {
+		r0 = rf(targetType, targetUuid, reporter)
+	} else {
+		r0 = ret.Get(0).(db.Report)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, string) error); ok {
+		r1 = rf(targetType, targetUuid, reporter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetPendingReport_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPendingReport'
+type Database_GetPendingReport_Call struct {
+	*mock.Call
+}
+
+// GetPendingReport is a helper method to define mock.On call
+//   - targetType string
+//   - targetUuid string
+//   - reporter string
+func (_e *Database_Expecter) GetPendingReport(targetType interface{}, targetUuid interface{}, reporter interface{}) *Database_GetPendingReport_Call {
+	return &Database_GetPendingReport_Call{Call: _e.mock.On("GetPendingReport", targetType, targetUuid, reporter)}
+}
+
+func (_c *Database_GetPendingReport_Call) Run(run func(targetType string, targetUuid string, reporter string)) *Database_GetPendingReport_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetPendingReport_Call) Return(_a0 db.Report, _a1 error) *Database_GetPendingReport_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetPendingReport_Call) RunAndReturn(run func(string, string, string) (db.Report, error)) *Database_GetPendingReport_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPendingReserveAmount provides a mock function with given fields: workspaceUuid
+func (_m *Database) GetPendingReserveAmount(workspaceUuid string) uint {
+	ret := _m.Called(workspaceUuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPendingReserveAmount")
+	}
+
+	var r0 uint
+	if rf, ok := ret.Get(0).(func(string) uint); ok {
+		r0 = rf(workspaceUuid)
+	} else {
+		r0 = ret.Get(0).(uint)
+	}
+
+	return r0
+}
+
+// Database_GetPendingReserveAmount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPendingReserveAmount'
+type Database_GetPendingReserveAmount_Call struct {
+	*mock.Call
+}
+
+// GetPendingReserveAmount is a helper method to define mock.On call
+//   - workspaceUuid string
+func (_e *Database_Expecter) GetPendingReserveAmount(workspaceUuid interface{}) *Database_GetPendingReserveAmount_Call {
+	return &Database_GetPendingReserveAmount_Call{Call: _e.mock.On("GetPendingReserveAmount", workspaceUuid)}
+}
+
+func (_c *Database_GetPendingReserveAmount_Call) Run(run func(workspaceUuid string)) *Database_GetPendingReserveAmount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetPendingReserveAmount_Call) Return(_a0 uint) *Database_GetPendingReserveAmount_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetPendingReserveAmount_Call) RunAndReturn(run func(string) uint) *Database_GetPendingReserveAmount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPendingTribeAppeal provides a mock function with given fields: tribeUuid
+func (_m *Database) GetPendingTribeAppeal(tribeUuid string) (db.TribeAppeal, error) {
+	ret := _m.Called(tribeUuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPendingTribeAppeal")
+	}
+
+	var r0 db.TribeAppeal
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (db.TribeAppeal, error)); ok {
+		return rf(tribeUuid)
+	}
+	if rf, ok := ret.Get(0).(func(string) db.TribeAppeal); ok {
+		r0 = rf(tribeUuid)
+	} else {
+		r0 = ret.Get(0).(db.TribeAppeal)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(tribeUuid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetPendingTribeAppeal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPendingTribeAppeal'
+type Database_GetPendingTribeAppeal_Call struct {
+	*mock.Call
+}
+
+// GetPendingTribeAppeal is a helper method to define mock.On call
+//   - tribeUuid string
+func (_e *Database_Expecter) GetPendingTribeAppeal(tribeUuid interface{}) *Database_GetPendingTribeAppeal_Call {
+	return &Database_GetPendingTribeAppeal_Call{Call: _e.mock.On("GetPendingTribeAppeal", tribeUuid)}
+}
+
+func (_c *Database_GetPendingTribeAppeal_Call) Run(run func(tribeUuid string)) *Database_GetPendingTribeAppeal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetPendingTribeAppeal_Call) Return(_a0 db.TribeAppeal, _a1 error) *Database_GetPendingTribeAppeal_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetPendingTribeAppeal_Call) RunAndReturn(run func(string) (db.TribeAppeal, error)) *Database_GetPendingTribeAppeal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPeopleByPubkeys provides a mock function with given fields: pubkeys
+func (_m *Database) GetPeopleByPubkeys(pubkeys []string) []db.Person {
+	ret := _m.Called(pubkeys)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPeopleByPubkeys")
+	}
+
+	var r0 []db.Person
+	if rf, ok := ret.Get(0).(func([]string) []db.Person); ok {
+		r0 = rf(pubkeys)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Person)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetPeopleByPubkeys_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPeopleByPubkeys'
+type Database_GetPeopleByPubkeys_Call struct {
+	*mock.Call
+}
+
+// GetPeopleByPubkeys is a helper method to define mock.On call
+//   - pubkeys []string
+func (_e *Database_Expecter) GetPeopleByPubkeys(pubkeys interface{}) *Database_GetPeopleByPubkeys_Call {
+	return &Database_GetPeopleByPubkeys_Call{Call: _e.mock.On("GetPeopleByPubkeys", pubkeys)}
+}
+
+func (_c *Database_GetPeopleByPubkeys_Call) Run(run func(pubkeys []string)) *Database_GetPeopleByPubkeys_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]string))
+	})
+	return _c
+}
+
+func (_c *Database_GetPeopleByPubkeys_Call) Return(_a0 []db.Person) *Database_GetPeopleByPubkeys_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetPeopleByPubkeys_Call) RunAndReturn(run func([]string) []db.Person) *Database_GetPeopleByPubkeys_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPeopleBySearch provides a mock function with given fields: r
+func (_m *Database) GetPeopleBySearch(r *http.Request) []db.Person {
+	ret := _m.Called(r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPeopleBySearch")
+	}
+
+	var r0 []db.Person
+	if rf, ok := ret.Get(0).(func(*http.Request) []db.Person); ok {
+		r0 = rf(r)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Person)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetPeopleBySearch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPeopleBySearch'
+type Database_GetPeopleBySearch_Call struct {
+	*mock.Call
+}
+
+// GetPeopleBySearch is a helper method to define mock.On call
+//   - r *http.Request
+func (_e *Database_Expecter) GetPeopleBySearch(r interface{}) *Database_GetPeopleBySearch_Call {
+	return &Database_GetPeopleBySearch_Call{Call: _e.mock.On("GetPeopleBySearch", r)}
+}
+
+func (_c *Database_GetPeopleBySearch_Call) Run(run func(r *http.Request)) *Database_GetPeopleBySearch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *Database_GetPeopleBySearch_Call) Return(_a0 []db.Person) *Database_GetPeopleBySearch_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetPeopleBySearch_Call) RunAndReturn(run func(*http.Request) []db.Person) *Database_GetPeopleBySearch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPeopleListShort provides a mock function with given fields: count
+func (_m *Database) GetPeopleListShort(count uint32) *[]db.PersonInShort {
+	ret := _m.Called(count)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPeopleListShort")
+	}
+
+	var r0 *[]db.PersonInShort
+	if rf, ok := ret.Get(0).(func(uint32) *[]db.PersonInShort); ok {
+		r0 = rf(count)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]db.PersonInShort)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetPeopleListShort_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPeopleListShort'
+type Database_GetPeopleListShort_Call struct {
+	*mock.Call
+}
+
+// GetPeopleListShort is a helper method to define mock.On call
+//   - count uint32
+func (_e *Database_Expecter) GetPeopleListShort(count interface{}) *Database_GetPeopleListShort_Call {
+	return &Database_GetPeopleListShort_Call{Call: _e.mock.On("GetPeopleListShort", count)}
+}
+
+func (_c *Database_GetPeopleListShort_Call) Run(run func(count uint32)) *Database_GetPeopleListShort_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint32))
+	})
+	return _c
+}
+
+func (_c *Database_GetPeopleListShort_Call) Return(_a0 *[]db.PersonInShort) *Database_GetPeopleListShort_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetPeopleListShort_Call) RunAndReturn(run func(uint32) *[]db.PersonInShort) *Database_GetPeopleListShort_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPerson provides a mock function with given fields: id
+func (_m *Database) GetPerson(id uint) db.Person {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPerson")
+	}
+
+	var r0 db.Person
+	if rf, ok := ret.Get(0).(func(uint) db.Person); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Get(0).(db.Person)
+	}
+
+	return r0
+}
+
+// Database_GetPerson_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPerson'
+type Database_GetPerson_Call struct {
+	*mock.Call
+}
+
+// GetPerson is a helper method to define mock.On call
+//   - id uint
+func (_e *Database_Expecter) GetPerson(id interface{}) *Database_GetPerson_Call {
+	return &Database_GetPerson_Call{Call: _e.mock.On("GetPerson", id)}
+}
+
+func (_c *Database_GetPerson_Call) Run(run func(id uint)) *Database_GetPerson_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *Database_GetPerson_Call) Return(_a0 db.Person) *Database_GetPerson_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetPerson_Call) RunAndReturn(run func(uint) db.Person) *Database_GetPerson_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPersonByGithubName provides a mock function with given fields: github_name
+func (_m *Database) GetPersonByGithubName(github_name string) db.Person {
+	ret := _m.Called(github_name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPersonByGithubName")
+	}
+
+	var r0 db.Person
+	if rf, ok := ret.Get(0).(func(string) db.Person); ok {
+		r0 = rf(github_name)
+	} else {
+		r0 = ret.Get(0).(db.Person)
+	}
+
+	return r0
+}
+
+// Database_GetPersonByGithubName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPersonByGithubName'
+type Database_GetPersonByGithubName_Call struct {
+	*mock.Call
+}
+
+// GetPersonByGithubName is a helper method to define mock.On call
+//   - github_name string
+func (_e *Database_Expecter) GetPersonByGithubName(github_name interface{}) *Database_GetPersonByGithubName_Call {
+	return &Database_GetPersonByGithubName_Call{Call: _e.mock.On("GetPersonByGithubName", github_name)}
+}
+
+func (_c *Database_GetPersonByGithubName_Call) Run(run func(github_name string)) *Database_GetPersonByGithubName_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetPersonByGithubName_Call) Return(_a0 db.Person) *Database_GetPersonByGithubName_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetPersonByGithubName_Call) RunAndReturn(run func(string) db.Person) *Database_GetPersonByGithubName_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPersonByPubkey provides a mock function with given fields: pubkey
+func (_m *Database) GetPersonByPubkey(pubkey string) db.Person {
+	ret := _m.Called(pubkey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPersonByPubkey")
+	}
+
+	var r0 db.Person
+	if rf, ok := ret.Get(0).(func(string) db.Person); ok {
+		r0 = rf(pubkey)
+	} else {
+		r0 = ret.Get(0).(db.Person)
+	}
+
+	return r0
+}
+
+// Database_GetPersonByPubkey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPersonByPubkey'
+type Database_GetPersonByPubkey_Call struct {
+	*mock.Call
+}
+
+// GetPersonByPubkey is a helper method to define mock.On call
+//   - pubkey string
+func (_e *Database_Expecter) GetPersonByPubkey(pubkey interface{}) *Database_GetPersonByPubkey_Call {
+	return &Database_GetPersonByPubkey_Call{Call: _e.mock.On("GetPersonByPubkey", pubkey)}
+}
+
+func (_c *Database_GetPersonByPubkey_Call) Run(run func(pubkey string)) *Database_GetPersonByPubkey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetPersonByPubkey_Call) Return(_a0 db.Person) *Database_GetPersonByPubkey_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetPersonByPubkey_Call) RunAndReturn(run func(string) db.Person) *Database_GetPersonByPubkey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPersonByUniqueName provides a mock function with given fields: un
+func (_m *Database) GetPersonByUniqueName(un string) db.Person {
+	ret := _m.Called(un)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPersonByUniqueName")
+	}
+
+	var r0 db.Person
+	if rf, ok := ret.Get(0).(func(string) db.Person); ok {
+		r0 = rf(un)
+	} else {
+		r0 = ret.Get(0).(db.Person)
+	}
+
+	return r0
+}
+
+// Database_GetPersonByUniqueName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPersonByUniqueName'
+type Database_GetPersonByUniqueName_Call struct {
+	*mock.Call
+}
+
+// GetPersonByUniqueName is a helper method to define mock.On call
+//   - un string
+func (_e *Database_Expecter) GetPersonByUniqueName(un interface{}) *Database_GetPersonByUniqueName_Call {
+	return &Database_GetPersonByUniqueName_Call{Call: _e.mock.On("GetPersonByUniqueName", un)}
+}
+
+func (_c *Database_GetPersonByUniqueName_Call) Run(run func(un string)) *Database_GetPersonByUniqueName_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetPersonByUniqueName_Call) Return(_a0 db.Person) *Database_GetPersonByUniqueName_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetPersonByUniqueName_Call) RunAndReturn(run func(string) db.Person) *Database_GetPersonByUniqueName_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPersonByUuid provides a mock function with given fields: uuid
+func (_m *Database) GetPersonByUuid(uuid string) db.Person {
+	ret := _m.Called(uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPersonByUuid")
+	}
+
+	var r0 db.Person
+	if rf, ok := ret.Get(0).(func(string) db.Person); ok {
+		r0 = rf(uuid)
+	} else {
+		r0 = ret.Get(0).(db.Person)
+	}
+
+	return r0
+}
+
+// Database_GetPersonByUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPersonByUuid'
+type Database_GetPersonByUuid_Call struct {
+	*mock.Call
+}
+
+// GetPersonByUuid is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) GetPersonByUuid(uuid interface{}) *Database_GetPersonByUuid_Call {
+	return &Database_GetPersonByUuid_Call{Call: _e.mock.On("GetPersonByUuid", uuid)}
+}
+
+func (_c *Database_GetPersonByUuid_Call) Run(run func(uuid string)) *Database_GetPersonByUuid_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetPersonByUuid_Call) Return(_a0 db.Person) *Database_GetPersonByUuid_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetPersonByUuid_Call) RunAndReturn(run func(string) db.Person) *Database_GetPersonByUuid_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPhaseByUuid provides a mock function with given fields: phaseUuid
+func (_m *Database) GetPhaseByUuid(phaseUuid string) (db.FeaturePhase, error) {
+	ret := _m.Called(phaseUuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPhaseByUuid")
+	}
+
+	var r0 db.FeaturePhase
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (db.FeaturePhase, error)); ok {
+		return rf(phaseUuid)
+	}
+	if rf, ok := ret.Get(0).(func(string) db.FeaturePhase); ok {
+		r0 = rf(phaseUuid)
+	} else {
+		r0 = ret.Get(0).(db.FeaturePhase)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(phaseUuid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetPhaseByUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPhaseByUuid'
+type Database_GetPhaseByUuid_Call struct {
+	*mock.Call
+}
+
+// GetPhaseByUuid is a helper method to define mock.On call
+//   - phaseUuid string
+func (_e *Database_Expecter) GetPhaseByUuid(phaseUuid interface{}) *Database_GetPhaseByUuid_Call {
+	return &Database_GetPhaseByUuid_Call{Call: _e.mock.On("GetPhaseByUuid", phaseUuid)}
+}
+
+func (_c *Database_GetPhaseByUuid_Call) Run(run func(phaseUuid string)) *Database_GetPhaseByUuid_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetPhaseByUuid_Call) Return(_a0 db.FeaturePhase, _a1 error) *Database_GetPhaseByUuid_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetPhaseByUuid_Call) RunAndReturn(run func(string) (db.FeaturePhase, error)) *Database_GetPhaseByUuid_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPhaseCompletion provides a mock function with given fields: phaseUuid
+func (_m *Database) GetPhaseCompletion(phaseUuid string) (int64, int64) {
+	ret := _m.Called(phaseUuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPhaseCompletion")
+	}
+
+	var r0 int64
+	var r1 int64
+	if rf, ok := ret.Get(0).(func(string) (int64, int64)); ok {
+		return rf(phaseUuid)
+	}
+	if rf, ok := ret.Get(0).(func(string) int64); ok {
+		r0 = rf(phaseUuid)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) int64); ok {
+		r1 = rf(phaseUuid)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	return r0, r1
+}
+
+// Database_GetPhaseCompletion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPhaseCompletion'
+type Database_GetPhaseCompletion_Call struct {
+	*mock.Call
+}
+
+// GetPhaseCompletion is a helper method to define mock.On call
+//   - phaseUuid string
+func (_e *Database_Expecter) GetPhaseCompletion(phaseUuid interface{}) *Database_GetPhaseCompletion_Call {
+	return &Database_GetPhaseCompletion_Call{Call: _e.mock.On("GetPhaseCompletion", phaseUuid)}
+}
+
+func (_c *Database_GetPhaseCompletion_Call) Run(run func(phaseUuid string)) *Database_GetPhaseCompletion_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetPhaseCompletion_Call) Return(_a0 int64, _a1 int64) *Database_GetPhaseCompletion_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetPhaseCompletion_Call) RunAndReturn(run func(string) (int64, int64)) *Database_GetPhaseCompletion_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPhasesByFeatureUuid provides a mock function with given fields: featureUuid
+func (_m *Database) GetPhasesByFeatureUuid(featureUuid string) []db.FeaturePhase {
+	ret := _m.Called(featureUuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPhasesByFeatureUuid")
+	}
+
+	var r0 []db.FeaturePhase
+	if rf, ok := ret.Get(0).(func(string) []db.FeaturePhase); ok {
+		r0 = rf(featureUuid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.FeaturePhase)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetPhasesByFeatureUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPhasesByFeatureUuid'
+type Database_GetPhasesByFeatureUuid_Call struct {
+	*mock.Call
+}
+
+// GetPhasesByFeatureUuid is a helper method to define mock.On call
+//   - featureUuid string
+func (_e *Database_Expecter) GetPhasesByFeatureUuid(featureUuid interface{}) *Database_GetPhasesByFeatureUuid_Call {
+	return &Database_GetPhasesByFeatureUuid_Call{Call: _e.mock.On("GetPhasesByFeatureUuid", featureUuid)}
+}
+
+func (_c *Database_GetPhasesByFeatureUuid_Call) Run(run func(featureUuid string)) *Database_GetPhasesByFeatureUuid_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetPhasesByFeatureUuid_Call) Return(_a0 []db.FeaturePhase) *Database_GetPhasesByFeatureUuid_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetPhasesByFeatureUuid_Call) RunAndReturn(run func(string) []db.FeaturePhase) *Database_GetPhasesByFeatureUuid_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPinnedTribeAnnouncements provides a mock function with given fields: tribeUuid, at
+func (_m *Database) GetPinnedTribeAnnouncements(tribeUuid string, at time.Time) []db.TribeAnnouncement {
+	ret := _m.Called(tribeUuid, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPinnedTribeAnnouncements")
+	}
+
+	var r0 []db.TribeAnnouncement
+	if rf, ok := ret.Get(0).(func(string, time.Time) []db.TribeAnnouncement); ok {
+		r0 = rf(tribeUuid, at)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.TribeAnnouncement)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetPinnedTribeAnnouncements_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPinnedTribeAnnouncements'
+type Database_GetPinnedTribeAnnouncements_Call struct {
+	*mock.Call
+}
+
+// GetPinnedTribeAnnouncements is a helper method to define mock.On call
+//   - tribeUuid string
+//   - at time.Time
+func (_e *Database_Expecter) GetPinnedTribeAnnouncements(tribeUuid interface{}, at interface{}) *Database_GetPinnedTribeAnnouncements_Call {
+	return &Database_GetPinnedTribeAnnouncements_Call{Call: _e.mock.On("GetPinnedTribeAnnouncements", tribeUuid, at)}
+}
+
+func (_c *Database_GetPinnedTribeAnnouncements_Call) Run(run func(tribeUuid string, at time.Time)) *Database_GetPinnedTribeAnnouncements_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *Database_GetPinnedTribeAnnouncements_Call) Return(_a0 []db.TribeAnnouncement) *Database_GetPinnedTribeAnnouncements_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetPinnedTribeAnnouncements_Call) RunAndReturn(run func(string, time.Time) []db.TribeAnnouncement) *Database_GetPinnedTribeAnnouncements_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPlatformStats provides a mock function with given fields: since
+func (_m *Database) GetPlatformStats(since time.Time) ([]db.PlatformStats, error) {
+	ret := _m.Called(since)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPlatformStats")
+	}
+
+	var r0 []db.PlatformStats
+	var r1 error
+	if rf, ok := ret.Get(0).(func(time.Time) ([]db.PlatformStats, error)); ok {
+		return rf(since)
+	}
+	if rf, ok := ret.Get(0).(func(time.Time) []db.PlatformStats); ok {
+		r0 = rf(since)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.PlatformStats)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(time.Time) error); ok {
+		r1 = rf(since)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetPlatformStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPlatformStats'
+type Database_GetPlatformStats_Call struct {
+	*mock.Call
+}
+
+// GetPlatformStats is a helper method to define mock.On call
+//   - since time.Time
+func (_e *Database_Expecter) GetPlatformStats(since interface{}) *Database_GetPlatformStats_Call {
+	return &Database_GetPlatformStats_Call{Call: _e.mock.On("GetPlatformStats", since)}
+}
+
+func (_c *Database_GetPlatformStats_Call) Run(run func(since time.Time)) *Database_GetPlatformStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(time.Time))
+	})
+	return _c
+}
+
+func (_c *Database_GetPlatformStats_Call) Return(_a0 []db.PlatformStats, _a1 error) *Database_GetPlatformStats_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetPlatformStats_Call) RunAndReturn(run func(time.Time) ([]db.PlatformStats, error)) *Database_GetPlatformStats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPoolStats provides a mock function with given fields:
+func (_m *Database) GetPoolStats() (db.PoolStats, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPoolStats")
+	}
+
+	var r0 db.PoolStats
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (db.PoolStats, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() db.PoolStats); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(db.PoolStats)
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetPoolStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPoolStats'
+type Database_GetPoolStats_Call struct {
+	*mock.Call
+}
+
+// GetPoolStats is a helper method to define mock.On call
+func (_e *Database_Expecter) GetPoolStats() *Database_GetPoolStats_Call {
+	return &Database_GetPoolStats_Call{Call: _e.mock.On("GetPoolStats")}
+}
+
+func (_c *Database_GetPoolStats_Call) Run(run func()) *Database_GetPoolStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Database_GetPoolStats_Call) Return(_a0 db.PoolStats, _a1 error) *Database_GetPoolStats_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetPoolStats_Call) RunAndReturn(run func() (db.PoolStats, error)) *Database_GetPoolStats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPreviousBountyByCreated provides a mock function with given fields: r
+func (_m *Database) GetPreviousBountyByCreated(r *http.Request) (uint, error) {
+	ret := _m.Called(r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPreviousBountyByCreated")
+	}
+
+	var r0 uint
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*http.Request) (uint, error)); ok {
+		return rf(r)
+	}
+	if rf, ok := ret.Get(0).(func(*http.Request) uint); ok {
+		r0 = rf(r)
+	} else {
+		r0 = ret.Get(0).(uint)
+	}
+
+	if rf, ok := ret.Get(1).(func(*http.Request) error); ok {
+		r1 = rf(r)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetPreviousBountyByCreated_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPreviousBountyByCreated'
+type Database_GetPreviousBountyByCreated_Call struct {
+	*mock.Call
+}
+
+// GetPreviousBountyByCreated is a helper method to define mock.On call
+//   - r *http.Request
+func (_e *Database_Expecter) GetPreviousBountyByCreated(r interface{}) *Database_GetPreviousBountyByCreated_Call {
+	return &Database_GetPreviousBountyByCreated_Call{Call: _e.mock.On("GetPreviousBountyByCreated", r)}
+}
+
+func (_c *Database_GetPreviousBountyByCreated_Call) Run(run func(r *http.Request)) *Database_GetPreviousBountyByCreated_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *Database_GetPreviousBountyByCreated_Call) Return(_a0 uint, _a1 error) *Database_GetPreviousBountyByCreated_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetPreviousBountyByCreated_Call) RunAndReturn(run func(*http.Request) (uint, error)) *Database_GetPreviousBountyByCreated_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPreviousWorkspaceBountyByCreated provides a mock function with given fields: r
+func (_m *Database) GetPreviousWorkspaceBountyByCreated(r *http.Request) (uint, error) {
+	ret := _m.Called(r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPreviousWorkspaceBountyByCreated")
+	}
+
+	var r0 uint
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*http.Request) (uint, error)); ok {
+		return rf(r)
+	}
+	if rf, ok := ret.Get(0).(func(*http.Request) uint); ok {
+		r0 = rf(r)
+	} else {
+		r0 = ret.Get(0).(uint)
+	}
+
+	if rf, ok := ret.Get(1).(func(*http.Request) error); ok {
+		r1 = rf(r)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetPreviousWorkspaceBountyByCreated_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPreviousWorkspaceBountyByCreated'
+type Database_GetPreviousWorkspaceBountyByCreated_Call struct {
+	*mock.Call
+}
+
+// GetPreviousWorkspaceBountyByCreated is a helper method to define mock.On call
+//   - r *http.Request
+func (_e *Database_Expecter) GetPreviousWorkspaceBountyByCreated(r interface{}) *Database_GetPreviousWorkspaceBountyByCreated_Call {
+	return &Database_GetPreviousWorkspaceBountyByCreated_Call{Call: _e.mock.On("GetPreviousWorkspaceBountyByCreated", r)}
+}
+
+func (_c *Database_GetPreviousWorkspaceBountyByCreated_Call) Run(run func(r *http.Request)) *Database_GetPreviousWorkspaceBountyByCreated_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *Database_GetPreviousWorkspaceBountyByCreated_Call) Return(_a0 uint, _a1 error) *Database_GetPreviousWorkspaceBountyByCreated_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetPreviousWorkspaceBountyByCreated_Call) RunAndReturn(run func(*http.Request) (uint, error)) *Database_GetPreviousWorkspaceBountyByCreated_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPublicMetrics provides a mock function with given fields: activeSince
+func (_m *Database) GetPublicMetrics(activeSince time.Time) (db.PublicMetrics, error) {
+	ret := _m.Called(activeSince)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPublicMetrics")
+	}
+
+	var r0 db.PublicMetrics
+	var r1 error
+	if rf, ok := ret.Get(0).(func(time.Time) (db.PublicMetrics, error)); ok {
+		return rf(activeSince)
+	}
+	if rf, ok := ret.Get(0).(func(time.Time) db.PublicMetrics); ok {
+		r0 = rf(activeSince)
+	} else {
+		r0 = ret.Get(0).(db.PublicMetrics)
+	}
+
+	if rf, ok := ret.Get(1).(func(time.Time) error); ok {
+		r1 = rf(activeSince)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetPublicMetrics_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPublicMetrics'
+type Database_GetPublicMetrics_Call struct {
+	*mock.Call
+}
+
+// GetPublicMetrics is a helper method to define mock.On call
+//   - activeSince time.Time
+func (_e *Database_Expecter) GetPublicMetrics(activeSince interface{}) *Database_GetPublicMetrics_Call {
+	return &Database_GetPublicMetrics_Call{Call: _e.mock.On("GetPublicMetrics", activeSince)}
+}
+
+func (_c *Database_GetPublicMetrics_Call) Run(run func(activeSince time.Time)) *Database_GetPublicMetrics_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(time.Time))
+	})
+	return _c
+}
+
+func (_c *Database_GetPublicMetrics_Call) Return(_a0 db.PublicMetrics, _a1 error) *Database_GetPublicMetrics_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetPublicMetrics_Call) RunAndReturn(run func(time.Time) (db.PublicMetrics, error)) *Database_GetPublicMetrics_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetQuest provides a mock function with given fields: uuid
+func (_m *Database) GetQuest(uuid string) (db.Quest, error) {
+	ret := _m.Called(uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetQuest")
+	}
+
+	var r0 db.Quest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (db.Quest, error)); ok {
+		return rf(uuid)
+	}
+	if rf, ok := ret.Get(0).(func(string) db.Quest); ok {
+		r0 = rf(uuid)
+	} else {
+		r0 = ret.Get(0).(db.Quest)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(uuid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetQuest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetQuest'
+type Database_GetQuest_Call struct {
+	*mock.Call
+}
+
+// GetQuest is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) GetQuest(uuid interface{}) *Database_GetQuest_Call {
+	return &Database_GetQuest_Call{Call: _e.mock.On("GetQuest", uuid)}
+}
+
+func (_c *Database_GetQuest_Call) Run(run func(uuid string)) *Database_GetQuest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetQuest_Call) Return(_a0 db.Quest, _a1 error) *Database_GetQuest_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetQuest_Call) RunAndReturn(run func(string) (db.Quest, error)) *Database_GetQuest_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetQuestBounties provides a mock function with given fields: questUuid
+func (_m *Database) GetQuestBounties(questUuid string) []db.NewBounty {
+	ret := _m.Called(questUuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetQuestBounties")
+	}
+
+	var r0 []db.NewBounty
+	if rf, ok := ret.Get(0).(func(string) []db.NewBounty); ok {
+		r0 = rf(questUuid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.NewBounty)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetQuestBounties_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetQuestBounties'
+type Database_GetQuestBounties_Call struct {
+	*mock.Call
+}
+
+// GetQuestBounties is a helper method to define mock.On call
+//   - questUuid string
+func (_e *Database_Expecter) GetQuestBounties(questUuid interface{}) *Database_GetQuestBounties_Call {
+	return &Database_GetQuestBounties_Call{Call: _e.mock.On("GetQuestBounties", questUuid)}
+}
+
+func (_c *Database_GetQuestBounties_Call) Run(run func(questUuid string)) *Database_GetQuestBounties_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetQuestBounties_Call) Return(_a0 []db.NewBounty) *Database_GetQuestBounties_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetQuestBounties_Call) RunAndReturn(run func(string) []db.NewBounty) *Database_GetQuestBounties_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetQuests provides a mock function with given fields: workspaceUuid, activeOnly
+func (_m *Database) GetQuests(workspaceUuid string, activeOnly bool) []db.Quest {
+	ret := _m.Called(workspaceUuid, activeOnly)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetQuests")
+	}
+
+	var r0 []db.Quest
+	if rf, ok := ret.Get(0).(func(string, bool) []db.Quest); ok {
+		r0 = rf(workspaceUuid, activeOnly)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Quest)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetQuests_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetQuests'
+type Database_GetQuests_Call struct {
+	*mock.Call
+}
+
+// GetQuests is a helper method to define mock.On call
+//   - workspaceUuid string
+//   - activeOnly bool
+func (_e *Database_Expecter) GetQuests(workspaceUuid interface{}, activeOnly interface{}) *Database_GetQuests_Call {
+	return &Database_GetQuests_Call{Call: _e.mock.On("GetQuests", workspaceUuid, activeOnly)}
+}
+
+func (_c *Database_GetQuests_Call) Run(run func(workspaceUuid string, activeOnly bool)) *Database_GetQuests_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(bool))
+	})
+	return _c
+}
+
+func (_c *Database_GetQuests_Call) Return(_a0 []db.Quest) *Database_GetQuests_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetQuests_Call) RunAndReturn(run func(string, bool) []db.Quest) *Database_GetQuests_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRecentWorkspaceBounties provides a mock function with given fields: workspaceUuid, limit
+func (_m *Database) GetRecentWorkspaceBounties(workspaceUuid string, limit int) []db.NewBounty {
+	ret := _m.Called(workspaceUuid, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRecentWorkspaceBounties")
+	}
+
+	var r0 []db.NewBounty
+	if rf, ok := ret.Get(0).(func(string, int) []db.NewBounty); ok {
+		r0 = rf(workspaceUuid, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.NewBounty)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetRecentWorkspaceBounties_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRecentWorkspaceBounties'
+type Database_GetRecentWorkspaceBounties_Call struct {
+	*mock.Call
+}
+
+// GetRecentWorkspaceBounties is a helper method to define mock.On call
+//   - workspaceUuid string
+//   - limit int
+func (_e *Database_Expecter) GetRecentWorkspaceBounties(workspaceUuid interface{}, limit interface{}) *Database_GetRecentWorkspaceBounties_Call {
+	return &Database_GetRecentWorkspaceBounties_Call{Call: _e.mock.On("GetRecentWorkspaceBounties", workspaceUuid, limit)}
+}
+
+func (_c *Database_GetRecentWorkspaceBounties_Call) Run(run func(workspaceUuid string, limit int)) *Database_GetRecentWorkspaceBounties_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *Database_GetRecentWorkspaceBounties_Call) Return(_a0 []db.NewBounty) *Database_GetRecentWorkspaceBounties_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetRecentWorkspaceBounties_Call) RunAndReturn(run func(string, int) []db.NewBounty) *Database_GetRecentWorkspaceBounties_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRecommendationPubkeys provides a mock function with given fields: at
+func (_m *Database) GetRecommendationPubkeys(at time.Time) ([]string, error) {
+	ret := _m.Called(at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRecommendationPubkeys")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(time.Time) ([]string, error)); ok {
+		return rf(at)
+	}
+	if rf, ok := ret.Get(0).(func(time.Time) []string); ok {
+		r0 = rf(at)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(time.Time) error); ok {
+		r1 = rf(at)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetRecommendationPubkeys_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRecommendationPubkeys'
+type Database_GetRecommendationPubkeys_Call struct {
+	*mock.Call
+}
+
+// GetRecommendationPubkeys is a helper method to define mock.On call
+//   - at time.Time
+func (_e *Database_Expecter) GetRecommendationPubkeys(at interface{}) *Database_GetRecommendationPubkeys_Call {
+	return &Database_GetRecommendationPubkeys_Call{Call: _e.mock.On("GetRecommendationPubkeys", at)}
+}
+
+func (_c *Database_GetRecommendationPubkeys_Call) Run(run func(at time.Time)) *Database_GetRecommendationPubkeys_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(time.Time))
+	})
+	return _c
+}
+
+func (_c *Database_GetRecommendationPubkeys_Call) Return(_a0 []string, _a1 error) *Database_GetRecommendationPubkeys_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetRecommendationPubkeys_Call) RunAndReturn(run func(time.Time) ([]string, error)) *Database_GetRecommendationPubkeys_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetReportByUuid provides a mock function with given fields: uuid
+func (_m *Database) GetReportByUuid(uuid string) (db.Report, error) {
+	ret := _m.Called(uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReportByUuid")
+	}
+
+	var r0 db.Report
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (db.Report, error)); ok {
+		return rf(uuid)
+	}
+	if rf, ok := ret.Get(0).(func(string) db.Report); ok {
+		r0 = rf(uuid)
+	} else {
+		r0 = ret.Get(0).(db.Report)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(uuid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetReportByUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetReportByUuid'
+type Database_GetReportByUuid_Call struct {
+	*mock.Call
+}
+
+// GetReportByUuid is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) GetReportByUuid(uuid interface{}) *Database_GetReportByUuid_Call {
+	return &Database_GetReportByUuid_Call{Call: _e.mock.On("GetReportByUuid", uuid)}
+}
+
+func (_c *Database_GetReportByUuid_Call) Run(run func(uuid string)) *Database_GetReportByUuid_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetReportByUuid_Call) Return(_a0 db.Report, _a1 error) *Database_GetReportByUuid_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetReportByUuid_Call) RunAndReturn(run func(string) (db.Report, error)) *Database_GetReportByUuid_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetReports provides a mock function with given fields: r
+func (_m *Database) GetReports(r *http.Request) ([]db.Report, error) {
+	ret := _m.Called(r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReports")
+	}
+
+	var r0 []db.Report
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*http.Request) ([]db.Report, error)); ok {
+		return rf(r)
+	}
+	if rf, ok := ret.Get(0).(func(*http.Request) []db.Report); ok {
+		r0 = rf(r)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Report)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*http.Request) error); ok {
+		r1 = rf(r)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetReports_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetReports'
+type Database_GetReports_Call struct {
+	*mock.Call
+}
+
+// GetReports is a helper method to define mock.On call
+//   - r *http.Request
+func (_e *Database_Expecter) GetReports(r interface{}) *Database_GetReports_Call {
+	return &Database_GetReports_Call{Call: _e.mock.On("GetReports", r)}
+}
+
+func (_c *Database_GetReports_Call) Run(run func(r *http.Request)) *Database_GetReports_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *Database_GetReports_Call) Return(_a0 []db.Report, _a1 error) *Database_GetReports_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetReports_Call) RunAndReturn(run func(*http.Request) ([]db.Report, error)) *Database_GetReports_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetReputations provides a mock function with given fields: pubkeys, at, halfLife
+func (_m *Database) GetReputations(pubkeys []string, at time.Time, halfLife time.Duration) (map[string]db.Reputation, error) {
+	ret := _m.Called(pubkeys, at, halfLife)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReputations")
+	}
+
+	var r0 map[string]db.Reputation
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]string, time.Time, time.Duration) (map[string]db.Reputation, error)); ok {
+		return rf(pubkeys, at, halfLife)
+	}
+	if rf, ok := ret.Get(0).(func([]string, time.Time, time.Duration) map[string]db.Reputation); ok {
+		r0 = rf(pubkeys, at, halfLife)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]db.Reputation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]string, time.Time, time.Duration) error); ok {
+		r1 = rf(pubkeys, at, halfLife)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetReputations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetReputations'
+type Database_GetReputations_Call struct {
+	*mock.Call
+}
+
+// GetReputations is a helper method to define mock.On call
+//   - pubkeys []string
+//   - at time.Time
+//   - halfLife time.Duration
+func (_e *Database_Expecter) GetReputations(pubkeys interface{}, at interface{}, halfLife interface{}) *Database_GetReputations_Call {
+	return &Database_GetReputations_Call{Call: _e.mock.On("GetReputations", pubkeys, at, halfLife)}
+}
+
+func (_c *Database_GetReputations_Call) Run(run func(pubkeys []string, at time.Time, halfLife time.Duration)) *Database_GetReputations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]string), args[1].(time.Time), args[2].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *Database_GetReputations_Call) Return(_a0 map[string]db.Reputation, _a1 error) *Database_GetReputations_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetReputations_Call) RunAndReturn(run func([]string, time.Time, time.Duration) (map[string]db.Reputation, error)) *Database_GetReputations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetReserveEntries provides a mock function with given fields: workspaceUuid
+func (_m *Database) GetReserveEntries(workspaceUuid string) []db.ReserveEntry {
+	ret := _m.Called(workspaceUuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReserveEntries")
+	}
+
+	var r0 []db.ReserveEntry
+	if rf, ok := ret.Get(0).(func(string) []db.ReserveEntry); ok {
+		r0 = rf(workspaceUuid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.ReserveEntry)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetReserveEntries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetReserveEntries'
+type Database_GetReserveEntries_Call struct {
+	*mock.Call
+}
+
+// GetReserveEntries is a helper method to define mock.On call
+//   - workspaceUuid string
+func (_e *Database_Expecter) GetReserveEntries(workspaceUuid interface{}) *Database_GetReserveEntries_Call {
+	return &Database_GetReserveEntries_Call{Call: _e.mock.On("GetReserveEntries", workspaceUuid)}
+}
+
+func (_c *Database_GetReserveEntries_Call) Run(run func(workspaceUuid string)) *Database_GetReserveEntries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetReserveEntries_Call) Return(_a0 []db.ReserveEntry) *Database_GetReserveEntries_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetReserveEntries_Call) RunAndReturn(run func(string) []db.ReserveEntry) *Database_GetReserveEntries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetReserveEntry provides a mock function with given fields: uuid
+func (_m *Database) GetReserveEntry(uuid string) (db.ReserveEntry, error) {
+	ret := _m.Called(uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReserveEntry")
+	}
+
+	var r0 db.ReserveEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (db.ReserveEntry, error)); ok {
+		return rf(uuid)
+	}
+	if rf, ok := ret.Get(0).(func(string) db.ReserveEntry); ok {
+		r0 = rf(uuid)
+	} else {
+		r0 = ret.Get(0).(db.ReserveEntry)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(uuid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetReserveEntry_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetReserveEntry'
+type Database_GetReserveEntry_Call struct {
+	*mock.Call
+}
+
+// GetReserveEntry is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) GetReserveEntry(uuid interface{}) *Database_GetReserveEntry_Call {
+	return &Database_GetReserveEntry_Call{Call: _e.mock.On("GetReserveEntry", uuid)}
+}
+
+func (_c *Database_GetReserveEntry_Call) Run(run func(uuid string)) *Database_GetReserveEntry_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetReserveEntry_Call) Return(_a0 db.ReserveEntry, _a1 error) *Database_GetReserveEntry_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetReserveEntry_Call) RunAndReturn(run func(string) (db.ReserveEntry, error)) *Database_GetReserveEntry_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRetentionRuns provides a mock function with given fields: r
+func (_m *Database) GetRetentionRuns(r *http.Request) ([]db.RetentionRun, error) {
+	ret := _m.Called(r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRetentionRuns")
+	}
+
+	var r0 []db.RetentionRun
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*http.Request) ([]db.RetentionRun, error)); ok {
+		return rf(r)
+	}
+	if rf, ok := ret.Get(0).(func(*http.Request) []db.RetentionRun); ok {
+		r0 = rf(r)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.RetentionRun)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*http.Request) error); ok {
+		r1 = rf(r)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetRetentionRuns_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRetentionRuns'
+type Database_GetRetentionRuns_Call struct {
+	*mock.Call
+}
+
+// GetRetentionRuns is a helper method to define mock.On call
+//   - r *http.Request
+func (_e *Database_Expecter) GetRetentionRuns(r interface{}) *Database_GetRetentionRuns_Call {
+	return &Database_GetRetentionRuns_Call{Call: _e.mock.On("GetRetentionRuns", r)}
+}
+
+func (_c *Database_GetRetentionRuns_Call) Run(run func(r *http.Request)) *Database_GetRetentionRuns_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *Database_GetRetentionRuns_Call) Return(_a0 []db.RetentionRun, _a1 error) *Database_GetRetentionRuns_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetRetentionRuns_Call) RunAndReturn(run func(*http.Request) ([]db.RetentionRun, error)) *Database_GetRetentionRuns_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRunningTimeEntry provides a mock function with given fields: pubkey
+func (_m *Database) GetRunningTimeEntry(pubkey string) (db.TimeEntry, error) {
+	ret := _m.Called(pubkey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRunningTimeEntry")
+	}
+
+	var r0 db.TimeEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (db.TimeEntry, error)); ok {
+		return rf(pubkey)
+	}
+	if rf, ok := ret.Get(0).(func(string) db.TimeEntry); ok {
+		r0 = rf(pubkey)
+	} else {
+		r0 = ret.Get(0).(db.TimeEntry)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(pubkey)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetRunningTimeEntry_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRunningTimeEntry'
+type Database_GetRunningTimeEntry_Call struct {
+	*mock.Call
+}
+
+// GetRunningTimeEntry is a helper method to define mock.On call
+//   - pubkey string
+func (_e *Database_Expecter) GetRunningTimeEntry(pubkey interface{}) *Database_GetRunningTimeEntry_Call {
+	return &Database_GetRunningTimeEntry_Call{Call: _e.mock.On("GetRunningTimeEntry", pubkey)}
+}
+
+func (_c *Database_GetRunningTimeEntry_Call) Run(run func(pubkey string)) *Database_GetRunningTimeEntry_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetRunningTimeEntry_Call) Return(_a0 db.TimeEntry, _a1 error) *Database_GetRunningTimeEntry_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetRunningTimeEntry_Call) RunAndReturn(run func(string) (db.TimeEntry, error)) *Database_GetRunningTimeEntry_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSearchDocument provides a mock function with given fields: index, id
+func (_m *Database) GetSearchDocument(index string, id string) (db.SearchDocument, bool, error) {
+	ret := _m.Called(index, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSearchDocument")
+	}
+
+	var r0 db.SearchDocument
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(string, string) (db.SearchDocument, bool, error)); ok {
+		return rf(index, id)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) db.SearchDocument); ok {
+		r0 = rf(index, id)
+	} else {
+		r0 = ret.Get(0).(db.SearchDocument)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) bool); ok {
+		r1 = rf(index, id)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(string, string) error); ok {
+		r2 = rf(index, id)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Database_GetSearchDocument_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSearchDocument'
+type Database_GetSearchDocument_Call struct {
+	*mock.Call
+}
+
+// GetSearchDocument is a helper method to define mock.On call
+//   - index string
+//   - id string
+func (_e *Database_Expecter) GetSearchDocument(index interface{}, id interface{}) *Database_GetSearchDocument_Call {
+	return &Database_GetSearchDocument_Call{Call: _e.mock.On("GetSearchDocument", index, id)}
+}
+
+func (_c *Database_GetSearchDocument_Call) Run(run func(index string, id string)) *Database_GetSearchDocument_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetSearchDocument_Call) Return(_a0 db.SearchDocument, _a1 bool, _a2 error) *Database_GetSearchDocument_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *Database_GetSearchDocument_Call) RunAndReturn(run func(string, string) (db.SearchDocument, bool, error)) *Database_GetSearchDocument_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSearchDocuments provides a mock function with given fields: index, limit, offset
+func (_m *Database) GetSearchDocuments(index string, limit int, offset int) ([]db.SearchDocument, error) {
+	ret := _m.Called(index, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSearchDocuments")
+	}
+
+	var r0 []db.SearchDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, int, int) ([]db.SearchDocument, error)); ok {
+		return rf(index, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(string, int, int) []db.SearchDocument); ok {
+		r0 = rf(index, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.SearchDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, int, int) error); ok {
+		r1 = rf(index, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetSearchDocuments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSearchDocuments'
+type Database_GetSearchDocuments_Call struct {
+	*mock.Call
+}
+
+// GetSearchDocuments is a helper method to define mock.On call
+//   - index string
+//   - limit int
+//   - offset int
+func (_e *Database_Expecter) GetSearchDocuments(index interface{}, limit interface{}, offset interface{}) *Database_GetSearchDocuments_Call {
+	return &Database_GetSearchDocuments_Call{Call: _e.mock.On("GetSearchDocuments", index, limit, offset)}
+}
+
+func (_c *Database_GetSearchDocuments_Call) Run(run func(index string, limit int, offset int)) *Database_GetSearchDocuments_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(int), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *Database_GetSearchDocuments_Call) Return(_a0 []db.SearchDocument, _a1 error) *Database_GetSearchDocuments_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetSearchDocuments_Call) RunAndReturn(run func(string, int, int) ([]db.SearchDocument, error)) *Database_GetSearchDocuments_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetShadowListedTribes provides a mock function with given fields: r
+func (_m *Database) GetShadowListedTribes(r *http.Request) ([]db.ShadowListedTribe, error) {
+	ret := _m.Called(r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetShadowListedTribes")
+	}
+
+	var r0 []db.ShadowListedTribe
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*http.Request) ([]db.ShadowListedTribe, error)); ok {
+		return rf(r)
+	}
+	if rf, ok := ret.Get(0).(func(*http.Request) []db.ShadowListedTribe); ok {
+		r0 = rf(r)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.ShadowListedTribe)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*http.Request) error); ok {
+		r1 = rf(r)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetShadowListedTribes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetShadowListedTribes'
+type Database_GetShadowListedTribes_Call struct {
+	*mock.Call
+}
+
+// GetShadowListedTribes is a helper method to define mock.On call
+//   - r *http.Request
+func (_e *Database_Expecter) GetShadowListedTribes(r interface{}) *Database_GetShadowListedTribes_Call {
+	return &Database_GetShadowListedTribes_Call{Call: _e.mock.On("GetShadowListedTribes", r)}
+}
+
+func (_c *Database_GetShadowListedTribes_Call) Run(run func(r *http.Request)) *Database_GetShadowListedTribes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *Database_GetShadowListedTribes_Call) Return(_a0 []db.ShadowListedTribe, _a1 error) *Database_GetShadowListedTribes_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetShadowListedTribes_Call) RunAndReturn(run func(*http.Request) ([]db.ShadowListedTribe, error)) *Database_GetShadowListedTribes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSoftDeleted provides a mock function with given fields: kind, r
+func (_m *Database) GetSoftDeleted(kind string, r *http.Request) ([]db.SoftDeleted, error) {
+	ret := _m.Called(kind, r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSoftDeleted")
+	}
+
+	var r0 []db.SoftDeleted
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, *http.Request) ([]db.SoftDeleted, error)); ok {
+		return rf(kind, r)
+	}
+	if rf, ok := ret.Get(0).(func(string, *http.Request) []db.SoftDeleted); ok {
+		r0 = rf(kind, r)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.SoftDeleted)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, *http.Request) error); ok {
+		r1 = rf(kind, r)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetSoftDeleted_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSoftDeleted'
+type Database_GetSoftDeleted_Call struct {
+	*mock.Call
+}
+
+// GetSoftDeleted is a helper method to define mock.On call
+//   - kind string
+//   - r *http.Request
+func (_e *Database_Expecter) GetSoftDeleted(kind interface{}, r interface{}) *Database_GetSoftDeleted_Call {
+	return &Database_GetSoftDeleted_Call{Call: _e.mock.On("GetSoftDeleted", kind, r)}
+}
+
+func (_c *Database_GetSoftDeleted_Call) Run(run func(kind string, r *http.Request)) *Database_GetSoftDeleted_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *Database_GetSoftDeleted_Call) Return(_a0 []db.SoftDeleted, _a1 error) *Database_GetSoftDeleted_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetSoftDeleted_Call) RunAndReturn(run func(string, *http.Request) ([]db.SoftDeleted, error)) *Database_GetSoftDeleted_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSpamScoringTribes provides a mock function with given fields:
+func (_m *Database) GetSpamScoringTribes() ([]db.Tribe, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSpamScoringTribes")
+	}
+
+	var r0 []db.Tribe
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]db.Tribe, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []db.Tribe); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Tribe)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetSpamScoringTribes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSpamScoringTribes'
+type Database_GetSpamScoringTribes_Call struct {
+	*mock.Call
+}
+
+// GetSpamScoringTribes is a helper method to define mock.On call
+func (_e *Database_Expecter) GetSpamScoringTribes() *Database_GetSpamScoringTribes_Call {
+	return &Database_GetSpamScoringTribes_Call{Call: _e.mock.On("GetSpamScoringTribes")}
+}
+
+func (_c *Database_GetSpamScoringTribes_Call) Run(run func()) *Database_GetSpamScoringTribes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Database_GetSpamScoringTribes_Call) Return(_a0 []db.Tribe, _a1 error) *Database_GetSpamScoringTribes_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetSpamScoringTribes_Call) RunAndReturn(run func() ([]db.Tribe, error)) *Database_GetSpamScoringTribes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTimeEntry provides a mock function with given fields: uuid
+func (_m *Database) GetTimeEntry(uuid string) (db.TimeEntry, error) {
+	ret := _m.Called(uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTimeEntry")
+	}
+
+	var r0 db.TimeEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (db.TimeEntry, error)); ok {
+		return rf(uuid)
+	}
+	if rf, ok := ret.Get(0).(func(string) db.TimeEntry); ok {
+		r0 = rf(uuid)
+	} else {
+		r0 = ret.Get(0).(db.TimeEntry)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(uuid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetTimeEntry_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTimeEntry'
+type Database_GetTimeEntry_Call struct {
+	*mock.Call
+}
+
+// GetTimeEntry is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) GetTimeEntry(uuid interface{}) *Database_GetTimeEntry_Call {
+	return &Database_GetTimeEntry_Call{Call: _e.mock.On("GetTimeEntry", uuid)}
+}
+
+func (_c *Database_GetTimeEntry_Call) Run(run func(uuid string)) *Database_GetTimeEntry_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetTimeEntry_Call) Return(_a0 db.TimeEntry, _a1 error) *Database_GetTimeEntry_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetTimeEntry_Call) RunAndReturn(run func(string) (db.TimeEntry, error)) *Database_GetTimeEntry_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTimeTotals provides a mock function with given fields: workspaceUuid, pubkey, from, to
+func (_m *Database) GetTimeTotals(workspaceUuid string, pubkey string, from *time.Time, to *time.Time) []db.TimeTotal {
+	ret := _m.Called(workspaceUuid, pubkey, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTimeTotals")
+	}
+
+	var r0 []db.TimeTotal
+	if rf, ok := ret.Get(0).(func(string, string, *time.Time, *time.Time) []db.TimeTotal); ok {
+		r0 = rf(workspaceUuid, pubkey, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.TimeTotal)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetTimeTotals_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTimeTotals'
+type Database_GetTimeTotals_Call struct {
+	*mock.Call
+}
+
+// GetTimeTotals is a helper method to define mock.On call
+//   - workspaceUuid string
+//   - pubkey string
+//   - from *time.Time
+//   - to *time.Time
+func (_e *Database_Expecter) GetTimeTotals(workspaceUuid interface{}, pubkey interface{}, from interface{}, to interface{}) *Database_GetTimeTotals_Call {
+	return &Database_GetTimeTotals_Call{Call: _e.mock.On("GetTimeTotals", workspaceUuid, pubkey, from, to)}
+}
+
+func (_c *Database_GetTimeTotals_Call) Run(run func(workspaceUuid string, pubkey string, from *time.Time, to *time.Time)) *Database_GetTimeTotals_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(*time.Time), args[3].(*time.Time))
+	})
+	return _c
+}
+
+func (_c *Database_GetTimeTotals_Call) Return(_a0 []db.TimeTotal) *Database_GetTimeTotals_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetTimeTotals_Call) RunAndReturn(run func(string, string, *time.Time, *time.Time) []db.TimeTotal) *Database_GetTimeTotals_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTribe provides a mock function with given fields: uuid
+func (_m *Database) GetTribe(uuid string) db.Tribe {
+	ret := _m.Called(uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTribe")
+	}
+
+	var r0 db.Tribe
+	if rf, ok := ret.Get(0).(func(string) db.Tribe); ok {
+		r0 = rf(uuid)
+	} else {
+		r0 = ret.Get(0).(db.Tribe)
+	}
+
+	return r0
+}
+
+// Database_GetTribe_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTribe'
+type Database_GetTribe_Call struct {
+	*mock.Call
+}
+
+// GetTribe is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) GetTribe(uuid interface{}) *Database_GetTribe_Call {
+	return &Database_GetTribe_Call{Call: _e.mock.On("GetTribe", uuid)}
+}
+
+func (_c *Database_GetTribe_Call) Run(run func(uuid string)) *Database_GetTribe_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetTribe_Call) Return(_a0 db.Tribe) *Database_GetTribe_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetTribe_Call) RunAndReturn(run func(string) db.Tribe) *Database_GetTribe_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTribeActivity provides a mock function with given fields: tribeUuid, kinds, r
+func (_m *Database) GetTribeActivity(tribeUuid string, kinds []string, r *http.Request) ([]db.TribeActivity, error) {
+	ret := _m.Called(tribeUuid, kinds, r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTribeActivity")
+	}
+
+	var r0 []db.TribeActivity
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, []string, *http.Request) ([]db.TribeActivity, error)); ok {
+		return rf(tribeUuid, kinds, r)
+	}
+	if rf, ok := ret.Get(0).(func(string, []string, *http.Request) []db.TribeActivity); ok {
+		r0 = rf(tribeUuid, kinds, r)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.TribeActivity)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, []string, *http.Request) error); ok {
+		r1 = rf(tribeUuid, kinds, r)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetTribeActivity_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTribeActivity'
+type Database_GetTribeActivity_Call struct {
+	*mock.Call
+}
+
+// GetTribeActivity is a helper method to define mock.On call
+//   - tribeUuid string
+//   - kinds []string
+//   - r *http.Request
+func (_e *Database_Expecter) GetTribeActivity(tribeUuid interface{}, kinds interface{}, r interface{}) *Database_GetTribeActivity_Call {
+	return &Database_GetTribeActivity_Call{Call: _e.mock.On("GetTribeActivity", tribeUuid, kinds, r)}
+}
+
+func (_c *Database_GetTribeActivity_Call) Run(run func(tribeUuid string, kinds []string, r *http.Request)) *Database_GetTribeActivity_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].([]string), args[2].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *Database_GetTribeActivity_Call) Return(_a0 []db.TribeActivity, _a1 error) *Database_GetTribeActivity_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetTribeActivity_Call) RunAndReturn(run func(string, []string, *http.Request) ([]db.TribeActivity, error)) *Database_GetTribeActivity_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTribeActivityCount provides a mock function with given fields: tribeUuid, kinds
+func (_m *Database) GetTribeActivityCount(tribeUuid string, kinds []string) int64 {
+	ret := _m.Called(tribeUuid, kinds)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTribeActivityCount")
+	}
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(string, []string) int64); ok {
+		r0 = rf(tribeUuid, kinds)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	return r0
+}
+
+// Database_GetTribeActivityCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTribeActivityCount'
+type Database_GetTribeActivityCount_Call struct {
+	*mock.Call
+}
+
+// GetTribeActivityCount is a helper method to define mock.On call
+//   - tribeUuid string
+//   - kinds []string
+func (_e *Database_Expecter) GetTribeActivityCount(tribeUuid interface{}, kinds interface{}) *Database_GetTribeActivityCount_Call {
+	return &Database_GetTribeActivityCount_Call{Call: _e.mock.On("GetTribeActivityCount", tribeUuid, kinds)}
+}
+
+func (_c *Database_GetTribeActivityCount_Call) Run(run func(tribeUuid string, kinds []string)) *Database_GetTribeActivityCount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *Database_GetTribeActivityCount_Call) Return(_a0 int64) *Database_GetTribeActivityCount_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetTribeActivityCount_Call) RunAndReturn(run func(string, []string) int64) *Database_GetTribeActivityCount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTribeAnnouncement provides a mock function with given fields: uuid
+func (_m *Database) GetTribeAnnouncement(uuid string) (db.TribeAnnouncement, error) {
+	ret := _m.Called(uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTribeAnnouncement")
+	}
+
+	var r0 db.TribeAnnouncement
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (db.TribeAnnouncement, error)); ok {
+		return rf(uuid)
+	}
+	if rf, ok := ret.Get(0).(func(string) db.TribeAnnouncement); ok {
+		r0 = rf(uuid)
+	} else {
+		r0 = ret.Get(0).(db.TribeAnnouncement)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(uuid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetTribeAnnouncement_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTribeAnnouncement'
+type Database_GetTribeAnnouncement_Call struct {
+	*mock.Call
+}
+
+// GetTribeAnnouncement is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) GetTribeAnnouncement(uuid interface{}) *Database_GetTribeAnnouncement_Call {
+	return &Database_GetTribeAnnouncement_Call{Call: _e.mock.On("GetTribeAnnouncement", uuid)}
+}
+
+func (_c *Database_GetTribeAnnouncement_Call) Run(run func(uuid string)) *Database_GetTribeAnnouncement_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetTribeAnnouncement_Call) Return(_a0 db.TribeAnnouncement, _a1 error) *Database_GetTribeAnnouncement_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetTribeAnnouncement_Call) RunAndReturn(run func(string) (db.TribeAnnouncement, error)) *Database_GetTribeAnnouncement_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTribeAnnouncements provides a mock function with given fields: tribeUuid
+func (_m *Database) GetTribeAnnouncements(tribeUuid string) []db.TribeAnnouncement {
+	ret := _m.Called(tribeUuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTribeAnnouncements")
+	}
+
+	var r0 []db.TribeAnnouncement
+	if rf, ok := ret.Get(0).(func(string) []db.TribeAnnouncement); ok {
+		r0 = rf(tribeUuid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.TribeAnnouncement)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetTribeAnnouncements_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTribeAnnouncements'
+type Database_GetTribeAnnouncements_Call struct {
+	*mock.Call
+}
+
+// GetTribeAnnouncements is a helper method to define mock.On call
+//   - tribeUuid string
+func (_e *Database_Expecter) GetTribeAnnouncements(tribeUuid interface{}) *Database_GetTribeAnnouncements_Call {
+	return &Database_GetTribeAnnouncements_Call{Call: _e.mock.On("GetTribeAnnouncements", tribeUuid)}
+}
+
+func (_c *Database_GetTribeAnnouncements_Call) Run(run func(tribeUuid string)) *Database_GetTribeAnnouncements_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetTribeAnnouncements_Call) Return(_a0 []db.TribeAnnouncement) *Database_GetTribeAnnouncements_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetTribeAnnouncements_Call) RunAndReturn(run func(string) []db.TribeAnnouncement) *Database_GetTribeAnnouncements_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTribeByIdAndPubkey provides a mock function with given fields: uuid, pubkey
+func (_m *Database) GetTribeByIdAndPubkey(uuid string, pubkey string) db.Tribe {
+	ret := _m.Called(uuid, pubkey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTribeByIdAndPubkey")
+	}
+
+	var r0 db.Tribe
+	if rf, ok := ret.Get(0).(func(string, string) db.Tribe); ok {
+		r0 = rf(uuid, pubkey)
+	} else {
+		r0 = ret.Get(0).(db.Tribe)
+	}
+
+	return r0
+}
+
+// Database_GetTribeByIdAndPubkey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTribeByIdAndPubkey'
+type Database_GetTribeByIdAndPubkey_Call struct {
+	*mock.Call
+}
+
+// GetTribeByIdAndPubkey is a helper method to define mock.On call
+//   - uuid string
+//   - pubkey string
+func (_e *Database_Expecter) GetTribeByIdAndPubkey(uuid interface{}, pubkey interface{}) *Database_GetTribeByIdAndPubkey_Call {
+	return &Database_GetTribeByIdAndPubkey_Call{Call: _e.mock.On("GetTribeByIdAndPubkey", uuid, pubkey)}
+}
+
+func (_c *Database_GetTribeByIdAndPubkey_Call) Run(run func(uuid string, pubkey string)) *Database_GetTribeByIdAndPubkey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetTribeByIdAndPubkey_Call) Return(_a0 db.Tribe) *Database_GetTribeByIdAndPubkey_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetTribeByIdAndPubkey_Call) RunAndReturn(run func(string, string) db.Tribe) *Database_GetTribeByIdAndPubkey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTribeByUniqueName provides a mock function with given fields: un
+func (_m *Database) GetTribeByUniqueName(un string) db.Tribe {
+	ret := _m.Called(un)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTribeByUniqueName")
+	}
+
+	var r0 db.Tribe
+	if rf, ok := ret.Get(0).(func(string) db.Tribe); ok {
+		r0 = rf(un)
+	} else {
+		r0 = ret.Get(0).(db.Tribe)
+	}
+
+	return r0
+}
+
+// Database_GetTribeByUniqueName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTribeByUniqueName'
+type Database_GetTribeByUniqueName_Call struct {
+	*mock.Call
+}
+
+// GetTribeByUniqueName is a helper method to define mock.On call
+//   - un string
+func (_e *Database_Expecter) GetTribeByUniqueName(un interface{}) *Database_GetTribeByUniqueName_Call {
+	return &Database_GetTribeByUniqueName_Call{Call: _e.mock.On("GetTribeByUniqueName", un)}
+}
+
+func (_c *Database_GetTribeByUniqueName_Call) Run(run func(un string)) *Database_GetTribeByUniqueName_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetTribeByUniqueName_Call) Return(_a0 db.Tribe) *Database_GetTribeByUniqueName_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetTribeByUniqueName_Call) RunAndReturn(run func(string) db.Tribe) *Database_GetTribeByUniqueName_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTribeEvent provides a mock function with given fields: uuid
+func (_m *Database) GetTribeEvent(uuid string) (db.TribeEvent, error) {
+	ret := _m.Called(uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTribeEvent")
+	}
+
+	var r0 db.TribeEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (db.TribeEvent, error)); ok {
+		return rf(uuid)
+	}
+	if rf, ok := ret.Get(0).(func(string) db.TribeEvent); ok {
+		r0 = rf(uuid)
+	} else {
+		r0 = ret.Get(0).(db.TribeEvent)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(uuid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetTribeEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTribeEvent'
+type Database_GetTribeEvent_Call struct {
+	*mock.Call
+}
+
+// GetTribeEvent is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) GetTribeEvent(uuid interface{}) *Database_GetTribeEvent_Call {
+	return &Database_GetTribeEvent_Call{Call: _e.mock.On("GetTribeEvent", uuid)}
+}
+
+func (_c *Database_GetTribeEvent_Call) Run(run func(uuid string)) *Database_GetTribeEvent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetTribeEvent_Call) Return(_a0 db.TribeEvent, _a1 error) *Database_GetTribeEvent_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetTribeEvent_Call) RunAndReturn(run func(string) (db.TribeEvent, error)) *Database_GetTribeEvent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTribeEvents provides a mock function with given fields: tribeUuid
+func (_m *Database) GetTribeEvents(tribeUuid string) []db.TribeEvent {
+	ret := _m.Called(tribeUuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTribeEvents")
+	}
+
+	var r0 []db.TribeEvent
+	if rf, ok := ret.Get(0).(func(string) []db.TribeEvent); ok {
+		r0 = rf(tribeUuid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.TribeEvent)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetTribeEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTribeEvents'
+type Database_GetTribeEvents_Call struct {
+	*mock.Call
+}
+
+// GetTribeEvents is a helper method to define mock.On call
+//   - tribeUuid string
+func (_e *Database_Expecter) GetTribeEvents(tribeUuid interface{}) *Database_GetTribeEvents_Call {
+	return &Database_GetTribeEvents_Call{Call: _e.mock.On("GetTribeEvents", tribeUuid)}
+}
+
+func (_c *Database_GetTribeEvents_Call) Run(run func(tribeUuid string)) *Database_GetTribeEvents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetTribeEvents_Call) Return(_a0 []db.TribeEvent) *Database_GetTribeEvents_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetTribeEvents_Call) RunAndReturn(run func(string) []db.TribeEvent) *Database_GetTribeEvents_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTribeMember provides a mock function with given fields: tribeUuid, pubkey
+func (_m *Database) GetTribeMember(tribeUuid string, pubkey string) db.TribeMember {
+	ret := _m.Called(tribeUuid, pubkey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTribeMember")
+	}
+
+	var r0 db.TribeMember
+	if rf, ok := ret.Get(0).(func(string, string) db.TribeMember); ok {
+		r0 = rf(tribeUuid, pubkey)
+	} else {
+		r0 = ret.Get(0).(db.TribeMember)
+	}
+
+	return r0
+}
+
+// Database_GetTribeMember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTribeMember'
+type Database_GetTribeMember_Call struct {
+	*mock.Call
+}
+
+// GetTribeMember is a helper method to define mock.On call
+//   - tribeUuid string
+//   - pubkey string
+func (_e *Database_Expecter) GetTribeMember(tribeUuid interface{}, pubkey interface{}) *Database_GetTribeMember_Call {
+	return &Database_GetTribeMember_Call{Call: _e.mock.On("GetTribeMember", tribeUuid, pubkey)}
+}
+
+func (_c *Database_GetTribeMember_Call) Run(run func(tribeUuid string, pubkey string)) *Database_GetTribeMember_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetTribeMember_Call) Return(_a0 db.TribeMember) *Database_GetTribeMember_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetTribeMember_Call) RunAndReturn(run func(string, string) db.TribeMember) *Database_GetTribeMember_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTribeMembers provides a mock function with given fields: tribeUuid, r
+func (_m *Database) GetTribeMembers(tribeUuid string, r *http.Request) ([]db.TribeMember, error) {
+	ret := _m.Called(tribeUuid, r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTribeMembers")
+	}
+
+	var r0 []db.TribeMember
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, *http.Request) ([]db.TribeMember, error)); ok {
+		return rf(tribeUuid, r)
+	}
+	if rf, ok := ret.Get(0).(func(string, *http.Request) []db.TribeMember); ok {
+		r0 = rf(tribeUuid, r)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.TribeMember)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, *http.Request) error); ok {
+		r1 = rf(tribeUuid, r)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetTribeMembers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTribeMembers'
+type Database_GetTribeMembers_Call struct {
+	*mock.Call
+}
+
+// GetTribeMembers is a helper method to define mock.On call
+//   - tribeUuid string
+//   - r *http.Request
+func (_e *Database_Expecter) GetTribeMembers(tribeUuid interface{}, r interface{}) *Database_GetTribeMembers_Call {
+	return &Database_GetTribeMembers_Call{Call: _e.mock.On("GetTribeMembers", tribeUuid, r)}
+}
+
+func (_c *Database_GetTribeMembers_Call) Run(run func(tribeUuid string, r *http.Request)) *Database_GetTribeMembers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *Database_GetTribeMembers_Call) Return(_a0 []db.TribeMember, _a1 error) *Database_GetTribeMembers_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetTribeMembers_Call) RunAndReturn(run func(string, *http.Request) ([]db.TribeMember, error)) *Database_GetTribeMembers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTribePreview provides a mock function with given fields: tribeUuid
+func (_m *Database) GetTribePreview(tribeUuid string) db.TribePreview {
+	ret := _m.Called(tribeUuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTribePreview")
+	}
+
+	var r0 db.TribePreview
+	if rf, ok := ret.Get(0).(func(string) db.TribePreview); ok {
+		r0 = rf(tribeUuid)
+	} else {
+		r0 = ret.Get(0).(db.TribePreview)
+	}
+
+	return r0
+}
+
+// Database_GetTribePreview_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTribePreview'
+type Database_GetTribePreview_Call struct {
+	*mock.Call
+}
+
+// GetTribePreview is a helper method to define mock.On call
+//   - tribeUuid string
+func (_e *Database_Expecter) GetTribePreview(tribeUuid interface{}) *Database_GetTribePreview_Call {
+	return &Database_GetTribePreview_Call{Call: _e.mock.On("GetTribePreview", tribeUuid)}
+}
+
+func (_c *Database_GetTribePreview_Call) Run(run func(tribeUuid string)) *Database_GetTribePreview_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetTribePreview_Call) Return(_a0 db.TribePreview) *Database_GetTribePreview_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetTribePreview_Call) RunAndReturn(run func(string) db.TribePreview) *Database_GetTribePreview_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTribeReportCounts provides a mock function with given fields:
+func (_m *Database) GetTribeReportCounts() (map[string]int64, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTribeReportCounts")
+	}
+
+	var r0 map[string]int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (map[string]int64, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() map[string]int64); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]int64)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetTribeReportCounts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTribeReportCounts'
+type Database_GetTribeReportCounts_Call struct {
+	*mock.Call
+}
+
+// GetTribeReportCounts is a helper method to define mock.On call
+func (_e *Database_Expecter) GetTribeReportCounts() *Database_GetTribeReportCounts_Call {
+	return &Database_GetTribeReportCounts_Call{Call: _e.mock.On("GetTribeReportCounts")}
+}
+
+func (_c *Database_GetTribeReportCounts_Call) Run(run func()) *Database_GetTribeReportCounts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Database_GetTribeReportCounts_Call) Return(_a0 map[string]int64, _a1 error) *Database_GetTribeReportCounts_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetTribeReportCounts_Call) RunAndReturn(run func() (map[string]int64, error)) *Database_GetTribeReportCounts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTribeUpdates provides a mock function with given fields: tribeUuid, limit
+func (_m *Database) GetTribeUpdates(tribeUuid string, limit int) db.TribeUpdates {
+	ret := _m.Called(tribeUuid, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTribeUpdates")
+	}
+
+	var r0 db.TribeUpdates
+	if rf, ok := ret.Get(0).(func(string, int) db.TribeUpdates); ok {
+		r0 = rf(tribeUuid, limit)
+	} else {
+		r0 = ret.Get(0).(db.TribeUpdates)
+	}
+
+	return r0
+}
+
+// Database_GetTribeUpdates_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTribeUpdates'
+type Database_GetTribeUpdates_Call struct {
+	*mock.Call
+}
+
+// GetTribeUpdates is a helper method to define mock.On call
+//   - tribeUuid string
+//   - limit int
+func (_e *Database_Expecter) GetTribeUpdates(tribeUuid interface{}, limit interface{}) *Database_GetTribeUpdates_Call {
+	return &Database_GetTribeUpdates_Call{Call: _e.mock.On("GetTribeUpdates", tribeUuid, limit)}
+}
+
+func (_c *Database_GetTribeUpdates_Call) Run(run func(tribeUuid string, limit int)) *Database_GetTribeUpdates_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *Database_GetTribeUpdates_Call) Return(_a0 db.TribeUpdates) *Database_GetTribeUpdates_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetTribeUpdates_Call) RunAndReturn(run func(string, int) db.TribeUpdates) *Database_GetTribeUpdates_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTribeWebhook provides a mock function with given fields: uuid
+func (_m *Database) GetTribeWebhook(uuid string) db.TribeWebhook {
+	ret := _m.Called(uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTribeWebhook")
+	}
+
+	var r0 db.TribeWebhook
+	if rf, ok := ret.Get(0).(func(string) db.TribeWebhook); ok {
+		r0 = rf(uuid)
+	} else {
+		r0 = ret.Get(0).(db.TribeWebhook)
+	}
+
+	return r0
+}
+
+// Database_GetTribeWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTribeWebhook'
+type Database_GetTribeWebhook_Call struct {
+	*mock.Call
+}
+
+// GetTribeWebhook is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) GetTribeWebhook(uuid interface{}) *Database_GetTribeWebhook_Call {
+	return &Database_GetTribeWebhook_Call{Call: _e.mock.On("GetTribeWebhook", uuid)}
+}
+
+func (_c *Database_GetTribeWebhook_Call) Run(run func(uuid string)) *Database_GetTribeWebhook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetTribeWebhook_Call) Return(_a0 db.TribeWebhook) *Database_GetTribeWebhook_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetTribeWebhook_Call) RunAndReturn(run func(string) db.TribeWebhook) *Database_GetTribeWebhook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTribeWebhooks provides a mock function with given fields: tribeUuid
+func (_m *Database) GetTribeWebhooks(tribeUuid string) ([]db.TribeWebhook, error) {
+	ret := _m.Called(tribeUuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTribeWebhooks")
+	}
+
+	var r0 []db.TribeWebhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]db.TribeWebhook, error)); ok {
+		return rf(tribeUuid)
+	}
+	if rf, ok := ret.Get(0).(func(string) []db.TribeWebhook); ok {
+		r0 = rf(tribeUuid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.TribeWebhook)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(tribeUuid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetTribeWebhooks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTribeWebhooks'
+type Database_GetTribeWebhooks_Call struct {
+	*mock.Call
+}
+
+// GetTribeWebhooks is a helper method to define mock.On call
+//   - tribeUuid string
+func (_e *Database_Expecter) GetTribeWebhooks(tribeUuid interface{}) *Database_GetTribeWebhooks_Call {
+	return &Database_GetTribeWebhooks_Call{Call: _e.mock.On("GetTribeWebhooks", tribeUuid)}
+}
+
+func (_c *Database_GetTribeWebhooks_Call) Run(run func(tribeUuid string)) *Database_GetTribeWebhooks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetTribeWebhooks_Call) Return(_a0 []db.TribeWebhook, _a1 error) *Database_GetTribeWebhooks_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetTribeWebhooks_Call) RunAndReturn(run func(string) ([]db.TribeWebhook, error)) *Database_GetTribeWebhooks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTribesByAppUrl provides a mock function with given fields: aurl
+func (_m *Database) GetTribesByAppUrl(aurl string) []db.Tribe {
+	ret := _m.Called(aurl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTribesByAppUrl")
+	}
+
+	var r0 []db.Tribe
+	if rf, ok := ret.Get(0).(func(string) []db.Tribe); ok {
+		r0 = rf(aurl)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Tribe)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetTribesByAppUrl_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTribesByAppUrl'
+type Database_GetTribesByAppUrl_Call struct {
+	*mock.Call
+}
+
+// GetTribesByAppUrl is a helper method to define mock.On call
+//   - aurl string
+func (_e *Database_Expecter) GetTribesByAppUrl(aurl interface{}) *Database_GetTribesByAppUrl_Call {
+	return &Database_GetTribesByAppUrl_Call{Call: _e.mock.On("GetTribesByAppUrl", aurl)}
+}
+
+func (_c *Database_GetTribesByAppUrl_Call) Run(run func(aurl string)) *Database_GetTribesByAppUrl_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetTribesByAppUrl_Call) Return(_a0 []db.Tribe) *Database_GetTribesByAppUrl_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetTribesByAppUrl_Call) RunAndReturn(run func(string) []db.Tribe) *Database_GetTribesByAppUrl_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTribesByOwner provides a mock function with given fields: pubkey
+func (_m *Database) GetTribesByOwner(pubkey string) []db.Tribe {
+	ret := _m.Called(pubkey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTribesByOwner")
+	}
+
+	var r0 []db.Tribe
+	if rf, ok := ret.Get(0).(func(string) []db.Tribe); ok {
+		r0 = rf(pubkey)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Tribe)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetTribesByOwner_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTribesByOwner'
+type Database_GetTribesByOwner_Call struct {
+	*mock.Call
+}
+
+// GetTribesByOwner is a helper method to define mock.On call
+//   - pubkey string
+func (_e *Database_Expecter) GetTribesByOwner(pubkey interface{}) *Database_GetTribesByOwner_Call {
+	return &Database_GetTribesByOwner_Call{Call: _e.mock.On("GetTribesByOwner", pubkey)}
+}
+
+func (_c *Database_GetTribesByOwner_Call) Run(run func(pubkey string)) *Database_GetTribesByOwner_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetTribesByOwner_Call) Return(_a0 []db.Tribe) *Database_GetTribesByOwner_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetTribesByOwner_Call) RunAndReturn(run func(string) []db.Tribe) *Database_GetTribesByOwner_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTribesByUuids provides a mock function with given fields: uuids
+func (_m *Database) GetTribesByUuids(uuids []string) []db.Tribe {
+	ret := _m.Called(uuids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTribesByUuids")
+	}
+
+	var r0 []db.Tribe
+	if rf, ok := ret.Get(0).(func([]string) []db.Tribe); ok {
+		r0 = rf(uuids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Tribe)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetTribesByUuids_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTribesByUuids'
+type Database_GetTribesByUuids_Call struct {
+	*mock.Call
+}
+
+// GetTribesByUuids is a helper method to define mock.On call
+//   - uuids []string
+func (_e *Database_Expecter) GetTribesByUuids(uuids interface{}) *Database_GetTribesByUuids_Call {
+	return &Database_GetTribesByUuids_Call{Call: _e.mock.On("GetTribesByUuids", uuids)}
+}
+
+func (_c *Database_GetTribesByUuids_Call) Run(run func(uuids []string)) *Database_GetTribesByUuids_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]string))
+	})
+	return _c
+}
+
+func (_c *Database_GetTribesByUuids_Call) Return(_a0 []db.Tribe) *Database_GetTribesByUuids_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetTribesByUuids_Call) RunAndReturn(run func([]string) []db.Tribe) *Database_GetTribesByUuids_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTribesCategories provides a mock function with given fields: uuids
+func (_m *Database) GetTribesCategories(uuids []string) (map[string][]string, error) {
+	ret := _m.Called(uuids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTribesCategories")
+	}
+
+	var r0 map[string][]string
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]string) (map[string][]string, error)); ok {
+		return rf(uuids)
+	}
+	if rf, ok := ret.Get(0).(func([]string) map[string][]string); ok {
+		r0 = rf(uuids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string][]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]string) error); ok {
+		r1 = rf(uuids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetTribesCategories_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTribesCategories'
+type Database_GetTribesCategories_Call struct {
+	*mock.Call
+}
+
+// GetTribesCategories is a helper method to define mock.On call
+//   - uuids []string
+func (_e *Database_Expecter) GetTribesCategories(uuids interface{}) *Database_GetTribesCategories_Call {
+	return &Database_GetTribesCategories_Call{Call: _e.mock.On("GetTribesCategories", uuids)}
+}
+
+func (_c *Database_GetTribesCategories_Call) Run(run func(uuids []string)) *Database_GetTribesCategories_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]string))
+	})
+	return _c
+}
+
+func (_c *Database_GetTribesCategories_Call) Return(_a0 map[string][]string, _a1 error) *Database_GetTribesCategories_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetTribesCategories_Call) RunAndReturn(run func([]string) (map[string][]string, error)) *Database_GetTribesCategories_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTribesTotal provides a mock function with given fields:
+func (_m *Database) GetTribesTotal() int64 {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTribesTotal")
+	}
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func() int64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	return r0
+}
+
+// Database_GetTribesTotal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTribesTotal'
+type Database_GetTribesTotal_Call struct {
+	*mock.Call
+}
+
+// GetTribesTotal is a helper method to define mock.On call
+func (_e *Database_Expecter) GetTribesTotal() *Database_GetTribesTotal_Call {
+	return &Database_GetTribesTotal_Call{Call: _e.mock.On("GetTribesTotal")}
+}
+
+func (_c *Database_GetTribesTotal_Call) Run(run func()) *Database_GetTribesTotal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Database_GetTribesTotal_Call) Return(_a0 int64) *Database_GetTribesTotal_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetTribesTotal_Call) RunAndReturn(run func() int64) *Database_GetTribesTotal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUnconfirmedGithub provides a mock function with given fields:
+func (_m *Database) GetUnconfirmedGithub() []db.Person {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUnconfirmedGithub")
+	}
+
+	var r0 []db.Person
+	if rf, ok := ret.Get(0).(func() []db.Person); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Person)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetUnconfirmedGithub_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUnconfirmedGithub'
+type Database_GetUnconfirmedGithub_Call struct {
+	*mock.Call
+}
+
+// GetUnconfirmedGithub is a helper method to define mock.On call
+func (_e *Database_Expecter) GetUnconfirmedGithub() *Database_GetUnconfirmedGithub_Call {
+	return &Database_GetUnconfirmedGithub_Call{Call: _e.mock.On("GetUnconfirmedGithub")}
+}
+
+func (_c *Database_GetUnconfirmedGithub_Call) Run(run func()) *Database_GetUnconfirmedGithub_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Database_GetUnconfirmedGithub_Call) Return(_a0 []db.Person) *Database_GetUnconfirmedGithub_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetUnconfirmedGithub_Call) RunAndReturn(run func() []db.Person) *Database_GetUnconfirmedGithub_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUnconfirmedTwitter provides a mock function with given fields:
+func (_m *Database) GetUnconfirmedTwitter() []db.Person {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUnconfirmedTwitter")
+	}
+
+	var r0 []db.Person
+	if rf, ok := ret.Get(0).(func() []db.Person); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Person)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetUnconfirmedTwitter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUnconfirmedTwitter'
+type Database_GetUnconfirmedTwitter_Call struct {
+	*mock.Call
+}
+
+// GetUnconfirmedTwitter is a helper method to define mock.On call
+func (_e *Database_Expecter) GetUnconfirmedTwitter() *Database_GetUnconfirmedTwitter_Call {
+	return &Database_GetUnconfirmedTwitter_Call{Call: _e.mock.On("GetUnconfirmedTwitter")}
+}
+
+func (_c *Database_GetUnconfirmedTwitter_Call) Run(run func()) *Database_GetUnconfirmedTwitter_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Database_GetUnconfirmedTwitter_Call) Return(_a0 []db.Person) *Database_GetUnconfirmedTwitter_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetUnconfirmedTwitter_Call) RunAndReturn(run func() []db.Person) *Database_GetUnconfirmedTwitter_Call {
+	_c.Call.Return(run)
+	return _c
 }
 
-// Database_DeleteInvoice_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteInvoice'
-type Database_DeleteInvoice_Call struct {
+// GetUploadChunks provides a mock function with given fields: uuid
+func (_m *Database) GetUploadChunks(uuid string) ([]db.UploadChunk, error) {
+	ret := _m.Called(uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUploadChunks")
+	}
+
+	var r0 []db.UploadChunk
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]db.UploadChunk, error)); ok {
+		return rf(uuid)
+	}
+	if rf, ok := ret.Get(0).(func(string) []db.UploadChunk); ok {
+		r0 = rf(uuid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.UploadChunk)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(uuid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetUploadChunks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUploadChunks'
+type Database_GetUploadChunks_Call struct {
 	*mock.Call
 }
 
-// DeleteInvoice is a helper method to define mock.On call
-//   - payment_request string
-func (_e *Database_Expecter) DeleteInvoice(payment_request interface{}) *Database_DeleteInvoice_Call {
-	return &Database_DeleteInvoice_Call{Call: _e.mock.On("DeleteInvoice", payment_request)}
+// GetUploadChunks is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) GetUploadChunks(uuid interface{}) *Database_GetUploadChunks_Call {
+	return &Database_GetUploadChunks_Call{Call: _e.mock.On("GetUploadChunks", uuid)}
 }
 
-func (_c *Database_DeleteInvoice_Call) Run(run func(payment_request string)) *Database_DeleteInvoice_Call {
+func (_c *Database_GetUploadChunks_Call) Run(run func(uuid string)) *Database_GetUploadChunks_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *Database_DeleteInvoice_Call) Return(_a0 db.NewInvoiceList) *Database_DeleteInvoice_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_GetUploadChunks_Call) Return(_a0 []db.UploadChunk, _a1 error) *Database_GetUploadChunks_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_DeleteInvoice_Call) RunAndReturn(run func(string) db.NewInvoiceList) *Database_DeleteInvoice_Call {
+func (_c *Database_GetUploadChunks_Call) RunAndReturn(run func(string) ([]db.UploadChunk, error)) *Database_GetUploadChunks_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteUserInvoiceData provides a mock function with given fields: payment_request
-func (_m *Database) DeleteUserInvoiceData(payment_request string) db.UserInvoiceData {
-	ret := _m.Called(payment_request)
+// GetUploadSession provides a mock function with given fields: uuid
+func (_m *Database) GetUploadSession(uuid string) (db.UploadSession, error) {
+	ret := _m.Called(uuid)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteUserInvoiceData")
+		panic("no return value specified for GetUploadSession")
 	}
 
-	var r0 db.UserInvoiceData
-	if rf, ok := ret.Get(0).(func(string) db.UserInvoiceData); ok {
-		r0 = rf(payment_request)
+	var r0 db.UploadSession
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (db.UploadSession, error)); ok {
+		return rf(uuid)
+	}
+	if rf, ok := ret.Get(0).(func(string) db.UploadSession); ok {
+		r0 = rf(uuid)
 	} else {
-		r0 = ret.Get(0).(db.UserInvoiceData)
+		r0 = ret.Get(0).(db.UploadSession)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(uuid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_DeleteUserInvoiceData_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteUserInvoiceData'
-type Database_DeleteUserInvoiceData_Call struct {
+// Database_GetUploadSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUploadSession'
+type Database_GetUploadSession_Call struct {
 	*mock.Call
 }
 
-// DeleteUserInvoiceData is a helper method to define mock.On call
-//   - payment_request string
-func (_e *Database_Expecter) DeleteUserInvoiceData(payment_request interface{}) *Database_DeleteUserInvoiceData_Call {
-	return &Database_DeleteUserInvoiceData_Call{Call: _e.mock.On("DeleteUserInvoiceData", payment_request)}
+// GetUploadSession is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) GetUploadSession(uuid interface{}) *Database_GetUploadSession_Call {
+	return &Database_GetUploadSession_Call{Call: _e.mock.On("GetUploadSession", uuid)}
 }
 
-func (_c *Database_DeleteUserInvoiceData_Call) Run(run func(payment_request string)) *Database_DeleteUserInvoiceData_Call {
+func (_c *Database_GetUploadSession_Call) Run(run func(uuid string)) *Database_GetUploadSession_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *Database_DeleteUserInvoiceData_Call) Return(_a0 db.UserInvoiceData) *Database_DeleteUserInvoiceData_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_GetUploadSession_Call) Return(_a0 db.UploadSession, _a1 error) *Database_GetUploadSession_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_DeleteUserInvoiceData_Call) RunAndReturn(run func(string) db.UserInvoiceData) *Database_DeleteUserInvoiceData_Call {
+func (_c *Database_GetUploadSession_Call) RunAndReturn(run func(string) (db.UploadSession, error)) *Database_GetUploadSession_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteWorkspaceRepository provides a mock function with given fields: workspace_uuid, uuid
-func (_m *Database) DeleteWorkspaceRepository(workspace_uuid string, uuid string) bool {
-	ret := _m.Called(workspace_uuid, uuid)
+// GetUserActivity provides a mock function with given fields: pubkey, types, r
+func (_m *Database) GetUserActivity(pubkey string, types []string, r *http.Request) ([]db.Event, error) {
+	ret := _m.Called(pubkey, types, r)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteWorkspaceRepository")
+		panic("no return value specified for GetUserActivity")
 	}
 
-	var r0 bool
-	if rf, ok := ret.Get(0).(func(string, string) bool); ok {
-		r0 = rf(workspace_uuid, uuid)
+	var r0 []db.Event
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, []string, *http.Request) ([]db.Event, error)); ok {
+		return rf(pubkey, types, r)
+	}
+	if rf, ok := ret.Get(0).(func(string, []string, *http.Request) []db.Event); ok {
+		r0 = rf(pubkey, types, r)
 	} else {
-		r0 = ret.Get(0).(bool)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Event)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(string, []string, *http.Request) error); ok {
+		r1 = rf(pubkey, types, r)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_DeleteWorkspaceRepository_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteWorkspaceRepository'
-type Database_DeleteWorkspaceRepository_Call struct {
+// Database_GetUserActivity_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserActivity'
+type Database_GetUserActivity_Call struct {
 	*mock.Call
 }
 
-// DeleteWorkspaceRepository is a helper method to define mock.On call
-//   - workspace_uuid string
-//   - uuid string
-func (_e *Database_Expecter) DeleteWorkspaceRepository(workspace_uuid interface{}, uuid interface{}) *Database_DeleteWorkspaceRepository_Call {
-	return &Database_DeleteWorkspaceRepository_Call{Call: _e.mock.On("DeleteWorkspaceRepository", workspace_uuid, uuid)}
+// GetUserActivity is a helper method to define mock.On call
+//   - pubkey string
+//   - types []string
+//   - r *http.Request
+func (_e *Database_Expecter) GetUserActivity(pubkey interface{}, types interface{}, r interface{}) *Database_GetUserActivity_Call {
+	return &Database_GetUserActivity_Call{Call: _e.mock.On("GetUserActivity", pubkey, types, r)}
 }
 
-func (_c *Database_DeleteWorkspaceRepository_Call) Run(run func(workspace_uuid string, uuid string)) *Database_DeleteWorkspaceRepository_Call {
+func (_c *Database_GetUserActivity_Call) Run(run func(pubkey string, types []string, r *http.Request)) *Database_GetUserActivity_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string))
+		run(args[0].(string), args[1].([]string), args[2].(*http.Request))
 	})
 	return _c
 }
 
-func (_c *Database_DeleteWorkspaceRepository_Call) Return(_a0 bool) *Database_DeleteWorkspaceRepository_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_GetUserActivity_Call) Return(_a0 []db.Event, _a1 error) *Database_GetUserActivity_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_DeleteWorkspaceRepository_Call) RunAndReturn(run func(string, string) bool) *Database_DeleteWorkspaceRepository_Call {
+func (_c *Database_GetUserActivity_Call) RunAndReturn(run func(string, []string, *http.Request) ([]db.Event, error)) *Database_GetUserActivity_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteWorkspaceUser provides a mock function with given fields: orgUser, org
-func (_m *Database) DeleteWorkspaceUser(orgUser db.WorkspaceUsersData, org string) db.WorkspaceUsersData {
-	ret := _m.Called(orgUser, org)
+// GetUserActivityCount provides a mock function with given fields: pubkey, types
+func (_m *Database) GetUserActivityCount(pubkey string, types []string) int64 {
+	ret := _m.Called(pubkey, types)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteWorkspaceUser")
+		panic("no return value specified for GetUserActivityCount")
 	}
 
-	var r0 db.WorkspaceUsersData
-	if rf, ok := ret.Get(0).(func(db.WorkspaceUsersData, string) db.WorkspaceUsersData); ok {
-		r0 = rf(orgUser, org)
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(string, []string) int64); ok {
+		r0 = rf(pubkey, types)
 	} else {
-		r0 = ret.Get(0).(db.WorkspaceUsersData)
+		r0 = ret.Get(0).(int64)
 	}
 
 	return r0
 }
 
-// Database_DeleteWorkspaceUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteWorkspaceUser'
-type Database_DeleteWorkspaceUser_Call struct {
+// Database_GetUserActivityCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserActivityCount'
+type Database_GetUserActivityCount_Call struct {
 	*mock.Call
 }
 
-// DeleteWorkspaceUser is a helper method to define mock.On call
-//   - orgUser db.WorkspaceUsersData
-//   - org string
-func (_e *Database_Expecter) DeleteWorkspaceUser(orgUser interface{}, org interface{}) *Database_DeleteWorkspaceUser_Call {
-	return &Database_DeleteWorkspaceUser_Call{Call: _e.mock.On("DeleteWorkspaceUser", orgUser, org)}
+// GetUserActivityCount is a helper method to define mock.On call
+//   - pubkey string
+//   - types []string
+func (_e *Database_Expecter) GetUserActivityCount(pubkey interface{}, types interface{}) *Database_GetUserActivityCount_Call {
+	return &Database_GetUserActivityCount_Call{Call: _e.mock.On("GetUserActivityCount", pubkey, types)}
 }
 
-func (_c *Database_DeleteWorkspaceUser_Call) Run(run func(orgUser db.WorkspaceUsersData, org string)) *Database_DeleteWorkspaceUser_Call {
+func (_c *Database_GetUserActivityCount_Call) Run(run func(pubkey string, types []string)) *Database_GetUserActivityCount_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.WorkspaceUsersData), args[1].(string))
+		run(args[0].(string), args[1].([]string))
 	})
 	return _c
 }
 
-func (_c *Database_DeleteWorkspaceUser_Call) Return(_a0 db.WorkspaceUsersData) *Database_DeleteWorkspaceUser_Call {
+func (_c *Database_GetUserActivityCount_Call) Return(_a0 int64) *Database_GetUserActivityCount_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_DeleteWorkspaceUser_Call) RunAndReturn(run func(db.WorkspaceUsersData, string) db.WorkspaceUsersData) *Database_DeleteWorkspaceUser_Call {
+func (_c *Database_GetUserActivityCount_Call) RunAndReturn(run func(string, []string) int64) *Database_GetUserActivityCount_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetAllBounties provides a mock function with given fields: r
-func (_m *Database) GetAllBounties(r *http.Request) []db.NewBounty {
-	ret := _m.Called(r)
+// GetUserAssignedWorkspaces provides a mock function with given fields: pubkey
+func (_m *Database) GetUserAssignedWorkspaces(pubkey string) []db.WorkspaceUsers {
+	ret := _m.Called(pubkey)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetAllBounties")
+		panic("no return value specified for GetUserAssignedWorkspaces")
 	}
 
-	var r0 []db.NewBounty
-	if rf, ok := ret.Get(0).(func(*http.Request) []db.NewBounty); ok {
-		r0 = rf(r)
+	var r0 []db.WorkspaceUsers
+	if rf, ok := ret.Get(0).(func(string) []db.WorkspaceUsers); ok {
+		r0 = rf(pubkey)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.NewBounty)
+			r0 = ret.Get(0).([]db.WorkspaceUsers)
 		}
 	}
 
 	return r0
 }
 
-// Database_GetAllBounties_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllBounties'
-type Database_GetAllBounties_Call struct {
+// Database_GetUserAssignedWorkspaces_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserAssignedWorkspaces'
+type Database_GetUserAssignedWorkspaces_Call struct {
 	*mock.Call
 }
 
-// GetAllBounties is a helper method to define mock.On call
-//   - r *http.Request
-func (_e *Database_Expecter) GetAllBounties(r interface{}) *Database_GetAllBounties_Call {
-	return &Database_GetAllBounties_Call{Call: _e.mock.On("GetAllBounties", r)}
+// GetUserAssignedWorkspaces is a helper method to define mock.On call
+//   - pubkey string
+func (_e *Database_Expecter) GetUserAssignedWorkspaces(pubkey interface{}) *Database_GetUserAssignedWorkspaces_Call {
+	return &Database_GetUserAssignedWorkspaces_Call{Call: _e.mock.On("GetUserAssignedWorkspaces", pubkey)}
 }
 
-func (_c *Database_GetAllBounties_Call) Run(run func(r *http.Request)) *Database_GetAllBounties_Call {
+func (_c *Database_GetUserAssignedWorkspaces_Call) Run(run func(pubkey string)) *Database_GetUserAssignedWorkspaces_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*http.Request))
+		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetAllBounties_Call) Return(_a0 []db.NewBounty) *Database_GetAllBounties_Call {
+func (_c *Database_GetUserAssignedWorkspaces_Call) Return(_a0 []db.WorkspaceUsers) *Database_GetUserAssignedWorkspaces_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetAllBounties_Call) RunAndReturn(run func(*http.Request) []db.NewBounty) *Database_GetAllBounties_Call {
+func (_c *Database_GetUserAssignedWorkspaces_Call) RunAndReturn(run func(string) []db.WorkspaceUsers) *Database_GetUserAssignedWorkspaces_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetAllTribes provides a mock function with given fields:
-func (_m *Database) GetAllTribes() []db.Tribe {
-	ret := _m.Called()
+// GetUserBountiesCount provides a mock function with given fields: personKey, tabType
+func (_m *Database) GetUserBountiesCount(personKey string, tabType string) int64 {
+	ret := _m.Called(personKey, tabType)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetAllTribes")
+		panic("no return value specified for GetUserBountiesCount")
 	}
 
-	var r0 []db.Tribe
-	if rf, ok := ret.Get(0).(func() []db.Tribe); ok {
-		r0 = rf()
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(string, string) int64); ok {
+		r0 = rf(personKey, tabType)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.Tribe)
-		}
+		r0 = ret.Get(0).(int64)
 	}
 
 	return r0
 }
 
-// Database_GetAllTribes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllTribes'
-type Database_GetAllTribes_Call struct {
+// Database_GetUserBountiesCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserBountiesCount'
+type Database_GetUserBountiesCount_Call struct {
 	*mock.Call
 }
 
-// GetAllTribes is a helper method to define mock.On call
-func (_e *Database_Expecter) GetAllTribes() *Database_GetAllTribes_Call {
-	return &Database_GetAllTribes_Call{Call: _e.mock.On("GetAllTribes")}
+// GetUserBountiesCount is a helper method to define mock.On call
+//   - personKey string
+//   - tabType string
+func (_e *Database_Expecter) GetUserBountiesCount(personKey interface{}, tabType interface{}) *Database_GetUserBountiesCount_Call {
+	return &Database_GetUserBountiesCount_Call{Call: _e.mock.On("GetUserBountiesCount", personKey, tabType)}
 }
 
-func (_c *Database_GetAllTribes_Call) Run(run func()) *Database_GetAllTribes_Call {
+func (_c *Database_GetUserBountiesCount_Call) Run(run func(personKey string, tabType string)) *Database_GetUserBountiesCount_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		run(args[0].(string), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetAllTribes_Call) Return(_a0 []db.Tribe) *Database_GetAllTribes_Call {
+func (_c *Database_GetUserBountiesCount_Call) Return(_a0 int64) *Database_GetUserBountiesCount_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetAllTribes_Call) RunAndReturn(run func() []db.Tribe) *Database_GetAllTribes_Call {
+func (_c *Database_GetUserBountiesCount_Call) RunAndReturn(run func(string, string) int64) *Database_GetUserBountiesCount_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetAllTribesByOwner provides a mock function with given fields: pubkey
-func (_m *Database) GetAllTribesByOwner(pubkey string) []db.Tribe {
+// GetUserCreatedWorkspaces provides a mock function with given fields: pubkey
+func (_m *Database) GetUserCreatedWorkspaces(pubkey string) []db.Workspace {
 	ret := _m.Called(pubkey)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetAllTribesByOwner")
+		panic("no return value specified for GetUserCreatedWorkspaces")
 	}
 
-	var r0 []db.Tribe
-	if rf, ok := ret.Get(0).(func(string) []db.Tribe); ok {
+	var r0 []db.Workspace
+	if rf, ok := ret.Get(0).(func(string) []db.Workspace); ok {
 		r0 = rf(pubkey)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.Tribe)
+			r0 = ret.Get(0).([]db.Workspace)
 		}
 	}
 
 	return r0
 }
 
-// Database_GetAllTribesByOwner_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllTribesByOwner'
-type Database_GetAllTribesByOwner_Call struct {
+// Database_GetUserCreatedWorkspaces_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserCreatedWorkspaces'
+type Database_GetUserCreatedWorkspaces_Call struct {
 	*mock.Call
 }
 
-// GetAllTribesByOwner is a helper method to define mock.On call
+// GetUserCreatedWorkspaces is a helper method to define mock.On call
 //   - pubkey string
-func (_e *Database_Expecter) GetAllTribesByOwner(pubkey interface{}) *Database_GetAllTribesByOwner_Call {
-	return &Database_GetAllTribesByOwner_Call{Call: _e.mock.On("GetAllTribesByOwner", pubkey)}
+func (_e *Database_Expecter) GetUserCreatedWorkspaces(pubkey interface{}) *Database_GetUserCreatedWorkspaces_Call {
+	return &Database_GetUserCreatedWorkspaces_Call{Call: _e.mock.On("GetUserCreatedWorkspaces", pubkey)}
 }
 
-func (_c *Database_GetAllTribesByOwner_Call) Run(run func(pubkey string)) *Database_GetAllTribesByOwner_Call {
+func (_c *Database_GetUserCreatedWorkspaces_Call) Run(run func(pubkey string)) *Database_GetUserCreatedWorkspaces_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetAllTribesByOwner_Call) Return(_a0 []db.Tribe) *Database_GetAllTribesByOwner_Call {
+func (_c *Database_GetUserCreatedWorkspaces_Call) Return(_a0 []db.Workspace) *Database_GetUserCreatedWorkspaces_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetAllTribesByOwner_Call) RunAndReturn(run func(string) []db.Tribe) *Database_GetAllTribesByOwner_Call {
+func (_c *Database_GetUserCreatedWorkspaces_Call) RunAndReturn(run func(string) []db.Workspace) *Database_GetUserCreatedWorkspaces_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetAssignedBounties provides a mock function with given fields: r
-func (_m *Database) GetAssignedBounties(r *http.Request) ([]db.NewBounty, error) {
-	ret := _m.Called(r)
+// GetUserEventSubjects provides a mock function with given fields: pubkey, after, upTo
+func (_m *Database) GetUserEventSubjects(pubkey string, after uint, upTo uint) ([]string, error) {
+	ret := _m.Called(pubkey, after, upTo)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetAssignedBounties")
+		panic("no return value specified for GetUserEventSubjects")
 	}
 
-	var r0 []db.NewBounty
+	var r0 []string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*http.Request) ([]db.NewBounty, error)); ok {
-		return rf(r)
+	if rf, ok := ret.Get(0).(func(string, uint, uint) ([]string, error)); ok {
+		return rf(pubkey, after, upTo)
 	}
-	if rf, ok := ret.Get(0).(func(*http.Request) []db.NewBounty); ok {
-		r0 = rf(r)
+	if rf, ok := ret.Get(0).(func(string, uint, uint) []string); ok {
+		r0 = rf(pubkey, after, upTo)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.NewBounty)
+			r0 = ret.Get(0).([]string)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(*http.Request) error); ok {
-		r1 = rf(r)
+	if rf, ok := ret.Get(1).(func(string, uint, uint) error); ok {
+		r1 = rf(pubkey, after, upTo)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -2066,234 +14130,235 @@ func (_m *Database) GetAssignedBounties(r *http.Request) ([]db.NewBounty, error)
 	return r0, r1
 }
 
-// Database_GetAssignedBounties_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAssignedBounties'
-type Database_GetAssignedBounties_Call struct {
+// Database_GetUserEventSubjects_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserEventSubjects'
+type Database_GetUserEventSubjects_Call struct {
 	*mock.Call
 }
 
-// GetAssignedBounties is a helper method to define mock.On call
-//   - r *http.Request
-func (_e *Database_Expecter) GetAssignedBounties(r interface{}) *Database_GetAssignedBounties_Call {
-	return &Database_GetAssignedBounties_Call{Call: _e.mock.On("GetAssignedBounties", r)}
+// GetUserEventSubjects is a helper method to define mock.On call
+//   - pubkey string
+//   - after uint
+//   - upTo uint
+func (_e *Database_Expecter) GetUserEventSubjects(pubkey interface{}, after interface{}, upTo interface{}) *Database_GetUserEventSubjects_Call {
+	return &Database_GetUserEventSubjects_Call{Call: _e.mock.On("GetUserEventSubjects", pubkey, after, upTo)}
 }
 
-func (_c *Database_GetAssignedBounties_Call) Run(run func(r *http.Request)) *Database_GetAssignedBounties_Call {
+func (_c *Database_GetUserEventSubjects_Call) Run(run func(pubkey string, after uint, upTo uint)) *Database_GetUserEventSubjects_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*http.Request))
+		run(args[0].(string), args[1].(uint), args[2].(uint))
 	})
 	return _c
 }
 
-func (_c *Database_GetAssignedBounties_Call) Return(_a0 []db.NewBounty, _a1 error) *Database_GetAssignedBounties_Call {
+func (_c *Database_GetUserEventSubjects_Call) Return(_a0 []string, _a1 error) *Database_GetUserEventSubjects_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetAssignedBounties_Call) RunAndReturn(run func(*http.Request) ([]db.NewBounty, error)) *Database_GetAssignedBounties_Call {
+func (_c *Database_GetUserEventSubjects_Call) RunAndReturn(run func(string, uint, uint) ([]string, error)) *Database_GetUserEventSubjects_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetBot provides a mock function with given fields: uuid
-func (_m *Database) GetBot(uuid string) db.Bot {
-	ret := _m.Called(uuid)
-
+// GetUserInvoiceData provides a mock function with given fields: payment_request
+func (_m *Database) GetUserInvoiceData(payment_request string) db.UserInvoiceData {
+	ret := _m.Called(payment_request)
+
 	if len(ret) == 0 {
-		panic("no return value specified for GetBot")
+		panic("no return value specified for GetUserInvoiceData")
 	}
 
-	var r0 db.Bot
-	if rf, ok := ret.Get(0).(func(string) db.Bot); ok {
-		r0 = rf(uuid)
+	var r0 db.UserInvoiceData
+	if rf, ok := ret.Get(0).(func(string) db.UserInvoiceData); ok {
+		r0 = rf(payment_request)
 	} else {
-		r0 = ret.Get(0).(db.Bot)
+		r0 = ret.Get(0).(db.UserInvoiceData)
 	}
 
 	return r0
 }
 
-// Database_GetBot_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBot'
-type Database_GetBot_Call struct {
+// Database_GetUserInvoiceData_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserInvoiceData'
+type Database_GetUserInvoiceData_Call struct {
 	*mock.Call
 }
 
-// GetBot is a helper method to define mock.On call
-//   - uuid string
-func (_e *Database_Expecter) GetBot(uuid interface{}) *Database_GetBot_Call {
-	return &Database_GetBot_Call{Call: _e.mock.On("GetBot", uuid)}
+// GetUserInvoiceData is a helper method to define mock.On call
+//   - payment_request string
+func (_e *Database_Expecter) GetUserInvoiceData(payment_request interface{}) *Database_GetUserInvoiceData_Call {
+	return &Database_GetUserInvoiceData_Call{Call: _e.mock.On("GetUserInvoiceData", payment_request)}
 }
 
-func (_c *Database_GetBot_Call) Run(run func(uuid string)) *Database_GetBot_Call {
+func (_c *Database_GetUserInvoiceData_Call) Run(run func(payment_request string)) *Database_GetUserInvoiceData_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetBot_Call) Return(_a0 db.Bot) *Database_GetBot_Call {
+func (_c *Database_GetUserInvoiceData_Call) Return(_a0 db.UserInvoiceData) *Database_GetUserInvoiceData_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetBot_Call) RunAndReturn(run func(string) db.Bot) *Database_GetBot_Call {
+func (_c *Database_GetUserInvoiceData_Call) RunAndReturn(run func(string) db.UserInvoiceData) *Database_GetUserInvoiceData_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetBotByUniqueName provides a mock function with given fields: un
-func (_m *Database) GetBotByUniqueName(un string) db.Bot {
-	ret := _m.Called(un)
+// GetWorkspaceAllocatedBudget provides a mock function with given fields: workspaceUuid
+func (_m *Database) GetWorkspaceAllocatedBudget(workspaceUuid string) uint {
+	ret := _m.Called(workspaceUuid)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetBotByUniqueName")
+		panic("no return value specified for GetWorkspaceAllocatedBudget")
 	}
 
-	var r0 db.Bot
-	if rf, ok := ret.Get(0).(func(string) db.Bot); ok {
-		r0 = rf(un)
+	var r0 uint
+	if rf, ok := ret.Get(0).(func(string) uint); ok {
+		r0 = rf(workspaceUuid)
 	} else {
-		r0 = ret.Get(0).(db.Bot)
+		r0 = ret.Get(0).(uint)
 	}
 
 	return r0
 }
 
-// Database_GetBotByUniqueName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBotByUniqueName'
-type Database_GetBotByUniqueName_Call struct {
+// Database_GetWorkspaceAllocatedBudget_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceAllocatedBudget'
+type Database_GetWorkspaceAllocatedBudget_Call struct {
 	*mock.Call
 }
 
-// GetBotByUniqueName is a helper method to define mock.On call
-//   - un string
-func (_e *Database_Expecter) GetBotByUniqueName(un interface{}) *Database_GetBotByUniqueName_Call {
-	return &Database_GetBotByUniqueName_Call{Call: _e.mock.On("GetBotByUniqueName", un)}
+// GetWorkspaceAllocatedBudget is a helper method to define mock.On call
+//   - workspaceUuid string
+func (_e *Database_Expecter) GetWorkspaceAllocatedBudget(workspaceUuid interface{}) *Database_GetWorkspaceAllocatedBudget_Call {
+	return &Database_GetWorkspaceAllocatedBudget_Call{Call: _e.mock.On("GetWorkspaceAllocatedBudget", workspaceUuid)}
 }
 
-func (_c *Database_GetBotByUniqueName_Call) Run(run func(un string)) *Database_GetBotByUniqueName_Call {
+func (_c *Database_GetWorkspaceAllocatedBudget_Call) Run(run func(workspaceUuid string)) *Database_GetWorkspaceAllocatedBudget_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetBotByUniqueName_Call) Return(_a0 db.Bot) *Database_GetBotByUniqueName_Call {
+func (_c *Database_GetWorkspaceAllocatedBudget_Call) Return(_a0 uint) *Database_GetWorkspaceAllocatedBudget_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetBotByUniqueName_Call) RunAndReturn(run func(string) db.Bot) *Database_GetBotByUniqueName_Call {
+func (_c *Database_GetWorkspaceAllocatedBudget_Call) RunAndReturn(run func(string) uint) *Database_GetWorkspaceAllocatedBudget_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetBotsByOwner provides a mock function with given fields: pubkey
-func (_m *Database) GetBotsByOwner(pubkey string) []db.Bot {
-	ret := _m.Called(pubkey)
+// GetWorkspaceBounties provides a mock function with given fields: r, workspace_uuid
+func (_m *Database) GetWorkspaceBounties(r *http.Request, workspace_uuid string) []db.NewBounty {
+	ret := _m.Called(r, workspace_uuid)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetBotsByOwner")
+		panic("no return value specified for GetWorkspaceBounties")
 	}
 
-	var r0 []db.Bot
-	if rf, ok := ret.Get(0).(func(string) []db.Bot); ok {
-		r0 = rf(pubkey)
+	var r0 []db.NewBounty
+	if rf, ok := ret.Get(0).(func(*http.Request, string) []db.NewBounty); ok {
+		r0 = rf(r, workspace_uuid)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.Bot)
+			r0 = ret.Get(0).([]db.NewBounty)
 		}
 	}
 
 	return r0
 }
 
-// Database_GetBotsByOwner_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBotsByOwner'
-type Database_GetBotsByOwner_Call struct {
+// Database_GetWorkspaceBounties_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceBounties'
+type Database_GetWorkspaceBounties_Call struct {
 	*mock.Call
 }
 
-// GetBotsByOwner is a helper method to define mock.On call
-//   - pubkey string
-func (_e *Database_Expecter) GetBotsByOwner(pubkey interface{}) *Database_GetBotsByOwner_Call {
-	return &Database_GetBotsByOwner_Call{Call: _e.mock.On("GetBotsByOwner", pubkey)}
+// GetWorkspaceBounties is a helper method to define mock.On call
+//   - r *http.Request
+//   - workspace_uuid string
+func (_e *Database_Expecter) GetWorkspaceBounties(r interface{}, workspace_uuid interface{}) *Database_GetWorkspaceBounties_Call {
+	return &Database_GetWorkspaceBounties_Call{Call: _e.mock.On("GetWorkspaceBounties", r, workspace_uuid)}
 }
 
-func (_c *Database_GetBotsByOwner_Call) Run(run func(pubkey string)) *Database_GetBotsByOwner_Call {
+func (_c *Database_GetWorkspaceBounties_Call) Run(run func(r *http.Request, workspace_uuid string)) *Database_GetWorkspaceBounties_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(*http.Request), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetBotsByOwner_Call) Return(_a0 []db.Bot) *Database_GetBotsByOwner_Call {
+func (_c *Database_GetWorkspaceBounties_Call) Return(_a0 []db.NewBounty) *Database_GetWorkspaceBounties_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetBotsByOwner_Call) RunAndReturn(run func(string) []db.Bot) *Database_GetBotsByOwner_Call {
+func (_c *Database_GetWorkspaceBounties_Call) RunAndReturn(run func(*http.Request, string) []db.NewBounty) *Database_GetWorkspaceBounties_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetBountiesByDateRange provides a mock function with given fields: r, re
-func (_m *Database) GetBountiesByDateRange(r db.PaymentDateRange, re *http.Request) []db.NewBounty {
-	ret := _m.Called(r, re)
+// GetWorkspaceBountiesCount provides a mock function with given fields: r, workspace_uuid
+func (_m *Database) GetWorkspaceBountiesCount(r *http.Request, workspace_uuid string) int64 {
+	ret := _m.Called(r, workspace_uuid)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetBountiesByDateRange")
+		panic("no return value specified for GetWorkspaceBountiesCount")
 	}
 
-	var r0 []db.NewBounty
-	if rf, ok := ret.Get(0).(func(db.PaymentDateRange, *http.Request) []db.NewBounty); ok {
-		r0 = rf(r, re)
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(*http.Request, string) int64); ok {
+		r0 = rf(r, workspace_uuid)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.NewBounty)
-		}
+		r0 = ret.Get(0).(int64)
 	}
 
 	return r0
 }
 
-// Database_GetBountiesByDateRange_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountiesByDateRange'
-type Database_GetBountiesByDateRange_Call struct {
+// Database_GetWorkspaceBountiesCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceBountiesCount'
+type Database_GetWorkspaceBountiesCount_Call struct {
 	*mock.Call
 }
 
-// GetBountiesByDateRange is a helper method to define mock.On call
-//   - r db.PaymentDateRange
-//   - re *http.Request
-func (_e *Database_Expecter) GetBountiesByDateRange(r interface{}, re interface{}) *Database_GetBountiesByDateRange_Call {
-	return &Database_GetBountiesByDateRange_Call{Call: _e.mock.On("GetBountiesByDateRange", r, re)}
+// GetWorkspaceBountiesCount is a helper method to define mock.On call
+//   - r *http.Request
+//   - workspace_uuid string
+func (_e *Database_Expecter) GetWorkspaceBountiesCount(r interface{}, workspace_uuid interface{}) *Database_GetWorkspaceBountiesCount_Call {
+	return &Database_GetWorkspaceBountiesCount_Call{Call: _e.mock.On("GetWorkspaceBountiesCount", r, workspace_uuid)}
 }
 
-func (_c *Database_GetBountiesByDateRange_Call) Run(run func(r db.PaymentDateRange, re *http.Request)) *Database_GetBountiesByDateRange_Call {
+func (_c *Database_GetWorkspaceBountiesCount_Call) Run(run func(r *http.Request, workspace_uuid string)) *Database_GetWorkspaceBountiesCount_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.PaymentDateRange), args[1].(*http.Request))
+		run(args[0].(*http.Request), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetBountiesByDateRange_Call) Return(_a0 []db.NewBounty) *Database_GetBountiesByDateRange_Call {
+func (_c *Database_GetWorkspaceBountiesCount_Call) Return(_a0 int64) *Database_GetWorkspaceBountiesCount_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetBountiesByDateRange_Call) RunAndReturn(run func(db.PaymentDateRange, *http.Request) []db.NewBounty) *Database_GetBountiesByDateRange_Call {
+func (_c *Database_GetWorkspaceBountiesCount_Call) RunAndReturn(run func(*http.Request, string) int64) *Database_GetWorkspaceBountiesCount_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetBountiesByDateRangeCount provides a mock function with given fields: r, re
-func (_m *Database) GetBountiesByDateRangeCount(r db.PaymentDateRange, re *http.Request) int64 {
-	ret := _m.Called(r, re)
+// GetWorkspaceBountyCount provides a mock function with given fields: uuid
+func (_m *Database) GetWorkspaceBountyCount(uuid string) int64 {
+	ret := _m.Called(uuid)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetBountiesByDateRangeCount")
+		panic("no return value specified for GetWorkspaceBountyCount")
 	}
 
 	var r0 int64
-	if rf, ok := ret.Get(0).(func(db.PaymentDateRange, *http.Request) int64); ok {
-		r0 = rf(r, re)
+	if rf, ok := ret.Get(0).(func(string) int64); ok {
+		r0 = rf(uuid)
 	} else {
 		r0 = ret.Get(0).(int64)
 	}
@@ -2301,58 +14366,55 @@ func (_m *Database) GetBountiesByDateRangeCount(r db.PaymentDateRange, re *http.
 	return r0
 }
 
-// Database_GetBountiesByDateRangeCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountiesByDateRangeCount'
-type Database_GetBountiesByDateRangeCount_Call struct {
+// Database_GetWorkspaceBountyCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceBountyCount'
+type Database_GetWorkspaceBountyCount_Call struct {
 	*mock.Call
 }
 
-// GetBountiesByDateRangeCount is a helper method to define mock.On call
-//   - r db.PaymentDateRange
-//   - re *http.Request
-func (_e *Database_Expecter) GetBountiesByDateRangeCount(r interface{}, re interface{}) *Database_GetBountiesByDateRangeCount_Call {
-	return &Database_GetBountiesByDateRangeCount_Call{Call: _e.mock.On("GetBountiesByDateRangeCount", r, re)}
+// GetWorkspaceBountyCount is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) GetWorkspaceBountyCount(uuid interface{}) *Database_GetWorkspaceBountyCount_Call {
+	return &Database_GetWorkspaceBountyCount_Call{Call: _e.mock.On("GetWorkspaceBountyCount", uuid)}
 }
 
-func (_c *Database_GetBountiesByDateRangeCount_Call) Run(run func(r db.PaymentDateRange, re *http.Request)) *Database_GetBountiesByDateRangeCount_Call {
+func (_c *Database_GetWorkspaceBountyCount_Call) Run(run func(uuid string)) *Database_GetWorkspaceBountyCount_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.PaymentDateRange), args[1].(*http.Request))
+		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetBountiesByDateRangeCount_Call) Return(_a0 int64) *Database_GetBountiesByDateRangeCount_Call {
+func (_c *Database_GetWorkspaceBountyCount_Call) Return(_a0 int64) *Database_GetWorkspaceBountyCount_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetBountiesByDateRangeCount_Call) RunAndReturn(run func(db.PaymentDateRange, *http.Request) int64) *Database_GetBountiesByDateRangeCount_Call {
+func (_c *Database_GetWorkspaceBountyCount_Call) RunAndReturn(run func(string) int64) *Database_GetWorkspaceBountyCount_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetBountiesByFeatureAndPhaseUuid provides a mock function with given fields: featureUuid, phaseUuid, r
-func (_m *Database) GetBountiesByFeatureAndPhaseUuid(featureUuid string, phaseUuid string, r *http.Request) ([]db.NewBounty, error) {
-	ret := _m.Called(featureUuid, phaseUuid, r)
+// GetWorkspaceBrief provides a mock function with given fields: uuid
+func (_m *Database) GetWorkspaceBrief(uuid string) (db.WorkspaceBrief, error) {
+	ret := _m.Called(uuid)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetBountiesByFeatureAndPhaseUuid")
+		panic("no return value specified for GetWorkspaceBrief")
 	}
 
-	var r0 []db.NewBounty
+	var r0 db.WorkspaceBrief
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string, *http.Request) ([]db.NewBounty, error)); ok {
-		return rf(featureUuid, phaseUuid, r)
+	if rf, ok := ret.Get(0).(func(string) (db.WorkspaceBrief, error)); ok {
+		return rf(uuid)
 	}
-	if rf, ok := ret.Get(0).(func(string, string, *http.Request) []db.NewBounty); ok {
-		r0 = rf(featureUuid, phaseUuid, r)
+	if rf, ok := ret.Get(0).(func(string) db.WorkspaceBrief); ok {
+		r0 = rf(uuid)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.NewBounty)
-		}
+		r0 = ret.Get(0).(db.WorkspaceBrief)
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string, *http.Request) error); ok {
-		r1 = rf(featureUuid, phaseUuid, r)
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(uuid)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -2360,341 +14422,404 @@ func (_m *Database) GetBountiesByFeatureAndPhaseUuid(featureUuid string, phaseUu
 	return r0, r1
 }
 
-// Database_GetBountiesByFeatureAndPhaseUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountiesByFeatureAndPhaseUuid'
-type Database_GetBountiesByFeatureAndPhaseUuid_Call struct {
+// Database_GetWorkspaceBrief_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceBrief'
+type Database_GetWorkspaceBrief_Call struct {
 	*mock.Call
 }
 
-// GetBountiesByFeatureAndPhaseUuid is a helper method to define mock.On call
-//   - featureUuid string
-//   - phaseUuid string
-//   - r *http.Request
-func (_e *Database_Expecter) GetBountiesByFeatureAndPhaseUuid(featureUuid interface{}, phaseUuid interface{}, r interface{}) *Database_GetBountiesByFeatureAndPhaseUuid_Call {
-	return &Database_GetBountiesByFeatureAndPhaseUuid_Call{Call: _e.mock.On("GetBountiesByFeatureAndPhaseUuid", featureUuid, phaseUuid, r)}
+// GetWorkspaceBrief is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) GetWorkspaceBrief(uuid interface{}) *Database_GetWorkspaceBrief_Call {
+	return &Database_GetWorkspaceBrief_Call{Call: _e.mock.On("GetWorkspaceBrief", uuid)}
 }
 
-func (_c *Database_GetBountiesByFeatureAndPhaseUuid_Call) Run(run func(featureUuid string, phaseUuid string, r *http.Request)) *Database_GetBountiesByFeatureAndPhaseUuid_Call {
+func (_c *Database_GetWorkspaceBrief_Call) Run(run func(uuid string)) *Database_GetWorkspaceBrief_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string), args[2].(*http.Request))
+		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetBountiesByFeatureAndPhaseUuid_Call) Return(_a0 []db.NewBounty, _a1 error) *Database_GetBountiesByFeatureAndPhaseUuid_Call {
+func (_c *Database_GetWorkspaceBrief_Call) Return(_a0 db.WorkspaceBrief, _a1 error) *Database_GetWorkspaceBrief_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetBountiesByFeatureAndPhaseUuid_Call) RunAndReturn(run func(string, string, *http.Request) ([]db.NewBounty, error)) *Database_GetBountiesByFeatureAndPhaseUuid_Call {
+func (_c *Database_GetWorkspaceBrief_Call) RunAndReturn(run func(string) (db.WorkspaceBrief, error)) *Database_GetWorkspaceBrief_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetBountiesByPhaseUuid provides a mock function with given fields: phaseUuid
-func (_m *Database) GetBountiesByPhaseUuid(phaseUuid string) []db.Bounty {
-	ret := _m.Called(phaseUuid)
+// GetWorkspaceBriefs provides a mock function with given fields: workspaceUuid
+func (_m *Database) GetWorkspaceBriefs(workspaceUuid string) []db.WorkspaceBrief {
+	ret := _m.Called(workspaceUuid)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetBountiesByPhaseUuid")
+		panic("no return value specified for GetWorkspaceBriefs")
 	}
 
-	var r0 []db.Bounty
-	if rf, ok := ret.Get(0).(func(string) []db.Bounty); ok {
-		r0 = rf(phaseUuid)
+	var r0 []db.WorkspaceBrief
+	if rf, ok := ret.Get(0).(func(string) []db.WorkspaceBrief); ok {
+		r0 = rf(workspaceUuid)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.Bounty)
+			r0 = ret.Get(0).([]db.WorkspaceBrief)
 		}
 	}
 
 	return r0
 }
 
-// Database_GetBountiesByPhaseUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountiesByPhaseUuid'
-type Database_GetBountiesByPhaseUuid_Call struct {
+// Database_GetWorkspaceBriefs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceBriefs'
+type Database_GetWorkspaceBriefs_Call struct {
 	*mock.Call
 }
 
-// GetBountiesByPhaseUuid is a helper method to define mock.On call
-//   - phaseUuid string
-func (_e *Database_Expecter) GetBountiesByPhaseUuid(phaseUuid interface{}) *Database_GetBountiesByPhaseUuid_Call {
-	return &Database_GetBountiesByPhaseUuid_Call{Call: _e.mock.On("GetBountiesByPhaseUuid", phaseUuid)}
+// GetWorkspaceBriefs is a helper method to define mock.On call
+//   - workspaceUuid string
+func (_e *Database_Expecter) GetWorkspaceBriefs(workspaceUuid interface{}) *Database_GetWorkspaceBriefs_Call {
+	return &Database_GetWorkspaceBriefs_Call{Call: _e.mock.On("GetWorkspaceBriefs", workspaceUuid)}
 }
 
-func (_c *Database_GetBountiesByPhaseUuid_Call) Run(run func(phaseUuid string)) *Database_GetBountiesByPhaseUuid_Call {
+func (_c *Database_GetWorkspaceBriefs_Call) Run(run func(workspaceUuid string)) *Database_GetWorkspaceBriefs_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetBountiesByPhaseUuid_Call) Return(_a0 []db.Bounty) *Database_GetBountiesByPhaseUuid_Call {
+func (_c *Database_GetWorkspaceBriefs_Call) Return(_a0 []db.WorkspaceBrief) *Database_GetWorkspaceBriefs_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetBountiesByPhaseUuid_Call) RunAndReturn(run func(string) []db.Bounty) *Database_GetBountiesByPhaseUuid_Call {
+func (_c *Database_GetWorkspaceBriefs_Call) RunAndReturn(run func(string) []db.WorkspaceBrief) *Database_GetWorkspaceBriefs_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetBountiesCount provides a mock function with given fields: r
-func (_m *Database) GetBountiesCount(r *http.Request) int64 {
-	ret := _m.Called(r)
+// GetWorkspaceBudget provides a mock function with given fields: workspace_uuid
+func (_m *Database) GetWorkspaceBudget(workspace_uuid string) db.NewBountyBudget {
+	ret := _m.Called(workspace_uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWorkspaceBudget")
+	}
+
+	var r0 db.NewBountyBudget
+	if rf, ok := ret.Get(0).(func(string) db.NewBountyBudget); ok {
+		r0 = rf(workspace_uuid)
+	} else {
+		r0 = ret.Get(0).(db.NewBountyBudget)
+	}
+
+	return r0
+}
+
+// Database_GetWorkspaceBudget_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceBudget'
+type Database_GetWorkspaceBudget_Call struct {
+	*mock.Call
+}
+
+// GetWorkspaceBudget is a helper method to define mock.On call
+//   - workspace_uuid string
+func (_e *Database_Expecter) GetWorkspaceBudget(workspace_uuid interface{}) *Database_GetWorkspaceBudget_Call {
+	return &Database_GetWorkspaceBudget_Call{Call: _e.mock.On("GetWorkspaceBudget", workspace_uuid)}
+}
+
+func (_c *Database_GetWorkspaceBudget_Call) Run(run func(workspace_uuid string)) *Database_GetWorkspaceBudget_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetWorkspaceBudget_Call) Return(_a0 db.NewBountyBudget) *Database_GetWorkspaceBudget_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetWorkspaceBudget_Call) RunAndReturn(run func(string) db.NewBountyBudget) *Database_GetWorkspaceBudget_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWorkspaceBudgetHistory provides a mock function with given fields: workspace_uuid
+func (_m *Database) GetWorkspaceBudgetHistory(workspace_uuid string) []db.BudgetHistoryData {
+	ret := _m.Called(workspace_uuid)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetBountiesCount")
+		panic("no return value specified for GetWorkspaceBudgetHistory")
 	}
 
-	var r0 int64
-	if rf, ok := ret.Get(0).(func(*http.Request) int64); ok {
-		r0 = rf(r)
+	var r0 []db.BudgetHistoryData
+	if rf, ok := ret.Get(0).(func(string) []db.BudgetHistoryData); ok {
+		r0 = rf(workspace_uuid)
 	} else {
-		r0 = ret.Get(0).(int64)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.BudgetHistoryData)
+		}
 	}
 
 	return r0
 }
 
-// Database_GetBountiesCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountiesCount'
-type Database_GetBountiesCount_Call struct {
+// Database_GetWorkspaceBudgetHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceBudgetHistory'
+type Database_GetWorkspaceBudgetHistory_Call struct {
 	*mock.Call
 }
 
-// GetBountiesCount is a helper method to define mock.On call
-//   - r *http.Request
-func (_e *Database_Expecter) GetBountiesCount(r interface{}) *Database_GetBountiesCount_Call {
-	return &Database_GetBountiesCount_Call{Call: _e.mock.On("GetBountiesCount", r)}
+// GetWorkspaceBudgetHistory is a helper method to define mock.On call
+//   - workspace_uuid string
+func (_e *Database_Expecter) GetWorkspaceBudgetHistory(workspace_uuid interface{}) *Database_GetWorkspaceBudgetHistory_Call {
+	return &Database_GetWorkspaceBudgetHistory_Call{Call: _e.mock.On("GetWorkspaceBudgetHistory", workspace_uuid)}
 }
 
-func (_c *Database_GetBountiesCount_Call) Run(run func(r *http.Request)) *Database_GetBountiesCount_Call {
+func (_c *Database_GetWorkspaceBudgetHistory_Call) Run(run func(workspace_uuid string)) *Database_GetWorkspaceBudgetHistory_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*http.Request))
+		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetBountiesCount_Call) Return(_a0 int64) *Database_GetBountiesCount_Call {
+func (_c *Database_GetWorkspaceBudgetHistory_Call) Return(_a0 []db.BudgetHistoryData) *Database_GetWorkspaceBudgetHistory_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetBountiesCount_Call) RunAndReturn(run func(*http.Request) int64) *Database_GetBountiesCount_Call {
+func (_c *Database_GetWorkspaceBudgetHistory_Call) RunAndReturn(run func(string) []db.BudgetHistoryData) *Database_GetWorkspaceBudgetHistory_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetBountiesCountByFeatureAndPhaseUuid provides a mock function with given fields: featureUuid, phaseUuid, r
-func (_m *Database) GetBountiesCountByFeatureAndPhaseUuid(featureUuid string, phaseUuid string, r *http.Request) int64 {
-	ret := _m.Called(featureUuid, phaseUuid, r)
+// GetWorkspaceBundle provides a mock function with given fields: workspaceUuid
+func (_m *Database) GetWorkspaceBundle(workspaceUuid string) (db.WorkspaceBundle, error) {
+	ret := _m.Called(workspaceUuid)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetBountiesCountByFeatureAndPhaseUuid")
+		panic("no return value specified for GetWorkspaceBundle")
 	}
 
-	var r0 int64
-	if rf, ok := ret.Get(0).(func(string, string, *http.Request) int64); ok {
-		r0 = rf(featureUuid, phaseUuid, r)
+	var r0 db.WorkspaceBundle
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (db.WorkspaceBundle, error)); ok {
+		return rf(workspaceUuid)
+	}
+	if rf, ok := ret.Get(0).(func(string) db.WorkspaceBundle); ok {
+		r0 = rf(workspaceUuid)
 	} else {
-		r0 = ret.Get(0).(int64)
+		r0 = ret.Get(0).(db.WorkspaceBundle)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(workspaceUuid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_GetBountiesCountByFeatureAndPhaseUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountiesCountByFeatureAndPhaseUuid'
-type Database_GetBountiesCountByFeatureAndPhaseUuid_Call struct {
+// Database_GetWorkspaceBundle_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceBundle'
+type Database_GetWorkspaceBundle_Call struct {
 	*mock.Call
 }
 
-// GetBountiesCountByFeatureAndPhaseUuid is a helper method to define mock.On call
-//   - featureUuid string
-//   - phaseUuid string
-//   - r *http.Request
-func (_e *Database_Expecter) GetBountiesCountByFeatureAndPhaseUuid(featureUuid interface{}, phaseUuid interface{}, r interface{}) *Database_GetBountiesCountByFeatureAndPhaseUuid_Call {
-	return &Database_GetBountiesCountByFeatureAndPhaseUuid_Call{Call: _e.mock.On("GetBountiesCountByFeatureAndPhaseUuid", featureUuid, phaseUuid, r)}
+// GetWorkspaceBundle is a helper method to define mock.On call
+//   - workspaceUuid string
+func (_e *Database_Expecter) GetWorkspaceBundle(workspaceUuid interface{}) *Database_GetWorkspaceBundle_Call {
+	return &Database_GetWorkspaceBundle_Call{Call: _e.mock.On("GetWorkspaceBundle", workspaceUuid)}
 }
 
-func (_c *Database_GetBountiesCountByFeatureAndPhaseUuid_Call) Run(run func(featureUuid string, phaseUuid string, r *http.Request)) *Database_GetBountiesCountByFeatureAndPhaseUuid_Call {
+func (_c *Database_GetWorkspaceBundle_Call) Run(run func(workspaceUuid string)) *Database_GetWorkspaceBundle_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string), args[2].(*http.Request))
+		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetBountiesCountByFeatureAndPhaseUuid_Call) Return(_a0 int64) *Database_GetBountiesCountByFeatureAndPhaseUuid_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_GetWorkspaceBundle_Call) Return(_a0 db.WorkspaceBundle, _a1 error) *Database_GetWorkspaceBundle_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetBountiesCountByFeatureAndPhaseUuid_Call) RunAndReturn(run func(string, string, *http.Request) int64) *Database_GetBountiesCountByFeatureAndPhaseUuid_Call {
+func (_c *Database_GetWorkspaceBundle_Call) RunAndReturn(run func(string) (db.WorkspaceBundle, error)) *Database_GetWorkspaceBundle_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetBountiesLeaderboard provides a mock function with given fields:
-func (_m *Database) GetBountiesLeaderboard() []db.LeaderData {
-	ret := _m.Called()
+// GetWorkspaceByName provides a mock function with given fields: name
+func (_m *Database) GetWorkspaceByName(name string) db.Workspace {
+	ret := _m.Called(name)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetBountiesLeaderboard")
+		panic("no return value specified for GetWorkspaceByName")
 	}
 
-	var r0 []db.LeaderData
-	if rf, ok := ret.Get(0).(func() []db.LeaderData); ok {
-		r0 = rf()
+	var r0 db.Workspace
+	if rf, ok := ret.Get(0).(func(string) db.Workspace); ok {
+		r0 = rf(name)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.LeaderData)
-		}
+		r0 = ret.Get(0).(db.Workspace)
 	}
 
 	return r0
 }
 
-// Database_GetBountiesLeaderboard_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountiesLeaderboard'
-type Database_GetBountiesLeaderboard_Call struct {
+// Database_GetWorkspaceByName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceByName'
+type Database_GetWorkspaceByName_Call struct {
 	*mock.Call
 }
 
-// GetBountiesLeaderboard is a helper method to define mock.On call
-func (_e *Database_Expecter) GetBountiesLeaderboard() *Database_GetBountiesLeaderboard_Call {
-	return &Database_GetBountiesLeaderboard_Call{Call: _e.mock.On("GetBountiesLeaderboard")}
+// GetWorkspaceByName is a helper method to define mock.On call
+//   - name string
+func (_e *Database_Expecter) GetWorkspaceByName(name interface{}) *Database_GetWorkspaceByName_Call {
+	return &Database_GetWorkspaceByName_Call{Call: _e.mock.On("GetWorkspaceByName", name)}
 }
 
-func (_c *Database_GetBountiesLeaderboard_Call) Run(run func()) *Database_GetBountiesLeaderboard_Call {
+func (_c *Database_GetWorkspaceByName_Call) Run(run func(name string)) *Database_GetWorkspaceByName_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetBountiesLeaderboard_Call) Return(_a0 []db.LeaderData) *Database_GetBountiesLeaderboard_Call {
+func (_c *Database_GetWorkspaceByName_Call) Return(_a0 db.Workspace) *Database_GetWorkspaceByName_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetBountiesLeaderboard_Call) RunAndReturn(run func() []db.LeaderData) *Database_GetBountiesLeaderboard_Call {
+func (_c *Database_GetWorkspaceByName_Call) RunAndReturn(run func(string) db.Workspace) *Database_GetWorkspaceByName_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetBountiesProviders provides a mock function with given fields: r, re
-func (_m *Database) GetBountiesProviders(r db.PaymentDateRange, re *http.Request) []db.Person {
-	ret := _m.Called(r, re)
+// GetWorkspaceByUuid provides a mock function with given fields: uuid
+func (_m *Database) GetWorkspaceByUuid(uuid string) db.Workspace {
+	ret := _m.Called(uuid)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetBountiesProviders")
+		panic("no return value specified for GetWorkspaceByUuid")
 	}
 
-	var r0 []db.Person
-	if rf, ok := ret.Get(0).(func(db.PaymentDateRange, *http.Request) []db.Person); ok {
-		r0 = rf(r, re)
+	var r0 db.Workspace
+	if rf, ok := ret.Get(0).(func(string) db.Workspace); ok {
+		r0 = rf(uuid)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.Person)
-		}
+		r0 = ret.Get(0).(db.Workspace)
 	}
 
 	return r0
 }
 
-// Database_GetBountiesProviders_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountiesProviders'
-type Database_GetBountiesProviders_Call struct {
+// Database_GetWorkspaceByUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceByUuid'
+type Database_GetWorkspaceByUuid_Call struct {
 	*mock.Call
 }
 
-// GetBountiesProviders is a helper method to define mock.On call
-//   - r db.PaymentDateRange
-//   - re *http.Request
-func (_e *Database_Expecter) GetBountiesProviders(r interface{}, re interface{}) *Database_GetBountiesProviders_Call {
-	return &Database_GetBountiesProviders_Call{Call: _e.mock.On("GetBountiesProviders", r, re)}
+// GetWorkspaceByUuid is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) GetWorkspaceByUuid(uuid interface{}) *Database_GetWorkspaceByUuid_Call {
+	return &Database_GetWorkspaceByUuid_Call{Call: _e.mock.On("GetWorkspaceByUuid", uuid)}
 }
 
-func (_c *Database_GetBountiesProviders_Call) Run(run func(r db.PaymentDateRange, re *http.Request)) *Database_GetBountiesProviders_Call {
+func (_c *Database_GetWorkspaceByUuid_Call) Run(run func(uuid string)) *Database_GetWorkspaceByUuid_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.PaymentDateRange), args[1].(*http.Request))
+		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetBountiesProviders_Call) Return(_a0 []db.Person) *Database_GetBountiesProviders_Call {
+func (_c *Database_GetWorkspaceByUuid_Call) Return(_a0 db.Workspace) *Database_GetWorkspaceByUuid_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetBountiesProviders_Call) RunAndReturn(run func(db.PaymentDateRange, *http.Request) []db.Person) *Database_GetBountiesProviders_Call {
+func (_c *Database_GetWorkspaceByUuid_Call) RunAndReturn(run func(string) db.Workspace) *Database_GetWorkspaceByUuid_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetBounty provides a mock function with given fields: id
-func (_m *Database) GetBounty(id uint) db.NewBounty {
-	ret := _m.Called(id)
+// GetWorkspaceDigests provides a mock function with given fields: workspaceUuid, r
+func (_m *Database) GetWorkspaceDigests(workspaceUuid string, r *http.Request) ([]db.WorkspaceDigest, error) {
+	ret := _m.Called(workspaceUuid, r)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetBounty")
+		panic("no return value specified for GetWorkspaceDigests")
 	}
 
-	var r0 db.NewBounty
-	if rf, ok := ret.Get(0).(func(uint) db.NewBounty); ok {
-		r0 = rf(id)
+	var r0 []db.WorkspaceDigest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, *http.Request) ([]db.WorkspaceDigest, error)); ok {
+		return rf(workspaceUuid, r)
+	}
+	if rf, ok := ret.Get(0).(func(string, *http.Request) []db.WorkspaceDigest); ok {
+		r0 = rf(workspaceUuid, r)
 	} else {
-		r0 = ret.Get(0).(db.NewBounty)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.WorkspaceDigest)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(string, *http.Request) error); ok {
+		r1 = rf(workspaceUuid, r)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_GetBounty_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBounty'
-type Database_GetBounty_Call struct {
+// Database_GetWorkspaceDigests_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceDigests'
+type Database_GetWorkspaceDigests_Call struct {
 	*mock.Call
 }
 
-// GetBounty is a helper method to define mock.On call
-//   - id uint
-func (_e *Database_Expecter) GetBounty(id interface{}) *Database_GetBounty_Call {
-	return &Database_GetBounty_Call{Call: _e.mock.On("GetBounty", id)}
+// GetWorkspaceDigests is a helper method to define mock.On call
+//   - workspaceUuid string
+//   - r *http.Request
+func (_e *Database_Expecter) GetWorkspaceDigests(workspaceUuid interface{}, r interface{}) *Database_GetWorkspaceDigests_Call {
+	return &Database_GetWorkspaceDigests_Call{Call: _e.mock.On("GetWorkspaceDigests", workspaceUuid, r)}
 }
 
-func (_c *Database_GetBounty_Call) Run(run func(id uint)) *Database_GetBounty_Call {
+func (_c *Database_GetWorkspaceDigests_Call) Run(run func(workspaceUuid string, r *http.Request)) *Database_GetWorkspaceDigests_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(uint))
+		run(args[0].(string), args[1].(*http.Request))
 	})
 	return _c
 }
 
-func (_c *Database_GetBounty_Call) Return(_a0 db.NewBounty) *Database_GetBounty_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_GetWorkspaceDigests_Call) Return(_a0 []db.WorkspaceDigest, _a1 error) *Database_GetWorkspaceDigests_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetBounty_Call) RunAndReturn(run func(uint) db.NewBounty) *Database_GetBounty_Call {
+func (_c *Database_GetWorkspaceDigests_Call) RunAndReturn(run func(string, *http.Request) ([]db.WorkspaceDigest, error)) *Database_GetWorkspaceDigests_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetBountyByCreated provides a mock function with given fields: created
-func (_m *Database) GetBountyByCreated(created uint) (db.NewBounty, error) {
-	ret := _m.Called(created)
+// GetWorkspaceExport provides a mock function with given fields: workspaceUuid, uuid
+func (_m *Database) GetWorkspaceExport(workspaceUuid string, uuid string) (db.WorkspaceExport, error) {
+	ret := _m.Called(workspaceUuid, uuid)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetBountyByCreated")
+		panic("no return value specified for GetWorkspaceExport")
 	}
 
-	var r0 db.NewBounty
+	var r0 db.WorkspaceExport
 	var r1 error
-	if rf, ok := ret.Get(0).(func(uint) (db.NewBounty, error)); ok {
-		return rf(created)
+	if rf, ok := ret.Get(0).(func(string, string) (db.WorkspaceExport, error)); ok {
+		return rf(workspaceUuid, uuid)
 	}
-	if rf, ok := ret.Get(0).(func(uint) db.NewBounty); ok {
-		r0 = rf(created)
+	if rf, ok := ret.Get(0).(func(string, string) db.WorkspaceExport); ok {
+		r0 = rf(workspaceUuid, uuid)
 	} else {
-		r0 = ret.Get(0).(db.NewBounty)
+		r0 = ret.Get(0).(db.WorkspaceExport)
 	}
 
-	if rf, ok := ret.Get(1).(func(uint) error); ok {
-		r1 = rf(created)
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(workspaceUuid, uuid)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -2702,161 +14827,140 @@ func (_m *Database) GetBountyByCreated(created uint) (db.NewBounty, error) {
 	return r0, r1
 }
 
-// Database_GetBountyByCreated_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountyByCreated'
-type Database_GetBountyByCreated_Call struct {
+// Database_GetWorkspaceExport_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceExport'
+type Database_GetWorkspaceExport_Call struct {
 	*mock.Call
 }
 
-// GetBountyByCreated is a helper method to define mock.On call
-//   - created uint
-func (_e *Database_Expecter) GetBountyByCreated(created interface{}) *Database_GetBountyByCreated_Call {
-	return &Database_GetBountyByCreated_Call{Call: _e.mock.On("GetBountyByCreated", created)}
+// GetWorkspaceExport is a helper method to define mock.On call
+//   - workspaceUuid string
+//   - uuid string
+func (_e *Database_Expecter) GetWorkspaceExport(workspaceUuid interface{}, uuid interface{}) *Database_GetWorkspaceExport_Call {
+	return &Database_GetWorkspaceExport_Call{Call: _e.mock.On("GetWorkspaceExport", workspaceUuid, uuid)}
 }
 
-func (_c *Database_GetBountyByCreated_Call) Run(run func(created uint)) *Database_GetBountyByCreated_Call {
+func (_c *Database_GetWorkspaceExport_Call) Run(run func(workspaceUuid string, uuid string)) *Database_GetWorkspaceExport_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(uint))
+		run(args[0].(string), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetBountyByCreated_Call) Return(_a0 db.NewBounty, _a1 error) *Database_GetBountyByCreated_Call {
+func (_c *Database_GetWorkspaceExport_Call) Return(_a0 db.WorkspaceExport, _a1 error) *Database_GetWorkspaceExport_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetBountyByCreated_Call) RunAndReturn(run func(uint) (db.NewBounty, error)) *Database_GetBountyByCreated_Call {
+func (_c *Database_GetWorkspaceExport_Call) RunAndReturn(run func(string, string) (db.WorkspaceExport, error)) *Database_GetWorkspaceExport_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetBountyById provides a mock function with given fields: id
-func (_m *Database) GetBountyById(id string) ([]db.NewBounty, error) {
-	ret := _m.Called(id)
+// GetWorkspaceFeaturesCount provides a mock function with given fields: uuid
+func (_m *Database) GetWorkspaceFeaturesCount(uuid string) int64 {
+	ret := _m.Called(uuid)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetBountyById")
-	}
-
-	var r0 []db.NewBounty
-	var r1 error
-	if rf, ok := ret.Get(0).(func(string) ([]db.NewBounty, error)); ok {
-		return rf(id)
-	}
-	if rf, ok := ret.Get(0).(func(string) []db.NewBounty); ok {
-		r0 = rf(id)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.NewBounty)
-		}
+		panic("no return value specified for GetWorkspaceFeaturesCount")
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(id)
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(string) int64); ok {
+		r0 = rf(uuid)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Get(0).(int64)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// Database_GetBountyById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountyById'
-type Database_GetBountyById_Call struct {
+// Database_GetWorkspaceFeaturesCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceFeaturesCount'
+type Database_GetWorkspaceFeaturesCount_Call struct {
 	*mock.Call
 }
 
-// GetBountyById is a helper method to define mock.On call
-//   - id string
-func (_e *Database_Expecter) GetBountyById(id interface{}) *Database_GetBountyById_Call {
-	return &Database_GetBountyById_Call{Call: _e.mock.On("GetBountyById", id)}
+// GetWorkspaceFeaturesCount is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) GetWorkspaceFeaturesCount(uuid interface{}) *Database_GetWorkspaceFeaturesCount_Call {
+	return &Database_GetWorkspaceFeaturesCount_Call{Call: _e.mock.On("GetWorkspaceFeaturesCount", uuid)}
 }
 
-func (_c *Database_GetBountyById_Call) Run(run func(id string)) *Database_GetBountyById_Call {
+func (_c *Database_GetWorkspaceFeaturesCount_Call) Run(run func(uuid string)) *Database_GetWorkspaceFeaturesCount_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetBountyById_Call) Return(_a0 []db.NewBounty, _a1 error) *Database_GetBountyById_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *Database_GetWorkspaceFeaturesCount_Call) Return(_a0 int64) *Database_GetWorkspaceFeaturesCount_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetBountyById_Call) RunAndReturn(run func(string) ([]db.NewBounty, error)) *Database_GetBountyById_Call {
+func (_c *Database_GetWorkspaceFeaturesCount_Call) RunAndReturn(run func(string) int64) *Database_GetWorkspaceFeaturesCount_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetBountyDataByCreated provides a mock function with given fields: created
-func (_m *Database) GetBountyDataByCreated(created string) ([]db.NewBounty, error) {
-	ret := _m.Called(created)
+// GetWorkspaceInvoices provides a mock function with given fields: workspace_uuid
+func (_m *Database) GetWorkspaceInvoices(workspace_uuid string) []db.NewInvoiceList {
+	ret := _m.Called(workspace_uuid)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetBountyDataByCreated")
+		panic("no return value specified for GetWorkspaceInvoices")
 	}
 
-	var r0 []db.NewBounty
-	var r1 error
-	if rf, ok := ret.Get(0).(func(string) ([]db.NewBounty, error)); ok {
-		return rf(created)
-	}
-	if rf, ok := ret.Get(0).(func(string) []db.NewBounty); ok {
-		r0 = rf(created)
+	var r0 []db.NewInvoiceList
+	if rf, ok := ret.Get(0).(func(string) []db.NewInvoiceList); ok {
+		r0 = rf(workspace_uuid)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.NewBounty)
+			r0 = ret.Get(0).([]db.NewInvoiceList)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(created)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
+	return r0
 }
 
-// Database_GetBountyDataByCreated_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountyDataByCreated'
-type Database_GetBountyDataByCreated_Call struct {
+// Database_GetWorkspaceInvoices_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceInvoices'
+type Database_GetWorkspaceInvoices_Call struct {
 	*mock.Call
 }
 
-// GetBountyDataByCreated is a helper method to define mock.On call
-//   - created string
-func (_e *Database_Expecter) GetBountyDataByCreated(created interface{}) *Database_GetBountyDataByCreated_Call {
-	return &Database_GetBountyDataByCreated_Call{Call: _e.mock.On("GetBountyDataByCreated", created)}
+// GetWorkspaceInvoices is a helper method to define mock.On call
+//   - workspace_uuid string
+func (_e *Database_Expecter) GetWorkspaceInvoices(workspace_uuid interface{}) *Database_GetWorkspaceInvoices_Call {
+	return &Database_GetWorkspaceInvoices_Call{Call: _e.mock.On("GetWorkspaceInvoices", workspace_uuid)}
 }
 
-func (_c *Database_GetBountyDataByCreated_Call) Run(run func(created string)) *Database_GetBountyDataByCreated_Call {
+func (_c *Database_GetWorkspaceInvoices_Call) Run(run func(workspace_uuid string)) *Database_GetWorkspaceInvoices_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetBountyDataByCreated_Call) Return(_a0 []db.NewBounty, _a1 error) *Database_GetBountyDataByCreated_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *Database_GetWorkspaceInvoices_Call) Return(_a0 []db.NewInvoiceList) *Database_GetWorkspaceInvoices_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetBountyDataByCreated_Call) RunAndReturn(run func(string) ([]db.NewBounty, error)) *Database_GetBountyDataByCreated_Call {
+func (_c *Database_GetWorkspaceInvoices_Call) RunAndReturn(run func(string) []db.NewInvoiceList) *Database_GetWorkspaceInvoices_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetBountyIndexById provides a mock function with given fields: id
-func (_m *Database) GetBountyIndexById(id string) int64 {
-	ret := _m.Called(id)
+// GetWorkspaceInvoicesCount provides a mock function with given fields: workspace_uuid
+func (_m *Database) GetWorkspaceInvoicesCount(workspace_uuid string) int64 {
+	ret := _m.Called(workspace_uuid)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetBountyIndexById")
+		panic("no return value specified for GetWorkspaceInvoicesCount")
 	}
 
 	var r0 int64
 	if rf, ok := ret.Get(0).(func(string) int64); ok {
-		r0 = rf(id)
+		r0 = rf(workspace_uuid)
 	} else {
 		r0 = ret.Get(0).(int64)
 	}
@@ -2864,243 +14968,276 @@ func (_m *Database) GetBountyIndexById(id string) int64 {
 	return r0
 }
 
-// Database_GetBountyIndexById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountyIndexById'
-type Database_GetBountyIndexById_Call struct {
+// Database_GetWorkspaceInvoicesCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceInvoicesCount'
+type Database_GetWorkspaceInvoicesCount_Call struct {
 	*mock.Call
 }
 
-// GetBountyIndexById is a helper method to define mock.On call
-//   - id string
-func (_e *Database_Expecter) GetBountyIndexById(id interface{}) *Database_GetBountyIndexById_Call {
-	return &Database_GetBountyIndexById_Call{Call: _e.mock.On("GetBountyIndexById", id)}
+// GetWorkspaceInvoicesCount is a helper method to define mock.On call
+//   - workspace_uuid string
+func (_e *Database_Expecter) GetWorkspaceInvoicesCount(workspace_uuid interface{}) *Database_GetWorkspaceInvoicesCount_Call {
+	return &Database_GetWorkspaceInvoicesCount_Call{Call: _e.mock.On("GetWorkspaceInvoicesCount", workspace_uuid)}
 }
 
-func (_c *Database_GetBountyIndexById_Call) Run(run func(id string)) *Database_GetBountyIndexById_Call {
+func (_c *Database_GetWorkspaceInvoicesCount_Call) Run(run func(workspace_uuid string)) *Database_GetWorkspaceInvoicesCount_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetBountyIndexById_Call) Return(_a0 int64) *Database_GetBountyIndexById_Call {
+func (_c *Database_GetWorkspaceInvoicesCount_Call) Return(_a0 int64) *Database_GetWorkspaceInvoicesCount_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetBountyIndexById_Call) RunAndReturn(run func(string) int64) *Database_GetBountyIndexById_Call {
+func (_c *Database_GetWorkspaceInvoicesCount_Call) RunAndReturn(run func(string) int64) *Database_GetWorkspaceInvoicesCount_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetBountyRoles provides a mock function with given fields:
-func (_m *Database) GetBountyRoles() []db.BountyRoles {
-	ret := _m.Called()
+// GetWorkspaceMemberPubkeys provides a mock function with given fields: workspace
+func (_m *Database) GetWorkspaceMemberPubkeys(workspace db.Workspace) ([]string, error) {
+	ret := _m.Called(workspace)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetBountyRoles")
+		panic("no return value specified for GetWorkspaceMemberPubkeys")
 	}
 
-	var r0 []db.BountyRoles
-	if rf, ok := ret.Get(0).(func() []db.BountyRoles); ok {
-		r0 = rf()
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.Workspace) ([]string, error)); ok {
+		return rf(workspace)
+	}
+	if rf, ok := ret.Get(0).(func(db.Workspace) []string); ok {
+		r0 = rf(workspace)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.BountyRoles)
+			r0 = ret.Get(0).([]string)
 		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(db.Workspace) error); ok {
+		r1 = rf(workspace)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_GetBountyRoles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountyRoles'
-type Database_GetBountyRoles_Call struct {
+// Database_GetWorkspaceMemberPubkeys_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceMemberPubkeys'
+type Database_GetWorkspaceMemberPubkeys_Call struct {
 	*mock.Call
 }
 
-// GetBountyRoles is a helper method to define mock.On call
-func (_e *Database_Expecter) GetBountyRoles() *Database_GetBountyRoles_Call {
-	return &Database_GetBountyRoles_Call{Call: _e.mock.On("GetBountyRoles")}
+// GetWorkspaceMemberPubkeys is a helper method to define mock.On call
+//   - workspace db.Workspace
+func (_e *Database_Expecter) GetWorkspaceMemberPubkeys(workspace interface{}) *Database_GetWorkspaceMemberPubkeys_Call {
+	return &Database_GetWorkspaceMemberPubkeys_Call{Call: _e.mock.On("GetWorkspaceMemberPubkeys", workspace)}
 }
 
-func (_c *Database_GetBountyRoles_Call) Run(run func()) *Database_GetBountyRoles_Call {
+func (_c *Database_GetWorkspaceMemberPubkeys_Call) Run(run func(workspace db.Workspace)) *Database_GetWorkspaceMemberPubkeys_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		run(args[0].(db.Workspace))
 	})
 	return _c
 }
 
-func (_c *Database_GetBountyRoles_Call) Return(_a0 []db.BountyRoles) *Database_GetBountyRoles_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_GetWorkspaceMemberPubkeys_Call) Return(_a0 []string, _a1 error) *Database_GetWorkspaceMemberPubkeys_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetBountyRoles_Call) RunAndReturn(run func() []db.BountyRoles) *Database_GetBountyRoles_Call {
+func (_c *Database_GetWorkspaceMemberPubkeys_Call) RunAndReturn(run func(db.Workspace) ([]string, error)) *Database_GetWorkspaceMemberPubkeys_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetChannel provides a mock function with given fields: id
-func (_m *Database) GetChannel(id uint) db.Channel {
-	ret := _m.Called(id)
+// GetWorkspaceMemberSuggestions provides a mock function with given fields: workspace
+func (_m *Database) GetWorkspaceMemberSuggestions(workspace db.Workspace) ([]db.WorkspaceMemberSuggestion, error) {
+	ret := _m.Called(workspace)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetChannel")
+		panic("no return value specified for GetWorkspaceMemberSuggestions")
 	}
 
-	var r0 db.Channel
-	if rf, ok := ret.Get(0).(func(uint) db.Channel); ok {
-		r0 = rf(id)
+	var r0 []db.WorkspaceMemberSuggestion
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.Workspace) ([]db.WorkspaceMemberSuggestion, error)); ok {
+		return rf(workspace)
+	}
+	if rf, ok := ret.Get(0).(func(db.Workspace) []db.WorkspaceMemberSuggestion); ok {
+		r0 = rf(workspace)
 	} else {
-		r0 = ret.Get(0).(db.Channel)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.WorkspaceMemberSuggestion)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(db.Workspace) error); ok {
+		r1 = rf(workspace)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_GetChannel_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetChannel'
-type Database_GetChannel_Call struct {
+// Database_GetWorkspaceMemberSuggestions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceMemberSuggestions'
+type Database_GetWorkspaceMemberSuggestions_Call struct {
 	*mock.Call
 }
 
-// GetChannel is a helper method to define mock.On call
-//   - id uint
-func (_e *Database_Expecter) GetChannel(id interface{}) *Database_GetChannel_Call {
-	return &Database_GetChannel_Call{Call: _e.mock.On("GetChannel", id)}
+// GetWorkspaceMemberSuggestions is a helper method to define mock.On call
+//   - workspace db.Workspace
+func (_e *Database_Expecter) GetWorkspaceMemberSuggestions(workspace interface{}) *Database_GetWorkspaceMemberSuggestions_Call {
+	return &Database_GetWorkspaceMemberSuggestions_Call{Call: _e.mock.On("GetWorkspaceMemberSuggestions", workspace)}
 }
 
-func (_c *Database_GetChannel_Call) Run(run func(id uint)) *Database_GetChannel_Call {
+func (_c *Database_GetWorkspaceMemberSuggestions_Call) Run(run func(workspace db.Workspace)) *Database_GetWorkspaceMemberSuggestions_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(uint))
+		run(args[0].(db.Workspace))
 	})
 	return _c
 }
 
-func (_c *Database_GetChannel_Call) Return(_a0 db.Channel) *Database_GetChannel_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_GetWorkspaceMemberSuggestions_Call) Return(_a0 []db.WorkspaceMemberSuggestion, _a1 error) *Database_GetWorkspaceMemberSuggestions_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetChannel_Call) RunAndReturn(run func(uint) db.Channel) *Database_GetChannel_Call {
+func (_c *Database_GetWorkspaceMemberSuggestions_Call) RunAndReturn(run func(db.Workspace) ([]db.WorkspaceMemberSuggestion, error)) *Database_GetWorkspaceMemberSuggestions_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetChannelsByTribe provides a mock function with given fields: tribe_uuid
-func (_m *Database) GetChannelsByTribe(tribe_uuid string) []db.Channel {
-	ret := _m.Called(tribe_uuid)
+// GetWorkspaceRepoByWorkspaceUuidAndRepoUuid provides a mock function with given fields: workspace_uuid, uuid
+func (_m *Database) GetWorkspaceRepoByWorkspaceUuidAndRepoUuid(workspace_uuid string, uuid string) (db.WorkspaceRepositories, error) {
+	ret := _m.Called(workspace_uuid, uuid)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetChannelsByTribe")
+		panic("no return value specified for GetWorkspaceRepoByWorkspaceUuidAndRepoUuid")
 	}
 
-	var r0 []db.Channel
-	if rf, ok := ret.Get(0).(func(string) []db.Channel); ok {
-		r0 = rf(tribe_uuid)
+	var r0 db.WorkspaceRepositories
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (db.WorkspaceRepositories, error)); ok {
+		return rf(workspace_uuid, uuid)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) db.WorkspaceRepositories); ok {
+		r0 = rf(workspace_uuid, uuid)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.Channel)
-		}
+		r0 = ret.Get(0).(db.WorkspaceRepositories)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(workspace_uuid, uuid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_GetChannelsByTribe_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetChannelsByTribe'
-type Database_GetChannelsByTribe_Call struct {
+// Database_GetWorkspaceRepoByWorkspaceUuidAndRepoUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceRepoByWorkspaceUuidAndRepoUuid'
+type Database_GetWorkspaceRepoByWorkspaceUuidAndRepoUuid_Call struct {
 	*mock.Call
 }
 
-// GetChannelsByTribe is a helper method to define mock.On call
-//   - tribe_uuid string
-func (_e *Database_Expecter) GetChannelsByTribe(tribe_uuid interface{}) *Database_GetChannelsByTribe_Call {
-	return &Database_GetChannelsByTribe_Call{Call: _e.mock.On("GetChannelsByTribe", tribe_uuid)}
+// GetWorkspaceRepoByWorkspaceUuidAndRepoUuid is a helper method to define mock.On call
+//   - workspace_uuid string
+//   - uuid string
+func (_e *Database_Expecter) GetWorkspaceRepoByWorkspaceUuidAndRepoUuid(workspace_uuid interface{}, uuid interface{}) *Database_GetWorkspaceRepoByWorkspaceUuidAndRepoUuid_Call {
+	return &Database_GetWorkspaceRepoByWorkspaceUuidAndRepoUuid_Call{Call: _e.mock.On("GetWorkspaceRepoByWorkspaceUuidAndRepoUuid", workspace_uuid, uuid)}
 }
 
-func (_c *Database_GetChannelsByTribe_Call) Run(run func(tribe_uuid string)) *Database_GetChannelsByTribe_Call {
+func (_c *Database_GetWorkspaceRepoByWorkspaceUuidAndRepoUuid_Call) Run(run func(workspace_uuid string, uuid string)) *Database_GetWorkspaceRepoByWorkspaceUuidAndRepoUuid_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(string), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetChannelsByTribe_Call) Return(_a0 []db.Channel) *Database_GetChannelsByTribe_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_GetWorkspaceRepoByWorkspaceUuidAndRepoUuid_Call) Return(_a0 db.WorkspaceRepositories, _a1 error) *Database_GetWorkspaceRepoByWorkspaceUuidAndRepoUuid_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetChannelsByTribe_Call) RunAndReturn(run func(string) []db.Channel) *Database_GetChannelsByTribe_Call {
+func (_c *Database_GetWorkspaceRepoByWorkspaceUuidAndRepoUuid_Call) RunAndReturn(run func(string, string) (db.WorkspaceRepositories, error)) *Database_GetWorkspaceRepoByWorkspaceUuidAndRepoUuid_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetConnectionCode provides a mock function with given fields:
-func (_m *Database) GetConnectionCode() db.ConnectionCodesShort {
-	ret := _m.Called()
+// GetWorkspaceRepositorByWorkspaceUuid provides a mock function with given fields: uuid
+func (_m *Database) GetWorkspaceRepositorByWorkspaceUuid(uuid string) []db.WorkspaceRepositories {
+	ret := _m.Called(uuid)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetConnectionCode")
+		panic("no return value specified for GetWorkspaceRepositorByWorkspaceUuid")
 	}
 
-	var r0 db.ConnectionCodesShort
-	if rf, ok := ret.Get(0).(func() db.ConnectionCodesShort); ok {
-		r0 = rf()
+	var r0 []db.WorkspaceRepositories
+	if rf, ok := ret.Get(0).(func(string) []db.WorkspaceRepositories); ok {
+		r0 = rf(uuid)
 	} else {
-		r0 = ret.Get(0).(db.ConnectionCodesShort)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.WorkspaceRepositories)
+		}
 	}
 
 	return r0
 }
 
-// Database_GetConnectionCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetConnectionCode'
-type Database_GetConnectionCode_Call struct {
+// Database_GetWorkspaceRepositorByWorkspaceUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceRepositorByWorkspaceUuid'
+type Database_GetWorkspaceRepositorByWorkspaceUuid_Call struct {
 	*mock.Call
 }
 
-// GetConnectionCode is a helper method to define mock.On call
-func (_e *Database_Expecter) GetConnectionCode() *Database_GetConnectionCode_Call {
-	return &Database_GetConnectionCode_Call{Call: _e.mock.On("GetConnectionCode")}
+// GetWorkspaceRepositorByWorkspaceUuid is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) GetWorkspaceRepositorByWorkspaceUuid(uuid interface{}) *Database_GetWorkspaceRepositorByWorkspaceUuid_Call {
+	return &Database_GetWorkspaceRepositorByWorkspaceUuid_Call{Call: _e.mock.On("GetWorkspaceRepositorByWorkspaceUuid", uuid)}
 }
 
-func (_c *Database_GetConnectionCode_Call) Run(run func()) *Database_GetConnectionCode_Call {
+func (_c *Database_GetWorkspaceRepositorByWorkspaceUuid_Call) Run(run func(uuid string)) *Database_GetWorkspaceRepositorByWorkspaceUuid_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetConnectionCode_Call) Return(_a0 db.ConnectionCodesShort) *Database_GetConnectionCode_Call {
+func (_c *Database_GetWorkspaceRepositorByWorkspaceUuid_Call) Return(_a0 []db.WorkspaceRepositories) *Database_GetWorkspaceRepositorByWorkspaceUuid_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetConnectionCode_Call) RunAndReturn(run func() db.ConnectionCodesShort) *Database_GetConnectionCode_Call {
+func (_c *Database_GetWorkspaceRepositorByWorkspaceUuid_Call) RunAndReturn(run func(string) []db.WorkspaceRepositories) *Database_GetWorkspaceRepositorByWorkspaceUuid_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetCreatedBounties provides a mock function with given fields: r
-func (_m *Database) GetCreatedBounties(r *http.Request) ([]db.NewBounty, error) {
-	ret := _m.Called(r)
+// GetWorkspaceSecret provides a mock function with given fields: workspaceUuid, name
+func (_m *Database) GetWorkspaceSecret(workspaceUuid string, name string) (db.WorkspaceSecret, error) {
+	ret := _m.Called(workspaceUuid, name)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetCreatedBounties")
+		panic("no return value specified for GetWorkspaceSecret")
 	}
 
-	var r0 []db.NewBounty
+	var r0 db.WorkspaceSecret
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*http.Request) ([]db.NewBounty, error)); ok {
-		return rf(r)
+	if rf, ok := ret.Get(0).(func(string, string) (db.WorkspaceSecret, error)); ok {
+		return rf(workspaceUuid, name)
 	}
-	if rf, ok := ret.Get(0).(func(*http.Request) []db.NewBounty); ok {
-		r0 = rf(r)
+	if rf, ok := ret.Get(0).(func(string, string) db.WorkspaceSecret); ok {
+		r0 = rf(workspaceUuid, name)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.NewBounty)
-		}
+		r0 = ret.Get(0).(db.WorkspaceSecret)
 	}
 
-	if rf, ok := ret.Get(1).(func(*http.Request) error); ok {
-		r1 = rf(r)
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(workspaceUuid, name)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -3108,101 +15245,116 @@ func (_m *Database) GetCreatedBounties(r *http.Request) ([]db.NewBounty, error)
 	return r0, r1
 }
 
-// Database_GetCreatedBounties_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCreatedBounties'
-type Database_GetCreatedBounties_Call struct {
+// Database_GetWorkspaceSecret_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceSecret'
+type Database_GetWorkspaceSecret_Call struct {
 	*mock.Call
 }
 
-// GetCreatedBounties is a helper method to define mock.On call
-//   - r *http.Request
-func (_e *Database_Expecter) GetCreatedBounties(r interface{}) *Database_GetCreatedBounties_Call {
-	return &Database_GetCreatedBounties_Call{Call: _e.mock.On("GetCreatedBounties", r)}
+// GetWorkspaceSecret is a helper method to define mock.On call
+//   - workspaceUuid string
+//   - name string
+func (_e *Database_Expecter) GetWorkspaceSecret(workspaceUuid interface{}, name interface{}) *Database_GetWorkspaceSecret_Call {
+	return &Database_GetWorkspaceSecret_Call{Call: _e.mock.On("GetWorkspaceSecret", workspaceUuid, name)}
 }
 
-func (_c *Database_GetCreatedBounties_Call) Run(run func(r *http.Request)) *Database_GetCreatedBounties_Call {
+func (_c *Database_GetWorkspaceSecret_Call) Run(run func(workspaceUuid string, name string)) *Database_GetWorkspaceSecret_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*http.Request))
+		run(args[0].(string), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetCreatedBounties_Call) Return(_a0 []db.NewBounty, _a1 error) *Database_GetCreatedBounties_Call {
+func (_c *Database_GetWorkspaceSecret_Call) Return(_a0 db.WorkspaceSecret, _a1 error) *Database_GetWorkspaceSecret_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetCreatedBounties_Call) RunAndReturn(run func(*http.Request) ([]db.NewBounty, error)) *Database_GetCreatedBounties_Call {
+func (_c *Database_GetWorkspaceSecret_Call) RunAndReturn(run func(string, string) (db.WorkspaceSecret, error)) *Database_GetWorkspaceSecret_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetFeatureByUuid provides a mock function with given fields: uuid
-func (_m *Database) GetFeatureByUuid(uuid string) db.WorkspaceFeatures {
-	ret := _m.Called(uuid)
+// GetWorkspaceSecrets provides a mock function with given fields: workspaceUuid
+func (_m *Database) GetWorkspaceSecrets(workspaceUuid string) ([]db.WorkspaceSecret, error) {
+	ret := _m.Called(workspaceUuid)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetFeatureByUuid")
+		panic("no return value specified for GetWorkspaceSecrets")
+	}
+
+	var r0 []db.WorkspaceSecret
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]db.WorkspaceSecret, error)); ok {
+		return rf(workspaceUuid)
+	}
+	if rf, ok := ret.Get(0).(func(string) []db.WorkspaceSecret); ok {
+		r0 = rf(workspaceUuid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.WorkspaceSecret)
+		}
 	}
 
-	var r0 db.WorkspaceFeatures
-	if rf, ok := ret.Get(0).(func(string) db.WorkspaceFeatures); ok {
-		r0 = rf(uuid)
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(workspaceUuid)
 	} else {
-		r0 = ret.Get(0).(db.WorkspaceFeatures)
+		r1 = ret.Error(1)
 	}
 
-	return r0
+	return r0, r1
 }
 
-// Database_GetFeatureByUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFeatureByUuid'
-type Database_GetFeatureByUuid_Call struct {
+// Database_GetWorkspaceSecrets_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceSecrets'
+type Database_GetWorkspaceSecrets_Call struct {
 	*mock.Call
 }
 
-// GetFeatureByUuid is a helper method to define mock.On call
-//   - uuid string
-func (_e *Database_Expecter) GetFeatureByUuid(uuid interface{}) *Database_GetFeatureByUuid_Call {
-	return &Database_GetFeatureByUuid_Call{Call: _e.mock.On("GetFeatureByUuid", uuid)}
+// GetWorkspaceSecrets is a helper method to define mock.On call
+//   - workspaceUuid string
+func (_e *Database_Expecter) GetWorkspaceSecrets(workspaceUuid interface{}) *Database_GetWorkspaceSecrets_Call {
+	return &Database_GetWorkspaceSecrets_Call{Call: _e.mock.On("GetWorkspaceSecrets", workspaceUuid)}
 }
 
-func (_c *Database_GetFeatureByUuid_Call) Run(run func(uuid string)) *Database_GetFeatureByUuid_Call {
+func (_c *Database_GetWorkspaceSecrets_Call) Run(run func(workspaceUuid string)) *Database_GetWorkspaceSecrets_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetFeatureByUuid_Call) Return(_a0 db.WorkspaceFeatures) *Database_GetFeatureByUuid_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_GetWorkspaceSecrets_Call) Return(_a0 []db.WorkspaceSecret, _a1 error) *Database_GetWorkspaceSecrets_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetFeatureByUuid_Call) RunAndReturn(run func(string) db.WorkspaceFeatures) *Database_GetFeatureByUuid_Call {
+func (_c *Database_GetWorkspaceSecrets_Call) RunAndReturn(run func(string) ([]db.WorkspaceSecret, error)) *Database_GetWorkspaceSecrets_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetFeaturePhaseByUuid provides a mock function with given fields: featureUuid, phaseUuid
-func (_m *Database) GetFeaturePhaseByUuid(featureUuid string, phaseUuid string) (db.FeaturePhase, error) {
-	ret := _m.Called(featureUuid, phaseUuid)
+// GetWorkspaceSecretsToRewrap provides a mock function with given fields: masterKeyId
+func (_m *Database) GetWorkspaceSecretsToRewrap(masterKeyId string) ([]db.WorkspaceSecret, error) {
+	ret := _m.Called(masterKeyId)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetFeaturePhaseByUuid")
+		panic("no return value specified for GetWorkspaceSecretsToRewrap")
 	}
 
-	var r0 db.FeaturePhase
+	var r0 []db.WorkspaceSecret
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string) (db.FeaturePhase, error)); ok {
-		return rf(featureUuid, phaseUuid)
+	if rf, ok := ret.Get(0).(func(string) ([]db.WorkspaceSecret, error)); ok {
+		return rf(masterKeyId)
 	}
-	if rf, ok := ret.Get(0).(func(string, string) db.FeaturePhase); ok {
-		r0 = rf(featureUuid, phaseUuid)
+	if rf, ok := ret.Get(0).(func(string) []db.WorkspaceSecret); ok {
+		r0 = rf(masterKeyId)
 	} else {
-		r0 = ret.Get(0).(db.FeaturePhase)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.WorkspaceSecret)
+		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(featureUuid, phaseUuid)
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(masterKeyId)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -3210,105 +15362,103 @@ func (_m *Database) GetFeaturePhaseByUuid(featureUuid string, phaseUuid string)
 	return r0, r1
 }
 
-// Database_GetFeaturePhaseByUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFeaturePhaseByUuid'
-type Database_GetFeaturePhaseByUuid_Call struct {
+// Database_GetWorkspaceSecretsToRewrap_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceSecretsToRewrap'
+type Database_GetWorkspaceSecretsToRewrap_Call struct {
 	*mock.Call
 }
 
-// GetFeaturePhaseByUuid is a helper method to define mock.On call
-//   - featureUuid string
-//   - phaseUuid string
-func (_e *Database_Expecter) GetFeaturePhaseByUuid(featureUuid interface{}, phaseUuid interface{}) *Database_GetFeaturePhaseByUuid_Call {
-	return &Database_GetFeaturePhaseByUuid_Call{Call: _e.mock.On("GetFeaturePhaseByUuid", featureUuid, phaseUuid)}
+// GetWorkspaceSecretsToRewrap is a helper method to define mock.On call
+//   - masterKeyId string
+func (_e *Database_Expecter) GetWorkspaceSecretsToRewrap(masterKeyId interface{}) *Database_GetWorkspaceSecretsToRewrap_Call {
+	return &Database_GetWorkspaceSecretsToRewrap_Call{Call: _e.mock.On("GetWorkspaceSecretsToRewrap", masterKeyId)}
 }
 
-func (_c *Database_GetFeaturePhaseByUuid_Call) Run(run func(featureUuid string, phaseUuid string)) *Database_GetFeaturePhaseByUuid_Call {
+func (_c *Database_GetWorkspaceSecretsToRewrap_Call) Run(run func(masterKeyId string)) *Database_GetWorkspaceSecretsToRewrap_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string))
+		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetFeaturePhaseByUuid_Call) Return(_a0 db.FeaturePhase, _a1 error) *Database_GetFeaturePhaseByUuid_Call {
+func (_c *Database_GetWorkspaceSecretsToRewrap_Call) Return(_a0 []db.WorkspaceSecret, _a1 error) *Database_GetWorkspaceSecretsToRewrap_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetFeaturePhaseByUuid_Call) RunAndReturn(run func(string, string) (db.FeaturePhase, error)) *Database_GetFeaturePhaseByUuid_Call {
+func (_c *Database_GetWorkspaceSecretsToRewrap_Call) RunAndReturn(run func(string) ([]db.WorkspaceSecret, error)) *Database_GetWorkspaceSecretsToRewrap_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetFeaturePhasesBountiesCount provides a mock function with given fields: bountyType, phaseUuid
-func (_m *Database) GetFeaturePhasesBountiesCount(bountyType string, phaseUuid string) int64 {
-	ret := _m.Called(bountyType, phaseUuid)
+// GetWorkspaceStatusBudget provides a mock function with given fields: workspace_uuid
+func (_m *Database) GetWorkspaceStatusBudget(workspace_uuid string) db.StatusBudget {
+	ret := _m.Called(workspace_uuid)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetFeaturePhasesBountiesCount")
+		panic("no return value specified for GetWorkspaceStatusBudget")
 	}
 
-	var r0 int64
-	if rf, ok := ret.Get(0).(func(string, string) int64); ok {
-		r0 = rf(bountyType, phaseUuid)
+	var r0 db.StatusBudget
+	if rf, ok := ret.Get(0).(func(string) db.StatusBudget); ok {
+		r0 = rf(workspace_uuid)
 	} else {
-		r0 = ret.Get(0).(int64)
+		r0 = ret.Get(0).(db.StatusBudget)
 	}
 
 	return r0
 }
 
-// Database_GetFeaturePhasesBountiesCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFeaturePhasesBountiesCount'
-type Database_GetFeaturePhasesBountiesCount_Call struct {
+// Database_GetWorkspaceStatusBudget_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceStatusBudget'
+type Database_GetWorkspaceStatusBudget_Call struct {
 	*mock.Call
 }
 
-// GetFeaturePhasesBountiesCount is a helper method to define mock.On call
-//   - bountyType string
-//   - phaseUuid string
-func (_e *Database_Expecter) GetFeaturePhasesBountiesCount(bountyType interface{}, phaseUuid interface{}) *Database_GetFeaturePhasesBountiesCount_Call {
-	return &Database_GetFeaturePhasesBountiesCount_Call{Call: _e.mock.On("GetFeaturePhasesBountiesCount", bountyType, phaseUuid)}
+// GetWorkspaceStatusBudget is a helper method to define mock.On call
+//   - workspace_uuid string
+func (_e *Database_Expecter) GetWorkspaceStatusBudget(workspace_uuid interface{}) *Database_GetWorkspaceStatusBudget_Call {
+	return &Database_GetWorkspaceStatusBudget_Call{Call: _e.mock.On("GetWorkspaceStatusBudget", workspace_uuid)}
 }
 
-func (_c *Database_GetFeaturePhasesBountiesCount_Call) Run(run func(bountyType string, phaseUuid string)) *Database_GetFeaturePhasesBountiesCount_Call {
+func (_c *Database_GetWorkspaceStatusBudget_Call) Run(run func(workspace_uuid string)) *Database_GetWorkspaceStatusBudget_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string))
+		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetFeaturePhasesBountiesCount_Call) Return(_a0 int64) *Database_GetFeaturePhasesBountiesCount_Call {
+func (_c *Database_GetWorkspaceStatusBudget_Call) Return(_a0 db.StatusBudget) *Database_GetWorkspaceStatusBudget_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetFeaturePhasesBountiesCount_Call) RunAndReturn(run func(string, string) int64) *Database_GetFeaturePhasesBountiesCount_Call {
+func (_c *Database_GetWorkspaceStatusBudget_Call) RunAndReturn(run func(string) db.StatusBudget) *Database_GetWorkspaceStatusBudget_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetFeatureStoriesByFeatureUuid provides a mock function with given fields: featureUuid
-func (_m *Database) GetFeatureStoriesByFeatureUuid(featureUuid string) ([]db.FeatureStory, error) {
-	ret := _m.Called(featureUuid)
+// GetWorkspaceUsage provides a mock function with given fields: workspaceUuid, at
+func (_m *Database) GetWorkspaceUsage(workspaceUuid string, at time.Time) (map[string]int64, error) {
+	ret := _m.Called(workspaceUuid, at)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetFeatureStoriesByFeatureUuid")
+		panic("no return value specified for GetWorkspaceUsage")
 	}
 
-	var r0 []db.FeatureStory
+	var r0 map[string]int64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) ([]db.FeatureStory, error)); ok {
-		return rf(featureUuid)
+	if rf, ok := ret.Get(0).(func(string, time.Time) (map[string]int64, error)); ok {
+		return rf(workspaceUuid, at)
 	}
-	if rf, ok := ret.Get(0).(func(string) []db.FeatureStory); ok {
-		r0 = rf(featureUuid)
+	if rf, ok := ret.Get(0).(func(string, time.Time) map[string]int64); ok {
+		r0 = rf(workspaceUuid, at)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.FeatureStory)
+			r0 = ret.Get(0).(map[string]int64)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(featureUuid)
+	if rf, ok := ret.Get(1).(func(string, time.Time) error); ok {
+		r1 = rf(workspaceUuid, at)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -3316,443 +15466,440 @@ func (_m *Database) GetFeatureStoriesByFeatureUuid(featureUuid string) ([]db.Fea
 	return r0, r1
 }
 
-// Database_GetFeatureStoriesByFeatureUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFeatureStoriesByFeatureUuid'
-type Database_GetFeatureStoriesByFeatureUuid_Call struct {
+// Database_GetWorkspaceUsage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceUsage'
+type Database_GetWorkspaceUsage_Call struct {
 	*mock.Call
 }
 
-// GetFeatureStoriesByFeatureUuid is a helper method to define mock.On call
-//   - featureUuid string
-func (_e *Database_Expecter) GetFeatureStoriesByFeatureUuid(featureUuid interface{}) *Database_GetFeatureStoriesByFeatureUuid_Call {
-	return &Database_GetFeatureStoriesByFeatureUuid_Call{Call: _e.mock.On("GetFeatureStoriesByFeatureUuid", featureUuid)}
+// GetWorkspaceUsage is a helper method to define mock.On call
+//   - workspaceUuid string
+//   - at time.Time
+func (_e *Database_Expecter) GetWorkspaceUsage(workspaceUuid interface{}, at interface{}) *Database_GetWorkspaceUsage_Call {
+	return &Database_GetWorkspaceUsage_Call{Call: _e.mock.On("GetWorkspaceUsage", workspaceUuid, at)}
 }
 
-func (_c *Database_GetFeatureStoriesByFeatureUuid_Call) Run(run func(featureUuid string)) *Database_GetFeatureStoriesByFeatureUuid_Call {
+func (_c *Database_GetWorkspaceUsage_Call) Run(run func(workspaceUuid string, at time.Time)) *Database_GetWorkspaceUsage_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(string), args[1].(time.Time))
 	})
 	return _c
 }
 
-func (_c *Database_GetFeatureStoriesByFeatureUuid_Call) Return(_a0 []db.FeatureStory, _a1 error) *Database_GetFeatureStoriesByFeatureUuid_Call {
+func (_c *Database_GetWorkspaceUsage_Call) Return(_a0 map[string]int64, _a1 error) *Database_GetWorkspaceUsage_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetFeatureStoriesByFeatureUuid_Call) RunAndReturn(run func(string) ([]db.FeatureStory, error)) *Database_GetFeatureStoriesByFeatureUuid_Call {
+func (_c *Database_GetWorkspaceUsage_Call) RunAndReturn(run func(string, time.Time) (map[string]int64, error)) *Database_GetWorkspaceUsage_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetFeatureStoryByUuid provides a mock function with given fields: featureUuid, storyUuid
-func (_m *Database) GetFeatureStoryByUuid(featureUuid string, storyUuid string) (db.FeatureStory, error) {
-	ret := _m.Called(featureUuid, storyUuid)
+// GetWorkspaceUser provides a mock function with given fields: pubkey, workspace_uuid
+func (_m *Database) GetWorkspaceUser(pubkey string, workspace_uuid string) db.WorkspaceUsers {
+	ret := _m.Called(pubkey, workspace_uuid)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetFeatureStoryByUuid")
-	}
-
-	var r0 db.FeatureStory
-	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string) (db.FeatureStory, error)); ok {
-		return rf(featureUuid, storyUuid)
-	}
-	if rf, ok := ret.Get(0).(func(string, string) db.FeatureStory); ok {
-		r0 = rf(featureUuid, storyUuid)
-	} else {
-		r0 = ret.Get(0).(db.FeatureStory)
+		panic("no return value specified for GetWorkspaceUser")
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(featureUuid, storyUuid)
+	var r0 db.WorkspaceUsers
+	if rf, ok := ret.Get(0).(func(string, string) db.WorkspaceUsers); ok {
+		r0 = rf(pubkey, workspace_uuid)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Get(0).(db.WorkspaceUsers)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// Database_GetFeatureStoryByUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFeatureStoryByUuid'
-type Database_GetFeatureStoryByUuid_Call struct {
+// Database_GetWorkspaceUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceUser'
+type Database_GetWorkspaceUser_Call struct {
 	*mock.Call
 }
 
-// GetFeatureStoryByUuid is a helper method to define mock.On call
-//   - featureUuid string
-//   - storyUuid string
-func (_e *Database_Expecter) GetFeatureStoryByUuid(featureUuid interface{}, storyUuid interface{}) *Database_GetFeatureStoryByUuid_Call {
-	return &Database_GetFeatureStoryByUuid_Call{Call: _e.mock.On("GetFeatureStoryByUuid", featureUuid, storyUuid)}
+// GetWorkspaceUser is a helper method to define mock.On call
+//   - pubkey string
+//   - workspace_uuid string
+func (_e *Database_Expecter) GetWorkspaceUser(pubkey interface{}, workspace_uuid interface{}) *Database_GetWorkspaceUser_Call {
+	return &Database_GetWorkspaceUser_Call{Call: _e.mock.On("GetWorkspaceUser", pubkey, workspace_uuid)}
 }
 
-func (_c *Database_GetFeatureStoryByUuid_Call) Run(run func(featureUuid string, storyUuid string)) *Database_GetFeatureStoryByUuid_Call {
+func (_c *Database_GetWorkspaceUser_Call) Run(run func(pubkey string, workspace_uuid string)) *Database_GetWorkspaceUser_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(string), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetFeatureStoryByUuid_Call) Return(_a0 db.FeatureStory, _a1 error) *Database_GetFeatureStoryByUuid_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *Database_GetWorkspaceUser_Call) Return(_a0 db.WorkspaceUsers) *Database_GetWorkspaceUser_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetFeatureStoryByUuid_Call) RunAndReturn(run func(string, string) (db.FeatureStory, error)) *Database_GetFeatureStoryByUuid_Call {
+func (_c *Database_GetWorkspaceUser_Call) RunAndReturn(run func(string, string) db.WorkspaceUsers) *Database_GetWorkspaceUser_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetFeaturesByWorkspaceUuid provides a mock function with given fields: uuid, r
-func (_m *Database) GetFeaturesByWorkspaceUuid(uuid string, r *http.Request) []db.WorkspaceFeatures {
-	ret := _m.Called(uuid, r)
+// GetWorkspaceUsers provides a mock function with given fields: uuid
+func (_m *Database) GetWorkspaceUsers(uuid string) ([]db.WorkspaceUsersData, error) {
+	ret := _m.Called(uuid)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetFeaturesByWorkspaceUuid")
+		panic("no return value specified for GetWorkspaceUsers")
 	}
 
-	var r0 []db.WorkspaceFeatures
-	if rf, ok := ret.Get(0).(func(string, *http.Request) []db.WorkspaceFeatures); ok {
-		r0 = rf(uuid, r)
+	var r0 []db.WorkspaceUsersData
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]db.WorkspaceUsersData, error)); ok {
+		return rf(uuid)
+	}
+	if rf, ok := ret.Get(0).(func(string) []db.WorkspaceUsersData); ok {
+		r0 = rf(uuid)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.WorkspaceFeatures)
+			r0 = ret.Get(0).([]db.WorkspaceUsersData)
 		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(uuid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_GetFeaturesByWorkspaceUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFeaturesByWorkspaceUuid'
-type Database_GetFeaturesByWorkspaceUuid_Call struct {
+// Database_GetWorkspaceUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceUsers'
+type Database_GetWorkspaceUsers_Call struct {
 	*mock.Call
 }
 
-// GetFeaturesByWorkspaceUuid is a helper method to define mock.On call
+// GetWorkspaceUsers is a helper method to define mock.On call
 //   - uuid string
-//   - r *http.Request
-func (_e *Database_Expecter) GetFeaturesByWorkspaceUuid(uuid interface{}, r interface{}) *Database_GetFeaturesByWorkspaceUuid_Call {
-	return &Database_GetFeaturesByWorkspaceUuid_Call{Call: _e.mock.On("GetFeaturesByWorkspaceUuid", uuid, r)}
+func (_e *Database_Expecter) GetWorkspaceUsers(uuid interface{}) *Database_GetWorkspaceUsers_Call {
+	return &Database_GetWorkspaceUsers_Call{Call: _e.mock.On("GetWorkspaceUsers", uuid)}
 }
 
-func (_c *Database_GetFeaturesByWorkspaceUuid_Call) Run(run func(uuid string, r *http.Request)) *Database_GetFeaturesByWorkspaceUuid_Call {
+func (_c *Database_GetWorkspaceUsers_Call) Run(run func(uuid string)) *Database_GetWorkspaceUsers_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(*http.Request))
+		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetFeaturesByWorkspaceUuid_Call) Return(_a0 []db.WorkspaceFeatures) *Database_GetFeaturesByWorkspaceUuid_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_GetWorkspaceUsers_Call) Return(_a0 []db.WorkspaceUsersData, _a1 error) *Database_GetWorkspaceUsers_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetFeaturesByWorkspaceUuid_Call) RunAndReturn(run func(string, *http.Request) []db.WorkspaceFeatures) *Database_GetFeaturesByWorkspaceUuid_Call {
+func (_c *Database_GetWorkspaceUsers_Call) RunAndReturn(run func(string) ([]db.WorkspaceUsersData, error)) *Database_GetWorkspaceUsers_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetFilterStatusCount provides a mock function with given fields:
-func (_m *Database) GetFilterStatusCount() db.FilterStattuCount {
-	ret := _m.Called()
+// GetWorkspaceUsersCount provides a mock function with given fields: uuid
+func (_m *Database) GetWorkspaceUsersCount(uuid string) int64 {
+	ret := _m.Called(uuid)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetFilterStatusCount")
+		panic("no return value specified for GetWorkspaceUsersCount")
 	}
 
-	var r0 db.FilterStattuCount
-	if rf, ok := ret.Get(0).(func() db.FilterStattuCount); ok {
-		r0 = rf()
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(string) int64); ok {
+		r0 = rf(uuid)
 	} else {
-		r0 = ret.Get(0).(db.FilterStattuCount)
+		r0 = ret.Get(0).(int64)
 	}
 
 	return r0
 }
 
-// Database_GetFilterStatusCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFilterStatusCount'
-type Database_GetFilterStatusCount_Call struct {
+// Database_GetWorkspaceUsersCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceUsersCount'
+type Database_GetWorkspaceUsersCount_Call struct {
 	*mock.Call
 }
 
-// GetFilterStatusCount is a helper method to define mock.On call
-func (_e *Database_Expecter) GetFilterStatusCount() *Database_GetFilterStatusCount_Call {
-	return &Database_GetFilterStatusCount_Call{Call: _e.mock.On("GetFilterStatusCount")}
+// GetWorkspaceUsersCount is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) GetWorkspaceUsersCount(uuid interface{}) *Database_GetWorkspaceUsersCount_Call {
+	return &Database_GetWorkspaceUsersCount_Call{Call: _e.mock.On("GetWorkspaceUsersCount", uuid)}
 }
 
-func (_c *Database_GetFilterStatusCount_Call) Run(run func()) *Database_GetFilterStatusCount_Call {
+func (_c *Database_GetWorkspaceUsersCount_Call) Run(run func(uuid string)) *Database_GetWorkspaceUsersCount_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetFilterStatusCount_Call) Return(_a0 db.FilterStattuCount) *Database_GetFilterStatusCount_Call {
+func (_c *Database_GetWorkspaceUsersCount_Call) Return(_a0 int64) *Database_GetWorkspaceUsersCount_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetFilterStatusCount_Call) RunAndReturn(run func() db.FilterStattuCount) *Database_GetFilterStatusCount_Call {
+func (_c *Database_GetWorkspaceUsersCount_Call) RunAndReturn(run func(string) int64) *Database_GetWorkspaceUsersCount_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetFirstTribeByFeedURL provides a mock function with given fields: feedURL
-func (_m *Database) GetFirstTribeByFeedURL(feedURL string) db.Tribe {
-	ret := _m.Called(feedURL)
+// GetWorkspaceVerification provides a mock function with given fields: workspaceUuid
+func (_m *Database) GetWorkspaceVerification(workspaceUuid string) db.WorkspaceVerification {
+	ret := _m.Called(workspaceUuid)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetFirstTribeByFeedURL")
+		panic("no return value specified for GetWorkspaceVerification")
 	}
 
-	var r0 db.Tribe
-	if rf, ok := ret.Get(0).(func(string) db.Tribe); ok {
-		r0 = rf(feedURL)
+	var r0 db.WorkspaceVerification
+	if rf, ok := ret.Get(0).(func(string) db.WorkspaceVerification); ok {
+		r0 = rf(workspaceUuid)
 	} else {
-		r0 = ret.Get(0).(db.Tribe)
+		r0 = ret.Get(0).(db.WorkspaceVerification)
 	}
 
 	return r0
 }
 
-// Database_GetFirstTribeByFeedURL_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFirstTribeByFeedURL'
-type Database_GetFirstTribeByFeedURL_Call struct {
+// Database_GetWorkspaceVerification_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceVerification'
+type Database_GetWorkspaceVerification_Call struct {
 	*mock.Call
 }
 
-// GetFirstTribeByFeedURL is a helper method to define mock.On call
-//   - feedURL string
-func (_e *Database_Expecter) GetFirstTribeByFeedURL(feedURL interface{}) *Database_GetFirstTribeByFeedURL_Call {
-	return &Database_GetFirstTribeByFeedURL_Call{Call: _e.mock.On("GetFirstTribeByFeedURL", feedURL)}
+// GetWorkspaceVerification is a helper method to define mock.On call
+//   - workspaceUuid string
+func (_e *Database_Expecter) GetWorkspaceVerification(workspaceUuid interface{}) *Database_GetWorkspaceVerification_Call {
+	return &Database_GetWorkspaceVerification_Call{Call: _e.mock.On("GetWorkspaceVerification", workspaceUuid)}
 }
 
-func (_c *Database_GetFirstTribeByFeedURL_Call) Run(run func(feedURL string)) *Database_GetFirstTribeByFeedURL_Call {
+func (_c *Database_GetWorkspaceVerification_Call) Run(run func(workspaceUuid string)) *Database_GetWorkspaceVerification_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetFirstTribeByFeedURL_Call) Return(_a0 db.Tribe) *Database_GetFirstTribeByFeedURL_Call {
+func (_c *Database_GetWorkspaceVerification_Call) Return(_a0 db.WorkspaceVerification) *Database_GetWorkspaceVerification_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetFirstTribeByFeedURL_Call) RunAndReturn(run func(string) db.Tribe) *Database_GetFirstTribeByFeedURL_Call {
+func (_c *Database_GetWorkspaceVerification_Call) RunAndReturn(run func(string) db.WorkspaceVerification) *Database_GetWorkspaceVerification_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetInvoice provides a mock function with given fields: payment_request
-func (_m *Database) GetInvoice(payment_request string) db.NewInvoiceList {
-	ret := _m.Called(payment_request)
+// GetWorkspaces provides a mock function with given fields: r
+func (_m *Database) GetWorkspaces(r *http.Request) []db.Workspace {
+	ret := _m.Called(r)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetInvoice")
+		panic("no return value specified for GetWorkspaces")
 	}
 
-	var r0 db.NewInvoiceList
-	if rf, ok := ret.Get(0).(func(string) db.NewInvoiceList); ok {
-		r0 = rf(payment_request)
+	var r0 []db.Workspace
+	if rf, ok := ret.Get(0).(func(*http.Request) []db.Workspace); ok {
+		r0 = rf(r)
 	} else {
-		r0 = ret.Get(0).(db.NewInvoiceList)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Workspace)
+		}
 	}
 
 	return r0
 }
 
-// Database_GetInvoice_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetInvoice'
-type Database_GetInvoice_Call struct {
+// Database_GetWorkspaces_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaces'
+type Database_GetWorkspaces_Call struct {
 	*mock.Call
 }
 
-// GetInvoice is a helper method to define mock.On call
-//   - payment_request string
-func (_e *Database_Expecter) GetInvoice(payment_request interface{}) *Database_GetInvoice_Call {
-	return &Database_GetInvoice_Call{Call: _e.mock.On("GetInvoice", payment_request)}
+// GetWorkspaces is a helper method to define mock.On call
+//   - r *http.Request
+func (_e *Database_Expecter) GetWorkspaces(r interface{}) *Database_GetWorkspaces_Call {
+	return &Database_GetWorkspaces_Call{Call: _e.mock.On("GetWorkspaces", r)}
 }
 
-func (_c *Database_GetInvoice_Call) Run(run func(payment_request string)) *Database_GetInvoice_Call {
+func (_c *Database_GetWorkspaces_Call) Run(run func(r *http.Request)) *Database_GetWorkspaces_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(*http.Request))
 	})
 	return _c
 }
 
-func (_c *Database_GetInvoice_Call) Return(_a0 db.NewInvoiceList) *Database_GetInvoice_Call {
+func (_c *Database_GetWorkspaces_Call) Return(_a0 []db.Workspace) *Database_GetWorkspaces_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetInvoice_Call) RunAndReturn(run func(string) db.NewInvoiceList) *Database_GetInvoice_Call {
+func (_c *Database_GetWorkspaces_Call) RunAndReturn(run func(*http.Request) []db.Workspace) *Database_GetWorkspaces_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetLeaderBoard provides a mock function with given fields: uuid
-func (_m *Database) GetLeaderBoard(uuid string) []db.LeaderBoard {
-	ret := _m.Called(uuid)
+// GetWorkspacesCount provides a mock function with given fields:
+func (_m *Database) GetWorkspacesCount() int64 {
+	ret := _m.Called()
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetLeaderBoard")
+		panic("no return value specified for GetWorkspacesCount")
 	}
 
-	var r0 []db.LeaderBoard
-	if rf, ok := ret.Get(0).(func(string) []db.LeaderBoard); ok {
-		r0 = rf(uuid)
+	var r0 int64
+	if rf, ok := ret.Get(0).(func() int64); ok {
+		r0 = rf()
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.LeaderBoard)
-		}
+		r0 = ret.Get(0).(int64)
 	}
 
 	return r0
 }
 
-// Database_GetLeaderBoard_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLeaderBoard'
-type Database_GetLeaderBoard_Call struct {
+// Database_GetWorkspacesCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspacesCount'
+type Database_GetWorkspacesCount_Call struct {
 	*mock.Call
 }
 
-// GetLeaderBoard is a helper method to define mock.On call
-//   - uuid string
-func (_e *Database_Expecter) GetLeaderBoard(uuid interface{}) *Database_GetLeaderBoard_Call {
-	return &Database_GetLeaderBoard_Call{Call: _e.mock.On("GetLeaderBoard", uuid)}
+// GetWorkspacesCount is a helper method to define mock.On call
+func (_e *Database_Expecter) GetWorkspacesCount() *Database_GetWorkspacesCount_Call {
+	return &Database_GetWorkspacesCount_Call{Call: _e.mock.On("GetWorkspacesCount")}
 }
 
-func (_c *Database_GetLeaderBoard_Call) Run(run func(uuid string)) *Database_GetLeaderBoard_Call {
+func (_c *Database_GetWorkspacesCount_Call) Run(run func()) *Database_GetWorkspacesCount_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run()
 	})
 	return _c
 }
 
-func (_c *Database_GetLeaderBoard_Call) Return(_a0 []db.LeaderBoard) *Database_GetLeaderBoard_Call {
+func (_c *Database_GetWorkspacesCount_Call) Return(_a0 int64) *Database_GetWorkspacesCount_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetLeaderBoard_Call) RunAndReturn(run func(string) []db.LeaderBoard) *Database_GetLeaderBoard_Call {
+func (_c *Database_GetWorkspacesCount_Call) RunAndReturn(run func() int64) *Database_GetWorkspacesCount_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetLeaderBoardByUuidAndAlias provides a mock function with given fields: uuid, alias
-func (_m *Database) GetLeaderBoardByUuidAndAlias(uuid string, alias string) db.LeaderBoard {
-	ret := _m.Called(uuid, alias)
+// HasAcceptedBountyTerms provides a mock function with given fields: bountyID, pubkey, hash
+func (_m *Database) HasAcceptedBountyTerms(bountyID uint, pubkey string, hash string) bool {
+	ret := _m.Called(bountyID, pubkey, hash)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetLeaderBoardByUuidAndAlias")
+		panic("no return value specified for HasAcceptedBountyTerms")
 	}
 
-	var r0 db.LeaderBoard
-	if rf, ok := ret.Get(0).(func(string, string) db.LeaderBoard); ok {
-		r0 = rf(uuid, alias)
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(uint, string, string) bool); ok {
+		r0 = rf(bountyID, pubkey, hash)
 	} else {
-		r0 = ret.Get(0).(db.LeaderBoard)
+		r0 = ret.Get(0).(bool)
 	}
 
 	return r0
 }
 
-// Database_GetLeaderBoardByUuidAndAlias_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLeaderBoardByUuidAndAlias'
-type Database_GetLeaderBoardByUuidAndAlias_Call struct {
+// Database_HasAcceptedBountyTerms_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HasAcceptedBountyTerms'
+type Database_HasAcceptedBountyTerms_Call struct {
 	*mock.Call
 }
 
-// GetLeaderBoardByUuidAndAlias is a helper method to define mock.On call
-//   - uuid string
-//   - alias string
-func (_e *Database_Expecter) GetLeaderBoardByUuidAndAlias(uuid interface{}, alias interface{}) *Database_GetLeaderBoardByUuidAndAlias_Call {
-	return &Database_GetLeaderBoardByUuidAndAlias_Call{Call: _e.mock.On("GetLeaderBoardByUuidAndAlias", uuid, alias)}
+// HasAcceptedBountyTerms is a helper method to define mock.On call
+//   - bountyID uint
+//   - pubkey string
+//   - hash string
+func (_e *Database_Expecter) HasAcceptedBountyTerms(bountyID interface{}, pubkey interface{}, hash interface{}) *Database_HasAcceptedBountyTerms_Call {
+	return &Database_HasAcceptedBountyTerms_Call{Call: _e.mock.On("HasAcceptedBountyTerms", bountyID, pubkey, hash)}
 }
 
-func (_c *Database_GetLeaderBoardByUuidAndAlias_Call) Run(run func(uuid string, alias string)) *Database_GetLeaderBoardByUuidAndAlias_Call {
+func (_c *Database_HasAcceptedBountyTerms_Call) Run(run func(bountyID uint, pubkey string, hash string)) *Database_HasAcceptedBountyTerms_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string))
+		run(args[0].(uint), args[1].(string), args[2].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetLeaderBoardByUuidAndAlias_Call) Return(_a0 db.LeaderBoard) *Database_GetLeaderBoardByUuidAndAlias_Call {
+func (_c *Database_HasAcceptedBountyTerms_Call) Return(_a0 bool) *Database_HasAcceptedBountyTerms_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetLeaderBoardByUuidAndAlias_Call) RunAndReturn(run func(string, string) db.LeaderBoard) *Database_GetLeaderBoardByUuidAndAlias_Call {
+func (_c *Database_HasAcceptedBountyTerms_Call) RunAndReturn(run func(uint, string, string) bool) *Database_HasAcceptedBountyTerms_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetListedBots provides a mock function with given fields: r
-func (_m *Database) GetListedBots(r *http.Request) []db.Bot {
-	ret := _m.Called(r)
+// HasApprovedBountyProof provides a mock function with given fields: bountyId
+func (_m *Database) HasApprovedBountyProof(bountyId uint) bool {
+	ret := _m.Called(bountyId)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetListedBots")
+		panic("no return value specified for HasApprovedBountyProof")
 	}
 
-	var r0 []db.Bot
-	if rf, ok := ret.Get(0).(func(*http.Request) []db.Bot); ok {
-		r0 = rf(r)
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(uint) bool); ok {
+		r0 = rf(bountyId)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.Bot)
-		}
+		r0 = ret.Get(0).(bool)
 	}
 
 	return r0
 }
 
-// Database_GetListedBots_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetListedBots'
-type Database_GetListedBots_Call struct {
+// Database_HasApprovedBountyProof_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HasApprovedBountyProof'
+type Database_HasApprovedBountyProof_Call struct {
 	*mock.Call
 }
 
-// GetListedBots is a helper method to define mock.On call
-//   - r *http.Request
-func (_e *Database_Expecter) GetListedBots(r interface{}) *Database_GetListedBots_Call {
-	return &Database_GetListedBots_Call{Call: _e.mock.On("GetListedBots", r)}
+// HasApprovedBountyProof is a helper method to define mock.On call
+//   - bountyId uint
+func (_e *Database_Expecter) HasApprovedBountyProof(bountyId interface{}) *Database_HasApprovedBountyProof_Call {
+	return &Database_HasApprovedBountyProof_Call{Call: _e.mock.On("HasApprovedBountyProof", bountyId)}
 }
 
-func (_c *Database_GetListedBots_Call) Run(run func(r *http.Request)) *Database_GetListedBots_Call {
+func (_c *Database_HasApprovedBountyProof_Call) Run(run func(bountyId uint)) *Database_HasApprovedBountyProof_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*http.Request))
+		run(args[0].(uint))
 	})
 	return _c
 }
 
-func (_c *Database_GetListedBots_Call) Return(_a0 []db.Bot) *Database_GetListedBots_Call {
+func (_c *Database_HasApprovedBountyProof_Call) Return(_a0 bool) *Database_HasApprovedBountyProof_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetListedBots_Call) RunAndReturn(run func(*http.Request) []db.Bot) *Database_GetListedBots_Call {
+func (_c *Database_HasApprovedBountyProof_Call) RunAndReturn(run func(uint) bool) *Database_HasApprovedBountyProof_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetListedOffers provides a mock function with given fields: r
-func (_m *Database) GetListedOffers(r *http.Request) ([]db.PeopleExtra, error) {
-	ret := _m.Called(r)
+// ImportWorkspaceBundle provides a mock function with given fields: bundle, name, pubkey
+func (_m *Database) ImportWorkspaceBundle(bundle db.WorkspaceBundle, name string, pubkey string) (db.Workspace, error) {
+	ret := _m.Called(bundle, name, pubkey)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetListedOffers")
+		panic("no return value specified for ImportWorkspaceBundle")
 	}
 
-	var r0 []db.PeopleExtra
+	var r0 db.Workspace
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*http.Request) ([]db.PeopleExtra, error)); ok {
-		return rf(r)
+	if rf, ok := ret.Get(0).(func(db.WorkspaceBundle, string, string) (db.Workspace, error)); ok {
+		return rf(bundle, name, pubkey)
 	}
-	if rf, ok := ret.Get(0).(func(*http.Request) []db.PeopleExtra); ok {
-		r0 = rf(r)
+	if rf, ok := ret.Get(0).(func(db.WorkspaceBundle, string, string) db.Workspace); ok {
+		r0 = rf(bundle, name, pubkey)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.PeopleExtra)
-		}
+		r0 = ret.Get(0).(db.Workspace)
 	}
 
-	if rf, ok := ret.Get(1).(func(*http.Request) error); ok {
-		r1 = rf(r)
+	if rf, ok := ret.Get(1).(func(db.WorkspaceBundle, string, string) error); ok {
+		r1 = rf(bundle, name, pubkey)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -3760,105 +15907,103 @@ func (_m *Database) GetListedOffers(r *http.Request) ([]db.PeopleExtra, error) {
 	return r0, r1
 }
 
-// Database_GetListedOffers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetListedOffers'
-type Database_GetListedOffers_Call struct {
+// Database_ImportWorkspaceBundle_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ImportWorkspaceBundle'
+type Database_ImportWorkspaceBundle_Call struct {
 	*mock.Call
 }
 
-// GetListedOffers is a helper method to define mock.On call
-//   - r *http.Request
-func (_e *Database_Expecter) GetListedOffers(r interface{}) *Database_GetListedOffers_Call {
-	return &Database_GetListedOffers_Call{Call: _e.mock.On("GetListedOffers", r)}
+// ImportWorkspaceBundle is a helper method to define mock.On call
+//   - bundle db.WorkspaceBundle
+//   - name string
+//   - pubkey string
+func (_e *Database_Expecter) ImportWorkspaceBundle(bundle interface{}, name interface{}, pubkey interface{}) *Database_ImportWorkspaceBundle_Call {
+	return &Database_ImportWorkspaceBundle_Call{Call: _e.mock.On("ImportWorkspaceBundle", bundle, name, pubkey)}
 }
 
-func (_c *Database_GetListedOffers_Call) Run(run func(r *http.Request)) *Database_GetListedOffers_Call {
+func (_c *Database_ImportWorkspaceBundle_Call) Run(run func(bundle db.WorkspaceBundle, name string, pubkey string)) *Database_ImportWorkspaceBundle_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*http.Request))
+		run(args[0].(db.WorkspaceBundle), args[1].(string), args[2].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetListedOffers_Call) Return(_a0 []db.PeopleExtra, _a1 error) *Database_GetListedOffers_Call {
+func (_c *Database_ImportWorkspaceBundle_Call) Return(_a0 db.Workspace, _a1 error) *Database_ImportWorkspaceBundle_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetListedOffers_Call) RunAndReturn(run func(*http.Request) ([]db.PeopleExtra, error)) *Database_GetListedOffers_Call {
+func (_c *Database_ImportWorkspaceBundle_Call) RunAndReturn(run func(db.WorkspaceBundle, string, string) (db.Workspace, error)) *Database_ImportWorkspaceBundle_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetListedPeople provides a mock function with given fields: r
-func (_m *Database) GetListedPeople(r *http.Request) []db.Person {
-	ret := _m.Called(r)
+// IsBannedPubkey provides a mock function with given fields: pubkey
+func (_m *Database) IsBannedPubkey(pubkey string) bool {
+	ret := _m.Called(pubkey)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetListedPeople")
+		panic("no return value specified for IsBannedPubkey")
 	}
 
-	var r0 []db.Person
-	if rf, ok := ret.Get(0).(func(*http.Request) []db.Person); ok {
-		r0 = rf(r)
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string) bool); ok {
+		r0 = rf(pubkey)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.Person)
-		}
+		r0 = ret.Get(0).(bool)
 	}
 
 	return r0
 }
 
-// Database_GetListedPeople_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetListedPeople'
-type Database_GetListedPeople_Call struct {
+// Database_IsBannedPubkey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsBannedPubkey'
+type Database_IsBannedPubkey_Call struct {
 	*mock.Call
 }
 
-// GetListedPeople is a helper method to define mock.On call
-//   - r *http.Request
-func (_e *Database_Expecter) GetListedPeople(r interface{}) *Database_GetListedPeople_Call {
-	return &Database_GetListedPeople_Call{Call: _e.mock.On("GetListedPeople", r)}
+// IsBannedPubkey is a helper method to define mock.On call
+//   - pubkey string
+func (_e *Database_Expecter) IsBannedPubkey(pubkey interface{}) *Database_IsBannedPubkey_Call {
+	return &Database_IsBannedPubkey_Call{Call: _e.mock.On("IsBannedPubkey", pubkey)}
 }
 
-func (_c *Database_GetListedPeople_Call) Run(run func(r *http.Request)) *Database_GetListedPeople_Call {
+func (_c *Database_IsBannedPubkey_Call) Run(run func(pubkey string)) *Database_IsBannedPubkey_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*http.Request))
+		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetListedPeople_Call) Return(_a0 []db.Person) *Database_GetListedPeople_Call {
+func (_c *Database_IsBannedPubkey_Call) Return(_a0 bool) *Database_IsBannedPubkey_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetListedPeople_Call) RunAndReturn(run func(*http.Request) []db.Person) *Database_GetListedPeople_Call {
+func (_c *Database_IsBannedPubkey_Call) RunAndReturn(run func(string) bool) *Database_IsBannedPubkey_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetListedPosts provides a mock function with given fields: r
-func (_m *Database) GetListedPosts(r *http.Request) ([]db.PeopleExtra, error) {
-	ret := _m.Called(r)
+// JoinTribe provides a mock function with given fields: tribeUuid, pubkey, alias
+func (_m *Database) JoinTribe(tribeUuid string, pubkey string, alias string) (db.TribeMember, error) {
+	ret := _m.Called(tribeUuid, pubkey, alias)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetListedPosts")
+		panic("no return value specified for JoinTribe")
 	}
 
-	var r0 []db.PeopleExtra
+	var r0 db.TribeMember
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*http.Request) ([]db.PeopleExtra, error)); ok {
-		return rf(r)
+	if rf, ok := ret.Get(0).(func(string, string, string) (db.TribeMember, error)); ok {
+		return rf(tribeUuid, pubkey, alias)
 	}
-	if rf, ok := ret.Get(0).(func(*http.Request) []db.PeopleExtra); ok {
-		r0 = rf(r)
+	if rf, ok := ret.Get(0).(func(string, string, string) db.TribeMember); ok {
+		r0 = rf(tribeUuid, pubkey, alias)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.PeopleExtra)
-		}
+		r0 = ret.Get(0).(db.TribeMember)
 	}
 
-	if rf, ok := ret.Get(1).(func(*http.Request) error); ok {
-		r1 = rf(r)
+	if rf, ok := ret.Get(1).(func(string, string, string) error); ok {
+		r1 = rf(tribeUuid, pubkey, alias)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -3866,149 +16011,151 @@ func (_m *Database) GetListedPosts(r *http.Request) ([]db.PeopleExtra, error) {
 	return r0, r1
 }
 
-// Database_GetListedPosts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetListedPosts'
-type Database_GetListedPosts_Call struct {
+// Database_JoinTribe_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'JoinTribe'
+type Database_JoinTribe_Call struct {
 	*mock.Call
 }
 
-// GetListedPosts is a helper method to define mock.On call
-//   - r *http.Request
-func (_e *Database_Expecter) GetListedPosts(r interface{}) *Database_GetListedPosts_Call {
-	return &Database_GetListedPosts_Call{Call: _e.mock.On("GetListedPosts", r)}
+// JoinTribe is a helper method to define mock.On call
+//   - tribeUuid string
+//   - pubkey string
+//   - alias string
+func (_e *Database_Expecter) JoinTribe(tribeUuid interface{}, pubkey interface{}, alias interface{}) *Database_JoinTribe_Call {
+	return &Database_JoinTribe_Call{Call: _e.mock.On("JoinTribe", tribeUuid, pubkey, alias)}
 }
 
-func (_c *Database_GetListedPosts_Call) Run(run func(r *http.Request)) *Database_GetListedPosts_Call {
+func (_c *Database_JoinTribe_Call) Run(run func(tribeUuid string, pubkey string, alias string)) *Database_JoinTribe_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*http.Request))
+		run(args[0].(string), args[1].(string), args[2].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetListedPosts_Call) Return(_a0 []db.PeopleExtra, _a1 error) *Database_GetListedPosts_Call {
+func (_c *Database_JoinTribe_Call) Return(_a0 db.TribeMember, _a1 error) *Database_JoinTribe_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetListedPosts_Call) RunAndReturn(run func(*http.Request) ([]db.PeopleExtra, error)) *Database_GetListedPosts_Call {
+func (_c *Database_JoinTribe_Call) RunAndReturn(run func(string, string, string) (db.TribeMember, error)) *Database_JoinTribe_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetListedTribes provides a mock function with given fields: r
-func (_m *Database) GetListedTribes(r *http.Request) []db.Tribe {
-	ret := _m.Called(r)
+// KickTribeMember provides a mock function with given fields: tribeUuid, pubkey
+func (_m *Database) KickTribeMember(tribeUuid string, pubkey string) error {
+	ret := _m.Called(tribeUuid, pubkey)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetListedTribes")
+		panic("no return value specified for KickTribeMember")
 	}
 
-	var r0 []db.Tribe
-	if rf, ok := ret.Get(0).(func(*http.Request) []db.Tribe); ok {
-		r0 = rf(r)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(tribeUuid, pubkey)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.Tribe)
-		}
+		r0 = ret.Error(0)
 	}
 
 	return r0
 }
 
-// Database_GetListedTribes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetListedTribes'
-type Database_GetListedTribes_Call struct {
+// Database_KickTribeMember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'KickTribeMember'
+type Database_KickTribeMember_Call struct {
 	*mock.Call
 }
 
-// GetListedTribes is a helper method to define mock.On call
-//   - r *http.Request
-func (_e *Database_Expecter) GetListedTribes(r interface{}) *Database_GetListedTribes_Call {
-	return &Database_GetListedTribes_Call{Call: _e.mock.On("GetListedTribes", r)}
+// KickTribeMember is a helper method to define mock.On call
+//   - tribeUuid string
+//   - pubkey string
+func (_e *Database_Expecter) KickTribeMember(tribeUuid interface{}, pubkey interface{}) *Database_KickTribeMember_Call {
+	return &Database_KickTribeMember_Call{Call: _e.mock.On("KickTribeMember", tribeUuid, pubkey)}
 }
 
-func (_c *Database_GetListedTribes_Call) Run(run func(r *http.Request)) *Database_GetListedTribes_Call {
+func (_c *Database_KickTribeMember_Call) Run(run func(tribeUuid string, pubkey string)) *Database_KickTribeMember_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*http.Request))
+		run(args[0].(string), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetListedTribes_Call) Return(_a0 []db.Tribe) *Database_GetListedTribes_Call {
+func (_c *Database_KickTribeMember_Call) Return(_a0 error) *Database_KickTribeMember_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetListedTribes_Call) RunAndReturn(run func(*http.Request) []db.Tribe) *Database_GetListedTribes_Call {
+func (_c *Database_KickTribeMember_Call) RunAndReturn(run func(string, string) error) *Database_KickTribeMember_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetLnUser provides a mock function with given fields: lnKey
-func (_m *Database) GetLnUser(lnKey string) int64 {
-	ret := _m.Called(lnKey)
+// LeaveTribe provides a mock function with given fields: tribeUuid, pubkey
+func (_m *Database) LeaveTribe(tribeUuid string, pubkey string) error {
+	ret := _m.Called(tribeUuid, pubkey)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetLnUser")
+		panic("no return value specified for LeaveTribe")
 	}
 
-	var r0 int64
-	if rf, ok := ret.Get(0).(func(string) int64); ok {
-		r0 = rf(lnKey)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(tribeUuid, pubkey)
 	} else {
-		r0 = ret.Get(0).(int64)
+		r0 = ret.Error(0)
 	}
 
 	return r0
 }
 
-// Database_GetLnUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLnUser'
-type Database_GetLnUser_Call struct {
+// Database_LeaveTribe_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LeaveTribe'
+type Database_LeaveTribe_Call struct {
 	*mock.Call
 }
 
-// GetLnUser is a helper method to define mock.On call
-//   - lnKey string
-func (_e *Database_Expecter) GetLnUser(lnKey interface{}) *Database_GetLnUser_Call {
-	return &Database_GetLnUser_Call{Call: _e.mock.On("GetLnUser", lnKey)}
+// LeaveTribe is a helper method to define mock.On call
+//   - tribeUuid string
+//   - pubkey string
+func (_e *Database_Expecter) LeaveTribe(tribeUuid interface{}, pubkey interface{}) *Database_LeaveTribe_Call {
+	return &Database_LeaveTribe_Call{Call: _e.mock.On("LeaveTribe", tribeUuid, pubkey)}
 }
 
-func (_c *Database_GetLnUser_Call) Run(run func(lnKey string)) *Database_GetLnUser_Call {
+func (_c *Database_LeaveTribe_Call) Run(run func(tribeUuid string, pubkey string)) *Database_LeaveTribe_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(string), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetLnUser_Call) Return(_a0 int64) *Database_GetLnUser_Call {
+func (_c *Database_LeaveTribe_Call) Return(_a0 error) *Database_LeaveTribe_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetLnUser_Call) RunAndReturn(run func(string) int64) *Database_GetLnUser_Call {
+func (_c *Database_LeaveTribe_Call) RunAndReturn(run func(string, string) error) *Database_LeaveTribe_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetNextBountyByCreated provides a mock function with given fields: r
-func (_m *Database) GetNextBountyByCreated(r *http.Request) (uint, error) {
-	ret := _m.Called(r)
+// LockBountyVersion provides a mock function with given fields: id
+func (_m *Database) LockBountyVersion(id uint) (int, error) {
+	ret := _m.Called(id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetNextBountyByCreated")
+		panic("no return value specified for LockBountyVersion")
 	}
 
-	var r0 uint
+	var r0 int
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*http.Request) (uint, error)); ok {
-		return rf(r)
+	if rf, ok := ret.Get(0).(func(uint) (int, error)); ok {
+		return rf(id)
 	}
-	if rf, ok := ret.Get(0).(func(*http.Request) uint); ok {
-		r0 = rf(r)
+	if rf, ok := ret.Get(0).(func(uint) int); ok {
+		r0 = rf(id)
 	} else {
-		r0 = ret.Get(0).(uint)
+		r0 = ret.Get(0).(int)
 	}
 
-	if rf, ok := ret.Get(1).(func(*http.Request) error); ok {
-		r1 = rf(r)
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(id)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -4016,111 +16163,102 @@ func (_m *Database) GetNextBountyByCreated(r *http.Request) (uint, error) {
 	return r0, r1
 }
 
-// Database_GetNextBountyByCreated_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetNextBountyByCreated'
-type Database_GetNextBountyByCreated_Call struct {
+// Database_LockBountyVersion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LockBountyVersion'
+type Database_LockBountyVersion_Call struct {
 	*mock.Call
 }
 
-// GetNextBountyByCreated is a helper method to define mock.On call
-//   - r *http.Request
-func (_e *Database_Expecter) GetNextBountyByCreated(r interface{}) *Database_GetNextBountyByCreated_Call {
-	return &Database_GetNextBountyByCreated_Call{Call: _e.mock.On("GetNextBountyByCreated", r)}
+// LockBountyVersion is a helper method to define mock.On call
+//   - id uint
+func (_e *Database_Expecter) LockBountyVersion(id interface{}) *Database_LockBountyVersion_Call {
+	return &Database_LockBountyVersion_Call{Call: _e.mock.On("LockBountyVersion", id)}
 }
 
-func (_c *Database_GetNextBountyByCreated_Call) Run(run func(r *http.Request)) *Database_GetNextBountyByCreated_Call {
+func (_c *Database_LockBountyVersion_Call) Run(run func(id uint)) *Database_LockBountyVersion_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*http.Request))
+		run(args[0].(uint))
 	})
 	return _c
 }
 
-func (_c *Database_GetNextBountyByCreated_Call) Return(_a0 uint, _a1 error) *Database_GetNextBountyByCreated_Call {
+func (_c *Database_LockBountyVersion_Call) Return(_a0 int, _a1 error) *Database_LockBountyVersion_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetNextBountyByCreated_Call) RunAndReturn(run func(*http.Request) (uint, error)) *Database_GetNextBountyByCreated_Call {
+func (_c *Database_LockBountyVersion_Call) RunAndReturn(run func(uint) (int, error)) *Database_LockBountyVersion_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetNextWorkspaceBountyByCreated provides a mock function with given fields: r
-func (_m *Database) GetNextWorkspaceBountyByCreated(r *http.Request) (uint, error) {
-	ret := _m.Called(r)
+// MarkTipState provides a mock function with given fields: paymentRequest, state
+func (_m *Database) MarkTipState(paymentRequest string, state string) error {
+	ret := _m.Called(paymentRequest, state)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetNextWorkspaceBountyByCreated")
-	}
-
-	var r0 uint
-	var r1 error
-	if rf, ok := ret.Get(0).(func(*http.Request) (uint, error)); ok {
-		return rf(r)
-	}
-	if rf, ok := ret.Get(0).(func(*http.Request) uint); ok {
-		r0 = rf(r)
-	} else {
-		r0 = ret.Get(0).(uint)
+		panic("no return value specified for MarkTipState")
 	}
 
-	if rf, ok := ret.Get(1).(func(*http.Request) error); ok {
-		r1 = rf(r)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(paymentRequest, state)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// Database_GetNextWorkspaceBountyByCreated_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetNextWorkspaceBountyByCreated'
-type Database_GetNextWorkspaceBountyByCreated_Call struct {
+// Database_MarkTipState_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkTipState'
+type Database_MarkTipState_Call struct {
 	*mock.Call
 }
 
-// GetNextWorkspaceBountyByCreated is a helper method to define mock.On call
-//   - r *http.Request
-func (_e *Database_Expecter) GetNextWorkspaceBountyByCreated(r interface{}) *Database_GetNextWorkspaceBountyByCreated_Call {
-	return &Database_GetNextWorkspaceBountyByCreated_Call{Call: _e.mock.On("GetNextWorkspaceBountyByCreated", r)}
+// MarkTipState is a helper method to define mock.On call
+//   - paymentRequest string
+//   - state string
+func (_e *Database_Expecter) MarkTipState(paymentRequest interface{}, state interface{}) *Database_MarkTipState_Call {
+	return &Database_MarkTipState_Call{Call: _e.mock.On("MarkTipState", paymentRequest, state)}
 }
 
-func (_c *Database_GetNextWorkspaceBountyByCreated_Call) Run(run func(r *http.Request)) *Database_GetNextWorkspaceBountyByCreated_Call {
+func (_c *Database_MarkTipState_Call) Run(run func(paymentRequest string, state string)) *Database_MarkTipState_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*http.Request))
+		run(args[0].(string), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetNextWorkspaceBountyByCreated_Call) Return(_a0 uint, _a1 error) *Database_GetNextWorkspaceBountyByCreated_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *Database_MarkTipState_Call) Return(_a0 error) *Database_MarkTipState_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetNextWorkspaceBountyByCreated_Call) RunAndReturn(run func(*http.Request) (uint, error)) *Database_GetNextWorkspaceBountyByCreated_Call {
+func (_c *Database_MarkTipState_Call) RunAndReturn(run func(string, string) error) *Database_MarkTipState_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetOpenGithubIssues provides a mock function with given fields: r
-func (_m *Database) GetOpenGithubIssues(r *http.Request) (int64, error) {
-	ret := _m.Called(r)
+// MigrateLegacyBounties provides a mock function with given fields: dryRun
+func (_m *Database) MigrateLegacyBounties(dryRun bool) (db.BountyMigrationReport, error) {
+	ret := _m.Called(dryRun)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetOpenGithubIssues")
+		panic("no return value specified for MigrateLegacyBounties")
 	}
 
-	var r0 int64
+	var r0 db.BountyMigrationReport
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*http.Request) (int64, error)); ok {
-		return rf(r)
+	if rf, ok := ret.Get(0).(func(bool) (db.BountyMigrationReport, error)); ok {
+		return rf(dryRun)
 	}
-	if rf, ok := ret.Get(0).(func(*http.Request) int64); ok {
-		r0 = rf(r)
+	if rf, ok := ret.Get(0).(func(bool) db.BountyMigrationReport); ok {
+		r0 = rf(dryRun)
 	} else {
-		r0 = ret.Get(0).(int64)
+		r0 = ret.Get(0).(db.BountyMigrationReport)
 	}
 
-	if rf, ok := ret.Get(1).(func(*http.Request) error); ok {
-		r1 = rf(r)
+	if rf, ok := ret.Get(1).(func(bool) error); ok {
+		r1 = rf(dryRun)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -4128,477 +16266,492 @@ func (_m *Database) GetOpenGithubIssues(r *http.Request) (int64, error) {
 	return r0, r1
 }
 
-// Database_GetOpenGithubIssues_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOpenGithubIssues'
-type Database_GetOpenGithubIssues_Call struct {
+// Database_MigrateLegacyBounties_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MigrateLegacyBounties'
+type Database_MigrateLegacyBounties_Call struct {
 	*mock.Call
 }
 
-// GetOpenGithubIssues is a helper method to define mock.On call
-//   - r *http.Request
-func (_e *Database_Expecter) GetOpenGithubIssues(r interface{}) *Database_GetOpenGithubIssues_Call {
-	return &Database_GetOpenGithubIssues_Call{Call: _e.mock.On("GetOpenGithubIssues", r)}
+// MigrateLegacyBounties is a helper method to define mock.On call
+//   - dryRun bool
+func (_e *Database_Expecter) MigrateLegacyBounties(dryRun interface{}) *Database_MigrateLegacyBounties_Call {
+	return &Database_MigrateLegacyBounties_Call{Call: _e.mock.On("MigrateLegacyBounties", dryRun)}
 }
 
-func (_c *Database_GetOpenGithubIssues_Call) Run(run func(r *http.Request)) *Database_GetOpenGithubIssues_Call {
+func (_c *Database_MigrateLegacyBounties_Call) Run(run func(dryRun bool)) *Database_MigrateLegacyBounties_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*http.Request))
+		run(args[0].(bool))
 	})
 	return _c
 }
 
-func (_c *Database_GetOpenGithubIssues_Call) Return(_a0 int64, _a1 error) *Database_GetOpenGithubIssues_Call {
+func (_c *Database_MigrateLegacyBounties_Call) Return(_a0 db.BountyMigrationReport, _a1 error) *Database_MigrateLegacyBounties_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetOpenGithubIssues_Call) RunAndReturn(run func(*http.Request) (int64, error)) *Database_GetOpenGithubIssues_Call {
+func (_c *Database_MigrateLegacyBounties_Call) RunAndReturn(run func(bool) (db.BountyMigrationReport, error)) *Database_MigrateLegacyBounties_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetPaymentHistory provides a mock function with given fields: workspace_uuid, r
-func (_m *Database) GetPaymentHistory(workspace_uuid string, r *http.Request) []db.NewPaymentHistory {
-	ret := _m.Called(workspace_uuid, r)
+// MoveTicket provides a mock function with given fields: bountyId, phaseUuid, position
+func (_m *Database) MoveTicket(bountyId uint, phaseUuid string, position int) (db.NewBounty, error) {
+	ret := _m.Called(bountyId, phaseUuid, position)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetPaymentHistory")
+		panic("no return value specified for MoveTicket")
 	}
 
-	var r0 []db.NewPaymentHistory
-	if rf, ok := ret.Get(0).(func(string, *http.Request) []db.NewPaymentHistory); ok {
-		r0 = rf(workspace_uuid, r)
+	var r0 db.NewBounty
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint, string, int) (db.NewBounty, error)); ok {
+		return rf(bountyId, phaseUuid, position)
+	}
+	if rf, ok := ret.Get(0).(func(uint, string, int) db.NewBounty); ok {
+		r0 = rf(bountyId, phaseUuid, position)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.NewPaymentHistory)
-		}
+		r0 = ret.Get(0).(db.NewBounty)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(uint, string, int) error); ok {
+		r1 = rf(bountyId, phaseUuid, position)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_GetPaymentHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPaymentHistory'
-type Database_GetPaymentHistory_Call struct {
+// Database_MoveTicket_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MoveTicket'
+type Database_MoveTicket_Call struct {
 	*mock.Call
 }
 
-// GetPaymentHistory is a helper method to define mock.On call
-//   - workspace_uuid string
-//   - r *http.Request
-func (_e *Database_Expecter) GetPaymentHistory(workspace_uuid interface{}, r interface{}) *Database_GetPaymentHistory_Call {
-	return &Database_GetPaymentHistory_Call{Call: _e.mock.On("GetPaymentHistory", workspace_uuid, r)}
+// MoveTicket is a helper method to define mock.On call
+//   - bountyId uint
+//   - phaseUuid string
+//   - position int
+func (_e *Database_Expecter) MoveTicket(bountyId interface{}, phaseUuid interface{}, position interface{}) *Database_MoveTicket_Call {
+	return &Database_MoveTicket_Call{Call: _e.mock.On("MoveTicket", bountyId, phaseUuid, position)}
 }
 
-func (_c *Database_GetPaymentHistory_Call) Run(run func(workspace_uuid string, r *http.Request)) *Database_GetPaymentHistory_Call {
+func (_c *Database_MoveTicket_Call) Run(run func(bountyId uint, phaseUuid string, position int)) *Database_MoveTicket_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(*http.Request))
+		run(args[0].(uint), args[1].(string), args[2].(int))
 	})
 	return _c
 }
 
-func (_c *Database_GetPaymentHistory_Call) Return(_a0 []db.NewPaymentHistory) *Database_GetPaymentHistory_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_MoveTicket_Call) Return(_a0 db.NewBounty, _a1 error) *Database_MoveTicket_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetPaymentHistory_Call) RunAndReturn(run func(string, *http.Request) []db.NewPaymentHistory) *Database_GetPaymentHistory_Call {
+func (_c *Database_MoveTicket_Call) RunAndReturn(run func(uint, string, int) (db.NewBounty, error)) *Database_MoveTicket_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetPaymentHistoryByCreated provides a mock function with given fields: created, workspace_uuid
-func (_m *Database) GetPaymentHistoryByCreated(created *time.Time, workspace_uuid string) db.NewPaymentHistory {
-	ret := _m.Called(created, workspace_uuid)
+// NewHuntersPaid provides a mock function with given fields: r, workspace
+func (_m *Database) NewHuntersPaid(r db.PaymentDateRange, workspace string) int64 {
+	ret := _m.Called(r, workspace)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetPaymentHistoryByCreated")
+		panic("no return value specified for NewHuntersPaid")
 	}
 
-	var r0 db.NewPaymentHistory
-	if rf, ok := ret.Get(0).(func(*time.Time, string) db.NewPaymentHistory); ok {
-		r0 = rf(created, workspace_uuid)
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(db.PaymentDateRange, string) int64); ok {
+		r0 = rf(r, workspace)
 	} else {
-		r0 = ret.Get(0).(db.NewPaymentHistory)
+		r0 = ret.Get(0).(int64)
 	}
 
 	return r0
 }
 
-// Database_GetPaymentHistoryByCreated_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPaymentHistoryByCreated'
-type Database_GetPaymentHistoryByCreated_Call struct {
+// Database_NewHuntersPaid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NewHuntersPaid'
+type Database_NewHuntersPaid_Call struct {
 	*mock.Call
 }
 
-// GetPaymentHistoryByCreated is a helper method to define mock.On call
-//   - created *time.Time
-//   - workspace_uuid string
-func (_e *Database_Expecter) GetPaymentHistoryByCreated(created interface{}, workspace_uuid interface{}) *Database_GetPaymentHistoryByCreated_Call {
-	return &Database_GetPaymentHistoryByCreated_Call{Call: _e.mock.On("GetPaymentHistoryByCreated", created, workspace_uuid)}
+// NewHuntersPaid is a helper method to define mock.On call
+//   - r db.PaymentDateRange
+//   - workspace string
+func (_e *Database_Expecter) NewHuntersPaid(r interface{}, workspace interface{}) *Database_NewHuntersPaid_Call {
+	return &Database_NewHuntersPaid_Call{Call: _e.mock.On("NewHuntersPaid", r, workspace)}
 }
 
-func (_c *Database_GetPaymentHistoryByCreated_Call) Run(run func(created *time.Time, workspace_uuid string)) *Database_GetPaymentHistoryByCreated_Call {
+func (_c *Database_NewHuntersPaid_Call) Run(run func(r db.PaymentDateRange, workspace string)) *Database_NewHuntersPaid_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*time.Time), args[1].(string))
+		run(args[0].(db.PaymentDateRange), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetPaymentHistoryByCreated_Call) Return(_a0 db.NewPaymentHistory) *Database_GetPaymentHistoryByCreated_Call {
+func (_c *Database_NewHuntersPaid_Call) Return(_a0 int64) *Database_NewHuntersPaid_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetPaymentHistoryByCreated_Call) RunAndReturn(run func(*time.Time, string) db.NewPaymentHistory) *Database_GetPaymentHistoryByCreated_Call {
+func (_c *Database_NewHuntersPaid_Call) RunAndReturn(run func(db.PaymentDateRange, string) int64) *Database_NewHuntersPaid_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetPeopleBySearch provides a mock function with given fields: r
-func (_m *Database) GetPeopleBySearch(r *http.Request) []db.Person {
-	ret := _m.Called(r)
+// PayQuestBonus provides a mock function with given fields: quest, payment
+func (_m *Database) PayQuestBonus(quest db.Quest, payment db.NewPaymentHistory) (db.Quest, error) {
+	ret := _m.Called(quest, payment)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetPeopleBySearch")
+		panic("no return value specified for PayQuestBonus")
 	}
 
-	var r0 []db.Person
-	if rf, ok := ret.Get(0).(func(*http.Request) []db.Person); ok {
-		r0 = rf(r)
+	var r0 db.Quest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.Quest, db.NewPaymentHistory) (db.Quest, error)); ok {
+		return rf(quest, payment)
+	}
+	if rf, ok := ret.Get(0).(func(db.Quest, db.NewPaymentHistory) db.Quest); ok {
+		r0 = rf(quest, payment)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.Person)
-		}
+		r0 = ret.Get(0).(db.Quest)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(db.Quest, db.NewPaymentHistory) error); ok {
+		r1 = rf(quest, payment)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_GetPeopleBySearch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPeopleBySearch'
-type Database_GetPeopleBySearch_Call struct {
+// Database_PayQuestBonus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PayQuestBonus'
+type Database_PayQuestBonus_Call struct {
 	*mock.Call
 }
 
-// GetPeopleBySearch is a helper method to define mock.On call
-//   - r *http.Request
-func (_e *Database_Expecter) GetPeopleBySearch(r interface{}) *Database_GetPeopleBySearch_Call {
-	return &Database_GetPeopleBySearch_Call{Call: _e.mock.On("GetPeopleBySearch", r)}
+// PayQuestBonus is a helper method to define mock.On call
+//   - quest db.Quest
+//   - payment db.NewPaymentHistory
+func (_e *Database_Expecter) PayQuestBonus(quest interface{}, payment interface{}) *Database_PayQuestBonus_Call {
+	return &Database_PayQuestBonus_Call{Call: _e.mock.On("PayQuestBonus", quest, payment)}
 }
 
-func (_c *Database_GetPeopleBySearch_Call) Run(run func(r *http.Request)) *Database_GetPeopleBySearch_Call {
+func (_c *Database_PayQuestBonus_Call) Run(run func(quest db.Quest, payment db.NewPaymentHistory)) *Database_PayQuestBonus_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*http.Request))
+		run(args[0].(db.Quest), args[1].(db.NewPaymentHistory))
 	})
 	return _c
 }
 
-func (_c *Database_GetPeopleBySearch_Call) Return(_a0 []db.Person) *Database_GetPeopleBySearch_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_PayQuestBonus_Call) Return(_a0 db.Quest, _a1 error) *Database_PayQuestBonus_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetPeopleBySearch_Call) RunAndReturn(run func(*http.Request) []db.Person) *Database_GetPeopleBySearch_Call {
+func (_c *Database_PayQuestBonus_Call) RunAndReturn(run func(db.Quest, db.NewPaymentHistory) (db.Quest, error)) *Database_PayQuestBonus_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetPeopleListShort provides a mock function with given fields: count
-func (_m *Database) GetPeopleListShort(count uint32) *[]db.PersonInShort {
-	ret := _m.Called(count)
+// PersonUniqueNameFromName provides a mock function with given fields: name
+func (_m *Database) PersonUniqueNameFromName(name string) (string, error) {
+	ret := _m.Called(name)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetPeopleListShort")
+		panic("no return value specified for PersonUniqueNameFromName")
 	}
 
-	var r0 *[]db.PersonInShort
-	if rf, ok := ret.Get(0).(func(uint32) *[]db.PersonInShort); ok {
-		r0 = rf(count)
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (string, error)); ok {
+		return rf(name)
+	}
+	if rf, ok := ret.Get(0).(func(string) string); ok {
+		r0 = rf(name)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*[]db.PersonInShort)
-		}
+		r0 = ret.Get(0).(string)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_GetPeopleListShort_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPeopleListShort'
-type Database_GetPeopleListShort_Call struct {
+// Database_PersonUniqueNameFromName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PersonUniqueNameFromName'
+type Database_PersonUniqueNameFromName_Call struct {
 	*mock.Call
 }
 
-// GetPeopleListShort is a helper method to define mock.On call
-//   - count uint32
-func (_e *Database_Expecter) GetPeopleListShort(count interface{}) *Database_GetPeopleListShort_Call {
-	return &Database_GetPeopleListShort_Call{Call: _e.mock.On("GetPeopleListShort", count)}
+// PersonUniqueNameFromName is a helper method to define mock.On call
+//   - name string
+func (_e *Database_Expecter) PersonUniqueNameFromName(name interface{}) *Database_PersonUniqueNameFromName_Call {
+	return &Database_PersonUniqueNameFromName_Call{Call: _e.mock.On("PersonUniqueNameFromName", name)}
 }
 
-func (_c *Database_GetPeopleListShort_Call) Run(run func(count uint32)) *Database_GetPeopleListShort_Call {
+func (_c *Database_PersonUniqueNameFromName_Call) Run(run func(name string)) *Database_PersonUniqueNameFromName_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(uint32))
+		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetPeopleListShort_Call) Return(_a0 *[]db.PersonInShort) *Database_GetPeopleListShort_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_PersonUniqueNameFromName_Call) Return(_a0 string, _a1 error) *Database_PersonUniqueNameFromName_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetPeopleListShort_Call) RunAndReturn(run func(uint32) *[]db.PersonInShort) *Database_GetPeopleListShort_Call {
+func (_c *Database_PersonUniqueNameFromName_Call) RunAndReturn(run func(string) (string, error)) *Database_PersonUniqueNameFromName_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetPerson provides a mock function with given fields: id
-func (_m *Database) GetPerson(id uint) db.Person {
-	ret := _m.Called(id)
+// ProcessAddInvoice provides a mock function with given fields: invoice, userData
+func (_m *Database) ProcessAddInvoice(invoice db.NewInvoiceList, userData db.UserInvoiceData) error {
+	ret := _m.Called(invoice, userData)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetPerson")
+		panic("no return value specified for ProcessAddInvoice")
 	}
 
-	var r0 db.Person
-	if rf, ok := ret.Get(0).(func(uint) db.Person); ok {
-		r0 = rf(id)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(db.NewInvoiceList, db.UserInvoiceData) error); ok {
+		r0 = rf(invoice, userData)
 	} else {
-		r0 = ret.Get(0).(db.Person)
+		r0 = ret.Error(0)
 	}
 
 	return r0
 }
 
-// Database_GetPerson_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPerson'
-type Database_GetPerson_Call struct {
+// Database_ProcessAddInvoice_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ProcessAddInvoice'
+type Database_ProcessAddInvoice_Call struct {
 	*mock.Call
 }
 
-// GetPerson is a helper method to define mock.On call
-//   - id uint
-func (_e *Database_Expecter) GetPerson(id interface{}) *Database_GetPerson_Call {
-	return &Database_GetPerson_Call{Call: _e.mock.On("GetPerson", id)}
+// ProcessAddInvoice is a helper method to define mock.On call
+//   - invoice db.NewInvoiceList
+//   - userData db.UserInvoiceData
+func (_e *Database_Expecter) ProcessAddInvoice(invoice interface{}, userData interface{}) *Database_ProcessAddInvoice_Call {
+	return &Database_ProcessAddInvoice_Call{Call: _e.mock.On("ProcessAddInvoice", invoice, userData)}
 }
 
-func (_c *Database_GetPerson_Call) Run(run func(id uint)) *Database_GetPerson_Call {
+func (_c *Database_ProcessAddInvoice_Call) Run(run func(invoice db.NewInvoiceList, userData db.UserInvoiceData)) *Database_ProcessAddInvoice_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(uint))
+		run(args[0].(db.NewInvoiceList), args[1].(db.UserInvoiceData))
 	})
 	return _c
 }
 
-func (_c *Database_GetPerson_Call) Return(_a0 db.Person) *Database_GetPerson_Call {
+func (_c *Database_ProcessAddInvoice_Call) Return(_a0 error) *Database_ProcessAddInvoice_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetPerson_Call) RunAndReturn(run func(uint) db.Person) *Database_GetPerson_Call {
+func (_c *Database_ProcessAddInvoice_Call) RunAndReturn(run func(db.NewInvoiceList, db.UserInvoiceData) error) *Database_ProcessAddInvoice_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetPersonByGithubName provides a mock function with given fields: github_name
-func (_m *Database) GetPersonByGithubName(github_name string) db.Person {
-	ret := _m.Called(github_name)
-
-	if len(ret) == 0 {
-		panic("no return value specified for GetPersonByGithubName")
-	}
-
-	var r0 db.Person
-	if rf, ok := ret.Get(0).(func(string) db.Person); ok {
-		r0 = rf(github_name)
-	} else {
-		r0 = ret.Get(0).(db.Person)
-	}
-
-	return r0
+// ProcessAlerts provides a mock function with given fields: p
+func (_m *Database) ProcessAlerts(p db.Person) {
+	_m.Called(p)
 }
 
-// Database_GetPersonByGithubName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPersonByGithubName'
-type Database_GetPersonByGithubName_Call struct {
+// Database_ProcessAlerts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ProcessAlerts'
+type Database_ProcessAlerts_Call struct {
 	*mock.Call
 }
 
-// GetPersonByGithubName is a helper method to define mock.On call
-//   - github_name string
-func (_e *Database_Expecter) GetPersonByGithubName(github_name interface{}) *Database_GetPersonByGithubName_Call {
-	return &Database_GetPersonByGithubName_Call{Call: _e.mock.On("GetPersonByGithubName", github_name)}
+// ProcessAlerts is a helper method to define mock.On call
+//   - p db.Person
+func (_e *Database_Expecter) ProcessAlerts(p interface{}) *Database_ProcessAlerts_Call {
+	return &Database_ProcessAlerts_Call{Call: _e.mock.On("ProcessAlerts", p)}
 }
 
-func (_c *Database_GetPersonByGithubName_Call) Run(run func(github_name string)) *Database_GetPersonByGithubName_Call {
+func (_c *Database_ProcessAlerts_Call) Run(run func(p db.Person)) *Database_ProcessAlerts_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(db.Person))
 	})
 	return _c
 }
 
-func (_c *Database_GetPersonByGithubName_Call) Return(_a0 db.Person) *Database_GetPersonByGithubName_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_ProcessAlerts_Call) Return() *Database_ProcessAlerts_Call {
+	_c.Call.Return()
 	return _c
 }
 
-func (_c *Database_GetPersonByGithubName_Call) RunAndReturn(run func(string) db.Person) *Database_GetPersonByGithubName_Call {
+func (_c *Database_ProcessAlerts_Call) RunAndReturn(run func(db.Person)) *Database_ProcessAlerts_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetPersonByPubkey provides a mock function with given fields: pubkey
-func (_m *Database) GetPersonByPubkey(pubkey string) db.Person {
-	ret := _m.Called(pubkey)
+// ProcessBudgetInvoice provides a mock function with given fields: paymentHistory, newInvoice
+func (_m *Database) ProcessBudgetInvoice(paymentHistory db.NewPaymentHistory, newInvoice db.NewInvoiceList) error {
+	ret := _m.Called(paymentHistory, newInvoice)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetPersonByPubkey")
+		panic("no return value specified for ProcessBudgetInvoice")
 	}
 
-	var r0 db.Person
-	if rf, ok := ret.Get(0).(func(string) db.Person); ok {
-		r0 = rf(pubkey)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(db.NewPaymentHistory, db.NewInvoiceList) error); ok {
+		r0 = rf(paymentHistory, newInvoice)
 	} else {
-		r0 = ret.Get(0).(db.Person)
+		r0 = ret.Error(0)
 	}
 
 	return r0
 }
 
-// Database_GetPersonByPubkey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPersonByPubkey'
-type Database_GetPersonByPubkey_Call struct {
+// Database_ProcessBudgetInvoice_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ProcessBudgetInvoice'
+type Database_ProcessBudgetInvoice_Call struct {
 	*mock.Call
 }
 
-// GetPersonByPubkey is a helper method to define mock.On call
-//   - pubkey string
-func (_e *Database_Expecter) GetPersonByPubkey(pubkey interface{}) *Database_GetPersonByPubkey_Call {
-	return &Database_GetPersonByPubkey_Call{Call: _e.mock.On("GetPersonByPubkey", pubkey)}
+// ProcessBudgetInvoice is a helper method to define mock.On call
+//   - paymentHistory db.NewPaymentHistory
+//   - newInvoice db.NewInvoiceList
+func (_e *Database_Expecter) ProcessBudgetInvoice(paymentHistory interface{}, newInvoice interface{}) *Database_ProcessBudgetInvoice_Call {
+	return &Database_ProcessBudgetInvoice_Call{Call: _e.mock.On("ProcessBudgetInvoice", paymentHistory, newInvoice)}
 }
 
-func (_c *Database_GetPersonByPubkey_Call) Run(run func(pubkey string)) *Database_GetPersonByPubkey_Call {
+func (_c *Database_ProcessBudgetInvoice_Call) Run(run func(paymentHistory db.NewPaymentHistory, newInvoice db.NewInvoiceList)) *Database_ProcessBudgetInvoice_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(db.NewPaymentHistory), args[1].(db.NewInvoiceList))
 	})
 	return _c
 }
 
-func (_c *Database_GetPersonByPubkey_Call) Return(_a0 db.Person) *Database_GetPersonByPubkey_Call {
+func (_c *Database_ProcessBudgetInvoice_Call) Return(_a0 error) *Database_ProcessBudgetInvoice_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetPersonByPubkey_Call) RunAndReturn(run func(string) db.Person) *Database_GetPersonByPubkey_Call {
+func (_c *Database_ProcessBudgetInvoice_Call) RunAndReturn(run func(db.NewPaymentHistory, db.NewInvoiceList) error) *Database_ProcessBudgetInvoice_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetPersonByUniqueName provides a mock function with given fields: un
-func (_m *Database) GetPersonByUniqueName(un string) db.Person {
-	ret := _m.Called(un)
+// ProcessDeleteWorkspace provides a mock function with given fields: workspace_uuid
+func (_m *Database) ProcessDeleteWorkspace(workspace_uuid string) error {
+	ret := _m.Called(workspace_uuid)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetPersonByUniqueName")
+		panic("no return value specified for ProcessDeleteWorkspace")
 	}
 
-	var r0 db.Person
-	if rf, ok := ret.Get(0).(func(string) db.Person); ok {
-		r0 = rf(un)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(workspace_uuid)
 	} else {
-		r0 = ret.Get(0).(db.Person)
+		r0 = ret.Error(0)
 	}
 
 	return r0
 }
 
-// Database_GetPersonByUniqueName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPersonByUniqueName'
-type Database_GetPersonByUniqueName_Call struct {
+// Database_ProcessDeleteWorkspace_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ProcessDeleteWorkspace'
+type Database_ProcessDeleteWorkspace_Call struct {
 	*mock.Call
 }
 
-// GetPersonByUniqueName is a helper method to define mock.On call
-//   - un string
-func (_e *Database_Expecter) GetPersonByUniqueName(un interface{}) *Database_GetPersonByUniqueName_Call {
-	return &Database_GetPersonByUniqueName_Call{Call: _e.mock.On("GetPersonByUniqueName", un)}
+// ProcessDeleteWorkspace is a helper method to define mock.On call
+//   - workspace_uuid string
+func (_e *Database_Expecter) ProcessDeleteWorkspace(workspace_uuid interface{}) *Database_ProcessDeleteWorkspace_Call {
+	return &Database_ProcessDeleteWorkspace_Call{Call: _e.mock.On("ProcessDeleteWorkspace", workspace_uuid)}
 }
 
-func (_c *Database_GetPersonByUniqueName_Call) Run(run func(un string)) *Database_GetPersonByUniqueName_Call {
+func (_c *Database_ProcessDeleteWorkspace_Call) Run(run func(workspace_uuid string)) *Database_ProcessDeleteWorkspace_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetPersonByUniqueName_Call) Return(_a0 db.Person) *Database_GetPersonByUniqueName_Call {
+func (_c *Database_ProcessDeleteWorkspace_Call) Return(_a0 error) *Database_ProcessDeleteWorkspace_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetPersonByUniqueName_Call) RunAndReturn(run func(string) db.Person) *Database_GetPersonByUniqueName_Call {
+func (_c *Database_ProcessDeleteWorkspace_Call) RunAndReturn(run func(string) error) *Database_ProcessDeleteWorkspace_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetPersonByUuid provides a mock function with given fields: uuid
-func (_m *Database) GetPersonByUuid(uuid string) db.Person {
-	ret := _m.Called(uuid)
+// ProcessUpdateBudget provides a mock function with given fields: invoice
+func (_m *Database) ProcessUpdateBudget(invoice db.NewInvoiceList) error {
+	ret := _m.Called(invoice)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetPersonByUuid")
+		panic("no return value specified for ProcessUpdateBudget")
 	}
 
-	var r0 db.Person
-	if rf, ok := ret.Get(0).(func(string) db.Person); ok {
-		r0 = rf(uuid)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(db.NewInvoiceList) error); ok {
+		r0 = rf(invoice)
 	} else {
-		r0 = ret.Get(0).(db.Person)
+		r0 = ret.Error(0)
 	}
 
 	return r0
 }
 
-// Database_GetPersonByUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPersonByUuid'
-type Database_GetPersonByUuid_Call struct {
+// Database_ProcessUpdateBudget_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ProcessUpdateBudget'
+type Database_ProcessUpdateBudget_Call struct {
 	*mock.Call
 }
 
-// GetPersonByUuid is a helper method to define mock.On call
-//   - uuid string
-func (_e *Database_Expecter) GetPersonByUuid(uuid interface{}) *Database_GetPersonByUuid_Call {
-	return &Database_GetPersonByUuid_Call{Call: _e.mock.On("GetPersonByUuid", uuid)}
+// ProcessUpdateBudget is a helper method to define mock.On call
+//   - invoice db.NewInvoiceList
+func (_e *Database_Expecter) ProcessUpdateBudget(invoice interface{}) *Database_ProcessUpdateBudget_Call {
+	return &Database_ProcessUpdateBudget_Call{Call: _e.mock.On("ProcessUpdateBudget", invoice)}
 }
 
-func (_c *Database_GetPersonByUuid_Call) Run(run func(uuid string)) *Database_GetPersonByUuid_Call {
+func (_c *Database_ProcessUpdateBudget_Call) Run(run func(invoice db.NewInvoiceList)) *Database_ProcessUpdateBudget_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(db.NewInvoiceList))
 	})
 	return _c
 }
 
-func (_c *Database_GetPersonByUuid_Call) Return(_a0 db.Person) *Database_GetPersonByUuid_Call {
+func (_c *Database_ProcessUpdateBudget_Call) Return(_a0 error) *Database_ProcessUpdateBudget_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetPersonByUuid_Call) RunAndReturn(run func(string) db.Person) *Database_GetPersonByUuid_Call {
+func (_c *Database_ProcessUpdateBudget_Call) RunAndReturn(run func(db.NewInvoiceList) error) *Database_ProcessUpdateBudget_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetPhaseByUuid provides a mock function with given fields: phaseUuid
-func (_m *Database) GetPhaseByUuid(phaseUuid string) (db.FeaturePhase, error) {
-	ret := _m.Called(phaseUuid)
+// QueueTribePreview provides a mock function with given fields: tribeUuid, url
+func (_m *Database) QueueTribePreview(tribeUuid string, url string) (db.TribePreview, error) {
+	ret := _m.Called(tribeUuid, url)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetPhaseByUuid")
+		panic("no return value specified for QueueTribePreview")
 	}
 
-	var r0 db.FeaturePhase
+	var r0 db.TribePreview
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (db.FeaturePhase, error)); ok {
-		return rf(phaseUuid)
+	if rf, ok := ret.Get(0).(func(string, string) (db.TribePreview, error)); ok {
+		return rf(tribeUuid, url)
 	}
-	if rf, ok := ret.Get(0).(func(string) db.FeaturePhase); ok {
-		r0 = rf(phaseUuid)
+	if rf, ok := ret.Get(0).(func(string, string) db.TribePreview); ok {
+		r0 = rf(tribeUuid, url)
 	} else {
-		r0 = ret.Get(0).(db.FeaturePhase)
+		r0 = ret.Get(0).(db.TribePreview)
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(phaseUuid)
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(tribeUuid, url)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -4606,103 +16759,112 @@ func (_m *Database) GetPhaseByUuid(phaseUuid string) (db.FeaturePhase, error) {
 	return r0, r1
 }
 
-// Database_GetPhaseByUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPhaseByUuid'
-type Database_GetPhaseByUuid_Call struct {
+// Database_QueueTribePreview_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'QueueTribePreview'
+type Database_QueueTribePreview_Call struct {
 	*mock.Call
 }
 
-// GetPhaseByUuid is a helper method to define mock.On call
-//   - phaseUuid string
-func (_e *Database_Expecter) GetPhaseByUuid(phaseUuid interface{}) *Database_GetPhaseByUuid_Call {
-	return &Database_GetPhaseByUuid_Call{Call: _e.mock.On("GetPhaseByUuid", phaseUuid)}
+// QueueTribePreview is a helper method to define mock.On call
+//   - tribeUuid string
+//   - url string
+func (_e *Database_Expecter) QueueTribePreview(tribeUuid interface{}, url interface{}) *Database_QueueTribePreview_Call {
+	return &Database_QueueTribePreview_Call{Call: _e.mock.On("QueueTribePreview", tribeUuid, url)}
 }
 
-func (_c *Database_GetPhaseByUuid_Call) Run(run func(phaseUuid string)) *Database_GetPhaseByUuid_Call {
+func (_c *Database_QueueTribePreview_Call) Run(run func(tribeUuid string, url string)) *Database_QueueTribePreview_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(string), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetPhaseByUuid_Call) Return(_a0 db.FeaturePhase, _a1 error) *Database_GetPhaseByUuid_Call {
+func (_c *Database_QueueTribePreview_Call) Return(_a0 db.TribePreview, _a1 error) *Database_QueueTribePreview_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetPhaseByUuid_Call) RunAndReturn(run func(string) (db.FeaturePhase, error)) *Database_GetPhaseByUuid_Call {
+func (_c *Database_QueueTribePreview_Call) RunAndReturn(run func(string, string) (db.TribePreview, error)) *Database_QueueTribePreview_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetPhasesByFeatureUuid provides a mock function with given fields: featureUuid
-func (_m *Database) GetPhasesByFeatureUuid(featureUuid string) []db.FeaturePhase {
-	ret := _m.Called(featureUuid)
+// ReadAllNotifications provides a mock function with given fields: pubkey
+func (_m *Database) ReadAllNotifications(pubkey string) (int64, error) {
+	ret := _m.Called(pubkey)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetPhasesByFeatureUuid")
+		panic("no return value specified for ReadAllNotifications")
 	}
 
-	var r0 []db.FeaturePhase
-	if rf, ok := ret.Get(0).(func(string) []db.FeaturePhase); ok {
-		r0 = rf(featureUuid)
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (int64, error)); ok {
+		return rf(pubkey)
+	}
+	if rf, ok := ret.Get(0).(func(string) int64); ok {
+		r0 = rf(pubkey)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.FeaturePhase)
-		}
+		r0 = ret.Get(0).(int64)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(pubkey)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_GetPhasesByFeatureUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPhasesByFeatureUuid'
-type Database_GetPhasesByFeatureUuid_Call struct {
+// Database_ReadAllNotifications_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReadAllNotifications'
+type Database_ReadAllNotifications_Call struct {
 	*mock.Call
 }
 
-// GetPhasesByFeatureUuid is a helper method to define mock.On call
-//   - featureUuid string
-func (_e *Database_Expecter) GetPhasesByFeatureUuid(featureUuid interface{}) *Database_GetPhasesByFeatureUuid_Call {
-	return &Database_GetPhasesByFeatureUuid_Call{Call: _e.mock.On("GetPhasesByFeatureUuid", featureUuid)}
+// ReadAllNotifications is a helper method to define mock.On call
+//   - pubkey string
+func (_e *Database_Expecter) ReadAllNotifications(pubkey interface{}) *Database_ReadAllNotifications_Call {
+	return &Database_ReadAllNotifications_Call{Call: _e.mock.On("ReadAllNotifications", pubkey)}
 }
 
-func (_c *Database_GetPhasesByFeatureUuid_Call) Run(run func(featureUuid string)) *Database_GetPhasesByFeatureUuid_Call {
+func (_c *Database_ReadAllNotifications_Call) Run(run func(pubkey string)) *Database_ReadAllNotifications_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetPhasesByFeatureUuid_Call) Return(_a0 []db.FeaturePhase) *Database_GetPhasesByFeatureUuid_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_ReadAllNotifications_Call) Return(_a0 int64, _a1 error) *Database_ReadAllNotifications_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetPhasesByFeatureUuid_Call) RunAndReturn(run func(string) []db.FeaturePhase) *Database_GetPhasesByFeatureUuid_Call {
+func (_c *Database_ReadAllNotifications_Call) RunAndReturn(run func(string) (int64, error)) *Database_ReadAllNotifications_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetPreviousBountyByCreated provides a mock function with given fields: r
-func (_m *Database) GetPreviousBountyByCreated(r *http.Request) (uint, error) {
-	ret := _m.Called(r)
+// ReadNotification provides a mock function with given fields: pubkey, id
+func (_m *Database) ReadNotification(pubkey string, id uint) (bool, error) {
+	ret := _m.Called(pubkey, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetPreviousBountyByCreated")
+		panic("no return value specified for ReadNotification")
 	}
 
-	var r0 uint
+	var r0 bool
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*http.Request) (uint, error)); ok {
-		return rf(r)
+	if rf, ok := ret.Get(0).(func(string, uint) (bool, error)); ok {
+		return rf(pubkey, id)
 	}
-	if rf, ok := ret.Get(0).(func(*http.Request) uint); ok {
-		r0 = rf(r)
+	if rf, ok := ret.Get(0).(func(string, uint) bool); ok {
+		r0 = rf(pubkey, id)
 	} else {
-		r0 = ret.Get(0).(uint)
+		r0 = ret.Get(0).(bool)
 	}
 
-	if rf, ok := ret.Get(1).(func(*http.Request) error); ok {
-		r1 = rf(r)
+	if rf, ok := ret.Get(1).(func(string, uint) error); ok {
+		r1 = rf(pubkey, id)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -4710,55 +16872,56 @@ func (_m *Database) GetPreviousBountyByCreated(r *http.Request) (uint, error) {
 	return r0, r1
 }
 
-// Database_GetPreviousBountyByCreated_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPreviousBountyByCreated'
-type Database_GetPreviousBountyByCreated_Call struct {
+// Database_ReadNotification_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReadNotification'
+type Database_ReadNotification_Call struct {
 	*mock.Call
 }
 
-// GetPreviousBountyByCreated is a helper method to define mock.On call
-//   - r *http.Request
-func (_e *Database_Expecter) GetPreviousBountyByCreated(r interface{}) *Database_GetPreviousBountyByCreated_Call {
-	return &Database_GetPreviousBountyByCreated_Call{Call: _e.mock.On("GetPreviousBountyByCreated", r)}
+// ReadNotification is a helper method to define mock.On call
+//   - pubkey string
+//   - id uint
+func (_e *Database_Expecter) ReadNotification(pubkey interface{}, id interface{}) *Database_ReadNotification_Call {
+	return &Database_ReadNotification_Call{Call: _e.mock.On("ReadNotification", pubkey, id)}
 }
 
-func (_c *Database_GetPreviousBountyByCreated_Call) Run(run func(r *http.Request)) *Database_GetPreviousBountyByCreated_Call {
+func (_c *Database_ReadNotification_Call) Run(run func(pubkey string, id uint)) *Database_ReadNotification_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*http.Request))
+		run(args[0].(string), args[1].(uint))
 	})
 	return _c
 }
 
-func (_c *Database_GetPreviousBountyByCreated_Call) Return(_a0 uint, _a1 error) *Database_GetPreviousBountyByCreated_Call {
+func (_c *Database_ReadNotification_Call) Return(_a0 bool, _a1 error) *Database_ReadNotification_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetPreviousBountyByCreated_Call) RunAndReturn(run func(*http.Request) (uint, error)) *Database_GetPreviousBountyByCreated_Call {
+func (_c *Database_ReadNotification_Call) RunAndReturn(run func(string, uint) (bool, error)) *Database_ReadNotification_Call {
 	_c.Call.Return(run)
 	return _c
-}
-
-// GetPreviousWorkspaceBountyByCreated provides a mock function with given fields: r
-func (_m *Database) GetPreviousWorkspaceBountyByCreated(r *http.Request) (uint, error) {
-	ret := _m.Called(r)
+}
+
+// ReconcilePayment provides a mock function with given fields: id, settled, paymentHash
+func (_m *Database) ReconcilePayment(id uint, settled bool, paymentHash string) (db.NewPaymentHistory, error) {
+	ret := _m.Called(id, settled, paymentHash)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetPreviousWorkspaceBountyByCreated")
+		panic("no return value specified for ReconcilePayment")
 	}
 
-	var r0 uint
+	var r0 db.NewPaymentHistory
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*http.Request) (uint, error)); ok {
-		return rf(r)
+	if rf, ok := ret.Get(0).(func(uint, bool, string) (db.NewPaymentHistory, error)); ok {
+		return rf(id, settled, paymentHash)
 	}
-	if rf, ok := ret.Get(0).(func(*http.Request) uint); ok {
-		r0 = rf(r)
+	if rf, ok := ret.Get(0).(func(uint, bool, string) db.NewPaymentHistory); ok {
+		r0 = rf(id, settled, paymentHash)
 	} else {
-		r0 = ret.Get(0).(uint)
+		r0 = ret.Get(0).(db.NewPaymentHistory)
 	}
 
-	if rf, ok := ret.Get(1).(func(*http.Request) error); ok {
-		r1 = rf(r)
+	if rf, ok := ret.Get(1).(func(uint, bool, string) error); ok {
+		r1 = rf(id, settled, paymentHash)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -4766,1286 +16929,1445 @@ func (_m *Database) GetPreviousWorkspaceBountyByCreated(r *http.Request) (uint,
 	return r0, r1
 }
 
-// Database_GetPreviousWorkspaceBountyByCreated_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPreviousWorkspaceBountyByCreated'
-type Database_GetPreviousWorkspaceBountyByCreated_Call struct {
+// Database_ReconcilePayment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReconcilePayment'
+type Database_ReconcilePayment_Call struct {
 	*mock.Call
 }
 
-// GetPreviousWorkspaceBountyByCreated is a helper method to define mock.On call
-//   - r *http.Request
-func (_e *Database_Expecter) GetPreviousWorkspaceBountyByCreated(r interface{}) *Database_GetPreviousWorkspaceBountyByCreated_Call {
-	return &Database_GetPreviousWorkspaceBountyByCreated_Call{Call: _e.mock.On("GetPreviousWorkspaceBountyByCreated", r)}
+// ReconcilePayment is a helper method to define mock.On call
+//   - id uint
+//   - settled bool
+//   - paymentHash string
+func (_e *Database_Expecter) ReconcilePayment(id interface{}, settled interface{}, paymentHash interface{}) *Database_ReconcilePayment_Call {
+	return &Database_ReconcilePayment_Call{Call: _e.mock.On("ReconcilePayment", id, settled, paymentHash)}
 }
 
-func (_c *Database_GetPreviousWorkspaceBountyByCreated_Call) Run(run func(r *http.Request)) *Database_GetPreviousWorkspaceBountyByCreated_Call {
+func (_c *Database_ReconcilePayment_Call) Run(run func(id uint, settled bool, paymentHash string)) *Database_ReconcilePayment_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*http.Request))
+		run(args[0].(uint), args[1].(bool), args[2].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetPreviousWorkspaceBountyByCreated_Call) Return(_a0 uint, _a1 error) *Database_GetPreviousWorkspaceBountyByCreated_Call {
+func (_c *Database_ReconcilePayment_Call) Return(_a0 db.NewPaymentHistory, _a1 error) *Database_ReconcilePayment_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetPreviousWorkspaceBountyByCreated_Call) RunAndReturn(run func(*http.Request) (uint, error)) *Database_GetPreviousWorkspaceBountyByCreated_Call {
+func (_c *Database_ReconcilePayment_Call) RunAndReturn(run func(uint, bool, string) (db.NewPaymentHistory, error)) *Database_ReconcilePayment_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetTribe provides a mock function with given fields: uuid
-func (_m *Database) GetTribe(uuid string) db.Tribe {
-	ret := _m.Called(uuid)
+// RecordTribeWebhookDelivery provides a mock function with given fields: uuid, status, deliveryErr
+func (_m *Database) RecordTribeWebhookDelivery(uuid string, status int, deliveryErr string) error {
+	ret := _m.Called(uuid, status, deliveryErr)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetTribe")
+		panic("no return value specified for RecordTribeWebhookDelivery")
 	}
 
-	var r0 db.Tribe
-	if rf, ok := ret.Get(0).(func(string) db.Tribe); ok {
-		r0 = rf(uuid)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, int, string) error); ok {
+		r0 = rf(uuid, status, deliveryErr)
 	} else {
-		r0 = ret.Get(0).(db.Tribe)
+		r0 = ret.Error(0)
 	}
 
 	return r0
 }
 
-// Database_GetTribe_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTribe'
-type Database_GetTribe_Call struct {
+// Database_RecordTribeWebhookDelivery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordTribeWebhookDelivery'
+type Database_RecordTribeWebhookDelivery_Call struct {
 	*mock.Call
 }
 
-// GetTribe is a helper method to define mock.On call
+// RecordTribeWebhookDelivery is a helper method to define mock.On call
 //   - uuid string
-func (_e *Database_Expecter) GetTribe(uuid interface{}) *Database_GetTribe_Call {
-	return &Database_GetTribe_Call{Call: _e.mock.On("GetTribe", uuid)}
+//   - status int
+//   - deliveryErr string
+func (_e *Database_Expecter) RecordTribeWebhookDelivery(uuid interface{}, status interface{}, deliveryErr interface{}) *Database_RecordTribeWebhookDelivery_Call {
+	return &Database_RecordTribeWebhookDelivery_Call{Call: _e.mock.On("RecordTribeWebhookDelivery", uuid, status, deliveryErr)}
 }
 
-func (_c *Database_GetTribe_Call) Run(run func(uuid string)) *Database_GetTribe_Call {
+func (_c *Database_RecordTribeWebhookDelivery_Call) Run(run func(uuid string, status int, deliveryErr string)) *Database_RecordTribeWebhookDelivery_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(string), args[1].(int), args[2].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetTribe_Call) Return(_a0 db.Tribe) *Database_GetTribe_Call {
+func (_c *Database_RecordTribeWebhookDelivery_Call) Return(_a0 error) *Database_RecordTribeWebhookDelivery_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetTribe_Call) RunAndReturn(run func(string) db.Tribe) *Database_GetTribe_Call {
+func (_c *Database_RecordTribeWebhookDelivery_Call) RunAndReturn(run func(string, int, string) error) *Database_RecordTribeWebhookDelivery_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetTribeByIdAndPubkey provides a mock function with given fields: uuid, pubkey
-func (_m *Database) GetTribeByIdAndPubkey(uuid string, pubkey string) db.Tribe {
-	ret := _m.Called(uuid, pubkey)
+// RedeemConnectionCode provides a mock function with given fields:
+func (_m *Database) RedeemConnectionCode() (db.ConnectionCodes, error) {
+	ret := _m.Called()
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetTribeByIdAndPubkey")
+		panic("no return value specified for RedeemConnectionCode")
 	}
 
-	var r0 db.Tribe
-	if rf, ok := ret.Get(0).(func(string, string) db.Tribe); ok {
-		r0 = rf(uuid, pubkey)
+	var r0 db.ConnectionCodes
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (db.ConnectionCodes, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() db.ConnectionCodes); ok {
+		r0 = rf()
 	} else {
-		r0 = ret.Get(0).(db.Tribe)
+		r0 = ret.Get(0).(db.ConnectionCodes)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_GetTribeByIdAndPubkey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTribeByIdAndPubkey'
-type Database_GetTribeByIdAndPubkey_Call struct {
+// Database_RedeemConnectionCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RedeemConnectionCode'
+type Database_RedeemConnectionCode_Call struct {
 	*mock.Call
 }
 
-// GetTribeByIdAndPubkey is a helper method to define mock.On call
-//   - uuid string
-//   - pubkey string
-func (_e *Database_Expecter) GetTribeByIdAndPubkey(uuid interface{}, pubkey interface{}) *Database_GetTribeByIdAndPubkey_Call {
-	return &Database_GetTribeByIdAndPubkey_Call{Call: _e.mock.On("GetTribeByIdAndPubkey", uuid, pubkey)}
+// RedeemConnectionCode is a helper method to define mock.On call
+func (_e *Database_Expecter) RedeemConnectionCode() *Database_RedeemConnectionCode_Call {
+	return &Database_RedeemConnectionCode_Call{Call: _e.mock.On("RedeemConnectionCode")}
 }
 
-func (_c *Database_GetTribeByIdAndPubkey_Call) Run(run func(uuid string, pubkey string)) *Database_GetTribeByIdAndPubkey_Call {
+func (_c *Database_RedeemConnectionCode_Call) Run(run func()) *Database_RedeemConnectionCode_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string))
+		run()
 	})
 	return _c
 }
 
-func (_c *Database_GetTribeByIdAndPubkey_Call) Return(_a0 db.Tribe) *Database_GetTribeByIdAndPubkey_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_RedeemConnectionCode_Call) Return(_a0 db.ConnectionCodes, _a1 error) *Database_RedeemConnectionCode_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetTribeByIdAndPubkey_Call) RunAndReturn(run func(string, string) db.Tribe) *Database_GetTribeByIdAndPubkey_Call {
+func (_c *Database_RedeemConnectionCode_Call) RunAndReturn(run func() (db.ConnectionCodes, error)) *Database_RedeemConnectionCode_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetTribeByUniqueName provides a mock function with given fields: un
-func (_m *Database) GetTribeByUniqueName(un string) db.Tribe {
-	ret := _m.Called(un)
+// RefreshBountyPriceSuggestions provides a mock function with given fields: at
+func (_m *Database) RefreshBountyPriceSuggestions(at time.Time) error {
+	ret := _m.Called(at)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetTribeByUniqueName")
+		panic("no return value specified for RefreshBountyPriceSuggestions")
 	}
 
-	var r0 db.Tribe
-	if rf, ok := ret.Get(0).(func(string) db.Tribe); ok {
-		r0 = rf(un)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(time.Time) error); ok {
+		r0 = rf(at)
 	} else {
-		r0 = ret.Get(0).(db.Tribe)
+		r0 = ret.Error(0)
 	}
 
 	return r0
 }
 
-// Database_GetTribeByUniqueName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTribeByUniqueName'
-type Database_GetTribeByUniqueName_Call struct {
+// Database_RefreshBountyPriceSuggestions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RefreshBountyPriceSuggestions'
+type Database_RefreshBountyPriceSuggestions_Call struct {
 	*mock.Call
 }
 
-// GetTribeByUniqueName is a helper method to define mock.On call
-//   - un string
-func (_e *Database_Expecter) GetTribeByUniqueName(un interface{}) *Database_GetTribeByUniqueName_Call {
-	return &Database_GetTribeByUniqueName_Call{Call: _e.mock.On("GetTribeByUniqueName", un)}
+// RefreshBountyPriceSuggestions is a helper method to define mock.On call
+//   - at time.Time
+func (_e *Database_Expecter) RefreshBountyPriceSuggestions(at interface{}) *Database_RefreshBountyPriceSuggestions_Call {
+	return &Database_RefreshBountyPriceSuggestions_Call{Call: _e.mock.On("RefreshBountyPriceSuggestions", at)}
 }
 
-func (_c *Database_GetTribeByUniqueName_Call) Run(run func(un string)) *Database_GetTribeByUniqueName_Call {
+func (_c *Database_RefreshBountyPriceSuggestions_Call) Run(run func(at time.Time)) *Database_RefreshBountyPriceSuggestions_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(time.Time))
 	})
 	return _c
 }
 
-func (_c *Database_GetTribeByUniqueName_Call) Return(_a0 db.Tribe) *Database_GetTribeByUniqueName_Call {
+func (_c *Database_RefreshBountyPriceSuggestions_Call) Return(_a0 error) *Database_RefreshBountyPriceSuggestions_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetTribeByUniqueName_Call) RunAndReturn(run func(string) db.Tribe) *Database_GetTribeByUniqueName_Call {
+func (_c *Database_RefreshBountyPriceSuggestions_Call) RunAndReturn(run func(time.Time) error) *Database_RefreshBountyPriceSuggestions_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetTribesByAppUrl provides a mock function with given fields: aurl
-func (_m *Database) GetTribesByAppUrl(aurl string) []db.Tribe {
-	ret := _m.Called(aurl)
+// RefreshBountyRecommendations provides a mock function with given fields: pubkeys, at
+func (_m *Database) RefreshBountyRecommendations(pubkeys []string, at time.Time) error {
+	ret := _m.Called(pubkeys, at)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetTribesByAppUrl")
+		panic("no return value specified for RefreshBountyRecommendations")
 	}
 
-	var r0 []db.Tribe
-	if rf, ok := ret.Get(0).(func(string) []db.Tribe); ok {
-		r0 = rf(aurl)
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]string, time.Time) error); ok {
+		r0 = rf(pubkeys, at)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.Tribe)
-		}
+		r0 = ret.Error(0)
 	}
 
 	return r0
 }
 
-// Database_GetTribesByAppUrl_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTribesByAppUrl'
-type Database_GetTribesByAppUrl_Call struct {
+// Database_RefreshBountyRecommendations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RefreshBountyRecommendations'
+type Database_RefreshBountyRecommendations_Call struct {
 	*mock.Call
 }
 
-// GetTribesByAppUrl is a helper method to define mock.On call
-//   - aurl string
-func (_e *Database_Expecter) GetTribesByAppUrl(aurl interface{}) *Database_GetTribesByAppUrl_Call {
-	return &Database_GetTribesByAppUrl_Call{Call: _e.mock.On("GetTribesByAppUrl", aurl)}
+// RefreshBountyRecommendations is a helper method to define mock.On call
+//   - pubkeys []string
+//   - at time.Time
+func (_e *Database_Expecter) RefreshBountyRecommendations(pubkeys interface{}, at interface{}) *Database_RefreshBountyRecommendations_Call {
+	return &Database_RefreshBountyRecommendations_Call{Call: _e.mock.On("RefreshBountyRecommendations", pubkeys, at)}
 }
 
-func (_c *Database_GetTribesByAppUrl_Call) Run(run func(aurl string)) *Database_GetTribesByAppUrl_Call {
+func (_c *Database_RefreshBountyRecommendations_Call) Run(run func(pubkeys []string, at time.Time)) *Database_RefreshBountyRecommendations_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].([]string), args[1].(time.Time))
 	})
 	return _c
 }
 
-func (_c *Database_GetTribesByAppUrl_Call) Return(_a0 []db.Tribe) *Database_GetTribesByAppUrl_Call {
+func (_c *Database_RefreshBountyRecommendations_Call) Return(_a0 error) *Database_RefreshBountyRecommendations_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetTribesByAppUrl_Call) RunAndReturn(run func(string) []db.Tribe) *Database_GetTribesByAppUrl_Call {
+func (_c *Database_RefreshBountyRecommendations_Call) RunAndReturn(run func([]string, time.Time) error) *Database_RefreshBountyRecommendations_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetTribesByOwner provides a mock function with given fields: pubkey
-func (_m *Database) GetTribesByOwner(pubkey string) []db.Tribe {
-	ret := _m.Called(pubkey)
+// RejectReserveRelease provides a mock function with given fields: entry, pubkey
+func (_m *Database) RejectReserveRelease(entry db.ReserveEntry, pubkey string) (db.ReserveEntry, error) {
+	ret := _m.Called(entry, pubkey)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetTribesByOwner")
+		panic("no return value specified for RejectReserveRelease")
 	}
 
-	var r0 []db.Tribe
-	if rf, ok := ret.Get(0).(func(string) []db.Tribe); ok {
-		r0 = rf(pubkey)
+	var r0 db.ReserveEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.ReserveEntry, string) (db.ReserveEntry, error)); ok {
+		return rf(entry, pubkey)
+	}
+	if rf, ok := ret.Get(0).(func(db.ReserveEntry, string) db.ReserveEntry); ok {
+		r0 = rf(entry, pubkey)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.Tribe)
-		}
+		r0 = ret.Get(0).(db.ReserveEntry)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(db.ReserveEntry, string) error); ok {
+		r1 = rf(entry, pubkey)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_GetTribesByOwner_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTribesByOwner'
-type Database_GetTribesByOwner_Call struct {
+// Database_RejectReserveRelease_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RejectReserveRelease'
+type Database_RejectReserveRelease_Call struct {
 	*mock.Call
 }
 
-// GetTribesByOwner is a helper method to define mock.On call
+// RejectReserveRelease is a helper method to define mock.On call
+//   - entry db.ReserveEntry
 //   - pubkey string
-func (_e *Database_Expecter) GetTribesByOwner(pubkey interface{}) *Database_GetTribesByOwner_Call {
-	return &Database_GetTribesByOwner_Call{Call: _e.mock.On("GetTribesByOwner", pubkey)}
+func (_e *Database_Expecter) RejectReserveRelease(entry interface{}, pubkey interface{}) *Database_RejectReserveRelease_Call {
+	return &Database_RejectReserveRelease_Call{Call: _e.mock.On("RejectReserveRelease", entry, pubkey)}
 }
 
-func (_c *Database_GetTribesByOwner_Call) Run(run func(pubkey string)) *Database_GetTribesByOwner_Call {
+func (_c *Database_RejectReserveRelease_Call) Run(run func(entry db.ReserveEntry, pubkey string)) *Database_RejectReserveRelease_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(db.ReserveEntry), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetTribesByOwner_Call) Return(_a0 []db.Tribe) *Database_GetTribesByOwner_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_RejectReserveRelease_Call) Return(_a0 db.ReserveEntry, _a1 error) *Database_RejectReserveRelease_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetTribesByOwner_Call) RunAndReturn(run func(string) []db.Tribe) *Database_GetTribesByOwner_Call {
+func (_c *Database_RejectReserveRelease_Call) RunAndReturn(run func(db.ReserveEntry, string) (db.ReserveEntry, error)) *Database_RejectReserveRelease_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetTribesTotal provides a mock function with given fields:
-func (_m *Database) GetTribesTotal() int64 {
-	ret := _m.Called()
+// ReleaseBountyPayment provides a mock function with given fields: id
+func (_m *Database) ReleaseBountyPayment(id uint) error {
+	ret := _m.Called(id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetTribesTotal")
+		panic("no return value specified for ReleaseBountyPayment")
 	}
 
-	var r0 int64
-	if rf, ok := ret.Get(0).(func() int64); ok {
-		r0 = rf()
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint) error); ok {
+		r0 = rf(id)
 	} else {
-		r0 = ret.Get(0).(int64)
+		r0 = ret.Error(0)
 	}
 
 	return r0
 }
 
-// Database_GetTribesTotal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTribesTotal'
-type Database_GetTribesTotal_Call struct {
+// Database_ReleaseBountyPayment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReleaseBountyPayment'
+type Database_ReleaseBountyPayment_Call struct {
 	*mock.Call
 }
 
-// GetTribesTotal is a helper method to define mock.On call
-func (_e *Database_Expecter) GetTribesTotal() *Database_GetTribesTotal_Call {
-	return &Database_GetTribesTotal_Call{Call: _e.mock.On("GetTribesTotal")}
+// ReleaseBountyPayment is a helper method to define mock.On call
+//   - id uint
+func (_e *Database_Expecter) ReleaseBountyPayment(id interface{}) *Database_ReleaseBountyPayment_Call {
+	return &Database_ReleaseBountyPayment_Call{Call: _e.mock.On("ReleaseBountyPayment", id)}
 }
 
-func (_c *Database_GetTribesTotal_Call) Run(run func()) *Database_GetTribesTotal_Call {
+func (_c *Database_ReleaseBountyPayment_Call) Run(run func(id uint)) *Database_ReleaseBountyPayment_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		run(args[0].(uint))
 	})
 	return _c
 }
 
-func (_c *Database_GetTribesTotal_Call) Return(_a0 int64) *Database_GetTribesTotal_Call {
+func (_c *Database_ReleaseBountyPayment_Call) Return(_a0 error) *Database_ReleaseBountyPayment_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetTribesTotal_Call) RunAndReturn(run func() int64) *Database_GetTribesTotal_Call {
+func (_c *Database_ReleaseBountyPayment_Call) RunAndReturn(run func(uint) error) *Database_ReleaseBountyPayment_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetUnconfirmedGithub provides a mock function with given fields:
-func (_m *Database) GetUnconfirmedGithub() []db.Person {
-	ret := _m.Called()
+// ReleaseInvoice provides a mock function with given fields: payment_request
+func (_m *Database) ReleaseInvoice(payment_request string) error {
+	ret := _m.Called(payment_request)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetUnconfirmedGithub")
+		panic("no return value specified for ReleaseInvoice")
 	}
 
-	var r0 []db.Person
-	if rf, ok := ret.Get(0).(func() []db.Person); ok {
-		r0 = rf()
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(payment_request)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.Person)
-		}
+		r0 = ret.Error(0)
 	}
 
 	return r0
 }
 
-// Database_GetUnconfirmedGithub_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUnconfirmedGithub'
-type Database_GetUnconfirmedGithub_Call struct {
+// Database_ReleaseInvoice_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReleaseInvoice'
+type Database_ReleaseInvoice_Call struct {
 	*mock.Call
 }
 
-// GetUnconfirmedGithub is a helper method to define mock.On call
-func (_e *Database_Expecter) GetUnconfirmedGithub() *Database_GetUnconfirmedGithub_Call {
-	return &Database_GetUnconfirmedGithub_Call{Call: _e.mock.On("GetUnconfirmedGithub")}
+// ReleaseInvoice is a helper method to define mock.On call
+//   - payment_request string
+func (_e *Database_Expecter) ReleaseInvoice(payment_request interface{}) *Database_ReleaseInvoice_Call {
+	return &Database_ReleaseInvoice_Call{Call: _e.mock.On("ReleaseInvoice", payment_request)}
 }
 
-func (_c *Database_GetUnconfirmedGithub_Call) Run(run func()) *Database_GetUnconfirmedGithub_Call {
+func (_c *Database_ReleaseInvoice_Call) Run(run func(payment_request string)) *Database_ReleaseInvoice_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetUnconfirmedGithub_Call) Return(_a0 []db.Person) *Database_GetUnconfirmedGithub_Call {
+func (_c *Database_ReleaseInvoice_Call) Return(_a0 error) *Database_ReleaseInvoice_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetUnconfirmedGithub_Call) RunAndReturn(run func() []db.Person) *Database_GetUnconfirmedGithub_Call {
+func (_c *Database_ReleaseInvoice_Call) RunAndReturn(run func(string) error) *Database_ReleaseInvoice_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetUnconfirmedTwitter provides a mock function with given fields:
-func (_m *Database) GetUnconfirmedTwitter() []db.Person {
-	ret := _m.Called()
+// ReleaseStaleJobs provides a mock function with given fields: lockedBefore
+func (_m *Database) ReleaseStaleJobs(lockedBefore time.Time) (int64, error) {
+	ret := _m.Called(lockedBefore)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetUnconfirmedTwitter")
+		panic("no return value specified for ReleaseStaleJobs")
 	}
 
-	var r0 []db.Person
-	if rf, ok := ret.Get(0).(func() []db.Person); ok {
-		r0 = rf()
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(time.Time) (int64, error)); ok {
+		return rf(lockedBefore)
+	}
+	if rf, ok := ret.Get(0).(func(time.Time) int64); ok {
+		r0 = rf(lockedBefore)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.Person)
-		}
+		r0 = ret.Get(0).(int64)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(time.Time) error); ok {
+		r1 = rf(lockedBefore)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_GetUnconfirmedTwitter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUnconfirmedTwitter'
-type Database_GetUnconfirmedTwitter_Call struct {
+// Database_ReleaseStaleJobs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReleaseStaleJobs'
+type Database_ReleaseStaleJobs_Call struct {
 	*mock.Call
 }
 
-// GetUnconfirmedTwitter is a helper method to define mock.On call
-func (_e *Database_Expecter) GetUnconfirmedTwitter() *Database_GetUnconfirmedTwitter_Call {
-	return &Database_GetUnconfirmedTwitter_Call{Call: _e.mock.On("GetUnconfirmedTwitter")}
+// ReleaseStaleJobs is a helper method to define mock.On call
+//   - lockedBefore time.Time
+func (_e *Database_Expecter) ReleaseStaleJobs(lockedBefore interface{}) *Database_ReleaseStaleJobs_Call {
+	return &Database_ReleaseStaleJobs_Call{Call: _e.mock.On("ReleaseStaleJobs", lockedBefore)}
 }
 
-func (_c *Database_GetUnconfirmedTwitter_Call) Run(run func()) *Database_GetUnconfirmedTwitter_Call {
+func (_c *Database_ReleaseStaleJobs_Call) Run(run func(lockedBefore time.Time)) *Database_ReleaseStaleJobs_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		run(args[0].(time.Time))
 	})
 	return _c
 }
 
-func (_c *Database_GetUnconfirmedTwitter_Call) Return(_a0 []db.Person) *Database_GetUnconfirmedTwitter_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_ReleaseStaleJobs_Call) Return(_a0 int64, _a1 error) *Database_ReleaseStaleJobs_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetUnconfirmedTwitter_Call) RunAndReturn(run func() []db.Person) *Database_GetUnconfirmedTwitter_Call {
+func (_c *Database_ReleaseStaleJobs_Call) RunAndReturn(run func(time.Time) (int64, error)) *Database_ReleaseStaleJobs_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetUserAssignedWorkspaces provides a mock function with given fields: pubkey
-func (_m *Database) GetUserAssignedWorkspaces(pubkey string) []db.WorkspaceUsers {
-	ret := _m.Called(pubkey)
+// RequeueJob provides a mock function with given fields: uuid
+func (_m *Database) RequeueJob(uuid string) (db.Job, error) {
+	ret := _m.Called(uuid)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetUserAssignedWorkspaces")
+		panic("no return value specified for RequeueJob")
 	}
 
-	var r0 []db.WorkspaceUsers
-	if rf, ok := ret.Get(0).(func(string) []db.WorkspaceUsers); ok {
-		r0 = rf(pubkey)
+	var r0 db.Job
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (db.Job, error)); ok {
+		return rf(uuid)
+	}
+	if rf, ok := ret.Get(0).(func(string) db.Job); ok {
+		r0 = rf(uuid)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.WorkspaceUsers)
-		}
+		r0 = ret.Get(0).(db.Job)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(uuid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_GetUserAssignedWorkspaces_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserAssignedWorkspaces'
-type Database_GetUserAssignedWorkspaces_Call struct {
+// Database_RequeueJob_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RequeueJob'
+type Database_RequeueJob_Call struct {
 	*mock.Call
 }
-
-// GetUserAssignedWorkspaces is a helper method to define mock.On call
-//   - pubkey string
-func (_e *Database_Expecter) GetUserAssignedWorkspaces(pubkey interface{}) *Database_GetUserAssignedWorkspaces_Call {
-	return &Database_GetUserAssignedWorkspaces_Call{Call: _e.mock.On("GetUserAssignedWorkspaces", pubkey)}
+
+// RequeueJob is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) RequeueJob(uuid interface{}) *Database_RequeueJob_Call {
+	return &Database_RequeueJob_Call{Call: _e.mock.On("RequeueJob", uuid)}
 }
 
-func (_c *Database_GetUserAssignedWorkspaces_Call) Run(run func(pubkey string)) *Database_GetUserAssignedWorkspaces_Call {
+func (_c *Database_RequeueJob_Call) Run(run func(uuid string)) *Database_RequeueJob_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetUserAssignedWorkspaces_Call) Return(_a0 []db.WorkspaceUsers) *Database_GetUserAssignedWorkspaces_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_RequeueJob_Call) Return(_a0 db.Job, _a1 error) *Database_RequeueJob_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetUserAssignedWorkspaces_Call) RunAndReturn(run func(string) []db.WorkspaceUsers) *Database_GetUserAssignedWorkspaces_Call {
+func (_c *Database_RequeueJob_Call) RunAndReturn(run func(string) (db.Job, error)) *Database_RequeueJob_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetUserBountiesCount provides a mock function with given fields: personKey, tabType
-func (_m *Database) GetUserBountiesCount(personKey string, tabType string) int64 {
-	ret := _m.Called(personKey, tabType)
+// ResolveTargetReports provides a mock function with given fields: resolution
+func (_m *Database) ResolveTargetReports(resolution db.Report) ([]db.Report, error) {
+	ret := _m.Called(resolution)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetUserBountiesCount")
+		panic("no return value specified for ResolveTargetReports")
 	}
 
-	var r0 int64
-	if rf, ok := ret.Get(0).(func(string, string) int64); ok {
-		r0 = rf(personKey, tabType)
+	var r0 []db.Report
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.Report) ([]db.Report, error)); ok {
+		return rf(resolution)
+	}
+	if rf, ok := ret.Get(0).(func(db.Report) []db.Report); ok {
+		r0 = rf(resolution)
 	} else {
-		r0 = ret.Get(0).(int64)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Report)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(db.Report) error); ok {
+		r1 = rf(resolution)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_GetUserBountiesCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserBountiesCount'
-type Database_GetUserBountiesCount_Call struct {
+// Database_ResolveTargetReports_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResolveTargetReports'
+type Database_ResolveTargetReports_Call struct {
 	*mock.Call
 }
 
-// GetUserBountiesCount is a helper method to define mock.On call
-//   - personKey string
-//   - tabType string
-func (_e *Database_Expecter) GetUserBountiesCount(personKey interface{}, tabType interface{}) *Database_GetUserBountiesCount_Call {
-	return &Database_GetUserBountiesCount_Call{Call: _e.mock.On("GetUserBountiesCount", personKey, tabType)}
+// ResolveTargetReports is a helper method to define mock.On call
+//   - resolution db.Report
+func (_e *Database_Expecter) ResolveTargetReports(resolution interface{}) *Database_ResolveTargetReports_Call {
+	return &Database_ResolveTargetReports_Call{Call: _e.mock.On("ResolveTargetReports", resolution)}
 }
 
-func (_c *Database_GetUserBountiesCount_Call) Run(run func(personKey string, tabType string)) *Database_GetUserBountiesCount_Call {
+func (_c *Database_ResolveTargetReports_Call) Run(run func(resolution db.Report)) *Database_ResolveTargetReports_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string))
+		run(args[0].(db.Report))
 	})
 	return _c
 }
 
-func (_c *Database_GetUserBountiesCount_Call) Return(_a0 int64) *Database_GetUserBountiesCount_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_ResolveTargetReports_Call) Return(_a0 []db.Report, _a1 error) *Database_ResolveTargetReports_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetUserBountiesCount_Call) RunAndReturn(run func(string, string) int64) *Database_GetUserBountiesCount_Call {
+func (_c *Database_ResolveTargetReports_Call) RunAndReturn(run func(db.Report) ([]db.Report, error)) *Database_ResolveTargetReports_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetUserCreatedWorkspaces provides a mock function with given fields: pubkey
-func (_m *Database) GetUserCreatedWorkspaces(pubkey string) []db.Workspace {
-	ret := _m.Called(pubkey)
+// RestoreDeleted provides a mock function with given fields: kind, id
+func (_m *Database) RestoreDeleted(kind string, id string) (bool, error) {
+	ret := _m.Called(kind, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetUserCreatedWorkspaces")
+		panic("no return value specified for RestoreDeleted")
 	}
 
-	var r0 []db.Workspace
-	if rf, ok := ret.Get(0).(func(string) []db.Workspace); ok {
-		r0 = rf(pubkey)
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (bool, error)); ok {
+		return rf(kind, id)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) bool); ok {
+		r0 = rf(kind, id)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.Workspace)
-		}
+		r0 = ret.Get(0).(bool)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(kind, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_GetUserCreatedWorkspaces_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserCreatedWorkspaces'
-type Database_GetUserCreatedWorkspaces_Call struct {
+// Database_RestoreDeleted_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreDeleted'
+type Database_RestoreDeleted_Call struct {
 	*mock.Call
 }
 
-// GetUserCreatedWorkspaces is a helper method to define mock.On call
-//   - pubkey string
-func (_e *Database_Expecter) GetUserCreatedWorkspaces(pubkey interface{}) *Database_GetUserCreatedWorkspaces_Call {
-	return &Database_GetUserCreatedWorkspaces_Call{Call: _e.mock.On("GetUserCreatedWorkspaces", pubkey)}
+// RestoreDeleted is a helper method to define mock.On call
+//   - kind string
+//   - id string
+func (_e *Database_Expecter) RestoreDeleted(kind interface{}, id interface{}) *Database_RestoreDeleted_Call {
+	return &Database_RestoreDeleted_Call{Call: _e.mock.On("RestoreDeleted", kind, id)}
 }
 
-func (_c *Database_GetUserCreatedWorkspaces_Call) Run(run func(pubkey string)) *Database_GetUserCreatedWorkspaces_Call {
+func (_c *Database_RestoreDeleted_Call) Run(run func(kind string, id string)) *Database_RestoreDeleted_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(string), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetUserCreatedWorkspaces_Call) Return(_a0 []db.Workspace) *Database_GetUserCreatedWorkspaces_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_RestoreDeleted_Call) Return(_a0 bool, _a1 error) *Database_RestoreDeleted_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetUserCreatedWorkspaces_Call) RunAndReturn(run func(string) []db.Workspace) *Database_GetUserCreatedWorkspaces_Call {
+func (_c *Database_RestoreDeleted_Call) RunAndReturn(run func(string, string) (bool, error)) *Database_RestoreDeleted_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetUserInvoiceData provides a mock function with given fields: payment_request
-func (_m *Database) GetUserInvoiceData(payment_request string) db.UserInvoiceData {
-	ret := _m.Called(payment_request)
+// ReviewBountyProof provides a mock function with given fields: proofUuid, reviewer, decision, comment, at
+func (_m *Database) ReviewBountyProof(proofUuid string, reviewer string, decision string, comment string, at time.Time) (db.BountyProof, error) {
+	ret := _m.Called(proofUuid, reviewer, decision, comment, at)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetUserInvoiceData")
+		panic("no return value specified for ReviewBountyProof")
 	}
 
-	var r0 db.UserInvoiceData
-	if rf, ok := ret.Get(0).(func(string) db.UserInvoiceData); ok {
-		r0 = rf(payment_request)
+	var r0 db.BountyProof
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, string, string, time.Time) (db.BountyProof, error)); ok {
+		return rf(proofUuid, reviewer, decision, comment, at)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, string, string, time.Time) db.BountyProof); ok {
+		r0 = rf(proofUuid, reviewer, decision, comment, at)
 	} else {
-		r0 = ret.Get(0).(db.UserInvoiceData)
+		r0 = ret.Get(0).(db.BountyProof)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(string, string, string, string, time.Time) error); ok {
+		r1 = rf(proofUuid, reviewer, decision, comment, at)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_GetUserInvoiceData_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserInvoiceData'
-type Database_GetUserInvoiceData_Call struct {
+// Database_ReviewBountyProof_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReviewBountyProof'
+type Database_ReviewBountyProof_Call struct {
 	*mock.Call
 }
 
-// GetUserInvoiceData is a helper method to define mock.On call
-//   - payment_request string
-func (_e *Database_Expecter) GetUserInvoiceData(payment_request interface{}) *Database_GetUserInvoiceData_Call {
-	return &Database_GetUserInvoiceData_Call{Call: _e.mock.On("GetUserInvoiceData", payment_request)}
+// ReviewBountyProof is a helper method to define mock.On call
+//   - proofUuid string
+//   - reviewer string
+//   - decision string
+//   - comment string
+//   - at time.Time
+func (_e *Database_Expecter) ReviewBountyProof(proofUuid interface{}, reviewer interface{}, decision interface{}, comment interface{}, at interface{}) *Database_ReviewBountyProof_Call {
+	return &Database_ReviewBountyProof_Call{Call: _e.mock.On("ReviewBountyProof", proofUuid, reviewer, decision, comment, at)}
 }
 
-func (_c *Database_GetUserInvoiceData_Call) Run(run func(payment_request string)) *Database_GetUserInvoiceData_Call {
+func (_c *Database_ReviewBountyProof_Call) Run(run func(proofUuid string, reviewer string, decision string, comment string, at time.Time)) *Database_ReviewBountyProof_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(string), args[1].(string), args[2].(string), args[3].(string), args[4].(time.Time))
 	})
 	return _c
 }
 
-func (_c *Database_GetUserInvoiceData_Call) Return(_a0 db.UserInvoiceData) *Database_GetUserInvoiceData_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_ReviewBountyProof_Call) Return(_a0 db.BountyProof, _a1 error) *Database_ReviewBountyProof_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetUserInvoiceData_Call) RunAndReturn(run func(string) db.UserInvoiceData) *Database_GetUserInvoiceData_Call {
+func (_c *Database_ReviewBountyProof_Call) RunAndReturn(run func(string, string, string, string, time.Time) (db.BountyProof, error)) *Database_ReviewBountyProof_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetWorkspaceBounties provides a mock function with given fields: r, workspace_uuid
-func (_m *Database) GetWorkspaceBounties(r *http.Request, workspace_uuid string) []db.NewBounty {
-	ret := _m.Called(r, workspace_uuid)
+// ReviewFlaggedWorkspace provides a mock function with given fields: uuid, review
+func (_m *Database) ReviewFlaggedWorkspace(uuid string, review db.WorkspaceReview) (db.Workspace, error) {
+	ret := _m.Called(uuid, review)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetWorkspaceBounties")
+		panic("no return value specified for ReviewFlaggedWorkspace")
 	}
 
-	var r0 []db.NewBounty
-	if rf, ok := ret.Get(0).(func(*http.Request, string) []db.NewBounty); ok {
-		r0 = rf(r, workspace_uuid)
+	var r0 db.Workspace
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, db.WorkspaceReview) (db.Workspace, error)); ok {
+		return rf(uuid, review)
+	}
+	if rf, ok := ret.Get(0).(func(string, db.WorkspaceReview) db.Workspace); ok {
+		r0 = rf(uuid, review)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.NewBounty)
-		}
+		r0 = ret.Get(0).(db.Workspace)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(string, db.WorkspaceReview) error); ok {
+		r1 = rf(uuid, review)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_GetWorkspaceBounties_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceBounties'
-type Database_GetWorkspaceBounties_Call struct {
+// Database_ReviewFlaggedWorkspace_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReviewFlaggedWorkspace'
+type Database_ReviewFlaggedWorkspace_Call struct {
 	*mock.Call
 }
 
-// GetWorkspaceBounties is a helper method to define mock.On call
-//   - r *http.Request
-//   - workspace_uuid string
-func (_e *Database_Expecter) GetWorkspaceBounties(r interface{}, workspace_uuid interface{}) *Database_GetWorkspaceBounties_Call {
-	return &Database_GetWorkspaceBounties_Call{Call: _e.mock.On("GetWorkspaceBounties", r, workspace_uuid)}
+// ReviewFlaggedWorkspace is a helper method to define mock.On call
+//   - uuid string
+//   - review db.WorkspaceReview
+func (_e *Database_Expecter) ReviewFlaggedWorkspace(uuid interface{}, review interface{}) *Database_ReviewFlaggedWorkspace_Call {
+	return &Database_ReviewFlaggedWorkspace_Call{Call: _e.mock.On("ReviewFlaggedWorkspace", uuid, review)}
 }
 
-func (_c *Database_GetWorkspaceBounties_Call) Run(run func(r *http.Request, workspace_uuid string)) *Database_GetWorkspaceBounties_Call {
+func (_c *Database_ReviewFlaggedWorkspace_Call) Run(run func(uuid string, review db.WorkspaceReview)) *Database_ReviewFlaggedWorkspace_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*http.Request), args[1].(string))
+		run(args[0].(string), args[1].(db.WorkspaceReview))
 	})
 	return _c
 }
 
-func (_c *Database_GetWorkspaceBounties_Call) Return(_a0 []db.NewBounty) *Database_GetWorkspaceBounties_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_ReviewFlaggedWorkspace_Call) Return(_a0 db.Workspace, _a1 error) *Database_ReviewFlaggedWorkspace_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetWorkspaceBounties_Call) RunAndReturn(run func(*http.Request, string) []db.NewBounty) *Database_GetWorkspaceBounties_Call {
+func (_c *Database_ReviewFlaggedWorkspace_Call) RunAndReturn(run func(string, db.WorkspaceReview) (db.Workspace, error)) *Database_ReviewFlaggedWorkspace_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetWorkspaceBountiesCount provides a mock function with given fields: r, workspace_uuid
-func (_m *Database) GetWorkspaceBountiesCount(r *http.Request, workspace_uuid string) int64 {
-	ret := _m.Called(r, workspace_uuid)
+// ReviewShadowListedTribe provides a mock function with given fields: uuid, review, reviewer
+func (_m *Database) ReviewShadowListedTribe(uuid string, review db.TribeSpamReview, reviewer string) (db.Tribe, error) {
+	ret := _m.Called(uuid, review, reviewer)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetWorkspaceBountiesCount")
+		panic("no return value specified for ReviewShadowListedTribe")
 	}
 
-	var r0 int64
-	if rf, ok := ret.Get(0).(func(*http.Request, string) int64); ok {
-		r0 = rf(r, workspace_uuid)
+	var r0 db.Tribe
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, db.TribeSpamReview, string) (db.Tribe, error)); ok {
+		return rf(uuid, review, reviewer)
+	}
+	if rf, ok := ret.Get(0).(func(string, db.TribeSpamReview, string) db.Tribe); ok {
+		r0 = rf(uuid, review, reviewer)
 	} else {
-		r0 = ret.Get(0).(int64)
+		r0 = ret.Get(0).(db.Tribe)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(string, db.TribeSpamReview, string) error); ok {
+		r1 = rf(uuid, review, reviewer)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_GetWorkspaceBountiesCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceBountiesCount'
-type Database_GetWorkspaceBountiesCount_Call struct {
+// Database_ReviewShadowListedTribe_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReviewShadowListedTribe'
+type Database_ReviewShadowListedTribe_Call struct {
 	*mock.Call
 }
 
-// GetWorkspaceBountiesCount is a helper method to define mock.On call
-//   - r *http.Request
-//   - workspace_uuid string
-func (_e *Database_Expecter) GetWorkspaceBountiesCount(r interface{}, workspace_uuid interface{}) *Database_GetWorkspaceBountiesCount_Call {
-	return &Database_GetWorkspaceBountiesCount_Call{Call: _e.mock.On("GetWorkspaceBountiesCount", r, workspace_uuid)}
+// ReviewShadowListedTribe is a helper method to define mock.On call
+//   - uuid string
+//   - review db.TribeSpamReview
+//   - reviewer string
+func (_e *Database_Expecter) ReviewShadowListedTribe(uuid interface{}, review interface{}, reviewer interface{}) *Database_ReviewShadowListedTribe_Call {
+	return &Database_ReviewShadowListedTribe_Call{Call: _e.mock.On("ReviewShadowListedTribe", uuid, review, reviewer)}
 }
 
-func (_c *Database_GetWorkspaceBountiesCount_Call) Run(run func(r *http.Request, workspace_uuid string)) *Database_GetWorkspaceBountiesCount_Call {
+func (_c *Database_ReviewShadowListedTribe_Call) Run(run func(uuid string, review db.TribeSpamReview, reviewer string)) *Database_ReviewShadowListedTribe_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*http.Request), args[1].(string))
+		run(args[0].(string), args[1].(db.TribeSpamReview), args[2].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetWorkspaceBountiesCount_Call) Return(_a0 int64) *Database_GetWorkspaceBountiesCount_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_ReviewShadowListedTribe_Call) Return(_a0 db.Tribe, _a1 error) *Database_ReviewShadowListedTribe_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetWorkspaceBountiesCount_Call) RunAndReturn(run func(*http.Request, string) int64) *Database_GetWorkspaceBountiesCount_Call {
+func (_c *Database_ReviewShadowListedTribe_Call) RunAndReturn(run func(string, db.TribeSpamReview, string) (db.Tribe, error)) *Database_ReviewShadowListedTribe_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetWorkspaceBountyCount provides a mock function with given fields: uuid
-func (_m *Database) GetWorkspaceBountyCount(uuid string) int64 {
-	ret := _m.Called(uuid)
+// RewrapWorkspaceSecret provides a mock function with given fields: uuid, dataKey, masterKeyId
+func (_m *Database) RewrapWorkspaceSecret(uuid string, dataKey []byte, masterKeyId string) error {
+	ret := _m.Called(uuid, dataKey, masterKeyId)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetWorkspaceBountyCount")
+		panic("no return value specified for RewrapWorkspaceSecret")
 	}
 
-	var r0 int64
-	if rf, ok := ret.Get(0).(func(string) int64); ok {
-		r0 = rf(uuid)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, []byte, string) error); ok {
+		r0 = rf(uuid, dataKey, masterKeyId)
 	} else {
-		r0 = ret.Get(0).(int64)
+		r0 = ret.Error(0)
 	}
 
 	return r0
 }
 
-// Database_GetWorkspaceBountyCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceBountyCount'
-type Database_GetWorkspaceBountyCount_Call struct {
+// Database_RewrapWorkspaceSecret_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RewrapWorkspaceSecret'
+type Database_RewrapWorkspaceSecret_Call struct {
 	*mock.Call
 }
 
-// GetWorkspaceBountyCount is a helper method to define mock.On call
+// RewrapWorkspaceSecret is a helper method to define mock.On call
 //   - uuid string
-func (_e *Database_Expecter) GetWorkspaceBountyCount(uuid interface{}) *Database_GetWorkspaceBountyCount_Call {
-	return &Database_GetWorkspaceBountyCount_Call{Call: _e.mock.On("GetWorkspaceBountyCount", uuid)}
+//   - dataKey []byte
+//   - masterKeyId string
+func (_e *Database_Expecter) RewrapWorkspaceSecret(uuid interface{}, dataKey interface{}, masterKeyId interface{}) *Database_RewrapWorkspaceSecret_Call {
+	return &Database_RewrapWorkspaceSecret_Call{Call: _e.mock.On("RewrapWorkspaceSecret", uuid, dataKey, masterKeyId)}
 }
 
-func (_c *Database_GetWorkspaceBountyCount_Call) Run(run func(uuid string)) *Database_GetWorkspaceBountyCount_Call {
+func (_c *Database_RewrapWorkspaceSecret_Call) Run(run func(uuid string, dataKey []byte, masterKeyId string)) *Database_RewrapWorkspaceSecret_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(string), args[1].([]byte), args[2].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetWorkspaceBountyCount_Call) Return(_a0 int64) *Database_GetWorkspaceBountyCount_Call {
+func (_c *Database_RewrapWorkspaceSecret_Call) Return(_a0 error) *Database_RewrapWorkspaceSecret_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetWorkspaceBountyCount_Call) RunAndReturn(run func(string) int64) *Database_GetWorkspaceBountyCount_Call {
+func (_c *Database_RewrapWorkspaceSecret_Call) RunAndReturn(run func(string, []byte, string) error) *Database_RewrapWorkspaceSecret_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetWorkspaceBudget provides a mock function with given fields: workspace_uuid
-func (_m *Database) GetWorkspaceBudget(workspace_uuid string) db.NewBountyBudget {
-	ret := _m.Called(workspace_uuid)
+// SatsPaidPercentage provides a mock function with given fields: r, workspace
+func (_m *Database) SatsPaidPercentage(r db.PaymentDateRange, workspace string) uint {
+	ret := _m.Called(r, workspace)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetWorkspaceBudget")
+		panic("no return value specified for SatsPaidPercentage")
 	}
 
-	var r0 db.NewBountyBudget
-	if rf, ok := ret.Get(0).(func(string) db.NewBountyBudget); ok {
-		r0 = rf(workspace_uuid)
+	var r0 uint
+	if rf, ok := ret.Get(0).(func(db.PaymentDateRange, string) uint); ok {
+		r0 = rf(r, workspace)
 	} else {
-		r0 = ret.Get(0).(db.NewBountyBudget)
+		r0 = ret.Get(0).(uint)
 	}
 
 	return r0
 }
 
-// Database_GetWorkspaceBudget_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceBudget'
-type Database_GetWorkspaceBudget_Call struct {
+// Database_SatsPaidPercentage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SatsPaidPercentage'
+type Database_SatsPaidPercentage_Call struct {
 	*mock.Call
 }
 
-// GetWorkspaceBudget is a helper method to define mock.On call
-//   - workspace_uuid string
-func (_e *Database_Expecter) GetWorkspaceBudget(workspace_uuid interface{}) *Database_GetWorkspaceBudget_Call {
-	return &Database_GetWorkspaceBudget_Call{Call: _e.mock.On("GetWorkspaceBudget", workspace_uuid)}
+// SatsPaidPercentage is a helper method to define mock.On call
+//   - r db.PaymentDateRange
+//   - workspace string
+func (_e *Database_Expecter) SatsPaidPercentage(r interface{}, workspace interface{}) *Database_SatsPaidPercentage_Call {
+	return &Database_SatsPaidPercentage_Call{Call: _e.mock.On("SatsPaidPercentage", r, workspace)}
 }
 
-func (_c *Database_GetWorkspaceBudget_Call) Run(run func(workspace_uuid string)) *Database_GetWorkspaceBudget_Call {
+func (_c *Database_SatsPaidPercentage_Call) Run(run func(r db.PaymentDateRange, workspace string)) *Database_SatsPaidPercentage_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(db.PaymentDateRange), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetWorkspaceBudget_Call) Return(_a0 db.NewBountyBudget) *Database_GetWorkspaceBudget_Call {
+func (_c *Database_SatsPaidPercentage_Call) Return(_a0 uint) *Database_SatsPaidPercentage_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetWorkspaceBudget_Call) RunAndReturn(run func(string) db.NewBountyBudget) *Database_GetWorkspaceBudget_Call {
+func (_c *Database_SatsPaidPercentage_Call) RunAndReturn(run func(db.PaymentDateRange, string) uint) *Database_SatsPaidPercentage_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetWorkspaceBudgetHistory provides a mock function with given fields: workspace_uuid
-func (_m *Database) GetWorkspaceBudgetHistory(workspace_uuid string) []db.BudgetHistoryData {
-	ret := _m.Called(workspace_uuid)
+// SaveEventConsumer provides a mock function with given fields: name, lastEventID
+func (_m *Database) SaveEventConsumer(name string, lastEventID uint) error {
+	ret := _m.Called(name, lastEventID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetWorkspaceBudgetHistory")
+		panic("no return value specified for SaveEventConsumer")
 	}
 
-	var r0 []db.BudgetHistoryData
-	if rf, ok := ret.Get(0).(func(string) []db.BudgetHistoryData); ok {
-		r0 = rf(workspace_uuid)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, uint) error); ok {
+		r0 = rf(name, lastEventID)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.BudgetHistoryData)
-		}
+		r0 = ret.Error(0)
 	}
 
 	return r0
 }
 
-// Database_GetWorkspaceBudgetHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceBudgetHistory'
-type Database_GetWorkspaceBudgetHistory_Call struct {
+// Database_SaveEventConsumer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveEventConsumer'
+type Database_SaveEventConsumer_Call struct {
 	*mock.Call
 }
 
-// GetWorkspaceBudgetHistory is a helper method to define mock.On call
-//   - workspace_uuid string
-func (_e *Database_Expecter) GetWorkspaceBudgetHistory(workspace_uuid interface{}) *Database_GetWorkspaceBudgetHistory_Call {
-	return &Database_GetWorkspaceBudgetHistory_Call{Call: _e.mock.On("GetWorkspaceBudgetHistory", workspace_uuid)}
+// SaveEventConsumer is a helper method to define mock.On call
+//   - name string
+//   - lastEventID uint
+func (_e *Database_Expecter) SaveEventConsumer(name interface{}, lastEventID interface{}) *Database_SaveEventConsumer_Call {
+	return &Database_SaveEventConsumer_Call{Call: _e.mock.On("SaveEventConsumer", name, lastEventID)}
 }
 
-func (_c *Database_GetWorkspaceBudgetHistory_Call) Run(run func(workspace_uuid string)) *Database_GetWorkspaceBudgetHistory_Call {
+func (_c *Database_SaveEventConsumer_Call) Run(run func(name string, lastEventID uint)) *Database_SaveEventConsumer_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(string), args[1].(uint))
 	})
 	return _c
 }
 
-func (_c *Database_GetWorkspaceBudgetHistory_Call) Return(_a0 []db.BudgetHistoryData) *Database_GetWorkspaceBudgetHistory_Call {
+func (_c *Database_SaveEventConsumer_Call) Return(_a0 error) *Database_SaveEventConsumer_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetWorkspaceBudgetHistory_Call) RunAndReturn(run func(string) []db.BudgetHistoryData) *Database_GetWorkspaceBudgetHistory_Call {
+func (_c *Database_SaveEventConsumer_Call) RunAndReturn(run func(string, uint) error) *Database_SaveEventConsumer_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetWorkspaceByName provides a mock function with given fields: name
-func (_m *Database) GetWorkspaceByName(name string) db.Workspace {
-	ret := _m.Called(name)
+// SaveMentions provides a mock function with given fields: sourceType, sourceID, mentions
+func (_m *Database) SaveMentions(sourceType string, sourceID string, mentions []db.Mention) ([]db.Mention, error) {
+	ret := _m.Called(sourceType, sourceID, mentions)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetWorkspaceByName")
+		panic("no return value specified for SaveMentions")
+	}
+
+	var r0 []db.Mention
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, []db.Mention) ([]db.Mention, error)); ok {
+		return rf(sourceType, sourceID, mentions)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, []db.Mention) []db.Mention); ok {
+		r0 = rf(sourceType, sourceID, mentions)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Mention)
+		}
 	}
 
-	var r0 db.Workspace
-	if rf, ok := ret.Get(0).(func(string) db.Workspace); ok {
-		r0 = rf(name)
+	if rf, ok := ret.Get(1).(func(string, string, []db.Mention) error); ok {
+		r1 = rf(sourceType, sourceID, mentions)
 	} else {
-		r0 = ret.Get(0).(db.Workspace)
+		r1 = ret.Error(1)
 	}
 
-	return r0
+	return r0, r1
 }
 
-// Database_GetWorkspaceByName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceByName'
-type Database_GetWorkspaceByName_Call struct {
+// Database_SaveMentions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveMentions'
+type Database_SaveMentions_Call struct {
 	*mock.Call
 }
 
-// GetWorkspaceByName is a helper method to define mock.On call
-//   - name string
-func (_e *Database_Expecter) GetWorkspaceByName(name interface{}) *Database_GetWorkspaceByName_Call {
-	return &Database_GetWorkspaceByName_Call{Call: _e.mock.On("GetWorkspaceByName", name)}
+// SaveMentions is a helper method to define mock.On call
+//   - sourceType string
+//   - sourceID string
+//   - mentions []db.Mention
+func (_e *Database_Expecter) SaveMentions(sourceType interface{}, sourceID interface{}, mentions interface{}) *Database_SaveMentions_Call {
+	return &Database_SaveMentions_Call{Call: _e.mock.On("SaveMentions", sourceType, sourceID, mentions)}
 }
 
-func (_c *Database_GetWorkspaceByName_Call) Run(run func(name string)) *Database_GetWorkspaceByName_Call {
+func (_c *Database_SaveMentions_Call) Run(run func(sourceType string, sourceID string, mentions []db.Mention)) *Database_SaveMentions_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(string), args[1].(string), args[2].([]db.Mention))
 	})
 	return _c
 }
 
-func (_c *Database_GetWorkspaceByName_Call) Return(_a0 db.Workspace) *Database_GetWorkspaceByName_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_SaveMentions_Call) Return(_a0 []db.Mention, _a1 error) *Database_SaveMentions_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetWorkspaceByName_Call) RunAndReturn(run func(string) db.Workspace) *Database_GetWorkspaceByName_Call {
+func (_c *Database_SaveMentions_Call) RunAndReturn(run func(string, string, []db.Mention) ([]db.Mention, error)) *Database_SaveMentions_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetWorkspaceByUuid provides a mock function with given fields: uuid
-func (_m *Database) GetWorkspaceByUuid(uuid string) db.Workspace {
-	ret := _m.Called(uuid)
+// SaveTribeSpamScore provides a mock function with given fields: score, threshold
+func (_m *Database) SaveTribeSpamScore(score db.TribeSpamScore, threshold float64) (bool, error) {
+	ret := _m.Called(score, threshold)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetWorkspaceByUuid")
+		panic("no return value specified for SaveTribeSpamScore")
 	}
 
-	var r0 db.Workspace
-	if rf, ok := ret.Get(0).(func(string) db.Workspace); ok {
-		r0 = rf(uuid)
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.TribeSpamScore, float64) (bool, error)); ok {
+		return rf(score, threshold)
+	}
+	if rf, ok := ret.Get(0).(func(db.TribeSpamScore, float64) bool); ok {
+		r0 = rf(score, threshold)
 	} else {
-		r0 = ret.Get(0).(db.Workspace)
+		r0 = ret.Get(0).(bool)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(db.TribeSpamScore, float64) error); ok {
+		r1 = rf(score, threshold)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_GetWorkspaceByUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceByUuid'
-type Database_GetWorkspaceByUuid_Call struct {
+// Database_SaveTribeSpamScore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveTribeSpamScore'
+type Database_SaveTribeSpamScore_Call struct {
 	*mock.Call
 }
 
-// GetWorkspaceByUuid is a helper method to define mock.On call
-//   - uuid string
-func (_e *Database_Expecter) GetWorkspaceByUuid(uuid interface{}) *Database_GetWorkspaceByUuid_Call {
-	return &Database_GetWorkspaceByUuid_Call{Call: _e.mock.On("GetWorkspaceByUuid", uuid)}
+// SaveTribeSpamScore is a helper method to define mock.On call
+//   - score db.TribeSpamScore
+//   - threshold float64
+func (_e *Database_Expecter) SaveTribeSpamScore(score interface{}, threshold interface{}) *Database_SaveTribeSpamScore_Call {
+	return &Database_SaveTribeSpamScore_Call{Call: _e.mock.On("SaveTribeSpamScore", score, threshold)}
 }
 
-func (_c *Database_GetWorkspaceByUuid_Call) Run(run func(uuid string)) *Database_GetWorkspaceByUuid_Call {
+func (_c *Database_SaveTribeSpamScore_Call) Run(run func(score db.TribeSpamScore, threshold float64)) *Database_SaveTribeSpamScore_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(db.TribeSpamScore), args[1].(float64))
 	})
 	return _c
 }
 
-func (_c *Database_GetWorkspaceByUuid_Call) Return(_a0 db.Workspace) *Database_GetWorkspaceByUuid_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_SaveTribeSpamScore_Call) Return(_a0 bool, _a1 error) *Database_SaveTribeSpamScore_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetWorkspaceByUuid_Call) RunAndReturn(run func(string) db.Workspace) *Database_GetWorkspaceByUuid_Call {
+func (_c *Database_SaveTribeSpamScore_Call) RunAndReturn(run func(db.TribeSpamScore, float64) (bool, error)) *Database_SaveTribeSpamScore_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetWorkspaceFeaturesCount provides a mock function with given fields: uuid
-func (_m *Database) GetWorkspaceFeaturesCount(uuid string) int64 {
-	ret := _m.Called(uuid)
+// SaveWorkspaceVerification provides a mock function with given fields: verification
+func (_m *Database) SaveWorkspaceVerification(verification db.WorkspaceVerification) (db.WorkspaceVerification, error) {
+	ret := _m.Called(verification)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetWorkspaceFeaturesCount")
+		panic("no return value specified for SaveWorkspaceVerification")
 	}
 
-	var r0 int64
-	if rf, ok := ret.Get(0).(func(string) int64); ok {
-		r0 = rf(uuid)
+	var r0 db.WorkspaceVerification
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.WorkspaceVerification) (db.WorkspaceVerification, error)); ok {
+		return rf(verification)
+	}
+	if rf, ok := ret.Get(0).(func(db.WorkspaceVerification) db.WorkspaceVerification); ok {
+		r0 = rf(verification)
 	} else {
-		r0 = ret.Get(0).(int64)
+		r0 = ret.Get(0).(db.WorkspaceVerification)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(db.WorkspaceVerification) error); ok {
+		r1 = rf(verification)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_GetWorkspaceFeaturesCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceFeaturesCount'
-type Database_GetWorkspaceFeaturesCount_Call struct {
+// Database_SaveWorkspaceVerification_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveWorkspaceVerification'
+type Database_SaveWorkspaceVerification_Call struct {
 	*mock.Call
 }
 
-// GetWorkspaceFeaturesCount is a helper method to define mock.On call
-//   - uuid string
-func (_e *Database_Expecter) GetWorkspaceFeaturesCount(uuid interface{}) *Database_GetWorkspaceFeaturesCount_Call {
-	return &Database_GetWorkspaceFeaturesCount_Call{Call: _e.mock.On("GetWorkspaceFeaturesCount", uuid)}
+// SaveWorkspaceVerification is a helper method to define mock.On call
+//   - verification db.WorkspaceVerification
+func (_e *Database_Expecter) SaveWorkspaceVerification(verification interface{}) *Database_SaveWorkspaceVerification_Call {
+	return &Database_SaveWorkspaceVerification_Call{Call: _e.mock.On("SaveWorkspaceVerification", verification)}
 }
 
-func (_c *Database_GetWorkspaceFeaturesCount_Call) Run(run func(uuid string)) *Database_GetWorkspaceFeaturesCount_Call {
+func (_c *Database_SaveWorkspaceVerification_Call) Run(run func(verification db.WorkspaceVerification)) *Database_SaveWorkspaceVerification_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(db.WorkspaceVerification))
 	})
 	return _c
 }
 
-func (_c *Database_GetWorkspaceFeaturesCount_Call) Return(_a0 int64) *Database_GetWorkspaceFeaturesCount_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_SaveWorkspaceVerification_Call) Return(_a0 db.WorkspaceVerification, _a1 error) *Database_SaveWorkspaceVerification_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetWorkspaceFeaturesCount_Call) RunAndReturn(run func(string) int64) *Database_GetWorkspaceFeaturesCount_Call {
+func (_c *Database_SaveWorkspaceVerification_Call) RunAndReturn(run func(db.WorkspaceVerification) (db.WorkspaceVerification, error)) *Database_SaveWorkspaceVerification_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetWorkspaceInvoices provides a mock function with given fields: workspace_uuid
-func (_m *Database) GetWorkspaceInvoices(workspace_uuid string) []db.NewInvoiceList {
-	ret := _m.Called(workspace_uuid)
+// SearchBots provides a mock function with given fields: s, limit, offset
+func (_m *Database) SearchBots(s string, limit int, offset int) []db.BotRes {
+	ret := _m.Called(s, limit, offset)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetWorkspaceInvoices")
+		panic("no return value specified for SearchBots")
 	}
 
-	var r0 []db.NewInvoiceList
-	if rf, ok := ret.Get(0).(func(string) []db.NewInvoiceList); ok {
-		r0 = rf(workspace_uuid)
+	var r0 []db.BotRes
+	if rf, ok := ret.Get(0).(func(string, int, int) []db.BotRes); ok {
+		r0 = rf(s, limit, offset)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.NewInvoiceList)
+			r0 = ret.Get(0).([]db.BotRes)
 		}
 	}
 
 	return r0
 }
 
-// Database_GetWorkspaceInvoices_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceInvoices'
-type Database_GetWorkspaceInvoices_Call struct {
+// Database_SearchBots_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchBots'
+type Database_SearchBots_Call struct {
 	*mock.Call
 }
 
-// GetWorkspaceInvoices is a helper method to define mock.On call
-//   - workspace_uuid string
-func (_e *Database_Expecter) GetWorkspaceInvoices(workspace_uuid interface{}) *Database_GetWorkspaceInvoices_Call {
-	return &Database_GetWorkspaceInvoices_Call{Call: _e.mock.On("GetWorkspaceInvoices", workspace_uuid)}
+// SearchBots is a helper method to define mock.On call
+//   - s string
+//   - limit int
+//   - offset int
+func (_e *Database_Expecter) SearchBots(s interface{}, limit interface{}, offset interface{}) *Database_SearchBots_Call {
+	return &Database_SearchBots_Call{Call: _e.mock.On("SearchBots", s, limit, offset)}
 }
 
-func (_c *Database_GetWorkspaceInvoices_Call) Run(run func(workspace_uuid string)) *Database_GetWorkspaceInvoices_Call {
+func (_c *Database_SearchBots_Call) Run(run func(s string, limit int, offset int)) *Database_SearchBots_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(string), args[1].(int), args[2].(int))
 	})
 	return _c
 }
 
-func (_c *Database_GetWorkspaceInvoices_Call) Return(_a0 []db.NewInvoiceList) *Database_GetWorkspaceInvoices_Call {
+func (_c *Database_SearchBots_Call) Return(_a0 []db.BotRes) *Database_SearchBots_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetWorkspaceInvoices_Call) RunAndReturn(run func(string) []db.NewInvoiceList) *Database_GetWorkspaceInvoices_Call {
+func (_c *Database_SearchBots_Call) RunAndReturn(run func(string, int, int) []db.BotRes) *Database_SearchBots_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetWorkspaceInvoicesCount provides a mock function with given fields: workspace_uuid
-func (_m *Database) GetWorkspaceInvoicesCount(workspace_uuid string) int64 {
-	ret := _m.Called(workspace_uuid)
+// SearchDocuments provides a mock function with given fields: ctx, index, text, limit, offset
+func (_m *Database) SearchDocuments(ctx context.Context, index string, text string, limit int, offset int) ([]db.SearchDocument, error) {
+	ret := _m.Called(ctx, index, text, limit, offset)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetWorkspaceInvoicesCount")
+		panic("no return value specified for SearchDocuments")
 	}
 
-	var r0 int64
-	if rf, ok := ret.Get(0).(func(string) int64); ok {
-		r0 = rf(workspace_uuid)
+	var r0 []db.SearchDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int, int) ([]db.SearchDocument, error)); ok {
+		return rf(ctx, index, text, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int, int) []db.SearchDocument); ok {
+		r0 = rf(ctx, index, text, limit, offset)
 	} else {
-		r0 = ret.Get(0).(int64)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.SearchDocument)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, int, int) error); ok {
+		r1 = rf(ctx, index, text, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_GetWorkspaceInvoicesCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceInvoicesCount'
-type Database_GetWorkspaceInvoicesCount_Call struct {
+// Database_SearchDocuments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchDocuments'
+type Database_SearchDocuments_Call struct {
 	*mock.Call
 }
 
-// GetWorkspaceInvoicesCount is a helper method to define mock.On call
-//   - workspace_uuid string
-func (_e *Database_Expecter) GetWorkspaceInvoicesCount(workspace_uuid interface{}) *Database_GetWorkspaceInvoicesCount_Call {
-	return &Database_GetWorkspaceInvoicesCount_Call{Call: _e.mock.On("GetWorkspaceInvoicesCount", workspace_uuid)}
+// SearchDocuments is a helper method to define mock.On call
+//   - ctx context.Context
+//   - index string
+//   - text string
+//   - limit int
+//   - offset int
+func (_e *Database_Expecter) SearchDocuments(ctx interface{}, index interface{}, text interface{}, limit interface{}, offset interface{}) *Database_SearchDocuments_Call {
+	return &Database_SearchDocuments_Call{Call: _e.mock.On("SearchDocuments", ctx, index, text, limit, offset)}
 }
 
-func (_c *Database_GetWorkspaceInvoicesCount_Call) Run(run func(workspace_uuid string)) *Database_GetWorkspaceInvoicesCount_Call {
+func (_c *Database_SearchDocuments_Call) Run(run func(ctx context.Context, index string, text string, limit int, offset int)) *Database_SearchDocuments_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(int), args[4].(int))
 	})
 	return _c
 }
 
-func (_c *Database_GetWorkspaceInvoicesCount_Call) Return(_a0 int64) *Database_GetWorkspaceInvoicesCount_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_SearchDocuments_Call) Return(_a0 []db.SearchDocument, _a1 error) *Database_SearchDocuments_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetWorkspaceInvoicesCount_Call) RunAndReturn(run func(string) int64) *Database_GetWorkspaceInvoicesCount_Call {
+func (_c *Database_SearchDocuments_Call) RunAndReturn(run func(context.Context, string, string, int, int) ([]db.SearchDocument, error)) *Database_SearchDocuments_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetWorkspaceRepoByWorkspaceUuidAndRepoUuid provides a mock function with given fields: workspace_uuid, uuid
-func (_m *Database) GetWorkspaceRepoByWorkspaceUuidAndRepoUuid(workspace_uuid string, uuid string) (db.WorkspaceRepositories, error) {
-	ret := _m.Called(workspace_uuid, uuid)
+// SearchPeople provides a mock function with given fields: s, limit, offset
+func (_m *Database) SearchPeople(s string, limit int, offset int) []db.Person {
+	ret := _m.Called(s, limit, offset)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetWorkspaceRepoByWorkspaceUuidAndRepoUuid")
-	}
-
-	var r0 db.WorkspaceRepositories
-	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string) (db.WorkspaceRepositories, error)); ok {
-		return rf(workspace_uuid, uuid)
-	}
-	if rf, ok := ret.Get(0).(func(string, string) db.WorkspaceRepositories); ok {
-		r0 = rf(workspace_uuid, uuid)
-	} else {
-		r0 = ret.Get(0).(db.WorkspaceRepositories)
+		panic("no return value specified for SearchPeople")
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(workspace_uuid, uuid)
+	var r0 []db.Person
+	if rf, ok := ret.Get(0).(func(string, int, int) []db.Person); ok {
+		r0 = rf(s, limit, offset)
 	} else {
-		r1 = ret.Error(1)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Person)
+		}
 	}
 
-	return r0, r1
+	return r0
 }
 
-// Database_GetWorkspaceRepoByWorkspaceUuidAndRepoUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceRepoByWorkspaceUuidAndRepoUuid'
-type Database_GetWorkspaceRepoByWorkspaceUuidAndRepoUuid_Call struct {
+// Database_SearchPeople_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchPeople'
+type Database_SearchPeople_Call struct {
 	*mock.Call
 }
 
-// GetWorkspaceRepoByWorkspaceUuidAndRepoUuid is a helper method to define mock.On call
-//   - workspace_uuid string
-//   - uuid string
-func (_e *Database_Expecter) GetWorkspaceRepoByWorkspaceUuidAndRepoUuid(workspace_uuid interface{}, uuid interface{}) *Database_GetWorkspaceRepoByWorkspaceUuidAndRepoUuid_Call {
-	return &Database_GetWorkspaceRepoByWorkspaceUuidAndRepoUuid_Call{Call: _e.mock.On("GetWorkspaceRepoByWorkspaceUuidAndRepoUuid", workspace_uuid, uuid)}
+// SearchPeople is a helper method to define mock.On call
+//   - s string
+//   - limit int
+//   - offset int
+func (_e *Database_Expecter) SearchPeople(s interface{}, limit interface{}, offset interface{}) *Database_SearchPeople_Call {
+	return &Database_SearchPeople_Call{Call: _e.mock.On("SearchPeople", s, limit, offset)}
 }
 
-func (_c *Database_GetWorkspaceRepoByWorkspaceUuidAndRepoUuid_Call) Run(run func(workspace_uuid string, uuid string)) *Database_GetWorkspaceRepoByWorkspaceUuidAndRepoUuid_Call {
+func (_c *Database_SearchPeople_Call) Run(run func(s string, limit int, offset int)) *Database_SearchPeople_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string))
+		run(args[0].(string), args[1].(int), args[2].(int))
 	})
 	return _c
 }
 
-func (_c *Database_GetWorkspaceRepoByWorkspaceUuidAndRepoUuid_Call) Return(_a0 db.WorkspaceRepositories, _a1 error) *Database_GetWorkspaceRepoByWorkspaceUuidAndRepoUuid_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *Database_SearchPeople_Call) Return(_a0 []db.Person) *Database_SearchPeople_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetWorkspaceRepoByWorkspaceUuidAndRepoUuid_Call) RunAndReturn(run func(string, string) (db.WorkspaceRepositories, error)) *Database_GetWorkspaceRepoByWorkspaceUuidAndRepoUuid_Call {
+func (_c *Database_SearchPeople_Call) RunAndReturn(run func(string, int, int) []db.Person) *Database_SearchPeople_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetWorkspaceRepositorByWorkspaceUuid provides a mock function with given fields: uuid
-func (_m *Database) GetWorkspaceRepositorByWorkspaceUuid(uuid string) []db.WorkspaceRepositories {
-	ret := _m.Called(uuid)
+// SearchTribes provides a mock function with given fields: s
+func (_m *Database) SearchTribes(s string) []db.Tribe {
+	ret := _m.Called(s)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetWorkspaceRepositorByWorkspaceUuid")
+		panic("no return value specified for SearchTribes")
 	}
 
-	var r0 []db.WorkspaceRepositories
-	if rf, ok := ret.Get(0).(func(string) []db.WorkspaceRepositories); ok {
-		r0 = rf(uuid)
+	var r0 []db.Tribe
+	if rf, ok := ret.Get(0).(func(string) []db.Tribe); ok {
+		r0 = rf(s)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.WorkspaceRepositories)
+			r0 = ret.Get(0).([]db.Tribe)
 		}
 	}
 
 	return r0
 }
 
-// Database_GetWorkspaceRepositorByWorkspaceUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceRepositorByWorkspaceUuid'
-type Database_GetWorkspaceRepositorByWorkspaceUuid_Call struct {
+// Database_SearchTribes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchTribes'
+type Database_SearchTribes_Call struct {
 	*mock.Call
 }
 
-// GetWorkspaceRepositorByWorkspaceUuid is a helper method to define mock.On call
-//   - uuid string
-func (_e *Database_Expecter) GetWorkspaceRepositorByWorkspaceUuid(uuid interface{}) *Database_GetWorkspaceRepositorByWorkspaceUuid_Call {
-	return &Database_GetWorkspaceRepositorByWorkspaceUuid_Call{Call: _e.mock.On("GetWorkspaceRepositorByWorkspaceUuid", uuid)}
+// SearchTribes is a helper method to define mock.On call
+//   - s string
+func (_e *Database_Expecter) SearchTribes(s interface{}) *Database_SearchTribes_Call {
+	return &Database_SearchTribes_Call{Call: _e.mock.On("SearchTribes", s)}
 }
 
-func (_c *Database_GetWorkspaceRepositorByWorkspaceUuid_Call) Run(run func(uuid string)) *Database_GetWorkspaceRepositorByWorkspaceUuid_Call {
+func (_c *Database_SearchTribes_Call) Run(run func(s string)) *Database_SearchTribes_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetWorkspaceRepositorByWorkspaceUuid_Call) Return(_a0 []db.WorkspaceRepositories) *Database_GetWorkspaceRepositorByWorkspaceUuid_Call {
+func (_c *Database_SearchTribes_Call) Return(_a0 []db.Tribe) *Database_SearchTribes_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetWorkspaceRepositorByWorkspaceUuid_Call) RunAndReturn(run func(string) []db.WorkspaceRepositories) *Database_GetWorkspaceRepositorByWorkspaceUuid_Call {
+func (_c *Database_SearchTribes_Call) RunAndReturn(run func(string) []db.Tribe) *Database_SearchTribes_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetWorkspaceStatusBudget provides a mock function with given fields: workspace_uuid
-func (_m *Database) GetWorkspaceStatusBudget(workspace_uuid string) db.StatusBudget {
-	ret := _m.Called(workspace_uuid)
+// SetBountyContactConsent provides a mock function with given fields: exchange, owner, consent
+func (_m *Database) SetBountyContactConsent(exchange db.BountyContactExchange, owner bool, consent bool) (db.BountyContactExchange, error) {
+	ret := _m.Called(exchange, owner, consent)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetWorkspaceStatusBudget")
+		panic("no return value specified for SetBountyContactConsent")
 	}
 
-	var r0 db.StatusBudget
-	if rf, ok := ret.Get(0).(func(string) db.StatusBudget); ok {
-		r0 = rf(workspace_uuid)
+	var r0 db.BountyContactExchange
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.BountyContactExchange, bool, bool) (db.BountyContactExchange, error)); ok {
+		return rf(exchange, owner, consent)
+	}
+	if rf, ok := ret.Get(0).(func(db.BountyContactExchange, bool, bool) db.BountyContactExchange); ok {
+		r0 = rf(exchange, owner, consent)
 	} else {
-		r0 = ret.Get(0).(db.StatusBudget)
+		r0 = ret.Get(0).(db.BountyContactExchange)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(db.BountyContactExchange, bool, bool) error); ok {
+		r1 = rf(exchange, owner, consent)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_GetWorkspaceStatusBudget_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceStatusBudget'
-type Database_GetWorkspaceStatusBudget_Call struct {
+// Database_SetBountyContactConsent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetBountyContactConsent'
+type Database_SetBountyContactConsent_Call struct {
 	*mock.Call
 }
 
-// GetWorkspaceStatusBudget is a helper method to define mock.On call
-//   - workspace_uuid string
-func (_e *Database_Expecter) GetWorkspaceStatusBudget(workspace_uuid interface{}) *Database_GetWorkspaceStatusBudget_Call {
-	return &Database_GetWorkspaceStatusBudget_Call{Call: _e.mock.On("GetWorkspaceStatusBudget", workspace_uuid)}
+// SetBountyContactConsent is a helper method to define mock.On call
+//   - exchange db.BountyContactExchange
+//   - owner bool
+//   - consent bool
+func (_e *Database_Expecter) SetBountyContactConsent(exchange interface{}, owner interface{}, consent interface{}) *Database_SetBountyContactConsent_Call {
+	return &Database_SetBountyContactConsent_Call{Call: _e.mock.On("SetBountyContactConsent", exchange, owner, consent)}
 }
 
-func (_c *Database_GetWorkspaceStatusBudget_Call) Run(run func(workspace_uuid string)) *Database_GetWorkspaceStatusBudget_Call {
+func (_c *Database_SetBountyContactConsent_Call) Run(run func(exchange db.BountyContactExchange, owner bool, consent bool)) *Database_SetBountyContactConsent_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(db.BountyContactExchange), args[1].(bool), args[2].(bool))
 	})
 	return _c
 }
 
-func (_c *Database_GetWorkspaceStatusBudget_Call) Return(_a0 db.StatusBudget) *Database_GetWorkspaceStatusBudget_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_SetBountyContactConsent_Call) Return(_a0 db.BountyContactExchange, _a1 error) *Database_SetBountyContactConsent_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetWorkspaceStatusBudget_Call) RunAndReturn(run func(string) db.StatusBudget) *Database_GetWorkspaceStatusBudget_Call {
+func (_c *Database_SetBountyContactConsent_Call) RunAndReturn(run func(db.BountyContactExchange, bool, bool) (db.BountyContactExchange, error)) *Database_SetBountyContactConsent_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetWorkspaceUser provides a mock function with given fields: pubkey, workspace_uuid
-func (_m *Database) GetWorkspaceUser(pubkey string, workspace_uuid string) db.WorkspaceUsers {
-	ret := _m.Called(pubkey, workspace_uuid)
+// SetFeatureReviewers provides a mock function with given fields: uuid, reviewers, pubkey
+func (_m *Database) SetFeatureReviewers(uuid string, reviewers []string, pubkey string) (db.WorkspaceFeatures, error) {
+	ret := _m.Called(uuid, reviewers, pubkey)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetWorkspaceUser")
+		panic("no return value specified for SetFeatureReviewers")
 	}
 
-	var r0 db.WorkspaceUsers
-	if rf, ok := ret.Get(0).(func(string, string) db.WorkspaceUsers); ok {
-		r0 = rf(pubkey, workspace_uuid)
+	var r0 db.WorkspaceFeatures
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, []string, string) (db.WorkspaceFeatures, error)); ok {
+		return rf(uuid, reviewers, pubkey)
+	}
+	if rf, ok := ret.Get(0).(func(string, []string, string) db.WorkspaceFeatures); ok {
+		r0 = rf(uuid, reviewers, pubkey)
 	} else {
-		r0 = ret.Get(0).(db.WorkspaceUsers)
+		r0 = ret.Get(0).(db.WorkspaceFeatures)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(string, []string, string) error); ok {
+		r1 = rf(uuid, reviewers, pubkey)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_GetWorkspaceUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceUser'
-type Database_GetWorkspaceUser_Call struct {
+// Database_SetFeatureReviewers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetFeatureReviewers'
+type Database_SetFeatureReviewers_Call struct {
 	*mock.Call
 }
 
-// GetWorkspaceUser is a helper method to define mock.On call
+// SetFeatureReviewers is a helper method to define mock.On call
+//   - uuid string
+//   - reviewers []string
 //   - pubkey string
-//   - workspace_uuid string
-func (_e *Database_Expecter) GetWorkspaceUser(pubkey interface{}, workspace_uuid interface{}) *Database_GetWorkspaceUser_Call {
-	return &Database_GetWorkspaceUser_Call{Call: _e.mock.On("GetWorkspaceUser", pubkey, workspace_uuid)}
+func (_e *Database_Expecter) SetFeatureReviewers(uuid interface{}, reviewers interface{}, pubkey interface{}) *Database_SetFeatureReviewers_Call {
+	return &Database_SetFeatureReviewers_Call{Call: _e.mock.On("SetFeatureReviewers", uuid, reviewers, pubkey)}
 }
 
-func (_c *Database_GetWorkspaceUser_Call) Run(run func(pubkey string, workspace_uuid string)) *Database_GetWorkspaceUser_Call {
+func (_c *Database_SetFeatureReviewers_Call) Run(run func(uuid string, reviewers []string, pubkey string)) *Database_SetFeatureReviewers_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string))
+		run(args[0].(string), args[1].([]string), args[2].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetWorkspaceUser_Call) Return(_a0 db.WorkspaceUsers) *Database_GetWorkspaceUser_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_SetFeatureReviewers_Call) Return(_a0 db.WorkspaceFeatures, _a1 error) *Database_SetFeatureReviewers_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetWorkspaceUser_Call) RunAndReturn(run func(string, string) db.WorkspaceUsers) *Database_GetWorkspaceUser_Call {
+func (_c *Database_SetFeatureReviewers_Call) RunAndReturn(run func(string, []string, string) (db.WorkspaceFeatures, error)) *Database_SetFeatureReviewers_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetWorkspaceUsers provides a mock function with given fields: uuid
-func (_m *Database) GetWorkspaceUsers(uuid string) ([]db.WorkspaceUsersData, error) {
-	ret := _m.Called(uuid)
+// SetMaintenance provides a mock function with given fields: m
+func (_m *Database) SetMaintenance(m db.Maintenance) (db.Maintenance, error) {
+	ret := _m.Called(m)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetWorkspaceUsers")
+		panic("no return value specified for SetMaintenance")
 	}
 
-	var r0 []db.WorkspaceUsersData
+	var r0 db.Maintenance
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) ([]db.WorkspaceUsersData, error)); ok {
-		return rf(uuid)
+	if rf, ok := ret.Get(0).(func(db.Maintenance) (db.Maintenance, error)); ok {
+		return rf(m)
 	}
-	if rf, ok := ret.Get(0).(func(string) []db.WorkspaceUsersData); ok {
-		r0 = rf(uuid)
+	if rf, ok := ret.Get(0).(func(db.Maintenance) db.Maintenance); ok {
+		r0 = rf(m)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.WorkspaceUsersData)
-		}
+		r0 = ret.Get(0).(db.Maintenance)
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(uuid)
+	if rf, ok := ret.Get(1).(func(db.Maintenance) error); ok {
+		r1 = rf(m)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -6053,241 +18375,267 @@ func (_m *Database) GetWorkspaceUsers(uuid string) ([]db.WorkspaceUsersData, err
 	return r0, r1
 }
 
-// Database_GetWorkspaceUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceUsers'
-type Database_GetWorkspaceUsers_Call struct {
+// Database_SetMaintenance_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetMaintenance'
+type Database_SetMaintenance_Call struct {
 	*mock.Call
 }
 
-// GetWorkspaceUsers is a helper method to define mock.On call
-//   - uuid string
-func (_e *Database_Expecter) GetWorkspaceUsers(uuid interface{}) *Database_GetWorkspaceUsers_Call {
-	return &Database_GetWorkspaceUsers_Call{Call: _e.mock.On("GetWorkspaceUsers", uuid)}
+// SetMaintenance is a helper method to define mock.On call
+//   - m db.Maintenance
+func (_e *Database_Expecter) SetMaintenance(m interface{}) *Database_SetMaintenance_Call {
+	return &Database_SetMaintenance_Call{Call: _e.mock.On("SetMaintenance", m)}
 }
 
-func (_c *Database_GetWorkspaceUsers_Call) Run(run func(uuid string)) *Database_GetWorkspaceUsers_Call {
+func (_c *Database_SetMaintenance_Call) Run(run func(m db.Maintenance)) *Database_SetMaintenance_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(db.Maintenance))
 	})
 	return _c
 }
 
-func (_c *Database_GetWorkspaceUsers_Call) Return(_a0 []db.WorkspaceUsersData, _a1 error) *Database_GetWorkspaceUsers_Call {
+func (_c *Database_SetMaintenance_Call) Return(_a0 db.Maintenance, _a1 error) *Database_SetMaintenance_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetWorkspaceUsers_Call) RunAndReturn(run func(string) ([]db.WorkspaceUsersData, error)) *Database_GetWorkspaceUsers_Call {
+func (_c *Database_SetMaintenance_Call) RunAndReturn(run func(db.Maintenance) (db.Maintenance, error)) *Database_SetMaintenance_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetWorkspaceUsersCount provides a mock function with given fields: uuid
-func (_m *Database) GetWorkspaceUsersCount(uuid string) int64 {
-	ret := _m.Called(uuid)
+// SetPaymentHash provides a mock function with given fields: id, paymentHash
+func (_m *Database) SetPaymentHash(id uint, paymentHash string) error {
+	ret := _m.Called(id, paymentHash)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetWorkspaceUsersCount")
+		panic("no return value specified for SetPaymentHash")
 	}
 
-	var r0 int64
-	if rf, ok := ret.Get(0).(func(string) int64); ok {
-		r0 = rf(uuid)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, string) error); ok {
+		r0 = rf(id, paymentHash)
 	} else {
-		r0 = ret.Get(0).(int64)
+		r0 = ret.Error(0)
 	}
 
 	return r0
 }
 
-// Database_GetWorkspaceUsersCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceUsersCount'
-type Database_GetWorkspaceUsersCount_Call struct {
+// Database_SetPaymentHash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetPaymentHash'
+type Database_SetPaymentHash_Call struct {
 	*mock.Call
 }
 
-// GetWorkspaceUsersCount is a helper method to define mock.On call
-//   - uuid string
-func (_e *Database_Expecter) GetWorkspaceUsersCount(uuid interface{}) *Database_GetWorkspaceUsersCount_Call {
-	return &Database_GetWorkspaceUsersCount_Call{Call: _e.mock.On("GetWorkspaceUsersCount", uuid)}
+// SetPaymentHash is a helper method to define mock.On call
+//   - id uint
+//   - paymentHash string
+func (_e *Database_Expecter) SetPaymentHash(id interface{}, paymentHash interface{}) *Database_SetPaymentHash_Call {
+	return &Database_SetPaymentHash_Call{Call: _e.mock.On("SetPaymentHash", id, paymentHash)}
 }
 
-func (_c *Database_GetWorkspaceUsersCount_Call) Run(run func(uuid string)) *Database_GetWorkspaceUsersCount_Call {
+func (_c *Database_SetPaymentHash_Call) Run(run func(id uint, paymentHash string)) *Database_SetPaymentHash_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(uint), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetWorkspaceUsersCount_Call) Return(_a0 int64) *Database_GetWorkspaceUsersCount_Call {
+func (_c *Database_SetPaymentHash_Call) Return(_a0 error) *Database_SetPaymentHash_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetWorkspaceUsersCount_Call) RunAndReturn(run func(string) int64) *Database_GetWorkspaceUsersCount_Call {
+func (_c *Database_SetPaymentHash_Call) RunAndReturn(run func(uint, string) error) *Database_SetPaymentHash_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetWorkspaces provides a mock function with given fields: r
-func (_m *Database) GetWorkspaces(r *http.Request) []db.Workspace {
-	ret := _m.Called(r)
+// SetPaymentPreimage provides a mock function with given fields: id, preimage
+func (_m *Database) SetPaymentPreimage(id uint, preimage string) error {
+	ret := _m.Called(id, preimage)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetWorkspaces")
+		panic("no return value specified for SetPaymentPreimage")
 	}
 
-	var r0 []db.Workspace
-	if rf, ok := ret.Get(0).(func(*http.Request) []db.Workspace); ok {
-		r0 = rf(r)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, string) error); ok {
+		r0 = rf(id, preimage)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.Workspace)
-		}
+		r0 = ret.Error(0)
 	}
 
 	return r0
 }
 
-// Database_GetWorkspaces_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaces'
-type Database_GetWorkspaces_Call struct {
+// Database_SetPaymentPreimage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetPaymentPreimage'
+type Database_SetPaymentPreimage_Call struct {
 	*mock.Call
 }
 
-// GetWorkspaces is a helper method to define mock.On call
-//   - r *http.Request
-func (_e *Database_Expecter) GetWorkspaces(r interface{}) *Database_GetWorkspaces_Call {
-	return &Database_GetWorkspaces_Call{Call: _e.mock.On("GetWorkspaces", r)}
+// SetPaymentPreimage is a helper method to define mock.On call
+//   - id uint
+//   - preimage string
+func (_e *Database_Expecter) SetPaymentPreimage(id interface{}, preimage interface{}) *Database_SetPaymentPreimage_Call {
+	return &Database_SetPaymentPreimage_Call{Call: _e.mock.On("SetPaymentPreimage", id, preimage)}
 }
 
-func (_c *Database_GetWorkspaces_Call) Run(run func(r *http.Request)) *Database_GetWorkspaces_Call {
+func (_c *Database_SetPaymentPreimage_Call) Run(run func(id uint, preimage string)) *Database_SetPaymentPreimage_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*http.Request))
+		run(args[0].(uint), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetWorkspaces_Call) Return(_a0 []db.Workspace) *Database_GetWorkspaces_Call {
+func (_c *Database_SetPaymentPreimage_Call) Return(_a0 error) *Database_SetPaymentPreimage_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetWorkspaces_Call) RunAndReturn(run func(*http.Request) []db.Workspace) *Database_GetWorkspaces_Call {
+func (_c *Database_SetPaymentPreimage_Call) RunAndReturn(run func(uint, string) error) *Database_SetPaymentPreimage_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetWorkspacesCount provides a mock function with given fields:
-func (_m *Database) GetWorkspacesCount() int64 {
-	ret := _m.Called()
+// SetPhaseDependency provides a mock function with given fields: phaseUuid, dependsOn, pubkey
+func (_m *Database) SetPhaseDependency(phaseUuid string, dependsOn string, pubkey string) (db.FeaturePhase, error) {
+	ret := _m.Called(phaseUuid, dependsOn, pubkey)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetWorkspacesCount")
+		panic("no return value specified for SetPhaseDependency")
 	}
 
-	var r0 int64
-	if rf, ok := ret.Get(0).(func() int64); ok {
-		r0 = rf()
+	var r0 db.FeaturePhase
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, string) (db.FeaturePhase, error)); ok {
+		return rf(phaseUuid, dependsOn, pubkey)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, string) db.FeaturePhase); ok {
+		r0 = rf(phaseUuid, dependsOn, pubkey)
 	} else {
-		r0 = ret.Get(0).(int64)
+		r0 = ret.Get(0).(db.FeaturePhase)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(string, string, string) error); ok {
+		r1 = rf(phaseUuid, dependsOn, pubkey)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_GetWorkspacesCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspacesCount'
-type Database_GetWorkspacesCount_Call struct {
+// Database_SetPhaseDependency_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetPhaseDependency'
+type Database_SetPhaseDependency_Call struct {
 	*mock.Call
 }
 
-// GetWorkspacesCount is a helper method to define mock.On call
-func (_e *Database_Expecter) GetWorkspacesCount() *Database_GetWorkspacesCount_Call {
-	return &Database_GetWorkspacesCount_Call{Call: _e.mock.On("GetWorkspacesCount")}
+// SetPhaseDependency is a helper method to define mock.On call
+//   - phaseUuid string
+//   - dependsOn string
+//   - pubkey string
+func (_e *Database_Expecter) SetPhaseDependency(phaseUuid interface{}, dependsOn interface{}, pubkey interface{}) *Database_SetPhaseDependency_Call {
+	return &Database_SetPhaseDependency_Call{Call: _e.mock.On("SetPhaseDependency", phaseUuid, dependsOn, pubkey)}
 }
 
-func (_c *Database_GetWorkspacesCount_Call) Run(run func()) *Database_GetWorkspacesCount_Call {
+func (_c *Database_SetPhaseDependency_Call) Run(run func(phaseUuid string, dependsOn string, pubkey string)) *Database_SetPhaseDependency_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		run(args[0].(string), args[1].(string), args[2].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetWorkspacesCount_Call) Return(_a0 int64) *Database_GetWorkspacesCount_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_SetPhaseDependency_Call) Return(_a0 db.FeaturePhase, _a1 error) *Database_SetPhaseDependency_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetWorkspacesCount_Call) RunAndReturn(run func() int64) *Database_GetWorkspacesCount_Call {
+func (_c *Database_SetPhaseDependency_Call) RunAndReturn(run func(string, string, string) (db.FeaturePhase, error)) *Database_SetPhaseDependency_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NewHuntersPaid provides a mock function with given fields: r, workspace
-func (_m *Database) NewHuntersPaid(r db.PaymentDateRange, workspace string) int64 {
-	ret := _m.Called(r, workspace)
+// SetPhaseGateOverride provides a mock function with given fields: phaseUuid, override, pubkey
+func (_m *Database) SetPhaseGateOverride(phaseUuid string, override bool, pubkey string) (db.FeaturePhase, error) {
+	ret := _m.Called(phaseUuid, override, pubkey)
 
 	if len(ret) == 0 {
-		panic("no return value specified for NewHuntersPaid")
+		panic("no return value specified for SetPhaseGateOverride")
 	}
 
-	var r0 int64
-	if rf, ok := ret.Get(0).(func(db.PaymentDateRange, string) int64); ok {
-		r0 = rf(r, workspace)
+	var r0 db.FeaturePhase
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, bool, string) (db.FeaturePhase, error)); ok {
+		return rf(phaseUuid, override, pubkey)
+	}
+	if rf, ok := ret.Get(0).(func(string, bool, string) db.FeaturePhase); ok {
+		r0 = rf(phaseUuid, override, pubkey)
 	} else {
-		r0 = ret.Get(0).(int64)
+		r0 = ret.Get(0).(db.FeaturePhase)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(string, bool, string) error); ok {
+		r1 = rf(phaseUuid, override, pubkey)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_NewHuntersPaid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NewHuntersPaid'
-type Database_NewHuntersPaid_Call struct {
+// Database_SetPhaseGateOverride_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetPhaseGateOverride'
+type Database_SetPhaseGateOverride_Call struct {
 	*mock.Call
 }
 
-// NewHuntersPaid is a helper method to define mock.On call
-//   - r db.PaymentDateRange
-//   - workspace string
-func (_e *Database_Expecter) NewHuntersPaid(r interface{}, workspace interface{}) *Database_NewHuntersPaid_Call {
-	return &Database_NewHuntersPaid_Call{Call: _e.mock.On("NewHuntersPaid", r, workspace)}
+// SetPhaseGateOverride is a helper method to define mock.On call
+//   - phaseUuid string
+//   - override bool
+//   - pubkey string
+func (_e *Database_Expecter) SetPhaseGateOverride(phaseUuid interface{}, override interface{}, pubkey interface{}) *Database_SetPhaseGateOverride_Call {
+	return &Database_SetPhaseGateOverride_Call{Call: _e.mock.On("SetPhaseGateOverride", phaseUuid, override, pubkey)}
 }
 
-func (_c *Database_NewHuntersPaid_Call) Run(run func(r db.PaymentDateRange, workspace string)) *Database_NewHuntersPaid_Call {
+func (_c *Database_SetPhaseGateOverride_Call) Run(run func(phaseUuid string, override bool, pubkey string)) *Database_SetPhaseGateOverride_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.PaymentDateRange), args[1].(string))
+		run(args[0].(string), args[1].(bool), args[2].(string))
 	})
 	return _c
 }
 
-func (_c *Database_NewHuntersPaid_Call) Return(_a0 int64) *Database_NewHuntersPaid_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_SetPhaseGateOverride_Call) Return(_a0 db.FeaturePhase, _a1 error) *Database_SetPhaseGateOverride_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_NewHuntersPaid_Call) RunAndReturn(run func(db.PaymentDateRange, string) int64) *Database_NewHuntersPaid_Call {
+func (_c *Database_SetPhaseGateOverride_Call) RunAndReturn(run func(string, bool, string) (db.FeaturePhase, error)) *Database_SetPhaseGateOverride_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// PersonUniqueNameFromName provides a mock function with given fields: name
-func (_m *Database) PersonUniqueNameFromName(name string) (string, error) {
-	ret := _m.Called(name)
+// SetTicketStatuses provides a mock function with given fields: phaseUuid, changes
+func (_m *Database) SetTicketStatuses(phaseUuid string, changes []db.TicketStatusChange) ([]db.NewBounty, error) {
+	ret := _m.Called(phaseUuid, changes)
 
 	if len(ret) == 0 {
-		panic("no return value specified for PersonUniqueNameFromName")
+		panic("no return value specified for SetTicketStatuses")
 	}
 
-	var r0 string
+	var r0 []db.NewBounty
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (string, error)); ok {
-		return rf(name)
+	if rf, ok := ret.Get(0).(func(string, []db.TicketStatusChange) ([]db.NewBounty, error)); ok {
+		return rf(phaseUuid, changes)
 	}
-	if rf, ok := ret.Get(0).(func(string) string); ok {
-		r0 = rf(name)
+	if rf, ok := ret.Get(0).(func(string, []db.TicketStatusChange) []db.NewBounty); ok {
+		r0 = rf(phaseUuid, changes)
 	} else {
-		r0 = ret.Get(0).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.NewBounty)
+		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(name)
+	if rf, ok := ret.Get(1).(func(string, []db.TicketStatusChange) error); ok {
+		r1 = rf(phaseUuid, changes)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -6295,45 +18643,46 @@ func (_m *Database) PersonUniqueNameFromName(name string) (string, error) {
 	return r0, r1
 }
 
-// Database_PersonUniqueNameFromName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PersonUniqueNameFromName'
-type Database_PersonUniqueNameFromName_Call struct {
+// Database_SetTicketStatuses_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetTicketStatuses'
+type Database_SetTicketStatuses_Call struct {
 	*mock.Call
 }
 
-// PersonUniqueNameFromName is a helper method to define mock.On call
-//   - name string
-func (_e *Database_Expecter) PersonUniqueNameFromName(name interface{}) *Database_PersonUniqueNameFromName_Call {
-	return &Database_PersonUniqueNameFromName_Call{Call: _e.mock.On("PersonUniqueNameFromName", name)}
+// SetTicketStatuses is a helper method to define mock.On call
+//   - phaseUuid string
+//   - changes []db.TicketStatusChange
+func (_e *Database_Expecter) SetTicketStatuses(phaseUuid interface{}, changes interface{}) *Database_SetTicketStatuses_Call {
+	return &Database_SetTicketStatuses_Call{Call: _e.mock.On("SetTicketStatuses", phaseUuid, changes)}
 }
 
-func (_c *Database_PersonUniqueNameFromName_Call) Run(run func(name string)) *Database_PersonUniqueNameFromName_Call {
+func (_c *Database_SetTicketStatuses_Call) Run(run func(phaseUuid string, changes []db.TicketStatusChange)) *Database_SetTicketStatuses_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(string), args[1].([]db.TicketStatusChange))
 	})
 	return _c
 }
 
-func (_c *Database_PersonUniqueNameFromName_Call) Return(_a0 string, _a1 error) *Database_PersonUniqueNameFromName_Call {
+func (_c *Database_SetTicketStatuses_Call) Return(_a0 []db.NewBounty, _a1 error) *Database_SetTicketStatuses_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_PersonUniqueNameFromName_Call) RunAndReturn(run func(string) (string, error)) *Database_PersonUniqueNameFromName_Call {
+func (_c *Database_SetTicketStatuses_Call) RunAndReturn(run func(string, []db.TicketStatusChange) ([]db.NewBounty, error)) *Database_SetTicketStatuses_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ProcessAddInvoice provides a mock function with given fields: invoice, userData
-func (_m *Database) ProcessAddInvoice(invoice db.NewInvoiceList, userData db.UserInvoiceData) error {
-	ret := _m.Called(invoice, userData)
+// SetTribeCategories provides a mock function with given fields: tribeUuid, slugs
+func (_m *Database) SetTribeCategories(tribeUuid string, slugs []string) error {
+	ret := _m.Called(tribeUuid, slugs)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ProcessAddInvoice")
+		panic("no return value specified for SetTribeCategories")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(db.NewInvoiceList, db.UserInvoiceData) error); ok {
-		r0 = rf(invoice, userData)
+	if rf, ok := ret.Get(0).(func(string, []string) error); ok {
+		r0 = rf(tribeUuid, slugs)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -6341,445 +18690,592 @@ func (_m *Database) ProcessAddInvoice(invoice db.NewInvoiceList, userData db.Use
 	return r0
 }
 
-// Database_ProcessAddInvoice_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ProcessAddInvoice'
-type Database_ProcessAddInvoice_Call struct {
+// Database_SetTribeCategories_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetTribeCategories'
+type Database_SetTribeCategories_Call struct {
 	*mock.Call
 }
 
-// ProcessAddInvoice is a helper method to define mock.On call
-//   - invoice db.NewInvoiceList
-//   - userData db.UserInvoiceData
-func (_e *Database_Expecter) ProcessAddInvoice(invoice interface{}, userData interface{}) *Database_ProcessAddInvoice_Call {
-	return &Database_ProcessAddInvoice_Call{Call: _e.mock.On("ProcessAddInvoice", invoice, userData)}
+// SetTribeCategories is a helper method to define mock.On call
+//   - tribeUuid string
+//   - slugs []string
+func (_e *Database_Expecter) SetTribeCategories(tribeUuid interface{}, slugs interface{}) *Database_SetTribeCategories_Call {
+	return &Database_SetTribeCategories_Call{Call: _e.mock.On("SetTribeCategories", tribeUuid, slugs)}
 }
 
-func (_c *Database_ProcessAddInvoice_Call) Run(run func(invoice db.NewInvoiceList, userData db.UserInvoiceData)) *Database_ProcessAddInvoice_Call {
+func (_c *Database_SetTribeCategories_Call) Run(run func(tribeUuid string, slugs []string)) *Database_SetTribeCategories_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *Database_SetTribeCategories_Call) Return(_a0 error) *Database_SetTribeCategories_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_SetTribeCategories_Call) RunAndReturn(run func(string, []string) error) *Database_SetTribeCategories_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetWorkspaceAiCap provides a mock function with given fields: uuid, monthlyCap
+func (_m *Database) SetWorkspaceAiCap(uuid string, monthlyCap uint) (db.Workspace, error) {
+	ret := _m.Called(uuid, monthlyCap)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetWorkspaceAiCap")
+	}
+
+	var r0 db.Workspace
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, uint) (db.Workspace, error)); ok {
+		return rf(uuid, monthlyCap)
+	}
+	if rf, ok := ret.Get(0).(func(string, uint) db.Workspace); ok {
+		r0 = rf(uuid, monthlyCap)
+	} else {
+		r0 = ret.Get(0).(db.Workspace)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, uint) error); ok {
+		r1 = rf(uuid, monthlyCap)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_SetWorkspaceAiCap_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetWorkspaceAiCap'
+type Database_SetWorkspaceAiCap_Call struct {
+	*mock.Call
+}
+
+// SetWorkspaceAiCap is a helper method to define mock.On call
+//   - uuid string
+//   - monthlyCap uint
+func (_e *Database_Expecter) SetWorkspaceAiCap(uuid interface{}, monthlyCap interface{}) *Database_SetWorkspaceAiCap_Call {
+	return &Database_SetWorkspaceAiCap_Call{Call: _e.mock.On("SetWorkspaceAiCap", uuid, monthlyCap)}
+}
+
+func (_c *Database_SetWorkspaceAiCap_Call) Run(run func(uuid string, monthlyCap uint)) *Database_SetWorkspaceAiCap_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.NewInvoiceList), args[1].(db.UserInvoiceData))
+		run(args[0].(string), args[1].(uint))
 	})
 	return _c
 }
 
-func (_c *Database_ProcessAddInvoice_Call) Return(_a0 error) *Database_ProcessAddInvoice_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_SetWorkspaceAiCap_Call) Return(_a0 db.Workspace, _a1 error) *Database_SetWorkspaceAiCap_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_ProcessAddInvoice_Call) RunAndReturn(run func(db.NewInvoiceList, db.UserInvoiceData) error) *Database_ProcessAddInvoice_Call {
+func (_c *Database_SetWorkspaceAiCap_Call) RunAndReturn(run func(string, uint) (db.Workspace, error)) *Database_SetWorkspaceAiCap_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ProcessAlerts provides a mock function with given fields: p
-func (_m *Database) ProcessAlerts(p db.Person) {
-	_m.Called(p)
+// SetWorkspaceAutoPay provides a mock function with given fields: uuid, autoPay, autoPayCap
+func (_m *Database) SetWorkspaceAutoPay(uuid string, autoPay bool, autoPayCap uint) (db.Workspace, error) {
+	ret := _m.Called(uuid, autoPay, autoPayCap)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetWorkspaceAutoPay")
+	}
+
+	var r0 db.Workspace
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, bool, uint) (db.Workspace, error)); ok {
+		return rf(uuid, autoPay, autoPayCap)
+	}
+	if rf, ok := ret.Get(0).(func(string, bool, uint) db.Workspace); ok {
+		r0 = rf(uuid, autoPay, autoPayCap)
+	} else {
+		r0 = ret.Get(0).(db.Workspace)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, bool, uint) error); ok {
+		r1 = rf(uuid, autoPay, autoPayCap)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_ProcessAlerts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ProcessAlerts'
-type Database_ProcessAlerts_Call struct {
+// Database_SetWorkspaceAutoPay_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetWorkspaceAutoPay'
+type Database_SetWorkspaceAutoPay_Call struct {
 	*mock.Call
 }
 
-// ProcessAlerts is a helper method to define mock.On call
-//   - p db.Person
-func (_e *Database_Expecter) ProcessAlerts(p interface{}) *Database_ProcessAlerts_Call {
-	return &Database_ProcessAlerts_Call{Call: _e.mock.On("ProcessAlerts", p)}
+// SetWorkspaceAutoPay is a helper method to define mock.On call
+//   - uuid string
+//   - autoPay bool
+//   - autoPayCap uint
+func (_e *Database_Expecter) SetWorkspaceAutoPay(uuid interface{}, autoPay interface{}, autoPayCap interface{}) *Database_SetWorkspaceAutoPay_Call {
+	return &Database_SetWorkspaceAutoPay_Call{Call: _e.mock.On("SetWorkspaceAutoPay", uuid, autoPay, autoPayCap)}
 }
 
-func (_c *Database_ProcessAlerts_Call) Run(run func(p db.Person)) *Database_ProcessAlerts_Call {
+func (_c *Database_SetWorkspaceAutoPay_Call) Run(run func(uuid string, autoPay bool, autoPayCap uint)) *Database_SetWorkspaceAutoPay_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.Person))
+		run(args[0].(string), args[1].(bool), args[2].(uint))
 	})
 	return _c
 }
 
-func (_c *Database_ProcessAlerts_Call) Return() *Database_ProcessAlerts_Call {
-	_c.Call.Return()
+func (_c *Database_SetWorkspaceAutoPay_Call) Return(_a0 db.Workspace, _a1 error) *Database_SetWorkspaceAutoPay_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_ProcessAlerts_Call) RunAndReturn(run func(db.Person)) *Database_ProcessAlerts_Call {
+func (_c *Database_SetWorkspaceAutoPay_Call) RunAndReturn(run func(string, bool, uint) (db.Workspace, error)) *Database_SetWorkspaceAutoPay_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ProcessBountyPayment provides a mock function with given fields: payment, bounty
-func (_m *Database) ProcessBountyPayment(payment db.NewPaymentHistory, bounty db.NewBounty) error {
-	ret := _m.Called(payment, bounty)
+// SetWorkspaceDefinitionOfDone provides a mock function with given fields: uuid, done
+func (_m *Database) SetWorkspaceDefinitionOfDone(uuid string, done db.DefinitionOfDone) (db.Workspace, error) {
+	ret := _m.Called(uuid, done)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ProcessBountyPayment")
+		panic("no return value specified for SetWorkspaceDefinitionOfDone")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(db.NewPaymentHistory, db.NewBounty) error); ok {
-		r0 = rf(payment, bounty)
+	var r0 db.Workspace
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, db.DefinitionOfDone) (db.Workspace, error)); ok {
+		return rf(uuid, done)
+	}
+	if rf, ok := ret.Get(0).(func(string, db.DefinitionOfDone) db.Workspace); ok {
+		r0 = rf(uuid, done)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(db.Workspace)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(string, db.DefinitionOfDone) error); ok {
+		r1 = rf(uuid, done)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_ProcessBountyPayment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ProcessBountyPayment'
-type Database_ProcessBountyPayment_Call struct {
+// Database_SetWorkspaceDefinitionOfDone_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetWorkspaceDefinitionOfDone'
+type Database_SetWorkspaceDefinitionOfDone_Call struct {
 	*mock.Call
 }
 
-// ProcessBountyPayment is a helper method to define mock.On call
-//   - payment db.NewPaymentHistory
-//   - bounty db.NewBounty
-func (_e *Database_Expecter) ProcessBountyPayment(payment interface{}, bounty interface{}) *Database_ProcessBountyPayment_Call {
-	return &Database_ProcessBountyPayment_Call{Call: _e.mock.On("ProcessBountyPayment", payment, bounty)}
+// SetWorkspaceDefinitionOfDone is a helper method to define mock.On call
+//   - uuid string
+//   - done db.DefinitionOfDone
+func (_e *Database_Expecter) SetWorkspaceDefinitionOfDone(uuid interface{}, done interface{}) *Database_SetWorkspaceDefinitionOfDone_Call {
+	return &Database_SetWorkspaceDefinitionOfDone_Call{Call: _e.mock.On("SetWorkspaceDefinitionOfDone", uuid, done)}
 }
 
-func (_c *Database_ProcessBountyPayment_Call) Run(run func(payment db.NewPaymentHistory, bounty db.NewBounty)) *Database_ProcessBountyPayment_Call {
+func (_c *Database_SetWorkspaceDefinitionOfDone_Call) Run(run func(uuid string, done db.DefinitionOfDone)) *Database_SetWorkspaceDefinitionOfDone_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.NewPaymentHistory), args[1].(db.NewBounty))
+		run(args[0].(string), args[1].(db.DefinitionOfDone))
 	})
 	return _c
 }
 
-func (_c *Database_ProcessBountyPayment_Call) Return(_a0 error) *Database_ProcessBountyPayment_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_SetWorkspaceDefinitionOfDone_Call) Return(_a0 db.Workspace, _a1 error) *Database_SetWorkspaceDefinitionOfDone_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_ProcessBountyPayment_Call) RunAndReturn(run func(db.NewPaymentHistory, db.NewBounty) error) *Database_ProcessBountyPayment_Call {
+func (_c *Database_SetWorkspaceDefinitionOfDone_Call) RunAndReturn(run func(string, db.DefinitionOfDone) (db.Workspace, error)) *Database_SetWorkspaceDefinitionOfDone_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ProcessBudgetInvoice provides a mock function with given fields: paymentHistory, newInvoice
-func (_m *Database) ProcessBudgetInvoice(paymentHistory db.NewPaymentHistory, newInvoice db.NewInvoiceList) error {
-	ret := _m.Called(paymentHistory, newInvoice)
+// SetWorkspacePlan provides a mock function with given fields: uuid, plan
+func (_m *Database) SetWorkspacePlan(uuid string, plan string) (db.Workspace, error) {
+	ret := _m.Called(uuid, plan)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ProcessBudgetInvoice")
+		panic("no return value specified for SetWorkspacePlan")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(db.NewPaymentHistory, db.NewInvoiceList) error); ok {
-		r0 = rf(paymentHistory, newInvoice)
+	var r0 db.Workspace
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (db.Workspace, error)); ok {
+		return rf(uuid, plan)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) db.Workspace); ok {
+		r0 = rf(uuid, plan)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(db.Workspace)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(uuid, plan)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_ProcessBudgetInvoice_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ProcessBudgetInvoice'
-type Database_ProcessBudgetInvoice_Call struct {
+// Database_SetWorkspacePlan_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetWorkspacePlan'
+type Database_SetWorkspacePlan_Call struct {
 	*mock.Call
 }
 
-// ProcessBudgetInvoice is a helper method to define mock.On call
-//   - paymentHistory db.NewPaymentHistory
-//   - newInvoice db.NewInvoiceList
-func (_e *Database_Expecter) ProcessBudgetInvoice(paymentHistory interface{}, newInvoice interface{}) *Database_ProcessBudgetInvoice_Call {
-	return &Database_ProcessBudgetInvoice_Call{Call: _e.mock.On("ProcessBudgetInvoice", paymentHistory, newInvoice)}
+// SetWorkspacePlan is a helper method to define mock.On call
+//   - uuid string
+//   - plan string
+func (_e *Database_Expecter) SetWorkspacePlan(uuid interface{}, plan interface{}) *Database_SetWorkspacePlan_Call {
+	return &Database_SetWorkspacePlan_Call{Call: _e.mock.On("SetWorkspacePlan", uuid, plan)}
 }
 
-func (_c *Database_ProcessBudgetInvoice_Call) Run(run func(paymentHistory db.NewPaymentHistory, newInvoice db.NewInvoiceList)) *Database_ProcessBudgetInvoice_Call {
+func (_c *Database_SetWorkspacePlan_Call) Run(run func(uuid string, plan string)) *Database_SetWorkspacePlan_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.NewPaymentHistory), args[1].(db.NewInvoiceList))
+		run(args[0].(string), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *Database_ProcessBudgetInvoice_Call) Return(_a0 error) *Database_ProcessBudgetInvoice_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_SetWorkspacePlan_Call) Return(_a0 db.Workspace, _a1 error) *Database_SetWorkspacePlan_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_ProcessBudgetInvoice_Call) RunAndReturn(run func(db.NewPaymentHistory, db.NewInvoiceList) error) *Database_ProcessBudgetInvoice_Call {
+func (_c *Database_SetWorkspacePlan_Call) RunAndReturn(run func(string, string) (db.Workspace, error)) *Database_SetWorkspacePlan_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ProcessDeleteWorkspace provides a mock function with given fields: workspace_uuid
-func (_m *Database) ProcessDeleteWorkspace(workspace_uuid string) error {
-	ret := _m.Called(workspace_uuid)
+// SetWorkspaceReservePercent provides a mock function with given fields: workspaceUuid, percent
+func (_m *Database) SetWorkspaceReservePercent(workspaceUuid string, percent uint) (db.NewBountyBudget, error) {
+	ret := _m.Called(workspaceUuid, percent)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ProcessDeleteWorkspace")
+		panic("no return value specified for SetWorkspaceReservePercent")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(string) error); ok {
-		r0 = rf(workspace_uuid)
+	var r0 db.NewBountyBudget
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, uint) (db.NewBountyBudget, error)); ok {
+		return rf(workspaceUuid, percent)
+	}
+	if rf, ok := ret.Get(0).(func(string, uint) db.NewBountyBudget); ok {
+		r0 = rf(workspaceUuid, percent)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(db.NewBountyBudget)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(string, uint) error); ok {
+		r1 = rf(workspaceUuid, percent)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_ProcessDeleteWorkspace_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ProcessDeleteWorkspace'
-type Database_ProcessDeleteWorkspace_Call struct {
+// Database_SetWorkspaceReservePercent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetWorkspaceReservePercent'
+type Database_SetWorkspaceReservePercent_Call struct {
 	*mock.Call
 }
 
-// ProcessDeleteWorkspace is a helper method to define mock.On call
-//   - workspace_uuid string
-func (_e *Database_Expecter) ProcessDeleteWorkspace(workspace_uuid interface{}) *Database_ProcessDeleteWorkspace_Call {
-	return &Database_ProcessDeleteWorkspace_Call{Call: _e.mock.On("ProcessDeleteWorkspace", workspace_uuid)}
+// SetWorkspaceReservePercent is a helper method to define mock.On call
+//   - workspaceUuid string
+//   - percent uint
+func (_e *Database_Expecter) SetWorkspaceReservePercent(workspaceUuid interface{}, percent interface{}) *Database_SetWorkspaceReservePercent_Call {
+	return &Database_SetWorkspaceReservePercent_Call{Call: _e.mock.On("SetWorkspaceReservePercent", workspaceUuid, percent)}
 }
 
-func (_c *Database_ProcessDeleteWorkspace_Call) Run(run func(workspace_uuid string)) *Database_ProcessDeleteWorkspace_Call {
+func (_c *Database_SetWorkspaceReservePercent_Call) Run(run func(workspaceUuid string, percent uint)) *Database_SetWorkspaceReservePercent_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(string), args[1].(uint))
 	})
 	return _c
 }
 
-func (_c *Database_ProcessDeleteWorkspace_Call) Return(_a0 error) *Database_ProcessDeleteWorkspace_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_SetWorkspaceReservePercent_Call) Return(_a0 db.NewBountyBudget, _a1 error) *Database_SetWorkspaceReservePercent_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_ProcessDeleteWorkspace_Call) RunAndReturn(run func(string) error) *Database_ProcessDeleteWorkspace_Call {
+func (_c *Database_SetWorkspaceReservePercent_Call) RunAndReturn(run func(string, uint) (db.NewBountyBudget, error)) *Database_SetWorkspaceReservePercent_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ProcessUpdateBudget provides a mock function with given fields: invoice
-func (_m *Database) ProcessUpdateBudget(invoice db.NewInvoiceList) error {
-	ret := _m.Called(invoice)
+// SetWorkspaceReviewers provides a mock function with given fields: uuid, settings
+func (_m *Database) SetWorkspaceReviewers(uuid string, settings db.ReviewerSettings) (db.Workspace, error) {
+	ret := _m.Called(uuid, settings)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ProcessUpdateBudget")
+		panic("no return value specified for SetWorkspaceReviewers")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(db.NewInvoiceList) error); ok {
-		r0 = rf(invoice)
+	var r0 db.Workspace
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, db.ReviewerSettings) (db.Workspace, error)); ok {
+		return rf(uuid, settings)
+	}
+	if rf, ok := ret.Get(0).(func(string, db.ReviewerSettings) db.Workspace); ok {
+		r0 = rf(uuid, settings)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(db.Workspace)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(string, db.ReviewerSettings) error); ok {
+		r1 = rf(uuid, settings)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_ProcessUpdateBudget_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ProcessUpdateBudget'
-type Database_ProcessUpdateBudget_Call struct {
+// Database_SetWorkspaceReviewers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetWorkspaceReviewers'
+type Database_SetWorkspaceReviewers_Call struct {
 	*mock.Call
 }
 
-// ProcessUpdateBudget is a helper method to define mock.On call
-//   - invoice db.NewInvoiceList
-func (_e *Database_Expecter) ProcessUpdateBudget(invoice interface{}) *Database_ProcessUpdateBudget_Call {
-	return &Database_ProcessUpdateBudget_Call{Call: _e.mock.On("ProcessUpdateBudget", invoice)}
+// SetWorkspaceReviewers is a helper method to define mock.On call
+//   - uuid string
+//   - settings db.ReviewerSettings
+func (_e *Database_Expecter) SetWorkspaceReviewers(uuid interface{}, settings interface{}) *Database_SetWorkspaceReviewers_Call {
+	return &Database_SetWorkspaceReviewers_Call{Call: _e.mock.On("SetWorkspaceReviewers", uuid, settings)}
 }
 
-func (_c *Database_ProcessUpdateBudget_Call) Run(run func(invoice db.NewInvoiceList)) *Database_ProcessUpdateBudget_Call {
+func (_c *Database_SetWorkspaceReviewers_Call) Run(run func(uuid string, settings db.ReviewerSettings)) *Database_SetWorkspaceReviewers_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.NewInvoiceList))
+		run(args[0].(string), args[1].(db.ReviewerSettings))
 	})
 	return _c
 }
 
-func (_c *Database_ProcessUpdateBudget_Call) Return(_a0 error) *Database_ProcessUpdateBudget_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_SetWorkspaceReviewers_Call) Return(_a0 db.Workspace, _a1 error) *Database_SetWorkspaceReviewers_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_ProcessUpdateBudget_Call) RunAndReturn(run func(db.NewInvoiceList) error) *Database_ProcessUpdateBudget_Call {
+func (_c *Database_SetWorkspaceReviewers_Call) RunAndReturn(run func(string, db.ReviewerSettings) (db.Workspace, error)) *Database_SetWorkspaceReviewers_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SatsPaidPercentage provides a mock function with given fields: r, workspace
-func (_m *Database) SatsPaidPercentage(r db.PaymentDateRange, workspace string) uint {
-	ret := _m.Called(r, workspace)
+// SettleTip provides a mock function with given fields: paymentRequest
+func (_m *Database) SettleTip(paymentRequest string) (db.NewPaymentHistory, error) {
+	ret := _m.Called(paymentRequest)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SatsPaidPercentage")
+		panic("no return value specified for SettleTip")
 	}
 
-	var r0 uint
-	if rf, ok := ret.Get(0).(func(db.PaymentDateRange, string) uint); ok {
-		r0 = rf(r, workspace)
+	var r0 db.NewPaymentHistory
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (db.NewPaymentHistory, error)); ok {
+		return rf(paymentRequest)
+	}
+	if rf, ok := ret.Get(0).(func(string) db.NewPaymentHistory); ok {
+		r0 = rf(paymentRequest)
 	} else {
-		r0 = ret.Get(0).(uint)
+		r0 = ret.Get(0).(db.NewPaymentHistory)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(paymentRequest)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_SatsPaidPercentage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SatsPaidPercentage'
-type Database_SatsPaidPercentage_Call struct {
+// Database_SettleTip_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SettleTip'
+type Database_SettleTip_Call struct {
 	*mock.Call
 }
 
-// SatsPaidPercentage is a helper method to define mock.On call
-//   - r db.PaymentDateRange
-//   - workspace string
-func (_e *Database_Expecter) SatsPaidPercentage(r interface{}, workspace interface{}) *Database_SatsPaidPercentage_Call {
-	return &Database_SatsPaidPercentage_Call{Call: _e.mock.On("SatsPaidPercentage", r, workspace)}
+// SettleTip is a helper method to define mock.On call
+//   - paymentRequest string
+func (_e *Database_Expecter) SettleTip(paymentRequest interface{}) *Database_SettleTip_Call {
+	return &Database_SettleTip_Call{Call: _e.mock.On("SettleTip", paymentRequest)}
 }
 
-func (_c *Database_SatsPaidPercentage_Call) Run(run func(r db.PaymentDateRange, workspace string)) *Database_SatsPaidPercentage_Call {
+func (_c *Database_SettleTip_Call) Run(run func(paymentRequest string)) *Database_SettleTip_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.PaymentDateRange), args[1].(string))
+		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *Database_SatsPaidPercentage_Call) Return(_a0 uint) *Database_SatsPaidPercentage_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_SettleTip_Call) Return(_a0 db.NewPaymentHistory, _a1 error) *Database_SettleTip_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_SatsPaidPercentage_Call) RunAndReturn(run func(db.PaymentDateRange, string) uint) *Database_SatsPaidPercentage_Call {
+func (_c *Database_SettleTip_Call) RunAndReturn(run func(string) (db.NewPaymentHistory, error)) *Database_SettleTip_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SearchBots provides a mock function with given fields: s, limit, offset
-func (_m *Database) SearchBots(s string, limit int, offset int) []db.BotRes {
-	ret := _m.Called(s, limit, offset)
+// SoftDelete provides a mock function with given fields: kind, id
+func (_m *Database) SoftDelete(kind string, id string) (bool, error) {
+	ret := _m.Called(kind, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SearchBots")
+		panic("no return value specified for SoftDelete")
 	}
 
-	var r0 []db.BotRes
-	if rf, ok := ret.Get(0).(func(string, int, int) []db.BotRes); ok {
-		r0 = rf(s, limit, offset)
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (bool, error)); ok {
+		return rf(kind, id)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) bool); ok {
+		r0 = rf(kind, id)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.BotRes)
-		}
+		r0 = ret.Get(0).(bool)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(kind, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_SearchBots_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchBots'
-type Database_SearchBots_Call struct {
+// Database_SoftDelete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SoftDelete'
+type Database_SoftDelete_Call struct {
 	*mock.Call
 }
 
-// SearchBots is a helper method to define mock.On call
-//   - s string
-//   - limit int
-//   - offset int
-func (_e *Database_Expecter) SearchBots(s interface{}, limit interface{}, offset interface{}) *Database_SearchBots_Call {
-	return &Database_SearchBots_Call{Call: _e.mock.On("SearchBots", s, limit, offset)}
+// SoftDelete is a helper method to define mock.On call
+//   - kind string
+//   - id string
+func (_e *Database_Expecter) SoftDelete(kind interface{}, id interface{}) *Database_SoftDelete_Call {
+	return &Database_SoftDelete_Call{Call: _e.mock.On("SoftDelete", kind, id)}
 }
 
-func (_c *Database_SearchBots_Call) Run(run func(s string, limit int, offset int)) *Database_SearchBots_Call {
+func (_c *Database_SoftDelete_Call) Run(run func(kind string, id string)) *Database_SoftDelete_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(int), args[2].(int))
+		run(args[0].(string), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *Database_SearchBots_Call) Return(_a0 []db.BotRes) *Database_SearchBots_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_SoftDelete_Call) Return(_a0 bool, _a1 error) *Database_SoftDelete_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_SearchBots_Call) RunAndReturn(run func(string, int, int) []db.BotRes) *Database_SearchBots_Call {
+func (_c *Database_SoftDelete_Call) RunAndReturn(run func(string, string) (bool, error)) *Database_SoftDelete_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SearchPeople provides a mock function with given fields: s, limit, offset
-func (_m *Database) SearchPeople(s string, limit int, offset int) []db.Person {
-	ret := _m.Called(s, limit, offset)
+// StopTimeEntry provides a mock function with given fields: entry, ended
+func (_m *Database) StopTimeEntry(entry db.TimeEntry, ended time.Time) (db.TimeEntry, error) {
+	ret := _m.Called(entry, ended)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SearchPeople")
+		panic("no return value specified for StopTimeEntry")
 	}
 
-	var r0 []db.Person
-	if rf, ok := ret.Get(0).(func(string, int, int) []db.Person); ok {
-		r0 = rf(s, limit, offset)
+	var r0 db.TimeEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.TimeEntry, time.Time) (db.TimeEntry, error)); ok {
+		return rf(entry, ended)
+	}
+	if rf, ok := ret.Get(0).(func(db.TimeEntry, time.Time) db.TimeEntry); ok {
+		r0 = rf(entry, ended)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.Person)
-		}
+		r0 = ret.Get(0).(db.TimeEntry)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(db.TimeEntry, time.Time) error); ok {
+		r1 = rf(entry, ended)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_SearchPeople_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchPeople'
-type Database_SearchPeople_Call struct {
+// Database_StopTimeEntry_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StopTimeEntry'
+type Database_StopTimeEntry_Call struct {
 	*mock.Call
 }
 
-// SearchPeople is a helper method to define mock.On call
-//   - s string
-//   - limit int
-//   - offset int
-func (_e *Database_Expecter) SearchPeople(s interface{}, limit interface{}, offset interface{}) *Database_SearchPeople_Call {
-	return &Database_SearchPeople_Call{Call: _e.mock.On("SearchPeople", s, limit, offset)}
+// StopTimeEntry is a helper method to define mock.On call
+//   - entry db.TimeEntry
+//   - ended time.Time
+func (_e *Database_Expecter) StopTimeEntry(entry interface{}, ended interface{}) *Database_StopTimeEntry_Call {
+	return &Database_StopTimeEntry_Call{Call: _e.mock.On("StopTimeEntry", entry, ended)}
 }
 
-func (_c *Database_SearchPeople_Call) Run(run func(s string, limit int, offset int)) *Database_SearchPeople_Call {
+func (_c *Database_StopTimeEntry_Call) Run(run func(entry db.TimeEntry, ended time.Time)) *Database_StopTimeEntry_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(int), args[2].(int))
+		run(args[0].(db.TimeEntry), args[1].(time.Time))
 	})
 	return _c
 }
 
-func (_c *Database_SearchPeople_Call) Return(_a0 []db.Person) *Database_SearchPeople_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_StopTimeEntry_Call) Return(_a0 db.TimeEntry, _a1 error) *Database_StopTimeEntry_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_SearchPeople_Call) RunAndReturn(run func(string, int, int) []db.Person) *Database_SearchPeople_Call {
+func (_c *Database_StopTimeEntry_Call) RunAndReturn(run func(db.TimeEntry, time.Time) (db.TimeEntry, error)) *Database_StopTimeEntry_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SearchTribes provides a mock function with given fields: s
-func (_m *Database) SearchTribes(s string) []db.Tribe {
-	ret := _m.Called(s)
+// SyncWorkspaceMemberSuggestions provides a mock function with given fields: workspaceUuid, logins, at
+func (_m *Database) SyncWorkspaceMemberSuggestions(workspaceUuid string, logins []db.GithubOrgLogin, at time.Time) error {
+	ret := _m.Called(workspaceUuid, logins, at)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SearchTribes")
+		panic("no return value specified for SyncWorkspaceMemberSuggestions")
 	}
 
-	var r0 []db.Tribe
-	if rf, ok := ret.Get(0).(func(string) []db.Tribe); ok {
-		r0 = rf(s)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, []db.GithubOrgLogin, time.Time) error); ok {
+		r0 = rf(workspaceUuid, logins, at)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.Tribe)
-		}
+		r0 = ret.Error(0)
 	}
 
 	return r0
 }
 
-// Database_SearchTribes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchTribes'
-type Database_SearchTribes_Call struct {
+// Database_SyncWorkspaceMemberSuggestions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SyncWorkspaceMemberSuggestions'
+type Database_SyncWorkspaceMemberSuggestions_Call struct {
 	*mock.Call
 }
 
-// SearchTribes is a helper method to define mock.On call
-//   - s string
-func (_e *Database_Expecter) SearchTribes(s interface{}) *Database_SearchTribes_Call {
-	return &Database_SearchTribes_Call{Call: _e.mock.On("SearchTribes", s)}
+// SyncWorkspaceMemberSuggestions is a helper method to define mock.On call
+//   - workspaceUuid string
+//   - logins []db.GithubOrgLogin
+//   - at time.Time
+func (_e *Database_Expecter) SyncWorkspaceMemberSuggestions(workspaceUuid interface{}, logins interface{}, at interface{}) *Database_SyncWorkspaceMemberSuggestions_Call {
+	return &Database_SyncWorkspaceMemberSuggestions_Call{Call: _e.mock.On("SyncWorkspaceMemberSuggestions", workspaceUuid, logins, at)}
 }
 
-func (_c *Database_SearchTribes_Call) Run(run func(s string)) *Database_SearchTribes_Call {
+func (_c *Database_SyncWorkspaceMemberSuggestions_Call) Run(run func(workspaceUuid string, logins []db.GithubOrgLogin, at time.Time)) *Database_SyncWorkspaceMemberSuggestions_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(string), args[1].([]db.GithubOrgLogin), args[2].(time.Time))
 	})
 	return _c
 }
 
-func (_c *Database_SearchTribes_Call) Return(_a0 []db.Tribe) *Database_SearchTribes_Call {
+func (_c *Database_SyncWorkspaceMemberSuggestions_Call) Return(_a0 error) *Database_SyncWorkspaceMemberSuggestions_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_SearchTribes_Call) RunAndReturn(run func(string) []db.Tribe) *Database_SearchTribes_Call {
+func (_c *Database_SyncWorkspaceMemberSuggestions_Call) RunAndReturn(run func(string, []db.GithubOrgLogin, time.Time) error) *Database_SyncWorkspaceMemberSuggestions_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -7170,7 +19666,7 @@ func (_c *Database_UpdateBounty_Call) RunAndReturn(run func(db.NewBounty) (db.Ne
 }
 
 // UpdateBountyBoolColumn provides a mock function with given fields: b, column
-func (_m *Database) UpdateBountyBoolColumn(b db.NewBounty, column string) db.NewBounty {
+func (_m *Database) UpdateBountyBoolColumn(b db.NewBounty, column string) (db.NewBounty, error) {
 	ret := _m.Called(b, column)
 
 	if len(ret) == 0 {
@@ -7178,13 +19674,23 @@ func (_m *Database) UpdateBountyBoolColumn(b db.NewBounty, column string) db.New
 	}
 
 	var r0 db.NewBounty
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.NewBounty, string) (db.NewBounty, error)); ok {
+		return rf(b, column)
+	}
 	if rf, ok := ret.Get(0).(func(db.NewBounty, string) db.NewBounty); ok {
 		r0 = rf(b, column)
 	} else {
 		r0 = ret.Get(0).(db.NewBounty)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(db.NewBounty, string) error); ok {
+		r1 = rf(b, column)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // Database_UpdateBountyBoolColumn_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateBountyBoolColumn'
@@ -7206,18 +19712,74 @@ func (_c *Database_UpdateBountyBoolColumn_Call) Run(run func(b db.NewBounty, col
 	return _c
 }
 
-func (_c *Database_UpdateBountyBoolColumn_Call) Return(_a0 db.NewBounty) *Database_UpdateBountyBoolColumn_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_UpdateBountyBoolColumn_Call) Return(_a0 db.NewBounty, _a1 error) *Database_UpdateBountyBoolColumn_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_UpdateBountyBoolColumn_Call) RunAndReturn(run func(db.NewBounty, string) (db.NewBounty, error)) *Database_UpdateBountyBoolColumn_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateBountyCompleted provides a mock function with given fields: b
+func (_m *Database) UpdateBountyCompleted(b db.NewBounty) (db.NewBounty, error) {
+	ret := _m.Called(b)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateBountyCompleted")
+	}
+
+	var r0 db.NewBounty
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.NewBounty) (db.NewBounty, error)); ok {
+		return rf(b)
+	}
+	if rf, ok := ret.Get(0).(func(db.NewBounty) db.NewBounty); ok {
+		r0 = rf(b)
+	} else {
+		r0 = ret.Get(0).(db.NewBounty)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.NewBounty) error); ok {
+		r1 = rf(b)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_UpdateBountyCompleted_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateBountyCompleted'
+type Database_UpdateBountyCompleted_Call struct {
+	*mock.Call
+}
+
+// UpdateBountyCompleted is a helper method to define mock.On call
+//   - b db.NewBounty
+func (_e *Database_Expecter) UpdateBountyCompleted(b interface{}) *Database_UpdateBountyCompleted_Call {
+	return &Database_UpdateBountyCompleted_Call{Call: _e.mock.On("UpdateBountyCompleted", b)}
+}
+
+func (_c *Database_UpdateBountyCompleted_Call) Run(run func(b db.NewBounty)) *Database_UpdateBountyCompleted_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.NewBounty))
+	})
+	return _c
+}
+
+func (_c *Database_UpdateBountyCompleted_Call) Return(_a0 db.NewBounty, _a1 error) *Database_UpdateBountyCompleted_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_UpdateBountyBoolColumn_Call) RunAndReturn(run func(db.NewBounty, string) db.NewBounty) *Database_UpdateBountyBoolColumn_Call {
+func (_c *Database_UpdateBountyCompleted_Call) RunAndReturn(run func(db.NewBounty) (db.NewBounty, error)) *Database_UpdateBountyCompleted_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // UpdateBountyNullColumn provides a mock function with given fields: b, column
-func (_m *Database) UpdateBountyNullColumn(b db.NewBounty, column string) db.NewBounty {
+func (_m *Database) UpdateBountyNullColumn(b db.NewBounty, column string) (db.NewBounty, error) {
 	ret := _m.Called(b, column)
 
 	if len(ret) == 0 {
@@ -7225,13 +19787,23 @@ func (_m *Database) UpdateBountyNullColumn(b db.NewBounty, column string) db.New
 	}
 
 	var r0 db.NewBounty
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.NewBounty, string) (db.NewBounty, error)); ok {
+		return rf(b, column)
+	}
 	if rf, ok := ret.Get(0).(func(db.NewBounty, string) db.NewBounty); ok {
 		r0 = rf(b, column)
 	} else {
 		r0 = ret.Get(0).(db.NewBounty)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(db.NewBounty, string) error); ok {
+		r1 = rf(b, column)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // Database_UpdateBountyNullColumn_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateBountyNullColumn'
@@ -7253,12 +19825,12 @@ func (_c *Database_UpdateBountyNullColumn_Call) Run(run func(b db.NewBounty, col
 	return _c
 }
 
-func (_c *Database_UpdateBountyNullColumn_Call) Return(_a0 db.NewBounty) *Database_UpdateBountyNullColumn_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_UpdateBountyNullColumn_Call) Return(_a0 db.NewBounty, _a1 error) *Database_UpdateBountyNullColumn_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_UpdateBountyNullColumn_Call) RunAndReturn(run func(db.NewBounty, string) db.NewBounty) *Database_UpdateBountyNullColumn_Call {
+func (_c *Database_UpdateBountyNullColumn_Call) RunAndReturn(run func(db.NewBounty, string) (db.NewBounty, error)) *Database_UpdateBountyNullColumn_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -7366,6 +19938,118 @@ func (_c *Database_UpdateChannel_Call) RunAndReturn(run func(uint, map[string]in
 	return _c
 }
 
+// UpdateChat provides a mock function with given fields: chat
+func (_m *Database) UpdateChat(chat db.Chat) (db.Chat, error) {
+	ret := _m.Called(chat)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateChat")
+	}
+
+	var r0 db.Chat
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.Chat) (db.Chat, error)); ok {
+		return rf(chat)
+	}
+	if rf, ok := ret.Get(0).(func(db.Chat) db.Chat); ok {
+		r0 = rf(chat)
+	} else {
+		r0 = ret.Get(0).(db.Chat)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.Chat) error); ok {
+		r1 = rf(chat)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_UpdateChat_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateChat'
+type Database_UpdateChat_Call struct {
+	*mock.Call
+}
+
+// UpdateChat is a helper method to define mock.On call
+//   - chat db.Chat
+func (_e *Database_Expecter) UpdateChat(chat interface{}) *Database_UpdateChat_Call {
+	return &Database_UpdateChat_Call{Call: _e.mock.On("UpdateChat", chat)}
+}
+
+func (_c *Database_UpdateChat_Call) Run(run func(chat db.Chat)) *Database_UpdateChat_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.Chat))
+	})
+	return _c
+}
+
+func (_c *Database_UpdateChat_Call) Return(_a0 db.Chat, _a1 error) *Database_UpdateChat_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_UpdateChat_Call) RunAndReturn(run func(db.Chat) (db.Chat, error)) *Database_UpdateChat_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateChatMessage provides a mock function with given fields: message
+func (_m *Database) UpdateChatMessage(message db.ChatMessage) (db.ChatMessage, error) {
+	ret := _m.Called(message)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateChatMessage")
+	}
+
+	var r0 db.ChatMessage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.ChatMessage) (db.ChatMessage, error)); ok {
+		return rf(message)
+	}
+	if rf, ok := ret.Get(0).(func(db.ChatMessage) db.ChatMessage); ok {
+		r0 = rf(message)
+	} else {
+		r0 = ret.Get(0).(db.ChatMessage)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.ChatMessage) error); ok {
+		r1 = rf(message)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_UpdateChatMessage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateChatMessage'
+type Database_UpdateChatMessage_Call struct {
+	*mock.Call
+}
+
+// UpdateChatMessage is a helper method to define mock.On call
+//   - message db.ChatMessage
+func (_e *Database_Expecter) UpdateChatMessage(message interface{}) *Database_UpdateChatMessage_Call {
+	return &Database_UpdateChatMessage_Call{Call: _e.mock.On("UpdateChatMessage", message)}
+}
+
+func (_c *Database_UpdateChatMessage_Call) Run(run func(message db.ChatMessage)) *Database_UpdateChatMessage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.ChatMessage))
+	})
+	return _c
+}
+
+func (_c *Database_UpdateChatMessage_Call) Return(_a0 db.ChatMessage, _a1 error) *Database_UpdateChatMessage_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_UpdateChatMessage_Call) RunAndReturn(run func(db.ChatMessage) (db.ChatMessage, error)) *Database_UpdateChatMessage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdateGithubConfirmed provides a mock function with given fields: id, confirmed
 func (_m *Database) UpdateGithubConfirmed(id uint, confirmed bool) {
 	_m.Called(id, confirmed)
@@ -7435,21 +20119,31 @@ func (_c *Database_UpdateGithubIssues_Call) RunAndReturn(run func(uint, map[stri
 }
 
 // UpdateInvoice provides a mock function with given fields: payment_request
-func (_m *Database) UpdateInvoice(payment_request string) db.NewInvoiceList {
+func (_m *Database) UpdateInvoice(payment_request string) (bool, error) {
 	ret := _m.Called(payment_request)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UpdateInvoice")
 	}
 
-	var r0 db.NewInvoiceList
-	if rf, ok := ret.Get(0).(func(string) db.NewInvoiceList); ok {
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (bool, error)); ok {
+		return rf(payment_request)
+	}
+	if rf, ok := ret.Get(0).(func(string) bool); ok {
 		r0 = rf(payment_request)
 	} else {
-		r0 = ret.Get(0).(db.NewInvoiceList)
+		r0 = ret.Get(0).(bool)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(payment_request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // Database_UpdateInvoice_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateInvoice'
@@ -7470,12 +20164,12 @@ func (_c *Database_UpdateInvoice_Call) Run(run func(payment_request string)) *Da
 	return _c
 }
 
-func (_c *Database_UpdateInvoice_Call) Return(_a0 db.NewInvoiceList) *Database_UpdateInvoice_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_UpdateInvoice_Call) Return(_a0 bool, _a1 error) *Database_UpdateInvoice_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_UpdateInvoice_Call) RunAndReturn(run func(string) db.NewInvoiceList) *Database_UpdateInvoice_Call {
+func (_c *Database_UpdateInvoice_Call) RunAndReturn(run func(string) (bool, error)) *Database_UpdateInvoice_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -7690,6 +20384,70 @@ func (_c *Database_UpdateTwitterConfirmed_Call) RunAndReturn(run func(uint, bool
 	return _c
 }
 
+// UpdateVerifiedSkills provides a mock function with given fields: pubkey, threshold
+func (_m *Database) UpdateVerifiedSkills(pubkey string, threshold int) (db.Person, bool, error) {
+	ret := _m.Called(pubkey, threshold)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateVerifiedSkills")
+	}
+
+	var r0 db.Person
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(string, int) (db.Person, bool, error)); ok {
+		return rf(pubkey, threshold)
+	}
+	if rf, ok := ret.Get(0).(func(string, int) db.Person); ok {
+		r0 = rf(pubkey, threshold)
+	} else {
+		r0 = ret.Get(0).(db.Person)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, int) bool); ok {
+		r1 = rf(pubkey, threshold)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(string, int) error); ok {
+		r2 = rf(pubkey, threshold)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Database_UpdateVerifiedSkills_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateVerifiedSkills'
+type Database_UpdateVerifiedSkills_Call struct {
+	*mock.Call
+}
+
+// UpdateVerifiedSkills is a helper method to define mock.On call
+//   - pubkey string
+//   - threshold int
+func (_e *Database_Expecter) UpdateVerifiedSkills(pubkey interface{}, threshold interface{}) *Database_UpdateVerifiedSkills_Call {
+	return &Database_UpdateVerifiedSkills_Call{Call: _e.mock.On("UpdateVerifiedSkills", pubkey, threshold)}
+}
+
+func (_c *Database_UpdateVerifiedSkills_Call) Run(run func(pubkey string, threshold int)) *Database_UpdateVerifiedSkills_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *Database_UpdateVerifiedSkills_Call) Return(_a0 db.Person, _a1 bool, _a2 error) *Database_UpdateVerifiedSkills_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *Database_UpdateVerifiedSkills_Call) RunAndReturn(run func(string, int) (db.Person, bool, error)) *Database_UpdateVerifiedSkills_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdateWorkspaceBudget provides a mock function with given fields: budget
 func (_m *Database) UpdateWorkspaceBudget(budget db.NewBountyBudget) db.NewBountyBudget {
 	ret := _m.Called(budget)
@@ -7877,37 +20635,105 @@ func (_c *Database_UserHasManageBountyRoles_Call) RunAndReturn(run func(string,
 	return _c
 }
 
-// WithdrawBudget provides a mock function with given fields: sender_pubkey, workspace_uuid, amount
-func (_m *Database) WithdrawBudget(sender_pubkey string, workspace_uuid string, amount uint) {
-	_m.Called(sender_pubkey, workspace_uuid, amount)
+// VerifyWorkspace provides a mock function with given fields: uuid, method
+func (_m *Database) VerifyWorkspace(uuid string, method string) (db.Workspace, error) {
+	ret := _m.Called(uuid, method)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyWorkspace")
+	}
+
+	var r0 db.Workspace
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (db.Workspace, error)); ok {
+		return rf(uuid, method)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) db.Workspace); ok {
+		r0 = rf(uuid, method)
+	} else {
+		r0 = ret.Get(0).(db.Workspace)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(uuid, method)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_WithdrawBudget_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WithdrawBudget'
-type Database_WithdrawBudget_Call struct {
+// Database_VerifyWorkspace_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'VerifyWorkspace'
+type Database_VerifyWorkspace_Call struct {
 	*mock.Call
 }
 
-// WithdrawBudget is a helper method to define mock.On call
-//   - sender_pubkey string
-//   - workspace_uuid string
-//   - amount uint
-func (_e *Database_Expecter) WithdrawBudget(sender_pubkey interface{}, workspace_uuid interface{}, amount interface{}) *Database_WithdrawBudget_Call {
-	return &Database_WithdrawBudget_Call{Call: _e.mock.On("WithdrawBudget", sender_pubkey, workspace_uuid, amount)}
+// VerifyWorkspace is a helper method to define mock.On call
+//   - uuid string
+//   - method string
+func (_e *Database_Expecter) VerifyWorkspace(uuid interface{}, method interface{}) *Database_VerifyWorkspace_Call {
+	return &Database_VerifyWorkspace_Call{Call: _e.mock.On("VerifyWorkspace", uuid, method)}
+}
+
+func (_c *Database_VerifyWorkspace_Call) Run(run func(uuid string, method string)) *Database_VerifyWorkspace_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_VerifyWorkspace_Call) Return(_a0 db.Workspace, _a1 error) *Database_VerifyWorkspace_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_VerifyWorkspace_Call) RunAndReturn(run func(string, string) (db.Workspace, error)) *Database_VerifyWorkspace_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// WithTx provides a mock function with given fields: fn
+func (_m *Database) WithTx(fn func(db.Database) error) error {
+	ret := _m.Called(fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WithTx")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(func(db.Database) error) error); ok {
+		r0 = rf(fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_WithTx_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WithTx'
+type Database_WithTx_Call struct {
+	*mock.Call
 }
 
-func (_c *Database_WithdrawBudget_Call) Run(run func(sender_pubkey string, workspace_uuid string, amount uint)) *Database_WithdrawBudget_Call {
+// WithTx is a helper method to define mock.On call
+//   - fn func(db.Database) error
+func (_e *Database_Expecter) WithTx(fn interface{}) *Database_WithTx_Call {
+	return &Database_WithTx_Call{Call: _e.mock.On("WithTx", fn)}
+}
+
+func (_c *Database_WithTx_Call) Run(run func(fn func(db.Database) error)) *Database_WithTx_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string), args[2].(uint))
+		run(args[0].(func(db.Database) error))
 	})
 	return _c
 }
 
-func (_c *Database_WithdrawBudget_Call) Return() *Database_WithdrawBudget_Call {
-	_c.Call.Return()
+func (_c *Database_WithTx_Call) Return(_a0 error) *Database_WithTx_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_WithdrawBudget_Call) RunAndReturn(run func(string, string, uint)) *Database_WithdrawBudget_Call {
+func (_c *Database_WithTx_Call) RunAndReturn(run func(func(db.Database) error) error) *Database_WithTx_Call {
 	_c.Call.Return(run)
 	return _c
 }
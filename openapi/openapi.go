@@ -0,0 +1,209 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/go-chi/chi"
+)
+
+const securitySchemeName = "jwt"
+
+// Route holds the metadata of a registered route that can not be read
+// from the router itself, i.e the request and response bodies
+type Route struct {
+	Summary  string
+	Tags     []string
+	Query    []string
+	Request  interface{}
+	Response interface{}
+}
+
+var (
+	routes   = map[string]Route{}
+	routesMu sync.RWMutex
+
+	paramRegex = regexp.MustCompile(`\{([^}:]+)(:[^}]*)?\}`)
+)
+
+// Describe registers the metadata for a method and full route pattern
+func Describe(method string, pattern string, route Route) {
+	routesMu.Lock()
+	defer routesMu.Unlock()
+	routes[routeKey(method, pattern)] = route
+}
+
+func routeKey(method string, pattern string) string {
+	return strings.ToUpper(method) + " " + pattern
+}
+
+func lookup(method string, pattern string) (Route, bool) {
+	routesMu.RLock()
+	defer routesMu.RUnlock()
+	route, ok := routes[routeKey(method, pattern)]
+	return route, ok
+}
+
+// Generate walks the router and builds the spec, routes behind any of the
+// auth middlewares are marked as secured
+func Generate(router chi.Routes, info Info, authMiddlewares ...func(http.Handler) http.Handler) (Spec, error) {
+	spec := Spec{
+		OpenAPI: "3.0.3",
+		Info:    info,
+		Paths:   map[string]PathItem{},
+		Components: Components{
+			Schemas: map[string]*Schema{},
+			SecuritySchemes: map[string]SecurityScheme{
+				securitySchemeName: {Type: "apiKey", In: "header", Name: "x-jwt"},
+			},
+		},
+	}
+
+	authPointers := map[uintptr]bool{}
+	for _, mw := range authMiddlewares {
+		authPointers[reflect.ValueOf(mw).Pointer()] = true
+	}
+
+	err := chi.Walk(router, func(method string, pattern string, handler http.Handler, middlewares ...func(http.Handler) http.Handler) error {
+		pattern = cleanPattern(pattern)
+		path := paramRegex.ReplaceAllString(pattern, "{$1}")
+
+		op := &Operation{
+			OperationID: operationID(method, path),
+			Responses: map[string]Response{
+				"default": {
+					Description: "Error",
+					Content:     jsonContent(&Schema{Ref: "#/components/schemas/ErrorResponse"}),
+				},
+			},
+		}
+
+		for _, match := range paramRegex.FindAllStringSubmatch(pattern, -1) {
+			op.Parameters = append(op.Parameters, Parameter{
+				Name:     match[1],
+				In:       "path",
+				Required: true,
+				Schema:   &Schema{Type: "string"},
+			})
+		}
+
+		for _, mw := range middlewares {
+			if authPointers[reflect.ValueOf(mw).Pointer()] {
+				op.Security = []map[string][]string{{securitySchemeName: {}}}
+				break
+			}
+		}
+
+		success := Response{Description: "OK"}
+		if route, ok := lookup(method, pattern); ok {
+			op.Summary = route.Summary
+			op.Tags = route.Tags
+			for _, q := range route.Query {
+				op.Parameters = append(op.Parameters, Parameter{
+					Name:   q,
+					In:     "query",
+					Schema: &Schema{Type: "string"},
+				})
+			}
+			if route.Request != nil {
+				op.RequestBody = &RequestBody{
+					Required: true,
+					Content:  jsonContent(schemaFor(route.Request, spec.Components.Schemas)),
+				}
+			}
+			if route.Response != nil {
+				success.Content = jsonContent(schemaFor(route.Response, spec.Components.Schemas))
+			}
+		}
+		if len(op.Tags) == 0 {
+			op.Tags = []string{defaultTag(path)}
+		}
+		op.Responses["200"] = success
+
+		item, ok := spec.Paths[path]
+		if !ok {
+			item = PathItem{}
+			spec.Paths[path] = item
+		}
+		item[strings.ToLower(method)] = op
+		return nil
+	})
+
+	spec.Components.Schemas["ErrorResponse"] = &Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"code":       {Type: "string"},
+			"message":    {Type: "string"},
+			"details":    {Type: "object"},
+			"request_id": {Type: "string"},
+		},
+	}
+
+	return spec, err
+}
+
+// Handler serves the generated spec as json
+func Handler(router chi.Routes, info Info, authMiddlewares ...func(http.Handler) http.Handler) http.HandlerFunc {
+	return MountedHandler("", router, info, authMiddlewares...)
+}
+
+// MountedHandler serves the spec of a router mounted at basePath, the paths
+// stay relative to the router and basePath is listed as the server url
+func MountedHandler(basePath string, router chi.Routes, info Info, authMiddlewares ...func(http.Handler) http.Handler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		spec, err := Generate(router, info, authMiddlewares...)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode("Could not generate the api spec")
+			return
+		}
+		if basePath != "" {
+			spec.Servers = []Server{{URL: basePath}}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(spec)
+	}
+}
+
+func jsonContent(schema *Schema) map[string]MediaType {
+	return map[string]MediaType{"application/json": {Schema: schema}}
+}
+
+// cleanPattern removes the trailing slashes chi adds to mounted routes
+func cleanPattern(pattern string) string {
+	pattern = strings.Replace(pattern, "/*", "", -1)
+	if len(pattern) > 1 {
+		pattern = strings.TrimSuffix(pattern, "/")
+	}
+	if pattern == "" {
+		return "/"
+	}
+	return pattern
+}
+
+func defaultTag(path string) string {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if parts[0] == "" || strings.HasPrefix(parts[0], "{") {
+		return "default"
+	}
+	return parts[0]
+}
+
+func operationID(method string, path string) string {
+	id := strings.ToLower(method)
+	for _, part := range strings.Split(path, "/") {
+		part = strings.Trim(part, "{}")
+		if part == "" {
+			continue
+		}
+		part = strings.NewReplacer("-", "_", ".", "_").Replace(part)
+		id += "_" + part
+	}
+	return id
+}
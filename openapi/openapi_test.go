@@ -0,0 +1,106 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/stretchr/testify/assert"
+)
+
+type testOwner struct {
+	Pubkey string `json:"owner_pubkey"`
+}
+
+type testTribe struct {
+	testOwner
+	Uuid    string     `json:"uuid"`
+	Tags    []string   `json:"tags"`
+	Price   int64      `json:"price"`
+	Created *time.Time `json:"created"`
+	Parent  *testTribe `json:"parent,omitempty"`
+	Secret  string     `json:"-"`
+}
+
+func testAuth(next http.Handler) http.Handler {
+	return next
+}
+
+func noop(w http.ResponseWriter, r *http.Request) {}
+
+func testRouter() chi.Router {
+	tribes := chi.NewRouter()
+	tribes.Get("/", noop)
+	tribes.Get("/{uuid}", noop)
+	tribes.Group(func(r chi.Router) {
+		r.Use(testAuth)
+		r.Post("/", noop)
+	})
+
+	r := chi.NewRouter()
+	r.Mount("/tribes", tribes)
+	return r
+}
+
+func TestGenerate(t *testing.T) {
+	Describe(http.MethodGet, "/tribes/{uuid}", Route{Summary: "Get a tribe", Tags: []string{"tribes"}, Response: testTribe{}})
+	Describe(http.MethodPost, "/tribes", Route{Summary: "Create a tribe", Request: testTribe{}, Response: testTribe{}})
+	Describe(http.MethodGet, "/tribes", Route{Query: []string{"search"}, Response: []testTribe{}})
+
+	spec, err := Generate(testRouter(), Info{Title: "test", Version: "1"}, testAuth)
+	assert.NoError(t, err)
+
+	t.Run("should add path parameters and metadata", func(t *testing.T) {
+		op := spec.Paths["/tribes/{uuid}"]["get"]
+		assert.NotNil(t, op)
+		assert.Equal(t, "Get a tribe", op.Summary)
+		assert.Equal(t, "uuid", op.Parameters[0].Name)
+		assert.Equal(t, "path", op.Parameters[0].In)
+		assert.Empty(t, op.Security)
+		assert.Equal(t, "#/components/schemas/testTribe", op.Responses["200"].Content["application/json"].Schema.Ref)
+	})
+
+	t.Run("should mark routes behind auth middlewares as secured", func(t *testing.T) {
+		op := spec.Paths["/tribes"]["post"]
+		assert.NotNil(t, op)
+		assert.Len(t, op.Security, 1)
+		assert.NotNil(t, op.RequestBody)
+	})
+
+	t.Run("should add query parameters and default tags", func(t *testing.T) {
+		op := spec.Paths["/tribes"]["get"]
+		assert.Equal(t, "search", op.Parameters[0].Name)
+		assert.Equal(t, "query", op.Parameters[0].In)
+		assert.Equal(t, []string{"tribes"}, op.Tags)
+		assert.Equal(t, "array", op.Responses["200"].Content["application/json"].Schema.Type)
+	})
+
+	t.Run("should build struct schemas from json tags", func(t *testing.T) {
+		schema := spec.Components.Schemas["testTribe"]
+		assert.NotNil(t, schema)
+		assert.Contains(t, schema.Properties, "owner_pubkey")
+		assert.Contains(t, schema.Properties, "uuid")
+		assert.NotContains(t, schema.Properties, "Secret")
+		assert.NotContains(t, schema.Properties, "-")
+		assert.Equal(t, "date-time", schema.Properties["created"].Format)
+		assert.Equal(t, "int64", schema.Properties["price"].Format)
+		assert.Equal(t, "#/components/schemas/testTribe", schema.Properties["parent"].Ref)
+		assert.Contains(t, spec.Components.Schemas, "ErrorResponse")
+	})
+}
+
+func TestHandler(t *testing.T) {
+	rr := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/openapi.json", nil)
+
+	Handler(testRouter(), Info{Title: "test", Version: "1"}).ServeHTTP(rr, req)
+
+	var spec map[string]interface{}
+	err := json.Unmarshal(rr.Body.Bytes(), &spec)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "3.0.3", spec["openapi"])
+}
@@ -0,0 +1,70 @@
+package routes
+
+import (
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/handlers"
+	"github.com/stakwork/sphinx-tribes/search"
+)
+
+func AdminRoutes() chi.Router {
+	r := chi.NewRouter()
+	jobHandler := handlers.NewJobHandler(db.DB)
+	metricHandler := handlers.NewMetricHandler(db.DB)
+	eventHandler := handlers.NewEventHandler(db.DB)
+	moderationHandler := handlers.NewModerationHandler(db.DB)
+	searchHandler := handlers.NewSearchHandler(search.Default)
+	retentionHandler := handlers.NewRetentionHandler(db.DB)
+	deletedHandler := handlers.NewDeletedHandler(db.DB)
+	authHandler := handlers.NewAuthHandler(db.DB)
+	maintenanceHandler := handlers.NewMaintenanceHandler(db.DB)
+	quotaHandler := handlers.NewQuotaHandler(db.DB)
+	secretHandler := handlers.NewSecretHandler(db.DB)
+	pendingPaymentHandler := handlers.NewPendingPaymentHandler(db.DB)
+	r.Group(func(r chi.Router) {
+		r.Use(auth.PubKeyContextSuperAdmin)
+
+		r.Get("/jobs", jobHandler.GetJobs)
+		r.Get("/jobs/{uuid}", jobHandler.GetJob)
+		r.Post("/jobs/{uuid}/requeue", jobHandler.RequeueJob)
+
+		r.Get("/events", eventHandler.GetEvents)
+		r.Get("/events/stats", handlers.GetEventStats)
+
+		r.Get("/reports", moderationHandler.GetReports)
+		r.Post("/reports/{uuid}/resolve", moderationHandler.ResolveReport)
+		r.Get("/tribes/shadow_listed", moderationHandler.GetShadowListedTribes)
+		r.Post("/tribes/{uuid}/spam_review", moderationHandler.ReviewShadowListedTribe)
+		r.Get("/workspaces/flagged", moderationHandler.GetFlaggedWorkspaces)
+		r.Post("/workspaces/{uuid}/review", moderationHandler.ReviewFlaggedWorkspace)
+		r.Post("/categories", moderationHandler.CreateOrEditCategory)
+		r.Delete("/categories/{slug}", moderationHandler.DeleteCategory)
+
+		r.Post("/search/reindex", searchHandler.Reindex)
+
+		r.Get("/retention", retentionHandler.GetRetention)
+		r.Post("/retention/run", retentionHandler.RunRetention)
+
+		r.Get("/deleted/{kind}", deletedHandler.GetDeleted)
+		r.Post("/deleted/{kind}/{id}/restore", deletedHandler.RestoreDeleted)
+
+		r.Get("/config", handlers.GetConfig)
+		r.Post("/config/reload", handlers.ReloadConfig)
+
+		r.Get("/maintenance", maintenanceHandler.GetMaintenance)
+		r.Put("/maintenance", maintenanceHandler.SetMaintenance)
+
+		r.Put("/workspaces/{uuid}/plan", quotaHandler.SetWorkspacePlan)
+		r.Post("/secrets/rewrap", secretHandler.RewrapSecrets)
+
+		r.Get("/payments/pending", pendingPaymentHandler.GetPendingPayments)
+		r.Post("/payments/{id}/reconcile", pendingPaymentHandler.ReconcilePayment)
+
+		r.Get("/stats", metricHandler.GetPlatformStats)
+		r.Get("/connectioncodes/stats", authHandler.GetConnectionCodeStats)
+		r.Get("/cache/stats", handlers.GetReadCacheStats)
+		r.Get("/debug/query-plans", metricHandler.GetQueryPlans)
+	})
+	return r
+}
@@ -11,6 +11,7 @@ func BotsRoutes() chi.Router {
 	botHandler := handlers.NewBotHandler(db.DB)
 
 	r.Group(func(r chi.Router) {
+		r.Use(personParams)
 		r.Post("/", botHandler.CreateOrEditBot)
 		r.Get("/", botHandler.GetListedBots)
 		r.Get("/owner/{pubkey}", botHandler.GetBotsByOwner)
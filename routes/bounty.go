@@ -1,18 +1,24 @@
 package routes
 
 import (
-	"net/http"
-
 	"github.com/go-chi/chi"
 	"github.com/stakwork/sphinx-tribes/auth"
 	"github.com/stakwork/sphinx-tribes/db"
 	"github.com/stakwork/sphinx-tribes/handlers"
+	"github.com/stakwork/sphinx-tribes/httpio"
+	"github.com/stakwork/sphinx-tribes/upstream"
 )
 
 func BountyRoutes() chi.Router {
 	r := chi.NewRouter()
-	bountyHandler := handlers.NewBountyHandler(http.DefaultClient, db.DB)
+	bountyHandler := handlers.NewBountyHandler(upstream.Default, db.DB)
+	timeHandler := handlers.NewTimeHandler(db.DB)
+	proofHandler := handlers.NewProofHandler(db.DB)
+	termsHandler := handlers.NewTermsHandler(db.DB)
 	r.Group(func(r chi.Router) {
+		r.Use(httpio.SelectFields)
+		r.Use(httpio.RenderMarkdown("description"))
+
 		r.Get("/all", bountyHandler.GetAllBounties)
 
 		r.Get("/id/{bountyId}", bountyHandler.GetBountyById)
@@ -27,21 +33,44 @@ func BountyRoutes() chi.Router {
 		r.Get("/created/{created}", bountyHandler.GetBountyByCreated)
 		r.Get("/count/{personKey}/{tabType}", handlers.GetUserBountyCount)
 		r.Get("/count", handlers.GetBountyCount)
-		r.Get("/invoice/{paymentRequest}", bountyHandler.GetInvoiceData)
+		r.With(upstream.Require(upstream.Relay)).Get("/invoice/{paymentRequest}", bountyHandler.GetInvoiceData)
 		r.Get("/filter/count", handlers.GetFilterCount)
+		r.Get("/{id}/payment_proof", bountyHandler.GetBountyPaymentProof)
+		r.Get("/price_suggestion", bountyHandler.GetBountyPriceSuggestion)
+		r.Get("/facets", bountyHandler.GetBountyFacets)
 
 	})
 	r.Group(func(r chi.Router) {
 		r.Use(auth.PubKeyContext)
-		r.Post("/pay/{id}", bountyHandler.MakeBountyPayment)
-		r.Post("/budget/withdraw", bountyHandler.BountyBudgetWithdraw)
-		r.Post("/budget_workspace/withdraw", bountyHandler.NewBountyBudgetWithdraw)
+		r.Use(personParams)
+		r.With(upstream.Require(upstream.Relay), httpio.Untimed).Post("/pay/{id}", bountyHandler.MakeBountyPayment)
+		r.With(upstream.Require(upstream.Relay), httpio.Untimed).Post("/budget/withdraw", bountyHandler.BountyBudgetWithdraw)
+		r.With(upstream.Require(upstream.Relay), httpio.Untimed).Post("/budget_workspace/withdraw", bountyHandler.NewBountyBudgetWithdraw)
 
 		r.Post("/", bountyHandler.CreateOrEditBounty)
+		r.Post("/generate_description", bountyHandler.GenerateBountyDescription)
+		r.Post("/generate_description/preview", bountyHandler.PreviewBountyDescription)
 		r.Delete("/assignee", handlers.DeleteBountyAssignee)
 		r.Delete("/{pubkey}/{created}", bountyHandler.DeleteBounty)
 		r.Post("/paymentstatus/{created}", handlers.UpdatePaymentStatus)
-		r.Post("/completedstatus/{created}", handlers.UpdateCompletedStatus)
+		r.With(httpio.Untimed).Post("/completedstatus/{created}", bountyHandler.UpdateCompletedStatus)
+
+		r.Put("/{id}/move", bountyHandler.MoveTicket)
+
+		r.Post("/{id}/time/start", timeHandler.StartTimer)
+		r.Post("/{id}/time/stop", timeHandler.StopTimer)
+		r.Post("/{id}/time", timeHandler.AddTimeEntry)
+		r.Get("/{id}/time", timeHandler.GetBountyTimeEntries)
+		r.Delete("/{id}/time/{entry_uuid}", timeHandler.DeleteTimeEntry)
+
+		r.Post("/{id}/proofs", proofHandler.SubmitBountyProof)
+		r.Get("/{id}/proofs", proofHandler.GetBountyProofs)
+		r.Post("/{id}/proofs/{proof_uuid}/review", proofHandler.ReviewBountyProof)
+
+		r.Get("/{id}/terms", termsHandler.GetBountyTerms)
+		r.Post("/{id}/terms/accept", termsHandler.AcceptBountyTerms)
+		r.Get("/{id}/contact", termsHandler.GetBountyContact)
+		r.Put("/{id}/contact", termsHandler.SetBountyContactConsent)
 	})
 	return r
 }
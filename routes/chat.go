@@ -0,0 +1,30 @@
+package routes
+
+import (
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/handlers"
+	"github.com/stakwork/sphinx-tribes/upstream"
+)
+
+func ChatRoutes() chi.Router {
+	r := chi.NewRouter()
+	chatHandler := handlers.NewChatHandler(upstream.Default, db.DB)
+	r.Group(func(r chi.Router) {
+		r.Use(auth.StakworkWebhook)
+		r.Post("/response", chatHandler.ProcessChatResponse)
+	})
+
+	r.Group(func(r chi.Router) {
+		r.Use(auth.PubKeyContext)
+
+		r.Post("/", chatHandler.CreateChat)
+		r.Get("/", chatHandler.GetChatsForWorkspace)
+		r.Get("/{uuid}", chatHandler.GetChat)
+		r.Put("/{uuid}", chatHandler.UpdateChat)
+		r.Get("/history/{uuid}", chatHandler.GetChatHistory)
+		r.Post("/send", chatHandler.SendMessage)
+	})
+	return r
+}
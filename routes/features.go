@@ -10,6 +10,10 @@ import (
 func FeatureRoutes() chi.Router {
 	r := chi.NewRouter()
 	featureHandlers := handlers.NewFeatureHandler(&db.DB)
+	r.Group(func(r chi.Router) {
+		// the signed links of the exports, for people without an account
+		r.Get("/{feature_uuid}/phase/{phase_uuid}/export/shared", featureHandlers.ExportPhase)
+	})
 	r.Group(func(r chi.Router) {
 		r.Use(auth.PubKeyContext)
 
@@ -19,11 +23,20 @@ func FeatureRoutes() chi.Router {
 		r.Get("/forworkspace/{workspace_uuid}", featureHandlers.GetFeaturesByWorkspaceUuid)
 		r.Get("/workspace/count/{uuid}", featureHandlers.GetWorkspaceFeaturesCount)
 		r.Delete("/{uuid}", featureHandlers.DeleteFeature)
+		r.Put("/{uuid}/budget", featureHandlers.AllocateFeatureBudget)
+		r.Get("/{uuid}/burndown", featureHandlers.GetFeatureBurndown)
+		r.Put("/{feature_uuid}/reviewers", featureHandlers.SetFeatureReviewers)
 
 		r.Post("/phase", featureHandlers.CreateOrEditFeaturePhase)
 		r.Get("/{feature_uuid}/phase", featureHandlers.GetFeaturePhases)
 		r.Get("/{feature_uuid}/phase/{phase_uuid}", featureHandlers.GetFeaturePhaseByUUID)
 		r.Delete("/{feature_uuid}/phase/{phase_uuid}", featureHandlers.DeleteFeaturePhase)
+		r.Put("/{feature_uuid}/phase/{phase_uuid}/dependency", featureHandlers.SetPhaseDependency)
+		r.Get("/{feature_uuid}/phase/{phase_uuid}/gate", featureHandlers.GetPhaseGate)
+		r.Put("/{feature_uuid}/phase/{phase_uuid}/gate", featureHandlers.OverridePhaseGate)
+		r.Put("/{feature_uuid}/phase/{phase_uuid}/tickets/status", featureHandlers.SetTicketStatuses)
+		r.Get("/{feature_uuid}/phase/{phase_uuid}/export", featureHandlers.ExportPhase)
+		r.Post("/{feature_uuid}/phase/{phase_uuid}/export/link", featureHandlers.CreatePhaseExportLink)
 
 		r.Post("/story", featureHandlers.CreateOrEditStory)
 		r.Get("/{feature_uuid}/story", featureHandlers.GetStoriesByFeatureUuid)
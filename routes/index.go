@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"io"
 	"net/http"
-	"os"
 	"time"
 
 	"github.com/go-chi/chi"
@@ -13,18 +12,138 @@ import (
 	"github.com/rs/cors"
 
 	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/config"
 	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/gql"
 	"github.com/stakwork/sphinx-tribes/handlers"
+	"github.com/stakwork/sphinx-tribes/httpio"
+	"github.com/stakwork/sphinx-tribes/openapi"
+	"github.com/stakwork/sphinx-tribes/search"
+	"github.com/stakwork/sphinx-tribes/tracing"
+	"github.com/stakwork/sphinx-tribes/upstream"
+	"github.com/stakwork/sphinx-tribes/utils"
 )
 
+// request limits of the route groups. The upload routes proxy images to the
+// meme server and take the chunks of resumable uploads, they get a larger
+// allowance, streams are long lived and have no timeout
+const (
+	maxBodySize       = 1 << 20
+	maxUploadBodySize = 10 << 20
+	routeTimeout      = 60 * time.Second
+	uploadTimeout     = 2 * time.Minute
+)
+
+// how long browsers and CDNs may keep the reads that rarely change, they
+// revalidate with the ETag afterwards
+const (
+	feedCacheAge        = 10 * time.Minute
+	tribeCacheAge       = time.Minute
+	leaderboardCacheAge = 5 * time.Minute
+	widgetCacheAge      = 5 * time.Minute
+)
+
+// the tribe directory is served from the read cache, each client still
+// gets only tribeDirectoryRateLimit pages of it a minute so crawling it
+// with new query strings doesn't reach the database on every request
+const tribeDirectoryRateLimit = 120
+
 // NewRouter creates a chi router
 func NewRouter() *http.Server {
 	r := initChi()
+
+	describeRoutes()
+
+	// the unversioned routes are v1, v2 serves the same handlers with the
+	// error and list envelopes and bearer auth applied to every route. The
+	// routes are built once and mounted under both, so the handlers share
+	// their locks and the rate limits count the requests of both versions
+	api := apiRoutes()
+	r.Mount("/v2", versionRoutes(api, "/v2", "2.0.0", httpio.EnvelopeErrors, auth.BearerToken, utils.ListEnvelope))
+	r.Mount("/", versionRoutes(api, "", "1.0.0", deprecatedV1))
+
+	// clients get 10s to send their headers and the upload timeout for the
+	// whole request, so slow clients can't hold connections open
+	server := &http.Server{
+		Addr:              ":" + config.Get().Port,
+		Handler:           r,
+		ReadHeaderTimeout: 10 * time.Second,
+		ReadTimeout:       uploadTimeout,
+		IdleTimeout:       2 * time.Minute,
+	}
+
+	go func() {
+		fmt.Println("Listening on port " + config.Get().Port)
+		if err := server.ListenAndServe(); err != nil {
+			fmt.Println("server err:", err.Error())
+		}
+	}()
+
+	return server
+}
+
+// versionRoutes serves the api routes at basePath with the middlewares of
+// an api version, and the api spec of that version
+func versionRoutes(api chi.Router, basePath string, version string, middlewares ...func(http.Handler) http.Handler) chi.Router {
+	r := chi.NewRouter()
+	r.Use(middlewares...)
+	r.Get("/openapi.json", openapi.MountedHandler(basePath, r, openapi.Info{Title: "Sphinx Tribes API", Version: version},
+		auth.PubKeyContext, auth.PubKeyContextSuperAdmin, auth.ConnectionCodeContext, auth.CypressContext))
+	r.Mount("/", api)
+	return r
+}
+
+// apiRoutes registers every api route on a new router
+func apiRoutes() chi.Router {
+	r := chi.NewRouter()
+	r.Use(httpio.ReadOnlyDuringMaintenance)
+
+	r.Group(func(r chi.Router) {
+		r.Use(httpio.MaxBodySize(maxBodySize), httpio.Timeout(routeTimeout))
+		defaultRoutes(r)
+	})
+
+	r.Group(func(r chi.Router) {
+		r.Use(httpio.MaxBodySize(maxUploadBodySize), httpio.Timeout(uploadTimeout), auth.PubKeyContext)
+		r.Post("/meme_upload", handlers.MemeImageUpload)
+		r.Post("/images/{kind}", handlers.NewImageHandler().UploadImage)
+
+		uploadHandler := handlers.NewUploadHandler(db.DB)
+		r.Post("/uploads", uploadHandler.CreateUpload)
+		r.Get("/uploads/{uuid}", uploadHandler.GetUpload)
+		r.Head("/uploads/{uuid}", uploadHandler.HeadUpload)
+		r.Patch("/uploads/{uuid}", uploadHandler.PatchUpload)
+		r.Delete("/uploads/{uuid}", uploadHandler.DeleteUpload)
+	})
+
+	r.Group(func(r chi.Router) {
+		r.Get("/websocket", handlers.HandleWebSocket)
+		r.Get("/events", handlers.StreamEvents)
+	})
+
+	return r
+}
+
+// defaultRoutes are the routes that share the default body size and timeout
+func defaultRoutes(r chi.Router) {
 	tribeHandlers := handlers.NewTribeHandler(db.DB)
 	authHandler := handlers.NewAuthHandler(db.DB)
 	channelHandler := handlers.NewChannelHandler(db.DB)
 	botHandler := handlers.NewBotHandler(db.DB)
-	bHandler := handlers.NewBountyHandler(http.DefaultClient, db.DB)
+	bHandler := handlers.NewBountyHandler(upstream.Default, db.DB)
+	moderationHandler := handlers.NewModerationHandler(db.DB)
+	eventHandler := handlers.NewEventHandler(db.DB)
+	mentionHandler := handlers.NewMentionHandler(db.DB)
+	certificateHandler := handlers.NewCertificateHandler(db.DB)
+	timeHandler := handlers.NewTimeHandler(db.DB)
+	notificationHandler := handlers.NewNotificationHandler(db.DB)
+	searchHandler := handlers.NewSearchHandler(search.Default)
+	batchHandler := handlers.NewBatchHandler(db.DB)
+	syncHandler := handlers.NewSyncHandler(db.DB)
+	graphqlHandler := gql.NewGraphqlHandler(db.DB)
+	healthHandler := handlers.NewHealthHandler(upstream.Default)
+	recommendationHandler := handlers.NewRecommendationHandler(db.DB)
+	widgetHandler := handlers.NewWidgetHandler(db.DB)
 
 	r.Mount("/tribes", TribeRoutes())
 	r.Mount("/bots", BotsRoutes())
@@ -37,16 +156,25 @@ func NewRouter() *http.Server {
 	r.Mount("/workspaces", WorkspaceRoutes())
 	r.Mount("/metrics", MetricsRoutes())
 	r.Mount("/features", FeatureRoutes())
+	r.Mount("/quests", QuestRoutes())
+	r.Mount("/public", PublicRoutes())
+	r.Mount("/hivechat", ChatRoutes())
+	r.Mount("/admin", AdminRoutes())
 
 	r.Group(func(r chi.Router) {
-		r.Get("/tribe_by_feed", tribeHandlers.GetFirstTribeByFeed)
-		r.Get("/leaderboard/{tribe_uuid}", handlers.GetLeaderBoard)
-		r.Get("/tribe_by_un/{un}", tribeHandlers.GetTribeByUniqueName)
-		r.Get("/tribes_by_owner/{pubkey}", tribeHandlers.GetTribesByOwner)
+		r.With(httpio.Cacheable(tribeCacheAge)).Get("/tribe_by_feed", tribeHandlers.GetFirstTribeByFeed)
+		r.With(tribeParams, httpio.Cacheable(leaderboardCacheAge)).Get("/leaderboard/{tribe_uuid}", handlers.GetLeaderBoard)
+		r.With(httpio.Cacheable(tribeCacheAge)).Get("/tribe_by_un/{un}", tribeHandlers.GetTribeByUniqueName)
+		r.With(personParams).Get("/tribes_by_owner/{pubkey}", tribeHandlers.GetTribesByOwner)
+		r.With(httpio.Cacheable(widgetCacheAge)).Get("/widget/workspaces/{uuid}/bounties", widgetHandler.GetWidgetBounties)
+		r.With(httpio.Cacheable(feedCacheAge)).Get("/bounty_certificates/key", certificateHandler.GetCertificateKey)
+		r.Get("/bounty_certificates/{uuid}", certificateHandler.GetBountyCertificate)
 
 		r.Get("/search/bots/{query}", botHandler.SearchBots)
-		r.Get("/podcast", handlers.GetPodcast)
-		r.Get("/feed", handlers.GetGenericFeed)
+		r.Get("/search/{index}", searchHandler.Search)
+		r.Post("/batch/get", batchHandler.BatchGet)
+		r.With(httpio.Cacheable(feedCacheAge)).Get("/podcast", handlers.GetPodcast)
+		r.With(httpio.Cacheable(feedCacheAge)).Get("/feed", handlers.GetGenericFeed)
 		r.Post("/feed/download", handlers.DownloadYoutubeFeed)
 		r.Get("/search_podcasts", handlers.SearchPodcasts)
 		r.Get("/search_podcast_episodes", handlers.SearchPodcastEpisodes)
@@ -54,17 +182,19 @@ func NewRouter() *http.Server {
 		r.Get("/search_youtube_videos", handlers.SearchYoutubeVideos)
 		r.Get("/youtube_videos", handlers.YoutubeVideosForChannel)
 		r.Get("/admin_pubkeys", handlers.GetAdminPubkeys)
+		r.Get("/health", healthHandler.GetHealth)
 
 		r.Get("/ask", db.Ask)
 		r.Get("/poll/{challenge}", db.Poll)
 		r.Post("/save", db.PostSave)
 		r.Get("/save/{key}", db.PollSave)
-		r.Get("/websocket", handlers.HandleWebSocket)
-		r.Get("/migrate_bounties", handlers.MigrateBounties)
+		r.Get("/graphql", graphqlHandler.ServeHTTP)
+		r.Post("/graphql", graphqlHandler.ServeHTTP)
 	})
 
 	r.Group(func(r chi.Router) {
 		r.Use(auth.PubKeyContext)
+		r.Use(tribeParams)
 		r.Post("/channel", channelHandler.CreateChannel)
 		r.Post("/leaderboard/{tribe_uuid}", handlers.CreateLeaderBoard)
 		r.Put("/leaderboard/{tribe_uuid}", handlers.UpdateLeaderBoard)
@@ -77,34 +207,46 @@ func NewRouter() *http.Server {
 		r.Post("/badges", handlers.AddOrRemoveBadge)
 		r.Delete("/channel/{id}", channelHandler.DeleteChannel)
 		r.Delete("/ticket/{pubKey}/{created}", handlers.DeleteTicketByAdmin)
-		r.Get("/poll/invoice/{paymentRequest}", bHandler.PollInvoice)
-		r.Post("/meme_upload", handlers.MemeImageUpload)
+		r.With(upstream.Require(upstream.Relay), httpio.Untimed).Get("/poll/invoice/{paymentRequest}", bHandler.PollInvoice)
 		r.Get("/admin/auth", authHandler.GetIsAdmin)
+		r.Post("/report", moderationHandler.CreateReport)
+		r.Post("/tribe/{uuid}/appeal", moderationHandler.AppealTribe)
+		r.Get("/me/activity", eventHandler.GetMyActivity)
+		r.Get("/me/time", timeHandler.GetMyTime)
+		r.Get("/me/recommended_bounties", recommendationHandler.GetRecommendedBounties)
+		r.Get("/me/bounty_certificates", certificateHandler.GetMyBountyCertificates)
+		r.Get("/mentions/{type}/{id}", mentionHandler.GetMentionsOf)
+		r.Get("/me/notifications", notificationHandler.GetNotifications)
+		r.Put("/me/notifications/read_all", notificationHandler.ReadAllNotifications)
+		r.Put("/me/notifications/{id}/read", notificationHandler.ReadNotification)
+		r.Get("/sync", syncHandler.Sync)
 	})
 
 	r.Group(func(r chi.Router) {
 		r.Get("/lnauth_login", handlers.ReceiveLnAuthData)
 		r.Get("/lnauth", handlers.GetLnurlAuth)
 		r.Get("/refresh_jwt", authHandler.RefreshToken)
-		r.Post("/invoices", handlers.GenerateInvoice)
-		r.Post("/budgetinvoices", tribeHandlers.GenerateBudgetInvoice)
+		r.With(upstream.Require(upstream.Relay)).Post("/invoices", handlers.GenerateInvoice)
+		r.With(upstream.Require(upstream.Relay)).Post("/budgetinvoices", tribeHandlers.GenerateBudgetInvoice)
 	})
+}
 
-	PORT := os.Getenv("PORT")
-	if PORT == "" {
-		PORT = "5002"
-	}
-
-	server := &http.Server{Addr: ":" + PORT, Handler: r}
-
-	go func() {
-		fmt.Println("Listening on port " + PORT)
-		if err := server.ListenAndServe(); err != nil {
-			fmt.Println("server err:", err.Error())
+// deprecatedV1 announces the deprecation of the unversioned routes and
+// points clients to the same route under /v2
+func deprecatedV1(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cfg := config.Get()
+		if cfg.ApiV1DeprecatedAt.IsZero() {
+			w.Header().Set("Deprecation", "true")
+		} else {
+			w.Header().Set("Deprecation", fmt.Sprintf("@%d", cfg.ApiV1DeprecatedAt.Unix()))
 		}
-	}()
-
-	return server
+		if !cfg.ApiV1Sunset.IsZero() {
+			w.Header().Set("Sunset", cfg.ApiV1Sunset.UTC().Format(http.TimeFormat))
+		}
+		w.Header().Set("Link", fmt.Sprintf("</v2%s>; rel=\"successor-version\"", r.URL.Path))
+		next.ServeHTTP(w, r)
+	})
 }
 
 type extractResponse struct {
@@ -141,16 +283,17 @@ func getFromAuth(path string) (*extractResponse, error) {
 func initChi() *chi.Mux {
 	r := chi.NewRouter()
 	r.Use(middleware.RequestID)
+	r.Use(tracing.Middleware)
 	r.Use(middleware.Logger)
 	r.Use(middleware.Recoverer)
 	cors := cors.New(cors.Options{
 		AllowedOrigins:   []string{"*"},
-		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token", "X-User", "authorization", "x-jwt", "Referer", "User-Agent"},
+		AllowedMethods:   []string{"GET", "HEAD", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token", "X-User", "authorization", "x-jwt", "Referer", "User-Agent", "Upload-Offset", "Upload-Checksum"},
+		ExposedHeaders:   []string{"Location", "Upload-Offset", "Upload-Length", "Upload-Expires"},
 		AllowCredentials: true,
 		MaxAge:           300,
 	})
 	r.Use(cors.Handler)
-	r.Use(middleware.Timeout(60 * time.Second))
 	return r
 }
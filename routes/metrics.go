@@ -1,15 +1,28 @@
 package routes
 
 import (
+	"time"
+
 	"github.com/go-chi/chi"
 	"github.com/stakwork/sphinx-tribes/auth"
 	"github.com/stakwork/sphinx-tribes/db"
 	"github.com/stakwork/sphinx-tribes/handlers"
+	"github.com/stakwork/sphinx-tribes/httpio"
+)
+
+// the public metrics are open to ecosystem dashboards, each client gets
+// publicMetricsRateLimit requests a minute and CDNs keep them for
+// publicMetricsCacheAge
+const (
+	publicMetricsRateLimit = 30
+	publicMetricsCacheAge  = 10 * time.Minute
 )
 
 func MetricsRoutes() chi.Router {
 	r := chi.NewRouter()
 	mh := handlers.NewMetricHandler(db.DB)
+	r.With(httpio.RateLimit(publicMetricsRateLimit, time.Minute), httpio.Cacheable(publicMetricsCacheAge)).Get("/public", mh.GetPublicMetrics)
+
 	r.Group(func(r chi.Router) {
 		r.Use(auth.PubKeyContextSuperAdmin)
 
@@ -23,6 +36,7 @@ func MetricsRoutes() chi.Router {
 		r.Post("/bounties/count", mh.MetricsBountiesCount)
 		r.Post("/bounties/providers", mh.MetricsBountiesProviders)
 		r.Post("/csv", handlers.MetricsCsv)
+		r.Get("/db", mh.GetPoolStats)
 	})
 	return r
 }
@@ -0,0 +1,255 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/stakwork/sphinx-tribes/credentials"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/handlers"
+	"github.com/stakwork/sphinx-tribes/openapi"
+)
+
+var paginationQuery = []string{"page", "limit", "sortBy", "direction", "search", "cursor"}
+
+// the bounty lists also take sort=popular, most viewed first
+var bountyListQuery = []string{"page", "limit", "sortBy", "direction", "search", "cursor", "sort"}
+
+// describeRoutes adds the request and response bodies of the public api to the
+// generated spec, every registered route is listed even if it is not described here
+func describeRoutes() {
+	// tribes
+	openapi.Describe(http.MethodGet, "/tribes", openapi.Route{Summary: "List listed tribes, by page or by cursor with after", Query: append(paginationQuery, "after", "categories", "fields", "render"), Response: []db.Tribe{}})
+	openapi.Describe(http.MethodGet, "/tribes/categories", openapi.Route{Summary: "Curated category tree tribes are filed under", Response: []db.Category{}})
+	openapi.Describe(http.MethodPost, "/tribes", openapi.Route{Summary: "Create or edit a tribe", Request: db.Tribe{}, Response: db.Tribe{}})
+	openapi.Describe(http.MethodGet, "/tribes/{uuid}", openapi.Route{Summary: "Get a tribe", Query: []string{"fields", "render"}, Response: db.Tribe{}})
+	openapi.Describe(http.MethodGet, "/tribes/{uuid}/invite_meta", openapi.Route{Summary: "OpenGraph metadata and a signed deep link to join a tribe", Response: db.TribeInviteMeta{}})
+	openapi.Describe(http.MethodGet, "/tribes/{uuid}/announcements", openapi.Route{Summary: "Announcements of a tribe, pinned or not", Response: []db.TribeAnnouncement{}})
+	openapi.Describe(http.MethodPost, "/tribes/{uuid}/announcements", openapi.Route{Summary: "Publish or edit an announcement of a tribe", Request: db.TribeAnnouncement{}, Response: db.TribeAnnouncement{}})
+	openapi.Describe(http.MethodGet, "/tribes/{uuid}/events", openapi.Route{Summary: "Events of a tribe, the next to start first", Response: []db.TribeEvent{}})
+	openapi.Describe(http.MethodGet, "/tribes/{uuid}/activity", openapi.Route{Summary: "Bounties, tickets, channels and payments of a tribe as one timeline, newest first", Query: []string{"types", "page", "limit", "cursor"}, Response: []db.TribeActivity{}})
+	openapi.Describe(http.MethodPost, "/tribes/{uuid}/events", openapi.Route{Summary: "Schedule or edit an event of a tribe", Request: db.TribeEvent{}, Response: db.TribeEvent{}})
+	openapi.Describe(http.MethodGet, "/tribes/{uuid}/updates.atom", openapi.Route{Summary: "Atom feed of the announcements and events of a tribe"})
+	openapi.Describe(http.MethodGet, "/tribes/{uuid}/preview", openapi.Route{Summary: "Status and open graph metadata of the preview card of a tribe", Response: db.TribePreview{}})
+	openapi.Describe(http.MethodGet, "/tribes/{uuid}/preview.svg", openapi.Route{Summary: "Preview card of a tribe, built from its app url"})
+	openapi.Describe(http.MethodDelete, "/tribes/{uuid}/announcements/{announcement_uuid}", openapi.Route{Summary: "Delete an announcement of a tribe", Response: true})
+	openapi.Describe(http.MethodDelete, "/tribes/{uuid}/events/{event_uuid}", openapi.Route{Summary: "Cancel an event of a tribe", Response: true})
+	openapi.Describe(http.MethodPost, "/tribes/{uuid}/join", openapi.Route{Summary: "Join a tribe, with an optional alias", Request: db.TribeMember{}, Response: db.TribeMember{}})
+	openapi.Describe(http.MethodPost, "/tribes/{uuid}/leave", openapi.Route{Summary: "Leave a tribe", Response: true})
+	openapi.Describe(http.MethodGet, "/tribes/{uuid}/members", openapi.Route{Summary: "Members of a tribe, the earliest to join first", Query: []string{"page", "limit"}, Response: []db.TribeMember{}})
+	openapi.Describe(http.MethodDelete, "/tribes/{uuid}/members/{pubkey}", openapi.Route{Summary: "Kick a member out of a tribe, the owner only", Response: true})
+	openapi.Describe(http.MethodGet, "/tribes/{uuid}/webhooks", openapi.Route{Summary: "Webhooks of a tribe, the owner only", Response: []db.TribeWebhook{}})
+	openapi.Describe(http.MethodPost, "/tribes/{uuid}/webhooks", openapi.Route{Summary: "Register a webhook posted the lifecycle events of a tribe, its secret is returned once", Request: db.TribeWebhookRequest{}, Response: db.TribeWebhook{}})
+	openapi.Describe(http.MethodDelete, "/tribes/{uuid}/webhooks/{webhook_uuid}", openapi.Route{Summary: "Delete a webhook of a tribe", Response: true})
+	openapi.Describe(http.MethodGet, "/tribes/total", openapi.Route{Summary: "Count of all tribes", Response: int64(0)})
+	openapi.Describe(http.MethodGet, "/tribes/app_url/{app_url}", openapi.Route{Summary: "Tribes for an app url", Response: []db.Tribe{}})
+	openapi.Describe(http.MethodGet, "/tribe_by_un/{un}", openapi.Route{Summary: "Get a tribe by unique name", Tags: []string{"tribes"}, Response: db.Tribe{}})
+	openapi.Describe(http.MethodGet, "/tribes_by_owner/{pubkey}", openapi.Route{Summary: "Tribes owned by a pubkey", Tags: []string{"tribes"}, Response: []db.Tribe{}})
+	openapi.Describe(http.MethodPut, "/tribe", openapi.Route{Summary: "Create or edit a tribe", Tags: []string{"tribes"}, Request: db.Tribe{}, Response: db.Tribe{}})
+	openapi.Describe(http.MethodPut, "/tribepreview/{uuid}", openapi.Route{Summary: "Build the preview card of a tribe from its app url, in the background", Tags: []string{"tribes"}, Response: db.TribePreview{}})
+	openapi.Describe(http.MethodDelete, "/tribe/{uuid}", openapi.Route{Summary: "Delete a tribe", Tags: []string{"tribes"}, Response: true})
+
+	// people
+	openapi.Describe(http.MethodGet, "/people", openapi.Route{Summary: "List people", Query: append(paginationQuery, "fields", "languages", "verified", "region", "tz_overlap", "tz"), Response: []db.Person{}})
+	openapi.Describe(http.MethodGet, "/people/search", openapi.Route{Summary: "Search people", Query: append(paginationQuery, "region", "tz_overlap", "tz"), Response: []db.Person{}})
+	openapi.Describe(http.MethodGet, "/people/wanteds/assigned/{uuid}", openapi.Route{Summary: "Bounties assigned to a person", Query: bountyListQuery, Response: []db.BountyResponse{}})
+	openapi.Describe(http.MethodGet, "/people/wanteds/created/{uuid}", openapi.Route{Summary: "Bounties created by a person", Query: bountyListQuery, Response: []db.BountyResponse{}})
+	openapi.Describe(http.MethodPost, "/people/{pubkey}/tip", openapi.Route{Summary: "Tip a person from a workspace budget or with an invoice", Request: db.TipRequest{}, Response: db.TipResponse{}})
+	openapi.Describe(http.MethodPost, "/people/{pubkey}/endorsements", openapi.Route{Summary: "Endorse a person for a skill, once per pair window", Request: db.PersonEndorsement{}, Response: db.PersonEndorsement{}})
+	openapi.Describe(http.MethodGet, "/people/{pubkey}/reputation", openapi.Route{Summary: "Reputation of a person and the endorsements behind it", Response: db.Reputation{}})
+	openapi.Describe(http.MethodGet, "/people/bounty/leaderboard", openapi.Route{Summary: "Bounty hunters by sats earned, or by reputation", Query: []string{"sort"}, Response: []db.LeaderData{}})
+	openapi.Describe(http.MethodGet, "/person/{pubkey}", openapi.Route{Summary: "Get a person by pubkey", Query: []string{"fields"}, Response: db.Person{}})
+	openapi.Describe(http.MethodGet, "/person/uuid/{uuid}", openapi.Route{Summary: "Get a person by uuid", Query: []string{"fields"}, Response: db.Person{}})
+	openapi.Describe(http.MethodPost, "/person", openapi.Route{Summary: "Create or edit a person", Request: db.Person{}, Response: db.Person{}})
+	openapi.Describe(http.MethodDelete, "/person/{id}", openapi.Route{Summary: "Delete a person"})
+
+	// bounties
+	openapi.Describe(http.MethodGet, "/gobounties/all", openapi.Route{Summary: "List bounties", Query: append(bountyListQuery, "Open", "Assigned", "Paid", "languages", "fields", "render"), Response: []db.BountyResponse{}})
+	openapi.Describe(http.MethodGet, "/gobounties/id/{bountyId}", openapi.Route{Summary: "Get a bounty, counted as a view", Response: []db.BountyResponse{}})
+	openapi.Describe(http.MethodGet, "/gobounties/price_suggestion", openapi.Route{Summary: "Suggested price range of a bounty from the paid bounties of its tags and estimate", Query: []string{"tags", "estimate_hours", "workspace_uuid"}, Response: db.BountyPriceSuggestions{}})
+	openapi.Describe(http.MethodGet, "/gobounties/facets", openapi.Route{Summary: "Counts of the shown bounties of a filter set per tag, language, price band and workspace", Query: []string{"filters"}, Response: db.BountyFacets{}})
+	openapi.Describe(http.MethodPut, "/gobounties/{id}/move", openapi.Route{Summary: "Move a bounty to a place in its phase or in another phase of its workspace", Request: db.TicketMoveRequest{}, Response: db.NewBounty{}})
+	openapi.Describe(http.MethodGet, "/gobounties/count", openapi.Route{Summary: "Count of bounties", Response: int64(0)})
+	openapi.Describe(http.MethodPost, "/gobounties", openapi.Route{Summary: "Create or edit a bounty, an edit sends the version it read in If-Match", Request: db.NewBounty{}, Response: db.NewBounty{}})
+	openapi.Describe(http.MethodPost, "/gobounties/generate_description", openapi.Route{Summary: "Generate a bounty description draft", Request: db.BountyDescriptionRequest{}, Response: db.BountyDescriptionDraft{}})
+	openapi.Describe(http.MethodPost, "/gobounties/generate_description/preview", openapi.Route{Summary: "Preview the call to the description generator without making it", Request: db.BountyDescriptionRequest{}, Response: db.StakworkPreview{}})
+	openapi.Describe(http.MethodDelete, "/gobounties/{pubkey}/{created}", openapi.Route{Summary: "Delete a bounty", Response: db.NewBounty{}})
+	openapi.Describe(http.MethodPost, "/gobounties/{id}/time/start", openapi.Route{Summary: "Start a timer on a bounty", Response: db.TimeEntry{}})
+	openapi.Describe(http.MethodPost, "/gobounties/{id}/time/stop", openapi.Route{Summary: "Stop the timer of the caller on a bounty", Response: db.TimeEntry{}})
+	openapi.Describe(http.MethodPost, "/gobounties/{id}/time", openapi.Route{Summary: "Add time spent on a bounty by hand", Request: db.ManualTimeEntry{}, Response: db.TimeEntry{}})
+	openapi.Describe(http.MethodGet, "/gobounties/{id}/time", openapi.Route{Summary: "Time entries of a bounty", Response: []db.TimeEntry{}})
+	openapi.Describe(http.MethodDelete, "/gobounties/{id}/time/{entry_uuid}", openapi.Route{Summary: "Delete a time entry of the caller", Response: true})
+	openapi.Describe(http.MethodPost, "/gobounties/{id}/proofs", openapi.Route{Summary: "Submit the work on a bounty for review, its reviewers are assigned and notified", Request: db.BountyProofRequest{}, Response: db.BountyProof{}})
+	openapi.Describe(http.MethodGet, "/gobounties/{id}/proofs", openapi.Route{Summary: "Proofs of a bounty with their reviews, the latest first", Response: []db.BountyProof{}})
+	openapi.Describe(http.MethodPost, "/gobounties/{id}/proofs/{proof_uuid}/review", openapi.Route{Summary: "Approve or request changes on the latest proof of a bounty", Request: db.ProofReviewRequest{}, Response: db.BountyProof{}})
+	openapi.Describe(http.MethodGet, "/gobounties/{id}/terms", openapi.Route{Summary: "Terms of a bounty, the message that accepts them and the acceptances, for both parties", Response: db.BountyTerms{}})
+	openapi.Describe(http.MethodPost, "/gobounties/{id}/terms/accept", openapi.Route{Summary: "Accept the terms of a bounty with a signature and complete the assignment", Request: db.BountyTermsAcceptRequest{}, Response: db.NewBounty{}})
+	openapi.Describe(http.MethodGet, "/gobounties/{id}/contact", openapi.Route{Summary: "Contact exchange of a bounty for its owner or assignee, with the contact of the other party once both consented", Response: db.BountyContactExchange{}})
+	openapi.Describe(http.MethodPut, "/gobounties/{id}/contact", openapi.Route{Summary: "Give or take back the consent to share a contact with the other party of a bounty", Request: db.BountyContactConsentRequest{}, Response: db.BountyContactExchange{}})
+	openapi.Describe(http.MethodGet, "/gobounties/{id}/payment_proof", openapi.Route{Summary: "Payment hash, preimage and settlement time of the payout of a bounty, without the people paid", Response: db.PaymentProof{}})
+	openapi.Describe(http.MethodGet, "/me/time", openapi.Route{Summary: "Time the caller tracked, per bounty", Query: []string{"from", "to"}, Response: db.TimeReport{}})
+	openapi.Describe(http.MethodGet, "/me/bounty_certificates", openapi.Route{Summary: "Signed completion certificates of the bounties the caller was paid for", Response: []db.SignedBountyCertificate{}})
+	openapi.Describe(http.MethodGet, "/me/recommended_bounties", openapi.Route{Summary: "Open bounties ranked for the caller", Query: []string{"limit"}, Response: []db.BountyRecommendation{}})
+
+	// search
+	openapi.Describe(http.MethodGet, "/search/{index}", openapi.Route{Summary: "Search the tribes, people or bounties, best match first", Tags: []string{"search"}, Query: []string{"search", "page", "limit"}, Response: []db.SearchDocument{}})
+	openapi.Describe(http.MethodPost, "/admin/search/reindex", openapi.Route{Summary: "Queue a job copying every record to the external search engine", Tags: []string{"search"}, Response: db.Job{}})
+
+	// batch reads
+	openapi.Describe(http.MethodPost, "/batch/get", openapi.Route{Summary: "People, bounties and tribes by pubkey, id or uuid in one request, with a status per ref", Request: db.BatchGetRequest{}, Response: []db.BatchItem{}})
+
+	// connection codes
+	openapi.Describe(http.MethodGet, "/connectioncodes", openapi.Route{Summary: "Redeem the newest unused connection code", Tags: []string{"connection codes"}, Query: []string{"source"}, Response: db.ConnectionCodesShort{}})
+	openapi.Describe(http.MethodPost, "/connectioncodes", openapi.Route{Summary: "Add connection codes, optionally to a campaign", Tags: []string{"connection codes"}, Query: []string{"campaign", "owner_pubkey"}, Request: []string{}, Response: ""})
+	openapi.Describe(http.MethodGet, "/admin/connectioncodes/stats", openapi.Route{Summary: "Connection codes created and redeemed per campaign", Tags: []string{"connection codes"}, Response: []db.ConnectionCodeStats{}})
+
+	// images
+	openapi.Describe(http.MethodPost, "/images/{kind}", openapi.Route{Summary: "Upload a profile, tribe or bounty image as a multipart file, stored in standard sizes", Tags: []string{"images"}, Response: handlers.ImageUploadResponse{}})
+	openapi.Describe(http.MethodPost, "/uploads", openapi.Route{Summary: "Open a resumable upload of a large file", Tags: []string{"uploads"}, Request: db.UploadSessionRequest{}, Response: db.UploadSession{}})
+	openapi.Describe(http.MethodGet, "/uploads/{uuid}", openapi.Route{Summary: "State of a resumable upload, with its url once it is stored", Tags: []string{"uploads"}, Response: db.UploadSession{}})
+	openapi.Describe(http.MethodHead, "/uploads/{uuid}", openapi.Route{Summary: "Offset a resumable upload continues from, in the Upload-Offset header", Tags: []string{"uploads"}})
+	openapi.Describe(http.MethodPatch, "/uploads/{uuid}", openapi.Route{Summary: "Send the chunk of a resumable upload at its Upload-Offset", Tags: []string{"uploads"}})
+	openapi.Describe(http.MethodDelete, "/uploads/{uuid}", openapi.Route{Summary: "End a resumable upload and drop its chunks", Tags: []string{"uploads"}, Response: true})
+
+	// moderation
+	openapi.Describe(http.MethodPost, "/report", openapi.Route{Summary: "Report a tribe or a bounty to the moderators", Tags: []string{"moderation"}, Request: db.Report{}, Response: db.Report{}})
+	openapi.Describe(http.MethodGet, "/admin/reports", openapi.Route{Summary: "Moderation queue", Tags: []string{"moderation"}, Query: []string{"status", "target_type", "page", "limit"}, Response: []db.Report{}})
+	openapi.Describe(http.MethodPost, "/admin/reports/{uuid}/resolve", openapi.Route{Summary: "Dismiss a report or unlist, delete or ban the owner of its target", Tags: []string{"moderation"}, Request: db.ReportResolution{}, Response: []db.Report{}})
+	openapi.Describe(http.MethodPost, "/tribe/{uuid}/appeal", openapi.Route{Summary: "Ask the admins to review a shadow-listed tribe", Tags: []string{"moderation"}, Request: db.TribeAppeal{}, Response: db.TribeAppeal{}})
+	openapi.Describe(http.MethodGet, "/admin/tribes/shadow_listed", openapi.Route{Summary: "Shadow-listed tribes with their spam score and appeal", Tags: []string{"moderation"}, Query: []string{"page", "limit"}, Response: []db.ShadowListedTribe{}})
+	openapi.Describe(http.MethodPost, "/admin/tribes/{uuid}/spam_review", openapi.Route{Summary: "Restore a shadow-listed tribe or unlist it", Tags: []string{"moderation"}, Request: db.TribeSpamReview{}, Response: db.Tribe{}})
+	openapi.Describe(http.MethodGet, "/admin/workspaces/flagged", openapi.Route{Summary: "Workspaces waiting for a review after their creation", Tags: []string{"moderation"}, Query: []string{"page", "limit"}, Response: []db.Workspace{}})
+	openapi.Describe(http.MethodPost, "/admin/workspaces/{uuid}/review", openapi.Route{Summary: "List a flagged workspace in the directory or delete it", Tags: []string{"moderation"}, Request: db.WorkspaceReview{}, Response: db.Workspace{}})
+	openapi.Describe(http.MethodPost, "/admin/categories", openapi.Route{Summary: "Add a tribe category, or rename or move the category of the slug", Tags: []string{"moderation"}, Request: db.CategoryRequest{}, Response: db.Category{}})
+	openapi.Describe(http.MethodDelete, "/admin/categories/{slug}", openapi.Route{Summary: "Delete a tribe category without subcategories", Tags: []string{"moderation"}, Response: true})
+
+	// realtime
+	openapi.Describe(http.MethodGet, "/events", openapi.Route{Summary: "Stream topic messages as server-sent events", Tags: []string{"realtime"}, Query: []string{"topics", "token", "last_event_id"}})
+	openapi.Describe(http.MethodGet, "/me/activity", openapi.Route{Summary: "Events that concern the caller, newest first", Tags: []string{"realtime"}, Query: []string{"types", "page", "limit", "cursor"}, Response: []db.Event{}})
+	openapi.Describe(http.MethodGet, "/mentions/{type}/{id}", openapi.Route{Summary: "Bounties and features that reference a person, bounty or feature", Tags: []string{"realtime"}, Response: []db.Mention{}})
+	openapi.Describe(http.MethodGet, "/me/notifications", openapi.Route{Summary: "Notification inbox of the caller, newest first, with the unread count in X-Unread-Count", Tags: []string{"realtime"}, Query: []string{"unread", "page", "limit", "cursor"}, Response: []db.Notification{}})
+	openapi.Describe(http.MethodPut, "/me/notifications/{id}/read", openapi.Route{Summary: "Mark a notification read", Tags: []string{"realtime"}, Response: true})
+	openapi.Describe(http.MethodPut, "/me/notifications/read_all", openapi.Route{Summary: "Mark every notification read", Tags: []string{"realtime"}, Response: map[string]int64{}})
+	openapi.Describe(http.MethodGet, "/sync", openapi.Route{Summary: "Profile, bounties and notifications of the caller changed since a sync cursor", Tags: []string{"realtime"}, Query: []string{"since", "wait"}, Response: db.SyncResponse{}})
+
+	// tickets
+	openapi.Describe(http.MethodDelete, "/ticket/{pubKey}/{created}", openapi.Route{Summary: "Delete a ticket as an admin", Tags: []string{"tickets"}, Response: true})
+
+	// payments
+	openapi.Describe(http.MethodPost, "/gobounties/pay/{id}", openapi.Route{Summary: "Pay a bounty", Tags: []string{"payments"}, Request: db.BountyPayRequest{}})
+	openapi.Describe(http.MethodPost, "/gobounties/completedstatus/{created}", openapi.Route{Summary: "Accept a bounty as completed once it meets the definition of done, paid right away when its workspace has auto-pay", Tags: []string{"payments"}, Response: db.NewBounty{}})
+	openapi.Describe(http.MethodPost, "/gobounties/budget_workspace/withdraw", openapi.Route{Summary: "Withdraw from a workspace budget", Tags: []string{"payments"}, Request: db.WithdrawBudgetRequest{}, Response: db.InvoicePaySuccess{}})
+	openapi.Describe(http.MethodGet, "/gobounties/invoice/{paymentRequest}", openapi.Route{Summary: "Get a lightning invoice status", Tags: []string{"payments"}, Response: db.InvoiceResult{}})
+	openapi.Describe(http.MethodPost, "/invoices", openapi.Route{Summary: "Generate a lightning invoice", Tags: []string{"payments"}, Request: db.InvoiceRequest{}, Response: db.InvoiceResponse{}})
+	openapi.Describe(http.MethodPost, "/budgetinvoices", openapi.Route{Summary: "Generate a budget invoice", Tags: []string{"payments"}, Request: db.BudgetInvoiceRequest{}, Response: db.InvoiceResponse{}})
+	openapi.Describe(http.MethodGet, "/workspaces/payments/{uuid}", openapi.Route{Summary: "Workspace payment history", Tags: []string{"payments"}, Query: paginationQuery, Response: []db.PaymentHistoryData{}})
+
+	// workspaces
+	openapi.Describe(http.MethodGet, "/workspaces", openapi.Route{Summary: "List workspaces", Query: paginationQuery, Response: []db.Workspace{}})
+	openapi.Describe(http.MethodPost, "/workspaces", openapi.Route{Summary: "Create or edit a workspace", Request: db.Workspace{}, Response: db.Workspace{}})
+	openapi.Describe(http.MethodGet, "/workspaces/{uuid}", openapi.Route{Summary: "Get a workspace", Response: db.Workspace{}})
+	openapi.Describe(http.MethodGet, "/workspaces/users/{uuid}", openapi.Route{Summary: "Users of a workspace", Response: []db.WorkspaceUsersData{}})
+	openapi.Describe(http.MethodGet, "/workspaces/bounties/{uuid}", openapi.Route{Summary: "Bounties of a workspace", Query: bountyListQuery, Response: []db.BountyResponse{}})
+	openapi.Describe(http.MethodGet, "/workspaces/budget/{uuid}", openapi.Route{Summary: "Workspace budget", Response: db.StatusBudget{}})
+	openapi.Describe(http.MethodGet, "/workspaces/{workspace_uuid}/features", openapi.Route{Summary: "Features of a workspace", Query: paginationQuery, Response: []db.WorkspaceFeatures{}})
+	openapi.Describe(http.MethodGet, "/workspaces/{workspace_uuid}/artifacts", openapi.Route{Summary: "Artifacts of a workspace", Query: []string{"kind", "tag", "feature_uuid", "page", "limit"}, Response: []db.WorkspaceArtifact{}})
+	openapi.Describe(http.MethodPost, "/workspaces/{workspace_uuid}/artifacts", openapi.Route{Summary: "Create an artifact or save its next version", Request: db.WorkspaceArtifact{}, Response: db.WorkspaceArtifact{}})
+	openapi.Describe(http.MethodGet, "/workspaces/{workspace_uuid}/artifacts/{uuid}", openapi.Route{Summary: "Get an artifact", Response: db.WorkspaceArtifact{}})
+	openapi.Describe(http.MethodGet, "/workspaces/{workspace_uuid}/artifacts/{uuid}/versions", openapi.Route{Summary: "Versions of an artifact", Response: []db.WorkspaceArtifactVersion{}})
+	openapi.Describe(http.MethodGet, "/workspaces/{workspace_uuid}/artifacts/{uuid}/versions/{version}", openapi.Route{Summary: "Get a version of an artifact", Response: db.WorkspaceArtifactVersion{}})
+	openapi.Describe(http.MethodDelete, "/workspaces/{workspace_uuid}/artifacts/{uuid}", openapi.Route{Summary: "Delete an artifact and its versions", Response: true})
+	openapi.Describe(http.MethodGet, "/workspaces/{workspace_uuid}/routing_rules", openapi.Route{Summary: "Bounty routing rules of a workspace", Response: []db.BountyRoutingRule{}})
+	openapi.Describe(http.MethodPost, "/workspaces/{workspace_uuid}/routing_rules", openapi.Route{Summary: "Create or edit a bounty routing rule", Request: db.BountyRoutingRule{}, Response: db.BountyRoutingRule{}})
+	openapi.Describe(http.MethodPost, "/workspaces/{workspace_uuid}/routing_rules/test", openapi.Route{Summary: "Dry run of the routing rules on a bounty or a draft", Request: db.BountyRoutingTest{}, Response: db.BountyRoutingPlan{}})
+	openapi.Describe(http.MethodDelete, "/workspaces/{workspace_uuid}/routing_rules/{uuid}", openapi.Route{Summary: "Delete a bounty routing rule", Response: true})
+	openapi.Describe(http.MethodGet, "/workspaces/{workspace_uuid}/usage", openapi.Route{Summary: "Plan of a workspace and what it used of its quotas", Response: db.WorkspaceUsage{}})
+	openapi.Describe(http.MethodGet, "/workspaces/{workspace_uuid}/ai_usage", openapi.Route{Summary: "AI submissions of a workspace in a month per workflow, with their duration and credit cost", Query: []string{"month"}, Response: db.AiUsage{}})
+	openapi.Describe(http.MethodPut, "/workspaces/{workspace_uuid}/ai_usage", openapi.Route{Summary: "Set the monthly cap of AI submissions of a workspace, 0 removes it", Request: db.AiCapRequest{}, Response: db.Workspace{}})
+	openapi.Describe(http.MethodPost, "/workspaces/{workspace_uuid}/widget", openapi.Route{Summary: "Sign a bounty widget config of the workspace and get the urls and snippet that embed it", Request: db.WidgetConfig{}, Response: db.WidgetLink{}})
+	openapi.Describe(http.MethodGet, "/widget/workspaces/{uuid}/bounties", openapi.Route{Summary: "Open bounties of a workspace for embedding, with the fields of the signed config", Tags: []string{"public"}, Query: []string{"format", "config", "sig"}, Response: map[string]interface{}{}})
+	openapi.Describe(http.MethodGet, "/bounty_certificates/key", openapi.Route{Summary: "Public key the completion certificates are verified with", Tags: []string{"public"}, Response: credentials.PublicKey{}})
+	openapi.Describe(http.MethodGet, "/bounty_certificates/{uuid}", openapi.Route{Summary: "Signed completion certificate of a paid bounty", Tags: []string{"public"}, Response: db.SignedBountyCertificate{}})
+	openapi.Describe(http.MethodGet, "/workspaces/{workspace_uuid}/secrets", openapi.Route{Summary: "Secrets of a workspace with their hints, never their values", Response: []db.WorkspaceSecret{}})
+	openapi.Describe(http.MethodPost, "/workspaces/{workspace_uuid}/secrets", openapi.Route{Summary: "Store a sealed secret in a workspace", Request: db.WorkspaceSecretRequest{}, Response: db.WorkspaceSecret{}})
+	openapi.Describe(http.MethodPut, "/workspaces/{workspace_uuid}/secrets/{name}/rotate", openapi.Route{Summary: "Replace the value of a secret", Request: db.WorkspaceSecretRequest{}, Response: db.WorkspaceSecret{}})
+	openapi.Describe(http.MethodDelete, "/workspaces/{workspace_uuid}/secrets/{name}", openapi.Route{Summary: "Delete a secret of a workspace", Response: true})
+	openapi.Describe(http.MethodPost, "/workspaces/{workspace_uuid}/brief/regenerate", openapi.Route{Summary: "Draft a new mission and tactics with Stakwork", Response: db.WorkspaceBrief{}})
+	openapi.Describe(http.MethodGet, "/workspaces/{workspace_uuid}/brief/versions", openapi.Route{Summary: "Brief versions of a workspace", Response: []db.WorkspaceBrief{}})
+	openapi.Describe(http.MethodPost, "/workspaces/{workspace_uuid}/brief/versions/{version_uuid}/approve", openapi.Route{Summary: "Apply a pending brief to the workspace", Request: db.WorkspaceBrief{}, Response: db.Workspace{}})
+	openapi.Describe(http.MethodPost, "/workspaces/{workspace_uuid}/brief/versions/{version_uuid}/reject", openapi.Route{Summary: "Reject a pending brief", Response: db.WorkspaceBrief{}})
+	openapi.Describe(http.MethodGet, "/workspaces/{workspace_uuid}/time", openapi.Route{Summary: "Time tracked on the bounties of a workspace, per person and per bounty", Query: []string{"from", "to"}, Response: db.TimeReport{}})
+	openapi.Describe(http.MethodGet, "/workspaces/{workspace_uuid}/digests", openapi.Route{Summary: "Weekly digests of a workspace", Query: []string{"page", "limit"}, Response: []db.WorkspaceDigest{}})
+	openapi.Describe(http.MethodGet, "/workspaces/{workspace_uuid}/member_suggestions", openapi.Route{Summary: "People of the linked GitHub organization who are not members yet", Response: []db.WorkspaceMemberSuggestion{}})
+	openapi.Describe(http.MethodPut, "/workspaces/{workspace_uuid}/autopay", openapi.Route{Summary: "Pay the bounties when their completion is accepted", Response: db.Workspace{}})
+	openapi.Describe(http.MethodPut, "/workspaces/{workspace_uuid}/definition_of_done", openapi.Route{Summary: "Set the conditions a bounty must meet before its completion is accepted", Request: db.DefinitionOfDone{}, Response: db.Workspace{}})
+	openapi.Describe(http.MethodPut, "/workspaces/{workspace_uuid}/reviewers", openapi.Route{Summary: "Set the default reviewers of the proofs and the approvals a bounty needs before its completion is accepted", Request: db.ReviewerSettings{}, Response: db.Workspace{}})
+	openapi.Describe(http.MethodPost, "/workspaces/{workspace_uuid}/verification", openapi.Route{Summary: "Send a code to the owner, or create the invoice to pay, to verify a workspace", Request: db.WorkspaceVerificationRequest{}, Response: db.WorkspaceVerification{}})
+	openapi.Describe(http.MethodPost, "/workspaces/{workspace_uuid}/verification/confirm", openapi.Route{Summary: "Verify a workspace with the code sent, or once the invoice is paid", Request: db.WorkspaceVerificationConfirm{}, Response: db.Workspace{}})
+	openapi.Describe(http.MethodPost, "/workspaces/{workspace_uuid}/export", openapi.Route{Summary: "Queue the export of the structure of a workspace", Response: db.WorkspaceExport{}})
+	openapi.Describe(http.MethodGet, "/workspaces/{workspace_uuid}/exports/{export_uuid}", openapi.Route{Summary: "Status of a workspace export", Response: db.WorkspaceExport{}})
+	openapi.Describe(http.MethodGet, "/workspaces/{workspace_uuid}/exports/{export_uuid}/bundle", openapi.Route{Summary: "Download the bundle of a ready export", Response: db.WorkspaceBundle{}})
+	openapi.Describe(http.MethodPost, "/workspaces/import", openapi.Route{Summary: "Create a workspace from an export bundle", Query: []string{"name"}, Request: db.WorkspaceBundle{}, Response: db.Workspace{}})
+	openapi.Describe(http.MethodGet, "/workspaces/{workspace_uuid}/reserve", openapi.Route{Summary: "Dispute reserve of a workspace", Response: db.WorkspaceReserve{}})
+	openapi.Describe(http.MethodPut, "/workspaces/{workspace_uuid}/reserve", openapi.Route{Summary: "Set the percent of the deposits kept in the reserve", Response: db.WorkspaceReserve{}})
+	openapi.Describe(http.MethodGet, "/workspaces/{workspace_uuid}/reserve/entries", openapi.Route{Summary: "Deposits and releases of the reserve", Response: []db.ReserveEntry{}})
+	openapi.Describe(http.MethodPost, "/workspaces/{workspace_uuid}/reserve/releases", openapi.Route{Summary: "Request a release of the reserve for a dispute or failed delivery", Request: db.ReserveReleaseRequest{}, Response: db.ReserveEntry{}})
+	openapi.Describe(http.MethodPost, "/workspaces/{workspace_uuid}/reserve/releases/{release_uuid}/approve", openapi.Route{Summary: "Move a pending release to the workspace budget", Response: db.NewBountyBudget{}})
+	openapi.Describe(http.MethodPost, "/workspaces/{workspace_uuid}/reserve/releases/{release_uuid}/reject", openapi.Route{Summary: "Reject a pending release", Response: db.ReserveEntry{}})
+	openapi.Describe(http.MethodPost, "/workspaces/repositories", openapi.Route{Summary: "Create or edit a workspace repository", Request: db.WorkspaceRepositories{}, Response: db.WorkspaceRepositories{}})
+	openapi.Describe(http.MethodPost, "/features", openapi.Route{Summary: "Create or edit a feature", Tags: []string{"workspaces"}, Request: db.WorkspaceFeatures{}, Response: db.WorkspaceFeatures{}})
+	openapi.Describe(http.MethodPut, "/features/{uuid}/budget", openapi.Route{Summary: "Allocate a part of the workspace budget to a feature", Tags: []string{"workspaces"}, Response: db.WorkspaceFeatures{}})
+	openapi.Describe(http.MethodGet, "/features/{uuid}/burndown", openapi.Route{Summary: "Allocated, spent and committed budget of a feature per day", Tags: []string{"workspaces"}, Response: db.FeatureBurndown{}})
+	openapi.Describe(http.MethodPost, "/features/phase", openapi.Route{Summary: "Create or edit a feature phase", Tags: []string{"workspaces"}, Request: db.FeaturePhase{}, Response: db.FeaturePhase{}})
+	openapi.Describe(http.MethodPut, "/features/{feature_uuid}/reviewers", openapi.Route{Summary: "Set the reviewers of the proofs of the bounties in a feature, instead of those of its workspace", Tags: []string{"workspaces"}, Request: db.FeatureReviewersRequest{}, Response: db.WorkspaceFeatures{}})
+	openapi.Describe(http.MethodPut, "/features/{feature_uuid}/phase/{phase_uuid}/dependency", openapi.Route{Summary: "Set the phase that has to be complete before bounties are opened in a phase", Tags: []string{"workspaces"}, Request: db.PhaseDependencyRequest{}, Response: db.FeaturePhase{}})
+	openapi.Describe(http.MethodGet, "/features/{feature_uuid}/phase/{phase_uuid}/gate", openapi.Route{Summary: "Whether bounties can be opened in a phase", Tags: []string{"workspaces"}, Response: db.PhaseGate{}})
+	openapi.Describe(http.MethodPut, "/features/{feature_uuid}/phase/{phase_uuid}/gate", openapi.Route{Summary: "Open a locked phase before the phase it depends on is complete, or lock it again", Tags: []string{"workspaces"}, Request: db.PhaseGateOverrideRequest{}, Response: db.PhaseGate{}})
+	openapi.Describe(http.MethodPut, "/features/{feature_uuid}/phase/{phase_uuid}/tickets/status", openapi.Route{Summary: "Move tickets of a phase to a status in one transaction, with the result of each", Tags: []string{"workspaces"}, Request: db.TicketStatusRequest{}, Response: []db.TicketStatusResult{}})
+	openapi.Describe(http.MethodGet, "/features/{feature_uuid}/phase/{phase_uuid}/export", openapi.Route{Summary: "Phase plan as markdown or PDF", Tags: []string{"workspaces"}, Query: []string{"format"}})
+	openapi.Describe(http.MethodGet, "/features/{feature_uuid}/phase/{phase_uuid}/export/shared", openapi.Route{Summary: "Phase plan from a signed export link", Tags: []string{"workspaces"}, Query: []string{"format", "expires", "token"}})
+	openapi.Describe(http.MethodPost, "/features/{feature_uuid}/phase/{phase_uuid}/export/link", openapi.Route{Summary: "Signed link to a phase export", Tags: []string{"workspaces"}, Query: []string{"days"}})
+
+	// public reads, with the private fields left out
+	openapi.Describe(http.MethodGet, "/public/tribes", openapi.Route{Summary: "Listed tribes without their private fields", Tags: []string{"public"}, Query: append(paginationQuery, "fields", "render"), Response: []map[string]interface{}{}})
+	openapi.Describe(http.MethodGet, "/public/tribes/{uuid}", openapi.Route{Summary: "Listed tribe and its channels without their private fields", Tags: []string{"public"}, Query: []string{"fields", "render"}, Response: map[string]interface{}{}})
+	openapi.Describe(http.MethodGet, "/public/people", openapi.Route{Summary: "Listed people without their private fields", Tags: []string{"public"}, Query: append(paginationQuery, "fields"), Response: []map[string]interface{}{}})
+	openapi.Describe(http.MethodGet, "/public/people/{uuid}", openapi.Route{Summary: "Listed person without their private fields", Tags: []string{"public"}, Query: []string{"fields"}, Response: map[string]interface{}{}})
+	openapi.Describe(http.MethodGet, "/public/bounties", openapi.Route{Summary: "Open bounties without the private fields of their people", Tags: []string{"public"}, Query: append(paginationQuery, "fields", "render"), Response: []map[string]interface{}{}})
+	openapi.Describe(http.MethodGet, "/public/bounties/{id}", openapi.Route{Summary: "Open bounty without the private fields of its people", Tags: []string{"public"}, Query: []string{"fields", "render"}, Response: map[string]interface{}{}})
+
+	// quests
+	openapi.Describe(http.MethodPost, "/quests", openapi.Route{Summary: "Create or edit a quest from bounties of a workspace", Request: db.Quest{}, Response: db.Quest{}})
+	openapi.Describe(http.MethodGet, "/quests", openapi.Route{Summary: "Active quests, newest first", Query: []string{"workspace_uuid", "active"}, Response: []db.Quest{}})
+	openapi.Describe(http.MethodGet, "/quests/{uuid}", openapi.Route{Summary: "Quest with its bounties in order", Response: db.Quest{}})
+	openapi.Describe(http.MethodGet, "/quests/{uuid}/progress", openapi.Route{Summary: "Progress of every hunter in a quest", Response: []db.QuestProgress{}})
+	openapi.Describe(http.MethodGet, "/quests/{uuid}/progress/{pubkey}", openapi.Route{Summary: "Progress of a hunter in a quest and their next bounty", Response: db.QuestProgress{}})
+	openapi.Describe(http.MethodPost, "/quests/{uuid}/bonus", openapi.Route{Summary: "Pay the bonus to the hunter who completed every bounty of the quest", Response: db.Quest{}})
+
+	// hive chat
+	openapi.Describe(http.MethodPost, "/hivechat", openapi.Route{Summary: "Create a chat", Request: db.Chat{}, Response: db.Chat{}})
+	openapi.Describe(http.MethodGet, "/hivechat", openapi.Route{Summary: "Chats of a workspace", Query: []string{"workspace_id", "status"}, Response: []db.Chat{}})
+	openapi.Describe(http.MethodGet, "/hivechat/history/{uuid}", openapi.Route{Summary: "Messages of a chat", Response: []db.ChatMessage{}})
+	openapi.Describe(http.MethodPost, "/hivechat/send", openapi.Route{Summary: "Send a chat message", Request: db.ChatMessageRequest{}, Response: db.ChatMessage{}})
+
+	// background jobs
+	openapi.Describe(http.MethodGet, "/admin/jobs", openapi.Route{Summary: "List background jobs", Query: []string{"status", "type", "page", "limit"}, Response: []db.Job{}})
+	openapi.Describe(http.MethodGet, "/admin/jobs/{uuid}", openapi.Route{Summary: "Get a background job", Response: db.Job{}})
+	openapi.Describe(http.MethodPost, "/admin/jobs/{uuid}/requeue", openapi.Route{Summary: "Requeue a failed or dead job", Response: db.Job{}})
+	openapi.Describe(http.MethodGet, "/admin/events", openapi.Route{Summary: "List the domain event log", Query: []string{"type", "subject", "page", "limit"}, Response: []db.Event{}})
+	openapi.Describe(http.MethodGet, "/admin/events/stats", openapi.Route{Summary: "Events published per type since startup", Response: map[string]int64{}})
+	openapi.Describe(http.MethodGet, "/admin/retention", openapi.Route{Summary: "Retention rules and what the latest runs cleaned up", Query: []string{"page", "limit"}, Response: db.RetentionReport{}})
+	openapi.Describe(http.MethodPost, "/admin/retention/run", openapi.Route{Summary: "Queue a retention run", Query: []string{"dry_run"}, Response: db.Job{}})
+	openapi.Describe(http.MethodGet, "/admin/deleted/{kind}", openapi.Route{Summary: "Soft deleted tribes, channels, bounties or features", Query: []string{"page", "limit"}, Response: []db.SoftDeleted{}})
+	openapi.Describe(http.MethodPost, "/admin/deleted/{kind}/{id}/restore", openapi.Route{Summary: "Restore a soft deleted record", Response: true})
+	openapi.Describe(http.MethodGet, "/admin/config", openapi.Route{Summary: "Running config with the secrets redacted", Response: map[string]interface{}{}})
+	openapi.Describe(http.MethodPost, "/admin/config/reload", openapi.Route{Summary: "Reload the feature flags and api deprecation dates", Response: handlers.ConfigReloadResponse{}})
+	openapi.Describe(http.MethodGet, "/admin/maintenance", openapi.Route{Summary: "Maintenance mode of the API", Response: db.Maintenance{}})
+	openapi.Describe(http.MethodPut, "/admin/maintenance", openapi.Route{Summary: "Turn the maintenance mode on or off", Request: db.MaintenanceRequest{}, Response: db.Maintenance{}})
+	openapi.Describe(http.MethodPut, "/admin/workspaces/{uuid}/plan", openapi.Route{Summary: "Move a workspace to a plan of the config", Request: db.WorkspacePlanRequest{}, Response: db.WorkspaceUsage{}})
+	openapi.Describe(http.MethodPost, "/admin/secrets/rewrap", openapi.Route{Summary: "Seal the data keys of every secret with the current master key", Response: db.SecretRewrap{}})
+	openapi.Describe(http.MethodGet, "/admin/stats", openapi.Route{Summary: "Platform totals and their 30 day trend", Response: db.PlatformStatsResponse{}})
+	openapi.Describe(http.MethodGet, "/admin/cache/stats", openapi.Route{Summary: "Read cache hits and misses", Response: db.ReadCacheStats{}})
+	openapi.Describe(http.MethodGet, "/admin/debug/query-plans", openapi.Route{Summary: "Query plans of the hot queries", Response: []db.QueryPlan{}})
+	openapi.Describe(http.MethodGet, "/health", openapi.Route{Summary: "Health of the instance and the breakers of the relay and Stakwork", Response: handlers.Health{}})
+	openapi.Describe(http.MethodGet, "/metrics/db", openapi.Route{Summary: "Database connection pool and slow query counts", Response: db.PoolStats{}})
+	openapi.Describe(http.MethodGet, "/metrics/public", openapi.Route{Summary: "Aggregate bounty numbers for ecosystem dashboards, rate limited per client", Tags: []string{"public"}, Response: db.PublicMetrics{}})
+}
@@ -0,0 +1,22 @@
+package routes
+
+import "github.com/stakwork/sphinx-tribes/httpio"
+
+// tribeParams checks the tribe uuids and the pubkeys in the urls of the
+// tribe routes. Tickets are named by the pubkey of their owner and their
+// created timestamp
+var tribeParams = httpio.ValidateParams(map[string]string{
+	"uuid":       "tribe_uuid",
+	"tribe_uuid": "tribe_uuid",
+	"pubkey":     "pubkey",
+	"pubKey":     "pubkey",
+	"created":    "number",
+})
+
+// personParams checks the person pubkeys and the ticket timestamps in the
+// urls of the people and bounty routes
+var personParams = httpio.ValidateParams(map[string]string{
+	"pubkey":  "pubkey",
+	"pubKey":  "pubkey",
+	"created": "number",
+})
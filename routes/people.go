@@ -1,19 +1,25 @@
 package routes
 
 import (
-	"net/http"
-
 	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
 	"github.com/stakwork/sphinx-tribes/db"
 	"github.com/stakwork/sphinx-tribes/handlers"
+	"github.com/stakwork/sphinx-tribes/httpio"
+	"github.com/stakwork/sphinx-tribes/upstream"
 )
 
 func PeopleRoutes() chi.Router {
 	r := chi.NewRouter()
-	bountyHandler := handlers.NewBountyHandler(http.DefaultClient, db.DB)
+	bountyHandler := handlers.NewBountyHandler(upstream.Default, db.DB)
 
 	peopleHandler := handlers.NewPeopleHandler(db.DB)
+	tipHandler := handlers.NewTipHandler(upstream.Default, db.DB)
 	r.Group(func(r chi.Router) {
+		r.Use(httpio.SelectFields)
+		r.Use(httpio.RenderMarkdown("description"))
+		r.Use(personParams)
+
 		r.Get("/", peopleHandler.GetListedPeople)
 		r.Get("/search", peopleHandler.GetPeopleBySearch)
 		r.Get("/posts", handlers.GetListedPosts)
@@ -22,7 +28,16 @@ func PeopleRoutes() chi.Router {
 		r.Get("/wanteds/header", handlers.GetWantedsHeader)
 		r.Get("/short", handlers.GetPeopleShortList)
 		r.Get("/offers", handlers.GetListedOffers)
-		r.Get("/bounty/leaderboard", handlers.GetBountiesLeaderboard)
+		r.With(httpio.Cacheable(leaderboardCacheAge)).Get("/bounty/leaderboard", handlers.GetBountiesLeaderboard)
+		r.Get("/{pubkey}/reputation", peopleHandler.GetPersonReputation)
+	})
+
+	r.Group(func(r chi.Router) {
+		r.Use(auth.PubKeyContext)
+		r.Use(personParams)
+
+		r.With(upstream.Require(upstream.Relay), httpio.Untimed).Post("/{pubkey}/tip", tipHandler.TipPerson)
+		r.Post("/{pubkey}/endorsements", peopleHandler.EndorsePerson)
 	})
 	return r
 }
@@ -5,12 +5,16 @@ import (
 	"github.com/stakwork/sphinx-tribes/auth"
 	"github.com/stakwork/sphinx-tribes/db"
 	"github.com/stakwork/sphinx-tribes/handlers"
+	"github.com/stakwork/sphinx-tribes/httpio"
 )
 
 func PersonRoutes() chi.Router {
 	r := chi.NewRouter()
 	peopleHandler := handlers.NewPeopleHandler(db.DB)
 	r.Group(func(r chi.Router) {
+		r.Use(httpio.SelectFields)
+		r.Use(personParams)
+
 		r.Get("/{pubkey}", peopleHandler.GetPersonByPubkey)
 		r.Get("/id/{id}", peopleHandler.GetPersonById)
 		r.Get("/uuid/{uuid}", peopleHandler.GetPersonByUuid)
@@ -0,0 +1,29 @@
+package routes
+
+import (
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/handlers"
+	"github.com/stakwork/sphinx-tribes/httpio"
+)
+
+// PublicRoutes are the reads of the public website, without auth and with
+// the private fields redacted
+func PublicRoutes() chi.Router {
+	r := chi.NewRouter()
+	publicHandler := handlers.NewPublicHandler(db.DB)
+	r.Group(func(r chi.Router) {
+		r.Use(httpio.SelectFields)
+		r.Use(httpio.RenderMarkdown("description"))
+
+		r.With(httpio.RateLimit(tribeDirectoryRateLimit, time.Minute)).Get("/tribes", publicHandler.GetTribes)
+		r.With(tribeParams).Get("/tribes/{uuid}", publicHandler.GetTribe)
+		r.Get("/people", publicHandler.GetPeople)
+		r.Get("/people/{uuid}", publicHandler.GetPerson)
+		r.Get("/bounties", publicHandler.GetBounties)
+		r.Get("/bounties/{id}", publicHandler.GetBounty)
+	})
+	return r
+}
@@ -0,0 +1,30 @@
+package routes
+
+import (
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/handlers"
+	"github.com/stakwork/sphinx-tribes/httpio"
+	"github.com/stakwork/sphinx-tribes/upstream"
+)
+
+func QuestRoutes() chi.Router {
+	r := chi.NewRouter()
+	questHandlers := handlers.NewQuestHandler(upstream.Default, db.DB)
+	r.Group(func(r chi.Router) {
+		r.Use(personParams)
+		r.Get("/", questHandlers.GetQuests)
+		r.Get("/{uuid}", questHandlers.GetQuest)
+		r.Get("/{uuid}/progress", questHandlers.GetQuestProgress)
+		r.Get("/{uuid}/progress/{pubkey}", questHandlers.GetHunterQuestProgress)
+	})
+	r.Group(func(r chi.Router) {
+		r.Use(auth.PubKeyContext)
+
+		r.Post("/", questHandlers.CreateOrEditQuest)
+		r.Delete("/{uuid}", questHandlers.DeleteQuest)
+		r.With(upstream.Require(upstream.Relay), httpio.Untimed).Post("/{uuid}/bonus", questHandlers.PayQuestBonus)
+	})
+	return r
+}
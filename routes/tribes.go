@@ -1,21 +1,53 @@
 package routes
 
 import (
+	"time"
+
 	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
 	"github.com/stakwork/sphinx-tribes/db"
 	"github.com/stakwork/sphinx-tribes/handlers"
+	"github.com/stakwork/sphinx-tribes/httpio"
 )
 
 func TribeRoutes() chi.Router {
 	r := chi.NewRouter()
 	tribeHandlers := handlers.NewTribeHandler(db.DB)
 	r.Group(func(r chi.Router) {
-		r.Get("/", tribeHandlers.GetListedTribes)
+		r.Use(httpio.SelectFields)
+		r.Use(httpio.RenderMarkdown("description"))
+		r.Use(tribeParams)
+
+		r.With(httpio.RateLimit(tribeDirectoryRateLimit, time.Minute)).Get("/", tribeHandlers.GetListedTribes)
 		r.Get("/app_url/{app_url}", tribeHandlers.GetTribesByAppUrl)
 		r.Get("/app_urls/{app_urls}", handlers.GetTribesByAppUrls)
-		r.Get("/{uuid}", tribeHandlers.GetTribe)
+		r.With(httpio.Cacheable(tribeCacheAge)).Get("/{uuid}", tribeHandlers.GetTribe)
+		r.With(httpio.Cacheable(tribeCacheAge)).Get("/{uuid}/invite_meta", tribeHandlers.GetTribeInviteMeta)
 		r.Get("/total", tribeHandlers.GetTotalribes)
+		r.With(httpio.Cacheable(tribeCacheAge)).Get("/categories", tribeHandlers.GetTribeCategories)
 		r.Post("/", tribeHandlers.CreateOrEditTribe)
+		r.Get("/{uuid}/announcements", tribeHandlers.GetTribeAnnouncements)
+		r.Get("/{uuid}/events", tribeHandlers.GetTribeEvents)
+		r.Get("/{uuid}/activity", tribeHandlers.GetTribeActivity)
+		r.With(httpio.Cacheable(tribeCacheAge)).Get("/{uuid}/updates.atom", tribeHandlers.GetTribeUpdatesFeed)
+		r.Get("/{uuid}/preview", tribeHandlers.GetTribePreview)
+		r.With(httpio.Cacheable(tribeCacheAge)).Get("/{uuid}/preview.svg", tribeHandlers.GetTribePreviewCard)
+	})
+	r.Group(func(r chi.Router) {
+		r.Use(auth.PubKeyContext)
+		r.Use(tribeParams)
+
+		r.Post("/{uuid}/announcements", tribeHandlers.CreateOrEditTribeAnnouncement)
+		r.Delete("/{uuid}/announcements/{announcement_uuid}", tribeHandlers.DeleteTribeAnnouncement)
+		r.Post("/{uuid}/events", tribeHandlers.CreateOrEditTribeEvent)
+		r.Delete("/{uuid}/events/{event_uuid}", tribeHandlers.DeleteTribeEvent)
+		r.Post("/{uuid}/join", tribeHandlers.JoinTribe)
+		r.Post("/{uuid}/leave", tribeHandlers.LeaveTribe)
+		r.Get("/{uuid}/members", tribeHandlers.GetTribeMembers)
+		r.Delete("/{uuid}/members/{pubkey}", tribeHandlers.KickTribeMember)
+		r.Get("/{uuid}/webhooks", tribeHandlers.GetTribeWebhooks)
+		r.Post("/{uuid}/webhooks", tribeHandlers.CreateTribeWebhook)
+		r.Delete("/{uuid}/webhooks/{webhook_uuid}", tribeHandlers.DeleteTribeWebhook)
 	})
 	return r
 }
@@ -5,11 +5,22 @@ import (
 	"github.com/stakwork/sphinx-tribes/auth"
 	"github.com/stakwork/sphinx-tribes/db"
 	"github.com/stakwork/sphinx-tribes/handlers"
+	"github.com/stakwork/sphinx-tribes/httpio"
+	"github.com/stakwork/sphinx-tribes/upstream"
 )
 
 func WorkspaceRoutes() chi.Router {
 	r := chi.NewRouter()
 	workspaceHandlers := handlers.NewWorkspaceHandler(db.DB)
+	artifactHandlers := handlers.NewArtifactHandler(db.DB)
+	briefHandlers := handlers.NewBriefHandler(upstream.Default, db.DB)
+	reserveHandlers := handlers.NewReserveHandler(db.DB)
+	timeHandlers := handlers.NewTimeHandler(db.DB)
+	routingHandlers := handlers.NewRoutingRuleHandler(db.DB)
+	quotaHandlers := handlers.NewQuotaHandler(db.DB)
+	widgetHandlers := handlers.NewWidgetHandler(db.DB)
+	secretHandlers := handlers.NewSecretHandler(db.DB)
+	r.Use(httpio.TenantScope)
 	r.Group(func(r chi.Router) {
 		r.Get("/", handlers.GetWorkspaces)
 		r.Get("/count", handlers.GetWorkspacesCount)
@@ -21,6 +32,10 @@ func WorkspaceRoutes() chi.Router {
 		r.Get("/user/{userId}", handlers.GetUserWorkspaces)
 		r.Get("/user/dropdown/{userId}", workspaceHandlers.GetUserDropdownWorkspaces)
 	})
+	r.Group(func(r chi.Router) {
+		r.Use(auth.StakworkWebhook)
+		r.Post("/brief/response", briefHandlers.ProcessBriefResponse)
+	})
 	r.Group(func(r chi.Router) {
 		r.Use(auth.PubKeyContext)
 
@@ -35,8 +50,8 @@ func WorkspaceRoutes() chi.Router {
 		r.Get("/budget/{uuid}", workspaceHandlers.GetWorkspaceBudget)
 		r.Get("/budget/history/{uuid}", workspaceHandlers.GetWorkspaceBudgetHistory)
 		r.Get("/payments/{uuid}", handlers.GetPaymentHistory)
-		r.Get("/poll/invoices/{uuid}", workspaceHandlers.PollBudgetInvoices)
-		r.Get("/poll/user/invoices", workspaceHandlers.PollUserWorkspacesBudget)
+		r.With(upstream.Require(upstream.Relay)).Get("/poll/invoices/{uuid}", workspaceHandlers.PollBudgetInvoices)
+		r.With(upstream.Require(upstream.Relay)).Get("/poll/user/invoices", workspaceHandlers.PollUserWorkspacesBudget)
 		r.Get("/invoices/count/{uuid}", handlers.GetInvoicesCount)
 		r.Get("/user/invoices/count", handlers.GetAllUserInvoicesCount)
 		r.Delete("/delete/{uuid}", workspaceHandlers.DeleteWorkspace)
@@ -51,6 +66,54 @@ func WorkspaceRoutes() chi.Router {
 		r.Get("/{workspace_uuid}/features", workspaceHandlers.GetFeaturesByWorkspaceUuid)
 		r.Get("/{workspace_uuid}/repository/{uuid}", workspaceHandlers.GetWorkspaceRepoByWorkspaceUuidAndRepoUuid)
 		r.Delete("/{workspace_uuid}/repository/{uuid}", workspaceHandlers.DeleteWorkspaceRepository)
+
+		r.Get("/{workspace_uuid}/artifacts", artifactHandlers.GetArtifacts)
+		r.Post("/{workspace_uuid}/artifacts", artifactHandlers.CreateOrEditArtifact)
+		r.Get("/{workspace_uuid}/artifacts/{uuid}", artifactHandlers.GetArtifact)
+		r.Get("/{workspace_uuid}/artifacts/{uuid}/versions", artifactHandlers.GetArtifactVersions)
+		r.Get("/{workspace_uuid}/artifacts/{uuid}/versions/{version}", artifactHandlers.GetArtifactVersion)
+		r.Delete("/{workspace_uuid}/artifacts/{uuid}", artifactHandlers.DeleteArtifact)
+
+		r.Get("/{workspace_uuid}/routing_rules", routingHandlers.GetRoutingRules)
+		r.Post("/{workspace_uuid}/routing_rules", routingHandlers.CreateOrEditRoutingRule)
+		r.Post("/{workspace_uuid}/routing_rules/test", routingHandlers.TestRoutingRules)
+		r.Delete("/{workspace_uuid}/routing_rules/{uuid}", routingHandlers.DeleteRoutingRule)
+
+		r.Get("/{workspace_uuid}/usage", quotaHandlers.GetWorkspaceUsage)
+		r.Get("/{workspace_uuid}/ai_usage", quotaHandlers.GetAiUsage)
+		r.Put("/{workspace_uuid}/ai_usage", quotaHandlers.SetAiCap)
+		r.Post("/{workspace_uuid}/widget", widgetHandlers.CreateWidget)
+
+		r.Get("/{workspace_uuid}/secrets", secretHandlers.GetWorkspaceSecrets)
+		r.Post("/{workspace_uuid}/secrets", secretHandlers.CreateWorkspaceSecret)
+		r.Put("/{workspace_uuid}/secrets/{name}/rotate", secretHandlers.RotateWorkspaceSecret)
+		r.Delete("/{workspace_uuid}/secrets/{name}", secretHandlers.DeleteWorkspaceSecret)
+
+		r.Post("/{workspace_uuid}/brief/regenerate", briefHandlers.RegenerateBrief)
+		r.Get("/{workspace_uuid}/brief/versions", briefHandlers.GetBriefVersions)
+		r.Post("/{workspace_uuid}/brief/versions/{version_uuid}/approve", briefHandlers.ApproveBrief)
+		r.Post("/{workspace_uuid}/brief/versions/{version_uuid}/reject", briefHandlers.RejectBrief)
+
+		r.Put("/{workspace_uuid}/autopay", workspaceHandlers.SetWorkspaceAutoPay)
+		r.Put("/{workspace_uuid}/definition_of_done", workspaceHandlers.SetWorkspaceDefinitionOfDone)
+		r.Put("/{workspace_uuid}/reviewers", workspaceHandlers.SetWorkspaceReviewers)
+		r.Post("/{workspace_uuid}/verification", workspaceHandlers.StartWorkspaceVerification)
+		r.Post("/{workspace_uuid}/verification/confirm", workspaceHandlers.ConfirmWorkspaceVerification)
+		r.Get("/{workspace_uuid}/time", timeHandlers.GetWorkspaceTime)
+		r.Get("/{workspace_uuid}/digests", workspaceHandlers.GetWorkspaceDigests)
+		r.Get("/{workspace_uuid}/member_suggestions", workspaceHandlers.GetWorkspaceMemberSuggestions)
+
+		r.Post("/import", workspaceHandlers.ImportWorkspace)
+		r.Post("/{workspace_uuid}/export", workspaceHandlers.ExportWorkspace)
+		r.Get("/{workspace_uuid}/exports/{export_uuid}", workspaceHandlers.GetWorkspaceExport)
+		r.Get("/{workspace_uuid}/exports/{export_uuid}/bundle", workspaceHandlers.GetWorkspaceExportBundle)
+
+		r.Get("/{workspace_uuid}/reserve", reserveHandlers.GetReserve)
+		r.Put("/{workspace_uuid}/reserve", reserveHandlers.SetReservePercent)
+		r.Get("/{workspace_uuid}/reserve/entries", reserveHandlers.GetReserveEntries)
+		r.Post("/{workspace_uuid}/reserve/releases", reserveHandlers.RequestRelease)
+		r.Post("/{workspace_uuid}/reserve/releases/{release_uuid}/approve", reserveHandlers.ApproveRelease)
+		r.Post("/{workspace_uuid}/reserve/releases/{release_uuid}/reject", reserveHandlers.RejectRelease)
 	})
 	return r
 }
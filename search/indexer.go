@@ -0,0 +1,93 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/events"
+	"github.com/stakwork/sphinx-tribes/jobs"
+)
+
+const (
+	ReindexJob       = "search.reindex"
+	reindexBatchSize = 500
+)
+
+// the index of the records named by event subjects and report targets
+var subjectIndexes = map[string]string{
+	"tribe":  db.SearchIndexTribes,
+	"person": db.SearchIndexPeople,
+	"bounty": db.SearchIndexBounties,
+}
+
+// RegisterIndexer keeps an external engine in sync with the tables through
+// a durable consumer, the postgres engine needs nothing
+func RegisterIndexer(bus *events.Bus, engine Engine, database db.Database) {
+	if !engine.External() {
+		return
+	}
+	bus.SubscribeDurable("search", Indexer(engine, database),
+		events.BountyCreated, events.BountyUpdated, events.BountyDeleted, events.PaymentSettled,
+		events.TribeCreated, events.TribeUpdated, events.TribeShadowListed, events.PersonUpdated, events.ReportResolved)
+}
+
+// Indexer reads the record an event is about and indexes it again, or
+// removes it when it was deleted or hidden. A moderation report changes its
+// target, not the report itself
+func Indexer(engine Engine, database db.Database) events.Handler {
+	return func(ctx context.Context, event db.Event) error {
+		kind, id := "", ""
+		if event.Type == events.ReportResolved {
+			kind, _ = event.Payload["target_type"].(string)
+			id, _ = event.Payload["target_uuid"].(string)
+		} else if parts := strings.SplitN(event.Subject, ":", 2); len(parts) == 2 {
+			kind, id = parts[0], parts[1]
+		}
+
+		index, ok := subjectIndexes[kind]
+		if !ok || id == "" {
+			return nil
+		}
+
+		doc, found, err := database.GetSearchDocument(index, id)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return engine.Remove(ctx, index, []string{id})
+		}
+		return engine.Index(ctx, index, []db.SearchDocument{doc})
+	}
+}
+
+// Reindex copies every visible record to the engine, for a new external
+// engine or one that lost its data
+func Reindex(ctx context.Context, engine Engine, database db.Database) error {
+	for _, index := range Indexes {
+		total := 0
+		for offset := 0; ; offset += reindexBatchSize {
+			docs, err := database.GetSearchDocuments(index, reindexBatchSize, offset)
+			if err != nil {
+				return err
+			}
+			if err := engine.Index(ctx, index, docs); err != nil {
+				return err
+			}
+			total += len(docs)
+			if len(docs) < reindexBatchSize {
+				break
+			}
+		}
+		fmt.Printf("[search] reindexed %d %s\n", total, index)
+	}
+	return nil
+}
+
+// RegisterReindex runs Reindex as a background job
+func RegisterReindex(q *jobs.Queue, engine Engine, database db.Database) {
+	q.Register(ReindexJob, func(ctx context.Context, job db.Job) error {
+		return Reindex(ctx, engine, database)
+	})
+}
@@ -0,0 +1,143 @@
+package search
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/db"
+)
+
+// meilisearch keeps the documents in a Meilisearch index of the same name
+type meilisearch struct {
+	url    string
+	key    string
+	client *http.Client
+}
+
+// meiliDocument is a document as stored in Meilisearch. Tribe uuids can
+// have characters Meilisearch refuses in a primary key, so documents are
+// keyed by the hex of their id
+type meiliDocument struct {
+	Key         string  `json:"key"`
+	ID          string  `json:"id"`
+	Title       string  `json:"title"`
+	Description string  `json:"description"`
+	Img         string  `json:"img"`
+	Score       float64 `json:"_rankingScore,omitempty"`
+}
+
+type meiliSearchRequest struct {
+	Q                string `json:"q"`
+	Limit            int    `json:"limit"`
+	Offset           int    `json:"offset"`
+	ShowRankingScore bool   `json:"showRankingScore"`
+}
+
+type meiliSearchResponse struct {
+	Hits []meiliDocument `json:"hits"`
+}
+
+func NewMeilisearch(url string, key string) Engine {
+	return meilisearch{
+		url:    strings.TrimRight(url, "/"),
+		key:    key,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func meiliKey(id string) string {
+	return hex.EncodeToString([]byte(id))
+}
+
+func (m meilisearch) Search(ctx context.Context, index string, text string, limit int, offset int) ([]db.SearchDocument, error) {
+	res := meiliSearchResponse{}
+	body := meiliSearchRequest{Q: text, Limit: limit, Offset: offset, ShowRankingScore: true}
+	if err := m.do(ctx, http.MethodPost, "/indexes/"+index+"/search", body, &res); err != nil {
+		return nil, err
+	}
+
+	docs := make([]db.SearchDocument, 0, len(res.Hits))
+	for _, hit := range res.Hits {
+		docs = append(docs, db.SearchDocument{
+			Index:       index,
+			ID:          hit.ID,
+			Title:       hit.Title,
+			Description: hit.Description,
+			Img:         hit.Img,
+			Rank:        hit.Score,
+		})
+	}
+	return docs, nil
+}
+
+// Index adds or replaces documents, Meilisearch applies them in the
+// background
+func (m meilisearch) Index(ctx context.Context, index string, docs []db.SearchDocument) error {
+	if len(docs) == 0 {
+		return nil
+	}
+	body := make([]meiliDocument, 0, len(docs))
+	for _, doc := range docs {
+		body = append(body, meiliDocument{
+			Key:         meiliKey(doc.ID),
+			ID:          doc.ID,
+			Title:       doc.Title,
+			Description: doc.Description,
+			Img:         doc.Img,
+		})
+	}
+	return m.do(ctx, http.MethodPost, "/indexes/"+index+"/documents?primaryKey=key", body, nil)
+}
+
+func (m meilisearch) Remove(ctx context.Context, index string, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(ids))
+	for _, id := range ids {
+		keys = append(keys, meiliKey(id))
+	}
+	return m.do(ctx, http.MethodPost, "/indexes/"+index+"/documents/delete-batch", keys, nil)
+}
+
+func (m meilisearch) External() bool {
+	return true
+}
+
+func (m meilisearch) do(ctx context.Context, method string, path string, body interface{}, out interface{}) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, m.url+path, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if m.key != "" {
+		req.Header.Set("Authorization", "Bearer "+m.key)
+	}
+
+	res, err := m.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= 300 {
+		message, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+		return fmt.Errorf("meilisearch returned %d: %s", res.StatusCode, message)
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(res.Body).Decode(out)
+}
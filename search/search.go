@@ -0,0 +1,70 @@
+package search
+
+import (
+	"context"
+
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/db"
+)
+
+// Indexes are the searchable kinds of records
+var Indexes = []string{db.SearchIndexTribes, db.SearchIndexPeople, db.SearchIndexBounties}
+
+// Engine searches the tribes, people and bounties. The postgres engine
+// reads the tables directly, external engines keep their own copy that is
+// updated from the event bus
+type Engine interface {
+	Search(ctx context.Context, index string, text string, limit int, offset int) ([]db.SearchDocument, error)
+	Index(ctx context.Context, index string, docs []db.SearchDocument) error
+	Remove(ctx context.Context, index string, ids []string) error
+	External() bool
+}
+
+// Default is the engine used by the rest of the app, it is set up in main
+var Default Engine
+
+// Init picks the engine of SEARCH_ENGINE
+func Init(database db.Database) {
+	cfg := config.Get()
+	if cfg.SearchEngine == config.SearchEngineMeilisearch {
+		Default = NewMeilisearch(cfg.MeilisearchUrl, cfg.MeilisearchKey)
+		return
+	}
+	Default = NewPostgres(database)
+}
+
+func IsIndex(index string) bool {
+	for _, i := range Indexes {
+		if i == index {
+			return true
+		}
+	}
+	return false
+}
+
+// postgres is the default engine, the full text search of the database
+type postgres struct {
+	db db.Database
+}
+
+func NewPostgres(database db.Database) Engine {
+	return postgres{db: database}
+}
+
+func (p postgres) Search(ctx context.Context, index string, text string, limit int, offset int) ([]db.SearchDocument, error) {
+	return p.db.SearchDocuments(ctx, index, text, limit, offset)
+}
+
+// Index and Remove have nothing to do, the search vectors are read from
+// the tables
+func (p postgres) Index(ctx context.Context, index string, docs []db.SearchDocument) error {
+	return nil
+}
+
+func (p postgres) Remove(ctx context.Context, index string, ids []string) error {
+	return nil
+}
+
+func (p postgres) External() bool {
+	return false
+}
@@ -0,0 +1,131 @@
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/events"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeEngine struct {
+	indexed map[string][]db.SearchDocument
+	removed map[string][]string
+}
+
+func newFakeEngine() *fakeEngine {
+	return &fakeEngine{indexed: map[string][]db.SearchDocument{}, removed: map[string][]string{}}
+}
+
+func (f *fakeEngine) Search(ctx context.Context, index string, text string, limit int, offset int) ([]db.SearchDocument, error) {
+	return f.indexed[index], nil
+}
+
+func (f *fakeEngine) Index(ctx context.Context, index string, docs []db.SearchDocument) error {
+	f.indexed[index] = append(f.indexed[index], docs...)
+	return nil
+}
+
+func (f *fakeEngine) Remove(ctx context.Context, index string, ids []string) error {
+	f.removed[index] = append(f.removed[index], ids...)
+	return nil
+}
+
+func (f *fakeEngine) External() bool {
+	return true
+}
+
+func TestIndexer(t *testing.T) {
+	t.Run("should index the record of the event subject", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		engine := newFakeEngine()
+		doc := db.SearchDocument{Index: db.SearchIndexBounties, ID: "12", Title: "Fix the build"}
+		mockDb.On("GetSearchDocument", db.SearchIndexBounties, "12").Return(doc, true, nil).Once()
+
+		err := Indexer(engine, mockDb)(context.Background(), db.Event{Type: events.BountyUpdated, Subject: "bounty:12"})
+		assert.NoError(t, err)
+		assert.Equal(t, []db.SearchDocument{doc}, engine.indexed[db.SearchIndexBounties])
+	})
+
+	t.Run("should remove deleted records", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		engine := newFakeEngine()
+		mockDb.On("GetSearchDocument", db.SearchIndexPeople, "person-uuid").Return(db.SearchDocument{}, false, nil).Once()
+
+		err := Indexer(engine, mockDb)(context.Background(), db.Event{Type: events.PersonUpdated, Subject: "person:person-uuid"})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"person-uuid"}, engine.removed[db.SearchIndexPeople])
+	})
+
+	t.Run("should index the target of a resolved report", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		engine := newFakeEngine()
+		mockDb.On("GetSearchDocument", db.SearchIndexTribes, "tribe-uuid").Return(db.SearchDocument{}, false, nil).Once()
+
+		event := db.Event{Type: events.ReportResolved, Subject: "report:report-uuid", Payload: db.PropertyMap{"target_type": "tribe", "target_uuid": "tribe-uuid"}}
+		assert.NoError(t, Indexer(engine, mockDb)(context.Background(), event))
+		assert.Equal(t, []string{"tribe-uuid"}, engine.removed[db.SearchIndexTribes])
+	})
+
+	t.Run("should skip subjects that are not searchable", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		engine := newFakeEngine()
+		assert.NoError(t, Indexer(engine, mockDb)(context.Background(), db.Event{Type: events.BudgetUpdated, Subject: "workspace:uuid"}))
+		mockDb.AssertNotCalled(t, "GetSearchDocument")
+	})
+}
+
+func TestReindex(t *testing.T) {
+	mockDb := &dbMocks.Database{}
+	engine := newFakeEngine()
+	batch := make([]db.SearchDocument, reindexBatchSize)
+	mockDb.On("GetSearchDocuments", db.SearchIndexTribes, reindexBatchSize, 0).Return(batch, nil).Once()
+	mockDb.On("GetSearchDocuments", db.SearchIndexTribes, reindexBatchSize, reindexBatchSize).Return(batch[:3], nil).Once()
+	mockDb.On("GetSearchDocuments", db.SearchIndexPeople, reindexBatchSize, 0).Return([]db.SearchDocument{}, nil).Once()
+	mockDb.On("GetSearchDocuments", db.SearchIndexBounties, reindexBatchSize, 0).Return(batch[:1], nil).Once()
+
+	assert.NoError(t, Reindex(context.Background(), engine, mockDb))
+	assert.Len(t, engine.indexed[db.SearchIndexTribes], reindexBatchSize+3)
+	assert.Len(t, engine.indexed[db.SearchIndexBounties], 1)
+	mockDb.AssertExpectations(t)
+}
+
+func TestMeilisearch(t *testing.T) {
+	var paths []string
+	var bodies []json.RawMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer master-key", r.Header.Get("Authorization"))
+		paths = append(paths, r.URL.RequestURI())
+		body := json.RawMessage{}
+		json.NewDecoder(r.Body).Decode(&body)
+		bodies = append(bodies, body)
+
+		if r.URL.Path == "/indexes/tribes/search" {
+			w.Write([]byte(`{"hits": [{"key": "74726962652d31", "id": "tribe-1", "title": "Bitcoin", "_rankingScore": 0.9}]}`))
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte(`{"taskUid": 1}`))
+	}))
+	defer server.Close()
+
+	engine := NewMeilisearch(server.URL+"/", "master-key")
+
+	docs, err := engine.Search(context.Background(), db.SearchIndexTribes, "bitcoin", 20, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []db.SearchDocument{{Index: "tribes", ID: "tribe-1", Title: "Bitcoin", Rank: 0.9}}, docs)
+	assert.JSONEq(t, `{"q": "bitcoin", "limit": 20, "offset": 0, "showRankingScore": true}`, string(bodies[0]))
+
+	assert.NoError(t, engine.Index(context.Background(), db.SearchIndexTribes, []db.SearchDocument{{ID: "tribe-1", Title: "Bitcoin"}}))
+	assert.Equal(t, "/indexes/tribes/documents?primaryKey=key", paths[1])
+	assert.JSONEq(t, `[{"key": "74726962652d31", "id": "tribe-1", "title": "Bitcoin", "description": "", "img": ""}]`, string(bodies[1]))
+
+	assert.NoError(t, engine.Remove(context.Background(), db.SearchIndexTribes, []string{"tribe-1"}))
+	assert.Equal(t, "/indexes/tribes/documents/delete-batch", paths[2])
+	assert.JSONEq(t, `["74726962652d31"]`, string(bodies[2]))
+}
@@ -0,0 +1,24 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/seed"
+)
+
+// runSeed handles the `seed` subcommand, it applies the migrations and
+// fills the database with the demo data
+func runSeed() {
+	db.InitDB()
+
+	summary, err := seed.Run(db.DB, time.Now())
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	fmt.Printf("seeded %d people, %d tribes, %d workspaces, %d repositories, %d features, %d phases, %d stories and %d bounties\n",
+		summary.People, summary.Tribes, summary.Workspaces, summary.Repositories, summary.Features, summary.Phases, summary.Stories, summary.Bounties)
+}
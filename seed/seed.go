@@ -0,0 +1,330 @@
+package seed
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/db"
+)
+
+// ErrSeeded is returned when the seed people already exist, seeding twice
+// would duplicate the workspace members and budgets
+var ErrSeeded = errors.New("the database is already seeded")
+
+// Summary counts what Run created
+type Summary struct {
+	People       int `json:"people"`
+	Tribes       int `json:"tribes"`
+	Workspaces   int `json:"workspaces"`
+	Repositories int `json:"repositories"`
+	Features     int `json:"features"`
+	Phases       int `json:"phases"`
+	Stories      int `json:"stories"`
+	Bounties     int `json:"bounties"`
+}
+
+type person struct {
+	alias       string
+	description string
+	tags        []string
+}
+
+type tribe struct {
+	uuid        string
+	name        string
+	description string
+	tags        []string
+	owner       int
+}
+
+type workspace struct {
+	uuid        string
+	name        string
+	description string
+	mission     string
+	owner       int
+	members     []int
+	budget      uint
+	repository  string
+	features    []feature
+}
+
+type feature struct {
+	name    string
+	brief   string
+	phases  []string
+	stories []string
+}
+
+var people = []person{
+	{"alice", "Maintainer of the Sphinx apps, reviews most of the bounties", []string{"Go", "TypeScript"}},
+	{"bob", "Lightning node operator and tooling author", []string{"Rust", "Lightning"}},
+	{"carol", "Frontend developer, likes small focused bounties", []string{"React", "TypeScript"}},
+	{"dave", "Backend developer new to the project", []string{"Go", "Postgres"}},
+	{"erin", "Designer working on the people directory", []string{"Design", "Figma"}},
+}
+
+var tribes = []tribe{
+	{"seed-tribe-sphinx-devs", "Sphinx Devs", "Where the Sphinx contributors plan the next release", []string{"Tech", "Bitcoin"}, 0},
+	{"seed-tribe-lightning-builders", "Lightning Builders", "Node operators sharing channel management tips", []string{"Bitcoin", "Lightning"}, 1},
+	{"seed-tribe-design-club", "Design Club", "Feedback on mockups and user flows", []string{"Art"}, 4},
+}
+
+var workspaces = []workspace{
+	{
+		uuid:        "seed-workspace-sphinx-labs",
+		name:        "Sphinx Labs",
+		description: "The Sphinx chat apps and the tribes server",
+		mission:     "Make lightning payments part of every conversation",
+		owner:       0,
+		members:     []int{2, 3},
+		budget:      2000000,
+		repository:  "https://github.com/stakwork/sphinx-tribes",
+		features: []feature{
+			{
+				name:    "Bounty Board",
+				brief:   "A public board of paid tasks for the workspace",
+				phases:  []string{"MVP", "Payments"},
+				stories: []string{"As a funder I can post a bounty with a price", "As a hunter I can filter bounties by language"},
+			},
+			{
+				name:    "People Directory",
+				brief:   "Profiles of the contributors with their skills",
+				phases:  []string{"Profiles", "Search"},
+				stories: []string{"As a visitor I can search people by skill"},
+			},
+		},
+	},
+	{
+		uuid:        "seed-workspace-lightning-tools",
+		name:        "Lightning Tools",
+		description: "Small tools for lightning node operators",
+		mission:     "Keep channels healthy without a terminal",
+		owner:       1,
+		members:     []int{3},
+		budget:      500000,
+		repository:  "https://github.com/stakwork/sphinx-relay",
+		features: []feature{
+			{
+				name:    "Channel Rebalancer",
+				brief:   "Suggests and runs circular rebalances",
+				phases:  []string{"Suggestions"},
+				stories: []string{"As an operator I see which channels are unbalanced"},
+			},
+		},
+	},
+}
+
+// Pubkey is the fake node pubkey of the i-th seed person
+func Pubkey(i int) string {
+	return fmt.Sprintf("02%064x", i+1)
+}
+
+// Run fills an empty database with people, tribes, workspaces, features,
+// phases, stories and bounties that reference each other, for development
+// and demos. The uuids are fixed so links to the seed data keep working
+func Run(database db.Database, now time.Time) (Summary, error) {
+	summary := Summary{}
+	if database.GetPersonByPubkey(Pubkey(0)).ID != 0 {
+		return summary, ErrSeeded
+	}
+
+	for i, p := range people {
+		_, err := database.CreateOrEditPerson(db.Person{
+			Uuid:        fmt.Sprintf("seed-person-%s", p.alias),
+			OwnerPubKey: Pubkey(i),
+			OwnerAlias:  p.alias,
+			UniqueName:  p.alias,
+			Description: p.description,
+			Tags:        p.tags,
+			Created:     &now,
+			Updated:     &now,
+			LastLogin:   now.Unix(),
+		})
+		if err != nil {
+			return summary, err
+		}
+		summary.People++
+	}
+
+	for _, t := range tribes {
+		_, err := database.CreateOrEditTribe(db.Tribe{
+			UUID:        t.uuid,
+			OwnerPubKey: Pubkey(t.owner),
+			OwnerAlias:  people[t.owner].alias,
+			Name:        t.name,
+			UniqueName:  t.uuid,
+			Description: t.description,
+			Tags:        t.tags,
+			PriceToJoin: 10,
+			Created:     &now,
+			Updated:     &now,
+			MemberCount: uint64(len(people)),
+		})
+		if err != nil {
+			return summary, err
+		}
+		summary.Tribes++
+	}
+
+	// bounties are created an hour apart, created is part of their key
+	created := now.Add(-24 * time.Hour)
+	for _, w := range workspaces {
+		owner := Pubkey(w.owner)
+		_, err := database.CreateOrEditWorkspace(db.Workspace{
+			Uuid:        w.uuid,
+			Name:        w.name,
+			OwnerPubKey: owner,
+			Description: w.description,
+			Mission:     w.mission,
+			Website:     w.repository,
+			Github:      w.repository,
+			Show:        true,
+			Created:     &now,
+			Updated:     &now,
+		})
+		if err != nil {
+			return summary, err
+		}
+		summary.Workspaces++
+
+		for _, m := range w.members {
+			database.CreateWorkspaceUser(db.WorkspaceUsers{OwnerPubKey: Pubkey(m), WorkspaceUuid: w.uuid, Created: &now, Updated: &now})
+		}
+		database.CreateWorkspaceBudget(db.NewBountyBudget{WorkspaceUuid: w.uuid, TotalBudget: w.budget, Created: &now, Updated: &now})
+		database.AddPaymentHistory(db.NewPaymentHistory{
+			Amount:        w.budget,
+			PaymentType:   db.Deposit,
+			WorkspaceUuid: w.uuid,
+			SenderPubKey:  owner,
+			Created:       &now,
+			Updated:       &now,
+			Status:        true,
+		})
+
+		if _, err := database.CreateOrEditWorkspaceRepository(db.WorkspaceRepositories{
+			Uuid:          w.uuid + "-repo",
+			WorkspaceUuid: w.uuid,
+			Name:          w.name,
+			Url:           w.repository,
+			CreatedBy:     owner,
+			UpdatedBy:     owner,
+		}); err != nil {
+			return summary, err
+		}
+		summary.Repositories++
+
+		for fi, f := range w.features {
+			featureUuid := fmt.Sprintf("%s-feature-%d", w.uuid, fi+1)
+			if _, err := database.CreateOrEditFeature(db.WorkspaceFeatures{
+				Uuid:          featureUuid,
+				WorkspaceUuid: w.uuid,
+				Name:          f.name,
+				Brief:         f.brief,
+				Priority:      fi + 1,
+				CreatedBy:     owner,
+				UpdatedBy:     owner,
+			}); err != nil {
+				return summary, err
+			}
+			summary.Features++
+
+			for si, s := range f.stories {
+				if _, err := database.CreateOrEditFeatureStory(db.FeatureStory{
+					Uuid:        fmt.Sprintf("%s-story-%d", featureUuid, si+1),
+					FeatureUuid: featureUuid,
+					Description: s,
+					Priority:    si + 1,
+					CreatedBy:   owner,
+					UpdatedBy:   owner,
+				}); err != nil {
+					return summary, err
+				}
+				summary.Stories++
+			}
+
+			for pi, name := range f.phases {
+				phaseUuid := fmt.Sprintf("%s-phase-%d", featureUuid, pi+1)
+				if _, err := database.CreateOrEditFeaturePhase(db.FeaturePhase{
+					Uuid:        phaseUuid,
+					FeatureUuid: featureUuid,
+					Name:        name,
+					Priority:    pi + 1,
+					CreatedBy:   owner,
+					UpdatedBy:   owner,
+				}); err != nil {
+					return summary, err
+				}
+				summary.Phases++
+
+				for bi, state := range bountyStates {
+					created = created.Add(time.Hour)
+					bounty := newBounty(w, f, name, state, owner, created)
+					bounty.Price = uint(10000 * (bi + 1))
+					bounty.PhaseUuid = phaseUuid
+					bounty.PhasePriority = bi + 1
+					bounty, err := database.CreateOrEditBounty(bounty)
+					if err != nil {
+						return summary, err
+					}
+					summary.Bounties++
+
+					if bounty.Paid {
+						database.AddPaymentHistory(db.NewPaymentHistory{
+							Amount:         bounty.Price,
+							BountyId:       bounty.ID,
+							PaymentType:    db.Payment,
+							WorkspaceUuid:  w.uuid,
+							SenderPubKey:   owner,
+							ReceiverPubKey: bounty.Assignee,
+							Created:        &created,
+							Updated:        &created,
+							Status:         true,
+						})
+					}
+				}
+			}
+		}
+	}
+	return summary, nil
+}
+
+// the states of the bounty board, each phase gets a bounty in every state
+var bountyStates = []string{"open", "assigned", "completed", "paid"}
+
+// newBounty is a bounty of a phase in one of the bountyStates, the work
+// goes to the first member of the workspace
+func newBounty(w workspace, f feature, phase string, state string, owner string, created time.Time) db.NewBounty {
+	bounty := db.NewBounty{
+		OwnerID:         owner,
+		Type:            "coding_task",
+		Title:           fmt.Sprintf("%s: %s %s", f.name, phase, state),
+		Description:     fmt.Sprintf("%s. This bounty covers the %s phase.", f.brief, phase),
+		WorkspaceUuid:   w.uuid,
+		Show:            true,
+		CodingLanguages: people[w.owner].tags,
+		Created:         created.Unix(),
+		Updated:         &created,
+	}
+
+	assignee := Pubkey(w.members[0])
+	switch state {
+	case "assigned":
+		bounty.Assignee = assignee
+		bounty.AssignedDate = &created
+	case "completed":
+		bounty.Assignee = assignee
+		bounty.AssignedDate = &created
+		bounty.Completed = true
+		bounty.CompletionDate = &created
+	case "paid":
+		bounty.Assignee = assignee
+		bounty.AssignedDate = &created
+		bounty.Completed = true
+		bounty.CompletionDate = &created
+		bounty.Paid = true
+		bounty.PaidDate = &created
+	}
+	return bounty
+}
@@ -0,0 +1,69 @@
+package seed
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/db"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRun(t *testing.T) {
+	now := time.Date(2024, 5, 1, 14, 20, 0, 0, time.UTC)
+
+	t.Run("should refuse to seed twice", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		mockDb.On("GetPersonByPubkey", Pubkey(0)).Return(db.Person{ID: 1}).Once()
+
+		_, err := Run(mockDb, now)
+
+		assert.Equal(t, ErrSeeded, err)
+		mockDb.AssertExpectations(t)
+	})
+
+	t.Run("should create linked records", func(t *testing.T) {
+		mockDb := &dbMocks.Database{}
+		phases := map[string]string{}
+		bounties := []db.NewBounty{}
+		payments := []db.NewPaymentHistory{}
+
+		mockDb.On("GetPersonByPubkey", Pubkey(0)).Return(db.Person{}).Once()
+		mockDb.On("CreateOrEditPerson", mock.Anything).Return(func(p db.Person) (db.Person, error) { return p, nil })
+		mockDb.On("CreateOrEditTribe", mock.Anything).Return(func(t db.Tribe) (db.Tribe, error) { return t, nil })
+		mockDb.On("CreateOrEditWorkspace", mock.Anything).Return(func(w db.Workspace) (db.Workspace, error) { return w, nil })
+		mockDb.On("CreateWorkspaceUser", mock.Anything).Return(db.WorkspaceUsers{})
+		mockDb.On("CreateWorkspaceBudget", mock.Anything).Return(db.NewBountyBudget{})
+		mockDb.On("CreateOrEditWorkspaceRepository", mock.Anything).Return(db.WorkspaceRepositories{}, nil)
+		mockDb.On("CreateOrEditFeature", mock.Anything).Return(db.WorkspaceFeatures{}, nil)
+		mockDb.On("CreateOrEditFeatureStory", mock.Anything).Return(db.FeatureStory{}, nil)
+		mockDb.On("CreateOrEditFeaturePhase", mock.Anything).Return(func(p db.FeaturePhase) (db.FeaturePhase, error) {
+			phases[p.Uuid] = p.FeatureUuid
+			return p, nil
+		})
+		mockDb.On("CreateOrEditBounty", mock.Anything).Return(func(b db.NewBounty) (db.NewBounty, error) {
+			b.ID = uint(len(bounties) + 1)
+			bounties = append(bounties, b)
+			return b, nil
+		})
+		mockDb.On("AddPaymentHistory", mock.Anything).Return(func(p db.NewPaymentHistory) db.NewPaymentHistory {
+			payments = append(payments, p)
+			return p
+		})
+
+		summary, err := Run(mockDb, now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, Summary{People: 5, Tribes: 3, Workspaces: 2, Repositories: 2, Features: 3, Phases: 5, Stories: 4, Bounties: 20}, summary)
+		assert.Len(t, bounties, 20)
+		for _, b := range bounties {
+			assert.Contains(t, phases, b.PhaseUuid)
+			assert.Contains(t, phases[b.PhaseUuid], b.WorkspaceUuid)
+		}
+		// a deposit per workspace and a payment per paid bounty
+		assert.Len(t, payments, 2+5)
+		assert.Equal(t, bounties[3].ID, payments[1].BountyId)
+		assert.Equal(t, bounties[3].Assignee, payments[1].ReceiverPubKey)
+	})
+}
@@ -0,0 +1,110 @@
+package upstream
+
+import (
+	"sync"
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/config"
+)
+
+// states of a breaker
+const (
+	BreakerClosed   = "closed"
+	BreakerOpen     = "open"
+	BreakerHalfOpen = "half_open"
+)
+
+// BreakerState is the breaker of a service as shown by the health endpoint
+type BreakerState struct {
+	Service  string     `json:"service"`
+	State    string     `json:"state"`
+	Failures int        `json:"failures"`
+	OpenedAt *time.Time `json:"opened_at,omitempty"`
+	// seconds until a trial call is let through
+	RetryAfter int `json:"retry_after,omitempty"`
+}
+
+// breaker opens after UPSTREAM_BREAKER_FAILURES failed calls in a row.
+// Calls are refused until UPSTREAM_BREAKER_COOLDOWN has passed, then one
+// trial call is let through, it closes the breaker when it succeeds and
+// opens it again when it fails
+type breaker struct {
+	mu       sync.Mutex
+	failures int
+	openedAt time.Time
+	trial    bool
+}
+
+func (b *breaker) allow(now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch b.stateLocked(now) {
+	case BreakerClosed:
+		return true
+	case BreakerHalfOpen:
+		b.trial = true
+		return true
+	}
+	return false
+}
+
+func (b *breaker) record(ok bool, now time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.trial = false
+	if ok {
+		b.failures = 0
+		return
+	}
+	b.failures++
+	b.openedAt = now
+}
+
+func (b *breaker) state(now time.Time) string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.stateLocked(now)
+}
+
+// stateLocked is half open once the cooldown passed and until the trial
+// call is taken, the breaker is open while the trial call runs
+func (b *breaker) stateLocked(now time.Time) string {
+	threshold := config.Get().UpstreamBreakerFailures
+	if threshold == 0 || b.failures < threshold {
+		return BreakerClosed
+	}
+	if b.trial || now.Sub(b.openedAt) < breakerCooldown() {
+		return BreakerOpen
+	}
+	return BreakerHalfOpen
+}
+
+// retryAfter is how long until the breaker lets a trial call through
+func (b *breaker) retryAfter(now time.Time) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	wait := b.openedAt.Add(breakerCooldown()).Sub(now)
+	if wait < time.Second {
+		return time.Second
+	}
+	return wait
+}
+
+func (b *breaker) snapshot(service string, now time.Time) BreakerState {
+	state := BreakerState{Service: service, State: b.state(now)}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	state.Failures = b.failures
+	if state.State != BreakerClosed {
+		openedAt := b.openedAt
+		state.OpenedAt = &openedAt
+	}
+	if state.State == BreakerOpen {
+		state.RetryAfter = int(b.openedAt.Add(breakerCooldown()).Sub(now).Seconds()) + 1
+	}
+	return state
+}
+
+func breakerCooldown() time.Duration {
+	return time.Duration(config.Get().UpstreamBreakerCooldown) * time.Second
+}
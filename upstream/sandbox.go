@@ -0,0 +1,303 @@
+package upstream
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/ecdsa"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/zpay32"
+	decodepay "github.com/nbd-wtf/ln-decodepay"
+	"github.com/stakwork/sphinx-tribes/config"
+)
+
+// the endpoints of the Relay the sandbox answers, they are the keys of
+// SANDBOX_FAILURES
+const (
+	SandboxCreateInvoice = "create_invoice"
+	SandboxInvoiceStatus = "invoice_status"
+	SandboxPayInvoice    = "pay_invoice"
+	SandboxKeysend       = "keysend"
+	SandboxNodeInfo      = "node_info"
+)
+
+// the seed of the key of the sandbox node, every instance has the same node
+const sandboxSeed = "sphinx-tribes sandbox node"
+
+// Sandbox answers the calls to the Relay in place of a lightning node, it
+// moves no sats. Its invoices are real regtest bolt11 invoices signed by
+// the sandbox node, and their preimage is derived from the amount, the memo
+// and how many such invoices were created before, so a replayed run gets
+// the same payment hashes. An invoice of the sandbox node is settled as
+// soon as it is created, any other invoice is paid when asked to. Every
+// nth call to an endpoint fails as set in SANDBOX_FAILURES. Calls to other
+// hosts go to next
+type Sandbox struct {
+	next http.RoundTripper
+	key  *btcec.PrivateKey
+
+	mu       sync.Mutex
+	calls    map[string]int
+	sequence map[string]int
+}
+
+func NewSandbox(next http.RoundTripper) *Sandbox {
+	seed := sha256.Sum256([]byte(sandboxSeed))
+	key, _ := btcec.PrivKeyFromBytes(seed[:])
+	return &Sandbox{next: next, key: key, calls: map[string]int{}, sequence: map[string]int{}}
+}
+
+// UseSandbox makes Default send the calls to the Relay to a Sandbox, the
+// node key of the instance is the one of the sandbox node
+func UseSandbox() {
+	sandbox := NewSandbox(http.DefaultTransport)
+	Default = NewClient(sandbox)
+	config.RelayNodeKey = sandbox.NodeKey()
+	log.Printf("[upstream] lightning sandbox answers the calls to %s, no sats are moved", config.RelayUrl)
+}
+
+// NodeKey is the pubkey of the sandbox node, the payee of its invoices
+func (s *Sandbox) NodeKey() string {
+	return hex.EncodeToString(s.key.PubKey().SerializeCompressed())
+}
+
+// sandboxError is the message of a call the sandbox refuses
+type sandboxError string
+
+type sandboxInvoice struct {
+	Settled        bool   `json:"settled"`
+	PaymentRequest string `json:"payment_request"`
+	PaymentHash    string `json:"payment_hash"`
+	Preimage       string `json:"preimage"`
+	Amount         string `json:"amount"`
+}
+
+func (s *Sandbox) RoundTrip(req *http.Request) (*http.Response, error) {
+	if ServiceOf(req.URL) != Relay {
+		return s.next.RoundTrip(req)
+	}
+
+	body := []byte{}
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	path := strings.TrimSuffix(req.URL.Path, "/")
+	switch {
+	case req.Method == http.MethodPost && path == "/invoices":
+		return s.answer(req, SandboxCreateInvoice, func() (int, interface{}) { return s.createInvoice(body) })
+	case req.Method == http.MethodGet && path == "/invoice":
+		return s.answer(req, SandboxInvoiceStatus, func() (int, interface{}) {
+			return s.invoiceStatus(req.URL.Query().Get("payment_request"))
+		})
+	case req.Method == http.MethodPut && path == "/invoices":
+		return s.answer(req, SandboxPayInvoice, func() (int, interface{}) { return s.payInvoice(body) })
+	case req.Method == http.MethodPost && path == "/payment":
+		return s.answer(req, SandboxKeysend, func() (int, interface{}) { return s.keysend(body) })
+	case req.Method == http.MethodGet && path == "/getinfo":
+		return s.answer(req, SandboxNodeInfo, func() (int, interface{}) {
+			return http.StatusOK, map[string]interface{}{"identity_pubkey": s.NodeKey(), "alias": "sandbox"}
+		})
+	case req.Method == http.MethodGet && path == "/contacts":
+		return s.answer(req, SandboxNodeInfo, func() (int, interface{}) {
+			return http.StatusOK, map[string]interface{}{"contacts": []map[string]interface{}{{"public_key": s.NodeKey(), "alias": "sandbox"}}}
+		})
+	}
+	return relayResponse(req, http.StatusNotFound, fmt.Sprintf("%s %s is not answered by the sandbox", req.Method, path), nil)
+}
+
+// answer counts the call to endpoint and fails it when it is one of the
+// calls SANDBOX_FAILURES fails, or answers with the status and response of
+// handle. A failed call is a 400, the way the node refuses a payment
+func (s *Sandbox) answer(req *http.Request, endpoint string, handle func() (int, interface{})) (*http.Response, error) {
+	s.mu.Lock()
+	s.calls[endpoint]++
+	call := s.calls[endpoint]
+	s.mu.Unlock()
+
+	if every := config.Get().SandboxFailures[endpoint]; every > 0 && call%every == 0 {
+		log.Printf("[upstream] sandbox fails call %d to %s", call, endpoint)
+		return relayResponse(req, http.StatusBadRequest, "sandbox failure of "+endpoint, nil)
+	}
+
+	status, response := handle()
+	if message, failed := response.(sandboxError); failed {
+		return relayResponse(req, status, string(message), nil)
+	}
+	return relayResponse(req, status, "", response)
+}
+
+// relayResponse is an answer in the envelope of the Relay, with the error
+// message or the response
+func relayResponse(req *http.Request, status int, message string, response interface{}) (*http.Response, error) {
+	envelope := map[string]interface{}{"success": message == ""}
+	if message != "" {
+		envelope["error"] = message
+	} else {
+		envelope["response"] = response
+	}
+	buf, err := json.Marshal(envelope)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          io.NopCloser(bytes.NewReader(buf)),
+		ContentLength: int64(len(buf)),
+		Request:       req,
+	}, nil
+}
+
+// preimage derives a preimage from parts and the seed of the sandbox node
+func (s *Sandbox) preimage(parts ...interface{}) [32]byte {
+	return sha256.Sum256([]byte(fmt.Sprint(append([]interface{}{sandboxSeed}, parts...)...)))
+}
+
+func (s *Sandbox) createInvoice(body []byte) (int, interface{}) {
+	request := struct {
+		Amount uint   `json:"amount"`
+		Memo   string `json:"memo"`
+	}{}
+	if err := json.Unmarshal(body, &request); err != nil {
+		return http.StatusBadRequest, sandboxError("invalid invoice request")
+	}
+
+	key := fmt.Sprintf("%d:%s", request.Amount, request.Memo)
+	s.mu.Lock()
+	sequence := s.sequence[key]
+	s.sequence[key]++
+	s.mu.Unlock()
+
+	preimage := s.preimage("invoice", key, sequence)
+	options := []func(*zpay32.Invoice){zpay32.Description(request.Memo)}
+	if request.Amount > 0 {
+		options = append(options, zpay32.Amount(lnwire.MilliSatoshi(request.Amount*1000)))
+	}
+	invoice, err := zpay32.NewInvoice(&chaincfg.RegressionNetParams, sha256.Sum256(preimage[:]), time.Now(), options...)
+	if err != nil {
+		return http.StatusBadRequest, sandboxError(err.Error())
+	}
+	paymentRequest, err := invoice.Encode(zpay32.MessageSigner{
+		SignCompact: func(msg []byte) ([]byte, error) {
+			return ecdsa.SignCompact(s.key, chainhash.HashB(msg), true)
+		},
+	})
+	if err != nil {
+		return http.StatusInternalServerError, sandboxError(err.Error())
+	}
+	return http.StatusOK, map[string]string{"invoice": paymentRequest}
+}
+
+// settle is the settled invoice of paymentRequest. The preimage of an
+// invoice of the sandbox node is found again from its description and
+// amount, other invoices get a preimage derived from their payment hash
+func (s *Sandbox) settle(paymentRequest string) (sandboxInvoice, bool) {
+	decoded, err := decodepay.Decodepay(paymentRequest)
+	if err != nil {
+		return sandboxInvoice{}, false
+	}
+	amount := uint(decoded.MSatoshi / 1000)
+	invoice := sandboxInvoice{
+		Settled:        true,
+		PaymentRequest: paymentRequest,
+		PaymentHash:    decoded.PaymentHash,
+		Amount:         fmt.Sprint(amount),
+	}
+
+	preimage := s.preimage("payment", decoded.PaymentHash)
+	if decoded.Payee == s.NodeKey() {
+		key := fmt.Sprintf("%d:%s", amount, decoded.Description)
+		s.mu.Lock()
+		created := s.sequence[key]
+		s.mu.Unlock()
+		for sequence := 0; sequence < created; sequence++ {
+			candidate := s.preimage("invoice", key, sequence)
+			hash := sha256.Sum256(candidate[:])
+			if hex.EncodeToString(hash[:]) == decoded.PaymentHash {
+				preimage = candidate
+				break
+			}
+		}
+	}
+	invoice.Preimage = hex.EncodeToString(preimage[:])
+	return invoice, true
+}
+
+// invoiceStatus reports the invoices of the sandbox node as settled, their
+// payer is simulated, and other invoices as open
+func (s *Sandbox) invoiceStatus(paymentRequest string) (int, interface{}) {
+	invoice, ok := s.settle(paymentRequest)
+	if !ok {
+		return http.StatusBadRequest, sandboxError("invalid payment request")
+	}
+	decoded, _ := decodepay.Decodepay(paymentRequest)
+	if decoded.Payee != s.NodeKey() {
+		invoice.Settled = false
+		invoice.Preimage = ""
+	}
+	return http.StatusOK, invoice
+}
+
+func (s *Sandbox) payInvoice(body []byte) (int, interface{}) {
+	request := struct {
+		PaymentRequest string `json:"payment_request"`
+	}{}
+	if err := json.Unmarshal(body, &request); err != nil {
+		return http.StatusBadRequest, sandboxError("invalid payment request")
+	}
+	invoice, ok := s.settle(request.PaymentRequest)
+	if !ok {
+		return http.StatusBadRequest, sandboxError("invalid payment request")
+	}
+	return http.StatusOK, invoice
+}
+
+func (s *Sandbox) keysend(body []byte) (int, interface{}) {
+	request := struct {
+		Amount         uint   `json:"amount"`
+		DestinationKey string `json:"destination_key"`
+	}{}
+	if err := json.Unmarshal(body, &request); err != nil || request.DestinationKey == "" {
+		return http.StatusBadRequest, sandboxError("invalid keysend request")
+	}
+	if request.Amount == 0 {
+		return http.StatusBadRequest, sandboxError("amount is required")
+	}
+
+	key := fmt.Sprintf("%d:%s", request.Amount, request.DestinationKey)
+	s.mu.Lock()
+	sequence := s.sequence["keysend:"+key]
+	s.sequence["keysend:"+key]++
+	s.mu.Unlock()
+
+	preimage := s.preimage("keysend", key, sequence)
+	hash := sha256.Sum256(preimage[:])
+	return http.StatusOK, map[string]interface{}{
+		"amount":          request.Amount,
+		"destination_key": request.DestinationKey,
+		"payment_hash":    hex.EncodeToString(hash[:]),
+		"preimage":        hex.EncodeToString(preimage[:]),
+	}
+}
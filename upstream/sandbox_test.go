@@ -0,0 +1,150 @@
+package upstream
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	decodepay "github.com/nbd-wtf/ln-decodepay"
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stretchr/testify/assert"
+)
+
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestSandbox(t *testing.T) {
+	t.Setenv("LIGHTNING_BACKEND", config.LightningSandbox)
+	t.Setenv("RELAY_URL", "")
+	t.Setenv("RELAY_AUTH_KEY", "")
+	config.InitConfig()
+
+	passedOn := false
+	newSandbox := func() *Sandbox {
+		return NewSandbox(roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			passedOn = true
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewReader(nil))}, nil
+		}))
+	}
+	call := func(client *Client, method string, path string, body string) (int, map[string]interface{}) {
+		req, _ := http.NewRequest(method, config.RelayUrl+path, bytes.NewBufferString(body))
+		res, err := client.Do(req)
+		assert.NoError(t, err)
+		defer res.Body.Close()
+		envelope := map[string]interface{}{}
+		json.NewDecoder(res.Body).Decode(&envelope)
+		return res.StatusCode, envelope
+	}
+	response := func(envelope map[string]interface{}) map[string]interface{} {
+		r, _ := envelope["response"].(map[string]interface{})
+		return r
+	}
+
+	t.Run("should create invoices a payer can decode", func(t *testing.T) {
+		sandbox := newSandbox()
+		client := NewClient(sandbox)
+
+		status, envelope := call(client, http.MethodPost, "/invoices", `{"amount": 1500, "memo": "Budget Invoice"}`)
+
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, true, envelope["success"])
+		invoice, _ := response(envelope)["invoice"].(string)
+		decoded, err := decodepay.Decodepay(invoice)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1500000), decoded.MSatoshi)
+		assert.Equal(t, "Budget Invoice", decoded.Description)
+		assert.Equal(t, sandbox.NodeKey(), decoded.Payee)
+	})
+
+	t.Run("should derive the same payment hashes on every run", func(t *testing.T) {
+		hashes := func() []string {
+			client := NewClient(newSandbox())
+			hashes := []string{}
+			for i := 0; i < 2; i++ {
+				_, envelope := call(client, http.MethodPost, "/invoices", `{"amount": 10, "memo": "bounty"}`)
+				decoded, _ := decodepay.Decodepay(response(envelope)["invoice"].(string))
+				hashes = append(hashes, decoded.PaymentHash)
+			}
+			return hashes
+		}
+
+		first := hashes()
+		assert.NotEqual(t, first[0], first[1])
+		assert.Equal(t, first, hashes())
+	})
+
+	t.Run("should settle its own invoices with their preimage", func(t *testing.T) {
+		client := NewClient(newSandbox())
+		_, envelope := call(client, http.MethodPost, "/invoices", `{"amount": 10, "memo": "bounty"}`)
+		invoice := response(envelope)["invoice"].(string)
+
+		status, envelope := call(client, http.MethodGet, "/invoice?payment_request="+invoice, "")
+
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, true, response(envelope)["settled"])
+		assert.Equal(t, "10", response(envelope)["amount"])
+		preimage, _ := hex.DecodeString(response(envelope)["preimage"].(string))
+		hash := sha256.Sum256(preimage)
+		assert.Equal(t, response(envelope)["payment_hash"], hex.EncodeToString(hash[:]))
+
+		status, envelope = call(client, http.MethodPut, "/invoices", `{"payment_request": "`+invoice+`"}`)
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, true, response(envelope)["settled"])
+
+		status, envelope = call(client, http.MethodPut, "/invoices", `{"payment_request": "lnbc1invalid"}`)
+		assert.Equal(t, http.StatusBadRequest, status)
+		assert.Equal(t, false, envelope["success"])
+	})
+
+	t.Run("should keysend", func(t *testing.T) {
+		client := NewClient(newSandbox())
+
+		status, envelope := call(client, http.MethodPost, "/payment", `{"amount": 2000, "destination_key": "assignee-pubkey", "text": "memo"}`)
+
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, true, envelope["success"])
+		assert.Equal(t, "assignee-pubkey", response(envelope)["destination_key"])
+
+		status, _ = call(client, http.MethodPost, "/payment", `{"amount": 0, "destination_key": "assignee-pubkey"}`)
+		assert.Equal(t, http.StatusBadRequest, status)
+	})
+
+	t.Run("should fail every nth call of SANDBOX_FAILURES", func(t *testing.T) {
+		t.Setenv("SANDBOX_FAILURES", "keysend=2")
+		config.InitConfig()
+		defer func() {
+			t.Setenv("SANDBOX_FAILURES", "")
+			config.InitConfig()
+		}()
+		client := NewClient(newSandbox())
+		body := `{"amount": 2000, "destination_key": "assignee-pubkey"}`
+
+		statuses := []int{}
+		for i := 0; i < 4; i++ {
+			status, _ := call(client, http.MethodPost, "/payment", body)
+			statuses = append(statuses, status)
+		}
+
+		assert.Equal(t, []int{http.StatusOK, http.StatusBadRequest, http.StatusOK, http.StatusBadRequest}, statuses)
+		status, _ := call(client, http.MethodPost, "/invoices", `{"amount": 10, "memo": "bounty"}`)
+		assert.Equal(t, http.StatusOK, status)
+	})
+
+	t.Run("should pass on the calls to other hosts", func(t *testing.T) {
+		passedOn = false
+		client := NewClient(newSandbox())
+		req, _ := http.NewRequest(http.MethodGet, "https://memes.sphinx.chat/public", nil)
+
+		_, err := client.Do(req)
+
+		assert.NoError(t, err)
+		assert.True(t, passedOn)
+	})
+}
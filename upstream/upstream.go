@@ -0,0 +1,262 @@
+// Package upstream is the http client of the calls to Stakwork and the
+// Relay. Every call gets the timeout of its service, and a service that
+// keeps failing is cut off by a circuit breaker for a cooldown so handlers
+// answer right away instead of queueing on a dead upstream. Status maps the
+// errors of the calls to the status a handler answers with.
+package upstream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/httpio"
+)
+
+// names of the upstream services
+const (
+	Relay    = "relay"
+	Stakwork = "stakwork"
+	Other    = "other"
+)
+
+// the timeout of calls to hosts that are not a known service
+const defaultTimeout = 30 * time.Second
+
+// ErrCircuitOpen is the error of a call to a service whose breaker is open
+var ErrCircuitOpen = errors.New("circuit open after repeated failures")
+
+// ErrPaymentPending is the error of a payment the node took but has not
+// settled or failed yet. The Relay answers such a payment with a 202
+var ErrPaymentPending = errors.New("the payment is in flight")
+
+// Error is a failed call to a service, either a transport error or an
+// answer with an error status
+type Error struct {
+	Service string
+	// the status the service answered with, 0 when it didn't answer
+	Status  int
+	Timeout bool
+	Err     error
+	// how long the breaker of the service stays open
+	RetryAfter time.Duration
+}
+
+func (e *Error) Error() string {
+	if e.Status != 0 {
+		return fmt.Sprintf("%s returned status %d", e.Service, e.Status)
+	}
+	return fmt.Sprintf("%s: %s", e.Service, e.Err)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// StatusError returns the Error of an answer of service with an error
+// status, for callers that don't accept it
+func StatusError(service string, res *http.Response) error {
+	return &Error{Service: service, Status: res.StatusCode}
+}
+
+// Status is the status a handler answers with when a call failed with err.
+// Timeouts are 504, an open breaker 503, and a 4xx of the service is passed
+// on unless it is about our own credentials. Everything else is 502
+func Status(err error) int {
+	upstreamErr := &Error{}
+	if !errors.As(err, &upstreamErr) {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return http.StatusGatewayTimeout
+		}
+		return http.StatusBadGateway
+	}
+
+	switch {
+	case errors.Is(upstreamErr, ErrCircuitOpen):
+		return http.StatusServiceUnavailable
+	case upstreamErr.Timeout:
+		return http.StatusGatewayTimeout
+	case upstreamErr.Status == http.StatusUnauthorized, upstreamErr.Status == http.StatusForbidden:
+		return http.StatusBadGateway
+	case upstreamErr.Status >= 400 && upstreamErr.Status < 500:
+		return upstreamErr.Status
+	}
+	return http.StatusBadGateway
+}
+
+// Attempted tells whether a failed call may have reached the service. A
+// call refused by the open breaker, or one that couldn't connect, was not
+// sent. A call that timed out or lost its connection may have been
+// processed, so a payment it made may still settle and must not be sent
+// again
+func Attempted(err error) bool {
+	if errors.Is(err, ErrCircuitOpen) {
+		return false
+	}
+	opErr := &net.OpError{}
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return false
+	}
+	return true
+}
+
+// WriteError writes the error envelope of a failed call with the status of
+// Status, the service is in the details
+func WriteError(w http.ResponseWriter, r *http.Request, err error, message string) {
+	details := map[string]interface{}{}
+	upstreamErr := &Error{}
+	if errors.As(err, &upstreamErr) {
+		details["upstream"] = upstreamErr.Service
+		if upstreamErr.Status != 0 {
+			details["upstream_status"] = upstreamErr.Status
+		}
+		if upstreamErr.RetryAfter > 0 {
+			w.Header().Set("Retry-After", fmt.Sprint(int(math.Ceil(upstreamErr.RetryAfter.Seconds()))))
+		}
+	}
+	httpio.WriteErrorDetails(w, r, Status(err), message, details)
+}
+
+// Require fails the requests of a route with a 503 while the breaker of
+// service is open, before the handler starts work that needs the service
+func Require(service string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if err := Default.Check(service); err != nil {
+				WriteError(w, r, err, fmt.Sprintf("The %s is unavailable, try again later", service))
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// Client sends requests with the timeout and breaker of the service of
+// their host, it can stand in for an http.Client
+type Client struct {
+	transport http.RoundTripper
+
+	mu       sync.Mutex
+	breakers map[string]*breaker
+}
+
+// Default is the client of the handlers
+var Default = NewClient(http.DefaultTransport)
+
+func NewClient(transport http.RoundTripper) *Client {
+	return &Client{transport: transport, breakers: map[string]*breaker{}}
+}
+
+// Do sends req. Transport errors, and an open breaker, are returned as an
+// *Error. An answer with any status is returned as is, a 5xx counts as a
+// failure of the service
+func (c *Client) Do(req *http.Request) (*http.Response, error) {
+	service := ServiceOf(req.URL)
+	if service == Other {
+		return (&http.Client{Transport: c.transport, Timeout: defaultTimeout}).Do(req)
+	}
+
+	b := c.breaker(service)
+	if !b.allow(time.Now()) {
+		return nil, &Error{Service: service, Err: ErrCircuitOpen, RetryAfter: b.retryAfter(time.Now())}
+	}
+
+	client := &http.Client{Transport: c.transport, Timeout: timeout(service)}
+	res, err := client.Do(req)
+	if err != nil {
+		b.record(false, time.Now())
+		return nil, &Error{Service: service, Timeout: isTimeout(err), Err: err}
+	}
+	b.record(res.StatusCode < 500, time.Now())
+	return res, nil
+}
+
+// Check returns the error a call to service fails with right now because
+// its breaker is open, or nil. It doesn't take the trial call
+func (c *Client) Check(service string) error {
+	b := c.breaker(service)
+	if b.state(time.Now()) != BreakerOpen {
+		return nil
+	}
+	return &Error{Service: service, Err: ErrCircuitOpen, RetryAfter: b.retryAfter(time.Now())}
+}
+
+// States returns the breaker of every service, for the health endpoint
+func (c *Client) States() []BreakerState {
+	now := time.Now()
+	states := []BreakerState{}
+	for _, service := range []string{Relay, Stakwork} {
+		states = append(states, c.breaker(service).snapshot(service, now))
+	}
+	return states
+}
+
+func (c *Client) breaker(service string) *breaker {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	b, ok := c.breakers[service]
+	if !ok {
+		b = &breaker{}
+		c.breakers[service] = b
+	}
+	return b
+}
+
+// ServiceOf is the service a url belongs to, the Relay is the host of
+// RELAY_URL and Stakwork the stakwork.com hosts and the description
+// generator
+func ServiceOf(u *url.URL) string {
+	host := strings.ToLower(u.Hostname())
+	if host == "" {
+		return Other
+	}
+	if host == hostOf(config.RelayUrl) {
+		return Relay
+	}
+	if host == "stakwork.com" || strings.HasSuffix(host, ".stakwork.com") || host == hostOf(config.StakworkProjectsUrl) || host == hostOf(config.BountyDescriptionUrl) {
+		return Stakwork
+	}
+	return Other
+}
+
+func hostOf(rawUrl string) string {
+	u, err := url.Parse(rawUrl)
+	if err != nil {
+		return ""
+	}
+	return strings.ToLower(u.Hostname())
+}
+
+func timeout(service string) time.Duration {
+	cfg := config.Get()
+	seconds := cfg.StakworkTimeout
+	if service == Relay {
+		seconds = cfg.RelayTimeout
+	}
+	if seconds == 0 {
+		return defaultTimeout
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+func isTimeout(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	netErr, ok := err.(net.Error)
+	if !ok {
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			netErr, ok = urlErr.Err.(net.Error)
+		}
+	}
+	return ok && netErr.Timeout()
+}
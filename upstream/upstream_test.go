@@ -0,0 +1,153 @@
+package upstream
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatus(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{"a transport error", &Error{Service: Relay, Err: errors.New("connection refused")}, http.StatusBadGateway},
+		{"a timeout", &Error{Service: Relay, Timeout: true, Err: context.DeadlineExceeded}, http.StatusGatewayTimeout},
+		{"an open breaker", &Error{Service: Stakwork, Err: ErrCircuitOpen}, http.StatusServiceUnavailable},
+		{"a 5xx", &Error{Service: Stakwork, Status: http.StatusInternalServerError}, http.StatusBadGateway},
+		{"a 4xx", &Error{Service: Relay, Status: http.StatusNotFound}, http.StatusNotFound},
+		{"our credentials refused", &Error{Service: Relay, Status: http.StatusUnauthorized}, http.StatusBadGateway},
+		{"the deadline of the request", context.DeadlineExceeded, http.StatusGatewayTimeout},
+		{"another error", errors.New("connection refused"), http.StatusBadGateway},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.status, Status(tt.err))
+		})
+	}
+}
+
+func TestAttempted(t *testing.T) {
+	dialErr := &url.Error{Op: "Post", URL: "http://relay.test/payment", Err: &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}}
+	readErr := &url.Error{Op: "Post", URL: "http://relay.test/payment", Err: &net.OpError{Op: "read", Net: "tcp", Err: errors.New("connection reset by peer")}}
+
+	assert.False(t, Attempted(&Error{Service: Relay, Err: ErrCircuitOpen}))
+	assert.False(t, Attempted(&Error{Service: Relay, Err: dialErr}))
+	assert.True(t, Attempted(&Error{Service: Relay, Timeout: true, Err: context.DeadlineExceeded}))
+	assert.True(t, Attempted(&Error{Service: Relay, Err: readErr}))
+}
+
+func TestClient(t *testing.T) {
+	failing := true
+	slow := false
+	relay := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if slow {
+			time.Sleep(200 * time.Millisecond)
+		}
+		if failing {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer relay.Close()
+
+	t.Setenv("RELAY_AUTH_KEY", "relay-key")
+	t.Setenv("RELAY_URL", relay.URL)
+	t.Setenv("UPSTREAM_BREAKER_FAILURES", "2")
+	t.Setenv("UPSTREAM_BREAKER_COOLDOWN", "60")
+	config.InitConfig()
+
+	relayRequest := func(ctx context.Context) *http.Request {
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, relay.URL+"/getinfo", nil)
+		return req
+	}
+
+	t.Run("should know the service of a url", func(t *testing.T) {
+		u, _ := url.Parse(relay.URL + "/invoices")
+		assert.Equal(t, Relay, ServiceOf(u))
+		u, _ = url.Parse("https://jobs.stakwork.com/api/v1/projects")
+		assert.Equal(t, Stakwork, ServiceOf(u))
+		u, _ = url.Parse("https://memes.sphinx.chat")
+		assert.Equal(t, Other, ServiceOf(u))
+	})
+
+	t.Run("should open the breaker after repeated failures", func(t *testing.T) {
+		client := NewClient(http.DefaultTransport)
+		for i := 0; i < 2; i++ {
+			res, err := client.Do(relayRequest(context.Background()))
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusInternalServerError, res.StatusCode)
+			res.Body.Close()
+		}
+
+		_, err := client.Do(relayRequest(context.Background()))
+		assert.ErrorIs(t, err, ErrCircuitOpen)
+		assert.Equal(t, http.StatusServiceUnavailable, Status(err))
+
+		states := client.States()
+		assert.Equal(t, Relay, states[0].Service)
+		assert.Equal(t, BreakerOpen, states[0].State)
+		assert.Equal(t, 2, states[0].Failures)
+		assert.Equal(t, BreakerClosed, states[1].State)
+	})
+
+	t.Run("should fail the requests of a route while the breaker is open", func(t *testing.T) {
+		defer func(client *Client) { Default = client }(Default)
+		Default = NewClient(http.DefaultTransport)
+		b := Default.breaker(Relay)
+		b.record(false, time.Now())
+		b.record(false, time.Now())
+
+		called := false
+		handler := Require(Relay)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			called = true
+		}))
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/invoices", nil))
+
+		assert.False(t, called)
+		assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+		assert.Equal(t, "60", rr.Header().Get("Retry-After"))
+	})
+
+	t.Run("should close the breaker when the trial call succeeds", func(t *testing.T) {
+		client := NewClient(http.DefaultTransport)
+		b := client.breaker(Relay)
+		b.record(false, time.Now().Add(-time.Hour))
+		b.record(false, time.Now().Add(-time.Hour))
+
+		failing = false
+		defer func() { failing = true }()
+		res, err := client.Do(relayRequest(context.Background()))
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		res.Body.Close()
+		assert.True(t, b.allow(time.Now()))
+	})
+
+	t.Run("should report a timeout", func(t *testing.T) {
+		client := NewClient(http.DefaultTransport)
+		slow = true
+		defer func() { slow = false }()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		_, err := client.Do(relayRequest(ctx))
+
+		upstreamErr := &Error{}
+		assert.True(t, errors.As(err, &upstreamErr))
+		assert.Equal(t, Relay, upstreamErr.Service)
+		assert.Equal(t, http.StatusGatewayTimeout, Status(err))
+	})
+}
@@ -0,0 +1,227 @@
+package upstream
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+
+	decodepay "github.com/nbd-wtf/ln-decodepay"
+	"github.com/stakwork/sphinx-tribes/config"
+)
+
+// V2 answers the calls to the Relay with the bot of a Sphinx v2 node, so
+// the handlers keep calling the Relay API whichever node a deployment runs.
+// The answers of the bot are turned into the answers of the Relay: amounts
+// in sats, the invoice, payment hash and preimage of a payment in the same
+// fields, so the payment history reads the same on both. Calls to other
+// hosts go to next
+type V2 struct {
+	next       http.RoundTripper
+	botUrl     string
+	token      string
+	contactKey string
+}
+
+func NewV2(next http.RoundTripper, botUrl string, token string, contactKey string) *V2 {
+	return &V2{next: next, botUrl: strings.TrimSuffix(botUrl, "/"), token: token, contactKey: contactKey}
+}
+
+// UseV2 makes Default send the calls to the Relay to the v2 bot of the
+// config
+func UseV2() {
+	cfg := config.Get()
+	Default = NewClient(NewV2(http.DefaultTransport, cfg.V2BotUrl, cfg.V2BotToken, cfg.V2ContactKey))
+	log.Printf("[upstream] the calls to %s are sent to the v2 bot at %s", config.RelayUrl, cfg.V2BotUrl)
+}
+
+// v2Payment is the answer of the bot to a payment, its status is COMPLETE,
+// PENDING or FAILED. A payment that is not COMPLETE or FAILED is in flight
+type v2Payment struct {
+	Status      string `json:"status"`
+	Tag         string `json:"tag"`
+	Preimage    string `json:"preimage"`
+	PaymentHash string `json:"payment_hash"`
+}
+
+func (v *V2) RoundTrip(req *http.Request) (*http.Response, error) {
+	if ServiceOf(req.URL) != Relay {
+		return v.next.RoundTrip(req)
+	}
+
+	body := []byte{}
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	path := strings.TrimSuffix(req.URL.Path, "/")
+	switch {
+	case req.Method == http.MethodPost && path == "/invoices":
+		return v.createInvoice(req, body)
+	case req.Method == http.MethodGet && path == "/invoice":
+		return v.invoiceStatus(req, req.URL.Query().Get("payment_request"))
+	case req.Method == http.MethodPut && path == "/invoices":
+		return v.payInvoice(req, body)
+	case req.Method == http.MethodPost && path == "/payment":
+		return v.keysend(req, body)
+	case req.Method == http.MethodGet && path == "/getinfo":
+		return relayResponse(req, http.StatusOK, "", map[string]interface{}{"identity_pubkey": v.contactKey})
+	case req.Method == http.MethodGet && path == "/contacts":
+		return relayResponse(req, http.StatusOK, "", map[string]interface{}{"contacts": []map[string]interface{}{{"public_key": v.contactKey}}})
+	}
+	return relayResponse(req, http.StatusNotFound, fmt.Sprintf("%s %s has no v2 counterpart", req.Method, path), nil)
+}
+
+// call posts payload to path of the bot and reads its answer into out. It
+// is false with the answer to pass on when the bot answered with an error
+// status
+func (v *V2) call(req *http.Request, path string, payload interface{}, out interface{}) (*http.Response, bool, error) {
+	buf, _ := json.Marshal(payload)
+	botReq, err := http.NewRequestWithContext(req.Context(), http.MethodPost, v.botUrl+path, bytes.NewReader(buf))
+	if err != nil {
+		return nil, false, err
+	}
+	botReq.Header.Set("x-admin-token", v.token)
+	botReq.Header.Set("Content-Type", "application/json")
+
+	res, err := v.next.RoundTrip(botReq)
+	if err != nil {
+		return nil, false, err
+	}
+	defer res.Body.Close()
+	answer, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, false, err
+	}
+	if res.StatusCode >= 300 {
+		message := strings.TrimSpace(string(answer))
+		if message == "" {
+			message = http.StatusText(res.StatusCode)
+		}
+		failed, err := relayResponse(req, res.StatusCode, "v2 bot: "+message, nil)
+		return failed, false, err
+	}
+	if err := json.Unmarshal(answer, out); err != nil {
+		return nil, false, fmt.Errorf("v2 bot: %w", err)
+	}
+	return nil, true, nil
+}
+
+// paid answers a payment the way the Relay does. A payment the bot failed
+// is refused with a 400, a payment it has not settled yet is answered with
+// a 202 and its payment hash, it may still settle
+func (v *V2) paid(req *http.Request, payment v2Payment, response map[string]interface{}) (*http.Response, error) {
+	response["payment_hash"] = payment.PaymentHash
+	switch {
+	case strings.EqualFold(payment.Status, "COMPLETE"):
+		response["preimage"] = payment.Preimage
+		return relayResponse(req, http.StatusOK, "", response)
+	case strings.EqualFold(payment.Status, "FAILED"):
+		log.Printf("[upstream] v2 payment %s failed", payment.Tag)
+		return relayResponse(req, http.StatusBadRequest, "payment failed", nil)
+	}
+	log.Printf("[upstream] v2 payment %s is %s", payment.Tag, payment.Status)
+	response["tag"] = payment.Tag
+	return relayResponse(req, http.StatusAccepted, "", response)
+}
+
+func (v *V2) createInvoice(req *http.Request, body []byte) (*http.Response, error) {
+	request := struct {
+		Amount uint   `json:"amount"`
+		Memo   string `json:"memo"`
+	}{}
+	if err := json.Unmarshal(body, &request); err != nil {
+		return relayResponse(req, http.StatusBadRequest, "invalid invoice request", nil)
+	}
+
+	invoice := struct {
+		Bolt11 string `json:"bolt11"`
+	}{}
+	payload := map[string]interface{}{"amt_msat": request.Amount * 1000, "memo": request.Memo}
+	if failed, ok, err := v.call(req, "/create_invoice", payload, &invoice); !ok {
+		return failed, err
+	}
+	return relayResponse(req, http.StatusOK, "", map[string]string{"invoice": invoice.Bolt11})
+}
+
+// invoiceStatus looks the invoice up by its payment hash, the bot doesn't
+// know payment requests
+func (v *V2) invoiceStatus(req *http.Request, paymentRequest string) (*http.Response, error) {
+	decoded, err := decodepay.Decodepay(paymentRequest)
+	if err != nil {
+		return relayResponse(req, http.StatusBadRequest, "invalid payment request", nil)
+	}
+
+	status := struct {
+		Status   string `json:"status"`
+		Preimage string `json:"preimage"`
+	}{}
+	if failed, ok, err := v.call(req, "/check_invoice", map[string]string{"payment_hash": decoded.PaymentHash}, &status); !ok {
+		return failed, err
+	}
+	return relayResponse(req, http.StatusOK, "", map[string]interface{}{
+		"settled":         strings.EqualFold(status.Status, "paid"),
+		"payment_request": paymentRequest,
+		"payment_hash":    decoded.PaymentHash,
+		"preimage":        status.Preimage,
+		"amount":          fmt.Sprint(decoded.MSatoshi / 1000),
+	})
+}
+
+func (v *V2) payInvoice(req *http.Request, body []byte) (*http.Response, error) {
+	request := struct {
+		PaymentRequest string `json:"payment_request"`
+	}{}
+	if err := json.Unmarshal(body, &request); err != nil {
+		return relayResponse(req, http.StatusBadRequest, "invalid payment request", nil)
+	}
+	decoded, err := decodepay.Decodepay(request.PaymentRequest)
+	if err != nil {
+		return relayResponse(req, http.StatusBadRequest, "invalid payment request", nil)
+	}
+
+	payment := v2Payment{}
+	if failed, ok, err := v.call(req, "/pay_invoice", map[string]interface{}{"bolt11": request.PaymentRequest, "wait": true}, &payment); !ok {
+		return failed, err
+	}
+	if payment.PaymentHash == "" {
+		payment.PaymentHash = decoded.PaymentHash
+	}
+	return v.paid(req, payment, map[string]interface{}{
+		"settled":         true,
+		"payment_request": request.PaymentRequest,
+		"amount":          fmt.Sprint(decoded.MSatoshi / 1000),
+	})
+}
+
+func (v *V2) keysend(req *http.Request, body []byte) (*http.Response, error) {
+	request := struct {
+		Amount         uint   `json:"amount"`
+		DestinationKey string `json:"destination_key"`
+		RouteHint      string `json:"route_hint"`
+	}{}
+	if err := json.Unmarshal(body, &request); err != nil || request.DestinationKey == "" {
+		return relayResponse(req, http.StatusBadRequest, "invalid keysend request", nil)
+	}
+	if request.Amount == 0 {
+		return relayResponse(req, http.StatusBadRequest, "amount is required", nil)
+	}
+
+	payment := v2Payment{}
+	payload := map[string]interface{}{"amt_msat": request.Amount * 1000, "dest": request.DestinationKey, "route_hint": request.RouteHint, "wait": true}
+	if failed, ok, err := v.call(req, "/pay", payload, &payment); !ok {
+		return failed, err
+	}
+	return v.paid(req, payment, map[string]interface{}{
+		"amount":          request.Amount,
+		"destination_key": request.DestinationKey,
+	})
+}
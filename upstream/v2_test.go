@@ -0,0 +1,125 @@
+package upstream
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestV2(t *testing.T) {
+	t.Setenv("LIGHTNING_BACKEND", config.LightningV2)
+	t.Setenv("RELAY_URL", "")
+	t.Setenv("RELAY_AUTH_KEY", "")
+	t.Setenv("V2_BOT_URL", "http://bot.v2:3000")
+	t.Setenv("V2_BOT_TOKEN", "bot-token")
+	config.InitConfig()
+
+	// the bot answers every call with its answers, in order
+	type botCall struct {
+		path  string
+		token string
+		body  map[string]interface{}
+	}
+	newV2 := func(answers ...string) (*Client, *[]botCall) {
+		calls := []botCall{}
+		v2 := NewV2(roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			body := map[string]interface{}{}
+			json.NewDecoder(req.Body).Decode(&body)
+			calls = append(calls, botCall{req.URL.Path, req.Header.Get("x-admin-token"), body})
+			answer := answers[len(calls)-1]
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewBufferString(answer))}, nil
+		}), config.Get().V2BotUrl, config.Get().V2BotToken, "v2-node-pubkey")
+		return NewClient(v2), &calls
+	}
+	call := func(client *Client, method string, path string, body string) (int, map[string]interface{}) {
+		req, _ := http.NewRequest(method, config.RelayUrl+path, bytes.NewBufferString(body))
+		res, err := client.Do(req)
+		assert.NoError(t, err)
+		defer res.Body.Close()
+		envelope := map[string]interface{}{}
+		json.NewDecoder(res.Body).Decode(&envelope)
+		return res.StatusCode, envelope
+	}
+	response := func(envelope map[string]interface{}) map[string]interface{} {
+		r, _ := envelope["response"].(map[string]interface{})
+		return r
+	}
+
+	// a real invoice of 10 sats to decode
+	_, envelope := call(NewClient(NewSandbox(http.DefaultTransport)), http.MethodPost, "/invoices", `{"amount": 10, "memo": "bounty"}`)
+	invoice := response(envelope)["invoice"].(string)
+
+	t.Run("should create an invoice in msats", func(t *testing.T) {
+		client, calls := newV2(`{"bolt11": "lnbc-v2", "payment_hash": "hash"}`)
+
+		status, envelope := call(client, http.MethodPost, "/invoices", `{"amount": 1500, "memo": "Budget Invoice"}`)
+
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, "lnbc-v2", response(envelope)["invoice"])
+		assert.Equal(t, "/create_invoice", (*calls)[0].path)
+		assert.Equal(t, "bot-token", (*calls)[0].token)
+		assert.Equal(t, float64(1500000), (*calls)[0].body["amt_msat"])
+	})
+
+	t.Run("should check an invoice by its payment hash", func(t *testing.T) {
+		client, calls := newV2(`{"status": "paid", "preimage": "preimage"}`)
+
+		status, envelope := call(client, http.MethodGet, "/invoice?payment_request="+invoice, "")
+
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, true, response(envelope)["settled"])
+		assert.Equal(t, "10", response(envelope)["amount"])
+		assert.Equal(t, response(envelope)["payment_hash"], (*calls)[0].body["payment_hash"])
+	})
+
+	t.Run("should pay an invoice", func(t *testing.T) {
+		client, _ := newV2(`{"status": "COMPLETE", "tag": "tag", "preimage": "preimage"}`)
+
+		status, envelope := call(client, http.MethodPut, "/invoices", `{"payment_request": "`+invoice+`"}`)
+
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, true, response(envelope)["settled"])
+		assert.Equal(t, "preimage", response(envelope)["preimage"])
+		assert.NotEmpty(t, response(envelope)["payment_hash"])
+	})
+
+	t.Run("should keysend and refuse a payment the bot didn't complete", func(t *testing.T) {
+		client, calls := newV2(`{"status": "COMPLETE", "payment_hash": "hash", "preimage": "preimage"}`, `{"status": "FAILED", "tag": "tag"}`)
+		body := `{"amount": 2000, "destination_key": "assignee-pubkey", "route_hint": "hint", "text": "memo"}`
+
+		status, envelope := call(client, http.MethodPost, "/payment", body)
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, "hash", response(envelope)["payment_hash"])
+		assert.Equal(t, "assignee-pubkey", (*calls)[0].body["dest"])
+		assert.Equal(t, float64(2000000), (*calls)[0].body["amt_msat"])
+
+		status, envelope = call(client, http.MethodPost, "/payment", body)
+		assert.Equal(t, http.StatusBadRequest, status)
+		assert.Equal(t, false, envelope["success"])
+	})
+
+	t.Run("should answer a payment the bot has not settled as pending", func(t *testing.T) {
+		client, _ := newV2(`{"status": "PENDING", "tag": "tag", "payment_hash": "hash"}`)
+
+		status, envelope := call(client, http.MethodPost, "/payment", `{"amount": 2000, "destination_key": "assignee-pubkey"}`)
+
+		assert.Equal(t, http.StatusAccepted, status)
+		assert.Equal(t, "hash", response(envelope)["payment_hash"])
+		assert.Nil(t, response(envelope)["preimage"])
+	})
+
+	t.Run("should answer the node key of the config", func(t *testing.T) {
+		client, calls := newV2()
+
+		status, envelope := call(client, http.MethodGet, "/getinfo", "")
+
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, "v2-node-pubkey", response(envelope)["identity_pubkey"])
+		assert.Empty(t, *calls)
+	})
+}
@@ -54,6 +54,17 @@ func GetInvoiceAmount(paymentRequest string) uint {
 	return amount
 }
 
+// GetInvoicePaymentHash is the payment hash of an invoice, which is how the
+// node knows its payment
+func GetInvoicePaymentHash(paymentRequest string) string {
+	decodedInvoice, err := decodepay.Decodepay(paymentRequest)
+	if err != nil {
+		fmt.Println("Could not Decode Invoice", err)
+		return ""
+	}
+	return decodedInvoice.PaymentHash
+}
+
 func GetInvoiceExpired(paymentRequest string) bool {
 	decodedInvoice, err := decodepay.Decodepay(paymentRequest)
 	if err != nil {
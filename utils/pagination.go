@@ -0,0 +1,93 @@
+package utils
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"reflect"
+	"strconv"
+)
+
+type listEnvelopeKey struct{}
+
+// ListResponse is the envelope of the paginated list endpoints. NextCursor
+// is empty on the last page, otherwise it is passed back as ?cursor=
+type ListResponse struct {
+	Data       interface{} `json:"data"`
+	Total      int64       `json:"total"`
+	NextCursor string      `json:"next_cursor"`
+	Limit      int         `json:"limit"`
+}
+
+type ListParams struct {
+	Offset    int
+	Limit     int
+	SortBy    string
+	Direction string
+	Search    string
+}
+
+func ParseListParams(r *http.Request) ListParams {
+	offset, limit, sortBy, direction, search := GetPaginationParams(r)
+	return ListParams{
+		Offset:    offset,
+		Limit:     limit,
+		SortBy:    sortBy,
+		Direction: direction,
+		Search:    search,
+	}
+}
+
+// cursors are opaque to clients, for now they only hold the offset
+func EncodeCursor(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(offset)))
+}
+
+func DecodeCursor(cursor string) (int, bool) {
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, false
+	}
+	offset, err := strconv.Atoi(string(b))
+	if err != nil || offset < 0 {
+		return 0, false
+	}
+	return offset, true
+}
+
+// NewListResponse wraps one page of data, which has to be a slice
+func NewListResponse(data interface{}, total int64, params ListParams) ListResponse {
+	count := 0
+	if v := reflect.ValueOf(data); v.Kind() == reflect.Slice {
+		count = v.Len()
+	}
+
+	res := ListResponse{Data: data, Total: total, Limit: params.Limit}
+	if count > 0 && int64(params.Offset+count) < total {
+		res.NextCursor = EncodeCursor(params.Offset + count)
+	}
+	return res
+}
+
+// ListEnvelope makes the list endpoints under it answer with ListResponse
+// instead of the bare array
+func ListEnvelope(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), listEnvelopeKey{}, true)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func WantsListEnvelope(r *http.Request) bool {
+	enabled, _ := r.Context().Value(listEnvelopeKey{}).(bool)
+	return enabled
+}
+
+// ListBody is what a list endpoint encodes, the envelope when the route asks
+// for it and data as is otherwise. total is only called for the envelope
+func ListBody(r *http.Request, data interface{}, total func() int64) interface{} {
+	if !WantsListEnvelope(r) {
+		return data
+	}
+	return NewListResponse(data, total(), ParseListParams(r))
+}
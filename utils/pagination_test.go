@@ -0,0 +1,69 @@
+package utils
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCursor(t *testing.T) {
+	offset, ok := DecodeCursor(EncodeCursor(40))
+	assert.True(t, ok)
+	assert.Equal(t, 40, offset)
+
+	_, ok = DecodeCursor("")
+	assert.False(t, ok)
+	_, ok = DecodeCursor("not a cursor")
+	assert.False(t, ok)
+}
+
+func TestGetPaginationParamsWithCursor(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/people?page=5&limit=20&cursor="+EncodeCursor(60), nil)
+	offset, limit, _, _, _ := GetPaginationParams(req)
+	assert.Equal(t, 60, offset)
+	assert.Equal(t, 20, limit)
+
+	req = httptest.NewRequest(http.MethodGet, "/people?page=3&limit=20", nil)
+	offset, _, _, _, _ = GetPaginationParams(req)
+	assert.Equal(t, 40, offset)
+}
+
+func TestNewListResponse(t *testing.T) {
+	params := ListParams{Offset: 20, Limit: 10}
+
+	res := NewListResponse([]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, 45, params)
+	assert.Equal(t, int64(45), res.Total)
+	assert.Equal(t, 10, res.Limit)
+	offset, ok := DecodeCursor(res.NextCursor)
+	assert.True(t, ok)
+	assert.Equal(t, 30, offset)
+
+	res = NewListResponse([]int{1, 2, 3, 4, 5}, 25, params)
+	assert.Equal(t, "", res.NextCursor)
+
+	res = NewListResponse([]int{}, 0, params)
+	assert.Equal(t, "", res.NextCursor)
+}
+
+func TestListBody(t *testing.T) {
+	data := []string{"a", "b"}
+	totalCalled := false
+	total := func() int64 {
+		totalCalled = true
+		return 2
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/tribes?limit=2", nil)
+	assert.Equal(t, data, ListBody(req, data, total))
+	assert.False(t, totalCalled)
+
+	var body interface{}
+	ListEnvelope(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body = ListBody(r, data, total)
+	})).ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.True(t, totalCalled)
+	assert.Equal(t, ListResponse{Data: data, Total: 2, Limit: 2}, body)
+}
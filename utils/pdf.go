@@ -0,0 +1,169 @@
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+const (
+	pdfPageWidth  = 595 // A4 in points
+	pdfPageHeight = 842
+	pdfMargin     = 50
+)
+
+type pdfLine struct {
+	text string
+	size float64
+	bold bool
+	// space left above the line
+	gap float64
+}
+
+// MarkdownPDF renders a markdown document as a plain A4 PDF with the
+// standard Helvetica fonts. Only headings, bullets and paragraphs are laid
+// out, the other markdown marks are printed as they are
+func MarkdownPDF(markdown string) []byte {
+	lines := []pdfLine{}
+	for _, raw := range strings.Split(markdown, "\n") {
+		raw = strings.TrimRight(raw, " \t\r")
+		line := pdfLine{text: raw, size: 10, gap: 4}
+		switch {
+		case raw == "":
+			lines = append(lines, pdfLine{size: 10, gap: 0})
+			continue
+		case strings.HasPrefix(raw, "### "):
+			line = pdfLine{text: raw[4:], size: 12, bold: true, gap: 8}
+		case strings.HasPrefix(raw, "## "):
+			line = pdfLine{text: raw[3:], size: 14, bold: true, gap: 10}
+		case strings.HasPrefix(raw, "# "):
+			line = pdfLine{text: raw[2:], size: 18, bold: true, gap: 0}
+		case strings.HasPrefix(raw, "- "):
+			line.text = "• " + raw[2:]
+		}
+		line.text = strings.NewReplacer("**", "", "`", "").Replace(line.text)
+		for i, wrapped := range wrapPDFText(line.text, line.size) {
+			l := line
+			l.text = wrapped
+			if i > 0 {
+				l.gap = 2
+			}
+			lines = append(lines, l)
+		}
+	}
+
+	pages := [][]byte{}
+	page := &bytes.Buffer{}
+	y := float64(pdfPageHeight - pdfMargin)
+	for _, line := range lines {
+		height := line.size*1.3 + line.gap
+		if y-height < pdfMargin && page.Len() > 0 {
+			pages = append(pages, page.Bytes())
+			page = &bytes.Buffer{}
+			y = pdfPageHeight - pdfMargin
+		}
+		y -= height
+		if line.text == "" {
+			continue
+		}
+		font := "F1"
+		if line.bold {
+			font = "F2"
+		}
+		fmt.Fprintf(page, "BT /%s %.0f Tf %d %.2f Td (%s) Tj ET\n", font, line.size, pdfMargin, y, escapePDFText(line.text))
+	}
+	pages = append(pages, page.Bytes())
+
+	return buildPDF(pages)
+}
+
+// wrapPDFText splits a line on the spaces to fit the page, Helvetica is
+// about half as wide as high on average
+func wrapPDFText(text string, size float64) []string {
+	maxChars := int((pdfPageWidth - 2*pdfMargin) / (size * 0.5))
+	words := strings.Fields(text)
+	if len(words) == 0 {
+		return []string{text}
+	}
+
+	lines := []string{}
+	current := ""
+	for _, word := range words {
+		for len([]rune(word)) > maxChars {
+			if current != "" {
+				lines = append(lines, current)
+				current = ""
+			}
+			lines = append(lines, string([]rune(word)[:maxChars]))
+			word = string([]rune(word)[maxChars:])
+		}
+		switch {
+		case current == "":
+			current = word
+		case len([]rune(current))+1+len([]rune(word)) > maxChars:
+			lines = append(lines, current)
+			current = word
+		default:
+			current += " " + word
+		}
+	}
+	if current != "" {
+		lines = append(lines, current)
+	}
+	return lines
+}
+
+// escapePDFText encodes a string for the WinAnsi encoding of the standard
+// fonts, the characters it doesn't have are replaced with a question mark
+func escapePDFText(text string) string {
+	buf := &strings.Builder{}
+	for _, r := range text {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			buf.WriteByte('\\')
+			buf.WriteRune(r)
+		case r == '•':
+			buf.WriteString("\\225")
+		case r >= 32 && r < 127:
+			buf.WriteRune(r)
+		case r >= 160 && r <= 255:
+			fmt.Fprintf(buf, "\\%03o", r)
+		default:
+			buf.WriteByte('?')
+		}
+	}
+	return buf.String()
+}
+
+func buildPDF(pages [][]byte) []byte {
+	out := &bytes.Buffer{}
+	offsets := []int{}
+	object := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	out.WriteString("%PDF-1.4\n")
+	kids := []string{}
+	for i := range pages {
+		// the catalog, page tree and fonts come first, then a page and its
+		// content for each page
+		kids = append(kids, fmt.Sprintf("%d 0 R", 5+2*i))
+	}
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	for i, content := range pages {
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>", pdfPageWidth, pdfPageHeight, 6+2*i))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", len(content), content))
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+	return out.Bytes()
+}
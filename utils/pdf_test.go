@@ -0,0 +1,34 @@
+package utils
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarkdownPDF(t *testing.T) {
+	pdf := MarkdownPDF("# Plan (v2)\n\n- first ticket\nCafé \\ done")
+
+	assert.True(t, bytes.HasPrefix(pdf, []byte("%PDF-1.4\n")))
+	assert.True(t, bytes.HasSuffix(pdf, []byte("%%EOF\n")))
+	assert.Contains(t, string(pdf), `/F2 18 Tf`)
+	assert.Contains(t, string(pdf), `(Plan \(v2\)) Tj`)
+	assert.Contains(t, string(pdf), `(\225 first ticket) Tj`)
+	assert.Contains(t, string(pdf), `(Caf\351 \\ done) Tj`)
+	assert.Contains(t, string(pdf), "/Count 1")
+
+	long := MarkdownPDF(strings.Repeat("a line of the plan\n", 200))
+	assert.Contains(t, string(long), "/Count 5")
+}
+
+func TestWrapPDFText(t *testing.T) {
+	lines := wrapPDFText(strings.Repeat("word ", 40), 10)
+
+	assert.Len(t, lines, 2)
+	for _, line := range lines {
+		assert.LessOrEqual(t, len(line), 99)
+	}
+	assert.Equal(t, []string{""}, wrapPDFText("", 10))
+}
@@ -0,0 +1,49 @@
+package utils
+
+import (
+	"time"
+)
+
+// the local working hours compared by WorkingHoursOverlap
+const (
+	WorkdayStart = 9
+	WorkdayEnd   = 17
+)
+
+// workday is the working hours of the day of day in loc
+func workday(loc *time.Location, day time.Time) (time.Time, time.Time) {
+	start := time.Date(day.Year(), day.Month(), day.Day(), WorkdayStart, 0, 0, 0, loc)
+	end := time.Date(day.Year(), day.Month(), day.Day(), WorkdayEnd, 0, 0, 0, loc)
+	return start, end
+}
+
+// WorkingHoursOverlap is how long the working hours of a and b overlap on
+// the day of at. The workday of b can fall on the day before or after in
+// the time zone of a, the best of the three is kept. The offsets, and so
+// daylight saving time, are the ones of that day
+func WorkingHoursOverlap(a *time.Location, b *time.Location, at time.Time) time.Duration {
+	day := at.In(a)
+	startA, endA := workday(a, day)
+
+	best := time.Duration(0)
+	for _, shift := range []int{-1, 0, 1} {
+		startB, endB := workday(b, day.AddDate(0, 0, shift))
+		start, end := startA, endA
+		if startB.After(start) {
+			start = startB
+		}
+		if endB.Before(end) {
+			end = endB
+		}
+		if overlap := end.Sub(start); overlap > best {
+			best = overlap
+		}
+	}
+	return best
+}
+
+// OverlapHours is the working hours overlap of a and b on the day of at,
+// in whole hours
+func OverlapHours(a *time.Location, b *time.Location, at time.Time) int {
+	return int(WorkingHoursOverlap(a, b, at) / time.Hour)
+}
@@ -0,0 +1,39 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkingHoursOverlap(t *testing.T) {
+	load := func(name string) *time.Location {
+		loc, err := time.LoadLocation(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return loc
+	}
+	berlin, newYork, tokyo, losAngeles := load("Europe/Berlin"), load("America/New_York"), load("Asia/Tokyo"), load("America/Los_Angeles")
+	winter := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+
+	t.Run("should be the whole workday in the same zone", func(t *testing.T) {
+		assert.Equal(t, 8*time.Hour, WorkingHoursOverlap(berlin, berlin, winter))
+	})
+
+	t.Run("should follow the offsets of the day", func(t *testing.T) {
+		assert.Equal(t, 2, OverlapHours(berlin, newYork, winter))
+		// New York moves to summer time two weeks before Berlin
+		assert.Equal(t, 3, OverlapHours(berlin, newYork, time.Date(2024, 3, 20, 12, 0, 0, 0, time.UTC)))
+	})
+
+	t.Run("should compare with the workday of the next or previous day", func(t *testing.T) {
+		assert.Equal(t, 1, OverlapHours(tokyo, losAngeles, winter))
+		assert.Equal(t, OverlapHours(tokyo, losAngeles, winter), OverlapHours(losAngeles, tokyo, winter))
+	})
+
+	t.Run("should be zero on opposite sides of the world", func(t *testing.T) {
+		assert.Zero(t, WorkingHoursOverlap(berlin, load("Pacific/Auckland"), winter))
+	})
+}
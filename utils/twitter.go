@@ -4,11 +4,11 @@ package utils
 
 import (
 	"errors"
-	"os"
 	"strings"
 
 	"github.com/imroc/req"
 	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/config"
 )
 
 func ConfirmIdentityTweet(username string) (string, error) {
@@ -37,7 +37,7 @@ type LookupUserIDResponse struct {
 
 func LookupUserID(username string) (string, error) {
 
-	twitterToken := os.Getenv("TWITTER_TOKEN")
+	twitterToken := config.Get().TwitterToken
 	if twitterToken == "" {
 		return "", errors.New("no twitter token")
 	}
@@ -78,7 +78,7 @@ type LookupUserTweetResponse struct {
 
 func LookupUserTweet(userID string) (string, error) {
 
-	twitterToken := os.Getenv("TWITTER_TOKEN")
+	twitterToken := config.Get().TwitterToken
 	if twitterToken == "" {
 		return "", errors.New("no twitter token")
 	}
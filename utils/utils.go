@@ -18,6 +18,7 @@ func GetPaginationParams(r *http.Request) (int, int, string, string, string) {
 	sortBy := keys.Get("sortBy")
 	direction := keys.Get("direction")
 	search := keys.Get("search")
+	cursor := keys.Get("cursor")
 
 	// convert string to int
 	intPage, _ := strconv.Atoi(page)
@@ -43,6 +44,10 @@ func GetPaginationParams(r *http.Request) (int, int, string, string, string) {
 		// so that all results arent replaced, a "page shifting" effect
 		offset = (intPage - 1) * intLimit
 	}
+	// a cursor from a list envelope takes over from page
+	if cursorOffset, ok := DecodeCursor(cursor); ok {
+		offset = cursorOffset
+	}
 
 	return offset, intLimit, sortBy, direction, search
 }
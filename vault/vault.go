@@ -0,0 +1,176 @@
+// Package vault seals the third-party secrets of workspaces with envelope
+// encryption. Every secret is encrypted with a data key of its own, and the
+// data key is encrypted with the master key of SECRETS_MASTER_KEY, so only
+// ciphertexts reach the database. Rotating the master key only rewraps the
+// data keys: SECRETS_PREVIOUS_MASTER_KEY keeps opening the secrets sealed
+// with the old key until Rewrap moves them to the new one.
+package vault
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"io"
+
+	"github.com/stakwork/sphinx-tribes/config"
+)
+
+// ErrNotConfigured is the error of the vault without SECRETS_MASTER_KEY
+var ErrNotConfigured = errors.New("SECRETS_MASTER_KEY is not set")
+
+// ErrUnknownKey is the error of a secret sealed with a master key that is
+// neither the current nor the previous one
+var ErrUnknownKey = errors.New("the master key of the secret is not configured")
+
+// Sealed is a value encrypted with its data key, with the data key
+// encrypted with the master key KeyId names
+type Sealed struct {
+	Ciphertext []byte
+	DataKey    []byte
+	KeyId      string
+}
+
+// KeyId names a master key without revealing it
+func KeyId(masterKey []byte) string {
+	sum := sha256.Sum256(masterKey)
+	return hex.EncodeToString(sum[:4])
+}
+
+// masterKeys are the configured master keys by id, with the id of the key
+// new secrets are sealed with. The config validates the keys
+func masterKeys() (map[string][]byte, string) {
+	cfg := config.Get()
+	keys := map[string][]byte{}
+	current := ""
+	for i, encoded := range []string{cfg.SecretsMasterKey, cfg.SecretsPreviousMasterKey} {
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if encoded == "" || err != nil {
+			continue
+		}
+		id := KeyId(key)
+		keys[id] = key
+		if i == 0 {
+			current = id
+		}
+	}
+	return keys, current
+}
+
+// Configured reports if secrets can be sealed
+func Configured() bool {
+	_, current := masterKeys()
+	return current != ""
+}
+
+// CurrentKeyId is the id of the master key new secrets are sealed with,
+// empty when the vault is not configured
+func CurrentKeyId() string {
+	_, current := masterKeys()
+	return current
+}
+
+// Seal encrypts a value with a new data key, sealed with the current
+// master key
+func Seal(plaintext string) (Sealed, error) {
+	keys, current := masterKeys()
+	if current == "" {
+		return Sealed{}, ErrNotConfigured
+	}
+	dataKey := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, dataKey); err != nil {
+		return Sealed{}, err
+	}
+	ciphertext, err := encrypt(dataKey, []byte(plaintext))
+	if err != nil {
+		return Sealed{}, err
+	}
+	sealedKey, err := encrypt(keys[current], dataKey)
+	if err != nil {
+		return Sealed{}, err
+	}
+	return Sealed{Ciphertext: ciphertext, DataKey: sealedKey, KeyId: current}, nil
+}
+
+// Open decrypts a sealed value with the master key it was sealed with
+func Open(sealed Sealed) (string, error) {
+	dataKey, err := openDataKey(sealed)
+	if err != nil {
+		return "", err
+	}
+	plaintext, err := decrypt(dataKey, sealed.Ciphertext)
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
+
+// Rewrap seals the data key of a value with the current master key, the
+// value itself is not encrypted again. It returns false when the value is
+// already sealed with the current master key
+func Rewrap(sealed Sealed) (Sealed, bool, error) {
+	keys, current := masterKeys()
+	if current == "" {
+		return sealed, false, ErrNotConfigured
+	}
+	if sealed.KeyId == current {
+		return sealed, false, nil
+	}
+	dataKey, err := openDataKey(sealed)
+	if err != nil {
+		return sealed, false, err
+	}
+	sealedKey, err := encrypt(keys[current], dataKey)
+	if err != nil {
+		return sealed, false, err
+	}
+	return Sealed{Ciphertext: sealed.Ciphertext, DataKey: sealedKey, KeyId: current}, true, nil
+}
+
+func openDataKey(sealed Sealed) ([]byte, error) {
+	keys, current := masterKeys()
+	if current == "" {
+		return nil, ErrNotConfigured
+	}
+	masterKey, ok := keys[sealed.KeyId]
+	if !ok {
+		return nil, ErrUnknownKey
+	}
+	return decrypt(masterKey, sealed.DataKey)
+}
+
+// encrypt is AES-256-GCM with the nonce ahead of the ciphertext
+func encrypt(key []byte, plaintext []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	return gcm.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+func decrypt(key []byte, ciphertext []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(ciphertext) < gcm.NonceSize() {
+		return nil, errors.New("ciphertext is too short")
+	}
+	nonce, ciphertext := ciphertext[:gcm.NonceSize()], ciphertext[gcm.NonceSize():]
+	return gcm.Open(nil, nonce, ciphertext, nil)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
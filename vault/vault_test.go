@@ -0,0 +1,80 @@
+package vault
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVault(t *testing.T) {
+	oldKey := base64.StdEncoding.EncodeToString([]byte(strings.Repeat("o", 32)))
+	newKey := base64.StdEncoding.EncodeToString([]byte(strings.Repeat("n", 32)))
+	t.Setenv("RELAY_AUTH_KEY", "relay-key")
+	t.Setenv("RELAY_URL", "http://localhost:3001")
+	defer func() {
+		t.Setenv("SECRETS_MASTER_KEY", "")
+		t.Setenv("SECRETS_PREVIOUS_MASTER_KEY", "")
+		config.InitConfig()
+	}()
+
+	t.Run("should not seal without a master key", func(t *testing.T) {
+		config.InitConfig()
+		assert.False(t, Configured())
+		_, err := Seal("ghp_token")
+		assert.Equal(t, ErrNotConfigured, err)
+	})
+
+	t.Setenv("SECRETS_MASTER_KEY", oldKey)
+	config.InitConfig()
+	sealed, err := Seal("ghp_token")
+	assert.NoError(t, err)
+
+	t.Run("should seal with a data key of its own", func(t *testing.T) {
+		assert.NotContains(t, string(sealed.Ciphertext), "ghp_token")
+		again, _ := Seal("ghp_token")
+		assert.NotEqual(t, sealed.DataKey, again.DataKey)
+		assert.NotEqual(t, sealed.Ciphertext, again.Ciphertext)
+
+		value, err := Open(sealed)
+		assert.NoError(t, err)
+		assert.Equal(t, "ghp_token", value)
+	})
+
+	t.Run("should not open a tampered value", func(t *testing.T) {
+		tampered := sealed
+		tampered.Ciphertext = append([]byte{}, sealed.Ciphertext...)
+		tampered.Ciphertext[len(tampered.Ciphertext)-1] ^= 1
+		_, err := Open(tampered)
+		assert.Error(t, err)
+	})
+
+	t.Run("should rewrap the data key on a new master key", func(t *testing.T) {
+		t.Setenv("SECRETS_MASTER_KEY", newKey)
+		t.Setenv("SECRETS_PREVIOUS_MASTER_KEY", oldKey)
+		config.InitConfig()
+
+		value, err := Open(sealed)
+		assert.NoError(t, err)
+		assert.Equal(t, "ghp_token", value)
+
+		rewrapped, changed, err := Rewrap(sealed)
+		assert.NoError(t, err)
+		assert.True(t, changed)
+		assert.Equal(t, CurrentKeyId(), rewrapped.KeyId)
+		assert.Equal(t, sealed.Ciphertext, rewrapped.Ciphertext)
+
+		_, changed, _ = Rewrap(rewrapped)
+		assert.False(t, changed)
+
+		t.Setenv("SECRETS_PREVIOUS_MASTER_KEY", "")
+		config.InitConfig()
+		_, err = Open(sealed)
+		assert.Equal(t, ErrUnknownKey, err)
+		value, err = Open(rewrapped)
+		assert.NoError(t, err)
+		assert.Equal(t, "ghp_token", value)
+	})
+}
@@ -2,17 +2,47 @@ package websocket
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"sync"
+	"time"
 
 	"github.com/gorilla/websocket"
 	"github.com/stakwork/sphinx-tribes/db"
 )
 
+const (
+	// a client that doesn't answer a ping within pongWait is dropped
+	pongWait   = 60 * time.Second
+	pingPeriod = (pongWait * 9) / 10
+	writeWait  = 10 * time.Second
+	// messages waiting to be written to a client, a client that falls this
+	// far behind is disconnected
+	sendQueueSize = 64
+)
+
+var errSlowClient = errors.New("client is too slow, it was disconnected")
+
 type Client struct {
 	Host string
-	Conn *websocket.Conn
-	Pool *Pool
+	// Pubkey is set when the connection was opened with a valid token
+	Pubkey string
+	Conn   *websocket.Conn
+	Pool   *Pool
+
+	queue     chan interface{}
+	closeOnce sync.Once
+}
+
+func NewClient(host string, pubkey string, conn *websocket.Conn, pool *Pool) *Client {
+	return &Client{
+		Host:   host,
+		Pubkey: pubkey,
+		Conn:   conn,
+		Pool:   pool,
+		queue:  make(chan interface{}, sendQueueSize),
+	}
 }
 
 type ClientData struct {
@@ -26,21 +56,61 @@ type Message struct {
 	Body string `json:"body"`
 }
 
+// clientAction is what a client sends to manage its topic subscriptions
+type clientAction struct {
+	Action string `json:"action"`
+	Topic  string `json:"topic"`
+}
+
+// WriteJSON queues v for the writer of the connection, it never waits on
+// the network. A client whose queue is full is disconnected so it can't
+// hold up the publishers
+func (c *Client) WriteJSON(v interface{}) error {
+	select {
+	case c.queue <- v:
+		return nil
+	default:
+		c.closeOnce.Do(func() {
+			fmt.Println("[websocket] dropping slow client", c.Host)
+			c.Conn.Close()
+		})
+		return errSlowClient
+	}
+}
+
+func (c *Client) send(message TopicMessage) error {
+	return c.WriteJSON(message)
+}
+
 func (c *Client) Read() {
+	done := make(chan struct{})
 	defer func() {
+		close(done)
 		c.Pool.Unregister <- c
 		c.Conn.Close()
 		db.Store.DeleteCache(c.Host)
 	}()
 
+	c.Conn.SetReadDeadline(time.Now().Add(pongWait))
+	c.Conn.SetPongHandler(func(string) error {
+		return c.Conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+	go c.write(done)
+
 	for {
-		var socketMsg db.LnHost
 		messageType, p, err := c.Conn.ReadMessage()
 		if err != nil {
 			log.Println(err)
 			return
 		}
 
+		action := clientAction{}
+		if err := json.Unmarshal(p, &action); err == nil && action.Action != "" {
+			c.handleAction(action)
+			continue
+		}
+
+		var socketMsg db.LnHost
 		err = json.Unmarshal(p, &socketMsg)
 		if err != nil {
 			fmt.Println("Message Decode Error", err, string(p))
@@ -51,3 +121,44 @@ func (c *Client) Read() {
 		c.Pool.Broadcast <- message
 	}
 }
+
+// write sends the queued messages and the pings of the connection until
+// Read returns. A failed write closes the connection, which ends Read
+func (c *Client) write(done chan struct{}) {
+	ticker := time.NewTicker(pingPeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case v := <-c.queue:
+			c.Conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := c.Conn.WriteJSON(v); err != nil {
+				fmt.Println("[websocket] write error", err)
+				c.Conn.Close()
+				return
+			}
+		case <-ticker.C:
+			if err := c.Conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(writeWait)); err != nil {
+				c.Conn.Close()
+				return
+			}
+		}
+	}
+}
+
+func (c *Client) handleAction(action clientAction) {
+	switch action.Action {
+	case "subscribe":
+		if err := c.Pool.Subscribe(c, action.Topic); err != nil {
+			c.WriteJSON(TopicMessage{Topic: action.Topic, Msg: "subscribe_error", Data: err.Error()})
+			return
+		}
+		c.WriteJSON(TopicMessage{Topic: action.Topic, Msg: "subscribed"})
+	case "unsubscribe":
+		c.Pool.Unsubscribe(c, action.Topic)
+		c.WriteJSON(TopicMessage{Topic: action.Topic, Msg: "unsubscribed"})
+	default:
+		c.WriteJSON(TopicMessage{Topic: action.Topic, Msg: "unknown_action", Data: action.Action})
+	}
+}
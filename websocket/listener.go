@@ -0,0 +1,47 @@
+package websocket
+
+// Listener receives topic messages on a channel, it backs the server-sent
+// events stream. Messages are dropped when the channel is full so a slow
+// stream never blocks a publisher
+type Listener struct {
+	Messages chan TopicMessage
+	topics   []string
+}
+
+func (l *Listener) send(message TopicMessage) error {
+	select {
+	case l.Messages <- message:
+	default:
+	}
+	return nil
+}
+
+// Listen subscribes a listener to topics after checking each of them for
+// pubkey. When lastEventID is set it also returns the kept messages
+// published after that event so a reconnecting stream can catch up
+func (pool *Pool) Listen(pubkey string, topics []string, lastEventID string) (*Listener, []TopicMessage, error) {
+	for _, topic := range topics {
+		if err := CanSubscribe(pubkey, topic); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	listener := &Listener{Messages: make(chan TopicMessage, topicHistorySize), topics: topics}
+	for _, topic := range topics {
+		pool.addSubscriber(listener, topic)
+	}
+
+	missed := []TopicMessage{}
+	if lastEventID != "" {
+		missed = pool.since(topics, lastEventID)
+	}
+	return listener, missed, nil
+}
+
+func (pool *Pool) StopListening(listener *Listener) {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+	for _, topic := range listener.topics {
+		pool.removeSubscriber(listener, topic)
+	}
+}
@@ -2,6 +2,7 @@ package websocket
 
 import (
 	"fmt"
+	"sync"
 
 	"github.com/stakwork/sphinx-tribes/db"
 )
@@ -11,6 +12,11 @@ type Pool struct {
 	Unregister chan *Client
 	Clients    map[string]*ClientData
 	Broadcast  chan Message
+
+	mu      sync.RWMutex
+	topics  map[string]map[subscriber]bool
+	history map[string][]TopicMessage
+	bridge  *redisBridge
 }
 
 func NewPool() *Pool {
@@ -19,6 +25,8 @@ func NewPool() *Pool {
 		Unregister: make(chan *Client),
 		Clients:    make(map[string]*ClientData),
 		Broadcast:  make(chan Message),
+		topics:     make(map[string]map[subscriber]bool),
+		history:    make(map[string][]TopicMessage),
 	}
 }
 
@@ -32,27 +40,27 @@ func (pool *Pool) Start() {
 			}
 			fmt.Println("Size of Websocket Connection Pool: ", len(pool.Clients))
 			err := db.Store.SetSocketConnections(db.Client{
-				Host: client.Host,
-				Conn: client.Conn,
+				Host:   client.Host,
+				Writer: client,
 			})
 			if err == nil {
-				pool.Clients[client.Host].Client.Conn.WriteJSON(Message{Type: 1, Msg: "user_connect", Body: client.Host})
+				client.WriteJSON(Message{Type: 1, Msg: "user_connect", Body: client.Host})
 				go client.Read()
 			} else {
 				fmt.Println("Websocket pool client save error")
 			}
 			break
 		case client := <-pool.Unregister:
-			pool.Clients[client.Host].Client.Conn.WriteJSON(Message{Type: 1, Body: "User Disconnected..."})
+			pool.unsubscribeAll(client)
+			client.WriteJSON(Message{Type: 1, Body: "User Disconnected..."})
 			delete(pool.Clients, client.Host)
 			fmt.Println("Size of Connection Pool: ", len(pool.Clients))
 			break
 		case message := <-pool.Broadcast:
 			fmt.Println("Sending message to all clients in Pool")
 			for client, _ := range pool.Clients {
-				if err := pool.Clients[client].Client.Conn.WriteJSON(message); err != nil {
+				if err := pool.Clients[client].Client.WriteJSON(message); err != nil {
 					fmt.Println(err)
-					return
 				}
 			}
 		}
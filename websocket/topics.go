@@ -0,0 +1,275 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/xid"
+	"github.com/stakwork/sphinx-tribes/db"
+)
+
+// Topic kinds a client can subscribe to, a topic is "<kind>:<id>"
+const (
+	TopicWorkspace = "workspace"
+	TopicBounty    = "bounty"
+	TopicTicket    = "ticket"
+	TopicPayment   = "payment"
+	TopicUser      = "user"
+	TopicTribe     = "tribe"
+)
+
+// number of recent messages kept per topic so event streams can resume
+const topicHistorySize = 100
+
+// TopicMessage is what subscribers of a topic receive. Published messages
+// get a sortable ID that streams use to resume
+type TopicMessage struct {
+	ID    string      `json:"id,omitempty"`
+	Topic string      `json:"topic"`
+	Msg   string      `json:"msg"`
+	Data  interface{} `json:"data,omitempty"`
+}
+
+// subscriber is a websocket client or an event stream listener
+type subscriber interface {
+	send(message TopicMessage) error
+}
+
+func Topic(kind string, id interface{}) string {
+	return fmt.Sprintf("%s:%v", kind, id)
+}
+
+func parseTopic(topic string) (string, string, bool) {
+	parts := strings.SplitN(topic, ":", 2)
+	if len(parts) != 2 || parts[1] == "" {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
+
+// isWorkspaceMember is swapped in tests
+var isWorkspaceMember = func(pubkey string, uuid string) bool {
+	if db.DB.GetWorkspaceByUuid(uuid).OwnerPubKey == pubkey {
+		return true
+	}
+	return db.DB.GetWorkspaceUser(pubkey, uuid).ID != 0
+}
+
+// canSeeBounty is swapped in tests. A bounty of a workspace is open to the
+// members of the workspace, a bounty without one to its owner and assignee
+var canSeeBounty = func(pubkey string, id uint) bool {
+	bounty := db.DB.GetBounty(id)
+	if bounty.ID == 0 {
+		return false
+	}
+	if bounty.WorkspaceUuid != "" {
+		return isWorkspaceMember(pubkey, bounty.WorkspaceUuid)
+	}
+	return bounty.OwnerID == pubkey || bounty.Assignee == pubkey
+}
+
+// CanSubscribe checks a subscription. Tribes are public. Bounty and ticket
+// topics are keyed by bounty id and carry the ticket and proof updates of
+// its workspace, so they need an authenticated member of that workspace.
+// Workspace and payment topics are keyed by workspace uuid and need a member
+// too, user topics are keyed by pubkey and only open to that user
+func CanSubscribe(pubkey string, topic string) error {
+	kind, id, ok := parseTopic(topic)
+	if !ok {
+		return errors.New("invalid topic")
+	}
+
+	switch kind {
+	case TopicTribe:
+		return nil
+	case TopicBounty, TopicTicket:
+		if pubkey == "" {
+			return errors.New("topic needs an authenticated connection")
+		}
+		bountyID, err := strconv.ParseUint(id, 10, 32)
+		if err != nil {
+			return errors.New("invalid topic")
+		}
+		if !canSeeBounty(pubkey, uint(bountyID)) {
+			return errors.New("not a member of the workspace of the bounty")
+		}
+		return nil
+	case TopicWorkspace, TopicPayment:
+		if pubkey == "" {
+			return errors.New("topic needs an authenticated connection")
+		}
+		if !isWorkspaceMember(pubkey, id) {
+			return errors.New("not a member of the workspace")
+		}
+		return nil
+	case TopicUser:
+		if pubkey == "" || pubkey != id {
+			return errors.New("topic is only open to its user")
+		}
+		return nil
+	}
+	return errors.New("unknown topic")
+}
+
+func (pool *Pool) Subscribe(client *Client, topic string) error {
+	if err := CanSubscribe(client.Pubkey, topic); err != nil {
+		return err
+	}
+	pool.addSubscriber(client, topic)
+	return nil
+}
+
+func (pool *Pool) Unsubscribe(client *Client, topic string) {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+	pool.removeSubscriber(client, topic)
+}
+
+func (pool *Pool) addSubscriber(s subscriber, topic string) {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+	if pool.topics[topic] == nil {
+		pool.topics[topic] = map[subscriber]bool{}
+	}
+	pool.topics[topic][s] = true
+}
+
+// removeSubscriber expects pool.mu to be held
+func (pool *Pool) removeSubscriber(s subscriber, topic string) {
+	delete(pool.topics[topic], s)
+	if len(pool.topics[topic]) == 0 {
+		delete(pool.topics, topic)
+	}
+}
+
+func (pool *Pool) unsubscribeAll(s subscriber) {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+	for topic := range pool.topics {
+		pool.removeSubscriber(s, topic)
+	}
+}
+
+func (pool *Pool) subscribers(topic string) []subscriber {
+	pool.mu.RLock()
+	defer pool.mu.RUnlock()
+	subscribers := make([]subscriber, 0, len(pool.topics[topic]))
+	for s := range pool.topics[topic] {
+		subscribers = append(subscribers, s)
+	}
+	return subscribers
+}
+
+// Publish sends msg to the subscribers of topic on this instance and, when
+// the redis bridge is running, on every other instance
+func (pool *Pool) Publish(topic string, msg string, data interface{}) {
+	message := TopicMessage{ID: xid.New().String(), Topic: topic, Msg: msg, Data: data}
+	pool.deliver(message)
+	if pool.bridge != nil {
+		pool.bridge.publish(message)
+	}
+}
+
+func (pool *Pool) deliver(message TopicMessage) {
+	pool.remember(message)
+	for _, s := range pool.subscribers(message.Topic) {
+		if err := s.send(message); err != nil {
+			fmt.Println("[websocket] publish error", err)
+		}
+	}
+}
+
+func (pool *Pool) remember(message TopicMessage) {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+	history := append(pool.history[message.Topic], message)
+	if len(history) > topicHistorySize {
+		history = history[len(history)-topicHistorySize:]
+	}
+	pool.history[message.Topic] = history
+}
+
+// since returns the kept messages of the topics published after the message
+// with lastID, oldest first
+func (pool *Pool) since(topics []string, lastID string) []TopicMessage {
+	pool.mu.RLock()
+	defer pool.mu.RUnlock()
+	messages := []TopicMessage{}
+	for _, topic := range topics {
+		for _, message := range pool.history[topic] {
+			if message.ID > lastID {
+				messages = append(messages, message)
+			}
+		}
+	}
+	sort.Slice(messages, func(i, j int) bool { return messages[i].ID < messages[j].ID })
+	return messages
+}
+
+// Publish sends a topic message through the default pool
+func Publish(topic string, msg string, data interface{}) {
+	WebsocketPool.Publish(topic, msg, data)
+}
+
+const redisBridgeChannel = "websocket:topics"
+
+// redisBridge fans topic messages out to the other backend instances, each
+// instance skips the messages it published itself
+type redisBridge struct {
+	client   *redis.Client
+	instance string
+}
+
+type bridgedMessage struct {
+	Instance string       `json:"instance"`
+	Message  TopicMessage `json:"message"`
+}
+
+func (b *redisBridge) publish(message TopicMessage) {
+	payload, err := json.Marshal(bridgedMessage{Instance: b.instance, Message: message})
+	if err != nil {
+		fmt.Println("[websocket] bridge encode error", err)
+		return
+	}
+	if err := b.client.Publish(context.Background(), redisBridgeChannel, payload).Err(); err != nil {
+		fmt.Println("[websocket] bridge publish error", err)
+	}
+}
+
+// StartRedisBridge relays topic messages between instances through redis
+// pub/sub. It does nothing when redis is not configured
+func (pool *Pool) StartRedisBridge(ctx context.Context) {
+	if db.RedisClient == nil || db.RedisError != nil {
+		return
+	}
+
+	bridge := &redisBridge{client: db.RedisClient, instance: xid.New().String()}
+	sub := bridge.client.Subscribe(ctx, redisBridgeChannel)
+	if _, err := sub.Receive(ctx); err != nil {
+		fmt.Println("[websocket] could not start the redis bridge", err)
+		return
+	}
+	pool.bridge = bridge
+	fmt.Println("[websocket] redis bridge started")
+
+	go func() {
+		defer sub.Close()
+		for msg := range sub.Channel() {
+			bridged := bridgedMessage{}
+			if err := json.Unmarshal([]byte(msg.Payload), &bridged); err != nil {
+				fmt.Println("[websocket] bridge decode error", err)
+				continue
+			}
+			if bridged.Instance == bridge.instance {
+				continue
+			}
+			pool.deliver(bridged.Message)
+		}
+	}()
+}
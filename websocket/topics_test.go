@@ -0,0 +1,204 @@
+package websocket
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stretchr/testify/assert"
+)
+
+func dialPool(t *testing.T, pool *Pool) *websocket.Conn {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ServeWs(pool, w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	// the pool greets every new connection
+	connected := Message{}
+	assert.NoError(t, conn.ReadJSON(&connected))
+	assert.Equal(t, "user_connect", connected.Msg)
+	return conn
+}
+
+func readTopicMessage(t *testing.T, conn *websocket.Conn) TopicMessage {
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	message := TopicMessage{}
+	if err := conn.ReadJSON(&message); err != nil {
+		t.Fatal(err)
+	}
+	return message
+}
+
+func TestTopicSubscriptions(t *testing.T) {
+	db.InitCache()
+	pool := NewPool()
+	go pool.Start()
+
+	t.Run("should deliver published messages to subscribers only", func(t *testing.T) {
+		subscriber := dialPool(t, pool)
+		other := dialPool(t, pool)
+
+		topic := Topic(TopicTribe, "tribe-1")
+		assert.NoError(t, subscriber.WriteJSON(clientAction{Action: "subscribe", Topic: topic}))
+		assert.Equal(t, TopicMessage{Topic: topic, Msg: "subscribed"}, readTopicMessage(t, subscriber))
+
+		pool.Publish(topic, "tribe_announcement", "tribe data")
+		message := readTopicMessage(t, subscriber)
+		assert.NotEmpty(t, message.ID)
+		assert.Equal(t, TopicMessage{ID: message.ID, Topic: topic, Msg: "tribe_announcement", Data: "tribe data"}, message)
+
+		other.SetReadDeadline(time.Now().Add(100 * time.Millisecond))
+		_, _, err := other.ReadMessage()
+		assert.Error(t, err)
+
+		assert.NoError(t, subscriber.WriteJSON(clientAction{Action: "unsubscribe", Topic: topic}))
+		assert.Equal(t, TopicMessage{Topic: topic, Msg: "unsubscribed"}, readTopicMessage(t, subscriber))
+		assert.Empty(t, pool.subscribers(topic))
+	})
+
+	t.Run("should refuse workspace topics on unauthenticated connections", func(t *testing.T) {
+		conn := dialPool(t, pool)
+
+		topic := Topic(TopicWorkspace, "workspace-uuid")
+		assert.NoError(t, conn.WriteJSON(clientAction{Action: "subscribe", Topic: topic}))
+
+		message := readTopicMessage(t, conn)
+		assert.Equal(t, "subscribe_error", message.Msg)
+		assert.Equal(t, "topic needs an authenticated connection", message.Data)
+	})
+}
+
+func TestSlowClient(t *testing.T) {
+	conns := make(chan *websocket.Conn, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := Upgrade(w, r)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		conns <- conn
+	}))
+	t.Cleanup(server.Close)
+
+	peer, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { peer.Close() })
+
+	t.Run("should disconnect a client whose queue is full without blocking the publisher", func(t *testing.T) {
+		pool := NewPool()
+		// the writer of the client is not started, nothing leaves its queue
+		client := NewClient("slow-client", "", <-conns, pool)
+		topic := Topic(TopicTribe, "tribe-1")
+		assert.NoError(t, pool.Subscribe(client, topic))
+
+		for i := 0; i < sendQueueSize; i++ {
+			assert.NoError(t, client.send(TopicMessage{Topic: topic, Msg: "tribe_announcement", Data: i}))
+		}
+		assert.Equal(t, errSlowClient, client.send(TopicMessage{Topic: topic, Msg: "tribe_announcement"}))
+		pool.Publish(topic, "tribe_announcement", "one more")
+
+		peer.SetReadDeadline(time.Now().Add(5 * time.Second))
+		_, _, err := peer.ReadMessage()
+		// the connection was closed, the read did not time out
+		assert.Error(t, err)
+		assert.False(t, errors.Is(err, os.ErrDeadlineExceeded))
+	})
+}
+
+func TestCanSubscribe(t *testing.T) {
+	isMember := isWorkspaceMember
+	defer func() { isWorkspaceMember = isMember }()
+	isWorkspaceMember = func(pubkey string, uuid string) bool {
+		return pubkey == "member" && uuid == "workspace-uuid"
+	}
+	canSee := canSeeBounty
+	defer func() { canSeeBounty = canSee }()
+	canSeeBounty = func(pubkey string, id uint) bool {
+		return isWorkspaceMember(pubkey, "workspace-uuid") && id == 12
+	}
+
+	assert.NoError(t, CanSubscribe("member", Topic(TopicBounty, 12)))
+	assert.NoError(t, CanSubscribe("member", Topic(TopicTicket, 12)))
+	assert.Error(t, CanSubscribe("", Topic(TopicBounty, 12)))
+	assert.Error(t, CanSubscribe("stranger", Topic(TopicTicket, 12)))
+	assert.Error(t, CanSubscribe("member", Topic(TopicBounty, 13)))
+	assert.Error(t, CanSubscribe("member", Topic(TopicTicket, "ticket-uuid")))
+	assert.NoError(t, CanSubscribe("", Topic(TopicTribe, "tribe-uuid")))
+	assert.NoError(t, CanSubscribe("member", Topic(TopicWorkspace, "workspace-uuid")))
+	assert.NoError(t, CanSubscribe("member", Topic(TopicPayment, "workspace-uuid")))
+
+	assert.Error(t, CanSubscribe("stranger", Topic(TopicWorkspace, "workspace-uuid")))
+	assert.Error(t, CanSubscribe("", Topic(TopicPayment, "workspace-uuid")))
+	assert.NoError(t, CanSubscribe("member", Topic(TopicUser, "member")))
+	assert.Error(t, CanSubscribe("stranger", Topic(TopicUser, "member")))
+	assert.Error(t, CanSubscribe("", Topic(TopicUser, "member")))
+	assert.Error(t, CanSubscribe("member", "chat:1"))
+	assert.Error(t, CanSubscribe("member", "bounty:"))
+}
+
+func TestListen(t *testing.T) {
+	pool := NewPool()
+	topic := Topic(TopicTribe, "tribe-1")
+	other := Topic(TopicTribe, "tribe-2")
+
+	t.Run("should stream published messages to listeners", func(t *testing.T) {
+		listener, missed, err := pool.Listen("", []string{topic}, "")
+		assert.NoError(t, err)
+		assert.Empty(t, missed)
+
+		pool.Publish(other, "tribe_announcement", "other tribe")
+		pool.Publish(topic, "tribe_announcement", "tribe data")
+
+		message := <-listener.Messages
+		assert.Equal(t, "tribe data", message.Data)
+		assert.Len(t, listener.Messages, 0)
+
+		pool.StopListening(listener)
+		assert.Empty(t, pool.subscribers(topic))
+	})
+
+	t.Run("should replay messages published after the last event id", func(t *testing.T) {
+		pool.Publish(topic, "tribe_announcement", "first")
+		seen := pool.history[topic][len(pool.history[topic])-1]
+		pool.Publish(other, "tribe_announcement", "second")
+		pool.Publish(topic, "tribe_announcement", "third")
+
+		listener, missed, err := pool.Listen("", []string{topic, other}, seen.ID)
+		assert.NoError(t, err)
+		defer pool.StopListening(listener)
+
+		assert.Len(t, missed, 2)
+		assert.Equal(t, "second", missed[0].Data)
+		assert.Equal(t, "third", missed[1].Data)
+	})
+
+	t.Run("should refuse topics the pubkey can not subscribe to", func(t *testing.T) {
+		listener, _, err := pool.Listen("", []string{topic, Topic(TopicPayment, "workspace-uuid")}, "")
+		assert.Error(t, err)
+		assert.Nil(t, listener)
+		assert.Empty(t, pool.subscribers(topic))
+	})
+
+	t.Run("should keep a bounded history per topic", func(t *testing.T) {
+		for i := 0; i < topicHistorySize+10; i++ {
+			pool.Publish(topic, "tribe_announcement", i)
+		}
+		assert.Len(t, pool.history[topic], topicHistorySize)
+		assert.Equal(t, topicHistorySize+9, pool.history[topic][topicHistorySize-1].Data)
+	})
+}
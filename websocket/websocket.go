@@ -6,6 +6,7 @@ import (
 	"net/http"
 
 	"github.com/gorilla/websocket"
+	"github.com/stakwork/sphinx-tribes/auth"
 	"github.com/stakwork/sphinx-tribes/config"
 	"github.com/stakwork/sphinx-tribes/utils"
 )
@@ -41,12 +42,29 @@ func ServeWs(pool *Pool, w http.ResponseWriter, r *http.Request) {
 	conn, err := Upgrade(w, r)
 	if err != nil {
 		fmt.Fprintf(w, "%+v\n", err)
+		return
 	}
 
-	client := &Client{
-		Host: websocketToken,
-		Conn: conn,
-		Pool: pool,
-	}
+	client := NewClient(websocketToken, ConnectionPubkey(r), conn, pool)
 	pool.Register <- client
 }
+
+// ConnectionPubkey authenticates the connection from the token query param
+// or the x-jwt header. Connections without a token are still accepted, they
+// are needed for the login flow, but can only subscribe to public topics
+func ConnectionPubkey(r *http.Request) string {
+	token := r.URL.Query().Get("token")
+	if token == "" {
+		token = r.Header.Get("x-jwt")
+	}
+	if token == "" {
+		return ""
+	}
+
+	pubkey, err := auth.PubKeyFromToken(token)
+	if err != nil {
+		fmt.Println("[websocket] invalid token", err)
+		return ""
+	}
+	return pubkey
+}